@@ -0,0 +1,20 @@
+package cmd
+
+import "github.com/urfave/cli/v2"
+
+// NewApp creates the cannon app with all of its commands.
+func NewApp() *cli.App {
+	app := cli.NewApp()
+	app.Name = "cannon"
+	app.Usage = "MIPS Fault Proof tool"
+	app.Description = "MIPS Fault Proof tool"
+	app.Commands = []*cli.Command{
+		LoadELFCommand,
+		WitnessCommand,
+		RunCommand,
+		AuditCommand,
+		PrestateInfoCommand,
+		SymbolizeCommand,
+	}
+	return app
+}
@@ -0,0 +1,274 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	AuditSnapshotDirFlag = &cli.PathFlag{
+		Name:      "snapshot-dir",
+		Usage:     "directory with the state snapshots of a completed run",
+		TakesFile: true,
+		Required:  true,
+	}
+	AuditSnapshotFmtFlag = &cli.StringFlag{
+		Name:     "snapshot-fmt",
+		Usage:    "format of the snapshot file names, as used by the run.",
+		Value:    "state-%d.json",
+		Required: false,
+	}
+	AuditSamplesFlag = &cli.UintFlag{
+		Name:     "samples",
+		Usage:    "number of random steps to audit",
+		Value:    10,
+		Required: false,
+	}
+	AuditSeedFlag = &cli.Int64Flag{
+		Name:     "seed",
+		Usage:    "seed to pick the random steps with, to reproduce an audit. Random if 0.",
+		Required: false,
+	}
+)
+
+var ErrStateMismatch = errors.New("state does not match the snapshot")
+
+type snapshotFile struct {
+	step uint64
+	path string
+}
+
+// listSnapshots finds the snapshots in dir with a name matching the format, ordered by step.
+func listSnapshots(dir string, format string) ([]snapshotFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot dir %v: %w", dir, err)
+	}
+	var snapshots []snapshotFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		var step uint64
+		if n, err := fmt.Sscanf(entry.Name(), format, &step); err != nil || n != 1 || fmt.Sprintf(format, step) != entry.Name() {
+			continue
+		}
+		snapshots = append(snapshots, snapshotFile{step: step, path: filepath.Join(dir, entry.Name())})
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].step < snapshots[j].step
+	})
+	return snapshots, nil
+}
+
+// pickSegments picks random steps between the first and the last snapshot,
+// and returns the indices of the snapshots that precede them, in order and without duplicates.
+// Longer segments between snapshots are thus more likely to be picked.
+func pickSegments(snapshots []snapshotFile, samples uint, rng *rand.Rand) []int {
+	first, last := snapshots[0].step, snapshots[len(snapshots)-1].step
+	picked := make(map[int]struct{})
+	for i := uint(0); i < samples; i++ {
+		step := first + uint64(rng.Int63n(int64(last-first)))
+		// the last snapshot at or before the step
+		idx := sort.Search(len(snapshots), func(i int) bool {
+			return snapshots[i].step > step
+		}) - 1
+		picked[idx] = struct{}{}
+	}
+	out := make([]int, 0, len(picked))
+	for idx := range picked {
+		out = append(out, idx)
+	}
+	sort.Ints(out)
+	return out
+}
+
+func loadState(vmType VMType, path string) (mipsevm.FPVMState, error) {
+	switch vmType {
+	case cannonVMType:
+		return jsonutil.LoadJSON[singlethreaded.State](path)
+	case mtVMType:
+		return jsonutil.LoadJSON[multithreaded.State](path)
+	default:
+		return nil, fmt.Errorf("invalid VM type: %q", vmType)
+	}
+}
+
+func newInstrumentedState(vmType VMType, path string, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, l log.Logger) (mipsevm.FPVM, error) {
+	switch vmType {
+	case cannonVMType:
+		return singlethreaded.NewInstrumentedStateFromFile(path, po, stdOut, stdErr, &program.Metadata{})
+	case mtVMType:
+		return multithreaded.NewInstrumentedStateFromFile(path, po, stdOut, stdErr, l)
+	default:
+		return nil, fmt.Errorf("invalid VM type: %q", vmType)
+	}
+}
+
+// auditSegment re-executes the steps from the pre snapshot to the post snapshot,
+// and checks that the resulting state matches the post snapshot.
+// It returns the verified state hash.
+func auditSegment(ctx *cli.Context, vmType VMType, pre, post snapshotFile, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, l log.Logger) (common.Hash, error) {
+	vm, err := newInstrumentedState(vmType, pre.path, po, stdOut, stdErr, l)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to load snapshot %v: %w", pre.path, err)
+	}
+	state := vm.GetState()
+	if state.GetStep() != pre.step {
+		return common.Hash{}, fmt.Errorf("snapshot %v is at step %d, expected %d", pre.path, state.GetStep(), pre.step)
+	}
+	for state.GetStep() < post.step && !state.GetExited() {
+		if state.GetStep()%100 == 0 {
+			if err := ctx.Context.Err(); err != nil {
+				return common.Hash{}, err
+			}
+		}
+		if _, err := vm.Step(false); err != nil {
+			return common.Hash{}, fmt.Errorf("failed at step %d (PC: %08x): %w", state.GetStep(), state.GetPC(), err)
+		}
+	}
+	_, got := state.EncodeWitness()
+	claimed, err := loadState(vmType, post.path)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to load snapshot %v: %w", post.path, err)
+	}
+	_, expected := claimed.EncodeWitness()
+	if got != expected {
+		return common.Hash{}, fmt.Errorf("%w: re-executed %d to step %d, got state %v, snapshot %v claims %v",
+			ErrStateMismatch, pre.step, state.GetStep(), got, post.path, expected)
+	}
+	return got, nil
+}
+
+// AuditResult is the output of the audit command in JSON format.
+type AuditResult struct {
+	Seed int64 `json:"seed"`
+	// Segments is the number of audited segments between snapshots.
+	Segments int `json:"segments"`
+	// Steps is the number of re-executed steps of the segments that matched.
+	Steps uint64 `json:"steps"`
+	// Mismatches are the steps of the snapshots that do not match the re-executed state.
+	Mismatches []uint64 `json:"mismatches"`
+	Passed     bool     `json:"passed"`
+}
+
+func Audit(ctx *cli.Context) error {
+	vmType, err := vmTypeFromString(ctx)
+	if err != nil {
+		return err
+	}
+	format, err := outputFormatFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+	l := FormatLogger(format, os.Stderr, log.LevelInfo).With("module", "audit")
+	// The guest output was already seen during the run, and is muted while re-executing
+	guestLogger := FormatLogger(format, os.Stderr, log.LevelWarn)
+	outLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stdout")}
+	errLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stderr")}
+
+	snapshots, err := listSnapshots(ctx.Path(AuditSnapshotDirFlag.Name), ctx.String(AuditSnapshotFmtFlag.Name))
+	if err != nil {
+		return err
+	}
+	if len(snapshots) < 2 {
+		return fmt.Errorf("need at least 2 snapshots to audit, found %d", len(snapshots))
+	}
+	seed := ctx.Int64(AuditSeedFlag.Name)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	l.Info("Auditing run", "snapshots", len(snapshots), "firstStep", snapshots[0].step,
+		"lastStep", snapshots[len(snapshots)-1].step, "samples", ctx.Uint(AuditSamplesFlag.Name), "seed", seed)
+	segments := pickSegments(snapshots, ctx.Uint(AuditSamplesFlag.Name), rand.New(rand.NewSource(seed)))
+
+	// split CLI args after first '--'
+	args := ctx.Args().Slice()
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	poOut, poErr := hostLoggers(format)
+	po, err := NewProcessPreimageOracle(args[0], args[1:], poOut, poErr)
+	if err != nil {
+		return fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	defer func() {
+		if err := po.Close(); err != nil {
+			l.Error("failed to close pre-image server", "err", err)
+		}
+	}()
+
+	mismatches := []uint64{}
+	steps := uint64(0)
+	for _, idx := range segments {
+		pre, post := snapshots[idx], snapshots[idx+1]
+		hash, err := auditSegment(ctx, vmType, pre, post, po, outLog, errLog, l)
+		if errors.Is(err, ErrStateMismatch) {
+			l.Error("Snapshot does not match the re-executed state", "from", pre.step, "to", post.step, "err", err)
+			mismatches = append(mismatches, post.step)
+			continue
+		} else if err != nil {
+			return fmt.Errorf("failed to audit steps %d to %d: %w", pre.step, post.step, err)
+		}
+		steps += post.step - pre.step
+		l.Info("Verified snapshot", "from", pre.step, "to", post.step, "hash", hash)
+	}
+	if format == outputFormatJSON {
+		if err := writeJSONResult(ctx.App.Writer, AuditResult{
+			Seed:       seed,
+			Segments:   len(segments),
+			Steps:      steps,
+			Mismatches: mismatches,
+			Passed:     len(mismatches) == 0,
+		}); err != nil {
+			return err
+		}
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("%w: snapshots at steps %v", ErrStateMismatch, mismatches)
+	}
+	l.Info("Audit passed", "segments", len(segments), "steps", steps, "seed", seed)
+	return nil
+}
+
+var AuditCommand = &cli.Command{
+	Name:  "audit",
+	Usage: "Spot-check the snapshots of a completed run",
+	Description: "Picks random steps of a completed run, re-executes them from the preceding snapshot, " +
+		"and verifies that the state hash of the next snapshot matches. " +
+		"The pre-image server of the run can be passed after a '--'.",
+	Action: Audit,
+	Flags: []cli.Flag{
+		VMTypeFlag,
+		AuditSnapshotDirFlag,
+		AuditSnapshotFmtFlag,
+		AuditSamplesFlag,
+		AuditSeedFlag,
+		OutputFormatFlag,
+	},
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// Tracks of the Chrome trace, as thread IDs of the single cannon process
+const (
+	chromeTraceFunctionsTrack = 1
+	chromeTraceSyscallsTrack  = 2
+	chromeTracePreimagesTrack = 3
+)
+
+// chromeTraceEvent is an event of the Chrome trace-event format, as loaded by Perfetto and chrome://tracing.
+type chromeTraceEvent struct {
+	Name string         `json:"name"`
+	Cat  string         `json:"cat,omitempty"`
+	Ph   string         `json:"ph"`
+	Ts   uint64         `json:"ts"`
+	Dur  uint64         `json:"dur,omitempty"`
+	Pid  int            `json:"pid"`
+	Tid  int            `json:"tid"`
+	Args map[string]any `json:"args,omitempty"`
+}
+
+// syscallSpan is a run of the same syscall, made repeatedly without other syscalls in between.
+type syscallSpan struct {
+	num   mipsevm.Word
+	fd    mipsevm.Word
+	start uint64
+	end   uint64 // inclusive
+	count uint64
+}
+
+// preimageSpan is the range of steps that read the same pre-image.
+type preimageSpan struct {
+	key   common.Hash
+	size  int
+	start uint64
+	end   uint64 // inclusive
+	reads uint64
+}
+
+// chromeTracer writes a Chrome trace of a run, with a track of the guest functions, if there is metadata,
+// a track of the syscalls, and a track of the pre-image reads.
+// Timestamps are steps: the trace shows one step per microsecond.
+// Repeated syscalls and reads of the same pre-image are merged into a single span,
+// and function spans shorter than the minimum number of steps are left out, to keep the trace of long runs loadable.
+type chromeTracer struct {
+	out      *bufio.Writer
+	enc      *json.Encoder
+	events   int
+	minSteps uint64
+
+	functions *program.StepRangeTracker
+	syscall   *syscallSpan
+	preimage  *preimageSpan
+}
+
+func newChromeTracer(meta *program.Metadata, out io.Writer, minSteps uint64) (*chromeTracer, error) {
+	buf := bufio.NewWriter(out)
+	t := &chromeTracer{out: buf, enc: json.NewEncoder(buf), minSteps: minSteps}
+	if _, err := buf.WriteString(`{"displayTimeUnit":"ns","traceEvents":[` + "\n"); err != nil {
+		return nil, fmt.Errorf("failed to write chrome trace: %w", err)
+	}
+	tracks := map[int]string{chromeTraceSyscallsTrack: "syscalls", chromeTracePreimagesTrack: "pre-images"}
+	if meta != nil && len(meta.Symbols) > 0 {
+		t.functions = program.NewStepRangeTracker(meta, t.writeFunction)
+		tracks[chromeTraceFunctionsTrack] = "functions"
+	}
+	if err := t.write(chromeTraceEvent{Name: "process_name", Ph: "M", Args: map[string]any{"name": "cannon"}}); err != nil {
+		return nil, err
+	}
+	for _, tid := range []int{chromeTraceFunctionsTrack, chromeTraceSyscallsTrack, chromeTracePreimagesTrack} {
+		name, ok := tracks[tid]
+		if !ok {
+			continue
+		}
+		if err := t.write(chromeTraceEvent{Name: "thread_name", Ph: "M", Tid: tid, Args: map[string]any{"name": name}}); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// observe records the next instruction of the state. It must be called before each step.
+func (t *chromeTracer) observe(state mipsevm.FPVMState) error {
+	step, pc := state.GetStep(), state.GetPC()
+	if t.functions != nil {
+		if err := t.functions.Record(step, pc); err != nil {
+			return err
+		}
+	}
+	if state.GetMemory().GetUint32(pc) != 0x0000000c { // syscall
+		return nil
+	}
+	regs := state.GetRegistersRef()
+	num, fd := regs[2], mipsevm.Word(0)
+	if num == exec.SysRead || num == exec.SysWrite {
+		fd = regs[4]
+	}
+	if s := t.syscall; s != nil && s.num == num && s.fd == fd {
+		s.end = step
+		s.count++
+		return nil
+	}
+	if err := t.flushSyscall(); err != nil {
+		return err
+	}
+	t.syscall = &syscallSpan{num: num, fd: fd, start: step, end: step, count: 1}
+	return nil
+}
+
+// observePreimage records that the step read from the pre-image with the given key.
+func (t *chromeTracer) observePreimage(step uint64, key [32]byte, value []byte) error {
+	if p := t.preimage; p != nil && p.key == key {
+		p.end = step
+		p.reads++
+		return nil
+	}
+	if err := t.flushPreimage(); err != nil {
+		return err
+	}
+	t.preimage = &preimageSpan{key: key, size: len(value), start: step, end: step, reads: 1}
+	return nil
+}
+
+// Close writes out the current spans, and completes the trace.
+func (t *chromeTracer) Close() error {
+	if t.functions != nil {
+		if err := t.functions.Flush(); err != nil {
+			return err
+		}
+	}
+	if err := t.flushSyscall(); err != nil {
+		return err
+	}
+	if err := t.flushPreimage(); err != nil {
+		return err
+	}
+	if _, err := t.out.WriteString("]}\n"); err != nil {
+		return fmt.Errorf("failed to write chrome trace: %w", err)
+	}
+	return t.out.Flush()
+}
+
+func (t *chromeTracer) writeFunction(r program.StepRange) error {
+	dur := r.End - r.Start + 1
+	if dur < t.minSteps {
+		return nil
+	}
+	return t.write(chromeTraceEvent{
+		Name: r.Function,
+		Cat:  "function",
+		Ph:   "X",
+		Ts:   r.Start,
+		Dur:  dur,
+		Tid:  chromeTraceFunctionsTrack,
+	})
+}
+
+func (t *chromeTracer) flushSyscall() error {
+	s := t.syscall
+	if s == nil {
+		return nil
+	}
+	t.syscall = nil
+	args := map[string]any{"num": s.num, "count": s.count}
+	name := syscallName(s.num)
+	if s.num == exec.SysRead || s.num == exec.SysWrite {
+		args["fd"] = s.fd
+		name = fmt.Sprintf("%s(%d)", name, s.fd)
+	}
+	return t.write(chromeTraceEvent{
+		Name: name,
+		Cat:  "syscall",
+		Ph:   "X",
+		Ts:   s.start,
+		Dur:  s.end - s.start + 1,
+		Tid:  chromeTraceSyscallsTrack,
+		Args: args,
+	})
+}
+
+func (t *chromeTracer) flushPreimage() error {
+	p := t.preimage
+	if p == nil {
+		return nil
+	}
+	t.preimage = nil
+	return t.write(chromeTraceEvent{
+		Name: preimageTypeName(p.key[0]),
+		Cat:  "preimage",
+		Ph:   "X",
+		Ts:   p.start,
+		Dur:  p.end - p.start + 1,
+		Tid:  chromeTracePreimagesTrack,
+		Args: map[string]any{"key": p.key, "size": p.size, "reads": p.reads},
+	})
+}
+
+func (t *chromeTracer) write(ev chromeTraceEvent) error {
+	if t.events > 0 {
+		if _, err := t.out.WriteString(","); err != nil {
+			return fmt.Errorf("failed to write chrome trace: %w", err)
+		}
+	}
+	// the encoder terminates each event with a newline
+	if err := t.enc.Encode(&ev); err != nil {
+		return fmt.Errorf("failed to write chrome trace event: %w", err)
+	}
+	t.events++
+	return nil
+}
+
+var syscallNames = map[mipsevm.Word]string{
+	exec.SysMmap:         "mmap",
+	exec.SysMunmap:       "munmap",
+	exec.SysBrk:          "brk",
+	exec.SysClone:        "clone",
+	exec.SysExitGroup:    "exit_group",
+	exec.SysRead:         "read",
+	exec.SysWrite:        "write",
+	exec.SysFcntl:        "fcntl",
+	exec.SysExit:         "exit",
+	exec.SysSchedYield:   "sched_yield",
+	exec.SysGetTID:       "gettid",
+	exec.SysFutex:        "futex",
+	exec.SysOpen:         "open",
+	exec.SysNanosleep:    "nanosleep",
+	exec.SysClockGetTime: "clock_gettime",
+}
+
+func syscallName(num mipsevm.Word) string {
+	if name, ok := syscallNames[num]; ok {
+		return name
+	}
+	return fmt.Sprintf("syscall %d", num)
+}
+
+func preimageTypeName(keyType byte) string {
+	switch preimage.KeyType(keyType) {
+	case preimage.LocalKeyType:
+		return "local"
+	case preimage.Keccak256KeyType:
+		return "keccak"
+	case preimage.Sha256KeyType:
+		return "sha256"
+	case preimage.BlobKeyType:
+		return "blob"
+	case preimage.PrecompileKeyType:
+		return "precompile"
+	default:
+		return fmt.Sprintf("type %d", keyType)
+	}
+}
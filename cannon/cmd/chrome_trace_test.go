@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+func TestChromeTrace(t *testing.T) {
+	state := singlethreaded.CreateInitialState(0x1000, 0x4000)
+	testutil.StoreInstruction(state.Memory, 0x1000, 0x00000000) // nop
+	testutil.StoreInstruction(state.Memory, 0x2000, 0x0000000c) // syscall
+	meta := &program.Metadata{Symbols: []program.Symbol{
+		{Name: "main.main", Start: 0x1000, Size: 0x10},
+		{Name: "syscall.Syscall", Start: 0x2000, Size: 0x10},
+	}}
+
+	var buf bytes.Buffer
+	tracer, err := newChromeTracer(meta, &buf, 2)
+	require.NoError(t, err)
+	key := preimage.Keccak256Key{0xaa}.PreimageKey()
+	step := func(pc mipsevm.Word, num mipsevm.Word, fd mipsevm.Word) {
+		state.Cpu.PC = pc
+		testutil.SetSyscallArgs(&state.Registers, num, fd)
+		require.NoError(t, tracer.observe(state))
+		state.Step++
+	}
+	step(0x1000, 0, 0)                              // step 0: main.main
+	step(0x1000, 0, 0)                              // step 1: main.main
+	step(0x2000, exec.SysRead, exec.FdPreimageRead) // step 2: read pre-image
+	require.NoError(t, tracer.observePreimage(2, key, make([]byte, 8)))
+	step(0x1000, 0, 0)                              // step 3: main.main, too short to be included
+	step(0x2000, exec.SysRead, exec.FdPreimageRead) // step 4: read pre-image again
+	require.NoError(t, tracer.observePreimage(4, key, make([]byte, 8)))
+	step(0x2000, exec.SysWrite, exec.FdStdout) // step 5: write to stdout
+	require.NoError(t, tracer.Close())
+
+	var trace struct {
+		TraceEvents []chromeTraceEvent `json:"traceEvents"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &trace))
+	var spans []chromeTraceEvent
+	for _, ev := range trace.TraceEvents {
+		if ev.Ph == "X" {
+			ev.Args = nil
+			spans = append(spans, ev)
+		}
+	}
+	require.ElementsMatch(t, []chromeTraceEvent{
+		{Name: "main.main", Cat: "function", Ph: "X", Ts: 0, Dur: 2, Tid: chromeTraceFunctionsTrack},
+		{Name: "syscall.Syscall", Cat: "function", Ph: "X", Ts: 4, Dur: 2, Tid: chromeTraceFunctionsTrack},
+		{Name: "read(5)", Cat: "syscall", Ph: "X", Ts: 2, Dur: 3, Tid: chromeTraceSyscallsTrack},
+		{Name: "write(1)", Cat: "syscall", Ph: "X", Ts: 5, Dur: 1, Tid: chromeTraceSyscallsTrack},
+		{Name: "keccak", Cat: "preimage", Ph: "X", Ts: 2, Dur: 3, Tid: chromeTracePreimagesTrack},
+	}, spans)
+}
+
+func TestChromeTrace_NoMetadata(t *testing.T) {
+	state := singlethreaded.CreateInitialState(0x1000, 0x4000)
+	var buf bytes.Buffer
+	tracer, err := newChromeTracer(&program.Metadata{}, &buf, 0)
+	require.NoError(t, err)
+	require.NoError(t, tracer.observe(state))
+	require.NoError(t, tracer.Close())
+
+	var trace struct {
+		TraceEvents []chromeTraceEvent `json:"traceEvents"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &trace))
+	for _, ev := range trace.TraceEvents {
+		require.NotEqual(t, chromeTraceFunctionsTrack, ev.Tid, "no function spans without symbols")
+	}
+}
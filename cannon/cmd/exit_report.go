@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+)
+
+var (
+	ErrInfiniteLoop         = errors.New("detected an infinite loop")
+	ErrPreimageServerExited = errors.New("pre-image server exited")
+)
+
+// exitReportSyscalls is the number of syscalls the exit report keeps.
+const exitReportSyscalls = 16
+
+// ExitClass classifies why a run stopped.
+type ExitClass string
+
+const (
+	// ExitClassSuccess is a guest that exited with code 0
+	ExitClassSuccess ExitClass = "success"
+	// ExitClassInvalidClaim is a guest that exited with code 1: the program found the claim to be invalid
+	ExitClassInvalidClaim ExitClass = "invalid_claim"
+	// ExitClassPanic is a guest that exited with code 2, as a panicking Go program does
+	ExitClassPanic ExitClass = "panic"
+	// ExitClassExitCode is a guest that exited with any other code
+	ExitClassExitCode ExitClass = "exit_code"
+	// ExitClassStopped is a run that stopped before the guest exited, e.g. with --stop-at
+	ExitClassStopped ExitClass = "stopped"
+	// ExitClassInfiniteLoop is a run aborted because the guest got stuck
+	ExitClassInfiniteLoop ExitClass = "infinite_loop"
+	// ExitClassOracleError is a run aborted because the pre-image server exited
+	ExitClassOracleError ExitClass = "oracle_error"
+	// ExitClassStateLimit is a run aborted because the state exceeded the limits
+	ExitClassStateLimit ExitClass = "state_limit"
+	// ExitClassInterrupted is a run that was interrupted
+	ExitClassInterrupted ExitClass = "interrupted"
+	// ExitClassFault is a run aborted because the guest executed an instruction that faults, e.g. an illegal instruction
+	ExitClassFault ExitClass = "fault"
+	// ExitClassVMError is a run aborted by any other error, e.g. an unsupported syscall
+	ExitClassVMError ExitClass = "vm_error"
+)
+
+// SyscallRecord is a syscall made by the guest.
+type SyscallRecord struct {
+	Step uint64          `json:"step"`
+	PC   mipsevm.HexWord `json:"pc"`
+	Num  mipsevm.Word    `json:"num"`
+	Args [4]hexutil.Uint `json:"args"`
+}
+
+// ExitReport explains why a run stopped.
+type ExitReport struct {
+	Class    ExitClass       `json:"class"`
+	Exited   bool            `json:"exited"`
+	ExitCode uint8           `json:"exitCode"`
+	Step     uint64          `json:"step"`
+	PC       mipsevm.HexWord `json:"pc"`
+	// Symbol is the guest function at the PC, if the run has metadata
+	Symbol string `json:"symbol,omitempty"`
+	// Error is the error that aborted the run, if any
+	Error string `json:"error,omitempty"`
+	// PreimageKey is the last pre-image key requested by the guest
+	PreimageKey common.Hash `json:"preimageKey"`
+	// LastHint is the last complete hint sent by the guest, if any
+	LastHint hexutil.Bytes `json:"lastHint,omitempty"`
+	// Syscalls are the last syscalls made by the guest, oldest first
+	Syscalls []SyscallRecord `json:"syscalls"`
+}
+
+// exitReporter tracks the syscalls of a run, to report on them when the run stops.
+type exitReporter struct {
+	syscalls []SyscallRecord
+	next     int
+}
+
+func newExitReporter() *exitReporter {
+	return &exitReporter{syscalls: make([]SyscallRecord, 0, exitReportSyscalls)}
+}
+
+// observe records the next instruction of the state, if it is a syscall.
+// It must be called before each step.
+func (r *exitReporter) observe(state mipsevm.FPVMState) {
+	pc := state.GetPC()
+	if state.GetMemory().GetUint32(pc) != 0x0000000c { // syscall
+		return
+	}
+	regs := state.GetRegistersRef()
+	record := SyscallRecord{
+		Step: state.GetStep(),
+		PC:   mipsevm.HexWord(pc),
+		Num:  regs[2],
+		Args: [4]hexutil.Uint{hexutil.Uint(regs[4]), hexutil.Uint(regs[5]), hexutil.Uint(regs[6]), hexutil.Uint(regs[7])},
+	}
+	if len(r.syscalls) < exitReportSyscalls {
+		r.syscalls = append(r.syscalls, record)
+	} else {
+		r.syscalls[r.next] = record
+	}
+	r.next = (r.next + 1) % exitReportSyscalls
+}
+
+// report creates the report of the run, that stopped at the state with the given error, if any.
+func (r *exitReporter) report(state mipsevm.FPVMState, meta *program.Metadata, runErr error) *ExitReport {
+	report := &ExitReport{
+		Class:       classifyExit(state, runErr),
+		Exited:      state.GetExited(),
+		ExitCode:    state.GetExitCode(),
+		Step:        state.GetStep(),
+		PC:          mipsevm.HexWord(state.GetPC()),
+		PreimageKey: state.GetPreimageKey(),
+		Syscalls:    make([]SyscallRecord, 0, len(r.syscalls)),
+	}
+	if meta != nil && len(meta.Symbols) > 0 {
+		report.Symbol = meta.LookupSymbol(state.GetPC())
+	}
+	if runErr != nil {
+		report.Error = runErr.Error()
+	}
+	// the hint is buffered, and only complete if the length prefix is covered
+	if hint := state.GetLastHint(); len(hint) > 4 && uint64(binary.BigEndian.Uint32(hint[:4])) <= uint64(len(hint[4:])) {
+		report.LastHint = hint[4 : 4+binary.BigEndian.Uint32(hint[:4])]
+	}
+	if len(r.syscalls) == exitReportSyscalls {
+		report.Syscalls = append(report.Syscalls, r.syscalls[r.next:]...)
+		report.Syscalls = append(report.Syscalls, r.syscalls[:r.next]...)
+	} else {
+		report.Syscalls = append(report.Syscalls, r.syscalls...)
+	}
+	return report
+}
+
+func classifyExit(state mipsevm.FPVMState, runErr error) ExitClass {
+	switch {
+	case runErr == nil && state.GetExited():
+		switch state.GetExitCode() {
+		case 0:
+			return ExitClassSuccess
+		case 1:
+			return ExitClassInvalidClaim
+		case 2:
+			return ExitClassPanic
+		default:
+			return ExitClassExitCode
+		}
+	case runErr == nil:
+		return ExitClassStopped
+	case errors.Is(runErr, ErrInfiniteLoop):
+		return ExitClassInfiniteLoop
+	case errors.Is(runErr, ErrPreimageServerExited):
+		return ExitClassOracleError
+	case errors.Is(runErr, mipsevm.ErrStateLimitExceeded):
+		return ExitClassStateLimit
+	case errors.Is(runErr, mipsevm.ErrFault):
+		return ExitClassFault
+	case errors.Is(runErr, context.Canceled), errors.Is(runErr, context.DeadlineExceeded):
+		return ExitClassInterrupted
+	default:
+		return ExitClassVMError
+	}
+}
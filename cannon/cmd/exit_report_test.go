@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+func TestExitReport(t *testing.T) {
+	state := singlethreaded.CreateInitialState(0x1000, 0x4000)
+	testutil.StoreInstruction(state.Memory, 0x1000, 0x0000000c) // syscall
+	testutil.StoreInstruction(state.Memory, 0x1004, 0x00000000) // nop
+
+	r := newExitReporter()
+	for i := 0; i < exitReportSyscalls+2; i++ {
+		state.Step = uint64(i)
+		testutil.SetSyscallArgs(&state.Registers, 4000+mipsevm.Word(i), mipsevm.Word(i))
+		r.observe(state)
+	}
+	state.Cpu.PC = 0x1004
+	r.observe(state)
+
+	state.Exited = true
+	state.ExitCode = 1
+	state.PreimageKey = common.Hash{0xaa}
+	state.LastHint = hexutil.Bytes{0, 0, 0, 2, 0xbb, 0xcc, 0xdd}
+	meta := &program.Metadata{Symbols: []program.Symbol{{Name: "main.main", Start: 0x1000, Size: 0x10}}}
+	report := r.report(state, meta, nil)
+	require.Equal(t, ExitClassInvalidClaim, report.Class)
+	require.True(t, report.Exited)
+	require.Equal(t, uint8(1), report.ExitCode)
+	require.Equal(t, mipsevm.HexWord(0x1004), report.PC)
+	require.Equal(t, "main.main", report.Symbol)
+	require.Equal(t, common.Hash{0xaa}, report.PreimageKey)
+	require.Equal(t, hexutil.Bytes{0xbb, 0xcc}, report.LastHint)
+	require.Empty(t, report.Error)
+
+	require.Len(t, report.Syscalls, exitReportSyscalls, "only keeps the last syscalls")
+	require.Equal(t, SyscallRecord{Step: 2, PC: 0x1000, Num: 4002, Args: [4]hexutil.Uint{2}}, report.Syscalls[0])
+	require.Equal(t, mipsevm.Word(4000+exitReportSyscalls+1), report.Syscalls[exitReportSyscalls-1].Num)
+
+	// incomplete hints and missing symbols are left out
+	state.LastHint = hexutil.Bytes{0, 0, 0, 8, 0xbb}
+	report = newExitReporter().report(state, &program.Metadata{}, errors.New("oops"))
+	require.Empty(t, report.LastHint)
+	require.Empty(t, report.Symbol)
+	require.Empty(t, report.Syscalls)
+	require.Equal(t, "oops", report.Error)
+}
+
+func TestClassifyExit(t *testing.T) {
+	exited := func(code uint8) mipsevm.FPVMState {
+		state := singlethreaded.CreateEmptyState()
+		state.Exited = true
+		state.ExitCode = code
+		return state
+	}
+	running := singlethreaded.CreateEmptyState()
+	tests := []struct {
+		state    mipsevm.FPVMState
+		err      error
+		expected ExitClass
+	}{
+		{exited(0), nil, ExitClassSuccess},
+		{exited(1), nil, ExitClassInvalidClaim},
+		{exited(2), nil, ExitClassPanic},
+		{exited(3), nil, ExitClassExitCode},
+		{running, nil, ExitClassStopped},
+		{running, fmt.Errorf("%w at step %d", ErrInfiniteLoop, 5), ExitClassInfiniteLoop},
+		{running, fmt.Errorf("failed at step 5: %w with code 1", ErrPreimageServerExited), ExitClassOracleError},
+		{running, fmt.Errorf("at step 5: %w", mipsevm.ErrStateLimitExceeded), ExitClassStateLimit},
+		{running, context.Canceled, ExitClassInterrupted},
+		{running, fmt.Errorf("failed at step 5: %w", &mipsevm.FaultError{Err: mipsevm.ErrIllegalInstruction}), ExitClassFault},
+		{running, errors.New("unrecognized syscall"), ExitClassVMError},
+		{exited(0), errors.New("failed to write state output"), ExitClassVMError},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, classifyExit(test.state, test.err), "err: %v", test.err)
+	}
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	outputFormatText = "text"
+	outputFormatJSON = "json"
+)
+
+var OutputFormatFlag = &cli.StringFlag{
+	Name: "format",
+	Usage: "output format: 'text' or 'json'. With json, logs are written to stderr as JSON lines, " +
+		"and the result is written to stdout as a single JSON object.",
+	Value: outputFormatText,
+}
+
+func outputFormatFromCtx(ctx *cli.Context) (string, error) {
+	switch format := ctx.String(OutputFormatFlag.Name); format {
+	case outputFormatText, outputFormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("invalid %v: %q, expected %q or %q", OutputFormatFlag.Name, format, outputFormatText, outputFormatJSON)
+	}
+}
+
+// FormatLogger returns a logger that writes in the output format, so logs can be parsed along with the result.
+func FormatLogger(format string, w io.Writer, lvl slog.Level) log.Logger {
+	if format == outputFormatJSON {
+		return log.NewLogger(log.JSONHandlerWithLevel(w, lvl))
+	}
+	return Logger(w, lvl)
+}
+
+// hostLoggers returns the loggers for the stdout and stderr of the pre-image server.
+// With JSON output, stdout is reserved for the result, so the pre-image server stdout is logged to stderr too.
+func hostLoggers(format string) (stdout log.Logger, stderr log.Logger) {
+	stdoutW := io.Writer(os.Stdout)
+	if format == outputFormatJSON {
+		stdoutW = os.Stderr
+	}
+	return FormatLogger(format, stdoutW, log.LevelInfo).With("module", "host"),
+		FormatLogger(format, os.Stderr, log.LevelInfo).With("module", "host")
+}
+
+// writeJSONResult writes the result of a command as a single line of JSON.
+func writeJSONResult(w io.Writer, result any) error {
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		return fmt.Errorf("failed to write result: %w", err)
+	}
+	return nil
+}
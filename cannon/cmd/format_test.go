@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+func runCommand(t *testing.T, command *cli.Command, args ...string) (string, error) {
+	var out bytes.Buffer
+	app := cli.NewApp()
+	app.Writer = &out
+	app.Commands = []*cli.Command{command}
+	err := app.Run(append([]string{"cannon", command.Name}, args...))
+	return out.String(), err
+}
+
+func TestWitnessFormat(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "state.json")
+	// Use a state of the default VM type of the build
+	var state mipsevm.FPVMState = singlethreaded.CreateEmptyState()
+	if !arch.IsMips32 {
+		state = multithreaded.CreateEmptyState()
+	}
+	require.NoError(t, jsonutil.WriteJSON(input, state, OutFilePerm))
+	_, expected := state.EncodeWitness()
+
+	out, err := runCommand(t, WitnessCommand, "--input", input)
+	require.NoError(t, err)
+	require.Equal(t, expected.Hex()+"\n", out)
+
+	output := filepath.Join(dir, "witness.bin")
+	out, err = runCommand(t, WitnessCommand, "--input", input, "--output", output, "--format", "json")
+	require.NoError(t, err)
+	var result WitnessResult
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	require.Equal(t, WitnessResult{StateHash: expected, Output: output}, result)
+	require.FileExists(t, output)
+
+	_, err = runCommand(t, WitnessCommand, "--input", input, "--format", "yaml")
+	require.ErrorContains(t, err, `invalid format: "yaml"`)
+}
+
+func TestWriteJSONResult(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, writeJSONResult(&out, RunResult{
+		Step:      10,
+		PC:        0x1234,
+		Exited:    true,
+		ExitCode:  1,
+		StateHash: common.Hash{0xaa},
+		Proofs:    []string{},
+		Snapshots: []string{"state-0.json"},
+	}))
+	require.Equal(t, 1, bytes.Count(out.Bytes(), []byte("\n")), "result must be a single line")
+	require.JSONEq(t, `{
+		"startStep": 0,
+		"step": 10,
+		"pc": 4660,
+		"exited": true,
+		"exitCode": 1,
+		"stateHash": "0xaa00000000000000000000000000000000000000000000000000000000000000",
+		"proofs": [],
+		"snapshots": ["state-0.json"]
+	}`, out.String())
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"crypto/sha256"
 	"debug/elf"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
@@ -38,24 +43,113 @@ var (
 		Value:    "meta.json",
 		Required: false,
 	}
+	LoadELFStateVersionFlag = &cli.UintFlag{
+		Name:     "state-version",
+		Usage:    "Version of the state to create, to prove the dispute games of an older contract. Defaults to the latest version of the VM type.",
+		Required: false,
+	}
+	LoadELFHashFlag = &cli.StringFlag{
+		Name:     "elf-hash",
+		Usage:    "Expected SHA-256 hash of the ELF file. Fails without writing any output if the ELF does not match.",
+		Required: false,
+	}
 )
 
+var ErrELFHashMismatch = errors.New("ELF hash mismatch")
+
+// LoadELFResult is the output of the load-elf command in JSON format.
+type LoadELFResult struct {
+	StateHash common.Hash `json:"stateHash"`
+	// Output is the path the state was written to, if any.
+	Output string `json:"output,omitempty"`
+	// Meta is the path the metadata was written to, if any.
+	Meta string `json:"meta,omitempty"`
+	// ELFHash is the SHA-256 hash of the loaded ELF file.
+	ELFHash common.Hash `json:"elfHash"`
+}
+
+// hashELF returns the SHA-256 hash of the ELF file at path.
+func hashELF(path string) (common.Hash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to open ELF file %q: %w", path, err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to hash ELF file %q: %w", path, err)
+	}
+	return common.Hash(h.Sum(nil)), nil
+}
+
+// verifyELFHash checks the hash of an ELF file against the expected hex-encoded hash.
+// There is nothing to verify if expected is empty.
+func verifyELFHash(expected string, actual common.Hash) error {
+	if expected == "" {
+		return nil
+	}
+	if len(common.FromHex(expected)) != common.HashLength {
+		return fmt.Errorf("invalid %v %q", LoadELFHashFlag.Name, expected)
+	}
+	if want := common.HexToHash(expected); want != actual {
+		return fmt.Errorf("%w: expected %v but got %v", ErrELFHashMismatch, want, actual)
+	}
+	return nil
+}
+
+// stateVersionFromCtx returns the state version to create, up to the latest version of the VM type.
+func stateVersionFromCtx(ctx *cli.Context, latest mipsevm.StateVersion) (mipsevm.StateVersion, error) {
+	if !ctx.IsSet(LoadELFStateVersionFlag.Name) {
+		return latest, nil
+	}
+	version := ctx.Uint(LoadELFStateVersionFlag.Name)
+	if version > uint(latest) {
+		return 0, fmt.Errorf("unsupported %v %d, the latest version is %d", LoadELFStateVersionFlag.Name, version, latest)
+	}
+	return mipsevm.StateVersion(version), nil
+}
+
 func LoadELF(ctx *cli.Context) error {
+	format, err := outputFormatFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+	if format == outputFormatJSON && (ctx.Path(LoadELFOutFlag.Name) == "-" || ctx.Path(LoadELFMetaFlag.Name) == "-") {
+		return fmt.Errorf("cannot write state or metadata to stdout with %v %v", OutputFormatFlag.Name, outputFormatJSON)
+	}
 	var createInitialState func(f *elf.File) (mipsevm.FPVMState, error)
 	var writeState func(path string, state mipsevm.FPVMState) error
 
 	if vmType, err := vmTypeFromString(ctx); err != nil {
 		return err
 	} else if vmType == cannonVMType {
+		version, err := stateVersionFromCtx(ctx, singlethreaded.LatestVersion)
+		if err != nil {
+			return err
+		}
 		createInitialState = func(f *elf.File) (mipsevm.FPVMState, error) {
-			return program.LoadELF(f, singlethreaded.CreateInitialState)
+			state, err := program.LoadELF(f, singlethreaded.CreateInitialState)
+			if err != nil {
+				return nil, err
+			}
+			state.Version = version
+			return state, nil
 		}
 		writeState = func(path string, state mipsevm.FPVMState) error {
 			return jsonutil.WriteJSON[*singlethreaded.State](path, state.(*singlethreaded.State), OutFilePerm)
 		}
 	} else if vmType == mtVMType {
+		version, err := stateVersionFromCtx(ctx, multithreaded.LatestVersion)
+		if err != nil {
+			return err
+		}
 		createInitialState = func(f *elf.File) (mipsevm.FPVMState, error) {
-			return program.LoadELF(f, multithreaded.CreateInitialState)
+			state, err := program.LoadELF(f, multithreaded.CreateInitialState)
+			if err != nil {
+				return nil, err
+			}
+			state.Version = version
+			return state, nil
 		}
 		writeState = func(path string, state mipsevm.FPVMState) error {
 			return jsonutil.WriteJSON[*multithreaded.State](path, state.(*multithreaded.State), OutFilePerm)
@@ -64,6 +158,13 @@ func LoadELF(ctx *cli.Context) error {
 		return fmt.Errorf("invalid VM type: %q", vmType)
 	}
 	elfPath := ctx.Path(LoadELFPathFlag.Name)
+	elfHash, err := hashELF(elfPath)
+	if err != nil {
+		return err
+	}
+	if err := verifyELFHash(ctx.String(LoadELFHashFlag.Name), elfHash); err != nil {
+		return err
+	}
 	elfProgram, err := elf.Open(elfPath)
 	if err != nil {
 		return fmt.Errorf("failed to open ELF file %q: %w", elfPath, err)
@@ -92,10 +193,23 @@ func LoadELF(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to compute program metadata: %w", err)
 	}
+	meta.ELFHash = elfHash
 	if err := jsonutil.WriteJSON[*program.Metadata](ctx.Path(LoadELFMetaFlag.Name), meta, OutFilePerm); err != nil {
 		return fmt.Errorf("failed to output metadata: %w", err)
 	}
-	return writeState(ctx.Path(LoadELFOutFlag.Name), state)
+	if err := writeState(ctx.Path(LoadELFOutFlag.Name), state); err != nil {
+		return err
+	}
+	if format == outputFormatJSON {
+		_, stateHash := state.EncodeWitness()
+		return writeJSONResult(ctx.App.Writer, LoadELFResult{
+			StateHash: stateHash,
+			Output:    ctx.Path(LoadELFOutFlag.Name),
+			Meta:      ctx.Path(LoadELFMetaFlag.Name),
+			ELFHash:   elfHash,
+		})
+	}
+	return nil
 }
 
 var LoadELFCommand = &cli.Command{
@@ -109,5 +223,8 @@ var LoadELFCommand = &cli.Command{
 		LoadELFPatchFlag,
 		LoadELFOutFlag,
 		LoadELFMetaFlag,
+		LoadELFHashFlag,
+		LoadELFStateVersionFlag,
+		OutputFormatFlag,
 	},
 }
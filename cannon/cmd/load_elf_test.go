@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+func TestVerifyELFHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "program.elf")
+	data := []byte("not really an ELF")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+
+	hash, err := hashELF(path)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash(sha256.Sum256(data)), hash)
+
+	require.NoError(t, verifyELFHash("", hash))
+	require.NoError(t, verifyELFHash(hash.Hex(), hash))
+	require.NoError(t, verifyELFHash(common.Bytes2Hex(hash[:]), hash))
+	require.ErrorIs(t, verifyELFHash(common.Hash{0xaa}.Hex(), hash), ErrELFHashMismatch)
+	require.ErrorContains(t, verifyELFHash("0x1234", hash), "invalid elf-hash")
+
+	_, err = hashELF(filepath.Join(t.TempDir(), "missing.elf"))
+	require.Error(t, err)
+}
+
+func TestStateVersionFromCtx(t *testing.T) {
+	latest := mipsevm.StateVersion(2)
+	parse := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		require.NoError(t, LoadELFStateVersionFlag.Apply(set))
+		require.NoError(t, set.Parse(args))
+		return cli.NewContext(nil, set, nil)
+	}
+
+	version, err := stateVersionFromCtx(parse(), latest)
+	require.NoError(t, err)
+	require.Equal(t, latest, version)
+
+	version, err = stateVersionFromCtx(parse("--state-version", "0"), latest)
+	require.NoError(t, err)
+	require.Equal(t, mipsevm.StateVersion(0), version)
+
+	_, err = stateVersionFromCtx(parse("--state-version", "3"), latest)
+	require.ErrorContains(t, err, "unsupported state-version 3")
+}
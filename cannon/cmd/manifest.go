@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+const runManifestVersion = 1
+
+type ManifestFileKind string
+
+const (
+	ManifestProof    ManifestFileKind = "proof"
+	ManifestSnapshot ManifestFileKind = "snapshot"
+)
+
+var (
+	ErrManifestMismatch  = errors.New("manifest mismatch")
+	ErrManifestCorrupted = errors.New("manifest file corrupted")
+)
+
+// StepRange is an inclusive range of steps.
+type StepRange struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
+// ManifestFile is a proof or snapshot written by cannon run.
+type ManifestFile struct {
+	// Path is relative to the directory of the manifest.
+	Path string           `json:"path"`
+	Kind ManifestFileKind `json:"kind"`
+	Step uint64           `json:"step"`
+	// StateHash is the hash of the state at Step, i.e. the pre-state of a proof.
+	StateHash common.Hash `json:"stateHash"`
+	// Checksum is the SHA-256 of the file as written to disk.
+	Checksum common.Hash `json:"sha256"`
+}
+
+// RunManifest records the proofs and snapshots written by one or more runs of the same trace,
+// so that a run can resume into an output directory of an earlier run:
+// it checks the input state is part of the recorded trace, and doesn't rewrite recorded files.
+type RunManifest struct {
+	Version int    `json:"version"`
+	VMType  VMType `json:"vmType"`
+	// Covered are the step ranges of completed runs, merged and sorted.
+	Covered []StepRange `json:"covered"`
+	// StateHashes are the known state hashes of the trace, by step.
+	StateHashes map[uint64]common.Hash `json:"stateHashes"`
+	Files       []ManifestFile         `json:"files"`
+
+	path string
+}
+
+// LoadRunManifest loads the manifest at path and verifies the checksums of its files,
+// or returns a new empty manifest if there is no file at path.
+func LoadRunManifest(path string, vmType VMType) (*RunManifest, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return &RunManifest{
+			Version:     runManifestVersion,
+			VMType:      vmType,
+			StateHashes: make(map[uint64]common.Hash),
+			path:        path,
+		}, nil
+	}
+	m, err := jsonutil.LoadJSON[RunManifest](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+	m.path = path
+	if m.Version != runManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", m.Version)
+	}
+	if m.VMType != vmType {
+		return nil, fmt.Errorf("%w: manifest of VM type %q, running %q", ErrManifestMismatch, m.VMType, vmType)
+	}
+	if m.StateHashes == nil {
+		m.StateHashes = make(map[uint64]common.Hash)
+	}
+	if err := m.Verify(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Verify checks all files of the manifest exist and match their checksums.
+func (m *RunManifest) Verify() error {
+	for _, f := range m.Files {
+		sum, err := fileChecksum(m.resolve(f.Path))
+		if err != nil {
+			return fmt.Errorf("%w: %s %s: %w", ErrManifestCorrupted, f.Kind, f.Path, err)
+		}
+		if sum != f.Checksum {
+			return fmt.Errorf("%w: %s %s has checksum %v, expected %v", ErrManifestCorrupted, f.Kind, f.Path, sum, f.Checksum)
+		}
+	}
+	return nil
+}
+
+// CheckState checks the state hash matches the hash recorded for the step, if any.
+func (m *RunManifest) CheckState(step uint64, hash common.Hash) error {
+	if known, ok := m.StateHashes[step]; ok && known != hash {
+		return fmt.Errorf("%w: state hash %v at step %d, expected %v", ErrManifestMismatch, hash, step, known)
+	}
+	return nil
+}
+
+// CheckResume checks a run may resume from the state with the given step and hash:
+// the manifest must be empty, or have recorded the same state.
+func (m *RunManifest) CheckResume(step uint64, hash common.Hash) error {
+	if len(m.StateHashes) == 0 && len(m.Files) == 0 {
+		return nil
+	}
+	if _, ok := m.StateHashes[step]; !ok {
+		return fmt.Errorf("%w: input state at step %d is not part of the recorded trace", ErrManifestMismatch, step)
+	}
+	return m.CheckState(step, hash)
+}
+
+// File returns the recorded file of the kind at the step, if any.
+func (m *RunManifest) File(kind ManifestFileKind, step uint64) (ManifestFile, bool) {
+	for _, f := range m.Files {
+		if f.Kind == kind && f.Step == step {
+			return f, true
+		}
+	}
+	return ManifestFile{}, false
+}
+
+// RecordFile records the file of the kind at the step, after it has been written to path.
+func (m *RunManifest) RecordFile(kind ManifestFileKind, path string, step uint64, stateHash common.Hash) error {
+	sum, err := fileChecksum(path)
+	if err != nil {
+		return fmt.Errorf("failed to checksum %s: %w", path, err)
+	}
+	rel, err := m.relative(path)
+	if err != nil {
+		return err
+	}
+	if err := m.RecordState(StateAt{Step: step, Hash: stateHash}); err != nil {
+		return err
+	}
+	entry := ManifestFile{Path: rel, Kind: kind, Step: step, StateHash: stateHash, Checksum: sum}
+	if i := slices.IndexFunc(m.Files, func(f ManifestFile) bool { return f.Kind == kind && f.Step == step }); i >= 0 {
+		m.Files[i] = entry
+	} else {
+		m.Files = append(m.Files, entry)
+	}
+	return nil
+}
+
+// RecordState records the state hash at a step of the trace.
+func (m *RunManifest) RecordState(state StateAt) error {
+	if err := m.CheckState(state.Step, state.Hash); err != nil {
+		return err
+	}
+	m.StateHashes[state.Step] = state.Hash
+	return nil
+}
+
+// RecordRun records the steps covered by a completed run, and the states it started and stopped at.
+func (m *RunManifest) RecordRun(from StateAt, to StateAt) error {
+	if err := m.RecordState(from); err != nil {
+		return err
+	}
+	if err := m.RecordState(to); err != nil {
+		return err
+	}
+	m.Covered = mergeRanges(append(m.Covered, StepRange{From: from.Step, To: to.Step}))
+	return nil
+}
+
+// StateAt identifies the state of the trace at a step.
+type StateAt struct {
+	Step uint64
+	Hash common.Hash
+}
+
+func (m *RunManifest) Save() error {
+	return jsonutil.WriteJSON(m.path, m, OutFilePerm)
+}
+
+func (m *RunManifest) resolve(rel string) string {
+	return filepath.Join(filepath.Dir(m.path), rel)
+}
+
+func (m *RunManifest) relative(path string) (string, error) {
+	dir, err := filepath.Abs(filepath.Dir(m.path))
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Rel(dir, abs)
+}
+
+func mergeRanges(ranges []StepRange) []StepRange {
+	slices.SortFunc(ranges, func(a, b StepRange) int {
+		if a.From < b.From {
+			return -1
+		} else if a.From > b.From {
+			return 1
+		}
+		return 0
+	})
+	var merged []StepRange
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.From <= merged[n-1].To {
+			merged[n-1].To = max(merged[n-1].To, r.To)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+func fileChecksum(path string) (common.Hash, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(h.Sum(nil)), nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunManifest(t *testing.T) {
+	dir := t.TempDir()
+	manifestPath := filepath.Join(dir, "manifest.json")
+	proofPath := filepath.Join(dir, "proofs", "10.json")
+	require.NoError(t, os.MkdirAll(filepath.Dir(proofPath), 0o755))
+	require.NoError(t, os.WriteFile(proofPath, []byte("proof"), 0o644))
+
+	m, err := LoadRunManifest(manifestPath, cannonVMType)
+	require.NoError(t, err)
+	require.NoError(t, m.CheckResume(5, common.Hash{0x05}), "empty manifest accepts any input state")
+	require.NoError(t, m.RecordState(StateAt{Step: 5, Hash: common.Hash{0x05}}))
+	require.NoError(t, m.RecordFile(ManifestProof, proofPath, 10, common.Hash{0x10}))
+	require.NoError(t, m.RecordRun(StateAt{Step: 5, Hash: common.Hash{0x05}}, StateAt{Step: 20, Hash: common.Hash{0x20}}))
+	require.NoError(t, m.Save())
+
+	m, err = LoadRunManifest(manifestPath, cannonVMType)
+	require.NoError(t, err)
+	require.Equal(t, []StepRange{{From: 5, To: 20}}, m.Covered)
+	f, ok := m.File(ManifestProof, 10)
+	require.True(t, ok)
+	require.Equal(t, filepath.Join("proofs", "10.json"), f.Path)
+	require.Equal(t, common.Hash{0x10}, f.StateHash)
+	_, ok = m.File(ManifestSnapshot, 10)
+	require.False(t, ok)
+
+	t.Run("resume", func(t *testing.T) {
+		require.NoError(t, m.CheckResume(10, common.Hash{0x10}))
+		require.NoError(t, m.CheckResume(20, common.Hash{0x20}))
+		require.ErrorIs(t, m.CheckResume(10, common.Hash{0xff}), ErrManifestMismatch)
+		require.ErrorIs(t, m.CheckResume(15, common.Hash{0x15}), ErrManifestMismatch)
+	})
+
+	t.Run("record conflicting state", func(t *testing.T) {
+		require.ErrorIs(t, m.RecordFile(ManifestSnapshot, proofPath, 20, common.Hash{0xff}), ErrManifestMismatch)
+	})
+
+	t.Run("different vm type", func(t *testing.T) {
+		_, err := LoadRunManifest(manifestPath, mtVMType)
+		require.ErrorIs(t, err, ErrManifestMismatch)
+	})
+
+	t.Run("modified file", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(proofPath, []byte("modified"), 0o644))
+		_, err := LoadRunManifest(manifestPath, cannonVMType)
+		require.ErrorIs(t, err, ErrManifestCorrupted)
+
+		require.NoError(t, os.Remove(proofPath))
+		_, err = LoadRunManifest(manifestPath, cannonVMType)
+		require.ErrorIs(t, err, ErrManifestCorrupted)
+	})
+}
+
+func TestMergeRanges(t *testing.T) {
+	require.Nil(t, mergeRanges(nil))
+	require.Equal(t,
+		[]StepRange{{From: 0, To: 30}, {From: 40, To: 50}},
+		mergeRanges([]StepRange{{From: 40, To: 50}, {From: 10, To: 30}, {From: 0, To: 10}, {From: 15, To: 20}}))
+}
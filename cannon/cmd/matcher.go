@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 )
@@ -15,6 +16,8 @@ type StepMatcher func(st VMState) bool
 type StepMatcherFlag struct {
 	repr    string
 	matcher StepMatcher
+	// next returns the first matching step at or after the step
+	next func(step uint64) uint64
 }
 
 func MustStepMatcherFlag(pattern string) *StepMatcherFlag {
@@ -31,10 +34,16 @@ func (m *StepMatcherFlag) Set(value string) error {
 		m.matcher = func(st VMState) bool {
 			return false
 		}
+		m.next = func(step uint64) uint64 {
+			return math.MaxUint64
+		}
 	} else if value == "always" {
 		m.matcher = func(st VMState) bool {
 			return true
 		}
+		m.next = func(step uint64) uint64 {
+			return step
+		}
 	} else if strings.HasPrefix(value, "=") {
 		when, err := strconv.ParseUint(value[1:], 0, 64)
 		if err != nil {
@@ -43,6 +52,12 @@ func (m *StepMatcherFlag) Set(value string) error {
 		m.matcher = func(st VMState) bool {
 			return st.GetStep() == when
 		}
+		m.next = func(step uint64) uint64 {
+			if step > when {
+				return math.MaxUint64
+			}
+			return when
+		}
 	} else if strings.HasPrefix(value, "%") {
 		when, err := strconv.ParseUint(value[1:], 0, 64)
 		if err != nil {
@@ -51,6 +66,15 @@ func (m *StepMatcherFlag) Set(value string) error {
 		m.matcher = func(st VMState) bool {
 			return st.GetStep()%when == 0
 		}
+		m.next = func(step uint64) uint64 {
+			if rem := step % when; rem != 0 {
+				if step > math.MaxUint64-(when-rem) {
+					return math.MaxUint64
+				}
+				return step + (when - rem)
+			}
+			return step
+		}
 	} else {
 		return fmt.Errorf("unrecognized step matcher: %q", value)
 	}
@@ -70,6 +94,15 @@ func (m *StepMatcherFlag) Matcher() StepMatcher {
 	return m.matcher
 }
 
+// NextMatch returns the first step at or after the given step that the matcher matches,
+// or math.MaxUint64 if it never matches again.
+func (m *StepMatcherFlag) NextMatch(step uint64) uint64 {
+	if m.next == nil { // omitted inputs never match, see Matcher
+		return math.MaxUint64
+	}
+	return m.next(step)
+}
+
 func (m *StepMatcherFlag) Clone() any {
 	var out StepMatcherFlag
 	if err := out.Set(m.repr); err != nil {
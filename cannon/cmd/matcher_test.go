@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type stepState uint64
+
+func (s stepState) GetStep() uint64 { return uint64(s) }
+
+func TestStepMatcherFlag_NextMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		step    uint64
+		next    uint64
+	}{
+		{"never", 10, math.MaxUint64},
+		{"", 10, math.MaxUint64},
+		{"always", 10, 10},
+		{"=100", 10, 100},
+		{"=100", 100, 100},
+		{"=100", 101, math.MaxUint64},
+		{"%100", 0, 0},
+		{"%100", 1, 100},
+		{"%100", 100, 100},
+		{"%100", 250, 300},
+		{"%100", math.MaxUint64 - 5, math.MaxUint64},
+	}
+	for _, test := range tests {
+		m := MustStepMatcherFlag(test.pattern)
+		next := m.NextMatch(test.step)
+		require.Equal(t, test.next, next, "pattern %q from step %d", test.pattern, test.step)
+		if next != math.MaxUint64 {
+			require.True(t, m.Matcher()(stepState(next)), "pattern %q must match step %d", test.pattern, next)
+		}
+	}
+
+	// omitted flags never match
+	require.Equal(t, uint64(math.MaxUint64), new(StepMatcherFlag).NextMatch(0))
+}
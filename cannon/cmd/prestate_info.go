@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-program/prestates"
+)
+
+var (
+	PrestateInfoRegistryFlag = &cli.PathFlag{
+		Name:      "registry",
+		Usage:     "path of a prestate registry JSON file to use instead of the registry embedded in this build.",
+		TakesFile: true,
+	}
+	PrestateInfoURLFlag = &cli.StringFlag{
+		Name:  "prestates-url",
+		Usage: "base URL prestates are published at, as used by op-challenger, to show the download URL of prestates without one in the registry.",
+	}
+)
+
+// PrestateInfoResult is the output of the prestate-info command in JSON format.
+type PrestateInfoResult struct {
+	Prestate           common.Hash `json:"prestate"`
+	Version            string      `json:"version"`
+	VMType             string      `json:"vmType"`
+	GovernanceApproved bool        `json:"governanceApproved"`
+	DownloadURL        string      `json:"downloadURL,omitempty"`
+}
+
+func PrestateInfo(ctx *cli.Context) error {
+	format, err := outputFormatFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.NArg() != 1 {
+		return errors.New("expected a single prestate hash argument")
+	}
+	hashStr := ctx.Args().First()
+	if len(common.FromHex(hashStr)) != common.HashLength {
+		return fmt.Errorf("invalid prestate hash %q", hashStr)
+	}
+	hash := common.HexToHash(hashStr)
+
+	registry := prestates.Default()
+	if path := ctx.Path(PrestateInfoRegistryFlag.Name); path != "" {
+		r, err := prestates.Load(path)
+		if err != nil {
+			return err
+		}
+		registry = r
+	}
+	var baseURL *url.URL
+	if s := ctx.String(PrestateInfoURLFlag.Name); s != "" {
+		u, err := url.Parse(s)
+		if err != nil {
+			return fmt.Errorf("invalid %v: %w", PrestateInfoURLFlag.Name, err)
+		}
+		baseURL = u
+	}
+	return writePrestateInfo(ctx.App.Writer, format, registry, baseURL, hash)
+}
+
+func writePrestateInfo(out io.Writer, format string, registry *prestates.Registry, baseURL *url.URL, hash common.Hash) error {
+	release, err := registry.ByHash(hash)
+	if err != nil {
+		return err
+	}
+	downloadURL, err := release.DownloadURL(baseURL)
+	if err != nil {
+		return fmt.Errorf("invalid download URL: %w", err)
+	}
+	if format == outputFormatJSON {
+		result := PrestateInfoResult{
+			Prestate:           release.Hash,
+			Version:            release.Version,
+			VMType:             release.Type,
+			GovernanceApproved: release.GovernanceApproved,
+		}
+		if downloadURL != nil {
+			result.DownloadURL = downloadURL.String()
+		}
+		return writeJSONResult(out, result)
+	}
+	_, _ = fmt.Fprintf(out, "Prestate:            %v\n", release.Hash)
+	_, _ = fmt.Fprintf(out, "Version:             op-program/v%v\n", release.Version)
+	_, _ = fmt.Fprintf(out, "VM type:             %v\n", release.Type)
+	_, _ = fmt.Fprintf(out, "Governance approved: %v\n", release.GovernanceApproved)
+	if downloadURL != nil {
+		_, _ = fmt.Fprintf(out, "Download URL:        %v\n", downloadURL)
+	}
+	return nil
+}
+
+var PrestateInfoCommand = &cli.Command{
+	Name:      "prestate-info",
+	Usage:     "Identify the op-program release of an absolute prestate hash",
+	ArgsUsage: "<hash>",
+	Description: "Looks up an absolute prestate hash, e.g. of a dispute game seen on-chain, in the registry of op-program releases, " +
+		"and prints the release version, VM type and where to download the prestate from.",
+	Action: PrestateInfo,
+	Flags: []cli.Flag{
+		PrestateInfoRegistryFlag,
+		PrestateInfoURLFlag,
+		OutputFormatFlag,
+	},
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/prestates"
+)
+
+func TestWritePrestateInfo(t *testing.T) {
+	registry, err := prestates.Parse([]byte(`[
+		{"version": "1.2.0", "hash": "0x0300000000000000000000000000000000000000000000000000000000000002", "type": "cannon", "governanceApproved": true}
+	]`))
+	require.NoError(t, err)
+	baseURL, err := url.Parse("https://example.com/prestates")
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	hash := common.HexToHash("0x0300000000000000000000000000000000000000000000000000000000000002")
+	require.NoError(t, writePrestateInfo(&out, outputFormatText, registry, baseURL, hash))
+	require.Equal(t, `Prestate:            0x0300000000000000000000000000000000000000000000000000000000000002
+Version:             op-program/v1.2.0
+VM type:             cannon
+Governance approved: true
+Download URL:        https://example.com/prestates/0x0300000000000000000000000000000000000000000000000000000000000002.json
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, writePrestateInfo(&out, outputFormatText, registry, nil, hash))
+	require.NotContains(t, out.String(), "Download URL")
+
+	require.ErrorIs(t, writePrestateInfo(&out, outputFormatText, registry, baseURL, common.Hash{0xaa}), prestates.ErrUnknownPrestate)
+
+	out.Reset()
+	require.NoError(t, writePrestateInfo(&out, outputFormatJSON, registry, baseURL, hash))
+	require.JSONEq(t, `{
+		"prestate": "0x0300000000000000000000000000000000000000000000000000000000000002",
+		"version": "1.2.0",
+		"vmType": "cannon",
+		"governanceApproved": true,
+		"downloadURL": "https://example.com/prestates/0x0300000000000000000000000000000000000000000000000000000000000002.json"
+	}`, out.String())
+}
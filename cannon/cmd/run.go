@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
 	"slices"
@@ -18,9 +19,14 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/gdbstub"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/proof"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/tracer"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 )
 
@@ -91,6 +97,58 @@ var (
 		Value:    "meta.json",
 		Required: false,
 	}
+	RunELFHashFlag = &cli.StringFlag{
+		Name:     "elf-hash",
+		Usage:    "expected SHA-256 hash of the ELF file the input state was loaded from, as recorded in the metadata file. Requires --meta.",
+		Required: false,
+	}
+	RunTraceMetaFlag = &cli.PathFlag{
+		Name:      "trace-meta",
+		Usage:     "path to write a sidecar to, that maps step ranges to the guest functions executing them. Requires --meta. Compressed if the path ends in .gz.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunTraceFlag = &cli.PathFlag{
+		Name: "trace",
+		Usage: "path to write an instruction trace to: a JSON record per step, one per line, with the instruction, " +
+			"the registers and memory it changed, and the arguments and result of syscalls. Kept if the run fails. Compressed if the path ends in .gz.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunChromeTraceFlag = &cli.PathFlag{
+		Name: "chrome-trace",
+		Usage: "path to write a Chrome trace of the run to, loadable into Perfetto, with spans of the syscalls, the pre-image reads, " +
+			"and the guest functions if there is a metadata file. Timestamps are steps, one per microsecond. Compressed if the path ends in .gz.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunChromeTraceMinStepsFlag = &cli.Uint64Flag{
+		Name:     "chrome-trace-min-steps",
+		Usage:    "minimum number of steps of the guest function spans in the Chrome trace, shorter spans are left out to keep the trace small",
+		Value:    100,
+		Required: false,
+	}
+	RunProfileFlag = &cli.PathFlag{
+		Name: "profile",
+		Usage: "path to write a profile of the executed instructions to when the run stops: the count of each op, " +
+			"and with --meta of the ops in each guest function. Written as JSON if the path ends in .json, or as a text report otherwise.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunSyscallStatsFlag = &cli.PathFlag{
+		Name:      "syscall-stats",
+		Usage:     "path to write the statistics of each syscall executed by the VM to when the run stops, as JSON. The statistics are logged in any case.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunManifestFlag = &cli.PathFlag{
+		Name: "manifest",
+		Usage: "path of the manifest of the proofs and snapshots written by the run. " +
+			"If the manifest exists, the run resumes into it: the input state must be part of the recorded trace, " +
+			"and recorded files are verified instead of written again.",
+		TakesFile: true,
+		Required:  false,
+	}
 	RunInfoAtFlag = &cli.GenericFlag{
 		Name:     "info-at",
 		Usage:    "step pattern to print info at: " + patternHelp,
@@ -111,23 +169,78 @@ var (
 		TakesFile: true,
 		Required:  false,
 	}
+	RunGDBFlag = &cli.StringFlag{
+		Name: "gdb",
+		Usage: "address to listen on for a gdb remote protocol connection (e.g. localhost:1234), before executing any step. " +
+			"The run continues without the debugger when it detaches.",
+		Required: false,
+	}
+	RunExitReportFlag = &cli.PathFlag{
+		Name: "exit-report",
+		Usage: "path to write a report to when the run stops, also when it fails: " +
+			"the exit code and its classification, the last syscalls, the PC and its symbol, and the last pre-image key requested.",
+		TakesFile: true,
+		Required:  false,
+	}
+
+	RunPageStoreFlag = &cli.PathFlag{
+		Name: "page-store",
+		Usage: "path of a new file to evict memory pages to when more than --max-resident-pages are in memory, " +
+			"to run programs that use more memory than the host has. The file is removed when the run ends.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunMaxResidentPagesFlag = &cli.IntFlag{
+		Name:     "max-resident-pages",
+		Usage:    "limit on the number of memory pages kept in host memory with --page-store",
+		Value:    1 << 18,
+		Required: false,
+	}
+	RunMaxPagesFlag = &cli.IntFlag{
+		Name:     "max-pages",
+		Usage:    "limit on the number of allocated memory pages. 0 to disable.",
+		Required: false,
+	}
+	RunMaxStateSizeFlag = &cli.Uint64Flag{
+		Name:     "max-state-size",
+		Usage:    "limit on the estimated serialized state size (in bytes). 0 to disable.",
+		Required: false,
+	}
+	RunStateLimitModeFlag = &cli.StringFlag{
+		Name:     "state-limit-mode",
+		Usage:    "action when the state exceeds --max-pages or --max-state-size: 'warn' to log a warning, 'refuse' to stop with an error",
+		Value:    stateLimitModeWarn,
+		Required: false,
+	}
+	RunUnknownSyscallFlag = &cli.StringFlag{
+		Name: "unknown-syscall",
+		Usage: "action when the program executes a syscall the VM doesn't know: 'noop' to execute it like the contract does, " +
+			"'warn' to also log a warning the first time each syscall is executed, 'halt' to stop with an error",
+		Value:    string(mipsevm.UnknownSyscallNoop),
+		Required: false,
+	}
+	RunStrictFlag = &cli.BoolFlag{
+		Name:     "strict",
+		Usage:    "stop with an error when the program loads or stores at an unaligned address, instead of aligning the address down like the contract does",
+		Required: false,
+	}
+	RunFastFlag = &cli.BoolFlag{
+		Name: "fast",
+		Usage: "execute the steps in between the steps to stop at, prove, snapshot or log info at, with the code translated to blocks " +
+			"instead of stepping each instruction. Has no effect with trace-meta, trace, chrome-trace, profile, exit-report, debug or strict, which observe each step",
+		Required: false,
+	}
 
 	OutFilePerm = os.FileMode(0o755)
 )
 
-type Proof struct {
-	Step uint64 `json:"step"`
+// maxFastSteps bounds the steps of a fast execution batch, to check for cancellation regularly.
+const maxFastSteps = 1 << 16
 
-	Pre  common.Hash `json:"pre"`
-	Post common.Hash `json:"post"`
-
-	StateData hexutil.Bytes `json:"state-data"`
-	ProofData hexutil.Bytes `json:"proof-data"`
-
-	OracleKey    hexutil.Bytes `json:"oracle-key,omitempty"`
-	OracleValue  hexutil.Bytes `json:"oracle-value,omitempty"`
-	OracleOffset uint32        `json:"oracle-offset,omitempty"`
-}
+const (
+	stateLimitModeWarn   = "warn"
+	stateLimitModeRefuse = "refuse"
+)
 
 type rawHint string
 
@@ -182,7 +295,7 @@ func NewProcessPreimageOracle(name string, args []string, stdout log.Logger, std
 	hostClientIO := preimage.NewFilePoller(ctx, hClientRW, clientPollTimeout)
 	out := &ProcessPreimageOracle{
 		pCl:      preimage.NewOracleClient(preimageClientIO),
-		hCl:      preimage.NewHintWriter(hostClientIO),
+		hCl:      preimage.NewHintWriterV2(hostClientIO),
 		cmd:      cmd,
 		waitErr:  make(chan error),
 		cancelIO: cancelIO,
@@ -241,7 +354,7 @@ func Guard(proc *os.ProcessState, fn StepFn) StepFn {
 		wit, err := fn(proof)
 		if err != nil {
 			if proc.Exited() {
-				return nil, fmt.Errorf("pre-image server exited with code %d, resulting in err %w", proc.ExitCode(), err)
+				return nil, fmt.Errorf("%w with code %d, resulting in err %w", ErrPreimageServerExited, proc.ExitCode(), err)
 			} else {
 				return nil, err
 			}
@@ -250,9 +363,67 @@ func Guard(proc *os.ProcessState, fn StepFn) StepFn {
 	}
 }
 
+// RecoverHintErrors turns the panic of a hint that the pre-image server failed to process into an error of the step,
+// so the run fails fast with the error of the server, instead of when the pre-images of the hint turn out to be missing.
+func RecoverHintErrors(fn StepFn) StepFn {
+	return func(proof bool) (wit *mipsevm.StepWitness, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				hintErr, ok := r.(*preimage.HintError)
+				if !ok {
+					panic(r)
+				}
+				wit, err = nil, hintErr
+			}
+		}()
+		return fn(proof)
+	}
+}
+
+// serveGDB waits for a debugger to connect on addr, and serves it until it detaches.
+// The steps the debugger executes are not included in proofs, snapshots or stop conditions of the run.
+func serveGDB(l log.Logger, addr string, vm mipsevm.FPVM, stepFn StepFn) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for debugger: %w", err)
+	}
+	defer listener.Close()
+	l.Info("Waiting for debugger to connect", "addr", listener.Addr())
+	conn, err := listener.Accept()
+	if err != nil {
+		return fmt.Errorf("failed to accept debugger connection: %w", err)
+	}
+	defer conn.Close()
+	l.Info("Debugger connected", "remote", conn.RemoteAddr())
+	server := gdbstub.NewServer(l, vm, func() error {
+		_, err := stepFn(false)
+		return err
+	})
+	if err := server.Serve(conn); err != nil {
+		return fmt.Errorf("debugger session failed: %w", err)
+	}
+	return nil
+}
+
 var _ mipsevm.PreimageOracle = (*ProcessPreimageOracle)(nil)
 
-func Run(ctx *cli.Context) error {
+// RunResult is the output of the run command in JSON format.
+type RunResult struct {
+	StartStep uint64       `json:"startStep"`
+	Step      uint64       `json:"step"`
+	PC        mipsevm.Word `json:"pc"`
+	Exited    bool         `json:"exited"`
+	ExitCode  uint8        `json:"exitCode"`
+	StateHash common.Hash  `json:"stateHash"`
+	// Output is the path the final state was written to, if any.
+	Output string `json:"output,omitempty"`
+	// Proofs are the paths of the proofs written during the run.
+	Proofs []string `json:"proofs"`
+	// Snapshots are the paths of the snapshots written during the run.
+	Snapshots []string `json:"snapshots"`
+}
+
+func Run(ctx *cli.Context) (runErr error) {
 	if ctx.Bool(RunPProfCPU.Name) {
 		defer profile.Start(profile.NoShutdownHook, profile.ProfilePath("."), profile.CPUProfile).Stop()
 	}
@@ -261,12 +432,23 @@ func Run(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	format, err := outputFormatFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+	if format == outputFormatJSON {
+		for _, name := range []string{RunOutputFlag.Name, RunProofFmtFlag.Name, RunSnapshotFmtFlag.Name} {
+			if ctx.String(name) == "-" {
+				return fmt.Errorf("cannot write %v to stdout with %v %v", name, OutputFormatFlag.Name, outputFormatJSON)
+			}
+		}
+	}
 
-	guestLogger := Logger(os.Stderr, log.LevelInfo)
+	guestLogger := FormatLogger(format, os.Stderr, log.LevelInfo)
 	outLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stdout")}
 	errLog := &mipsevm.LoggingWriter{Log: guestLogger.With("module", "guest", "stream", "stderr")}
 
-	l := Logger(os.Stderr, log.LevelInfo).With("module", "vm")
+	l := FormatLogger(format, os.Stderr, log.LevelInfo).With("module", "vm")
 
 	stopAtAnyPreimage := false
 	var stopAtPreimageKeyPrefix []byte
@@ -307,6 +489,19 @@ func Run(ctx *cli.Context) error {
 	}
 	stopAtPreimageLargerThan := ctx.Int(RunStopAtPreimageLargerThanFlag.Name)
 
+	stateLimits := mipsevm.StateLimits{
+		MaxPages:          ctx.Int(RunMaxPagesFlag.Name),
+		MaxSerializedSize: ctx.Uint64(RunMaxStateSizeFlag.Name),
+	}
+	stateLimitMode := ctx.String(RunStateLimitModeFlag.Name)
+	if stateLimitMode != stateLimitModeWarn && stateLimitMode != stateLimitModeRefuse {
+		return fmt.Errorf("invalid %v: %q", RunStateLimitModeFlag.Name, stateLimitMode)
+	}
+	unknownSyscallMode, err := mipsevm.ParseUnknownSyscallMode(ctx.String(RunUnknownSyscallFlag.Name))
+	if err != nil {
+		return fmt.Errorf("invalid %v: %w", RunUnknownSyscallFlag.Name, err)
+	}
+
 	// split CLI args after first '--'
 	args := ctx.Args().Slice()
 	for i, arg := range args {
@@ -319,8 +514,7 @@ func Run(ctx *cli.Context) error {
 		args = []string{""}
 	}
 
-	poOut := Logger(os.Stdout, log.LevelInfo).With("module", "host")
-	poErr := Logger(os.Stderr, log.LevelInfo).With("module", "host")
+	poOut, poErr := hostLoggers(format)
 	po, err := NewProcessPreimageOracle(args[0], args[1:], poOut, poErr)
 	if err != nil {
 		return fmt.Errorf("failed to create pre-image oracle process: %w", err)
@@ -334,10 +528,14 @@ func Run(ctx *cli.Context) error {
 		}
 	}()
 
-	stopAt := ctx.Generic(RunStopAtFlag.Name).(*StepMatcherFlag).Matcher()
-	proofAt := ctx.Generic(RunProofAtFlag.Name).(*StepMatcherFlag).Matcher()
-	snapshotAt := ctx.Generic(RunSnapshotAtFlag.Name).(*StepMatcherFlag).Matcher()
-	infoAt := ctx.Generic(RunInfoAtFlag.Name).(*StepMatcherFlag).Matcher()
+	stopAtFlag := ctx.Generic(RunStopAtFlag.Name).(*StepMatcherFlag)
+	proofAtFlag := ctx.Generic(RunProofAtFlag.Name).(*StepMatcherFlag)
+	snapshotAtFlag := ctx.Generic(RunSnapshotAtFlag.Name).(*StepMatcherFlag)
+	infoAtFlag := ctx.Generic(RunInfoAtFlag.Name).(*StepMatcherFlag)
+	stopAt := stopAtFlag.Matcher()
+	proofAt := proofAtFlag.Matcher()
+	snapshotAt := snapshotAtFlag.Matcher()
+	infoAt := infoAtFlag.Matcher()
 
 	var meta *program.Metadata
 	if metaPath := ctx.Path(RunMetaFlag.Name); metaPath == "" {
@@ -350,6 +548,17 @@ func Run(ctx *cli.Context) error {
 			meta = m
 		}
 	}
+	if expected := ctx.String(RunELFHashFlag.Name); expected != "" {
+		if metaPath := ctx.Path(RunMetaFlag.Name); metaPath == "" {
+			return fmt.Errorf("cannot verify the ELF hash without a metadata file")
+		}
+		if meta.ELFHash == (common.Hash{}) {
+			return fmt.Errorf("cannot verify the ELF hash: metadata file does not record one")
+		}
+		if err := verifyELFHash(expected, meta.ELFHash); err != nil {
+			return err
+		}
+	}
 
 	var vm mipsevm.FPVM
 	var debugProgram bool
@@ -389,22 +598,198 @@ func Run(ctx *cli.Context) error {
 		return fmt.Errorf("unknown VM type %q", vmType)
 	}
 
+	vm.SetUnknownSyscallMode(unknownSyscallMode, l)
+	vm.SetStrictMode(ctx.Bool(RunStrictFlag.Name))
+
+	if pageStorePath := ctx.Path(RunPageStoreFlag.Name); pageStorePath != "" {
+		store, err := memory.NewPageStore(pageStorePath)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := store.Close(); err != nil {
+				l.Error("Failed to close page store", "err", err)
+			}
+		}()
+		if err := vm.GetState().GetMemory().SetPageStore(store, ctx.Int(RunMaxResidentPagesFlag.Name)); err != nil {
+			return fmt.Errorf("invalid %v: %w", RunMaxResidentPagesFlag.Name, err)
+		}
+	}
+
+	var traceMeta *program.TraceMetaWriter
+	var traceMetaOut *ioutil.AtomicWriter
+	if traceMetaPath := ctx.Path(RunTraceMetaFlag.Name); traceMetaPath != "" {
+		if metaPath := ctx.Path(RunMetaFlag.Name); metaPath == "" {
+			return fmt.Errorf("cannot write trace metadata without a metadata file")
+		}
+		out, err := ioutil.NewAtomicWriterCompressed(traceMetaPath, OutFilePerm)
+		if err != nil {
+			return fmt.Errorf("failed to create trace metadata file: %w", err)
+		}
+		// Only a completed sidecar is moved into place, an aborted run leaves none behind
+		defer func() {
+			_ = out.Abort()
+		}()
+		traceMeta = program.NewTraceMetaWriter(meta, out)
+		traceMetaOut = out
+	}
+
+	var chromeTrace *chromeTracer
+	var chromeTraceOut *ioutil.AtomicWriter
+	if chromeTracePath := ctx.Path(RunChromeTraceFlag.Name); chromeTracePath != "" {
+		out, err := ioutil.NewAtomicWriterCompressed(chromeTracePath, OutFilePerm)
+		if err != nil {
+			return fmt.Errorf("failed to create chrome trace file: %w", err)
+		}
+		defer func() {
+			_ = out.Abort()
+		}()
+		chromeTrace, err = newChromeTracer(meta, out, ctx.Uint64(RunChromeTraceMinStepsFlag.Name))
+		if err != nil {
+			return err
+		}
+		chromeTraceOut = out
+	}
+
+	// stepTracers observe the steps of the VM, see tracer.Multi
+	var stepTracers []mipsevm.StepTracer
+
+	// closeTrace is set while the instruction trace is open.
+	// The trace is kept if the run fails, as it is most useful to find out why.
+	var closeTrace func() error
+	defer func() {
+		if closeTrace != nil {
+			if err := closeTrace(); err != nil {
+				l.Error("Failed to write instruction trace", "err", err)
+			}
+		}
+	}()
+	if tracePath := ctx.Path(RunTraceFlag.Name); tracePath != "" {
+		out, err := ioutil.OpenCompressed(tracePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, OutFilePerm)
+		if err != nil {
+			return fmt.Errorf("failed to create instruction trace file: %w", err)
+		}
+		stepTracer := tracer.NewJSONTracer(out)
+		if len(meta.Symbols) > 0 {
+			stepTracer.SetSymbolizer(meta)
+		}
+		stepTracers = append(stepTracers, stepTracer)
+		closeTrace = func() error {
+			vm.SetTracer(nil)
+			if err := stepTracer.Close(); err != nil {
+				_ = out.Close()
+				return err
+			}
+			return out.Close()
+		}
+	}
+
+	var profiler *tracer.Profiler
+	if profilePath := ctx.Path(RunProfileFlag.Name); profilePath != "" {
+		profiler = tracer.NewProfiler()
+		stepTracers = append(stepTracers, profiler)
+		// the profile up to the last step is written, also if the run fails
+		defer func() {
+			if err := writeProfile(profilePath, profiler.Profile(meta)); err != nil {
+				l.Error("Failed to write profile", "err", err)
+			}
+		}()
+	}
+	if len(stepTracers) > 0 {
+		vm.SetTracer(tracer.Multi(stepTracers...))
+	}
+
 	proofFmt := ctx.String(RunProofFmtFlag.Name)
 	snapshotFmt := ctx.String(RunSnapshotFmtFlag.Name)
 
 	stepFn := vm.Step
+	// fastSteps is the number of steps runStepsFn executes
+	var fastSteps uint64
+	runStepsFn := StepFn(func(proof bool) (*mipsevm.StepWitness, error) {
+		return nil, vm.RunSteps(fastSteps)
+	})
 	if po.cmd != nil {
-		stepFn = Guard(po.cmd.ProcessState, stepFn)
+		stepFn = Guard(po.cmd.ProcessState, RecoverHintErrors(stepFn))
+		runStepsFn = Guard(po.cmd.ProcessState, RecoverHintErrors(runStepsFn))
+	}
+
+	if gdbAddr := ctx.String(RunGDBFlag.Name); gdbAddr != "" {
+		if err := serveGDB(l, gdbAddr, vm, stepFn); err != nil {
+			return err
+		}
 	}
 
 	start := time.Now()
 
 	state := vm.GetState()
 	startStep := state.GetStep()
+	startPages := state.GetMemory().PageCount()
+
+	// checkStateLimits is called whenever the number of pages changes.
+	// It only warns once, when the state first exceeds the limits.
+	stateLimitWarned := false
+	checkStateLimits := func() error {
+		size := mipsevm.EstimateStateSize(state)
+		if err := stateLimits.Check(size); err != nil {
+			if stateLimitMode == stateLimitModeRefuse {
+				return fmt.Errorf("at step %d: %w", state.GetStep(), err)
+			}
+			if !stateLimitWarned {
+				l.Warn("State exceeds limits", "step", state.GetStep(), "pages", size.Pages, "size", size.SerializedSize, "err", err)
+				stateLimitWarned = true
+			}
+		}
+		return nil
+	}
+	if err := checkStateLimits(); err != nil {
+		return err
+	}
+	var exitReport *exitReporter
+	if exitReportPath := ctx.Path(RunExitReportFlag.Name); exitReportPath != "" {
+		exitReport = newExitReporter()
+		defer func() {
+			report := exitReport.report(state, meta, runErr)
+			if err := jsonutil.WriteJSON(exitReportPath, report, OutFilePerm); err != nil {
+				l.Error("Failed to write exit report", "err", err)
+			}
+		}()
+	}
+	// The steps in between the matched steps are executed in batches, unless each step is observed
+	fast := ctx.Bool(RunFastFlag.Name)
+	if fast && (traceMeta != nil || len(stepTracers) > 0 || chromeTrace != nil || exitReport != nil || debugProgram || ctx.Bool(RunStrictFlag.Name)) {
+		l.Warn("Fast execution is disabled, the steps are observed individually")
+		fast = false
+	}
+	vm.SetFastExecution(fast)
+
+	lastPages := startPages
+	result := RunResult{StartStep: startStep, Proofs: []string{}, Snapshots: []string{}}
+
+	var manifest *RunManifest
+	if manifestPath := ctx.Path(RunManifestFlag.Name); manifestPath != "" {
+		manifest, err = LoadRunManifest(manifestPath, vmType)
+		if err != nil {
+			return err
+		}
+		_, startHash := state.EncodeWitness()
+		if err := manifest.CheckResume(startStep, startHash); err != nil {
+			return err
+		}
+		if err := manifest.RecordState(StateAt{Step: startStep, Hash: startHash}); err != nil {
+			return err
+		}
+		l.Info("Using run manifest", "path", manifestPath, "files", len(manifest.Files), "covered", manifest.Covered)
+		// Keep the files written so far, also when the run fails
+		defer func() {
+			if err := manifest.Save(); err != nil {
+				l.Error("Failed to write run manifest", "err", err)
+			}
+		}()
+	}
 
 	for !state.GetExited() {
 		step := state.GetStep()
-		if step%100 == 0 { // don't do the ctx err check (includes lock) too often
+		if fast || step%100 == 0 { // don't do the ctx err check (includes lock) too often, fast execution runs batches of steps
 			if err := ctx.Context.Err(); err != nil {
 				return err
 			}
@@ -414,18 +799,20 @@ func Run(ctx *cli.Context) error {
 			delta := time.Since(start)
 			l.Info("processing",
 				"step", step,
-				"pc", mipsevm.HexU32(state.GetPC()),
-				"insn", mipsevm.HexU32(state.GetMemory().GetMemory(state.GetPC())),
+				"pc", mipsevm.HexWord(state.GetPC()),
+				"insn", mipsevm.HexU32(state.GetMemory().GetUint32(state.GetPC())),
 				"ips", float64(step-startStep)/(float64(delta)/float64(time.Second)),
 				"pages", state.GetMemory().PageCount(),
+				"evictedPages", state.GetMemory().EvictedPageCount(),
+				"pageGrowth", pageGrowthRate(state.GetMemory().PageCount()-startPages, step-startStep),
 				"mem", state.GetMemory().Usage(),
-				"name", meta.LookupSymbol(state.GetPC()),
+				"name", symbolName(meta, state.GetPC()),
 			)
 		}
 
 		if vm.CheckInfiniteLoop() {
 			// don't loop forever when we get stuck because of an unexpected bad program
-			return fmt.Errorf("detected an infinite loop at step %d", step)
+			return fmt.Errorf("%w at step %d", ErrInfiniteLoop, step)
 		}
 
 		if stopAt(state) {
@@ -433,42 +820,99 @@ func Run(ctx *cli.Context) error {
 			break
 		}
 
+		if traceMeta != nil {
+			if err := traceMeta.Record(step, state.GetPC()); err != nil {
+				return err
+			}
+		}
+
 		if snapshotAt(state) {
-			if err := jsonutil.WriteJSON(fmt.Sprintf(snapshotFmt, step), state, OutFilePerm); err != nil {
-				return fmt.Errorf("failed to write state snapshot: %w", err)
+			path := fmt.Sprintf(snapshotFmt, step)
+			if recorded, err := manifestRecorded(manifest, ManifestSnapshot, step, state); err != nil {
+				return err
+			} else if !recorded {
+				if err := jsonutil.WriteJSON(path, state, OutFilePerm); err != nil {
+					return fmt.Errorf("failed to write state snapshot: %w", err)
+				}
+				result.Snapshots = append(result.Snapshots, path)
+				if err := manifestRecord(manifest, ManifestSnapshot, path, step, state); err != nil {
+					return err
+				}
+				// Snapshots are the points a later run resumes from, so checkpoint the manifest
+				if manifest != nil {
+					if err := manifest.Save(); err != nil {
+						return fmt.Errorf("failed to write run manifest: %w", err)
+					}
+				}
 			}
 		}
 
+		if exitReport != nil {
+			exitReport.observe(state)
+		}
+		if chromeTrace != nil {
+			if err := chromeTrace.observe(state); err != nil {
+				return err
+			}
+		}
+
+		proofRecorded := false
 		if proofAt(state) {
+			proofRecorded, err = manifestRecorded(manifest, ManifestProof, step, state)
+			if err != nil {
+				return err
+			}
+		}
+		if proofAt(state) && !proofRecorded {
+			path := fmt.Sprintf(proofFmt, step)
+			var preStateHash common.Hash
+			if manifest != nil {
+				_, preStateHash = state.EncodeWitness()
+			}
 			witness, err := stepFn(true)
 			if err != nil {
-				return fmt.Errorf("failed at proof-gen step %d (PC: %08x): %w", step, state.GetPC(), err)
+				return fmt.Errorf("failed at proof-gen step %d (PC: %s): %w", step, program.FormatPC(meta, state.GetPC()), err)
 			}
 			_, postStateHash := state.EncodeWitness()
-			proof := &Proof{
-				Step:      step,
-				Pre:       witness.StateHash,
-				Post:      postStateHash,
-				StateData: witness.State,
-				ProofData: witness.ProofData,
-			}
-			if witness.HasPreimage() {
-				proof.OracleKey = witness.PreimageKey[:]
-				proof.OracleValue = witness.PreimageValue
-				proof.OracleOffset = witness.PreimageOffset
-			}
-			if err := jsonutil.WriteJSON(fmt.Sprintf(proofFmt, step), proof, OutFilePerm); err != nil {
+			stepProof := proof.FromWitness(step, witness, postStateHash)
+			if err := jsonutil.WriteJSON(path, stepProof, OutFilePerm); err != nil {
 				return fmt.Errorf("failed to write proof data: %w", err)
 			}
+			result.Proofs = append(result.Proofs, path)
+			if manifest != nil && path != "-" {
+				if err := manifest.RecordFile(ManifestProof, path, step, preStateHash); err != nil {
+					return err
+				}
+			}
+		} else if fast {
+			// execute up to the next step to stop at, prove, snapshot or log info at
+			next := min(stopAtFlag.NextMatch(step+1), proofAtFlag.NextMatch(step+1), snapshotAtFlag.NextMatch(step+1), infoAtFlag.NextMatch(step+1))
+			fastSteps = min(next-step, maxFastSteps)
+			_, err = runStepsFn(false)
+			if err != nil {
+				return fmt.Errorf("failed at step %d (PC: %s): %w", state.GetStep(), program.FormatPC(meta, state.GetPC()), err)
+			}
 		} else {
 			_, err = stepFn(false)
 			if err != nil {
-				return fmt.Errorf("failed at step %d (PC: %08x): %w", step, state.GetPC(), err)
+				return fmt.Errorf("failed at step %d (PC: %s): %w", step, program.FormatPC(meta, state.GetPC()), err)
+			}
+		}
+
+		if pages := state.GetMemory().PageCount(); pages != lastPages {
+			lastPages = pages
+			if err := checkStateLimits(); err != nil {
+				return err
 			}
 		}
 
 		lastPreimageKey, lastPreimageValue, lastPreimageOffset := vm.LastPreimage()
 		if lastPreimageOffset != ^uint32(0) {
+			if chromeTrace != nil {
+				if err := chromeTrace.observePreimage(step, lastPreimageKey, lastPreimageValue); err != nil {
+					return err
+				}
+			}
 			if stopAtAnyPreimage {
 				l.Info("Stopping at preimage read")
 				break
@@ -487,21 +931,139 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 	l.Info("Execution stopped", "exited", state.GetExited(), "code", state.GetExitCode())
+	stats := vm.Stats()
+	logSyscallStats(l, stats.Syscalls)
+	if statsFile := ctx.Path(RunSyscallStatsFlag.Name); statsFile != "" {
+		if err := jsonutil.WriteJSON(statsFile, stats, OutFilePerm); err != nil {
+			return fmt.Errorf("failed to write syscall statistics: %w", err)
+		}
+	}
 	if debugProgram {
 		vm.Traceback()
 	}
+	if traceMeta != nil {
+		if err := traceMeta.Flush(); err != nil {
+			return err
+		}
+		if err := traceMetaOut.Close(); err != nil {
+			return fmt.Errorf("failed to write trace metadata: %w", err)
+		}
+	}
+	if closeTrace != nil {
+		err := closeTrace()
+		closeTrace = nil
+		if err != nil {
+			return fmt.Errorf("failed to write instruction trace: %w", err)
+		}
+	}
+	if chromeTrace != nil {
+		if err := chromeTrace.Close(); err != nil {
+			return err
+		}
+		if err := chromeTraceOut.Close(); err != nil {
+			return fmt.Errorf("failed to write chrome trace: %w", err)
+		}
+	}
+
+	if manifest != nil {
+		_, endHash := state.EncodeWitness()
+		if err := manifest.RecordRun(StateAt{Step: startStep, Hash: manifest.StateHashes[startStep]}, StateAt{Step: state.GetStep(), Hash: endHash}); err != nil {
+			return err
+		}
+	}
 
 	if err := jsonutil.WriteJSON(ctx.Path(RunOutputFlag.Name), state, OutFilePerm); err != nil {
 		return fmt.Errorf("failed to write state output: %w", err)
 	}
 	if debugInfoFile := ctx.Path(RunDebugInfoFlag.Name); debugInfoFile != "" {
-		if err := jsonutil.WriteJSON(debugInfoFile, vm.GetDebugInfo(), OutFilePerm); err != nil {
+		debugInfo := vm.GetDebugInfo()
+		debugInfo.EstimatedStateSize = hexutil.Uint64(mipsevm.EstimateStateSize(state).SerializedSize)
+		debugInfo.PageGrowthRate = pageGrowthRate(state.GetMemory().PageCount()-startPages, state.GetStep()-startStep)
+		if err := jsonutil.WriteJSON(debugInfoFile, debugInfo, OutFilePerm); err != nil {
 			return fmt.Errorf("failed to write benchmark data: %w", err)
 		}
 	}
+	if format == outputFormatJSON {
+		result.Step = state.GetStep()
+		result.PC = state.GetPC()
+		result.Exited = state.GetExited()
+		result.ExitCode = state.GetExitCode()
+		_, result.StateHash = state.EncodeWitness()
+		result.Output = ctx.Path(RunOutputFlag.Name)
+		return writeJSONResult(ctx.App.Writer, result)
+	}
 	return nil
 }
 
+// manifestRecorded returns true if the manifest has already recorded the file of the kind at the step,
+// after checking the current state matches the recorded state.
+func manifestRecorded(manifest *RunManifest, kind ManifestFileKind, step uint64, state mipsevm.FPVMState) (bool, error) {
+	if manifest == nil {
+		return false, nil
+	}
+	f, ok := manifest.File(kind, step)
+	if !ok {
+		return false, nil
+	}
+	if _, hash := state.EncodeWitness(); hash != f.StateHash {
+		return false, fmt.Errorf("%w: state hash %v at step %d, recorded %s has %v", ErrManifestMismatch, hash, step, kind, f.StateHash)
+	}
+	return true, nil
+}
+
+func manifestRecord(manifest *RunManifest, kind ManifestFileKind, path string, step uint64, state mipsevm.FPVMState) error {
+	if manifest == nil || path == "-" {
+		return nil
+	}
+	_, hash := state.EncodeWitness()
+	return manifest.RecordFile(kind, path, step, hash)
+}
+
+// logSyscallStats logs the statistics of each syscall, to tell the time spent on oracle I/O from the compute.
+func logSyscallStats(l log.Logger, stats []mipsevm.SyscallStat) {
+	for _, stat := range stats {
+		l.Info("Syscall statistics", "num", stat.Num, "name", syscallName(stat.Num), "count", stat.Count,
+			"bytesRead", stat.BytesRead, "bytesWritten", stat.BytesWritten,
+			"preimageReads", stat.PreimageReads, "preimageBytes", stat.PreimageBytes, "duration", stat.Duration)
+	}
+}
+
+// profileTopSymbols is the number of guest functions listed in the text report of the profile.
+const profileTopSymbols = 100
+
+// writeProfile writes the profile as JSON if the path ends in .json, or as a text report otherwise.
+func writeProfile(path string, profile *tracer.Profile) error {
+	if strings.HasSuffix(path, ".json") {
+		return jsonutil.WriteJSON(path, profile, OutFilePerm)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, OutFilePerm)
+	if err != nil {
+		return fmt.Errorf("failed to create profile file: %w", err)
+	}
+	if err := profile.WriteText(f, profileTopSymbols); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+	return f.Close()
+}
+
+// symbolName returns the symbol containing the pc with the offset into it, e.g. runtime.mallocgc+0x54,
+// or why there is none, see program.Metadata.LookupSymbol.
+func symbolName(meta *program.Metadata, pc mipsevm.Word) string {
+	if name := meta.Symbolize(pc); name != "" {
+		return name
+	}
+	return meta.LookupSymbol(pc)
+}
+
+// pageGrowthRate returns the number of pages allocated per million steps.
+func pageGrowthRate(pages int, steps uint64) float64 {
+	if steps == 0 {
+		return 0
+	}
+	return float64(pages) / float64(steps) * 1_000_000
+}
+
 var RunCommand = &cli.Command{
 	Name:        "run",
 	Usage:       "Run VM step(s) and generate proof data to replicate onchain.",
@@ -520,9 +1082,28 @@ var RunCommand = &cli.Command{
 		RunStopAtPreimageTypeFlag,
 		RunStopAtPreimageLargerThanFlag,
 		RunMetaFlag,
+		RunELFHashFlag,
+		RunTraceMetaFlag,
+		RunTraceFlag,
+		RunChromeTraceFlag,
+		RunChromeTraceMinStepsFlag,
+		RunProfileFlag,
+		RunSyscallStatsFlag,
+		RunManifestFlag,
 		RunInfoAtFlag,
 		RunPProfCPU,
 		RunDebugFlag,
 		RunDebugInfoFlag,
+		RunGDBFlag,
+		RunExitReportFlag,
+		RunPageStoreFlag,
+		RunMaxResidentPagesFlag,
+		RunMaxPagesFlag,
+		RunMaxStateSizeFlag,
+		RunStateLimitModeFlag,
+		RunUnknownSyscallFlag,
+		RunStrictFlag,
+		RunFastFlag,
+		OutputFormatFlag,
 	},
 }
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/tracer"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+func TestRecoverHintErrors(t *testing.T) {
+	t.Run("HintError", func(t *testing.T) {
+		hintErr := &preimage.HintError{Hint: "l1-block-header 0x01", Message: "not found"}
+		step := RecoverHintErrors(func(proof bool) (*mipsevm.StepWitness, error) {
+			panic(hintErr)
+		})
+		wit, err := step(true)
+		require.Nil(t, wit)
+		require.ErrorIs(t, err, hintErr)
+	})
+
+	t.Run("OtherPanic", func(t *testing.T) {
+		step := RecoverHintErrors(func(proof bool) (*mipsevm.StepWitness, error) {
+			panic(errors.New("boom"))
+		})
+		require.PanicsWithError(t, "boom", func() { _, _ = step(false) })
+	})
+
+	t.Run("NoPanic", func(t *testing.T) {
+		expected := &mipsevm.StepWitness{}
+		step := RecoverHintErrors(func(proof bool) (*mipsevm.StepWitness, error) {
+			return expected, nil
+		})
+		wit, err := step(true)
+		require.NoError(t, err)
+		require.Same(t, expected, wit)
+	})
+}
+
+func TestWriteProfile(t *testing.T) {
+	dir := t.TempDir()
+	profile := &tracer.Profile{Total: 3, Ops: []tracer.OpCount{{Op: "addiu", Count: 2}, {Op: "syscall", Count: 1}}}
+
+	jsonPath := filepath.Join(dir, "profile.json")
+	require.NoError(t, writeProfile(jsonPath, profile))
+	loaded, err := jsonutil.LoadJSON[tracer.Profile](jsonPath)
+	require.NoError(t, err)
+	require.Equal(t, profile, loaded)
+
+	textPath := filepath.Join(dir, "profile.txt")
+	require.NoError(t, writeProfile(textPath, profile))
+	text, err := os.ReadFile(textPath)
+	require.NoError(t, err)
+	require.Contains(t, string(text), "66.67%  addiu\n")
+}
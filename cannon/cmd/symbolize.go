@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+var (
+	SymbolizeMetaFlag = &cli.PathFlag{
+		Name:      "meta",
+		Usage:     "path of the metadata file written by load-elf, to load the symbols from",
+		TakesFile: true,
+	}
+	SymbolizeELFFlag = &cli.PathFlag{
+		Name:      "elf",
+		Usage:     "path of the ELF file of the program, to load the symbols from instead of a metadata file",
+		TakesFile: true,
+	}
+)
+
+// SymbolizeResult is the output of the symbolize command in JSON format.
+type SymbolizeResult struct {
+	Symbols []SymbolizedPC `json:"symbols"`
+}
+
+type SymbolizedPC struct {
+	PC hexutil.Uint64 `json:"pc"`
+	// Symbol is the symbol containing the PC with the offset into it, or empty if there is none.
+	Symbol string `json:"symbol"`
+}
+
+func Symbolize(ctx *cli.Context) error {
+	format, err := outputFormatFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+	if ctx.NArg() == 0 {
+		return errors.New("expected at least one program counter argument")
+	}
+	pcs := make([]mipsevm.Word, ctx.NArg())
+	for i, arg := range ctx.Args().Slice() {
+		pc, err := parsePC(arg)
+		if err != nil {
+			return err
+		}
+		pcs[i] = pc
+	}
+
+	var symbolizer program.Symbolizer
+	metaPath, elfPath := ctx.Path(SymbolizeMetaFlag.Name), ctx.Path(SymbolizeELFFlag.Name)
+	switch {
+	case metaPath != "" && elfPath != "":
+		return fmt.Errorf("only one of --%v and --%v can be used", SymbolizeMetaFlag.Name, SymbolizeELFFlag.Name)
+	case metaPath != "":
+		meta, err := jsonutil.LoadJSON[program.Metadata](metaPath)
+		if err != nil {
+			return fmt.Errorf("failed to load metadata: %w", err)
+		}
+		symbolizer = meta
+	case elfPath != "":
+		elfProgram, err := elf.Open(elfPath)
+		if err != nil {
+			return fmt.Errorf("failed to open ELF file %q: %w", elfPath, err)
+		}
+		defer elfProgram.Close()
+		symbolizer, err = program.NewELFSymbolizer(elfProgram)
+		if err != nil {
+			return fmt.Errorf("failed to load symbols: %w", err)
+		}
+	default:
+		return fmt.Errorf("either --%v or --%v is required", SymbolizeMetaFlag.Name, SymbolizeELFFlag.Name)
+	}
+	return writeSymbolized(ctx.App.Writer, format, symbolizer, pcs)
+}
+
+// parsePC parses a program counter in hex, with or without 0x prefix, and with optional _ separators.
+func parsePC(s string) (mipsevm.Word, error) {
+	digits := strings.ReplaceAll(strings.TrimPrefix(strings.ToLower(s), "0x"), "_", "")
+	v, err := strconv.ParseUint(digits, 16, arch.WordSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid program counter %q: %w", s, err)
+	}
+	return mipsevm.Word(v), nil
+}
+
+func writeSymbolized(out io.Writer, format string, symbolizer program.Symbolizer, pcs []mipsevm.Word) error {
+	if format == outputFormatJSON {
+		result := SymbolizeResult{Symbols: make([]SymbolizedPC, len(pcs))}
+		for i, pc := range pcs {
+			result.Symbols[i] = SymbolizedPC{PC: hexutil.Uint64(pc), Symbol: symbolizer.Symbolize(pc)}
+		}
+		return writeJSONResult(out, result)
+	}
+	for _, pc := range pcs {
+		_, _ = fmt.Fprintln(out, program.FormatPC(symbolizer, pc))
+	}
+	return nil
+}
+
+var SymbolizeCommand = &cli.Command{
+	Name:      "symbolize",
+	Usage:     "Resolve program counters to the symbols of the guest program",
+	ArgsUsage: "<pc>...",
+	Description: "Resolves program counters in hex, e.g. as logged by the VM, to the symbols of the guest program containing them, " +
+		"with the offset into the symbol, e.g. runtime.mallocgc+0x54. The symbols are loaded from the metadata file or ELF file of the program.",
+	Action: Symbolize,
+	Flags: []cli.Flag{
+		SymbolizeMetaFlag,
+		SymbolizeELFFlag,
+		OutputFormatFlag,
+	},
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+func TestSymbolize(t *testing.T) {
+	meta := &program.Metadata{Symbols: []program.Symbol{
+		{Name: "runtime.mallocgc", Start: 0x08001200, Size: 0x100},
+	}}
+	metaPath := filepath.Join(t.TempDir(), "meta.json")
+	require.NoError(t, jsonutil.WriteJSON(metaPath, meta, OutFilePerm))
+
+	out, err := runCommand(t, SymbolizeCommand, "--meta", metaPath, "0x08_00_12_54", "8001000")
+	require.NoError(t, err)
+	require.Equal(t, mipsevm.HexWord(0x08001254).String()+" (runtime.mallocgc+0x54)\n"+
+		mipsevm.HexWord(0x08001000).String()+"\n", out)
+
+	out, err = runCommand(t, SymbolizeCommand, "--meta", metaPath, "--format", "json", "0x08001200", "0x10")
+	require.NoError(t, err)
+	var result SymbolizeResult
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	require.Equal(t, SymbolizeResult{Symbols: []SymbolizedPC{
+		{PC: 0x08001200, Symbol: "runtime.mallocgc"},
+		{PC: 0x10},
+	}}, result)
+
+	_, err = runCommand(t, SymbolizeCommand, "--meta", metaPath, "0xzz")
+	require.ErrorContains(t, err, "invalid program counter")
+	_, err = runCommand(t, SymbolizeCommand, "0x10")
+	require.ErrorContains(t, err, "either --meta or --elf is required")
+	_, err = runCommand(t, SymbolizeCommand, "--meta", metaPath)
+	require.ErrorContains(t, err, "expected at least one program counter")
+}
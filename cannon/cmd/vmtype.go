@@ -4,22 +4,42 @@ import (
 	"fmt"
 
 	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 )
 
 type VMType string
 
 var cannonVMType VMType = "cannon"
-var mtVMType VMType = "cannon-mt"
+
+// mtVMType is the multithreaded VM of the architecture cannon is built for.
+// The 64-bit VM only comes as a multithreaded VM.
+var mtVMType = func() VMType {
+	if arch.IsMips32 {
+		return "cannon-mt"
+	}
+	return "cannon-mt64"
+}()
 
 var VMTypeFlag = &cli.StringFlag{
 	Name:     "type",
-	Usage:    "VM type to create state for. Options are 'cannon' (default), 'cannon-mt'",
-	Value:    "cannon",
+	Usage:    "VM type to create state for. Options are 'cannon' (default) and 'cannon-mt', or 'cannon-mt64' when built for 64-bit MIPS",
+	Value:    string(defaultVMType()),
 	Required: false,
 }
 
+func defaultVMType() VMType {
+	if arch.IsMips32 {
+		return cannonVMType
+	}
+	return mtVMType
+}
+
 func vmTypeFromString(ctx *cli.Context) (VMType, error) {
 	if vmTypeStr := ctx.String(VMTypeFlag.Name); vmTypeStr == string(cannonVMType) {
+		if !arch.IsMips32 {
+			return "", fmt.Errorf("VM type %q is not supported by the 64-bit build of cannon", vmTypeStr)
+		}
 		return cannonVMType, nil
 	} else if vmTypeStr == string(mtVMType) {
 		return mtVMType, nil
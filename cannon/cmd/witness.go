@@ -3,13 +3,18 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
-	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
-	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
-	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 )
 
 var (
@@ -24,46 +29,141 @@ var (
 		Usage:     "path to write binary witness.",
 		TakesFile: true,
 	}
+	WitnessHashBackendFlag = &cli.StringFlag{
+		Name:  "hash-backend",
+		Usage: "state hash backend used to compute the witness hash",
+		Value: mipsevm.KeccakHashBackend,
+	}
+	WitnessMemProofFlag = &cli.StringSliceFlag{
+		Name:  "mem-proof",
+		Usage: "word-aligned memory address, e.g. 0x1000, to add a Merkle proof of to the output. Can be repeated.",
+	}
 )
 
+// WitnessResult is the output of the witness command in JSON format.
+type WitnessResult struct {
+	StateHash common.Hash `json:"stateHash"`
+	// Output is the path the witness was written to, if any.
+	Output string `json:"output,omitempty"`
+	// MemProofs are the Merkle proofs of the memory addresses requested with --mem-proof, in order.
+	MemProofs []MemProof `json:"memProofs,omitempty"`
+}
+
+// MemProof is a Merkle proof of a memory word against the memory root of the state,
+// in the format of the memory proofs of the onchain MIPS VM.
+type MemProof struct {
+	Address hexutil.Uint  `json:"address"`
+	Value   hexutil.Uint  `json:"value"`
+	Proof   hexutil.Bytes `json:"proof"`
+}
+
 func Witness(ctx *cli.Context) error {
+	format, err := outputFormatFromCtx(ctx)
+	if err != nil {
+		return err
+	}
 	input := ctx.Path(WitnessInputFlag.Name)
 	output := ctx.Path(WitnessOutputFlag.Name)
-	var state mipsevm.FPVMState
-	if vmType, err := vmTypeFromString(ctx); err != nil {
+	vmType, err := vmTypeFromString(ctx)
+	if err != nil {
+		return err
+	}
+	backend, err := mipsevm.LookupHashBackend(ctx.String(WitnessHashBackendFlag.Name))
+	if err != nil {
+		return fmt.Errorf("%w (available: %v)", err, strings.Join(mipsevm.HashBackendNames(), ", "))
+	}
+	hashFn, err := stateHashFn(vmType, backend)
+	if err != nil {
+		return err
+	}
+	memProofAddrs, err := parseMemProofAddrs(ctx.StringSlice(WitnessMemProofFlag.Name))
+	if err != nil {
 		return err
-	} else if vmType == cannonVMType {
-		state, err = jsonutil.LoadJSON[singlethreaded.State](input)
-		if err != nil {
-			return fmt.Errorf("invalid input state (%v): %w", input, err)
-		}
-	} else if vmType == mtVMType {
-		state, err = jsonutil.LoadJSON[multithreaded.State](input)
-		if err != nil {
-			return fmt.Errorf("invalid input state (%v): %w", input, err)
-		}
-	} else {
-		return fmt.Errorf("invalid VM type: %q", vmType)
+	}
+	state, err := loadState(vmType, input)
+	if err != nil {
+		return fmt.Errorf("invalid input state (%v): %w", input, err)
 	}
 
-	witness, h := state.EncodeWitness()
+	witness, _ := state.EncodeWitness()
+	h, err := hashFn(witness)
+	if err != nil {
+		return fmt.Errorf("failed to hash witness: %w", err)
+	}
 	if output != "" {
 		if err := os.WriteFile(output, witness, 0755); err != nil {
 			return fmt.Errorf("writing output to %v: %w", output, err)
 		}
 	}
-	fmt.Println(h.Hex())
+	memProofs, err := memoryProofs(state.GetMemory(), memProofAddrs)
+	if err != nil {
+		return err
+	}
+	if format == outputFormatJSON {
+		return writeJSONResult(ctx.App.Writer, WitnessResult{StateHash: h, Output: output, MemProofs: memProofs})
+	}
+	_, _ = fmt.Fprintln(ctx.App.Writer, h.Hex())
+	for _, p := range memProofs {
+		_, _ = fmt.Fprintln(ctx.App.Writer, p.Address, p.Value, p.Proof)
+	}
 	return nil
 }
 
+func parseMemProofAddrs(values []string) ([]mipsevm.Word, error) {
+	addrs := make([]mipsevm.Word, 0, len(values))
+	for _, v := range values {
+		addr, err := strconv.ParseUint(v, 0, arch.WordSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v address %q: %w", WitnessMemProofFlag.Name, v, err)
+		}
+		if addr&arch.ExtMask != 0 {
+			return nil, fmt.Errorf("invalid %v address %q: %w", WitnessMemProofFlag.Name, v, memory.ErrUnalignedAddress)
+		}
+		addrs = append(addrs, mipsevm.Word(addr))
+	}
+	return addrs, nil
+}
+
+// memoryProofs creates the Merkle proofs of the addresses, and checks they verify against the memory root.
+func memoryProofs(mem *memory.Memory, addrs []mipsevm.Word) ([]MemProof, error) {
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	root := mem.MerkleRoot()
+	proofs := make([]MemProof, 0, len(addrs))
+	for _, addr := range addrs {
+		value := mem.GetMemory(addr)
+		proof := mem.MerkleProof(addr)
+		if err := memory.VerifyMerkleProof(root, addr, value, proof); err != nil {
+			return nil, fmt.Errorf("memory proof of address %08x: %w", addr, err)
+		}
+		proofs = append(proofs, MemProof{Address: hexutil.Uint(addr), Value: hexutil.Uint(value), Proof: proof[:]})
+	}
+	return proofs, nil
+}
+
+func stateHashFn(vmType VMType, backend mipsevm.HashBackend) (mipsevm.HashFn, error) {
+	switch vmType {
+	case cannonVMType:
+		return singlethreaded.GetStateHashFnWithBackend(backend), nil
+	case mtVMType:
+		return multithreaded.GetStateHashFnWithBackend(backend), nil
+	default:
+		return nil, fmt.Errorf("invalid VM type: %q", vmType)
+	}
+}
+
 var WitnessCommand = &cli.Command{
 	Name:        "witness",
 	Usage:       "Convert a Cannon JSON state into a binary witness",
-	Description: "Convert a Cannon JSON state into a binary witness. The hash of the witness is written to stdout",
+	Description: "Convert a Cannon JSON state into a binary witness. The hash of the witness is written to stdout, followed by the address, value and Merkle proof of each --mem-proof address",
 	Action:      Witness,
 	Flags: []cli.Flag{
 		VMTypeFlag,
 		WitnessInputFlag,
 		WitnessOutputFlag,
+		WitnessHashBackendFlag,
+		WitnessMemProofFlag,
+		OutputFormatFlag,
 	},
 }
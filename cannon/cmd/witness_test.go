@@ -0,0 +1,68 @@
+//go:build !cannon64
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+func TestWitnessMemProof(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "state.json")
+	state := singlethreaded.CreateEmptyState()
+	state.Memory.SetMemory(0x1000, 0xaabbccdd)
+	state.Memory.SetMemory(0x13370004, 42)
+	require.NoError(t, jsonutil.WriteJSON(input, state, OutFilePerm))
+	_, stateHash := state.EncodeWitness()
+	root := state.Memory.MerkleRoot()
+
+	t.Run("json", func(t *testing.T) {
+		out, err := runCommand(t, WitnessCommand, "--input", input, "--format", "json",
+			"--mem-proof", "0x1000", "--mem-proof", "0x13370004", "--mem-proof", "0x2000")
+		require.NoError(t, err)
+		var result WitnessResult
+		require.NoError(t, json.Unmarshal([]byte(out), &result))
+		require.Equal(t, stateHash, result.StateHash)
+		require.Len(t, result.MemProofs, 3)
+		for i, expected := range []struct{ addr, value uint32 }{{0x1000, 0xaabbccdd}, {0x13370004, 42}, {0x2000, 0}} {
+			p := result.MemProofs[i]
+			require.Equal(t, hexutil.Uint(expected.addr), p.Address)
+			require.Equal(t, hexutil.Uint(expected.value), p.Value)
+			require.Len(t, p.Proof, memory.MEM_PROOF_SIZE)
+			require.NoError(t, memory.VerifyMerkleProof(root, expected.addr, expected.value, [memory.MEM_PROOF_SIZE]byte(p.Proof)))
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		out, err := runCommand(t, WitnessCommand, "--input", input, "--mem-proof", "0x1000")
+		require.NoError(t, err)
+		proof := state.Memory.MerkleProof(0x1000)
+		expected := fmt.Sprintf("%v\n0x1000 0xaabbccdd %v\n", stateHash.Hex(), hexutil.Bytes(proof[:]))
+		require.Equal(t, expected, out)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		out, err := runCommand(t, WitnessCommand, "--input", input, "--format", "json")
+		require.NoError(t, err)
+		require.NotContains(t, out, "memProofs")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := runCommand(t, WitnessCommand, "--input", input, "--mem-proof", "0x1001")
+		require.ErrorIs(t, err, memory.ErrUnalignedAddress)
+		_, err = runCommand(t, WitnessCommand, "--input", input, "--mem-proof", "foo")
+		require.ErrorContains(t, err, `invalid mem-proof address "foo"`)
+		_, err = runCommand(t, WitnessCommand, "--input", input, "--mem-proof", "0x100000000")
+		require.ErrorContains(t, err, "out of range")
+	})
+}
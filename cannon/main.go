@@ -8,21 +8,11 @@ import (
 	"os/signal"
 	"syscall"
 
-	"github.com/urfave/cli/v2"
-
 	"github.com/ethereum-optimism/optimism/cannon/cmd"
 )
 
 func main() {
-	app := cli.NewApp()
-	app.Name = "cannon"
-	app.Usage = "MIPS Fault Proof tool"
-	app.Description = "MIPS Fault Proof tool"
-	app.Commands = []*cli.Command{
-		cmd.LoadELFCommand,
-		cmd.WitnessCommand,
-		cmd.RunCommand,
-	}
+	app := cmd.NewApp()
 	ctx, cancel := context.WithCancel(context.Background())
 
 	c := make(chan os.Signal, 1)
@@ -0,0 +1,16 @@
+// Package arch defines the architecture the VM is built for: the register width, the memory layout,
+// and the syscall numbers of the guest ABI.
+// The VM executes 32-bit MIPS programs by default, and 64-bit MIPS programs when built with the cannon64 tag.
+package arch
+
+// ByteOrder encodes and decodes words of the register width, in the big-endian byte order of the VM.
+type ByteOrder interface {
+	Word([]byte) Word
+	AppendWord([]byte, Word) []byte
+	PutWord([]byte, Word)
+}
+
+var _ ByteOrder = ByteOrderWord
+
+// UndefinedSysNr is the syscall number of syscalls that do not exist in the ABI of the architecture.
+const UndefinedSysNr = ^Word(0)
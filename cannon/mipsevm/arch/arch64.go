@@ -0,0 +1,109 @@
+//go:build cannon64
+
+package arch
+
+import "encoding/binary"
+
+type (
+	// Word is an unsigned integer of the register width
+	Word = uint64
+	// SignedWord is a signed integer of the register width
+	SignedWord = int64
+)
+
+const (
+	IsMips32      = false
+	WordSize      = 64
+	WordSizeBytes = WordSize >> 3
+	PageAddrSize  = 12
+	PageKeySize   = WordSize - PageAddrSize
+
+	// MemProofLeafCount is the number of 32-byte nodes in a memory proof: the leaf, and a sibling per level of the tree
+	MemProofLeafCount = WordSize - 5 + 1
+	MemProofSize      = MemProofLeafCount * 32
+
+	AddressMask = 0xFFFFFFFFFFFFFFF8
+	ExtMask     = 0x7
+
+	HeapStart       = 0x10_00_00_00_00_00_00_00
+	HeapEnd         = 0x60_00_00_00_00_00_00_00
+	ProgramBreak    = 0x40_00_00_00_00_00_00_00
+	HighMemoryStart = 0x7F_FF_FF_FF_D0_00_00_00
+)
+
+// 64-bit syscall codes, of the n64 ABI.
+// See https://github.com/torvalds/linux/blob/master/arch/mips/kernel/syscalls/syscall_n64.tbl
+const (
+	SysMmap         = 5009
+	SysBrk          = 5012
+	SysClone        = 5055
+	SysExitGroup    = 5205
+	SysRead         = 5000
+	SysWrite        = 5001
+	SysFcntl        = 5070
+	SysExit         = 5058
+	SysSchedYield   = 5023
+	SysGetTID       = 5178
+	SysFutex        = 5194
+	SysOpen         = 5002
+	SysNanosleep    = 5034
+	SysClockGetTime = 5222
+)
+
+// 64-bit noop syscall codes
+const (
+	SysMunmap        = 5011
+	SysGetAffinity   = 5196
+	SysMadvise       = 5027
+	SysRtSigprocmask = 5014
+	SysSigaltstack   = 5129
+	SysRtSigaction   = 5013
+	SysPrlimit64     = 5297
+	SysClose         = 5003
+	SysPread64       = 5016
+	SysFstat         = 5005
+	SysFstat64       = UndefinedSysNr
+	SysOpenAt        = 5247
+	SysReadlink      = 5087
+	SysReadlinkAt    = 5257
+	SysIoctl         = 5015
+	SysEpollCreate1  = 5285
+	SysPipe2         = 5287
+	SysEpollCtl      = 5208
+	SysEpollPwait    = 5272
+	SysEventFd2      = 5284
+	SysGetRandom     = 5313
+	SysUname         = 5061
+	SysStat64        = UndefinedSysNr
+	SysGetuid        = 5100
+	SysGetgid        = 5102
+	SysLlseek        = UndefinedSysNr
+	SysLseek         = 5008
+	SysGetRLimit     = 5095
+	SysMinCore       = 5026
+	SysTgkill        = 5225
+)
+
+// 64-bit profiling-related syscall codes
+const (
+	SysSetITimer    = 5036
+	SysTimerCreate  = 5216
+	SysTimerSetTime = 5217
+	SysTimerDelete  = 5220
+)
+
+type byteOrder64 struct{}
+
+func (byteOrder64) Word(b []byte) Word {
+	return binary.BigEndian.Uint64(b)
+}
+
+func (byteOrder64) AppendWord(b []byte, v Word) []byte {
+	return binary.BigEndian.AppendUint64(b, v)
+}
+
+func (byteOrder64) PutWord(b []byte, v Word) {
+	binary.BigEndian.PutUint64(b, v)
+}
+
+var ByteOrderWord = byteOrder64{}
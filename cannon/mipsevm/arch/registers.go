@@ -0,0 +1,43 @@
+package arch
+
+// Indices of the general purpose registers, by their name in the MIPS ABI.
+// Registers 8 to 15 are named differently in the 32-bit (t0-t7) and 64-bit (a4-a7, t0-t3) ABIs, and are left out.
+const (
+	RegZero = 0
+	RegAT   = 1
+	RegV0   = 2
+	RegV1   = 3
+	RegA0   = 4
+	RegA1   = 5
+	RegA2   = 6
+	RegA3   = 7
+	RegS0   = 16
+	RegS1   = 17
+	RegS2   = 18
+	RegS3   = 19
+	RegS4   = 20
+	RegS5   = 21
+	RegS6   = 22
+	RegS7   = 23
+	RegT8   = 24
+	RegT9   = 25
+	RegK0   = 26
+	RegK1   = 27
+	RegGP   = 28
+	RegSP   = 29
+	RegFP   = 30
+	RegRA   = 31
+)
+
+// Registers of the Linux syscall convention.
+// The syscall number is passed in v0 and the arguments in a0 to a3.
+// The result is returned in v0, and a3 is set to the errno, or 0 if the syscall succeeded.
+const (
+	RegSyscallNum    = RegV0
+	RegSyscallParam1 = RegA0
+	RegSyscallParam2 = RegA1
+	RegSyscallParam3 = RegA2
+	RegSyscallParam4 = RegA3
+	RegSyscallRet    = RegV0
+	RegSyscallErrno  = RegA3
+)
@@ -7,4 +7,8 @@ type DebugInfo struct {
 	MemoryUsed          hexutil.Uint64 `json:"memory_used"`
 	NumPreimageRequests int            `json:"num_preimage_requests"`
 	TotalPreimageSize   int            `json:"total_preimage_size"`
+	// EstimatedStateSize is an upper bound of the serialized size of the final state
+	EstimatedStateSize hexutil.Uint64 `json:"estimated_state_size,omitempty"`
+	// PageGrowthRate is the number of memory pages allocated per million steps during the run
+	PageGrowthRate float64 `json:"page_growth_rate,omitempty"`
 }
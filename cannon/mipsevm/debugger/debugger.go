@@ -0,0 +1,294 @@
+// Package debugger implements breakpoints and watchpoints on the steps executed by a VM.
+package debugger
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+type Word = mipsevm.Word
+
+// Condition is a predicate on the registers of the thread executing an instruction.
+type Condition func(regs *[32]Word) bool
+
+// RegisterEquals returns a condition that holds when the general purpose register reg has the value.
+func RegisterEquals(reg int, value Word) Condition {
+	return func(regs *[32]Word) bool {
+		return regs[reg] == value
+	}
+}
+
+// WatchKind is the kind of memory access a watchpoint stops at.
+type WatchKind uint8
+
+const (
+	WatchRead WatchKind = 1 << iota
+	WatchWrite
+	WatchAccess = WatchRead | WatchWrite
+)
+
+func (k WatchKind) String() string {
+	switch k {
+	case WatchRead:
+		return "read"
+	case WatchWrite:
+		return "write"
+	case WatchAccess:
+		return "access"
+	default:
+		return fmt.Sprintf("WatchKind(%d)", uint8(k))
+	}
+}
+
+// Breakpoint stops at the instruction at PC, if the condition holds or is nil.
+type Breakpoint struct {
+	ID        int
+	PC        Word
+	Condition Condition
+}
+
+// Watchpoint stops at the instructions that access memory in [Addr, Addr+Size) in the way of the kind.
+type Watchpoint struct {
+	ID   int
+	Addr Word
+	Size Word
+	Kind WatchKind
+}
+
+// Access is a memory access of an instruction.
+type Access struct {
+	Addr Word
+	Size Word
+	// Kind is WatchRead or WatchWrite.
+	Kind WatchKind
+}
+
+// Hit is a breakpoint or watchpoint that an instruction hit, before the instruction executed.
+type Hit struct {
+	Step uint64
+	// Thread is the ID of the thread executing the instruction, in the multithreaded VM.
+	Thread *uint64
+	PC     Word
+	Insn   uint32
+	// Breakpoint is set if the hit is of a breakpoint.
+	Breakpoint *Breakpoint
+	// Watchpoint and Access are set if the hit is of a watchpoint, with the access of the instruction that hit it.
+	Watchpoint *Watchpoint
+	Access     *Access
+}
+
+// Debugger is a StepTracer that checks the breakpoints and watchpoints before each instruction executes,
+// and calls the callback for each hit. Set it as the tracer of the VM, with SetTracer.
+// The hits of the last instruction are returned by Hits, e.g. for the caller stepping the VM to stop.
+type Debugger struct {
+	onHit func(hit Hit)
+
+	nextID      int
+	breakpoints map[Word][]*Breakpoint
+	watchpoints []*Watchpoint
+
+	hits []Hit
+}
+
+var _ mipsevm.StepTracer = (*Debugger)(nil)
+
+// NewDebugger creates a debugger without breakpoints and watchpoints, that calls onHit, if not nil, for each hit.
+func NewDebugger(onHit func(hit Hit)) *Debugger {
+	return &Debugger{
+		onHit:       onHit,
+		nextID:      1,
+		breakpoints: make(map[Word][]*Breakpoint),
+	}
+}
+
+// AddBreakpoint adds a breakpoint at pc with an optional condition, and returns its ID.
+func (d *Debugger) AddBreakpoint(pc Word, cond Condition) int {
+	bp := &Breakpoint{ID: d.nextID, PC: pc, Condition: cond}
+	d.nextID++
+	d.breakpoints[pc] = append(d.breakpoints[pc], bp)
+	return bp.ID
+}
+
+// AddWatchpoint adds a watchpoint on the size bytes at addr, and returns its ID.
+func (d *Debugger) AddWatchpoint(addr, size Word, kind WatchKind) (int, error) {
+	if size == 0 {
+		return 0, fmt.Errorf("empty watchpoint at %#x", addr)
+	}
+	if addr+size < addr {
+		return 0, fmt.Errorf("watchpoint of %d bytes at %#x overflows the address space", size, addr)
+	}
+	if kind == 0 || kind&^WatchAccess != 0 {
+		return 0, fmt.Errorf("invalid watchpoint kind %v", kind)
+	}
+	wp := &Watchpoint{ID: d.nextID, Addr: addr, Size: size, Kind: kind}
+	d.nextID++
+	d.watchpoints = append(d.watchpoints, wp)
+	return wp.ID, nil
+}
+
+// Remove removes the breakpoint or watchpoint with the ID, and returns whether it existed.
+func (d *Debugger) Remove(id int) bool {
+	for pc, bps := range d.breakpoints {
+		for i, bp := range bps {
+			if bp.ID != id {
+				continue
+			}
+			if len(bps) == 1 {
+				delete(d.breakpoints, pc)
+			} else {
+				d.breakpoints[pc] = append(bps[:i:i], bps[i+1:]...)
+			}
+			return true
+		}
+	}
+	for i, wp := range d.watchpoints {
+		if wp.ID == id {
+			d.watchpoints = append(d.watchpoints[:i:i], d.watchpoints[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Breakpoints returns the breakpoints, ordered by ID.
+func (d *Debugger) Breakpoints() []*Breakpoint {
+	var out []*Breakpoint
+	for _, bps := range d.breakpoints {
+		out = append(out, bps...)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// Watchpoints returns the watchpoints, ordered by ID.
+func (d *Debugger) Watchpoints() []*Watchpoint {
+	return append([]*Watchpoint(nil), d.watchpoints...)
+}
+
+// Hits returns the hits of the last instruction, which is yet to execute if the step stopped early.
+func (d *Debugger) Hits() []Hit {
+	return d.hits
+}
+
+func (d *Debugger) OnInstruction(state mipsevm.FPVMState, pc Word, insn uint32) {
+	d.hits = d.hits[:0]
+	if len(d.breakpoints[pc]) == 0 && len(d.watchpoints) == 0 {
+		return
+	}
+	thread, regs := currentThread(state)
+	hit := Hit{Step: state.GetStep(), Thread: thread, PC: pc, Insn: insn}
+	for _, bp := range d.breakpoints[pc] {
+		if bp.Condition == nil || bp.Condition(regs) {
+			h := hit
+			h.Breakpoint = bp
+			d.hit(h)
+		}
+	}
+	if len(d.watchpoints) == 0 {
+		return
+	}
+	access, ok := memAccess(insn, regs)
+	if !ok {
+		return
+	}
+	for _, wp := range d.watchpoints {
+		if wp.Kind&access.Kind != 0 && overlaps(wp, access) {
+			h := hit
+			h.Watchpoint = wp
+			h.Access = &access
+			d.hit(h)
+		}
+	}
+}
+
+func (d *Debugger) hit(h Hit) {
+	d.hits = append(d.hits, h)
+	if d.onHit != nil {
+		d.onHit(h)
+	}
+}
+
+func (d *Debugger) OnMemAccess(addr Word) {}
+
+func (d *Debugger) OnStepEnd(state mipsevm.FPVMState) {}
+
+func overlaps(wp *Watchpoint, access Access) bool {
+	// watchpoints don't overflow the address space, the buffers of syscalls may
+	accessLast := access.Addr + access.Size - 1
+	if accessLast < access.Addr {
+		accessLast = ^Word(0)
+	}
+	return wp.Addr <= accessLast && access.Addr <= wp.Addr+wp.Size-1
+}
+
+// memAccess returns the memory access of the instruction, with the address aligned down to the size of the access
+// like the VM does. Syscalls reading into or writing from a buffer access the buffer given by their arguments.
+func memAccess(insn uint32, regs *[32]Word) (Access, bool) {
+	opcode := insn >> 26
+	if opcode == 0 && insn&0x3F == 0xC {
+		syscallNum, _, a1, a2, _ := exec.GetSyscallArgs(regs)
+		switch syscallNum {
+		case exec.SysRead:
+			return Access{Addr: a1, Size: a2, Kind: WatchWrite}, a2 != 0
+		case exec.SysWrite:
+			return Access{Addr: a1, Size: a2, Kind: WatchRead}, a2 != 0
+		}
+		return Access{}, false
+	}
+	var size Word
+	kind := WatchRead
+	switch opcode {
+	case 0x20, 0x24: // lb, lbu
+		size = 1
+	case 0x21, 0x25: // lh, lhu
+		size = 2
+	case 0x23, 0x22, 0x26, 0x30, exec.OpLoadWordCop1: // lw, lwl, lwr, ll, lwc1
+		size = 4
+	case 0x28: // sb
+		size, kind = 1, WatchWrite
+	case 0x29: // sh
+		size, kind = 2, WatchWrite
+	case 0x2B, 0x2A, 0x2E, 0x38, exec.OpStoreWordCop1: // sw, swl, swr, sc, swc1
+		size, kind = 4, WatchWrite
+	case exec.OpLoadDoubleCop1:
+		size = 8
+	case exec.OpStoreDoubleCop1:
+		size, kind = 8, WatchWrite
+	default:
+		if arch.IsMips32 {
+			return Access{}, false
+		}
+		switch opcode {
+		case 0x27: // lwu
+			size = 4
+		case 0x37, 0x34, 0x1A, 0x1B: // ld, lld, ldl, ldr
+			size = 8
+		case 0x3F, 0x3C, 0x2C, 0x2D: // sd, scd, sdl, sdr
+			size, kind = 8, WatchWrite
+		default:
+			return Access{}, false
+		}
+	}
+	base := regs[(insn>>21)&0x1F]
+	addr := base + exec.SignExtend(Word(insn&0xFFFF), 16)
+	return Access{Addr: addr &^ (size - 1), Size: size, Kind: kind}, true
+}
+
+func currentThread(state mipsevm.FPVMState) (*uint64, *[32]Word) {
+	switch s := state.(type) {
+	case *multithreaded.State:
+		th := s.GetCurrentThread()
+		id := uint64(th.ThreadId)
+		return &id, &th.Registers
+	case *singlethreaded.State:
+		return nil, &s.Registers
+	default:
+		panic(fmt.Errorf("unsupported state type %T", state))
+	}
+}
@@ -0,0 +1,159 @@
+//go:build !cannon64
+
+package debugger
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+const programStart = 0x1000
+
+var testProgram = []uint32{
+	0x24080005, // addiu $t0, $zero, 5
+	0x3c090002, // lui $t1, 0x2
+	0xad280004, // sw $t0, 4($t1)
+	0x8d2a0006, // lw $t2, 6($t1), aligned down to 4($t1)
+	0x2508ffff, // addiu $t0, $t0, -1
+	0x1500fffe, // bne $t0, $zero, -2
+	0x00000000, // nop
+	0x24021096, // addiu $v0, $zero, 4246 (exit_group)
+	0x24040003, // addiu $a0, $zero, 3
+	0x0000000c, // syscall
+}
+
+func TestDebugger(t *testing.T) {
+	t.Run("singlethreaded", func(t *testing.T) {
+		state := singlethreaded.CreateInitialState(programStart, 0)
+		storeProgram(state.Memory)
+		vm := singlethreaded.NewInstrumentedState(state, testutil.StaticOracle(t, nil), io.Discard, io.Discard, nil)
+		for _, hit := range runDebugged(t, vm) {
+			require.Nil(t, hit.Thread)
+		}
+	})
+	t.Run("multithreaded", func(t *testing.T) {
+		state := multithreaded.CreateInitialState(programStart, 0)
+		storeProgram(state.Memory)
+		vm := multithreaded.NewInstrumentedState(state, testutil.StaticOracle(t, nil), io.Discard, io.Discard, testutil.CreateLogger())
+		for _, hit := range runDebugged(t, vm) {
+			require.NotNil(t, hit.Thread)
+			require.Equal(t, uint64(0), *hit.Thread)
+		}
+	})
+}
+
+func storeProgram(mem *memory.Memory) {
+	for i, insn := range testProgram {
+		testutil.StoreInstruction(mem, mipsevm.Word(programStart+4*i), insn)
+	}
+}
+
+func runDebugged(t *testing.T, vm mipsevm.FPVM) []Hit {
+	var hits []Hit
+	d := NewDebugger(func(hit Hit) {
+		// the callback is called before the instruction executes
+		require.Equal(t, hit.PC, vm.GetState().GetPC())
+		require.Equal(t, hit.Step, vm.GetState().GetStep())
+		hits = append(hits, hit)
+	})
+	vm.SetTracer(d)
+
+	store := d.AddBreakpoint(programStart+8, nil)
+	// the loop decrements $t0 from 5, so the branch is executed with $t0 = 4, 3, 2, 1, 0
+	loop := d.AddBreakpoint(programStart+20, RegisterEquals(8, 2))
+	removed := d.AddBreakpoint(programStart+4, nil)
+	write, err := d.AddWatchpoint(0x20006, 2, WatchWrite)
+	require.NoError(t, err)
+	read, err := d.AddWatchpoint(0x20000, 5, WatchRead)
+	require.NoError(t, err)
+	unused, err := d.AddWatchpoint(0x20008, 4, WatchAccess)
+	require.NoError(t, err)
+	require.True(t, d.Remove(removed))
+	require.False(t, d.Remove(removed))
+	require.Len(t, d.Breakpoints(), 2)
+	require.Len(t, d.Watchpoints(), 3)
+
+	for !vm.GetState().GetExited() {
+		_, err := vm.Step(false)
+		require.NoError(t, err)
+	}
+
+	type result struct {
+		id     int
+		pc     mipsevm.Word
+		access *Access
+	}
+	var results []result
+	for _, hit := range hits {
+		id := 0
+		if hit.Breakpoint != nil {
+			require.Nil(t, hit.Watchpoint)
+			id = hit.Breakpoint.ID
+		} else {
+			id = hit.Watchpoint.ID
+		}
+		require.NotEqual(t, unused, id)
+		results = append(results, result{id: id, pc: hit.PC, access: hit.Access})
+	}
+	require.Equal(t, []result{
+		{id: store, pc: programStart + 8},
+		{id: write, pc: programStart + 8, access: &Access{Addr: 0x20004, Size: 4, Kind: WatchWrite}},
+		{id: read, pc: programStart + 12, access: &Access{Addr: 0x20004, Size: 4, Kind: WatchRead}},
+		{id: loop, pc: programStart + 20},
+	}, results)
+	return hits
+}
+
+func TestAddWatchpoint(t *testing.T) {
+	d := NewDebugger(nil)
+	_, err := d.AddWatchpoint(0x1000, 0, WatchRead)
+	require.ErrorContains(t, err, "empty watchpoint")
+	_, err = d.AddWatchpoint(^mipsevm.Word(0), 2, WatchRead)
+	require.ErrorContains(t, err, "overflows")
+	_, err = d.AddWatchpoint(0x1000, 4, 0)
+	require.ErrorContains(t, err, "invalid watchpoint kind")
+	_, err = d.AddWatchpoint(0x1000, 4, 4)
+	require.ErrorContains(t, err, "invalid watchpoint kind")
+	require.Empty(t, d.Watchpoints())
+}
+
+func TestMemAccess(t *testing.T) {
+	var regs [32]mipsevm.Word
+	regs[9] = 0x20000
+	regs[4] = 1
+	regs[5] = 0xfffffff0
+	regs[6] = 0x100
+
+	access, ok := memAccess(0xa1280003, &regs) // sb $t0, 3($t1)
+	require.True(t, ok)
+	require.Equal(t, Access{Addr: 0x20003, Size: 1, Kind: WatchWrite}, access)
+	access, ok = memAccess(0x8528fffe, &regs) // lh $t0, -2($t1)
+	require.True(t, ok)
+	require.Equal(t, Access{Addr: 0x1fffe, Size: 2, Kind: WatchRead}, access)
+	_, ok = memAccess(0x25080001, &regs) // addiu $t0, $t0, 1
+	require.False(t, ok)
+
+	// the buffers of read and write syscalls
+	regs[2] = 4004 // write
+	access, ok = memAccess(0x0000000c, &regs)
+	require.True(t, ok)
+	require.Equal(t, Access{Addr: 0xfffffff0, Size: 0x100, Kind: WatchRead}, access)
+	regs[2] = 4003 // read
+	access, ok = memAccess(0x0000000c, &regs)
+	require.True(t, ok)
+	require.Equal(t, WatchWrite, access.Kind)
+	// the buffer is clamped to the end of the address space
+	require.True(t, overlaps(&Watchpoint{Addr: 0xfffffffc, Size: 4}, access))
+	require.False(t, overlaps(&Watchpoint{Addr: 0x10, Size: 4}, access))
+	require.False(t, overlaps(&Watchpoint{Addr: 0x100, Size: 4}, access))
+	require.False(t, overlaps(&Watchpoint{Addr: 0xffffffec, Size: 4}, access))
+	require.True(t, overlaps(&Watchpoint{Addr: 0xffffffec, Size: 5}, access))
+}
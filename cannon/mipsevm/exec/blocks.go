@@ -0,0 +1,354 @@
+package exec
+
+import (
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+// maxBlockInstructions bounds the number of instructions translated into a single block.
+const maxBlockInstructions = 256
+
+// blockMachine is the state the instructions of a block execute on.
+type blockMachine struct {
+	cpu       *mipsevm.CpuScalars
+	registers *[32]Word
+	fpu       *mipsevm.FpuState
+	memory    *memory.Memory
+}
+
+// blockOp executes a translated instruction, like a step of the interpreter.
+// It returns false if the instruction faults, in which case the instruction has not changed the state,
+// and must be executed by the interpreter instead to fault the step.
+type blockOp func(m *blockMachine) bool
+
+// block is the translated code starting at an address: the instructions up to the next control transfer and
+// its delay slot, or up to the next instruction the VM must interpret. A block without ops starts with such an instruction.
+type block struct {
+	ops []blockOp
+}
+
+// BlockCache translates the code of a program to blocks of pre-decoded instructions, to execute straight-line code
+// without the instruction fetch, decoding and per-step bookkeeping of the interpreter.
+// Every translated instruction updates the state exactly like a step of the interpreter does, so a VM can execute
+// blocks between any two steps, and stepping, proofs and snapshots are unchanged.
+// Blocks are invalidated when the memory they are translated from is written to.
+type BlockCache struct {
+	memory *memory.Memory
+	// interpreted returns whether the VM must execute the instruction itself, e.g. syscalls. It ends blocks.
+	interpreted func(insn, opcode, fun uint32) bool
+	// onStore is called before an instruction stores to memory. Optional.
+	onStore func(insn, opcode uint32)
+
+	blocks map[Word]*block
+	// pageBlocks are the start addresses of the blocks translated from each page
+	pageBlocks map[Word][]Word
+	// stale is set when blocks are invalidated, to stop executing the current block
+	stale bool
+
+	machine blockMachine
+}
+
+// NewBlockCache creates a cache of the blocks translated from the memory. The memory may only have one cache.
+// The interpreted instructions end blocks, and onStore is called before any translated instruction stores to memory.
+func NewBlockCache(mem *memory.Memory, interpreted func(insn, opcode, fun uint32) bool, onStore func(insn, opcode uint32)) *BlockCache {
+	c := &BlockCache{
+		memory:      mem,
+		interpreted: interpreted,
+		onStore:     onStore,
+		blocks:      make(map[Word]*block),
+		pageBlocks:  make(map[Word][]Word),
+		machine:     blockMachine{memory: mem},
+	}
+	mem.SetWriteHook(c.invalidatePage)
+	return c
+}
+
+// Close stops tracking the writes to the memory. The cache must not be used afterwards.
+func (c *BlockCache) Close() {
+	c.memory.SetWriteHook(nil)
+}
+
+// RunBlock executes the block starting at the current PC, for at most maxSteps instructions,
+// and returns the number of instructions executed, i.e. the number of steps.
+// It returns 0 if the current instruction must be executed by the interpreter: instructions the VM interprets,
+// faulting instructions, and instructions in delay slots, i.e. if the next PC doesn't follow the PC.
+// The fpu is nil if the VM has no floating-point coprocessor.
+func (c *BlockCache) RunBlock(cpu *mipsevm.CpuScalars, registers *[32]Word, fpu *mipsevm.FpuState, maxSteps uint64) uint64 {
+	if cpu.NextPC != cpu.PC+4 || cpu.PC&3 != 0 {
+		return 0
+	}
+	b := c.lookup(cpu.PC)
+	c.machine.cpu, c.machine.registers, c.machine.fpu = cpu, registers, fpu
+	c.stale = false
+	steps := uint64(0)
+	for _, op := range b.ops {
+		if steps == maxSteps || !op(&c.machine) {
+			break
+		}
+		steps++
+		// the rest of the block may have been overwritten
+		if c.stale {
+			break
+		}
+	}
+	return steps
+}
+
+func (c *BlockCache) lookup(start Word) *block {
+	if b, ok := c.blocks[start]; ok {
+		return b
+	}
+	b := c.translate(start)
+	c.blocks[start] = b
+	end := start
+	if len(b.ops) > 0 {
+		end += Word(len(b.ops)-1) * 4
+	}
+	for pageIndex := start >> memory.PageAddrSize; pageIndex <= end>>memory.PageAddrSize; pageIndex++ {
+		c.pageBlocks[pageIndex] = append(c.pageBlocks[pageIndex], start)
+	}
+	return b
+}
+
+func (c *BlockCache) invalidatePage(pageIndex Word) {
+	starts, ok := c.pageBlocks[pageIndex]
+	if !ok {
+		return
+	}
+	for _, start := range starts {
+		delete(c.blocks, start)
+	}
+	delete(c.pageBlocks, pageIndex)
+	c.stale = true
+}
+
+func (c *BlockCache) translate(start Word) *block {
+	b := &block{}
+	for pc := start; len(b.ops) < maxBlockInstructions; pc += 4 {
+		insn, opcode, fun := GetInstructionDetails(pc, c.memory)
+		if c.interpreted(insn, opcode, fun) {
+			break
+		}
+		if isControlTransfer(insn, opcode, fun) {
+			// The delay slot is executed before the control transfer completes, so it ends the block.
+			// If the VM interprets the delay slot, the control transfer is interpreted as well.
+			slotInsn, slotOpcode, slotFun := GetInstructionDetails(pc+4, c.memory)
+			if c.interpreted(slotInsn, slotOpcode, slotFun) || isControlTransfer(slotInsn, slotOpcode, slotFun) {
+				break
+			}
+			b.ops = append(b.ops, c.translateInsn(insn, opcode, fun), c.translateInsn(slotInsn, slotOpcode, slotFun))
+			break
+		}
+		b.ops = append(b.ops, c.translateInsn(insn, opcode, fun))
+	}
+	return b
+}
+
+// isControlTransfer returns whether the instruction is a branch or jump, which is followed by a delay slot.
+func isControlTransfer(insn, opcode, fun uint32) bool {
+	switch {
+	case opcode >= 1 && opcode <= 7: // regimm branches, j, jal, beq, bne, blez, bgtz
+		return true
+	case opcode == 0 && (fun == 8 || fun == 9): // jr, jalr
+		return true
+	case opcode == OpCop1 && (insn>>21)&0x1F == 8: // bc1f, bc1t, bc1fl, bc1tl
+		return true
+	default:
+		return false
+	}
+}
+
+// translateInsn returns the op of an instruction. The most common instructions are specialized,
+// any other instruction is executed like the interpreter does.
+func (c *BlockCache) translateInsn(insn, opcode, fun uint32) blockOp {
+	rs := (insn >> 21) & 0x1F
+	rt := (insn >> 16) & 0x1F
+	rd := (insn >> 11) & 0x1F
+	simm := SignExtend(Word(insn&0xFFFF), 16)
+	zimm := Word(insn & 0xFFFF)
+
+	if opcode == 0 {
+		shamt := Word((insn >> 6) & 0x1F)
+		switch fun {
+		case 0x00: // sll
+			return rdOp(rd, func(r *[32]Word) Word { return SignExtend((r[rt]&0xFFFFFFFF)<<shamt, 32) })
+		case 0x02: // srl
+			return rdOp(rd, func(r *[32]Word) Word { return SignExtend((r[rt]&0xFFFFFFFF)>>shamt, 32) })
+		case 0x03: // sra
+			return rdOp(rd, func(r *[32]Word) Word { return SignExtend((r[rt]&0xFFFFFFFF)>>shamt, 32-shamt) })
+		case 0x08: // jr
+			return jumpOp(0, func(m *blockMachine) Word { return m.registers[rs] })
+		case 0x09: // jalr
+			return jumpOp(rd, func(m *blockMachine) Word { return m.registers[rs] })
+		case 0x21: // addu
+			return rdOp(rd, func(r *[32]Word) Word { return SignExtend((r[rs]+r[rt])&0xFFFFFFFF, 32) })
+		case 0x23: // subu
+			return rdOp(rd, func(r *[32]Word) Word { return SignExtend((r[rs]-r[rt])&0xFFFFFFFF, 32) })
+		case 0x24: // and
+			return rdOp(rd, func(r *[32]Word) Word { return r[rs] & r[rt] })
+		case 0x25: // or
+			return rdOp(rd, func(r *[32]Word) Word { return r[rs] | r[rt] })
+		case 0x26: // xor
+			return rdOp(rd, func(r *[32]Word) Word { return r[rs] ^ r[rt] })
+		case 0x27: // nor
+			return rdOp(rd, func(r *[32]Word) Word { return ^(r[rs] | r[rt]) })
+		case 0x2a: // slt
+			return rdOp(rd, func(r *[32]Word) Word { return boolWord(arch.SignedWord(r[rs]) < arch.SignedWord(r[rt])) })
+		case 0x2b: // sltu
+			return rdOp(rd, func(r *[32]Word) Word { return boolWord(r[rs] < r[rt]) })
+		}
+	}
+
+	switch opcode {
+	case 2, 3: // j, jal
+		link := uint32(0)
+		if opcode == 3 {
+			link = 31
+		}
+		region := SignExtend(0xF0000000, 32)
+		offset := Word((insn & 0x03FFFFFF) << 2)
+		return jumpOp(link, func(m *blockMachine) Word { return (m.cpu.NextPC & region) | offset })
+	case 4: // beq
+		return branchOp(simm<<2, func(r *[32]Word) bool { return r[rs] == r[rt] })
+	case 5: // bne
+		return branchOp(simm<<2, func(r *[32]Word) bool { return r[rs] != r[rt] })
+	case 9: // addiu
+		return rdOp(rt, func(r *[32]Word) Word { return SignExtend((r[rs]+simm)&0xFFFFFFFF, 32) })
+	case 0xA: // slti
+		return rdOp(rt, func(r *[32]Word) Word { return boolWord(arch.SignedWord(r[rs]) < arch.SignedWord(simm)) })
+	case 0xB: // sltiu
+		return rdOp(rt, func(r *[32]Word) Word { return boolWord(r[rs] < simm) })
+	case 0xC: // andi
+		return rdOp(rt, func(r *[32]Word) Word { return r[rs] & zimm })
+	case 0xD: // ori
+		return rdOp(rt, func(r *[32]Word) Word { return r[rs] | zimm })
+	case 0xE: // xori
+		return rdOp(rt, func(r *[32]Word) Word { return r[rs] ^ zimm })
+	case 0xF: // lui
+		val := SignExtend(zimm<<16, 32)
+		return rdOp(rt, func(r *[32]Word) Word { return val })
+	case 0x20: // lb
+		return loadOp(rs, rt, simm, 1, true)
+	case 0x21: // lh
+		return loadOp(rs, rt, simm, 2, true)
+	case 0x23: // lw
+		return loadOp(rs, rt, simm, 4, true)
+	case 0x24: // lbu
+		return loadOp(rs, rt, simm, 1, false)
+	case 0x25: // lhu
+		return loadOp(rs, rt, simm, 2, false)
+	case 0x28: // sb
+		return c.storeOp(insn, opcode, rs, rt, simm, 1)
+	case 0x29: // sh
+		return c.storeOp(insn, opcode, rs, rt, simm, 2)
+	case 0x2B: // sw
+		return c.storeOp(insn, opcode, rs, rt, simm, 4)
+	}
+	return c.interpreterOp(insn, opcode, fun)
+}
+
+// interpreterOp executes the instruction with the logic of the interpreter.
+func (c *BlockCache) interpreterOp(insn, opcode, fun uint32) blockOp {
+	onStore := c.onStore
+	if !IsStore(opcode) {
+		onStore = nil
+	}
+	return func(m *blockMachine) bool {
+		if onStore != nil {
+			onStore(insn, opcode)
+		}
+		err := ExecMipsCoreStepLogic(m.cpu, m.registers, m.fpu, m.memory, insn, opcode, fun, noopMemTracker{}, &NoopStackTracker{})
+		return err == nil
+	}
+}
+
+// rdOp writes the result of an ALU instruction to the destination register.
+func rdOp(dest uint32, fn func(r *[32]Word) Word) blockOp {
+	if dest == 0 {
+		return func(m *blockMachine) bool {
+			m.cpu.PC = m.cpu.NextPC
+			m.cpu.NextPC = m.cpu.NextPC + 4
+			return true
+		}
+	}
+	return func(m *blockMachine) bool {
+		m.registers[dest] = fn(m.registers)
+		m.cpu.PC = m.cpu.NextPC
+		m.cpu.NextPC = m.cpu.NextPC + 4
+		return true
+	}
+}
+
+func loadOp(base, dest uint32, offset Word, byteLength Word, signExtend bool) blockOp {
+	return func(m *blockMachine) bool {
+		vaddr := m.registers[base] + offset
+		val := SelectSubWord(vaddr, m.memory.GetMemory(vaddr&arch.AddressMask), byteLength, signExtend)
+		if dest != 0 {
+			m.registers[dest] = val
+		}
+		m.cpu.PC = m.cpu.NextPC
+		m.cpu.NextPC = m.cpu.NextPC + 4
+		return true
+	}
+}
+
+func (c *BlockCache) storeOp(insn, opcode uint32, base, src uint32, offset Word, byteLength Word) blockOp {
+	onStore := c.onStore
+	return func(m *blockMachine) bool {
+		if onStore != nil {
+			onStore(insn, opcode)
+		}
+		vaddr := m.registers[base] + offset
+		addr := vaddr & arch.AddressMask
+		m.memory.SetMemory(addr, UpdateSubWord(vaddr, m.memory.GetMemory(addr), byteLength, m.registers[src]))
+		m.cpu.PC = m.cpu.NextPC
+		m.cpu.NextPC = m.cpu.NextPC + 4
+		return true
+	}
+}
+
+// branchOp is a conditional branch by offset, relative to the delay slot.
+func branchOp(offset Word, cond func(r *[32]Word) bool) blockOp {
+	return func(m *blockMachine) bool {
+		if m.cpu.NextPC != m.cpu.PC+4 {
+			return false
+		}
+		prevPC := m.cpu.PC
+		m.cpu.PC = m.cpu.NextPC
+		if cond(m.registers) {
+			m.cpu.NextPC = prevPC + 4 + offset
+		} else {
+			m.cpu.NextPC = m.cpu.NextPC + 4
+		}
+		return true
+	}
+}
+
+// jumpOp is a jump to the target, linking the address after the delay slot to the link register if not 0.
+func jumpOp(link uint32, target func(m *blockMachine) Word) blockOp {
+	return func(m *blockMachine) bool {
+		if m.cpu.NextPC != m.cpu.PC+4 {
+			return false
+		}
+		dest := target(m)
+		prevPC := m.cpu.PC
+		m.cpu.PC = m.cpu.NextPC
+		m.cpu.NextPC = dest
+		if link != 0 {
+			m.registers[link] = prevPC + 8
+		}
+		return true
+	}
+}
+
+func boolWord(b bool) Word {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+type noopMemTracker struct{}
+
+func (noopMemTracker) TrackMemAccess(Word) {}
@@ -0,0 +1,179 @@
+package exec
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+const (
+	blockTestCodeAddr = 0x1000
+	blockTestDataAddr = 0x8000
+	blockTestCodeLen  = 64
+	// the program only writes registers 1 to 8, the others hold the addresses the loads and stores are based on
+	blockTestDataReg = 9
+	blockTestCodeReg = 10
+	// blockTestInsnReg holds an instruction, that the program stores to its own code
+	blockTestInsnReg = 11
+)
+
+type blockTestVM struct {
+	cpu       mipsevm.CpuScalars
+	registers [32]Word
+	fpu       mipsevm.FpuState
+	memory    *memory.Memory
+}
+
+func newBlockTestVM(program []uint32, seed int64) *blockTestVM {
+	vm := &blockTestVM{
+		cpu:    mipsevm.CpuScalars{PC: blockTestCodeAddr, NextPC: blockTestCodeAddr + 4},
+		memory: memory.NewMemory(),
+	}
+	for i, insn := range program {
+		storeInsn(vm.memory, blockTestCodeAddr+Word(i)*4, insn)
+	}
+	r := rand.New(rand.NewSource(seed))
+	for i := Word(0); i < 64; i++ {
+		vm.memory.SetMemory(blockTestDataAddr+i*arch.WordSizeBytes, Word(r.Uint64()))
+	}
+	for i := 1; i <= 8; i++ {
+		vm.registers[i] = Word(r.Uint64())
+	}
+	vm.registers[blockTestDataReg] = blockTestDataAddr
+	vm.registers[blockTestCodeReg] = blockTestCodeAddr
+	vm.registers[blockTestInsnReg] = Word(iType(9, 1, 1, 7)) // addiu $1, $1, 7
+	return vm
+}
+
+func storeInsn(mem *memory.Memory, addr Word, insn uint32) {
+	mem.SetMemory(addr&arch.AddressMask, UpdateSubWord(addr, mem.GetMemory(addr&arch.AddressMask), 4, Word(insn)))
+}
+
+func iType(opcode, rs, rt uint32, imm uint16) uint32 {
+	return opcode<<26 | rs<<21 | rt<<16 | uint32(imm)
+}
+
+func rType(fun, rs, rt, rd, shamt uint32) uint32 {
+	return rs<<21 | rt<<16 | rd<<11 | shamt<<6 | fun
+}
+
+// randomProgram returns straight-line code, loads and stores, branches and jumps within the program,
+// and stores to the code of the program.
+func randomProgram(r *rand.Rand) []uint32 {
+	reg := func() uint32 { return uint32(r.Intn(9)) } // including $zero
+	program := make([]uint32, blockTestCodeLen)
+	for i := range program {
+		// branches and jumps stay in the program
+		branchOffset := uint16(r.Intn(blockTestCodeLen) - i - 1)
+		switch r.Intn(12) {
+		case 0, 1, 2:
+			funs := []uint32{0x00, 0x02, 0x03, 0x04, 0x06, 0x07, 0x0a, 0x0b, 0x21, 0x23, 0x24, 0x25, 0x26, 0x27, 0x2a, 0x2b}
+			program[i] = rType(funs[r.Intn(len(funs))], reg(), reg(), reg(), uint32(r.Intn(32)))
+		case 3, 4:
+			opcodes := []uint32{9, 0xA, 0xB, 0xC, 0xD, 0xE, 0xF}
+			program[i] = iType(opcodes[r.Intn(len(opcodes))], reg(), reg(), uint16(r.Uint32()))
+		case 5, 6:
+			opcodes := []uint32{0x20, 0x21, 0x23, 0x24, 0x25, 0x22, 0x26}
+			program[i] = iType(opcodes[r.Intn(len(opcodes))], blockTestDataReg, reg(), uint16(r.Intn(256)))
+		case 7:
+			opcodes := []uint32{0x28, 0x29, 0x2B, 0x2A, 0x2E}
+			program[i] = iType(opcodes[r.Intn(len(opcodes))], blockTestDataReg, reg(), uint16(r.Intn(256)))
+		case 8:
+			opcodes := []uint32{4, 5, 6, 7}
+			program[i] = iType(opcodes[r.Intn(len(opcodes))], reg(), reg(), branchOffset)
+		case 9:
+			program[i] = iType(1, reg(), uint32(r.Intn(2)), branchOffset) // bltz, bgez
+		case 10:
+			target := uint32(blockTestCodeAddr+r.Intn(blockTestCodeLen)*4) >> 2
+			program[i] = uint32(2+r.Intn(2))<<26 | target // j, jal
+		case 11:
+			program[i] = iType(0x2B, blockTestCodeReg, blockTestInsnReg, uint16(r.Intn(blockTestCodeLen)*4)) // sw to code
+		}
+	}
+	return program
+}
+
+// TestBlockCache checks that executing blocks results in the same states as stepping the instructions.
+func TestBlockCache(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		r := rand.New(rand.NewSource(seed))
+		program := randomProgram(r)
+		const steps = 2000
+
+		expected := newBlockTestVM(program, seed)
+		var expectedErr error
+		expectedSteps := 0
+		for ; expectedSteps < steps; expectedSteps++ {
+			insn, opcode, fun := GetInstructionDetails(expected.cpu.PC, expected.memory)
+			expectedErr = ExecMipsCoreStepLogic(&expected.cpu, &expected.registers, &expected.fpu, expected.memory, insn, opcode, fun, noopMemTracker{}, &NoopStackTracker{})
+			if expectedErr != nil {
+				break
+			}
+		}
+
+		actual := newBlockTestVM(program, seed)
+		cache := NewBlockCache(actual.memory, func(insn, opcode, fun uint32) bool {
+			return opcode == 0 && fun == 0xC
+		}, nil)
+		var actualErr error
+		actualSteps := 0
+		blockSteps := 0
+		for actualSteps < steps {
+			if n := cache.RunBlock(&actual.cpu, &actual.registers, &actual.fpu, uint64(steps-actualSteps)); n > 0 {
+				actualSteps += int(n)
+				blockSteps += int(n)
+				continue
+			}
+			insn, opcode, fun := GetInstructionDetails(actual.cpu.PC, actual.memory)
+			actualErr = ExecMipsCoreStepLogic(&actual.cpu, &actual.registers, &actual.fpu, actual.memory, insn, opcode, fun, noopMemTracker{}, &NoopStackTracker{})
+			if actualErr != nil {
+				break
+			}
+			actualSteps++
+		}
+		cache.Close()
+
+		require.Equal(t, expectedErr, actualErr, "seed %d", seed)
+		require.Equal(t, expectedSteps, actualSteps, "seed %d", seed)
+		require.Equal(t, expected.cpu, actual.cpu, "seed %d", seed)
+		require.Equal(t, expected.registers, actual.registers, "seed %d", seed)
+		require.Equal(t, expected.memory.MerkleRoot(), actual.memory.MerkleRoot(), "seed %d", seed)
+		if expectedSteps > 10 {
+			require.NotZero(t, blockSteps, "seed %d: must execute blocks", seed)
+		}
+	}
+}
+
+func TestBlockCache_Invalidation(t *testing.T) {
+	program := []uint32{
+		iType(9, 1, 1, 1), // addiu $1, $1, 1
+		iType(0x2B, blockTestCodeReg, blockTestInsnReg, 8), // sw $11, 8($10)
+		iType(9, 1, 1, 1), // addiu $1, $1, 1, overwritten with addiu $1, $1, 7
+		iType(9, 1, 1, 1), // addiu $1, $1, 1
+	}
+	vm := newBlockTestVM(program, 0)
+	vm.registers[1] = 0
+	cache := NewBlockCache(vm.memory, func(insn, opcode, fun uint32) bool { return false }, nil)
+	defer cache.Close()
+
+	// the block stops after the store to its own code
+	require.Equal(t, uint64(2), cache.RunBlock(&vm.cpu, &vm.registers, &vm.fpu, 100))
+	require.Equal(t, Word(blockTestCodeAddr+8), vm.cpu.PC)
+	// the rest of the code is translated again
+	require.Equal(t, uint64(2), cache.RunBlock(&vm.cpu, &vm.registers, &vm.fpu, 2))
+	require.Equal(t, Word(1+7+1), vm.registers[1])
+
+	// the block is limited to the steps
+	vm.cpu = mipsevm.CpuScalars{PC: blockTestCodeAddr, NextPC: blockTestCodeAddr + 4}
+	require.Equal(t, uint64(1), cache.RunBlock(&vm.cpu, &vm.registers, &vm.fpu, 1))
+	require.Equal(t, Word(blockTestCodeAddr+4), vm.cpu.PC)
+
+	// delay slots are executed by the interpreter
+	vm.cpu = mipsevm.CpuScalars{PC: blockTestCodeAddr, NextPC: blockTestCodeAddr + 8}
+	require.Zero(t, cache.RunBlock(&vm.cpu, &vm.registers, &vm.fpu, 100))
+}
@@ -0,0 +1,69 @@
+package exec
+
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+// fault is panicked with by the instruction helpers that don't return errors,
+// and recovered into the mipsevm.FaultError of the step by ExecMipsCoreStepLogic.
+type fault struct {
+	err error
+}
+
+// illegalInstructionFault faults the step with mipsevm.ErrIllegalInstruction.
+var illegalInstructionFault = fault{err: mipsevm.ErrIllegalInstruction}
+
+// recoverFault turns a fault panic into the error of the step. Any other panic is re-raised.
+func recoverFault(err *error) {
+	if r := recover(); r != nil {
+		f, ok := r.(fault)
+		if !ok {
+			panic(r)
+		}
+		*err = f.err
+	}
+}
+
+// CheckAlignment returns a mipsevm.ErrUnalignedAccess fault if the load or store insn accesses an address
+// that isn't aligned to the size of the access. The VM only checks this in strict mode: by default,
+// like the contract, it aligns the address down. Byte accesses, and the unaligned loads and stores
+// (lwl, lwr, swl, swr and their 64-bit counterparts) are never unaligned.
+func CheckAlignment(pc Word, insn, opcode uint32, registers *[32]Word) error {
+	size := accessSize(opcode)
+	if size <= 1 {
+		return nil
+	}
+	vaddr := registers[(insn>>21)&0x1F] + SignExtend(Word(insn&0xFFFF), 16)
+	if vaddr&(size-1) == 0 {
+		return nil
+	}
+	return &mipsevm.FaultError{
+		Err:  fmt.Errorf("%w: %d-byte access to %s", mipsevm.ErrUnalignedAccess, size, mipsevm.HexWord(vaddr)),
+		PC:   pc,
+		Insn: insn,
+	}
+}
+
+// accessSize returns the number of bytes of a naturally aligned load or store, or 0 for any other opcode.
+func accessSize(opcode uint32) Word {
+	switch opcode {
+	case 0x21, 0x25, 0x29: // lh, lhu, sh
+		return 2
+	case 0x23, 0x2B, OpLoadLinked, OpStoreConditional, OpLoadWordCop1, OpStoreWordCop1: // lw, sw, ll, sc, lwc1, swc1
+		return 4
+	case OpLoadDoubleCop1, OpStoreDoubleCop1: // ldc1, sdc1
+		return 8
+	case 0x27: // lwu
+		if !arch.IsMips32 {
+			return 4
+		}
+	case OpLoadDouble, 0x3F, OpLoadLinked64, OpStoreConditional64: // ld, sd, lld, scd
+		if !arch.IsMips32 {
+			return 8
+		}
+	}
+	return 0
+}
@@ -3,27 +3,32 @@ package exec
 import (
 	"fmt"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 )
 
 type MemTracker interface {
-	TrackMemAccess(addr uint32)
+	TrackMemAccess(addr Word)
 }
 
 type MemoryTrackerImpl struct {
 	memory          *memory.Memory
-	lastMemAccess   uint32
+	lastMemAccess   Word
 	memProofEnabled bool
 	memProof        [memory.MEM_PROOF_SIZE]byte
+	tracer          mipsevm.StepTracer
 }
 
 func NewMemoryTracker(memory *memory.Memory) *MemoryTrackerImpl {
 	return &MemoryTrackerImpl{memory: memory}
 }
 
-func (m *MemoryTrackerImpl) TrackMemAccess(effAddr uint32) {
+func (m *MemoryTrackerImpl) TrackMemAccess(effAddr Word) {
+	if m.tracer != nil {
+		m.tracer.OnMemAccess(effAddr)
+	}
 	if m.memProofEnabled && m.lastMemAccess != effAddr {
-		if m.lastMemAccess != ^uint32(0) {
+		if m.lastMemAccess != ^Word(0) {
 			panic(fmt.Errorf("unexpected different mem access at %08x, already have access at %08x buffered", effAddr, m.lastMemAccess))
 		}
 		m.lastMemAccess = effAddr
@@ -31,9 +36,14 @@ func (m *MemoryTrackerImpl) TrackMemAccess(effAddr uint32) {
 	}
 }
 
+// SetTracer sets the tracer to report memory accesses to, or stops reporting them if nil.
+func (m *MemoryTrackerImpl) SetTracer(tracer mipsevm.StepTracer) {
+	m.tracer = tracer
+}
+
 func (m *MemoryTrackerImpl) Reset(enableProof bool) {
 	m.memProofEnabled = enableProof
-	m.lastMemAccess = ^uint32(0)
+	m.lastMemAccess = ^Word(0)
 }
 
 func (m *MemoryTrackerImpl) MemProof() [memory.MEM_PROOF_SIZE]byte {
@@ -0,0 +1,380 @@
+package exec
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+const (
+	OpCop1            = 0x11
+	OpLoadWordCop1    = 0x31 // lwc1
+	OpLoadDoubleCop1  = 0x35 // ldc1
+	OpStoreWordCop1   = 0x39 // swc1
+	OpStoreDoubleCop1 = 0x3D // sdc1
+)
+
+// The fmt field of COP1 arithmetic instructions
+const (
+	fpuFmtS = 16 // single
+	fpuFmtD = 17 // double
+	fpuFmtW = 20 // 32-bit fixed point
+	fpuFmtL = 21 // 64-bit fixed point
+)
+
+const (
+	// fpuCanonicalNaNS and fpuCanonicalNaND are the default quiet NaNs of the legacy MIPS NaN encoding.
+	// Every NaN result is canonicalized to these, so results do not depend on host NaN propagation.
+	fpuCanonicalNaNS uint32 = 0x7FBFFFFF
+	fpuCanonicalNaND uint64 = 0x7FF7FFFFFFFFFFFF
+	// fpuInvalidW and fpuInvalidL are the results of invalid conversions to fixed point (NaN, infinity, out of range).
+	fpuInvalidW uint32 = 0x7FFFFFFF
+	fpuInvalidL uint64 = 0x7FFFFFFFFFFFFFFF
+
+	// fpuFCSRUnsupportedMask covers the rounding mode and exception enable bits of the FCSR.
+	// Only round-to-nearest with all exceptions disabled is emulated.
+	fpuFCSRUnsupportedMask uint32 = 0x00000F83
+)
+
+// FpuFIR returns the value of the read-only floating-point implementation register:
+// S, D, W and L formats are implemented, and 64-bit FPRs in 64-bit builds.
+func FpuFIR() uint32 {
+	if arch.IsMips32 {
+		return 0x00330000
+	}
+	return 0x00730000
+}
+
+// IsFpuInstruction returns whether the opcode is a COP1 instruction, or a load or store of a floating-point register.
+func IsFpuInstruction(opcode uint32) bool {
+	return opcode == OpCop1 || opcode == OpLoadWordCop1 || opcode == OpLoadDoubleCop1 || opcode == OpStoreWordCop1 || opcode == OpStoreDoubleCop1
+}
+
+// ExecMipsFpuStepLogic executes a floating-point instruction.
+// Arithmetic is IEEE 754 with round-to-nearest-even, NaN results are canonicalized,
+// and the FCSR flag and cause bits are not updated.
+func ExecMipsFpuStepLogic(cpu *mipsevm.CpuScalars, registers *[32]Word, fpu *mipsevm.FpuState, memory *memory.Memory, insn, opcode uint32, memTracker MemTracker) error {
+	if opcode != OpCop1 {
+		return handleFpuLoadStore(cpu, registers, fpu, memory, insn, opcode, memTracker)
+	}
+
+	rs := (insn >> 21) & 0x1F
+	rt := (insn >> 16) & 0x1F
+	fs := (insn >> 11) & 0x1F
+	switch rs {
+	case 0: // mfc1
+		return HandleRd(cpu, registers, rt, SignExtend(Word(readFpuS(fpu, fs)), 32), true)
+	case 1: // dmfc1
+		if arch.IsMips32 {
+			break
+		}
+		return HandleRd(cpu, registers, rt, Word(readFpuD(fpu, fs)), true)
+	case 2: // cfc1
+		switch fs {
+		case 0:
+			return HandleRd(cpu, registers, rt, Word(FpuFIR()), true)
+		case 31:
+			return HandleRd(cpu, registers, rt, Word(fpu.FCSR), true)
+		}
+	case 4: // mtc1
+		writeFpuS(fpu, fs, uint32(registers[rt]))
+		return HandleRd(cpu, registers, 0, 0, false)
+	case 5: // dmtc1
+		if arch.IsMips32 {
+			break
+		}
+		writeFpuD(fpu, fs, uint64(registers[rt]))
+		return HandleRd(cpu, registers, 0, 0, false)
+	case 6: // ctc1
+		switch fs {
+		case 0: // the FIR is read-only
+			return HandleRd(cpu, registers, 0, 0, false)
+		case 31:
+			val := uint32(registers[rt])
+			if val&fpuFCSRUnsupportedMask != 0 {
+				return fmt.Errorf("%w: unsupported fcsr value %08x", mipsevm.ErrIllegalInstruction, val)
+			}
+			fpu.FCSR = val
+			return HandleRd(cpu, registers, 0, 0, false)
+		}
+	case 8: // bc1f, bc1t, bc1fl, bc1tl
+		return handleFpuBranch(cpu, fpu, insn)
+	case fpuFmtS, fpuFmtD, fpuFmtW, fpuFmtL:
+		execFpuArithmetic(fpu, insn, rs)
+		return HandleRd(cpu, registers, 0, 0, false)
+	}
+	return mipsevm.ErrIllegalInstruction
+}
+
+func handleFpuLoadStore(cpu *mipsevm.CpuScalars, registers *[32]Word, fpu *mipsevm.FpuState, memory *memory.Memory, insn, opcode uint32, memTracker MemTracker) error {
+	ft := (insn >> 16) & 0x1F
+	vaddr := registers[(insn>>21)&0x1F] + SignExtend(Word(insn&0xFFFF), 16)
+	// doubles are 8-byte aligned, and thus always within a single memory proof leaf
+	daddr := vaddr &^ 7
+	switch opcode {
+	case OpLoadWordCop1:
+		addr := vaddr & arch.AddressMask
+		memTracker.TrackMemAccess(addr)
+		writeFpuS(fpu, ft, uint32(SelectSubWord(vaddr, memory.GetMemory(addr), 4, false)))
+	case OpLoadDoubleCop1:
+		memTracker.TrackMemAccess(daddr)
+		if arch.IsMips32 {
+			writeFpuD(fpu, ft, uint64(memory.GetMemory(daddr))<<32|uint64(memory.GetMemory(daddr+4)))
+		} else {
+			writeFpuD(fpu, ft, uint64(memory.GetMemory(daddr)))
+		}
+	case OpStoreWordCop1:
+		addr := vaddr & arch.AddressMask
+		memTracker.TrackMemAccess(addr)
+		memory.SetMemory(addr, UpdateSubWord(vaddr, memory.GetMemory(addr), 4, Word(readFpuS(fpu, ft))))
+	case OpStoreDoubleCop1:
+		memTracker.TrackMemAccess(daddr)
+		val := readFpuD(fpu, ft)
+		if arch.IsMips32 {
+			memory.SetMemory(daddr, Word(val>>32))
+			memory.SetMemory(daddr+4, Word(uint32(val)))
+		} else {
+			memory.SetMemory(daddr, Word(val))
+		}
+	}
+	return HandleRd(cpu, registers, 0, 0, false)
+}
+
+func handleFpuBranch(cpu *mipsevm.CpuScalars, fpu *mipsevm.FpuState, insn uint32) error {
+	if cpu.NextPC != cpu.PC+4 {
+		return mipsevm.ErrBranchInDelaySlot
+	}
+	likely := (insn>>17)&1 == 1
+	shouldBranch := fpuConditionCode(fpu.FCSR, (insn>>18)&7) == ((insn>>16)&1 == 1)
+
+	prevPC := cpu.PC
+	if shouldBranch {
+		cpu.PC = cpu.NextPC // execute the delay slot first
+		cpu.NextPC = prevPC + 4 + (SignExtend(Word(insn&0xFFFF), 16) << 2)
+	} else if likely {
+		// the delay slot of a branch likely is nullified if the branch is not taken
+		cpu.PC = cpu.NextPC + 4
+		cpu.NextPC = cpu.NextPC + 8
+	} else {
+		cpu.PC = cpu.NextPC
+		cpu.NextPC = cpu.NextPC + 4
+	}
+	return nil
+}
+
+func execFpuArithmetic(fpu *mipsevm.FpuState, insn uint32, format uint32) {
+	fun := insn & 0x3F
+	ft := (insn >> 16) & 0x1F
+	fs := (insn >> 11) & 0x1F
+	fd := (insn >> 6) & 0x1F
+
+	isFloat := format == fpuFmtS || format == fpuFmtD
+	switch {
+	case fun <= 0x4 && isFloat: // add, sub, mul, div, sqrt
+		if format == fpuFmtS {
+			writeFpuS(fpu, fd, fpuArithmeticS(fun, readFpuS(fpu, fs), readFpuS(fpu, ft)))
+		} else {
+			writeFpuD(fpu, fd, fpuArithmeticD(fun, readFpuD(fpu, fs), readFpuD(fpu, ft)))
+		}
+	case fun <= 0x7 && isFloat: // abs, mov, neg
+		if format == fpuFmtS {
+			writeFpuS(fpu, fd, uint32(fpuSignOp(fun, uint64(readFpuS(fpu, fs)), 31)))
+		} else {
+			writeFpuD(fpu, fd, fpuSignOp(fun, readFpuD(fpu, fs), 63))
+		}
+	case fun <= 0xF && isFloat: // round.l, trunc.l, ceil.l, floor.l, round.w, trunc.w, ceil.w, floor.w
+		val := readFpuFloat(fpu, fs, format)
+		if fun < 0xC {
+			writeFpuD(fpu, fd, fpuToInt64(val, fun&3))
+		} else {
+			writeFpuS(fpu, fd, fpuToInt32(val, fun&3))
+		}
+	case fun == 0x20 && format != fpuFmtS: // cvt.s
+		switch format {
+		case fpuFmtD:
+			writeFpuS(fpu, fd, fpuCanonicalS(float32(math.Float64frombits(readFpuD(fpu, fs)))))
+		case fpuFmtW:
+			writeFpuS(fpu, fd, math.Float32bits(float32(int32(readFpuS(fpu, fs)))))
+		case fpuFmtL:
+			writeFpuS(fpu, fd, math.Float32bits(float32(int64(readFpuD(fpu, fs)))))
+		}
+	case fun == 0x21 && format != fpuFmtD: // cvt.d
+		switch format {
+		case fpuFmtS:
+			writeFpuD(fpu, fd, fpuCanonicalD(float64(math.Float32frombits(readFpuS(fpu, fs)))))
+		case fpuFmtW:
+			writeFpuD(fpu, fd, math.Float64bits(float64(int32(readFpuS(fpu, fs)))))
+		case fpuFmtL:
+			writeFpuD(fpu, fd, math.Float64bits(float64(int64(readFpuD(fpu, fs)))))
+		}
+	case fun == 0x24 && isFloat: // cvt.w, with the round-to-nearest rounding mode
+		writeFpuS(fpu, fd, fpuToInt32(readFpuFloat(fpu, fs, format), 0))
+	case fun == 0x25 && isFloat: // cvt.l, with the round-to-nearest rounding mode
+		writeFpuD(fpu, fd, fpuToInt64(readFpuFloat(fpu, fs, format), 0))
+	case fun >= 0x30 && isFloat: // c.cond
+		x, y := readFpuFloat(fpu, fs, format), readFpuFloat(fpu, ft, format)
+		unordered := math.IsNaN(x) || math.IsNaN(y)
+		cond := (fun&1 != 0 && unordered) || (fun&2 != 0 && x == y) || (fun&4 != 0 && x < y)
+		fpu.FCSR = setFpuConditionCode(fpu.FCSR, (insn>>8)&7, cond)
+	default:
+		panic(illegalInstructionFault)
+	}
+}
+
+func readFpuS(fpu *mipsevm.FpuState, r uint32) uint32 {
+	return uint32(fpu.FPR[r])
+}
+
+func writeFpuS(fpu *mipsevm.FpuState, r uint32, val uint32) {
+	fpu.FPR[r] = Word(val)
+}
+
+// readFpuD reads a 64-bit value, from an even/odd register pair in 32-bit builds.
+func readFpuD(fpu *mipsevm.FpuState, r uint32) uint64 {
+	if arch.IsMips32 {
+		if r&1 != 0 {
+			panic(illegalInstructionFault)
+		}
+		return uint64(fpu.FPR[r+1])<<32 | uint64(fpu.FPR[r])
+	}
+	return uint64(fpu.FPR[r])
+}
+
+// writeFpuD writes a 64-bit value, to an even/odd register pair in 32-bit builds.
+func writeFpuD(fpu *mipsevm.FpuState, r uint32, val uint64) {
+	if arch.IsMips32 {
+		if r&1 != 0 {
+			panic(illegalInstructionFault)
+		}
+		fpu.FPR[r] = Word(uint32(val))
+		fpu.FPR[r+1] = Word(val >> 32)
+		return
+	}
+	fpu.FPR[r] = Word(val)
+}
+
+// readFpuFloat reads a single or double as a float64. The conversion of a single is exact.
+func readFpuFloat(fpu *mipsevm.FpuState, r uint32, format uint32) float64 {
+	if format == fpuFmtS {
+		return float64(math.Float32frombits(readFpuS(fpu, r)))
+	}
+	return math.Float64frombits(readFpuD(fpu, r))
+}
+
+func fpuArithmeticS(fun uint32, a, b uint32) uint32 {
+	x, y := math.Float32frombits(a), math.Float32frombits(b)
+	var res float32
+	switch fun {
+	case 0x0:
+		res = x + y
+	case 0x1:
+		res = x - y
+	case 0x2:
+		res = x * y
+	case 0x3:
+		res = x / y
+	case 0x4:
+		// the double square root is exact enough to round correctly to single precision
+		res = float32(math.Sqrt(float64(x)))
+	}
+	return fpuCanonicalS(res)
+}
+
+func fpuArithmeticD(fun uint32, a, b uint64) uint64 {
+	x, y := math.Float64frombits(a), math.Float64frombits(b)
+	var res float64
+	switch fun {
+	case 0x0:
+		res = x + y
+	case 0x1:
+		res = x - y
+	case 0x2:
+		res = x * y
+	case 0x3:
+		res = x / y
+	case 0x4:
+		res = math.Sqrt(x)
+	}
+	return fpuCanonicalD(res)
+}
+
+// fpuSignOp executes abs, mov or neg, which only operate on the sign bit.
+func fpuSignOp(fun uint32, val uint64, signBit uint) uint64 {
+	switch fun {
+	case 0x5: // abs
+		return val &^ (1 << signBit)
+	case 0x7: // neg
+		return val ^ (1 << signBit)
+	}
+	return val // mov
+}
+
+func fpuCanonicalS(val float32) uint32 {
+	if val != val {
+		return fpuCanonicalNaNS
+	}
+	return math.Float32bits(val)
+}
+
+func fpuCanonicalD(val float64) uint64 {
+	if val != val {
+		return fpuCanonicalNaND
+	}
+	return math.Float64bits(val)
+}
+
+// fpuRound rounds to an integral value with the rounding mode: nearest-even, towards zero, up or down.
+func fpuRound(val float64, mode uint32) float64 {
+	switch mode {
+	case 0:
+		return math.RoundToEven(val)
+	case 1:
+		return math.Trunc(val)
+	case 2:
+		return math.Ceil(val)
+	default:
+		return math.Floor(val)
+	}
+}
+
+func fpuToInt32(val float64, mode uint32) uint32 {
+	val = fpuRound(val, mode)
+	// NaN fails the range check as well
+	if !(val >= math.MinInt32 && val <= math.MaxInt32) {
+		return fpuInvalidW
+	}
+	return uint32(int32(val))
+}
+
+func fpuToInt64(val float64, mode uint32) uint64 {
+	val = fpuRound(val, mode)
+	// NaN fails the range check as well, and 2^63 is the first float above the int64 range
+	if !(val >= math.MinInt64 && val < -math.MinInt64) {
+		return fpuInvalidL
+	}
+	return uint64(int64(val))
+}
+
+// fpuConditionCode returns the FCSR condition code bit cc: FCC0 is bit 23, FCC1-7 are bits 25-31.
+func fpuConditionCode(fcsr uint32, cc uint32) bool {
+	return (fcsr>>fpuConditionCodeBit(cc))&1 == 1
+}
+
+func setFpuConditionCode(fcsr uint32, cc uint32, val bool) uint32 {
+	bit := fpuConditionCodeBit(cc)
+	fcsr &^= 1 << bit
+	if val {
+		fcsr |= 1 << bit
+	}
+	return fcsr
+}
+
+func fpuConditionCodeBit(cc uint32) uint32 {
+	if cc == 0 {
+		return 23
+	}
+	return 24 + cc
+}
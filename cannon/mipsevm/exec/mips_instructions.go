@@ -1,34 +1,72 @@
 package exec
 
 import (
+	"math/bits"
+
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 )
 
-func GetInstructionDetails(pc uint32, memory *memory.Memory) (insn, opcode, fun uint32) {
-	insn = memory.GetMemory(pc)
+type Word = arch.Word
+
+const (
+	OpLoadLinked       = 0x30
+	OpStoreConditional = 0x38
+	// 64-bit only
+	OpLoadLinked64       = 0x34
+	OpStoreConditional64 = 0x3C
+	OpLoadDoubleLeft     = 0x1A
+	OpLoadDoubleRight    = 0x1B
+	OpLoadDouble         = 0x37
+)
+
+func GetInstructionDetails(pc Word, memory *memory.Memory) (insn, opcode, fun uint32) {
+	insn = memory.GetUint32(pc)
 	opcode = insn >> 26 // First 6-bits
 	fun = insn & 0x3f   // Last 6-bits
 
 	return insn, opcode, fun
 }
 
-func ExecMipsCoreStepLogic(cpu *mipsevm.CpuScalars, registers *[32]uint32, memory *memory.Memory, insn, opcode, fun uint32, memTracker MemTracker, stackTracker StackTracker) error {
+// ExecMipsCoreStepLogic executes any instruction but a syscall.
+// The fpu is nil if the VM has no floating-point coprocessor, in which case its instructions are illegal.
+// It returns a *mipsevm.FaultError if the instruction faults, in which case the step must not be completed.
+func ExecMipsCoreStepLogic(cpu *mipsevm.CpuScalars, registers *[32]Word, fpu *mipsevm.FpuState, memory *memory.Memory, insn, opcode, fun uint32, memTracker MemTracker, stackTracker StackTracker) error {
+	pc := cpu.PC
+	err := execMipsCoreStepLogic(cpu, registers, fpu, memory, insn, opcode, fun, memTracker, stackTracker)
+	if err != nil {
+		return &mipsevm.FaultError{Err: err, PC: pc, Insn: insn}
+	}
+	return nil
+}
+
+func execMipsCoreStepLogic(cpu *mipsevm.CpuScalars, registers *[32]Word, fpu *mipsevm.FpuState, memory *memory.Memory, insn, opcode, fun uint32, memTracker MemTracker, stackTracker StackTracker) (err error) {
+	defer recoverFault(&err)
+
+	// floating-point coprocessor instructions, and the loads and stores of its registers
+	if IsFpuInstruction(opcode) {
+		if fpu == nil {
+			return mipsevm.ErrIllegalInstruction
+		}
+		return ExecMipsFpuStepLogic(cpu, registers, fpu, memory, insn, opcode, memTracker)
+	}
+
 	// j-type j/jal
 	if opcode == 2 || opcode == 3 {
 		linkReg := uint32(0)
 		if opcode == 3 {
-			linkReg = 31
+			linkReg = arch.RegRA
 		}
-		// Take top 4 bits of the next PC (its 256 MB region), and concatenate with the 26-bit offset
-		target := (cpu.NextPC & 0xF0000000) | ((insn & 0x03FFFFFF) << 2)
+		// Take the top bits of the next PC (its 256 MB region), and concatenate with the 26-bit offset
+		target := (cpu.NextPC & SignExtend(0xF0000000, 32)) | Word((insn&0x03FFFFFF)<<2)
 		stackTracker.PushStack(cpu.PC, target)
 		return HandleJump(cpu, registers, linkReg, target)
 	}
 
 	// register fetch
-	rs := uint32(0) // source register 1 value
-	rt := uint32(0) // source register 2 / temp value
+	rs := Word(0) // source register 1 value
+	rt := Word(0) // source register 2 / temp value
 	rtReg := (insn >> 16) & 0x1F
 
 	// R-type or I-type (stores rt)
@@ -38,15 +76,18 @@ func ExecMipsCoreStepLogic(cpu *mipsevm.CpuScalars, registers *[32]uint32, memor
 		// R-type (stores rd)
 		rt = registers[rtReg]
 		rdReg = (insn >> 11) & 0x1F
+	} else if !arch.IsMips32 && (opcode == OpLoadDoubleLeft || opcode == OpLoadDoubleRight) {
+		// store actual rt with ldl and ldr
+		rt = registers[rtReg]
 	} else if opcode < 0x20 {
 		// rt is SignExtImm
 		// don't sign extend for andi, ori, xori
 		if opcode == 0xC || opcode == 0xD || opcode == 0xe {
 			// ZeroExtImm
-			rt = insn & 0xFFFF
+			rt = Word(insn & 0xFFFF)
 		} else {
 			// SignExtImm
-			rt = SignExtend(insn&0xFFFF, 16)
+			rt = SignExtend(Word(insn&0xFFFF), 16)
 		}
 	} else if opcode >= 0x28 || opcode == 0x22 || opcode == 0x26 {
 		// store rt value with store
@@ -60,17 +101,17 @@ func ExecMipsCoreStepLogic(cpu *mipsevm.CpuScalars, registers *[32]uint32, memor
 		return HandleBranch(cpu, registers, opcode, insn, rtReg, rs)
 	}
 
-	storeAddr := uint32(0xFF_FF_FF_FF)
+	storeAddr := ^Word(0)
 	// memory fetch (all I-type)
 	// we do the load for stores also
-	mem := uint32(0)
-	if opcode >= 0x20 {
+	mem := Word(0)
+	if opcode >= 0x20 || (!arch.IsMips32 && (opcode == OpLoadDoubleLeft || opcode == OpLoadDoubleRight)) {
 		// M[R[rs]+SignExtImm]
-		rs += SignExtend(insn&0xFFFF, 16)
-		addr := rs & 0xFFFFFFFC
+		rs += SignExtend(Word(insn&0xFFFF), 16)
+		addr := rs & arch.AddressMask
 		memTracker.TrackMemAccess(addr)
 		mem = memory.GetMemory(addr)
-		if opcode >= 0x28 && opcode != 0x30 {
+		if IsStore(opcode) {
 			// store
 			storeAddr = addr
 			// store opcodes don't write back to a register
@@ -81,7 +122,7 @@ func ExecMipsCoreStepLogic(cpu *mipsevm.CpuScalars, registers *[32]uint32, memor
 	// ALU
 	val := ExecuteMipsInstruction(insn, opcode, fun, rs, rt, mem)
 
-	if opcode == 0 && fun >= 8 && fun < 0x1c {
+	if opcode == 0 && fun >= 8 && fun < 0x20 {
 		if fun == 8 || fun == 9 { // jr/jalr
 			linkReg := uint32(0)
 			if fun == 9 {
@@ -100,18 +141,18 @@ func ExecMipsCoreStepLogic(cpu *mipsevm.CpuScalars, registers *[32]uint32, memor
 
 		// lo and hi registers
 		// can write back
-		if fun >= 0x10 && fun < 0x1c {
+		if (fun >= 0x10 && fun < 0x14) || (fun >= 0x18 && fun < 0x20) {
 			return HandleHiLo(cpu, registers, fun, rs, rt, rdReg)
 		}
 	}
 
 	// store conditional, write a 1 to rt
-	if opcode == 0x38 && rtReg != 0 {
+	if (opcode == OpStoreConditional || (!arch.IsMips32 && opcode == OpStoreConditional64)) && rtReg != 0 {
 		registers[rtReg] = 1
 	}
 
 	// write memory
-	if storeAddr != 0xFF_FF_FF_FF {
+	if storeAddr != ^Word(0) {
 		memTracker.TrackMemAccess(storeAddr)
 		memory.SetMemory(storeAddr, val)
 	}
@@ -120,8 +161,24 @@ func ExecMipsCoreStepLogic(cpu *mipsevm.CpuScalars, registers *[32]uint32, memor
 	return HandleRd(cpu, registers, rdReg, val, true)
 }
 
-func ExecuteMipsInstruction(insn, opcode, fun, rs, rt, mem uint32) uint32 {
-	if opcode == 0 || (opcode >= 8 && opcode < 0xF) {
+// IsStore returns whether the opcode of a load or store instruction writes to memory.
+// The store opcodes are listed explicitly: the store range also holds loads (ll, lwc1, ldc1, lld, ld) and cache.
+// The 32-bit opcodes must match isStore of MIPS2.sol, which breaks the ll/sc reservation on the same stores.
+func IsStore(opcode uint32) bool {
+	switch opcode {
+	case 0x28, 0x29, 0x2A, 0x2B, 0x2E: // sb, sh, swl, sw, swr
+		return true
+	case OpStoreConditional, OpStoreWordCop1, OpStoreDoubleCop1: // sc, swc1, sdc1
+		return true
+	case 0x2C, 0x2D, 0x3F, OpStoreConditional64: // sdl, sdr, sd, scd
+		return !arch.IsMips32
+	default:
+		return false
+	}
+}
+
+func ExecuteMipsInstruction(insn, opcode, fun uint32, rs, rt, mem Word) Word {
+	if opcode == 0 || (opcode >= 8 && opcode < 0xF) || (!arch.IsMips32 && (opcode == 0x18 || opcode == 0x19)) {
 		// transform ArithLogI to SPECIAL
 		switch opcode {
 		case 8:
@@ -138,24 +195,32 @@ func ExecuteMipsInstruction(insn, opcode, fun, rs, rt, mem uint32) uint32 {
 			fun = 0x25 // ori
 		case 0xE:
 			fun = 0x26 // xori
+		case 0x18:
+			fun = 0x2C // daddi
+		case 0x19:
+			fun = 0x2D // daddiu
 		}
 
 		switch fun {
 		case 0x00: // sll
-			return rt << ((insn >> 6) & 0x1F)
+			shamt := Word((insn >> 6) & 0x1F)
+			return SignExtend((rt&0xFFFFFFFF)<<shamt, 32)
 		case 0x02: // srl
-			return rt >> ((insn >> 6) & 0x1F)
+			shamt := Word((insn >> 6) & 0x1F)
+			return SignExtend((rt&0xFFFFFFFF)>>shamt, 32)
 		case 0x03: // sra
-			shamt := (insn >> 6) & 0x1F
-			return SignExtend(rt>>shamt, 32-shamt)
+			shamt := Word((insn >> 6) & 0x1F)
+			return SignExtend((rt&0xFFFFFFFF)>>shamt, 32-shamt)
 		case 0x04: // sllv
-			return rt << (rs & 0x1F)
+			shamt := rs & 0x1F
+			return SignExtend((rt&0xFFFFFFFF)<<shamt, 32)
 		case 0x06: // srlv
-			return rt >> (rs & 0x1F)
+			shamt := rs & 0x1F
+			return SignExtend((rt&0xFFFFFFFF)>>shamt, 32)
 		case 0x07: // srav
 			shamt := rs & 0x1F
-			return SignExtend(rt>>shamt, 32-shamt)
-		// functs in range [0x8, 0x1b] are handled specially by other functions
+			return SignExtend((rt&0xFFFFFFFF)>>shamt, 32-shamt)
+		// functs in range [0x8, 0x1f] are handled specially by other functions
 		case 0x08: // jr
 			return rs
 		case 0x09: // jalr
@@ -177,6 +242,15 @@ func ExecuteMipsInstruction(insn, opcode, fun, rs, rt, mem uint32) uint32 {
 			return rs
 		case 0x13: // mtlo
 			return rs
+		case 0x14: // dsllv
+			assertMips64()
+			return rt << (rs & 0x3F)
+		case 0x16: // dsrlv
+			assertMips64()
+			return rt >> (rs & 0x3F)
+		case 0x17: // dsrav
+			assertMips64()
+			return Word(arch.SignedWord(rt) >> (rs & 0x3F))
 		case 0x18: // mult
 			return rs
 		case 0x19: // multu
@@ -185,15 +259,18 @@ func ExecuteMipsInstruction(insn, opcode, fun, rs, rt, mem uint32) uint32 {
 			return rs
 		case 0x1b: // divu
 			return rs
+		case 0x1c, 0x1d, 0x1e, 0x1f: // dmult, dmultu, ddiv, ddivu
+			assertMips64()
+			return rs
 		// The rest includes transformed R-type arith imm instructions
 		case 0x20: // add
-			return rs + rt
+			return SignExtend((rs+rt)&0xFFFFFFFF, 32)
 		case 0x21: // addu
-			return rs + rt
+			return SignExtend((rs+rt)&0xFFFFFFFF, 32)
 		case 0x22: // sub
-			return rs - rt
+			return SignExtend((rs-rt)&0xFFFFFFFF, 32)
 		case 0x23: // subu
-			return rs - rt
+			return SignExtend((rs-rt)&0xFFFFFFFF, 32)
 		case 0x24: // and
 			return rs & rt
 		case 0x25: // or
@@ -203,7 +280,7 @@ func ExecuteMipsInstruction(insn, opcode, fun, rs, rt, mem uint32) uint32 {
 		case 0x27: // nor
 			return ^(rs | rt)
 		case 0x2a: // slti
-			if int32(rs) < int32(rt) {
+			if arch.SignedWord(rs) < arch.SignedWord(rt) {
 				return 1
 			}
 			return 0
@@ -212,8 +289,32 @@ func ExecuteMipsInstruction(insn, opcode, fun, rs, rt, mem uint32) uint32 {
 				return 1
 			}
 			return 0
+		case 0x2c, 0x2d: // dadd, daddu
+			assertMips64()
+			return rs + rt
+		case 0x2e, 0x2f: // dsub, dsubu
+			assertMips64()
+			return rs - rt
+		case 0x38: // dsll
+			assertMips64()
+			return rt << ((insn >> 6) & 0x1F)
+		case 0x3a: // dsrl
+			assertMips64()
+			return rt >> ((insn >> 6) & 0x1F)
+		case 0x3b: // dsra
+			assertMips64()
+			return Word(arch.SignedWord(rt) >> ((insn >> 6) & 0x1F))
+		case 0x3c: // dsll32
+			assertMips64()
+			return rt << (((insn >> 6) & 0x1F) + 32)
+		case 0x3e: // dsrl32
+			assertMips64()
+			return rt >> (((insn >> 6) & 0x1F) + 32)
+		case 0x3f: // dsra32
+			assertMips64()
+			return Word(arch.SignedWord(rt) >> (((insn >> 6) & 0x1F) + 32))
 		default:
-			panic("invalid instruction")
+			panic(illegalInstructionFault)
 		}
 	} else {
 		switch opcode {
@@ -221,70 +322,128 @@ func ExecuteMipsInstruction(insn, opcode, fun, rs, rt, mem uint32) uint32 {
 		case 0x1C:
 			switch fun {
 			case 0x2: // mul
-				return uint32(int32(rs) * int32(rt))
+				return Word(int32(rs) * int32(rt))
 			case 0x20, 0x21: // clz, clo
 				if fun == 0x20 {
 					rs = ^rs
 				}
-				i := uint32(0)
+				i := Word(0)
 				for ; rs&0x80000000 != 0; i++ {
 					rs <<= 1
 				}
 				return i
+			case 0x24: // dclz
+				assertMips64()
+				return Word(bits.LeadingZeros64(uint64(rs)))
+			case 0x25: // dclo
+				assertMips64()
+				return Word(bits.LeadingZeros64(^uint64(rs)))
 			}
 		case 0x0F: // lui
-			return rt << 16
+			return SignExtend(rt<<16, 32)
 		case 0x20: // lb
-			return SignExtend((mem>>(24-(rs&3)*8))&0xFF, 8)
+			return SelectSubWord(rs, mem, 1, true)
 		case 0x21: // lh
-			return SignExtend((mem>>(16-(rs&2)*8))&0xFFFF, 16)
+			return SelectSubWord(rs, mem, 2, true)
 		case 0x22: // lwl
-			val := mem << ((rs & 3) * 8)
+			w := uint32(SelectSubWord(rs, mem, 4, false))
+			val := w << ((rs & 3) * 8)
 			mask := uint32(0xFFFFFFFF) << ((rs & 3) * 8)
-			return (rt & ^mask) | val
+			return SignExtend(Word((uint32(rt)&^mask)|val), 32)
 		case 0x23: // lw
-			return mem
+			return SelectSubWord(rs, mem, 4, true)
 		case 0x24: // lbu
-			return (mem >> (24 - (rs&3)*8)) & 0xFF
+			return SelectSubWord(rs, mem, 1, false)
 		case 0x25: //  lhu
-			return (mem >> (16 - (rs&2)*8)) & 0xFFFF
+			return SelectSubWord(rs, mem, 2, false)
 		case 0x26: //  lwr
-			val := mem >> (24 - (rs&3)*8)
+			w := uint32(SelectSubWord(rs, mem, 4, false))
+			val := w >> (24 - (rs&3)*8)
 			mask := uint32(0xFFFFFFFF) >> (24 - (rs&3)*8)
-			return (rt & ^mask) | val
+			lwrResult := (uint32(rt) & ^mask) | val
+			if rs&3 == 3 {
+				// the whole word is loaded, including its sign bit
+				return SignExtend(Word(lwrResult), 32)
+			}
+			// the upper word of a 64-bit register is left untouched
+			return (rt &^ 0xFFFFFFFF) | Word(lwrResult)
+		case 0x27: // lwu
+			assertMips64()
+			return SelectSubWord(rs, mem, 4, false)
 		case 0x28: //  sb
-			val := (rt & 0xFF) << (24 - (rs&3)*8)
-			mask := 0xFFFFFFFF ^ uint32(0xFF<<(24-(rs&3)*8))
-			return (mem & mask) | val
+			return UpdateSubWord(rs, mem, 1, rt)
 		case 0x29: //  sh
-			val := (rt & 0xFFFF) << (16 - (rs&2)*8)
-			mask := 0xFFFFFFFF ^ uint32(0xFFFF<<(16-(rs&2)*8))
-			return (mem & mask) | val
+			return UpdateSubWord(rs, mem, 2, rt)
 		case 0x2a: //  swl
-			val := rt >> ((rs & 3) * 8)
+			w := uint32(SelectSubWord(rs, mem, 4, false))
+			val := uint32(rt) >> ((rs & 3) * 8)
 			mask := uint32(0xFFFFFFFF) >> ((rs & 3) * 8)
-			return (mem & ^mask) | val
+			return UpdateSubWord(rs, mem, 4, Word((w & ^mask)|val))
 		case 0x2b: //  sw
-			return rt
+			return UpdateSubWord(rs, mem, 4, rt)
 		case 0x2e: //  swr
-			val := rt << (24 - (rs&3)*8)
+			w := uint32(SelectSubWord(rs, mem, 4, false))
+			val := uint32(rt) << (24 - (rs&3)*8)
 			mask := uint32(0xFFFFFFFF) << (24 - (rs&3)*8)
-			return (mem & ^mask) | val
+			return UpdateSubWord(rs, mem, 4, Word((w & ^mask)|val))
 		case 0x30: //  ll
-			return mem
+			return SelectSubWord(rs, mem, 4, true)
 		case 0x38: //  sc
+			return UpdateSubWord(rs, mem, 4, rt)
+		case OpLoadDoubleLeft: // ldl
+			assertMips64()
+			sl := (rs & 0x7) << 3
+			val := mem << sl
+			mask := ^Word(0) << sl
+			return val | (rt & ^mask)
+		case OpLoadDoubleRight: // ldr
+			assertMips64()
+			sr := 56 - ((rs & 0x7) << 3)
+			val := mem >> sr
+			mask := ^Word(0) >> sr
+			return val | (rt & ^mask)
+		case 0x2c: // sdl
+			assertMips64()
+			sr := (rs & 0x7) << 3
+			val := rt >> sr
+			mask := ^Word(0) >> sr
+			return val | (mem & ^mask)
+		case 0x2d: // sdr
+			assertMips64()
+			sl := 56 - ((rs & 0x7) << 3)
+			val := rt << sl
+			mask := ^Word(0) << sl
+			return val | (mem & ^mask)
+		case OpLoadLinked64: // lld
+			assertMips64()
+			return mem
+		case OpLoadDouble: // ld
+			assertMips64()
+			return mem
+		case OpStoreConditional64: // scd
+			assertMips64()
+			return rt
+		case 0x3F: // sd
+			assertMips64()
 			return rt
 		default:
-			panic("invalid instruction")
+			panic(illegalInstructionFault)
 		}
 	}
-	panic("invalid instruction")
+	panic(illegalInstructionFault)
+}
+
+// assertMips64 faults the step of instructions that are only valid in the 64-bit VM.
+func assertMips64() {
+	if arch.IsMips32 {
+		panic(illegalInstructionFault)
+	}
 }
 
-func SignExtend(dat uint32, idx uint32) uint32 {
-	isSigned := (dat >> (idx - 1)) != 0
-	signed := ((uint32(1) << (32 - idx)) - 1) << idx
-	mask := (uint32(1) << idx) - 1
+func SignExtend(dat Word, idx Word) Word {
+	isSigned := (dat>>(idx-1))&1 != 0
+	signed := ((Word(1) << (arch.WordSize - idx)) - 1) << idx
+	mask := (Word(1) << idx) - 1
 	if isSigned {
 		return dat&mask | signed
 	} else {
@@ -292,9 +451,43 @@ func SignExtend(dat uint32, idx uint32) uint32 {
 	}
 }
 
-func HandleBranch(cpu *mipsevm.CpuScalars, registers *[32]uint32, opcode uint32, insn uint32, rtReg uint32, rs uint32) error {
+// SelectSubWord returns the bytes of the memory word at addr, for a load of byteLength bytes.
+func SelectSubWord(addr Word, memWord Word, byteLength Word, signExtend bool) Word {
+	dataMask, bitOffset, bitLength := calculateSubWordMaskAndOffset(addr, byteLength)
+	retVal := (memWord >> bitOffset) & dataMask
+	if signExtend {
+		retVal = SignExtend(retVal, bitLength)
+	}
+	return retVal
+}
+
+// UpdateSubWord returns the memory word with the bytes at addr replaced, for a store of byteLength bytes.
+func UpdateSubWord(addr Word, memWord Word, byteLength Word, value Word) Word {
+	dataMask, bitOffset, _ := calculateSubWordMaskAndOffset(addr, byteLength)
+	subWordValue := dataMask & value
+	memUpdateMask := dataMask << bitOffset
+	return subWordValue<<bitOffset | (^memUpdateMask)&memWord
+}
+
+func calculateSubWordMaskAndOffset(addr Word, byteLength Word) (dataMask, bitOffset, bitLength Word) {
+	bitLength = byteLength << 3
+	dataMask = ^Word(0) >> (arch.WordSize - bitLength)
+
+	// the index of the sub-word in the big-endian memory word, from the low-order bits of the address
+	byteIndex := addr & arch.ExtMask &^ (byteLength - 1)
+	bitOffset = (arch.WordSizeBytes - byteLength - byteIndex) << 3
+	return dataMask, bitOffset, bitLength
+}
+
+// EffectiveAddress returns the word-aligned memory address accessed by a load or store instruction.
+func EffectiveAddress(insn uint32, registers *[32]Word) Word {
+	base := registers[(insn>>21)&0x1F]
+	return (base + SignExtend(Word(insn&0xFFFF), 16)) & arch.AddressMask
+}
+
+func HandleBranch(cpu *mipsevm.CpuScalars, registers *[32]Word, opcode uint32, insn uint32, rtReg uint32, rs Word) error {
 	if cpu.NextPC != cpu.PC+4 {
-		panic("branch in delay slot")
+		return mipsevm.ErrBranchInDelaySlot
 	}
 
 	shouldBranch := false
@@ -302,32 +495,35 @@ func HandleBranch(cpu *mipsevm.CpuScalars, registers *[32]uint32, opcode uint32,
 		rt := registers[rtReg]
 		shouldBranch = (rs == rt && opcode == 4) || (rs != rt && opcode == 5)
 	} else if opcode == 6 {
-		shouldBranch = int32(rs) <= 0 // blez
+		shouldBranch = arch.SignedWord(rs) <= 0 // blez
 	} else if opcode == 7 {
-		shouldBranch = int32(rs) > 0 // bgtz
+		shouldBranch = arch.SignedWord(rs) > 0 // bgtz
 	} else if opcode == 1 {
 		// regimm
 		rtv := (insn >> 16) & 0x1F
 		if rtv == 0 { // bltz
-			shouldBranch = int32(rs) < 0
+			shouldBranch = arch.SignedWord(rs) < 0
 		}
 		if rtv == 1 { // bgez
-			shouldBranch = int32(rs) >= 0
+			shouldBranch = arch.SignedWord(rs) >= 0
 		}
 	}
 
 	prevPC := cpu.PC
 	cpu.PC = cpu.NextPC // execute the delay slot first
 	if shouldBranch {
-		cpu.NextPC = prevPC + 4 + (SignExtend(insn&0xFFFF, 16) << 2) // then continue with the instruction the branch jumps to.
+		cpu.NextPC = prevPC + 4 + (SignExtend(Word(insn&0xFFFF), 16) << 2) // then continue with the instruction the branch jumps to.
 	} else {
 		cpu.NextPC = cpu.NextPC + 4 // branch not taken
 	}
 	return nil
 }
 
-func HandleHiLo(cpu *mipsevm.CpuScalars, registers *[32]uint32, fun uint32, rs uint32, rt uint32, storeReg uint32) error {
-	val := uint32(0)
+func HandleHiLo(cpu *mipsevm.CpuScalars, registers *[32]Word, fun uint32, rs Word, rt Word, storeReg uint32) error {
+	if ((fun == 0x1a || fun == 0x1b) && uint32(rt) == 0) || ((fun == 0x1e || fun == 0x1f) && rt == 0) { // div, divu, ddiv, ddivu
+		return mipsevm.ErrDivisionByZero
+	}
+	val := Word(0)
 	switch fun {
 	case 0x10: // mfhi
 		val = cpu.HI
@@ -339,16 +535,37 @@ func HandleHiLo(cpu *mipsevm.CpuScalars, registers *[32]uint32, fun uint32, rs u
 		cpu.LO = rs
 	case 0x18: // mult
 		acc := uint64(int64(int32(rs)) * int64(int32(rt)))
-		cpu.HI = uint32(acc >> 32)
-		cpu.LO = uint32(acc)
+		cpu.HI = Word(int32(acc >> 32))
+		cpu.LO = Word(int32(acc))
 	case 0x19: // multu
-		acc := uint64(uint64(rs) * uint64(rt))
-		cpu.HI = uint32(acc >> 32)
-		cpu.LO = uint32(acc)
+		acc := uint64(uint32(rs)) * uint64(uint32(rt))
+		cpu.HI = Word(int32(acc >> 32))
+		cpu.LO = Word(int32(acc))
 	case 0x1a: // div
-		cpu.HI = uint32(int32(rs) % int32(rt))
-		cpu.LO = uint32(int32(rs) / int32(rt))
+		cpu.HI = Word(int32(rs) % int32(rt))
+		cpu.LO = Word(int32(rs) / int32(rt))
 	case 0x1b: // divu
+		cpu.HI = Word(int32(uint32(rs) % uint32(rt)))
+		cpu.LO = Word(int32(uint32(rs) / uint32(rt)))
+	case 0x1c: // dmult
+		hi, lo := bits.Mul64(uint64(rs), uint64(rt))
+		// correct the high bits of the unsigned product for negative operands
+		if int64(rs) < 0 {
+			hi -= uint64(rt)
+		}
+		if int64(rt) < 0 {
+			hi -= uint64(rs)
+		}
+		cpu.HI = Word(hi)
+		cpu.LO = Word(lo)
+	case 0x1d: // dmultu
+		hi, lo := bits.Mul64(uint64(rs), uint64(rt))
+		cpu.HI = Word(hi)
+		cpu.LO = Word(lo)
+	case 0x1e: // ddiv
+		cpu.HI = Word(arch.SignedWord(rs) % arch.SignedWord(rt))
+		cpu.LO = Word(arch.SignedWord(rs) / arch.SignedWord(rt))
+	case 0x1f: // ddivu
 		cpu.HI = rs % rt
 		cpu.LO = rs / rt
 	}
@@ -362,9 +579,9 @@ func HandleHiLo(cpu *mipsevm.CpuScalars, registers *[32]uint32, fun uint32, rs u
 	return nil
 }
 
-func HandleJump(cpu *mipsevm.CpuScalars, registers *[32]uint32, linkReg uint32, dest uint32) error {
+func HandleJump(cpu *mipsevm.CpuScalars, registers *[32]Word, linkReg uint32, dest Word) error {
 	if cpu.NextPC != cpu.PC+4 {
-		panic("jump in delay slot")
+		return mipsevm.ErrJumpInDelaySlot
 	}
 	prevPC := cpu.PC
 	cpu.PC = cpu.NextPC
@@ -375,7 +592,7 @@ func HandleJump(cpu *mipsevm.CpuScalars, registers *[32]uint32, linkReg uint32,
 	return nil
 }
 
-func HandleRd(cpu *mipsevm.CpuScalars, registers *[32]uint32, storeReg uint32, val uint32, conditional bool) error {
+func HandleRd(cpu *mipsevm.CpuScalars, registers *[32]Word, storeReg uint32, val Word, conditional bool) error {
 	if storeReg >= 32 {
 		panic("invalid register")
 	}
@@ -8,66 +8,72 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 )
 
-// Syscall codes
+// Syscall codes, of the ABI of the architecture
 const (
-	SysMmap       = 4090
-	SysMunmap     = 4091
-	SysBrk        = 4045
-	SysClone      = 4120
-	SysExitGroup  = 4246
-	SysRead       = 4003
-	SysWrite      = 4004
-	SysFcntl      = 4055
-	SysExit       = 4001
-	SysSchedYield = 4162
-	SysGetTID     = 4222
-	SysFutex      = 4238
-	SysOpen       = 4005
-	SysNanosleep  = 4166
+	SysMmap       = arch.SysMmap
+	SysMunmap     = arch.SysMunmap
+	SysBrk        = arch.SysBrk
+	SysClone      = arch.SysClone
+	SysExitGroup  = arch.SysExitGroup
+	SysRead       = arch.SysRead
+	SysWrite      = arch.SysWrite
+	SysFcntl      = arch.SysFcntl
+	SysExit       = arch.SysExit
+	SysSchedYield = arch.SysSchedYield
+	SysGetTID     = arch.SysGetTID
+	SysFutex      = arch.SysFutex
+	SysOpen       = arch.SysOpen
+	SysNanosleep  = arch.SysNanosleep
 )
 
 // Noop Syscall codes
+// Syscalls that do not exist in the ABI of the architecture are arch.UndefinedSysNr.
 const (
-	SysGetAffinity   = 4240
-	SysMadvise       = 4218
-	SysRtSigprocmask = 4195
-	SysSigaltstack   = 4206
-	SysRtSigaction   = 4194
-	SysPrlimit64     = 4338
-	SysClose         = 4006
-	SysPread64       = 4200
-	SysFstat64       = 4215
-	SysOpenAt        = 4288
-	SysReadlink      = 4085
-	SysReadlinkAt    = 4298
-	SysIoctl         = 4054
-	SysEpollCreate1  = 4326
-	SysPipe2         = 4328
-	SysEpollCtl      = 4249
-	SysEpollPwait    = 4313
-	SysGetRandom     = 4353
-	SysUname         = 4122
-	SysStat64        = 4213
-	SysGetuid        = 4024
-	SysGetgid        = 4047
-	SysLlseek        = 4140
-	SysMinCore       = 4217
-	SysTgkill        = 4266
+	SysGetAffinity   = arch.SysGetAffinity
+	SysMadvise       = arch.SysMadvise
+	SysRtSigprocmask = arch.SysRtSigprocmask
+	SysSigaltstack   = arch.SysSigaltstack
+	SysRtSigaction   = arch.SysRtSigaction
+	SysPrlimit64     = arch.SysPrlimit64
+	SysClose         = arch.SysClose
+	SysPread64       = arch.SysPread64
+	SysFstat         = arch.SysFstat
+	SysFstat64       = arch.SysFstat64
+	SysOpenAt        = arch.SysOpenAt
+	SysReadlink      = arch.SysReadlink
+	SysReadlinkAt    = arch.SysReadlinkAt
+	SysIoctl         = arch.SysIoctl
+	SysEpollCreate1  = arch.SysEpollCreate1
+	SysPipe2         = arch.SysPipe2
+	SysEpollCtl      = arch.SysEpollCtl
+	SysEpollPwait    = arch.SysEpollPwait
+	SysEventFd2      = arch.SysEventFd2
+	SysGetRandom     = arch.SysGetRandom
+	SysUname         = arch.SysUname
+	SysStat64        = arch.SysStat64
+	SysGetuid        = arch.SysGetuid
+	SysGetgid        = arch.SysGetgid
+	SysLlseek        = arch.SysLlseek
+	SysLseek         = arch.SysLseek
+	SysGetRLimit     = arch.SysGetRLimit
+	SysMinCore       = arch.SysMinCore
+	SysTgkill        = arch.SysTgkill
 )
 
 // Profiling-related syscalls
 // Should be able to ignore if we patch out prometheus calls and disable memprofiling
 // TODO(cp-903) - Update patching for mt-cannon so that these can be ignored
 const (
-	SysSetITimer    = 4104
-	SysTimerCreate  = 4257
-	SysTimerSetTime = 4258
-	SysTimerDelete  = 4261
-	SysClockGetTime = 4263
+	SysSetITimer    = arch.SysSetITimer
+	SysTimerCreate  = arch.SysTimerCreate
+	SysTimerSetTime = arch.SysTimerSetTime
+	SysTimerDelete  = arch.SysTimerDelete
+	SysClockGetTime = arch.SysClockGetTime
 )
 
 // File descriptors
@@ -79,15 +85,24 @@ const (
 	FdHintWrite     = 4
 	FdPreimageRead  = 5
 	FdPreimageWrite = 6
+	// The eventfd and the epoll instance of the Go runtime network poller
+	FdEventFd = 100
+	FdEpoll   = 101
+)
+
+// SysEventFd2 flags
+const (
+	EfdNonBlock = 0x80
 )
 
 // Errors
 const (
-	SysErrorSignal = ^uint32(0)
+	SysErrorSignal = ^Word(0)
 	MipsEBADF      = 0x9
 	MipsEINVAL     = 0x16
 	MipsEAGAIN     = 0xb
 	MipsETIMEDOUT  = 0x91
+	MipsENOSYS     = 0x59
 )
 
 // SysFutex-related constants
@@ -96,7 +111,7 @@ const (
 	FutexWakePrivate  = 129
 	FutexTimeoutSteps = 10_000
 	FutexNoTimeout    = ^uint64(0)
-	FutexEmptyAddr    = ^uint32(0)
+	FutexEmptyAddr    = ^Word(0)
 )
 
 // SysClone flags
@@ -135,19 +150,19 @@ const (
 	SchedQuantum = 100_000
 )
 
-func GetSyscallArgs(registers *[32]uint32) (syscallNum, a0, a1, a2, a3 uint32) {
-	syscallNum = registers[2] // v0
+func GetSyscallArgs(registers *[32]Word) (syscallNum, a0, a1, a2, a3 Word) {
+	syscallNum = registers[arch.RegSyscallNum]
 
-	a0 = registers[4]
-	a1 = registers[5]
-	a2 = registers[6]
-	a3 = registers[7]
+	a0 = registers[arch.RegSyscallParam1]
+	a1 = registers[arch.RegSyscallParam2]
+	a2 = registers[arch.RegSyscallParam3]
+	a3 = registers[arch.RegSyscallParam4]
 
 	return syscallNum, a0, a1, a2, a3
 }
 
-func HandleSysMmap(a0, a1, heap uint32) (v0, v1, newHeap uint32) {
-	v1 = uint32(0)
+func HandleSysMmap(a0, a1, heap Word) (v0, v1, newHeap Word) {
+	v1 = Word(0)
 	newHeap = heap
 
 	sz := a1
@@ -172,52 +187,52 @@ func HandleSysMmap(a0, a1, heap uint32) (v0, v1, newHeap uint32) {
 	return v0, v1, newHeap
 }
 
-func HandleSysRead(a0, a1, a2 uint32, preimageKey [32]byte, preimageOffset uint32, preimageReader PreimageReader, memory *memory.Memory, memTracker MemTracker) (v0, v1, newPreimageOffset uint32) {
+func HandleSysRead(a0, a1, a2 Word, preimageKey [32]byte, preimageOffset uint32, preimageReader PreimageReader, memory *memory.Memory, memTracker MemTracker) (v0, v1 Word, newPreimageOffset uint32) {
 	// args: a0 = fd, a1 = addr, a2 = count
 	// returns: v0 = read, v1 = err code
-	v0 = uint32(0)
-	v1 = uint32(0)
+	v0 = Word(0)
+	v1 = Word(0)
 	newPreimageOffset = preimageOffset
 
 	switch a0 {
 	case FdStdin:
 		// leave v0 and v1 zero: read nothing, no error
 	case FdPreimageRead: // pre-image oracle
-		effAddr := a1 & 0xFFffFFfc
+		effAddr := a1 & arch.AddressMask
 		memTracker.TrackMemAccess(effAddr)
 		mem := memory.GetMemory(effAddr)
 		dat, datLen := preimageReader.ReadPreimage(preimageKey, preimageOffset)
 		//fmt.Printf("reading pre-image data: addr: %08x, offset: %d, datLen: %d, data: %x, key: %s  count: %d\n", a1, m.state.PreimageOffset, datLen, dat[:datLen], m.state.PreimageKey, a2)
-		alignment := a1 & 3
-		space := 4 - alignment
-		if space < datLen {
-			datLen = space
+		alignment := a1 & arch.ExtMask
+		space := arch.WordSizeBytes - alignment
+		if space < Word(datLen) {
+			datLen = uint32(space)
 		}
-		if a2 < datLen {
-			datLen = a2
+		if a2 < Word(datLen) {
+			datLen = uint32(a2)
 		}
-		var outMem [4]byte
-		binary.BigEndian.PutUint32(outMem[:], mem)
+		var outMem [arch.WordSizeBytes]byte
+		arch.ByteOrderWord.PutWord(outMem[:], mem)
 		copy(outMem[alignment:], dat[:datLen])
-		memory.SetMemory(effAddr, binary.BigEndian.Uint32(outMem[:]))
+		memory.SetMemory(effAddr, arch.ByteOrderWord.Word(outMem[:]))
 		newPreimageOffset += datLen
-		v0 = datLen
+		v0 = Word(datLen)
 		//fmt.Printf("read %d pre-image bytes, new offset: %d, eff addr: %08x mem: %08x\n", datLen, m.state.PreimageOffset, effAddr, outMem)
 	case FdHintRead: // hint response
 		// don't actually read into memory, just say we read it all, we ignore the result anyway
 		v0 = a2
 	default:
-		v0 = 0xFFffFFff
+		v0 = SysErrorSignal
 		v1 = MipsEBADF
 	}
 
 	return v0, v1, newPreimageOffset
 }
 
-func HandleSysWrite(a0, a1, a2 uint32, lastHint hexutil.Bytes, preimageKey [32]byte, preimageOffset uint32, oracle mipsevm.PreimageOracle, memory *memory.Memory, memTracker MemTracker, stdOut, stdErr io.Writer) (v0, v1 uint32, newLastHint hexutil.Bytes, newPreimageKey common.Hash, newPreimageOffset uint32) {
+func HandleSysWrite(a0, a1, a2 Word, lastHint hexutil.Bytes, preimageKey [32]byte, preimageOffset uint32, oracle mipsevm.PreimageOracle, memory *memory.Memory, memTracker MemTracker, stdOut, stdErr io.Writer) (v0, v1 Word, newLastHint hexutil.Bytes, newPreimageKey common.Hash, newPreimageOffset uint32) {
 	// args: a0 = fd, a1 = addr, a2 = count
 	// returns: v0 = written, v1 = err code
-	v1 = uint32(0)
+	v1 = Word(0)
 	newLastHint = lastHint
 	newPreimageKey = preimageKey
 	newPreimageOffset = preimageOffset
@@ -245,34 +260,78 @@ func HandleSysWrite(a0, a1, a2 uint32, lastHint hexutil.Bytes, preimageKey [32]b
 		newLastHint = lastHint
 		v0 = a2
 	case FdPreimageWrite:
-		effAddr := a1 & 0xFFffFFfc
+		effAddr := a1 & arch.AddressMask
 		memTracker.TrackMemAccess(effAddr)
 		mem := memory.GetMemory(effAddr)
 		key := preimageKey
-		alignment := a1 & 3
-		space := 4 - alignment
+		alignment := a1 & arch.ExtMask
+		space := arch.WordSizeBytes - alignment
 		if space < a2 {
 			a2 = space
 		}
 		copy(key[:], key[a2:])
-		var tmp [4]byte
-		binary.BigEndian.PutUint32(tmp[:], mem)
+		var tmp [arch.WordSizeBytes]byte
+		arch.ByteOrderWord.PutWord(tmp[:], mem)
 		copy(key[32-a2:], tmp[alignment:])
 		newPreimageKey = key
 		newPreimageOffset = 0
 		//fmt.Printf("updating pre-image key: %s\n", m.state.PreimageKey)
 		v0 = a2
 	default:
-		v0 = 0xFFffFFff
+		v0 = SysErrorSignal
 		v1 = MipsEBADF
 	}
 
 	return v0, v1, newLastHint, newPreimageKey, newPreimageOffset
 }
 
-func HandleSysFcntl(a0, a1 uint32) (v0, v1 uint32) {
+// IsNetpollSyscall returns whether the syscall is one of the syscalls of the Go runtime network poller,
+// handled by HandleNetpollSyscall: the epoll and eventfd syscalls, pipe2, and reads and writes of the eventfd.
+func IsNetpollSyscall(syscallNum, a0 Word) bool {
+	switch syscallNum {
+	case SysEpollCreate1, SysEpollCtl, SysEpollPwait, SysEventFd2, SysPipe2:
+		return true
+	case SysRead, SysWrite:
+		return a0 == FdEventFd
+	}
+	return false
+}
+
+// HandleNetpollSyscall handles the syscalls of the Go runtime network poller. The epoll instance and the eventfd are
+// dedicated file descriptors without any I/O: polling reports no events, and reads and writes of the eventfd fail
+// with EAGAIN, as they would block. pipe2 is not supported.
+func HandleNetpollSyscall(syscallNum, a0, a1 Word) (v0, v1 Word) {
+	switch syscallNum {
+	case SysEpollCreate1:
+		v0 = FdEpoll
+	case SysEpollCtl, SysEpollPwait:
+		// args: a0 = epoll fd. No events are ever reported.
+		if a0 != FdEpoll {
+			v0 = SysErrorSignal
+			v1 = MipsEBADF
+		}
+	case SysEventFd2:
+		// args: a0 = initial value, a1 = flags. Only non-blocking eventfds are supported.
+		if a1&EfdNonBlock == 0 {
+			v0 = SysErrorSignal
+			v1 = MipsEINVAL
+		} else {
+			v0 = FdEventFd
+		}
+	case SysPipe2:
+		v0 = SysErrorSignal
+		v1 = MipsENOSYS
+	default:
+		// read or write of the eventfd
+		v0 = SysErrorSignal
+		v1 = MipsEAGAIN
+	}
+	return v0, v1
+}
+
+func HandleSysFcntl(a0, a1 Word) (v0, v1 Word) {
 	// args: a0 = fd, a1 = cmd
-	v1 = uint32(0)
+	v1 = Word(0)
 
 	if a1 == 3 { // F_GETFL: get file descriptor flags
 		switch a0 {
@@ -281,20 +340,20 @@ func HandleSysFcntl(a0, a1 uint32) (v0, v1 uint32) {
 		case FdStdout, FdStderr, FdPreimageWrite, FdHintWrite:
 			v0 = 1 // O_WRONLY
 		default:
-			v0 = 0xFFffFFff
+			v0 = SysErrorSignal
 			v1 = MipsEBADF
 		}
 	} else {
-		v0 = 0xFFffFFff
+		v0 = SysErrorSignal
 		v1 = MipsEINVAL // cmd not recognized by this kernel
 	}
 
 	return v0, v1
 }
 
-func HandleSyscallUpdates(cpu *mipsevm.CpuScalars, registers *[32]uint32, v0, v1 uint32) {
-	registers[2] = v0
-	registers[7] = v1
+func HandleSyscallUpdates(cpu *mipsevm.CpuScalars, registers *[32]Word, v0, v1 Word) {
+	registers[arch.RegSyscallRet] = v0
+	registers[arch.RegSyscallErrno] = v1
 
 	cpu.PC = cpu.NextPC
 	cpu.NextPC = cpu.NextPC + 4
@@ -2,8 +2,11 @@ package exec
 
 import (
 	"encoding/binary"
+	"errors"
+	"fmt"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 )
 
 type PreimageReader interface {
@@ -50,6 +53,7 @@ func (p *TrackingPreimageOracleReader) ReadPreimage(key [32]byte, offset uint32)
 	if key != p.lastPreimageKey {
 		p.lastPreimageKey = key
 		data := p.po.GetPreimage(key)
+		verifyPreimage(key, data)
 		// add the length prefix
 		preimage = make([]byte, 0, 8+len(data))
 		preimage = binary.BigEndian.AppendUint64(preimage, uint64(len(data)))
@@ -61,6 +65,15 @@ func (p *TrackingPreimageOracleReader) ReadPreimage(key [32]byte, offset uint32)
 	return
 }
 
+// verifyPreimage panics if the data is not a valid pre-image for the key.
+// The onchain pre-image oracle only accepts valid pre-images, so a step reading invalid data can't be proven:
+// the VM halts instead of continuing with a trace that silently diverges from the onchain one.
+func verifyPreimage(key [32]byte, data []byte) {
+	if err := preimage.Verify(key, data); err != nil && !errors.Is(err, preimage.ErrUnsupportedKeyType) {
+		panic(fmt.Errorf("invalid pre-image from oracle: %w", err))
+	}
+}
+
 func (p *TrackingPreimageOracleReader) LastPreimage() ([32]byte, []byte, uint32) {
 	return p.lastPreimageKey, p.lastPreimage, p.lastPreimageOffset
 }
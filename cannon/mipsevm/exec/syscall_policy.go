@@ -0,0 +1,133 @@
+package exec
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+// SyscallAction is the way a VM handles a syscall number.
+type SyscallAction uint8
+
+const (
+	// SyscallAbort halts the VM: the Go VM panics, and the contract reverts.
+	SyscallAbort SyscallAction = iota
+	// SyscallImplemented syscalls have a dedicated handler in the VM.
+	SyscallImplemented
+	// SyscallNoop syscalls are ignored, and succeed with a zero result.
+	SyscallNoop
+	// SyscallENOSYS syscalls fail with the ENOSYS errno.
+	SyscallENOSYS
+)
+
+func (a SyscallAction) String() string {
+	switch a {
+	case SyscallAbort:
+		return "abort"
+	case SyscallImplemented:
+		return "implemented"
+	case SyscallNoop:
+		return "noop"
+	case SyscallENOSYS:
+		return "enosys"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(a))
+	}
+}
+
+// SyscallPolicy lists how a VM handles each syscall number.
+// It must match the syscall handling of the contract version of the VM exactly,
+// since any difference results in a different post-state.
+type SyscallPolicy struct {
+	actions  map[Word]SyscallAction
+	fallback SyscallAction
+}
+
+// NewSyscallPolicy creates a policy that handles the listed syscalls with the given actions,
+// and any syscall that is not listed with the fallback action.
+func NewSyscallPolicy(fallback SyscallAction, syscalls map[SyscallAction][]Word) *SyscallPolicy {
+	actions := make(map[Word]SyscallAction)
+	for action, nums := range syscalls {
+		for _, num := range nums {
+			if num == arch.UndefinedSysNr {
+				// not a syscall of the architecture
+				continue
+			}
+			if prev, ok := actions[num]; ok {
+				panic(fmt.Errorf("syscall %d is both %v and %v", num, prev, action))
+			}
+			actions[num] = action
+		}
+	}
+	return &SyscallPolicy{actions: actions, fallback: fallback}
+}
+
+// Action returns the way the syscall is handled.
+func (p *SyscallPolicy) Action(syscallNum Word) SyscallAction {
+	if action, ok := p.actions[syscallNum]; ok {
+		return action
+	}
+	return p.fallback
+}
+
+// Known returns whether the policy lists the syscall, rather than handling it with the fallback action.
+func (p *SyscallPolicy) Known(syscallNum Word) bool {
+	_, ok := p.actions[syscallNum]
+	return ok
+}
+
+// HandleUnimplemented returns the result of a syscall without a dedicated handler.
+// It returns false if the VM must abort instead.
+func (p *SyscallPolicy) HandleUnimplemented(syscallNum Word) (v0, v1 Word, ok bool) {
+	switch p.Action(syscallNum) {
+	case SyscallNoop:
+		return 0, 0, true
+	case SyscallENOSYS:
+		return SysErrorSignal, MipsENOSYS, true
+	default:
+		// Implemented syscalls must not end up here, abort if they do
+		return 0, 0, false
+	}
+}
+
+// UnknownSyscalls reports the syscalls a VM executes that its policy doesn't know, see mipsevm.UnknownSyscallMode.
+type UnknownSyscalls struct {
+	policy *SyscallPolicy
+	mode   mipsevm.UnknownSyscallMode
+	log    log.Logger
+	warned map[Word]struct{}
+}
+
+func NewUnknownSyscalls(policy *SyscallPolicy) *UnknownSyscalls {
+	return &UnknownSyscalls{policy: policy, mode: mipsevm.UnknownSyscallNoop}
+}
+
+func (u *UnknownSyscalls) SetMode(mode mipsevm.UnknownSyscallMode, logger log.Logger) {
+	if _, err := mipsevm.ParseUnknownSyscallMode(string(mode)); err != nil {
+		panic(err)
+	}
+	u.mode = mode
+	u.log = logger
+	u.warned = make(map[Word]struct{})
+}
+
+// Check reports the syscall executed at pc if the policy doesn't know it.
+// It returns an error wrapping mipsevm.ErrUnknownSyscall in halt mode, in which case the syscall must not be executed.
+func (u *UnknownSyscalls) Check(syscallNum Word, pc Word) error {
+	if u.policy.Known(syscallNum) {
+		return nil
+	}
+	switch u.mode {
+	case mipsevm.UnknownSyscallWarn:
+		if _, ok := u.warned[syscallNum]; !ok {
+			u.warned[syscallNum] = struct{}{}
+			u.log.Warn("Unknown syscall", "num", syscallNum, "pc", mipsevm.HexWord(pc), "action", u.policy.fallback)
+		}
+	case mipsevm.UnknownSyscallHalt:
+		return fmt.Errorf("%w %d at pc %#x", mipsevm.ErrUnknownSyscall, syscallNum, pc)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package exec
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestUnknownSyscalls(t *testing.T) {
+	policy := NewSyscallPolicy(SyscallNoop, map[SyscallAction][]Word{
+		SyscallImplemented: {SysRead},
+		SyscallENOSYS:      {SysOpen},
+	})
+	const unknown = SysRead + 1000
+	require.True(t, policy.Known(SysRead))
+	require.True(t, policy.Known(SysOpen))
+	require.False(t, policy.Known(unknown))
+
+	t.Run("noop", func(t *testing.T) {
+		u := NewUnknownSyscalls(policy)
+		require.NoError(t, u.Check(unknown, 0x100))
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, slog.LevelWarn)
+		u := NewUnknownSyscalls(policy)
+		u.SetMode(mipsevm.UnknownSyscallWarn, logger)
+		require.NoError(t, u.Check(SysOpen, 0x100))
+		require.NoError(t, u.Check(unknown, 0x100))
+		require.NoError(t, u.Check(unknown, 0x200))
+		require.NoError(t, u.Check(unknown+1, 0x300))
+		require.Len(t, logs.FindLogs(testlog.NewMessageFilter("Unknown syscall")), 2, "warns once per unknown syscall")
+		for _, num := range []Word{unknown, unknown + 1} {
+			require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Unknown syscall"), testlog.NewAttributesFilter("num", fmt.Sprint(num))))
+		}
+	})
+
+	t.Run("halt", func(t *testing.T) {
+		u := NewUnknownSyscalls(policy)
+		u.SetMode(mipsevm.UnknownSyscallHalt, testlog.Logger(t, slog.LevelInfo))
+		require.NoError(t, u.Check(SysRead, 0x100))
+		err := u.Check(unknown, 0x100)
+		require.ErrorIs(t, err, mipsevm.ErrUnknownSyscall)
+		require.ErrorContains(t, err, "0x100")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		require.Panics(t, func() { NewUnknownSyscalls(policy).SetMode("ignore", nil) })
+	})
+}
+
+func TestParseUnknownSyscallMode(t *testing.T) {
+	for _, mode := range []mipsevm.UnknownSyscallMode{mipsevm.UnknownSyscallNoop, mipsevm.UnknownSyscallWarn, mipsevm.UnknownSyscallHalt} {
+		parsed, err := mipsevm.ParseUnknownSyscallMode(string(mode))
+		require.NoError(t, err)
+		require.Equal(t, mode, parsed)
+	}
+	_, err := mipsevm.ParseUnknownSyscallMode("ignore")
+	require.ErrorContains(t, err, "unknown syscall mode")
+}
@@ -0,0 +1,64 @@
+package exec
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+// SyscallStats counts the syscalls executed by a VM, see mipsevm.Stats.
+type SyscallStats struct {
+	stats map[Word]*mipsevm.SyscallStat
+}
+
+func NewSyscallStats() *SyscallStats {
+	return &SyscallStats{stats: make(map[Word]*mipsevm.SyscallStat)}
+}
+
+// Track executes the syscall of the registers with handle, and records it.
+// The registers must be those of the thread executing the syscall, which are read again after handle
+// for the result of read and write syscalls.
+func (s *SyscallStats) Track(registers *[32]Word, handle func() error) error {
+	syscallNum, a0, _, _, _ := GetSyscallArgs(registers)
+	start := time.Now()
+	err := handle()
+	elapsed := time.Since(start)
+	if err != nil {
+		return err
+	}
+
+	stat, ok := s.stats[syscallNum]
+	if !ok {
+		stat = &mipsevm.SyscallStat{Num: syscallNum}
+		s.stats[syscallNum] = stat
+	}
+	stat.Count++
+	stat.Duration += elapsed
+	if registers[arch.RegSyscallErrno] != 0 {
+		return nil
+	}
+	switch syscallNum {
+	case SysRead:
+		n := uint64(registers[arch.RegSyscallRet])
+		stat.BytesRead += n
+		if a0 == FdPreimageRead {
+			stat.PreimageReads++
+			stat.PreimageBytes += n
+		}
+	case SysWrite:
+		stat.BytesWritten += uint64(registers[arch.RegSyscallRet])
+	}
+	return nil
+}
+
+// Stats returns a copy of the statistics recorded so far, by ascending syscall number.
+func (s *SyscallStats) Stats() []mipsevm.SyscallStat {
+	out := make([]mipsevm.SyscallStat, 0, len(s.stats))
+	for _, stat := range s.stats {
+		out = append(out, *stat)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Num < out[j].Num })
+	return out
+}
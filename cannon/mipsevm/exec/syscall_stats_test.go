@@ -0,0 +1,45 @@
+package exec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+func TestSyscallStats(t *testing.T) {
+	stats := NewSyscallStats()
+	var registers [32]Word
+	syscall := func(num, fd, ret, errno Word) error {
+		registers[arch.RegSyscallNum] = num
+		registers[arch.RegSyscallParam1] = fd
+		return stats.Track(&registers, func() error {
+			registers[arch.RegSyscallRet] = ret
+			registers[arch.RegSyscallErrno] = errno
+			return nil
+		})
+	}
+	require.NoError(t, syscall(SysRead, FdPreimageRead, 32, 0))
+	require.NoError(t, syscall(SysRead, FdPreimageRead, 8, 0))
+	require.NoError(t, syscall(SysRead, FdStdin, 0, 0))
+	require.NoError(t, syscall(SysRead, 42, ^Word(0), MipsEBADF))
+	require.NoError(t, syscall(SysWrite, FdHintWrite, 100, 0))
+	require.NoError(t, syscall(SysBrk, 0, 0x4000_0000, 0))
+
+	err := errors.New("unknown syscall")
+	registers[arch.RegSyscallNum] = SysOpen
+	require.ErrorIs(t, stats.Track(&registers, func() error { return err }), err)
+
+	got := stats.Stats()
+	for i := range got {
+		got[i].Duration = 0 // host time, not deterministic
+	}
+	require.Equal(t, []mipsevm.SyscallStat{
+		{Num: SysRead, Count: 4, BytesRead: 40, PreimageReads: 2, PreimageBytes: 40},
+		{Num: SysWrite, Count: 1, BytesWritten: 100},
+		{Num: SysBrk, Count: 1},
+	}, got)
+}
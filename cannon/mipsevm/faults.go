@@ -0,0 +1,48 @@
+package mipsevm
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFault matches any FaultError, whatever the kind of the fault.
+var ErrFault = errors.New("fault")
+
+// The kinds of faults, matched with errors.Is on the FaultError returned by FPVM.Step.
+var (
+	// ErrIllegalInstruction is the fault of an instruction the VM doesn't implement, or of operands it doesn't support.
+	ErrIllegalInstruction = errors.New("illegal instruction")
+	// ErrUnalignedAccess is the fault of a load or store at an address that isn't aligned to the size of the access.
+	// It is only raised in strict mode: by default, the VM aligns the address down, like the contract does.
+	ErrUnalignedAccess = errors.New("unaligned memory access")
+	// ErrBranchInDelaySlot is the fault of a branch in the delay slot of a branch or jump.
+	ErrBranchInDelaySlot = errors.New("branch in delay slot")
+	// ErrJumpInDelaySlot is the fault of a jump in the delay slot of a branch or jump.
+	ErrJumpInDelaySlot = errors.New("jump in delay slot")
+	// ErrDivisionByZero is the fault of an integer division by zero.
+	ErrDivisionByZero = errors.New("division by zero")
+)
+
+// FaultError is returned by FPVM.Step when the program executes an instruction that the contract can't step over
+// either. The step is not completed: like the contract reverts it, it leaves the state unchanged, including the step
+// counter, so the state still hashes to the pre-state of the faulting step. Stepping the VM again faults again.
+type FaultError struct {
+	// Err is the kind of the fault, e.g. ErrIllegalInstruction, possibly wrapped with details.
+	Err error
+	// PC is the address of the faulting instruction.
+	PC Word
+	// Insn is the faulting instruction.
+	Insn uint32
+}
+
+func (e *FaultError) Error() string {
+	return fmt.Sprintf("%v at pc %s (insn %08x)", e.Err, HexWord(e.PC), e.Insn)
+}
+
+func (e *FaultError) Unwrap() error {
+	return e.Err
+}
+
+func (e *FaultError) Is(target error) bool {
+	return target == ErrFault
+}
@@ -0,0 +1,387 @@
+// Package gdbstub implements a server of the GDB remote serial protocol for an FPVM,
+// so gdb or lldb can attach to a VM to set breakpoints, step, and inspect registers and memory.
+//
+// The registers are served in the layout gdb uses for MIPS targets without a target description:
+// the 32 general purpose registers, sr, lo, hi, bad, cause, pc, the 32 floating-point registers, fsr and fir.
+// The VM state is read-only: registers and memory can't be written, and breakpoints are kept by the server.
+package gdbstub
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+)
+
+const (
+	packetSize = 0x4000
+
+	// interruptCheckInterval is the number of steps to execute between checks for an interrupt of the debugger.
+	interruptCheckInterval = 1 << 14
+
+	sigInt  = 0x02
+	sigTrap = 0x05
+	sigAbrt = 0x06
+
+	regCount = 72
+	regSR    = 32
+	regLO    = 33
+	regHI    = 34
+	regBad   = 35
+	regCause = 36
+	regPC    = 37
+	regF0    = 38
+	regFSR   = 70
+	regFIR   = 71
+)
+
+// ErrKilled is returned by Serve when the debugger killed the program.
+var ErrKilled = errors.New("killed by debugger")
+
+// StepFn executes a single step of the VM.
+type StepFn func() error
+
+type Server struct {
+	logger log.Logger
+	vm     mipsevm.FPVM
+	step   StepFn
+
+	breakpoints map[mipsevm.Word]struct{}
+	noAck       atomic.Bool
+
+	writeLock sync.Mutex
+	w         io.Writer
+}
+
+// NewServer creates a server for the VM, which executes steps with step.
+func NewServer(logger log.Logger, vm mipsevm.FPVM, step StepFn) *Server {
+	return &Server{
+		logger:      logger,
+		vm:          vm,
+		step:        step,
+		breakpoints: make(map[mipsevm.Word]struct{}),
+	}
+}
+
+// Serve handles the packets of a debugger on conn until it detaches, or kills the program.
+// The VM is stopped while the debugger is attached, and only executes steps when the debugger continues or steps.
+// Returns nil if the debugger detached or closed the connection, and ErrKilled if it killed the program.
+func (s *Server) Serve(conn io.ReadWriter) error {
+	s.w = conn
+	packets := make(chan string)
+	interrupts := make(chan struct{}, 1)
+	readErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		readErr <- s.readPackets(bufio.NewReader(conn), packets, interrupts, done)
+	}()
+	for {
+		var packet string
+		select {
+		case packet = <-packets:
+		case <-interrupts:
+			// Interrupts while the VM is stopped are answered with the current stop reason
+			if err := s.sendPacket(s.stopReply(sigInt)); err != nil {
+				return err
+			}
+			continue
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				s.logger.Info("Debugger disconnected")
+				return nil
+			}
+			return err
+		}
+		reply, err := s.handle(packet, interrupts)
+		if err != nil {
+			if errors.Is(err, errDetach) {
+				return s.sendPacket("OK")
+			}
+			return err
+		}
+		if err := s.sendPacket(reply); err != nil {
+			return err
+		}
+	}
+}
+
+// readPackets reads packets from r, and acks them unless in no-ack mode.
+// Interrupt requests are sent to interrupts, and dropped if one is already pending.
+func (s *Server) readPackets(r *bufio.Reader, packets chan<- string, interrupts chan<- struct{}, done <-chan struct{}) error {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch b {
+		case 0x03:
+			select {
+			case interrupts <- struct{}{}:
+			default:
+			}
+			continue
+		case '$':
+		default:
+			// Acks, and anything else outside a packet, are ignored
+			continue
+		}
+		data, err := r.ReadString('#')
+		if err != nil {
+			return err
+		}
+		data = data[:len(data)-1]
+		var checksum [2]byte
+		if _, err := io.ReadFull(r, checksum[:]); err != nil {
+			return err
+		}
+		if !s.noAck.Load() {
+			expected, err := strconv.ParseUint(string(checksum[:]), 16, 8)
+			if err != nil || uint8(expected) != packetChecksum(data) {
+				if err := s.write([]byte{'-'}); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := s.write([]byte{'+'}); err != nil {
+				return err
+			}
+		}
+		select {
+		case packets <- unescape(data):
+		case <-done:
+			return nil
+		}
+	}
+}
+
+var errDetach = errors.New("detach")
+
+func (s *Server) handle(packet string, interrupts <-chan struct{}) (string, error) {
+	s.logger.Trace("Debugger packet", "packet", packet)
+	if packet == "" {
+		return "", nil
+	}
+	cmd, args := packet[0], packet[1:]
+	switch cmd {
+	case '?':
+		return s.stopReply(sigTrap), nil
+	case 'g':
+		return s.readRegisters(), nil
+	case 'p':
+		n, err := strconv.ParseUint(args, 16, 32)
+		if err != nil || n >= regCount {
+			return "E01", nil
+		}
+		return s.encodeRegister(s.register(int(n))), nil
+	case 'G', 'P', 'M', 'X':
+		// The VM state is read-only
+		return "E01", nil
+	case 'm':
+		return s.readMemory(args), nil
+	case 'c':
+		return s.resume(false, interrupts), nil
+	case 's':
+		return s.resume(true, interrupts), nil
+	case 'Z', 'z':
+		return s.breakpoint(cmd == 'Z', args), nil
+	case 'H', 'T':
+		// There is a single thread: the one the VM is executing
+		return "OK", nil
+	case 'D':
+		s.logger.Info("Debugger detached")
+		return "", errDetach
+	case 'k':
+		s.logger.Info("Debugger killed the program")
+		return "", ErrKilled
+	case 'q', 'Q':
+		return s.query(packet), nil
+	default:
+		return "", nil
+	}
+}
+
+func (s *Server) query(packet string) string {
+	name, _, _ := strings.Cut(packet, ":")
+	switch name {
+	case "qSupported":
+		return fmt.Sprintf("PacketSize=%x;QStartNoAckMode+;swbreak+;hwbreak+", packetSize)
+	case "QStartNoAckMode":
+		s.noAck.Store(true)
+		return "OK"
+	case "qAttached":
+		return "1"
+	case "qC":
+		return "QC1"
+	case "qfThreadInfo":
+		return "m1"
+	case "qsThreadInfo":
+		return "l"
+	default:
+		return ""
+	}
+}
+
+// resume executes steps until a breakpoint is hit, the program exits, or the debugger interrupts it,
+// or a single step if step is true, and returns the stop reply.
+func (s *Server) resume(step bool, interrupts <-chan struct{}) string {
+	state := s.vm.GetState()
+	for i := uint64(1); !state.GetExited(); i++ {
+		if err := s.step(); err != nil {
+			s.logger.Error("Failed to execute step", "step", state.GetStep(), "pc", state.GetPC(), "err", err)
+			return s.stopReply(sigAbrt)
+		}
+		if step || state.GetExited() {
+			break
+		}
+		if _, ok := s.breakpoints[state.GetPC()]; ok {
+			return s.stopReply(sigTrap) + "swbreak:;"
+		}
+		if i%interruptCheckInterval == 0 {
+			select {
+			case <-interrupts:
+				return s.stopReply(sigInt)
+			default:
+			}
+		}
+	}
+	return s.stopReply(sigTrap)
+}
+
+func (s *Server) stopReply(signal byte) string {
+	state := s.vm.GetState()
+	if state.GetExited() {
+		return fmt.Sprintf("W%02x", state.GetExitCode())
+	}
+	return fmt.Sprintf("T%02x%02x:%s;", signal, regPC, s.encodeRegister(state.GetPC()))
+}
+
+func (s *Server) breakpoint(insert bool, args string) string {
+	parts := strings.Split(args, ",")
+	if len(parts) < 2 || (parts[0] != "0" && parts[0] != "1") {
+		// Only software and hardware breakpoints are supported, not watchpoints
+		return ""
+	}
+	addr, err := strconv.ParseUint(parts[1], 16, arch.WordSize)
+	if err != nil {
+		return "E01"
+	}
+	if insert {
+		s.breakpoints[mipsevm.Word(addr)] = struct{}{}
+	} else {
+		delete(s.breakpoints, mipsevm.Word(addr))
+	}
+	return "OK"
+}
+
+func (s *Server) register(n int) mipsevm.Word {
+	state := s.vm.GetState()
+	switch {
+	case n < 32:
+		return state.GetRegistersRef()[n]
+	case n == regLO:
+		return state.GetCpu().LO
+	case n == regHI:
+		return state.GetCpu().HI
+	case n == regPC:
+		return state.GetPC()
+	case n >= regF0 && n < regF0+32:
+		return state.GetFpuRef().FPR[n-regF0]
+	case n == regFSR:
+		return mipsevm.Word(state.GetFpuRef().FCSR)
+	case n == regFIR:
+		return mipsevm.Word(exec.FpuFIR())
+	default: // sr, bad and cause are not emulated
+		return 0
+	}
+}
+
+func (s *Server) encodeRegister(v mipsevm.Word) string {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(v))
+	return hex.EncodeToString(buf[8-arch.WordSizeBytes:])
+}
+
+func (s *Server) readRegisters() string {
+	var out strings.Builder
+	for n := 0; n < regCount; n++ {
+		out.WriteString(s.encodeRegister(s.register(n)))
+	}
+	return out.String()
+}
+
+func (s *Server) readMemory(args string) string {
+	addrStr, lengthStr, ok := strings.Cut(args, ",")
+	if !ok {
+		return "E01"
+	}
+	addr, err := strconv.ParseUint(addrStr, 16, arch.WordSize)
+	if err != nil {
+		return "E01"
+	}
+	length, err := strconv.ParseUint(lengthStr, 16, 32)
+	if err != nil || length > packetSize/2 {
+		return "E01"
+	}
+	mem := s.vm.GetState().GetMemory()
+	out := make([]byte, 0, length)
+	var buf [8]byte
+	for i := uint64(0); i < length; i++ {
+		byteAddr := mipsevm.Word(addr + i)
+		if i == 0 || byteAddr&arch.ExtMask == 0 {
+			binary.BigEndian.PutUint64(buf[:], uint64(mem.GetMemory(byteAddr&^arch.ExtMask)))
+		}
+		out = append(out, buf[8-arch.WordSizeBytes+int(byteAddr&arch.ExtMask)])
+	}
+	return hex.EncodeToString(out)
+}
+
+func (s *Server) sendPacket(data string) error {
+	return s.write([]byte(fmt.Sprintf("$%s#%02x", data, packetChecksum(data))))
+}
+
+func (s *Server) write(b []byte) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+	if _, err := s.w.Write(b); err != nil {
+		return fmt.Errorf("failed to write to debugger: %w", err)
+	}
+	return nil
+}
+
+func packetChecksum(data string) uint8 {
+	var sum uint8
+	for i := 0; i < len(data); i++ {
+		sum += data[i]
+	}
+	return sum
+}
+
+// unescape reverts the escaping of '}' followed by the escaped byte XOR 0x20.
+func unescape(data string) string {
+	if !strings.Contains(data, "}") {
+		return data
+	}
+	var out strings.Builder
+	for i := 0; i < len(data); i++ {
+		if data[i] == '}' && i+1 < len(data) {
+			i++
+			out.WriteByte(data[i] ^ 0x20)
+			continue
+		}
+		out.WriteByte(data[i])
+	}
+	return out.String()
+}
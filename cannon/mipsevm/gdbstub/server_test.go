@@ -0,0 +1,150 @@
+//go:build !cannon64
+
+package gdbstub
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// program computes $t1 = 5 + 7 and exits with code 3.
+var program = []uint32{
+	0x24080005, // addiu $t0, $zero, 5
+	0x25090007, // addiu $t1, $t0, 7
+	0x24021096, // addiu $v0, $zero, 4246 (exit_group)
+	0x24040003, // addiu $a0, $zero, 3
+	0x0000000c, // syscall
+}
+
+const programStart = 0x1000
+
+func TestServer(t *testing.T) {
+	client, result := startServer(t)
+
+	require.Contains(t, client.request("qSupported:swbreak+"), "QStartNoAckMode+")
+	require.Equal(t, "OK", client.request("QStartNoAckMode"))
+	client.noAck = true
+	require.Equal(t, "T0525:00001000;", client.request("?"))
+	require.Equal(t, "24080005", client.request("m1000,4"))
+	require.Equal(t, "00052509", client.request("m1002,4"))
+
+	require.Equal(t, "T0525:00001004;", client.request("s"))
+	require.Equal(t, "00000005", client.request("p8"))
+
+	require.Equal(t, "OK", client.request("Z0,100c,4"))
+	require.Equal(t, "T0525:0000100c;swbreak:;", client.request("c"))
+	regs := client.request("g")
+	require.Len(t, regs, regCount*8)
+	require.Equal(t, "0000000c", regs[9*8:10*8], "t1")
+	require.Equal(t, "00001096", regs[2*8:3*8], "v0")
+	require.Equal(t, "0000100c", regs[regPC*8:(regPC+1)*8], "pc")
+
+	require.Equal(t, "E01", client.request("P8=00000001"))
+	require.Equal(t, "OK", client.request("z0,100c,4"))
+	require.Equal(t, "W03", client.request("c"))
+	require.Equal(t, "W03", client.request("?"))
+
+	client.send("D")
+	require.Equal(t, "OK", client.readPacket())
+	require.NoError(t, <-result)
+}
+
+func TestServerKill(t *testing.T) {
+	client, result := startServer(t)
+	client.send("k")
+	require.ErrorIs(t, <-result, ErrKilled)
+}
+
+func TestServerInterruptWhileStopped(t *testing.T) {
+	client, result := startServer(t)
+	_, err := client.conn.Write([]byte{0x03})
+	require.NoError(t, err)
+	require.Equal(t, "T0225:00001000;", client.readPacket())
+	require.NoError(t, client.conn.Close())
+	require.NoError(t, <-result)
+}
+
+func TestServerRejectsBadChecksum(t *testing.T) {
+	client, _ := startServer(t)
+	_, err := client.conn.Write([]byte("$?#00"))
+	require.NoError(t, err)
+	ack := make([]byte, 1)
+	_, err = io.ReadFull(client.r, ack)
+	require.NoError(t, err)
+	require.Equal(t, byte('-'), ack[0])
+}
+
+func startServer(t *testing.T) (*testClient, <-chan error) {
+	state := singlethreaded.CreateEmptyState()
+	state.Cpu.PC = programStart
+	state.Cpu.NextPC = programStart + 4
+	for i, insn := range program {
+		state.Memory.SetMemory(mipsevm.Word(programStart+4*i), mipsevm.Word(insn))
+	}
+	vm := singlethreaded.NewInstrumentedState(state, testutil.StaticOracle(t, nil), io.Discard, io.Discard, nil)
+	server := NewServer(testlog.Logger(t, log.LevelInfo), vm, func() error {
+		_, err := vm.Step(false)
+		return err
+	})
+
+	serverConn, clientConn := net.Pipe()
+	result := make(chan error, 1)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		result <- server.Serve(serverConn)
+		_ = serverConn.Close()
+	}()
+	t.Cleanup(func() {
+		_ = clientConn.Close()
+		<-stopped
+	})
+	return &testClient{t: t, conn: clientConn, r: bufio.NewReader(clientConn)}, result
+}
+
+type testClient struct {
+	t     *testing.T
+	conn  net.Conn
+	r     *bufio.Reader
+	noAck bool
+}
+
+func (c *testClient) request(data string) string {
+	c.send(data)
+	return c.readPacket()
+}
+
+func (c *testClient) send(data string) {
+	_, err := fmt.Fprintf(c.conn, "$%s#%02x", data, packetChecksum(data))
+	require.NoError(c.t, err)
+	if !c.noAck {
+		ack, err := c.r.ReadByte()
+		require.NoError(c.t, err)
+		require.Equal(c.t, byte('+'), ack)
+	}
+}
+
+func (c *testClient) readPacket() string {
+	start, err := c.r.ReadByte()
+	require.NoError(c.t, err)
+	require.Equal(c.t, byte('$'), start)
+	data, err := c.r.ReadString('#')
+	require.NoError(c.t, err)
+	data = data[:len(data)-1]
+	var checksum [2]byte
+	_, err = io.ReadFull(c.r, checksum[:])
+	require.NoError(c.t, err)
+	require.Equal(c.t, fmt.Sprintf("%02x", packetChecksum(data)), string(checksum[:]))
+	return data
+}
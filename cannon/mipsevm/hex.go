@@ -1,6 +1,10 @@
 package mipsevm
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
 
 // HexU32 to lazy-format integer attributes for logging
 type HexU32 uint32
@@ -12,3 +16,14 @@ func (v HexU32) String() string {
 func (v HexU32) MarshalText() ([]byte, error) {
 	return []byte(v.String()), nil
 }
+
+// HexWord to lazy-format word-sized attributes, such as addresses, for logging
+type HexWord Word
+
+func (v HexWord) String() string {
+	return fmt.Sprintf("%0*x", arch.WordSizeBytes*2, Word(v))
+}
+
+func (v HexWord) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
@@ -3,6 +3,7 @@ package mipsevm
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 )
@@ -11,7 +12,7 @@ type FPVMState interface {
 	GetMemory() *memory.Memory
 
 	// GetHeap returns the current memory address at the top of the heap
-	GetHeap() uint32
+	GetHeap() Word
 
 	// GetPreimageKey returns the most recently accessed preimage key
 	GetPreimageKey() common.Hash
@@ -20,13 +21,16 @@ type FPVMState interface {
 	GetPreimageOffset() uint32
 
 	// GetPC returns the currently executing program counter
-	GetPC() uint32
+	GetPC() Word
 
 	// GetCpu returns the currently active cpu scalars, including the program counter
 	GetCpu() CpuScalars
 
 	// GetRegistersRef returns a pointer to the currently active registers
-	GetRegistersRef() *[32]uint32
+	GetRegistersRef() *[32]Word
+
+	// GetFpuRef returns a pointer to the currently active floating-point coprocessor state
+	GetFpuRef() *FpuState
 
 	// GetStep returns the current VM step
 	GetStep() uint64
@@ -49,13 +53,24 @@ type FPVMState interface {
 
 	// EncodeWitness returns the witness for the current state and the state hash
 	EncodeWitness() (witness []byte, hash common.Hash)
+
+	// Snapshot returns a deep copy of the state, including its memory, threads, pre-image state and last hint.
+	// The snapshot shares nothing with the state and has the same state hash, so it can be executed by another VM
+	// to branch the execution, or be restored with Restore.
+	Snapshot() FPVMState
+
+	// Restore sets the state to a deep copy of the snapshot, which must be a state of the same type.
+	// The memory is restored in place, so a VM executing the state continues from the snapshot,
+	// and the state hash is that of the snapshot.
+	Restore(snapshot FPVMState) error
 }
 
 type FPVM interface {
 	// GetState returns the current state of the VM. The FPVMState is updated by successive calls to Step
 	GetState() FPVMState
 
-	// Step executes a single instruction and returns the witness for the step
+	// Step executes a single instruction and returns the witness for the step.
+	// It returns a *FaultError if the instruction faults, see ErrFault.
 	Step(includeProof bool) (*StepWitness, error)
 
 	// CheckInfiniteLoop returns true if the vm is stuck in an infinite loop
@@ -69,4 +84,39 @@ type FPVM interface {
 
 	// GetDebugInfo returns debug information about the VM
 	GetDebugInfo() *DebugInfo
+
+	// Stats returns statistics of the steps executed by the VM since it was created, e.g. of each syscall
+	Stats() *Stats
+
+	// SetTracer sets the tracer to observe the steps of the VM, or disables tracing if nil
+	SetTracer(tracer StepTracer)
+
+	// SetUnknownSyscallMode sets the way the VM reports the syscalls it doesn't know, warning with the logger
+	SetUnknownSyscallMode(mode UnknownSyscallMode, logger log.Logger)
+
+	// SetStrictMode sets whether steps fault with ErrUnalignedAccess on unaligned loads and stores,
+	// instead of aligning the address down like the contract does. Disabled by default.
+	SetStrictMode(strict bool)
+
+	// SetFastExecution sets whether RunSteps executes the code translated to blocks, instead of stepping each instruction.
+	// The blocks are only executed when no tracer, stack tracker or strict mode observes the steps. Disabled by default.
+	SetFastExecution(enabled bool)
+
+	// RunSteps executes up to n steps without proofs, and results in the same state as n calls to Step(false).
+	// It stops early when the VM exits, after a step that read a pre-image, see LastPreimage,
+	// and when CheckInfiniteLoop is true. It returns a *FaultError if a step faults, see Step.
+	RunSteps(n uint64) error
+}
+
+// StepTracer observes the steps executed by a VM, e.g. to write an instruction trace.
+type StepTracer interface {
+	// OnInstruction is called before the step executes the instruction insn at pc.
+	// Steps of the multithreaded VM that only schedule threads don't execute an instruction.
+	OnInstruction(state FPVMState, pc Word, insn uint32)
+
+	// OnMemAccess is called before the step accesses the memory proof leaf containing addr.
+	OnMemAccess(addr Word)
+
+	// OnStepEnd is called after a step completed without error.
+	OnStepEnd(state FPVMState)
 }
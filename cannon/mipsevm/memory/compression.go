@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// PageCompression is the algorithm idle memory pages are compressed with.
+type PageCompression string
+
+const (
+	PageCompressionSnappy PageCompression = "snappy"
+	PageCompressionZstd   PageCompression = "zstd"
+)
+
+// The zstd encoder and decoder are safe for concurrent use with EncodeAll and DecodeAll.
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+)
+
+func (c PageCompression) compress(p *Page) []byte {
+	switch c {
+	case PageCompressionSnappy:
+		return snappy.Encode(nil, p[:])
+	case PageCompressionZstd:
+		return zstdEncoder.EncodeAll(p[:], nil)
+	default:
+		panic(fmt.Errorf("unknown page compression %q", c))
+	}
+}
+
+func (c PageCompression) decompress(data []byte) *Page {
+	p := new(Page)
+	var err error
+	var out []byte
+	switch c {
+	case PageCompressionSnappy:
+		out, err = snappy.Decode(p[:], data)
+	case PageCompressionZstd:
+		out, err = zstdDecoder.DecodeAll(data, p[:0])
+	default:
+		err = fmt.Errorf("unknown page compression %q", c)
+	}
+	// Pages are only ever compressed in memory, so failing to decompress one means the memory is corrupt
+	if err != nil {
+		panic(fmt.Errorf("failed to decompress page: %w", err))
+	}
+	if len(out) != PageSize {
+		panic(fmt.Errorf("decompressed page has %d bytes, expected %d", len(out), PageSize))
+	}
+	return p
+}
+
+// compressedPages holds the memory pages that are compressed while idle.
+type compressedPages struct {
+	compression PageCompression
+	pages       map[Word]compressedPage
+	size        uint64
+}
+
+type compressedPage struct {
+	data []byte
+	// root is the merkle root of the page, so proofs of neighbouring pages don't need to decompress the page
+	root [32]byte
+}
+
+// Compress compresses all memory pages and releases their uncompressed data and merkle caches,
+// to reduce the host memory used by a state that is held in memory but not executed, e.g. an intermediate snapshot.
+// Compression is transparent: pages are decompressed on demand when next accessed, and stay decompressed
+// until Compress is called again. Pages compressed with a different algorithm are recompressed.
+func (m *Memory) Compress(compression PageCompression) {
+	if m.compressed.compression != compression {
+		for pageIndex := range m.compressed.pages {
+			m.decompressPage(pageIndex)
+		}
+		m.compressed.compression = compression
+	}
+	if m.compressed.pages == nil {
+		m.compressed.pages = make(map[Word]compressedPage)
+	}
+	// Cache the merkle nodes above the pages, so the merkle root is available without decompressing pages.
+	m.MerkleRoot()
+	for pageIndex, p := range m.pages {
+		data := compression.compress(p.Data)
+		m.compressed.pages[pageIndex] = compressedPage{data: data, root: p.MerkleRoot()}
+		m.compressed.size += uint64(len(data))
+		delete(m.pages, pageIndex)
+	}
+	m.lastPageKeys = [2]Word{^Word(0), ^Word(0)}
+	m.lastPage = [2]*CachedPage{nil, nil}
+}
+
+// CompressedPageCount returns the number of pages that are currently compressed.
+func (m *Memory) CompressedPageCount() int {
+	return len(m.compressed.pages)
+}
+
+// CompressedSize returns the total size of the currently compressed pages.
+func (m *Memory) CompressedSize() uint64 {
+	return m.compressed.size
+}
+
+// page returns the page with the given index, decompressing it if it is compressed, or loading it if it is evicted.
+func (m *Memory) page(pageIndex Word) (*CachedPage, bool) {
+	if p, ok := m.pages[pageIndex]; ok {
+		p.referenced = true
+		return p, true
+	}
+	if _, ok := m.compressed.pages[pageIndex]; ok {
+		return m.decompressPage(pageIndex), true
+	}
+	if _, ok := m.disk.pages[pageIndex]; ok {
+		return m.loadPage(pageIndex), true
+	}
+	return nil, false
+}
+
+// pageData returns the data of the page with the given index, without keeping it decompressed if it is compressed,
+// or loaded if it is evicted. The data must not be modified.
+func (m *Memory) pageData(pageIndex Word) (*Page, bool) {
+	if p, ok := m.pages[pageIndex]; ok {
+		return p.Data, true
+	}
+	if p, ok := m.compressed.pages[pageIndex]; ok {
+		return m.compressed.compression.decompress(p.data), true
+	}
+	if _, ok := m.disk.pages[pageIndex]; ok {
+		return m.readEvictedPage(pageIndex), true
+	}
+	return nil, false
+}
+
+func (m *Memory) decompressPage(pageIndex Word) *CachedPage {
+	compressed := m.compressed.pages[pageIndex]
+	p := &CachedPage{Data: m.compressed.compression.decompress(compressed.data)}
+	// The merkle nodes above the page may still be cached, while invalidation of the page only invalidates
+	// the nodes above it if the page merkle root was cached too. So the page merkle cache is restored.
+	p.MerkleRoot()
+	m.pages[pageIndex] = p
+	delete(m.compressed.pages, pageIndex)
+	m.compressed.size -= uint64(len(compressed.data))
+	m.onResident(pageIndex)
+	return p
+}
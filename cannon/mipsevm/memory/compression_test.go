@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestMemories creates two memories with the same contents: a mix of sparse, random and unaligned data.
+func newTestMemories(t *testing.T) (*Memory, *Memory) {
+	rng := rand.New(rand.NewSource(1234))
+	a, b := NewMemory(), NewMemory()
+	for _, m := range []*Memory{a, b} {
+		m.SetMemory(0x10000, 0xaabbccdd)
+		m.SetMemory(0x13370000, 123)
+	}
+	data := make([]byte, 3*PageSize+100)
+	rng.Read(data)
+	for _, m := range []*Memory{a, b} {
+		require.NoError(t, m.SetMemoryRange(0x80010, bytes.NewReader(data)))
+	}
+	return a, b
+}
+
+func TestMemoryCompress(t *testing.T) {
+	for _, compression := range []PageCompression{PageCompressionSnappy, PageCompressionZstd} {
+		compression := compression
+		t.Run(string(compression), func(t *testing.T) {
+			m, expected := newTestMemories(t)
+			pages := m.PageCount()
+			m.Compress(compression)
+			require.Equal(t, pages, m.PageCount())
+			require.Equal(t, pages, m.CompressedPageCount())
+			require.Less(t, m.CompressedSize(), m.UsageRaw(), "sparse pages compress")
+
+			// Reads do not need to decompress pages to compute the merkle root or serialize the memory
+			require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+			expectedJSON, err := json.Marshal(expected)
+			require.NoError(t, err)
+			actualJSON, err := json.Marshal(m)
+			require.NoError(t, err)
+			require.Equal(t, expectedJSON, actualJSON)
+			require.Equal(t, pages, m.CompressedPageCount())
+
+			// Pages are decompressed on access
+			require.Equal(t, expected.GetMemory(0x10000), m.GetMemory(0x10000))
+			require.Equal(t, expected.MerkleProof(0x80010), m.MerkleProof(0x80010))
+			require.Equal(t, pages-2, m.CompressedPageCount())
+			actual, err := io.ReadAll(m.ReadMemoryRange(0x80000, 2*PageSize))
+			require.NoError(t, err)
+			expectedData, err := io.ReadAll(expected.ReadMemoryRange(0x80000, 2*PageSize))
+			require.NoError(t, err)
+			require.Equal(t, expectedData, actual)
+
+			// Writes to decompressed pages update the merkle root
+			for _, addr := range []Word{0x10000, 0x13370000, 0x82000, 0x200000} {
+				expected.SetMemory(addr, 0x11223344)
+				m.SetMemory(addr, 0x11223344)
+				require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+			}
+			require.Equal(t, pages+1, m.PageCount())
+
+			// Pages can be compressed again, with a different compression
+			m.Compress(PageCompressionSnappy)
+			m.Compress(PageCompressionZstd)
+			require.Equal(t, pages+1, m.CompressedPageCount())
+			require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+			require.Equal(t, expected.GetMemory(0x13370000), m.GetMemory(0x13370000))
+		})
+	}
+}
+
+func TestMemoryCompressedJSONRoundTrip(t *testing.T) {
+	m, expected := newTestMemories(t)
+	m.Compress(PageCompressionZstd)
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	var decoded Memory
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Zero(t, decoded.CompressedPageCount())
+	require.Equal(t, expected.MerkleRoot(), decoded.MerkleRoot())
+}
@@ -0,0 +1,54 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCopy(t *testing.T) {
+	m, expected := newTestMemories(t)
+	m.Compress(PageCompressionSnappy)
+	cp := m.Copy()
+	require.Equal(t, expected.MerkleRoot(), cp.MerkleRoot())
+	require.Equal(t, m.PageCount(), cp.PageCount())
+	require.Zero(t, cp.CompressedPageCount(), "the copy holds its pages in host memory")
+
+	// the copy shares no data with the memory
+	cp.SetMemory(0x10000, 1)
+	require.Equal(t, expected.GetMemory(0x10000), m.GetMemory(0x10000))
+	require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+}
+
+func TestMemoryCopyFrom(t *testing.T) {
+	t.Run("restore", func(t *testing.T) {
+		m, expected := newTestMemories(t)
+		snapshot := m.Copy()
+		m.SetMemory(0x10000, 1)
+		m.SetMemory(0x20000, 2) // a new page
+		var written []Word
+		m.SetWriteHook(func(pageIndex Word) { written = append(written, pageIndex) })
+
+		m.CopyFrom(snapshot)
+		require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+		require.Equal(t, expected.PageCount(), m.PageCount())
+		require.Equal(t, Word(0), m.GetMemory(0x20000))
+		require.Contains(t, written, Word(0x20000>>PageAddrSize), "pages of the memory are written")
+		require.Contains(t, written, Word(0x10000>>PageAddrSize), "pages of the snapshot are written")
+
+		// the snapshot can be restored again
+		m.SetMemory(0x10000, 1)
+		m.CopyFrom(snapshot)
+		require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+	})
+
+	t.Run("page store", func(t *testing.T) {
+		m, expected := newTestMemories(t)
+		snapshot := m.Copy()
+		require.NoError(t, m.SetPageStore(newTestPageStore(t, mmapSupported), MinResidentPages))
+		m.SetMemory(0x10000, 1)
+		m.CopyFrom(snapshot)
+		require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+		require.Equal(t, m.PageCount()-MinResidentPages, m.EvictedPageCount(), "the memory keeps its page store")
+	})
+}
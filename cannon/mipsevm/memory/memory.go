@@ -3,25 +3,38 @@ package memory
 import (
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/bits"
 	"sort"
 
 	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 )
 
 // Note: 2**12 = 4 KiB, the min phys page size in the Go runtime.
 const (
-	PageAddrSize = 12
-	PageKeySize  = 32 - PageAddrSize
+	PageAddrSize = arch.PageAddrSize
+	PageKeySize  = arch.PageKeySize
 	PageSize     = 1 << PageAddrSize
 	PageAddrMask = PageSize - 1
 	MaxPageCount = 1 << PageKeySize
 	PageKeyMask  = MaxPageCount - 1
 )
 
-const MEM_PROOF_SIZE = 28 * 32
+// MEM_PROOF_SIZE is the size of a memory proof: the 32-byte leaf, and the sibling nodes up to the root
+const MEM_PROOF_SIZE = arch.MemProofSize
+
+// Word is an unsigned integer of the register width of the VM, and of memory addresses
+type Word = arch.Word
+
+var (
+	ErrUnalignedAddress   = errors.New("unaligned memory address")
+	ErrInvalidMerkleProof = errors.New("invalid memory merkle proof")
+	ErrValueMismatch      = errors.New("memory value mismatch")
+)
 
 func HashPair(left, right [32]byte) [32]byte {
 	out := crypto.Keccak256Hash(left[:], right[:])
@@ -43,46 +56,75 @@ type Memory struct {
 	nodes map[uint64]*[32]byte
 
 	// pageIndex -> cached page
-	pages map[uint32]*CachedPage
+	pages map[Word]*CachedPage
+
+	// pages that are compressed while idle, and not in pages
+	compressed compressedPages
+
+	// pages that are evicted to a page store, and not in pages
+	disk diskPages
 
 	// Note: since we don't de-alloc pages, we don't do ref-counting.
 	// Once a page exists, it doesn't leave memory
 
 	// two caches: we often read instructions from one page, and do memory things with another page.
 	// this prevents map lookups each instruction
-	lastPageKeys [2]uint32
+	lastPageKeys [2]Word
 	lastPage     [2]*CachedPage
+
+	// writeHook is called with the index of every page that is written to. Optional.
+	writeHook func(pageIndex Word)
 }
 
 func NewMemory() *Memory {
 	return &Memory{
 		nodes:        make(map[uint64]*[32]byte),
-		pages:        make(map[uint32]*CachedPage),
-		lastPageKeys: [2]uint32{^uint32(0), ^uint32(0)}, // default to invalid keys, to not match any pages
+		pages:        make(map[Word]*CachedPage),
+		lastPageKeys: [2]Word{^Word(0), ^Word(0)}, // default to invalid keys, to not match any pages
 	}
 }
 
 func (m *Memory) PageCount() int {
-	return len(m.pages)
+	return len(m.pages) + len(m.compressed.pages) + len(m.disk.pages)
 }
 
-func (m *Memory) ForEachPage(fn func(pageIndex uint32, page *Page) error) error {
+// ForEachPage calls fn with the data of each page. Compressed and evicted pages are decompressed or loaded
+// for the call only, so the page data must not be modified.
+func (m *Memory) ForEachPage(fn func(pageIndex Word, page *Page) error) error {
 	for pageIndex, cachedPage := range m.pages {
 		if err := fn(pageIndex, cachedPage.Data); err != nil {
 			return err
 		}
 	}
+	for pageIndex := range m.compressed.pages {
+		data, _ := m.pageData(pageIndex)
+		if err := fn(pageIndex, data); err != nil {
+			return err
+		}
+	}
+	for pageIndex := range m.disk.pages {
+		if err := fn(pageIndex, m.readEvictedPage(pageIndex)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
-func (m *Memory) Invalidate(addr uint32) {
-	// addr must be aligned to 4 bytes
-	if addr&0x3 != 0 {
+// SetWriteHook sets a function that is called with the index of every page that is written to,
+// e.g. to invalidate the code translated from the page. A nil hook removes it.
+func (m *Memory) SetWriteHook(fn func(pageIndex Word)) {
+	m.writeHook = fn
+}
+
+func (m *Memory) Invalidate(addr Word) {
+	// addr must be aligned to the word size
+	if addr&arch.ExtMask != 0 {
 		panic(fmt.Errorf("unaligned memory access: %x", addr))
 	}
 
 	// find page, and invalidate addr within it
 	if p, ok := m.pageLookup(addr >> PageAddrSize); ok {
+		m.onWrite(addr >> PageAddrSize)
 		prevValid := p.Ok[1]
 		p.Invalidate(addr & PageAddrMask)
 		if !prevValid { // if the page was already invalid before, then nodes to mem-root will also still be.
@@ -93,7 +135,7 @@ func (m *Memory) Invalidate(addr uint32) {
 	}
 
 	// find the gindex of the first page covering the address
-	gindex := ((uint64(1) << 32) | uint64(addr)) >> PageAddrSize
+	gindex := (uint64(1) << PageKeySize) | uint64(addr>>PageAddrSize)
 
 	for gindex > 0 {
 		m.nodes[gindex] = nil
@@ -103,23 +145,29 @@ func (m *Memory) Invalidate(addr uint32) {
 
 func (m *Memory) MerkleizeSubtree(gindex uint64) [32]byte {
 	l := uint64(bits.Len64(gindex))
-	if l > 28 {
+	if l > arch.MemProofLeafCount {
 		panic("gindex too deep")
 	}
 	if l > PageKeySize {
 		depthIntoPage := l - 1 - PageKeySize
 		pageIndex := (gindex >> depthIntoPage) & PageKeyMask
-		if p, ok := m.pages[uint32(pageIndex)]; ok {
+		if p, ok := m.compressed.pages[Word(pageIndex)]; ok && depthIntoPage == 0 {
+			return p.root
+		}
+		if root, ok := m.disk.pages[Word(pageIndex)]; ok && depthIntoPage == 0 {
+			return root
+		}
+		if p, ok := m.page(Word(pageIndex)); ok {
 			pageGindex := (1 << depthIntoPage) | (gindex & ((1 << depthIntoPage) - 1))
 			return p.MerkleizeSubtree(pageGindex)
 		} else {
-			return zeroHashes[28-l] // page does not exist
+			return zeroHashes[arch.MemProofLeafCount-l] // page does not exist
 		}
 	}
 	n, ok := m.nodes[gindex]
 	if !ok {
 		// if the node doesn't exist, the whole sub-tree is zeroed
-		return zeroHashes[28-l]
+		return zeroHashes[arch.MemProofLeafCount-l]
 	}
 	if n != nil {
 		return *n
@@ -131,27 +179,27 @@ func (m *Memory) MerkleizeSubtree(gindex uint64) [32]byte {
 	return r
 }
 
-func (m *Memory) MerkleProof(addr uint32) (out [MEM_PROOF_SIZE]byte) {
+func (m *Memory) MerkleProof(addr Word) (out [MEM_PROOF_SIZE]byte) {
 	proof := m.traverseBranch(1, addr, 0)
 	// encode the proof
-	for i := 0; i < 28; i++ {
+	for i := 0; i < arch.MemProofLeafCount; i++ {
 		copy(out[i*32:(i+1)*32], proof[i][:])
 	}
 	return out
 }
 
-func (m *Memory) traverseBranch(parent uint64, addr uint32, depth uint8) (proof [][32]byte) {
-	if depth == 32-5 {
-		proof = make([][32]byte, 0, 32-5+1)
+func (m *Memory) traverseBranch(parent uint64, addr Word, depth uint8) (proof [][32]byte) {
+	if depth == arch.WordSize-5 {
+		proof = make([][32]byte, 0, arch.MemProofLeafCount)
 		proof = append(proof, m.MerkleizeSubtree(parent))
 		return
 	}
-	if depth > 32-5 {
+	if depth > arch.WordSize-5 {
 		panic("traversed too deep")
 	}
 	self := parent << 1
 	sibling := self | 1
-	if addr&(1<<(31-depth)) != 0 {
+	if addr&(1<<(arch.WordSize-1-depth)) != 0 {
 		self, sibling = sibling, self
 	}
 	proof = m.traverseBranch(self, addr, depth+1)
@@ -164,7 +212,36 @@ func (m *Memory) MerkleRoot() [32]byte {
 	return m.MerkleizeSubtree(1)
 }
 
-func (m *Memory) pageLookup(pageIndex uint32) (*CachedPage, bool) {
+// VerifyMerkleProof checks that the memory with the given merkle root contains value at addr,
+// using a proof as produced by MerkleProof.
+// The validation matches the on-chain MIPSMemory.readMem proof validation.
+func VerifyMerkleProof(root [32]byte, addr Word, value Word, proof [MEM_PROOF_SIZE]byte) error {
+	if addr&arch.ExtMask != 0 {
+		return fmt.Errorf("%w: %08x", ErrUnalignedAddress, addr)
+	}
+	leaf := *(*[32]byte)(proof[:32])
+	node := leaf
+	path := addr >> 5
+	for i := 1; i < arch.MemProofLeafCount; i++ {
+		sibling := *(*[32]byte)(proof[i*32 : (i+1)*32])
+		if path&1 != 0 {
+			node = HashPair(sibling, node)
+		} else {
+			node = HashPair(node, sibling)
+		}
+		path >>= 1
+	}
+	if node != root {
+		return fmt.Errorf("%w: computed root %x, expected %x", ErrInvalidMerkleProof, node, root)
+	}
+	offset := addr & 31
+	if actual := arch.ByteOrderWord.Word(leaf[offset : offset+arch.WordSizeBytes]); actual != value {
+		return fmt.Errorf("%w: memory at %08x contains %08x, expected %08x", ErrValueMismatch, addr, actual, value)
+	}
+	return nil
+}
+
+func (m *Memory) pageLookup(pageIndex Word) (*CachedPage, bool) {
 	// hit caches
 	if pageIndex == m.lastPageKeys[0] {
 		return m.lastPage[0], true
@@ -172,7 +249,7 @@ func (m *Memory) pageLookup(pageIndex uint32) (*CachedPage, bool) {
 	if pageIndex == m.lastPageKeys[1] {
 		return m.lastPage[1], true
 	}
-	p, ok := m.pages[pageIndex]
+	p, ok := m.page(pageIndex)
 
 	// only cache existing pages.
 	if ok {
@@ -185,9 +262,9 @@ func (m *Memory) pageLookup(pageIndex uint32) (*CachedPage, bool) {
 	return p, ok
 }
 
-func (m *Memory) SetMemory(addr uint32, v uint32) {
-	// addr must be aligned to 4 bytes
-	if addr&0x3 != 0 {
+func (m *Memory) SetMemory(addr Word, v Word) {
+	// addr must be aligned to the word size
+	if addr&arch.ExtMask != 0 {
 		panic(fmt.Errorf("unaligned memory access: %x", addr))
 	}
 
@@ -201,10 +278,26 @@ func (m *Memory) SetMemory(addr uint32, v uint32) {
 	} else {
 		m.Invalidate(addr) // invalidate this branch of memory, now that the value changed
 	}
-	binary.BigEndian.PutUint32(p.Data[pageAddr:pageAddr+4], v)
+	arch.ByteOrderWord.PutWord(p.Data[pageAddr:pageAddr+arch.WordSizeBytes], v)
+}
+
+// GetMemory returns the word at the address, which must be aligned to the word size.
+func (m *Memory) GetMemory(addr Word) Word {
+	// addr must be aligned to the word size
+	if addr&arch.ExtMask != 0 {
+		panic(fmt.Errorf("unaligned memory access: %x", addr))
+	}
+	p, ok := m.pageLookup(addr >> PageAddrSize)
+	if !ok {
+		return 0
+	}
+	pageAddr := addr & PageAddrMask
+	return arch.ByteOrderWord.Word(p.Data[pageAddr : pageAddr+arch.WordSizeBytes])
 }
 
-func (m *Memory) GetMemory(addr uint32) uint32 {
+// GetUint32 returns the 32 bits at the address, which must be aligned to 4 bytes.
+// Instructions are 32 bits wide, also in 64-bit programs.
+func (m *Memory) GetUint32(addr Word) uint32 {
 	// addr must be aligned to 4 bytes
 	if addr&0x3 != 0 {
 		panic(fmt.Errorf("unaligned memory access: %x", addr))
@@ -217,30 +310,40 @@ func (m *Memory) GetMemory(addr uint32) uint32 {
 	return binary.BigEndian.Uint32(p.Data[pageAddr : pageAddr+4])
 }
 
-func (m *Memory) AllocPage(pageIndex uint32) *CachedPage {
+func (m *Memory) AllocPage(pageIndex Word) *CachedPage {
+	if compressed, ok := m.compressed.pages[pageIndex]; ok {
+		delete(m.compressed.pages, pageIndex)
+		m.compressed.size -= uint64(len(compressed.data))
+	}
+	delete(m.disk.pages, pageIndex)
 	p := &CachedPage{Data: new(Page)}
 	m.pages[pageIndex] = p
+	m.onWrite(pageIndex)
 	// make nodes to root
 	k := (1 << PageKeySize) | uint64(pageIndex)
 	for k > 0 {
 		m.nodes[k] = nil
 		k >>= 1
 	}
+	m.onResident(pageIndex)
 	return p
 }
 
 type pageEntry struct {
-	Index uint32 `json:"index"`
-	Data  *Page  `json:"data"`
+	Index Word  `json:"index"`
+	Data  *Page `json:"data"`
 }
 
 func (m *Memory) MarshalJSON() ([]byte, error) { // nosemgrep
-	pages := make([]pageEntry, 0, len(m.pages))
-	for k, p := range m.pages {
+	pages := make([]pageEntry, 0, m.PageCount())
+	if err := m.ForEachPage(func(pageIndex Word, page *Page) error {
 		pages = append(pages, pageEntry{
-			Index: k,
-			Data:  p.Data,
+			Index: pageIndex,
+			Data:  page,
 		})
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 	sort.Slice(pages, func(i, j int) bool {
 		return pages[i].Index < pages[j].Index
@@ -254,8 +357,10 @@ func (m *Memory) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	m.nodes = make(map[uint64]*[32]byte)
-	m.pages = make(map[uint32]*CachedPage)
-	m.lastPageKeys = [2]uint32{^uint32(0), ^uint32(0)}
+	m.pages = make(map[Word]*CachedPage)
+	m.compressed = compressedPages{}
+	m.disk.reset()
+	m.lastPageKeys = [2]Word{^Word(0), ^Word(0)}
 	m.lastPage = [2]*CachedPage{nil, nil}
 	for i, p := range pages {
 		if _, ok := m.pages[p.Index]; ok {
@@ -266,7 +371,48 @@ func (m *Memory) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-func (m *Memory) SetMemoryRange(addr uint32, r io.Reader) error {
+// Copy returns a deep copy of the memory, e.g. to snapshot a state. The copy holds all its pages in host memory,
+// and doesn't share the page store, compression or write hook of the memory.
+func (m *Memory) Copy() *Memory {
+	out := NewMemory()
+	_ = m.ForEachPage(func(pageIndex Word, page *Page) error {
+		*out.AllocPage(pageIndex).Data = *page
+		return nil
+	})
+	return out
+}
+
+// CopyFrom replaces the pages of the memory with a copy of the pages of src, e.g. to restore a snapshot.
+// The memory keeps its page store, compression and write hook, and the write hook is called for every page
+// of the memory before and after the copy.
+func (m *Memory) CopyFrom(src *Memory) {
+	if src == m {
+		return
+	}
+	if m.writeHook != nil {
+		for pageIndex := range m.pages {
+			m.writeHook(pageIndex)
+		}
+		for pageIndex := range m.compressed.pages {
+			m.writeHook(pageIndex)
+		}
+		for pageIndex := range m.disk.pages {
+			m.writeHook(pageIndex)
+		}
+	}
+	m.nodes = make(map[uint64]*[32]byte)
+	m.pages = make(map[Word]*CachedPage)
+	m.compressed = compressedPages{compression: m.compressed.compression}
+	m.disk.reset()
+	m.lastPageKeys = [2]Word{^Word(0), ^Word(0)}
+	m.lastPage = [2]*CachedPage{nil, nil}
+	_ = src.ForEachPage(func(pageIndex Word, page *Page) error {
+		*m.AllocPage(pageIndex).Data = *page
+		return nil
+	})
+}
+
+func (m *Memory) SetMemoryRange(addr Word, r io.Reader) error {
 	for {
 		pageIndex := addr >> PageAddrSize
 		pageAddr := addr & PageAddrMask
@@ -275,6 +421,7 @@ func (m *Memory) SetMemoryRange(addr uint32, r io.Reader) error {
 			p = m.AllocPage(pageIndex)
 		}
 		p.InvalidateFull()
+		m.onWrite(pageIndex)
 		n, err := r.Read(p.Data[pageAddr:])
 		if err != nil {
 			if err == io.EOF {
@@ -282,14 +429,14 @@ func (m *Memory) SetMemoryRange(addr uint32, r io.Reader) error {
 			}
 			return err
 		}
-		addr += uint32(n)
+		addr += Word(n)
 	}
 }
 
 type memReader struct {
 	m     *Memory
-	addr  uint32
-	count uint32
+	addr  Word
+	count Word
 }
 
 func (r *memReader) Read(dest []byte) (n int, err error) {
@@ -303,7 +450,7 @@ func (r *memReader) Read(dest []byte) (n int, err error) {
 
 	pageIndex := r.addr >> PageAddrSize
 	start := r.addr & PageAddrMask
-	end := uint32(PageSize)
+	end := Word(PageSize)
 
 	if pageIndex == (endAddr >> PageAddrSize) {
 		end = endAddr & PageAddrMask
@@ -314,17 +461,17 @@ func (r *memReader) Read(dest []byte) (n int, err error) {
 	} else {
 		n = copy(dest, make([]byte, end-start)) // default to zeroes
 	}
-	r.addr += uint32(n)
-	r.count -= uint32(n)
+	r.addr += Word(n)
+	r.count -= Word(n)
 	return n, nil
 }
 
-func (m *Memory) ReadMemoryRange(addr uint32, count uint32) io.Reader {
+func (m *Memory) ReadMemoryRange(addr Word, count Word) io.Reader {
 	return &memReader{m: m, addr: addr, count: count}
 }
 
 func (m *Memory) UsageRaw() uint64 {
-	return uint64(len(m.pages)) * PageSize
+	return uint64(m.PageCount()) * PageSize
 }
 
 func (m *Memory) Usage() string {
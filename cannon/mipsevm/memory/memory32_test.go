@@ -1,3 +1,5 @@
+//go:build !cannon64
+
 package memory
 
 import (
@@ -187,3 +189,62 @@ func TestMemoryJSON(t *testing.T) {
 	require.NoError(t, json.Unmarshal(dat, &res))
 	require.Equal(t, uint32(123), res.GetMemory(8))
 }
+
+func TestVerifyMerkleProof(t *testing.T) {
+	m := NewMemory()
+	m.SetMemory(0x10000, 0xaabbccdd)
+	m.SetMemory(0x80004, 42)
+	m.SetMemory(0x13370000, 123)
+	root := m.MerkleRoot()
+
+	t.Run("valid", func(t *testing.T) {
+		for _, addr := range []uint32{0x10000, 0x80004, 0x13370000, 0x80000, 0xfffffffc} {
+			require.NoError(t, VerifyMerkleProof(root, addr, m.GetMemory(addr), m.MerkleProof(addr)))
+		}
+	})
+	t.Run("unaligned", func(t *testing.T) {
+		err := VerifyMerkleProof(root, 0x80005, 42, m.MerkleProof(0x80004))
+		require.ErrorIs(t, err, ErrUnalignedAddress)
+	})
+	t.Run("wrong value", func(t *testing.T) {
+		err := VerifyMerkleProof(root, 0x80004, 43, m.MerkleProof(0x80004))
+		require.ErrorIs(t, err, ErrValueMismatch)
+	})
+	t.Run("wrong root", func(t *testing.T) {
+		err := VerifyMerkleProof([32]byte{0x01}, 0x80004, 42, m.MerkleProof(0x80004))
+		require.ErrorIs(t, err, ErrInvalidMerkleProof)
+	})
+	t.Run("proof of other address", func(t *testing.T) {
+		err := VerifyMerkleProof(root, 0x13370000, 42, m.MerkleProof(0x80004))
+		require.ErrorIs(t, err, ErrInvalidMerkleProof)
+	})
+	t.Run("corrupted sibling", func(t *testing.T) {
+		proof := m.MerkleProof(0x80004)
+		proof[32*5] ^= 0x01
+		err := VerifyMerkleProof(root, 0x80004, 42, proof)
+		require.ErrorIs(t, err, ErrInvalidMerkleProof)
+	})
+}
+
+func FuzzVerifyMerkleProof(f *testing.F) {
+	f.Add(uint32(0x10000), uint32(0xaabbccdd), uint16(0), byte(0x01))
+	f.Add(uint32(0x80004), uint32(42), uint16(MEM_PROOF_SIZE-1), byte(0x80))
+	f.Add(uint32(0xfffffffc), uint32(0), uint16(100), byte(0xff))
+	f.Fuzz(func(t *testing.T, addr uint32, value uint32, corruptIndex uint16, corruptMask byte) {
+		addr &^= 0x3
+		m := NewMemory()
+		m.SetMemory(addr, value)
+		// add some unrelated memory, so that not all siblings are zero-hashes
+		m.SetMemory(addr^0x1000, ^value)
+		m.SetMemory(addr^0x80000000, value+1)
+		root := m.MerkleRoot()
+		proof := m.MerkleProof(addr)
+		require.NoError(t, VerifyMerkleProof(root, addr, value, proof))
+
+		if corruptMask == 0 {
+			corruptMask = 1
+		}
+		proof[int(corruptIndex)%MEM_PROOF_SIZE] ^= corruptMask
+		require.ErrorIs(t, VerifyMerkleProof(root, addr, value, proof), ErrInvalidMerkleProof)
+	})
+}
@@ -68,9 +68,11 @@ type CachedPage struct {
 	Cache [PageSize / 32][32]byte
 	// true if the intermediate node is valid
 	Ok [PageSize / 32]bool
+	// true if the page was looked up since it was last considered for eviction to a page store
+	referenced bool
 }
 
-func (p *CachedPage) Invalidate(pageAddr uint32) {
+func (p *CachedPage) Invalidate(pageAddr Word) {
 	if pageAddr >= PageSize {
 		panic("invalid page addr")
 	}
@@ -0,0 +1,284 @@
+package memory
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// MinResidentPages is the minimum number of pages a memory with a page store keeps in host memory:
+// the page being accessed, and the two pages cached for the instruction fetch and memory access of a step.
+const MinResidentPages = 4
+
+// initialPageStoreSlots is the number of pages the page store file is first sized for.
+const initialPageStoreSlots = 1024
+
+var ErrPageStoreInUse = errors.New("page store is already used by another memory")
+
+// PageStore is a file that memory pages are evicted to, to run programs that use more memory than the host can hold.
+// The file is memory-mapped where supported, and only holds data while the memory using it is alive:
+// it is created empty, and removed by Close.
+type PageStore struct {
+	path string
+	file *os.File
+	// mapped is the memory-mapped file, or nil if the file is read and written with syscalls instead
+	mapped []byte
+	mmap   bool
+
+	// capacity is the number of page slots the file is sized for
+	capacity uint64
+	// next is the next unused slot
+	next uint64
+
+	inUse bool
+}
+
+// NewPageStore creates a page store backed by a new file at path. The file must not exist yet.
+func NewPageStore(path string) (*PageStore, error) {
+	return newPageStore(path, mmapSupported)
+}
+
+func newPageStore(path string, mmap bool) (*PageStore, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create page store file: %w", err)
+	}
+	s := &PageStore{path: path, file: file, mmap: mmap}
+	if err := s.grow(initialPageStoreSlots); err != nil {
+		_ = s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Size returns the size of the page store file.
+func (s *PageStore) Size() uint64 {
+	return s.capacity * PageSize
+}
+
+func (s *PageStore) allocSlot() uint64 {
+	slot := s.next
+	s.next++
+	return slot
+}
+
+func (s *PageStore) reset() {
+	s.next = 0
+}
+
+func (s *PageStore) grow(slots uint64) error {
+	if s.mapped != nil {
+		if err := unmapFile(s.mapped); err != nil {
+			return fmt.Errorf("failed to unmap page store: %w", err)
+		}
+		s.mapped = nil
+	}
+	if err := s.file.Truncate(int64(slots * PageSize)); err != nil {
+		return fmt.Errorf("failed to grow page store to %d pages: %w", slots, err)
+	}
+	s.capacity = slots
+	if s.mmap {
+		mapped, err := mapFile(s.file, int(slots*PageSize))
+		if err != nil {
+			return fmt.Errorf("failed to map page store: %w", err)
+		}
+		s.mapped = mapped
+	}
+	return nil
+}
+
+func (s *PageStore) writePage(slot uint64, p *Page) error {
+	if slot >= s.capacity {
+		if err := s.grow(max(2*s.capacity, slot+1)); err != nil {
+			return err
+		}
+	}
+	offset := slot * PageSize
+	if s.mapped != nil {
+		copy(s.mapped[offset:offset+PageSize], p[:])
+		return nil
+	}
+	_, err := s.file.WriteAt(p[:], int64(offset))
+	return err
+}
+
+func (s *PageStore) readPage(slot uint64, p *Page) error {
+	if slot >= s.capacity {
+		return fmt.Errorf("page store slot %d out of range", slot)
+	}
+	offset := slot * PageSize
+	if s.mapped != nil {
+		copy(p[:], s.mapped[offset:offset+PageSize])
+		return nil
+	}
+	_, err := s.file.ReadAt(p[:], int64(offset))
+	return err
+}
+
+// Close unmaps, closes and removes the page store file. The memory that used the store must not be used after.
+func (s *PageStore) Close() error {
+	var errs []error
+	if s.mapped != nil {
+		errs = append(errs, unmapFile(s.mapped))
+		s.mapped = nil
+	}
+	errs = append(errs, s.file.Close(), os.Remove(s.path))
+	return errors.Join(errs...)
+}
+
+// diskPages holds the memory pages that are evicted to a page store.
+type diskPages struct {
+	store *PageStore
+	// maxResident is the number of pages kept in host memory, beyond which pages are evicted
+	maxResident int
+	// pages are the evicted pages, with their merkle root, so proofs of neighbouring pages don't need to load the page
+	pages map[Word][32]byte
+	// slots are the slots in the store of the pages that were ever evicted. A page is written back to the same slot.
+	slots map[Word]uint64
+	// clean are the resident pages that are unchanged since they were loaded, and don't need to be written back.
+	clean map[Word]struct{}
+	// clock are the pages that may be resident, in the order they are considered for eviction.
+	// Entries of pages that are no longer resident are dropped when the clock hand passes them.
+	clock []Word
+	hand  int
+}
+
+// SetPageStore evicts memory pages to the store when more than maxResident pages are in host memory,
+// in approximately least recently used order. Evicted pages are loaded on demand when accessed,
+// and modified pages are written back to the store when evicted again.
+// Like compression, eviction is transparent: the merkle root of the memory and its serialization are unchanged.
+// A store can only be used by one memory.
+func (m *Memory) SetPageStore(store *PageStore, maxResident int) error {
+	if maxResident < MinResidentPages {
+		return fmt.Errorf("max resident pages %d is less than the minimum of %d", maxResident, MinResidentPages)
+	}
+	if store.inUse {
+		return ErrPageStoreInUse
+	}
+	if m.disk.store != nil {
+		return errors.New("memory already uses a page store")
+	}
+	store.inUse = true
+	m.disk = diskPages{
+		store:       store,
+		maxResident: maxResident,
+		pages:       make(map[Word][32]byte),
+		slots:       make(map[Word]uint64),
+		clean:       make(map[Word]struct{}),
+	}
+	for pageIndex := range m.pages {
+		m.disk.clock = append(m.disk.clock, pageIndex)
+	}
+	m.evictPages(^Word(0))
+	return nil
+}
+
+// EvictedPageCount returns the number of pages that are currently evicted to the page store.
+func (m *Memory) EvictedPageCount() int {
+	return len(m.disk.pages)
+}
+
+// onResident is called when a page is made resident, and evicts pages if there are too many resident pages.
+func (m *Memory) onResident(pageIndex Word) {
+	if m.disk.store == nil {
+		return
+	}
+	m.disk.clock = append(m.disk.clock, pageIndex)
+	m.evictPages(pageIndex)
+}
+
+// onWrite is called when the data of a resident page changes.
+func (m *Memory) onWrite(pageIndex Word) {
+	delete(m.disk.clean, pageIndex)
+	if m.writeHook != nil {
+		m.writeHook(pageIndex)
+	}
+}
+
+// evictPages evicts resident pages until at most maxResident pages are resident.
+// The keep page, and the pages in the lookup cache, are not evicted.
+// Pages that were accessed since the clock hand last passed them are given a second chance.
+func (m *Memory) evictPages(keep Word) {
+	d := &m.disk
+	for len(m.pages) > d.maxResident {
+		if d.hand >= len(d.clock) {
+			d.hand = 0
+		}
+		pageIndex := d.clock[d.hand]
+		p, ok := m.pages[pageIndex]
+		if !ok {
+			d.dropClockHand()
+			continue
+		}
+		if pageIndex == keep || pageIndex == m.lastPageKeys[0] || pageIndex == m.lastPageKeys[1] {
+			d.hand++
+			continue
+		}
+		if p.referenced {
+			p.referenced = false
+			d.hand++
+			continue
+		}
+		m.evictPage(pageIndex, p)
+		d.dropClockHand()
+	}
+}
+
+// dropClockHand removes the clock entry at the hand, which then points to the next entry to consider.
+func (d *diskPages) dropClockHand() {
+	last := len(d.clock) - 1
+	d.clock[d.hand] = d.clock[last]
+	d.clock = d.clock[:last]
+}
+
+func (m *Memory) evictPage(pageIndex Word, p *CachedPage) {
+	d := &m.disk
+	if _, ok := d.clean[pageIndex]; !ok {
+		slot, ok := d.slots[pageIndex]
+		if !ok {
+			slot = d.store.allocSlot()
+			d.slots[pageIndex] = slot
+		}
+		// Pages are only ever evicted to a local file, so failing to write one means the memory can't be kept consistent
+		if err := d.store.writePage(slot, p.Data); err != nil {
+			panic(fmt.Errorf("failed to evict page %x: %w", pageIndex, err))
+		}
+	}
+	delete(d.clean, pageIndex)
+	d.pages[pageIndex] = p.MerkleRoot()
+	delete(m.pages, pageIndex)
+}
+
+func (m *Memory) loadPage(pageIndex Word) *CachedPage {
+	p := &CachedPage{Data: m.readEvictedPage(pageIndex)}
+	// The merkle nodes above the page may still be cached, see decompressPage
+	if root := p.MerkleRoot(); root != m.disk.pages[pageIndex] {
+		panic(fmt.Errorf("page %x loaded from the page store has merkle root %x, expected %x", pageIndex, root, m.disk.pages[pageIndex]))
+	}
+	delete(m.disk.pages, pageIndex)
+	m.pages[pageIndex] = p
+	m.disk.clean[pageIndex] = struct{}{}
+	m.onResident(pageIndex)
+	return p
+}
+
+func (m *Memory) readEvictedPage(pageIndex Word) *Page {
+	data := new(Page)
+	if err := m.disk.store.readPage(m.disk.slots[pageIndex], data); err != nil {
+		panic(fmt.Errorf("failed to load page %x: %w", pageIndex, err))
+	}
+	return data
+}
+
+// reset drops all pages of the page store, keeping the store.
+func (d *diskPages) reset() {
+	if d.store == nil {
+		return
+	}
+	d.store.reset()
+	d.pages = make(map[Word][32]byte)
+	d.slots = make(map[Word]uint64)
+	d.clean = make(map[Word]struct{})
+	d.clock = nil
+	d.hand = 0
+}
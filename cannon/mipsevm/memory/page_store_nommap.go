@@ -0,0 +1,19 @@
+//go:build !linux && !darwin
+
+package memory
+
+import (
+	"errors"
+	"os"
+)
+
+// mmapSupported is false where the page store is not memory-mapped, and read and written with syscalls instead.
+const mmapSupported = false
+
+func mapFile(file *os.File, size int) ([]byte, error) {
+	return nil, errors.New("memory-mapped page store not supported")
+}
+
+func unmapFile(data []byte) error {
+	return nil
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+)
+
+func newTestPageStore(t *testing.T, mmap bool) *PageStore {
+	path := filepath.Join(t.TempDir(), "pages")
+	store, err := newPageStore(path, mmap)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, store.Close())
+		require.NoFileExists(t, path)
+	})
+	return store
+}
+
+func TestMemoryPageStore(t *testing.T) {
+	modes := map[string]bool{"syscalls": false}
+	if mmapSupported {
+		modes["mmap"] = true
+	}
+	for name, mmap := range modes {
+		t.Run(name, func(t *testing.T) {
+			m, expected := newTestMemories(t)
+			pages := m.PageCount()
+			require.NoError(t, m.SetPageStore(newTestPageStore(t, mmap), MinResidentPages))
+			require.Equal(t, pages, m.PageCount())
+			require.Equal(t, pages-MinResidentPages, m.EvictedPageCount())
+
+			// Reads do not need to load pages to compute the merkle root or serialize the memory
+			require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+			expectedJSON, err := json.Marshal(expected)
+			require.NoError(t, err)
+			actualJSON, err := json.Marshal(m)
+			require.NoError(t, err)
+			require.Equal(t, expectedJSON, actualJSON)
+			require.Equal(t, pages-MinResidentPages, m.EvictedPageCount())
+
+			// Pages are loaded on access, evicting others
+			require.Equal(t, expected.GetMemory(0x10000), m.GetMemory(0x10000))
+			require.Equal(t, expected.GetMemory(0x13370000), m.GetMemory(0x13370000))
+			require.Equal(t, expected.MerkleProof(0x80010), m.MerkleProof(0x80010))
+			actual, err := io.ReadAll(m.ReadMemoryRange(0x80000, 3*PageSize))
+			require.NoError(t, err)
+			expectedData, err := io.ReadAll(expected.ReadMemoryRange(0x80000, 3*PageSize))
+			require.NoError(t, err)
+			require.Equal(t, expectedData, actual)
+			require.Equal(t, pages-MinResidentPages, m.EvictedPageCount())
+
+			// Writes are kept when pages are evicted, and to new pages beyond the resident pages
+			rng := rand.New(rand.NewSource(1234))
+			for i := 0; i < 1000; i++ {
+				addr := Word(rng.Intn(8))*PageSize*64 + Word(rng.Intn(PageSize))&^arch.ExtMask
+				v := Word(rng.Uint32())
+				expected.SetMemory(addr, v)
+				m.SetMemory(addr, v)
+				require.Equal(t, expected.GetMemory(addr), m.GetMemory(addr))
+			}
+			require.Equal(t, expected.PageCount(), m.PageCount())
+			require.Equal(t, expected.PageCount()-MinResidentPages, m.EvictedPageCount())
+			require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+			for _, addr := range []Word{0x10000, 0x13370000, 0x80018, PageSize * 64 * 3} {
+				require.Equal(t, expected.GetMemory(addr), m.GetMemory(addr))
+				require.Equal(t, expected.MerkleProof(addr), m.MerkleProof(addr))
+			}
+
+			// Compressed pages can be decompressed while other pages are evicted
+			m.Compress(PageCompressionSnappy)
+			require.Equal(t, expected.PageCount(), m.PageCount())
+			require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+			for _, addr := range []Word{0x10000, 0x13370000, 0x80018, PageSize * 64 * 3, PageSize * 64 * 5} {
+				require.Equal(t, expected.GetMemory(addr), m.GetMemory(addr))
+			}
+			require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+		})
+	}
+}
+
+func TestMemoryPageStoreJSONRoundTrip(t *testing.T) {
+	m, expected := newTestMemories(t)
+	require.NoError(t, m.SetPageStore(newTestPageStore(t, mmapSupported), MinResidentPages))
+	data, err := json.Marshal(m)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, m))
+	require.Equal(t, m.PageCount()-MinResidentPages, m.EvictedPageCount(), "loaded pages are evicted")
+	require.Equal(t, expected.MerkleRoot(), m.MerkleRoot())
+}
+
+func TestMemoryPageStoreGrows(t *testing.T) {
+	store := newTestPageStore(t, mmapSupported)
+	m := NewMemory()
+	require.NoError(t, m.SetPageStore(store, MinResidentPages))
+	pages := 2*initialPageStoreSlots + 10
+	for i := 0; i < pages; i++ {
+		m.SetMemory(Word(i)*PageSize, Word(i))
+	}
+	require.Equal(t, pages-MinResidentPages, m.EvictedPageCount())
+	require.GreaterOrEqual(t, store.Size(), uint64(pages-MinResidentPages)*PageSize)
+	for i := 0; i < pages; i++ {
+		require.Equal(t, Word(i), m.GetMemory(Word(i)*PageSize))
+	}
+}
+
+func TestSetPageStoreInvalid(t *testing.T) {
+	store := newTestPageStore(t, mmapSupported)
+	require.ErrorContains(t, NewMemory().SetPageStore(store, MinResidentPages-1), "minimum")
+	m := NewMemory()
+	require.NoError(t, m.SetPageStore(store, MinResidentPages))
+	require.ErrorIs(t, NewMemory().SetPageStore(store, MinResidentPages), ErrPageStoreInUse)
+	require.Error(t, m.SetPageStore(newTestPageStore(t, mmapSupported), MinResidentPages))
+
+	_, err := NewPageStore(store.path)
+	require.ErrorContains(t, err, "file exists", "must not overwrite an existing file")
+}
@@ -23,19 +23,32 @@ type InstrumentedState struct {
 	stackTracker  ThreadedStackTracker
 
 	preimageOracle *exec.TrackingPreimageOracleReader
+
+	tracer          mipsevm.StepTracer
+	syscallPolicy   *exec.SyscallPolicy
+	unknownSyscalls *exec.UnknownSyscalls
+	syscallStats    *exec.SyscallStats
+	strict          bool
+
+	// blocks is the code translated for RunSteps, if fast execution is enabled
+	blocks *exec.BlockCache
 }
 
 var _ mipsevm.FPVM = (*InstrumentedState)(nil)
 
 func NewInstrumentedState(state *State, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger) *InstrumentedState {
+	syscallPolicy := GetSyscallPolicy(state.Version)
 	return &InstrumentedState{
-		state:          state,
-		log:            log,
-		stdOut:         stdOut,
-		stdErr:         stdErr,
-		memoryTracker:  exec.NewMemoryTracker(state.Memory),
-		stackTracker:   &NoopThreadedStackTracker{},
-		preimageOracle: exec.NewTrackingPreimageOracleReader(po),
+		state:           state,
+		log:             log,
+		stdOut:          stdOut,
+		stdErr:          stdErr,
+		memoryTracker:   exec.NewMemoryTracker(state.Memory),
+		stackTracker:    &NoopThreadedStackTracker{},
+		preimageOracle:  exec.NewTrackingPreimageOracleReader(po),
+		syscallPolicy:   syscallPolicy,
+		unknownSyscalls: exec.NewUnknownSyscalls(syscallPolicy),
+		syscallStats:    exec.NewSyscallStats(),
 	}
 }
 
@@ -78,6 +91,9 @@ func (m *InstrumentedState) Step(proof bool) (wit *mipsevm.StepWitness, err erro
 	if err != nil {
 		return nil, err
 	}
+	if m.tracer != nil {
+		m.tracer.OnStepEnd(m.state)
+	}
 
 	if proof {
 		memProof := m.memoryTracker.MemProof()
@@ -104,6 +120,10 @@ func (m *InstrumentedState) GetState() mipsevm.FPVMState {
 	return m.state
 }
 
+func (m *InstrumentedState) Stats() *mipsevm.Stats {
+	return &mipsevm.Stats{Syscalls: m.syscallStats.Stats()}
+}
+
 func (m *InstrumentedState) GetDebugInfo() *mipsevm.DebugInfo {
 	return &mipsevm.DebugInfo{
 		Pages:               m.state.Memory.PageCount(),
@@ -113,6 +133,67 @@ func (m *InstrumentedState) GetDebugInfo() *mipsevm.DebugInfo {
 	}
 }
 
+func (m *InstrumentedState) SetTracer(tracer mipsevm.StepTracer) {
+	m.tracer = tracer
+	m.memoryTracker.SetTracer(tracer)
+}
+
+func (m *InstrumentedState) SetUnknownSyscallMode(mode mipsevm.UnknownSyscallMode, logger log.Logger) {
+	m.unknownSyscalls.SetMode(mode, logger)
+}
+
+func (m *InstrumentedState) SetStrictMode(strict bool) {
+	m.strict = strict
+}
+
+func (m *InstrumentedState) SetFastExecution(enabled bool) {
+	if enabled && m.blocks == nil {
+		m.blocks = exec.NewBlockCache(m.state.Memory, isInterpreted, m.handleStore)
+	} else if !enabled && m.blocks != nil {
+		m.blocks.Close()
+		m.blocks = nil
+	}
+}
+
+func (m *InstrumentedState) RunSteps(n uint64) error {
+	m.preimageOracle.Reset()
+	for i := uint64(0); i < n && !m.state.Exited; {
+		if m.canRunBlocks() {
+			// blocks don't cross the context switches of the scheduler
+			budget := min(n-i, exec.SchedQuantum-m.state.StepsSinceLastContextSwitch)
+			thread := m.state.GetCurrentThread()
+			if steps := m.blocks.RunBlock(&thread.Cpu, &thread.Registers, m.state.getFpuRef(), budget); steps > 0 {
+				m.state.Step += steps
+				m.state.StepsSinceLastContextSwitch += steps
+				i += steps
+				continue
+			}
+		}
+		if _, err := m.Step(false); err != nil {
+			return err
+		}
+		i++
+		if _, _, offset := m.preimageOracle.LastPreimage(); offset != ^uint32(0) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// canRunBlocks returns whether RunSteps can execute blocks: nothing observes the individual steps,
+// and the next step executes an instruction of the current thread, instead of scheduling threads.
+func (m *InstrumentedState) canRunBlocks() bool {
+	if m.blocks == nil || m.tracer != nil || m.strict {
+		return false
+	}
+	if _, noop := m.stackTracker.(*NoopThreadedStackTracker); !noop {
+		return false
+	}
+	thread := m.state.GetCurrentThread()
+	return m.state.Wakeup == exec.FutexEmptyAddr && !thread.Exited && thread.FutexAddr == exec.FutexEmptyAddr &&
+		m.state.StepsSinceLastContextSwitch < exec.SchedQuantum
+}
+
 func (m *InstrumentedState) Traceback() {
 	m.stackTracker.Traceback()
 }
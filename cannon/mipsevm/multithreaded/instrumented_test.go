@@ -10,6 +10,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
 )
@@ -23,6 +25,14 @@ func TestInstrumentedState_OpenMips(t *testing.T) {
 	testutil.RunVMTests_OpenMips(t, CreateEmptyState, vmFactory, "clone.bin")
 }
 
+func TestInstrumentedState_FastExecution(t *testing.T) {
+	testutil.RunVMTests_FastExecution(t, CreateEmptyState, vmFactory, "clone.bin")
+}
+
+func TestInstrumentedState_SnapshotRestore(t *testing.T) {
+	testutil.RunVMTests_SnapshotRestore(t, CreateEmptyState, vmFactory, "clone.bin")
+}
+
 func TestInstrumentedState_Hello(t *testing.T) {
 	testutil.RunVMTest_Hello(t, CreateInitialState, vmFactory, false)
 }
@@ -32,7 +42,7 @@ func TestInstrumentedState_Claim(t *testing.T) {
 }
 
 func TestInstrumentedState_MultithreadedProgram(t *testing.T) {
-	state := testutil.LoadELFProgram(t, "../../testdata/example/bin/multithreaded.elf", CreateInitialState, false)
+	state := testutil.LoadELFProgram(t, testutil.ProgramPath("multithreaded"), CreateInitialState, false)
 	oracle := testutil.StaticOracle(t, []byte{})
 
 	var stdOutBuf, stdErrBuf bytes.Buffer
@@ -56,7 +66,7 @@ func TestInstrumentedState_MultithreadedProgram(t *testing.T) {
 func TestInstrumentedState_Alloc(t *testing.T) {
 	t.Skip("TODO(client-pod#906): Currently failing - need to debug.")
 
-	state := testutil.LoadELFProgram(t, "../../testdata/example/bin/alloc.elf", CreateInitialState, false)
+	state := testutil.LoadELFProgram(t, testutil.ProgramPath("alloc"), CreateInitialState, false)
 	const numAllocs = 100 // where each alloc is a 32 MiB chunk
 	oracle := testutil.AllocOracle(t, numAllocs)
 
@@ -77,3 +87,99 @@ func TestInstrumentedState_Alloc(t *testing.T) {
 	require.Equal(t, uint8(0), state.ExitCode, "exit with 0")
 	require.Less(t, state.Memory.PageCount()*memory.PageSize, 1*1024*1024*1024, "must not allocate more than 1 GiB")
 }
+
+func TestInstrumentedState_NetpollSyscalls(t *testing.T) {
+	netpollSyscalls := []Word{exec.SysEpollCreate1, exec.SysEventFd2, exec.SysEpollCtl, exec.SysPipe2, exec.SysEpollPwait}
+	for _, syscallNum := range netpollSyscalls {
+		require.Equal(t, exec.SyscallImplemented, GetSyscallPolicy(LatestVersion).Action(syscallNum), "syscall %d", syscallNum)
+	}
+
+	// Before VersionNetpoll, eventfd2 is unknown, and the other syscalls are ignored
+	require.Equal(t, exec.SyscallAbort, GetSyscallPolicy(VersionLLReservation).Action(exec.SysEventFd2))
+	for _, syscallNum := range []Word{exec.SysEpollCreate1, exec.SysEpollCtl, exec.SysPipe2, exec.SysEpollPwait} {
+		require.Equal(t, exec.SyscallNoop, GetSyscallPolicy(VersionLLReservation).Action(syscallNum), "syscall %d", syscallNum)
+
+		state := CreateEmptyState()
+		state.Version = VersionLLReservation
+		testutil.StoreInstruction(state.Memory, state.GetPC(), 0x00_00_00_0C) // syscall instruction
+		testutil.SetSyscallArgs(state.GetRegistersRef(), syscallNum)
+		us := NewInstrumentedState(state, nil, os.Stdout, os.Stderr, testutil.CreateLogger())
+		_, err := us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, Word(0), state.GetRegistersRef()[arch.RegSyscallRet], "syscall %d result", syscallNum)
+		require.Equal(t, Word(0), state.GetRegistersRef()[arch.RegSyscallErrno], "syscall %d errno", syscallNum)
+	}
+
+	// Neither are the reads and writes of the eventfd handled
+	state := CreateEmptyState()
+	state.Version = VersionLLReservation
+	testutil.StoreInstruction(state.Memory, state.GetPC(), 0x00_00_00_0C) // syscall instruction
+	testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysWrite, exec.FdEventFd, 0x1000, 8)
+	us := NewInstrumentedState(state, nil, os.Stdout, os.Stderr, testutil.CreateLogger())
+	_, err := us.Step(false)
+	require.NoError(t, err)
+	require.Equal(t, Word(exec.MipsEBADF), state.GetRegistersRef()[arch.RegSyscallErrno])
+}
+
+func TestInstrumentedState_BaselineLoadLinked(t *testing.T) {
+	state := CreateEmptyState()
+	state.Version = VersionBaseline
+	testutil.StoreInstruction(state.Memory, 0, 0xC0_82_00_00) // ll $2, 0($4)
+	testutil.StoreInstruction(state.Memory, 4, 0xE0_85_00_00) // sc $5, 0($4)
+	testutil.StoreInstruction(state.Memory, 8, 0xE0_85_00_00) // sc $5, 0($4)
+	testutil.SetMemoryUint32(state.Memory, 0x1000, 0x0abbccdd)
+	state.GetRegistersRef()[4] = 0x1000
+	state.GetRegistersRef()[5] = 0x11223344
+
+	// before the ll/sc reservation, ll and sc are a plain load and store: sc always succeeds
+	vm := NewInstrumentedState(state, nil, io.Discard, io.Discard, testutil.CreateLogger())
+	for i := 0; i < 3; i++ {
+		_, err := vm.Step(true)
+		require.NoError(t, err)
+		require.False(t, state.LLReservationActive)
+	}
+	require.Equal(t, Word(0x0abbccdd), state.GetRegistersRef()[2])
+	require.Equal(t, Word(1), state.GetRegistersRef()[5])
+	require.Equal(t, uint32(1), state.Memory.GetUint32(0x1000))
+	witness, _ := state.EncodeWitness()
+	require.Len(t, witness, BASELINE_STATE_WITNESS_SIZE)
+}
+
+func TestInstrumentedState_BaselineFpuInstructions(t *testing.T) {
+	// Before VersionFPU, the floating-point instructions are illegal, also when executed in blocks
+	for _, insn := range []uint32{0x44_82_00_00, 0xC4_80_00_00} { // mtc1 $2, $f0; lwc1 $f0, 0($4)
+		for _, fast := range []bool{false, true} {
+			state := CreateEmptyState()
+			state.Version = VersionNetpoll
+			testutil.StoreInstruction(state.Memory, 0, insn)
+			_, preHash := state.EncodeWitness()
+
+			vm := NewInstrumentedState(state, nil, io.Discard, io.Discard, testutil.CreateLogger())
+			vm.SetFastExecution(fast)
+			err := vm.RunSteps(1)
+			require.ErrorIs(t, err, mipsevm.ErrIllegalInstruction, "insn %08x", insn)
+			_, postHash := state.EncodeWitness()
+			require.Equal(t, preHash, postHash)
+		}
+	}
+}
+
+func TestInstrumentedState_FaultLeavesStateUnchanged(t *testing.T) {
+	state := CreateEmptyState()
+	// a branch in the delay slot of a jump to 8
+	state.GetCurrentThread().Cpu.NextPC = 8
+	testutil.StoreInstruction(state.Memory, 0, 0x11_02_00_03)
+	state.Step = 10
+	state.StepsSinceLastContextSwitch = 5
+	_, preHash := state.EncodeWitness()
+
+	vm := NewInstrumentedState(state, nil, io.Discard, io.Discard, testutil.CreateLogger())
+	for i := 0; i < 2; i++ {
+		_, err := vm.Step(true)
+		require.ErrorIs(t, err, mipsevm.ErrBranchInDelaySlot)
+		_, postHash := state.EncodeWitness()
+		require.Equal(t, preHash, postHash, "faulting step must not change the state")
+		require.Equal(t, uint64(10), state.Step)
+		require.Equal(t, uint64(5), state.StepsSinceLastContextSwitch)
+	}
+}
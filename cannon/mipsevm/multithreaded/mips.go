@@ -9,21 +9,63 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 )
 
+var implementedSyscalls = []exec.Word{exec.SysMmap, exec.SysBrk, exec.SysClone, exec.SysExitGroup, exec.SysRead, exec.SysWrite,
+	exec.SysFcntl, exec.SysGetTID, exec.SysExit, exec.SysFutex, exec.SysSchedYield, exec.SysNanosleep, exec.SysOpen}
+
+var noopSyscalls = []exec.Word{
+	exec.SysMunmap, exec.SysGetAffinity, exec.SysMadvise, exec.SysRtSigprocmask, exec.SysSigaltstack,
+	exec.SysRtSigaction, exec.SysPrlimit64, exec.SysClose, exec.SysPread64, exec.SysFstat, exec.SysFstat64,
+	exec.SysOpenAt, exec.SysReadlink, exec.SysReadlinkAt, exec.SysIoctl, exec.SysGetRandom, exec.SysUname,
+	exec.SysStat64, exec.SysGetuid, exec.SysGetgid, exec.SysLlseek, exec.SysLseek, exec.SysGetRLimit, exec.SysMinCore,
+	exec.SysTgkill, exec.SysSetITimer, exec.SysTimerCreate, exec.SysTimerSetTime, exec.SysTimerDelete,
+	exec.SysClockGetTime,
+}
+
+// latestSyscallPolicy matches the syscall handling of MIPS2.sol, which reverts on any syscall it does not know.
+var latestSyscallPolicy = exec.NewSyscallPolicy(exec.SyscallAbort, map[exec.SyscallAction][]exec.Word{
+	exec.SyscallImplemented: append([]exec.Word{
+		exec.SysEpollCreate1, exec.SysPipe2, exec.SysEpollCtl, exec.SysEpollPwait, exec.SysEventFd2}, implementedSyscalls...),
+	exec.SyscallNoop: noopSyscalls,
+})
+
+// baselineSyscallPolicy is the syscall handling before VersionNetpoll, which ignores the epoll syscalls and pipe2,
+// and doesn't know eventfd2.
+var baselineSyscallPolicy = exec.NewSyscallPolicy(exec.SyscallAbort, map[exec.SyscallAction][]exec.Word{
+	exec.SyscallImplemented: implementedSyscalls,
+	exec.SyscallNoop: append([]exec.Word{
+		exec.SysEpollCreate1, exec.SysPipe2, exec.SysEpollCtl, exec.SysEpollPwait}, noopSyscalls...),
+})
+
+// GetSyscallPolicy returns the way the VM handles each syscall number, for states of the given version.
+func GetSyscallPolicy(version mipsevm.StateVersion) *exec.SyscallPolicy {
+	if version < VersionNetpoll {
+		return baselineSyscallPolicy
+	}
+	return latestSyscallPolicy
+}
+
 func (m *InstrumentedState) handleSyscall() error {
 	thread := m.state.GetCurrentThread()
 
 	syscallNum, a0, a1, a2, a3 := exec.GetSyscallArgs(m.state.GetRegistersRef())
-	v0 := uint32(0)
-	v1 := uint32(0)
+	if m.state.hasNetpoll() && exec.IsNetpollSyscall(syscallNum, a0) {
+		v0, v1 := exec.HandleNetpollSyscall(syscallNum, a0, a1)
+		exec.HandleSyscallUpdates(&thread.Cpu, &thread.Registers, v0, v1)
+		return nil
+	}
+
+	v0 := Word(0)
+	v1 := Word(0)
 
 	//fmt.Printf("syscall: %d\n", syscallNum)
 	switch syscallNum {
 	case exec.SysMmap:
-		var newHeap uint32
+		var newHeap Word
 		v0, v1, newHeap = exec.HandleSysMmap(a0, a1, m.state.Heap)
 		m.state.Heap = newHeap
 	case exec.SysBrk:
@@ -52,12 +94,14 @@ func (m *InstrumentedState) handleSyscall() error {
 				LO:     thread.Cpu.LO,
 			},
 			Registers: thread.Registers,
+			// the child inherits the floating-point context of the parent
+			Fpu: thread.Fpu,
 		}
 
-		newThread.Registers[29] = a1
+		newThread.Registers[arch.RegSP] = a1
 		// the child will perceive a 0 value as returned value instead, and no error
-		newThread.Registers[2] = 0
-		newThread.Registers[7] = 0
+		newThread.Registers[arch.RegSyscallRet] = 0
+		newThread.Registers[arch.RegSyscallErrno] = 0
 		m.state.NextThreadId++
 
 		// Preempt this thread for the new one. But not before updating PCs
@@ -77,6 +121,10 @@ func (m *InstrumentedState) handleSyscall() error {
 		var newPreimageOffset uint32
 		v0, v1, newPreimageOffset = exec.HandleSysRead(a0, a1, a2, m.state.PreimageKey, m.state.PreimageOffset, m.preimageOracle, m.state.Memory, m.memoryTracker)
 		m.state.PreimageOffset = newPreimageOffset
+		// A pre-image read writes to memory, which breaks any reservation of the same address
+		if a0 == exec.FdPreimageRead {
+			m.handleMemoryUpdate(a1 & arch.AddressMask)
+		}
 	case exec.SysWrite:
 		var newLastHint hexutil.Bytes
 		var newPreimageKey common.Hash
@@ -103,13 +151,13 @@ func (m *InstrumentedState) handleSyscall() error {
 		switch a1 {
 		case exec.FutexWaitPrivate:
 			thread.FutexAddr = a0
-			m.memoryTracker.TrackMemAccess(a0)
-			mem := m.state.Memory.GetMemory(a0)
-			if mem != a2 {
+			// the futex value is a 32-bit word, also in the 64-bit VM
+			mem := m.getFutexValue(a0)
+			if mem != uint32(a2) {
 				v0 = exec.SysErrorSignal
 				v1 = exec.MipsEAGAIN
 			} else {
-				thread.FutexVal = a2
+				thread.FutexVal = uint32(a2)
 				if a3 == 0 {
 					thread.FutexTimeoutStep = exec.FutexNoTimeout
 				} else {
@@ -143,50 +191,32 @@ func (m *InstrumentedState) handleSyscall() error {
 	case exec.SysOpen:
 		v0 = exec.SysErrorSignal
 		v1 = exec.MipsEBADF
-	case exec.SysMunmap:
-	case exec.SysGetAffinity:
-	case exec.SysMadvise:
-	case exec.SysRtSigprocmask:
-	case exec.SysSigaltstack:
-	case exec.SysRtSigaction:
-	case exec.SysPrlimit64:
-	case exec.SysClose:
-	case exec.SysPread64:
-	case exec.SysFstat64:
-	case exec.SysOpenAt:
-	case exec.SysReadlink:
-	case exec.SysReadlinkAt:
-	case exec.SysIoctl:
-	case exec.SysEpollCreate1:
-	case exec.SysPipe2:
-	case exec.SysEpollCtl:
-	case exec.SysEpollPwait:
-	case exec.SysGetRandom:
-	case exec.SysUname:
-	case exec.SysStat64:
-	case exec.SysGetuid:
-	case exec.SysGetgid:
-	case exec.SysLlseek:
-	case exec.SysMinCore:
-	case exec.SysTgkill:
-	case exec.SysSetITimer:
-	case exec.SysTimerCreate:
-	case exec.SysTimerSetTime:
-	case exec.SysTimerDelete:
-	case exec.SysClockGetTime:
 	default:
-		m.Traceback()
-		panic(fmt.Sprintf("unrecognized syscall: %d", syscallNum))
+		if err := m.unknownSyscalls.Check(syscallNum, thread.Cpu.PC); err != nil {
+			return err
+		}
+		var ok bool
+		if v0, v1, ok = m.syscallPolicy.HandleUnimplemented(syscallNum); !ok {
+			m.Traceback()
+			panic(fmt.Sprintf("unrecognized syscall: %d", syscallNum))
+		}
 	}
 
 	exec.HandleSyscallUpdates(&thread.Cpu, &thread.Registers, v0, v1)
 	return nil
 }
 
-func (m *InstrumentedState) mipsStep() error {
+func (m *InstrumentedState) mipsStep() (err error) {
 	if m.state.Exited {
 		return nil
 	}
+	// A faulting step is not completed: instructions fault before changing the state, and the step isn't counted.
+	step, stepsSinceLastContextSwitch := m.state.Step, m.state.StepsSinceLastContextSwitch
+	defer func() {
+		if err != nil {
+			m.state.Step, m.state.StepsSinceLastContextSwitch = step, stepsSinceLastContextSwitch
+		}
+	}()
 	m.state.Step += 1
 	thread := m.state.GetCurrentThread()
 
@@ -225,8 +255,7 @@ func (m *InstrumentedState) mipsStep() error {
 			m.onWaitComplete(thread, true)
 			return nil
 		} else {
-			m.memoryTracker.TrackMemAccess(thread.FutexAddr)
-			mem := m.state.Memory.GetMemory(thread.FutexAddr)
+			mem := m.getFutexValue(thread.FutexAddr)
 			if thread.FutexVal == mem {
 				// still got expected value, continue sleeping, try next thread.
 				m.preemptThread(thread)
@@ -256,15 +285,120 @@ func (m *InstrumentedState) mipsStep() error {
 
 	//instruction fetch
 	insn, opcode, fun := exec.GetInstructionDetails(m.state.GetPC(), m.state.Memory)
+	if m.tracer != nil {
+		m.tracer.OnInstruction(m.state, m.state.GetPC(), insn)
+	}
 
 	// Handle syscall separately
 	// syscall (can read and write)
 	if opcode == 0 && fun == 0xC {
-		return m.handleSyscall()
+		return m.syscallStats.Track(m.state.GetRegistersRef(), m.handleSyscall)
+	}
+
+	if m.strict {
+		if err := exec.CheckAlignment(m.state.GetPC(), insn, opcode, m.state.GetRegistersRef()); err != nil {
+			return err
+		}
+	}
+
+	// Handle the read-modify-write ops separately.
+	// Before VersionLLReservation, they are a plain load and store, executed with the rest of the step logic.
+	if m.state.hasLLReservation() && isRMWOp(opcode) {
+		return m.handleRMWOps(insn, opcode)
 	}
 
 	// Exec the rest of the step logic
-	return exec.ExecMipsCoreStepLogic(m.state.getCpuRef(), m.state.GetRegistersRef(), m.state.Memory, insn, opcode, fun, m.memoryTracker, m.stackTracker)
+	if err := exec.ExecMipsCoreStepLogic(m.state.getCpuRef(), m.state.GetRegistersRef(), m.state.getFpuRef(), m.state.Memory, insn, opcode, fun, m.memoryTracker, m.stackTracker); err != nil {
+		return err
+	}
+	// stores don't change the registers the address is computed from
+	if exec.IsStore(opcode) {
+		m.handleStore(insn, opcode)
+	}
+	return nil
+}
+
+// isInterpreted returns whether the instruction must be executed by mipsStep, instead of translated to a block:
+// the syscalls, and the read-modify-write ops.
+func isInterpreted(insn, opcode, fun uint32) bool {
+	if opcode == 0 && fun == 0xC {
+		return true
+	}
+	return isRMWOp(opcode)
+}
+
+// isRMWOp returns whether the opcode is one of the read-modify-write ops, ll and sc, or lld and scd in 64-bit.
+func isRMWOp(opcode uint32) bool {
+	if opcode == exec.OpLoadLinked || opcode == exec.OpStoreConditional {
+		return true
+	}
+	return !arch.IsMips32 && (opcode == exec.OpLoadLinked64 || opcode == exec.OpStoreConditional64)
+}
+
+// handleStore breaks the ll/sc reservation if the store insn writes to the reserved address.
+func (m *InstrumentedState) handleStore(insn, opcode uint32) {
+	addr := exec.EffectiveAddress(insn, m.state.GetRegistersRef())
+	if arch.IsMips32 && opcode == exec.OpStoreDoubleCop1 {
+		// sdc1 writes both words of the 8-byte aligned double word
+		m.handleMemoryUpdate(addr &^ 7)
+		m.handleMemoryUpdate(addr&^7 + 4)
+	} else {
+		m.handleMemoryUpdate(addr)
+	}
+}
+
+// handleRMWOps handles the load-linked and store-conditional instructions.
+// The store-conditional only succeeds if the reservation of the load-linked is still held by the thread,
+// i.e. if no other thread has stored to the address, or load-linked any address, since.
+func (m *InstrumentedState) handleRMWOps(insn, opcode uint32) error {
+	thread := m.state.GetCurrentThread()
+	rtReg := (insn >> 16) & 0x1F
+	base := thread.Registers[(insn>>21)&0x1F]
+	effAddr := base + exec.SignExtend(Word(insn&0xFFFF), 16)
+	addr := effAddr & arch.AddressMask
+	m.memoryTracker.TrackMemAccess(addr)
+	mem := m.state.Memory.GetMemory(addr)
+
+	// ll and sc access a 32-bit word of the memory word, lld and scd the whole 64-bit memory word
+	byteLength := Word(arch.WordSizeBytes)
+	if opcode == exec.OpLoadLinked || opcode == exec.OpStoreConditional {
+		byteLength = 4
+	}
+
+	retVal := Word(0)
+	if opcode == exec.OpLoadLinked || opcode == exec.OpLoadLinked64 {
+		retVal = exec.SelectSubWord(effAddr, mem, byteLength, true)
+		m.state.LLReservationActive = true
+		m.state.LLAddress = addr
+		m.state.LLOwnerThread = thread.ThreadId
+	} else if m.state.LLReservationActive && m.state.LLOwnerThread == thread.ThreadId && m.state.LLAddress == addr {
+		// The reservation is intact: complete the atomic update, and return 1 for success
+		m.clearLLMemoryReservation()
+		m.state.Memory.SetMemory(addr, exec.UpdateSubWord(effAddr, mem, byteLength, thread.Registers[rtReg]))
+		retVal = 1
+	}
+	// else the atomic update failed, and 0 is returned
+
+	return exec.HandleRd(&thread.Cpu, &thread.Registers, rtReg, retVal, true)
+}
+
+// getFutexValue returns the 32-bit futex word at the given 4-byte aligned address.
+func (m *InstrumentedState) getFutexValue(addr Word) uint32 {
+	m.memoryTracker.TrackMemAccess(addr & arch.AddressMask)
+	return m.state.Memory.GetUint32(addr)
+}
+
+// handleMemoryUpdate breaks the ll/sc reservation if the memory at the reserved address is updated.
+func (m *InstrumentedState) handleMemoryUpdate(memAddr Word) {
+	if m.state.hasLLReservation() && memAddr == m.state.LLAddress {
+		m.clearLLMemoryReservation()
+	}
+}
+
+func (m *InstrumentedState) clearLLMemoryReservation() {
+	m.state.LLReservationActive = false
+	m.state.LLAddress = 0
+	m.state.LLOwnerThread = 0
 }
 
 func (m *InstrumentedState) onWaitComplete(thread *ThreadState, isTimedOut bool) {
@@ -274,8 +408,8 @@ func (m *InstrumentedState) onWaitComplete(thread *ThreadState, isTimedOut bool)
 	thread.FutexTimeoutStep = 0
 
 	// Complete the FUTEX_WAIT syscall
-	v0 := uint32(0)
-	v1 := uint32(0)
+	v0 := Word(0)
+	v1 := Word(0)
 	if isTimedOut {
 		v0 = exec.SysErrorSignal
 		v1 = exec.MipsETIMEDOUT
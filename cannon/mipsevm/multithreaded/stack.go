@@ -9,7 +9,7 @@ import (
 
 type ThreadedStackTracker interface {
 	exec.TraceableStackTracker
-	DropThread(threadId uint32)
+	DropThread(threadId Word)
 }
 
 type NoopThreadedStackTracker struct {
@@ -18,12 +18,12 @@ type NoopThreadedStackTracker struct {
 
 var _ ThreadedStackTracker = (*ThreadedStackTrackerImpl)(nil)
 
-func (n *NoopThreadedStackTracker) DropThread(threadId uint32) {}
+func (n *NoopThreadedStackTracker) DropThread(threadId Word) {}
 
 type ThreadedStackTrackerImpl struct {
 	meta               *program.Metadata
 	state              *State
-	trackersByThreadId map[uint32]exec.TraceableStackTracker
+	trackersByThreadId map[Word]exec.TraceableStackTracker
 }
 
 var _ ThreadedStackTracker = (*ThreadedStackTrackerImpl)(nil)
@@ -36,11 +36,11 @@ func NewThreadedStackTracker(state *State, meta *program.Metadata) (*ThreadedSta
 	return &ThreadedStackTrackerImpl{
 		state:              state,
 		meta:               meta,
-		trackersByThreadId: make(map[uint32]exec.TraceableStackTracker),
+		trackersByThreadId: make(map[Word]exec.TraceableStackTracker),
 	}, nil
 }
 
-func (t *ThreadedStackTrackerImpl) PushStack(caller uint32, target uint32) {
+func (t *ThreadedStackTrackerImpl) PushStack(caller Word, target Word) {
 	t.getCurrentTracker().PushStack(caller, target)
 }
 
@@ -62,6 +62,6 @@ func (t *ThreadedStackTrackerImpl) getCurrentTracker() exec.TraceableStackTracke
 	return tracker
 }
 
-func (t *ThreadedStackTrackerImpl) DropThread(threadId uint32) {
+func (t *ThreadedStackTrackerImpl) DropThread(threadId Word) {
 	delete(t.trackersByThreadId, threadId)
 }
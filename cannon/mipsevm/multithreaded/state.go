@@ -3,53 +3,90 @@ package multithreaded
 import (
 	"encoding/binary"
 	"fmt"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 )
 
+// The versions of the multithreaded VM state.
+const (
+	// VersionBaseline is the original layout, without the ll/sc reservation: ll and sc are a plain load and store.
+	VersionBaseline mipsevm.StateVersion = iota
+	// VersionLLReservation adds the ll/sc memory reservation to the state witness.
+	VersionLLReservation
+	// VersionNetpoll adds the file descriptors of the Go runtime network poller, see exec.HandleNetpollSyscall.
+	// Before, eventfd2 is not supported, and the epoll syscalls and pipe2 are ignored.
+	VersionNetpoll
+	// VersionFPU adds the floating-point coprocessor, and its registers to the serialized threads.
+	// Before, the floating-point instructions are illegal.
+	VersionFPU
+
+	// LatestVersion is the version of newly created states, and the STATE_VERSION implemented by MIPS2.sol.
+	LatestVersion = VersionFPU
+)
+
 // STATE_WITNESS_SIZE is the size of the state witness encoding in bytes.
-const STATE_WITNESS_SIZE = 163
+const STATE_WITNESS_SIZE = THREAD_ID_WITNESS_OFFSET + arch.WordSizeBytes
+
+// The layout of the state witness before VersionLLReservation, which doesn't encode the reservation fields.
+const (
+	llReservationWitnessSize = EXITCODE_WITNESS_OFFSET - LL_RESERVATION_ACTIVE_OFFSET
+
+	BASELINE_STATE_WITNESS_SIZE      = STATE_WITNESS_SIZE - llReservationWitnessSize
+	BASELINE_EXITCODE_WITNESS_OFFSET = LL_RESERVATION_ACTIVE_OFFSET
+	BASELINE_EXITED_WITNESS_OFFSET   = BASELINE_EXITCODE_WITNESS_OFFSET + 1
+)
 const (
 	MEMROOT_WITNESS_OFFSET                    = 0
 	PREIMAGE_KEY_WITNESS_OFFSET               = MEMROOT_WITNESS_OFFSET + 32
 	PREIMAGE_OFFSET_WITNESS_OFFSET            = PREIMAGE_KEY_WITNESS_OFFSET + 32
 	HEAP_WITNESS_OFFSET                       = PREIMAGE_OFFSET_WITNESS_OFFSET + 4
-	EXITCODE_WITNESS_OFFSET                   = HEAP_WITNESS_OFFSET + 4
+	LL_RESERVATION_ACTIVE_OFFSET              = HEAP_WITNESS_OFFSET + arch.WordSizeBytes
+	LL_ADDRESS_OFFSET                         = LL_RESERVATION_ACTIVE_OFFSET + 1
+	LL_OWNER_THREAD_OFFSET                    = LL_ADDRESS_OFFSET + arch.WordSizeBytes
+	EXITCODE_WITNESS_OFFSET                   = LL_OWNER_THREAD_OFFSET + arch.WordSizeBytes
 	EXITED_WITNESS_OFFSET                     = EXITCODE_WITNESS_OFFSET + 1
 	STEP_WITNESS_OFFSET                       = EXITED_WITNESS_OFFSET + 1
 	STEPS_SINCE_CONTEXT_SWITCH_WITNESS_OFFSET = STEP_WITNESS_OFFSET + 8
 	WAKEUP_WITNESS_OFFSET                     = STEPS_SINCE_CONTEXT_SWITCH_WITNESS_OFFSET + 8
-	TRAVERSE_RIGHT_WITNESS_OFFSET             = WAKEUP_WITNESS_OFFSET + 4
+	TRAVERSE_RIGHT_WITNESS_OFFSET             = WAKEUP_WITNESS_OFFSET + arch.WordSizeBytes
 	LEFT_THREADS_ROOT_WITNESS_OFFSET          = TRAVERSE_RIGHT_WITNESS_OFFSET + 1
 	RIGHT_THREADS_ROOT_WITNESS_OFFSET         = LEFT_THREADS_ROOT_WITNESS_OFFSET + 32
 	THREAD_ID_WITNESS_OFFSET                  = RIGHT_THREADS_ROOT_WITNESS_OFFSET + 32
 )
 
 type State struct {
+	// Version of the state, see LatestVersion. States serialized without a version are VersionBaseline states.
+	Version mipsevm.StateVersion `json:"version"`
+
 	Memory *memory.Memory `json:"memory"`
 
 	PreimageKey    common.Hash `json:"preimageKey"`
 	PreimageOffset uint32      `json:"preimageOffset"` // note that the offset includes the 8-byte length prefix
 
-	Heap uint32 `json:"heap"` // to handle mmap growth
+	Heap Word `json:"heap"` // to handle mmap growth
+
+	LLReservationActive bool `json:"llReservationActive"` // Whether there is an active memory reservation of a ll/sc pair
+	LLAddress           Word `json:"llAddress"`           // The "linked" memory address reserved by the ll instruction
+	LLOwnerThread       Word `json:"llOwnerThread"`       // The id of the thread that holds the reservation
 
 	ExitCode uint8 `json:"exit"`
 	Exited   bool  `json:"exited"`
 
 	Step                        uint64 `json:"step"`
 	StepsSinceLastContextSwitch uint64 `json:"stepsSinceLastContextSwitch"`
-	Wakeup                      uint32 `json:"wakeup"`
+	Wakeup                      Word   `json:"wakeup"`
 
 	TraverseRight    bool           `json:"traverseRight"`
 	LeftThreadStack  []*ThreadState `json:"leftThreadStack"`
 	RightThreadStack []*ThreadState `json:"rightThreadStack"`
-	NextThreadId     uint32         `json:"nextThreadId"`
+	NextThreadId     Word           `json:"nextThreadId"`
 
 	// LastHint is optional metadata, and not part of the VM state itself.
 	LastHint hexutil.Bytes `json:"lastHint,omitempty"`
@@ -61,6 +98,7 @@ func CreateEmptyState() *State {
 	initThread := CreateEmptyThread()
 
 	return &State{
+		Version:          LatestVersion,
 		Memory:           memory.NewMemory(),
 		Heap:             0,
 		ExitCode:         0,
@@ -74,7 +112,7 @@ func CreateEmptyState() *State {
 	}
 }
 
-func CreateInitialState(pc, heapStart uint32) *State {
+func CreateInitialState(pc, heapStart Word) *State {
 	state := CreateEmptyState()
 	currentThread := state.GetCurrentThread()
 	currentThread.Cpu.PC = pc
@@ -117,13 +155,61 @@ func (s *State) getLeftThreadStackRoot() common.Hash {
 func (s *State) calculateThreadStackRoot(stack []*ThreadState) common.Hash {
 	curRoot := EmptyThreadsRoot
 	for _, thread := range stack {
-		curRoot = computeThreadRoot(curRoot, thread)
+		curRoot = computeThreadRoot(curRoot, thread, s.hasFPU())
 	}
 
 	return curRoot
 }
 
-func (s *State) GetPC() uint32 {
+func (s *State) Snapshot() mipsevm.FPVMState {
+	snapshot := *s
+	snapshot.Memory = s.Memory.Copy()
+	snapshot.LeftThreadStack = copyThreads(s.LeftThreadStack)
+	snapshot.RightThreadStack = copyThreads(s.RightThreadStack)
+	snapshot.LastHint = slices.Clone(s.LastHint)
+	return &snapshot
+}
+
+func (s *State) Restore(snapshot mipsevm.FPVMState) error {
+	src, ok := snapshot.(*State)
+	if !ok {
+		return fmt.Errorf("cannot restore %T snapshot to %T state", snapshot, s)
+	}
+	mem := s.Memory
+	*s = *src
+	s.Memory = mem
+	s.Memory.CopyFrom(src.Memory)
+	s.LeftThreadStack = copyThreads(src.LeftThreadStack)
+	s.RightThreadStack = copyThreads(src.RightThreadStack)
+	s.LastHint = slices.Clone(src.LastHint)
+	return nil
+}
+
+func copyThreads(threads []*ThreadState) []*ThreadState {
+	out := make([]*ThreadState, len(threads))
+	for i, thread := range threads {
+		cp := *thread
+		out[i] = &cp
+	}
+	return out
+}
+
+// hasLLReservation returns whether ll and sc reserve the memory address, instead of being a plain load and store.
+func (s *State) hasLLReservation() bool {
+	return s.Version >= VersionLLReservation
+}
+
+// hasNetpoll returns whether the VM implements the file descriptors of the Go runtime network poller.
+func (s *State) hasNetpoll() bool {
+	return s.Version >= VersionNetpoll
+}
+
+// hasFPU returns whether the VM has the floating-point coprocessor.
+func (s *State) hasFPU() bool {
+	return s.Version >= VersionFPU
+}
+
+func (s *State) GetPC() Word {
 	activeThread := s.GetCurrentThread()
 	return activeThread.Cpu.PC
 }
@@ -137,11 +223,24 @@ func (s *State) getCpuRef() *mipsevm.CpuScalars {
 	return &s.GetCurrentThread().Cpu
 }
 
-func (s *State) GetRegistersRef() *[32]uint32 {
+func (s *State) GetRegistersRef() *[32]Word {
 	activeThread := s.GetCurrentThread()
 	return &activeThread.Registers
 }
 
+func (s *State) GetFpuRef() *mipsevm.FpuState {
+	activeThread := s.GetCurrentThread()
+	return &activeThread.Fpu
+}
+
+// getFpuRef returns the floating-point registers of the current thread, or nil if the VM has no FPU.
+func (s *State) getFpuRef() *mipsevm.FpuState {
+	if !s.hasFPU() {
+		return nil
+	}
+	return s.GetFpuRef()
+}
+
 func (s *State) GetExitCode() uint8 { return s.ExitCode }
 
 func (s *State) GetExited() bool { return s.Exited }
@@ -160,7 +259,7 @@ func (s *State) GetMemory() *memory.Memory {
 	return s.Memory
 }
 
-func (s *State) GetHeap() uint32 {
+func (s *State) GetHeap() Word {
 	return s.Heap
 }
 
@@ -178,20 +277,25 @@ func (s *State) EncodeWitness() ([]byte, common.Hash) {
 	out = append(out, memRoot[:]...)
 	out = append(out, s.PreimageKey[:]...)
 	out = binary.BigEndian.AppendUint32(out, s.PreimageOffset)
-	out = binary.BigEndian.AppendUint32(out, s.Heap)
+	out = arch.ByteOrderWord.AppendWord(out, s.Heap)
+	if s.hasLLReservation() {
+		out = mipsevm.AppendBoolToWitness(out, s.LLReservationActive)
+		out = arch.ByteOrderWord.AppendWord(out, s.LLAddress)
+		out = arch.ByteOrderWord.AppendWord(out, s.LLOwnerThread)
+	}
 	out = append(out, s.ExitCode)
 	out = mipsevm.AppendBoolToWitness(out, s.Exited)
 
 	out = binary.BigEndian.AppendUint64(out, s.Step)
 	out = binary.BigEndian.AppendUint64(out, s.StepsSinceLastContextSwitch)
-	out = binary.BigEndian.AppendUint32(out, s.Wakeup)
+	out = arch.ByteOrderWord.AppendWord(out, s.Wakeup)
 
 	leftStackRoot := s.getLeftThreadStackRoot()
 	rightStackRoot := s.getRightThreadStackRoot()
 	out = mipsevm.AppendBoolToWitness(out, s.TraverseRight)
 	out = append(out, (leftStackRoot)[:]...)
 	out = append(out, (rightStackRoot)[:]...)
-	out = binary.BigEndian.AppendUint32(out, s.NextThreadId)
+	out = arch.ByteOrderWord.AppendWord(out, s.NextThreadId)
 
 	return out, stateHashFromWitness(out)
 }
@@ -205,7 +309,7 @@ func (s *State) EncodeThreadProof() []byte {
 
 	activeThread := activeStack[threadCount-1]
 	otherThreads := activeStack[:threadCount-1]
-	threadBytes := activeThread.serializeThread()
+	threadBytes := activeThread.serializeThread(s.hasFPU())
 	otherThreadsWitness := s.calculateThreadStackRoot(otherThreads)
 
 	out := make([]byte, 0, THREAD_WITNESS_SIZE)
@@ -219,28 +323,47 @@ func (s *State) threadCount() int {
 	return len(s.LeftThreadStack) + len(s.RightThreadStack)
 }
 
+// StateWitness is the witness of a state of any version: the layout is identified by the witness length.
 type StateWitness []byte
 
 func (sw StateWitness) StateHash() (common.Hash, error) {
-	if len(sw) != STATE_WITNESS_SIZE {
-		return common.Hash{}, fmt.Errorf("Invalid witness length. Got %d, expected %d", len(sw), STATE_WITNESS_SIZE)
+	return sw.StateHashWithBackend(mipsevm.KeccakHash)
+}
+
+// StateHashWithBackend commits to the witness with the given hash backend instead of keccak.
+func (sw StateWitness) StateHashWithBackend(backend mipsevm.HashBackend) (common.Hash, error) {
+	if len(sw) != STATE_WITNESS_SIZE && len(sw) != BASELINE_STATE_WITNESS_SIZE {
+		return common.Hash{}, fmt.Errorf("Invalid witness length. Got %d, expected %d or %d", len(sw), STATE_WITNESS_SIZE, BASELINE_STATE_WITNESS_SIZE)
 	}
-	return stateHashFromWitness(sw), nil
+	return stateHashFromWitnessWithBackend(sw, backend), nil
 }
 
 func GetStateHashFn() mipsevm.HashFn {
+	return GetStateHashFnWithBackend(mipsevm.KeccakHash)
+}
+
+// GetStateHashFnWithBackend returns a HashFn that commits to state witnesses with the given hash backend.
+func GetStateHashFnWithBackend(backend mipsevm.HashBackend) mipsevm.HashFn {
 	return func(sw []byte) (common.Hash, error) {
-		return StateWitness(sw).StateHash()
+		return StateWitness(sw).StateHashWithBackend(backend)
 	}
 }
 
 func stateHashFromWitness(sw []byte) common.Hash {
-	if len(sw) != STATE_WITNESS_SIZE {
+	return stateHashFromWitnessWithBackend(sw, mipsevm.KeccakHash)
+}
+
+func stateHashFromWitnessWithBackend(sw []byte, backend mipsevm.HashBackend) common.Hash {
+	var exitCode, exited byte
+	switch len(sw) {
+	case STATE_WITNESS_SIZE:
+		exitCode, exited = sw[EXITCODE_WITNESS_OFFSET], sw[EXITED_WITNESS_OFFSET]
+	case BASELINE_STATE_WITNESS_SIZE:
+		exitCode, exited = sw[BASELINE_EXITCODE_WITNESS_OFFSET], sw[BASELINE_EXITED_WITNESS_OFFSET]
+	default:
 		panic("Invalid witness length")
 	}
-	hash := crypto.Keccak256Hash(sw)
-	exitCode := sw[EXITCODE_WITNESS_OFFSET]
-	exited := sw[EXITED_WITNESS_OFFSET]
+	hash := backend(sw)
 	status := mipsevm.VmStatus(exited == 1, exitCode)
 	hash[0] = status
 	return hash
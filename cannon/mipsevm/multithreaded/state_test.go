@@ -3,6 +3,7 @@ package multithreaded
 import (
 	"debug/elf"
 	"encoding/json"
+	"slices"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -10,8 +11,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
 )
 
 func setWitnessField(witness StateWitness, fieldOffset int, fieldData []byte) {
@@ -20,6 +23,12 @@ func setWitnessField(witness StateWitness, fieldOffset int, fieldData []byte) {
 	copy(witness[start:end], fieldData)
 }
 
+func wordBytes(v Word) []byte {
+	var out [arch.WordSizeBytes]byte
+	arch.ByteOrderWord.PutWord(out[:], v)
+	return out[:]
+}
+
 // Run through all permutations of `exited` / `exitCode` and ensure that the
 // correct witness, state hash, and VM Status is produced.
 func TestState_EncodeWitness(t *testing.T) {
@@ -37,7 +46,9 @@ func TestState_EncodeWitness(t *testing.T) {
 		{exited: true, exitCode: 3},
 	}
 
-	heap := uint32(12)
+	heap := Word(12)
+	llAddress := Word(55)
+	llThreadOwner := Word(99)
 	preimageKey := crypto.Keccak256Hash([]byte{1, 2, 3, 4})
 	preimageOffset := uint32(24)
 	step := uint64(33)
@@ -49,6 +60,9 @@ func TestState_EncodeWitness(t *testing.T) {
 		state.PreimageKey = preimageKey
 		state.PreimageOffset = preimageOffset
 		state.Heap = heap
+		state.LLReservationActive = true
+		state.LLAddress = llAddress
+		state.LLOwnerThread = llThreadOwner
 		state.Step = step
 		state.StepsSinceLastContextSwitch = stepsSinceContextSwitch
 
@@ -61,18 +75,21 @@ func TestState_EncodeWitness(t *testing.T) {
 		setWitnessField(expectedWitness, MEMROOT_WITNESS_OFFSET, memRoot[:])
 		setWitnessField(expectedWitness, PREIMAGE_KEY_WITNESS_OFFSET, preimageKey[:])
 		setWitnessField(expectedWitness, PREIMAGE_OFFSET_WITNESS_OFFSET, []byte{0, 0, 0, byte(preimageOffset)})
-		setWitnessField(expectedWitness, HEAP_WITNESS_OFFSET, []byte{0, 0, 0, byte(heap)})
+		setWitnessField(expectedWitness, HEAP_WITNESS_OFFSET, wordBytes(heap))
+		setWitnessField(expectedWitness, LL_RESERVATION_ACTIVE_OFFSET, []byte{1})
+		setWitnessField(expectedWitness, LL_ADDRESS_OFFSET, wordBytes(llAddress))
+		setWitnessField(expectedWitness, LL_OWNER_THREAD_OFFSET, wordBytes(llThreadOwner))
 		setWitnessField(expectedWitness, EXITCODE_WITNESS_OFFSET, []byte{c.exitCode})
 		if c.exited {
 			setWitnessField(expectedWitness, EXITED_WITNESS_OFFSET, []byte{1})
 		}
 		setWitnessField(expectedWitness, STEP_WITNESS_OFFSET, []byte{0, 0, 0, 0, 0, 0, 0, byte(step)})
 		setWitnessField(expectedWitness, STEPS_SINCE_CONTEXT_SWITCH_WITNESS_OFFSET, []byte{0, 0, 0, 0, 0, 0, 0, byte(stepsSinceContextSwitch)})
-		setWitnessField(expectedWitness, WAKEUP_WITNESS_OFFSET, []byte{0xFF, 0xFF, 0xFF, 0xFF})
+		setWitnessField(expectedWitness, WAKEUP_WITNESS_OFFSET, wordBytes(exec.FutexEmptyAddr))
 		setWitnessField(expectedWitness, TRAVERSE_RIGHT_WITNESS_OFFSET, []byte{0})
 		setWitnessField(expectedWitness, LEFT_THREADS_ROOT_WITNESS_OFFSET, leftStackRoot[:])
 		setWitnessField(expectedWitness, RIGHT_THREADS_ROOT_WITNESS_OFFSET, rightStackRoot[:])
-		setWitnessField(expectedWitness, THREAD_ID_WITNESS_OFFSET, []byte{0, 0, 0, 1})
+		setWitnessField(expectedWitness, THREAD_ID_WITNESS_OFFSET, wordBytes(1))
 
 		// Validate witness
 		actualWitness, actualStateHash := state.EncodeWitness()
@@ -85,8 +102,60 @@ func TestState_EncodeWitness(t *testing.T) {
 	}
 }
 
+func TestState_EncodeWitness_Baseline(t *testing.T) {
+	state := CreateEmptyState()
+	state.Version = VersionBaseline
+	state.Heap = 12
+	state.Exited = true
+	state.ExitCode = 3
+	state.Step = 33
+	// the reservation fields are not part of the baseline witness
+	state.LLReservationActive = true
+	state.LLAddress = 55
+
+	// the witness of the last version before VersionFPU, which serializes the threads the same way
+	latest := CreateEmptyState()
+	latest.Version = VersionNetpoll
+	latest.Heap, latest.Exited, latest.ExitCode, latest.Step = state.Heap, state.Exited, state.ExitCode, state.Step
+	latestWitness, _ := latest.EncodeWitness()
+	expectedWitness := append(slices.Clone(latestWitness[:LL_RESERVATION_ACTIVE_OFFSET]), latestWitness[EXITCODE_WITNESS_OFFSET:]...)
+
+	witness, stateHash := state.EncodeWitness()
+	require.Len(t, witness, BASELINE_STATE_WITNESS_SIZE)
+	require.Equal(t, expectedWitness, witness)
+	require.Equal(t, byte(3), witness[BASELINE_EXITCODE_WITNESS_OFFSET])
+	require.Equal(t, byte(1), witness[BASELINE_EXITED_WITNESS_OFFSET])
+	expectedStateHash := crypto.Keccak256Hash(witness)
+	expectedStateHash[0] = mipsevm.VMStatusPanic
+	require.Equal(t, expectedStateHash, stateHash)
+
+	hash, err := StateWitness(witness).StateHash()
+	require.NoError(t, err)
+	require.Equal(t, stateHash, hash)
+	_, err = StateWitness(witness[:BASELINE_STATE_WITNESS_SIZE-1]).StateHash()
+	require.ErrorContains(t, err, "Invalid witness length")
+}
+
+func TestState_JSONCodec_Unversioned(t *testing.T) {
+	state := CreateEmptyState()
+	stateJSON, err := json.Marshal(state)
+	require.NoError(t, err)
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(stateJSON, &fields))
+	delete(fields, "version")
+	stateJSON, err = json.Marshal(fields)
+	require.NoError(t, err)
+
+	// states serialized before the versioning are baseline states, and keep their state hash
+	var newState *State
+	require.NoError(t, json.Unmarshal(stateJSON, &newState))
+	require.Equal(t, VersionBaseline, newState.Version)
+	witness, _ := newState.EncodeWitness()
+	require.Len(t, witness, BASELINE_STATE_WITNESS_SIZE)
+}
+
 func TestState_JSONCodec(t *testing.T) {
-	elfProgram, err := elf.Open("../../testdata/example/bin/hello.elf")
+	elfProgram, err := elf.Open(testutil.ProgramPath("hello"))
 	require.NoError(t, err, "open ELF file")
 	state, err := program.LoadELF(elfProgram, CreateInitialState)
 	require.NoError(t, err, "load ELF into state")
@@ -94,11 +163,16 @@ func TestState_JSONCodec(t *testing.T) {
 	state.PreimageKey = crypto.Keccak256Hash([]byte{1, 2, 3, 4})
 	state.PreimageOffset = 4
 	state.Heap = 555
+	state.LLReservationActive = true
+	state.LLAddress = 0x00_01_00_04
+	state.LLOwnerThread = 3
 	state.Step = 99_999
 	state.StepsSinceLastContextSwitch = 123
 	state.Exited = true
 	state.ExitCode = 2
 	state.LastHint = []byte{11, 12, 13}
+	state.GetCurrentThread().Fpu.FCSR = 0x00800000
+	state.GetCurrentThread().Fpu.FPR[2] = 0x3FF00000
 
 	stateJSON, err := json.Marshal(state)
 	require.NoError(t, err)
@@ -107,9 +181,13 @@ func TestState_JSONCodec(t *testing.T) {
 	err = json.Unmarshal(stateJSON, &newState)
 	require.NoError(t, err)
 
+	require.Equal(t, LatestVersion, newState.Version)
 	require.Equal(t, state.PreimageKey, newState.PreimageKey)
 	require.Equal(t, state.PreimageOffset, newState.PreimageOffset)
 	require.Equal(t, state.Heap, newState.Heap)
+	require.Equal(t, state.LLReservationActive, newState.LLReservationActive)
+	require.Equal(t, state.LLAddress, newState.LLAddress)
+	require.Equal(t, state.LLOwnerThread, newState.LLOwnerThread)
 	require.Equal(t, state.ExitCode, newState.ExitCode)
 	require.Equal(t, state.Exited, newState.Exited)
 	require.Equal(t, state.Memory.MerkleRoot(), newState.Memory.MerkleRoot())
@@ -139,10 +217,18 @@ func TestState_EncodeThreadProof_SingleThread(t *testing.T) {
 	activeThread.Cpu.HI = 11
 	activeThread.Cpu.LO = 22
 	for i := 0; i < 32; i++ {
-		activeThread.Registers[i] = uint32(i)
+		activeThread.Registers[i] = Word(i)
 	}
+	activeThread.Fpu.FCSR = 0x00800000
+	activeThread.Fpu.FPR[31] = 33
 
-	expectedProof := append([]byte{}, activeThread.serializeThread()[:]...)
+	// The FPU state is serialized last: the FCSR, followed by the floating-point registers
+	serializedThread := activeThread.serializeThread(true)
+	fpuOffset := SERIALIZED_THREAD_SIZE - 4 - 32*arch.WordSizeBytes
+	require.Equal(t, []byte{0x00, 0x80, 0x00, 0x00}, serializedThread[fpuOffset:fpuOffset+4])
+	require.Equal(t, wordBytes(33), serializedThread[SERIALIZED_THREAD_SIZE-arch.WordSizeBytes:])
+
+	expectedProof := append([]byte{}, activeThread.serializeThread(true)[:]...)
 	expectedProof = append(expectedProof, EmptyThreadsRoot[:]...)
 
 	actualProof := state.EncodeThreadProof()
@@ -150,6 +236,28 @@ func TestState_EncodeThreadProof_SingleThread(t *testing.T) {
 	require.Equal(t, expectedProof, actualProof)
 }
 
+func TestState_EncodeThreadProof_Baseline(t *testing.T) {
+	state := CreateEmptyState()
+	state.Version = VersionNetpoll
+	activeThread := state.GetCurrentThread()
+	activeThread.Registers[31] = 0x44
+	// the FPU state is not part of the threads before VersionFPU
+	activeThread.Fpu.FCSR = 0x00800000
+	activeThread.Fpu.FPR[31] = 33
+
+	serializedThread := activeThread.serializeThread(false)
+	require.Len(t, serializedThread, BASELINE_SERIALIZED_THREAD_SIZE)
+	require.Equal(t, activeThread.serializeThread(true)[:BASELINE_SERIALIZED_THREAD_SIZE], serializedThread)
+
+	actualProof := state.EncodeThreadProof()
+	require.Len(t, actualProof, BASELINE_THREAD_WITNESS_SIZE)
+	require.Equal(t, append(serializedThread, EmptyThreadsRoot[:]...), actualProof)
+
+	witness, _ := state.EncodeWitness()
+	expectedLeftRoot := computeThreadRoot(EmptyThreadsRoot, activeThread, false)
+	require.Equal(t, expectedLeftRoot[:], witness[LEFT_THREADS_ROOT_WITNESS_OFFSET:LEFT_THREADS_ROOT_WITNESS_OFFSET+32])
+}
+
 func TestState_EncodeThreadProof_MultipleThreads(t *testing.T) {
 	state := CreateEmptyState()
 	// Add some more threads
@@ -161,19 +269,19 @@ func TestState_EncodeThreadProof_MultipleThreads(t *testing.T) {
 	// Set some fields on our threads
 	for i := 0; i < 3; i++ {
 		curThread := state.LeftThreadStack[i]
-		curThread.Cpu.PC = uint32(4 * i)
+		curThread.Cpu.PC = Word(4 * i)
 		curThread.Cpu.NextPC = curThread.Cpu.PC + 4
-		curThread.Cpu.HI = uint32(11 + i)
-		curThread.Cpu.LO = uint32(22 + i)
+		curThread.Cpu.HI = Word(11 + i)
+		curThread.Cpu.LO = Word(22 + i)
 		for j := 0; j < 32; j++ {
-			curThread.Registers[j] = uint32(j + i)
+			curThread.Registers[j] = Word(j + i)
 		}
 	}
 
 	expectedRoot := EmptyThreadsRoot
 	for i := 0; i < 2; i++ {
 		curThread := state.LeftThreadStack[i]
-		hashedThread := crypto.Keccak256Hash(curThread.serializeThread())
+		hashedThread := crypto.Keccak256Hash(curThread.serializeThread(true))
 
 		// root = prevRoot ++ hash(curRoot)
 		hashData := append([]byte{}, expectedRoot[:]...)
@@ -181,7 +289,7 @@ func TestState_EncodeThreadProof_MultipleThreads(t *testing.T) {
 		expectedRoot = crypto.Keccak256Hash(hashData)
 	}
 
-	expectedProof := append([]byte{}, state.GetCurrentThread().serializeThread()[:]...)
+	expectedProof := append([]byte{}, state.GetCurrentThread().serializeThread(true)[:]...)
 	expectedProof = append(expectedProof, expectedRoot[:]...)
 
 	actualProof := state.EncodeThreadProof()
@@ -192,12 +300,12 @@ func TestState_EncodeThreadProof_MultipleThreads(t *testing.T) {
 func TestState_EncodeThreadProof_EmptyThreadStackPanic(t *testing.T) {
 	cases := []struct {
 		name          string
-		wakeupAddr    uint32
+		wakeupAddr    Word
 		traverseRight bool
 	}{
-		{"traverse left during wakeup traversal", uint32(99), false},
+		{"traverse left during wakeup traversal", Word(99), false},
 		{"traverse left during normal traversal", exec.FutexEmptyAddr, false},
-		{"traverse right during wakeup traversal", uint32(99), true},
+		{"traverse right during wakeup traversal", Word(99), true},
 		{"traverse right during normal traversal", exec.FutexEmptyAddr, true},
 	}
 
@@ -219,3 +327,59 @@ func TestState_EncodeThreadProof_EmptyThreadStackPanic(t *testing.T) {
 		})
 	}
 }
+
+func TestState_SnapshotRestore(t *testing.T) {
+	state := CreateInitialState(0x1000, 0x2000)
+	state.Memory.SetMemory(0x1000, 0xaabbccdd)
+	state.PreimageKey = crypto.Keccak256Hash([]byte{1, 2, 3, 4})
+	state.PreimageOffset = 4
+	state.LLReservationActive = true
+	state.LLAddress = 0x1000
+	state.Step = 99
+	state.LastHint = []byte{11, 12, 13}
+	thread := CreateEmptyThread()
+	thread.ThreadId = 1
+	thread.Registers[3] = 33
+	state.RightThreadStack = append(state.RightThreadStack, thread)
+	state.GetCurrentThread().Fpu.FPR[1] = 0x3F800000
+	expectedWitness, expectedHash := state.EncodeWitness()
+	expectedThreadProof := state.EncodeThreadProof()
+
+	snapshot := state.Snapshot()
+	_, snapshotHash := snapshot.EncodeWitness()
+	require.Equal(t, expectedHash, snapshotHash)
+
+	// the snapshot shares nothing with the state
+	mem := state.Memory
+	state.Memory.SetMemory(0x1000, 1)
+	state.Memory.SetMemory(0x5000, 2)
+	state.LLReservationActive = false
+	state.Step = 100
+	state.LastHint[0] = 0
+	state.GetCurrentThread().Cpu.PC = 0x2000
+	state.GetCurrentThread().Fpu.FPR[1] = 0
+	thread.Registers[3] = 0
+	state.LeftThreadStack = append(state.LeftThreadStack, CreateEmptyThread())
+	_, snapshotHash = snapshot.EncodeWitness()
+	require.Equal(t, expectedHash, snapshotHash)
+
+	require.NoError(t, state.Restore(snapshot))
+	witness, hash := state.EncodeWitness()
+	require.Equal(t, expectedWitness, witness)
+	require.Equal(t, expectedHash, hash)
+	require.Equal(t, expectedThreadProof, state.EncodeThreadProof())
+	require.Same(t, mem, state.Memory, "memory is restored in place")
+
+	// the restored threads are not shared with the snapshot either
+	state.GetCurrentThread().Cpu.PC = 0x2000
+	_, snapshotHash = snapshot.EncodeWitness()
+	require.Equal(t, expectedHash, snapshotHash)
+
+	var other mipsevm.FPVMState = &otherState{}
+	require.ErrorContains(t, state.Restore(other), "cannot restore")
+}
+
+// otherState is a state of another VM
+type otherState struct {
+	mipsevm.FPVMState
+}
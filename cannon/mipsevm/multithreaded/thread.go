@@ -7,11 +7,20 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 )
 
+type Word = arch.Word
+
 // SERIALIZED_THREAD_SIZE is the size of a serialized ThreadState object
-const SERIALIZED_THREAD_SIZE = 166
+const SERIALIZED_THREAD_SIZE = BASELINE_SERIALIZED_THREAD_SIZE + fpuThreadSize
+
+// BASELINE_SERIALIZED_THREAD_SIZE is the size of a serialized ThreadState object before VersionFPU,
+// which doesn't serialize the floating-point registers.
+const BASELINE_SERIALIZED_THREAD_SIZE = arch.WordSizeBytes + 1 + 1 + arch.WordSizeBytes + 4 + 8 + 4*arch.WordSizeBytes + 32*arch.WordSizeBytes
+
+const fpuThreadSize = 4 + 32*arch.WordSizeBytes
 
 // THREAD_WITNESS_SIZE is the size of a thread witness encoded in bytes.
 //
@@ -19,22 +28,26 @@ const SERIALIZED_THREAD_SIZE = 166
 //	32 byte hash onion of the active thread stack without the active thread
 const THREAD_WITNESS_SIZE = SERIALIZED_THREAD_SIZE + 32
 
+// BASELINE_THREAD_WITNESS_SIZE is the size of a thread witness before VersionFPU.
+const BASELINE_THREAD_WITNESS_SIZE = BASELINE_SERIALIZED_THREAD_SIZE + 32
+
 // The empty thread root - keccak256(bytes32(0) ++ bytes32(0))
 var EmptyThreadsRoot common.Hash = common.HexToHash("0xad3228b676f7d3cd4284a5443f17f1962b36e491b30a40b2405849e597ba5fb5")
 
 type ThreadState struct {
-	ThreadId         uint32             `json:"threadId"`
+	ThreadId         Word               `json:"threadId"`
 	ExitCode         uint8              `json:"exit"`
 	Exited           bool               `json:"exited"`
-	FutexAddr        uint32             `json:"futexAddr"`
+	FutexAddr        Word               `json:"futexAddr"`
 	FutexVal         uint32             `json:"futexVal"`
 	FutexTimeoutStep uint64             `json:"futexTimeoutStep"`
 	Cpu              mipsevm.CpuScalars `json:"cpu"`
-	Registers        [32]uint32         `json:"registers"`
+	Registers        [32]Word           `json:"registers"`
+	Fpu              mipsevm.FpuState   `json:"fpu"`
 }
 
 func CreateEmptyThread() *ThreadState {
-	initThreadId := uint32(0)
+	initThreadId := Word(0)
 	return &ThreadState{
 		ThreadId: initThreadId,
 		ExitCode: 0,
@@ -48,34 +61,42 @@ func CreateEmptyThread() *ThreadState {
 		FutexAddr:        exec.FutexEmptyAddr,
 		FutexVal:         0,
 		FutexTimeoutStep: 0,
-		Registers:        [32]uint32{},
+		Registers:        [32]Word{},
 	}
 }
 
-func (t *ThreadState) serializeThread() []byte {
+// serializeThread serializes the thread, with the floating-point registers if the VM has the FPU.
+func (t *ThreadState) serializeThread(fpu bool) []byte {
 	out := make([]byte, 0, SERIALIZED_THREAD_SIZE)
 
-	out = binary.BigEndian.AppendUint32(out, t.ThreadId)
+	out = arch.ByteOrderWord.AppendWord(out, t.ThreadId)
 	out = append(out, t.ExitCode)
 	out = mipsevm.AppendBoolToWitness(out, t.Exited)
-	out = binary.BigEndian.AppendUint32(out, t.FutexAddr)
+	out = arch.ByteOrderWord.AppendWord(out, t.FutexAddr)
 	out = binary.BigEndian.AppendUint32(out, t.FutexVal)
 	out = binary.BigEndian.AppendUint64(out, t.FutexTimeoutStep)
 
-	out = binary.BigEndian.AppendUint32(out, t.Cpu.PC)
-	out = binary.BigEndian.AppendUint32(out, t.Cpu.NextPC)
-	out = binary.BigEndian.AppendUint32(out, t.Cpu.LO)
-	out = binary.BigEndian.AppendUint32(out, t.Cpu.HI)
+	out = arch.ByteOrderWord.AppendWord(out, t.Cpu.PC)
+	out = arch.ByteOrderWord.AppendWord(out, t.Cpu.NextPC)
+	out = arch.ByteOrderWord.AppendWord(out, t.Cpu.LO)
+	out = arch.ByteOrderWord.AppendWord(out, t.Cpu.HI)
 
 	for _, r := range t.Registers {
-		out = binary.BigEndian.AppendUint32(out, r)
+		out = arch.ByteOrderWord.AppendWord(out, r)
+	}
+
+	if fpu {
+		out = binary.BigEndian.AppendUint32(out, t.Fpu.FCSR)
+		for _, r := range t.Fpu.FPR {
+			out = arch.ByteOrderWord.AppendWord(out, r)
+		}
 	}
 
 	return out
 }
 
-func computeThreadRoot(prevStackRoot common.Hash, threadToPush *ThreadState) common.Hash {
-	hashedThread := crypto.Keccak256Hash(threadToPush.serializeThread())
+func computeThreadRoot(prevStackRoot common.Hash, threadToPush *ThreadState, fpu bool) common.Hash {
+	hashedThread := crypto.Keccak256Hash(threadToPush.serializeThread(fpu))
 
 	var hashData []byte
 	hashData = append(hashData, prevStackRoot[:]...)
@@ -7,19 +7,25 @@ import (
 	"io"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 )
 
+type Word = arch.Word
+
 const (
-	HEAP_START    = 0x05_00_00_00
-	HEAP_END      = 0x60_00_00_00
-	PROGRAM_BREAK = 0x40_00_00_00
+	HEAP_START    = arch.HeapStart
+	HEAP_END      = arch.HeapEnd
+	PROGRAM_BREAK = arch.ProgramBreak
 )
 
-type CreateInitialFPVMState[T mipsevm.FPVMState] func(pc, heapStart uint32) T
+type CreateInitialFPVMState[T mipsevm.FPVMState] func(pc, heapStart Word) T
 
 func LoadELF[T mipsevm.FPVMState](f *elf.File, initState CreateInitialFPVMState[T]) (T, error) {
 	var empty T
-	s := initState(uint32(f.Entry), HEAP_START)
+	if expected := elfClass(); f.Class != expected {
+		return empty, fmt.Errorf("unsupported ELF class %v, expected %v for this VM", f.Class, expected)
+	}
+	s := initState(Word(f.Entry), HEAP_START)
 
 	for i, prog := range f.Progs {
 		if prog.Type == 0x70000003 { // MIPS_ABIFLAGS
@@ -39,16 +45,24 @@ func LoadELF[T mipsevm.FPVMState](f *elf.File, initState CreateInitialFPVMState[
 			}
 		}
 
-		if prog.Vaddr+prog.Memsz >= uint64(1<<32) {
+		if arch.IsMips32 && prog.Vaddr+prog.Memsz >= uint64(1<<32) {
 			return empty, fmt.Errorf("program %d out of 32-bit mem range: %x - %x (size: %x)", i, prog.Vaddr, prog.Vaddr+prog.Memsz, prog.Memsz)
 		}
 		if prog.Vaddr+prog.Memsz >= HEAP_START {
 			return empty, fmt.Errorf("program %d overlaps with heap: %x - %x (size: %x). The heap start offset must be reconfigured", i, prog.Vaddr, prog.Vaddr+prog.Memsz, prog.Memsz)
 		}
-		if err := s.GetMemory().SetMemoryRange(uint32(prog.Vaddr), r); err != nil {
+		if err := s.GetMemory().SetMemoryRange(Word(prog.Vaddr), r); err != nil {
 			return empty, fmt.Errorf("failed to read program segment %d: %w", i, err)
 		}
 	}
 
 	return s, nil
 }
+
+// elfClass returns the class of the ELF binaries the VM executes.
+func elfClass() elf.Class {
+	if arch.IsMips32 {
+		return elf.ELFCLASS32
+	}
+	return elf.ELFCLASS64
+}
@@ -4,16 +4,21 @@ import (
 	"debug/elf"
 	"fmt"
 	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 type Symbol struct {
 	Name  string `json:"name"`
-	Start uint32 `json:"start"`
-	Size  uint32 `json:"size"`
+	Start Word   `json:"start"`
+	Size  Word   `json:"size"`
 }
 
 type Metadata struct {
 	Symbols []Symbol `json:"symbols"`
+	// ELFHash is the SHA-256 hash of the ELF file the program was loaded from.
+	// It is zero if unknown, e.g. in metadata written by older versions.
+	ELFHash common.Hash `json:"elfHash"`
 }
 
 func MakeMetadata(elfProgram *elf.File) (*Metadata, error) {
@@ -27,42 +32,49 @@ func MakeMetadata(elfProgram *elf.File) (*Metadata, error) {
 	})
 	out := &Metadata{Symbols: make([]Symbol, len(syms))}
 	for i, s := range syms {
-		out.Symbols[i] = Symbol{Name: s.Name, Start: uint32(s.Value), Size: uint32(s.Size)}
+		out.Symbols[i] = Symbol{Name: s.Name, Start: Word(s.Value), Size: Word(s.Size)}
 	}
 	return out, nil
 }
 
-func (m *Metadata) LookupSymbol(addr uint32) string {
+func (m *Metadata) LookupSymbol(addr Word) string {
+	_, name := m.lookup(addr)
+	return name
+}
+
+// lookup returns the symbol that contains the address, and its name.
+// The symbol is nil if there is none, and the name describes why instead.
+func (m *Metadata) lookup(addr Word) (*Symbol, string) {
 	if len(m.Symbols) == 0 {
-		return "!unknown"
+		return nil, "!unknown"
 	}
 	// find first symbol with higher start. Or n if no such symbol exists
 	i := sort.Search(len(m.Symbols), func(i int) bool {
 		return m.Symbols[i].Start > addr
 	})
 	if i == 0 {
-		return "!start"
+		return nil, "!start"
 	}
 	out := &m.Symbols[i-1]
 	if out.Start+out.Size < addr { // addr may be pointing to a gap between symbols
-		return "!gap"
+		return nil, "!gap"
 	}
-	return out.Name
+	return out, out.Name
 }
 
-type SymbolMatcher func(addr uint32) bool
+type SymbolMatcher func(addr Word) bool
 
 func (m *Metadata) CreateSymbolMatcher(name string) SymbolMatcher {
 	for _, s := range m.Symbols {
 		if s.Name == name {
 			start := s.Start
 			end := s.Start + s.Size
-			return func(addr uint32) bool {
+			return func(addr Word) bool {
 				return addr >= start && addr < end
 			}
 		}
 	}
-	return func(addr uint32) bool {
+	return func(addr Word) bool {
 		return false
 	}
 }
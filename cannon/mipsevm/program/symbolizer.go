@@ -0,0 +1,51 @@
+package program
+
+import (
+	"debug/elf"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// Symbolizer resolves the addresses of a guest program to the symbols containing them,
+// for logs, traces and error messages.
+type Symbolizer interface {
+	// Symbolize returns the symbol containing the address with the offset into it, e.g. runtime.mallocgc+0x54,
+	// or an empty string if no symbol contains the address.
+	Symbolize(addr Word) string
+}
+
+var _ Symbolizer = (*Metadata)(nil)
+
+// Symbolize implements Symbolizer with the symbols of the metadata. The metadata may be nil.
+func (m *Metadata) Symbolize(addr Word) string {
+	if m == nil {
+		return ""
+	}
+	sym, _ := m.lookup(addr)
+	if sym == nil {
+		return ""
+	}
+	if offset := addr - sym.Start; offset != 0 {
+		return fmt.Sprintf("%s+%#x", sym.Name, offset)
+	}
+	return sym.Name
+}
+
+// NewELFSymbolizer loads the symbol table of the ELF file, to symbolize the addresses of the program loaded from it.
+func NewELFSymbolizer(f *elf.File) (Symbolizer, error) {
+	return MakeMetadata(f)
+}
+
+// FormatPC formats the program counter in hex, followed by the symbol containing it, if any,
+// e.g. 08001234 (runtime.mallocgc+0x54). The symbolizer may be nil.
+func FormatPC(s Symbolizer, pc Word) string {
+	hex := mipsevm.HexWord(pc).String()
+	if s == nil {
+		return hex
+	}
+	if sym := s.Symbolize(pc); sym != "" {
+		return hex + " (" + sym + ")"
+	}
+	return hex
+}
@@ -0,0 +1,29 @@
+package program
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+func TestSymbolize(t *testing.T) {
+	meta := &Metadata{Symbols: []Symbol{
+		{Name: "runtime.mallocgc", Start: 0x1000, Size: 0x100},
+		{Name: "main.main", Start: 0x1200, Size: 0x40},
+	}}
+	require.Equal(t, "runtime.mallocgc", meta.Symbolize(0x1000))
+	require.Equal(t, "runtime.mallocgc+0x54", meta.Symbolize(0x1054))
+	require.Equal(t, "main.main+0x3c", meta.Symbolize(0x123c))
+	require.Empty(t, meta.Symbolize(0x800), "before the first symbol")
+	require.Empty(t, meta.Symbolize(0x1180), "in between symbols")
+
+	var none *Metadata
+	require.Empty(t, none.Symbolize(0x1000))
+	require.Empty(t, (&Metadata{}).Symbolize(0x1000))
+
+	require.Equal(t, mipsevm.HexWord(0x1054).String()+" (runtime.mallocgc+0x54)", FormatPC(meta, 0x1054))
+	require.Equal(t, mipsevm.HexWord(0x800).String(), FormatPC(meta, 0x800))
+	require.Equal(t, mipsevm.HexWord(0x1054).String(), FormatPC(nil, 0x1054))
+}
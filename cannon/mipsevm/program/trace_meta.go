@@ -0,0 +1,131 @@
+package program
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// StepRange is a range of consecutive steps that execute within the same guest function.
+type StepRange struct {
+	Start    uint64 `json:"start"`
+	End      uint64 `json:"end"` // inclusive
+	Function string `json:"fn"`
+}
+
+// StepRangeTracker tracks the step ranges of a run, with the guest function each range executes in,
+// and passes each completed range to a callback.
+type StepRangeTracker struct {
+	meta *Metadata
+	emit func(r StepRange) error
+
+	current StepRange
+	active  bool
+	// bounds of the symbol of the current range, to skip lookups while the PC stays within the function
+	symStart, symEnd Word
+}
+
+func NewStepRangeTracker(meta *Metadata, emit func(r StepRange) error) *StepRangeTracker {
+	return &StepRangeTracker{meta: meta, emit: emit}
+}
+
+// Record registers that the given step executes at the given PC.
+// Steps must be recorded in increasing order.
+func (t *StepRangeTracker) Record(step uint64, pc Word) error {
+	if t.active {
+		if step <= t.current.End {
+			return fmt.Errorf("step %d recorded after step %d", step, t.current.End)
+		}
+		if step == t.current.End+1 && pc >= t.symStart && pc < t.symEnd {
+			t.current.End = step
+			return nil
+		}
+	}
+	sym, name := t.meta.lookup(pc)
+	if sym != nil {
+		t.symStart, t.symEnd = sym.Start, sym.Start+sym.Size
+	} else {
+		t.symStart, t.symEnd = 0, 0
+	}
+	// Consecutive steps in the same function extend the current range,
+	// also when the PC is outside any symbol, and there are no bounds to skip the lookup with.
+	if t.active && step == t.current.End+1 && name == t.current.Function {
+		t.current.End = step
+		return nil
+	}
+	if err := t.Flush(); err != nil {
+		return err
+	}
+	t.current = StepRange{Start: step, End: step, Function: name}
+	t.active = true
+	return nil
+}
+
+// Flush completes the current step range, if any.
+func (t *StepRangeTracker) Flush() error {
+	if !t.active {
+		return nil
+	}
+	t.active = false
+	return t.emit(t.current)
+}
+
+// TraceMetaWriter writes the trace metadata sidecar of a run:
+// the step ranges of the run, with the guest function each range executes in,
+// encoded as one JSON StepRange per line.
+type TraceMetaWriter struct {
+	*StepRangeTracker
+	out *bufio.Writer
+}
+
+func NewTraceMetaWriter(meta *Metadata, out io.Writer) *TraceMetaWriter {
+	buf := bufio.NewWriter(out)
+	enc := json.NewEncoder(buf)
+	return &TraceMetaWriter{
+		StepRangeTracker: NewStepRangeTracker(meta, func(r StepRange) error {
+			if err := enc.Encode(&r); err != nil {
+				return fmt.Errorf("failed to write step range: %w", err)
+			}
+			return nil
+		}),
+		out: buf,
+	}
+}
+
+// Flush writes out the current step range, and any buffered data.
+func (w *TraceMetaWriter) Flush() error {
+	if err := w.StepRangeTracker.Flush(); err != nil {
+		return err
+	}
+	return w.out.Flush()
+}
+
+// ReadTraceMeta reads the step ranges of a trace metadata sidecar.
+func ReadTraceMeta(in io.Reader) ([]StepRange, error) {
+	var out []StepRange
+	dec := json.NewDecoder(bufio.NewReader(in))
+	for {
+		var r StepRange
+		if err := dec.Decode(&r); errors.Is(err, io.EOF) {
+			return out, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read step range %d: %w", len(out), err)
+		}
+		out = append(out, r)
+	}
+}
+
+// FindStepRange returns the step range that contains the step, if any.
+// The ranges must be ordered by step, as written by the TraceMetaWriter.
+func FindStepRange(ranges []StepRange, step uint64) (StepRange, bool) {
+	i := sort.Search(len(ranges), func(i int) bool {
+		return ranges[i].End >= step
+	})
+	if i == len(ranges) || ranges[i].Start > step {
+		return StepRange{}, false
+	}
+	return ranges[i], true
+}
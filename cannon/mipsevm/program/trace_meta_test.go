@@ -0,0 +1,73 @@
+package program
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceMeta(t *testing.T) {
+	meta := &Metadata{Symbols: []Symbol{
+		{Name: "main.main", Start: 0x100, Size: 0x40},
+		{Name: "main.helper", Start: 0x140, Size: 0x20},
+		{Name: "runtime.exit", Start: 0x200, Size: 0x10},
+	}}
+
+	var buf bytes.Buffer
+	w := NewTraceMetaWriter(meta, &buf)
+	pcs := []Word{
+		0x100, 0x104, 0x108, // main.main
+		0x140, 0x144, // main.helper
+		0x10c, // back in main.main
+		0x180, // gap
+		0x184, // gap
+		0x200, // runtime.exit
+	}
+	for i, pc := range pcs {
+		require.NoError(t, w.Record(uint64(10+i), pc))
+	}
+	require.ErrorContains(t, w.Record(12, 0x100), "recorded after")
+	require.NoError(t, w.Flush())
+
+	ranges, err := ReadTraceMeta(&buf)
+	require.NoError(t, err)
+	require.Equal(t, []StepRange{
+		{Start: 10, End: 12, Function: "main.main"},
+		{Start: 13, End: 14, Function: "main.helper"},
+		{Start: 15, End: 15, Function: "main.main"},
+		{Start: 16, End: 17, Function: "!gap"},
+		{Start: 18, End: 18, Function: "runtime.exit"},
+	}, ranges)
+
+	r, ok := FindStepRange(ranges, 14)
+	require.True(t, ok)
+	require.Equal(t, "main.helper", r.Function)
+	r, ok = FindStepRange(ranges, 10)
+	require.True(t, ok)
+	require.Equal(t, "main.main", r.Function)
+	_, ok = FindStepRange(ranges, 9)
+	require.False(t, ok)
+	_, ok = FindStepRange(ranges, 19)
+	require.False(t, ok)
+}
+
+func TestTraceMeta_SkippedSteps(t *testing.T) {
+	meta := &Metadata{Symbols: []Symbol{{Name: "main.main", Start: 0x100, Size: 0x40}}}
+
+	var buf bytes.Buffer
+	w := NewTraceMetaWriter(meta, &buf)
+	// Steps that were not recorded are not covered by any range
+	require.NoError(t, w.Record(1, 0x100))
+	require.NoError(t, w.Record(5, 0x104))
+	require.NoError(t, w.Flush())
+
+	ranges, err := ReadTraceMeta(&buf)
+	require.NoError(t, err)
+	require.Equal(t, []StepRange{
+		{Start: 1, End: 1, Function: "main.main"},
+		{Start: 5, End: 5, Function: "main.main"},
+	}, ranges)
+	_, ok := FindStepRange(ranges, 3)
+	require.False(t, ok)
+}
@@ -0,0 +1,151 @@
+// Package proof defines the versioned JSON schema of the step proof files emitted by cannon.
+package proof
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// Version is the version of the proof file schema.
+type Version uint64
+
+const (
+	// VersionLegacy is the schema of proof files without a version field.
+	VersionLegacy Version = 0
+	// VersionV1 adds the version field to the legacy schema.
+	VersionV1 Version = 1
+
+	// CurrentVersion is the version of the proof files written by this version of cannon.
+	CurrentVersion = VersionV1
+)
+
+var ErrUnsupportedVersion = errors.New("unsupported proof version")
+
+// Proof is the step proof file for a single VM step, with the data to replicate the step onchain.
+type Proof struct {
+	Version Version `json:"version"`
+
+	Step uint64 `json:"step"`
+
+	Pre  common.Hash `json:"pre"`
+	Post common.Hash `json:"post"`
+
+	StateData hexutil.Bytes `json:"state-data"`
+	ProofData hexutil.Bytes `json:"proof-data"`
+
+	OracleKey    hexutil.Bytes `json:"oracle-key,omitempty"`
+	OracleValue  hexutil.Bytes `json:"oracle-value,omitempty"`
+	OracleOffset uint32        `json:"oracle-offset,omitempty"`
+}
+
+// legacyProof is the schema of VersionLegacy proof files.
+type legacyProof struct {
+	Step uint64      `json:"step"`
+	Pre  common.Hash `json:"pre"`
+	Post common.Hash `json:"post"`
+
+	StateData hexutil.Bytes `json:"state-data"`
+	ProofData hexutil.Bytes `json:"proof-data"`
+
+	OracleKey    hexutil.Bytes `json:"oracle-key,omitempty"`
+	OracleValue  hexutil.Bytes `json:"oracle-value,omitempty"`
+	OracleOffset uint32        `json:"oracle-offset,omitempty"`
+}
+
+// FromWitness creates a proof of the current version from the witness of a step, and the resulting post-state hash.
+func FromWitness(step uint64, witness *mipsevm.StepWitness, post common.Hash) *Proof {
+	p := &Proof{
+		Version:   CurrentVersion,
+		Step:      step,
+		Pre:       witness.StateHash,
+		Post:      post,
+		StateData: witness.State,
+		ProofData: witness.ProofData,
+	}
+	if witness.HasPreimage() {
+		p.OracleKey = witness.PreimageKey[:]
+		p.OracleValue = witness.PreimageValue
+		p.OracleOffset = witness.PreimageOffset
+	}
+	return p
+}
+
+// Witness converts the proof back to the witness of the step.
+func (p *Proof) Witness() *mipsevm.StepWitness {
+	wit := &mipsevm.StepWitness{
+		State:          p.StateData,
+		StateHash:      p.Pre,
+		ProofData:      p.ProofData,
+		PreimageValue:  p.OracleValue,
+		PreimageOffset: p.OracleOffset,
+	}
+	copy(wit.PreimageKey[:], p.OracleKey)
+	return wit
+}
+
+// requiredFields are the fields that must be present in versioned proof files.
+var requiredFields = []string{"version", "step", "pre", "post", "state-data", "proof-data"}
+
+var ErrMissingField = errors.New("missing proof field")
+
+// Decode decodes a proof file of any supported version, and converts it to the current version.
+// Versioned proofs are decoded strictly: the version must be supported and all required fields must be present.
+// Unknown fields are ignored, so fields can be added without breaking existing readers;
+// incompatible changes require a new version, which older readers reject with ErrUnsupportedVersion.
+// Legacy proofs without a version are decoded as before, without any required fields.
+func Decode(r io.Reader) (*Proof, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read proof: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode proof: %w", err)
+	}
+	rawVersion, ok := fields["version"]
+	if !ok {
+		var legacy legacyProof
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return nil, fmt.Errorf("failed to decode legacy proof: %w", err)
+		}
+		return legacy.upgrade(), nil
+	}
+	var version Version
+	if err := json.Unmarshal(rawVersion, &version); err != nil {
+		return nil, fmt.Errorf("failed to decode proof version: %w", err)
+	}
+	if version != VersionV1 {
+		return nil, fmt.Errorf("%w: %d, latest supported version is %d", ErrUnsupportedVersion, version, CurrentVersion)
+	}
+	for _, name := range requiredFields {
+		if _, ok := fields[name]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrMissingField, name)
+		}
+	}
+	var p Proof
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to decode proof: %w", err)
+	}
+	return &p, nil
+}
+
+func (l *legacyProof) upgrade() *Proof {
+	return &Proof{
+		Version:      CurrentVersion,
+		Step:         l.Step,
+		Pre:          l.Pre,
+		Post:         l.Post,
+		StateData:    l.StateData,
+		ProofData:    l.ProofData,
+		OracleKey:    l.OracleKey,
+		OracleValue:  l.OracleValue,
+		OracleOffset: l.OracleOffset,
+	}
+}
@@ -0,0 +1,87 @@
+package proof
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+func TestRoundTrip(t *testing.T) {
+	witness := &mipsevm.StepWitness{
+		State:          []byte{1, 2, 3},
+		StateHash:      common.Hash{0xaa},
+		ProofData:      []byte{4, 5},
+		PreimageKey:    [32]byte{0x02, 0x01},
+		PreimageValue:  []byte{0, 0, 0, 0, 0, 0, 0, 1, 0xff},
+		PreimageOffset: 4,
+	}
+	p := FromWitness(42, witness, common.Hash{0xbb})
+	require.Equal(t, CurrentVersion, p.Version)
+
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+	decoded, err := Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, p, decoded)
+	require.Equal(t, witness, decoded.Witness())
+}
+
+func TestWithoutPreimage(t *testing.T) {
+	p := FromWitness(1, &mipsevm.StepWitness{State: []byte{1}, ProofData: []byte{2}}, common.Hash{})
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "oracle-key")
+}
+
+func TestDecodeLegacy(t *testing.T) {
+	legacy := `{"step":3,"pre":"0x0100000000000000000000000000000000000000000000000000000000000000","post":"0x0200000000000000000000000000000000000000000000000000000000000000","state-data":"0x0102","proof-data":"0x03","oracle-key":"0x04","oracle-value":"0x05","oracle-offset":6}`
+	p, err := Decode(strings.NewReader(legacy))
+	require.NoError(t, err)
+	require.Equal(t, &Proof{
+		Version:      CurrentVersion,
+		Step:         3,
+		Pre:          common.Hash{0x01},
+		Post:         common.Hash{0x02},
+		StateData:    []byte{1, 2},
+		ProofData:    []byte{3},
+		OracleKey:    []byte{4},
+		OracleValue:  []byte{5},
+		OracleOffset: 6,
+	}, p)
+}
+
+func TestDecodeStrict(t *testing.T) {
+	t.Run("ignore unknown field", func(t *testing.T) {
+		p, err := Decode(strings.NewReader(`{"version":1,"step":3,"pre":"0x0101010101010101010101010101010101010101010101010101010101010101","post":"0x0202020202020202020202020202020202020202020202020202020202020202","state-data":"0x","proof-data":"0x","extra":true}`))
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), p.Step)
+	})
+	t.Run("ignore unknown legacy field", func(t *testing.T) {
+		p, err := Decode(strings.NewReader(`{"step":3,"extra":true}`))
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), p.Step)
+	})
+	t.Run("missing field", func(t *testing.T) {
+		_, err := Decode(strings.NewReader(`{"version":1,"step":3,"pre":"0x0101010101010101010101010101010101010101010101010101010101010101","post":"0x0202020202020202020202020202020202020202020202020202020202020202","state-data":"0x"}`))
+		require.ErrorIs(t, err, ErrMissingField)
+		require.ErrorContains(t, err, "proof-data")
+	})
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := Decode(strings.NewReader(`{"version":2,"step":3}`))
+		require.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+	t.Run("version zero", func(t *testing.T) {
+		_, err := Decode(strings.NewReader(`{"version":0,"step":3}`))
+		require.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := Decode(strings.NewReader(`{`))
+		require.Error(t, err)
+	})
+}
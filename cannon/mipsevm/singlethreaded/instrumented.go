@@ -8,6 +8,7 @@ import (
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 type InstrumentedState struct {
@@ -23,6 +24,15 @@ type InstrumentedState struct {
 	stackTracker  exec.TraceableStackTracker
 
 	preimageOracle *exec.TrackingPreimageOracleReader
+
+	tracer          mipsevm.StepTracer
+	syscallPolicy   *exec.SyscallPolicy
+	unknownSyscalls *exec.UnknownSyscalls
+	syscallStats    *exec.SyscallStats
+	strict          bool
+
+	// blocks is the code translated for RunSteps, if fast execution is enabled
+	blocks *exec.BlockCache
 }
 
 var _ mipsevm.FPVM = (*InstrumentedState)(nil)
@@ -30,20 +40,24 @@ var _ mipsevm.FPVM = (*InstrumentedState)(nil)
 func NewInstrumentedState(state *State, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, meta *program.Metadata) *InstrumentedState {
 	var sleepCheck program.SymbolMatcher
 	if meta == nil {
-		sleepCheck = func(addr uint32) bool { return false }
+		sleepCheck = func(addr Word) bool { return false }
 	} else {
 		sleepCheck = meta.CreateSymbolMatcher("runtime.notesleep")
 	}
+	syscallPolicy := GetSyscallPolicy(state.Version)
 
 	return &InstrumentedState{
-		meta:           meta,
-		sleepCheck:     sleepCheck,
-		state:          state,
-		stdOut:         stdOut,
-		stdErr:         stdErr,
-		memoryTracker:  exec.NewMemoryTracker(state.Memory),
-		stackTracker:   &exec.NoopStackTracker{},
-		preimageOracle: exec.NewTrackingPreimageOracleReader(po),
+		meta:            meta,
+		sleepCheck:      sleepCheck,
+		state:           state,
+		stdOut:          stdOut,
+		stdErr:          stdErr,
+		memoryTracker:   exec.NewMemoryTracker(state.Memory),
+		stackTracker:    &exec.NoopStackTracker{},
+		preimageOracle:  exec.NewTrackingPreimageOracleReader(po),
+		syscallPolicy:   syscallPolicy,
+		unknownSyscalls: exec.NewUnknownSyscalls(syscallPolicy),
+		syscallStats:    exec.NewSyscallStats(),
 	}
 }
 
@@ -81,6 +95,9 @@ func (m *InstrumentedState) Step(proof bool) (wit *mipsevm.StepWitness, err erro
 	if err != nil {
 		return nil, err
 	}
+	if m.tracer != nil {
+		m.tracer.OnStepEnd(m.state)
+	}
 
 	if proof {
 		memProof := m.memoryTracker.MemProof()
@@ -107,6 +124,10 @@ func (m *InstrumentedState) GetState() mipsevm.FPVMState {
 	return m.state
 }
 
+func (m *InstrumentedState) Stats() *mipsevm.Stats {
+	return &mipsevm.Stats{Syscalls: m.syscallStats.Stats()}
+}
+
 func (m *InstrumentedState) GetDebugInfo() *mipsevm.DebugInfo {
 	return &mipsevm.DebugInfo{
 		Pages:               m.state.Memory.PageCount(),
@@ -116,6 +137,67 @@ func (m *InstrumentedState) GetDebugInfo() *mipsevm.DebugInfo {
 	}
 }
 
+func (m *InstrumentedState) SetTracer(tracer mipsevm.StepTracer) {
+	m.tracer = tracer
+	m.memoryTracker.SetTracer(tracer)
+}
+
+func (m *InstrumentedState) SetUnknownSyscallMode(mode mipsevm.UnknownSyscallMode, logger log.Logger) {
+	m.unknownSyscalls.SetMode(mode, logger)
+}
+
+func (m *InstrumentedState) SetStrictMode(strict bool) {
+	m.strict = strict
+}
+
+func (m *InstrumentedState) SetFastExecution(enabled bool) {
+	if enabled && m.blocks == nil {
+		m.blocks = exec.NewBlockCache(m.state.Memory, func(insn, opcode, fun uint32) bool {
+			return opcode == 0 && fun == 0xC // syscall
+		}, nil)
+	} else if !enabled && m.blocks != nil {
+		m.blocks.Close()
+		m.blocks = nil
+	}
+}
+
+func (m *InstrumentedState) RunSteps(n uint64) error {
+	m.preimageOracle.Reset()
+	for i := uint64(0); i < n && !m.state.Exited; {
+		if m.canRunBlocks() {
+			if steps := m.blocks.RunBlock(&m.state.Cpu, &m.state.Registers, m.state.getFpuRef(), n-i); steps > 0 {
+				m.state.Step += steps
+				i += steps
+				// blocks are entered through jumps, so the sleep loop is detected at the start of the next block
+				if m.CheckInfiniteLoop() {
+					return nil
+				}
+				continue
+			}
+		}
+		if _, err := m.Step(false); err != nil {
+			return err
+		}
+		i++
+		if _, _, offset := m.preimageOracle.LastPreimage(); offset != ^uint32(0) {
+			return nil
+		}
+		if m.CheckInfiniteLoop() {
+			return nil
+		}
+	}
+	return nil
+}
+
+// canRunBlocks returns whether RunSteps can execute blocks, i.e. whether nothing observes the individual steps.
+func (m *InstrumentedState) canRunBlocks() bool {
+	if m.blocks == nil || m.tracer != nil || m.strict {
+		return false
+	}
+	_, noop := m.stackTracker.(*exec.NoopStackTracker)
+	return noop
+}
+
 func (m *InstrumentedState) Traceback() {
 	m.stackTracker.Traceback()
 }
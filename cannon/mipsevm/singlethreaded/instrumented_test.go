@@ -1,13 +1,22 @@
+//go:build !cannon64
+
 package singlethreaded
 
 import (
 	"io"
 	"testing"
+	"time"
 
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	preimagetest "github.com/ethereum-optimism/optimism/op-preimage/test"
 )
 
 func vmFactory(state *State, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger) mipsevm.FPVM {
@@ -18,6 +27,14 @@ func TestInstrumentedState_OpenMips(t *testing.T) {
 	testutil.RunVMTests_OpenMips(t, CreateEmptyState, vmFactory)
 }
 
+func TestInstrumentedState_FastExecution(t *testing.T) {
+	testutil.RunVMTests_FastExecution(t, CreateEmptyState, vmFactory)
+}
+
+func TestInstrumentedState_SnapshotRestore(t *testing.T) {
+	testutil.RunVMTests_SnapshotRestore(t, CreateEmptyState, vmFactory)
+}
+
 func TestInstrumentedState_Hello(t *testing.T) {
 	testutil.RunVMTest_Hello(t, CreateInitialState, vmFactory, true)
 }
@@ -25,3 +42,105 @@ func TestInstrumentedState_Hello(t *testing.T) {
 func TestInstrumentedState_Claim(t *testing.T) {
 	testutil.RunVMTest_Claim(t, CreateInitialState, vmFactory, true)
 }
+
+func TestInstrumentedState_PreimageFaults(t *testing.T) {
+	data := []byte("hello world")
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(data)).PreimageKey()
+	other := []byte("goodbye world")
+	otherKey := preimage.Keccak256Key(crypto.Keccak256Hash(other)).PreimageKey()
+	source := func(k [32]byte) []byte {
+		if k == otherKey {
+			return other
+		}
+		return data
+	}
+	// readStep reads the start of the pre-image of key in a single step
+	readStep := func(fault *preimagetest.Fault) (state *State, err error) {
+		oracle := preimagetest.NewFaultOracle(source, nil)
+		if fault != nil {
+			oracle.InjectKey(key, *fault)
+		}
+		state = CreateEmptyState()
+		state.PreimageKey = key
+		state.Memory.SetMemory(0, 0x0000000c) // syscall
+		testutil.SetSyscallArgs(&state.Registers, exec.SysRead, exec.FdPreimageRead, 0x1000, 4)
+		vm := NewInstrumentedState(state, oracle, io.Discard, io.Discard, nil)
+		defer func() {
+			if r := recover(); r != nil {
+				err = r.(error)
+			}
+		}()
+		_, err = vm.Step(true)
+		return state, err
+	}
+
+	expected, err := readStep(nil)
+	require.NoError(t, err)
+	delay := preimagetest.Delay(10 * time.Millisecond)
+	delayed, err := readStep(&delay)
+	require.NoError(t, err)
+	_, expectedHash := expected.EncodeWitness()
+	_, delayedHash := delayed.EncodeWitness()
+	require.Equal(t, expectedHash, delayedHash, "delays don't change the trace")
+
+	for _, fault := range []preimagetest.Fault{preimagetest.Truncate(), preimagetest.Corrupt(), preimagetest.WrongKey(otherKey)} {
+		t.Run(fault.Kind.String(), func(t *testing.T) {
+			state, err := readStep(&fault)
+			require.ErrorIs(t, err, preimage.ErrIncorrectData)
+			require.Zero(t, state.Memory.GetMemory(0x1000), "must not read invalid pre-image data into memory")
+		})
+	}
+}
+
+func TestInstrumentedState_BaselineNetpollSyscalls(t *testing.T) {
+	// Before VersionNetpoll, the network poller syscalls are ignored like any other syscall without a handler
+	for _, syscallNum := range []Word{exec.SysEpollCreate1, exec.SysEventFd2, exec.SysEpollCtl, exec.SysPipe2, exec.SysEpollPwait} {
+		require.Equal(t, exec.SyscallImplemented, GetSyscallPolicy(LatestVersion).Action(syscallNum), "syscall %d", syscallNum)
+		require.Equal(t, exec.SyscallNoop, GetSyscallPolicy(VersionBaseline).Action(syscallNum), "syscall %d", syscallNum)
+
+		state := CreateEmptyState()
+		state.Version = VersionBaseline
+		testutil.StoreInstruction(state.Memory, state.GetPC(), 0x00_00_00_0C) // syscall instruction
+		testutil.SetSyscallArgs(state.GetRegistersRef(), syscallNum, 0, 0x80080)
+		vm := NewInstrumentedState(state, nil, io.Discard, io.Discard, nil)
+		_, err := vm.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, Word(0), state.Registers[arch.RegSyscallRet], "syscall %d result", syscallNum)
+		require.Equal(t, Word(0), state.Registers[arch.RegSyscallErrno], "syscall %d errno", syscallNum)
+	}
+}
+
+func TestInstrumentedState_BaselineFpuInstructions(t *testing.T) {
+	// Before VersionFPU, the floating-point instructions are illegal, also when executed in blocks
+	for _, insn := range []uint32{0x44_82_00_00, 0xC4_80_00_00} { // mtc1 $2, $f0; lwc1 $f0, 0($4)
+		for _, fast := range []bool{false, true} {
+			state := CreateEmptyState()
+			state.Version = VersionNetpoll
+			testutil.StoreInstruction(state.Memory, 0, insn)
+			_, preHash := state.EncodeWitness()
+
+			vm := NewInstrumentedState(state, nil, io.Discard, io.Discard, nil)
+			vm.SetFastExecution(fast)
+			err := vm.RunSteps(1)
+			require.ErrorIs(t, err, mipsevm.ErrIllegalInstruction, "insn %08x", insn)
+			_, postHash := state.EncodeWitness()
+			require.Equal(t, preHash, postHash)
+		}
+	}
+}
+
+func TestInstrumentedState_FaultLeavesStateUnchanged(t *testing.T) {
+	state := CreateEmptyState()
+	testutil.StoreInstruction(state.Memory, 0, 0xFF_FF_FF_FF)
+	state.Step = 10
+	_, preHash := state.EncodeWitness()
+
+	vm := NewInstrumentedState(state, nil, io.Discard, io.Discard, nil)
+	for i := 0; i < 2; i++ {
+		_, err := vm.Step(true)
+		require.ErrorIs(t, err, mipsevm.ErrIllegalInstruction)
+		_, postHash := state.EncodeWitness()
+		require.Equal(t, preHash, postHash, "faulting step must not change the state")
+		require.Equal(t, uint64(10), state.Step)
+	}
+}
@@ -1,23 +1,52 @@
 package singlethreaded
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 )
 
+var implementedSyscalls = []exec.Word{exec.SysMmap, exec.SysBrk, exec.SysClone, exec.SysExitGroup, exec.SysRead, exec.SysWrite, exec.SysFcntl}
+
+// latestSyscallPolicy matches the syscall handling of MIPS.sol, which ignores any syscall without a handler.
+var latestSyscallPolicy = exec.NewSyscallPolicy(exec.SyscallNoop, map[exec.SyscallAction][]exec.Word{
+	exec.SyscallImplemented: append([]exec.Word{
+		exec.SysEpollCreate1, exec.SysPipe2, exec.SysEpollCtl, exec.SysEpollPwait, exec.SysEventFd2}, implementedSyscalls...),
+})
+
+// baselineSyscallPolicy is the syscall handling before VersionNetpoll, which also ignores the network poller syscalls.
+var baselineSyscallPolicy = exec.NewSyscallPolicy(exec.SyscallNoop, map[exec.SyscallAction][]exec.Word{
+	exec.SyscallImplemented: implementedSyscalls,
+})
+
+// GetSyscallPolicy returns the way the VM handles each syscall number, for states of the given version.
+func GetSyscallPolicy(version mipsevm.StateVersion) *exec.SyscallPolicy {
+	if version < VersionNetpoll {
+		return baselineSyscallPolicy
+	}
+	return latestSyscallPolicy
+}
+
 func (m *InstrumentedState) handleSyscall() error {
 	syscallNum, a0, a1, a2, _ := exec.GetSyscallArgs(&m.state.Registers)
+	if m.state.hasNetpoll() && exec.IsNetpollSyscall(syscallNum, a0) {
+		v0, v1 := exec.HandleNetpollSyscall(syscallNum, a0, a1)
+		exec.HandleSyscallUpdates(&m.state.Cpu, &m.state.Registers, v0, v1)
+		return nil
+	}
 
-	v0 := uint32(0)
-	v1 := uint32(0)
+	v0 := Word(0)
+	v1 := Word(0)
 
 	//fmt.Printf("syscall: %d\n", syscallNum)
 	switch syscallNum {
 	case exec.SysMmap:
-		var newHeap uint32
+		var newHeap Word
 		v0, v1, newHeap = exec.HandleSysMmap(a0, a1, m.state.Heap)
 		m.state.Heap = newHeap
 	case exec.SysBrk:
@@ -42,26 +71,49 @@ func (m *InstrumentedState) handleSyscall() error {
 		m.state.PreimageOffset = newPreimageOffset
 	case exec.SysFcntl:
 		v0, v1 = exec.HandleSysFcntl(a0, a1)
+	default:
+		if err := m.unknownSyscalls.Check(syscallNum, m.state.Cpu.PC); err != nil {
+			return err
+		}
+		var ok bool
+		if v0, v1, ok = m.syscallPolicy.HandleUnimplemented(syscallNum); !ok {
+			panic(fmt.Sprintf("unrecognized syscall: %d", syscallNum))
+		}
 	}
 
 	exec.HandleSyscallUpdates(&m.state.Cpu, &m.state.Registers, v0, v1)
 	return nil
 }
 
-func (m *InstrumentedState) mipsStep() error {
+func (m *InstrumentedState) mipsStep() (err error) {
 	if m.state.Exited {
 		return nil
 	}
 	m.state.Step += 1
+	// A faulting step is not completed: instructions fault before changing the state, and the step isn't counted.
+	defer func() {
+		if err != nil {
+			m.state.Step -= 1
+		}
+	}()
 	// instruction fetch
 	insn, opcode, fun := exec.GetInstructionDetails(m.state.Cpu.PC, m.state.Memory)
+	if m.tracer != nil {
+		m.tracer.OnInstruction(m.state, m.state.GetPC(), insn)
+	}
 
 	// Handle syscall separately
 	// syscall (can read and write)
 	if opcode == 0 && fun == 0xC {
-		return m.handleSyscall()
+		return m.syscallStats.Track(&m.state.Registers, m.handleSyscall)
+	}
+
+	if m.strict {
+		if err := exec.CheckAlignment(m.state.Cpu.PC, insn, opcode, &m.state.Registers); err != nil {
+			return err
+		}
 	}
 
 	// Exec the rest of the step logic
-	return exec.ExecMipsCoreStepLogic(&m.state.Cpu, &m.state.Registers, m.state.Memory, insn, opcode, fun, m.memoryTracker, m.stackTracker)
+	return exec.ExecMipsCoreStepLogic(&m.state.Cpu, &m.state.Registers, m.state.getFpuRef(), m.state.Memory, insn, opcode, fun, m.memoryTracker, m.stackTracker)
 }
@@ -4,19 +4,50 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
-	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 )
 
+type Word = arch.Word
+
+// The versions of the singlethreaded VM state.
+const (
+	// VersionBaseline is the original VM.
+	VersionBaseline mipsevm.StateVersion = iota
+	// VersionNetpoll adds the file descriptors of the Go runtime network poller, see exec.HandleNetpollSyscall.
+	VersionNetpoll
+	// VersionFPU adds the floating-point coprocessor, and its registers to the state witness.
+	// Before, the floating-point instructions are illegal.
+	VersionFPU
+
+	// LatestVersion is the version of newly created states, and the STATE_VERSION implemented by MIPS.sol.
+	LatestVersion = VersionFPU
+)
+
 // STATE_WITNESS_SIZE is the size of the state witness encoding in bytes.
-const STATE_WITNESS_SIZE = 226
+const STATE_WITNESS_SIZE = FPU_WITNESS_OFFSET + 4 + 32*arch.WordSizeBytes
+
+// BASELINE_STATE_WITNESS_SIZE is the size of the state witness before VersionFPU, which ends before the FPU state.
+const BASELINE_STATE_WITNESS_SIZE = FPU_WITNESS_OFFSET
+
+// EXITCODE_WITNESS_OFFSET is the offset of the exit code in the state witness,
+// after the memory root, pre-image key and offset, cpu scalars and heap.
+const EXITCODE_WITNESS_OFFSET = 32*2 + 4 + 5*arch.WordSizeBytes
+
+// FPU_WITNESS_OFFSET is the offset of the FPU state (FCSR, then the floating-point registers) in the state witness,
+// after the exit code, exited flag, step and registers.
+const FPU_WITNESS_OFFSET = EXITCODE_WITNESS_OFFSET + 1 + 1 + 8 + 32*arch.WordSizeBytes
 
 type State struct {
+	// Version of the state, see LatestVersion. States serialized without a version are VersionBaseline states.
+	Version mipsevm.StateVersion `json:"version"`
+
 	Memory *memory.Memory `json:"memory"`
 
 	PreimageKey    common.Hash `json:"preimageKey"`
@@ -24,14 +55,16 @@ type State struct {
 
 	Cpu mipsevm.CpuScalars `json:"cpu"`
 
-	Heap uint32 `json:"heap"` // to handle mmap growth
+	Heap Word `json:"heap"` // to handle mmap growth
 
 	ExitCode uint8 `json:"exit"`
 	Exited   bool  `json:"exited"`
 
 	Step uint64 `json:"step"`
 
-	Registers [32]uint32 `json:"registers"`
+	Registers [32]Word `json:"registers"`
+
+	Fpu mipsevm.FpuState `json:"fpu"`
 
 	// LastHint is optional metadata, and not part of the VM state itself.
 	LastHint hexutil.Bytes `json:"lastHint,omitempty"`
@@ -41,6 +74,7 @@ var _ mipsevm.FPVMState = (*State)(nil)
 
 func CreateEmptyState() *State {
 	return &State{
+		Version: LatestVersion,
 		Cpu: mipsevm.CpuScalars{
 			PC:     0,
 			NextPC: 4,
@@ -48,7 +82,7 @@ func CreateEmptyState() *State {
 			HI:     0,
 		},
 		Heap:      0,
-		Registers: [32]uint32{},
+		Registers: [32]Word{},
 		Memory:    memory.NewMemory(),
 		ExitCode:  0,
 		Exited:    false,
@@ -56,7 +90,7 @@ func CreateEmptyState() *State {
 	}
 }
 
-func CreateInitialState(pc, heapStart uint32) *State {
+func CreateInitialState(pc, heapStart Word) *State {
 	state := CreateEmptyState()
 	state.Cpu.PC = pc
 	state.Cpu.NextPC = pc + 4
@@ -66,23 +100,26 @@ func CreateInitialState(pc, heapStart uint32) *State {
 }
 
 type stateMarshaling struct {
-	Memory         *memory.Memory `json:"memory"`
-	PreimageKey    common.Hash    `json:"preimageKey"`
-	PreimageOffset uint32         `json:"preimageOffset"`
-	PC             uint32         `json:"pc"`
-	NextPC         uint32         `json:"nextPC"`
-	LO             uint32         `json:"lo"`
-	HI             uint32         `json:"hi"`
-	Heap           uint32         `json:"heap"`
-	ExitCode       uint8          `json:"exit"`
-	Exited         bool           `json:"exited"`
-	Step           uint64         `json:"step"`
-	Registers      [32]uint32     `json:"registers"`
-	LastHint       hexutil.Bytes  `json:"lastHint,omitempty"`
+	Version        mipsevm.StateVersion `json:"version"`
+	Memory         *memory.Memory       `json:"memory"`
+	PreimageKey    common.Hash          `json:"preimageKey"`
+	PreimageOffset uint32               `json:"preimageOffset"`
+	PC             Word                 `json:"pc"`
+	NextPC         Word                 `json:"nextPC"`
+	LO             Word                 `json:"lo"`
+	HI             Word                 `json:"hi"`
+	Heap           Word                 `json:"heap"`
+	ExitCode       uint8                `json:"exit"`
+	Exited         bool                 `json:"exited"`
+	Step           uint64               `json:"step"`
+	Registers      [32]Word             `json:"registers"`
+	Fpu            mipsevm.FpuState     `json:"fpu"`
+	LastHint       hexutil.Bytes        `json:"lastHint,omitempty"`
 }
 
 func (s *State) MarshalJSON() ([]byte, error) { // nosemgrep
 	sm := &stateMarshaling{
+		Version:        s.Version,
 		Memory:         s.Memory,
 		PreimageKey:    s.PreimageKey,
 		PreimageOffset: s.PreimageOffset,
@@ -95,6 +132,7 @@ func (s *State) MarshalJSON() ([]byte, error) { // nosemgrep
 		Exited:         s.Exited,
 		Step:           s.Step,
 		Registers:      s.Registers,
+		Fpu:            s.Fpu,
 		LastHint:       s.LastHint,
 	}
 	return json.Marshal(sm)
@@ -105,6 +143,7 @@ func (s *State) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, sm); err != nil {
 		return err
 	}
+	s.Version = sm.Version
 	s.Memory = sm.Memory
 	s.PreimageKey = sm.PreimageKey
 	s.PreimageOffset = sm.PreimageOffset
@@ -117,15 +156,56 @@ func (s *State) UnmarshalJSON(data []byte) error {
 	s.Exited = sm.Exited
 	s.Step = sm.Step
 	s.Registers = sm.Registers
+	s.Fpu = sm.Fpu
 	s.LastHint = sm.LastHint
 	return nil
 }
 
-func (s *State) GetPC() uint32 { return s.Cpu.PC }
+func (s *State) Snapshot() mipsevm.FPVMState {
+	snapshot := *s
+	snapshot.Memory = s.Memory.Copy()
+	snapshot.LastHint = slices.Clone(s.LastHint)
+	return &snapshot
+}
+
+func (s *State) Restore(snapshot mipsevm.FPVMState) error {
+	src, ok := snapshot.(*State)
+	if !ok {
+		return fmt.Errorf("cannot restore %T snapshot to %T state", snapshot, s)
+	}
+	mem := s.Memory
+	*s = *src
+	s.Memory = mem
+	s.Memory.CopyFrom(src.Memory)
+	s.LastHint = slices.Clone(src.LastHint)
+	return nil
+}
+
+// hasNetpoll returns whether the VM implements the file descriptors of the Go runtime network poller.
+func (s *State) hasNetpoll() bool {
+	return s.Version >= VersionNetpoll
+}
+
+// hasFPU returns whether the VM has the floating-point coprocessor.
+func (s *State) hasFPU() bool {
+	return s.Version >= VersionFPU
+}
+
+// getFpuRef returns the floating-point registers, or nil if the VM has no FPU.
+func (s *State) getFpuRef() *mipsevm.FpuState {
+	if !s.hasFPU() {
+		return nil
+	}
+	return &s.Fpu
+}
+
+func (s *State) GetPC() Word { return s.Cpu.PC }
 
 func (s *State) GetCpu() mipsevm.CpuScalars { return s.Cpu }
 
-func (s *State) GetRegistersRef() *[32]uint32 { return &s.Registers }
+func (s *State) GetRegistersRef() *[32]Word { return &s.Registers }
+
+func (s *State) GetFpuRef() *mipsevm.FpuState { return &s.Fpu }
 
 func (s *State) GetExitCode() uint8 { return s.ExitCode }
 
@@ -145,7 +225,7 @@ func (s *State) GetMemory() *memory.Memory {
 	return s.Memory
 }
 
-func (s *State) GetHeap() uint32 {
+func (s *State) GetHeap() Word {
 	return s.Heap
 }
 
@@ -163,43 +243,63 @@ func (s *State) EncodeWitness() ([]byte, common.Hash) {
 	out = append(out, memRoot[:]...)
 	out = append(out, s.PreimageKey[:]...)
 	out = binary.BigEndian.AppendUint32(out, s.PreimageOffset)
-	out = binary.BigEndian.AppendUint32(out, s.Cpu.PC)
-	out = binary.BigEndian.AppendUint32(out, s.Cpu.NextPC)
-	out = binary.BigEndian.AppendUint32(out, s.Cpu.LO)
-	out = binary.BigEndian.AppendUint32(out, s.Cpu.HI)
-	out = binary.BigEndian.AppendUint32(out, s.Heap)
+	out = arch.ByteOrderWord.AppendWord(out, s.Cpu.PC)
+	out = arch.ByteOrderWord.AppendWord(out, s.Cpu.NextPC)
+	out = arch.ByteOrderWord.AppendWord(out, s.Cpu.LO)
+	out = arch.ByteOrderWord.AppendWord(out, s.Cpu.HI)
+	out = arch.ByteOrderWord.AppendWord(out, s.Heap)
 	out = append(out, s.ExitCode)
 	out = mipsevm.AppendBoolToWitness(out, s.Exited)
 	out = binary.BigEndian.AppendUint64(out, s.Step)
 	for _, r := range s.Registers {
-		out = binary.BigEndian.AppendUint32(out, r)
+		out = arch.ByteOrderWord.AppendWord(out, r)
+	}
+	if s.hasFPU() {
+		out = binary.BigEndian.AppendUint32(out, s.Fpu.FCSR)
+		for _, r := range s.Fpu.FPR {
+			out = arch.ByteOrderWord.AppendWord(out, r)
+		}
 	}
 	return out, stateHashFromWitness(out)
 }
 
+// StateWitness is the witness of a state of any version: the layout is identified by the witness length.
 type StateWitness []byte
 
 func (sw StateWitness) StateHash() (common.Hash, error) {
-	if len(sw) != STATE_WITNESS_SIZE {
-		return common.Hash{}, fmt.Errorf("Invalid witness length. Got %d, expected %d", len(sw), STATE_WITNESS_SIZE)
+	return sw.StateHashWithBackend(mipsevm.KeccakHash)
+}
+
+// StateHashWithBackend commits to the witness with the given hash backend instead of keccak.
+func (sw StateWitness) StateHashWithBackend(backend mipsevm.HashBackend) (common.Hash, error) {
+	if len(sw) != STATE_WITNESS_SIZE && len(sw) != BASELINE_STATE_WITNESS_SIZE {
+		return common.Hash{}, fmt.Errorf("Invalid witness length. Got %d, expected %d or %d", len(sw), STATE_WITNESS_SIZE, BASELINE_STATE_WITNESS_SIZE)
 	}
-	return stateHashFromWitness(sw), nil
+	return stateHashFromWitnessWithBackend(sw, backend), nil
 }
 
 func GetStateHashFn() mipsevm.HashFn {
+	return GetStateHashFnWithBackend(mipsevm.KeccakHash)
+}
+
+// GetStateHashFnWithBackend returns a HashFn that commits to state witnesses with the given hash backend.
+func GetStateHashFnWithBackend(backend mipsevm.HashBackend) mipsevm.HashFn {
 	return func(sw []byte) (common.Hash, error) {
-		return StateWitness(sw).StateHash()
+		return StateWitness(sw).StateHashWithBackend(backend)
 	}
 }
 
 func stateHashFromWitness(sw []byte) common.Hash {
-	if len(sw) != STATE_WITNESS_SIZE {
+	return stateHashFromWitnessWithBackend(sw, mipsevm.KeccakHash)
+}
+
+func stateHashFromWitnessWithBackend(sw []byte, backend mipsevm.HashBackend) common.Hash {
+	if len(sw) != STATE_WITNESS_SIZE && len(sw) != BASELINE_STATE_WITNESS_SIZE {
 		panic("Invalid witness length")
 	}
-	hash := crypto.Keccak256Hash(sw)
-	offset := 32*2 + 4*6
-	exitCode := sw[offset]
-	exited := sw[offset+1]
+	hash := backend(sw)
+	exitCode := sw[EXITCODE_WITNESS_OFFSET]
+	exited := sw[EXITCODE_WITNESS_OFFSET+1]
 	status := mipsevm.VmStatus(exited == 1, exitCode)
 	hash[0] = status
 	return hash
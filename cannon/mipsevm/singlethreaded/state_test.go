@@ -1,9 +1,12 @@
+//go:build !cannon64
+
 package singlethreaded
 
 import (
 	"debug/elf"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
@@ -30,17 +33,21 @@ func TestStateHash(t *testing.T) {
 	}
 
 	exitedOffset := 32*2 + 4*6
+	fpuOffset := exitedOffset + 1 + 1 + 8 + 32*4
 	for _, c := range cases {
 		state := &State{
+			Version:  LatestVersion,
 			Memory:   memory.NewMemory(),
 			Exited:   c.exited,
 			ExitCode: c.exitCode,
 		}
+		state.Fpu.FCSR = 0x00800000
+		state.Fpu.FPR[1] = 0x3F800000
 
 		actualWitness, actualStateHash := state.EncodeWitness()
 		require.Equal(t, len(actualWitness), STATE_WITNESS_SIZE, "Incorrect witness size")
 
-		expectedWitness := make(StateWitness, 226)
+		expectedWitness := make(StateWitness, 358)
 		memRoot := state.Memory.MerkleRoot()
 		copy(expectedWitness[:32], memRoot[:])
 		expectedWitness[exitedOffset] = c.exitCode
@@ -49,6 +56,8 @@ func TestStateHash(t *testing.T) {
 			exited = 1
 		}
 		expectedWitness[exitedOffset+1] = uint8(exited)
+		copy(expectedWitness[fpuOffset:], []byte{0x00, 0x80, 0x00, 0x00})
+		copy(expectedWitness[fpuOffset+4+4:], []byte{0x3F, 0x80, 0x00, 0x00})
 		require.EqualValues(t, expectedWitness[:], actualWitness[:], "Incorrect witness")
 
 		expectedStateHash := crypto.Keccak256Hash(actualWitness)
@@ -57,11 +66,39 @@ func TestStateHash(t *testing.T) {
 	}
 }
 
+func TestStateHash_Baseline(t *testing.T) {
+	state := &State{
+		Version:  VersionNetpoll,
+		Memory:   memory.NewMemory(),
+		Exited:   true,
+		ExitCode: 2,
+	}
+	// the FPU state is not part of the witness before VersionFPU
+	state.Fpu.FCSR = 0x00800000
+
+	latest := *state
+	latest.Version = LatestVersion
+	latestWitness, _ := latest.EncodeWitness()
+
+	actualWitness, actualStateHash := state.EncodeWitness()
+	require.Len(t, actualWitness, BASELINE_STATE_WITNESS_SIZE)
+	require.Equal(t, latestWitness[:FPU_WITNESS_OFFSET], actualWitness)
+
+	expectedStateHash := crypto.Keccak256Hash(actualWitness)
+	expectedStateHash[0] = mipsevm.VmStatus(true, 2)
+	require.Equal(t, expectedStateHash, actualStateHash)
+	stateHash, err := StateWitness(actualWitness).StateHash()
+	require.NoError(t, err)
+	require.Equal(t, expectedStateHash, stateHash)
+}
+
 func TestStateJSONCodec(t *testing.T) {
 	elfProgram, err := elf.Open("../../testdata/example/bin/hello.elf")
 	require.NoError(t, err, "open ELF file")
 	state, err := program.LoadELF(elfProgram, CreateInitialState)
 	require.NoError(t, err, "load ELF into state")
+	state.Fpu.FCSR = 0x00800000
+	state.Fpu.FPR[2] = 0x3FF00000
 
 	stateJSON, err := state.MarshalJSON()
 	require.NoError(t, err)
@@ -69,6 +106,7 @@ func TestStateJSONCodec(t *testing.T) {
 	newState := new(State)
 	require.NoError(t, newState.UnmarshalJSON(stateJSON))
 
+	require.Equal(t, LatestVersion, newState.Version)
 	require.Equal(t, state.PreimageKey, newState.PreimageKey)
 	require.Equal(t, state.PreimageOffset, newState.PreimageOffset)
 	require.Equal(t, state.Cpu, newState.Cpu)
@@ -77,5 +115,55 @@ func TestStateJSONCodec(t *testing.T) {
 	require.Equal(t, state.Exited, newState.Exited)
 	require.Equal(t, state.Memory.MerkleRoot(), newState.Memory.MerkleRoot())
 	require.Equal(t, state.Registers, newState.Registers)
+	require.Equal(t, state.Fpu, newState.Fpu)
 	require.Equal(t, state.Step, newState.Step)
 }
+
+func TestStateSnapshotRestore(t *testing.T) {
+	state := CreateInitialState(0x1000, 0x2000)
+	state.Memory.SetMemory(0x1000, 0xaabbccdd)
+	state.Registers[3] = 33
+	state.Fpu.FPR[1] = 0x3F800000
+	state.PreimageKey = crypto.Keccak256Hash([]byte{1, 2, 3, 4})
+	state.PreimageOffset = 4
+	state.Step = 99
+	state.LastHint = []byte{11, 12, 13}
+	expectedWitness, expectedHash := state.EncodeWitness()
+
+	snapshot := state.Snapshot()
+	_, snapshotHash := snapshot.EncodeWitness()
+	require.Equal(t, expectedHash, snapshotHash)
+
+	// the snapshot shares nothing with the state
+	mem := state.Memory
+	state.Memory.SetMemory(0x1000, 1)
+	state.Memory.SetMemory(0x5000, 2)
+	state.Registers[3] = 0
+	state.Fpu.FPR[1] = 0
+	state.PreimageOffset = 8
+	state.Step = 100
+	state.LastHint[0] = 0
+	_, snapshotHash = snapshot.EncodeWitness()
+	require.Equal(t, expectedHash, snapshotHash)
+
+	require.NoError(t, state.Restore(snapshot))
+	witness, hash := state.EncodeWitness()
+	require.Equal(t, expectedWitness, witness)
+	require.Equal(t, expectedHash, hash)
+	require.Equal(t, hexutil.Bytes{11, 12, 13}, state.LastHint)
+	require.Same(t, mem, state.Memory, "memory is restored in place")
+
+	// the snapshot can be restored again
+	state.Memory.SetMemory(0x1000, 1)
+	require.NoError(t, state.Restore(snapshot))
+	_, hash = state.EncodeWitness()
+	require.Equal(t, expectedHash, hash)
+
+	var other mipsevm.FPVMState = &otherState{}
+	require.ErrorContains(t, state.Restore(other), "cannot restore")
+}
+
+// otherState is a state of another VM
+type otherState struct {
+	mipsevm.FPVMState
+}
@@ -0,0 +1,65 @@
+package mipsevm
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+)
+
+var ErrStateLimitExceeded = errors.New("state limit exceeded")
+
+const (
+	// pageSerializedSizeBound is an upper bound of the JSON-serialized size of a single memory page:
+	// the base64 encoding of the zlib-compressed page data (which for incompressible data is a little larger
+	// than the page itself), plus the page index and JSON object overhead.
+	pageSerializedSizeBound = (memory.PageSize+16+2)/3*4 + 32
+	// stateSerializedOverhead is an upper bound of the JSON-serialized size of everything but memory pages:
+	// registers, thread stacks and other scalars.
+	stateSerializedOverhead = 16 * 1024
+	// pageFootprint is the host memory used by a single page, including its merkle cache.
+	pageFootprint = uint64(unsafe.Sizeof(memory.CachedPage{}))
+)
+
+// StateSize is an estimate of the resources required to hold and serialize a VM state.
+type StateSize struct {
+	// Pages is the number of allocated memory pages
+	Pages int
+	// MemoryUsed is the size of the allocated VM memory
+	MemoryUsed uint64
+	// Footprint is an estimate of the host memory used by the VM memory, including the page merkle caches.
+	// Compressed pages only use the size of their compressed data, and evicted pages none.
+	Footprint uint64
+	// SerializedSize is an upper bound of the size of the JSON-serialized state
+	SerializedSize uint64
+}
+
+// EstimateStateSize estimates the memory and serialized size of the given state.
+func EstimateStateSize(state FPVMState) StateSize {
+	mem := state.GetMemory()
+	pages := mem.PageCount()
+	return StateSize{
+		Pages:          pages,
+		MemoryUsed:     mem.UsageRaw(),
+		Footprint:      uint64(pages-mem.CompressedPageCount()-mem.EvictedPageCount())*pageFootprint + mem.CompressedSize(),
+		SerializedSize: uint64(pages)*pageSerializedSizeBound + stateSerializedOverhead + uint64(len(state.GetLastHint()))*2,
+	}
+}
+
+// StateLimits are limits on the size of a VM state. A limit of 0 is not enforced.
+type StateLimits struct {
+	MaxPages          int
+	MaxSerializedSize uint64
+}
+
+// Check returns an error wrapping ErrStateLimitExceeded if the state size exceeds the limits.
+func (l StateLimits) Check(size StateSize) error {
+	if l.MaxPages != 0 && size.Pages > l.MaxPages {
+		return fmt.Errorf("%w: %d memory pages exceeds limit of %d", ErrStateLimitExceeded, size.Pages, l.MaxPages)
+	}
+	if l.MaxSerializedSize != 0 && size.SerializedSize > l.MaxSerializedSize {
+		return fmt.Errorf("%w: estimated serialized size of %d bytes exceeds limit of %d", ErrStateLimitExceeded, size.SerializedSize, l.MaxSerializedSize)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package mipsevm_test
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func TestEstimateStateSize(t *testing.T) {
+	states := map[string]mipsevm.FPVMState{
+		"singlethreaded": singlethreaded.CreateEmptyState(),
+		"multithreaded":  multithreaded.CreateEmptyState(),
+	}
+	for name, state := range states {
+		t.Run(name, func(t *testing.T) {
+			// Random data doesn't compress, so the serialized pages are as large as they get.
+			rng := rand.New(rand.NewSource(1234))
+			for i := mipsevm.Word(0); i < 10; i++ {
+				page := state.GetMemory().AllocPage(i * 7)
+				rng.Read(page.Data[:])
+			}
+			size := mipsevm.EstimateStateSize(state)
+			require.Equal(t, 10, size.Pages)
+			require.Equal(t, uint64(10*memory.PageSize), size.MemoryUsed)
+			require.Greater(t, size.Footprint, size.MemoryUsed)
+
+			data, err := json.Marshal(state)
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, size.SerializedSize, uint64(len(data)))
+		})
+	}
+}
+
+func TestEstimateStateSizeCompressed(t *testing.T) {
+	state := singlethreaded.CreateEmptyState()
+	for i := mipsevm.Word(0); i < 10; i++ {
+		state.GetMemory().SetMemory(i*memory.PageSize, i)
+	}
+	size := mipsevm.EstimateStateSize(state)
+	state.GetMemory().Compress(memory.PageCompressionSnappy)
+	compressedSize := mipsevm.EstimateStateSize(state)
+	require.Equal(t, size.Pages, compressedSize.Pages)
+	require.Equal(t, size.MemoryUsed, compressedSize.MemoryUsed)
+	require.Equal(t, size.SerializedSize, compressedSize.SerializedSize)
+	// Nearly empty pages compress to a small fraction of their footprint
+	require.Less(t, compressedSize.Footprint*10, size.Footprint)
+}
+
+func TestStateLimits(t *testing.T) {
+	size := mipsevm.StateSize{Pages: 100, SerializedSize: 1000}
+	require.NoError(t, mipsevm.StateLimits{}.Check(size))
+	require.NoError(t, mipsevm.StateLimits{MaxPages: 100, MaxSerializedSize: 1000}.Check(size))
+	require.ErrorIs(t, mipsevm.StateLimits{MaxPages: 99}.Check(size), mipsevm.ErrStateLimitExceeded)
+	require.ErrorIs(t, mipsevm.StateLimits{MaxSerializedSize: 999}.Check(size), mipsevm.ErrStateLimitExceeded)
+}
@@ -1,10 +1,22 @@
 package mipsevm
 
+import "github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+
+type Word = arch.Word
+
 type CpuScalars struct {
-	PC     uint32 `json:"pc"`
-	NextPC uint32 `json:"nextPC"`
-	LO     uint32 `json:"lo"`
-	HI     uint32 `json:"hi"`
+	PC     Word `json:"pc"`
+	NextPC Word `json:"nextPC"`
+	LO     Word `json:"lo"`
+	HI     Word `json:"hi"`
+}
+
+// FpuState is the state of the floating-point coprocessor (CP1).
+// In 32-bit builds the FPU runs with FR=0: a double occupies an even/odd register pair,
+// with the low word in the even register. In 64-bit builds every register holds a double (FR=1).
+type FpuState struct {
+	FCSR uint32   `json:"fcsr"`
+	FPR  [32]Word `json:"fpr"`
 }
 
 const (
@@ -0,0 +1,73 @@
+package mipsevm
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// HashBackend commits to an encoded state witness.
+// The VM status is applied to the resulting hash by the state implementations, regardless of the backend.
+type HashBackend func(sw []byte) common.Hash
+
+// KeccakHashBackend is the name of the backend used by the on-chain VMs.
+const KeccakHashBackend = "keccak"
+
+// KeccakHash is the state hash backend used by the on-chain VMs.
+func KeccakHash(sw []byte) common.Hash {
+	return crypto.Keccak256Hash(sw)
+}
+
+var ErrUnknownHashBackend = errors.New("unknown state hash backend")
+
+var (
+	hashBackendsLock sync.RWMutex
+	hashBackends     = map[string]HashBackend{
+		KeccakHashBackend: KeccakHash,
+	}
+)
+
+// RegisterHashBackend makes a state hash backend available by name,
+// allowing proof systems that commit to the state differently (e.g. poseidon for zk proofs) to reuse the VM and witness generation.
+func RegisterHashBackend(name string, backend HashBackend) error {
+	if name == "" {
+		return errors.New("empty state hash backend name")
+	}
+	if backend == nil {
+		return fmt.Errorf("nil state hash backend %q", name)
+	}
+	hashBackendsLock.Lock()
+	defer hashBackendsLock.Unlock()
+	if _, ok := hashBackends[name]; ok {
+		return fmt.Errorf("state hash backend %q already registered", name)
+	}
+	hashBackends[name] = backend
+	return nil
+}
+
+// LookupHashBackend returns the registered state hash backend with the given name.
+func LookupHashBackend(name string) (HashBackend, error) {
+	hashBackendsLock.RLock()
+	defer hashBackendsLock.RUnlock()
+	backend, ok := hashBackends[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownHashBackend, name)
+	}
+	return backend, nil
+}
+
+// HashBackendNames returns the names of all registered state hash backends, sorted.
+func HashBackendNames() []string {
+	hashBackendsLock.RLock()
+	defer hashBackendsLock.RUnlock()
+	names := make([]string, 0, len(hashBackends))
+	for name := range hashBackends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,78 @@
+package mipsevm_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+func TestHashBackends(t *testing.T) {
+	t.Run("KeccakRegisteredByDefault", func(t *testing.T) {
+		backend, err := mipsevm.LookupHashBackend(mipsevm.KeccakHashBackend)
+		require.NoError(t, err)
+		require.Equal(t, crypto.Keccak256Hash([]byte{1, 2, 3}), backend([]byte{1, 2, 3}))
+		require.Contains(t, mipsevm.HashBackendNames(), mipsevm.KeccakHashBackend)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := mipsevm.LookupHashBackend("unknown")
+		require.ErrorIs(t, err, mipsevm.ErrUnknownHashBackend)
+	})
+
+	t.Run("Register", func(t *testing.T) {
+		backend := func(sw []byte) common.Hash {
+			return common.Hash{0xff, byte(len(sw))}
+		}
+		require.NoError(t, mipsevm.RegisterHashBackend("test-register", backend))
+		actual, err := mipsevm.LookupHashBackend("test-register")
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{0xff, 3}, actual([]byte{1, 2, 3}))
+		require.Contains(t, mipsevm.HashBackendNames(), "test-register")
+	})
+
+	t.Run("RejectDuplicate", func(t *testing.T) {
+		require.Error(t, mipsevm.RegisterHashBackend(mipsevm.KeccakHashBackend, mipsevm.KeccakHash))
+	})
+
+	t.Run("RejectInvalid", func(t *testing.T) {
+		require.Error(t, mipsevm.RegisterHashBackend("", mipsevm.KeccakHash))
+		require.Error(t, mipsevm.RegisterHashBackend("test-nil", nil))
+	})
+}
+
+func TestStateHashFnWithBackend(t *testing.T) {
+	backend := func(sw []byte) common.Hash {
+		return common.Hash{0xaa, 0xbb}
+	}
+	cases := []struct {
+		name   string
+		state  mipsevm.FPVMState
+		hashFn func(mipsevm.HashBackend) mipsevm.HashFn
+	}{
+		{"singlethreaded", singlethreaded.CreateEmptyState(), singlethreaded.GetStateHashFnWithBackend},
+		{"multithreaded", multithreaded.CreateEmptyState(), multithreaded.GetStateHashFnWithBackend},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			witness, keccakHash := c.state.EncodeWitness()
+
+			hash, err := c.hashFn(mipsevm.KeccakHash)(witness)
+			require.NoError(t, err)
+			require.Equal(t, keccakHash, hash)
+
+			hash, err = c.hashFn(backend)(witness)
+			require.NoError(t, err)
+			// The VM status is still applied to the first byte.
+			require.Equal(t, common.Hash{mipsevm.VMStatusUnfinished, 0xbb}, hash)
+
+			_, err = c.hashFn(backend)(witness[1:])
+			require.Error(t, err)
+		})
+	}
+}
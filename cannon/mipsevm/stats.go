@@ -0,0 +1,24 @@
+package mipsevm
+
+import "time"
+
+// Stats are statistics of the execution of a VM, to understand where the guest time goes.
+// They are not part of the state: they count what the VM executed since it was created.
+type Stats struct {
+	// Syscalls are the statistics of each syscall number executed, by ascending number.
+	Syscalls []SyscallStat `json:"syscalls"`
+}
+
+type SyscallStat struct {
+	Num Word `json:"num"`
+	// Count is the number of times the syscall was executed.
+	Count uint64 `json:"count"`
+	// BytesRead and BytesWritten are the bytes returned by the read and write syscalls.
+	BytesRead    uint64 `json:"bytesRead,omitempty"`
+	BytesWritten uint64 `json:"bytesWritten,omitempty"`
+	// PreimageReads is the number of reads of the pre-image oracle, and PreimageBytes the bytes they returned.
+	PreimageReads uint64 `json:"preimageReads,omitempty"`
+	PreimageBytes uint64 `json:"preimageBytes,omitempty"`
+	// Duration is the host time spent handling the syscall, including the pre-image oracle requests.
+	Duration time.Duration `json:"duration"`
+}
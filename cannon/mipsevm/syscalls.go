@@ -0,0 +1,33 @@
+package mipsevm
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrUnknownSyscall = errors.New("unknown syscall")
+
+// UnknownSyscallMode is the way a VM reports the syscalls it doesn't know, i.e. that its syscall policy doesn't list.
+// The mode doesn't change how unknown syscalls are executed: they are handled with the fallback action of the policy,
+// like the contract handles them. It only decides whether the Go VM reports them, or refuses to execute them.
+type UnknownSyscallMode string
+
+const (
+	// UnknownSyscallNoop executes unknown syscalls without reporting them. This is the default.
+	UnknownSyscallNoop UnknownSyscallMode = "noop"
+	// UnknownSyscallWarn logs a warning the first time each unknown syscall number is executed.
+	UnknownSyscallWarn UnknownSyscallMode = "warn"
+	// UnknownSyscallHalt fails the step of an unknown syscall with an error wrapping ErrUnknownSyscall,
+	// to find the syscalls a program relies on that the VM doesn't implement.
+	UnknownSyscallHalt UnknownSyscallMode = "halt"
+)
+
+// ParseUnknownSyscallMode parses the name of an UnknownSyscallMode.
+func ParseUnknownSyscallMode(s string) (UnknownSyscallMode, error) {
+	switch mode := UnknownSyscallMode(s); mode {
+	case UnknownSyscallNoop, UnknownSyscallWarn, UnknownSyscallHalt:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown syscall mode %q, expected %q, %q or %q", s, UnknownSyscallNoop, UnknownSyscallWarn, UnknownSyscallHalt)
+	}
+}
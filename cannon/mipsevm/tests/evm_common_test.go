@@ -2,8 +2,11 @@ package tests
 
 import (
 	"bytes"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
 	"path"
 	"strings"
@@ -13,17 +16,22 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/tracing"
-	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
 
 func TestEVM(t *testing.T) {
+	if !arch.IsMips32 {
+		t.Skip("the open_mips_tests programs are built for 32-bit MIPS")
+	}
 	testFiles, err := os.ReadDir("open_mips_tests/test/bin")
 	require.NoError(t, err)
 
@@ -38,6 +46,8 @@ func TestEVM(t *testing.T) {
 	for _, c := range cases {
 		skipped, exists := skippedTests[c.Name]
 		require.True(t, exists)
+		// Deploy the contracts once per version, and reset the state for every test case.
+		evm := testutil.NewMIPSEVM(c.Contracts)
 		for _, f := range testFiles {
 			testName := fmt.Sprintf("%v (%v)", f.Name(), c.Name)
 			t.Run(testName, func(t *testing.T) {
@@ -50,9 +60,9 @@ func TestEVM(t *testing.T) {
 				oracle := testutil.SelectOracleFixture(t, f.Name())
 				// Short-circuit early for exit_group.bin
 				exitGroup := f.Name() == "exit_group.bin"
-				expectPanic := strings.HasSuffix(f.Name(), "panic.bin")
+				expectFault := strings.HasSuffix(f.Name(), "panic.bin")
 
-				evm := testutil.NewMIPSEVM(c.Contracts)
+				evm.Reset()
 				evm.SetTracer(tracer)
 				evm.SetLocalOracle(oracle)
 				testutil.LogStepFailureAtCleanup(t, evm)
@@ -67,18 +77,7 @@ func TestEVM(t *testing.T) {
 				require.NoError(t, err, "load program into state")
 
 				// set the return address ($ra) to jump into when test completes
-				state.GetRegistersRef()[31] = testutil.EndAddr
-
-				// Catch panics and check if they are expected
-				defer func() {
-					if r := recover(); r != nil {
-						if expectPanic {
-							// Success
-						} else {
-							t.Errorf("unexpected panic: %v", r)
-						}
-					}
-				}()
+				state.GetRegistersRef()[arch.RegRA] = testutil.EndAddr
 
 				for i := 0; i < 1000; i++ {
 					curStep := goVm.GetState().GetStep()
@@ -88,30 +87,31 @@ func TestEVM(t *testing.T) {
 					if exitGroup && goVm.GetState().GetExited() {
 						break
 					}
-					insn := state.GetMemory().GetMemory(state.GetPC())
+					insn := state.GetMemory().GetUint32(state.GetPC())
 					t.Logf("step: %4d pc: 0x%08x insn: 0x%08x", state.GetStep(), state.GetPC(), insn)
 
 					stepWitness, err := goVm.Step(true)
+					if expectFault && errors.Is(err, mipsevm.ErrFault) {
+						break
+					}
 					require.NoError(t, err)
-					evmPost := evm.Step(t, stepWitness, curStep, c.StateHashFn)
 					// verify the post-state matches.
-					// TODO: maybe more readable to decode the evmPost state, and do attribute-wise comparison.
 					goPost, _ := goVm.GetState().EncodeWitness()
-					require.Equalf(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+					evm.ValidateStep(t, stepWitness, curStep, c.StateHashFn, goPost,
 						"mipsevm produced different state than EVM at step %d", state.GetStep())
 				}
 				if exitGroup {
-					require.NotEqual(t, uint32(testutil.EndAddr), goVm.GetState().GetPC(), "must not reach end")
+					require.NotEqual(t, Word(testutil.EndAddr), goVm.GetState().GetPC(), "must not reach end")
 					require.True(t, goVm.GetState().GetExited(), "must set exited state")
 					require.Equal(t, uint8(1), goVm.GetState().GetExitCode(), "must exit with 1")
-				} else if expectPanic {
-					require.NotEqual(t, uint32(testutil.EndAddr), state.GetPC(), "must not reach end")
+				} else if expectFault {
+					require.NotEqual(t, Word(testutil.EndAddr), state.GetPC(), "must not reach end")
 				} else {
-					require.Equal(t, uint32(testutil.EndAddr), state.GetPC(), "must reach end")
+					require.Equal(t, Word(testutil.EndAddr), state.GetPC(), "must reach end")
 					// inspect test result
 					done, result := state.GetMemory().GetMemory(testutil.BaseAddrEnd+4), state.GetMemory().GetMemory(testutil.BaseAddrEnd+8)
-					require.Equal(t, done, uint32(1), "must be done")
-					require.Equal(t, result, uint32(1), "must have success result")
+					require.Equal(t, done, Word(1), "must be done")
+					require.Equal(t, result, Word(1), "must have success result")
 				}
 			})
 		}
@@ -124,8 +124,8 @@ func TestEVMSingleStep(t *testing.T) {
 	versions := GetMipsVersionTestCases(t)
 	cases := []struct {
 		name   string
-		pc     uint32
-		nextPC uint32
+		pc     Word
+		nextPC Word
 		insn   uint32
 	}{
 		{"j MSB set target", 0, 4, 0x0A_00_00_02},                         // j 0x02_00_00_02
@@ -135,24 +135,24 @@ func TestEVMSingleStep(t *testing.T) {
 	}
 
 	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
 		for _, tt := range cases {
 			testName := fmt.Sprintf("%v (%v)", tt.name, v.Name)
 			t.Run(testName, func(t *testing.T) {
 				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(tt.pc), WithNextPC(tt.nextPC))
 				state := goVm.GetState()
-				state.GetMemory().SetMemory(tt.pc, tt.insn)
+				testutil.StoreInstruction(state.GetMemory(), tt.pc, tt.insn)
 				curStep := state.GetStep()
 
 				stepWitness, err := goVm.Step(true)
 				require.NoError(t, err)
 
-				evm := testutil.NewMIPSEVM(v.Contracts)
+				evm.Reset()
 				evm.SetTracer(tracer)
 				testutil.LogStepFailureAtCleanup(t, evm)
 
-				evmPost := evm.Step(t, stepWitness, curStep, v.StateHashFn)
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, curStep, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			})
 		}
@@ -165,17 +165,17 @@ func TestEVM_MMap(t *testing.T) {
 	versions := GetMipsVersionTestCases(t)
 	cases := []struct {
 		name         string
-		heap         uint32
-		address      uint32
-		size         uint32
+		heap         Word
+		address      Word
+		size         Word
 		shouldFail   bool
-		expectedHeap uint32
+		expectedHeap Word
 	}{
-		{name: "Increment heap by max value", heap: program.HEAP_START, address: 0, size: ^uint32(0), shouldFail: true},
-		{name: "Increment heap to 0", heap: program.HEAP_START, address: 0, size: ^uint32(0) - program.HEAP_START + 1, shouldFail: true},
-		{name: "Increment heap to previous page", heap: program.HEAP_START, address: 0, size: ^uint32(0) - program.HEAP_START - memory.PageSize + 1, shouldFail: true},
-		{name: "Increment max page size", heap: program.HEAP_START, address: 0, size: ^uint32(0) & ^uint32(memory.PageAddrMask), shouldFail: true},
-		{name: "Increment max page size from 0", heap: 0, address: 0, size: ^uint32(0) & ^uint32(memory.PageAddrMask), shouldFail: true},
+		{name: "Increment heap by max value", heap: program.HEAP_START, address: 0, size: ^Word(0), shouldFail: true},
+		{name: "Increment heap to 0", heap: program.HEAP_START, address: 0, size: ^Word(0) - program.HEAP_START + 1, shouldFail: true},
+		{name: "Increment heap to previous page", heap: program.HEAP_START, address: 0, size: ^Word(0) - program.HEAP_START - memory.PageSize + 1, shouldFail: true},
+		{name: "Increment max page size", heap: program.HEAP_START, address: 0, size: ^Word(0) & ^Word(memory.PageAddrMask), shouldFail: true},
+		{name: "Increment max page size from 0", heap: 0, address: 0, size: ^Word(0) & ^Word(memory.PageAddrMask), shouldFail: true},
 		{name: "Increment heap at limit", heap: program.HEAP_END, address: 0, size: 1, shouldFail: true},
 		{name: "Increment heap to limit", heap: program.HEAP_END - memory.PageSize, address: 0, size: 1, shouldFail: false, expectedHeap: program.HEAP_END},
 		{name: "Increment heap within limit", heap: program.HEAP_END - 2*memory.PageSize, address: 0, size: 1, shouldFail: false, expectedHeap: program.HEAP_END - memory.PageSize},
@@ -189,27 +189,22 @@ func TestEVM_MMap(t *testing.T) {
 				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithHeap(c.heap))
 				state := goVm.GetState()
 
-				state.GetMemory().SetMemory(state.GetPC(), syscallInsn)
+				testutil.StoreInstruction(state.GetMemory(), state.GetPC(), syscallInsn)
 				*state.GetRegistersRef() = testutil.RandomRegisters(77)
-				state.GetRegistersRef()[2] = exec.SysMmap
-				state.GetRegistersRef()[4] = c.address
-				state.GetRegistersRef()[5] = c.size
+				testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysMmap, c.address, c.size)
 				step := state.GetStep()
 
 				expectedRegisters := testutil.CopyRegisters(state)
 				expectedHeap := state.GetHeap()
 				expectedMemoryRoot := state.GetMemory().MerkleRoot()
 				if c.shouldFail {
-					expectedRegisters[2] = exec.SysErrorSignal
-					expectedRegisters[7] = exec.MipsEINVAL
+					testutil.SetSyscallResult(expectedRegisters, exec.SysErrorSignal, exec.MipsEINVAL)
 				} else {
 					expectedHeap = c.expectedHeap
 					if c.address == 0 {
-						expectedRegisters[2] = state.GetHeap()
-						expectedRegisters[7] = 0
+						testutil.SetSyscallResult(expectedRegisters, state.GetHeap(), 0)
 					} else {
-						expectedRegisters[2] = c.address
-						expectedRegisters[7] = 0
+						testutil.SetSyscallResult(expectedRegisters, c.address, 0)
 					}
 				}
 
@@ -223,10 +218,10 @@ func TestEVM_MMap(t *testing.T) {
 				require.Equal(t, expectedMemoryRoot, state.GetMemory().MerkleRoot())
 				require.Equal(t, common.Hash{}, state.GetPreimageKey())
 				require.Equal(t, uint32(0), state.GetPreimageOffset())
-				require.Equal(t, uint32(4), state.GetCpu().PC)
-				require.Equal(t, uint32(8), state.GetCpu().NextPC)
-				require.Equal(t, uint32(0), state.GetCpu().HI)
-				require.Equal(t, uint32(0), state.GetCpu().LO)
+				require.Equal(t, Word(4), state.GetCpu().PC)
+				require.Equal(t, Word(8), state.GetCpu().NextPC)
+				require.Equal(t, Word(0), state.GetCpu().HI)
+				require.Equal(t, Word(0), state.GetCpu().LO)
 				require.Equal(t, false, state.GetExited())
 				require.Equal(t, uint8(0), state.GetExitCode())
 				require.Equal(t, hexutil.Bytes(nil), state.GetLastHint())
@@ -235,9 +230,8 @@ func TestEVM_MMap(t *testing.T) {
 				evm.SetTracer(tracer)
 				testutil.LogStepFailureAtCleanup(t, evm)
 
-				evmPost := evm.Step(t, stepWitness, step, v.StateHashFn)
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, step, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			})
 		}
@@ -399,14 +393,11 @@ func TestEVMSysWriteHint(t *testing.T) {
 				oracle := hintTrackingOracle{}
 				goVm := v.VMFactory(&oracle, os.Stdout, os.Stderr, testutil.CreateLogger(), WithLastHint(tt.lastHint))
 				state := goVm.GetState()
-				state.GetRegistersRef()[2] = exec.SysWrite
-				state.GetRegistersRef()[4] = exec.FdHintWrite
-				state.GetRegistersRef()[5] = uint32(tt.memOffset)
-				state.GetRegistersRef()[6] = uint32(tt.bytesToWrite)
+				testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysWrite, exec.FdHintWrite, Word(tt.memOffset), Word(tt.bytesToWrite))
 
-				err := state.GetMemory().SetMemoryRange(uint32(tt.memOffset), bytes.NewReader(tt.hintData))
+				err := state.GetMemory().SetMemoryRange(Word(tt.memOffset), bytes.NewReader(tt.hintData))
 				require.NoError(t, err)
-				state.GetMemory().SetMemory(0, insn)
+				testutil.StoreInstruction(state.GetMemory(), 0, insn)
 				curStep := state.GetStep()
 
 				stepWitness, err := goVm.Step(true)
@@ -417,9 +408,8 @@ func TestEVMSysWriteHint(t *testing.T) {
 				evm.SetTracer(tracer)
 				testutil.LogStepFailureAtCleanup(t, evm)
 
-				evmPost := evm.Step(t, stepWitness, curStep, v.StateHashFn)
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, curStep, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			})
 		}
@@ -428,33 +418,41 @@ func TestEVMSysWriteHint(t *testing.T) {
 
 func TestEVMFault(t *testing.T) {
 	var tracer *tracing.Hooks // no-tracer by default, but see test_util.MarkdownTracer
-	sender := common.Address{0x13, 0x37}
 
 	versions := GetMipsVersionTestCases(t)
 	cases := []struct {
 		name   string
-		nextPC uint32
+		nextPC Word
 		insn   uint32
+		err    error
 	}{
-		{"illegal instruction", 0, 0xFF_FF_FF_FF},
-		{"branch in delay-slot", 8, 0x11_02_00_03},
-		{"jump in delay-slot", 8, 0x0c_00_00_0c},
+		{"illegal instruction", 0, 0xEC_00_00_00, mipsevm.ErrIllegalInstruction}, // opcode 0x3B is undefined
+		{"branch in delay-slot", 8, 0x11_02_00_03, mipsevm.ErrBranchInDelaySlot},
+		{"jump in delay-slot", 8, 0x0c_00_00_0c, mipsevm.ErrJumpInDelaySlot},
 	}
 
 	for _, v := range versions {
 		for _, tt := range cases {
 			testName := fmt.Sprintf("%v (%v)", tt.name, v.Name)
 			t.Run(testName, func(t *testing.T) {
-				env, evmState := testutil.NewEVMEnv(v.Contracts)
-				env.Config.Tracer = tracer
+				evm := testutil.NewMIPSEVM(v.Contracts)
+				evm.SetTracer(tracer)
 
 				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithNextPC(tt.nextPC))
 				state := goVm.GetState()
-				state.GetMemory().SetMemory(0, tt.insn)
+				testutil.StoreInstruction(state.GetMemory(), 0, tt.insn)
 				// set the return address ($ra) to jump into when test completes
-				state.GetRegistersRef()[31] = testutil.EndAddr
-
-				require.Panics(t, func() { _, _ = goVm.Step(true) })
+				state.GetRegistersRef()[arch.RegRA] = testutil.EndAddr
+				preWitness, _ := state.EncodeWitness()
+
+				_, err := goVm.Step(true)
+				require.ErrorIs(t, err, tt.err)
+				var fault *mipsevm.FaultError
+				require.ErrorAs(t, err, &fault)
+				require.Equal(t, Word(0), fault.PC)
+				require.Equal(t, tt.insn, fault.Insn)
+				postWitness, _ := state.EncodeWitness()
+				require.Equal(t, hexutil.Bytes(preWitness).String(), hexutil.Bytes(postWitness).String(), "faulting step must not change the state")
 
 				insnProof := state.GetMemory().MerkleProof(0)
 				encodedWitness, _ := state.EncodeWitness()
@@ -462,13 +460,260 @@ func TestEVMFault(t *testing.T) {
 					State:     encodedWitness,
 					ProofData: insnProof[:],
 				}
-				input := testutil.EncodeStepInput(t, stepWitness, mipsevm.LocalContext{}, v.Contracts.Artifacts.MIPS)
-				startingGas := uint64(30_000_000)
+				evm.StepReverts(t, stepWitness)
+			})
+		}
+	}
+}
+
+// TestEVM_StrictMode checks that unaligned loads and stores match the contract by default,
+// and that in strict mode the Go VM faults on those that must be aligned.
+func TestEVM_StrictMode(t *testing.T) {
+	var tracer *tracing.Hooks
+
+	// itype encodes a load or store of register $t2 at the offset from the address in $t0.
+	// The register is even, since ldc1 of an odd FPU register is illegal in the 32-bit VM.
+	itype := func(opcode uint32, offset uint32) uint32 {
+		return opcode<<26 | 8<<21 | 10<<16 | offset
+	}
+	versions := GetMipsVersionTestCases(t)
+	cases := []struct {
+		name   string
+		insn   uint32
+		faults bool
+	}{
+		{"lw aligned", itype(0x23, 4), false},
+		{"lw", itype(0x23, 2), true},
+		{"lh", itype(0x21, 1), true},
+		{"lh aligned", itype(0x21, 2), false},
+		{"sw", itype(0x2B, 3), true},
+		{"ll", itype(exec.OpLoadLinked, 1), true},
+		{"lwc1", itype(exec.OpLoadWordCop1, 2), true},
+		{"ldc1", itype(exec.OpLoadDoubleCop1, 4), true},
+		{"lb", itype(0x20, 3), false},
+		{"lwl", itype(0x22, 1), false},
+		{"swr", itype(0x2E, 2), false},
+	}
+	for _, v := range versions {
+		for _, tt := range cases {
+			for _, strict := range []bool{false, true} {
+				testName := fmt.Sprintf("%v strict=%v (%v)", tt.name, strict, v.Name)
+				t.Run(testName, func(t *testing.T) {
+					opts := []VMOption{WithPC(0), WithNextPC(4)}
+					if strict {
+						opts = append(opts, WithStrictMode())
+					}
+					goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), opts...)
+					state := goVm.GetState()
+					testutil.StoreInstruction(state.GetMemory(), 0, tt.insn)
+					state.GetRegistersRef()[8] = 0x100
+					state.GetRegistersRef()[10] = 0x12345678
+
+					stepWitness, err := goVm.Step(true)
+					if strict && tt.faults {
+						require.ErrorIs(t, err, mipsevm.ErrUnalignedAccess)
+						return
+					}
+					require.NoError(t, err)
+
+					evm := testutil.NewMIPSEVM(v.Contracts)
+					evm.SetTracer(tracer)
+					testutil.LogStepFailureAtCleanup(t, evm)
+					goPost, _ := goVm.GetState().EncodeWitness()
+					evm.ValidateStep(t, stepWitness, 0, v.StateHashFn, goPost,
+						"mipsevm produced different state than EVM")
+				})
+			}
+		}
+	}
+}
+
+// TestEVM_SyscallPolicy checks that the Go VM and the contract agree on the handling of every syscall number.
+func TestEVM_SyscallPolicy(t *testing.T) {
+	var tracer *tracing.Hooks
+
+	const insn = uint32(0x00_00_00_0C) // syscall instruction
+	versions := GetMipsVersionTestCases(t)
+	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
+		// The syscall numbers of the o32 and n64 ABIs start at 4000 and 5000 respectively
+		firstSyscall := Word(4000)
+		if !arch.IsMips32 {
+			firstSyscall = 5000
+		}
+		for syscallNum := firstSyscall; syscallNum <= firstSyscall+400; syscallNum++ {
+			action := v.SyscallPolicy.Action(syscallNum)
+			testName := fmt.Sprintf("%d %v (%v)", syscallNum, action, v.Name)
+			t.Run(testName, func(t *testing.T) {
+				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger())
+				state := goVm.GetState()
+				testutil.StoreInstruction(state.GetMemory(), state.GetPC(), insn)
+				testutil.SetSyscallArgs(state.GetRegistersRef(), syscallNum)
+				curStep := state.GetStep()
+
+				if action == exec.SyscallAbort {
+					// The witness is encoded up-front, since the Go VM panics before returning it
+					var proofData []byte
+					if mtState, ok := state.(*multithreaded.State); ok {
+						proofData = append(proofData, mtState.EncodeThreadProof()...)
+					}
+					insnProof := state.GetMemory().MerkleProof(state.GetPC())
+					proofData = append(proofData, insnProof[:]...)
+					proofData = append(proofData, insnProof[:]...)
+					encodedWitness, _ := state.EncodeWitness()
+					stepWitness := &mipsevm.StepWitness{
+						State:     encodedWitness,
+						ProofData: proofData,
+					}
+					require.Panics(t, func() { _, _ = goVm.Step(true) })
+
+					evm.Reset()
+					evm.SetTracer(tracer)
+					reason := evm.StepReverts(t, stepWitness)
+					require.Contains(t, reason, "unimplemented syscall")
+					return
+				}
+
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				switch action {
+				case exec.SyscallNoop:
+					require.Equal(t, Word(0), state.GetRegistersRef()[arch.RegSyscallRet])
+					require.Equal(t, Word(0), state.GetRegistersRef()[arch.RegSyscallErrno])
+				case exec.SyscallENOSYS:
+					require.Equal(t, exec.SysErrorSignal, state.GetRegistersRef()[arch.RegSyscallRet])
+					require.Equal(t, Word(exec.MipsENOSYS), state.GetRegistersRef()[arch.RegSyscallErrno])
+				}
+
+				evm.Reset()
+				evm.SetTracer(tracer)
+				testutil.LogStepFailureAtCleanup(t, evm)
+
+				goPost, _ := goVm.GetState().EncodeWitness()
+				evm.ValidateStep(t, stepWitness, curStep, v.StateHashFn, goPost,
+					"mipsevm produced different state than EVM")
+			})
+		}
+	}
+}
+
+// TestEVM_UnknownSyscallMode checks that reporting unknown syscalls doesn't change their execution,
+// and that in halt mode the Go VM fails the step instead of executing them.
+func TestEVM_UnknownSyscallMode(t *testing.T) {
+	var tracer *tracing.Hooks
+
+	const insn = uint32(0x00_00_00_0C) // syscall instruction
+	versions := GetMipsVersionTestCases(t)
+	for _, v := range versions {
+		// Find a syscall that the policy doesn't list
+		syscallNum := Word(4000)
+		if !arch.IsMips32 {
+			syscallNum = 5000
+		}
+		for v.SyscallPolicy.Known(syscallNum) {
+			syscallNum++
+		}
+		action := v.SyscallPolicy.Action(syscallNum)
+
+		t.Run(fmt.Sprintf("halt-%v", v.Name), func(t *testing.T) {
+			goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger())
+			goVm.SetUnknownSyscallMode(mipsevm.UnknownSyscallHalt, testutil.CreateLogger())
+			state := goVm.GetState()
+			testutil.StoreInstruction(state.GetMemory(), state.GetPC(), insn)
+			testutil.SetSyscallArgs(state.GetRegistersRef(), syscallNum)
+			_, err := goVm.Step(true)
+			require.ErrorIs(t, err, mipsevm.ErrUnknownSyscall)
+		})
+
+		if action == exec.SyscallAbort {
+			// The Go VM panics after warning, see TestEVM_SyscallPolicy
+			continue
+		}
+		t.Run(fmt.Sprintf("warn-%v", v.Name), func(t *testing.T) {
+			logger, logs := testlog.CaptureLogger(t, slog.LevelWarn)
+			goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger())
+			goVm.SetUnknownSyscallMode(mipsevm.UnknownSyscallWarn, logger)
+			state := goVm.GetState()
+			evm := testutil.NewMIPSEVM(v.Contracts)
+			evm.SetTracer(tracer)
+			testutil.LogStepFailureAtCleanup(t, evm)
 
-				_, _, err := env.Call(vm.AccountRef(sender), v.Contracts.Addresses.MIPS, input, startingGas, common.U2560)
-				require.EqualValues(t, err, vm.ErrExecutionReverted)
-				logs := evmState.Logs()
-				require.Equal(t, 0, len(logs))
+			// The warning is logged once, for the first of the steps executing the syscall
+			for i := 0; i < 2; i++ {
+				testutil.StoreInstruction(state.GetMemory(), state.GetPC(), insn)
+				testutil.SetSyscallArgs(state.GetRegistersRef(), syscallNum)
+				curStep := state.GetStep()
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+
+				goPost, _ := goVm.GetState().EncodeWitness()
+				evm.ValidateStep(t, stepWitness, curStep, v.StateHashFn, goPost,
+					"mipsevm produced different state than EVM")
+			}
+			require.Len(t, logs.FindLogs(testlog.NewMessageFilter("Unknown syscall")), 1)
+		})
+	}
+}
+
+// TestEVM_NetpollSyscalls pins the results of the syscalls of the Go runtime network poller.
+// It initializes with epoll_create1, eventfd2 and epoll_ctl, and aborts if any of them fail, so they return dedicated
+// file descriptors. netpollBreak writes to the eventfd, and accepts EAGAIN. pipe2 is not supported.
+func TestEVM_NetpollSyscalls(t *testing.T) {
+	var tracer *tracing.Hooks
+
+	const insn = uint32(0x00_00_00_0C) // syscall instruction
+	const bufAddr = Word(0x1000)
+	cases := []struct {
+		name       string
+		syscallNum Word
+		args       []Word
+		ret        Word
+		errno      Word
+	}{
+		{"epoll_create1", exec.SysEpollCreate1, []Word{0x80000}, exec.FdEpoll, 0},
+		{"epoll_create1 no flags", exec.SysEpollCreate1, []Word{0}, exec.FdEpoll, 0},
+		{"eventfd2", exec.SysEventFd2, []Word{0, 0x80080}, exec.FdEventFd, 0},
+		{"eventfd2 nonblocking only", exec.SysEventFd2, []Word{0, exec.EfdNonBlock}, exec.FdEventFd, 0},
+		{"eventfd2 initial value", exec.SysEventFd2, []Word{1, 0x80080}, exec.FdEventFd, 0},
+		{"eventfd2 blocking", exec.SysEventFd2, []Word{0, 0x80000}, exec.SysErrorSignal, exec.MipsEINVAL},
+		{"epoll_ctl", exec.SysEpollCtl, []Word{exec.FdEpoll, 1, exec.FdEventFd, bufAddr}, 0, 0},
+		{"epoll_ctl bad fd", exec.SysEpollCtl, []Word{exec.FdStdin, 1, exec.FdEventFd, bufAddr}, exec.SysErrorSignal, exec.MipsEBADF},
+		{"epoll_pwait", exec.SysEpollPwait, []Word{exec.FdEpoll, bufAddr, 128, 0}, 0, 0},
+		{"epoll_pwait bad fd", exec.SysEpollPwait, []Word{exec.FdEventFd, bufAddr, 128, 0}, exec.SysErrorSignal, exec.MipsEBADF},
+		{"eventfd write", exec.SysWrite, []Word{exec.FdEventFd, bufAddr, 8}, exec.SysErrorSignal, exec.MipsEAGAIN},
+		{"eventfd read", exec.SysRead, []Word{exec.FdEventFd, bufAddr, 8}, exec.SysErrorSignal, exec.MipsEAGAIN},
+		// the epoll instance is not read or written, so these are handled like any unknown file descriptor
+		{"epoll write", exec.SysWrite, []Word{exec.FdEpoll, bufAddr, 8}, exec.SysErrorSignal, exec.MipsEBADF},
+		{"epoll read", exec.SysRead, []Word{exec.FdEpoll, bufAddr, 8}, exec.SysErrorSignal, exec.MipsEBADF},
+		{"pipe2", exec.SysPipe2, []Word{bufAddr, 0}, exec.SysErrorSignal, exec.MipsENOSYS},
+	}
+
+	versions := GetMipsVersionTestCases(t)
+	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
+		for _, tt := range cases {
+			testName := fmt.Sprintf("%v (%v)", tt.name, v.Name)
+			t.Run(testName, func(t *testing.T) {
+				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger())
+				state := goVm.GetState()
+				testutil.StoreInstruction(state.GetMemory(), state.GetPC(), insn)
+				state.GetMemory().SetMemory(bufAddr, 0xaabbccdd)
+				testutil.SetSyscallArgs(state.GetRegistersRef(), tt.syscallNum, tt.args...)
+				curStep := state.GetStep()
+
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				require.Equal(t, tt.ret, state.GetRegistersRef()[arch.RegSyscallRet], "result")
+				require.Equal(t, tt.errno, state.GetRegistersRef()[arch.RegSyscallErrno], "errno")
+				require.Equal(t, Word(0xaabbccdd), state.GetMemory().GetMemory(bufAddr), "memory is not written")
+
+				evm.Reset()
+				evm.SetTracer(tracer)
+				testutil.LogStepFailureAtCleanup(t, evm)
+
+				goPost, _ := goVm.GetState().EncodeWitness()
+				evm.ValidateStep(t, stepWitness, curStep, v.StateHashFn, goPost,
+					"mipsevm produced different state than EVM")
 			})
 		}
 	}
@@ -485,7 +730,7 @@ func TestHelloEVM(t *testing.T) {
 			testutil.LogStepFailureAtCleanup(t, evm)
 
 			var stdOutBuf, stdErrBuf bytes.Buffer
-			elfFile := "../../testdata/example/bin/hello.elf"
+			elfFile := testutil.ProgramPath("hello")
 			goVm := v.ElfVMFactory(t, elfFile, nil, io.MultiWriter(&stdOutBuf, os.Stdout), io.MultiWriter(&stdErrBuf, os.Stderr), testutil.CreateLogger())
 			state := goVm.GetState()
 
@@ -495,18 +740,16 @@ func TestHelloEVM(t *testing.T) {
 				if goVm.GetState().GetExited() {
 					break
 				}
-				insn := state.GetMemory().GetMemory(state.GetPC())
+				insn := state.GetMemory().GetUint32(state.GetPC())
 				if i%1000 == 0 { // avoid spamming test logs, we are executing many steps
 					t.Logf("step: %4d pc: 0x%08x insn: 0x%08x", state.GetStep(), state.GetPC(), insn)
 				}
 
 				stepWitness, err := goVm.Step(true)
 				require.NoError(t, err)
-				evmPost := evm.Step(t, stepWitness, curStep, v.StateHashFn)
 				// verify the post-state matches.
-				// TODO: maybe more readable to decode the evmPost state, and do attribute-wise comparison.
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, curStep, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			}
 			end := time.Now()
@@ -535,7 +778,7 @@ func TestClaimEVM(t *testing.T) {
 			oracle, expectedStdOut, expectedStdErr := testutil.ClaimTestOracle(t)
 
 			var stdOutBuf, stdErrBuf bytes.Buffer
-			elfFile := "../../testdata/example/bin/claim.elf"
+			elfFile := testutil.ProgramPath("claim")
 			goVm := v.ElfVMFactory(t, elfFile, oracle, io.MultiWriter(&stdOutBuf, os.Stdout), io.MultiWriter(&stdErrBuf, os.Stderr), testutil.CreateLogger())
 			state := goVm.GetState()
 
@@ -545,7 +788,7 @@ func TestClaimEVM(t *testing.T) {
 					break
 				}
 
-				insn := state.GetMemory().GetMemory(state.GetPC())
+				insn := state.GetMemory().GetUint32(state.GetPC())
 				if i%1000 == 0 { // avoid spamming test logs, we are executing many steps
 					t.Logf("step: %4d pc: 0x%08x insn: 0x%08x", state.GetStep(), state.GetPC(), insn)
 				}
@@ -553,10 +796,8 @@ func TestClaimEVM(t *testing.T) {
 				stepWitness, err := goVm.Step(true)
 				require.NoError(t, err)
 
-				evmPost := evm.Step(t, stepWitness, curStep, v.StateHashFn)
-
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, curStep, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			}
 
@@ -580,3 +821,17 @@ func (t *hintTrackingOracle) Hint(v []byte) {
 func (t *hintTrackingOracle) GetPreimage(k [32]byte) []byte {
 	return nil
 }
+
+var updateContractHashes = flag.Bool("update-contract-hashes", false, "record the current contract bytecode, see `make contract-hashes`")
+
+// TestContractArtifactHashes checks the contracts used by the differential tests against the recorded bytecode hashes.
+// `make contract-hashes` runs it with -update-contract-hashes, to record the current contract bytecode instead.
+func TestContractArtifactHashes(t *testing.T) {
+	versions := []testutil.MipsVersion{testutil.MipsSingleThreaded, testutil.MipsMultithreaded}
+	if *updateContractHashes {
+		testutil.RecordArtifactHashes(t, versions...)
+	}
+	for _, version := range versions {
+		testutil.TestContractsSetup(t, version)
+	}
+}
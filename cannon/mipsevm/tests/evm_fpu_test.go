@@ -0,0 +1,532 @@
+//go:build !cannon64
+
+package tests
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+const (
+	fmtS = 16
+	fmtD = 17
+	fmtW = 20
+	fmtL = 21
+
+	// COP1 rs field values of the moves and branches
+	cop1Mfc1 = 0
+	cop1Cfc1 = 2
+	cop1Mtc1 = 4
+	cop1Ctc1 = 6
+	cop1Bc   = 8
+
+	fpuCanonicalNaNS = 0x7FBFFFFF
+	fpuCanonicalNaND = 0x7FF7FFFFFFFFFFFF
+	fcc0             = 1 << 23
+)
+
+// cop1 encodes a COP1 instruction. The format is the fmt field of arithmetic instructions,
+// or the rs field selecting a move or branch.
+func cop1(format, ft, fs, fd, fun uint32) uint32 {
+	return exec.OpCop1<<26 | format<<21 | ft<<16 | fs<<11 | fd<<6 | fun
+}
+
+// cop1Compare encodes c.cond.fmt, writing the condition code cc.
+func cop1Compare(format, cond, ft, fs, cc uint32) uint32 {
+	return cop1(format, ft, fs, cc<<2, 0x30|cond)
+}
+
+// setFpr sets a single or word in an FPR, or a double or long in an even/odd FPR pair.
+func setFpr(fpu *mipsevm.FpuState, r uint32, val uint64, double bool) {
+	fpu.FPR[r] = Word(uint32(val))
+	if double {
+		fpu.FPR[r+1] = Word(val >> 32)
+	}
+}
+
+type fpuArithmeticTestCase struct {
+	name string
+	insn uint32
+	// fs and ft are the operands in $f2 and $f4, and expected is the result in $f6
+	fs, ft   uint64
+	expected uint64
+	// srcDouble and dstDouble are set if the operands and result, respectively, occupy register pairs
+	srcDouble, dstDouble bool
+}
+
+func fpuOp(name string, format, fun uint32, fs, ft, expected uint64) fpuArithmeticTestCase {
+	double := format == fmtD || format == fmtL
+	return fpuArithmeticTestCase{name, cop1(format, 4, 2, 6, fun), fs, ft, expected, double, double}
+}
+
+func fpuConvert(name string, format, fun uint32, fs uint64, expected uint64, dstDouble bool) fpuArithmeticTestCase {
+	return fpuArithmeticTestCase{name, cop1(format, 0, 2, 6, fun), fs, 0, expected, format == fmtD || format == fmtL, dstDouble}
+}
+
+var fpuArithmeticTestCases = []fpuArithmeticTestCase{
+	fpuOp("add.s", fmtS, 0x0, 0x3F800000, 0x40000000, 0x40400000),                                   // 1 + 2 = 3
+	fpuOp("add.s tie to even", fmtS, 0x0, 0x3F800000, 0x33800000, 0x3F800000),                       // 1 + 2^-24
+	fpuOp("add.s round up", fmtS, 0x0, 0x3F800000, 0x33800001, 0x3F800001),                          // 1 + (2^-24 + ulp)
+	fpuOp("add.s nan operand", fmtS, 0x0, 0x7F800001, 0x3F800000, fpuCanonicalNaNS),                 // sNaN + 1
+	fpuOp("sub.s inf", fmtS, 0x1, 0x7F800000, 0x7F800000, fpuCanonicalNaNS),                         // inf - inf
+	fpuOp("sub.s zero", fmtS, 0x1, 0x3F800000, 0x3F800000, 0x00000000),                              // 1 - 1 = +0
+	fpuOp("mul.s overflow", fmtS, 0x2, 0x7F7FFFFF, 0x40000000, 0x7F800000),                          // max * 2 = inf
+	fpuOp("mul.s underflow tie", fmtS, 0x2, 0x00000001, 0x3F000000, 0x00000000),                     // min subnormal / 2
+	fpuOp("mul.s subnormal tie", fmtS, 0x2, 0x00000003, 0x3F000000, 0x00000002),                     // 1.5 subnormal ulps
+	fpuOp("mul.s zero inf", fmtS, 0x2, 0x00000000, 0x7F800000, fpuCanonicalNaNS),                    // 0 * inf
+	fpuOp("div.s", fmtS, 0x3, 0x3F800000, 0x40400000, 0x3EAAAAAB),                                   // 1 / 3
+	fpuOp("div.s by zero", fmtS, 0x3, 0xBF800000, 0x00000000, 0xFF800000),                           // -1 / 0 = -inf
+	fpuOp("sqrt.s", fmtS, 0x4, 0x40000000, 0, 0x3FB504F3),                                           // sqrt(2)
+	fpuOp("sqrt.s negative zero", fmtS, 0x4, 0x80000000, 0, 0x80000000),                             // sqrt(-0) = -0
+	fpuOp("sqrt.s negative", fmtS, 0x4, 0xBF800000, 0, fpuCanonicalNaNS),                            // sqrt(-1)
+	fpuOp("abs.s nan", fmtS, 0x5, 0xFFC00000, 0, 0x7FC00000),                                        // only clears the sign
+	fpuOp("mov.s", fmtS, 0x6, 0xFFC00001, 0, 0xFFC00001),                                            // copies the bits
+	fpuOp("neg.s", fmtS, 0x7, 0x3F800000, 0, 0xBF800000),                                            // -1
+	fpuOp("add.d", fmtD, 0x0, 0x3FB999999999999A, 0x3FC999999999999A, 0x3FD3333333333334),           // 0.1 + 0.2
+	fpuOp("add.d nan operand", fmtD, 0x0, 0x7FF0000000000001, 0x3FF0000000000000, fpuCanonicalNaND), // sNaN + 1
+	fpuOp("sub.d", fmtD, 0x1, 0x3FF0000000000000, 0x3CA0000000000000, 0x3FEFFFFFFFFFFFFF),           // 1 - 2^-53
+	fpuOp("mul.d underflow tie", fmtD, 0x2, 0x0000000000000001, 0x3FE0000000000000, 0),              // min subnormal / 2
+	fpuOp("div.d", fmtD, 0x3, 0x3FF0000000000000, 0x4008000000000000, 0x3FD5555555555555),           // 1 / 3
+	fpuOp("div.d zero by zero", fmtD, 0x3, 0, 0x8000000000000000, fpuCanonicalNaND),                 // 0 / -0
+	fpuOp("sqrt.d", fmtD, 0x4, 0x4000000000000000, 0, 0x3FF6A09E667F3BCD),                           // sqrt(2)
+	fpuOp("abs.d", fmtD, 0x5, 0xBFF0000000000000, 0, 0x3FF0000000000000),                            // |-1|
+	fpuOp("neg.d", fmtD, 0x7, 0x0000000000000000, 0, 0x8000000000000000),                            // -(+0)
+	fpuOp("div.s zero by zero", fmtS, 0x3, 0x00000000, 0x00000000, fpuCanonicalNaNS),                // 0 / 0
+	fpuOp("sub.d nan operand", fmtD, 0x1, 0x3FF0000000000000, 0xFFF8000000000001, fpuCanonicalNaND), // 1 - -qNaN
+	fpuOp("mul.d inf zero", fmtD, 0x2, 0x7FF0000000000000, 0, fpuCanonicalNaND),                     // inf * 0
+	fpuOp("sqrt.d negative", fmtD, 0x4, 0xBFF0000000000000, 0, fpuCanonicalNaND),                    // sqrt(-1)
+
+	fpuConvert("cvt.s.d", fmtD, 0x20, 0x3FB999999999999A, 0x3DCCCCCD, false),                  // 0.1
+	fpuConvert("cvt.s.d overflow", fmtD, 0x20, 0x7E37E43C8800759C, 0x7F800000, false),         // 1e300 = inf
+	fpuConvert("cvt.s.w", fmtW, 0x20, 0x01000001, 0x4B800000, false),                          // 2^24 + 1 ties to 2^24
+	fpuConvert("cvt.s.l", fmtL, 0x20, 0xFFFFFFFFFFFFFFFF, 0xBF800000, false),                  // -1
+	fpuConvert("cvt.d.s", fmtS, 0x21, 0x3DCCCCCD, 0x3FB99999A0000000, true),                   // exact
+	fpuConvert("cvt.d.s nan", fmtS, 0x21, 0x7F800001, fpuCanonicalNaND, true),                 // sNaN
+	fpuConvert("cvt.d.w", fmtW, 0x21, 0x80000000, 0xC1E0000000000000, true),                   // -2^31
+	fpuConvert("cvt.d.l", fmtL, 0x21, 0x0020000000000001, 0x4340000000000000, true),           // 2^53 + 1 ties to 2^53
+	fpuConvert("cvt.w.s tie down", fmtS, 0x24, 0x40200000, 2, false),                          // 2.5
+	fpuConvert("cvt.w.s tie up", fmtS, 0x24, 0x40600000, 4, false),                            // 3.5
+	fpuConvert("cvt.w.d", fmtD, 0x24, 0xC004000000000000, 0xFFFFFFFE, false),                  // -2.5
+	fpuConvert("cvt.w.d overflow", fmtD, 0x24, 0x4202A05F20000000, 0x7FFFFFFF, false),         // 1e10
+	fpuConvert("cvt.l.d", fmtD, 0x25, 0x43D0000000000000, 0x4000000000000000, true),           // 2^62
+	fpuConvert("round.l.d", fmtD, 0x8, 0x3FE0000000000000, 0, true),                           // 0.5
+	fpuConvert("trunc.l.d overflow", fmtD, 0x9, 0x43E0000000000000, 0x7FFFFFFFFFFFFFFF, true), // 2^63
+	fpuConvert("trunc.l.s", fmtS, 0x9, 0xBFC00000, 0xFFFFFFFFFFFFFFFF, true),                  // -1.5
+	fpuConvert("ceil.l.d", fmtD, 0xA, 0x3FB999999999999A, 1, true),                            // 0.1
+	fpuConvert("floor.l.d negative zero", fmtD, 0xB, 0x8000000000000000, 0, true),             // -0
+	fpuConvert("round.w.s", fmtS, 0xC, 0x3F000000, 0, false),                                  // 0.5
+	fpuConvert("trunc.w.d", fmtD, 0xD, 0xC00599999999999A, 0xFFFFFFFE, false),                 // -2.7
+	fpuConvert("trunc.w.s min", fmtS, 0xD, 0xCF000000, 0x80000000, false),                     // -2^31
+	fpuConvert("trunc.w.s overflow", fmtS, 0xD, 0x4F000000, 0x7FFFFFFF, false),                // 2^31
+	fpuConvert("trunc.w.d nan", fmtD, 0xD, 0x7FF8000000000000, 0x7FFFFFFF, false),             // NaN
+	fpuConvert("ceil.w.d", fmtD, 0xE, 0x3FB999999999999A, 1, false),                           // 0.1
+	fpuConvert("floor.w.s", fmtS, 0xF, 0xBF000000, 0xFFFFFFFF, false),                         // -0.5
+	fpuConvert("cvt.s.d nan", fmtD, 0x20, 0xFFF8000000000001, fpuCanonicalNaNS, false),        // -qNaN
+
+	// invalid conversions to fixed point all result in the maximum positive integer
+	fpuConvert("cvt.w.s nan", fmtS, 0x24, 0x7FC00000, 0x7FFFFFFF, false),                        // NaN
+	fpuConvert("cvt.w.d negative overflow", fmtD, 0x24, 0xC202A05F20000000, 0x7FFFFFFF, false),  // -1e10
+	fpuConvert("round.w.d negative inf", fmtD, 0xC, 0xFFF0000000000000, 0x7FFFFFFF, false),      // -inf
+	fpuConvert("ceil.w.d min", fmtD, 0xE, 0xC1E0000000100000, 0x80000000, false),                // -2^31 - 0.5
+	fpuConvert("floor.w.d negative overflow", fmtD, 0xF, 0xC1E0000000100000, 0x7FFFFFFF, false), // -2^31 - 0.5
+	fpuConvert("cvt.l.d nan", fmtD, 0x25, 0x7FF8000000000000, 0x7FFFFFFFFFFFFFFF, true),         // NaN
+	fpuConvert("cvt.l.s inf", fmtS, 0x25, 0x7F800000, 0x7FFFFFFFFFFFFFFF, true),                 // inf
+	fpuConvert("trunc.l.d min", fmtD, 0x9, 0xC3E0000000000000, 0x8000000000000000, true),        // -2^63
+	fpuConvert("floor.l.d negative overflow", fmtD, 0xB, 0xC3E0000000000001, 0x7FFFFFFFFFFFFFFF, true),
+	fpuConvert("ceil.l.s nan", fmtS, 0xA, 0xFFC00000, 0x7FFFFFFFFFFFFFFF, true), // -NaN
+}
+
+func TestEVM_FpuArithmetic(t *testing.T) {
+	versions := GetMipsVersionTestCases(t)
+	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
+		for _, tt := range fpuArithmeticTestCases {
+			testName := fmt.Sprintf("%v (%v)", tt.name, v.Name)
+			t.Run(testName, func(t *testing.T) {
+				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(0), WithNextPC(4))
+				state := goVm.GetState()
+				testutil.StoreInstruction(state.GetMemory(), 0, tt.insn)
+				fpu := state.GetFpuRef()
+				setFpr(fpu, 2, tt.fs, tt.srcDouble)
+				setFpr(fpu, 4, tt.ft, tt.srcDouble)
+				expectedFpu := *fpu
+				setFpr(&expectedFpu, 6, tt.expected, tt.dstDouble)
+
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				require.Equal(t, expectedFpu, *state.GetFpuRef())
+				require.Equal(t, Word(4), state.GetPC())
+
+				evm.Reset()
+				testutil.LogStepFailureAtCleanup(t, evm)
+				validateFpuStep(t, v, evm, goVm, stepWitness, 0)
+			})
+		}
+	}
+}
+
+func TestEVM_FpuCompare(t *testing.T) {
+	versions := GetMipsVersionTestCases(t)
+	cases := []struct {
+		name     string
+		insn     uint32
+		fs, ft   uint64
+		double   bool
+		expected uint32 // the expected FCSR, starting from all condition codes set
+	}{
+		{"c.eq.s true", cop1Compare(fmtS, 0x2, 4, 2, 0), 0x3F800000, 0x3F800000, false, 0xFE800000},
+		{"c.eq.s false", cop1Compare(fmtS, 0x2, 4, 2, 0), 0x3F800000, 0x40000000, false, 0xFE000000},
+		{"c.eq.d zeros", cop1Compare(fmtD, 0x2, 4, 2, 0), 0, 0x8000000000000000, true, 0xFE800000},
+		{"c.olt.d true", cop1Compare(fmtD, 0x4, 4, 2, 0), 0x3FF0000000000000, 0x4000000000000000, true, 0xFE800000},
+		{"c.olt.d nan", cop1Compare(fmtD, 0x4, 4, 2, 0), 0x7FF8000000000000, 0x4000000000000000, true, 0xFE000000},
+		{"c.ult.d nan", cop1Compare(fmtD, 0x5, 4, 2, 0), 0x7FF8000000000000, 0x4000000000000000, true, 0xFE800000},
+		{"c.un.s", cop1Compare(fmtS, 0x1, 4, 2, 0), 0x3F800000, 0xFFC00000, false, 0xFE800000},
+		{"c.ole.s", cop1Compare(fmtS, 0x6, 4, 2, 0), 0x40000000, 0x40000000, false, 0xFE800000},
+		{"c.f.s", cop1Compare(fmtS, 0x0, 4, 2, 0), 0x40000000, 0x40000000, false, 0xFE000000},
+		{"c.ngt.d false", cop1Compare(fmtD, 0xF, 4, 2, 0), 0x4000000000000000, 0x3FF0000000000000, true, 0xFE000000},
+		{"c.lt.s cc3", cop1Compare(fmtS, 0xC, 4, 2, 3), 0x40000000, 0x3F800000, false, 0xF6800000},
+	}
+	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
+		for _, tt := range cases {
+			testName := fmt.Sprintf("%v (%v)", tt.name, v.Name)
+			t.Run(testName, func(t *testing.T) {
+				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(0), WithNextPC(4))
+				state := goVm.GetState()
+				testutil.StoreInstruction(state.GetMemory(), 0, tt.insn)
+				fpu := state.GetFpuRef()
+				fpu.FCSR = 0xFE800000
+				setFpr(fpu, 2, tt.fs, tt.double)
+				setFpr(fpu, 4, tt.ft, tt.double)
+
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, state.GetFpuRef().FCSR)
+
+				evm.Reset()
+				testutil.LogStepFailureAtCleanup(t, evm)
+				validateFpuStep(t, v, evm, goVm, stepWitness, 0)
+			})
+		}
+	}
+}
+
+func TestEVM_FpuBranch(t *testing.T) {
+	versions := GetMipsVersionTestCases(t)
+	cases := []struct {
+		name           string
+		cc             uint32
+		likely, onTrue bool
+		fcsr           uint32
+		expectedPC     Word
+		expectedNextPC Word
+	}{
+		{"bc1t taken", 0, false, true, fcc0, 0x104, 0x40},
+		{"bc1t not taken", 0, false, true, 0, 0x104, 0x108},
+		{"bc1f taken", 0, false, false, 0, 0x104, 0x40},
+		{"bc1f not taken", 0, false, false, fcc0, 0x104, 0x108},
+		{"bc1t cc7 taken", 7, false, true, 1 << 31, 0x104, 0x40},
+		{"bc1t cc7 not taken", 7, false, true, fcc0, 0x104, 0x108},
+		{"bc1tl taken", 0, true, true, fcc0, 0x104, 0x40},
+		// the delay slot is nullified
+		{"bc1tl not taken", 0, true, true, 0, 0x108, 0x10C},
+	}
+	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
+		for _, tt := range cases {
+			testName := fmt.Sprintf("%v (%v)", tt.name, v.Name)
+			t.Run(testName, func(t *testing.T) {
+				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(0x100), WithNextPC(0x104))
+				state := goVm.GetState()
+				ndtf := uint32(0)
+				if tt.likely {
+					ndtf |= 2
+				}
+				if tt.onTrue {
+					ndtf |= 1
+				}
+				// branch to 0x40, relative to the delay slot
+				offset := int32(0x40-0x104) >> 2
+				insn := exec.OpCop1<<26 | cop1Bc<<21 | tt.cc<<18 | ndtf<<16 | uint32(offset)&0xFFFF
+				testutil.StoreInstruction(state.GetMemory(), 0x100, insn)
+				state.GetFpuRef().FCSR = tt.fcsr
+
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedPC, state.GetPC(), "pc")
+				require.Equal(t, tt.expectedNextPC, state.GetCpu().NextPC, "next pc")
+
+				evm.Reset()
+				testutil.LogStepFailureAtCleanup(t, evm)
+				validateFpuStep(t, v, evm, goVm, stepWitness, 0)
+			})
+		}
+	}
+}
+
+func TestEVM_FpuMoves(t *testing.T) {
+	versions := GetMipsVersionTestCases(t)
+	cases := []struct {
+		name         string
+		insn         uint32
+		expectedReg  Word
+		expectedFpr  Word
+		expectedFCSR uint32
+	}{
+		// $t0 holds 0x3F800000, $f2 holds 0xBF800000 and the FCSR 0x00800000
+		{"mtc1", cop1(cop1Mtc1, 8, 2, 0, 0), 0x3F800000, 0x3F800000, 0x00800000},
+		{"mfc1", cop1(cop1Mfc1, 8, 2, 0, 0), 0xBF800000, 0xBF800000, 0x00800000},
+		{"cfc1 fir", cop1(cop1Cfc1, 8, 0, 0, 0), 0x00330000, 0xBF800000, 0x00800000},
+		{"cfc1 fcsr", cop1(cop1Cfc1, 8, 31, 0, 0), 0x00800000, 0xBF800000, 0x00800000},
+		{"ctc1 fir", cop1(cop1Ctc1, 8, 0, 0, 0), 0x3F800000, 0xBF800000, 0x00800000},
+		{"ctc1 fcsr", cop1(cop1Ctc1, 8, 31, 0, 0), 0x3F800000, 0xBF800000, 0x3F800000},
+		// the flag, cause and FS bits are not covered by the unsupported mask
+		{"ctc1 fcsr flags", cop1(cop1Ctc1, 9, 31, 0, 0), 0x3F800000, 0xBF800000, 0x0103F07C},
+	}
+	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
+		for _, tt := range cases {
+			testName := fmt.Sprintf("%v (%v)", tt.name, v.Name)
+			t.Run(testName, func(t *testing.T) {
+				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(0), WithNextPC(4))
+				state := goVm.GetState()
+				testutil.StoreInstruction(state.GetMemory(), 0, tt.insn)
+				state.GetRegistersRef()[8] = 0x3F800000
+				state.GetRegistersRef()[9] = 0x0103F07C
+				state.GetFpuRef().FPR[2] = 0xBF800000
+				state.GetFpuRef().FCSR = 0x00800000
+
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				require.Equal(t, tt.expectedReg, state.GetRegistersRef()[8], "register")
+				require.Equal(t, tt.expectedFpr, state.GetFpuRef().FPR[2], "fpr")
+				require.Equal(t, tt.expectedFCSR, state.GetFpuRef().FCSR, "fcsr")
+
+				evm.Reset()
+				testutil.LogStepFailureAtCleanup(t, evm)
+				validateFpuStep(t, v, evm, goVm, stepWitness, 0)
+			})
+		}
+	}
+}
+
+func TestEVM_FpuLoadStore(t *testing.T) {
+	versions := GetMipsVersionTestCases(t)
+	const base = Word(0x1000)
+	cases := []struct {
+		name      string
+		opcode    uint32
+		offset    uint32
+		double    bool
+		fprVal    uint64
+		memVal    uint64 // big-endian memory contents at base+offset
+		expectMem bool   // whether the memory is expected to hold the FPR value afterwards
+	}{
+		{"lwc1", exec.OpLoadWordCop1, 4, false, 0, 0x3F800000, false},
+		{"ldc1", exec.OpLoadDoubleCop1, 8, true, 0, 0x3FF0000000000001, false},
+		{"swc1", exec.OpStoreWordCop1, 4, false, 0xBF800000, 0, true},
+		{"sdc1", exec.OpStoreDoubleCop1, 8, true, 0xBFF0000000000001, 0, true},
+	}
+	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
+		for _, tt := range cases {
+			testName := fmt.Sprintf("%v (%v)", tt.name, v.Name)
+			t.Run(testName, func(t *testing.T) {
+				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(0), WithNextPC(4))
+				state := goVm.GetState()
+				// $f2, offset($t0)
+				testutil.StoreInstruction(state.GetMemory(), 0, tt.opcode<<26|8<<21|2<<16|tt.offset)
+				state.GetRegistersRef()[8] = base
+				addr := base + Word(tt.offset)
+				if tt.double {
+					testutil.SetMemoryUint32(state.GetMemory(), addr, uint32(tt.memVal>>32))
+					testutil.SetMemoryUint32(state.GetMemory(), addr+4, uint32(tt.memVal))
+				} else {
+					testutil.SetMemoryUint32(state.GetMemory(), addr, uint32(tt.memVal))
+				}
+				setFpr(state.GetFpuRef(), 2, tt.fprVal, tt.double)
+
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				expected := tt.memVal
+				if tt.expectMem {
+					expected = tt.fprVal
+				}
+				expectedFpu := mipsevm.FpuState{}
+				setFpr(&expectedFpu, 2, expected, tt.double)
+				require.Equal(t, expectedFpu, *state.GetFpuRef(), "fpu")
+				if tt.double {
+					require.Equal(t, uint32(expected>>32), state.GetMemory().GetUint32(addr), "memory")
+					require.Equal(t, uint32(expected), state.GetMemory().GetUint32(addr+4), "memory")
+				} else {
+					require.Equal(t, uint32(expected), state.GetMemory().GetUint32(addr), "memory")
+				}
+
+				evm.Reset()
+				testutil.LogStepFailureAtCleanup(t, evm)
+				validateFpuStep(t, v, evm, goVm, stepWitness, 0)
+			})
+		}
+	}
+}
+
+func TestEVM_FpuDoubleRegisterPair(t *testing.T) {
+	versions := GetMipsVersionTestCases(t)
+	// a double in memory is big-endian, and its low word goes to the even register of the pair
+	cases := []struct {
+		name   string
+		insn   uint32
+		offset Word
+	}{
+		{"ldc1", exec.OpLoadDoubleCop1<<26 | 8<<21 | 4<<16 | 0x8, 0x8},                           // ldc1 $f4, 8($t0)
+		{"sdc1", exec.OpStoreDoubleCop1<<26 | 8<<21 | 4<<16 | 0x10, 0x10},                        // sdc1 $f4, 16($t0)
+		{"ldc1 negative offset", exec.OpLoadDoubleCop1<<26 | 8<<21 | 4<<16 | 0xFFF8, 0xFFFFFFF8}, // ldc1 $f4, -8($t0)
+	}
+	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
+		for _, tt := range cases {
+			testName := fmt.Sprintf("%v (%v)", tt.name, v.Name)
+			t.Run(testName, func(t *testing.T) {
+				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(0), WithNextPC(4))
+				state := goVm.GetState()
+				testutil.StoreInstruction(state.GetMemory(), 0, tt.insn)
+				state.GetRegistersRef()[8] = 0x1000
+				addr := Word(0x1000) + tt.offset
+				load := tt.insn>>26 == exec.OpLoadDoubleCop1
+				if load {
+					testutil.SetMemoryUint32(state.GetMemory(), addr, 0x11223344)
+					testutil.SetMemoryUint32(state.GetMemory(), addr+4, 0x55667788)
+				} else {
+					state.GetFpuRef().FPR[4] = 0x55667788
+					state.GetFpuRef().FPR[5] = 0x11223344
+				}
+
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				require.Equal(t, Word(0x55667788), state.GetFpuRef().FPR[4], "even register")
+				require.Equal(t, Word(0x11223344), state.GetFpuRef().FPR[5], "odd register")
+				require.Equal(t, uint32(0x11223344), state.GetMemory().GetUint32(addr), "memory")
+				require.Equal(t, uint32(0x55667788), state.GetMemory().GetUint32(addr+4), "memory")
+
+				evm.Reset()
+				testutil.LogStepFailureAtCleanup(t, evm)
+				validateFpuStep(t, v, evm, goVm, stepWitness, 0)
+			})
+		}
+	}
+}
+
+func TestEVM_FpuProgram(t *testing.T) {
+	versions := GetMipsVersionTestCases(t)
+	// Computes sqrt(a*a + b*b) of the doubles a and b at 0x1000 and 0x1008, stores it to 0x1010,
+	// and sets $t1 to 1 if the result is less than the single at 0x1018, converted to a double.
+	program := []uint32{
+		exec.OpLoadDoubleCop1<<26 | 8<<21 | 2<<16 | 0x0,   // ldc1 $f2, 0($t0)
+		exec.OpLoadDoubleCop1<<26 | 8<<21 | 4<<16 | 0x8,   // ldc1 $f4, 8($t0)
+		cop1(fmtD, 2, 2, 2, 0x2),                          // mul.d $f2, $f2, $f2
+		cop1(fmtD, 4, 4, 4, 0x2),                          // mul.d $f4, $f4, $f4
+		cop1(fmtD, 4, 2, 6, 0x0),                          // add.d $f6, $f2, $f4
+		cop1(fmtD, 0, 6, 6, 0x4),                          // sqrt.d $f6, $f6
+		exec.OpStoreDoubleCop1<<26 | 8<<21 | 6<<16 | 0x10, // sdc1 $f6, 16($t0)
+		exec.OpLoadWordCop1<<26 | 8<<21 | 8<<16 | 0x18,    // lwc1 $f8, 24($t0)
+		cop1(fmtS, 0, 8, 8, 0x21),                         // cvt.d.s $f8, $f8
+		cop1Compare(fmtD, 0x4, 8, 6, 0),                   // c.olt.d $f6, $f8
+		exec.OpCop1<<26 | cop1Bc<<21 | 1<<16 | 2,          // bc1t +2
+		0,                                                 // nop
+		0x24090000,                                        // addiu $t1, $zero, 0: skipped by the branch
+		0x24090001,                                        // addiu $t1, $zero, 1
+	}
+	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
+		t.Run(v.Name, func(t *testing.T) {
+			goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(0), WithNextPC(4))
+			state := goVm.GetState()
+			for j, insn := range program {
+				testutil.StoreInstruction(state.GetMemory(), Word(j*4), insn)
+			}
+			state.GetRegistersRef()[8] = 0x1000
+			mem := state.GetMemory()
+			testutil.SetMemoryUint32(mem, 0x1000, 0x40080000) // 3.0
+			testutil.SetMemoryUint32(mem, 0x1008, 0x40100000) // 4.0
+			testutil.SetMemoryUint32(mem, 0x1018, 0x40B00000) // 5.5
+
+			evm.Reset()
+			testutil.LogStepFailureAtCleanup(t, evm)
+			for state.GetPC() < Word(len(program)*4) {
+				curStep := state.GetStep()
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				validateFpuStep(t, v, evm, goVm, stepWitness, curStep)
+			}
+
+			require.Equal(t, uint32(0x40140000), mem.GetUint32(0x1010), "sqrt(3*3 + 4*4) = 5")
+			require.Equal(t, uint32(0), mem.GetUint32(0x1014))
+			require.Equal(t, Word(1), state.GetRegistersRef()[9], "5 < 5.5")
+		})
+	}
+}
+
+func TestEVM_FpuUnsupported(t *testing.T) {
+	versions := GetMipsVersionTestCases(t)
+	cases := []struct {
+		name string
+		insn uint32
+		rt   Word
+	}{
+		{"add.w", cop1(fmtW, 4, 2, 6, 0x0), 0},
+		{"add.d odd register", cop1(fmtD, 4, 3, 6, 0x0), 0},
+		{"cvt.s.s", cop1(fmtS, 0, 2, 6, 0x20), 0},
+		{"cfc1 fccr", cop1(cop1Cfc1, 8, 25, 0, 0), 0},
+		{"ctc1 rounding mode", cop1(cop1Ctc1, 8, 31, 0, 0), 0x1},
+		{"ctc1 rounding mode up", cop1(cop1Ctc1, 8, 31, 0, 0), 0x2},
+		{"ctc1 exception enable", cop1(cop1Ctc1, 8, 31, 0, 0), 0x80},
+		{"ctc1 invalid exception enable", cop1(cop1Ctc1, 8, 31, 0, 0), 0x800},
+		{"ctc1 supported and unsupported bits", cop1(cop1Ctc1, 8, 31, 0, 0), 0x0103F07C | 0x100},
+		{"ldc1 odd register", exec.OpLoadDoubleCop1<<26 | 8<<21 | 3<<16, 0},
+		{"sdc1 odd register", exec.OpStoreDoubleCop1<<26 | 8<<21 | 3<<16, 0},
+		{"dmfc1", cop1(1, 8, 2, 0, 0), 0},
+	}
+	for _, v := range versions {
+		for _, tt := range cases {
+			testName := fmt.Sprintf("%v (%v)", tt.name, v.Name)
+			t.Run(testName, func(t *testing.T) {
+				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(0), WithNextPC(4))
+				state := goVm.GetState()
+				testutil.StoreInstruction(state.GetMemory(), 0, tt.insn)
+				state.GetRegistersRef()[8] = tt.rt
+
+				var proofData []byte
+				if mtState, ok := state.(*multithreaded.State); ok {
+					proofData = append(proofData, mtState.EncodeThreadProof()...)
+				}
+				insnProof := state.GetMemory().MerkleProof(0)
+				proofData = append(proofData, insnProof[:]...)
+				proofData = append(proofData, insnProof[:]...)
+				encodedWitness, _ := state.EncodeWitness()
+				stepWitness := &mipsevm.StepWitness{
+					State:     encodedWitness,
+					ProofData: proofData,
+				}
+				_, err := goVm.Step(true)
+				require.ErrorIs(t, err, mipsevm.ErrIllegalInstruction)
+
+				testutil.NewMIPSEVM(v.Contracts).StepReverts(t, stepWitness)
+			})
+		}
+	}
+}
+
+// validateFpuStep asserts that the onchain VM produces the same post-state as the Go VM for the step witness.
+func validateFpuStep(t *testing.T, v VersionedVMTestCase, evm *testutil.MIPSEVM, goVm mipsevm.FPVM, stepWitness *mipsevm.StepWitness, step uint64) {
+	goPost, _ := goVm.GetState().EncodeWitness()
+	evm.ValidateStep(t, stepWitness, step, v.StateHashFn, goPost,
+		"mipsevm produced different state than EVM at step %d", step)
+}
@@ -0,0 +1,237 @@
+//go:build !cannon64
+
+package tests
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+const (
+	funMfhi  = 0x10
+	funMthi  = 0x11
+	funMflo  = 0x12
+	funMtlo  = 0x13
+	funMult  = 0x18
+	funMultu = 0x19
+	funDiv   = 0x1a
+	funDivu  = 0x1b
+
+	// SPECIAL2 functions, which are not supported by the VM
+	funMadd = 0x00
+	funMsub = 0x04
+)
+
+// hiLoEdgeValues are the operands of the hi/lo vectors: zero, one, sign boundaries and half-word boundaries.
+var hiLoEdgeValues = []uint32{
+	0,
+	1,
+	2,
+	0xFF_FF,
+	0x1_00_00,
+	0x7F_FF_FF_FF, // INT_MAX
+	0x80_00_00_00, // INT_MIN
+	0xFF_FF_FF_FF, // -1
+}
+
+// rtype encodes an R-type instruction of the given opcode.
+func rtype(opcode uint32, rs uint32, rt uint32, rd uint32, fun uint32) uint32 {
+	return opcode<<26 | rs<<21 | rt<<16 | rd<<11 | fun
+}
+
+// hiLoOp is a reference implementation of a hi/lo instruction, independent of the VM implementation.
+type hiLoOp struct {
+	name string
+	fun  uint32
+	// result returns the expected hi and lo registers, and false if the instruction must fault.
+	result func(rs, rt uint32) (hi uint32, lo uint32, ok bool)
+}
+
+func signed(v uint32) *big.Int {
+	return big.NewInt(int64(int32(v)))
+}
+
+func unsigned(v uint32) *big.Int {
+	return new(big.Int).SetUint64(uint64(v))
+}
+
+// split returns the 64-bit two's complement representation of v as hi and lo words.
+func split(v *big.Int) (uint32, uint32) {
+	u := new(big.Int).And(v, new(big.Int).SetUint64(^uint64(0))).Uint64()
+	return uint32(u >> 32), uint32(u)
+}
+
+// word returns the 32-bit two's complement representation of v.
+func word(v *big.Int) uint32 {
+	_, lo := split(v)
+	return lo
+}
+
+var hiLoOps = []hiLoOp{
+	{"mult", funMult, func(rs, rt uint32) (uint32, uint32, bool) {
+		hi, lo := split(new(big.Int).Mul(signed(rs), signed(rt)))
+		return hi, lo, true
+	}},
+	{"multu", funMultu, func(rs, rt uint32) (uint32, uint32, bool) {
+		hi, lo := split(new(big.Int).Mul(unsigned(rs), unsigned(rt)))
+		return hi, lo, true
+	}},
+	{"div", funDiv, func(rs, rt uint32) (uint32, uint32, bool) {
+		if rt == 0 {
+			return 0, 0, false
+		}
+		// Truncated division, where INT_MIN/-1 wraps around to INT_MIN
+		q, r := new(big.Int).QuoRem(signed(rs), signed(rt), new(big.Int))
+		return word(r), word(q), true
+	}},
+	{"divu", funDivu, func(rs, rt uint32) (uint32, uint32, bool) {
+		if rt == 0 {
+			return 0, 0, false
+		}
+		q, r := new(big.Int).QuoRem(unsigned(rs), unsigned(rt), new(big.Int))
+		return word(r), word(q), true
+	}},
+}
+
+func TestEVM_HiLoEdgeCases(t *testing.T) {
+	versions := GetMipsVersionTestCases(t)
+	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
+		for _, op := range hiLoOps {
+			for _, rs := range hiLoEdgeValues {
+				for _, rt := range hiLoEdgeValues {
+					expectedHi, expectedLo, ok := op.result(rs, rt)
+					testName := fmt.Sprintf("%v %08x %08x (%v)", op.name, rs, rt, v.Name)
+					t.Run(testName, func(t *testing.T) {
+						goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(0), WithNextPC(4))
+						state := goVm.GetState()
+						testutil.StoreInstruction(state.GetMemory(), 0, rtype(0, 8, 9, 0, op.fun))
+						state.GetRegistersRef()[8] = rs
+						state.GetRegistersRef()[9] = rt
+						if !ok {
+							assertHiLoFault(t, v, goVm)
+							return
+						}
+
+						stepWitness, err := goVm.Step(true)
+						require.NoError(t, err)
+						require.Equal(t, expectedHi, state.GetCpu().HI, "hi")
+						require.Equal(t, expectedLo, state.GetCpu().LO, "lo")
+
+						evm.Reset()
+						testutil.LogStepFailureAtCleanup(t, evm)
+						goPost, _ := goVm.GetState().EncodeWitness()
+						evm.ValidateStep(t, stepWitness, 0, v.StateHashFn, goPost,
+							"mipsevm produced different state than EVM")
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestEVM_HiLoInterleaving(t *testing.T) {
+	versions := GetMipsVersionTestCases(t)
+	// Registers: $t0-$t3 (8-11) hold the inputs, $t4-$t7 (12-15) receive the results.
+	program := []uint32{
+		rtype(0, 8, 0, 0, funMthi),    // mthi $t0
+		rtype(0, 9, 0, 0, funMtlo),    // mtlo $t1
+		rtype(0, 0, 0, 12, funMflo),   // mflo $t4: reads the moved lo
+		rtype(0, 10, 11, 0, funMult),  // mult $t2, $t3: overwrites hi and lo
+		rtype(0, 0, 0, 13, funMfhi),   // mfhi $t5
+		rtype(0, 10, 0, 0, funMthi),   // mthi $t2: only overwrites hi
+		rtype(0, 0, 0, 14, funMflo),   // mflo $t6: still the lo of the mult
+		rtype(0, 13, 11, 0, funDivu),  // divu $t5, $t3
+		rtype(0, 0, 0, 15, funMfhi),   // mfhi $t7
+		rtype(0, 10, 11, 0, funMultu), // multu $t2, $t3
+		rtype(0, 0, 0, 8, funMfhi),    // mfhi $t0
+		rtype(0, 0, 0, 9, funMflo),    // mflo $t1
+	}
+	inputs := [][4]uint32{
+		{0x11111111, 0x22222222, 0x80000000, 0xFFFFFFFF},
+		{0xFFFFFFFF, 0, 0x7FFFFFFF, 0x7FFFFFFF},
+		{0, 0xFFFFFFFF, 0xFFFFFFFF, 0x80000000},
+		{0x12345678, 0x9ABCDEF0, 0x10000, 0x10000},
+	}
+	for _, v := range versions {
+		evm := testutil.NewMIPSEVM(v.Contracts)
+		for i, in := range inputs {
+			testName := fmt.Sprintf("inputs %d (%v)", i, v.Name)
+			t.Run(testName, func(t *testing.T) {
+				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(0), WithNextPC(4))
+				state := goVm.GetState()
+				for j, insn := range program {
+					testutil.StoreInstruction(state.GetMemory(), Word(j*4), insn)
+				}
+				copy(state.GetRegistersRef()[8:12], in[:])
+
+				evm.Reset()
+				testutil.LogStepFailureAtCleanup(t, evm)
+				for range program {
+					curStep := state.GetStep()
+					stepWitness, err := goVm.Step(true)
+					require.NoError(t, err)
+					goPost, _ := goVm.GetState().EncodeWitness()
+					evm.ValidateStep(t, stepWitness, curStep, v.StateHashFn, goPost,
+						"mipsevm produced different state than EVM at step %d", curStep)
+				}
+
+				multHi, multLo := split(new(big.Int).Mul(signed(in[2]), signed(in[3])))
+				multuHi, multuLo := split(new(big.Int).Mul(unsigned(in[2]), unsigned(in[3])))
+				regs := state.GetRegistersRef()
+				require.Equal(t, in[1], regs[12], "mflo after mtlo")
+				require.Equal(t, multHi, regs[13], "mfhi after mult")
+				require.Equal(t, multLo, regs[14], "mflo after mthi")
+				require.Equal(t, multHi%in[3], regs[15], "mfhi after divu")
+				require.Equal(t, multuHi, regs[8], "mfhi after multu")
+				require.Equal(t, multuLo, regs[9], "mflo after multu")
+			})
+		}
+	}
+}
+
+func TestEVM_HiLoUnsupported(t *testing.T) {
+	versions := GetMipsVersionTestCases(t)
+	cases := []struct {
+		name string
+		insn uint32
+	}{
+		{"madd", rtype(0x1c, 8, 9, 0, funMadd)},
+		{"msub", rtype(0x1c, 8, 9, 0, funMsub)},
+	}
+	for _, v := range versions {
+		for _, tt := range cases {
+			testName := fmt.Sprintf("%v (%v)", tt.name, v.Name)
+			t.Run(testName, func(t *testing.T) {
+				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(), WithPC(0), WithNextPC(4))
+				state := goVm.GetState()
+				testutil.StoreInstruction(state.GetMemory(), 0, tt.insn)
+				state.GetRegistersRef()[8] = 0x7FFFFFFF
+				state.GetRegistersRef()[9] = 2
+				assertHiLoFault(t, v, goVm)
+			})
+		}
+	}
+}
+
+// assertHiLoFault asserts that the next instruction faults in both the Go VM and the onchain VM.
+func assertHiLoFault(t *testing.T, v VersionedVMTestCase, goVm mipsevm.FPVM) {
+	state := goVm.GetState()
+	insnProof := state.GetMemory().MerkleProof(state.GetPC())
+	encodedWitness, _ := state.EncodeWitness()
+	stepWitness := &mipsevm.StepWitness{
+		State:     encodedWitness,
+		ProofData: insnProof[:],
+	}
+	_, err := goVm.Step(true)
+	require.ErrorIs(t, err, mipsevm.ErrFault)
+
+	testutil.NewMIPSEVM(v.Contracts).StepReverts(t, stepWitness)
+}
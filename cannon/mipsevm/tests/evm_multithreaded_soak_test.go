@@ -0,0 +1,76 @@
+package tests
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+// maxSoakRunSteps bounds a single run of a soak program, to fail instead of spinning if a program never completes.
+const maxSoakRunSteps = 50_000_000
+
+// TestEVM_MT_Soak runs the multi-threaded programs with the current thread preempted at random steps,
+// on top of the scheduler's own preemptions, and checks the EVM produces the same post-state on a random sample of steps.
+// The random preemptions are made by exhausting the scheduling quantum of the thread in the pre-state,
+// so the preempted steps remain valid, provable steps of the VM.
+// By default it is a short smoke run; set CANNON_SOAK, e.g. via `make soak`, to run for millions of steps. See testutil.ReadSoakConfig.
+func TestEVM_MT_Soak(t *testing.T) {
+	cfg := testutil.ReadSoakConfig(t)
+	v := GetMultiThreadedTestCase(t)
+
+	cases := []struct {
+		name    string
+		program string
+		stdOut  string
+	}{
+		{"mutex contention", "mt-mutex", "mutex counter: 200\n"},
+		{"wait/wake storm", "mt-wakeup", "wakeups: 80\n"},
+		{"thread exit race", "mt-exit", "exited threads: 12\n"},
+	}
+	for i, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(cfg.Seed + int64(i)))
+			elfFile := testutil.ProgramPath(tt.program)
+			evm := testutil.NewMIPSEVM(v.Contracts)
+			testutil.LogStepFailureAtCleanup(t, evm)
+
+			var steps, preemptions, samples uint64
+			for run := 0; run == 0 || steps < cfg.Steps; run++ {
+				var stdOutBuf, stdErrBuf bytes.Buffer
+				goVm := v.ElfVMFactory(t, elfFile, nil, &stdOutBuf, &stdErrBuf, testutil.CreateLogger())
+				state := goVm.GetState().(*multithreaded.State)
+				for !state.GetExited() {
+					curStep := state.GetStep()
+					require.Lessf(t, curStep, uint64(maxSoakRunSteps), "run %d did not complete", run)
+					if rng.Uint64()%cfg.PreemptRate == 0 {
+						state.StepsSinceLastContextSwitch = exec.SchedQuantum
+						preemptions++
+					}
+					if rng.Uint64()%cfg.SampleRate != 0 {
+						_, err := goVm.Step(false)
+						require.NoErrorf(t, err, "failed step %d of run %d", curStep, run)
+						continue
+					}
+					stepWitness, err := goVm.Step(true)
+					require.NoErrorf(t, err, "failed step %d of run %d", curStep, run)
+					goPost, _ := state.EncodeWitness()
+					evm.ValidateStep(t, stepWitness, curStep, v.StateHashFn, goPost,
+						"mipsevm produced different state than EVM at step %d of run %d", curStep, run)
+					samples++
+				}
+				steps += state.GetStep()
+
+				require.Equalf(t, uint8(0), state.GetExitCode(), "run %d must exit with 0", run)
+				require.Equalf(t, tt.stdOut, stdOutBuf.String(), "run %d must produce the expected output", run)
+				require.Equalf(t, "", stdErrBuf.String(), "run %d stderr silent", run)
+			}
+			t.Logf("Completed %d steps, with %d random preemptions and %d steps checked against the EVM", steps, preemptions, samples)
+		})
+	}
+}
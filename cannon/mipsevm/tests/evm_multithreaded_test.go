@@ -1,32 +1,36 @@
 package tests
 
 import (
+	"bytes"
+	"io"
 	"os"
 	"testing"
 
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 )
 
 func TestEVM_CloneFlags(t *testing.T) {
-	contracts := testutil.TestContractsSetup(t, testutil.MipsMultithreaded)
+	contracts := GetMultiThreadedTestCase(t).Contracts
 	var tracer *tracing.Hooks
 
 	cases := []struct {
 		name  string
-		flags uint32
+		flags Word
 		valid bool
 	}{
 		{"the supported flags bitmask", exec.ValidCloneFlags, true},
 		{"no flags", 0, false},
-		{"all flags", ^uint32(0), false},
-		{"all unsupported flags", ^uint32(exec.ValidCloneFlags), false},
+		{"all flags", ^Word(0), false},
+		{"all unsupported flags", ^Word(exec.ValidCloneFlags), false},
 		{"a few supported flags", exec.CloneFs | exec.CloneSysvsem, false},
 		{"one supported flag", exec.CloneFs, false},
 		{"mixed supported and unsupported flags", exec.CloneFs | exec.CloneParentSettid, false},
@@ -38,9 +42,8 @@ func TestEVM_CloneFlags(t *testing.T) {
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
 			state := multithreaded.CreateEmptyState()
-			state.Memory.SetMemory(state.GetPC(), insn)
-			state.GetRegistersRef()[2] = exec.SysClone // Set syscall number
-			state.GetRegistersRef()[4] = tt.flags      // Set first argument
+			testutil.StoreInstruction(state.Memory, state.GetPC(), insn)
+			testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysClone, tt.flags)
 			curStep := state.Step
 
 			var err error
@@ -61,10 +64,259 @@ func TestEVM_CloneFlags(t *testing.T) {
 			evm.SetTracer(tracer)
 			testutil.LogStepFailureAtCleanup(t, evm)
 
-			evmPost := evm.Step(t, stepWitness, curStep, multithreaded.GetStateHashFn())
 			goPost, _ := us.GetState().EncodeWitness()
-			require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+			evm.ValidateStep(t, stepWitness, curStep, multithreaded.GetStateHashFn(), goPost,
 				"mipsevm produced different state than EVM")
 		})
 	}
 }
+
+type llReservation struct {
+	active bool
+	addr   Word
+	owner  Word
+}
+
+func setLLReservation(state *multithreaded.State, r llReservation) {
+	state.LLReservationActive = r.active
+	state.LLAddress = r.addr
+	state.LLOwnerThread = r.owner
+}
+
+func requireLLReservation(t *testing.T, state *multithreaded.State, r llReservation) {
+	require.Equal(t, r.active, state.LLReservationActive, "reservation active")
+	require.Equal(t, r.addr, state.LLAddress, "reservation address")
+	require.Equal(t, r.owner, state.LLOwnerThread, "reservation owner")
+}
+
+func stepMultithreaded(t *testing.T, evm *testutil.MIPSEVM, state *multithreaded.State, po mipsevm.PreimageOracle) {
+	curStep := state.Step
+	us := multithreaded.NewInstrumentedState(state, po, os.Stdout, os.Stderr, testutil.CreateLogger())
+	stepWitness, err := us.Step(true)
+	require.NoError(t, err)
+
+	evm.Reset()
+	evm.SetLocalOracle(po)
+	testutil.LogStepFailureAtCleanup(t, evm)
+
+	goPost, _ := state.EncodeWitness()
+	evm.ValidateStep(t, stepWitness, curStep, multithreaded.GetStateHashFn(), goPost,
+		"mipsevm produced different state than EVM")
+}
+
+func TestEVM_MT_LL(t *testing.T) {
+	contracts := GetMultiThreadedTestCase(t).Contracts
+	evm := testutil.NewMIPSEVM(contracts)
+
+	const threadId = Word(3)
+	const base = Word(0x1000)
+	const addr = base + 4
+	// The reservation is of the memory word of the address
+	const reserved = addr & arch.AddressMask
+	const val = uint32(0x12_34_56_78)
+	cases := []struct {
+		name     string
+		existing llReservation
+	}{
+		{"no reservation", llReservation{}},
+		{"own reservation of another address", llReservation{true, 0x2000, threadId}},
+		{"reservation of another thread", llReservation{true, reserved, threadId + 1}},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			state := multithreaded.CreateEmptyState()
+			thread := state.GetCurrentThread()
+			thread.ThreadId = threadId
+			setLLReservation(state, tt.existing)
+			testutil.StoreInstruction(state.Memory, 0, 0xC0_00_00_00|9<<21|8<<16|4) // ll $t0, 4($t1)
+			testutil.SetMemoryUint32(state.Memory, addr, val)
+			thread.Registers[9] = base
+
+			stepMultithreaded(t, evm, state, nil)
+			require.Equal(t, Word(val), thread.Registers[8])
+			requireLLReservation(t, state, llReservation{true, reserved, threadId})
+		})
+	}
+}
+
+func TestEVM_MT_SC(t *testing.T) {
+	contracts := GetMultiThreadedTestCase(t).Contracts
+	evm := testutil.NewMIPSEVM(contracts)
+
+	const threadId = Word(3)
+	const base = Word(0x1000)
+	const addr = base + 4
+	const reserved = addr & arch.AddressMask
+	const prev = uint32(0x11_11_11_11)
+	const val = uint32(0xaa_bb_cc_dd)
+	cases := []struct {
+		name     string
+		existing llReservation
+		success  bool
+	}{
+		{"own reservation", llReservation{true, reserved, threadId}, true},
+		{"no reservation", llReservation{}, false},
+		{"inactive reservation", llReservation{false, reserved, threadId}, false},
+		{"own reservation of another address", llReservation{true, 0x2000, threadId}, false},
+		{"reservation of another thread", llReservation{true, reserved, threadId + 1}, false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			state := multithreaded.CreateEmptyState()
+			thread := state.GetCurrentThread()
+			thread.ThreadId = threadId
+			setLLReservation(state, tt.existing)
+			testutil.StoreInstruction(state.Memory, 0, 0xE0_00_00_00|9<<21|8<<16|4) // sc $t0, 4($t1)
+			testutil.SetMemoryUint32(state.Memory, addr, prev)
+			thread.Registers[8] = Word(val)
+			thread.Registers[9] = base
+
+			stepMultithreaded(t, evm, state, nil)
+			if tt.success {
+				require.Equal(t, Word(1), thread.Registers[8])
+				require.Equal(t, val, state.Memory.GetUint32(addr))
+				requireLLReservation(t, state, llReservation{})
+			} else {
+				require.Equal(t, Word(0), thread.Registers[8])
+				require.Equal(t, prev, state.Memory.GetUint32(addr))
+				requireLLReservation(t, state, tt.existing)
+			}
+		})
+	}
+}
+
+func TestEVM_MT_StoreBreaksReservation(t *testing.T) {
+	contracts := GetMultiThreadedTestCase(t).Contracts
+	evm := testutil.NewMIPSEVM(contracts)
+
+	const base = Word(0x1000)
+	const addr = base + 4
+	cases := []struct {
+		name    string
+		insn    uint32
+		cleared bool
+	}{
+		{"sw to the reserved address", 0xAC_00_00_00 | 9<<21 | 8<<16 | 4, true},        // sw $t0, 4($t1)
+		{"sb to the reserved word", 0xA0_00_00_00 | 9<<21 | 8<<16 | 6, true},           // sb $t0, 6($t1)
+		{"swr to the reserved word", 0xB8_00_00_00 | 9<<21 | 8<<16 | 5, true},          // swr $t0, 5($t1)
+		{"swc1 to the reserved address", 0xE4_00_00_00 | 9<<21 | 0<<16 | 4, true},      // swc1 $f0, 4($t1)
+		{"sdc1 to the reserved address", 0xF4_00_00_00 | 9<<21 | 0<<16 | 4, true},      // sdc1 $f0, 4($t1)
+		{"sdc1 to the reserved word", 0xF4_00_00_00 | 9<<21 | 0<<16 | 0, true},         // sdc1 $f0, 0($t1)
+		{"sw to another address", 0xAC_00_00_00 | 9<<21 | 8<<16 | 8, false},            // sw $t0, 8($t1)
+		{"swc1 to another address", 0xE4_00_00_00 | 9<<21 | 0<<16 | 8, false},          // swc1 $f0, 8($t1)
+		{"sdc1 to another address", 0xF4_00_00_00 | 9<<21 | 0<<16 | 8, false},          // sdc1 $f0, 8($t1)
+		{"lw of the reserved address", 0x8C_00_00_00 | 9<<21 | 8<<16 | 4, false},       // lw $t0, 4($t1)
+		{"lwc1 of the reserved address", 0xC4_00_00_00 | 9<<21 | 0<<16 | 4, false},     // lwc1 $f0, 4($t1)
+		{"addu on the reserved address", 0x00_00_00_21 | 9<<21 | 8<<16 | 8<<11, false}, // addu $t0, $t1, $t0
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			state := multithreaded.CreateEmptyState()
+			thread := state.GetCurrentThread()
+			// The reservation is held by another thread, which is preempted
+			reservation := llReservation{true, addr & arch.AddressMask, thread.ThreadId + 1}
+			setLLReservation(state, reservation)
+			testutil.StoreInstruction(state.Memory, 0, tt.insn)
+			thread.Registers[8] = 0xaa_bb_cc_dd
+			thread.Registers[9] = base
+
+			stepMultithreaded(t, evm, state, nil)
+			if tt.cleared {
+				requireLLReservation(t, state, llReservation{})
+			} else {
+				requireLLReservation(t, state, reservation)
+			}
+		})
+	}
+}
+
+func TestEVM_MT_PreimageReadBreaksReservation(t *testing.T) {
+	contracts := GetMultiThreadedTestCase(t).Contracts
+	evm := testutil.NewMIPSEVM(contracts)
+
+	const addr = Word(0x1000)
+	preimageData := []byte("hello world")
+	preimageKey := preimage.Keccak256Key(crypto.Keccak256Hash(preimageData)).PreimageKey()
+	cases := []struct {
+		name    string
+		readTo  Word
+		cleared bool
+	}{
+		{"read into the reserved word", addr + 2, true},
+		{"read into another word", addr + arch.WordSizeBytes, false},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			oracle := testutil.StaticOracle(t, preimageData)
+			state := multithreaded.CreateEmptyState()
+			state.PreimageKey = preimageKey
+			thread := state.GetCurrentThread()
+			reservation := llReservation{true, addr, thread.ThreadId}
+			setLLReservation(state, reservation)
+			testutil.StoreInstruction(state.Memory, 0, 0x00_00_00_0C) // syscall
+			testutil.SetSyscallArgs(&thread.Registers, exec.SysRead, exec.FdPreimageRead, tt.readTo, 4)
+
+			stepMultithreaded(t, evm, state, oracle)
+			if tt.cleared {
+				requireLLReservation(t, state, llReservation{})
+			} else {
+				requireLLReservation(t, state, reservation)
+			}
+		})
+	}
+}
+
+func TestEVM_MT_ThreadInterleavingPrograms(t *testing.T) {
+	var tracer *tracing.Hooks
+	v := GetMultiThreadedTestCase(t)
+
+	cases := []struct {
+		name    string
+		program string
+		stdOut  string
+	}{
+		{"mutex contention", "mt-mutex", "mutex counter: 200\n"},
+		{"wait/wake storm", "mt-wakeup", "wakeups: 80\n"},
+		{"thread exit race", "mt-exit", "exited threads: 12\n"},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			elfFile := testutil.ProgramPath(tt.program)
+			evm := testutil.NewMIPSEVM(v.Contracts)
+			evm.SetTracer(tracer)
+			testutil.LogStepFailureAtCleanup(t, evm)
+
+			var stdOutBuf, stdErrBuf bytes.Buffer
+			goVm := v.ElfVMFactory(t, elfFile, nil, io.MultiWriter(&stdOutBuf, os.Stdout), io.MultiWriter(&stdErrBuf, os.Stderr), testutil.CreateLogger())
+			state := goVm.GetState()
+			for i := 0; i < 5_000_000; i++ {
+				curStep := state.GetStep()
+				if state.GetExited() {
+					break
+				}
+				stepWitness, err := goVm.Step(true)
+				require.NoError(t, err)
+				goPost, _ := state.EncodeWitness()
+				evm.ValidateStep(t, stepWitness, curStep, v.StateHashFn, goPost,
+					"mipsevm produced different state than EVM at step %d", curStep)
+			}
+			t.Logf("Completed in %d steps", state.GetStep())
+
+			require.True(t, state.GetExited(), "must complete program")
+			require.Equal(t, uint8(0), state.GetExitCode(), "exit with 0")
+			require.Equal(t, tt.stdOut, stdOutBuf.String())
+			require.Equal(t, "", stdErrBuf.String(), "stderr silent")
+
+			// The scheduler is deterministic, so a second run must interleave the threads identically.
+			rerun := v.ElfVMFactory(t, elfFile, nil, io.Discard, io.Discard, testutil.CreateLogger())
+			for !rerun.GetState().GetExited() {
+				_, err := rerun.Step(false)
+				require.NoError(t, err)
+			}
+			require.Equal(t, state.GetStep(), rerun.GetState().GetStep(), "must complete in the same number of steps")
+			_, expectedHash := state.EncodeWitness()
+			_, actualHash := rerun.GetState().EncodeWitness()
+			require.Equal(t, expectedHash, actualHash, "must produce the same final state")
+		})
+	}
+}
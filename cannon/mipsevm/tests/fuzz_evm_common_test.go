@@ -7,10 +7,10 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
@@ -22,7 +22,7 @@ const syscallInsn = uint32(0x00_00_00_0c)
 
 func FuzzStateSyscallBrk(f *testing.F) {
 	versions := GetMipsVersionTestCases(f)
-	f.Fuzz(func(t *testing.T, pc uint32, step uint64, preimageOffset uint32) {
+	f.Fuzz(func(t *testing.T, pc Word, step uint64, preimageOffset uint32) {
 		for _, v := range versions {
 			t.Run(v.Name, func(t *testing.T) {
 				pc = pc & 0xFF_FF_FF_FC // align PC
@@ -31,12 +31,12 @@ func FuzzStateSyscallBrk(f *testing.F) {
 				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(),
 					WithPC(pc), WithNextPC(nextPC), WithStep(step), WithPreimageOffset(preimageOffset))
 				state := goVm.GetState()
-				state.GetRegistersRef()[2] = exec.SysBrk
-				state.GetMemory().SetMemory(pc, syscallInsn)
+				testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysBrk)
+				testutil.StoreInstruction(state.GetMemory(), pc, syscallInsn)
 
 				preStateRoot := state.GetMemory().MerkleRoot()
 				expectedRegisters := testutil.CopyRegisters(state)
-				expectedRegisters[2] = program.PROGRAM_BREAK
+				expectedRegisters[arch.RegSyscallRet] = program.PROGRAM_BREAK
 
 				stepWitness, err := goVm.Step(true)
 				require.NoError(t, err)
@@ -44,9 +44,9 @@ func FuzzStateSyscallBrk(f *testing.F) {
 
 				require.Equal(t, pc+4, state.GetPC())
 				require.Equal(t, nextPC+4, state.GetCpu().NextPC)
-				require.Equal(t, uint32(0), state.GetCpu().LO)
-				require.Equal(t, uint32(0), state.GetCpu().HI)
-				require.Equal(t, uint32(0), state.GetHeap())
+				require.Equal(t, Word(0), state.GetCpu().LO)
+				require.Equal(t, Word(0), state.GetCpu().HI)
+				require.Equal(t, Word(0), state.GetHeap())
 				require.Equal(t, uint8(0), state.GetExitCode())
 				require.Equal(t, false, state.GetExited())
 				require.Equal(t, preStateRoot, state.GetMemory().MerkleRoot())
@@ -56,9 +56,8 @@ func FuzzStateSyscallBrk(f *testing.F) {
 				require.Equal(t, preimageOffset, state.GetPreimageOffset())
 
 				evm := testutil.NewMIPSEVM(v.Contracts)
-				evmPost := evm.Step(t, stepWitness, step, v.StateHashFn)
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, step, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			})
 		}
@@ -67,13 +66,13 @@ func FuzzStateSyscallBrk(f *testing.F) {
 
 func FuzzStateSyscallMmap(f *testing.F) {
 	// Add special cases for large memory allocation
-	f.Add(uint32(0), uint32(0x1000), uint32(program.HEAP_END), int64(1))
-	f.Add(uint32(0), uint32(1<<31), uint32(program.HEAP_START), int64(2))
+	f.Add(Word(0), Word(0x1000), Word(program.HEAP_END), int64(1))
+	f.Add(Word(0), Word(1<<31), Word(program.HEAP_START), int64(2))
 	// Check edge case - just within bounds
-	f.Add(uint32(0), uint32(0x1000), uint32(program.HEAP_END-4096), int64(3))
+	f.Add(Word(0), Word(0x1000), Word(program.HEAP_END-4096), int64(3))
 
 	versions := GetMipsVersionTestCases(f)
-	f.Fuzz(func(t *testing.T, addr uint32, siz uint32, heap uint32, seed int64) {
+	f.Fuzz(func(t *testing.T, addr Word, siz Word, heap Word, seed int64) {
 		for _, v := range versions {
 			t.Run(v.Name, func(t *testing.T) {
 				step := uint64(0)
@@ -81,10 +80,8 @@ func FuzzStateSyscallMmap(f *testing.F) {
 					WithStep(step), WithHeap(heap))
 				state := goVm.GetState()
 				*state.GetRegistersRef() = testutil.RandomRegisters(seed)
-				state.GetRegistersRef()[2] = exec.SysMmap
-				state.GetRegistersRef()[4] = addr
-				state.GetRegistersRef()[5] = siz
-				state.GetMemory().SetMemory(0, syscallInsn)
+				testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysMmap, addr, siz)
+				testutil.StoreInstruction(state.GetMemory(), 0, syscallInsn)
 
 				preStateRoot := state.GetMemory().MerkleRoot()
 				preStateRegisters := testutil.CopyRegisters(state)
@@ -93,7 +90,7 @@ func FuzzStateSyscallMmap(f *testing.F) {
 				require.NoError(t, err)
 				require.False(t, stepWitness.HasPreimage())
 
-				var expectedHeap uint32
+				var expectedHeap Word
 				expectedRegisters := preStateRegisters
 				if addr == 0 {
 					sizAlign := siz
@@ -103,23 +100,20 @@ func FuzzStateSyscallMmap(f *testing.F) {
 					newHeap := heap + sizAlign
 					if newHeap > program.HEAP_END || newHeap < heap || sizAlign < siz {
 						expectedHeap = heap
-						expectedRegisters[2] = exec.SysErrorSignal
-						expectedRegisters[7] = exec.MipsEINVAL
+						testutil.SetSyscallResult(expectedRegisters, exec.SysErrorSignal, exec.MipsEINVAL)
 					} else {
-						expectedRegisters[2] = heap
-						expectedRegisters[7] = 0 // no error
+						testutil.SetSyscallResult(expectedRegisters, heap, 0) // no error
 						expectedHeap = heap + sizAlign
 					}
 				} else {
-					expectedRegisters[2] = addr
-					expectedRegisters[7] = 0 // no error
+					testutil.SetSyscallResult(expectedRegisters, addr, 0) // no error
 					expectedHeap = heap
 				}
 
-				require.Equal(t, uint32(4), state.GetCpu().PC)
-				require.Equal(t, uint32(8), state.GetCpu().NextPC)
-				require.Equal(t, uint32(0), state.GetCpu().LO)
-				require.Equal(t, uint32(0), state.GetCpu().HI)
+				require.Equal(t, Word(4), state.GetCpu().PC)
+				require.Equal(t, Word(8), state.GetCpu().NextPC)
+				require.Equal(t, Word(0), state.GetCpu().LO)
+				require.Equal(t, Word(0), state.GetCpu().HI)
 				require.Equal(t, preStateRoot, state.GetMemory().MerkleRoot())
 				require.Equal(t, uint64(1), state.GetStep())
 				require.Equal(t, common.Hash{}, state.GetPreimageKey())
@@ -130,9 +124,8 @@ func FuzzStateSyscallMmap(f *testing.F) {
 				require.Equal(t, expectedRegisters, state.GetRegistersRef())
 
 				evm := testutil.NewMIPSEVM(v.Contracts)
-				evmPost := evm.Step(t, stepWitness, step, v.StateHashFn)
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, step, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			})
 		}
@@ -141,7 +134,7 @@ func FuzzStateSyscallMmap(f *testing.F) {
 
 func FuzzStateSyscallExitGroup(f *testing.F) {
 	versions := GetMipsVersionTestCases(f)
-	f.Fuzz(func(t *testing.T, exitCode uint8, pc uint32, step uint64) {
+	f.Fuzz(func(t *testing.T, exitCode uint8, pc Word, step uint64) {
 		for _, v := range versions {
 			t.Run(v.Name, func(t *testing.T) {
 				pc = pc & 0xFF_FF_FF_FC // align PC
@@ -149,9 +142,8 @@ func FuzzStateSyscallExitGroup(f *testing.F) {
 				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(),
 					WithPC(pc), WithNextPC(nextPC), WithStep(step))
 				state := goVm.GetState()
-				state.GetRegistersRef()[2] = exec.SysExitGroup
-				state.GetRegistersRef()[4] = uint32(exitCode)
-				state.GetMemory().SetMemory(pc, syscallInsn)
+				testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysExitGroup, Word(exitCode))
+				testutil.StoreInstruction(state.GetMemory(), pc, syscallInsn)
 
 				preStateRoot := state.GetMemory().MerkleRoot()
 				preStateRegisters := testutil.CopyRegisters(state)
@@ -162,9 +154,9 @@ func FuzzStateSyscallExitGroup(f *testing.F) {
 
 				require.Equal(t, pc, state.GetCpu().PC)
 				require.Equal(t, nextPC, state.GetCpu().NextPC)
-				require.Equal(t, uint32(0), state.GetCpu().LO)
-				require.Equal(t, uint32(0), state.GetCpu().HI)
-				require.Equal(t, uint32(0), state.GetHeap())
+				require.Equal(t, Word(0), state.GetCpu().LO)
+				require.Equal(t, Word(0), state.GetCpu().HI)
+				require.Equal(t, Word(0), state.GetHeap())
 				require.Equal(t, uint8(exitCode), state.GetExitCode())
 				require.Equal(t, true, state.GetExited())
 				require.Equal(t, preStateRoot, state.GetMemory().MerkleRoot())
@@ -174,9 +166,8 @@ func FuzzStateSyscallExitGroup(f *testing.F) {
 				require.Equal(t, uint32(0), state.GetPreimageOffset())
 
 				evm := testutil.NewMIPSEVM(v.Contracts)
-				evmPost := evm.Step(t, stepWitness, step, v.StateHashFn)
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, step, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			})
 		}
@@ -185,17 +176,15 @@ func FuzzStateSyscallExitGroup(f *testing.F) {
 
 func FuzzStateSyscallFcntl(f *testing.F) {
 	versions := GetMipsVersionTestCases(f)
-	f.Fuzz(func(t *testing.T, fd uint32, cmd uint32) {
+	f.Fuzz(func(t *testing.T, fd Word, cmd Word) {
 		for _, v := range versions {
 			t.Run(v.Name, func(t *testing.T) {
 				step := uint64(0)
 				goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(),
 					WithStep(step))
 				state := goVm.GetState()
-				state.GetRegistersRef()[2] = exec.SysFcntl
-				state.GetRegistersRef()[4] = fd
-				state.GetRegistersRef()[5] = cmd
-				state.GetMemory().SetMemory(0, syscallInsn)
+				testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysFcntl, fd, cmd)
+				testutil.StoreInstruction(state.GetMemory(), 0, syscallInsn)
 
 				preStateRoot := state.GetMemory().MerkleRoot()
 				preStateRegisters := testutil.CopyRegisters(state)
@@ -204,11 +193,11 @@ func FuzzStateSyscallFcntl(f *testing.F) {
 				require.NoError(t, err)
 				require.False(t, stepWitness.HasPreimage())
 
-				require.Equal(t, uint32(4), state.GetCpu().PC)
-				require.Equal(t, uint32(8), state.GetCpu().NextPC)
-				require.Equal(t, uint32(0), state.GetCpu().LO)
-				require.Equal(t, uint32(0), state.GetCpu().HI)
-				require.Equal(t, uint32(0), state.GetHeap())
+				require.Equal(t, Word(4), state.GetCpu().PC)
+				require.Equal(t, Word(8), state.GetCpu().NextPC)
+				require.Equal(t, Word(0), state.GetCpu().LO)
+				require.Equal(t, Word(0), state.GetCpu().HI)
+				require.Equal(t, Word(0), state.GetHeap())
 				require.Equal(t, uint8(0), state.GetExitCode())
 				require.Equal(t, false, state.GetExited())
 				require.Equal(t, preStateRoot, state.GetMemory().MerkleRoot())
@@ -219,25 +208,22 @@ func FuzzStateSyscallFcntl(f *testing.F) {
 					expectedRegisters := preStateRegisters
 					switch fd {
 					case exec.FdStdin, exec.FdPreimageRead, exec.FdHintRead:
-						expectedRegisters[2] = 0
+						expectedRegisters[arch.RegSyscallRet] = 0
 					case exec.FdStdout, exec.FdStderr, exec.FdPreimageWrite, exec.FdHintWrite:
-						expectedRegisters[2] = 1
+						expectedRegisters[arch.RegSyscallRet] = 1
 					default:
-						expectedRegisters[2] = 0xFF_FF_FF_FF
-						expectedRegisters[7] = exec.MipsEBADF
+						testutil.SetSyscallResult(expectedRegisters, 0xFF_FF_FF_FF, exec.MipsEBADF)
 					}
 					require.Equal(t, expectedRegisters, state.GetRegistersRef())
 				} else {
 					expectedRegisters := preStateRegisters
-					expectedRegisters[2] = 0xFF_FF_FF_FF
-					expectedRegisters[7] = exec.MipsEINVAL
+					testutil.SetSyscallResult(expectedRegisters, 0xFF_FF_FF_FF, exec.MipsEINVAL)
 					require.Equal(t, expectedRegisters, state.GetRegistersRef())
 				}
 
 				evm := testutil.NewMIPSEVM(v.Contracts)
-				evmPost := evm.Step(t, stepWitness, step, v.StateHashFn)
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, step, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			})
 		}
@@ -246,7 +232,7 @@ func FuzzStateSyscallFcntl(f *testing.F) {
 
 func FuzzStateHintRead(f *testing.F) {
 	versions := GetMipsVersionTestCases(f)
-	f.Fuzz(func(t *testing.T, addr uint32, count uint32) {
+	f.Fuzz(func(t *testing.T, addr Word, count Word) {
 		for _, v := range versions {
 			t.Run(v.Name, func(t *testing.T) {
 				step := uint64(0)
@@ -257,26 +243,23 @@ func FuzzStateHintRead(f *testing.F) {
 				goVm := v.VMFactory(oracle, os.Stdout, os.Stderr, testutil.CreateLogger(),
 					WithStep(step), WithPreimageKey(preimageKey))
 				state := goVm.GetState()
-				state.GetRegistersRef()[2] = exec.SysRead
-				state.GetRegistersRef()[4] = exec.FdHintRead
-				state.GetRegistersRef()[5] = addr
-				state.GetRegistersRef()[6] = count
-				state.GetMemory().SetMemory(0, syscallInsn)
+				testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysRead, exec.FdHintRead, addr, count)
+				testutil.StoreInstruction(state.GetMemory(), 0, syscallInsn)
 
 				preStatePreimageKey := state.GetPreimageKey()
 				preStateRoot := state.GetMemory().MerkleRoot()
 				expectedRegisters := testutil.CopyRegisters(state)
-				expectedRegisters[2] = count
+				expectedRegisters[arch.RegSyscallRet] = count
 
 				stepWitness, err := goVm.Step(true)
 				require.NoError(t, err)
 				require.False(t, stepWitness.HasPreimage())
 
-				require.Equal(t, uint32(4), state.GetCpu().PC)
-				require.Equal(t, uint32(8), state.GetCpu().NextPC)
-				require.Equal(t, uint32(0), state.GetCpu().LO)
-				require.Equal(t, uint32(0), state.GetCpu().HI)
-				require.Equal(t, uint32(0), state.GetHeap())
+				require.Equal(t, Word(4), state.GetCpu().PC)
+				require.Equal(t, Word(8), state.GetCpu().NextPC)
+				require.Equal(t, Word(0), state.GetCpu().LO)
+				require.Equal(t, Word(0), state.GetCpu().HI)
+				require.Equal(t, Word(0), state.GetHeap())
 				require.Equal(t, uint8(0), state.GetExitCode())
 				require.Equal(t, false, state.GetExited())
 				require.Equal(t, preStateRoot, state.GetMemory().MerkleRoot())
@@ -285,9 +268,8 @@ func FuzzStateHintRead(f *testing.F) {
 				require.Equal(t, expectedRegisters, state.GetRegistersRef())
 
 				evm := testutil.NewMIPSEVM(v.Contracts)
-				evmPost := evm.Step(t, stepWitness, step, v.StateHashFn)
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, step, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			})
 		}
@@ -296,7 +278,7 @@ func FuzzStateHintRead(f *testing.F) {
 
 func FuzzStatePreimageRead(f *testing.F) {
 	versions := GetMipsVersionTestCases(f)
-	f.Fuzz(func(t *testing.T, addr uint32, count uint32, preimageOffset uint32) {
+	f.Fuzz(func(t *testing.T, addr Word, count Word, preimageOffset uint32) {
 		for _, v := range versions {
 			t.Run(v.Name, func(t *testing.T) {
 				step := uint64(0)
@@ -310,31 +292,28 @@ func FuzzStatePreimageRead(f *testing.F) {
 				goVm := v.VMFactory(oracle, os.Stdout, os.Stderr, testutil.CreateLogger(),
 					WithStep(step), WithPreimageKey(preimageKey), WithPreimageOffset(preimageOffset))
 				state := goVm.GetState()
-				state.GetRegistersRef()[2] = exec.SysRead
-				state.GetRegistersRef()[4] = exec.FdPreimageRead
-				state.GetRegistersRef()[5] = addr
-				state.GetRegistersRef()[6] = count
-				state.GetMemory().SetMemory(0, syscallInsn)
+				testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysRead, exec.FdPreimageRead, addr, count)
+				testutil.StoreInstruction(state.GetMemory(), 0, syscallInsn)
 
 				preStatePreimageKey := state.GetPreimageKey()
 				preStateRoot := state.GetMemory().MerkleRoot()
 				writeLen := count
-				if writeLen > 4 {
-					writeLen = 4
+				if writeLen > arch.WordSizeBytes {
+					writeLen = arch.WordSizeBytes
 				}
-				if preimageOffset+writeLen > uint32(8+len(preimageData)) {
-					writeLen = uint32(8+len(preimageData)) - preimageOffset
+				if Word(preimageOffset)+writeLen > Word(8+len(preimageData)) {
+					writeLen = Word(8+len(preimageData)) - Word(preimageOffset)
 				}
 
 				stepWitness, err := goVm.Step(true)
 				require.NoError(t, err)
 				require.True(t, stepWitness.HasPreimage())
 
-				require.Equal(t, uint32(4), state.GetCpu().PC)
-				require.Equal(t, uint32(8), state.GetCpu().NextPC)
-				require.Equal(t, uint32(0), state.GetCpu().LO)
-				require.Equal(t, uint32(0), state.GetCpu().HI)
-				require.Equal(t, uint32(0), state.GetHeap())
+				require.Equal(t, Word(4), state.GetCpu().PC)
+				require.Equal(t, Word(8), state.GetCpu().NextPC)
+				require.Equal(t, Word(0), state.GetCpu().LO)
+				require.Equal(t, Word(0), state.GetCpu().HI)
+				require.Equal(t, Word(0), state.GetHeap())
 				require.Equal(t, uint8(0), state.GetExitCode())
 				require.Equal(t, false, state.GetExited())
 				if writeLen > 0 {
@@ -349,9 +328,8 @@ func FuzzStatePreimageRead(f *testing.F) {
 				require.Equal(t, preStatePreimageKey, state.GetPreimageKey())
 
 				evm := testutil.NewMIPSEVM(v.Contracts)
-				evmPost := evm.Step(t, stepWitness, step, v.StateHashFn)
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, step, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			})
 		}
@@ -360,7 +338,7 @@ func FuzzStatePreimageRead(f *testing.F) {
 
 func FuzzStateHintWrite(f *testing.F) {
 	versions := GetMipsVersionTestCases(f)
-	f.Fuzz(func(t *testing.T, addr uint32, count uint32, randSeed int64) {
+	f.Fuzz(func(t *testing.T, addr Word, count Word, randSeed int64) {
 		for _, v := range versions {
 			t.Run(v.Name, func(t *testing.T) {
 				step := uint64(0)
@@ -371,10 +349,7 @@ func FuzzStateHintWrite(f *testing.F) {
 				goVm := v.VMFactory(oracle, os.Stdout, os.Stderr, testutil.CreateLogger(),
 					WithStep(step), WithPreimageKey(preimageKey))
 				state := goVm.GetState()
-				state.GetRegistersRef()[2] = exec.SysWrite
-				state.GetRegistersRef()[4] = exec.FdHintWrite
-				state.GetRegistersRef()[5] = addr
-				state.GetRegistersRef()[6] = count
+				testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysWrite, exec.FdHintWrite, addr, count)
 
 				// Set random data at the target memory range
 				randBytes, err := randomBytes(randSeed, count)
@@ -382,22 +357,22 @@ func FuzzStateHintWrite(f *testing.F) {
 				err = state.GetMemory().SetMemoryRange(addr, bytes.NewReader(randBytes))
 				require.NoError(t, err)
 				// Set syscall instruction
-				state.GetMemory().SetMemory(0, syscallInsn)
+				testutil.StoreInstruction(state.GetMemory(), 0, syscallInsn)
 
 				preStatePreimageKey := state.GetPreimageKey()
 				preStateRoot := state.GetMemory().MerkleRoot()
 				expectedRegisters := testutil.CopyRegisters(state)
-				expectedRegisters[2] = count
+				expectedRegisters[arch.RegSyscallRet] = count
 
 				stepWitness, err := goVm.Step(true)
 				require.NoError(t, err)
 				require.False(t, stepWitness.HasPreimage())
 
-				require.Equal(t, uint32(4), state.GetCpu().PC)
-				require.Equal(t, uint32(8), state.GetCpu().NextPC)
-				require.Equal(t, uint32(0), state.GetCpu().LO)
-				require.Equal(t, uint32(0), state.GetCpu().HI)
-				require.Equal(t, uint32(0), state.GetHeap())
+				require.Equal(t, Word(4), state.GetCpu().PC)
+				require.Equal(t, Word(8), state.GetCpu().NextPC)
+				require.Equal(t, Word(0), state.GetCpu().LO)
+				require.Equal(t, Word(0), state.GetCpu().HI)
+				require.Equal(t, Word(0), state.GetHeap())
 				require.Equal(t, uint8(0), state.GetExitCode())
 				require.Equal(t, false, state.GetExited())
 				require.Equal(t, preStateRoot, state.GetMemory().MerkleRoot())
@@ -406,9 +381,8 @@ func FuzzStateHintWrite(f *testing.F) {
 				require.Equal(t, expectedRegisters, state.GetRegistersRef())
 
 				evm := testutil.NewMIPSEVM(v.Contracts)
-				evmPost := evm.Step(t, stepWitness, step, v.StateHashFn)
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, step, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			})
 		}
@@ -417,7 +391,7 @@ func FuzzStateHintWrite(f *testing.F) {
 
 func FuzzStatePreimageWrite(f *testing.F) {
 	versions := GetMipsVersionTestCases(f)
-	f.Fuzz(func(t *testing.T, addr uint32, count uint32) {
+	f.Fuzz(func(t *testing.T, addr Word, count Word) {
 		for _, v := range versions {
 			t.Run(v.Name, func(t *testing.T) {
 				step := uint64(0)
@@ -428,11 +402,8 @@ func FuzzStatePreimageWrite(f *testing.F) {
 				goVm := v.VMFactory(oracle, os.Stdout, os.Stderr, testutil.CreateLogger(),
 					WithStep(step), WithPreimageKey(preimageKey), WithPreimageOffset(128))
 				state := goVm.GetState()
-				state.GetRegistersRef()[2] = exec.SysWrite
-				state.GetRegistersRef()[4] = exec.FdPreimageWrite
-				state.GetRegistersRef()[5] = addr
-				state.GetRegistersRef()[6] = count
-				state.GetMemory().SetMemory(0, syscallInsn)
+				testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysWrite, exec.FdPreimageWrite, addr, count)
+				testutil.StoreInstruction(state.GetMemory(), 0, syscallInsn)
 
 				preStateRoot := state.GetMemory().MerkleRoot()
 				expectedRegisters := testutil.CopyRegisters(state)
@@ -440,17 +411,17 @@ func FuzzStatePreimageWrite(f *testing.F) {
 				if sz < count {
 					count = sz
 				}
-				expectedRegisters[2] = count
+				expectedRegisters[arch.RegSyscallRet] = count
 
 				stepWitness, err := goVm.Step(true)
 				require.NoError(t, err)
 				require.False(t, stepWitness.HasPreimage())
 
-				require.Equal(t, uint32(4), state.GetCpu().PC)
-				require.Equal(t, uint32(8), state.GetCpu().NextPC)
-				require.Equal(t, uint32(0), state.GetCpu().LO)
-				require.Equal(t, uint32(0), state.GetCpu().HI)
-				require.Equal(t, uint32(0), state.GetHeap())
+				require.Equal(t, Word(4), state.GetCpu().PC)
+				require.Equal(t, Word(8), state.GetCpu().NextPC)
+				require.Equal(t, Word(0), state.GetCpu().LO)
+				require.Equal(t, Word(0), state.GetCpu().HI)
+				require.Equal(t, Word(0), state.GetHeap())
 				require.Equal(t, uint8(0), state.GetExitCode())
 				require.Equal(t, false, state.GetExited())
 				require.Equal(t, preStateRoot, state.GetMemory().MerkleRoot())
@@ -459,16 +430,15 @@ func FuzzStatePreimageWrite(f *testing.F) {
 				require.Equal(t, expectedRegisters, state.GetRegistersRef())
 
 				evm := testutil.NewMIPSEVM(v.Contracts)
-				evmPost := evm.Step(t, stepWitness, step, v.StateHashFn)
 				goPost, _ := goVm.GetState().EncodeWitness()
-				require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+				evm.ValidateStep(t, stepWitness, step, v.StateHashFn, goPost,
 					"mipsevm produced different state than EVM")
 			})
 		}
 	})
 }
 
-func randomBytes(seed int64, length uint32) ([]byte, error) {
+func randomBytes(seed int64, length Word) ([]byte, error) {
 	r := rand.New(rand.NewSource(seed))
 	randBytes := make([]byte, length)
 	if _, err := r.Read(randBytes); err != nil {
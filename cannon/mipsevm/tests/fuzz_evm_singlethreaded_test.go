@@ -1,3 +1,5 @@
+//go:build !cannon64
+
 package tests
 
 import (
@@ -5,9 +7,9 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
 )
@@ -20,12 +22,12 @@ func FuzzStateSyscallCloneST(f *testing.F) {
 		goVm := v.VMFactory(nil, os.Stdout, os.Stderr, testutil.CreateLogger(),
 			WithPC(pc), WithNextPC(nextPC), WithStep(step), WithPreimageOffset(preimageOffset))
 		state := goVm.GetState()
-		state.GetRegistersRef()[2] = exec.SysClone
+		testutil.SetSyscallArgs(state.GetRegistersRef(), exec.SysClone)
 
-		state.GetMemory().SetMemory(pc, syscallInsn)
+		testutil.StoreInstruction(state.GetMemory(), pc, syscallInsn)
 		preStateRoot := state.GetMemory().MerkleRoot()
 		expectedRegisters := testutil.CopyRegisters(state)
-		expectedRegisters[2] = 0x1
+		expectedRegisters[arch.RegSyscallRet] = 0x1
 
 		stepWitness, err := goVm.Step(true)
 		require.NoError(t, err)
@@ -45,9 +47,8 @@ func FuzzStateSyscallCloneST(f *testing.F) {
 		require.Equal(t, preimageOffset, state.GetPreimageOffset())
 
 		evm := testutil.NewMIPSEVM(v.Contracts)
-		evmPost := evm.Step(t, stepWitness, step, v.StateHashFn)
 		goPost, _ := goVm.GetState().EncodeWitness()
-		require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(),
+		evm.ValidateStep(t, stepWitness, step, v.StateHashFn, goPost,
 			"mipsevm produced different state than EVM")
 	})
 }
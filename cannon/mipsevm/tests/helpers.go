@@ -9,36 +9,43 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
 )
 
+type Word = arch.Word
+
 type StateMutator interface {
-	SetPC(pc uint32)
-	SetNextPC(nextPC uint32)
-	SetHeap(addr uint32)
+	SetPC(pc Word)
+	SetNextPC(nextPC Word)
+	SetHeap(addr Word)
 	SetLastHint(lastHint hexutil.Bytes)
 	SetPreimageKey(key common.Hash)
 	SetPreimageOffset(offset uint32)
 	SetStep(step uint64)
+	// SetStrictMode sets the strict mode of the VM created with the state, see mipsevm.FPVM.SetStrictMode
+	SetStrictMode(strict bool)
 }
 
 type singlethreadedMutator struct {
-	state *singlethreaded.State
+	state  *singlethreaded.State
+	strict bool
 }
 
 var _ StateMutator = (*singlethreadedMutator)(nil)
 
-func (m *singlethreadedMutator) SetPC(pc uint32) {
+func (m *singlethreadedMutator) SetPC(pc Word) {
 	m.state.Cpu.PC = pc
 }
 
-func (m *singlethreadedMutator) SetNextPC(nextPC uint32) {
+func (m *singlethreadedMutator) SetNextPC(nextPC Word) {
 	m.state.Cpu.NextPC = nextPC
 }
 
-func (m *singlethreadedMutator) SetHeap(addr uint32) {
+func (m *singlethreadedMutator) SetHeap(addr Word) {
 	m.state.Heap = addr
 }
 
@@ -58,22 +65,27 @@ func (m *singlethreadedMutator) SetStep(step uint64) {
 	m.state.Step = step
 }
 
+func (m *singlethreadedMutator) SetStrictMode(strict bool) {
+	m.strict = strict
+}
+
 type multithreadedMutator struct {
-	state *multithreaded.State
+	state  *multithreaded.State
+	strict bool
 }
 
 var _ StateMutator = (*multithreadedMutator)(nil)
 
-func (m *multithreadedMutator) SetPC(pc uint32) {
+func (m *multithreadedMutator) SetPC(pc Word) {
 	thread := m.state.GetCurrentThread()
 	thread.Cpu.PC = pc
 }
 
-func (m *multithreadedMutator) SetHeap(addr uint32) {
+func (m *multithreadedMutator) SetHeap(addr Word) {
 	m.state.Heap = addr
 }
 
-func (m *multithreadedMutator) SetNextPC(nextPC uint32) {
+func (m *multithreadedMutator) SetNextPC(nextPC Word) {
 	thread := m.state.GetCurrentThread()
 	thread.Cpu.NextPC = nextPC
 }
@@ -94,21 +106,25 @@ func (m *multithreadedMutator) SetStep(step uint64) {
 	m.state.Step = step
 }
 
+func (m *multithreadedMutator) SetStrictMode(strict bool) {
+	m.strict = strict
+}
+
 type VMOption func(vm StateMutator)
 
-func WithPC(pc uint32) VMOption {
+func WithPC(pc Word) VMOption {
 	return func(state StateMutator) {
 		state.SetPC(pc)
 	}
 }
 
-func WithNextPC(nextPC uint32) VMOption {
+func WithNextPC(nextPC Word) VMOption {
 	return func(state StateMutator) {
 		state.SetNextPC(nextPC)
 	}
 }
 
-func WithHeap(addr uint32) VMOption {
+func WithHeap(addr Word) VMOption {
 	return func(state StateMutator) {
 		state.SetHeap(addr)
 	}
@@ -138,6 +154,13 @@ func WithStep(step uint64) VMOption {
 	}
 }
 
+// WithStrictMode creates the VM in strict mode, faulting on unaligned loads and stores.
+func WithStrictMode() VMOption {
+	return func(state StateMutator) {
+		state.SetStrictMode(true)
+	}
+}
+
 type VMFactory func(po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger, opts ...VMOption) mipsevm.FPVM
 
 func singleThreadedVmFactory(po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger, opts ...VMOption) mipsevm.FPVM {
@@ -146,7 +169,9 @@ func singleThreadedVmFactory(po mipsevm.PreimageOracle, stdOut, stdErr io.Writer
 	for _, opt := range opts {
 		opt(mutator)
 	}
-	return singlethreaded.NewInstrumentedState(state, po, stdOut, stdErr, nil)
+	vm := singlethreaded.NewInstrumentedState(state, po, stdOut, stdErr, nil)
+	vm.SetStrictMode(mutator.strict)
+	return vm
 }
 
 func multiThreadedVmFactory(po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger, opts ...VMOption) mipsevm.FPVM {
@@ -155,7 +180,9 @@ func multiThreadedVmFactory(po mipsevm.PreimageOracle, stdOut, stdErr io.Writer,
 	for _, opt := range opts {
 		opt(mutator)
 	}
-	return multithreaded.NewInstrumentedState(state, po, stdOut, stdErr, log)
+	vm := multithreaded.NewInstrumentedState(state, po, stdOut, stdErr, log)
+	vm.SetStrictMode(mutator.strict)
+	return vm
 }
 
 type ElfVMFactory func(t require.TestingT, elfFile string, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer, log log.Logger) mipsevm.FPVM
@@ -171,34 +198,49 @@ func multiThreadElfVmFactory(t require.TestingT, elfFile string, po mipsevm.Prei
 }
 
 type VersionedVMTestCase struct {
-	Name         string
-	Contracts    *testutil.ContractMetadata
-	StateHashFn  mipsevm.HashFn
-	VMFactory    VMFactory
-	ElfVMFactory ElfVMFactory
+	Name          string
+	Contracts     *testutil.ContractMetadata
+	StateHashFn   mipsevm.HashFn
+	SyscallPolicy *exec.SyscallPolicy
+	VMFactory     VMFactory
+	ElfVMFactory  ElfVMFactory
 }
 
 func GetSingleThreadedTestCase(t require.TestingT) VersionedVMTestCase {
 	return VersionedVMTestCase{
-		Name:         "single-threaded",
-		Contracts:    testutil.TestContractsSetup(t, testutil.MipsSingleThreaded),
-		StateHashFn:  singlethreaded.GetStateHashFn(),
-		VMFactory:    singleThreadedVmFactory,
-		ElfVMFactory: singleThreadElfVmFactory,
+		Name:          "single-threaded",
+		Contracts:     testutil.TestContractsSetup(t, testutil.MipsSingleThreaded),
+		StateHashFn:   singlethreaded.GetStateHashFn(),
+		SyscallPolicy: singlethreaded.GetSyscallPolicy(singlethreaded.LatestVersion),
+		VMFactory:     singleThreadedVmFactory,
+		ElfVMFactory:  singleThreadElfVmFactory,
 	}
 }
 
+// GetMultiThreadedTestCase returns the multithreaded VM test case.
+// No MIPS contract implements the 64-bit VM, so the EVM checks of its differential tests are skipped,
+// see testutil.MIPSEVM.ValidateStep.
 func GetMultiThreadedTestCase(t require.TestingT) VersionedVMTestCase {
+	name, version := "multi-threaded", testutil.MipsMultithreaded
+	if !arch.IsMips32 {
+		name, version = "multi-threaded-64", testutil.MipsMultithreaded64
+	}
 	return VersionedVMTestCase{
-		Name:         "multi-threaded",
-		Contracts:    testutil.TestContractsSetup(t, testutil.MipsMultithreaded),
-		StateHashFn:  multithreaded.GetStateHashFn(),
-		VMFactory:    multiThreadedVmFactory,
-		ElfVMFactory: multiThreadElfVmFactory,
+		Name:          name,
+		Contracts:     testutil.TestContractsSetup(t, version),
+		StateHashFn:   multithreaded.GetStateHashFn(),
+		SyscallPolicy: multithreaded.GetSyscallPolicy(multithreaded.LatestVersion),
+		VMFactory:     multiThreadedVmFactory,
+		ElfVMFactory:  multiThreadElfVmFactory,
 	}
 }
 
+// GetMipsVersionTestCases returns the VM versions supported by the current build.
+// The single-threaded VM is only available for 32-bit MIPS.
 func GetMipsVersionTestCases(t require.TestingT) []VersionedVMTestCase {
+	if !arch.IsMips32 {
+		return []VersionedVMTestCase{GetMultiThreadedTestCase(t)}
+	}
 	return []VersionedVMTestCase{
 		GetSingleThreadedTestCase(t),
 		GetMultiThreadedTestCase(t),
@@ -0,0 +1,116 @@
+package testutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
+)
+
+// artifactHashesFile records the keccak of the deployed bytecode of the contracts
+// that the Go differential tests were last verified against.
+const artifactHashesFile = "../../testdata/contract-hashes.json"
+
+var artifactHashesLock sync.Mutex
+
+// checkArtifactHashes fails the test if the deployed bytecode of the contracts differs from the recorded hashes,
+// so contract changes can't silently diverge from the Go VM implementation the tests expectations come from.
+// Contracts without a recorded hash fail the check too, so new contracts can't skip it.
+func checkArtifactHashes(t require.TestingT, artifacts map[string]*foundry.Artifact) {
+	artifactHashesLock.Lock()
+	defer artifactHashesLock.Unlock()
+
+	recorded, err := readArtifactHashes(artifactHashesFile)
+	require.NoError(t, err)
+	if diff := diffArtifactHashes(recorded, artifactHashes(artifacts)); len(diff) > 0 {
+		require.Fail(t, "contract bytecode is not recorded, or changed since the Go test expectations were last verified",
+			"%s\nCheck the Go VM still matches the contracts, then record the new bytecode with `make contract-hashes` in cannon/.",
+			strings.Join(diff, "\n"))
+	}
+}
+
+// RecordArtifactHashes records the deployed bytecode of the contracts implementing the VM versions,
+// as the bytecode the Go differential tests are verified against. Only `make contract-hashes` records them.
+func RecordArtifactHashes(t require.TestingT, versions ...MipsVersion) {
+	artifactHashesLock.Lock()
+	defer artifactHashesLock.Unlock()
+
+	recorded, err := readArtifactHashes(artifactHashesFile)
+	require.NoError(t, err)
+	for _, version := range versions {
+		artifacts, err := loadArtifacts(version)
+		require.NoError(t, err)
+		mipsName, err := mipsContractName(version)
+		require.NoError(t, err)
+		for name, hash := range artifactHashes(contractArtifacts(mipsName, artifacts)) {
+			recorded[name] = hash
+		}
+	}
+	require.NoError(t, writeArtifactHashes(artifactHashesFile, recorded))
+}
+
+// contractArtifacts returns the artifacts of the contracts implementing a VM version, by contract name.
+func contractArtifacts(mipsName string, artifacts *Artifacts) map[string]*foundry.Artifact {
+	return map[string]*foundry.Artifact{
+		mipsName:         artifacts.MIPS,
+		"PreimageOracle": artifacts.Oracle,
+	}
+}
+
+func artifactHashes(artifacts map[string]*foundry.Artifact) map[string]common.Hash {
+	hashes := make(map[string]common.Hash, len(artifacts))
+	for name, artifact := range artifacts {
+		hashes[name] = crypto.Keccak256Hash(artifact.DeployedBytecode.Object)
+	}
+	return hashes
+}
+
+// diffArtifactHashes describes the contracts whose hash is not recorded or differs from the recorded hash,
+// sorted by contract name.
+func diffArtifactHashes(recorded map[string]common.Hash, actual map[string]common.Hash) []string {
+	var diff []string
+	for name, hash := range actual {
+		expected, ok := recorded[name]
+		if !ok {
+			diff = append(diff, fmt.Sprintf("  %v: not recorded, deployed bytecode is %v", name, hash))
+			continue
+		}
+		if expected == hash {
+			continue
+		}
+		diff = append(diff, fmt.Sprintf("  %v: recorded %v, deployed bytecode is now %v", name, expected, hash))
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+func readArtifactHashes(path string) (map[string]common.Hash, error) {
+	hashes := make(map[string]common.Hash)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return hashes, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read contract hashes: %w", err)
+	}
+	if err := json.Unmarshal(data, &hashes); err != nil {
+		return nil, fmt.Errorf("failed to parse contract hashes %v: %w", path, err)
+	}
+	return hashes, nil
+}
+
+func writeArtifactHashes(path string, hashes map[string]common.Hash) error {
+	data, err := json.MarshalIndent(hashes, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,44 @@
+package testutil
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffArtifactHashes(t *testing.T) {
+	recorded := map[string]common.Hash{
+		"MIPS":           {0x01},
+		"PreimageOracle": {0x02},
+	}
+	require.Empty(t, diffArtifactHashes(recorded, map[string]common.Hash{"MIPS": {0x01}, "PreimageOracle": {0x02}}))
+	// Contracts without a recorded hash fail the check
+	require.Equal(t, []string{
+		"  MIPS2: not recorded, deployed bytecode is 0x0300000000000000000000000000000000000000000000000000000000000000",
+	}, diffArtifactHashes(recorded, map[string]common.Hash{"MIPS": {0x01}, "MIPS2": {0x03}}))
+
+	diff := diffArtifactHashes(recorded, map[string]common.Hash{"MIPS": {0xaa}, "PreimageOracle": {0xbb}})
+	require.Equal(t, []string{
+		"  MIPS: recorded 0x0100000000000000000000000000000000000000000000000000000000000000, deployed bytecode is now 0xaa00000000000000000000000000000000000000000000000000000000000000",
+		"  PreimageOracle: recorded 0x0200000000000000000000000000000000000000000000000000000000000000, deployed bytecode is now 0xbb00000000000000000000000000000000000000000000000000000000000000",
+	}, diff)
+}
+
+func TestArtifactHashesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hashes.json")
+	hashes, err := readArtifactHashes(path)
+	require.NoError(t, err)
+	require.Empty(t, hashes)
+
+	hashes["MIPS"] = common.Hash{0x01}
+	require.NoError(t, writeArtifactHashes(path, hashes))
+	read, err := readArtifactHashes(path)
+	require.NoError(t, err)
+	require.Equal(t, hashes, read)
+
+	// The recorded hashes must always parse
+	_, err = readArtifactHashes(artifactHashesFile)
+	require.NoError(t, err)
+}
@@ -11,4 +11,7 @@ type MipsVersion int
 const (
 	MipsSingleThreaded MipsVersion = iota
 	MipsMultithreaded
+	// MipsMultithreaded64 is the 64-bit multithreaded VM. No contract implements it yet,
+	// so its differential tests only run the Go VM, and skip the EVM checks.
+	MipsMultithreaded64
 )
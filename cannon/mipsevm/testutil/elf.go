@@ -2,10 +2,12 @@ package testutil
 
 import (
 	"debug/elf"
+	"fmt"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 )
 
@@ -24,3 +26,12 @@ func LoadELFProgram[T mipsevm.FPVMState](t require.TestingT, name string, initSt
 	require.NoError(t, program.PatchStack(state), "add initial stack")
 	return state
 }
+
+// ProgramPath returns the path of the example program with the given name, built for the architecture of the VM.
+// The path is relative to the mipsevm package directories.
+func ProgramPath(name string) string {
+	if arch.IsMips32 {
+		return fmt.Sprintf("../../testdata/example/bin/%s.elf", name)
+	}
+	return fmt.Sprintf("../../testdata/example/bin/%s64.elf", name)
+}
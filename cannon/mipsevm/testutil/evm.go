@@ -36,14 +36,22 @@ type Addresses struct {
 	FeeRecipient common.Address
 }
 
+// ContractMetadata holds the contracts of a VM version.
+// The Artifacts are nil if no contract implements the VM version: the EVM checks of its differential tests are skipped.
 type ContractMetadata struct {
 	Artifacts *Artifacts
 	Addresses *Addresses
 }
 
 func TestContractsSetup(t require.TestingT, version MipsVersion) *ContractMetadata {
+	if version == MipsMultithreaded64 {
+		return &ContractMetadata{Addresses: &Addresses{}}
+	}
 	artifacts, err := loadArtifacts(version)
 	require.NoError(t, err)
+	mipsName, err := mipsContractName(version)
+	require.NoError(t, err)
+	checkArtifactHashes(t, contractArtifacts(mipsName, artifacts))
 
 	addrs := &Addresses{
 		MIPS:         common.Address{0: 0xff, 19: 1},
@@ -55,17 +63,25 @@ func TestContractsSetup(t require.TestingT, version MipsVersion) *ContractMetada
 	return &ContractMetadata{Artifacts: artifacts, Addresses: addrs}
 }
 
-// loadArtifacts loads the Cannon contracts, from the contracts package.
-func loadArtifacts(version MipsVersion) (*Artifacts, error) {
-	var mipsMetadata string
+// mipsContractName returns the name of the MIPS contract implementing the VM version.
+func mipsContractName(version MipsVersion) (string, error) {
 	switch version {
 	case MipsSingleThreaded:
-		mipsMetadata = "../../../packages/contracts-bedrock/forge-artifacts/MIPS.sol/MIPS.json"
+		return "MIPS", nil
 	case MipsMultithreaded:
-		mipsMetadata = "../../../packages/contracts-bedrock/forge-artifacts/MIPS2.sol/MIPS2.json"
+		return "MIPS2", nil
 	default:
-		return nil, fmt.Errorf("Unknown MipsVersion supplied: %v", version)
+		return "", fmt.Errorf("Unknown MipsVersion supplied: %v", version)
+	}
+}
+
+// loadArtifacts loads the Cannon contracts, from the contracts package.
+func loadArtifacts(version MipsVersion) (*Artifacts, error) {
+	mipsName, err := mipsContractName(version)
+	if err != nil {
+		return nil, err
 	}
+	mipsMetadata := fmt.Sprintf("../../../packages/contracts-bedrock/forge-artifacts/%[1]v.sol/%[1]v.json", mipsName)
 
 	mips, err := foundry.ReadArtifact(mipsMetadata)
 	if err != nil {
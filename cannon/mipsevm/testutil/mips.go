@@ -8,7 +8,9 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/vm"
@@ -28,15 +30,43 @@ type MIPSEVM struct {
 	// Track step execution for logging purposes
 	lastStep      uint64
 	lastStepInput []byte
+	// snapshot of the state right after deployment, to reset to
+	deployed int
 }
 
 func NewMIPSEVM(contracts *ContractMetadata) *MIPSEVM {
+	if contracts.Artifacts == nil {
+		// No contract implements the VM version, see ContractMetadata
+		return &MIPSEVM{addrs: contracts.Addresses, lastStep: math.MaxUint64}
+	}
 	env, evmState := NewEVMEnv(contracts)
-	return &MIPSEVM{env, evmState, contracts.Addresses, nil, contracts.Artifacts, math.MaxUint64, nil}
+	return &MIPSEVM{env, evmState, contracts.Addresses, nil, contracts.Artifacts, math.MaxUint64, nil, evmState.Snapshot()}
+}
+
+// HasContract returns whether a contract implements the VM version, so the EVM steps can be checked.
+func (m *MIPSEVM) HasContract() bool {
+	return m.artifacts != nil
+}
+
+// Reset reverts the EVM state to right after the contracts were deployed, and clears the tracer and local oracle.
+// This allows reusing a single MIPSEVM across test cases, instead of redeploying the contracts for every test case.
+func (m *MIPSEVM) Reset() {
+	if m.HasContract() {
+		m.evmState.RevertToSnapshot(m.deployed)
+		// Reverting invalidates the snapshot, so take a new one of the same state.
+		m.deployed = m.evmState.Snapshot()
+		m.env.Config.Tracer = nil
+	}
+	m.localOracle = nil
+	m.lastStep = math.MaxUint64
+	m.lastStepInput = nil
 }
 
+// SetTracer sets the tracer of the EVM. It is ignored if no contract implements the VM version.
 func (m *MIPSEVM) SetTracer(tracer *tracing.Hooks) {
-	m.env.Config.Tracer = tracer
+	if m.HasContract() {
+		m.env.Config.Tracer = tracer
+	}
 }
 
 func (m *MIPSEVM) SetLocalOracle(oracle mipsevm.PreimageOracle) {
@@ -45,6 +75,7 @@ func (m *MIPSEVM) SetLocalOracle(oracle mipsevm.PreimageOracle) {
 
 // Step is a pure function that computes the poststate from the VM state encoded in the StepWitness.
 func (m *MIPSEVM) Step(t *testing.T, stepWitness *mipsevm.StepWitness, step uint64, stateHashFn mipsevm.HashFn) []byte {
+	require.True(t, m.HasContract(), "no contract implements the VM version")
 	m.lastStep = step
 	m.lastStepInput = nil
 	sender := common.Address{0x13, 0x37}
@@ -81,6 +112,37 @@ func (m *MIPSEVM) Step(t *testing.T, stepWitness *mipsevm.StepWitness, step uint
 	return evmPost
 }
 
+// ValidateStep steps the VM state encoded in the StepWitness, and asserts that the post-state of the contract
+// matches goPost, the post-state of the Go VM.
+// The test is skipped if no contract implements the VM version.
+func (m *MIPSEVM) ValidateStep(t *testing.T, stepWitness *mipsevm.StepWitness, step uint64, stateHashFn mipsevm.HashFn, goPost []byte, msgAndArgs ...any) {
+	if !m.HasContract() {
+		t.Skip("skipping EVM validation, no contract implements the VM version")
+	}
+	evmPost := m.Step(t, stepWitness, step, stateHashFn)
+	require.Equal(t, hexutil.Bytes(goPost).String(), hexutil.Bytes(evmPost).String(), msgAndArgs...)
+}
+
+// StepReverts steps the VM state encoded in the StepWitness, asserts that the step reverts, and returns the reason.
+// The test is skipped if no contract implements the VM version.
+func (m *MIPSEVM) StepReverts(t *testing.T, stepWitness *mipsevm.StepWitness) string {
+	if !m.HasContract() {
+		t.Skip("skipping EVM revert check, no contract implements the VM version")
+	}
+	sender := common.Address{0x13, 0x37}
+	startingGas := uint64(30_000_000)
+	snap := m.env.StateDB.Snapshot()
+	defer m.env.StateDB.RevertToSnapshot(snap)
+
+	input := EncodeStepInput(t, stepWitness, mipsevm.LocalContext{}, m.artifacts.MIPS)
+	m.lastStepInput = input
+	ret, _, err := m.env.Call(vm.AccountRef(sender), m.addrs.MIPS, input, startingGas, common.U2560)
+	require.EqualValues(t, vm.ErrExecutionReverted, err)
+	require.Empty(t, m.evmState.Logs(), "reverted step must not log a post-state")
+	reason, _ := abi.UnpackRevert(ret)
+	return reason
+}
+
 func EncodeStepInput(t *testing.T, wit *mipsevm.StepWitness, localContext mipsevm.LocalContext, mips *foundry.Artifact) []byte {
 	input, err := mips.ABI.Pack("step", wit.State, wit.ProofData, localContext)
 	require.NoError(t, err)
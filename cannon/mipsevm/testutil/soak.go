@@ -0,0 +1,74 @@
+package testutil
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// SoakEnv is the env var to set to run the soak tests for long, e.g. via `make soak`.
+// Without it, the soak tests run a short smoke run with a fixed seed.
+const SoakEnv = "CANNON_SOAK"
+
+const (
+	// SoakStepsEnv sets the minimum number of steps to run in soak mode, restarting programs that complete before.
+	SoakStepsEnv = "CANNON_SOAK_STEPS"
+	// SoakSeedEnv sets the seed of the soak run, to reproduce a failure. Random in soak mode if not set.
+	SoakSeedEnv = "CANNON_SOAK_SEED"
+	// SoakPreemptRateEnv sets the average number of steps between random preemptions.
+	SoakPreemptRateEnv = "CANNON_SOAK_PREEMPT_RATE"
+	// SoakSampleRateEnv sets the average number of steps between the steps checked against the EVM.
+	SoakSampleRateEnv = "CANNON_SOAK_SAMPLE_RATE"
+)
+
+// SoakConfig configures a long run of a VM with randomized scheduling.
+type SoakConfig struct {
+	Seed int64
+	// Steps is the minimum number of steps to run. Programs that complete are restarted until it is reached,
+	// and each program completes at least once.
+	Steps uint64
+	// PreemptRate is the average number of steps between random preemptions of the current thread.
+	PreemptRate uint64
+	// SampleRate is the average number of steps between the steps checked against the EVM.
+	SampleRate uint64
+}
+
+// ReadSoakConfig returns the soak config of the env, see SoakEnv. The config is logged, to reproduce failures.
+func ReadSoakConfig(t *testing.T) SoakConfig {
+	cfg := SoakConfig{
+		Seed:        1,
+		PreemptRate: 50,
+		SampleRate:  100,
+	}
+	if os.Getenv(SoakEnv) != "" {
+		cfg = SoakConfig{
+			Seed:        time.Now().UnixNano(),
+			Steps:       10_000_000,
+			PreemptRate: 1000,
+			SampleRate:  10_000,
+		}
+	}
+	readUint := func(name string, dest *uint64) {
+		if v := os.Getenv(name); v != "" {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil || n == 0 {
+				t.Fatalf("invalid %s %q: must be a positive integer", name, v)
+			}
+			*dest = n
+		}
+	}
+	readUint(SoakStepsEnv, &cfg.Steps)
+	readUint(SoakPreemptRateEnv, &cfg.PreemptRate)
+	readUint(SoakSampleRateEnv, &cfg.SampleRate)
+	if v := os.Getenv(SoakSeedEnv); v != "" {
+		seed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			t.Fatalf("invalid %s %q: %v", SoakSeedEnv, v, err)
+		}
+		cfg.Seed = seed
+	}
+	t.Logf("soak config: %s=%d %s=%d %s=%d %s=%d", SoakSeedEnv, cfg.Seed, SoakStepsEnv, cfg.Steps,
+		SoakPreemptRateEnv, cfg.PreemptRate, SoakSampleRateEnv, cfg.SampleRate)
+	return cfg
+}
@@ -4,19 +4,59 @@ import (
 	"math/rand"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
 )
 
-func RandomRegisters(seed int64) [32]uint32 {
+type Word = arch.Word
+
+func RandomRegisters(seed int64) [32]Word {
 	r := rand.New(rand.NewSource(seed))
-	var registers [32]uint32
+	var registers [32]Word
 	for i := 0; i < 32; i++ {
-		registers[i] = r.Uint32()
+		if arch.IsMips32 {
+			registers[i] = Word(r.Uint32())
+		} else {
+			registers[i] = Word(r.Uint64())
+		}
 	}
 	return registers
 }
 
-func CopyRegisters(state mipsevm.FPVMState) *[32]uint32 {
-	copy := new([32]uint32)
+func CopyRegisters(state mipsevm.FPVMState) *[32]Word {
+	copy := new([32]Word)
 	*copy = *state.GetRegistersRef()
 	return copy
 }
+
+// SetSyscallArgs sets up the registers to execute the syscall num, with the args passed in a0 to a3, in order.
+func SetSyscallArgs(registers *[32]Word, num Word, args ...Word) {
+	if len(args) > 4 {
+		panic("too many syscall args")
+	}
+	registers[arch.RegSyscallNum] = num
+	for i, arg := range args {
+		registers[arch.RegSyscallParam1+i] = arg
+	}
+}
+
+// SetSyscallResult sets the registers to the result and errno a syscall returns.
+func SetSyscallResult(registers *[32]Word, ret Word, errno Word) {
+	registers[arch.RegSyscallRet] = ret
+	registers[arch.RegSyscallErrno] = errno
+}
+
+// StoreInstruction writes the 32-bit instruction at the 4-byte aligned pc, leaving the rest of the memory word as is.
+func StoreInstruction(mem *memory.Memory, pc Word, insn uint32) {
+	SetMemoryUint32(mem, pc, insn)
+}
+
+// SetMemoryUint32 writes a 32-bit value at the 4-byte aligned addr, leaving the rest of the memory word as is.
+func SetMemoryUint32(mem *memory.Memory, addr Word, val uint32) {
+	if addr&0x3 != 0 {
+		panic("unaligned 32-bit memory access")
+	}
+	effAddr := addr & arch.AddressMask
+	mem.SetMemory(effAddr, exec.UpdateSubWord(addr, mem.GetMemory(effAddr), 4, Word(val)))
+}
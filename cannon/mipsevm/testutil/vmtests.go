@@ -2,6 +2,7 @@ package testutil
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"os"
 	"path"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
 )
 
@@ -19,6 +21,9 @@ type VMFactory[T mipsevm.FPVMState] func(state T, po mipsevm.PreimageOracle, std
 type StateFactory[T mipsevm.FPVMState] func() T
 
 func RunVMTests_OpenMips[T mipsevm.FPVMState](t *testing.T, stateFactory StateFactory[T], vmFactory VMFactory[T], excludedTests ...string) {
+	if !arch.IsMips32 {
+		t.Skip("the open_mips_tests programs are built for 32-bit MIPS")
+	}
 	testFiles, err := os.ReadDir("../tests/open_mips_tests/test/bin")
 	require.NoError(t, err)
 
@@ -33,7 +38,7 @@ func RunVMTests_OpenMips[T mipsevm.FPVMState](t *testing.T, stateFactory StateFa
 			oracle := SelectOracleFixture(t, f.Name())
 			// Short-circuit early for exit_group.bin
 			exitGroup := f.Name() == "exit_group.bin"
-			expectPanic := strings.HasSuffix(f.Name(), "panic.bin")
+			expectFault := strings.HasSuffix(f.Name(), "panic.bin")
 
 			// TODO: currently tests are compiled as flat binary objects
 			// We can use more standard tooling to compile them to ELF files and get remove maketests.py
@@ -49,21 +54,10 @@ func RunVMTests_OpenMips[T mipsevm.FPVMState](t *testing.T, stateFactory StateFa
 			require.NoError(t, err, "load program into state")
 
 			// set the return address ($ra) to jump into when test completes
-			state.GetRegistersRef()[31] = EndAddr
+			state.GetRegistersRef()[arch.RegRA] = EndAddr
 
 			us := vmFactory(state, oracle, os.Stdout, os.Stderr, CreateLogger())
 
-			// Catch panics and check if they are expected
-			defer func() {
-				if r := recover(); r != nil {
-					if expectPanic {
-						// Success
-					} else {
-						t.Errorf("unexpected panic: %v", r)
-					}
-				}
-			}()
-
 			for i := 0; i < 1000; i++ {
 				if us.GetState().GetPC() == EndAddr {
 					break
@@ -72,18 +66,21 @@ func RunVMTests_OpenMips[T mipsevm.FPVMState](t *testing.T, stateFactory StateFa
 					break
 				}
 				_, err := us.Step(false)
+				if expectFault && errors.Is(err, mipsevm.ErrFault) {
+					break
+				}
 				require.NoError(t, err)
 			}
 
 			if exitGroup {
-				require.NotEqual(t, uint32(EndAddr), us.GetState().GetPC(), "must not reach end")
+				require.NotEqual(t, Word(EndAddr), us.GetState().GetPC(), "must not reach end")
 				require.True(t, us.GetState().GetExited(), "must set exited state")
 				require.Equal(t, uint8(1), us.GetState().GetExitCode(), "must exit with 1")
-			} else if expectPanic {
-				require.NotEqual(t, uint32(EndAddr), us.GetState().GetPC(), "must not reach end")
+			} else if expectFault {
+				require.NotEqual(t, Word(EndAddr), us.GetState().GetPC(), "must not reach end")
 			} else {
-				require.Equal(t, uint32(EndAddr), us.GetState().GetPC(), "must reach end")
-				done, result := state.GetMemory().GetMemory(BaseAddrEnd+4), state.GetMemory().GetMemory(BaseAddrEnd+8)
+				require.Equal(t, Word(EndAddr), us.GetState().GetPC(), "must reach end")
+				done, result := state.GetMemory().GetUint32(BaseAddrEnd+4), state.GetMemory().GetUint32(BaseAddrEnd+8)
 				// inspect test result
 				require.Equal(t, done, uint32(1), "must be done")
 				require.Equal(t, result, uint32(1), "must have success result")
@@ -92,8 +89,126 @@ func RunVMTests_OpenMips[T mipsevm.FPVMState](t *testing.T, stateFactory StateFa
 	}
 }
 
+// RunVMTests_FastExecution runs the open_mips_tests programs with fast execution, see mipsevm.FPVM.RunSteps,
+// and checks that they result in the same state as stepping each instruction.
+func RunVMTests_FastExecution[T mipsevm.FPVMState](t *testing.T, stateFactory StateFactory[T], vmFactory VMFactory[T], excludedTests ...string) {
+	if !arch.IsMips32 {
+		t.Skip("the open_mips_tests programs are built for 32-bit MIPS")
+	}
+	testFiles, err := os.ReadDir("../tests/open_mips_tests/test/bin")
+	require.NoError(t, err)
+
+	for _, f := range testFiles {
+		t.Run(f.Name(), func(t *testing.T) {
+			for _, skipped := range excludedTests {
+				if f.Name() == skipped {
+					t.Skipf("Skipping explicitly excluded open_mips testcase: %v", f.Name())
+				}
+			}
+			programMem, err := os.ReadFile(path.Join("../tests/open_mips_tests/test/bin", f.Name()))
+			require.NoError(t, err)
+			newVM := func() mipsevm.FPVM {
+				state := stateFactory()
+				require.NoError(t, state.GetMemory().SetMemoryRange(0, bytes.NewReader(programMem)))
+				state.GetRegistersRef()[arch.RegRA] = EndAddr
+				return vmFactory(state, SelectOracleFixture(t, f.Name()), io.Discard, io.Discard, CreateLogger())
+			}
+
+			// step each instruction until the end, the exit or a fault
+			expected := newVM()
+			var expectedErr error
+			for i := 0; i < 1000; i++ {
+				if expected.GetState().GetPC() == EndAddr || expected.GetState().GetExited() {
+					break
+				}
+				if _, expectedErr = expected.Step(false); expectedErr != nil {
+					break
+				}
+			}
+			steps := expected.GetState().GetStep()
+
+			fast := newVM()
+			fast.SetFastExecution(true)
+			var fastErr error
+			for fast.GetState().GetStep() < steps && !fast.GetState().GetExited() {
+				if fastErr = fast.RunSteps(steps - fast.GetState().GetStep()); fastErr != nil {
+					break
+				}
+			}
+			require.Equal(t, expectedErr, fastErr)
+
+			_, expectedHash := expected.GetState().EncodeWitness()
+			_, fastHash := fast.GetState().EncodeWitness()
+			require.Equal(t, steps, fast.GetState().GetStep())
+			require.Equal(t, expectedHash, fastHash, "fast execution must result in the same state")
+		})
+	}
+}
+
+// RunVMTests_SnapshotRestore runs the open_mips_tests programs, and checks that the runs from a snapshot taken halfway,
+// either restored in the VM or executed by another VM, result in the same state as the run without it.
+func RunVMTests_SnapshotRestore[T mipsevm.FPVMState](t *testing.T, stateFactory StateFactory[T], vmFactory VMFactory[T], excludedTests ...string) {
+	if !arch.IsMips32 {
+		t.Skip("the open_mips_tests programs are built for 32-bit MIPS")
+	}
+	testFiles, err := os.ReadDir("../tests/open_mips_tests/test/bin")
+	require.NoError(t, err)
+
+	for _, f := range testFiles {
+		t.Run(f.Name(), func(t *testing.T) {
+			for _, skipped := range excludedTests {
+				if f.Name() == skipped {
+					t.Skipf("Skipping explicitly excluded open_mips testcase: %v", f.Name())
+				}
+			}
+			programMem, err := os.ReadFile(path.Join("../tests/open_mips_tests/test/bin", f.Name()))
+			require.NoError(t, err)
+			oracle := SelectOracleFixture(t, f.Name())
+			newVM := func() mipsevm.FPVM {
+				state := stateFactory()
+				require.NoError(t, state.GetMemory().SetMemoryRange(0, bytes.NewReader(programMem)))
+				state.GetRegistersRef()[arch.RegRA] = EndAddr
+				return vmFactory(state, oracle, io.Discard, io.Discard, CreateLogger())
+			}
+			// step until the end, the exit or a fault
+			stepUntil := func(vm mipsevm.FPVM, end uint64) {
+				for vm.GetState().GetStep() < end && vm.GetState().GetPC() != EndAddr && !vm.GetState().GetExited() {
+					if err := vm.RunSteps(1); err != nil {
+						break
+					}
+				}
+			}
+			expected := newVM()
+			stepUntil(expected, 1000)
+			_, expectedHash := expected.GetState().EncodeWitness()
+
+			vm := newVM()
+			// blocks translated after the snapshot must be invalidated by the restore
+			vm.SetFastExecution(true)
+			stepUntil(vm, expected.GetState().GetStep()/2)
+			snapshot := vm.GetState().Snapshot()
+			_, snapshotHash := snapshot.EncodeWitness()
+			stepUntil(vm, 1000)
+			_, hash := vm.GetState().EncodeWitness()
+			require.Equal(t, expectedHash, hash)
+
+			require.NoError(t, vm.GetState().Restore(snapshot))
+			_, restoredHash := vm.GetState().EncodeWitness()
+			require.Equal(t, snapshotHash, restoredHash, "restored state must have the hash of the snapshot")
+			stepUntil(vm, 1000)
+			_, hash = vm.GetState().EncodeWitness()
+			require.Equal(t, expectedHash, hash, "run from the restored snapshot must result in the same state")
+
+			branch := vmFactory(snapshot.(T), oracle, io.Discard, io.Discard, CreateLogger())
+			stepUntil(branch, 1000)
+			_, hash = branch.GetState().EncodeWitness()
+			require.Equal(t, expectedHash, hash, "run of the snapshot must result in the same state")
+		})
+	}
+}
+
 func RunVMTest_Hello[T mipsevm.FPVMState](t *testing.T, initState program.CreateInitialFPVMState[T], vmFactory VMFactory[T], doPatchGo bool) {
-	state := LoadELFProgram(t, "../../testdata/example/bin/hello.elf", initState, doPatchGo)
+	state := LoadELFProgram(t, ProgramPath("hello"), initState, doPatchGo)
 
 	var stdOutBuf, stdErrBuf bytes.Buffer
 	us := vmFactory(state, nil, io.MultiWriter(&stdOutBuf, os.Stdout), io.MultiWriter(&stdErrBuf, os.Stderr), CreateLogger())
@@ -114,7 +229,7 @@ func RunVMTest_Hello[T mipsevm.FPVMState](t *testing.T, initState program.Create
 }
 
 func RunVMTest_Claim[T mipsevm.FPVMState](t *testing.T, initState program.CreateInitialFPVMState[T], vmFactory VMFactory[T], doPatchGo bool) {
-	state := LoadELFProgram(t, "../../testdata/example/bin/claim.elf", initState, doPatchGo)
+	state := LoadELFProgram(t, ProgramPath("claim"), initState, doPatchGo)
 
 	oracle, expectedStdOut, expectedStdErr := ClaimTestOracle(t)
 
@@ -0,0 +1,260 @@
+// Package tracer implements tracers of the steps executed by a VM.
+package tracer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/arch"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+)
+
+// leafSize is the size of the memory proof leaf: a step writes to at most the leaf it accesses.
+const leafSize = 32
+
+// Record is the JSON record of a step.
+// Steps of the multithreaded VM that only schedule threads have no instruction, and only the step number.
+type Record struct {
+	Step uint64 `json:"step"`
+	// Thread is the ID of the thread executing the instruction, in the multithreaded VM.
+	Thread *uint64         `json:"thread,omitempty"`
+	PC     *hexutil.Uint64 `json:"pc,omitempty"`
+	Insn   *hexutil.Uint64 `json:"insn,omitempty"`
+	Op     string          `json:"op,omitempty"`
+	// Func is the guest symbol containing the PC with the offset into it, e.g. runtime.mallocgc+0x54,
+	// if the tracer has a symbolizer.
+	Func string `json:"func,omitempty"`
+	// Regs are the registers of the thread that the instruction changed, with their new values.
+	Regs []RegisterChange `json:"regs,omitempty"`
+	// Mem are the memory words that the instruction changed, with their new values.
+	Mem     []MemoryWrite `json:"mem,omitempty"`
+	Syscall *Syscall      `json:"syscall,omitempty"`
+	// ExitCode is set if the VM exited in the step.
+	ExitCode *uint8 `json:"exitCode,omitempty"`
+}
+
+type RegisterChange struct {
+	// Name is r0 to r31 for the general purpose registers, lo, hi, f0 to f31 for the floating-point registers, or fcsr.
+	Name  string         `json:"name"`
+	Value hexutil.Uint64 `json:"value"`
+}
+
+type MemoryWrite struct {
+	Addr  hexutil.Uint64 `json:"addr"`
+	Value hexutil.Uint64 `json:"value"`
+}
+
+type Syscall struct {
+	Num  uint64            `json:"num"`
+	Args [4]hexutil.Uint64 `json:"args"`
+	// Ret and Errno are the result of the syscall, unless the VM exited.
+	Ret   *hexutil.Uint64 `json:"ret,omitempty"`
+	Errno *hexutil.Uint64 `json:"errno,omitempty"`
+}
+
+// thread refers to the registers of the thread executing an instruction,
+// which remain valid when the instruction switches to another thread.
+type thread struct {
+	id   *uint64
+	regs *[32]mipsevm.Word
+	cpu  *mipsevm.CpuScalars
+	fpu  *mipsevm.FpuState
+}
+
+// threadRegisters is a copy of the registers of a thread, to find the registers an instruction changed.
+type threadRegisters struct {
+	regs [32]mipsevm.Word
+	cpu  mipsevm.CpuScalars
+	fpu  mipsevm.FpuState
+}
+
+// JSONTracer writes a JSON record of each step, one per line.
+// Write errors are returned by Close, as the VM can't handle them.
+type JSONTracer struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+	err error
+
+	lastStep   uint64
+	started    bool
+	symbolizer program.Symbolizer
+
+	// the instruction executed by the current step, if any
+	record   *Record
+	mem      *memory.Memory
+	thread   thread
+	before   threadRegisters
+	leaves   map[mipsevm.Word][leafSize / arch.WordSizeBytes]mipsevm.Word
+	leafList []mipsevm.Word
+}
+
+var _ mipsevm.StepTracer = (*JSONTracer)(nil)
+
+func NewJSONTracer(w io.Writer) *JSONTracer {
+	bw := bufio.NewWriter(w)
+	return &JSONTracer{
+		w:      bw,
+		enc:    json.NewEncoder(bw),
+		leaves: make(map[mipsevm.Word][leafSize / arch.WordSizeBytes]mipsevm.Word),
+	}
+}
+
+// SetSymbolizer sets the symbolizer to record the guest symbol of each instruction with, or disables it if nil.
+func (t *JSONTracer) SetSymbolizer(s program.Symbolizer) {
+	t.symbolizer = s
+}
+
+func (t *JSONTracer) OnInstruction(state mipsevm.FPVMState, pc mipsevm.Word, insn uint32) {
+	pcVal := hexutil.Uint64(pc)
+	insnVal := hexutil.Uint64(insn)
+	t.mem = state.GetMemory()
+	t.thread = currentThread(state)
+	t.before = threadRegisters{regs: *t.thread.regs, cpu: *t.thread.cpu, fpu: *t.thread.fpu}
+	t.record = &Record{
+		Thread: t.thread.id,
+		PC:     &pcVal,
+		Insn:   &insnVal,
+		Op:     Mnemonic(insn),
+	}
+	if t.symbolizer != nil {
+		t.record.Func = t.symbolizer.Symbolize(pc)
+	}
+	if insn&0xFC00003F == 0x0C {
+		t.record.Syscall = &Syscall{
+			Num: uint64(t.before.regs[arch.RegSyscallNum]),
+			Args: [4]hexutil.Uint64{
+				hexutil.Uint64(t.before.regs[arch.RegSyscallParam1]),
+				hexutil.Uint64(t.before.regs[arch.RegSyscallParam2]),
+				hexutil.Uint64(t.before.regs[arch.RegSyscallParam3]),
+				hexutil.Uint64(t.before.regs[arch.RegSyscallParam4]),
+			},
+		}
+	}
+}
+
+func (t *JSONTracer) OnMemAccess(addr mipsevm.Word) {
+	if t.record == nil {
+		return
+	}
+	leaf := addr &^ (leafSize - 1)
+	if _, ok := t.leaves[leaf]; ok {
+		return
+	}
+	t.leaves[leaf] = t.readLeaf(leaf)
+	t.leafList = append(t.leafList, leaf)
+}
+
+func (t *JSONTracer) OnStepEnd(state mipsevm.FPVMState) {
+	step := state.GetStep()
+	if t.started && step == t.lastStep {
+		// the VM already exited, and didn't execute a step
+		return
+	}
+	t.started = true
+	t.lastStep = step
+	record := t.record
+	if record == nil {
+		record = &Record{}
+	} else {
+		t.addChanges(state, record)
+	}
+	record.Step = step
+	if state.GetExited() {
+		exitCode := state.GetExitCode()
+		record.ExitCode = &exitCode
+	}
+	t.write(record)
+
+	t.record = nil
+	clear(t.leaves)
+	t.leafList = t.leafList[:0]
+}
+
+// addChanges adds the registers and memory the instruction changed, and the result of a syscall, to the record.
+func (t *JSONTracer) addChanges(state mipsevm.FPVMState, record *Record) {
+	for i, v := range t.thread.regs {
+		if v != t.before.regs[i] {
+			record.Regs = append(record.Regs, RegisterChange{Name: fmt.Sprintf("r%d", i), Value: hexutil.Uint64(v)})
+		}
+	}
+	if v := t.thread.cpu.LO; v != t.before.cpu.LO {
+		record.Regs = append(record.Regs, RegisterChange{Name: "lo", Value: hexutil.Uint64(v)})
+	}
+	if v := t.thread.cpu.HI; v != t.before.cpu.HI {
+		record.Regs = append(record.Regs, RegisterChange{Name: "hi", Value: hexutil.Uint64(v)})
+	}
+	for i, v := range t.thread.fpu.FPR {
+		if v != t.before.fpu.FPR[i] {
+			record.Regs = append(record.Regs, RegisterChange{Name: fmt.Sprintf("f%d", i), Value: hexutil.Uint64(v)})
+		}
+	}
+	if v := t.thread.fpu.FCSR; v != t.before.fpu.FCSR {
+		record.Regs = append(record.Regs, RegisterChange{Name: "fcsr", Value: hexutil.Uint64(v)})
+	}
+
+	for _, leaf := range t.leafList {
+		before := t.leaves[leaf]
+		after := t.readLeaf(leaf)
+		for i := range after {
+			if after[i] != before[i] {
+				addr := leaf + mipsevm.Word(i*arch.WordSizeBytes)
+				record.Mem = append(record.Mem, MemoryWrite{Addr: hexutil.Uint64(addr), Value: hexutil.Uint64(after[i])})
+			}
+		}
+	}
+
+	if record.Syscall != nil && !state.GetExited() {
+		ret := hexutil.Uint64(t.thread.regs[arch.RegSyscallRet])
+		errno := hexutil.Uint64(t.thread.regs[arch.RegSyscallErrno])
+		record.Syscall.Ret = &ret
+		record.Syscall.Errno = &errno
+	}
+}
+
+func (t *JSONTracer) readLeaf(leaf mipsevm.Word) (words [leafSize / arch.WordSizeBytes]mipsevm.Word) {
+	for i := range words {
+		words[i] = t.mem.GetMemory(leaf + mipsevm.Word(i*arch.WordSizeBytes))
+	}
+	return words
+}
+
+func (t *JSONTracer) write(record *Record) {
+	if t.err != nil {
+		return
+	}
+	if err := t.enc.Encode(record); err != nil {
+		t.err = fmt.Errorf("failed to write trace record of step %d: %w", record.Step, err)
+	}
+}
+
+// Close flushes the trace, and returns the first error writing it.
+func (t *JSONTracer) Close() error {
+	if t.err != nil {
+		return t.err
+	}
+	if err := t.w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush trace: %w", err)
+	}
+	return nil
+}
+
+func currentThread(state mipsevm.FPVMState) thread {
+	switch s := state.(type) {
+	case *multithreaded.State:
+		th := s.GetCurrentThread()
+		id := uint64(th.ThreadId)
+		return thread{id: &id, regs: &th.Registers, cpu: &th.Cpu, fpu: &th.Fpu}
+	case *singlethreaded.State:
+		return thread{regs: &s.Registers, cpu: &s.Cpu, fpu: &s.Fpu}
+	default:
+		panic(fmt.Errorf("unsupported state type %T", state))
+	}
+}
@@ -0,0 +1,139 @@
+//go:build !cannon64
+
+package tracer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/memory"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/multithreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+const programStart = 0x1000
+
+var testProgram = []uint32{
+	0x24080005, // addiu $t0, $zero, 5
+	0x3c090002, // lui $t1, 0x2
+	0xad280004, // sw $t0, 4($t1)
+	0x24020fcd, // addiu $v0, $zero, 4045 (brk)
+	0x0000000c, // syscall
+	0x24021096, // addiu $v0, $zero, 4246 (exit_group)
+	0x24040003, // addiu $a0, $zero, 3
+	0x0000000c, // syscall
+}
+
+func TestJSONTracer(t *testing.T) {
+	t.Run("singlethreaded", func(t *testing.T) {
+		state := singlethreaded.CreateInitialState(programStart, 0)
+		storeProgram(state.Memory)
+		vm := singlethreaded.NewInstrumentedState(state, testutil.StaticOracle(t, nil), io.Discard, io.Discard, nil)
+		records := runTraced(t, vm)
+		for _, record := range records {
+			require.Nil(t, record.Thread)
+		}
+	})
+	t.Run("multithreaded", func(t *testing.T) {
+		state := multithreaded.CreateInitialState(programStart, 0)
+		storeProgram(state.Memory)
+		vm := multithreaded.NewInstrumentedState(state, testutil.StaticOracle(t, nil), io.Discard, io.Discard, testutil.CreateLogger())
+		records := runTraced(t, vm)
+		for _, record := range records {
+			require.NotNil(t, record.Thread)
+			require.Equal(t, uint64(0), *record.Thread)
+		}
+	})
+}
+
+func storeProgram(mem *memory.Memory) {
+	for i, insn := range testProgram {
+		testutil.StoreInstruction(mem, mipsevm.Word(programStart+4*i), insn)
+	}
+}
+
+func runTraced(t *testing.T, vm mipsevm.FPVM) []Record {
+	var out bytes.Buffer
+	tracer := NewJSONTracer(&out)
+	tracer.SetSymbolizer(&program.Metadata{Symbols: []program.Symbol{
+		{Name: "main.main", Start: programStart, Size: 4 * 5},
+	}})
+	vm.SetTracer(tracer)
+	for !vm.GetState().GetExited() {
+		_, err := vm.Step(false)
+		require.NoError(t, err)
+	}
+	// Steps after the exit are not traced
+	_, err := vm.Step(false)
+	require.NoError(t, err)
+	require.NoError(t, tracer.Close())
+
+	var records []Record
+	scanner := bufio.NewScanner(&out)
+	for scanner.Scan() {
+		var record Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, records, len(testProgram))
+
+	for i, record := range records {
+		require.Equal(t, uint64(i+1), record.Step)
+		require.Equal(t, hexutil.Uint64(programStart+4*i), *record.PC)
+		require.Equal(t, hexutil.Uint64(testProgram[i]), *record.Insn)
+	}
+
+	require.Equal(t, "addiu", records[0].Op)
+	require.Equal(t, []RegisterChange{{Name: "r8", Value: 5}}, records[0].Regs)
+
+	require.Equal(t, "main.main", records[0].Func)
+	require.Equal(t, "main.main+0x8", records[2].Func)
+	require.Empty(t, records[7].Func, "no symbol contains the pc")
+
+	require.Equal(t, "sw", records[2].Op)
+	require.Empty(t, records[2].Regs)
+	require.Equal(t, []MemoryWrite{{Addr: 0x20004, Value: 5}}, records[2].Mem)
+
+	brk := records[4]
+	require.Equal(t, "syscall", brk.Op)
+	require.NotNil(t, brk.Syscall)
+	require.Equal(t, uint64(exec.SysBrk), brk.Syscall.Num)
+	require.Equal(t, hexutil.Uint64(program.PROGRAM_BREAK), *brk.Syscall.Ret)
+	require.Equal(t, hexutil.Uint64(0), *brk.Syscall.Errno)
+	require.Nil(t, brk.ExitCode)
+
+	exit := records[7]
+	require.Equal(t, uint64(exec.SysExitGroup), exit.Syscall.Num)
+	require.Equal(t, hexutil.Uint64(3), exit.Syscall.Args[0])
+	require.Nil(t, exit.Syscall.Ret)
+	require.NotNil(t, exit.ExitCode)
+	require.Equal(t, uint8(3), *exit.ExitCode)
+	return records
+}
+
+func TestJSONTracerDisabled(t *testing.T) {
+	state := singlethreaded.CreateInitialState(programStart, 0)
+	storeProgram(state.Memory)
+	vm := singlethreaded.NewInstrumentedState(state, testutil.StaticOracle(t, nil), io.Discard, io.Discard, nil)
+	var out bytes.Buffer
+	tracer := NewJSONTracer(&out)
+	vm.SetTracer(tracer)
+	_, err := vm.Step(false)
+	require.NoError(t, err)
+	vm.SetTracer(nil)
+	_, err = vm.Step(false)
+	require.NoError(t, err)
+	require.NoError(t, tracer.Close())
+	require.Equal(t, 1, bytes.Count(out.Bytes(), []byte("\n")))
+}
@@ -0,0 +1,217 @@
+package tracer
+
+// specialMnemonics are the SPECIAL (opcode 0) instructions, by function field.
+var specialMnemonics = map[uint32]string{
+	0x00: "sll",
+	0x02: "srl",
+	0x03: "sra",
+	0x04: "sllv",
+	0x06: "srlv",
+	0x07: "srav",
+	0x08: "jr",
+	0x09: "jalr",
+	0x0a: "movz",
+	0x0b: "movn",
+	0x0c: "syscall",
+	0x0d: "break",
+	0x0f: "sync",
+	0x10: "mfhi",
+	0x11: "mthi",
+	0x12: "mflo",
+	0x13: "mtlo",
+	0x14: "dsllv",
+	0x16: "dsrlv",
+	0x17: "dsrav",
+	0x18: "mult",
+	0x19: "multu",
+	0x1a: "div",
+	0x1b: "divu",
+	0x1c: "dmult",
+	0x1d: "dmultu",
+	0x1e: "ddiv",
+	0x1f: "ddivu",
+	0x20: "add",
+	0x21: "addu",
+	0x22: "sub",
+	0x23: "subu",
+	0x24: "and",
+	0x25: "or",
+	0x26: "xor",
+	0x27: "nor",
+	0x2a: "slt",
+	0x2b: "sltu",
+	0x2c: "dadd",
+	0x2d: "daddu",
+	0x2e: "dsub",
+	0x2f: "dsubu",
+	0x38: "dsll",
+	0x3a: "dsrl",
+	0x3b: "dsra",
+	0x3c: "dsll32",
+	0x3e: "dsrl32",
+	0x3f: "dsra32",
+}
+
+// special2Mnemonics are the SPECIAL2 (opcode 0x1c) instructions, by function field.
+var special2Mnemonics = map[uint32]string{
+	0x02: "mul",
+	0x20: "clz",
+	0x21: "clo",
+	0x24: "dclz",
+	0x25: "dclo",
+}
+
+// regimmMnemonics are the REGIMM (opcode 1) instructions, by rt field.
+var regimmMnemonics = map[uint32]string{
+	0x00: "bltz",
+	0x01: "bgez",
+	0x10: "bltzal",
+	0x11: "bgezal",
+}
+
+var opcodeMnemonics = map[uint32]string{
+	0x02: "j",
+	0x03: "jal",
+	0x04: "beq",
+	0x05: "bne",
+	0x06: "blez",
+	0x07: "bgtz",
+	0x08: "addi",
+	0x09: "addiu",
+	0x0a: "slti",
+	0x0b: "sltiu",
+	0x0c: "andi",
+	0x0d: "ori",
+	0x0e: "xori",
+	0x0f: "lui",
+	0x18: "daddi",
+	0x19: "daddiu",
+	0x1a: "ldl",
+	0x1b: "ldr",
+	0x20: "lb",
+	0x21: "lh",
+	0x22: "lwl",
+	0x23: "lw",
+	0x24: "lbu",
+	0x25: "lhu",
+	0x26: "lwr",
+	0x27: "lwu",
+	0x28: "sb",
+	0x29: "sh",
+	0x2a: "swl",
+	0x2b: "sw",
+	0x2c: "sdl",
+	0x2d: "sdr",
+	0x2e: "swr",
+	0x30: "ll",
+	0x31: "lwc1",
+	0x34: "lld",
+	0x35: "ldc1",
+	0x37: "ld",
+	0x38: "sc",
+	0x39: "swc1",
+	0x3c: "scd",
+	0x3d: "sdc1",
+	0x3f: "sd",
+}
+
+// cop1MoveMnemonics are the COP1 moves and branches, by rs field.
+var cop1MoveMnemonics = map[uint32]string{
+	0: "mfc1",
+	1: "dmfc1",
+	2: "cfc1",
+	4: "mtc1",
+	5: "dmtc1",
+	6: "ctc1",
+}
+
+// cop1ArithMnemonics are the COP1 arithmetic instructions, by function field, without the format suffix.
+var cop1ArithMnemonics = map[uint32]string{
+	0x00: "add",
+	0x01: "sub",
+	0x02: "mul",
+	0x03: "div",
+	0x04: "sqrt",
+	0x05: "abs",
+	0x06: "mov",
+	0x07: "neg",
+	0x08: "round.l",
+	0x09: "trunc.l",
+	0x0a: "ceil.l",
+	0x0b: "floor.l",
+	0x0c: "round.w",
+	0x0d: "trunc.w",
+	0x0e: "ceil.w",
+	0x0f: "floor.w",
+	0x20: "cvt.s",
+	0x21: "cvt.d",
+	0x24: "cvt.w",
+	0x25: "cvt.l",
+}
+
+var cop1Conditions = [16]string{"f", "un", "eq", "ueq", "olt", "ult", "ole", "ule", "sf", "ngle", "seq", "ngl", "lt", "nge", "le", "ngt"}
+
+var cop1Formats = map[uint32]string{
+	16: "s",
+	17: "d",
+	20: "w",
+	21: "l",
+}
+
+// Mnemonic returns the assembler mnemonic of the MIPS instruction, or "unknown" if it is not decoded.
+// Instructions are decoded regardless of whether the VM supports them, e.g. 64-bit instructions in the 32-bit VM.
+func Mnemonic(insn uint32) string {
+	if insn == 0 {
+		return "nop"
+	}
+	opcode := insn >> 26
+	fun := insn & 0x3F
+	var name string
+	var ok bool
+	switch opcode {
+	case 0x00:
+		name, ok = specialMnemonics[fun]
+	case 0x01:
+		name, ok = regimmMnemonics[(insn>>16)&0x1F]
+	case 0x11:
+		return cop1Mnemonic(insn)
+	case 0x1c:
+		name, ok = special2Mnemonics[fun]
+	default:
+		name, ok = opcodeMnemonics[opcode]
+	}
+	if !ok {
+		return "unknown"
+	}
+	return name
+}
+
+func cop1Mnemonic(insn uint32) string {
+	rs := (insn >> 21) & 0x1F
+	if name, ok := cop1MoveMnemonics[rs]; ok {
+		return name
+	}
+	if rs == 8 {
+		name := "bc1f"
+		if insn&(1<<16) != 0 {
+			name = "bc1t"
+		}
+		if insn&(1<<17) != 0 {
+			name += "l"
+		}
+		return name
+	}
+	format, ok := cop1Formats[rs]
+	if !ok {
+		return "unknown"
+	}
+	fun := insn & 0x3F
+	if fun >= 0x30 {
+		return "c." + cop1Conditions[fun&0xF] + "." + format
+	}
+	name, ok := cop1ArithMnemonics[fun]
+	if !ok {
+		return "unknown"
+	}
+	return name + "." + format
+}
@@ -0,0 +1,39 @@
+package tracer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMnemonic(t *testing.T) {
+	tests := []struct {
+		insn     uint32
+		expected string
+	}{
+		{0x00000000, "nop"},
+		{0x24080005, "addiu"},
+		{0x00851021, "addu"},
+		{0x0000000c, "syscall"},
+		{0x03e00008, "jr"},
+		{0x0c000400, "jal"},
+		{0x04110004, "bgezal"},
+		{0x8fbf0010, "lw"},
+		{0xafbf0010, "sw"},
+		{0x70821002, "mul"},
+		{0x0064002d, "daddu"},
+		{0xdfbf0010, "ld"},
+		{0x44820000, "mtc1"},
+		{0x45010002, "bc1t"},
+		{0x46020800, "add.s"},
+		{0x46800821, "cvt.d.w"},
+		{0x46221032, "c.eq.d"},
+		{0xc7a00008, "lwc1"},
+		{0xfc000000, "sd"},
+		{0xec000000, "unknown"},
+		{0x0000003d, "unknown"},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expected, Mnemonic(test.insn), "insn %08x", test.insn)
+	}
+}
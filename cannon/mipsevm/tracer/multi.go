@@ -0,0 +1,33 @@
+package tracer
+
+import "github.com/ethereum-optimism/optimism/cannon/mipsevm"
+
+// multiTracer reports the steps to multiple tracers, in order.
+type multiTracer []mipsevm.StepTracer
+
+// Multi returns a tracer that reports the steps to each of the tracers, in order,
+// to observe the steps of a VM with more than one tracer.
+func Multi(tracers ...mipsevm.StepTracer) mipsevm.StepTracer {
+	if len(tracers) == 1 {
+		return tracers[0]
+	}
+	return multiTracer(tracers)
+}
+
+func (m multiTracer) OnInstruction(state mipsevm.FPVMState, pc mipsevm.Word, insn uint32) {
+	for _, t := range m {
+		t.OnInstruction(state, pc, insn)
+	}
+}
+
+func (m multiTracer) OnMemAccess(addr mipsevm.Word) {
+	for _, t := range m {
+		t.OnMemAccess(addr)
+	}
+}
+
+func (m multiTracer) OnStepEnd(state mipsevm.FPVMState) {
+	for _, t := range m {
+		t.OnStepEnd(state)
+	}
+}
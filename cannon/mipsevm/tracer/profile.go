@@ -0,0 +1,147 @@
+package tracer
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+)
+
+// Profiler counts the instructions executed by a VM, to find the hotspots of a program,
+// e.g. when tuning the number of steps of op-program. Set it as the tracer of the VM, see Multi to combine tracers.
+type Profiler struct {
+	// counts are the executions of each instruction, by address:
+	// it is aggregated by op and symbol in the report only, to keep counting cheap.
+	counts map[profileKey]uint64
+	total  uint64
+}
+
+type profileKey struct {
+	pc   mipsevm.Word
+	insn uint32
+}
+
+var _ mipsevm.StepTracer = (*Profiler)(nil)
+
+func NewProfiler() *Profiler {
+	return &Profiler{counts: make(map[profileKey]uint64)}
+}
+
+func (p *Profiler) OnInstruction(state mipsevm.FPVMState, pc mipsevm.Word, insn uint32) {
+	p.counts[profileKey{pc: pc, insn: insn}]++
+	p.total++
+}
+
+func (p *Profiler) OnMemAccess(addr mipsevm.Word) {}
+
+func (p *Profiler) OnStepEnd(state mipsevm.FPVMState) {}
+
+// Profile is the report of a Profiler.
+type Profile struct {
+	// Total is the number of executed instructions.
+	Total uint64 `json:"total"`
+	// Ops are the executed instructions by op, e.g. addiu, from the most to the least executed.
+	Ops []OpCount `json:"ops"`
+	// Symbols are the executed instructions by the guest symbol containing them, from the most to the least executed.
+	// Only set if the profile was made with the symbols of the program.
+	Symbols []SymbolProfile `json:"symbols,omitempty"`
+}
+
+type OpCount struct {
+	Op    string `json:"op"`
+	Count uint64 `json:"count"`
+}
+
+type SymbolProfile struct {
+	Name  string `json:"name"`
+	Count uint64 `json:"count"`
+	// Ops are the instructions executed in the symbol by op, from the most to the least executed.
+	Ops []OpCount `json:"ops"`
+}
+
+// Profile returns the instructions counted so far, by op, and by symbol of the metadata if it has any.
+func (p *Profiler) Profile(meta *program.Metadata) *Profile {
+	ops := make(map[string]uint64)
+	var symbols map[string]map[string]uint64
+	if meta != nil && len(meta.Symbols) > 0 {
+		symbols = make(map[string]map[string]uint64)
+	}
+	for key, count := range p.counts {
+		op := Mnemonic(key.insn)
+		ops[op] += count
+		if symbols != nil {
+			name := meta.LookupSymbol(key.pc)
+			symOps := symbols[name]
+			if symOps == nil {
+				symOps = make(map[string]uint64)
+				symbols[name] = symOps
+			}
+			symOps[op] += count
+		}
+	}
+	out := &Profile{Total: p.total, Ops: sortedOps(ops)}
+	for name, symOps := range symbols {
+		sym := SymbolProfile{Name: name, Ops: sortedOps(symOps)}
+		for _, op := range sym.Ops {
+			sym.Count += op.Count
+		}
+		out.Symbols = append(out.Symbols, sym)
+	}
+	sort.Slice(out.Symbols, func(i, j int) bool {
+		a, b := out.Symbols[i], out.Symbols[j]
+		return a.Count > b.Count || (a.Count == b.Count && a.Name < b.Name)
+	})
+	return out
+}
+
+func sortedOps(ops map[string]uint64) []OpCount {
+	out := make([]OpCount, 0, len(ops))
+	for op, count := range ops {
+		out = append(out, OpCount{Op: op, Count: count})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Count > out[j].Count || (out[i].Count == out[j].Count && out[i].Op < out[j].Op)
+	})
+	return out
+}
+
+// profileTopOps is the number of ops listed for each symbol in the text report.
+const profileTopOps = 5
+
+// WriteText writes the profile as a text report: the flat profile by op, and the top symbols, if any,
+// with the ops executed most in each. All symbols are written if top is 0.
+func (p *Profile) WriteText(w io.Writer, top int) error {
+	var b strings.Builder
+	percent := func(count uint64) float64 {
+		if p.Total == 0 {
+			return 0
+		}
+		return float64(count) * 100 / float64(p.Total)
+	}
+	fmt.Fprintf(&b, "Total instructions: %d\n\nBy op:\n", p.Total)
+	fmt.Fprintf(&b, "%14s %8s  %s\n", "count", "percent", "op")
+	for _, op := range p.Ops {
+		fmt.Fprintf(&b, "%14d %7.2f%%  %s\n", op.Count, percent(op.Count), op.Op)
+	}
+	if len(p.Symbols) > 0 {
+		fmt.Fprintf(&b, "\nBy symbol:\n")
+		fmt.Fprintf(&b, "%14s %8s  %s\n", "count", "percent", "symbol")
+		for i, sym := range p.Symbols {
+			if top > 0 && i == top {
+				fmt.Fprintf(&b, "... %d more symbols\n", len(p.Symbols)-top)
+				break
+			}
+			fmt.Fprintf(&b, "%14d %7.2f%%  %s\n", sym.Count, percent(sym.Count), sym.Name)
+			ops := make([]string, 0, profileTopOps)
+			for _, op := range sym.Ops[:min(len(sym.Ops), profileTopOps)] {
+				ops = append(ops, fmt.Sprintf("%s %.1f%%", op.Op, float64(op.Count)*100/float64(sym.Count)))
+			}
+			fmt.Fprintf(&b, "%24s%s\n", "", strings.Join(ops, ", "))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,58 @@
+//go:build !cannon64
+
+package tracer
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/program"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/testutil"
+)
+
+func TestProfiler(t *testing.T) {
+	state := singlethreaded.CreateInitialState(programStart, 0)
+	storeProgram(state.Memory)
+	vm := singlethreaded.NewInstrumentedState(state, testutil.StaticOracle(t, nil), io.Discard, io.Discard, nil)
+	profiler := NewProfiler()
+	var out bytes.Buffer
+	jsonTracer := NewJSONTracer(&out)
+	vm.SetTracer(Multi(profiler, jsonTracer))
+	for !vm.GetState().GetExited() {
+		_, err := vm.Step(false)
+		require.NoError(t, err)
+	}
+	require.NoError(t, jsonTracer.Close())
+	require.Equal(t, len(testProgram), bytes.Count(out.Bytes(), []byte("\n")), "all tracers observe the steps")
+
+	flat := profiler.Profile(nil)
+	require.Equal(t, uint64(len(testProgram)), flat.Total)
+	require.Equal(t, []OpCount{{Op: "addiu", Count: 4}, {Op: "syscall", Count: 2}, {Op: "lui", Count: 1}, {Op: "sw", Count: 1}}, flat.Ops)
+	require.Empty(t, flat.Symbols)
+
+	meta := &program.Metadata{Symbols: []program.Symbol{
+		{Name: "main.store", Start: programStart, Size: 10},
+		{Name: "main.exit", Start: programStart + 4*5, Size: 4 * 3},
+	}}
+	profile := profiler.Profile(meta)
+	require.Equal(t, flat.Ops, profile.Ops)
+	require.Equal(t, []SymbolProfile{
+		{Name: "main.exit", Count: 3, Ops: []OpCount{{Op: "addiu", Count: 2}, {Op: "syscall", Count: 1}}},
+		{Name: "main.store", Count: 3, Ops: []OpCount{{Op: "addiu", Count: 1}, {Op: "lui", Count: 1}, {Op: "sw", Count: 1}}},
+		// the brk syscall in between the symbols
+		{Name: "!gap", Count: 2, Ops: []OpCount{{Op: "addiu", Count: 1}, {Op: "syscall", Count: 1}}},
+	}, profile.Symbols)
+
+	var text bytes.Buffer
+	require.NoError(t, profile.WriteText(&text, 1))
+	require.Contains(t, text.String(), "Total instructions: 8\n")
+	require.Contains(t, text.String(), "50.00%  addiu\n")
+	require.Contains(t, text.String(), "main.exit\n")
+	require.Contains(t, text.String(), "addiu 66.7%, syscall 33.3%\n")
+	require.Contains(t, text.String(), "... 2 more symbols\n")
+	require.NotContains(t, text.String(), "main.store")
+}
@@ -0,0 +1,8 @@
+package mipsevm
+
+// StateVersion identifies the witness layout and the instruction and syscall semantics of a VM state.
+// Each VM numbers its own versions. A version is never changed once a contract implementing it is deployed:
+// changes that reshape the witness or the semantics add a new version, so that the dispute games of the
+// deployed contracts can still be proven from states of the older versions.
+// States serialized without a version are of version 0, the original layout of the VM.
+type StateVersion uint8
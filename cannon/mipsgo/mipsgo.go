@@ -0,0 +1,62 @@
+// Package mipsgo is support code for Go programs that run as guests in Cannon,
+// such as the example programs in testdata/example.
+//
+// Guests communicate with the VM through fixed file descriptors.
+// The helpers here use raw read/write/exit syscalls on those descriptors,
+// so guests do not depend on the runtime setting up os.File for them.
+package mipsgo
+
+import (
+	"io"
+	"syscall"
+)
+
+// File is a file descriptor provided to guests by the VM.
+type File int
+
+// The file descriptors provided by the VM.
+const (
+	Stdin         File = 0
+	Stdout        File = 1
+	Stderr        File = 2
+	HintRead      File = 3
+	HintWrite     File = 4
+	PreimageRead  File = 5
+	PreimageWrite File = 6
+)
+
+var _ io.ReadWriter = File(0)
+
+// Read reads from the file descriptor with a single read syscall.
+// Returns io.EOF if nothing is left to read.
+func (f File) Read(b []byte) (int, error) {
+	n, err := syscall.Read(int(f), b)
+	if err != nil {
+		return 0, err
+	}
+	if n == 0 && len(b) > 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// Write writes all of b to the file descriptor, retrying short writes.
+func (f File) Write(b []byte) (int, error) {
+	written := 0
+	for written < len(b) {
+		n, err := syscall.Write(int(f), b[written:])
+		if err != nil {
+			return written, err
+		}
+		if n == 0 {
+			return written, io.ErrShortWrite
+		}
+		written += n
+	}
+	return written, nil
+}
+
+// Exit exits the guest with the given exit code, without running deferred functions.
+func Exit(code uint8) {
+	syscall.Exit(int(code))
+}
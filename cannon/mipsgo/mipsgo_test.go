@@ -0,0 +1,81 @@
+package mipsgo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/exec"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+func TestFileDescriptorsMatchVM(t *testing.T) {
+	require.EqualValues(t, exec.FdStdin, Stdin)
+	require.EqualValues(t, exec.FdStdout, Stdout)
+	require.EqualValues(t, exec.FdStderr, Stderr)
+	require.EqualValues(t, exec.FdHintRead, HintRead)
+	require.EqualValues(t, exec.FdHintWrite, HintWrite)
+	require.EqualValues(t, exec.FdPreimageRead, PreimageRead)
+	require.EqualValues(t, exec.FdPreimageWrite, PreimageWrite)
+
+	require.EqualValues(t, preimage.HClientRFd, HintRead)
+	require.EqualValues(t, preimage.HClientWFd, HintWrite)
+	require.EqualValues(t, preimage.PClientRFd, PreimageRead)
+	require.EqualValues(t, preimage.PClientWFd, PreimageWrite)
+}
+
+// pipe returns the read and write ends of a pipe as File descriptors.
+func pipe(t *testing.T) (File, File) {
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = r.Close()
+		_ = w.Close()
+	})
+	return File(r.Fd()), File(w.Fd())
+}
+
+func TestFile(t *testing.T) {
+	r, w := pipe(t)
+	n, err := w.Write([]byte("hello"))
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+
+	buf := make([]byte, 10)
+	n, err = r.Read(buf)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(buf[:n]))
+
+	_, err = File(-1).Write([]byte("x"))
+	require.Error(t, err)
+}
+
+func TestOracleChannels(t *testing.T) {
+	// Serve a single pre-image request over a pair of pipes, as the VM would on the oracle file descriptors
+	clientR, serverW := pipe(t)
+	serverR, clientW := pipe(t)
+	client := preimage.NewOracleClient(ReadWritePair{R: clientR, W: clientW})
+
+	key := preimage.LocalIndexKey(1)
+	value := []byte("value")
+	go func() {
+		var k [32]byte
+		if _, err := io.ReadFull(serverR, k[:]); err != nil {
+			return
+		}
+		var length [8]byte
+		binary.BigEndian.PutUint64(length[:], uint64(len(value)))
+		_, _ = serverW.Write(append(length[:], value...))
+	}()
+	require.Equal(t, value, client.Get(key))
+}
+
+func TestReportPanic(t *testing.T) {
+	var out bytes.Buffer
+	reportPanic(&out, "oops", []byte("stack\n"))
+	require.Equal(t, "panic: oops\n\nstack\n", out.String())
+}
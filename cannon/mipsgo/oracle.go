@@ -0,0 +1,39 @@
+package mipsgo
+
+import (
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// ReadWritePair reads from and writes to different file descriptors.
+type ReadWritePair struct {
+	R File
+	W File
+}
+
+func (rw ReadWritePair) Read(b []byte) (int, error) {
+	return rw.R.Read(b)
+}
+
+func (rw ReadWritePair) Write(b []byte) (int, error) {
+	return rw.W.Write(b)
+}
+
+// HintChannel is the channel to send hints to the pre-image server with.
+func HintChannel() ReadWritePair {
+	return ReadWritePair{R: HintRead, W: HintWrite}
+}
+
+// PreimageChannel is the channel to request pre-images from the pre-image oracle with.
+func PreimageChannel() ReadWritePair {
+	return ReadWritePair{R: PreimageRead, W: PreimageWrite}
+}
+
+// OracleClient returns a pre-image oracle client using PreimageChannel.
+func OracleClient() *preimage.OracleClient {
+	return preimage.NewOracleClient(PreimageChannel())
+}
+
+// HintWriter returns a hint writer using HintChannel.
+func HintWriter() *preimage.HintWriter {
+	return preimage.NewHintWriter(HintChannel())
+}
@@ -0,0 +1,25 @@
+package mipsgo
+
+import (
+	"fmt"
+	"io"
+	"runtime/debug"
+)
+
+// PanicExitCode is the exit code of guests that panic, matching the Go runtime.
+const PanicExitCode = 2
+
+// HandlePanic reports a panic of the guest on Stderr and exits with PanicExitCode.
+// It must be deferred directly, at the start of main:
+//
+//	defer mipsgo.HandlePanic()
+func HandlePanic() {
+	if v := recover(); v != nil {
+		reportPanic(Stderr, v, debug.Stack())
+		Exit(PanicExitCode)
+	}
+}
+
+func reportPanic(w io.Writer, v any, stack []byte) {
+	_, _ = fmt.Fprintf(w, "panic: %v\n\n%s", v, stack)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsgo"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 )
 
@@ -15,10 +16,11 @@ func (rh rawHint) Hint() string {
 }
 
 func main() {
-	_, _ = os.Stderr.Write([]byte("started!"))
+	defer mipsgo.HandlePanic()
+	_, _ = mipsgo.Stderr.Write([]byte("started!"))
 
-	po := preimage.NewOracleClient(preimage.ClientPreimageChannel())
-	hinter := preimage.NewHintWriter(preimage.ClientHinterChannel())
+	po := mipsgo.OracleClient()
+	hinter := mipsgo.HintWriter()
 
 	preHash := *(*[32]byte)(po.Get(preimage.LocalIndexKey(0)))
 	diffHash := *(*[32]byte)(po.Get(preimage.LocalIndexKey(1)))
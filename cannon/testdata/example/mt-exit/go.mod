@@ -0,0 +1,3 @@
+module mt-exit
+
+go 1.21
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Goroutines that return while locked to their OS thread cause the runtime to exit that thread.
+// Several threads exit at the same time, while the remaining threads keep running.
+func main() {
+	const waves = 3
+	const exiters = 4
+	runtime.GOMAXPROCS(4)
+
+	var exited atomic.Int32
+	for i := 0; i < waves; i++ {
+		var wg sync.WaitGroup
+		wg.Add(exiters)
+		for j := 0; j < exiters; j++ {
+			go func() {
+				defer wg.Done()
+				// Not unlocking the OS thread terminates it once the goroutine exits.
+				runtime.LockOSThread()
+				exited.Add(1)
+			}()
+		}
+		wg.Wait()
+	}
+
+	// Threads must still be usable after the exits.
+	done := make(chan int)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		done <- int(exited.Load())
+	}()
+	fmt.Printf("exited threads: %d\n", <-done)
+}
@@ -0,0 +1,3 @@
+module mt-mutex
+
+go 1.21
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Workers contend on a single mutex from multiple OS threads.
+// Each worker yields while holding the lock, so the other workers block on it and have to be woken.
+func main() {
+	const workers = 4
+	const iterations = 50
+	runtime.GOMAXPROCS(workers)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	counter := 0
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				mu.Lock()
+				v := counter
+				runtime.Gosched()
+				counter = v + 1
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	fmt.Printf("mutex counter: %d\n", counter)
+}
@@ -0,0 +1,3 @@
+module mt-wakeup
+
+go 1.21
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Many waiters block on a condition variable and are all woken at once, round after round.
+func main() {
+	const waiters = 8
+	const rounds = 10
+	runtime.GOMAXPROCS(4)
+
+	var mu sync.Mutex
+	cond := sync.NewCond(&mu)
+	generation := 0
+	acks := 0
+	wakeups := 0
+
+	var wg sync.WaitGroup
+	wg.Add(waiters)
+	for w := 0; w < waiters; w++ {
+		go func() {
+			defer wg.Done()
+			mu.Lock()
+			defer mu.Unlock()
+			for seen := 0; seen < rounds; seen++ {
+				for generation == seen {
+					cond.Wait()
+				}
+				wakeups++
+				acks++
+				cond.Broadcast()
+			}
+		}()
+	}
+
+	mu.Lock()
+	for r := 1; r <= rounds; r++ {
+		generation = r
+		acks = 0
+		cond.Broadcast()
+		for acks < waiters {
+			cond.Wait()
+		}
+	}
+	mu.Unlock()
+	wg.Wait()
+	fmt.Printf("wakeups: %d\n", wakeups)
+}
@@ -7,10 +7,12 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-service/da"
 )
 
 // ErrInvalidCommitment is returned when the commitment cannot be parsed into a known commitment type.
-var ErrInvalidCommitment = errors.New("invalid commitment")
+var ErrInvalidCommitment = da.ErrInvalidCommitment
 
 // ErrCommitmentMismatch is returned when the commitment does not match the given input.
 var ErrCommitmentMismatch = errors.New("commitment mismatch")
@@ -33,8 +35,8 @@ func CommitmentTypeFromString(s string) (CommitmentType, error) {
 // KeccakCommitmentType is the default commitment type for the centralized DA storage.
 // GenericCommitmentType indicates an opaque bytestring that the op-node never opens.
 const (
-	Keccak256CommitmentType CommitmentType = 0
-	GenericCommitmentType   CommitmentType = 1
+	Keccak256CommitmentType CommitmentType = CommitmentType(da.Keccak256CommitmentType)
+	GenericCommitmentType   CommitmentType = CommitmentType(da.GenericCommitmentType)
 	KeccakCommitmentString  string         = "KeccakCommitment"
 	GenericCommitmentString string         = "GenericCommitment"
 )
@@ -3,17 +3,18 @@ package altda
 import (
 	"bytes"
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
+
+	"github.com/ethereum-optimism/optimism/op-service/da"
 )
 
 // ErrNotFound is returned when the server could not find the input.
-var ErrNotFound = errors.New("not found")
+var ErrNotFound = da.ErrNotFound
 
 // ErrInvalidInput is returned when the input is not valid for posting to the DA storage.
-var ErrInvalidInput = errors.New("invalid input")
+var ErrInvalidInput = da.ErrInvalidInput
 
 // DAClient is an HTTP client to communicate with a DA storage service.
 // It creates commitments and retrieves input data + verifies if needed.
@@ -25,12 +26,56 @@ type DAClient struct {
 	precompute bool
 }
 
+var _ da.Client = (*DAClient)(nil)
+
 func NewDAClient(url string, verify bool, pc bool) *DAClient {
 	return &DAClient{url, verify, pc}
 }
 
+// Put implements da.Client: it sets the input data and returns the encoded commitment.
+func (c *DAClient) Put(ctx context.Context, data []byte) ([]byte, error) {
+	comm, err := c.SetInput(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return comm.Encode(), nil
+}
+
+// Get implements da.Client: it returns the input data for the given encoded commitment.
+// The input is not verified against the commitment, see VerifyCommitment.
+func (c *DAClient) Get(ctx context.Context, commitment []byte) ([]byte, error) {
+	comm, err := DecodeCommitmentData(commitment)
+	if err != nil {
+		return nil, err
+	}
+	return c.getInput(ctx, comm)
+}
+
+// VerifyCommitment implements da.Client: it checks the input data against the encoded commitment.
+func (c *DAClient) VerifyCommitment(commitment []byte, data []byte) error {
+	comm, err := DecodeCommitmentData(commitment)
+	if err != nil {
+		return err
+	}
+	return comm.Verify(data)
+}
+
 // GetInput returns the input data for the given encoded commitment bytes.
 func (c *DAClient) GetInput(ctx context.Context, comm CommitmentData) ([]byte, error) {
+	input, err := c.getInput(ctx, comm)
+	if err != nil {
+		return nil, err
+	}
+	if c.verify {
+		if err := comm.Verify(input); err != nil {
+			return nil, err
+		}
+	}
+	return input, nil
+}
+
+// getInput returns the unverified input data for the given commitment.
+func (c *DAClient) getInput(ctx context.Context, comm CommitmentData) ([]byte, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/get/0x%x", c.url, comm.Encode()), nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
@@ -46,18 +91,7 @@ func (c *DAClient) GetInput(ctx context.Context, comm CommitmentData) ([]byte, e
 		return nil, fmt.Errorf("failed to get preimage: %v", resp.StatusCode)
 	}
 	defer resp.Body.Close()
-	input, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	if c.verify {
-		if err := comm.Verify(input); err != nil {
-			return nil, err
-		}
-
-	}
-	return input, nil
+	return io.ReadAll(resp.Body)
 }
 
 // SetInput sets the input data and returns the respective commitment.
@@ -159,3 +159,48 @@ func TestDAClientService(t *testing.T) {
 	_, err = client.GetInput(ctx, NewKeccak256Commitment(input))
 	require.Error(t, err)
 }
+
+func TestDAStorageFromClient(t *testing.T) {
+	store := NewMemStore()
+	logger := testlog.Logger(t, log.LevelDebug)
+
+	ctx := context.Background()
+
+	server := NewDAServer("127.0.0.1", 0, store, logger, false)
+
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		_ = server.Stop()
+	})
+
+	cfg := CLIConfig{
+		Enabled:      true,
+		DAServerURL:  fmt.Sprintf("http://%s", server.Endpoint()),
+		VerifyOnRead: true,
+	}
+	require.NoError(t, cfg.Check())
+
+	storage := NewDAStorage(cfg.NewDAClient(), cfg.VerifyOnRead)
+
+	rng := rand.New(rand.NewSource(1234))
+
+	input := RandomData(rng, 2000)
+
+	comm, err := storage.SetInput(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, comm, NewKeccak256Commitment(input))
+
+	stored, err := storage.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, input, stored)
+
+	// set a bad commitment in the store
+	require.NoError(t, store.Put(ctx, comm.Encode(), []byte("bad data")))
+
+	_, err = storage.GetInput(ctx, comm)
+	require.ErrorIs(t, err, ErrCommitmentMismatch)
+
+	// test not found error
+	_, err = storage.GetInput(ctx, NewKeccak256Commitment(RandomData(rng, 32)))
+	require.ErrorIs(t, err, ErrNotFound)
+}
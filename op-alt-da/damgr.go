@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-alt-da/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/da"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -44,6 +45,41 @@ type DAStorage interface {
 	SetInput(ctx context.Context, img []byte) (CommitmentData, error)
 }
 
+// clientStorage adapts a generic da.Client to the DAStorage interface.
+type clientStorage struct {
+	client da.Client
+	verify bool
+}
+
+// NewDAStorage creates a DAStorage backed by the given generic DA client.
+// Commitments are encoded with their commitment type prefix before being passed to the client.
+// If verify is set, inputs are verified against their commitment on read.
+func NewDAStorage(client da.Client, verify bool) DAStorage {
+	return &clientStorage{client: client, verify: verify}
+}
+
+func (s *clientStorage) GetInput(ctx context.Context, key CommitmentData) ([]byte, error) {
+	commitment := key.Encode()
+	input, err := s.client.Get(ctx, commitment)
+	if err != nil {
+		return nil, err
+	}
+	if s.verify {
+		if err := s.client.VerifyCommitment(commitment, input); err != nil {
+			return nil, err
+		}
+	}
+	return input, nil
+}
+
+func (s *clientStorage) SetInput(ctx context.Context, img []byte) (CommitmentData, error) {
+	commitment, err := s.client.Put(ctx, img)
+	if err != nil {
+		return nil, err
+	}
+	return DecodeCommitmentData(commitment)
+}
+
 // HeadSignalFn is the callback function to accept head-signals without a context.
 type HeadSignalFn func(eth.L1BlockRef)
 
@@ -79,7 +115,7 @@ type DA struct {
 
 // NewAltDA creates a new AltDA instance with the given log and CLIConfig.
 func NewAltDA(log log.Logger, cli CLIConfig, cfg Config, metrics Metricer) *DA {
-	return NewAltDAWithStorage(log, cfg, cli.NewDAClient(), metrics)
+	return NewAltDAWithStorage(log, cfg, NewDAStorage(cli.NewDAClient(), cli.VerifyOnRead), metrics)
 }
 
 // NewAltDAWithStorage creates a new AltDA instance with the given log and DAStorage interface.
@@ -47,6 +47,9 @@ type channelManager struct {
 
 	// if set to true, prevents production of any new channel frames
 	closed bool
+
+	// economics is notified of completed channels. nil if disabled.
+	economics *economics
 }
 
 func NewChannelManager(log log.Logger, metr metrics.Metricer, cfgProvider ChannelConfigProvider, rollupCfg *rollup.Config) *channelManager {
@@ -72,6 +75,9 @@ func (s *channelManager) Clear(l1OriginLastClosedChannel eth.BlockID) {
 	s.currentChannel = nil
 	s.channelQueue = nil
 	s.txChannels = make(map[string]*channel)
+	if s.economics != nil {
+		s.economics.Clear()
+	}
 }
 
 // TxFailed records a transaction as failed. It will attempt to resubmit the data
@@ -106,6 +112,10 @@ func (s *channelManager) TxConfirmed(_id txID, inclusionBlock eth.BlockID) {
 		s.blocks = append(blocks, s.blocks...)
 		if done {
 			s.removePendingChannel(channel)
+			if s.economics != nil {
+				// blocks are only returned if the channel timed out
+				s.economics.ChannelCompleted(newCompletedChannel(channel, blocks != nil))
+			}
 		}
 	} else {
 		s.log.Warn("transaction from unknown channel marked as confirmed", "id", id)
@@ -3,6 +3,7 @@ package batcher
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/feewatch"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -99,6 +101,35 @@ type CLIConfig struct {
 	// Should only be used for testing purposes.
 	TestUseMaxTxSizeForBlobs bool
 
+	// TestFailures injects data availability failures into L1 transaction submission.
+	// Should only be used for testing purposes.
+	TestFailures *FailureConfig
+
+	// FailoverLeaseRpc is the URL of the lock RPC that the batcher failover lease is shared through.
+	// Enables active/passive failover mode if set.
+	FailoverLeaseRpc string
+
+	// FailoverLeaseTTL is the duration of the failover lease.
+	FailoverLeaseTTL time.Duration
+
+	// FailoverHolderID identifies this batcher as holder of the failover lease.
+	FailoverHolderID string
+
+	// KeyRotationPrivateKeys are the private keys of the batcher keys scheduled in the rollup config, in order of activation.
+	KeyRotationPrivateKeys []string
+
+	// KeyRotationInclusionMargin is the time within which a batcher transaction is expected to be included.
+	KeyRotationInclusionMargin time.Duration
+
+	// EconomicsEnabled enables reporting the L1 cost, fee revenue and margin of each completed channel.
+	EconomicsEnabled bool
+
+	// EconomicsReportInterval is the interval of the aggregated channel economics report. 0 disables the report.
+	EconomicsReportInterval time.Duration
+
+	// FeeWatch watches the realized fee margin of the channels, and alerts on sustained losses. Requires EconomicsEnabled.
+	FeeWatch feewatch.CLIConfig
+
 	TxMgrConfig   txmgr.CLIConfig
 	LogConfig     oplog.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
@@ -138,6 +169,9 @@ func (c *CLIConfig) Check() error {
 	if c.BatchType > 1 {
 		return fmt.Errorf("unknown batch type: %v", c.BatchType)
 	}
+	if c.CompressionAlgo == derive.ZlibDict && c.BatchType != derive.SpanBatchType {
+		return fmt.Errorf("compression algo %v requires span batches", c.CompressionAlgo)
+	}
 	if c.CheckRecentTxsDepth > 128 {
 		return fmt.Errorf("CheckRecentTxsDepth cannot be set higher than 128: %v", c.CheckRecentTxsDepth)
 	}
@@ -147,6 +181,39 @@ func (c *CLIConfig) Check() error {
 	if !flags.ValidDataAvailabilityType(c.DataAvailabilityType) {
 		return fmt.Errorf("unknown data availability type: %q", c.DataAvailabilityType)
 	}
+	if c.TestFailures != nil {
+		if err := c.TestFailures.Check(); err != nil {
+			return err
+		}
+	}
+	if c.FailoverLeaseRpc != "" {
+		if c.Stopped {
+			return errors.New("cannot start stopped in failover mode, the lease decides when to start")
+		}
+		if c.FailoverLeaseTTL < time.Second {
+			return errors.New("failover lease TTL must be at least 1s")
+		}
+		if c.FailoverHolderID == "" {
+			return errors.New("empty failover holder ID")
+		}
+	}
+	for i, key := range c.KeyRotationPrivateKeys {
+		if key == "" {
+			return fmt.Errorf("empty key rotation private key %d", i)
+		}
+	}
+	if len(c.KeyRotationPrivateKeys) > 0 && c.KeyRotationInclusionMargin < 0 {
+		return errors.New("key rotation inclusion margin must not be negative")
+	}
+	if c.EconomicsEnabled && c.EconomicsReportInterval < 0 {
+		return errors.New("economics report interval must not be negative")
+	}
+	if c.FeeWatch.Enabled && !c.EconomicsEnabled {
+		return errors.New("fee watch requires channel economics to be enabled")
+	}
+	if err := c.FeeWatch.Check(); err != nil {
+		return err
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
@@ -187,6 +254,14 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		BatchType:                    ctx.Uint(flags.BatchTypeFlag.Name),
 		DataAvailabilityType:         flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
 		ActiveSequencerCheckDuration: ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
+		FailoverLeaseRpc:             ctx.String(flags.FailoverLeaseRpcFlag.Name),
+		FailoverLeaseTTL:             ctx.Duration(flags.FailoverLeaseTTLFlag.Name),
+		FailoverHolderID:             failoverHolderID(ctx),
+		KeyRotationPrivateKeys:       ctx.StringSlice(flags.KeyRotationPrivateKeysFlag.Name),
+		KeyRotationInclusionMargin:   ctx.Duration(flags.KeyRotationInclusionMarginFlag.Name),
+		EconomicsEnabled:             ctx.Bool(flags.EconomicsEnabledFlag.Name),
+		EconomicsReportInterval:      ctx.Duration(flags.EconomicsReportIntervalFlag.Name),
+		FeeWatch:                     feewatch.ReadCLIConfig(ctx),
 		TxMgrConfig:                  txmgr.ReadCLIConfig(ctx),
 		LogConfig:                    oplog.ReadCLIConfig(ctx),
 		MetricsConfig:                opmetrics.ReadCLIConfig(ctx),
@@ -195,3 +270,11 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		AltDA:                        altda.ReadCLIConfig(ctx),
 	}
 }
+
+func failoverHolderID(ctx *cli.Context) string {
+	if id := ctx.String(flags.FailoverHolderIDFlag.Name); id != "" {
+		return id
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
@@ -87,6 +87,14 @@ func TestBatcherConfig(t *testing.T) {
 			override:  func(c *batcher.CLIConfig) { c.BatchType = 100 },
 			errString: "unknown batch type: 100",
 		},
+		{
+			name: "dictionary compression with singular batches",
+			override: func(c *batcher.CLIConfig) {
+				c.CompressionAlgo = derive.ZlibDict
+				c.BatchType = derive.SingularBatchType
+			},
+			errString: "compression algo zlib-dict requires span batches",
+		},
 		{
 			name:      "invalid batch submission policy",
 			override:  func(c *batcher.CLIConfig) { c.DataAvailabilityType = "foo" },
@@ -105,6 +113,13 @@ func TestBatcherConfig(t *testing.T) {
 			},
 			errString: "too many frames for blob transactions, max 6",
 		},
+		{
+			name: "nonce gaps without timeout",
+			override: func(c *batcher.CLIConfig) {
+				c.TestFailures = &batcher.FailureConfig{NonceGaps: 1}
+			},
+			errString: "NonceGapTimeout must be set to inject nonce gaps",
+		},
 		{
 			name: "invalid compr ratio for ratio compressor",
 			override: func(c *batcher.CLIConfig) {
@@ -113,6 +128,48 @@ func TestBatcherConfig(t *testing.T) {
 			},
 			errString: "invalid ApproxComprRatio 4.2 for ratio compressor",
 		},
+		{
+			name: "failover lease TTL too small",
+			override: func(c *batcher.CLIConfig) {
+				c.FailoverLeaseRpc = "fake"
+				c.FailoverHolderID = "batcher-0"
+				c.FailoverLeaseTTL = time.Millisecond
+			},
+			errString: "failover lease TTL must be at least 1s",
+		},
+		{
+			name: "empty failover holder ID",
+			override: func(c *batcher.CLIConfig) {
+				c.FailoverLeaseRpc = "fake"
+				c.FailoverLeaseTTL = time.Minute
+			},
+			errString: "empty failover holder ID",
+		},
+		{
+			name: "stopped in failover mode",
+			override: func(c *batcher.CLIConfig) {
+				c.FailoverLeaseRpc = "fake"
+				c.FailoverHolderID = "batcher-0"
+				c.FailoverLeaseTTL = time.Minute
+				c.Stopped = true
+			},
+			errString: "cannot start stopped in failover mode",
+		},
+		{
+			name: "empty key rotation private key",
+			override: func(c *batcher.CLIConfig) {
+				c.KeyRotationPrivateKeys = []string{""}
+			},
+			errString: "empty key rotation private key 0",
+		},
+		{
+			name: "negative key rotation inclusion margin",
+			override: func(c *batcher.CLIConfig) {
+				c.KeyRotationPrivateKeys = []string{"0x01"}
+				c.KeyRotationInclusionMargin = -time.Second
+			},
+			errString: "key rotation inclusion margin must not be negative",
+		},
 	}
 
 	for _, test := range tests {
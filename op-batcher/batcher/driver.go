@@ -15,8 +15,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/da"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/feewatch"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -57,15 +59,19 @@ type RollupClient interface {
 
 // DriverSetup is the collection of input/output interfaces and configuration that the driver operates on.
 type DriverSetup struct {
-	Log              log.Logger
-	Metr             metrics.Metricer
-	RollupConfig     *rollup.Config
-	Config           BatcherConfig
-	Txmgr            *txmgr.SimpleTxManager
+	Log          log.Logger
+	Metr         metrics.Metricer
+	RollupConfig *rollup.Config
+	Config       BatcherConfig
+	Txmgr        *txmgr.SimpleTxManager
+	// RotationTxmgrs send from the batcher keys scheduled in the rollup config, in order of activation. Optional.
+	RotationTxmgrs   []*txmgr.SimpleTxManager
 	L1Client         L1Client
 	EndpointProvider dial.L2EndpointProvider
 	ChannelConfig    ChannelConfigProvider
-	AltDA            *altda.DAClient
+	AltDA            da.Client
+	// FeeWatcher is notified of the cost and revenue of completed channels, if economics are enabled. Optional.
+	FeeWatcher *feewatch.Watcher
 }
 
 // BatchSubmitter encapsulates a service responsible for submitting L2 tx
@@ -88,14 +94,56 @@ type BatchSubmitter struct {
 	lastL1Tip       eth.L1BlockRef
 
 	state *channelManager
+
+	// failures injects failures into transaction submission, for testing ONLY. nil if disabled.
+	failures *failureInjector
+
+	// rotator selects the batcher key to send from. nil if there are no keys to rotate to.
+	rotator *keyRotator
+
+	// economics reports the cost and revenue of completed channels. nil if disabled.
+	economics *economics
 }
 
 // NewBatchSubmitter initializes the BatchSubmitter driver from a preconfigured DriverSetup
 func NewBatchSubmitter(setup DriverSetup) *BatchSubmitter {
-	return &BatchSubmitter{
+	l := &BatchSubmitter{
 		DriverSetup: setup,
 		state:       NewChannelManager(setup.Log, setup.Metr, setup.ChannelConfig, setup.RollupConfig),
 	}
+	if len(setup.RotationTxmgrs) > 0 {
+		rotation := make([]txmgr.TxManager, len(setup.RotationTxmgrs))
+		for i, m := range setup.RotationTxmgrs {
+			rotation[i] = m
+		}
+		l.rotator = newKeyRotator(setup.Log, setup.RollupConfig, setup.Config.KeyRotationInclusionMargin, setup.Txmgr, rotation)
+	}
+	if setup.Config.TestFailures != nil {
+		setup.Log.Warn("Injecting data availability failures, this must only be used for testing")
+		keys := append([]*txmgr.SimpleTxManager{setup.Txmgr}, setup.RotationTxmgrs...)
+		l.failures = newFailureInjector(setup.Log, l.txMgr(), keys, *setup.Config.TestFailures)
+	}
+	if setup.Config.EconomicsEnabled {
+		l.economics = newEconomics(setup.Log, setup.Metr, setup.EndpointProvider, setup.Config.NetworkTimeout, setup.Config.EconomicsReportInterval)
+		if setup.FeeWatcher != nil {
+			l.economics.observer = setup.FeeWatcher
+		}
+		l.state.economics = l.economics
+	}
+	return l
+}
+
+// txMgr returns the transaction manager that selects the batcher key to send from.
+func (l *BatchSubmitter) txMgr() txmgr.TxManager {
+	if l.rotator != nil {
+		return l.rotator
+	}
+	return l.Txmgr
+}
+
+// sender returns the address that batcher transactions are sent from.
+func (l *BatchSubmitter) sender() common.Address {
+	return l.txMgr().From()
 }
 
 func (l *BatchSubmitter) StartBatchSubmitting() error {
@@ -298,12 +346,25 @@ func (l *BatchSubmitter) loop() {
 	defer l.wg.Done()
 
 	receiptsCh := make(chan txmgr.TxReceipt[txRef])
-	queue := txmgr.NewQueue[txRef](l.killCtx, l.Txmgr, l.Config.MaxPendingTransactions)
+	txMgr := l.txMgr()
+	if l.failures != nil {
+		// the failures are injected into the transactions of any batcher key
+		txMgr = l.failures
+	}
+	queue := txmgr.NewQueue[txRef](l.killCtx, txMgr, l.Config.MaxPendingTransactions)
 
 	// start the receipt/result processing loop
 	receiptLoopDone := make(chan struct{})
 	defer close(receiptLoopDone) // shut down receipt loop
 
+	if l.economics != nil {
+		l.wg.Add(1)
+		go func() {
+			defer l.wg.Done()
+			l.economics.run(receiptLoopDone)
+		}()
+	}
+
 	var (
 		txpoolState       atomic.Int32
 		txpoolBlockedBlob bool
@@ -409,10 +470,12 @@ func (l *BatchSubmitter) waitNodeSync() error {
 		return fmt.Errorf("failed to retrieve l1 tip: %w", err)
 	}
 
+	l.recordL1Tip(l1Tip)
+
 	l1TargetBlock := l1Tip.Number
 	if l.Config.CheckRecentTxsDepth != 0 {
 		l.Log.Info("Checking for recently submitted batcher transactions on L1")
-		recentBlock, found, err := eth.CheckRecentTxs(cCtx, l.L1Client, l.Config.CheckRecentTxsDepth, l.Txmgr.From())
+		recentBlock, found, err := eth.CheckRecentTxs(cCtx, l.L1Client, l.Config.CheckRecentTxsDepth, l.sender())
 		if err != nil {
 			return fmt.Errorf("failed checking recent batcher txs: %w", err)
 		}
@@ -571,13 +634,20 @@ func (l *BatchSubmitter) sendTransaction(ctx context.Context, txdata txData, que
 		data := txdata.CallData()
 		// if AltDA is enabled we post the txdata to the DA Provider and replace it with the commitment.
 		if l.Config.UseAltDA {
-			comm, err := l.AltDA.SetInput(ctx, data)
+			commitment, err := l.AltDA.Put(ctx, data)
 			if err != nil {
 				l.Log.Error("Failed to post input to Alt DA", "error", err)
 				// requeue frame if we fail to post to the DA Provider so it can be retried
 				l.recordFailedTx(txdata.ID(), err)
 				return nil
 			}
+			// commitments are encoded with their commitment type, see da.Client
+			comm, err := altda.DecodeCommitmentData(commitment)
+			if err != nil {
+				l.Log.Error("Invalid commitment returned by Alt DA", "error", err)
+				l.recordFailedTx(txdata.ID(), err)
+				return nil
+			}
 			l.Log.Info("Set AltDA input", "commitment", comm, "tx", txdata.ID())
 			// signal AltDA commitment tx with TxDataVersion1
 			data = comm.TxData()
@@ -640,6 +710,9 @@ func (l *BatchSubmitter) recordL1Tip(l1tip eth.L1BlockRef) {
 	}
 	l.lastL1Tip = l1tip
 	l.Metr.RecordLatestL1Block(l1tip)
+	if l.rotator != nil {
+		l.rotator.setL1Head(l1tip)
+	}
 }
 
 func (l *BatchSubmitter) recordFailedTx(id txID, err error) {
@@ -650,6 +723,10 @@ func (l *BatchSubmitter) recordFailedTx(id txID, err error) {
 func (l *BatchSubmitter) recordConfirmedTx(id txID, receipt *types.Receipt) {
 	l.Log.Info("Transaction confirmed", logFields(id, receipt)...)
 	l1block := eth.ReceiptBlockID(receipt)
+	if l.economics != nil {
+		// The cost must be known before the channel completes with this transaction
+		l.economics.TxConfirmed(id, receipt)
+	}
 	l.state.TxConfirmed(id, l1block)
 }
 
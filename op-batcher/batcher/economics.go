@@ -0,0 +1,289 @@
+package batcher
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// completedChannelsBuffer is the number of completed channels that can wait for their fee revenue to be fetched.
+const completedChannelsBuffer = 64
+
+// completedChannel is a channel that is done with submission, because it was fully submitted or timed out.
+type completedChannel struct {
+	ID          derive.ChannelID
+	Blocks      []eth.BlockID
+	InputBytes  int
+	OutputBytes int
+	TimedOut    bool
+}
+
+func newCompletedChannel(c *channel, timedOut bool) completedChannel {
+	blocks := make([]eth.BlockID, 0, len(c.channelBuilder.Blocks()))
+	for _, block := range c.channelBuilder.Blocks() {
+		blocks = append(blocks, eth.ToBlockID(block))
+	}
+	return completedChannel{
+		ID:          c.ID(),
+		Blocks:      blocks,
+		InputBytes:  c.InputBytes(),
+		OutputBytes: c.OutputBytes(),
+		TimedOut:    timedOut,
+	}
+}
+
+// ChannelEconomics are the costs and revenue of a channel, at the time it completed submission.
+type ChannelEconomics struct {
+	ID derive.ChannelID
+	// Blocks is the number of L2 blocks in the channel
+	Blocks      int
+	InputBytes  int
+	OutputBytes int
+	// Txs is the number of confirmed batcher transactions of the channel
+	Txs int
+	// L1Cost is the fee in wei paid for the batcher transactions of the channel, including the blob fee
+	L1Cost *big.Int
+	// FeeRevenue is the L1 data fee in wei paid by the L2 transactions in the channel.
+	// It is zero for channels that timed out, as their blocks are submitted again in a later channel.
+	FeeRevenue *big.Int
+	TimedOut   bool
+}
+
+// Margin returns the fee revenue minus the L1 cost of the channel.
+func (e *ChannelEconomics) Margin() *big.Int {
+	return new(big.Int).Sub(e.FeeRevenue, e.L1Cost)
+}
+
+// receiptCost returns the fee in wei paid for the L1 transaction of the receipt.
+func receiptCost(receipt *types.Receipt) *big.Int {
+	cost := new(big.Int).SetUint64(receipt.GasUsed)
+	if receipt.EffectiveGasPrice != nil {
+		cost.Mul(cost, receipt.EffectiveGasPrice)
+	} else {
+		cost.SetUint64(0)
+	}
+	if receipt.BlobGasPrice != nil {
+		blobCost := new(big.Int).SetUint64(receipt.BlobGasUsed)
+		cost.Add(cost, blobCost.Mul(blobCost, receipt.BlobGasPrice))
+	}
+	return cost
+}
+
+// economicsReport aggregates the economics of the channels completed since the last report.
+type economicsReport struct {
+	start       time.Time
+	channels    int
+	timedOut    int
+	inputBytes  int
+	outputBytes int
+	l1Cost      *big.Int
+	feeRevenue  *big.Int
+}
+
+func newEconomicsReport(start time.Time) *economicsReport {
+	return &economicsReport{start: start, l1Cost: new(big.Int), feeRevenue: new(big.Int)}
+}
+
+func (r *economicsReport) add(e *ChannelEconomics) {
+	r.channels++
+	if e.TimedOut {
+		r.timedOut++
+	}
+	r.inputBytes += e.InputBytes
+	r.outputBytes += e.OutputBytes
+	r.l1Cost.Add(r.l1Cost, e.L1Cost)
+	r.feeRevenue.Add(r.feeRevenue, e.FeeRevenue)
+}
+
+// economicsObserver is notified of the L1 cost and fee revenue of each completed channel, e.g. the fee watcher.
+type economicsObserver interface {
+	Observe(l1Cost, feeRevenue *big.Int)
+}
+
+// economics tracks the L1 cost of the batcher transactions of each channel, and reports the cost, fee revenue
+// and margin of channels as they complete submission, to tune the fee scalars of the chain.
+type economics struct {
+	log     log.Logger
+	metr    metrics.Metricer
+	l2      dial.L2EndpointProvider
+	timeout time.Duration
+
+	mu    sync.Mutex
+	costs map[derive.ChannelID]*channelCost
+
+	completed      chan completedChannel
+	reportInterval time.Duration
+	report         *economicsReport
+
+	// observer is notified of the economics of each completed channel. Optional.
+	observer economicsObserver
+}
+
+type channelCost struct {
+	l1Cost *big.Int
+	txs    int
+}
+
+func newEconomics(log log.Logger, metr metrics.Metricer, l2 dial.L2EndpointProvider, timeout time.Duration, reportInterval time.Duration) *economics {
+	return &economics{
+		log:            log,
+		metr:           metr,
+		l2:             l2,
+		timeout:        timeout,
+		costs:          make(map[derive.ChannelID]*channelCost),
+		completed:      make(chan completedChannel, completedChannelsBuffer),
+		reportInterval: reportInterval,
+	}
+}
+
+// TxConfirmed adds the cost of the confirmed batcher transaction to its channel.
+func (e *economics) TxConfirmed(id txID, receipt *types.Receipt) {
+	if len(id) == 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	// All frames of a transaction are from the same channel
+	cost, ok := e.costs[id[0].chID]
+	if !ok {
+		cost = &channelCost{l1Cost: new(big.Int)}
+		e.costs[id[0].chID] = cost
+	}
+	cost.l1Cost.Add(cost.l1Cost, receiptCost(receipt))
+	cost.txs++
+}
+
+// ChannelCompleted queues the channel for its fee revenue to be fetched and reported.
+func (e *economics) ChannelCompleted(c completedChannel) {
+	select {
+	case e.completed <- c:
+	default:
+		e.log.Warn("Dropping channel economics, fee revenue fetching is lagging behind", "id", c.ID)
+		e.mu.Lock()
+		delete(e.costs, c.ID)
+		e.mu.Unlock()
+	}
+}
+
+// Clear forgets the costs of all channels, e.g. when the channel manager is cleared.
+func (e *economics) Clear() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.costs = make(map[derive.ChannelID]*channelCost)
+}
+
+// run processes completed channels until done is closed, and logs a report of the processed channels
+// every report interval, if it is not zero.
+func (e *economics) run(done <-chan struct{}) {
+	e.report = newEconomicsReport(time.Now())
+	var reportTick <-chan time.Time
+	if e.reportInterval > 0 {
+		ticker := time.NewTicker(e.reportInterval)
+		defer ticker.Stop()
+		reportTick = ticker.C
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	for {
+		select {
+		case c := <-e.completed:
+			e.process(ctx, c)
+		case <-reportTick:
+			e.logReport()
+		case <-done:
+			return
+		}
+	}
+}
+
+func (e *economics) process(ctx context.Context, c completedChannel) {
+	e.mu.Lock()
+	cost, ok := e.costs[c.ID]
+	delete(e.costs, c.ID)
+	e.mu.Unlock()
+	if !ok {
+		cost = &channelCost{l1Cost: new(big.Int)}
+	}
+
+	res := &ChannelEconomics{
+		ID:          c.ID,
+		Blocks:      len(c.Blocks),
+		InputBytes:  c.InputBytes,
+		OutputBytes: c.OutputBytes,
+		Txs:         cost.txs,
+		L1Cost:      cost.l1Cost,
+		FeeRevenue:  new(big.Int),
+		TimedOut:    c.TimedOut,
+	}
+	if !c.TimedOut {
+		revenue, err := e.feeRevenue(ctx, c.Blocks)
+		if err != nil {
+			e.log.Warn("Failed to fetch the fee revenue of channel", "id", c.ID, "err", err)
+			return
+		}
+		res.FeeRevenue = revenue
+	}
+
+	e.metr.RecordChannelEconomics(res.L1Cost, res.FeeRevenue)
+	if e.observer != nil {
+		e.observer.Observe(res.L1Cost, res.FeeRevenue)
+	}
+	e.log.Info("Channel economics", "id", res.ID, "blocks", res.Blocks, "txs", res.Txs, "timed_out", res.TimedOut,
+		"input_bytes", res.InputBytes, "output_bytes", res.OutputBytes,
+		"l1_cost", res.L1Cost, "fee_revenue", res.FeeRevenue, "margin", res.Margin())
+	e.report.add(res)
+}
+
+// feeRevenue sums the L1 data fees paid by the transactions of the L2 blocks.
+func (e *economics) feeRevenue(ctx context.Context, blocks []eth.BlockID) (*big.Int, error) {
+	l2Client, err := e.l2.EthClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting L2 client: %w", err)
+	}
+	revenue := new(big.Int)
+	for _, block := range blocks {
+		cCtx, cancel := context.WithTimeout(ctx, e.timeout)
+		receipts, err := l2Client.BlockReceipts(cCtx, rpc.BlockNumberOrHashWithHash(block.Hash, true))
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("getting receipts of L2 block %s: %w", block, err)
+		}
+		for _, receipt := range receipts {
+			// Deposit transactions don't pay an L1 data fee
+			if receipt.L1Fee != nil {
+				revenue.Add(revenue, receipt.L1Fee)
+			}
+		}
+	}
+	return revenue, nil
+}
+
+func (e *economics) logReport() {
+	r := e.report
+	e.report = newEconomicsReport(time.Now())
+	var comprRatio float64
+	if r.inputBytes > 0 {
+		comprRatio = float64(r.outputBytes) / float64(r.inputBytes)
+	}
+	e.log.Info("Channel economics report", "since", r.start, "channels", r.channels, "timed_out", r.timedOut,
+		"input_bytes", r.inputBytes, "output_bytes", r.outputBytes, "compr_ratio", comprRatio,
+		"l1_cost", r.l1Cost, "fee_revenue", r.feeRevenue, "margin", new(big.Int).Sub(r.feeRevenue, r.l1Cost))
+}
@@ -0,0 +1,105 @@
+package batcher
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type economicsMetrics struct {
+	metrics.Metricer
+	l1Cost, feeRevenue *big.Int
+}
+
+func (m *economicsMetrics) RecordChannelEconomics(l1Cost, feeRevenue *big.Int) {
+	m.l1Cost, m.feeRevenue = l1Cost, feeRevenue
+}
+
+type economicsRecorder struct {
+	l1Cost, feeRevenue *big.Int
+}
+
+func (r *economicsRecorder) Observe(l1Cost, feeRevenue *big.Int) {
+	r.l1Cost, r.feeRevenue = l1Cost, feeRevenue
+}
+
+func TestReceiptCost(t *testing.T) {
+	require.Equal(t, big.NewInt(21_000*10), receiptCost(&types.Receipt{GasUsed: 21_000, EffectiveGasPrice: big.NewInt(10)}))
+	require.Equal(t, big.NewInt(21_000*10+131072*3), receiptCost(&types.Receipt{
+		GasUsed:           21_000,
+		EffectiveGasPrice: big.NewInt(10),
+		BlobGasUsed:       131072,
+		BlobGasPrice:      big.NewInt(3),
+	}))
+}
+
+func TestEconomics_ChannelCompleted(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	lgr := testlog.Logger(t, log.LevelError)
+	metr := &economicsMetrics{Metricer: metrics.NoopMetrics}
+	ep := newEndpointProvider()
+	e := newEconomics(lgr, metr, ep, time.Second, 0)
+	observer := &economicsRecorder{}
+	e.observer = observer
+
+	cfg := channelManagerTestConfig(120_000, 0)
+	cfg.ChannelTimeout = 10
+	cfg.CompressorConfig.TargetOutputSize = 1 // full on first block
+	m := NewChannelManager(lgr, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.economics = e
+	m.Clear(eth.BlockID{})
+
+	block := derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID)
+	require.NoError(t, m.AddL2Block(block))
+	txdata, err := m.TxData(eth.BlockID{})
+	require.NoError(t, err)
+
+	receipt := &types.Receipt{GasUsed: 50_000, EffectiveGasPrice: big.NewInt(100), BlockNumber: big.NewInt(1)}
+	e.TxConfirmed(txdata.ID(), receipt)
+	m.TxConfirmed(txdata.ID(), eth.ReceiptBlockID(receipt))
+
+	var c completedChannel
+	select {
+	case c = <-e.completed:
+	default:
+		t.Fatal("expected completed channel")
+	}
+	require.Equal(t, txdata.ID()[0].chID, c.ID)
+	require.Equal(t, []eth.BlockID{eth.ToBlockID(block)}, c.Blocks)
+	require.False(t, c.TimedOut)
+	require.Positive(t, c.InputBytes)
+	require.Positive(t, c.OutputBytes)
+
+	ep.ethClient.ExpectBlockReceipts(rpc.BlockNumberOrHashWithHash(block.Hash(), true), []*types.Receipt{
+		{}, // deposit
+		{L1Fee: big.NewInt(2_000_000)},
+		{L1Fee: big.NewInt(4_000_000)},
+	}, nil)
+	e.report = newEconomicsReport(time.Now())
+	e.process(context.Background(), c)
+	ep.ethClient.AssertExpectations(t)
+
+	require.Equal(t, big.NewInt(5_000_000), metr.l1Cost)
+	require.Equal(t, big.NewInt(6_000_000), metr.feeRevenue)
+	require.Equal(t, &economicsRecorder{l1Cost: big.NewInt(5_000_000), feeRevenue: big.NewInt(6_000_000)}, observer)
+	require.Equal(t, 1, e.report.channels)
+	require.Equal(t, big.NewInt(1_000_000), new(big.Int).Sub(e.report.feeRevenue, e.report.l1Cost))
+	require.Empty(t, e.costs, "costs of completed channels must be forgotten")
+}
+
+func TestEconomics_Margin(t *testing.T) {
+	res := ChannelEconomics{L1Cost: big.NewInt(300), FeeRevenue: big.NewInt(200)}
+	require.Equal(t, big.NewInt(-100), res.Margin())
+}
@@ -0,0 +1,183 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/lease"
+)
+
+// submitterControl is the part of the BatchSubmitter that the failover controls.
+type submitterControl interface {
+	StartBatchSubmitting() error
+	StopBatchSubmitting(ctx context.Context) error
+	StopBatchSubmittingIfRunning(ctx context.Context) error
+}
+
+var ErrNotLeaseHolder = errors.New("batcher does not hold the failover lease")
+
+type FailoverMetricer interface {
+	RecordFailoverActive(active bool)
+}
+
+// Failover runs the batcher in active/passive mode: only the batcher holding the shared lease submits batches.
+// The active batcher renews the lease, and stops submitting before its lease can expire if it fails to do so.
+// A standby batcher polls the lease, and takes over batch submission once the lease is released or expires.
+// A batcher that takes over resumes from the L2 safe head, which reflects the batches submitted on L1 so far.
+type Failover struct {
+	log     log.Logger
+	metr    FailoverMetricer
+	clock   clock.Clock
+	lease   lease.Lease
+	driver  submitterControl
+	holder  string
+	ttl     time.Duration
+	timeout time.Duration
+
+	active atomic.Bool
+	// expiry is the time the lease expires, if not renewed
+	expiry time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewFailover(log log.Logger, metr FailoverMetricer, clock clock.Clock, shared lease.Lease, driver submitterControl, holder string, ttl time.Duration, stopTimeout time.Duration) *Failover {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Failover{
+		log:     log.New("holder", holder),
+		metr:    metr,
+		clock:   clock,
+		lease:   shared,
+		driver:  driver,
+		holder:  holder,
+		ttl:     ttl,
+		timeout: stopTimeout,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start starts polling the lease in the background.
+func (f *Failover) Start() {
+	f.log.Info("Starting batcher in failover mode", "ttl", f.ttl)
+	f.metr.RecordFailoverActive(false)
+	f.wg.Add(1)
+	go f.loop()
+}
+
+// Stop stops polling the lease, stops submitting batches if active, and releases the lease.
+func (f *Failover) Stop(ctx context.Context) error {
+	f.cancel()
+	f.wg.Wait()
+	if !f.active.Load() {
+		return nil
+	}
+	err := f.demote(ctx)
+	if releaseErr := f.lease.Release(ctx, f.holder); releaseErr != nil {
+		err = errors.Join(err, releaseErr)
+	}
+	return err
+}
+
+func (f *Failover) loop() {
+	defer f.wg.Done()
+	// Renew well within the ttl, so a single failed renewal does not lose the lease.
+	ticker := f.clock.NewTicker(f.ttl / 3)
+	defer ticker.Stop()
+	f.step(f.ctx)
+	for {
+		select {
+		case <-ticker.Ch():
+			f.step(f.ctx)
+		case <-f.ctx.Done():
+			return
+		}
+	}
+}
+
+// step acquires or renews the lease, and starts or stops batch submission accordingly.
+func (f *Failover) step(ctx context.Context) {
+	// The expiry is counted from before the request, so it's never later than the expiry seen by the lease.
+	start := f.clock.Now()
+	reqCtx, cancel := context.WithTimeout(ctx, f.ttl/3)
+	held, err := f.lease.Acquire(reqCtx, f.holder, f.ttl)
+	cancel()
+	if err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		f.log.Warn("Failed to acquire batcher lease", "active", f.active.Load(), "err", err)
+		// Stop submitting before the lease can expire and a standby takes over.
+		if f.active.Load() && !f.clock.Now().Before(f.expiry.Add(-f.ttl/3)) {
+			f.log.Error("Unable to renew batcher lease, stopping batch submission")
+			f.stepDown(ctx)
+		}
+		return
+	}
+	if !held {
+		if f.active.Load() {
+			f.log.Error("Batcher lease was taken over, stopping batch submission")
+			f.stepDown(ctx)
+		}
+		return
+	}
+	f.expiry = start.Add(f.ttl)
+	if f.active.Load() {
+		return
+	}
+	f.log.Info("Acquired batcher lease, starting batch submission")
+	if err := f.driver.StartBatchSubmitting(); err != nil {
+		f.log.Error("Failed to start batch submission, releasing lease", "err", err)
+		// Stop a partially started batcher, e.g. when it failed waiting for node sync.
+		if err := f.driver.StopBatchSubmittingIfRunning(ctx); err != nil {
+			f.log.Error("Failed to stop batch submission", "err", err)
+		}
+		if err := f.lease.Release(ctx, f.holder); err != nil {
+			f.log.Warn("Failed to release batcher lease", "err", err)
+		}
+		return
+	}
+	f.active.Store(true)
+	f.metr.RecordFailoverActive(true)
+}
+
+func (f *Failover) stepDown(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+	if err := f.demote(ctx); err != nil {
+		f.log.Error("Failed to stop batch submission", "err", err)
+	}
+}
+
+func (f *Failover) demote(ctx context.Context) error {
+	f.active.Store(false)
+	f.metr.RecordFailoverActive(false)
+	return f.driver.StopBatchSubmittingIfRunning(ctx)
+}
+
+// Active returns whether this batcher holds the lease and is submitting batches.
+func (f *Failover) Active() bool {
+	return f.active.Load()
+}
+
+// StartBatchSubmitting resumes batch submission, e.g. after it was stopped with the admin RPC.
+// It fails unless the batcher holds the lease, so a standby batcher can't submit batches next to the active one.
+func (f *Failover) StartBatchSubmitting() error {
+	if !f.active.Load() {
+		return ErrNotLeaseHolder
+	}
+	return f.driver.StartBatchSubmitting()
+}
+
+// StopBatchSubmitting stops batch submission, keeping the lease: the batcher stays active, but paused.
+func (f *Failover) StopBatchSubmitting(ctx context.Context) error {
+	return f.driver.StopBatchSubmitting(ctx)
+}
@@ -0,0 +1,172 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/lease"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type mockSubmitter struct {
+	running bool
+	starts  int
+	stops   int
+}
+
+func (m *mockSubmitter) StartBatchSubmitting() error {
+	if m.running {
+		return errors.New("already running")
+	}
+	m.running = true
+	m.starts++
+	return nil
+}
+
+func (m *mockSubmitter) StopBatchSubmitting(ctx context.Context) error {
+	if !m.running {
+		return errors.New("not running")
+	}
+	return m.StopBatchSubmittingIfRunning(ctx)
+}
+
+func (m *mockSubmitter) StopBatchSubmittingIfRunning(ctx context.Context) error {
+	if m.running {
+		m.stops++
+	}
+	m.running = false
+	return nil
+}
+
+// flakyLease fails all requests while down.
+type flakyLease struct {
+	lease.Lease
+	down bool
+}
+
+func (f *flakyLease) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	if f.down {
+		return false, errors.New("lease unavailable")
+	}
+	return f.Lease.Acquire(ctx, holder, ttl)
+}
+
+const testLeaseTTL = 30 * time.Second
+
+func newTestFailover(t *testing.T, cl clock.Clock, shared lease.Lease, holder string) (*Failover, *mockSubmitter) {
+	driver := new(mockSubmitter)
+	logger := testlog.Logger(t, log.LevelDebug)
+	return NewFailover(logger, metrics.NoopMetrics, cl, shared, driver, holder, testLeaseTTL, time.Second), driver
+}
+
+func TestFailoverTakeover(t *testing.T) {
+	ctx := context.Background()
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	shared := lease.NewMemoryLease(cl)
+	primary, primaryDriver := newTestFailover(t, cl, shared, "primary")
+	standby, standbyDriver := newTestFailover(t, cl, shared, "standby")
+
+	primary.step(ctx)
+	standby.step(ctx)
+	require.True(t, primary.Active())
+	require.True(t, primaryDriver.running)
+	require.False(t, standby.Active())
+	require.False(t, standbyDriver.running)
+	require.Equal(t, "primary", shared.Holder())
+
+	// The primary keeps renewing the lease, the standby keeps waiting.
+	for i := 0; i < 5; i++ {
+		cl.AdvanceTime(testLeaseTTL / 3)
+		primary.step(ctx)
+		standby.step(ctx)
+		require.True(t, primary.Active())
+		require.False(t, standby.Active())
+	}
+
+	// The primary stops renewing, the standby takes over once the lease expires.
+	cl.AdvanceTime(testLeaseTTL / 3)
+	standby.step(ctx)
+	require.False(t, standby.Active())
+	cl.AdvanceTime(testLeaseTTL)
+	standby.step(ctx)
+	require.True(t, standby.Active())
+	require.True(t, standbyDriver.running)
+	require.Equal(t, "standby", shared.Holder())
+
+	// The primary finds out it lost the lease and stops submitting.
+	primary.step(ctx)
+	require.False(t, primary.Active())
+	require.False(t, primaryDriver.running)
+	require.Equal(t, 1, primaryDriver.stops)
+
+	// A graceful stop releases the lease, so the primary can take over without waiting for the lease to expire.
+	require.NoError(t, standby.Stop(ctx))
+	require.False(t, standbyDriver.running)
+	require.Equal(t, "", shared.Holder())
+	primary.step(ctx)
+	require.True(t, primary.Active())
+	require.Equal(t, 2, primaryDriver.starts)
+}
+
+func TestFailoverLeaseUnavailable(t *testing.T) {
+	ctx := context.Background()
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	flaky := &flakyLease{Lease: lease.NewMemoryLease(cl)}
+	f, driver := newTestFailover(t, cl, flaky, "primary")
+
+	f.step(ctx)
+	require.True(t, f.Active())
+
+	// A failed renewal is tolerated while the lease is not close to expiring.
+	flaky.down = true
+	cl.AdvanceTime(testLeaseTTL / 3)
+	f.step(ctx)
+	require.True(t, f.Active())
+	require.True(t, driver.running)
+
+	// Batch submission stops before the lease can expire.
+	cl.AdvanceTime(testLeaseTTL / 3)
+	f.step(ctx)
+	require.False(t, f.Active())
+	require.False(t, driver.running)
+
+	// The standby doesn't start submitting while the lease is unavailable.
+	cl.AdvanceTime(testLeaseTTL)
+	f.step(ctx)
+	require.False(t, f.Active())
+
+	flaky.down = false
+	f.step(ctx)
+	require.True(t, f.Active())
+}
+
+func TestFailoverAdminStart(t *testing.T) {
+	ctx := context.Background()
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	shared := lease.NewMemoryLease(cl)
+	primary, primaryDriver := newTestFailover(t, cl, shared, "primary")
+	standby, standbyDriver := newTestFailover(t, cl, shared, "standby")
+	primary.step(ctx)
+	standby.step(ctx)
+
+	// The standby can't be started without the lease.
+	require.ErrorIs(t, standby.StartBatchSubmitting(), ErrNotLeaseHolder)
+	require.False(t, standbyDriver.running)
+
+	// The active batcher can be paused and resumed, and keeps the lease.
+	require.NoError(t, primary.StopBatchSubmitting(ctx))
+	require.False(t, primaryDriver.running)
+	cl.AdvanceTime(testLeaseTTL / 3)
+	primary.step(ctx)
+	require.True(t, primary.Active())
+	require.Equal(t, "primary", shared.Holder())
+	require.NoError(t, primary.StartBatchSubmitting())
+	require.True(t, primaryDriver.running)
+}
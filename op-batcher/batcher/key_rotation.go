@@ -0,0 +1,117 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+var ErrNoAcceptedBatcherKey = errors.New("none of the batcher keys is accepted by derivation")
+
+// keyRotator is a txmgr.TxManager that sends each transaction from one of several batcher keys,
+// following the batcher keys scheduled in the rollup config.
+// It prefers the most recently rotated-to key that derivation accepts both now and at the expected inclusion time,
+// so the batcher moves to a new key during the overlap with the old key, without downtime.
+//
+// Derivation accepts a batch by the time of the L1 block that includes it, so keys are selected by the time
+// of the latest L1 head, which the inclusion block follows, instead of the local clock, which may be skewed.
+type keyRotator struct {
+	log       log.Logger
+	rollupCfg *rollup.Config
+	// inclusionMargin is the time within which a transaction is expected to be included after it is sent
+	inclusionMargin time.Duration
+	// primary is the key of the batcher address in the system config
+	primary txmgr.TxManager
+	// managers are all keys, in order of preference: the last rotation key first, the primary key last
+	managers []txmgr.TxManager
+	// l1Time is the time of the latest L1 head, see setL1Head
+	l1Time atomic.Uint64
+}
+
+func newKeyRotator(logger log.Logger, rollupCfg *rollup.Config, inclusionMargin time.Duration, primary txmgr.TxManager, rotation []txmgr.TxManager) *keyRotator {
+	managers := make([]txmgr.TxManager, 0, len(rotation)+1)
+	for i := len(rotation) - 1; i >= 0; i-- {
+		managers = append(managers, rotation[i])
+	}
+	managers = append(managers, primary)
+	return &keyRotator{
+		log:             logger,
+		rollupCfg:       rollupCfg,
+		inclusionMargin: inclusionMargin,
+		primary:         primary,
+		managers:        managers,
+	}
+}
+
+// setL1Head updates the L1 head that the keys are selected at.
+func (k *keyRotator) setL1Head(head eth.L1BlockRef) {
+	k.l1Time.Store(head.Time)
+}
+
+// current selects the key to send the next transaction from.
+func (k *keyRotator) current() (txmgr.TxManager, error) {
+	now := k.l1Time.Load()
+	accepted := k.rollupCfg.BatcherAddresses(now, k.primary.From())
+	acceptedLater := k.rollupCfg.BatcherAddresses(now+uint64(k.inclusionMargin/time.Second), k.primary.From())
+	for _, m := range k.managers {
+		if slices.Contains(accepted, m.From()) && slices.Contains(acceptedLater, m.From()) {
+			return m, nil
+		}
+	}
+	// None of the keys is accepted for the full inclusion margin. Use a key that is accepted now,
+	// the transaction may still be included before the key is deactivated.
+	for _, m := range k.managers {
+		if slices.Contains(accepted, m.From()) {
+			k.log.Warn("Batcher key is deactivated within the inclusion margin", "key", m.From(), "margin", k.inclusionMargin)
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("%w at L1 time %d: accepted %v", ErrNoAcceptedBatcherKey, now, accepted)
+}
+
+func (k *keyRotator) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	m, err := k.current()
+	if err != nil {
+		return nil, err
+	}
+	return m.Send(ctx, candidate)
+}
+
+// From returns the key that the next transaction is sent from, or the primary key if none is accepted.
+func (k *keyRotator) From() common.Address {
+	m, err := k.current()
+	if err != nil {
+		return k.primary.From()
+	}
+	return m.From()
+}
+
+func (k *keyRotator) BlockNumber(ctx context.Context) (uint64, error) {
+	return k.primary.BlockNumber(ctx)
+}
+
+func (k *keyRotator) API() rpc.API {
+	return k.primary.API()
+}
+
+func (k *keyRotator) Close() {
+	for _, m := range k.managers {
+		m.Close()
+	}
+}
+
+func (k *keyRotator) IsClosed() bool {
+	return k.primary.IsClosed()
+}
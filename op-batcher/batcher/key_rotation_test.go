@@ -0,0 +1,119 @@
+package batcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+type keyTxManager struct {
+	from   common.Address
+	sent   int
+	closed bool
+}
+
+func (m *keyTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	m.sent++
+	return &types.Receipt{}, nil
+}
+
+func (m *keyTxManager) From() common.Address { return m.from }
+
+func (m *keyTxManager) BlockNumber(ctx context.Context) (uint64, error) { return 0, nil }
+
+func (m *keyTxManager) API() rpc.API { return rpc.API{} }
+
+func (m *keyTxManager) Close() { m.closed = true }
+
+func (m *keyTxManager) IsClosed() bool { return m.closed }
+
+func TestKeyRotator(t *testing.T) {
+	primary := &keyTxManager{from: common.Address{0xaa}}
+	next := &keyTxManager{from: common.Address{0xbb}}
+	deactivation := uint64(2000)
+	rollupCfg := &rollup.Config{
+		BatcherKeys: []rollup.BatcherKey{
+			{Address: primary.from, ActivationTime: 1000, DeactivationTime: &deactivation},
+			{Address: next.from, ActivationTime: 1500},
+		},
+	}
+	rotator := newKeyRotator(testlog.Logger(t, log.LevelInfo), rollupCfg, time.Minute, primary, []txmgr.TxManager{next})
+	rotator.setL1Head(eth.L1BlockRef{Time: 500})
+
+	send := func(expected *keyTxManager) {
+		t.Helper()
+		before := expected.sent
+		_, err := rotator.Send(context.Background(), txmgr.TxCandidate{})
+		require.NoError(t, err)
+		require.Equal(t, before+1, expected.sent)
+		require.Equal(t, expected.from, rotator.From())
+	}
+
+	// Before any scheduled key is active, the system config batcher is used.
+	send(primary)
+	// Only the primary key is scheduled, but the next key activates within the inclusion margin.
+	rotator.setL1Head(eth.L1BlockRef{Time: 1480})
+	send(primary)
+	// Both keys are active: the rotated-to key is preferred.
+	rotator.setL1Head(eth.L1BlockRef{Time: 1500})
+	send(next)
+	// The primary key is deactivated.
+	rotator.setL1Head(eth.L1BlockRef{Time: 2500})
+	send(next)
+
+	rotator.Close()
+	require.True(t, primary.closed)
+	require.True(t, next.closed)
+}
+
+func TestKeyRotator_DeactivatedWithinMargin(t *testing.T) {
+	primary := &keyTxManager{from: common.Address{0xaa}}
+	deactivation := uint64(1030)
+	rollupCfg := &rollup.Config{
+		BatcherKeys: []rollup.BatcherKey{
+			{Address: primary.from, ActivationTime: 1000, DeactivationTime: &deactivation},
+		},
+	}
+	other := &keyTxManager{from: common.Address{0xcc}}
+	rotator := newKeyRotator(testlog.Logger(t, log.LevelInfo), rollupCfg, time.Minute, primary, []txmgr.TxManager{other})
+	rotator.setL1Head(eth.L1BlockRef{Time: 1010})
+
+	// The primary key is still accepted now, so it is used even though it deactivates within the margin.
+	_, err := rotator.Send(context.Background(), txmgr.TxCandidate{})
+	require.NoError(t, err)
+	require.Equal(t, 1, primary.sent)
+
+	// Once the only scheduled key is deactivated, the system config batcher is accepted again.
+	rotator.setL1Head(eth.L1BlockRef{Time: 1040})
+	_, err = rotator.Send(context.Background(), txmgr.TxCandidate{})
+	require.NoError(t, err)
+	require.Equal(t, 2, primary.sent)
+	require.Zero(t, other.sent)
+}
+
+func TestKeyRotator_NoAcceptedKey(t *testing.T) {
+	primary := &keyTxManager{from: common.Address{0xaa}}
+	rollupCfg := &rollup.Config{
+		BatcherKeys: []rollup.BatcherKey{
+			{Address: common.Address{0xdd}, ActivationTime: 0},
+		},
+	}
+	rotator := newKeyRotator(testlog.Logger(t, log.LevelInfo), rollupCfg, time.Minute, primary, nil)
+	rotator.setL1Head(eth.L1BlockRef{Time: 1000})
+
+	_, err := rotator.Send(context.Background(), txmgr.TxCandidate{})
+	require.ErrorIs(t, err, ErrNoAcceptedBatcherKey)
+	require.Zero(t, primary.sent)
+	require.Equal(t, primary.from, rotator.From())
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -19,13 +20,23 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/params"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/da"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/feewatch"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	"github.com/ethereum-optimism/optimism/op-service/lease"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	opsigner "github.com/ethereum-optimism/optimism/op-service/signer"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
@@ -42,6 +53,17 @@ type BatcherConfig struct {
 
 	WaitNodeSync        bool
 	CheckRecentTxsDepth int
+
+	// TestFailures injects failures into L1 transaction submission, for testing ONLY.
+	TestFailures *FailureConfig
+
+	// KeyRotationInclusionMargin is the time within which a batcher transaction is expected to be included.
+	KeyRotationInclusionMargin time.Duration
+
+	// EconomicsEnabled enables reporting the L1 cost, fee revenue and margin of each completed channel.
+	EconomicsEnabled bool
+	// EconomicsReportInterval is the interval of the aggregated channel economics report. 0 disables the report.
+	EconomicsReportInterval time.Duration
 }
 
 // BatcherService represents a full batch-submitter instance and its resources,
@@ -52,7 +74,9 @@ type BatcherService struct {
 	L1Client         *ethclient.Client
 	EndpointProvider dial.L2EndpointProvider
 	TxManager        *txmgr.SimpleTxManager
-	AltDA            *altda.DAClient
+	// RotationTxManagers send from the batcher keys that are rotated to, in order of activation
+	RotationTxManagers []*txmgr.SimpleTxManager
+	AltDA              da.Client
 
 	BatcherConfig
 
@@ -61,6 +85,13 @@ type BatcherService struct {
 
 	driver *BatchSubmitter
 
+	// failover is nil if the batcher is not running in failover mode
+	failover *Failover
+	leaseRPC *lease.RPCLease
+
+	// feeWatcher is nil if the fee margin is not watched
+	feeWatcher *feewatch.Watcher
+
 	Version string
 
 	pprofService *oppprof.Service
@@ -96,6 +127,10 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	bs.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
 	bs.CheckRecentTxsDepth = cfg.CheckRecentTxsDepth
 	bs.WaitNodeSync = cfg.WaitNodeSync
+	bs.TestFailures = cfg.TestFailures
+	bs.KeyRotationInclusionMargin = cfg.KeyRotationInclusionMargin
+	bs.EconomicsEnabled = cfg.EconomicsEnabled
+	bs.EconomicsReportInterval = cfg.EconomicsReportInterval
 	if err := bs.initRPCClients(ctx, cfg); err != nil {
 		return err
 	}
@@ -119,7 +154,11 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	if err := bs.initAltDA(cfg); err != nil {
 		return fmt.Errorf("failed to init AltDA: %w", err)
 	}
+	bs.initFeeWatcher(cfg)
 	bs.initDriver()
+	if err := bs.initFailover(ctx, cfg); err != nil {
+		return fmt.Errorf("failed to init failover: %w", err)
+	}
 	if err := bs.initRPCServer(cfg); err != nil {
 		return fmt.Errorf("failed to start RPC server: %w", err)
 	}
@@ -182,6 +221,15 @@ func (bs *BatcherService) initRollupConfig(ctx context.Context) error {
 		return fmt.Errorf("invalid rollup config: %w", err)
 	}
 	bs.RollupConfig.LogDescription(bs.Log, chaincfg.L2ChainIDToNetworkDisplayName)
+	rollupConfigHash, err := bs.RollupConfig.Hash()
+	if err != nil {
+		return err
+	}
+	appinfo.SetRollupConfigHash(rollupConfigHash)
+	// Batches sent to an address with code may revert or be misinterpreted, instead of being derived from.
+	if err := addrcheck.Check(ctx, bs.Log, bs.L1Client, addrcheck.ExpectNoCode("batch inbox", bs.RollupConfig.BatchInboxAddress)); err != nil {
+		return fmt.Errorf("invalid rollup config: %w", err)
+	}
 	return nil
 }
 
@@ -232,6 +280,10 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 	if cc.CompressorConfig.CompressionAlgo.IsBrotli() && !bs.RollupConfig.IsFjord(uint64(time.Now().Unix())) {
 		return errors.New("cannot use brotli compression before Fjord")
 	}
+	// Checking for dictionary compression only post Holocene
+	if cc.CompressorConfig.CompressionAlgo == derive.ZlibDict && !bs.RollupConfig.IsHolocene(uint64(time.Now().Unix())) {
+		return errors.New("cannot use dictionary compression before Holocene")
+	}
 
 	if err := cc.Check(); err != nil {
 		return fmt.Errorf("invalid channel configuration: %w", err)
@@ -273,6 +325,21 @@ func (bs *BatcherService) initTxManager(cfg *CLIConfig) error {
 		return err
 	}
 	bs.TxManager = txManager
+	for i, key := range cfg.KeyRotationPrivateKeys {
+		rotationCfg := cfg.TxMgrConfig
+		rotationCfg.PrivateKey = key
+		rotationCfg.Mnemonic = ""
+		rotationCfg.HDPath = ""
+		rotationCfg.SignerCLIConfig = opsigner.NewCLIConfig()
+		rotationTxManager, err := txmgr.NewSimpleTxManager(fmt.Sprintf("batcher-rotation-%d", i), bs.Log, bs.Metrics, rotationCfg)
+		if err != nil {
+			return fmt.Errorf("failed to init tx manager of key rotation key %d: %w", i, err)
+		}
+		bs.RotationTxManagers = append(bs.RotationTxManagers, rotationTxManager)
+		if !slices.ContainsFunc(bs.RollupConfig.BatcherKeys, func(k rollup.BatcherKey) bool { return k.Address == rotationTxManager.From() }) {
+			return fmt.Errorf("key rotation key %d (%v) is not scheduled in the batcher keys of the rollup config", i, rotationTxManager.From())
+		}
+	}
 	return nil
 }
 
@@ -319,25 +386,67 @@ func (bs *BatcherService) initDriver() {
 		RollupConfig:     bs.RollupConfig,
 		Config:           bs.BatcherConfig,
 		Txmgr:            bs.TxManager,
+		RotationTxmgrs:   bs.RotationTxManagers,
 		L1Client:         bs.L1Client,
 		EndpointProvider: bs.EndpointProvider,
 		ChannelConfig:    bs.ChannelConfig,
 		AltDA:            bs.AltDA,
+		FeeWatcher:       bs.feeWatcher,
 	})
 }
 
+// initFeeWatcher depends on Metrics, L1Client and RollupConfig to watch the fee scalars in the SystemConfig
+// contract and the realized fee margin of the channels, as reported by the channel economics.
+func (bs *BatcherService) initFeeWatcher(cfg *CLIConfig) {
+	if !cfg.FeeWatch.Enabled {
+		return
+	}
+	caller := batching.NewMultiCaller(bs.L1Client.Client(), batching.DefaultBatchSize)
+	sysCfg := feewatch.NewSystemConfigContract(caller, bs.RollupConfig.L1SystemConfigAddress)
+	bs.feeWatcher = feewatch.NewWatcher(bs.Log, bs.Metrics, clock.SystemClock, sysCfg, cfg.FeeWatch.Config())
+	bs.feeWatcher.Start()
+}
+
+func (bs *BatcherService) initFailover(ctx context.Context, cfg *CLIConfig) error {
+	if cfg.FailoverLeaseRpc == "" {
+		return nil
+	}
+	rpc, err := client.NewRPC(ctx, bs.Log, cfg.FailoverLeaseRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial lease RPC: %w", err)
+	}
+	bs.leaseRPC = lease.NewRPCLease(rpc)
+	bs.failover = NewFailover(bs.Log, bs.Metrics, clock.SystemClock, bs.leaseRPC, bs.driver,
+		cfg.FailoverHolderID, cfg.FailoverLeaseTTL, bs.NetworkTimeout)
+	return nil
+}
+
 func (bs *BatcherService) initRPCServer(cfg *CLIConfig) error {
+	adminJWTSecret, err := cfg.RPC.AdminJWTSecret()
+	if err != nil {
+		return err
+	}
 	server := oprpc.NewServer(
 		cfg.RPC.ListenAddr,
 		cfg.RPC.ListenPort,
 		bs.Version,
 		oprpc.WithLogger(bs.Log),
+		oprpc.WithAdminJWTSecret(adminJWTSecret),
 	)
 	if cfg.RPC.EnableAdmin {
-		adminAPI := rpc.NewAdminAPI(bs.driver, bs.Metrics, bs.Log)
+		var driver rpc.BatcherDriver = bs.driver
+		if bs.failover != nil {
+			// In failover mode, batch submission can only be started by the batcher holding the lease
+			driver = bs.failover
+		}
+		adminAPI := rpc.NewAdminAPI(driver, bs.Metrics, bs.Log)
 		server.AddAPI(rpc.GetAdminAPI(adminAPI))
 		server.AddAPI(bs.TxManager.API())
 		bs.Log.Info("Admin RPC enabled")
+		if adminJWTSecret != nil {
+			server.AddAPI(oppprof.GetRuntimeAPI(oppprof.NewRuntimeAPI(bs.Log, cfg.PprofConfig.ProfileDir)))
+			bs.Log.Info("Authenticated admin runtime RPC enabled")
+		}
 	}
 	bs.Log.Info("Starting JSON-RPC server")
 	if err := server.Start(); err != nil {
@@ -362,6 +471,10 @@ func (bs *BatcherService) initAltDA(cfg *CLIConfig) error {
 func (bs *BatcherService) Start(_ context.Context) error {
 	bs.driver.Log.Info("Starting batcher", "notSubmittingOnStart", bs.NotSubmittingOnStart)
 
+	if bs.failover != nil {
+		bs.failover.Start()
+		return nil
+	}
 	if !bs.NotSubmittingOnStart {
 		return bs.driver.StartBatchSubmitting()
 	}
@@ -394,8 +507,19 @@ func (bs *BatcherService) Stop(ctx context.Context) error {
 	if bs.TxManager != nil {
 		bs.TxManager.Close()
 	}
+	for _, m := range bs.RotationTxManagers {
+		m.Close()
+	}
 
 	var result error
+	if bs.failover != nil {
+		if err := bs.failover.Stop(ctx); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to stop failover: %w", err))
+		}
+	}
+	if bs.leaseRPC != nil {
+		bs.leaseRPC.Close()
+	}
 	if bs.driver != nil {
 		if err := bs.driver.StopBatchSubmittingIfRunning(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to stop batch submitting: %w", err))
@@ -413,6 +537,11 @@ func (bs *BatcherService) Stop(ctx context.Context) error {
 			result = errors.Join(result, fmt.Errorf("failed to stop PProf server: %w", err))
 		}
 	}
+	if bs.feeWatcher != nil {
+		if err := bs.feeWatcher.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close fee watcher: %w", err))
+		}
+	}
 	if bs.balanceMetricer != nil {
 		if err := bs.balanceMetricer.Close(); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close balance metricer: %w", err))
@@ -0,0 +1,140 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+var (
+	ErrInjectedBlobRevert   = errors.New("injected failure: blob transaction reverted")
+	ErrInjectedMempoolStall = errors.New("injected failure: transaction dropped from the mempool")
+)
+
+// FailureConfig configures data availability failures injected into the batcher's L1 transaction
+// submission. It is for testing ONLY, to check that the batcher recovers from failed submissions.
+// Each failure is injected once into each of the next transactions it applies to, until its count
+// is used up.
+type FailureConfig struct {
+	// BlobTxReverts is the number of blob transactions that fail as if they reverted. They are not sent.
+	BlobTxReverts uint64
+
+	// MempoolStalls is the number of transactions held for MempoolStallDuration and then dropped,
+	// as if they were stuck in and then evicted from the mempool. They are not sent.
+	MempoolStalls        uint64
+	MempoolStallDuration time.Duration
+
+	// NonceGaps is the number of transactions sent with a skipped nonce, so they can't be included.
+	// They are abandoned after NonceGapTimeout, which resets the nonce and closes the gap.
+	NonceGaps       uint64
+	NonceGapTimeout time.Duration
+}
+
+func (c *FailureConfig) Check() error {
+	if c.NonceGaps > 0 && c.NonceGapTimeout == 0 {
+		return errors.New("NonceGapTimeout must be set to inject nonce gaps")
+	}
+	return nil
+}
+
+type injectedFailure int
+
+const (
+	noFailure injectedFailure = iota
+	blobRevertFailure
+	mempoolStallFailure
+	nonceGapFailure
+)
+
+// failureInjector is a txmgr.TxManager that injects the failures of a FailureConfig
+// before sending transactions through the wrapped transaction manager.
+type failureInjector struct {
+	txmgr.TxManager
+	log log.Logger
+	// keys are the transaction managers of the batcher keys the wrapped transaction manager sends from
+	keys      []*txmgr.SimpleTxManager
+	skipNonce func(ctx context.Context) error
+	cfg       FailureConfig
+
+	mu            sync.Mutex
+	blobReverts   uint64
+	mempoolStalls uint64
+	nonceGaps     uint64
+}
+
+// newFailureInjector wraps the transaction manager, which sends from one of the batcher keys.
+func newFailureInjector(logger log.Logger, txMgr txmgr.TxManager, keys []*txmgr.SimpleTxManager, cfg FailureConfig) *failureInjector {
+	f := &failureInjector{
+		TxManager:     txMgr,
+		log:           logger,
+		keys:          keys,
+		cfg:           cfg,
+		blobReverts:   cfg.BlobTxReverts,
+		mempoolStalls: cfg.MempoolStalls,
+		nonceGaps:     cfg.NonceGaps,
+	}
+	f.skipNonce = f.skipSenderNonce
+	return f
+}
+
+// skipSenderNonce skips a nonce of the key that the next transaction is sent from.
+func (f *failureInjector) skipSenderNonce(ctx context.Context) error {
+	from := f.TxManager.From()
+	for _, key := range f.keys {
+		if key.From() == from {
+			return (&txmgr.TestTxManager{SimpleTxManager: key}).SkipNonce(ctx)
+		}
+	}
+	return fmt.Errorf("no batcher key to skip the nonce of sender %v", from)
+}
+
+// next selects the failure to inject into the candidate, if any.
+func (f *failureInjector) next(candidate txmgr.TxCandidate) injectedFailure {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(candidate.Blobs) > 0 && f.blobReverts > 0 {
+		f.blobReverts--
+		return blobRevertFailure
+	}
+	if f.mempoolStalls > 0 {
+		f.mempoolStalls--
+		return mempoolStallFailure
+	}
+	if f.nonceGaps > 0 {
+		f.nonceGaps--
+		return nonceGapFailure
+	}
+	return noFailure
+}
+
+func (f *failureInjector) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	switch f.next(candidate) {
+	case blobRevertFailure:
+		f.log.Warn("Injecting blob transaction revert", "blobs", len(candidate.Blobs))
+		return nil, ErrInjectedBlobRevert
+	case mempoolStallFailure:
+		f.log.Warn("Injecting mempool stall", "duration", f.cfg.MempoolStallDuration)
+		select {
+		case <-time.After(f.cfg.MempoolStallDuration):
+			return nil, ErrInjectedMempoolStall
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	case nonceGapFailure:
+		f.log.Warn("Injecting nonce gap", "timeout", f.cfg.NonceGapTimeout)
+		if err := f.skipNonce(ctx); err != nil {
+			return nil, fmt.Errorf("failed to inject nonce gap: %w", err)
+		}
+		ctx, cancel := context.WithTimeout(ctx, f.cfg.NonceGapTimeout)
+		defer cancel()
+		return f.TxManager.Send(ctx, candidate)
+	}
+	return f.TxManager.Send(ctx, candidate)
+}
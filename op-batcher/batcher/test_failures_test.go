@@ -0,0 +1,128 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+func TestFailureInjector(t *testing.T) {
+	blobTx := txmgr.TxCandidate{Blobs: []*eth.Blob{{}}}
+	calldataTx := txmgr.TxCandidate{TxData: []byte{1}}
+
+	t.Run("BlobReverts", func(t *testing.T) {
+		f, stub := newTestFailureInjector(t, FailureConfig{BlobTxReverts: 1})
+		_, err := f.Send(context.Background(), calldataTx)
+		require.NoError(t, err)
+		_, err = f.Send(context.Background(), blobTx)
+		require.ErrorIs(t, err, ErrInjectedBlobRevert)
+		_, err = f.Send(context.Background(), blobTx)
+		require.NoError(t, err)
+		require.Equal(t, 2, stub.sent)
+	})
+
+	t.Run("MempoolStalls", func(t *testing.T) {
+		f, stub := newTestFailureInjector(t, FailureConfig{MempoolStalls: 1, MempoolStallDuration: time.Millisecond})
+		_, err := f.Send(context.Background(), calldataTx)
+		require.ErrorIs(t, err, ErrInjectedMempoolStall)
+		_, err = f.Send(context.Background(), calldataTx)
+		require.NoError(t, err)
+		require.Equal(t, 1, stub.sent)
+	})
+
+	t.Run("MempoolStallCancelled", func(t *testing.T) {
+		f, _ := newTestFailureInjector(t, FailureConfig{MempoolStalls: 1, MempoolStallDuration: time.Hour})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := f.Send(ctx, calldataTx)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("NonceGaps", func(t *testing.T) {
+		f, stub := newTestFailureInjector(t, FailureConfig{NonceGaps: 1, NonceGapTimeout: time.Minute})
+		skipped := 0
+		f.skipNonce = func(ctx context.Context) error {
+			skipped++
+			return nil
+		}
+		_, err := f.Send(context.Background(), calldataTx)
+		require.NoError(t, err)
+		_, err = f.Send(context.Background(), calldataTx)
+		require.NoError(t, err)
+		require.Equal(t, 1, skipped)
+		require.Equal(t, 2, stub.sent)
+		// The transaction sent after the nonce gap is abandoned after the timeout
+		require.True(t, stub.deadlines[0])
+		require.False(t, stub.deadlines[1])
+	})
+
+	t.Run("NonceGapFailsToSkip", func(t *testing.T) {
+		f, stub := newTestFailureInjector(t, FailureConfig{NonceGaps: 1, NonceGapTimeout: time.Minute})
+		expectedErr := errors.New("boom")
+		f.skipNonce = func(ctx context.Context) error {
+			return expectedErr
+		}
+		_, err := f.Send(context.Background(), calldataTx)
+		require.ErrorIs(t, err, expectedErr)
+		require.Zero(t, stub.sent)
+	})
+}
+
+func TestFailureInjector_KeyRotation(t *testing.T) {
+	primary := &keyTxManager{from: common.Address{0xaa}}
+	next := &keyTxManager{from: common.Address{0xbb}}
+	rollupCfg := &rollup.Config{
+		BatcherKeys: []rollup.BatcherKey{{Address: next.from, ActivationTime: 1000}},
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+	rotator := newKeyRotator(logger, rollupCfg, time.Minute, primary, []txmgr.TxManager{next})
+	rotator.setL1Head(eth.L1BlockRef{Time: 1000})
+	f := newFailureInjector(logger, rotator, nil, FailureConfig{BlobTxReverts: 1, NonceGaps: 1, NonceGapTimeout: time.Minute})
+
+	// The failures are injected into the transactions of the rotated-to key
+	_, err := f.Send(context.Background(), txmgr.TxCandidate{Blobs: []*eth.Blob{{}}})
+	require.ErrorIs(t, err, ErrInjectedBlobRevert)
+	// The nonce gap is injected into the key the transaction is sent from, which must be known
+	_, err = f.Send(context.Background(), txmgr.TxCandidate{})
+	require.ErrorContains(t, err, next.from.String())
+	_, err = f.Send(context.Background(), txmgr.TxCandidate{})
+	require.NoError(t, err)
+	require.Equal(t, 1, next.sent)
+	require.Zero(t, primary.sent)
+}
+
+func newTestFailureInjector(t *testing.T, cfg FailureConfig) (*failureInjector, *stubTxManager) {
+	stub := &stubTxManager{}
+	return &failureInjector{
+		TxManager:     stub,
+		log:           testlog.Logger(t, log.LevelInfo),
+		cfg:           cfg,
+		blobReverts:   cfg.BlobTxReverts,
+		mempoolStalls: cfg.MempoolStalls,
+		nonceGaps:     cfg.NonceGaps,
+	}, stub
+}
+
+type stubTxManager struct {
+	txmgr.TxManager
+	sent      int
+	deadlines []bool
+}
+
+func (s *stubTxManager) Send(ctx context.Context, _ txmgr.TxCandidate) (*types.Receipt, error) {
+	s.sent++
+	_, hasDeadline := ctx.Deadline()
+	s.deadlines = append(s.deadlines, hasDeadline)
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
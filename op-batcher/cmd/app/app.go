@@ -0,0 +1,29 @@
+package app
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/batcher"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/metrics/doc"
+)
+
+// New creates the op-batcher app, running the batcher with the given version.
+func New(version string) *cli.App {
+	app := cli.NewApp()
+	app.Flags = cliapp.ProtectFlags(flags.Flags)
+	app.Version = version
+	app.Name = "op-batcher"
+	app.Usage = "Batch Submitter Service"
+	app.Description = "Service for generating and submitting L2 tx batches to L1"
+	app.Action = cliapp.LifecycleCmd(batcher.Main(version))
+	app.Commands = []*cli.Command{
+		{
+			Name:        "doc",
+			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
+		},
+	}
+	return app
+}
@@ -4,15 +4,10 @@ import (
 	"context"
 	"os"
 
-	"github.com/urfave/cli/v2"
-
-	"github.com/ethereum-optimism/optimism/op-batcher/batcher"
-	"github.com/ethereum-optimism/optimism/op-batcher/flags"
-	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/cmd/app"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
-	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
-	"github.com/ethereum-optimism/optimism/op-service/metrics/doc"
 	"github.com/ethereum-optimism/optimism/op-service/opio"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -25,23 +20,13 @@ var (
 
 func main() {
 	oplog.SetupDefaults()
+	appinfo.SetBuild(Version, GitCommit, GitDate)
 
-	app := cli.NewApp()
-	app.Flags = cliapp.ProtectFlags(flags.Flags)
-	app.Version = opservice.FormatVersion(Version, GitCommit, GitDate, "")
-	app.Name = "op-batcher"
-	app.Usage = "Batch Submitter Service"
-	app.Description = "Service for generating and submitting L2 tx batches to L1"
-	app.Action = cliapp.LifecycleCmd(batcher.Main(Version))
-	app.Commands = []*cli.Command{
-		{
-			Name:        "doc",
-			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
-		},
-	}
+	batcherApp := app.New(Version)
+	batcherApp.Version = opservice.FormatVersion(Version, GitCommit, GitDate, "")
 
 	ctx := opio.WithInterruptBlocker(context.Background())
-	err := app.RunContext(ctx, os.Args)
+	err := batcherApp.RunContext(ctx, os.Args)
 	if err != nil {
 		log.Crit("Application failed", "message", err)
 	}
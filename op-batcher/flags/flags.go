@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	"github.com/ethereum-optimism/optimism/op-service/feewatch"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -156,6 +157,46 @@ var (
 		Value:   false,
 		EnvVars: prefixEnvVars("WAIT_NODE_SYNC"),
 	}
+	FailoverLeaseRpcFlag = &cli.StringFlag{
+		Name: "failover.lease-rpc",
+		Usage: "URL of a lock RPC serving the lease_acquire and lease_release methods. If set, the batcher runs in " +
+			"active/passive failover mode, and only submits batches while it holds the lease shared with the other batchers.",
+		EnvVars: prefixEnvVars("FAILOVER_LEASE_RPC"),
+	}
+	FailoverLeaseTTLFlag = &cli.DurationFlag{
+		Name:    "failover.lease-ttl",
+		Usage:   "Duration of the failover lease. A standby batcher takes over if the active batcher fails to renew the lease within this duration.",
+		Value:   30 * time.Second,
+		EnvVars: prefixEnvVars("FAILOVER_LEASE_TTL"),
+	}
+	FailoverHolderIDFlag = &cli.StringFlag{
+		Name:    "failover.holder-id",
+		Usage:   "Unique ID of this batcher as holder of the failover lease. Defaults to the hostname.",
+		EnvVars: prefixEnvVars("FAILOVER_HOLDER_ID"),
+	}
+	KeyRotationPrivateKeysFlag = &cli.StringSliceFlag{
+		Name: "key-rotation.private-keys",
+		Usage: "Private keys of the batcher keys scheduled in the rollup config to rotate to, in order of activation. " +
+			"The batcher sends from the most recently rotated-to key that derivation accepts, so it switches keys without downtime.",
+		EnvVars: prefixEnvVars("KEY_ROTATION_PRIVATE_KEYS"),
+	}
+	KeyRotationInclusionMarginFlag = &cli.DurationFlag{
+		Name:    "key-rotation.inclusion-margin",
+		Usage:   "Time within which a batcher transaction is expected to be included. Keys that are deactivated within this margin are avoided.",
+		Value:   time.Minute,
+		EnvVars: prefixEnvVars("KEY_ROTATION_INCLUSION_MARGIN"),
+	}
+	EconomicsEnabledFlag = &cli.BoolFlag{
+		Name:    "economics.enabled",
+		Usage:   "Report the L1 cost, L2 fee revenue and margin of each channel as it completes submission, as logs and metrics. Fetches the receipts of the L2 blocks in each channel.",
+		EnvVars: prefixEnvVars("ECONOMICS_ENABLED"),
+	}
+	EconomicsReportIntervalFlag = &cli.DurationFlag{
+		Name:    "economics.report-interval",
+		Usage:   "Interval of the aggregated channel economics report. 0 disables the report.",
+		Value:   24 * time.Hour,
+		EnvVars: prefixEnvVars("ECONOMICS_REPORT_INTERVAL"),
+	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -184,6 +225,13 @@ var optionalFlags = []cli.Flag{
 	DataAvailabilityTypeFlag,
 	ActiveSequencerCheckDurationFlag,
 	CompressionAlgoFlag,
+	FailoverLeaseRpcFlag,
+	FailoverLeaseTTLFlag,
+	FailoverHolderIDFlag,
+	KeyRotationPrivateKeysFlag,
+	KeyRotationInclusionMarginFlag,
+	EconomicsEnabledFlag,
+	EconomicsReportIntervalFlag,
 }
 
 func init() {
@@ -193,6 +241,7 @@ func init() {
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, altda.CLIFlags(EnvVarPrefix, "")...)
+	optionalFlags = append(optionalFlags, feewatch.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -12,6 +13,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/feewatch"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
@@ -28,6 +30,9 @@ type Metricer interface {
 	// Record Tx metrics
 	txmetrics.TxMetricer
 
+	// Record fee watch metrics
+	feewatch.FeeMetricer
+
 	opmetrics.RPCMetricer
 
 	StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer
@@ -48,6 +53,10 @@ type Metricer interface {
 
 	RecordBlobUsedBytes(num int)
 
+	RecordChannelEconomics(l1Cost, feeRevenue *big.Int)
+
+	RecordFailoverActive(active bool)
+
 	Document() []opmetrics.DocumentedMetric
 }
 
@@ -58,6 +67,7 @@ type Metrics struct {
 
 	opmetrics.RefMetrics
 	txmetrics.TxMetrics
+	feewatch.FeeMetrics
 	opmetrics.RPCMetrics
 
 	info prometheus.GaugeVec
@@ -83,6 +93,13 @@ type Metrics struct {
 	batcherTxEvs opmetrics.EventVec
 
 	blobUsedBytes prometheus.Histogram
+
+	channelL1CostTotal     prometheus.Counter
+	channelFeeRevenueTotal prometheus.Counter
+	channelMargin          prometheus.Gauge
+	channelFeeCoverage     prometheus.Gauge
+
+	failoverActive prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -106,6 +123,7 @@ func NewMetrics(procName string) *Metrics {
 
 		RefMetrics: opmetrics.MakeRefMetrics(ns, factory),
 		TxMetrics:  txmetrics.MakeTxMetrics(ns, factory),
+		FeeMetrics: feewatch.MakeFeeMetrics(ns, factory),
 		RPCMetrics: opmetrics.MakeRPCMetrics(ns, factory),
 
 		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
@@ -193,6 +211,33 @@ func NewMetrics(procName string) *Metrics {
 		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		channelL1CostTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "channel_l1_cost_eth_total",
+			Help:      "Total L1 fees in ETH paid for the batcher transactions of completed channels.",
+		}),
+		channelFeeRevenueTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "channel_fee_revenue_eth_total",
+			Help:      "Total L1 data fees in ETH paid by the L2 transactions of completed channels.",
+		}),
+		channelMargin: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "channel_margin_eth",
+			Help:      "Fee revenue minus L1 cost in ETH of the last completed channel.",
+		}),
+		channelFeeCoverage: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "channel_fee_coverage",
+			Help:      "Fee revenue divided by L1 cost of the last completed channel.",
+		}),
+
+		failoverActive: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "failover_active",
+			Help:      "1 if the batcher holds the failover lease and is the active batcher, 0 if it is on standby",
+		}),
 	}
 }
 
@@ -318,6 +363,17 @@ func (m *Metrics) RecordBlobUsedBytes(num int) {
 	m.blobUsedBytes.Observe(float64(num))
 }
 
+// RecordChannelEconomics records the L1 cost and fee revenue in wei of a completed channel.
+func (m *Metrics) RecordChannelEconomics(l1Cost, feeRevenue *big.Int) {
+	cost, revenue := eth.WeiToEther(l1Cost), eth.WeiToEther(feeRevenue)
+	m.channelL1CostTotal.Add(cost)
+	m.channelFeeRevenueTotal.Add(revenue)
+	m.channelMargin.Set(revenue - cost)
+	if cost > 0 {
+		m.channelFeeCoverage.Set(revenue / cost)
+	}
+}
+
 // estimateBatchSize estimates the size of the batch
 func estimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
@@ -331,3 +387,12 @@ func estimateBatchSize(block *types.Block) uint64 {
 	}
 	return size
 }
+
+// RecordFailoverActive records whether the batcher is the active batcher in failover mode.
+func (m *Metrics) RecordFailoverActive(active bool) {
+	if active {
+		m.failoverActive.Set(1)
+	} else {
+		m.failoverActive.Set(0)
+	}
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	e2eBindings "github.com/ethereum-optimism/optimism/op-e2e/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/bindings"
+	op_service "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+)
+
+var EnvPrefix = "DEPOSIT_TRACER"
+
+var (
+	L1Flag = &cli.StringFlag{
+		Name:    "l1",
+		Usage:   "L1 execution RPC endpoint",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "L1"),
+		Value:   "http://localhost:8545",
+	}
+	L2Flag = &cli.StringFlag{
+		Name:    "l2",
+		Usage:   "L2 execution RPC endpoint, with the debug namespace enabled",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "L2"),
+		Value:   "http://localhost:9545",
+	}
+	PortalFlag = &cli.StringFlag{
+		Name:     "portal",
+		Usage:    "Address of the OptimismPortal proxy on L1",
+		EnvVars:  op_service.PrefixEnvVar(EnvPrefix, "PORTAL"),
+		Required: true,
+	}
+	FromFlag = &cli.StringFlag{
+		Name:     "from",
+		Usage:    "L1 account that sends the deposit",
+		EnvVars:  op_service.PrefixEnvVar(EnvPrefix, "FROM"),
+		Required: true,
+	}
+	ToFlag = &cli.StringFlag{
+		Name:    "to",
+		Usage:   "L2 target of the deposit. Must be empty for contract creations.",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "TO"),
+	}
+	MintFlag = &cli.StringFlag{
+		Name:    "mint",
+		Usage:   "ETH (in wei) sent along with the deposit on L1, and minted on L2",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "MINT"),
+		Value:   "0",
+	}
+	ValueFlag = &cli.StringFlag{
+		Name:    "value",
+		Usage:   "ETH (in wei) transferred to the target on L2",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "VALUE"),
+		Value:   "0",
+	}
+	GasLimitFlag = &cli.Uint64Flag{
+		Name:    "gas-limit",
+		Usage:   "Guaranteed L2 gas of the deposit",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "GAS_LIMIT"),
+		Value:   100_000,
+	}
+	DataFlag = &cli.StringFlag{
+		Name:    "data",
+		Usage:   "Hex-encoded calldata, or init code for contract creations",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "DATA"),
+		Value:   "0x",
+	}
+	CreateFlag = &cli.BoolFlag{
+		Name:    "create",
+		Usage:   "Deposit a contract creation",
+		EnvVars: op_service.PrefixEnvVar(EnvPrefix, "CREATE"),
+	}
+)
+
+func main() {
+	flags := []cli.Flag{
+		L1Flag, L2Flag, PortalFlag, FromFlag, ToFlag, MintFlag, ValueFlag, GasLimitFlag, DataFlag, CreateFlag,
+	}
+	flags = append(flags, oplog.CLIFlags(EnvPrefix)...)
+
+	app := cli.NewApp()
+	app.Name = "deposit-tracer"
+	app.Usage = "Simulate a deposit transaction before sending it."
+	app.Description = "Estimate the L1 cost and guaranteed gas burn of a deposit, and simulate its execution against the current L2 state."
+	app.Flags = cliapp.ProtectFlags(flags)
+	app.Action = mainAction
+	app.Writer = os.Stdout
+	app.ErrWriter = os.Stderr
+	err := app.Run(os.Args)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Application failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type depositArgs struct {
+	from       common.Address
+	to         common.Address
+	mint       *big.Int
+	value      *big.Int
+	gasLimit   uint64
+	data       []byte
+	isCreation bool
+}
+
+func readDepositArgs(c *cli.Context) (*depositArgs, error) {
+	args := &depositArgs{
+		gasLimit:   c.Uint64(GasLimitFlag.Name),
+		isCreation: c.Bool(CreateFlag.Name),
+	}
+	if !common.IsHexAddress(c.String(FromFlag.Name)) {
+		return nil, fmt.Errorf("invalid from address: %q", c.String(FromFlag.Name))
+	}
+	args.from = common.HexToAddress(c.String(FromFlag.Name))
+	if to := c.String(ToFlag.Name); to != "" {
+		if args.isCreation {
+			return nil, errors.New("contract creations can not have a target")
+		}
+		if !common.IsHexAddress(to) {
+			return nil, fmt.Errorf("invalid to address: %q", to)
+		}
+		args.to = common.HexToAddress(to)
+	} else if !args.isCreation {
+		return nil, errors.New("deposits need a target, unless they are contract creations")
+	}
+	var ok bool
+	if args.mint, ok = new(big.Int).SetString(c.String(MintFlag.Name), 10); !ok || args.mint.Sign() < 0 {
+		return nil, fmt.Errorf("invalid mint: %q", c.String(MintFlag.Name))
+	}
+	if args.value, ok = new(big.Int).SetString(c.String(ValueFlag.Name), 10); !ok || args.value.Sign() < 0 {
+		return nil, fmt.Errorf("invalid value: %q", c.String(ValueFlag.Name))
+	}
+	data, err := hexutil.Decode(c.String(DataFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid data: %w", err)
+	}
+	args.data = data
+	return args, nil
+}
+
+func mainAction(c *cli.Context) error {
+	ctx := opio.CancelOnInterrupt(c.Context)
+	logCfg := oplog.ReadCLIConfig(c)
+	logger := oplog.NewLogger(c.App.Writer, logCfg)
+
+	args, err := readDepositArgs(c)
+	if err != nil {
+		return err
+	}
+	if !common.IsHexAddress(c.String(PortalFlag.Name)) {
+		return fmt.Errorf("invalid portal address: %q", c.String(PortalFlag.Name))
+	}
+	portalAddr := common.HexToAddress(c.String(PortalFlag.Name))
+
+	l1, err := ethclient.DialContext(ctx, c.String(L1Flag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1.Close()
+	l2, err := rpc.DialContext(ctx, c.String(L2Flag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to dial L2 RPC: %w", err)
+	}
+	defer l2.Close()
+
+	l2From, err := traceL1(ctx, logger, l1, portalAddr, args)
+	if err != nil {
+		return err
+	}
+	return traceL2(ctx, logger, l2, l2From, args)
+}
+
+// traceL1 estimates the L1 costs of the deposit, and returns the sender of the deposit on L2.
+func traceL1(ctx context.Context, logger log.Logger, l1 *ethclient.Client, portalAddr common.Address, args *depositArgs) (common.Address, error) {
+	portal, err := bindings.NewOptimismPortalCaller(portalAddr, l1)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to bind OptimismPortal: %w", err)
+	}
+	head, err := l1.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get L1 head: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: head.Number}
+
+	minGasLimit, err := portal.MinimumGasLimit(opts, uint64(len(args.data)))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get minimum gas limit: %w", err)
+	}
+	if args.gasLimit < minGasLimit {
+		return common.Address{}, fmt.Errorf("gas limit %d is below the minimum of %d for %d bytes of data", args.gasLimit, minGasLimit, len(args.data))
+	}
+	p, err := portal.Params(opts)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get resource metering params: %w", err)
+	}
+	sysCfgAddr, err := portal.SystemConfig(opts)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get SystemConfig address: %w", err)
+	}
+	sysCfg, err := e2eBindings.NewSystemConfigCaller(sysCfgAddr, l1)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to bind SystemConfig: %w", err)
+	}
+	resourceCfg, err := sysCfg.ResourceConfig(opts)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get resource config: %w", err)
+	}
+	// Assume the deposit is included in the next L1 block, at the current base fee
+	metered, err := meterDeposit(resourceParams{
+		PrevBaseFee:   p.PrevBaseFee,
+		PrevBoughtGas: p.PrevBoughtGas,
+		PrevBlockNum:  p.PrevBlockNum,
+	}, resourceCfg, head.Number.Uint64()+1, head.BaseFee, args.gasLimit)
+	if err != nil {
+		return common.Address{}, err
+	}
+	logger.Info("Guaranteed gas",
+		"gasLimit", args.gasLimit,
+		"baseFee", metered.BaseFee,
+		"resourceCost", metered.ResourceCost,
+		"l1GasBurn", metered.GasBurn)
+
+	portalABI, err := bindings.OptimismPortalMetaData.GetAbi()
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to load OptimismPortal ABI: %w", err)
+	}
+	data, err := portalABI.Pack("depositTransaction", args.to, args.value, args.gasLimit, args.isCreation, args.data)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to pack deposit: %w", err)
+	}
+	l1Gas, err := l1.EstimateGas(ctx, ethereum.CallMsg{
+		From:  args.from,
+		To:    &portalAddr,
+		Value: args.mint,
+		Data:  data,
+	})
+	if err != nil {
+		// Still simulate the L2 side, e.g. if the sender is not funded yet
+		logger.Warn("Failed to estimate L1 gas of the deposit", "err", err)
+	} else {
+		tip, err := l1.SuggestGasTipCap(ctx)
+		if err != nil {
+			return common.Address{}, fmt.Errorf("failed to get L1 tip suggestion: %w", err)
+		}
+		gasPrice := new(big.Int).Add(head.BaseFee, tip)
+		logger.Info("L1 cost",
+			"gas", l1Gas,
+			"gasPrice", gasPrice,
+			"fee", new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(l1Gas)),
+			"total", new(big.Int).Add(new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(l1Gas)), args.mint))
+	}
+
+	// The portal aliases the sender if the deposit is not sent by an EOA
+	code, err := l1.CodeAt(ctx, args.from, head.Number)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to get code of sender: %w", err)
+	}
+	if len(code) > 0 {
+		aliased := crossdomain.ApplyL1ToL2Alias(args.from)
+		logger.Info("Sender is a contract, the L2 sender is aliased", "from", args.from, "aliased", aliased)
+		return aliased, nil
+	}
+	return args.from, nil
+}
+
+// traceL2 simulates the deposit against the latest L2 state.
+func traceL2(ctx context.Context, logger log.Logger, l2 *rpc.Client, from common.Address, args *depositArgs) error {
+	head, err := ethclient.NewClient(l2).HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get L2 head: %w", err)
+	}
+	conf, err := fetchChainConfig(ctx, l2)
+	if err != nil {
+		return fmt.Errorf("failed to get chain config: %w", err)
+	}
+	dep := &types.DepositTx{
+		From:  from,
+		Mint:  args.mint,
+		Value: args.value,
+		Gas:   args.gasLimit,
+		Data:  args.data,
+	}
+	if !args.isCreation {
+		to := args.to
+		dep.To = &to
+	}
+	prestate, err := fetchPrestate(ctx, l2, head, dep)
+	if err != nil {
+		return err
+	}
+	res, err := simulateDeposit(conf, head, prestate, dep)
+	if err != nil {
+		return err
+	}
+	if res.Status == types.ReceiptStatusSuccessful {
+		logger.Info("Deposit succeeds on L2", "l2Block", head.Number, "gasUsed", res.GasUsed, "logs", len(res.Logs), "returnData", hexutil.Bytes(res.ReturnData))
+	} else {
+		// Failed deposits still mint, and still increment the nonce of the sender
+		logger.Warn("Deposit fails on L2", "l2Block", head.Number, "gasUsed", res.GasUsed, "err", res.Err, "revertData", hexutil.Bytes(res.ReturnData))
+	}
+	for i, l := range res.Logs {
+		logger.Info("Log", "index", i, "address", l.Address, "topics", l.Topics, "data", hexutil.Bytes(l.Data))
+	}
+	return nil
+}
+
+func fetchChainConfig(ctx context.Context, cl *rpc.Client) (*params.ChainConfig, error) {
+	var idResult hexutil.Big
+	if err := cl.CallContext(ctx, &idResult, "eth_chainId"); err != nil {
+		return nil, fmt.Errorf("failed to retrieve chain ID: %w", err)
+	}
+	id := (*big.Int)(&idResult)
+	if id.IsUint64() {
+		cfg, err := params.LoadOPStackChainConfig(id.Uint64())
+		if err == nil {
+			return cfg, nil
+		}
+	}
+	var config params.ChainConfig
+	if err := cl.CallContext(ctx, &config, "eth_chainConfig"); err != nil {
+		return nil, fmt.Errorf("failed to retrieve chain config: %w", err)
+	}
+	return &config, nil
+}
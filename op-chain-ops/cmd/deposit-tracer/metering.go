@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+)
+
+var ErrResourceLimit = errors.New("deposit exceeds the resource limit of the L1 block")
+
+// resourceParams mirrors the params of the ResourceMetering contract of the OptimismPortal.
+type resourceParams struct {
+	PrevBaseFee   *big.Int
+	PrevBoughtGas uint64
+	PrevBlockNum  uint64
+}
+
+// meteringResult describes the cost of buying guaranteed L2 gas with a deposit.
+type meteringResult struct {
+	// BaseFee is the deposit base fee, in wei per unit of guaranteed gas.
+	BaseFee *big.Int
+	// ResourceCost is the ETH paid for the guaranteed gas.
+	ResourceCost *big.Int
+	// GasBurn is the L1 gas burned to pay the resource cost,
+	// before the refund of the gas used by the deposit itself.
+	GasBurn *big.Int
+}
+
+// meterDeposit replicates the ResourceMetering logic of the OptimismPortal for a deposit of gasLimit,
+// included in an L1 block with the given number and base fee.
+// The compounding of the base fee over empty blocks is approximated with floating point math.
+func meterDeposit(p resourceParams, cfg bindings.ResourceMeteringResourceConfig, blockNum uint64, l1BaseFee *big.Int, gasLimit uint64) (meteringResult, error) {
+	baseFee := new(big.Int).Set(p.PrevBaseFee)
+	boughtGas := p.PrevBoughtGas
+	minBaseFee := new(big.Int).SetUint64(uint64(cfg.MinimumBaseFee))
+	maxBaseFee := cfg.MaximumBaseFee
+	if blockNum > p.PrevBlockNum {
+		blockDiff := blockNum - p.PrevBlockNum
+		target := big.NewInt(int64(cfg.MaxResourceLimit) / int64(cfg.ElasticityMultiplier))
+		denominator := big.NewInt(int64(cfg.BaseFeeMaxChangeDenominator))
+
+		gasUsedDelta := new(big.Int).Sub(new(big.Int).SetUint64(p.PrevBoughtGas), target)
+		baseFeeDelta := new(big.Int).Mul(p.PrevBaseFee, gasUsedDelta)
+		baseFeeDelta.Quo(baseFeeDelta, new(big.Int).Mul(target, denominator))
+		baseFee = clamp(baseFee.Add(baseFee, baseFeeDelta), minBaseFee, maxBaseFee)
+
+		// Every skipped block is an empty block, without demand for deposits
+		if blockDiff > 1 {
+			decay := math.Pow(1-1/float64(cfg.BaseFeeMaxChangeDenominator), float64(blockDiff-1))
+			baseFee, _ = new(big.Float).Mul(new(big.Float).SetInt(baseFee), big.NewFloat(decay)).Int(nil)
+			baseFee = clamp(baseFee, minBaseFee, maxBaseFee)
+		}
+		boughtGas = 0
+	}
+	boughtGas += gasLimit
+	if boughtGas > uint64(cfg.MaxResourceLimit) {
+		return meteringResult{}, ErrResourceLimit
+	}
+	resourceCost := new(big.Int).Mul(new(big.Int).SetUint64(gasLimit), baseFee)
+	// The contract assumes a minimum L1 base fee of 1 gwei
+	divisor := l1BaseFee
+	if divisor.Cmp(big.NewInt(params.GWei)) < 0 {
+		divisor = big.NewInt(params.GWei)
+	}
+	return meteringResult{
+		BaseFee:      baseFee,
+		ResourceCost: resourceCost,
+		GasBurn:      new(big.Int).Quo(resourceCost, divisor),
+	}, nil
+}
+
+func clamp(v *big.Int, lo *big.Int, hi *big.Int) *big.Int {
+	if v.Cmp(lo) < 0 {
+		return new(big.Int).Set(lo)
+	}
+	if v.Cmp(hi) > 0 {
+		return new(big.Int).Set(hi)
+	}
+	return v
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/bindings"
+)
+
+var testResourceConfig = bindings.ResourceMeteringResourceConfig{
+	MaxResourceLimit:            20_000_000,
+	ElasticityMultiplier:        10,
+	BaseFeeMaxChangeDenominator: 8,
+	MinimumBaseFee:              params.GWei,
+	SystemTxMaxGas:              1_000_000,
+	MaximumBaseFee:              new(big.Int).Sub(new(big.Int).Lsh(common.Big1, 128), common.Big1),
+}
+
+func TestMeterDeposit(t *testing.T) {
+	l1BaseFee := big.NewInt(10 * params.GWei)
+
+	t.Run("SameBlock", func(t *testing.T) {
+		p := resourceParams{PrevBaseFee: big.NewInt(2 * params.GWei), PrevBoughtGas: 1_000_000, PrevBlockNum: 100}
+		res, err := meterDeposit(p, testResourceConfig, 100, l1BaseFee, 100_000)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(2*params.GWei), res.BaseFee)
+		require.Equal(t, new(big.Int).Mul(big.NewInt(100_000), big.NewInt(2*params.GWei)), res.ResourceCost)
+		require.Equal(t, big.NewInt(20_000), res.GasBurn)
+	})
+
+	t.Run("AtTarget", func(t *testing.T) {
+		p := resourceParams{PrevBaseFee: big.NewInt(2 * params.GWei), PrevBoughtGas: 2_000_000, PrevBlockNum: 100}
+		res, err := meterDeposit(p, testResourceConfig, 101, l1BaseFee, 100_000)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(2*params.GWei), res.BaseFee)
+	})
+
+	t.Run("AboveTarget", func(t *testing.T) {
+		p := resourceParams{PrevBaseFee: big.NewInt(8 * params.GWei), PrevBoughtGas: 4_000_000, PrevBlockNum: 100}
+		res, err := meterDeposit(p, testResourceConfig, 101, l1BaseFee, 100_000)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(9*params.GWei), res.BaseFee)
+	})
+
+	t.Run("EmptyBlocksDecayToMinimum", func(t *testing.T) {
+		p := resourceParams{PrevBaseFee: big.NewInt(8 * params.GWei), PrevBoughtGas: 2_000_000, PrevBlockNum: 100}
+		res, err := meterDeposit(p, testResourceConfig, 1000, l1BaseFee, 100_000)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(params.GWei), res.BaseFee)
+	})
+
+	t.Run("MinimumL1BaseFee", func(t *testing.T) {
+		p := resourceParams{PrevBaseFee: big.NewInt(params.GWei), PrevBoughtGas: 0, PrevBlockNum: 100}
+		res, err := meterDeposit(p, testResourceConfig, 100, big.NewInt(1), 100_000)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(100_000), res.GasBurn)
+	})
+
+	t.Run("ResourceLimit", func(t *testing.T) {
+		p := resourceParams{PrevBaseFee: big.NewInt(params.GWei), PrevBoughtGas: 19_950_000, PrevBlockNum: 100}
+		_, err := meterDeposit(p, testResourceConfig, 100, l1BaseFee, 100_000)
+		require.ErrorIs(t, err, ErrResourceLimit)
+		// The bought gas resets in a new block
+		_, err = meterDeposit(p, testResourceConfig, 101, l1BaseFee, 100_000)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	gstate "github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+type DumpAccount struct {
+	Balance hexutil.Big                 `json:"balance"`
+	Nonce   uint64                      `json:"nonce"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+type callArgs struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to,omitempty"`
+	Gas   hexutil.Uint64  `json:"gas"`
+	Value *hexutil.Big    `json:"value"`
+	Data  hexutil.Bytes   `json:"data"`
+}
+
+type traceCallConfig struct {
+	Tracer         string                             `json:"tracer"`
+	StateOverrides map[common.Address]accountOverride `json:"stateOverrides,omitempty"`
+}
+
+type accountOverride struct {
+	Balance *hexutil.Big `json:"balance,omitempty"`
+}
+
+// simulationResult is the L2 outcome of the deposit.
+type simulationResult struct {
+	Status     uint64
+	GasUsed    uint64
+	Logs       []*types.Log
+	ReturnData []byte
+	Err        error
+}
+
+// fetchPrestate forks the L2 state at the given block, by tracing a call equivalent to the deposit
+// to collect all the accounts and storage slots it touches.
+func fetchPrestate(ctx context.Context, cl *rpc.Client, head *types.Header, dep *types.DepositTx) (map[common.Address]DumpAccount, error) {
+	balance, err := ethclient.NewClient(cl).BalanceAt(ctx, dep.From, head.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance of %s: %w", dep.From, err)
+	}
+	// The deposit mints before executing, so the call must be able to afford the value
+	minted := new(big.Int).Add(balance, dep.Mint)
+	var result map[common.Address]DumpAccount
+	if err := cl.CallContext(ctx, &result, "debug_traceCall", callArgs{
+		From:  dep.From,
+		To:    dep.To,
+		Gas:   hexutil.Uint64(dep.Gas),
+		Value: (*hexutil.Big)(dep.Value),
+		Data:  dep.Data,
+	}, hexutil.EncodeBig(head.Number), traceCallConfig{
+		Tracer: "prestateTracer",
+		StateOverrides: map[common.Address]accountOverride{
+			dep.From: {Balance: (*hexutil.Big)(minted)},
+		},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to retrieve prestate trace: %w", err)
+	}
+	// Undo the balance override, the deposit mints by itself
+	if acc, ok := result[dep.From]; ok {
+		acc.Balance = hexutil.Big(*balance)
+		result[dep.From] = acc
+	} else {
+		result[dep.From] = DumpAccount{Balance: hexutil.Big(*balance)}
+	}
+	return result, nil
+}
+
+type simChainContext struct {
+	eng  consensus.Engine
+	head *types.Header
+}
+
+func (d *simChainContext) Engine() consensus.Engine {
+	return d.eng
+}
+
+func (d *simChainContext) GetHeader(h common.Hash, n uint64) *types.Header {
+	if n == d.head.Number.Uint64() {
+		return d.head
+	}
+	panic(fmt.Errorf("header retrieval not supported, cannot fetch %s %d", h, n))
+}
+
+// simulateDeposit executes the deposit in a new block on top of head, with the given prestate.
+func simulateDeposit(conf *params.ChainConfig, head *types.Header, prestate map[common.Address]DumpAccount, dep *types.DepositTx) (*simulationResult, error) {
+	state, err := gstate.New(types.EmptyRootHash, gstate.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-memory state: %w", err)
+	}
+	for addr, acc := range prestate {
+		state.CreateAccount(addr)
+		state.SetBalance(addr, uint256.MustFromBig((*big.Int)(&acc.Balance)), tracing.BalanceChangeUnspecified)
+		state.SetNonce(addr, acc.Nonce)
+		state.SetCode(addr, acc.Code)
+		state.SetStorage(addr, acc.Storage)
+	}
+
+	header := &types.Header{
+		ParentHash: head.Hash(),
+		Coinbase:   head.Coinbase,
+		Number:     new(big.Int).Add(head.Number, common.Big1),
+		GasLimit:   head.GasLimit,
+		Time:       head.Time,
+		BaseFee:    head.BaseFee,
+		Difficulty: common.Big0,
+		MixDigest:  head.MixDigest,
+	}
+	tx := types.NewTx(dep)
+	msg, err := core.TransactionToMessage(tx, types.MakeSigner(conf, header.Number, header.Time), header.BaseFee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert deposit to message: %w", err)
+	}
+	rules := conf.Rules(header.Number, true, header.Time)
+	state.Prepare(rules, msg.From, header.Coinbase, msg.To, vm.ActivePrecompiles(rules), nil)
+	state.SetTxContext(tx.Hash(), 0)
+
+	cCtx := &simChainContext{eng: beacon.NewFaker(), head: head}
+	blockCtx := core.NewEVMBlockContext(header, cCtx, &header.Coinbase, conf, state)
+	evm := vm.NewEVM(blockCtx, core.NewEVMTxContext(msg), state, conf, vm.Config{})
+	gp := core.GasPool(header.GasLimit)
+	res, err := core.ApplyMessage(evm, msg, &gp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply deposit: %w", err)
+	}
+	out := &simulationResult{
+		Status:     types.ReceiptStatusSuccessful,
+		GasUsed:    res.UsedGas,
+		Logs:       state.GetLogs(tx.Hash(), header.Number.Uint64(), common.Hash{}),
+		ReturnData: res.ReturnData,
+		Err:        res.Err,
+	}
+	if res.Failed() {
+		out.Status = types.ReceiptStatusFailed
+		out.ReturnData = res.Revert()
+	}
+	return out, nil
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/mattn/go-isatty"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/storagediff"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var (
+	PreAllocsFlag = &cli.PathFlag{
+		Name:  "pre",
+		Usage: "Path to the forge allocs state dump before the upgrade",
+	}
+	PostAllocsFlag = &cli.PathFlag{
+		Name:  "post",
+		Usage: "Path to the forge allocs state dump after the upgrade",
+	}
+	RPCFlag = &cli.StringFlag{
+		Name:  "rpc",
+		Usage: "L2 RPC to read the pre and post upgrade state from, instead of state dumps. Only the slots of known storage variables are compared.",
+	}
+	PreBlockFlag = &cli.Uint64Flag{
+		Name:  "pre-block",
+		Usage: "L2 block number to read the pre upgrade state at, with --rpc",
+	}
+	PostBlockFlag = &cli.Uint64Flag{
+		Name:  "post-block",
+		Usage: "L2 block number to read the post upgrade state at, with --rpc. Defaults to the latest block.",
+	}
+	ArtifactsFlag = &cli.PathFlag{
+		Name:  "artifacts",
+		Usage: "Path to the forge-artifacts directory to read the predeploy storage layouts from. Slots are reported raw if not set.",
+	}
+	AllAccountsFlag = &cli.BoolFlag{
+		Name:  "all",
+		Usage: "Compare all accounts in the state dumps, not only the predeploys",
+	}
+)
+
+func main() {
+	color := isatty.IsTerminal(os.Stderr.Fd())
+	oplog.SetGlobalLogHandler(log.NewTerminalHandler(os.Stderr, color))
+
+	app := &cli.App{
+		Name:  "storage-diff",
+		Usage: "Report the storage changes of L2 predeploys between the pre and post upgrade state",
+		Flags: []cli.Flag{
+			PreAllocsFlag,
+			PostAllocsFlag,
+			RPCFlag,
+			PreBlockFlag,
+			PostBlockFlag,
+			ArtifactsFlag,
+			AllAccountsFlag,
+		},
+		Action: entrypoint,
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("error diffing storage", "err", err)
+	}
+}
+
+func entrypoint(ctx *cli.Context) error {
+	var artifacts *foundry.ArtifactsFS
+	if ctx.IsSet(ArtifactsFlag.Name) {
+		artifacts = foundry.OpenArtifactsDir(ctx.Path(ArtifactsFlag.Name))
+	}
+	contracts, err := storagediff.PredeployContracts(artifacts)
+	if err != nil {
+		return err
+	}
+
+	var pre, post types.GenesisAlloc
+	switch {
+	case ctx.IsSet(RPCFlag.Name):
+		if ctx.Bool(AllAccountsFlag.Name) {
+			return errors.New("cannot compare all accounts over RPC")
+		}
+		if !ctx.IsSet(PreBlockFlag.Name) {
+			return fmt.Errorf("--%s is required with --%s", PreBlockFlag.Name, RPCFlag.Name)
+		}
+		client, err := ethclient.DialContext(ctx.Context, ctx.String(RPCFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to dial L2 RPC: %w", err)
+		}
+		defer client.Close()
+		pre, err = storagediff.FetchState(ctx.Context, client, new(big.Int).SetUint64(ctx.Uint64(PreBlockFlag.Name)), contracts)
+		if err != nil {
+			return fmt.Errorf("failed to read pre upgrade state: %w", err)
+		}
+		var postBlock *big.Int
+		if ctx.IsSet(PostBlockFlag.Name) {
+			postBlock = new(big.Int).SetUint64(ctx.Uint64(PostBlockFlag.Name))
+		}
+		post, err = storagediff.FetchState(ctx.Context, client, postBlock, contracts)
+		if err != nil {
+			return fmt.Errorf("failed to read post upgrade state: %w", err)
+		}
+	case ctx.IsSet(PreAllocsFlag.Name) && ctx.IsSet(PostAllocsFlag.Name):
+		preAllocs, err := foundry.LoadForgeAllocs(ctx.Path(PreAllocsFlag.Name))
+		if err != nil {
+			return err
+		}
+		postAllocs, err := foundry.LoadForgeAllocs(ctx.Path(PostAllocsFlag.Name))
+		if err != nil {
+			return err
+		}
+		pre, post = preAllocs.Accounts, postAllocs.Accounts
+		if ctx.Bool(AllAccountsFlag.Name) {
+			contracts = allContracts(pre, post, contracts)
+		}
+	default:
+		return fmt.Errorf("either --%s and --%s, or --%s must be set", PreAllocsFlag.Name, PostAllocsFlag.Name, RPCFlag.Name)
+	}
+
+	return storagediff.Format(ctx.App.Writer, storagediff.Diff(pre, post, contracts))
+}
+
+// allContracts extends the predeploys with all other accounts of the states.
+func allContracts(pre, post types.GenesisAlloc, predeploys map[common.Address]storagediff.Contract) map[common.Address]storagediff.Contract {
+	out := make(map[common.Address]storagediff.Contract, len(pre))
+	for _, state := range []types.GenesisAlloc{pre, post} {
+		for addr := range state {
+			out[addr] = predeploys[addr]
+		}
+	}
+	return out
+}
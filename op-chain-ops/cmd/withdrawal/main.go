@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+
+	op_service "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Name = "withdrawal"
+	app.Usage = "Prove and finalize L2 withdrawals on L1."
+	app.Description = "Prove and finalize L2 withdrawals on L1, against the L2OutputOracle or the dispute games of fault proofs."
+	app.Action = func(c *cli.Context) error {
+		return errors.New("see sub-commands")
+	}
+	app.Writer = os.Stdout
+	app.ErrWriter = os.Stderr
+	app.Commands = []*cli.Command{
+		makeCommand("prove", "Prove the withdrawal, once an output that includes it has been proposed", prove),
+		makeCommand("finalize", "Finalize the proven withdrawal, once the proof has matured", finalize),
+		makeCommand("relay", "Prove and then finalize the withdrawal", relay),
+	}
+
+	err := app.Run(os.Args)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Application failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type relayAction func(ctx context.Context, r *relayer, txHash common.Hash) error
+
+var (
+	prefix     = "WITHDRAWAL"
+	EndpointL2 = &cli.StringFlag{
+		Name:    "l2",
+		Usage:   "L2 execution RPC endpoint",
+		EnvVars: op_service.PrefixEnvVar(prefix, "L2"),
+		Value:   "http://localhost:9545",
+	}
+	PortalAddress = &cli.StringFlag{
+		Name:     "portal",
+		Usage:    "Address of the OptimismPortal proxy on L1",
+		EnvVars:  op_service.PrefixEnvVar(prefix, "PORTAL"),
+		Required: true,
+	}
+	TxHash = &cli.StringFlag{
+		Name:     "tx",
+		Usage:    "Hash of the L2 transaction that initiated the withdrawal",
+		EnvVars:  op_service.PrefixEnvVar(prefix, "TX"),
+		Required: true,
+	}
+	PollInterval = &cli.DurationFlag{
+		Name:    "poll-interval",
+		Usage:   "Interval at which to check if the withdrawal can be proven or finalized",
+		EnvVars: op_service.PrefixEnvVar(prefix, "POLL_INTERVAL"),
+		Value:   12 * time.Second,
+	}
+)
+
+func makeFlags() []cli.Flag {
+	flags := []cli.Flag{
+		EndpointL2,
+		PortalAddress,
+		TxHash,
+		PollInterval,
+	}
+	flags = append(flags, txmgr.CLIFlagsWithDefaults(prefix, txmgr.DefaultChallengerFlagValues)...)
+	return append(flags, oplog.CLIFlags(prefix)...)
+}
+
+func makeCommand(name string, usage string, fn relayAction) *cli.Command {
+	return &cli.Command{
+		Name:   name,
+		Usage:  usage,
+		Action: makeCommandAction(fn),
+		Flags:  cliapp.ProtectFlags(makeFlags()),
+	}
+}
+
+func makeCommandAction(fn relayAction) func(c *cli.Context) error {
+	return func(c *cli.Context) error {
+		logCfg := oplog.ReadCLIConfig(c)
+		logger := oplog.NewLogger(c.App.Writer, logCfg)
+
+		c.Context = opio.CancelOnInterrupt(c.Context)
+		if !common.IsHexAddress(c.String(PortalAddress.Name)) {
+			return fmt.Errorf("invalid portal address: %q", c.String(PortalAddress.Name))
+		}
+		portalAddr := common.HexToAddress(c.String(PortalAddress.Name))
+		var txHash common.Hash
+		if err := txHash.UnmarshalText([]byte(c.String(TxHash.Name))); err != nil {
+			return fmt.Errorf("invalid withdrawal tx hash: %w", err)
+		}
+
+		txMgrCfg := txmgr.ReadCLIConfig(c)
+		if err := txMgrCfg.Check(); err != nil {
+			return fmt.Errorf("invalid tx manager config: %w", err)
+		}
+		l1Cl, err := ethclient.DialContext(c.Context, txMgrCfg.L1RPCURL)
+		if err != nil {
+			return fmt.Errorf("failed to dial L1 RPC: %w", err)
+		}
+		defer l1Cl.Close()
+		l2Cl, err := ethclient.DialContext(c.Context, c.String(EndpointL2.Name))
+		if err != nil {
+			return fmt.Errorf("failed to dial L2 RPC: %w", err)
+		}
+		defer l2Cl.Close()
+		txMgr, err := txmgr.NewSimpleTxManager("withdrawal", logger, &metrics.NoopTxMetrics{}, txMgrCfg)
+		if err != nil {
+			return fmt.Errorf("failed to create tx manager: %w", err)
+		}
+		defer txMgr.Close()
+
+		r, err := newRelayer(logger, l1Cl, l2Cl, txMgr, portalAddr, c.Duration(PollInterval.Name))
+		if err != nil {
+			return err
+		}
+		if err := fn(c.Context, r, txHash); err != nil {
+			return fmt.Errorf("command error: %w", err)
+		}
+		return nil
+	}
+}
+
+func prove(ctx context.Context, r *relayer, txHash common.Hash) error {
+	_, err := r.Prove(ctx, txHash)
+	return err
+}
+
+func finalize(ctx context.Context, r *relayer, txHash common.Hash) error {
+	return r.Finalize(ctx, txHash)
+}
+
+func relay(ctx context.Context, r *relayer, txHash common.Hash) error {
+	if _, err := r.Prove(ctx, txHash); err != nil {
+		return err
+	}
+	return r.Finalize(ctx, txHash)
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/gethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/crossdomain"
+	"github.com/ethereum-optimism/optimism/op-node/bindings"
+	bindingspreview "github.com/ethereum-optimism/optimism/op-node/bindings/preview"
+	"github.com/ethereum-optimism/optimism/op-node/withdrawals"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// relayer proves and finalizes a withdrawal on L1, against either the L2OutputOracle
+// or, with fault proofs, the dispute games of the DisputeGameFactory.
+type relayer struct {
+	log          log.Logger
+	l1           *ethclient.Client
+	l2           *ethclient.Client
+	txMgr        txmgr.TxManager
+	pollInterval time.Duration
+
+	portalAddr common.Address
+	portalABI  *abi.ABI
+	// portal2 is set if the portal uses fault proofs, and oracle otherwise
+	portal2 *bindingspreview.OptimismPortal2Caller
+	factory *bindings.DisputeGameFactoryCaller
+	portal  *bindings.OptimismPortalCaller
+	oracle  *bindings.L2OutputOracleCaller
+}
+
+func newRelayer(logger log.Logger, l1 *ethclient.Client, l2 *ethclient.Client, txMgr txmgr.TxManager, portalAddr common.Address, pollInterval time.Duration) (*relayer, error) {
+	r := &relayer{
+		log:          logger,
+		l1:           l1,
+		l2:           l2,
+		txMgr:        txMgr,
+		pollInterval: pollInterval,
+		portalAddr:   portalAddr,
+	}
+	portal2, err := bindingspreview.NewOptimismPortal2Caller(portalAddr, l1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind OptimismPortal2: %w", err)
+	}
+	// Only the fault proof portal has a dispute game factory
+	if factoryAddr, err := portal2.DisputeGameFactory(&bind.CallOpts{}); err == nil {
+		logger.Info("Using fault proofs", "factory", factoryAddr)
+		r.portal2 = portal2
+		r.factory, err = bindings.NewDisputeGameFactoryCaller(factoryAddr, l1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bind DisputeGameFactory: %w", err)
+		}
+		r.portalABI, err = bindingspreview.OptimismPortal2MetaData.GetAbi()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load OptimismPortal2 ABI: %w", err)
+		}
+		return r, nil
+	}
+	r.portal, err = bindings.NewOptimismPortalCaller(portalAddr, l1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind OptimismPortal: %w", err)
+	}
+	oracleAddr, err := r.portal.L2Oracle(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load L2OutputOracle address: %w", err)
+	}
+	logger.Info("Using output oracle", "oracle", oracleAddr)
+	r.oracle, err = bindings.NewL2OutputOracleCaller(oracleAddr, l1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind L2OutputOracle: %w", err)
+	}
+	r.portalABI, err = bindings.OptimismPortalMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OptimismPortal ABI: %w", err)
+	}
+	return r, nil
+}
+
+// Prove waits for an output that includes the withdrawal to be proposed, and proves the withdrawal against it.
+func (r *relayer) Prove(ctx context.Context, txHash common.Hash) (withdrawals.ProvenWithdrawalParameters, error) {
+	receipt, err := r.l2.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return withdrawals.ProvenWithdrawalParameters{}, fmt.Errorf("failed to get withdrawal receipt: %w", err)
+	}
+	r.log.Info("Waiting for output proposal", "l2Block", receipt.BlockNumber)
+	proofCl := gethclient.New(r.l2.Client())
+	var params withdrawals.ProvenWithdrawalParameters
+	if r.portal2 != nil {
+		err = r.waitFor(ctx, func() (bool, error) {
+			game, err := withdrawals.FindLatestGame(ctx, r.factory, r.portal2)
+			if err != nil {
+				r.log.Debug("No game found yet", "err", err)
+				return false, nil
+			}
+			l2Block := new(big.Int).SetBytes(game.ExtraData[0:32])
+			return l2Block.Cmp(receipt.BlockNumber) >= 0, nil
+		})
+		if err != nil {
+			return withdrawals.ProvenWithdrawalParameters{}, err
+		}
+		params, err = withdrawals.ProveWithdrawalParametersFaultProofs(ctx, proofCl, r.l2, r.l2, txHash, r.factory, r.portal2)
+	} else {
+		err = r.waitFor(ctx, func() (bool, error) {
+			latest, err := r.oracle.LatestBlockNumber(&bind.CallOpts{Context: ctx})
+			if err != nil {
+				return false, fmt.Errorf("failed to get latest proposed block: %w", err)
+			}
+			return latest.Cmp(receipt.BlockNumber) >= 0, nil
+		})
+		if err != nil {
+			return withdrawals.ProvenWithdrawalParameters{}, err
+		}
+		var output bindings.TypesOutputProposal
+		output, err = r.oracle.GetL2OutputAfter(&bind.CallOpts{Context: ctx}, receipt.BlockNumber)
+		if err != nil {
+			return withdrawals.ProvenWithdrawalParameters{}, fmt.Errorf("failed to get output proposal: %w", err)
+		}
+		var outputIndex *big.Int
+		outputIndex, err = r.oracle.GetL2OutputIndexAfter(&bind.CallOpts{Context: ctx}, receipt.BlockNumber)
+		if err != nil {
+			return withdrawals.ProvenWithdrawalParameters{}, fmt.Errorf("failed to get output index: %w", err)
+		}
+		params, err = withdrawals.ProveWithdrawalParametersForBlock(ctx, proofCl, r.l2, r.l2, txHash, output.L2BlockNumber, outputIndex)
+	}
+	if err != nil {
+		return withdrawals.ProvenWithdrawalParameters{}, fmt.Errorf("failed to generate withdrawal proof: %w", err)
+	}
+
+	data, err := r.portalABI.Pack("proveWithdrawalTransaction",
+		toWithdrawal(params).WithdrawalTransaction(),
+		params.L2OutputIndex,
+		params.OutputRootProof,
+		params.WithdrawalProof)
+	if err != nil {
+		return withdrawals.ProvenWithdrawalParameters{}, fmt.Errorf("failed to pack prove tx: %w", err)
+	}
+	r.log.Info("Proving withdrawal", "outputIndex", params.L2OutputIndex)
+	if err := r.send(ctx, data); err != nil {
+		return withdrawals.ProvenWithdrawalParameters{}, fmt.Errorf("failed to prove withdrawal: %w", err)
+	}
+	return params, nil
+}
+
+// Finalize waits for the proof of the withdrawal to mature, and finalizes the withdrawal.
+// With fault proofs, this includes waiting for the dispute game of the proof to be resolved.
+func (r *relayer) Finalize(ctx context.Context, txHash common.Hash) error {
+	receipt, err := r.l2.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return fmt.Errorf("failed to get withdrawal receipt: %w", err)
+	}
+	ev, err := withdrawals.ParseMessagePassed(receipt)
+	if err != nil {
+		return fmt.Errorf("failed to parse withdrawal: %w", err)
+	}
+	wd := crossdomain.NewWithdrawal(ev.Nonce, &ev.Sender, &ev.Target, ev.Value, ev.GasLimit, ev.Data)
+	wdHash, err := wd.Hash()
+	if err != nil {
+		return fmt.Errorf("failed to hash withdrawal: %w", err)
+	}
+	r.log.Info("Waiting for withdrawal to be finalizable", "withdrawal", wdHash)
+	if r.portal2 != nil {
+		submitter := r.txMgr.From()
+		err = r.waitFor(ctx, func() (bool, error) {
+			// Reverts until the proof matured, and the game resolved in favor of the proposal
+			err := r.portal2.CheckWithdrawal(&bind.CallOpts{Context: ctx}, wdHash, submitter)
+			if err != nil {
+				r.log.Debug("Withdrawal not finalizable yet", "err", err)
+			}
+			return err == nil, nil
+		})
+	} else {
+		var period *big.Int
+		period, err = r.oracle.FinalizationPeriodSeconds(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return fmt.Errorf("failed to get finalization period: %w", err)
+		}
+		err = r.waitFor(ctx, func() (bool, error) {
+			proven, err := r.portal.ProvenWithdrawals(&bind.CallOpts{Context: ctx}, wdHash)
+			if err != nil {
+				return false, fmt.Errorf("failed to get proven withdrawal: %w", err)
+			}
+			if proven.Timestamp.Sign() == 0 {
+				return false, errors.New("withdrawal is not proven")
+			}
+			// Both the proof and the output it was proven against must be older than the finalization period
+			head, err := r.l1.HeaderByNumber(ctx, nil)
+			if err != nil {
+				return false, fmt.Errorf("failed to get L1 head: %w", err)
+			}
+			if new(big.Int).Add(proven.Timestamp, period).Uint64() > head.Time {
+				return false, nil
+			}
+			return r.portal.IsOutputFinalized(&bind.CallOpts{Context: ctx}, proven.L2OutputIndex)
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := r.portalABI.Pack("finalizeWithdrawalTransaction", wd.WithdrawalTransaction())
+	if err != nil {
+		return fmt.Errorf("failed to pack finalize tx: %w", err)
+	}
+	r.log.Info("Finalizing withdrawal", "withdrawal", wdHash)
+	if err := r.send(ctx, data); err != nil {
+		return fmt.Errorf("failed to finalize withdrawal: %w", err)
+	}
+	return nil
+}
+
+func (r *relayer) send(ctx context.Context, data []byte) error {
+	receipt, err := r.txMgr.Send(ctx, txmgr.TxCandidate{
+		TxData: data,
+		To:     &r.portalAddr,
+	})
+	if err != nil {
+		return err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("tx %v reverted", receipt.TxHash)
+	}
+	r.log.Info("Transaction confirmed", "tx", receipt.TxHash, "block", receipt.BlockNumber)
+	return nil
+}
+
+// waitFor polls the condition until it is met, returns an error or the context is done.
+func (r *relayer) waitFor(ctx context.Context, cond func() (bool, error)) error {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+	for {
+		ok, err := cond()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func toWithdrawal(params withdrawals.ProvenWithdrawalParameters) *crossdomain.Withdrawal {
+	return crossdomain.NewWithdrawal(params.Nonce, &params.Sender, &params.Target, params.Value, params.GasLimit, params.Data)
+}
@@ -0,0 +1,187 @@
+// Package storagediff compares the state of L2 predeploys before and after an upgrade,
+// and maps the changed storage slots to the variables of the predeploy storage layouts.
+package storagediff
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/solc"
+)
+
+// Contract describes an account that is compared, by name and storage layout.
+type Contract struct {
+	Name string
+	// Layout is the storage layout that slots are decoded with. Slots are reported raw if nil.
+	Layout *solc.StorageLayout
+}
+
+// VarChange is a change of a storage variable, decoded with a storage layout.
+type VarChange struct {
+	Label string
+	Type  string
+	Pre   string
+	Post  string
+}
+
+// SlotChange is a change of the value of a storage slot.
+type SlotChange struct {
+	Slot common.Hash
+	Pre  common.Hash
+	Post common.Hash
+	// Vars are the storage variables that are stored in the slot and changed.
+	// Empty if the slot could not be mapped to the storage layout, e.g. a mapping entry.
+	Vars []VarChange
+}
+
+// AccountDiff is the difference of the state of an account.
+type AccountDiff struct {
+	Address common.Address
+	Name    string
+	Created bool
+	Deleted bool
+	// CodeChanged is true if the code of the account changed, e.g. when a predeploy without proxy is replaced.
+	CodeChanged bool
+	Slots       []SlotChange
+}
+
+// Empty returns true if the account did not change.
+func (d *AccountDiff) Empty() bool {
+	return !d.Created && !d.Deleted && !d.CodeChanged && len(d.Slots) == 0
+}
+
+// Diff compares the storage and code of the given contracts between the pre and post state.
+// If contracts is nil, all accounts of either state are compared.
+// The result is sorted by address, and only includes accounts that changed.
+func Diff(pre, post types.GenesisAlloc, contracts map[common.Address]Contract) []AccountDiff {
+	var addrs []common.Address
+	if contracts == nil {
+		for addr := range pre {
+			addrs = append(addrs, addr)
+		}
+		for addr := range post {
+			if _, ok := pre[addr]; !ok {
+				addrs = append(addrs, addr)
+			}
+		}
+	} else {
+		for addr := range contracts {
+			addrs = append(addrs, addr)
+		}
+	}
+	slices.SortFunc(addrs, func(a, b common.Address) int { return a.Cmp(b) })
+
+	var out []AccountDiff
+	for _, addr := range addrs {
+		preAcc, preOk := pre[addr]
+		postAcc, postOk := post[addr]
+		if !preOk && !postOk {
+			continue
+		}
+		contract := contracts[addr]
+		d := AccountDiff{
+			Address:     addr,
+			Name:        contract.Name,
+			Created:     !preOk,
+			Deleted:     !postOk,
+			CodeChanged: preOk && postOk && !bytes.Equal(preAcc.Code, postAcc.Code),
+			Slots:       diffStorage(preAcc.Storage, postAcc.Storage, contract.Layout),
+		}
+		if !d.Empty() {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+func diffStorage(pre, post map[common.Hash]common.Hash, layout *solc.StorageLayout) []SlotChange {
+	var slots []common.Hash
+	for slot, v := range pre {
+		if post[slot] != v {
+			slots = append(slots, slot)
+		}
+	}
+	for slot, v := range post {
+		if _, ok := pre[slot]; !ok && v != (common.Hash{}) {
+			slots = append(slots, slot)
+		}
+	}
+	slices.SortFunc(slots, func(a, b common.Hash) int { return a.Cmp(b) })
+	out := make([]SlotChange, 0, len(slots))
+	for _, slot := range slots {
+		preVal, postVal := pre[slot], post[slot]
+		out = append(out, SlotChange{
+			Slot: slot,
+			Pre:  preVal,
+			Post: postVal,
+			Vars: decodeSlot(layout, slot, preVal, postVal),
+		})
+	}
+	return out
+}
+
+// decodeSlot maps a changed slot to the variables stored in it.
+func decodeSlot(layout *solc.StorageLayout, slot, pre, post common.Hash) []VarChange {
+	switch slot {
+	case genesis.ImplementationSlot:
+		return []VarChange{{Label: "implementation (EIP-1967)", Type: "address", Pre: formatAddress(pre), Post: formatAddress(post)}}
+	case genesis.AdminSlot:
+		return []VarChange{{Label: "admin (EIP-1967)", Type: "address", Pre: formatAddress(pre), Post: formatAddress(post)}}
+	}
+	if layout == nil {
+		return nil
+	}
+	var out []VarChange
+	for _, v := range layoutVars(layout) {
+		if v.slot != slot {
+			continue
+		}
+		preVal, postVal := v.decode(pre), v.decode(post)
+		if preVal != postVal {
+			out = append(out, VarChange{Label: v.label, Type: v.typ.Label, Pre: preVal, Post: postVal})
+		}
+	}
+	return out
+}
+
+// Format writes a human-readable report of the diff.
+func Format(w io.Writer, diffs []AccountDiff) error {
+	var sb strings.Builder
+	if len(diffs) == 0 {
+		sb.WriteString("No changes\n")
+	}
+	for _, d := range diffs {
+		name := d.Name
+		if name == "" {
+			name = "unknown"
+		}
+		fmt.Fprintf(&sb, "%s (%s)\n", name, d.Address)
+		if d.Created {
+			sb.WriteString("  account created\n")
+		}
+		if d.Deleted {
+			sb.WriteString("  account deleted\n")
+		}
+		if d.CodeChanged {
+			sb.WriteString("  code changed\n")
+		}
+		for _, s := range d.Slots {
+			if len(s.Vars) == 0 {
+				fmt.Fprintf(&sb, "  slot %s: %s -> %s\n", s.Slot, s.Pre, s.Post)
+				continue
+			}
+			for _, v := range s.Vars {
+				fmt.Fprintf(&sb, "  %s (%s): %s -> %s\n", v.Label, v.Type, v.Pre, v.Post)
+			}
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,207 @@
+package storagediff
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/solc"
+)
+
+// testLayout packs an address and a bool into slot 0, and has a struct, a string and a mapping.
+var testLayout = &solc.StorageLayout{
+	Storage: []solc.StorageLayoutEntry{
+		{Label: "owner", Slot: 0, Offset: 0, Type: "t_address"},
+		{Label: "paused", Slot: 0, Offset: 20, Type: "t_bool"},
+		{Label: "config", Slot: 1, Type: "t_struct(Config)"},
+		{Label: "name", Slot: 3, Type: "t_string_storage"},
+		{Label: "balances", Slot: 4, Type: "t_mapping(t_address,t_uint256)"},
+	},
+	Types: map[string]solc.StorageLayoutType{
+		"t_address":        {Encoding: "inplace", Label: "address", NumberOfBytes: 20},
+		"t_bool":           {Encoding: "inplace", Label: "bool", NumberOfBytes: 1},
+		"t_uint64":         {Encoding: "inplace", Label: "uint64", NumberOfBytes: 8},
+		"t_int32":          {Encoding: "inplace", Label: "int32", NumberOfBytes: 4},
+		"t_string_storage": {Encoding: "bytes", Label: "string", NumberOfBytes: 32},
+		"t_struct(Config)": {Encoding: "inplace", Label: "struct Config", NumberOfBytes: 64, Members: []solc.StorageLayoutEntry{
+			{Label: "gasLimit", Slot: 0, Offset: 0, Type: "t_uint64"},
+			{Label: "delta", Slot: 1, Offset: 0, Type: "t_int32"},
+		}},
+		"t_mapping(t_address,t_uint256)": {Encoding: "mapping", Label: "mapping(address => uint256)", NumberOfBytes: 32},
+	},
+}
+
+func slot(n int64) common.Hash {
+	return common.BigToHash(big.NewInt(n))
+}
+
+func shortString(s string) common.Hash {
+	var h common.Hash
+	copy(h[:], s)
+	h[31] = byte(len(s) * 2)
+	return h
+}
+
+func TestDiff(t *testing.T) {
+	addr := common.Address{0x42}
+	other := common.Address{0x43}
+	created := common.Address{0x44}
+	ownerA := common.Address{0xaa}
+	ownerB := common.Address{0xbb}
+	mappingSlot := common.Hash{0x99}
+
+	var packedPre, packedPost common.Hash
+	copy(packedPre[12:], ownerA[:])
+	copy(packedPost[12:], ownerA[:])
+	packedPost[11] = 1 // paused = true
+
+	pre := types.GenesisAlloc{
+		addr: {
+			Code: []byte{1},
+			Storage: map[common.Hash]common.Hash{
+				slot(0):                    packedPre,
+				slot(1):                    common.BigToHash(big.NewInt(30_000_000)),
+				slot(3):                    shortString("old"),
+				genesis.ImplementationSlot: common.BytesToHash(ownerA[:]),
+				mappingSlot:                slot(5),
+				slot(2):                    slot(7),
+			},
+		},
+		other: {Code: []byte{1}, Storage: map[common.Hash]common.Hash{slot(0): slot(1)}},
+	}
+	post := types.GenesisAlloc{
+		addr: {
+			Code: []byte{1},
+			Storage: map[common.Hash]common.Hash{
+				slot(0):                    packedPost,
+				slot(1):                    common.BigToHash(big.NewInt(60_000_000)),
+				slot(2):                    common.BytesToHash([]byte{0xff, 0xff, 0xff, 0xfe}),
+				slot(3):                    shortString("new"),
+				genesis.ImplementationSlot: common.BytesToHash(ownerB[:]),
+			},
+		},
+		other:   {Code: []byte{2}, Storage: map[common.Hash]common.Hash{slot(0): slot(1)}},
+		created: {Code: []byte{3}},
+	}
+
+	diffs := Diff(pre, post, map[common.Address]Contract{
+		addr:    {Name: "Test", Layout: testLayout},
+		other:   {Name: "Other"},
+		created: {Name: "Created"},
+	})
+	require.Len(t, diffs, 3)
+
+	require.Equal(t, addr, diffs[0].Address)
+	require.False(t, diffs[0].CodeChanged)
+	require.Equal(t, []SlotChange{
+		{Slot: slot(0), Pre: packedPre, Post: packedPost, Vars: []VarChange{{Label: "paused", Type: "bool", Pre: "false", Post: "true"}}},
+		{Slot: slot(1), Pre: common.BigToHash(big.NewInt(30_000_000)), Post: common.BigToHash(big.NewInt(60_000_000)),
+			Vars: []VarChange{{Label: "config.gasLimit", Type: "uint64", Pre: "30000000", Post: "60000000"}}},
+		{Slot: slot(2), Pre: slot(7), Post: common.BytesToHash([]byte{0xff, 0xff, 0xff, 0xfe}),
+			Vars: []VarChange{{Label: "config.delta", Type: "int32", Pre: "7", Post: "-2"}}},
+		{Slot: slot(3), Pre: shortString("old"), Post: shortString("new"),
+			Vars: []VarChange{{Label: "name", Type: "string", Pre: `"old"`, Post: `"new"`}}},
+		{Slot: genesis.ImplementationSlot, Pre: common.BytesToHash(ownerA[:]), Post: common.BytesToHash(ownerB[:]),
+			Vars: []VarChange{{Label: "implementation (EIP-1967)", Type: "address", Pre: ownerA.Hex(), Post: ownerB.Hex()}}},
+		{Slot: mappingSlot, Pre: slot(5), Post: common.Hash{}},
+	}, diffs[0].Slots)
+
+	require.Equal(t, other, diffs[1].Address)
+	require.True(t, diffs[1].CodeChanged)
+	require.Empty(t, diffs[1].Slots)
+
+	require.Equal(t, created, diffs[2].Address)
+	require.True(t, diffs[2].Created)
+
+	var buf bytes.Buffer
+	require.NoError(t, Format(&buf, diffs))
+	require.Contains(t, buf.String(), "Test ("+addr.Hex()+")\n")
+	require.Contains(t, buf.String(), "  paused (bool): false -> true\n")
+	require.Contains(t, buf.String(), "  slot "+mappingSlot.Hex()+": ")
+	require.Contains(t, buf.String(), "Other ("+other.Hex()+")\n  code changed\n")
+	require.Contains(t, buf.String(), "  account created\n")
+}
+
+func TestDiff_AllAccounts(t *testing.T) {
+	pre := types.GenesisAlloc{
+		{0x01}: {Storage: map[common.Hash]common.Hash{slot(0): slot(1)}},
+		{0x02}: {Storage: map[common.Hash]common.Hash{slot(0): slot(1)}},
+	}
+	post := types.GenesisAlloc{
+		{0x02}: {Storage: map[common.Hash]common.Hash{slot(0): slot(1)}},
+	}
+	diffs := Diff(pre, post, nil)
+	require.Len(t, diffs, 1)
+	require.Equal(t, common.Address{0x01}, diffs[0].Address)
+	require.True(t, diffs[0].Deleted)
+
+	var buf bytes.Buffer
+	require.NoError(t, Format(&buf, Diff(post, post, nil)))
+	require.Equal(t, "No changes\n", buf.String())
+}
+
+func TestDecodeShortBytes(t *testing.T) {
+	require.Equal(t, `"hello"`, decodeShortBytes(shortString("hello"), "string"))
+	require.Equal(t, "0x6869", decodeShortBytes(shortString("hi"), "bytes"))
+	require.Equal(t, "<string of 100 bytes>", decodeShortBytes(slot(201), "string"))
+}
+
+func TestPredeployContracts(t *testing.T) {
+	contracts, err := PredeployContracts(nil)
+	require.NoError(t, err)
+	require.Contains(t, contracts, common.HexToAddress("0x4200000000000000000000000000000000000015"))
+	require.Equal(t, "L1Block", contracts[common.HexToAddress("0x4200000000000000000000000000000000000015")].Name)
+
+	// None of the test artifacts are predeploys.
+	contracts, err = PredeployContracts(foundry.OpenArtifactsDir("../foundry/testdata/forge-artifacts"))
+	require.NoError(t, err)
+	for _, c := range contracts {
+		require.Nil(t, c.Layout)
+	}
+}
+
+type stubStateReader map[common.Address]types.Account
+
+func (s stubStateReader) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return s[account].Code, nil
+}
+
+func (s stubStateReader) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	v := s[account].Storage[key]
+	return v[:], nil
+}
+
+func TestFetchState(t *testing.T) {
+	artifact, err := foundry.OpenArtifactsDir("../foundry/testdata/forge-artifacts").ReadArtifact("Owned.sol", "Owned")
+	require.NoError(t, err)
+	addr := common.Address{0x42}
+	missing := common.Address{0x43}
+	owner := common.BytesToHash(common.Address{0xaa}.Bytes())
+	reader := stubStateReader{
+		addr: {Code: []byte{1}, Storage: map[common.Hash]common.Hash{
+			slot(0):           owner,
+			slot(1):           slot(1), // not in the layout
+			genesis.AdminSlot: slot(2),
+		}},
+	}
+	state, err := FetchState(context.Background(), reader, nil, map[common.Address]Contract{
+		addr:    {Name: "Owned", Layout: &artifact.StorageLayout},
+		missing: {Name: "Missing"},
+	})
+	require.NoError(t, err)
+	require.NotContains(t, state, missing)
+	require.Equal(t, []byte{1}, state[addr].Code)
+	require.Equal(t, map[common.Hash]common.Hash{
+		slot(0):                    owner,
+		genesis.AdminSlot:          slot(2),
+		genesis.ImplementationSlot: {},
+	}, state[addr].Storage)
+}
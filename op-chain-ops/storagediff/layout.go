@@ -0,0 +1,129 @@
+package storagediff
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/solc"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+// storageVar is a storage variable with a fixed location, that fits in a single slot.
+type storageVar struct {
+	label  string
+	slot   common.Hash
+	offset uint
+	typ    solc.StorageLayoutType
+}
+
+// layoutVars lists the variables of the layout that can be located without knowing mapping keys or array lengths.
+// Struct members are listed individually.
+func layoutVars(layout *solc.StorageLayout) []storageVar {
+	var out []storageVar
+	var visit func(entries []solc.StorageLayoutEntry, base uint64, prefix string)
+	visit = func(entries []solc.StorageLayoutEntry, base uint64, prefix string) {
+		for _, entry := range entries {
+			typ, ok := layout.Types[entry.Type]
+			if !ok {
+				continue
+			}
+			slot := base + uint64(entry.Slot)
+			label := prefix + entry.Label
+			if len(typ.Members) > 0 {
+				visit(typ.Members, slot, label+".")
+				continue
+			}
+			if typ.Encoding != "inplace" && typ.Encoding != "bytes" {
+				continue
+			}
+			if typ.Encoding == "inplace" && typ.NumberOfBytes > 32 {
+				continue // static arrays spanning multiple slots are reported raw
+			}
+			out = append(out, storageVar{
+				label:  label,
+				slot:   common.BigToHash(new(big.Int).SetUint64(slot)),
+				offset: entry.Offset,
+				typ:    typ,
+			})
+		}
+	}
+	visit(layout.Storage, 0, "")
+	return out
+}
+
+// decode formats the value of the variable from the slot value.
+func (v storageVar) decode(slotValue common.Hash) string {
+	if v.typ.Encoding == "bytes" {
+		return decodeShortBytes(slotValue, v.typ.Label)
+	}
+	size := v.typ.NumberOfBytes
+	if v.offset+size > 32 {
+		return slotValue.Hex()
+	}
+	// Values are packed from the lower-order bytes of the slot.
+	data := slotValue[32-v.offset-size : 32-v.offset]
+	label := v.typ.Label
+	switch {
+	case label == "bool":
+		return strconv.FormatBool(data[len(data)-1] != 0)
+	case label == "address" || label == "address payable" || strings.HasPrefix(label, "contract "):
+		return formatAddress(common.BytesToHash(data))
+	case strings.HasPrefix(label, "uint") || strings.HasPrefix(label, "enum "):
+		return new(big.Int).SetBytes(data).String()
+	case strings.HasPrefix(label, "int"):
+		n := new(big.Int).SetBytes(data)
+		if len(data) > 0 && data[0]&0x80 != 0 {
+			n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(data))*8))
+		}
+		return n.String()
+	default:
+		return "0x" + common.Bytes2Hex(data)
+	}
+}
+
+// decodeShortBytes decodes a string or bytes value that is stored in the slot itself.
+// Values of 32 bytes or longer are stored elsewhere, and only their length is reported.
+func decodeShortBytes(slotValue common.Hash, label string) string {
+	if slotValue[31]&1 == 1 {
+		length := new(big.Int).Rsh(slotValue.Big(), 1)
+		return fmt.Sprintf("<%s of %d bytes>", label, length)
+	}
+	length := int(slotValue[31] / 2)
+	if label == "string" {
+		return strconv.Quote(string(slotValue[:length]))
+	}
+	return "0x" + common.Bytes2Hex(slotValue[:length])
+}
+
+func formatAddress(v common.Hash) string {
+	return common.BytesToAddress(v[12:]).Hex()
+}
+
+// PredeployContracts returns the predeploys, with the storage layouts of their contract artifacts.
+// Predeploys without artifact, e.g. preinstalls that are not built from the contracts, have no layout.
+// Proxied predeploys hold the storage of their implementation, so the layout of the implementation is used.
+// If artifacts is nil, no layouts are loaded.
+func PredeployContracts(artifacts *foundry.ArtifactsFS) (map[common.Address]Contract, error) {
+	out := make(map[common.Address]Contract, len(predeploys.Predeploys))
+	for name, p := range predeploys.Predeploys {
+		c := Contract{Name: name}
+		if artifacts != nil {
+			artifact, err := artifacts.ReadArtifact(name+".sol", name)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return nil, fmt.Errorf("failed to read artifact of predeploy %s: %w", name, err)
+			}
+			if artifact != nil {
+				c.Layout = &artifact.StorageLayout
+			}
+		}
+		out[p.Address] = c
+	}
+	return out, nil
+}
@@ -0,0 +1,62 @@
+package storagediff
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+)
+
+// StateReader reads account state at a block, e.g. an ethclient.Client.
+type StateReader interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+}
+
+// FetchState reads the code and storage of the contracts at the given block, from a live RPC.
+// Storage cannot be enumerated over RPC, so only the EIP-1967 proxy slots and the slots of the variables
+// in the storage layout are read. Changes to other slots, like mapping entries, require state dumps to be compared.
+func FetchState(ctx context.Context, client StateReader, block *big.Int, contracts map[common.Address]Contract) (types.GenesisAlloc, error) {
+	out := make(types.GenesisAlloc, len(contracts))
+	for addr, contract := range contracts {
+		code, err := client.CodeAt(ctx, addr, block)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read code of %s (%s): %w", contract.Name, addr, err)
+		}
+		slots := []common.Hash{genesis.ImplementationSlot, genesis.AdminSlot}
+		if contract.Layout != nil {
+			for _, v := range layoutVars(contract.Layout) {
+				slots = append(slots, v.slot)
+			}
+		}
+		storage := make(map[common.Hash]common.Hash)
+		for _, slot := range slots {
+			if _, ok := storage[slot]; ok {
+				continue
+			}
+			v, err := client.StorageAt(ctx, addr, slot, block)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read slot %s of %s (%s): %w", slot, contract.Name, addr, err)
+			}
+			storage[slot] = common.BytesToHash(v)
+		}
+		if len(code) == 0 && isZero(storage) {
+			continue // the account does not exist (yet)
+		}
+		out[addr] = types.Account{Code: code, Storage: storage, Balance: new(big.Int)}
+	}
+	return out, nil
+}
+
+func isZero(storage map[common.Hash]common.Hash) bool {
+	for _, v := range storage {
+		if v != (common.Hash{}) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+type ConfiguredLifecycle func(ctx context.Context, log log.Logger, config *config.Config) (cliapp.Lifecycle, error)
+
+// New creates the op-challenger app, running the challenger created by action with the given version.
+func New(version string, action ConfiguredLifecycle) *cli.App {
+	app := cli.NewApp()
+	app.Version = version
+	app.Flags = cliapp.ProtectFlags(flags.Flags)
+	app.Name = "op-challenger"
+	app.Usage = "Challenge outputs"
+	app.Description = "Ensures that on chain outputs are correct."
+	app.Commands = []*cli.Command{
+		ListGamesCommand,
+		ListClaimsCommand,
+		ListCreditsCommand,
+		CreateGameCommand,
+		MoveCommand,
+		ResolveCommand,
+		ResolveClaimCommand,
+		RunTraceCommand(version),
+		PruneCommand,
+	}
+	app.Action = cliapp.LifecycleCmd(func(ctx *cli.Context, close context.CancelCauseFunc) (cliapp.Lifecycle, error) {
+		logger, err := setupLogging(ctx)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("Starting op-challenger", "version", version)
+
+		cfg, err := flags.NewConfigFromCLI(ctx, logger)
+		if err != nil {
+			return nil, err
+		}
+		return action(ctx.Context, logger, cfg)
+	})
+	return app
+}
+
+func setupLogging(ctx *cli.Context) (log.Logger, error) {
+	logCfg := oplog.ReadCLIConfig(ctx)
+	logger := oplog.NewLogger(oplog.AppOut(ctx), logCfg)
+	oplog.SetGlobalLogHandler(logger.Handler())
+	return logger, nil
+}
@@ -0,0 +1,138 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+)
+
+var DryRunFlag = &cli.BoolFlag{
+	Name:    "dry-run",
+	Usage:   "Only report the game data that would be deleted.",
+	EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "DRY_RUN"),
+}
+
+// gameResolver returns the status of a game, and the time it was resolved if it is resolved.
+type gameResolver func(ctx context.Context, addr common.Address) (types.GameStatus, time.Time, error)
+
+func Prune(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	rpcUrl := ctx.String(flags.L1EthRpcFlag.Name)
+	if rpcUrl == "" {
+		return fmt.Errorf("missing %v", flags.L1EthRpcFlag.Name)
+	}
+	datadir := ctx.String(flags.DatadirFlag.Name)
+	if datadir == "" {
+		return fmt.Errorf("missing %v", flags.DatadirFlag.Name)
+	}
+	retention := ctx.Duration(flags.GameDataRetentionFlag.Name)
+	if retention < 0 {
+		return fmt.Errorf("negative %v", flags.GameDataRetentionFlag.Name)
+	}
+
+	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, rpcUrl)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer l1Client.Close()
+	caller := batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize)
+	resolver := func(ctx context.Context, addr common.Address) (types.GameStatus, time.Time, error) {
+		gameContract, err := contracts.NewFaultDisputeGameContract(ctx, metrics.NoopContractMetrics, addr, caller)
+		if err != nil {
+			return 0, time.Time{}, fmt.Errorf("failed to create dispute game contract: %w", err)
+		}
+		status, err := gameContract.GetStatus(ctx)
+		if err != nil || status == types.GameStatusInProgress {
+			return status, time.Time{}, err
+		}
+		resolvedAt, err := gameContract.GetResolvedAt(ctx, rpcblock.Latest)
+		return status, resolvedAt, err
+	}
+	disk := game.NewDiskManager(datadir, retention, clock.SystemClock)
+	return prune(ctx.Context, os.Stdout, disk, resolver, clock.SystemClock.Now(), retention, ctx.Bool(DryRunFlag.Name))
+}
+
+// prune deletes the data of games that were resolved on chain at least retention ago.
+// Games that are in progress or whose status can't be determined are kept.
+func prune(ctx context.Context, out io.Writer, disk *game.DiskManager, resolver gameResolver, now time.Time, retention time.Duration, dryRun bool) error {
+	usage, err := disk.Usage()
+	if err != nil {
+		return fmt.Errorf("failed to measure game data: %w", err)
+	}
+	lineFormat := "%-42v %14v %-14v %-19v %v\n"
+	fmt.Fprintf(out, lineFormat, "Game", "Size (bytes)", "Status", "Resolved (Local)", "Action")
+	var total, deleted uint64
+	for _, gameUsage := range usage {
+		total += gameUsage.Bytes
+		status, resolvedAt, err := resolver(ctx, gameUsage.Game)
+		resolved := ""
+		action := "keep"
+		switch {
+		case err != nil:
+			action = fmt.Sprintf("keep (unknown status: %v)", err)
+		case status != types.GameStatusInProgress:
+			resolved = resolvedAt.Local().Format(time.DateTime)
+			if now.Sub(resolvedAt) >= retention {
+				action = "delete"
+			}
+		}
+		if action == "delete" {
+			if !dryRun {
+				if err := disk.RemoveGame(gameUsage.Game); err != nil {
+					return fmt.Errorf("failed to delete data of game %v: %w", gameUsage.Game, err)
+				}
+			}
+			deleted += gameUsage.Bytes
+		}
+		statusStr := ""
+		if err == nil {
+			statusStr = status.String()
+		}
+		fmt.Fprintf(out, lineFormat, gameUsage.Game, gameUsage.Bytes, statusStr, resolved, action)
+	}
+	verb := "Deleted"
+	if dryRun {
+		verb = "Would delete"
+	}
+	fmt.Fprintf(out, "%v %d of %d bytes of game data\n", verb, deleted, total)
+	return nil
+}
+
+func pruneFlags() []cli.Flag {
+	cliFlags := []cli.Flag{
+		flags.L1EthRpcFlag,
+		flags.DatadirFlag,
+		flags.GameDataRetentionFlag,
+		DryRunFlag,
+	}
+	cliFlags = append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
+	return cliFlags
+}
+
+var PruneCommand = &cli.Command{
+	Name:        "prune",
+	Usage:       "Deletes the data of resolved games",
+	Description: "Deletes the data of games in the datadir that were resolved on chain longer ago than the game data retention, and reports the disk space used by each game",
+	Action:      Interruptible(Prune),
+	Flags:       pruneFlags(),
+}
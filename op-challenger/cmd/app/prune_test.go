@@ -0,0 +1,75 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+func TestPrune(t *testing.T) {
+	now := time.Unix(100_000, 0)
+	inProgress := common.Address{0x01}
+	resolvedLongAgo := common.Address{0x02}
+	resolvedRecently := common.Address{0x03}
+	unknown := common.Address{0x04}
+	resolver := func(_ context.Context, addr common.Address) (types.GameStatus, time.Time, error) {
+		switch addr {
+		case inProgress:
+			return types.GameStatusInProgress, time.Time{}, nil
+		case resolvedLongAgo:
+			return types.GameStatusDefenderWon, now.Add(-2 * time.Hour), nil
+		case resolvedRecently:
+			return types.GameStatusChallengerWon, now.Add(-time.Minute), nil
+		default:
+			return 0, time.Time{}, errors.New("not a game")
+		}
+	}
+	setup := func(t *testing.T) *game.DiskManager {
+		disk := game.NewDiskManager(t.TempDir(), 0, clock.NewDeterministicClock(now))
+		for _, addr := range []common.Address{inProgress, resolvedLongAgo, resolvedRecently, unknown} {
+			require.NoError(t, os.MkdirAll(disk.DirForGame(addr), 0777))
+			require.NoError(t, os.WriteFile(filepath.Join(disk.DirForGame(addr), "proof.json"), make([]byte, 10), 0644))
+		}
+		return disk
+	}
+
+	t.Run("DeletesResolvedGamesAfterRetention", func(t *testing.T) {
+		disk := setup(t)
+		var out bytes.Buffer
+		require.NoError(t, prune(context.Background(), &out, disk, resolver, now, time.Hour, false))
+		require.NoDirExists(t, disk.DirForGame(resolvedLongAgo))
+		require.DirExists(t, disk.DirForGame(inProgress))
+		require.DirExists(t, disk.DirForGame(resolvedRecently))
+		require.DirExists(t, disk.DirForGame(unknown), "should keep games with unknown status")
+		require.Contains(t, out.String(), "Deleted 10 of 40 bytes of game data")
+	})
+
+	t.Run("DryRun", func(t *testing.T) {
+		disk := setup(t)
+		var out bytes.Buffer
+		require.NoError(t, prune(context.Background(), &out, disk, resolver, now, time.Hour, true))
+		require.DirExists(t, disk.DirForGame(resolvedLongAgo))
+		require.Contains(t, out.String(), "Would delete 10 of 40 bytes of game data")
+	})
+
+	t.Run("ZeroRetention", func(t *testing.T) {
+		disk := setup(t)
+		var out bytes.Buffer
+		require.NoError(t, prune(context.Background(), &out, disk, resolver, now, 0, false))
+		require.NoDirExists(t, disk.DirForGame(resolvedLongAgo))
+		require.NoDirExists(t, disk.DirForGame(resolvedRecently))
+		require.DirExists(t, disk.DirForGame(inProgress))
+		require.DirExists(t, disk.DirForGame(unknown))
+	})
+}
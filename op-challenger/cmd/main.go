@@ -4,16 +4,15 @@ import (
 	"context"
 	"os"
 
-	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
-	"github.com/urfave/cli/v2"
-
 	"github.com/ethereum/go-ethereum/log"
 
 	challenger "github.com/ethereum-optimism/optimism/op-challenger"
+	"github.com/ethereum-optimism/optimism/op-challenger/cmd/app"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
-	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-challenger/version"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/opio"
@@ -37,46 +36,9 @@ func main() {
 	}
 }
 
-type ConfiguredLifecycle func(ctx context.Context, log log.Logger, config *config.Config) (cliapp.Lifecycle, error)
-
-func run(ctx context.Context, args []string, action ConfiguredLifecycle) error {
+func run(ctx context.Context, args []string, action app.ConfiguredLifecycle) error {
 	oplog.SetupDefaults()
+	appinfo.SetBuild(version.SimpleWithMeta, GitCommit, GitDate)
 
-	app := cli.NewApp()
-	app.Version = VersionWithMeta
-	app.Flags = cliapp.ProtectFlags(flags.Flags)
-	app.Name = "op-challenger"
-	app.Usage = "Challenge outputs"
-	app.Description = "Ensures that on chain outputs are correct."
-	app.Commands = []*cli.Command{
-		ListGamesCommand,
-		ListClaimsCommand,
-		ListCreditsCommand,
-		CreateGameCommand,
-		MoveCommand,
-		ResolveCommand,
-		ResolveClaimCommand,
-		RunTraceCommand,
-	}
-	app.Action = cliapp.LifecycleCmd(func(ctx *cli.Context, close context.CancelCauseFunc) (cliapp.Lifecycle, error) {
-		logger, err := setupLogging(ctx)
-		if err != nil {
-			return nil, err
-		}
-		logger.Info("Starting op-challenger", "version", VersionWithMeta)
-
-		cfg, err := flags.NewConfigFromCLI(ctx, logger)
-		if err != nil {
-			return nil, err
-		}
-		return action(ctx.Context, logger, cfg)
-	})
-	return app.RunContext(ctx, args)
-}
-
-func setupLogging(ctx *cli.Context) (log.Logger, error) {
-	logCfg := oplog.ReadCLIConfig(ctx)
-	logger := oplog.NewLogger(oplog.AppOut(ctx), logCfg)
-	oplog.SetGlobalLogHandler(logger.Handler())
-	return logger, nil
+	return app.New(VersionWithMeta, action).RunContext(ctx, args)
 }
@@ -4,6 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,6 +18,8 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/coordination"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -88,6 +94,19 @@ func TestL1Beacon(t *testing.T) {
 	})
 }
 
+func TestL1BeaconFallbacks(t *testing.T) {
+	t.Run("NotRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon))
+		require.Empty(t, cfg.Cannon.L1BeaconFallbacks)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon, "--l1-beacon-archiver=http://archiver.example.com"))
+		require.Equal(t, []string{"http://archiver.example.com"}, cfg.Cannon.L1BeaconFallbacks)
+		require.Equal(t, []string{"http://archiver.example.com"}, cfg.Asterisc.L1BeaconFallbacks)
+	})
+}
+
 func TestTraceType(t *testing.T) {
 	t.Run("Default", func(t *testing.T) {
 		expectedDefault := types.TraceTypeCannon
@@ -244,6 +263,58 @@ func TestMaxPendingTx(t *testing.T) {
 	})
 }
 
+func TestGovernorLimits(t *testing.T) {
+	t.Run("DefaultsToNoLimit", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Zero(t, cfg.MaxTxsPerBlock)
+		require.Zero(t, cfg.MaxGameTxsPerBlock)
+		require.Nil(t, cfg.DailyGasBudget)
+		require.Zero(t, cfg.MaxConcurrentVmExecutions)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet,
+			"--max-txs-per-block", "12",
+			"--max-game-txs-per-block", "3",
+			"--daily-gas-budget", "1.5",
+			"--max-concurrent-vm-executions", "4"))
+		require.Equal(t, uint64(12), cfg.MaxTxsPerBlock)
+		require.Equal(t, uint64(3), cfg.MaxGameTxsPerBlock)
+		require.Equal(t, big.NewInt(1_500_000_000_000_000_000), cfg.DailyGasBudget)
+		require.Equal(t, uint(4), cfg.MaxConcurrentVmExecutions)
+	})
+
+	t.Run("NegativeGasBudget", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"invalid daily-gas-budget: must not be negative",
+			addRequiredArgs(types.TraceTypeAlphabet, "--daily-gas-budget", "-1"))
+	})
+}
+
+func TestVmSandbox(t *testing.T) {
+	t.Run("DefaultsToNoLimit", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon))
+		require.Equal(t, vm.SandboxConfig{}, cfg.Cannon.Sandbox)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon,
+			"--vm-memory-limit-mb", "2048",
+			"--vm-cpu-limit", "1.5",
+			"--vm-timeout", "2h"))
+		expected := vm.SandboxConfig{MemoryLimit: 2048 * 1024 * 1024, CPULimit: 1.5, Timeout: 2 * time.Hour}
+		require.Equal(t, expected, cfg.Cannon.Sandbox)
+		require.Equal(t, expected, cfg.Asterisc.Sandbox)
+		require.Equal(t, expected, cfg.AsteriscKona.Sandbox)
+	})
+
+	t.Run("CPULimitWithoutTimeoutOrCgroup", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon, "--vm-cpu-limit", "2"))
+		require.ErrorIs(t, cfg.Check(), vm.ErrVmCPULimitRequiresLimits)
+	})
+}
+
 func TestPollInterval(t *testing.T) {
 	t.Run("UsesDefault", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeCannon))
@@ -264,6 +335,32 @@ func TestPollInterval(t *testing.T) {
 	})
 }
 
+func TestCoordination(t *testing.T) {
+	t.Run("DisabledByDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.False(t, cfg.Coordination.Enabled())
+		require.Equal(t, config.DefaultCoordinationTTL, cfg.Coordination.TTL)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		peer := "/ip4/127.0.0.1/tcp/9223/p2p/16Uiu2HAmNVCuiQLqkrHoGeYFDvqvpJEHoXHCAhqodyLKPpjHuEfn"
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet,
+			"--coordination.listen-addr", "/ip4/0.0.0.0/tcp/9222",
+			"--coordination.priv-key", "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			"--coordination.peers", peer,
+			"--coordination.ttl", "5m"))
+		require.Equal(t, "/ip4/0.0.0.0/tcp/9222", cfg.Coordination.ListenAddr)
+		require.Equal(t, []string{peer}, cfg.Coordination.Peers)
+		require.Equal(t, 5*time.Minute, cfg.Coordination.TTL)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("MissingPrivKey", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--coordination.listen-addr", "/ip4/0.0.0.0/tcp/9222"))
+		require.ErrorIs(t, cfg.Check(), coordination.ErrMissingPrivKey)
+	})
+}
+
 func TestAsteriscRequiredArgs(t *testing.T) {
 	for _, traceType := range []types.TraceType{types.TraceTypeAsterisc} {
 		traceType := traceType
@@ -722,6 +819,59 @@ func TestRollupRpc(t *testing.T) {
 	})
 }
 
+func TestChains(t *testing.T) {
+	chainsFile := filepath.Join(t.TempDir(), "chains.json")
+	require.NoError(t, os.WriteFile(chainsFile, []byte(`[
+		{"name": "a", "gameFactoryAddress": "0xaa00000000000000000000000000000000000000", "rollupRpc": "http://a:8555", "l2Rpc": "http://a:9545", "rollupConfig": "a/rollup.json", "l2Genesis": "a/genesis.json"},
+		{"name": "b", "network": "op-sepolia", "rollupRpc": "http://b:8555", "l2Rpc": "http://b:9545", "cannonPrestate": "b/pre.json"}
+	]`), 0o644))
+	multiChainArgsExcept := func(traceType types.TraceType, except string, args ...string) []string {
+		req := requiredArgs(traceType)
+		for _, name := range []string{"--rollup-rpc", "--l2-eth-rpc", "--game-factory-address", "--cannon-network", except} {
+			delete(req, name)
+		}
+		return append(toArgList(req), append([]string{"--chains=" + chainsFile}, args...)...)
+	}
+	multiChainArgs := func(traceType types.TraceType, args ...string) []string {
+		return multiChainArgsExcept(traceType, "", args...)
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, multiChainArgs(types.TraceTypeCannon))
+		require.NoError(t, cfg.Check())
+		require.Len(t, cfg.Chains, 2)
+		require.Equal(t, common.HexToAddress("0xaa00000000000000000000000000000000000000"), cfg.Chains[0].GameFactoryAddress)
+		// Chains that specify a network default to the factory of the network
+		require.Equal(t, common.Address(superchain.Addresses[11155420].DisputeGameFactoryProxy), cfg.Chains[1].GameFactoryAddress)
+
+		chainA, err := cfg.ForChain(cfg.Chains[0])
+		require.NoError(t, err)
+		require.Equal(t, "http://a:9545", chainA.Cannon.L2)
+		require.Equal(t, cannonPreState, chainA.CannonAbsolutePreState)
+		chainB, err := cfg.ForChain(cfg.Chains[1])
+		require.NoError(t, err)
+		require.Equal(t, "op-sepolia", chainB.Cannon.Network)
+		require.Equal(t, "b/pre.json", chainB.CannonAbsolutePreState)
+	})
+
+	t.Run("SharedFlagsRequired", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag cannon-bin is required", multiChainArgsExcept(types.TraceTypeCannon, "--cannon-bin"))
+		verifyArgsInvalid(t, "flag l1-eth-rpc is required", multiChainArgsExcept(types.TraceTypeCannon, "--l1-eth-rpc"))
+	})
+
+	for _, arg := range []string{"--rollup-rpc=" + rollupRpc, "--l2-eth-rpc=" + l2EthRpc, "--game-factory-address=" + gameFactoryAddressValue, "--network=" + testNetwork} {
+		arg := arg
+		name := strings.TrimPrefix(strings.Split(arg, "=")[0], "--")
+		t.Run("RejectSingleChainFlag-"+name, func(t *testing.T) {
+			verifyArgsInvalid(t, fmt.Sprintf("flag %v can not be used with chains", name), multiChainArgs(types.TraceTypeCannon, arg))
+		})
+	}
+
+	t.Run("MissingFile", func(t *testing.T) {
+		verifyArgsInvalid(t, "failed to load chains", multiChainArgs(types.TraceTypeAlphabet, "--chains="+filepath.Join(t.TempDir(), "missing.json")))
+	})
+}
+
 func TestGameWindow(t *testing.T) {
 	t.Run("UsesDefault", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
@@ -739,6 +889,52 @@ func TestGameWindow(t *testing.T) {
 	})
 }
 
+func TestGameFactoryLogRange(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Equal(t, uint64(config.DefaultGameFactoryLogRange), cfg.GameFactoryLogRange)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--game-factory-log-range=50"))
+		require.Equal(t, uint64(50), cfg.GameFactoryLogRange)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--game-factory-log-range=0"))
+		require.Zero(t, cfg.GameFactoryLogRange)
+	})
+}
+
+func TestGameDataRetention(t *testing.T) {
+	t.Run("DefaultsToZero", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.Zero(t, cfg.GameDataRetention)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--game-data-retention=72h"))
+		require.Equal(t, 72*time.Hour, cfg.GameDataRetention)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid value \"abc\" for flag -game-data-retention",
+			addRequiredArgs(types.TraceTypeAlphabet, "--game-data-retention=abc"))
+	})
+}
+
+func TestOutputCache(t *testing.T) {
+	t.Run("DefaultsToTrue", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet))
+		require.True(t, cfg.OutputCache)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(types.TraceTypeAlphabet, "--output-cache=false"))
+		require.False(t, cfg.OutputCache)
+	})
+}
+
 func TestUnsafeAllowInvalidPrestate(t *testing.T) {
 	t.Run("DefaultsToFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgsExcept(types.TraceTypeAlphabet, "--unsafe-allow-invalid-prestate"))
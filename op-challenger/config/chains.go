@@ -0,0 +1,117 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrMissingChainName   = errors.New("missing chain name")
+	ErrInvalidChainName   = errors.New("invalid chain name")
+	ErrDuplicateChainName = errors.New("duplicate chain name")
+)
+
+// ChainConfig is the configuration specific to one of multiple chains monitored by the same challenger.
+// Settings that are not specific to a chain, such as the L1 RPC, tx manager and VM binaries, are shared by all chains.
+// Unset optional fields default to the corresponding values of the shared Config.
+type ChainConfig struct {
+	// Name identifies the chain in logs and metrics, and is the name of the chain's directory in the datadir.
+	Name string `json:"name"`
+
+	GameFactoryAddress common.Address   `json:"gameFactoryAddress"`
+	GameAllowlist      []common.Address `json:"gameAllowlist,omitempty"`
+	RollupRpc          string           `json:"rollupRpc"`
+	L2Rpc              string           `json:"l2Rpc"`
+
+	// Network is the network name used by the VMs, used instead of RollupConfigPath and L2GenesisPath.
+	Network          string `json:"network,omitempty"`
+	RollupConfigPath string `json:"rollupConfig,omitempty"`
+	L2GenesisPath    string `json:"l2Genesis,omitempty"`
+
+	CannonAbsolutePreState              string `json:"cannonPrestate,omitempty"`
+	CannonAbsolutePreStateBaseURL       string `json:"cannonPrestatesUrl,omitempty"`
+	AsteriscAbsolutePreState            string `json:"asteriscPrestate,omitempty"`
+	AsteriscAbsolutePreStateBaseURL     string `json:"asteriscPrestatesUrl,omitempty"`
+	AsteriscKonaAbsolutePreState        string `json:"asteriscKonaPrestate,omitempty"`
+	AsteriscKonaAbsolutePreStateBaseURL string `json:"asteriscKonaPrestatesUrl,omitempty"`
+}
+
+// ForChain returns the config to monitor chain with.
+// The chain's games are tracked in its own directory within the datadir,
+// while downloaded prestates remain shared by all chains.
+func (c Config) ForChain(chain ChainConfig) (Config, error) {
+	cfg := c
+	cfg.Chains = nil
+	cfg.Datadir = filepath.Join(c.Datadir, chain.Name)
+	cfg.PrestatesDir = c.PrestatesDatadir()
+	cfg.GameFactoryAddress = chain.GameFactoryAddress
+	cfg.GameAllowlist = chain.GameAllowlist
+	cfg.RollupRpc = chain.RollupRpc
+	cfg.L2Rpc = chain.L2Rpc
+	for _, vmCfg := range []*vm.Config{&cfg.Cannon, &cfg.Asterisc, &cfg.AsteriscKona} {
+		vmCfg.L2 = chain.L2Rpc
+		vmCfg.Network = chain.Network
+		vmCfg.RollupConfigPath = chain.RollupConfigPath
+		vmCfg.L2GenesisPath = chain.L2GenesisPath
+	}
+	var err error
+	if cfg.CannonAbsolutePreState, cfg.CannonAbsolutePreStateBaseURL, err = chainPrestate(
+		chain.CannonAbsolutePreState, chain.CannonAbsolutePreStateBaseURL, c.CannonAbsolutePreState, c.CannonAbsolutePreStateBaseURL); err != nil {
+		return Config{}, fmt.Errorf("invalid cannon prestates url: %w", err)
+	}
+	if cfg.AsteriscAbsolutePreState, cfg.AsteriscAbsolutePreStateBaseURL, err = chainPrestate(
+		chain.AsteriscAbsolutePreState, chain.AsteriscAbsolutePreStateBaseURL, c.AsteriscAbsolutePreState, c.AsteriscAbsolutePreStateBaseURL); err != nil {
+		return Config{}, fmt.Errorf("invalid asterisc prestates url: %w", err)
+	}
+	if cfg.AsteriscKonaAbsolutePreState, cfg.AsteriscKonaAbsolutePreStateBaseURL, err = chainPrestate(
+		chain.AsteriscKonaAbsolutePreState, chain.AsteriscKonaAbsolutePreStateBaseURL, c.AsteriscKonaAbsolutePreState, c.AsteriscKonaAbsolutePreStateBaseURL); err != nil {
+		return Config{}, fmt.Errorf("invalid asterisc kona prestates url: %w", err)
+	}
+	return cfg, nil
+}
+
+// chainPrestate returns the prestate and prestates base URL of a chain,
+// defaulting to the shared ones if the chain sets neither.
+func chainPrestate(prestate string, baseURL string, defaultPrestate string, defaultBaseURL *url.URL) (string, *url.URL, error) {
+	if prestate == "" && baseURL == "" {
+		return defaultPrestate, defaultBaseURL, nil
+	}
+	if baseURL == "" {
+		return prestate, nil, nil
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", nil, err
+	}
+	return prestate, u, nil
+}
+
+func (c Config) checkChains() error {
+	names := make(map[string]bool, len(c.Chains))
+	for _, chain := range c.Chains {
+		if chain.Name == "" {
+			return ErrMissingChainName
+		}
+		if strings.ContainsAny(chain.Name, `/\`) || chain.Name == "." || chain.Name == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidChainName, chain.Name)
+		}
+		if names[chain.Name] {
+			return fmt.Errorf("%w: %v", ErrDuplicateChainName, chain.Name)
+		}
+		names[chain.Name] = true
+		cfg, err := c.ForChain(chain)
+		if err != nil {
+			return fmt.Errorf("chain %v: %w", chain.Name, err)
+		}
+		if err := cfg.Check(); err != nil {
+			return fmt.Errorf("chain %v: %w", chain.Name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+func validChain(name string) ChainConfig {
+	return ChainConfig{
+		Name:               name,
+		GameFactoryAddress: common.Address{0xaa},
+		RollupRpc:          "http://" + name + ":8555",
+		L2Rpc:              "http://" + name + ":9545",
+		Network:            validCannonNetwork,
+	}
+}
+
+func validMultiChainConfig() Config {
+	cfg := validConfig(types.TraceTypeCannon)
+	cfg.GameFactoryAddress = common.Address{}
+	cfg.RollupRpc = ""
+	cfg.L2Rpc = ""
+	cfg.Cannon.Network = ""
+	cfg.Chains = []ChainConfig{validChain("a"), validChain("b")}
+	return cfg
+}
+
+func TestMultiChainConfigIsValid(t *testing.T) {
+	require.NoError(t, validMultiChainConfig().Check())
+}
+
+func TestForChain(t *testing.T) {
+	cfg := validMultiChainConfig()
+	chain := cfg.Chains[0]
+	chain.GameAllowlist = []common.Address{{0xbb}}
+
+	chainCfg, err := cfg.ForChain(chain)
+	require.NoError(t, err)
+	require.Empty(t, chainCfg.Chains)
+	require.Equal(t, filepath.Join(validDatadir, "a"), chainCfg.Datadir)
+	require.Equal(t, validDatadir, chainCfg.PrestatesDatadir(), "prestates should be shared by all chains")
+	require.Equal(t, chain.GameFactoryAddress, chainCfg.GameFactoryAddress)
+	require.Equal(t, chain.GameAllowlist, chainCfg.GameAllowlist)
+	require.Equal(t, chain.RollupRpc, chainCfg.RollupRpc)
+	require.Equal(t, chain.L2Rpc, chainCfg.L2Rpc)
+	require.Equal(t, chain.L2Rpc, chainCfg.Cannon.L2)
+	require.Equal(t, chain.Network, chainCfg.Cannon.Network)
+	require.Equal(t, validL1EthRpc, chainCfg.Cannon.L1)
+
+	t.Run("InheritPrestate", func(t *testing.T) {
+		chainCfg, err := cfg.ForChain(chain)
+		require.NoError(t, err)
+		require.Equal(t, validCannonAbsolutePreStateBaseURL, chainCfg.CannonAbsolutePreStateBaseURL)
+		require.Empty(t, chainCfg.CannonAbsolutePreState)
+	})
+
+	t.Run("OverridePrestate", func(t *testing.T) {
+		chain := chain
+		chain.CannonAbsolutePreState = "chain.json"
+		chainCfg, err := cfg.ForChain(chain)
+		require.NoError(t, err)
+		require.Equal(t, "chain.json", chainCfg.CannonAbsolutePreState)
+		require.Nil(t, chainCfg.CannonAbsolutePreStateBaseURL)
+	})
+
+	t.Run("OverridePrestatesURL", func(t *testing.T) {
+		chain := chain
+		chain.CannonAbsolutePreStateBaseURL = "http://prestates.example.com/"
+		chainCfg, err := cfg.ForChain(chain)
+		require.NoError(t, err)
+		require.Equal(t, "http://prestates.example.com/", chainCfg.CannonAbsolutePreStateBaseURL.String())
+	})
+
+	t.Run("InvalidPrestatesURL", func(t *testing.T) {
+		chain := chain
+		chain.CannonAbsolutePreStateBaseURL = "://"
+		_, err := cfg.ForChain(chain)
+		require.ErrorContains(t, err, "invalid cannon prestates url")
+	})
+}
+
+func TestCheckChains(t *testing.T) {
+	t.Run("MissingName", func(t *testing.T) {
+		cfg := validMultiChainConfig()
+		cfg.Chains[1].Name = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingChainName)
+	})
+
+	for _, name := range []string{".", "..", "a/b", `a\b`} {
+		name := name
+		t.Run("InvalidName-"+name, func(t *testing.T) {
+			cfg := validMultiChainConfig()
+			cfg.Chains[1].Name = name
+			require.ErrorIs(t, cfg.Check(), ErrInvalidChainName)
+		})
+	}
+
+	t.Run("DuplicateName", func(t *testing.T) {
+		cfg := validMultiChainConfig()
+		cfg.Chains[1].Name = cfg.Chains[0].Name
+		require.ErrorIs(t, cfg.Check(), ErrDuplicateChainName)
+	})
+
+	t.Run("MissingRollupRpc", func(t *testing.T) {
+		cfg := validMultiChainConfig()
+		cfg.Chains[1].RollupRpc = ""
+		err := cfg.Check()
+		require.ErrorIs(t, err, ErrMissingRollupRpc)
+		require.ErrorContains(t, err, "chain b")
+	})
+
+	t.Run("MissingGameFactory", func(t *testing.T) {
+		cfg := validMultiChainConfig()
+		cfg.Chains[0].GameFactoryAddress = common.Address{}
+		require.ErrorIs(t, cfg.Check(), ErrMissingGameFactoryAddress)
+	})
+
+	t.Run("MissingNetwork", func(t *testing.T) {
+		cfg := validMultiChainConfig()
+		cfg.Chains[0].Network = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingCannonRollupConfig)
+	})
+
+	t.Run("SharedConfigChecked", func(t *testing.T) {
+		cfg := validMultiChainConfig()
+		cfg.L1EthRpc = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingL1EthRPC)
+	})
+}
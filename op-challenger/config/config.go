@@ -3,14 +3,18 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"net/url"
 	"runtime"
 	"slices"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/coordination"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
@@ -21,6 +25,7 @@ var (
 	ErrMissingTraceType                 = errors.New("no supported trace types specified")
 	ErrMissingDatadir                   = errors.New("missing datadir")
 	ErrMaxConcurrencyZero               = errors.New("max concurrency must not be 0")
+	ErrNegativeGameDataRetention        = errors.New("game data retention must not be negative")
 	ErrMissingL2Rpc                     = errors.New("missing L2 rpc url")
 	ErrMissingCannonBin                 = errors.New("missing cannon bin")
 	ErrMissingCannonServer              = errors.New("missing cannon server")
@@ -64,6 +69,12 @@ const (
 	// buffer to monitor games to ensure bonds are claimed.
 	DefaultGameWindow   = time.Duration(28 * 24 * time.Hour)
 	DefaultMaxPendingTx = 10
+	// DefaultResolutionBatchGasLimit is the default maximum gas of the claim resolutions batched in a single transaction.
+	DefaultResolutionBatchGasLimit = 10_000_000
+	// DefaultCoordinationTTL is the default duration after which announcements of countered claims expire.
+	DefaultCoordinationTTL = 10 * time.Minute
+	// DefaultGameFactoryLogRange is the default maximum number of L1 blocks to scan for new games in a single request.
+	DefaultGameFactoryLogRange = 1000
 )
 
 // Config is a well typed config that is parsed from the CLI params.
@@ -75,7 +86,11 @@ type Config struct {
 	GameFactoryAddress   common.Address   // Address of the dispute game factory
 	GameAllowlist        []common.Address // Allowlist of fault game addresses
 	GameWindow           time.Duration    // Maximum time duration to look for games to progress
+	GameFactoryLogRange  uint64           // Maximum number of L1 blocks to scan for new games per request (0 == load games by index instead)
 	Datadir              string           // Data Directory
+	GameDataRetention    time.Duration    // Duration to keep the data of games that are no longer required (0 == delete immediately)
+	OutputCache          bool             // Whether to store the output roots of finalized L2 blocks in the datadir
+	PrestatesDir         string           // Directory to store downloaded prestates in (defaults to Datadir)
 	MaxConcurrency       uint             // Maximum number of threads to use when progressing games
 	PollInterval         time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
 	AllowInvalidPrestate bool             // Whether to allow responding to games where the prestate does not match
@@ -84,12 +99,18 @@ type Config struct {
 
 	SelectiveClaimResolution bool // Whether to only resolve claims for the claimants in AdditionalBondClaimants union [TxSender.From()]
 
+	ResolutionBatchGasLimit uint64         // Maximum gas of claim resolutions batched in a single transaction (0 == no batching)
+	Multicall3Address       common.Address // Address of the Multicall3 contract used to batch claim resolutions
+
 	TraceTypes []types.TraceType // Type of traces supported
 
 	RollupRpc string // L2 Rollup RPC Url
 
 	L2Rpc string // L2 RPC Url
 
+	// Chains to monitor from this process, instead of the single chain of GameFactoryAddress, RollupRpc and L2Rpc
+	Chains []ChainConfig
+
 	// Specific to the cannon trace provider
 	Cannon                        vm.Config
 	CannonAbsolutePreState        string   // File to load the absolute pre-state for Cannon traces from
@@ -105,9 +126,17 @@ type Config struct {
 
 	MaxPendingTx uint64 // Maximum number of pending transactions (0 == no limit)
 
-	TxMgrConfig   txmgr.CLIConfig
-	MetricsConfig opmetrics.CLIConfig
-	PprofConfig   oppprof.CLIConfig
+	MaxTxsPerBlock            uint64   // Maximum number of transactions sent per L1 block (0 == no limit)
+	MaxGameTxsPerBlock        uint64   // Maximum number of transactions sent per L1 block for a single game (0 == no limit)
+	DailyGasBudget            *big.Int // Maximum wei spent on transaction fees in any 24 hour window (nil == no limit)
+	MaxConcurrentVmExecutions uint     // Maximum number of VM executions running at once, across all games (0 == no limit)
+
+	Coordination coordination.Config // Gossip of countered claims with other honest challengers
+
+	TxMgrConfig     txmgr.CLIConfig
+	MetricsConfig   opmetrics.CLIConfig
+	PprofConfig     oppprof.CLIConfig
+	AddrCheckConfig addrcheck.CLIConfig
 }
 
 func NewConfig(
@@ -132,11 +161,17 @@ func NewConfig(
 
 		MaxPendingTx: DefaultMaxPendingTx,
 
+		ResolutionBatchGasLimit: DefaultResolutionBatchGasLimit,
+		Multicall3Address:       contracts.DefaultMulticall3Address,
+
+		Coordination: coordination.Config{TTL: DefaultCoordinationTTL},
+
 		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc, txmgr.DefaultChallengerFlagValues),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
 
-		Datadir: datadir,
+		Datadir:     datadir,
+		OutputCache: true,
 
 		Cannon: vm.Config{
 			VmType:       types.TraceTypeCannon,
@@ -146,6 +181,7 @@ func NewConfig(
 			SnapshotFreq: DefaultCannonSnapshotFreq,
 			InfoFreq:     DefaultCannonInfoFreq,
 			DebugInfo:    true,
+			ExitReport:   true,
 		},
 		Asterisc: vm.Config{
 			VmType:       types.TraceTypeAsterisc,
@@ -163,7 +199,8 @@ func NewConfig(
 			SnapshotFreq: DefaultAsteriscSnapshotFreq,
 			InfoFreq:     DefaultAsteriscInfoFreq,
 		},
-		GameWindow: DefaultGameWindow,
+		GameWindow:          DefaultGameWindow,
+		GameFactoryLogRange: DefaultGameFactoryLogRange,
 	}
 }
 
@@ -171,7 +208,18 @@ func (c Config) TraceTypeEnabled(t types.TraceType) bool {
 	return slices.Contains(c.TraceTypes, t)
 }
 
+// PrestatesDatadir returns the directory to store downloaded prestates in.
+func (c Config) PrestatesDatadir() string {
+	if c.PrestatesDir != "" {
+		return c.PrestatesDir
+	}
+	return c.Datadir
+}
+
 func (c Config) Check() error {
+	if len(c.Chains) > 0 {
+		return c.checkChains()
+	}
 	if c.L1EthRpc == "" {
 		return ErrMissingL1EthRPC
 	}
@@ -196,6 +244,14 @@ func (c Config) Check() error {
 	if c.MaxConcurrency == 0 {
 		return ErrMaxConcurrencyZero
 	}
+	if c.GameDataRetention < 0 {
+		return ErrNegativeGameDataRetention
+	}
+	for _, vmCfg := range []vm.Config{c.Cannon, c.Asterisc, c.AsteriscKona} {
+		if err := vmCfg.Sandbox.Check(); err != nil {
+			return fmt.Errorf("invalid %v vm sandbox: %w", vmCfg.VmType, err)
+		}
+	}
 	if c.TraceTypeEnabled(types.TraceTypeCannon) || c.TraceTypeEnabled(types.TraceTypePermissioned) {
 		if c.Cannon.VmBin == "" {
 			return ErrMissingCannonBin
@@ -272,6 +328,9 @@ func (c Config) Check() error {
 			return ErrMissingAsteriscInfoFreq
 		}
 	}
+	if err := c.Coordination.Check(); err != nil {
+		return err
+	}
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
@@ -281,5 +340,8 @@ func (c Config) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.AddrCheckConfig.Check(); err != nil {
+		return err
+	}
 	return nil
 }
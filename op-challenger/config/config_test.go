@@ -5,10 +5,12 @@ import (
 	"net/url"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
@@ -351,6 +353,28 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestGameDataRetention(t *testing.T) {
+	t.Run("DefaultsToZero", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		require.Zero(t, config.GameDataRetention)
+	})
+
+	t.Run("MustNotBeNegative", func(t *testing.T) {
+		config := validConfig(types.TraceTypeAlphabet)
+		config.GameDataRetention = -time.Hour
+		require.ErrorIs(t, config.Check(), ErrNegativeGameDataRetention)
+	})
+}
+
+func TestVmSandbox(t *testing.T) {
+	config := validConfig(types.TraceTypeCannon)
+	config.Cannon.Sandbox = vm.SandboxConfig{MemoryLimit: 1 << 30, CPULimit: 2, Timeout: time.Hour}
+	require.NoError(t, config.Check())
+
+	config.Asterisc.Sandbox.Timeout = -time.Hour
+	require.ErrorIs(t, config.Check(), vm.ErrNegativeVmTimeout)
+}
+
 func TestHttpPollInterval(t *testing.T) {
 	t.Run("Default", func(t *testing.T) {
 		config := validConfig(types.TraceTypeAlphabet)
@@ -2,23 +2,30 @@ package flags
 
 import (
 	"fmt"
+	"math/big"
 	"net/url"
 	"runtime"
 	"slices"
 	"strings"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/coordination"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-service/flags"
 	"github.com/ethereum-optimism/superchain-registry/superchain"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -43,6 +50,12 @@ var (
 		Usage:   "Address of L1 Beacon API endpoint to use",
 		EnvVars: prefixEnvVars("L1_BEACON"),
 	}
+	L1BeaconFallbacksFlag = &cli.StringSliceFlag{
+		Name:    "l1-beacon-fallbacks",
+		Aliases: []string{"l1-beacon-archiver"},
+		Usage:   "Addresses of L1 Beacon-API compatible HTTP fallback endpoints, passed to the pre-image server. Used to fetch blob sidecars not available at the l1-beacon (e.g. expired blobs).",
+		EnvVars: prefixEnvVars("L1_BEACON_FALLBACKS"),
+	}
 	RollupRpcFlag = &cli.StringFlag{
 		Name:    "rollup-rpc",
 		Usage:   "HTTP provider URL for the rollup node",
@@ -72,6 +85,19 @@ var (
 		EnvVars: prefixEnvVars("DATADIR"),
 	}
 	// Optional Flags
+	GameDataRetentionFlag = &cli.DurationFlag{
+		Name: "game-data-retention",
+		Usage: "Duration to keep the data of a game, such as VM snapshots and proofs, after the game is resolved " +
+			"or leaves the game window. Data is deleted immediately by default.",
+		EnvVars: prefixEnvVars("GAME_DATA_RETENTION"),
+	}
+	OutputCacheFlag = &cli.BoolFlag{
+		Name: "output-cache",
+		Usage: "Store the output roots of finalized L2 blocks in the datadir as they are fetched from the rollup node, " +
+			"so game bisection doesn't fetch them again",
+		EnvVars: prefixEnvVars("OUTPUT_CACHE"),
+		Value:   true,
+	}
 	MaxConcurrencyFlag = &cli.UintFlag{
 		Name:    "max-concurrency",
 		Usage:   "Maximum number of threads to use when progressing games",
@@ -89,6 +115,48 @@ var (
 		Value:   config.DefaultMaxPendingTx,
 		EnvVars: prefixEnvVars("MAX_PENDING_TX"),
 	}
+	MaxTxsPerBlockFlag = &cli.Uint64Flag{
+		Name:    "max-txs-per-block",
+		Usage:   "The maximum number of transactions to send per L1 block. Further transactions wait for the next block. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_TXS_PER_BLOCK"),
+	}
+	MaxGameTxsPerBlockFlag = &cli.Uint64Flag{
+		Name:    "max-game-txs-per-block",
+		Usage:   "The maximum number of transactions to send per L1 block for a single game. Further transactions wait for the next block. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_GAME_TXS_PER_BLOCK"),
+	}
+	DailyGasBudgetFlag = &cli.Float64Flag{
+		Name:    "daily-gas-budget",
+		Usage:   "The maximum ETH to spend on transaction fees in any 24 hour window. Transactions fail once the budget is spent. 0 for no limit.",
+		EnvVars: prefixEnvVars("DAILY_GAS_BUDGET"),
+	}
+	MaxConcurrentVmExecutionsFlag = &cli.UintFlag{
+		Name:    "max-concurrent-vm-executions",
+		Usage:   "The maximum number of fault proof VM executions to run at once, across all games. 0 for no limit.",
+		EnvVars: prefixEnvVars("MAX_CONCURRENT_VM_EXECUTIONS"),
+	}
+	VmMemoryLimitFlag = &cli.Uint64Flag{
+		Name:    "vm-memory-limit-mb",
+		Usage:   "The maximum memory in MiB of each fault proof VM execution, including its pre-image server. 0 for no limit.",
+		EnvVars: prefixEnvVars("VM_MEMORY_LIMIT_MB"),
+	}
+	VmCPULimitFlag = &cli.Float64Flag{
+		Name: "vm-cpu-limit",
+		Usage: "The maximum number of CPUs each fault proof VM execution may use. 0 for no limit. " +
+			"Without a vm-cgroup-dir, this limits the CPU time of an execution to vm-cpu-limit * vm-timeout instead.",
+		EnvVars: prefixEnvVars("VM_CPU_LIMIT"),
+	}
+	VmTimeoutFlag = &cli.DurationFlag{
+		Name:    "vm-timeout",
+		Usage:   "The maximum duration of each fault proof VM execution. 0 for no limit.",
+		EnvVars: prefixEnvVars("VM_TIMEOUT"),
+	}
+	VmCgroupDirFlag = &cli.PathFlag{
+		Name: "vm-cgroup-dir",
+		Usage: "Linux only. A cgroup v2 directory delegated to the challenger, to enforce the VM limits with a cgroup per execution. " +
+			"The memory and cpu controllers must be enabled in its cgroup.subtree_control. Without it, the limits are enforced with rlimits.",
+		EnvVars: prefixEnvVars("VM_CGROUP_DIR"),
+	}
 	HTTPPollInterval = &cli.DurationFlag{
 		Name:    "http-poll-interval",
 		Usage:   "Polling interval for latest-block subscription when using an HTTP RPC provider.",
@@ -224,11 +292,61 @@ var (
 		EnvVars: prefixEnvVars("GAME_WINDOW"),
 		Value:   config.DefaultGameWindow,
 	}
+	GameFactoryLogRangeFlag = &cli.Uint64Flag{
+		Name: "game-factory-log-range",
+		Usage: "Maximum number of L1 blocks to scan for new games in a single eth_getLogs request. " +
+			"The scan position is stored in the datadir so restarts resume from it. " +
+			"Set to 0 to load the games in the game window by index on every L1 block instead.",
+		EnvVars: prefixEnvVars("GAME_FACTORY_LOG_RANGE"),
+		Value:   config.DefaultGameFactoryLogRange,
+	}
 	SelectiveClaimResolutionFlag = &cli.BoolFlag{
 		Name:    "selective-claim-resolution",
 		Usage:   "Only resolve claims for the configured claimants",
 		EnvVars: prefixEnvVars("SELECTIVE_CLAIM_RESOLUTION"),
 	}
+	ResolutionBatchGasLimitFlag = &cli.Uint64Flag{
+		Name:    "resolution-batch-gas-limit",
+		Usage:   "Maximum gas of claim resolutions to batch in a single transaction via Multicall3. 0 to resolve claims individually.",
+		EnvVars: prefixEnvVars("RESOLUTION_BATCH_GAS_LIMIT"),
+		Value:   config.DefaultResolutionBatchGasLimit,
+	}
+	Multicall3AddressFlag = &cli.StringFlag{
+		Name:    "multicall3-address",
+		Usage:   "Address of the Multicall3 contract used to batch claim resolutions. Claims are resolved individually if it is not deployed.",
+		EnvVars: prefixEnvVars("MULTICALL3_ADDRESS"),
+		Value:   contracts.DefaultMulticall3Address.Hex(),
+	}
+	ChainsFlag = &cli.PathFlag{
+		Name: "chains",
+		Usage: "Path of a JSON file listing multiple chains to monitor, each with its name, game factory address, rollup and L2 RPCs, " +
+			"network or rollup config and L2 genesis, and optionally prestates. Replaces the flags configuring a single chain.",
+		EnvVars:   prefixEnvVars("CHAINS"),
+		TakesFile: true,
+	}
+	CoordinationListenAddrFlag = &cli.StringFlag{
+		Name: "coordination.listen-addr",
+		Usage: "libp2p multiaddr to listen on for announcements of the claims other honest challengers are countering, " +
+			"e.g. /ip4/0.0.0.0/tcp/9223. Claims announced by the coordination peers are not countered again, " +
+			"unless the announcement expires first. Coordination is disabled if not set.",
+		EnvVars: prefixEnvVars("COORDINATION_LISTEN_ADDR"),
+	}
+	CoordinationPrivKeyFlag = &cli.StringFlag{
+		Name:    "coordination.priv-key",
+		Usage:   "Hex encoded secp256k1 private key of the libp2p identity to sign announcements with.",
+		EnvVars: prefixEnvVars("COORDINATION_PRIV_KEY"),
+	}
+	CoordinationPeersFlag = &cli.StringSliceFlag{
+		Name:    "coordination.peers",
+		Usage:   "libp2p multiaddrs, including the /p2p/ peer ID, of the other honest challengers to coordinate with.",
+		EnvVars: prefixEnvVars("COORDINATION_PEERS"),
+	}
+	CoordinationTTLFlag = &cli.DurationFlag{
+		Name:    "coordination.ttl",
+		Usage:   "Duration after which an announcement expires, if the announced claim was not countered yet.",
+		EnvVars: prefixEnvVars("COORDINATION_TTL"),
+		Value:   config.DefaultCoordinationTTL,
+	}
 	UnsafeAllowInvalidPrestate = &cli.BoolFlag{
 		Name:    "unsafe-allow-invalid-prestate",
 		Usage:   "Allow responding to games where the absolute prestate is configured incorrectly. THIS IS UNSAFE!",
@@ -250,9 +368,20 @@ var optionalFlags = []cli.Flag{
 	NetworkFlag,
 	FactoryAddressFlag,
 	TraceTypeFlag,
+	GameDataRetentionFlag,
+	OutputCacheFlag,
 	MaxConcurrencyFlag,
 	L2EthRpcFlag,
+	L1BeaconFallbacksFlag,
 	MaxPendingTransactionsFlag,
+	MaxTxsPerBlockFlag,
+	MaxGameTxsPerBlockFlag,
+	DailyGasBudgetFlag,
+	MaxConcurrentVmExecutionsFlag,
+	VmMemoryLimitFlag,
+	VmCPULimitFlag,
+	VmTimeoutFlag,
+	VmCgroupDirFlag,
 	HTTPPollInterval,
 	AdditionalBondClaimants,
 	GameAllowlistFlag,
@@ -279,15 +408,40 @@ var optionalFlags = []cli.Flag{
 	AsteriscSnapshotFreqFlag,
 	AsteriscInfoFreqFlag,
 	GameWindowFlag,
+	GameFactoryLogRangeFlag,
 	SelectiveClaimResolutionFlag,
+	ResolutionBatchGasLimitFlag,
+	Multicall3AddressFlag,
+	ChainsFlag,
+	CoordinationListenAddrFlag,
+	CoordinationPrivKeyFlag,
+	CoordinationPeersFlag,
+	CoordinationTTLFlag,
 	UnsafeAllowInvalidPrestate,
 }
 
+// chainFlags configure the single chain to monitor, so can't be used with ChainsFlag.
+var chainFlags = []cli.Flag{
+	RollupRpcFlag,
+	L2EthRpcFlag,
+	CannonL2Flag,
+	NetworkFlag,
+	FactoryAddressFlag,
+	GameAllowlistFlag,
+	CannonNetworkFlag,
+	CannonRollupConfigFlag,
+	CannonL2GenesisFlag,
+	AsteriscNetworkFlag,
+	AsteriscRollupConfigFlag,
+	AsteriscL2GenesisFlag,
+}
+
 func init() {
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlagsWithDefaults(EnvVarPrefix, txmgr.DefaultChallengerFlagValues)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, addrcheck.CLIFlags(EnvVarPrefix, "")...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -360,6 +514,9 @@ func CheckAsteriscFlags(ctx *cli.Context) error {
 }
 
 func CheckRequired(ctx *cli.Context, traceTypes []types.TraceType) error {
+	if ctx.IsSet(ChainsFlag.Name) {
+		return checkRequiredMultiChain(ctx, traceTypes)
+	}
 	for _, f := range requiredFlags {
 		if !ctx.IsSet(f.Names()[0]) {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
@@ -387,6 +544,43 @@ func CheckRequired(ctx *cli.Context, traceTypes []types.TraceType) error {
 	return nil
 }
 
+// checkRequiredMultiChain checks the flags shared by all chains when monitoring multiple chains.
+// The chain specific config is checked once loaded, by [config.Config.Check].
+func checkRequiredMultiChain(ctx *cli.Context, traceTypes []types.TraceType) error {
+	for _, f := range requiredFlags {
+		if f != RollupRpcFlag && !ctx.IsSet(f.Names()[0]) {
+			return fmt.Errorf("flag %s is required", f.Names()[0])
+		}
+	}
+	for _, f := range chainFlags {
+		if ctx.IsSet(f.Names()[0]) {
+			return fmt.Errorf("flag %v can not be used with %v, configure it for each chain instead", f.Names()[0], ChainsFlag.Name)
+		}
+	}
+	for _, traceType := range traceTypes {
+		switch traceType {
+		case types.TraceTypeCannon, types.TraceTypePermissioned:
+			if !ctx.IsSet(CannonBinFlag.Name) {
+				return fmt.Errorf("flag %s is required", CannonBinFlag.Name)
+			}
+			if !ctx.IsSet(CannonServerFlag.Name) {
+				return fmt.Errorf("flag %s is required", CannonServerFlag.Name)
+			}
+		case types.TraceTypeAsterisc:
+			if !ctx.IsSet(AsteriscBinFlag.Name) {
+				return fmt.Errorf("flag %s is required", AsteriscBinFlag.Name)
+			}
+			if !ctx.IsSet(AsteriscServerFlag.Name) {
+				return fmt.Errorf("flag %s is required", AsteriscServerFlag.Name)
+			}
+		case types.TraceTypeAlphabet, types.TraceTypeFast:
+		default:
+			return fmt.Errorf("invalid trace type %v. must be one of %v", traceType, types.TraceTypes)
+		}
+	}
+	return nil
+}
+
 func parseTraceTypes(ctx *cli.Context) ([]types.TraceType, error) {
 	var traceTypes []types.TraceType
 	for _, typeName := range ctx.StringSlice(TraceTypeFlag.Name) {
@@ -426,25 +620,52 @@ func FactoryAddress(ctx *cli.Context) (common.Address, error) {
 		return gameFactoryAddress, nil
 	}
 	if ctx.IsSet(flags.NetworkFlagName) {
-		chainName := ctx.String(flags.NetworkFlagName)
-		chainCfg := chaincfg.ChainByName(chainName)
-		if chainCfg == nil {
-			var opts []string
-			for _, cfg := range superchain.OPChains {
-				opts = append(opts, cfg.Chain+"-"+cfg.Superchain)
-			}
-			return common.Address{}, fmt.Errorf("unknown chain: %v (Valid options: %v)", chainName, strings.Join(opts, ", "))
+		return networkFactoryAddress(ctx.String(flags.NetworkFlagName))
+	}
+	return common.Address{}, fmt.Errorf("flag %v or %v is required", FactoryAddressFlag.Name, flags.NetworkFlagName)
+}
+
+// networkFactoryAddress returns the dispute game factory address of a chain in the superchain registry.
+func networkFactoryAddress(chainName string) (common.Address, error) {
+	chainCfg := chaincfg.ChainByName(chainName)
+	if chainCfg == nil {
+		var opts []string
+		for _, cfg := range superchain.OPChains {
+			opts = append(opts, cfg.Chain+"-"+cfg.Superchain)
 		}
-		addrs, ok := superchain.Addresses[chainCfg.ChainID]
-		if !ok {
-			return common.Address{}, fmt.Errorf("no addresses available for chain %v", chainName)
+		return common.Address{}, fmt.Errorf("unknown chain: %v (Valid options: %v)", chainName, strings.Join(opts, ", "))
+	}
+	addrs, ok := superchain.Addresses[chainCfg.ChainID]
+	if !ok {
+		return common.Address{}, fmt.Errorf("no addresses available for chain %v", chainName)
+	}
+	if addrs.DisputeGameFactoryProxy == (superchain.Address{}) {
+		return common.Address{}, fmt.Errorf("dispute factory proxy not available for chain %v", chainName)
+	}
+	return common.Address(addrs.DisputeGameFactoryProxy), nil
+}
+
+// loadChains loads the chains to monitor from the JSON file of ChainsFlag.
+// Chains that specify a network but no game factory address use the factory of the network.
+func loadChains(path string) ([]config.ChainConfig, error) {
+	chains, err := jsonutil.LoadJSON[[]config.ChainConfig](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chains: %w", err)
+	}
+	if len(*chains) == 0 {
+		return nil, fmt.Errorf("no chains configured in %v", path)
+	}
+	for i, chain := range *chains {
+		if chain.GameFactoryAddress != (common.Address{}) || chain.Network == "" {
+			continue
 		}
-		if addrs.DisputeGameFactoryProxy == (superchain.Address{}) {
-			return common.Address{}, fmt.Errorf("dispute factory proxy not available for chain %v", chainName)
+		addr, err := networkFactoryAddress(chain.Network)
+		if err != nil {
+			return nil, fmt.Errorf("chain %v: %w", chain.Name, err)
 		}
-		return common.Address(addrs.DisputeGameFactoryProxy), nil
+		(*chains)[i].GameFactoryAddress = addr
 	}
-	return common.Address{}, fmt.Errorf("flag %v or %v is required", FactoryAddressFlag.Name, flags.NetworkFlagName)
+	return *chains, nil
 }
 
 // NewConfigFromCLI parses the Config from the provided flags or environment variables.
@@ -456,7 +677,13 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 	if err := CheckRequired(ctx, traceTypes); err != nil {
 		return nil, err
 	}
-	gameFactoryAddress, err := FactoryAddress(ctx)
+	var gameFactoryAddress common.Address
+	var chains []config.ChainConfig
+	if ctx.IsSet(ChainsFlag.Name) {
+		chains, err = loadChains(ctx.Path(ChainsFlag.Name))
+	} else {
+		gameFactoryAddress, err = FactoryAddress(ctx)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -479,6 +706,10 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 	if maxConcurrency == 0 {
 		return nil, fmt.Errorf("%v must not be 0", MaxConcurrencyFlag.Name)
 	}
+	multicall3Address, err := opservice.ParseAddress(ctx.String(Multicall3AddressFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v: %w", Multicall3AddressFlag.Name, err)
+	}
 	var claimants []common.Address
 	if ctx.IsSet(AdditionalBondClaimants.Name) {
 		for _, addrStr := range ctx.StringSlice(AdditionalBondClaimants.Name) {
@@ -525,73 +756,113 @@ func NewConfigFromCLI(ctx *cli.Context, logger log.Logger) (*config.Config, erro
 	if ctx.IsSet(flags.NetworkFlagName) {
 		asteriscNetwork = ctx.String(flags.NetworkFlagName)
 	}
+	var dailyGasBudget *big.Int
+	if budget := ctx.Float64(DailyGasBudgetFlag.Name); budget < 0 {
+		return nil, fmt.Errorf("invalid %v: must not be negative", DailyGasBudgetFlag.Name)
+	} else if budget > 0 {
+		dailyGasBudget, err = eth.GweiToWei(budget * params.GWei)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v: %w", DailyGasBudgetFlag.Name, err)
+		}
+	}
+	vmSandbox := vm.SandboxConfig{
+		MemoryLimit: ctx.Uint64(VmMemoryLimitFlag.Name) * 1024 * 1024,
+		CPULimit:    ctx.Float64(VmCPULimitFlag.Name),
+		Timeout:     ctx.Duration(VmTimeoutFlag.Name),
+		CgroupDir:   ctx.Path(VmCgroupDirFlag.Name),
+	}
 	l1EthRpc := ctx.String(L1EthRpcFlag.Name)
 	l1Beacon := ctx.String(L1BeaconFlag.Name)
+	l1BeaconFallbacks := ctx.StringSlice(L1BeaconFallbacksFlag.Name)
 	return &config.Config{
 		// Required Flags
-		L1EthRpc:                l1EthRpc,
-		L1Beacon:                l1Beacon,
-		TraceTypes:              traceTypes,
-		GameFactoryAddress:      gameFactoryAddress,
-		GameAllowlist:           allowedGames,
-		GameWindow:              ctx.Duration(GameWindowFlag.Name),
-		MaxConcurrency:          maxConcurrency,
-		L2Rpc:                   l2Rpc,
-		MaxPendingTx:            ctx.Uint64(MaxPendingTransactionsFlag.Name),
-		PollInterval:            ctx.Duration(HTTPPollInterval.Name),
-		AdditionalBondClaimants: claimants,
-		RollupRpc:               ctx.String(RollupRpcFlag.Name),
+		L1EthRpc:                  l1EthRpc,
+		L1Beacon:                  l1Beacon,
+		TraceTypes:                traceTypes,
+		GameFactoryAddress:        gameFactoryAddress,
+		GameAllowlist:             allowedGames,
+		GameWindow:                ctx.Duration(GameWindowFlag.Name),
+		GameFactoryLogRange:       ctx.Uint64(GameFactoryLogRangeFlag.Name),
+		MaxConcurrency:            maxConcurrency,
+		L2Rpc:                     l2Rpc,
+		MaxPendingTx:              ctx.Uint64(MaxPendingTransactionsFlag.Name),
+		MaxTxsPerBlock:            ctx.Uint64(MaxTxsPerBlockFlag.Name),
+		MaxGameTxsPerBlock:        ctx.Uint64(MaxGameTxsPerBlockFlag.Name),
+		DailyGasBudget:            dailyGasBudget,
+		MaxConcurrentVmExecutions: ctx.Uint(MaxConcurrentVmExecutionsFlag.Name),
+		PollInterval:              ctx.Duration(HTTPPollInterval.Name),
+		AdditionalBondClaimants:   claimants,
+		RollupRpc:                 ctx.String(RollupRpcFlag.Name),
+		Chains:                    chains,
 		Cannon: vm.Config{
-			VmType:           types.TraceTypeCannon,
-			L1:               l1EthRpc,
-			L1Beacon:         l1Beacon,
-			L2:               l2Rpc,
-			VmBin:            ctx.String(CannonBinFlag.Name),
-			Server:           ctx.String(CannonServerFlag.Name),
-			Network:          cannonNetwork,
-			RollupConfigPath: ctx.String(CannonRollupConfigFlag.Name),
-			L2GenesisPath:    ctx.String(CannonL2GenesisFlag.Name),
-			SnapshotFreq:     ctx.Uint(CannonSnapshotFreqFlag.Name),
-			InfoFreq:         ctx.Uint(CannonInfoFreqFlag.Name),
-			DebugInfo:        true,
+			VmType:            types.TraceTypeCannon,
+			L1:                l1EthRpc,
+			L1Beacon:          l1Beacon,
+			L1BeaconFallbacks: l1BeaconFallbacks,
+			L2:                l2Rpc,
+			VmBin:             ctx.String(CannonBinFlag.Name),
+			Server:            ctx.String(CannonServerFlag.Name),
+			Network:           cannonNetwork,
+			RollupConfigPath:  ctx.String(CannonRollupConfigFlag.Name),
+			L2GenesisPath:     ctx.String(CannonL2GenesisFlag.Name),
+			SnapshotFreq:      ctx.Uint(CannonSnapshotFreqFlag.Name),
+			InfoFreq:          ctx.Uint(CannonInfoFreqFlag.Name),
+			DebugInfo:         true,
+			ExitReport:        true,
+			Sandbox:           vmSandbox,
 		},
 		CannonAbsolutePreState:        ctx.String(CannonPreStateFlag.Name),
 		CannonAbsolutePreStateBaseURL: cannonPrestatesURL,
 		Datadir:                       ctx.String(DatadirFlag.Name),
+		GameDataRetention:             ctx.Duration(GameDataRetentionFlag.Name),
+		OutputCache:                   ctx.Bool(OutputCacheFlag.Name),
 		Asterisc: vm.Config{
-			VmType:           types.TraceTypeAsterisc,
-			L1:               l1EthRpc,
-			L1Beacon:         l1Beacon,
-			L2:               l2Rpc,
-			VmBin:            ctx.String(AsteriscBinFlag.Name),
-			Server:           ctx.String(AsteriscServerFlag.Name),
-			Network:          asteriscNetwork,
-			RollupConfigPath: ctx.String(AsteriscRollupConfigFlag.Name),
-			L2GenesisPath:    ctx.String(AsteriscL2GenesisFlag.Name),
-			SnapshotFreq:     ctx.Uint(AsteriscSnapshotFreqFlag.Name),
-			InfoFreq:         ctx.Uint(AsteriscInfoFreqFlag.Name),
+			VmType:            types.TraceTypeAsterisc,
+			L1:                l1EthRpc,
+			L1Beacon:          l1Beacon,
+			L1BeaconFallbacks: l1BeaconFallbacks,
+			L2:                l2Rpc,
+			VmBin:             ctx.String(AsteriscBinFlag.Name),
+			Server:            ctx.String(AsteriscServerFlag.Name),
+			Network:           asteriscNetwork,
+			RollupConfigPath:  ctx.String(AsteriscRollupConfigFlag.Name),
+			L2GenesisPath:     ctx.String(AsteriscL2GenesisFlag.Name),
+			SnapshotFreq:      ctx.Uint(AsteriscSnapshotFreqFlag.Name),
+			InfoFreq:          ctx.Uint(AsteriscInfoFreqFlag.Name),
+			Sandbox:           vmSandbox,
 		},
 		AsteriscAbsolutePreState:        ctx.String(AsteriscPreStateFlag.Name),
 		AsteriscAbsolutePreStateBaseURL: asteriscPreStatesURL,
 		AsteriscKona: vm.Config{
-			VmType:           types.TraceTypeAsteriscKona,
-			L1:               l1EthRpc,
-			L1Beacon:         l1Beacon,
-			L2:               l2Rpc,
-			VmBin:            ctx.String(AsteriscBinFlag.Name),
-			Server:           ctx.String(AsteriscKonaServerFlag.Name),
-			Network:          asteriscNetwork,
-			RollupConfigPath: ctx.String(AsteriscRollupConfigFlag.Name),
-			L2GenesisPath:    ctx.String(AsteriscL2GenesisFlag.Name),
-			SnapshotFreq:     ctx.Uint(AsteriscSnapshotFreqFlag.Name),
-			InfoFreq:         ctx.Uint(AsteriscInfoFreqFlag.Name),
+			VmType:            types.TraceTypeAsteriscKona,
+			L1:                l1EthRpc,
+			L1Beacon:          l1Beacon,
+			L1BeaconFallbacks: l1BeaconFallbacks,
+			L2:                l2Rpc,
+			VmBin:             ctx.String(AsteriscBinFlag.Name),
+			Server:            ctx.String(AsteriscKonaServerFlag.Name),
+			Network:           asteriscNetwork,
+			RollupConfigPath:  ctx.String(AsteriscRollupConfigFlag.Name),
+			L2GenesisPath:     ctx.String(AsteriscL2GenesisFlag.Name),
+			SnapshotFreq:      ctx.Uint(AsteriscSnapshotFreqFlag.Name),
+			InfoFreq:          ctx.Uint(AsteriscInfoFreqFlag.Name),
+			Sandbox:           vmSandbox,
 		},
 		AsteriscKonaAbsolutePreState:        ctx.String(AsteriscKonaPreStateFlag.Name),
 		AsteriscKonaAbsolutePreStateBaseURL: asteriscKonaPreStatesURL,
 		TxMgrConfig:                         txMgrConfig,
 		MetricsConfig:                       metricsConfig,
 		PprofConfig:                         pprofConfig,
+		AddrCheckConfig:                     addrcheck.ReadCLIConfig(ctx),
 		SelectiveClaimResolution:            ctx.Bool(SelectiveClaimResolutionFlag.Name),
+		ResolutionBatchGasLimit:             ctx.Uint64(ResolutionBatchGasLimitFlag.Name),
+		Multicall3Address:                   multicall3Address,
 		AllowInvalidPrestate:                ctx.Bool(UnsafeAllowInvalidPrestate.Name),
+		Coordination: coordination.Config{
+			ListenAddr: ctx.String(CoordinationListenAddrFlag.Name),
+			PrivKey:    ctx.String(CoordinationPrivKeyFlag.Name),
+			Peers:      ctx.StringSlice(CoordinationPeersFlag.Name),
+			TTL:        ctx.Duration(CoordinationTTLFlag.Name),
+		},
 	}, nil
 }
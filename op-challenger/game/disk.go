@@ -3,35 +3,118 @@ package game
 import (
 	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 )
 
-const gameDirPrefix = "game-"
+const (
+	gameDirPrefix = "game-"
+	// inactiveMarkerFile records the unix timestamp at which a game's data was first no longer required.
+	// It is stored in the game directory so the retention period survives restarts.
+	inactiveMarkerFile = ".inactive-since"
+)
 
-// diskManager coordinates the storage of game data on disk.
-type diskManager struct {
-	datadir string
+// DiskManager coordinates the storage of game data on disk.
+type DiskManager struct {
+	datadir   string
+	retention time.Duration
+	clock     clock.Clock
 }
 
-func newDiskManager(dir string) *diskManager {
-	return &diskManager{datadir: dir}
+// NewDiskManager creates a DiskManager for the game data in dir.
+// The data of games that are no longer required is kept for the retention period before being deleted.
+func NewDiskManager(dir string, retention time.Duration, clock clock.Clock) *DiskManager {
+	return &DiskManager{datadir: dir, retention: retention, clock: clock}
 }
 
-func (d *diskManager) DirForGame(addr common.Address) string {
+func (d *DiskManager) DirForGame(addr common.Address) string {
 	return filepath.Join(d.datadir, gameDirPrefix+addr.Hex())
 }
 
-func (d *diskManager) RemoveAllExcept(keep []common.Address) error {
-	entries, err := os.ReadDir(d.datadir)
+// RemoveAllExcept deletes the data of all games other than keep, once it has been retained for the retention period.
+func (d *DiskManager) RemoveAllExcept(keep []common.Address) error {
+	games, err := d.gameDirs()
+	if err != nil {
+		return err
+	}
+	now := d.clock.Now()
+	var errs []error
+	for _, addr := range games {
+		dir := d.DirForGame(addr)
+		markerPath := filepath.Join(dir, inactiveMarkerFile)
+		if slices.Contains(keep, addr) {
+			// Preserve data for games we should keep.
+			if err := os.Remove(markerPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("failed to clear inactive marker of game %v: %w", addr, err))
+			}
+			continue
+		}
+		if d.retention <= 0 {
+			errs = append(errs, os.RemoveAll(dir))
+			continue
+		}
+		since, err := readInactiveSince(markerPath)
+		if errors.Is(err, fs.ErrNotExist) {
+			since = now
+			err = os.WriteFile(markerPath, []byte(strconv.FormatInt(since.Unix(), 10)), 0644)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to record inactive time of game %v: %w", addr, err))
+			continue
+		}
+		if now.Sub(since) >= d.retention {
+			errs = append(errs, os.RemoveAll(dir))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Usage returns the disk space used by the data of each game.
+func (d *DiskManager) Usage() ([]scheduler.GameDiskUsage, error) {
+	games, err := d.gameDirs()
 	if err != nil {
-		return fmt.Errorf("failed to list directory: %w", err)
+		return nil, err
 	}
 	var errs []error
+	usage := make([]scheduler.GameDiskUsage, 0, len(games))
+	for _, addr := range games {
+		dir := d.DirForGame(addr)
+		size, err := dirSize(dir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to measure data of game %v: %w", addr, err))
+			continue
+		}
+		gameUsage := scheduler.GameDiskUsage{Game: addr, Bytes: size}
+		if since, err := readInactiveSince(filepath.Join(dir, inactiveMarkerFile)); err == nil {
+			gameUsage.InactiveSince = since
+		}
+		usage = append(usage, gameUsage)
+	}
+	return usage, errors.Join(errs...)
+}
+
+// RemoveGame deletes the data of the game, regardless of the retention period.
+func (d *DiskManager) RemoveGame(addr common.Address) error {
+	return os.RemoveAll(d.DirForGame(addr))
+}
+
+// gameDirs returns the addresses of the games with a data directory.
+func (d *DiskManager) gameDirs() ([]common.Address, error) {
+	entries, err := os.ReadDir(d.datadir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list directory: %w", err)
+	}
+	var games []common.Address
 	for _, entry := range entries {
 		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), gameDirPrefix) {
 			// Skip files and directories that don't have the game directory prefix.
@@ -45,11 +128,43 @@ func (d *diskManager) RemoveAllExcept(keep []common.Address) error {
 			// Ignore directories with non-address names.
 			continue
 		}
-		if slices.Contains(keep, addr) {
-			// Preserve data for games we should keep.
-			continue
-		}
-		errs = append(errs, os.RemoveAll(filepath.Join(d.datadir, entry.Name())))
+		games = append(games, addr)
 	}
-	return errors.Join(errs...)
+	return games, nil
+}
+
+func readInactiveSince(path string) (time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	since, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid inactive marker %v: %w", path, err)
+	}
+	return time.Unix(since, 0), nil
+}
+
+func dirSize(dir string) (uint64, error) {
+	var size uint64
+	err := filepath.WalkDir(dir, func(_ string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			// Files may be deleted while the game is progressed
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
 }
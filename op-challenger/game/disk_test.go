@@ -4,15 +4,19 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 )
 
 func TestDiskManager_DirForGame(t *testing.T) {
 	baseDir := t.TempDir()
 	addr := common.Address{0x53}
-	disk := newDiskManager(baseDir)
+	disk := NewDiskManager(baseDir, 0, clock.NewDeterministicClock(time.Unix(1000, 0)))
 	result := disk.DirForGame(addr)
 	require.Equal(t, filepath.Join(baseDir, gameDirPrefix+addr.Hex()), result)
 }
@@ -21,7 +25,7 @@ func TestDiskManager_RemoveAllExcept(t *testing.T) {
 	baseDir := t.TempDir()
 	keep := common.Address{0x53}
 	delete := common.Address{0xaa}
-	disk := newDiskManager(baseDir)
+	disk := NewDiskManager(baseDir, 0, clock.NewDeterministicClock(time.Unix(1000, 0)))
 	keepDir := disk.DirForGame(keep)
 	deleteDir := disk.DirForGame(delete)
 
@@ -55,3 +59,67 @@ func TestDiskManager_RemoveAllExcept(t *testing.T) {
 	require.DirExists(t, unexpectedDir, "should not delete unexpected dir")
 	require.DirExists(t, invalidHexDir, "should not delete dir with invalid address")
 }
+
+func TestDiskManager_RemoveAllExceptWithRetention(t *testing.T) {
+	baseDir := t.TempDir()
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	disk := NewDiskManager(baseDir, time.Hour, cl)
+	active := common.Address{0x53}
+	inactive := common.Address{0xaa}
+	for _, addr := range []common.Address{active, inactive} {
+		require.NoError(t, os.MkdirAll(disk.DirForGame(addr), 0777))
+		require.NoError(t, os.WriteFile(filepath.Join(disk.DirForGame(addr), "proof.json"), []byte("proof"), 0644))
+	}
+
+	// Data of inactive games is retained, and the time they became inactive is recorded
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	require.DirExists(t, disk.DirForGame(inactive))
+	require.FileExists(t, filepath.Join(disk.DirForGame(inactive), inactiveMarkerFile))
+	require.NoFileExists(t, filepath.Join(disk.DirForGame(active), inactiveMarkerFile))
+
+	// The recorded time survives restarts
+	cl.AdvanceTime(59 * time.Minute)
+	disk = NewDiskManager(baseDir, time.Hour, cl)
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	require.DirExists(t, disk.DirForGame(inactive))
+
+	// Games that become active again are no longer inactive
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active, inactive}))
+	require.NoFileExists(t, filepath.Join(disk.DirForGame(inactive), inactiveMarkerFile))
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+
+	// Data is deleted once the retention period has passed
+	cl.AdvanceTime(59 * time.Minute)
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	require.DirExists(t, disk.DirForGame(inactive))
+	cl.AdvanceTime(time.Minute)
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	require.NoDirExists(t, disk.DirForGame(inactive))
+	require.DirExists(t, disk.DirForGame(active))
+}
+
+func TestDiskManager_Usage(t *testing.T) {
+	baseDir := t.TempDir()
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	disk := NewDiskManager(baseDir, time.Hour, cl)
+	active := common.Address{0x53}
+	inactive := common.Address{0xaa}
+	require.NoError(t, os.MkdirAll(filepath.Join(disk.DirForGame(active), "snapshots"), 0777))
+	require.NoError(t, os.WriteFile(filepath.Join(disk.DirForGame(active), "proof.json"), make([]byte, 100), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(disk.DirForGame(active), "snapshots", "1.json"), make([]byte, 20), 0644))
+	require.NoError(t, os.MkdirAll(disk.DirForGame(inactive), 0777))
+	require.NoError(t, os.MkdirAll(filepath.Join(baseDir, "notagame"), 0777))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, "notagame", "file.txt"), make([]byte, 1000), 0644))
+
+	require.NoError(t, disk.RemoveAllExcept([]common.Address{active}))
+	usage, err := disk.Usage()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []scheduler.GameDiskUsage{
+		{Game: active, Bytes: 120},
+		// Only the inactive marker is stored
+		{Game: inactive, Bytes: 4, InactiveSince: time.Unix(1000, 0)},
+	}, usage)
+
+	require.NoError(t, disk.RemoveGame(active))
+	require.NoDirExists(t, disk.DirForGame(active))
+}
@@ -0,0 +1,314 @@
+package factoryscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+const (
+	cursorVersion = 1
+	// maxCheckpoints is the number of recently scanned blocks to keep, to find a common ancestor after a reorg.
+	maxCheckpoints = 64
+	cursorFilePerm = 0o644
+)
+
+// errInconsistent is returned when the scan can't be continued, and games must be reloaded from the factory.
+var errInconsistent = errors.New("game scan inconsistent with factory")
+
+type Factory interface {
+	GetGameCount(ctx context.Context, blockHash common.Hash) (uint64, error)
+	GetGamesAtOrAfter(ctx context.Context, blockHash common.Hash, earliestTimestamp uint64) ([]types.GameMetadata, error)
+	GameCreatedFilter(fromBlock uint64, toBlock uint64) ethereum.FilterQuery
+	DecodeGameCreatedLog(log *ethTypes.Log) (common.Address, uint32, error)
+}
+
+type L1Source interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*ethTypes.Header, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethTypes.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error)
+}
+
+// Checkpoint is a scanned L1 block, and the index of the first game created after it.
+type Checkpoint struct {
+	Number    uint64      `json:"number"`
+	Hash      common.Hash `json:"hash"`
+	NextIndex uint64      `json:"nextIndex"`
+}
+
+// Cursor is the progress of the scan, persisted so that restarts resume where the scan left off.
+type Cursor struct {
+	Version int            `json:"version"`
+	Factory common.Address `json:"factory"`
+	// EarliestTimestamp is the earliest creation time of games included in Games.
+	EarliestTimestamp uint64 `json:"earliestTimestamp"`
+	// Checkpoints are the most recently scanned blocks, oldest first. The last one is the head of the scan.
+	Checkpoints []Checkpoint `json:"checkpoints"`
+	// Games are the games created from EarliestTimestamp up to the head of the scan, by ascending index.
+	Games []types.GameMetadata `json:"games"`
+}
+
+func (c *Cursor) head() Checkpoint {
+	return c.Checkpoints[len(c.Checkpoints)-1]
+}
+
+// Scanner tracks the games created by the DisputeGameFactory from its DisputeGameCreated events.
+// Games are loaded from the factory by index once, and then kept up to date by scanning the logs of new blocks,
+// in chunks of at most logRange blocks. The cursor is persisted after each chunk, if a path is set.
+type Scanner struct {
+	logger      log.Logger
+	factoryAddr common.Address
+	factory     Factory
+	l1          L1Source
+	path        string
+	logRange    uint64
+
+	mu     sync.Mutex
+	cursor *Cursor
+}
+
+func NewScanner(logger log.Logger, factoryAddr common.Address, factory Factory, l1 L1Source, path string, logRange uint64) *Scanner {
+	s := &Scanner{
+		logger:      logger,
+		factoryAddr: factoryAddr,
+		factory:     factory,
+		l1:          l1,
+		path:        path,
+		logRange:    max(logRange, 1),
+	}
+	cursor, err := loadCursor(path, factoryAddr)
+	if err != nil {
+		logger.Warn("Ignoring game scan cursor, games will be reloaded from the factory", "path", path, "err", err)
+	} else if cursor != nil {
+		logger.Info("Resuming game scan", "block", cursor.head().Number, "games", len(cursor.Games))
+	}
+	s.cursor = cursor
+	return s
+}
+
+func loadCursor(path string, factoryAddr common.Address) (*Cursor, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	cursor, err := jsonutil.LoadJSON[Cursor](path)
+	if err != nil {
+		return nil, err
+	}
+	if cursor.Version != cursorVersion {
+		return nil, fmt.Errorf("unsupported cursor version %v", cursor.Version)
+	}
+	if cursor.Factory != factoryAddr {
+		return nil, fmt.Errorf("cursor is for factory %v", cursor.Factory)
+	}
+	if len(cursor.Checkpoints) == 0 {
+		return nil, errors.New("cursor has no checkpoints")
+	}
+	return cursor, nil
+}
+
+// GetGamesAtOrAfter returns the games created at or after earliestTimestamp, up to the specified block,
+// ordered from the newest to the oldest game.
+func (s *Scanner) GetGamesAtOrAfter(ctx context.Context, blockHash common.Hash, earliestTimestamp uint64) ([]types.GameMetadata, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	head, err := s.l1.HeaderByHash(ctx, blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load block %v: %w", blockHash, err)
+	}
+	if err := s.update(ctx, head, earliestTimestamp); err != nil {
+		return nil, err
+	}
+	s.prune(earliestTimestamp)
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	games := slices.Clone(s.cursor.Games)
+	slices.Reverse(games)
+	return games, nil
+}
+
+func (s *Scanner) update(ctx context.Context, head *ethTypes.Header, earliestTimestamp uint64) error {
+	if s.cursor != nil && earliestTimestamp < s.cursor.EarliestTimestamp {
+		s.logger.Info("Game window starts before scanned games, reloading games from the factory",
+			"earliest", earliestTimestamp, "scanned", s.cursor.EarliestTimestamp)
+		s.cursor = nil
+	}
+	if s.cursor != nil {
+		err := s.scan(ctx, head)
+		if !errors.Is(err, errInconsistent) {
+			return err
+		}
+		s.logger.Warn("Reloading games from the factory", "err", err)
+	}
+	return s.seed(ctx, head, earliestTimestamp)
+}
+
+// seed loads the games from the factory by index, and starts the scan from head.
+func (s *Scanner) seed(ctx context.Context, head *ethTypes.Header, earliestTimestamp uint64) error {
+	games, err := s.factory.GetGamesAtOrAfter(ctx, head.Hash(), earliestTimestamp)
+	if err != nil {
+		return err
+	}
+	count, err := s.factory.GetGameCount(ctx, head.Hash())
+	if err != nil {
+		return err
+	}
+	slices.Reverse(games)
+	s.cursor = &Cursor{
+		Version:           cursorVersion,
+		Factory:           s.factoryAddr,
+		EarliestTimestamp: earliestTimestamp,
+		Checkpoints:       []Checkpoint{{Number: head.Number.Uint64(), Hash: head.Hash(), NextIndex: count}},
+		Games:             games,
+	}
+	s.logger.Info("Loaded games from the factory", "block", head.Number, "games", len(games), "count", count)
+	return nil
+}
+
+// scan rewinds the cursor to a canonical block if there was a reorg, and then scans the logs up to head.
+func (s *Scanner) scan(ctx context.Context, head *ethTypes.Header) error {
+	headNum := head.Number.Uint64()
+	if err := s.rewind(ctx, headNum); err != nil {
+		return err
+	}
+	from := s.cursor.head().Number + 1
+	if headNum >= from && headNum-from >= s.logRange {
+		s.logger.Info("Backfilling game scan", "from", from, "to", headNum)
+	}
+	for from <= headNum {
+		to := min(from+s.logRange-1, headNum)
+		toHeader := head
+		if to != headNum {
+			var err error
+			toHeader, err = s.l1.HeaderByNumber(ctx, new(big.Int).SetUint64(to))
+			if err != nil {
+				return fmt.Errorf("failed to load block %v: %w", to, err)
+			}
+		}
+		games, err := s.scanRange(ctx, from, to, s.cursor.head().NextIndex)
+		if err != nil {
+			return err
+		}
+		s.cursor.Games = append(s.cursor.Games, games...)
+		nextIndex := s.cursor.head().NextIndex + uint64(len(games))
+		s.addCheckpoint(Checkpoint{Number: to, Hash: toHeader.Hash(), NextIndex: nextIndex})
+		if err := s.save(); err != nil {
+			return err
+		}
+		from = to + 1
+	}
+	count, err := s.factory.GetGameCount(ctx, head.Hash())
+	if err != nil {
+		return err
+	}
+	if scanned := s.cursor.head().NextIndex; scanned != count {
+		return fmt.Errorf("%w: scanned %v games but factory has %v", errInconsistent, scanned, count)
+	}
+	return nil
+}
+
+// scanRange returns the games created in the inclusive block range, starting at nextIndex.
+func (s *Scanner) scanRange(ctx context.Context, from uint64, to uint64, nextIndex uint64) ([]types.GameMetadata, error) {
+	logs, err := s.l1.FilterLogs(ctx, s.factory.GameCreatedFilter(from, to))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load game created logs in blocks %v to %v: %w", from, to, err)
+	}
+	var games []types.GameMetadata
+	timestamps := make(map[common.Hash]uint64)
+	for i := range logs {
+		gameLog := &logs[i]
+		if gameLog.Removed {
+			continue
+		}
+		proxy, gameType, err := s.factory.DecodeGameCreatedLog(gameLog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode game created log in block %v: %w", gameLog.BlockNumber, err)
+		}
+		timestamp, ok := timestamps[gameLog.BlockHash]
+		if !ok {
+			header, err := s.l1.HeaderByHash(ctx, gameLog.BlockHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load block %v: %w", gameLog.BlockHash, err)
+			}
+			timestamp = header.Time
+			timestamps[gameLog.BlockHash] = timestamp
+		}
+		games = append(games, types.GameMetadata{
+			Index:     nextIndex,
+			GameType:  gameType,
+			Timestamp: timestamp,
+			Proxy:     proxy,
+		})
+		nextIndex++
+	}
+	return games, nil
+}
+
+// rewind drops the checkpoints that are after headNum or no longer canonical,
+// and the games created after the remaining head of the scan.
+func (s *Scanner) rewind(ctx context.Context, headNum uint64) error {
+	checkpoints := s.cursor.Checkpoints
+	for len(checkpoints) > 0 {
+		last := checkpoints[len(checkpoints)-1]
+		if last.Number <= headNum {
+			header, err := s.l1.HeaderByNumber(ctx, new(big.Int).SetUint64(last.Number))
+			if err != nil {
+				return fmt.Errorf("failed to load block %v: %w", last.Number, err)
+			}
+			if header.Hash() == last.Hash {
+				break
+			}
+		}
+		checkpoints = checkpoints[:len(checkpoints)-1]
+	}
+	if len(checkpoints) == 0 {
+		return fmt.Errorf("%w: no scanned block is canonical", errInconsistent)
+	}
+	if len(checkpoints) == len(s.cursor.Checkpoints) {
+		return nil
+	}
+	s.cursor.Checkpoints = checkpoints
+	nextIndex := s.cursor.head().NextIndex
+	s.cursor.Games = slices.DeleteFunc(s.cursor.Games, func(game types.GameMetadata) bool {
+		return game.Index >= nextIndex
+	})
+	s.logger.Warn("Rewound game scan after L1 reorg", "block", s.cursor.head().Number, "nextIndex", nextIndex)
+	return nil
+}
+
+func (s *Scanner) addCheckpoint(checkpoint Checkpoint) {
+	s.cursor.Checkpoints = append(s.cursor.Checkpoints, checkpoint)
+	if excess := len(s.cursor.Checkpoints) - maxCheckpoints; excess > 0 {
+		s.cursor.Checkpoints = slices.Delete(s.cursor.Checkpoints, 0, excess)
+	}
+}
+
+// prune drops the games created before earliestTimestamp.
+func (s *Scanner) prune(earliestTimestamp uint64) {
+	s.cursor.Games = slices.DeleteFunc(s.cursor.Games, func(game types.GameMetadata) bool {
+		return game.Timestamp < earliestTimestamp
+	})
+	s.cursor.EarliestTimestamp = max(s.cursor.EarliestTimestamp, earliestTimestamp)
+}
+
+func (s *Scanner) save() error {
+	if err := jsonutil.WriteJSON(s.path, s.cursor, cursorFilePerm); err != nil {
+		return fmt.Errorf("failed to write game scan cursor: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+package factoryscan
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var factoryAddr = common.Address{0xfa}
+
+func TestScanner(t *testing.T) {
+	t.Run("LoadGamesFromFactoryInitially", func(t *testing.T) {
+		chain := newStubChain()
+		chain.addBlocks(10, 1)
+		scanner := newScanner(t, chain, "", 100)
+
+		games, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+		require.Equal(t, chain.expectedGames(chain.head(), 0), games)
+		require.Equal(t, 1, chain.seedCalls)
+		require.Zero(t, chain.filterCalls)
+	})
+
+	t.Run("ScanNewBlocksInChunks", func(t *testing.T) {
+		chain := newStubChain()
+		chain.addBlocks(10, 1)
+		scanner := newScanner(t, chain, "", 4)
+		_, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+
+		chain.addBlocks(10, 2)
+		games, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+		require.Equal(t, chain.expectedGames(chain.head(), 0), games)
+		require.Equal(t, 1, chain.seedCalls)
+		require.Equal(t, 3, chain.filterCalls)
+	})
+
+	t.Run("ResumeFromPersistedCursor", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cursor.json")
+		chain := newStubChain()
+		chain.addBlocks(10, 1)
+		_, err := newScanner(t, chain, path, 100).GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+
+		chain.addBlocks(5, 1)
+		games, err := newScanner(t, chain, path, 100).GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+		require.Equal(t, chain.expectedGames(chain.head(), 0), games)
+		require.Equal(t, 1, chain.seedCalls)
+		require.Equal(t, 1, chain.filterCalls)
+	})
+
+	t.Run("IgnoreCursorForOtherFactory", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "cursor.json")
+		chain := newStubChain()
+		chain.addBlocks(10, 1)
+		_, err := newScanner(t, chain, path, 100).GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+
+		scanner := NewScanner(testlog.Logger(t, log.LevelInfo), common.Address{0xbb}, chain, chain, path, 100)
+		_, err = scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, chain.seedCalls)
+	})
+
+	t.Run("ResumeAfterFailedChunk", func(t *testing.T) {
+		chain := newStubChain()
+		chain.addBlocks(10, 1)
+		scanner := newScanner(t, chain, "", 4)
+		_, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+
+		chain.addBlocks(10, 1)
+		chain.failFilterAfter = 2
+		_, err = scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.ErrorIs(t, err, errFilterFailed)
+
+		chain.failFilterAfter = 0
+		games, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+		require.Equal(t, chain.expectedGames(chain.head(), 0), games)
+		require.Equal(t, 1, chain.seedCalls)
+	})
+
+	t.Run("RewindAfterReorg", func(t *testing.T) {
+		chain := newStubChain()
+		chain.addBlocks(10, 1)
+		scanner := newScanner(t, chain, "", 100)
+		_, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+		for i := 0; i < 5; i++ {
+			chain.addBlocks(1, 1)
+			_, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+			require.NoError(t, err)
+		}
+
+		chain.reorg(13, 2)
+		chain.addBlocks(4, 2)
+		games, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+		require.Equal(t, chain.expectedGames(chain.head(), 0), games)
+		require.Equal(t, 1, chain.seedCalls)
+	})
+
+	t.Run("ReloadWhenReorgIsDeeperThanCheckpoints", func(t *testing.T) {
+		chain := newStubChain()
+		chain.addBlocks(10, 1)
+		scanner := newScanner(t, chain, "", 100)
+		_, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+
+		chain.reorg(5, 2)
+		chain.addBlocks(8, 1)
+		games, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+		require.Equal(t, chain.expectedGames(chain.head(), 0), games)
+		require.Equal(t, 2, chain.seedCalls)
+	})
+
+	t.Run("ReloadWhenGameCountDiffers", func(t *testing.T) {
+		chain := newStubChain()
+		chain.addBlocks(10, 1)
+		scanner := newScanner(t, chain, "", 100)
+		_, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+
+		chain.addBlocks(2, 1)
+		chain.hideLogs = true
+		_, err = scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+		require.Equal(t, 2, chain.seedCalls)
+	})
+
+	t.Run("PruneGamesBeforeEarliestTimestamp", func(t *testing.T) {
+		chain := newStubChain()
+		chain.addBlocks(10, 1)
+		scanner := newScanner(t, chain, "", 100)
+		_, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+
+		chain.addBlocks(5, 1)
+		earliest := chain.blocks[8].Time
+		games, err := scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), earliest)
+		require.NoError(t, err)
+		require.Equal(t, chain.expectedGames(chain.head(), earliest), games)
+		require.Len(t, scanner.cursor.Games, len(games))
+		require.Equal(t, 1, chain.seedCalls)
+
+		// Games before the scanned window have to be loaded from the factory again
+		games, err = scanner.GetGamesAtOrAfter(context.Background(), chain.head().Hash(), 0)
+		require.NoError(t, err)
+		require.Equal(t, chain.expectedGames(chain.head(), 0), games)
+		require.Equal(t, 2, chain.seedCalls)
+	})
+}
+
+func newScanner(t *testing.T, chain *stubChain, path string, logRange uint64) *Scanner {
+	return NewScanner(testlog.Logger(t, log.LevelInfo), factoryAddr, chain, chain, path, logRange)
+}
+
+var errFilterFailed = errors.New("filter failed")
+
+// stubChain is an L1 chain with the factory deployed at genesis, implementing both Factory and L1Source.
+type stubChain struct {
+	blocks  []*ethTypes.Header
+	headers map[common.Hash]*ethTypes.Header
+	games   map[common.Hash][]common.Address
+
+	seedCalls       int
+	filterCalls     int
+	failFilterAfter int
+	hideLogs        bool
+}
+
+func newStubChain() *stubChain {
+	c := &stubChain{
+		headers: make(map[common.Hash]*ethTypes.Header),
+		games:   make(map[common.Hash][]common.Address),
+	}
+	c.addBlocks(1, 0)
+	return c
+}
+
+func (c *stubChain) head() *ethTypes.Header {
+	return c.blocks[len(c.blocks)-1]
+}
+
+func (c *stubChain) addBlocks(count int, fork byte) {
+	for i := 0; i < count; i++ {
+		num := uint64(len(c.blocks))
+		header := &ethTypes.Header{
+			Number: new(big.Int).SetUint64(num),
+			Time:   1000 + num*12,
+			Extra:  []byte{fork},
+		}
+		if num > 0 {
+			header.ParentHash = c.head().Hash()
+		}
+		c.blocks = append(c.blocks, header)
+		c.headers[header.Hash()] = header
+		if num > 0 {
+			c.games[header.Hash()] = []common.Address{{byte(num), fork, 1}, {byte(num), fork, 2}}[:1+num%2]
+		}
+	}
+}
+
+// reorg drops the blocks from num onwards, and replaces them with the same number of blocks on a new fork.
+func (c *stubChain) reorg(num uint64, fork byte) {
+	dropped := len(c.blocks) - int(num)
+	c.blocks = c.blocks[:num]
+	c.addBlocks(dropped, fork)
+}
+
+func (c *stubChain) canonicalNumber(blockHash common.Hash) (uint64, error) {
+	header, ok := c.headers[blockHash]
+	if !ok {
+		return 0, ethereum.NotFound
+	}
+	if num := header.Number.Uint64(); num < uint64(len(c.blocks)) && c.blocks[num].Hash() == blockHash {
+		return num, nil
+	}
+	return 0, errors.New("block not canonical")
+}
+
+// allGames returns the games created up to and including the block, by ascending index.
+func (c *stubChain) allGames(head *ethTypes.Header) []types.GameMetadata {
+	var games []types.GameMetadata
+	for _, header := range c.blocks[:head.Number.Uint64()+1] {
+		for _, proxy := range c.games[header.Hash()] {
+			games = append(games, types.GameMetadata{
+				Index:     uint64(len(games)),
+				GameType:  uint32(proxy[2]),
+				Timestamp: header.Time,
+				Proxy:     proxy,
+			})
+		}
+	}
+	return games
+}
+
+func (c *stubChain) expectedGames(head *ethTypes.Header, earliestTimestamp uint64) []types.GameMetadata {
+	games := slices.DeleteFunc(c.allGames(head), func(game types.GameMetadata) bool {
+		return game.Timestamp < earliestTimestamp
+	})
+	slices.Reverse(games)
+	return games
+}
+
+func (c *stubChain) GetGameCount(_ context.Context, blockHash common.Hash) (uint64, error) {
+	num, err := c.canonicalNumber(blockHash)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(c.allGames(c.blocks[num]))), nil
+}
+
+func (c *stubChain) GetGamesAtOrAfter(_ context.Context, blockHash common.Hash, earliestTimestamp uint64) ([]types.GameMetadata, error) {
+	num, err := c.canonicalNumber(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	c.seedCalls++
+	return c.expectedGames(c.blocks[num], earliestTimestamp), nil
+}
+
+func (c *stubChain) GameCreatedFilter(fromBlock uint64, toBlock uint64) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{factoryAddr},
+	}
+}
+
+func (c *stubChain) DecodeGameCreatedLog(log *ethTypes.Log) (common.Address, uint32, error) {
+	proxy := common.BytesToAddress(log.Topics[1].Bytes())
+	return proxy, uint32(log.Topics[2][31]), nil
+}
+
+func (c *stubChain) HeaderByHash(_ context.Context, hash common.Hash) (*ethTypes.Header, error) {
+	header, ok := c.headers[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return header, nil
+}
+
+func (c *stubChain) HeaderByNumber(_ context.Context, number *big.Int) (*ethTypes.Header, error) {
+	if number.Uint64() >= uint64(len(c.blocks)) {
+		return nil, ethereum.NotFound
+	}
+	return c.blocks[number.Uint64()], nil
+}
+
+func (c *stubChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error) {
+	c.filterCalls++
+	if c.failFilterAfter > 0 && c.filterCalls > c.failFilterAfter {
+		return nil, errFilterFailed
+	}
+	if c.hideLogs {
+		return nil, nil
+	}
+	var logs []ethTypes.Log
+	for num := q.FromBlock.Uint64(); num <= q.ToBlock.Uint64() && num < uint64(len(c.blocks)); num++ {
+		header := c.blocks[num]
+		for _, proxy := range c.games[header.Hash()] {
+			logs = append(logs, ethTypes.Log{
+				Address:     factoryAddr,
+				Topics:      []common.Hash{{}, common.BytesToHash(proxy.Bytes()), common.BigToHash(big.NewInt(int64(proxy[2])))},
+				BlockNumber: num,
+				BlockHash:   header.Hash(),
+			})
+		}
+	}
+	return logs, nil
+}
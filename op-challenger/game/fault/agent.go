@@ -28,6 +28,18 @@ type Responder interface {
 	PerformAction(ctx context.Context, action types.Action) error
 }
 
+// ClaimCoordinator coordinates the claims countered in the game with other honest challengers.
+type ClaimCoordinator interface {
+	CounteredByOther(claimIdx uint64) bool
+	Announce(ctx context.Context, claimIdx uint64)
+}
+
+// ProofPruner is implemented by trace accessors that keep proofs on disk,
+// to remove the proofs the game can no longer require.
+type ProofPruner interface {
+	PruneProofs(ctx context.Context, game types.Game) error
+}
+
 type ClaimLoader interface {
 	GetAllClaims(ctx context.Context, block rpcblock.Block) ([]types.Claim, error)
 	IsL2BlockNumberChallenged(ctx context.Context, block rpcblock.Block) (bool, error)
@@ -37,9 +49,11 @@ type Agent struct {
 	metrics          metrics.Metricer
 	systemClock      clock.Clock
 	l1Clock          types.ClockReader
-	solver           *solver.GameSolver
+	solver           solver.Policy
+	trace            types.TraceAccessor
 	loader           ClaimLoader
 	responder        Responder
+	coordinator      ClaimCoordinator
 	selective        bool
 	claimants        []common.Address
 	maxDepth         types.Depth
@@ -59,14 +73,17 @@ func NewAgent(
 	log log.Logger,
 	selective bool,
 	claimants []common.Address,
+	coordinator ClaimCoordinator,
 ) *Agent {
 	return &Agent{
 		metrics:          m,
 		systemClock:      systemClock,
 		l1Clock:          l1Clock,
 		solver:           solver.NewGameSolver(maxDepth, trace),
+		trace:            trace,
 		loader:           loader,
 		responder:        responder,
+		coordinator:      coordinator,
 		selective:        selective,
 		claimants:        claimants,
 		maxDepth:         maxDepth,
@@ -103,15 +120,49 @@ func (a *Agent) Act(ctx context.Context) error {
 		a.log.Error("Failed to calculate all required moves", "err", err)
 	}
 
+	actions = a.coordinate(ctx, actions)
 	var wg sync.WaitGroup
 	wg.Add(len(actions))
 	for _, action := range actions {
 		go a.performAction(ctx, &wg, action)
 	}
 	wg.Wait()
+	a.pruneProofs(ctx, game)
 	return nil
 }
 
+// pruneProofs removes the proofs the game can no longer require, if the trace keeps proofs on disk.
+// The moves just made counter claims of the game, so their positions remain required.
+func (a *Agent) pruneProofs(ctx context.Context, game types.Game) {
+	pruner, ok := a.trace.(ProofPruner)
+	if !ok {
+		return
+	}
+	if err := pruner.PruneProofs(ctx, game); err != nil {
+		a.log.Warn("Failed to prune proofs", "err", err)
+	}
+}
+
+// coordinate skips the actions that counter claims other challengers announced they are countering,
+// and announces the claims countered by the remaining actions.
+func (a *Agent) coordinate(ctx context.Context, actions []types.Action) []types.Action {
+	var coordinated []types.Action
+	for _, action := range actions {
+		if action.Type != types.ActionTypeMove && action.Type != types.ActionTypeStep {
+			coordinated = append(coordinated, action)
+			continue
+		}
+		claimIdx := uint64(action.ParentClaim.ContractIndex)
+		if a.coordinator.CounteredByOther(claimIdx) {
+			a.log.Info("Skipping action, claim is being countered by another challenger", "action", action.Type, "parent", claimIdx)
+			continue
+		}
+		a.coordinator.Announce(ctx, claimIdx)
+		coordinated = append(coordinated, action)
+	}
+	return coordinated
+}
+
 func (a *Agent) performAction(ctx context.Context, wg *sync.WaitGroup, action types.Action) {
 	defer wg.Done()
 	actionLog := a.log.New("action", action.Type)
@@ -175,6 +175,22 @@ func TestSkipAttemptingToResolveClaimsWhenClockNotExpired(t *testing.T) {
 	require.Equal(t, 2, responder.callResolveClaimCount)
 }
 
+func TestCoordinateActions(t *testing.T) {
+	agent, _, _ := setupTestAgent(t)
+	coordinator := &stubCoordinator{counteredByOther: map[uint64]bool{1: true}}
+	agent.coordinator = coordinator
+
+	move := func(parentIdx int) types.Action {
+		return types.Action{Type: types.ActionTypeMove, ParentClaim: types.Claim{ContractIndex: parentIdx}}
+	}
+	step := types.Action{Type: types.ActionTypeStep, ParentClaim: types.Claim{ContractIndex: 2}}
+	l2Challenge := types.Action{Type: types.ActionTypeChallengeL2BlockNumber}
+	actions := agent.coordinate(context.Background(), []types.Action{move(0), move(1), step, l2Challenge})
+
+	require.Equal(t, []types.Action{move(0), step, l2Challenge}, actions, "should skip claims countered by another challenger")
+	require.Equal(t, []uint64{0, 2}, coordinator.announced, "should announce countered claims")
+}
+
 func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	// Checks that if the game isn't resolvable, that the agent continues on to start checking claims
 	agent, claimLoader, responder := setupTestAgent(t)
@@ -194,6 +210,25 @@ func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	require.Zero(t, responder.resolveClaimCount, "should not send resolveClaim")
 }
 
+func TestPruneProofsAfterActing(t *testing.T) {
+	agent, claimLoader, _ := setupTestAgent(t)
+	pruner := &stubProofPruner{TraceAccessor: agent.trace}
+	agent.trace = pruner
+	depth := types.Depth(4)
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider(big.NewInt(0), depth))
+	claimLoader.claims = []types.Claim{
+		claimBuilder.CreateRootClaim(),
+	}
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, pruner.games, 1)
+	require.Equal(t, claimLoader.claims, pruner.games[0].Claims())
+
+	pruner.err = errors.New("boom")
+	require.NoError(t, agent.Act(context.Background()), "should not fail when pruning fails")
+	require.Len(t, pruner.games, 2)
+}
+
 func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
 	logger := testlog.Logger(t, log.LevelInfo)
 	claimLoader := &stubClaimLoader{}
@@ -203,10 +238,21 @@ func setupTestAgent(t *testing.T) (*Agent, *stubClaimLoader, *stubResponder) {
 	responder := &stubResponder{}
 	systemClock := clock.NewDeterministicClock(time.UnixMilli(120200))
 	l1Clock := clock.NewDeterministicClock(l1Time)
-	agent := NewAgent(metrics.NoopMetrics, systemClock, l1Clock, claimLoader, depth, gameDuration, trace.NewSimpleTraceAccessor(provider), responder, logger, false, []common.Address{})
+	agent := NewAgent(metrics.NoopMetrics, systemClock, l1Clock, claimLoader, depth, gameDuration, trace.NewSimpleTraceAccessor(provider), responder, logger, false, []common.Address{}, &stubCoordinator{})
 	return agent, claimLoader, responder
 }
 
+type stubProofPruner struct {
+	types.TraceAccessor
+	games []types.Game
+	err   error
+}
+
+func (s *stubProofPruner) PruneProofs(_ context.Context, game types.Game) error {
+	s.games = append(s.games, game)
+	return s.err
+}
+
 type stubClaimLoader struct {
 	callCount          int
 	maxLoads           int
@@ -276,3 +322,16 @@ func (s *stubResponder) ResolveClaims(claims ...uint64) error {
 func (s *stubResponder) PerformAction(_ context.Context, _ types.Action) error {
 	return nil
 }
+
+type stubCoordinator struct {
+	counteredByOther map[uint64]bool
+	announced        []uint64
+}
+
+func (s *stubCoordinator) CounteredByOther(claimIdx uint64) bool {
+	return s.counteredByOther[claimIdx]
+}
+
+func (s *stubCoordinator) Announce(_ context.Context, claimIdx uint64) {
+	s.announced = append(s.announced, claimIdx)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
@@ -191,6 +192,31 @@ func (f *DisputeGameFactoryContract) DecodeDisputeGameCreatedLog(rcpt *ethTypes.
 	return common.Address{}, 0, common.Hash{}, fmt.Errorf("%w: %v", ErrEventNotFound, eventDisputeGameCreated)
 }
 
+// GameCreatedFilter returns a query for the DisputeGameCreated events emitted in the inclusive block range.
+func (f *DisputeGameFactoryContract) GameCreatedFilter(fromBlock uint64, toBlock uint64) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{f.contract.Addr()},
+		Topics:    [][]common.Hash{{f.abi.Events[eventDisputeGameCreated].ID}},
+	}
+}
+
+// DecodeGameCreatedLog decodes a DisputeGameCreated event, returning the game proxy and game type.
+func (f *DisputeGameFactoryContract) DecodeGameCreatedLog(log *ethTypes.Log) (common.Address, uint32, error) {
+	if log.Address != f.contract.Addr() {
+		return common.Address{}, 0, fmt.Errorf("%w: log from %v, not the factory", ErrEventNotFound, log.Address)
+	}
+	name, result, err := f.contract.DecodeEvent(log)
+	if err != nil {
+		return common.Address{}, 0, fmt.Errorf("failed to decode event: %w", err)
+	}
+	if name != eventDisputeGameCreated {
+		return common.Address{}, 0, fmt.Errorf("%w: %v, got %v", ErrEventNotFound, eventDisputeGameCreated, name)
+	}
+	return result.GetAddress(0), result.GetUint32(1), nil
+}
+
 func (f *DisputeGameFactoryContract) decodeGame(idx uint64, result *batching.CallResult) types.GameMetadata {
 	gameType := result.GetUint32(0)
 	timestamp := result.GetUint64(1)
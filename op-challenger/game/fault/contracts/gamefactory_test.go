@@ -307,3 +307,50 @@ func setupDisputeGameFactoryTest(t *testing.T) (*batchingTest.AbiBasedRpc, *Disp
 	factory := NewDisputeGameFactoryContract(metrics.NoopContractMetrics, factoryAddr, caller)
 	return stubRpc, factory
 }
+
+func TestDecodeGameCreatedLog(t *testing.T) {
+	_, factory := setupDisputeGameFactoryTest(t)
+	eventAbi := snapshots.LoadDisputeGameFactoryABI().Events[eventDisputeGameCreated]
+	gameAddr := common.Address{0x11}
+	gameType := uint32(4)
+	createLog := func() *ethTypes.Log {
+		return &ethTypes.Log{
+			Address: fdgAddr,
+			Topics: []common.Hash{
+				eventAbi.ID,
+				common.BytesToHash(gameAddr.Bytes()),
+				common.BytesToHash(big.NewInt(int64(gameType)).Bytes()),
+				{0xaa, 0xbb, 0xcc},
+			},
+		}
+	}
+
+	t.Run("ValidEvent", func(t *testing.T) {
+		actualGameAddr, actualGameType, err := factory.DecodeGameCreatedLog(createLog())
+		require.NoError(t, err)
+		require.Equal(t, gameAddr, actualGameAddr)
+		require.Equal(t, gameType, actualGameType)
+	})
+
+	t.Run("IncorrectContract", func(t *testing.T) {
+		log := createLog()
+		log.Address = common.Address{0xff}
+		_, _, err := factory.DecodeGameCreatedLog(log)
+		require.ErrorIs(t, err, ErrEventNotFound)
+	})
+
+	t.Run("InvalidEvent", func(t *testing.T) {
+		log := createLog()
+		log.Topics = log.Topics[0:2]
+		_, _, err := factory.DecodeGameCreatedLog(log)
+		require.Error(t, err)
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		filter := factory.GameCreatedFilter(10, 20)
+		require.Equal(t, big.NewInt(10), filter.FromBlock)
+		require.Equal(t, big.NewInt(20), filter.ToBlock)
+		require.Equal(t, []common.Address{fdgAddr}, filter.Addresses)
+		require.Equal(t, [][]common.Hash{{eventAbi.ID}}, filter.Topics)
+	})
+}
@@ -0,0 +1,56 @@
+package contracts
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+const methodAggregate3 = "aggregate3"
+
+// DefaultMulticall3Address is the address Multicall3 is deployed at on most chains.
+var DefaultMulticall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+//go:embed abis/Multicall3.json
+var multicall3Abi []byte
+
+type Multicall3Contract struct {
+	contract *batching.BoundContract
+}
+
+type call3 struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+func NewMulticall3Contract(addr common.Address) *Multicall3Contract {
+	return &Multicall3Contract{
+		contract: batching.NewBoundContract(mustParseAbi(multicall3Abi), addr),
+	}
+}
+
+func (m *Multicall3Contract) Addr() common.Address {
+	return m.contract.Addr()
+}
+
+// Aggregate3Tx creates a transaction that executes the calls of the given transactions in a single transaction.
+// The calls are allowed to fail individually, so a call that fails, e.g. because a claim was resolved
+// in the meantime, does not revert the other calls.
+func (m *Multicall3Contract) Aggregate3Tx(txs []txmgr.TxCandidate) (txmgr.TxCandidate, error) {
+	calls := make([]call3, 0, len(txs))
+	for i, tx := range txs {
+		if tx.To == nil {
+			return txmgr.TxCandidate{}, fmt.Errorf("tx %d has no target", i)
+		}
+		if tx.Value != nil && tx.Value.Sign() != 0 {
+			return txmgr.TxCandidate{}, fmt.Errorf("tx %d has a value", i)
+		}
+		calls = append(calls, call3{Target: *tx.To, AllowFailure: true, CallData: tx.TxData})
+	}
+	return m.contract.Call(methodAggregate3, calls).ToTxCandidate()
+}
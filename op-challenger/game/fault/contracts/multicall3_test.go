@@ -0,0 +1,50 @@
+package contracts
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+func TestMulticall3Aggregate3Tx(t *testing.T) {
+	contract := NewMulticall3Contract(DefaultMulticall3Address)
+	target1 := common.Address{0x01}
+	target2 := common.Address{0x02}
+	tx, err := contract.Aggregate3Tx([]txmgr.TxCandidate{
+		{To: &target1, TxData: []byte{1, 2, 3}},
+		{To: &target2, TxData: []byte{4}, Value: new(big.Int)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, DefaultMulticall3Address, *tx.To)
+
+	abi := mustParseAbi(multicall3Abi)
+	method, err := abi.MethodById(tx.TxData[:4])
+	require.NoError(t, err)
+	require.Equal(t, methodAggregate3, method.Name)
+	args, err := method.Inputs.Unpack(tx.TxData[4:])
+	require.NoError(t, err)
+	calls := args[0].([]struct {
+		Target       common.Address `json:"target"`
+		AllowFailure bool           `json:"allowFailure"`
+		CallData     []byte         `json:"callData"`
+	})
+	require.Len(t, calls, 2)
+	require.Equal(t, target1, calls[0].Target)
+	require.True(t, calls[0].AllowFailure)
+	require.Equal(t, []byte{1, 2, 3}, calls[0].CallData)
+	require.Equal(t, target2, calls[1].Target)
+	require.Equal(t, []byte{4}, calls[1].CallData)
+}
+
+func TestMulticall3Aggregate3TxRejectsValue(t *testing.T) {
+	contract := NewMulticall3Contract(DefaultMulticall3Address)
+	target := common.Address{0x01}
+	_, err := contract.Aggregate3Tx([]txmgr.TxCandidate{{To: &target, Value: big.NewInt(1)}})
+	require.ErrorContains(t, err, "has a value")
+	_, err = contract.Aggregate3Tx([]txmgr.TxCandidate{{}})
+	require.ErrorContains(t, err, "no target")
+}
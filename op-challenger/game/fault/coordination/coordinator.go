@@ -0,0 +1,49 @@
+// Package coordination lets honest challengers announce the claims they are about to counter,
+// so other honest challengers can avoid posting bonds to counter the same claims.
+//
+// Coordination is strictly an optimisation: announcements expire, and a challenger that receives
+// no announcements, e.g. because coordination is disabled or its peers are unreachable,
+// acts fully independently.
+package coordination
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Coordinator tracks the claims that other challengers announced they are countering.
+type Coordinator interface {
+	// CounteredByOther returns true if another challenger announced it is countering the claim
+	// and the announcement has not expired yet.
+	CounteredByOther(game common.Address, claimIdx uint64) bool
+
+	// Announce announces that this challenger is about to counter the claim.
+	// Failures are logged, but do not prevent the challenger from countering the claim.
+	Announce(ctx context.Context, game common.Address, claimIdx uint64)
+}
+
+// NoopCoordinator does not coordinate with other challengers, so all claims are countered independently.
+type NoopCoordinator struct{}
+
+func (NoopCoordinator) CounteredByOther(common.Address, uint64) bool { return false }
+
+func (NoopCoordinator) Announce(context.Context, common.Address, uint64) {}
+
+// GameCoordinator coordinates the claims countered in a single game.
+type GameCoordinator struct {
+	coordinator Coordinator
+	game        common.Address
+}
+
+func ForGame(coordinator Coordinator, game common.Address) *GameCoordinator {
+	return &GameCoordinator{coordinator: coordinator, game: game}
+}
+
+func (g *GameCoordinator) CounteredByOther(claimIdx uint64) bool {
+	return g.coordinator.CounteredByOther(g.game, claimIdx)
+}
+
+func (g *GameCoordinator) Announce(ctx context.Context, claimIdx uint64) {
+	g.coordinator.Announce(ctx, g.game, claimIdx)
+}
@@ -0,0 +1,330 @@
+package coordination
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/libp2p/go-libp2p"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+const (
+	// maxAnnouncementSize is the maximum size of an encoded announcement.
+	maxAnnouncementSize = 1024
+	// reconnectInterval is the interval at which disconnected peers are redialed.
+	reconnectInterval = 30 * time.Second
+	// maxClockDrift is the tolerated difference between the clocks of peers, when validating announcement expiry.
+	maxClockDrift = 30 * time.Second
+)
+
+var ErrMissingPrivKey = errors.New("missing coordination private key")
+
+// Config configures the gossip of announcements between challengers.
+type Config struct {
+	// ListenAddr is the libp2p multiaddr to listen on. Coordination is disabled if empty.
+	ListenAddr string
+	// PrivKey is the hex encoded secp256k1 private key of the libp2p identity announcements are signed with.
+	PrivKey string
+	// Peers are the multiaddrs, including the /p2p/ peer ID, of the other challengers to coordinate with.
+	// Only announcements signed by these peers are accepted.
+	Peers []string
+	// TTL is the time after which an announcement expires, if the claim was not countered yet.
+	TTL time.Duration
+}
+
+func (c Config) Enabled() bool {
+	return c.ListenAddr != ""
+}
+
+func (c Config) Check() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.PrivKey == "" {
+		return ErrMissingPrivKey
+	}
+	if _, err := c.privKey(); err != nil {
+		return err
+	}
+	if _, err := c.peers(); err != nil {
+		return err
+	}
+	if c.TTL <= 0 {
+		return fmt.Errorf("invalid coordination TTL: %v", c.TTL)
+	}
+	return nil
+}
+
+func (c Config) privKey() (crypto.PrivKey, error) {
+	b, err := hex.DecodeString(strings.TrimPrefix(c.PrivKey, "0x"))
+	if err != nil {
+		return nil, errors.New("coordination private key is not formatted in hex chars")
+	}
+	key, err := crypto.UnmarshalSecp256k1PrivateKey(b)
+	if err != nil {
+		// avoid logging the private key in the error
+		return nil, fmt.Errorf("failed to parse coordination private key from %d bytes", len(b))
+	}
+	return key, nil
+}
+
+func (c Config) peers() ([]peer.AddrInfo, error) {
+	peers := make([]peer.AddrInfo, 0, len(c.Peers))
+	for _, addr := range c.Peers {
+		info, err := peer.AddrInfoFromString(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid coordination peer %q: %w", addr, err)
+		}
+		peers = append(peers, *info)
+	}
+	return peers, nil
+}
+
+// Announcement is an announcement of a challenger that it is about to counter a claim.
+type Announcement struct {
+	Game       common.Address `json:"game"`
+	ClaimIndex uint64         `json:"claimIndex"`
+	// Expiry is the unix timestamp in seconds after which the announcement no longer applies.
+	Expiry uint64 `json:"expiry"`
+}
+
+// Gossip is a libp2p host that gossips announcements with the configured peers.
+type Gossip struct {
+	log   log.Logger
+	clock clock.Clock
+	ttl   time.Duration
+
+	host    host.Host
+	ps      *pubsub.PubSub
+	peers   []peer.AddrInfo
+	trusted map[peer.ID]bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// StartGossip starts the libp2p host and connects to the configured peers.
+func StartGossip(logger log.Logger, cfg Config, cl clock.Clock) (*Gossip, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	key, _ := cfg.privKey()
+	peers, _ := cfg.peers()
+	h, err := libp2p.New(libp2p.Identity(key), libp2p.ListenAddrStrings(cfg.ListenAddr))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start coordination host: %w", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ps, err := pubsub.NewGossipSub(ctx, h,
+		pubsub.WithMessageSignaturePolicy(pubsub.StrictSign),
+		pubsub.WithMaxMessageSize(maxAnnouncementSize),
+		pubsub.WithPeerExchange(false))
+	if err != nil {
+		cancel()
+		return nil, errors.Join(fmt.Errorf("failed to start coordination gossip: %w", err), h.Close())
+	}
+	g := &Gossip{
+		log:     logger,
+		clock:   cl,
+		ttl:     cfg.TTL,
+		host:    h,
+		ps:      ps,
+		peers:   peers,
+		trusted: make(map[peer.ID]bool),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	for _, p := range peers {
+		g.trusted[p.ID] = true
+	}
+	logger.Info("Started coordination gossip", "id", h.ID(), "addrs", h.Addrs(), "peers", len(peers))
+	g.wg.Add(1)
+	go g.connectLoop()
+	return g, nil
+}
+
+// ID returns the libp2p peer ID of this challenger.
+func (g *Gossip) ID() peer.ID {
+	return g.host.ID()
+}
+
+// Addrs returns the multiaddrs, including the peer ID, that this challenger can be reached at.
+func (g *Gossip) Addrs() []string {
+	addrs := make([]string, 0, len(g.host.Addrs()))
+	for _, addr := range g.host.Addrs() {
+		addrs = append(addrs, fmt.Sprintf("%v/p2p/%v", addr, g.host.ID()))
+	}
+	return addrs
+}
+
+// connectLoop keeps the connections to the configured peers open, redialing peers that went offline.
+func (g *Gossip) connectLoop() {
+	defer g.wg.Done()
+	ticker := g.clock.NewTicker(reconnectInterval)
+	defer ticker.Stop()
+	for {
+		g.connectPeers()
+		select {
+		case <-g.ctx.Done():
+			return
+		case <-ticker.Ch():
+		}
+	}
+}
+
+func (g *Gossip) connectPeers() {
+	for _, p := range g.peers {
+		if len(g.host.Network().ConnsToPeer(p.ID)) > 0 {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(g.ctx, 10*time.Second)
+		if err := g.host.Connect(ctx, p); err != nil {
+			g.log.Debug("Failed to connect to coordination peer", "peer", p.ID, "err", err)
+		}
+		cancel()
+	}
+}
+
+// Join joins the announcement topic of the games created by the given dispute game factory.
+func (g *Gossip) Join(factory common.Address) (*GossipCoordinator, error) {
+	topicName := fmt.Sprintf("/optimism/challenger/0/announcements/%v", factory.Hex())
+	if err := g.ps.RegisterTopicValidator(topicName, g.validate); err != nil {
+		return nil, fmt.Errorf("failed to register announcement validator: %w", err)
+	}
+	topic, err := g.ps.Join(topicName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join announcement topic: %w", err)
+	}
+	sub, err := topic.Subscribe()
+	if err != nil {
+		return nil, errors.Join(fmt.Errorf("failed to subscribe to announcement topic: %w", err), topic.Close())
+	}
+	c := &GossipCoordinator{
+		log:           g.log.New("factory", factory),
+		clock:         g.clock,
+		ttl:           g.ttl,
+		self:          g.host.ID(),
+		topic:         topic,
+		announcements: make(map[claimKey]time.Time),
+	}
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer sub.Cancel()
+		c.readLoop(g.ctx, sub)
+	}()
+	return c, nil
+}
+
+// validate accepts well-formed, unexpired announcements of this challenger and the configured peers.
+func (g *Gossip) validate(_ context.Context, _ peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+	from := msg.GetFrom()
+	if from != g.host.ID() && !g.trusted[from] {
+		return pubsub.ValidationReject
+	}
+	var announcement Announcement
+	if err := json.Unmarshal(msg.Data, &announcement); err != nil {
+		return pubsub.ValidationReject
+	}
+	expiry := time.Unix(int64(announcement.Expiry), 0)
+	now := g.clock.Now()
+	if expiry.Before(now) || expiry.After(now.Add(g.ttl+maxClockDrift)) {
+		return pubsub.ValidationIgnore
+	}
+	msg.ValidatorData = announcement
+	return pubsub.ValidationAccept
+}
+
+func (g *Gossip) Close() error {
+	g.cancel()
+	err := g.host.Close()
+	g.wg.Wait()
+	return err
+}
+
+type claimKey struct {
+	game     common.Address
+	claimIdx uint64
+}
+
+// GossipCoordinator tracks the announcements of the games of a single dispute game factory.
+type GossipCoordinator struct {
+	log   log.Logger
+	clock clock.Clock
+	ttl   time.Duration
+	self  peer.ID
+	topic *pubsub.Topic
+
+	lock          sync.Mutex
+	announcements map[claimKey]time.Time
+}
+
+var _ Coordinator = (*GossipCoordinator)(nil)
+
+func (c *GossipCoordinator) readLoop(ctx context.Context, sub *pubsub.Subscription) {
+	for {
+		msg, err := sub.Next(ctx)
+		if err != nil {
+			// The subscription only fails when the gossip is closed
+			return
+		}
+		if msg.GetFrom() == c.self {
+			continue
+		}
+		announcement := msg.ValidatorData.(Announcement)
+		c.log.Debug("Received counter announcement", "from", msg.GetFrom(), "game", announcement.Game, "claim", announcement.ClaimIndex)
+		c.add(announcement)
+	}
+}
+
+func (c *GossipCoordinator) add(announcement Announcement) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	now := c.clock.Now()
+	for key, expiry := range c.announcements {
+		if !expiry.After(now) {
+			delete(c.announcements, key)
+		}
+	}
+	key := claimKey{game: announcement.Game, claimIdx: announcement.ClaimIndex}
+	expiry := time.Unix(int64(announcement.Expiry), 0)
+	if expiry.After(c.announcements[key]) {
+		c.announcements[key] = expiry
+	}
+}
+
+func (c *GossipCoordinator) CounteredByOther(game common.Address, claimIdx uint64) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	expiry, ok := c.announcements[claimKey{game: game, claimIdx: claimIdx}]
+	return ok && expiry.After(c.clock.Now())
+}
+
+func (c *GossipCoordinator) Announce(ctx context.Context, game common.Address, claimIdx uint64) {
+	data, err := json.Marshal(Announcement{
+		Game:       game,
+		ClaimIndex: claimIdx,
+		Expiry:     uint64(c.clock.Now().Add(c.ttl).Unix()),
+	})
+	if err != nil {
+		c.log.Error("Failed to encode counter announcement", "err", err)
+		return
+	}
+	if err := c.topic.Publish(ctx, data); err != nil {
+		c.log.Warn("Failed to announce counter", "game", game, "claim", claimIdx, "err", err)
+	}
+}
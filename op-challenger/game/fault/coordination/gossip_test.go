@@ -0,0 +1,111 @@
+package coordination
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var factory = common.Address{0xfa}
+
+func newPrivKey(t *testing.T) string {
+	key, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	raw, err := key.Raw()
+	require.NoError(t, err)
+	return hex.EncodeToString(raw)
+}
+
+func startGossip(t *testing.T, name string, cl clock.Clock, peers ...*Gossip) (*Gossip, *GossipCoordinator) {
+	cfg := Config{
+		ListenAddr: "/ip4/127.0.0.1/tcp/0",
+		PrivKey:    newPrivKey(t),
+		TTL:        time.Minute,
+	}
+	for _, p := range peers {
+		cfg.Peers = append(cfg.Peers, p.Addrs()[0])
+	}
+	g, err := StartGossip(testlog.Logger(t, log.LevelInfo).New("node", name), cfg, cl)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, g.Close())
+	})
+	coordinator, err := g.Join(factory)
+	require.NoError(t, err)
+	return g, coordinator
+}
+
+// announceUntil repeats the announcement until the condition is met, as the gossip mesh forms asynchronously.
+func announceUntil(t *testing.T, c *GossipCoordinator, game common.Address, claimIdx uint64, cond func() bool) {
+	require.Eventually(t, func() bool {
+		c.Announce(context.Background(), game, claimIdx)
+		return cond()
+	}, 30*time.Second, 100*time.Millisecond)
+}
+
+func TestGossipCoordinator(t *testing.T) {
+	game := common.Address{0xaa}
+	now := time.Now()
+	clockA := clock.NewDeterministicClock(now)
+	clockB := clock.NewDeterministicClock(now)
+	clockC := clock.NewDeterministicClock(now)
+	gossipA, coordinatorA := startGossip(t, "a", clockA)
+	// B trusts the announcements of A, but not of C
+	gossipB, coordinatorB := startGossip(t, "b", clockB, gossipA)
+	_, coordinatorC := startGossip(t, "c", clockC, gossipB)
+
+	announceUntil(t, coordinatorA, game, 1, func() bool {
+		return coordinatorB.CounteredByOther(game, 1)
+	})
+	require.False(t, coordinatorA.CounteredByOther(game, 1), "own announcements are ignored")
+	require.False(t, coordinatorB.CounteredByOther(game, 2))
+	require.False(t, coordinatorB.CounteredByOther(common.Address{0xbb}, 1))
+
+	// C is connected to B, but B rejects its announcements.
+	announceUntil(t, coordinatorA, game, 4, func() bool {
+		coordinatorC.Announce(context.Background(), game, 3)
+		return coordinatorB.CounteredByOther(game, 4)
+	})
+	require.False(t, coordinatorB.CounteredByOther(game, 3), "announcements of untrusted peers are rejected")
+
+	// Announcements expire, so a crashed challenger doesn't prevent others from countering the claim
+	clockB.AdvanceTime(time.Minute)
+	require.False(t, coordinatorB.CounteredByOther(game, 1))
+}
+
+func TestConfigCheck(t *testing.T) {
+	valid := Config{
+		ListenAddr: "/ip4/127.0.0.1/tcp/0",
+		PrivKey:    newPrivKey(t),
+		Peers:      []string{"/ip4/127.0.0.1/tcp/9223/p2p/16Uiu2HAmNVCuiQLqkrHoGeYFDvqvpJEHoXHCAhqodyLKPpjHuEfn"},
+		TTL:        time.Minute,
+	}
+	require.NoError(t, valid.Check())
+	require.NoError(t, Config{}.Check(), "disabled")
+
+	cfg := valid
+	cfg.PrivKey = ""
+	require.ErrorIs(t, cfg.Check(), ErrMissingPrivKey)
+
+	cfg = valid
+	cfg.PrivKey = "0xnothex"
+	require.ErrorContains(t, cfg.Check(), "hex")
+
+	cfg = valid
+	cfg.Peers = []string{"/ip4/127.0.0.1/tcp/9223"}
+	require.ErrorContains(t, cfg.Check(), "invalid coordination peer")
+
+	cfg = valid
+	cfg.TTL = 0
+	require.ErrorContains(t, cfg.Check(), "invalid coordination TTL")
+}
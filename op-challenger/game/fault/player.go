@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/coordination"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
@@ -82,6 +83,8 @@ func NewGamePlayer(
 	l1HeaderSource L1HeaderSource,
 	selective bool,
 	claimants []common.Address,
+	resolutionBatching responder.ResolutionBatching,
+	coordinator coordination.Coordinator,
 ) (*GamePlayer, error) {
 	logger = logger.New("game", addr)
 
@@ -141,12 +144,12 @@ func NewGamePlayer(
 	direct := preimages.NewDirectPreimageUploader(logger, txSender, loader)
 	large := preimages.NewLargePreimageUploader(logger, l1Clock, txSender, oracle)
 	uploader := preimages.NewSplitPreimageUploader(direct, large, minLargePreimageSize)
-	responder, err := responder.NewFaultResponder(logger, txSender, loader, uploader, oracle)
+	responder, err := responder.NewFaultResponder(logger, txSender, loader, uploader, oracle, resolutionBatching)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
 
-	agent := NewAgent(m, systemClock, l1Clock, loader, gameDepth, maxClockDuration, accessor, responder, logger, selective, claimants)
+	agent := NewAgent(m, systemClock, l1Clock, loader, gameDepth, maxClockDuration, accessor, responder, logger, selective, claimants, coordination.ForGame(coordinator, addr))
 	return &GamePlayer{
 		act:                agent.Act,
 		loader:             loader,
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/preimages"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -32,6 +34,29 @@ type TxSender interface {
 	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
 }
 
+// ClaimBatcher combines the calls of multiple transactions into a single transaction.
+type ClaimBatcher interface {
+	Aggregate3Tx(txs []txmgr.TxCandidate) (txmgr.TxCandidate, error)
+}
+
+type GasEstimator interface {
+	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+}
+
+// ResolutionBatching configures batching of claim resolution transactions.
+// Batching is disabled if Batcher is nil.
+type ResolutionBatching struct {
+	Batcher   ClaimBatcher
+	Estimator GasEstimator
+	// From is the sender of the transactions, used to estimate their gas
+	From common.Address
+	// MaxGas is the maximum gas of the calls combined into a single transaction
+	MaxGas uint64
+}
+
+// resolutionBatchTimeout bounds the time to estimate the gas of the claim resolutions to batch.
+const resolutionBatchTimeout = time.Minute
+
 // FaultResponder implements the [Responder] interface to send onchain transactions.
 type FaultResponder struct {
 	log      log.Logger
@@ -39,16 +64,18 @@ type FaultResponder struct {
 	contract GameContract
 	uploader preimages.PreimageUploader
 	oracle   Oracle
+	batching ResolutionBatching
 }
 
 // NewFaultResponder returns a new [FaultResponder].
-func NewFaultResponder(logger log.Logger, sender TxSender, contract GameContract, uploader preimages.PreimageUploader, oracle Oracle) (*FaultResponder, error) {
+func NewFaultResponder(logger log.Logger, sender TxSender, contract GameContract, uploader preimages.PreimageUploader, oracle Oracle, batching ResolutionBatching) (*FaultResponder, error) {
 	return &FaultResponder{
 		log:      logger,
 		sender:   sender,
 		contract: contract,
 		uploader: uploader,
 		oracle:   oracle,
+		batching: batching,
 	}, nil
 }
 
@@ -84,7 +111,75 @@ func (r *FaultResponder) ResolveClaims(claimIdxs ...uint64) error {
 		}
 		txs = append(txs, candidate)
 	}
-	return r.sender.SendAndWaitSimple("resolve claim", txs...)
+	if r.batching.Batcher == nil || len(txs) < 2 {
+		return r.sender.SendAndWaitSimple("resolve claim", txs...)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), resolutionBatchTimeout)
+	defer cancel()
+	batches, err := r.batchResolutions(ctx, txs)
+	if err != nil {
+		r.log.Warn("Failed to batch claim resolutions, resolving claims individually", "err", err)
+		return r.sender.SendAndWaitSimple("resolve claim", txs...)
+	}
+	r.log.Info("Resolving claims in batches", "numClaims", len(txs), "numTxs", len(batches))
+	if err := r.sender.SendAndWaitSimple("resolve claims", batches...); err != nil {
+		// Some batches may have been included, only resolve the claims that are still resolvable.
+		r.log.Warn("Failed to resolve claims in batches, resolving remaining claims individually", "err", err)
+		var remaining []txmgr.TxCandidate
+		for i, claimIdx := range claimIdxs {
+			if r.contract.CallResolveClaim(ctx, claimIdx) == nil {
+				remaining = append(remaining, txs[i])
+			}
+		}
+		return r.sender.SendAndWaitSimple("resolve claim", remaining...)
+	}
+	return nil
+}
+
+// batchResolutions combines the claim resolution transactions into batches of at most the max gas.
+// Claims that can no longer be resolved, and thus fail gas estimation, are skipped.
+func (r *FaultResponder) batchResolutions(ctx context.Context, txs []txmgr.TxCandidate) ([]txmgr.TxCandidate, error) {
+	var batches []txmgr.TxCandidate
+	var chunk []txmgr.TxCandidate
+	var chunkGas uint64
+	flush := func() error {
+		switch len(chunk) {
+		case 0:
+			return nil
+		case 1:
+			batches = append(batches, chunk[0])
+		default:
+			batch, err := r.batching.Batcher.Aggregate3Tx(chunk)
+			if err != nil {
+				return err
+			}
+			batches = append(batches, batch)
+		}
+		chunk = nil
+		chunkGas = 0
+		return nil
+	}
+	for _, tx := range txs {
+		gas, err := r.batching.Estimator.EstimateGas(ctx, ethereum.CallMsg{From: r.batching.From, To: tx.To, Data: tx.TxData})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("failed to estimate claim resolution gas: %w", err)
+			}
+			r.log.Debug("Skipping claim resolution that failed gas estimation", "err", err)
+			continue
+		}
+		if len(chunk) > 0 && chunkGas+gas > r.batching.MaxGas {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		chunk = append(chunk, tx)
+		chunkGas += gas
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return batches, nil
 }
 
 func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action) error {
@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -103,6 +104,55 @@ func TestResolveClaim(t *testing.T) {
 	})
 }
 
+func TestResolveClaimsBatched(t *testing.T) {
+	setup := func(t *testing.T, gas map[byte]uint64) (*FaultResponder, *mockTxManager, *mockBatcher) {
+		responder, mockTxMgr, _, _, _ := newTestFaultResponder(t)
+		batcher := &mockBatcher{}
+		responder.batching = ResolutionBatching{
+			Batcher:   batcher,
+			Estimator: mockEstimator(gas),
+			MaxGas:    10_000_000,
+		}
+		return responder, mockTxMgr, batcher
+	}
+
+	t.Run("ChunkUnderGasLimit", func(t *testing.T) {
+		responder, mockTxMgr, batcher := setup(t, map[byte]uint64{0: 4_000_000, 1: 4_000_000, 2: 4_000_000, 3: 4_000_000, 4: 4_000_000})
+		require.NoError(t, responder.ResolveClaims(0, 1, 2, 3, 4))
+		require.Equal(t, [][]byte{{0, 1}, {2, 3}}, batcher.batches)
+		require.Equal(t, []txmgr.TxCandidate{
+			{TxData: []byte{0, 1}},
+			{TxData: []byte{2, 3}},
+			{TxData: []byte{4}},
+		}, mockTxMgr.sent)
+	})
+
+	t.Run("SkipUnresolvable", func(t *testing.T) {
+		responder, mockTxMgr, batcher := setup(t, map[byte]uint64{0: 1000, 2: 1000})
+		require.NoError(t, responder.ResolveClaims(0, 1, 2))
+		require.Equal(t, [][]byte{{0, 2}}, batcher.batches)
+		require.Equal(t, 1, mockTxMgr.sends)
+	})
+
+	t.Run("SingleClaimNotBatched", func(t *testing.T) {
+		responder, mockTxMgr, batcher := setup(t, map[byte]uint64{0: 1000})
+		require.NoError(t, responder.ResolveClaims(0))
+		require.Empty(t, batcher.batches)
+		require.Equal(t, []txmgr.TxCandidate{{TxData: []byte{0}}}, mockTxMgr.sent)
+	})
+
+	t.Run("FallbackToIndividual", func(t *testing.T) {
+		responder, mockTxMgr, _ := setup(t, map[byte]uint64{0: 1000, 1: 1000, 2: 1000})
+		mockTxMgr.failedCalls = 1
+		require.NoError(t, responder.ResolveClaims(0, 1, 2))
+		require.Equal(t, []txmgr.TxCandidate{
+			{TxData: []byte{0}},
+			{TxData: []byte{1}},
+			{TxData: []byte{2}},
+		}, mockTxMgr.sent)
+	})
+}
+
 // TestRespond tests the [Responder.Respond] method.
 func TestPerformAction(t *testing.T) {
 	t.Run("send fails", func(t *testing.T) {
@@ -305,7 +355,7 @@ func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager, *mock
 	contract := &mockContract{}
 	uploader := &mockPreimageUploader{}
 	oracle := &mockOracle{}
-	responder, err := NewFaultResponder(log, mockTxMgr, contract, uploader, oracle)
+	responder, err := NewFaultResponder(log, mockTxMgr, contract, uploader, oracle, ResolutionBatching{})
 	require.NoError(t, err)
 	return responder, mockTxMgr, contract, uploader, oracle
 }
@@ -342,9 +392,15 @@ type mockTxManager struct {
 	sends     int
 	sent      []txmgr.TxCandidate
 	sendFails bool
+	// failedCalls is the number of SendAndWaitSimple calls to fail before sending
+	failedCalls int
 }
 
 func (m *mockTxManager) SendAndWaitSimple(_ string, txs ...txmgr.TxCandidate) error {
+	if m.failedCalls > 0 {
+		m.failedCalls--
+		return mockSendError
+	}
 	for _, tx := range txs {
 		if m.sendFails {
 			return mockSendError
@@ -397,8 +453,8 @@ func (m *mockContract) CallResolveClaim(_ context.Context, _ uint64) error {
 	return nil
 }
 
-func (m *mockContract) ResolveClaimTx(_ uint64) (txmgr.TxCandidate, error) {
-	return txmgr.TxCandidate{}, nil
+func (m *mockContract) ResolveClaimTx(claimIdx uint64) (txmgr.TxCandidate, error) {
+	return txmgr.TxCandidate{TxData: []byte{byte(claimIdx)}}, nil
 }
 
 func (m *mockContract) ChallengeL2BlockNumberTx(challenge *types.InvalidL2BlockNumberChallenge) (txmgr.TxCandidate, error) {
@@ -434,3 +490,28 @@ func (m *mockContract) GetCredit(_ context.Context, _ common.Address) (*big.Int,
 func (m *mockContract) ClaimCredit(_ common.Address) (txmgr.TxCandidate, error) {
 	return txmgr.TxCandidate{TxData: ([]byte)("claimCredit")}, nil
 }
+
+type mockBatcher struct {
+	batches [][]byte
+}
+
+// Aggregate3Tx concatenates the tx data of the batched transactions.
+func (m *mockBatcher) Aggregate3Tx(txs []txmgr.TxCandidate) (txmgr.TxCandidate, error) {
+	var data []byte
+	for _, tx := range txs {
+		data = append(data, tx.TxData...)
+	}
+	m.batches = append(m.batches, data)
+	return txmgr.TxCandidate{TxData: data}, nil
+}
+
+// mockEstimator estimates the gas of claim resolutions by claim index, and fails for unknown claims.
+type mockEstimator map[byte]uint64
+
+func (m mockEstimator) EstimateGas(_ context.Context, msg ethereum.CallMsg) (uint64, error) {
+	gas, ok := m[msg.Data[0]]
+	if !ok {
+		return 0, mockCallError
+	}
+	return gas, nil
+}
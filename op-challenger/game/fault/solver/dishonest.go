@@ -0,0 +1,118 @@
+package solver
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The dishonest policies below simulate adversarial actors, to experiment with game strategies.
+// They are never used by the challenger itself.
+
+// NewMirrorPolicy creates a policy that plays the honest strategy for the opposite outcome:
+// it defends the claims the honest actor attacks, and attacks the claims the honest actor defends,
+// using the values of the trace for its own claims.
+func NewMirrorPolicy(gameDepth types.Depth, trace types.TraceAccessor) *GameSolver {
+	return NewGameSolver(gameDepth, trace, WithClaimHonesty(NewInvertedHonesty(NewTraceHonesty(trace))))
+}
+
+// RandomPolicy counters random claims, with random positions and values.
+// It never steps, as steps with made up data would be rejected by the VM.
+type RandomPolicy struct {
+	rng *rand.Rand
+	// moveProbability is the probability to counter each claim in a round
+	moveProbability float64
+}
+
+var _ Policy = (*RandomPolicy)(nil)
+
+func NewRandomPolicy(rng *rand.Rand, moveProbability float64) *RandomPolicy {
+	return &RandomPolicy{rng: rng, moveProbability: moveProbability}
+}
+
+func (p *RandomPolicy) CalculateNextActions(_ context.Context, game types.Game) ([]types.Action, error) {
+	var actions []types.Action
+	for _, claim := range game.Claims() {
+		if claim.Depth() == game.MaxDepth() || p.rng.Float64() >= p.moveProbability {
+			continue
+		}
+		// The root claim can only be attacked
+		isAttack := claim.IsRoot() || p.rng.Intn(2) == 0
+		var value common.Hash
+		p.rng.Read(value[:])
+		position := claim.Defend()
+		if isAttack {
+			position = claim.Attack()
+		}
+		move := types.Claim{
+			ClaimData:           types.ClaimData{Value: value, Position: position},
+			ParentContractIndex: claim.ContractIndex,
+		}
+		if game.IsDuplicate(move) {
+			continue
+		}
+		actions = append(actions, types.Action{
+			Type:        types.ActionTypeMove,
+			IsAttack:    isAttack,
+			ParentClaim: claim,
+			Value:       value,
+		})
+	}
+	return actions, nil
+}
+
+type actionKey struct {
+	actionType  types.ActionType
+	parentIndex int
+	isAttack    bool
+	value       common.Hash
+}
+
+// DelayPolicy delays the actions of another policy: an action is only taken once the
+// wrapped policy has proposed it in the given number of consecutive rounds.
+// This simulates an actor that responds late, spending its chess clock.
+// Each DelayPolicy tracks the rounds of a single game.
+type DelayPolicy struct {
+	inner  Policy
+	rounds int
+
+	proposed map[actionKey]int
+}
+
+var _ Policy = (*DelayPolicy)(nil)
+
+func NewDelayPolicy(inner Policy, rounds int) *DelayPolicy {
+	return &DelayPolicy{
+		inner:    inner,
+		rounds:   rounds,
+		proposed: make(map[actionKey]int),
+	}
+}
+
+func (p *DelayPolicy) CalculateNextActions(ctx context.Context, game types.Game) ([]types.Action, error) {
+	actions, err := p.inner.CalculateNextActions(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	proposed := make(map[actionKey]int, len(actions))
+	var ready []types.Action
+	for _, action := range actions {
+		key := actionKey{
+			actionType:  action.Type,
+			parentIndex: action.ParentClaim.ContractIndex,
+			isAttack:    action.IsAttack,
+			value:       action.Value,
+		}
+		count := p.proposed[key] + 1
+		if count > p.rounds {
+			ready = append(ready, action)
+			continue
+		}
+		proposed[key] = count
+	}
+	// Actions that were taken or no longer proposed start over
+	p.proposed = proposed
+	return ready, nil
+}
@@ -0,0 +1,74 @@
+package solver
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	faulttest "github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelayPolicy(t *testing.T) {
+	parent := types.Claim{ContractIndex: 3}
+	actionA := types.Action{Type: types.ActionTypeMove, ParentClaim: parent, IsAttack: true, Value: common.Hash{0xaa}}
+	actionB := types.Action{Type: types.ActionTypeMove, ParentClaim: parent, IsAttack: false, Value: common.Hash{0xbb}}
+	inner := &stubPolicy{}
+	policy := NewDelayPolicy(inner, 2)
+
+	next := func() []types.Action {
+		actions, err := policy.CalculateNextActions(context.Background(), nil)
+		require.NoError(t, err)
+		return actions
+	}
+
+	inner.actions = []types.Action{actionA}
+	require.Empty(t, next())
+	require.Empty(t, next())
+	inner.actions = []types.Action{actionA, actionB}
+	require.Equal(t, []types.Action{actionA}, next())
+
+	// Actions no longer proposed start over
+	inner.actions = nil
+	require.Empty(t, next())
+	inner.actions = []types.Action{actionB}
+	require.Empty(t, next())
+	require.Empty(t, next())
+	require.Equal(t, []types.Action{actionB}, next())
+}
+
+func TestRandomPolicy(t *testing.T) {
+	claimBuilder := faulttest.NewAlphabetClaimBuilder(t, big.NewInt(50), types.Depth(4))
+	builder := claimBuilder.GameBuilder()
+	builder.Seq().Attack().Defend().Attack().Attack()
+	game := builder.Game
+	policy := NewRandomPolicy(rand.New(rand.NewSource(1)), 1)
+
+	actions, err := policy.CalculateNextActions(context.Background(), game)
+	require.NoError(t, err)
+	// Every claim except the leaf claim is countered
+	require.Len(t, actions, len(game.Claims())-1)
+	for _, action := range actions {
+		require.Equal(t, types.ActionTypeMove, action.Type)
+		require.NotEqual(t, game.MaxDepth(), action.ParentClaim.Depth())
+		if action.ParentClaim.IsRoot() {
+			require.True(t, action.IsAttack, "must attack the root claim")
+		}
+	}
+
+	never := NewRandomPolicy(rand.New(rand.NewSource(1)), 0)
+	actions, err = never.CalculateNextActions(context.Background(), game)
+	require.NoError(t, err)
+	require.Empty(t, actions)
+}
+
+type stubPolicy struct {
+	actions []types.Action
+}
+
+func (s *stubPolicy) CalculateNextActions(_ context.Context, _ types.Game) ([]types.Action, error) {
+	return s.actions, nil
+}
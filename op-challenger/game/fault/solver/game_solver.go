@@ -9,13 +9,16 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+// GameSolver is the honest [Policy]: it counters every claim that disagrees with its trace.
 type GameSolver struct {
 	claimSolver *claimSolver
 }
 
-func NewGameSolver(gameDepth types.Depth, trace types.TraceAccessor) *GameSolver {
+var _ Policy = (*GameSolver)(nil)
+
+func NewGameSolver(gameDepth types.Depth, trace types.TraceAccessor, opts ...Option) *GameSolver {
 	return &GameSolver{
-		claimSolver: newClaimSolver(gameDepth, trace),
+		claimSolver: newClaimSolver(gameDepth, trace, opts...),
 	}
 }
 
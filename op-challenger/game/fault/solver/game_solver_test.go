@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"math/rand"
 	"testing"
 
 	faulttest "github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
@@ -328,6 +329,67 @@ func TestMultipleRounds(t *testing.T) {
 	}
 }
 
+func TestMultipleRoundsAgainstPolicies(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		actor func(maxDepth types.Depth, trace types.TraceAccessor) actor
+	}{
+		{
+			name: "Mirror",
+			actor: func(maxDepth types.Depth, trace types.TraceAccessor) actor {
+				return policyActor(NewMirrorPolicy(maxDepth, trace))
+			},
+		},
+		{
+			name: "DelayedMirror",
+			actor: func(maxDepth types.Depth, trace types.TraceAccessor) actor {
+				return playRounds(policyActor(NewDelayPolicy(NewMirrorPolicy(maxDepth, trace), 2)), 30)
+			},
+		},
+		{
+			name: "Random",
+			actor: func(maxDepth types.Depth, trace types.TraceAccessor) actor {
+				return playRounds(policyActor(NewRandomPolicy(rand.New(rand.NewSource(42)), 0.3)), 10)
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		for _, rootClaimCorrect := range []bool{true, false} {
+			rootClaimCorrect := rootClaimCorrect
+			t.Run(fmt.Sprintf("%v-%v", test.name, rootClaimCorrect), func(t *testing.T) {
+				t.Parallel()
+
+				maxDepth := types.Depth(6)
+				startingL2BlockNumber := big.NewInt(50)
+				claimBuilder := faulttest.NewAlphabetClaimBuilder(t, startingL2BlockNumber, maxDepth)
+				builder := claimBuilder.GameBuilder(faulttest.WithInvalidValue(!rootClaimCorrect))
+				game := builder.Game
+
+				correctTrace := claimBuilder.CorrectTraceProvider()
+				accessor := trace.NewSimpleTraceAccessor(correctTrace)
+				solver := NewGameSolver(maxDepth, accessor)
+				opponent := test.actor(maxDepth, accessor)
+
+				roundNum := 0
+				done := false
+				for !done {
+					t.Logf("------ ROUND %v ------", roundNum)
+					game, _ = runStep(t, solver, game, correctTrace)
+					verifyGameRules(t, game, rootClaimCorrect)
+
+					game, done = opponent.Apply(t, game, correctTrace)
+					roundNum++
+				}
+				// Give the honest actor the final say
+				game, _ = runStep(t, solver, game, correctTrace)
+				verifyGameRules(t, game, rootClaimCorrect)
+			})
+		}
+	}
+}
+
 func applyActions(game types.Game, claimant common.Address, actions []types.Action) types.Game {
 	claims := game.Claims()
 	for _, action := range actions {
@@ -358,3 +420,45 @@ func applyActions(game types.Game, claimant common.Address, actions []types.Acti
 	}
 	return types.NewGameState(claims, game.MaxDepth())
 }
+
+var dishonestClaimant = common.Address{0xde, 0xad}
+
+// policyActor applies the moves chosen by a Policy, as a dishonest claimant.
+// Steps are not applied as they would only succeed if the policy agreed with the correct trace.
+// The actor is done once the policy has no further moves to make.
+func policyActor(policy Policy) actor {
+	return actorFn(func(t *testing.T, game types.Game, correctTrace types.TraceProvider) (types.Game, bool) {
+		actions, err := policy.CalculateNextActions(context.Background(), game)
+		require.NoError(t, err)
+		var moves []types.Action
+		for _, action := range actions {
+			if action.Type != types.ActionTypeMove || game.IsDuplicate(moveClaim(action)) {
+				continue
+			}
+			moves = append(moves, action)
+		}
+		return applyActions(game, dishonestClaimant, moves), len(moves) == 0
+	})
+}
+
+// playRounds applies an actor for a fixed number of rounds, for policies that may skip a round
+// without being done, or never run out of moves.
+func playRounds(a actor, rounds int) actor {
+	round := 0
+	return actorFn(func(t *testing.T, game types.Game, correctTrace types.TraceProvider) (types.Game, bool) {
+		round++
+		game, _ = a.Apply(t, game, correctTrace)
+		return game, round >= rounds
+	})
+}
+
+func moveClaim(action types.Action) types.Claim {
+	position := action.ParentClaim.Position.Attack()
+	if !action.IsAttack {
+		position = action.ParentClaim.Position.Defend()
+	}
+	return types.Claim{
+		ClaimData:           types.ClaimData{Value: action.Value, Position: position},
+		ParentContractIndex: action.ParentClaim.ContractIndex,
+	}
+}
@@ -0,0 +1,68 @@
+package solver
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+// Policy decides the actions an actor takes in a game.
+type Policy interface {
+	CalculateNextActions(ctx context.Context, game types.Game) ([]types.Action, error)
+}
+
+// ClaimHonesty decides which claims an actor agrees with.
+// The actor defends the claims it agrees with, and attacks the others.
+type ClaimHonesty interface {
+	AgreeWithClaim(ctx context.Context, game types.Game, claim types.Claim) (bool, error)
+}
+
+// StepProvider provides the data to execute a step against a leaf claim.
+type StepProvider interface {
+	GetStepData(ctx context.Context, game types.Game, ref types.Claim, pos types.Position) (prestate []byte, proofData []byte, preimageData *types.PreimageOracleData, err error)
+}
+
+type Option func(s *claimSolver)
+
+// WithClaimHonesty overrides the claims the solver agrees with.
+func WithClaimHonesty(honesty ClaimHonesty) Option {
+	return func(s *claimSolver) {
+		s.honesty = honesty
+	}
+}
+
+// WithStepProvider overrides the source of the data the solver executes steps with.
+func WithStepProvider(steps StepProvider) Option {
+	return func(s *claimSolver) {
+		s.steps = steps
+	}
+}
+
+// TraceHonesty agrees with the claims that match the trace.
+type TraceHonesty struct {
+	trace types.TraceAccessor
+}
+
+func NewTraceHonesty(trace types.TraceAccessor) *TraceHonesty {
+	return &TraceHonesty{trace: trace}
+}
+
+func (h *TraceHonesty) AgreeWithClaim(ctx context.Context, game types.Game, claim types.Claim) (bool, error) {
+	ourValue, err := h.trace.Get(ctx, game, claim, claim.Position)
+	return bytes.Equal(ourValue[:], claim.Value[:]), err
+}
+
+// InvertedHonesty agrees with exactly the claims the wrapped ClaimHonesty disagrees with.
+type InvertedHonesty struct {
+	inner ClaimHonesty
+}
+
+func NewInvertedHonesty(inner ClaimHonesty) *InvertedHonesty {
+	return &InvertedHonesty{inner: inner}
+}
+
+func (h *InvertedHonesty) AgreeWithClaim(ctx context.Context, game types.Game, claim types.Claim) (bool, error) {
+	agree, err := h.inner.AgreeWithClaim(ctx, game, claim)
+	return !agree, err
+}
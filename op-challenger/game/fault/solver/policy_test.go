@@ -0,0 +1,54 @@
+package solver
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	faulttest "github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceHonesty(t *testing.T) {
+	claimBuilder := faulttest.NewAlphabetClaimBuilder(t, big.NewInt(50), types.Depth(4))
+	honesty := NewTraceHonesty(trace.NewSimpleTraceAccessor(claimBuilder.CorrectTraceProvider()))
+	game := claimBuilder.GameBuilder().Game
+	correct := claimBuilder.CreateRootClaim()
+	incorrect := claimBuilder.CreateRootClaim(faulttest.WithValue(common.Hash{0xaa}))
+
+	agree, err := honesty.AgreeWithClaim(context.Background(), game, correct)
+	require.NoError(t, err)
+	require.True(t, agree)
+
+	agree, err = honesty.AgreeWithClaim(context.Background(), game, incorrect)
+	require.NoError(t, err)
+	require.False(t, agree)
+}
+
+func TestInvertedHonesty(t *testing.T) {
+	claim := types.Claim{ClaimData: types.ClaimData{Value: common.Hash{0xaa}}}
+	for _, agree := range []bool{true, false} {
+		honesty := NewInvertedHonesty(&stubHonesty{agree: agree})
+		actual, err := honesty.AgreeWithClaim(context.Background(), nil, claim)
+		require.NoError(t, err)
+		require.Equal(t, !agree, actual)
+	}
+
+	expectedErr := errors.New("boom")
+	honesty := NewInvertedHonesty(&stubHonesty{err: expectedErr})
+	_, err := honesty.AgreeWithClaim(context.Background(), nil, claim)
+	require.ErrorIs(t, err, expectedErr)
+}
+
+type stubHonesty struct {
+	agree bool
+	err   error
+}
+
+func (s *stubHonesty) AgreeWithClaim(_ context.Context, _ types.Game, _ types.Claim) (bool, error) {
+	return s.agree, s.err
+}
@@ -1,7 +1,6 @@
 package solver
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -16,15 +15,24 @@ var (
 // claimSolver uses a [TraceProvider] to determine the moves to make in a dispute game.
 type claimSolver struct {
 	trace     types.TraceAccessor
+	honesty   ClaimHonesty
+	steps     StepProvider
 	gameDepth types.Depth
 }
 
 // newClaimSolver creates a new [claimSolver] using the provided [TraceProvider].
-func newClaimSolver(gameDepth types.Depth, trace types.TraceAccessor) *claimSolver {
-	return &claimSolver{
-		trace,
-		gameDepth,
-	}
+// Claims are judged by the trace and steps are made with data from the trace, unless overridden by the options.
+func newClaimSolver(gameDepth types.Depth, trace types.TraceAccessor, opts ...Option) *claimSolver {
+	s := &claimSolver{
+		trace:     trace,
+		honesty:   NewTraceHonesty(trace),
+		steps:     trace,
+		gameDepth: gameDepth,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 func (s *claimSolver) shouldCounter(game types.Game, claim types.Claim, honestClaims *honestClaimTracker) (bool, error) {
@@ -118,7 +126,7 @@ func (s *claimSolver) AttemptStep(ctx context.Context, game types.Game, claim ty
 		position = claim.Position.MoveRight()
 	}
 
-	preState, proofData, oracleData, err := s.trace.GetStepData(ctx, game, claim, position)
+	preState, proofData, oracleData, err := s.steps.GetStepData(ctx, game, claim, position)
 	if err != nil {
 		return nil, err
 	}
@@ -161,8 +169,7 @@ func (s *claimSolver) defend(ctx context.Context, game types.Game, claim types.C
 	}, nil
 }
 
-// agreeWithClaim returns true if the claim is correct according to the internal [TraceProvider].
+// agreeWithClaim returns true if the claim is correct according to the [ClaimHonesty].
 func (s *claimSolver) agreeWithClaim(ctx context.Context, game types.Game, claim types.Claim) (bool, error) {
-	ourValue, err := s.trace.Get(ctx, game, claim, claim.Position)
-	return bytes.Equal(ourValue[:], claim.Value[:]), err
+	return s.honesty.AgreeWithClaim(ctx, game, claim)
 }
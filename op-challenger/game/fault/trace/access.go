@@ -2,6 +2,7 @@ package trace
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -48,4 +49,43 @@ func (t *Accessor) GetL2BlockNumberChallenge(ctx context.Context, game types.Gam
 	return provider.GetL2BlockNumberChallenge(ctx)
 }
 
+// PruneProofs lets the providers that keep proofs remove the proofs the game can no longer require.
+// Each provider is passed the positions of the claims it provides the trace for.
+func (t *Accessor) PruneProofs(ctx context.Context, game types.Game) error {
+	type prunerClaims struct {
+		pruner  types.ProofPruner
+		claimed []types.Position
+	}
+	var order []types.TraceProvider
+	byProvider := make(map[types.TraceProvider]*prunerClaims)
+	for _, claim := range game.Claims() {
+		provider, err := t.selector(ctx, game, claim, claim.Position)
+		if err != nil {
+			return err
+		}
+		pruner, ok := provider.(types.ProofPruner)
+		if !ok {
+			continue
+		}
+		// Translating providers are created per request, so group the claims by the original provider.
+		key := provider
+		if translated, ok := provider.(*TranslatingProvider); ok {
+			key = translated.Original()
+		}
+		entry, ok := byProvider[key]
+		if !ok {
+			entry = &prunerClaims{pruner: pruner}
+			byProvider[key] = entry
+			order = append(order, key)
+		}
+		entry.claimed = append(entry.claimed, claim.Position)
+	}
+	var errs []error
+	for _, key := range order {
+		entry := byProvider[key]
+		errs = append(errs, entry.pruner.PruneProofs(entry.claimed))
+	}
+	return errors.Join(errs...)
+}
+
 var _ types.TraceAccessor = (*Accessor)(nil)
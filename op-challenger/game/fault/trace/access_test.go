@@ -88,6 +88,41 @@ func TestAccessor_UsesSelector(t *testing.T) {
 	})
 }
 
+func TestAccessor_PruneProofs(t *testing.T) {
+	ctx := context.Background()
+	depth := types.Depth(4)
+	top := alphabet.NewTraceProvider(big.NewInt(0), depth)
+	bottom1 := &stubPruningProvider{TraceProvider: alphabet.NewTraceProvider(big.NewInt(0), 2)}
+	bottom2 := &stubPruningProvider{TraceProvider: alphabet.NewTraceProvider(big.NewInt(0), 2)}
+	claims := []types.Claim{
+		{ClaimData: types.ClaimData{Position: types.RootPosition}},
+		{ClaimData: types.ClaimData{Position: types.NewPositionFromGIndex(big.NewInt(4))}},
+		{ClaimData: types.ClaimData{Position: types.NewPositionFromGIndex(big.NewInt(8))}},
+		{ClaimData: types.ClaimData{Position: types.NewPositionFromGIndex(big.NewInt(12))}},
+		{ClaimData: types.ClaimData{Position: types.NewPositionFromGIndex(big.NewInt(17))}},
+	}
+	game := types.NewGameState(claims, depth)
+
+	accessor := &Accessor{
+		selector: func(ctx context.Context, actualGame types.Game, ref types.Claim, pos types.Position) (types.TraceProvider, error) {
+			require.Equal(t, game, actualGame)
+			require.Equal(t, ref.Position, pos)
+			if pos.Depth() < 3 {
+				return top, nil
+			}
+			// Translating providers are created for each request, selected by the ancestor at the split depth
+			ancestorIdx := new(big.Int).Rsh(pos.IndexAtDepth(), uint(pos.Depth()-3))
+			if ancestorIdx.Sign() == 0 {
+				return Translate(bottom1, 3), nil
+			}
+			return Translate(bottom2, 3), nil
+		},
+	}
+	require.NoError(t, accessor.PruneProofs(ctx, game))
+	require.Equal(t, [][]uint64{{1, 3}}, bottom1.prunedGIndices())
+	require.Equal(t, [][]uint64{{1}}, bottom2.prunedGIndices())
+}
+
 type ChallengeTraceProvider struct {
 	types.TraceProvider
 }
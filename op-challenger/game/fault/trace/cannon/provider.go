@@ -2,7 +2,6 @@ package cannon
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -14,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm/proof"
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm/singlethreaded"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
@@ -91,6 +91,12 @@ func (p *CannonTraceProvider) GetStepData(ctx context.Context, pos types.Positio
 	return value, data, oracleData, nil
 }
 
+// PruneProofs removes the proofs and snapshots that the game can no longer require, given the positions claimed in it.
+// This bounds the disk usage of deep execution games, as proofs of positions that were bisected away are removed.
+func (p *CannonTraceProvider) PruneProofs(claimed []types.Position) error {
+	return vm.PruneProofs(p.logger, p.dir, vm.RequiredProofs(p.gameDepth, claimed), p.lastStep)
+}
+
 func (p *CannonTraceProvider) GetL2BlockNumberChallenge(_ context.Context) (*types.InvalidL2BlockNumberChallenge, error) {
 	return nil, types.ErrL2BlockNumberValid
 }
@@ -154,12 +160,18 @@ func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*utils.P
 		return nil, fmt.Errorf("cannot open proof file (%v): %w", path, err)
 	}
 	defer file.Close()
-	var proof utils.ProofData
-	err = json.NewDecoder(file).Decode(&proof)
+	stepProof, err := proof.Decode(file)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read proof (%v): %w", path, err)
 	}
-	return &proof, nil
+	return &utils.ProofData{
+		ClaimValue:   stepProof.Post,
+		StateData:    stepProof.StateData,
+		ProofData:    stepProof.ProofData,
+		OracleKey:    stepProof.OracleKey,
+		OracleValue:  stepProof.OracleValue,
+		OracleOffset: stepProof.OracleOffset,
+	}, nil
 }
 
 func (c *CannonTraceProvider) finalState() (*singlethreaded.State, error) {
@@ -219,6 +219,19 @@ func TestGetStepData(t *testing.T) {
 	})
 }
 
+func TestPruneProofs(t *testing.T) {
+	dataDir, prestate := setupTestData(t)
+	provider, _ := setupWithTestData(t, dataDir, prestate)
+	require.NoError(t, provider.PruneProofs([]types.Position{PositionFromTraceIndex(provider, big.NewInt(1))}))
+	entries, err := os.ReadDir(filepath.Join(dataDir, utils.ProofsDir))
+	require.NoError(t, err)
+	var remaining []string
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Name())
+	}
+	require.ElementsMatch(t, []string{"1.json.gz", "2.json.gz"}, remaining, "should keep the proofs to step from the leaf claim")
+}
+
 func setupTestData(t *testing.T) (string, string) {
 	srcDir := filepath.Join("test_data", "proofs")
 	entries, err := testData.ReadDir(srcDir)
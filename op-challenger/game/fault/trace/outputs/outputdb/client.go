@@ -0,0 +1,63 @@
+package outputdb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
+)
+
+const metricsLabel = "output_db"
+
+type RollupClient interface {
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+	SafeHeadAtL1Block(ctx context.Context, l1BlockNum uint64) (*eth.SafeHeadResponse, error)
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+// CachingRollupClient is a RollupClient that serves outputs from the DB, and fetches the outputs that aren't
+// stored yet from the rollup node. Fetched outputs of finalized blocks are stored in the DB as they are fetched,
+// so the DB is populated incrementally with the outputs that games bisect over.
+type CachingRollupClient struct {
+	RollupClient
+	log     log.Logger
+	metrics caching.Metrics
+	db      *DB
+}
+
+func NewCachingRollupClient(logger log.Logger, m caching.Metrics, client RollupClient, db *DB) *CachingRollupClient {
+	return &CachingRollupClient{
+		RollupClient: client,
+		log:          logger,
+		metrics:      m,
+		db:           db,
+	}
+}
+
+// OutputAtBlock returns the output of the L2 block. Outputs served from the DB have a nil sync status.
+func (c *CachingRollupClient) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	output, err := c.db.OutputAtBlock(blockNum)
+	if err == nil {
+		c.metrics.CacheGet(metricsLabel, true)
+		return output, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		// The rollup node is still the source of truth, so continue without the DB.
+		c.log.Warn("Failed to read output from db", "block", blockNum, "err", err)
+	}
+	c.metrics.CacheGet(metricsLabel, false)
+	output, err = c.RollupClient.OutputAtBlock(ctx, blockNum)
+	if err != nil {
+		return nil, err
+	}
+	// Only outputs of finalized blocks are stored, as the outputs of other blocks can still change in a reorg.
+	if output.Status != nil && output.BlockRef.Number == blockNum && blockNum <= output.Status.FinalizedL2.Number {
+		if err := c.db.StoreOutput(output); err != nil {
+			c.log.Warn("Failed to store output in db", "block", blockNum, "err", err)
+		}
+	}
+	return output, nil
+}
@@ -0,0 +1,82 @@
+package outputdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestCachingRollupClient(t *testing.T) {
+	setup := func(t *testing.T) (*CachingRollupClient, *stubRollupClient) {
+		db, err := Open(testlog.Logger(t, log.LevelInfo), t.TempDir(), genesis)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+		rollup := &stubRollupClient{outputs: make(map[uint64]*eth.OutputResponse), finalized: 100}
+		return NewCachingRollupClient(testlog.Logger(t, log.LevelInfo), metrics.NoopMetrics, rollup, db), rollup
+	}
+
+	t.Run("StoreFinalizedOutputs", func(t *testing.T) {
+		client, rollup := setup(t)
+		rollup.outputs[100] = newOutput(100)
+		for i := 0; i < 3; i++ {
+			output, err := client.OutputAtBlock(context.Background(), 100)
+			require.NoError(t, err)
+			require.Equal(t, rollup.outputs[100].OutputRoot, output.OutputRoot)
+		}
+		require.Equal(t, 1, rollup.requests, "should only fetch output from rollup node once")
+	})
+
+	t.Run("DoNotStoreUnfinalizedOutputs", func(t *testing.T) {
+		client, rollup := setup(t)
+		rollup.outputs[101] = newOutput(101)
+		for i := 0; i < 3; i++ {
+			output, err := client.OutputAtBlock(context.Background(), 101)
+			require.NoError(t, err)
+			require.Equal(t, rollup.outputs[101].OutputRoot, output.OutputRoot)
+		}
+		require.Equal(t, 3, rollup.requests)
+
+		// Store the output once the block is finalized
+		rollup.finalized = 101
+		_, err := client.OutputAtBlock(context.Background(), 101)
+		require.NoError(t, err)
+		_, err = client.OutputAtBlock(context.Background(), 101)
+		require.NoError(t, err)
+		require.Equal(t, 4, rollup.requests)
+	})
+
+	t.Run("ReturnErrors", func(t *testing.T) {
+		client, rollup := setup(t)
+		_, err := client.OutputAtBlock(context.Background(), 50)
+		require.ErrorIs(t, err, errNotAvailable)
+		require.Equal(t, 1, rollup.requests)
+	})
+}
+
+var errNotAvailable = errors.New("not available")
+
+type stubRollupClient struct {
+	RollupClient
+	outputs   map[uint64]*eth.OutputResponse
+	finalized uint64
+	requests  int
+}
+
+func (s *stubRollupClient) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	s.requests++
+	output, ok := s.outputs[blockNum]
+	if !ok {
+		return nil, errNotAvailable
+	}
+	// Copy the output, so the cached value can't be modified
+	result := *output
+	result.Status = &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: s.finalized}}
+	return &result, nil
+}
@@ -0,0 +1,134 @@
+package outputdb
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrWrongChain   = errors.New("output db is for a different chain")
+	ErrInvalidEntry = errors.New("invalid db entry")
+	ErrClosed       = errors.New("output db is closed")
+)
+
+const (
+	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
+	keyPrefixOutputByL2BlockNum byte = 0
+	keyPrefixGenesis            byte = 1
+)
+
+func outputKey(blockNum uint64) []byte {
+	key := make([]byte, 0, 9)
+	key = append(key, keyPrefixOutputByL2BlockNum)
+	return binary.BigEndian.AppendUint64(key, blockNum)
+}
+
+var genesisKey = []byte{keyPrefixGenesis}
+
+// DB stores the L2 output roots of finalized blocks, so they don't need to be fetched from the rollup node again.
+// Outputs of finalized blocks never change, so entries never need to be invalidated.
+type DB struct {
+	// m prevents closing the database while it is read or written.
+	m      sync.RWMutex
+	log    log.Logger
+	db     *pebble.DB
+	closed bool
+}
+
+// Open opens the output database at path, creating it if it doesn't exist yet.
+// The database only stores outputs of the chain with the given L2 genesis hash,
+// and fails to open with ErrWrongChain if it was created for another chain.
+func Open(logger log.Logger, path string, l2Genesis common.Hash) (*DB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGenesis(db, l2Genesis); err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+	return &DB{log: logger, db: db}, nil
+}
+
+func checkGenesis(db *pebble.DB, l2Genesis common.Hash) error {
+	val, closer, err := db.Get(genesisKey)
+	if errors.Is(err, pebble.ErrNotFound) {
+		if err := db.Set(genesisKey, l2Genesis.Bytes(), pebble.Sync); err != nil {
+			return fmt.Errorf("failed to record L2 genesis: %w", err)
+		}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to read L2 genesis: %w", err)
+	}
+	defer closer.Close()
+	if stored := common.BytesToHash(val); stored != l2Genesis {
+		return fmt.Errorf("%w: stored L2 genesis %v, expected %v", ErrWrongChain, stored, l2Genesis)
+	}
+	return nil
+}
+
+// OutputAtBlock returns the stored output of the L2 block, or ErrNotFound if it isn't stored.
+// The sync status of the returned output is always nil.
+func (d *DB) OutputAtBlock(blockNum uint64) (*eth.OutputResponse, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.closed {
+		return nil, ErrClosed
+	}
+	val, closer, err := d.db.Get(outputKey(blockNum))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read output at block %v: %w", blockNum, err)
+	}
+	defer closer.Close()
+	var output eth.OutputResponse
+	if err := json.Unmarshal(val, &output); err != nil {
+		return nil, fmt.Errorf("%w: output at block %v: %w", ErrInvalidEntry, blockNum, err)
+	}
+	if output.BlockRef.Number != blockNum {
+		return nil, fmt.Errorf("%w: output at block %v is for block %v", ErrInvalidEntry, blockNum, output.BlockRef.Number)
+	}
+	return &output, nil
+}
+
+// StoreOutput stores the output of a finalized L2 block.
+// The sync status of the output isn't stored.
+func (d *DB) StoreOutput(output *eth.OutputResponse) error {
+	stored := *output
+	stored.Status = nil
+	val, err := json.Marshal(&stored)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+	d.m.RLock()
+	defer d.m.RUnlock()
+	if d.closed {
+		return ErrClosed
+	}
+	// Outputs can be fetched again if they are lost, so there's no need to sync writes.
+	if err := d.db.Set(outputKey(output.BlockRef.Number), val, pebble.NoSync); err != nil {
+		return fmt.Errorf("failed to store output at block %v: %w", output.BlockRef.Number, err)
+	}
+	return nil
+}
+
+func (d *DB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		// Already closed
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
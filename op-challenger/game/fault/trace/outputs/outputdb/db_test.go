@@ -0,0 +1,77 @@
+package outputdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var genesis = common.Hash{0x01}
+
+func newOutput(blockNum uint64) *eth.OutputResponse {
+	return &eth.OutputResponse{
+		OutputRoot:            eth.Bytes32{0xaa, byte(blockNum)},
+		BlockRef:              eth.L2BlockRef{Hash: common.Hash{0xbb, byte(blockNum)}, Number: blockNum},
+		WithdrawalStorageRoot: common.Hash{0xcc},
+		StateRoot:             common.Hash{0xdd},
+	}
+}
+
+func TestStoreOutputs(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	db, err := Open(logger, dir, genesis)
+	require.NoError(t, err)
+
+	_, err = db.OutputAtBlock(10)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	output := newOutput(10)
+	output.Status = &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 20}}
+	require.NoError(t, db.StoreOutput(output))
+	require.NoError(t, db.StoreOutput(newOutput(11)))
+
+	verify := func(db *DB) {
+		actual, err := db.OutputAtBlock(10)
+		require.NoError(t, err)
+		require.Equal(t, newOutput(10), actual, "should not store sync status")
+		actual, err = db.OutputAtBlock(11)
+		require.NoError(t, err)
+		require.Equal(t, newOutput(11), actual)
+		_, err = db.OutputAtBlock(12)
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+	verify(db)
+
+	require.NoError(t, db.Close())
+	_, err = db.OutputAtBlock(10)
+	require.ErrorIs(t, err, ErrClosed)
+	require.ErrorIs(t, db.StoreOutput(newOutput(12)), ErrClosed)
+
+	// Outputs persist across restarts
+	db, err = Open(logger, dir, genesis)
+	require.NoError(t, err)
+	defer db.Close()
+	verify(db)
+}
+
+func TestRejectOtherChain(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	db, err := Open(logger, dir, genesis)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	_, err = Open(logger, dir, common.Hash{0x02})
+	require.ErrorIs(t, err, ErrWrongChain)
+
+	// Database is still usable for the original chain
+	db, err = Open(logger, dir, genesis)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+}
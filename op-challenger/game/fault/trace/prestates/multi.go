@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 
+	registry "github.com/ethereum-optimism/optimism/op-program/prestates"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -18,14 +19,16 @@ var (
 )
 
 type MultiPrestateProvider struct {
-	baseUrl *url.URL
-	dataDir string
+	baseUrl  *url.URL
+	dataDir  string
+	registry *registry.Registry
 }
 
 func NewMultiPrestateProvider(baseUrl *url.URL, dataDir string) *MultiPrestateProvider {
 	return &MultiPrestateProvider{
-		baseUrl: baseUrl,
-		dataDir: dataDir,
+		baseUrl:  baseUrl,
+		dataDir:  dataDir,
+		registry: registry.Default(),
 	}
 }
 
@@ -33,7 +36,7 @@ func (m *MultiPrestateProvider) PrestatePath(hash common.Hash) (string, error) {
 	path := filepath.Join(m.dataDir, hash.Hex()+".json.gz")
 	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
 		if err := m.fetchPrestate(hash, path); err != nil {
-			return "", fmt.Errorf("failed to fetch prestate: %w", err)
+			return "", fmt.Errorf("failed to fetch prestate of %v: %w", m.describe(hash), err)
 		}
 	} else if err != nil {
 		return "", fmt.Errorf("error checking for existing prestate %v: %w", hash, err)
@@ -41,11 +44,38 @@ func (m *MultiPrestateProvider) PrestatePath(hash common.Hash) (string, error) {
 	return path, nil
 }
 
+// describe identifies the op-program release of the prestate, if it is in the registry.
+func (m *MultiPrestateProvider) describe(hash common.Hash) string {
+	release, err := m.registry.ByHash(hash)
+	if err != nil {
+		return "unknown release"
+	}
+	return release.String()
+}
+
 func (m *MultiPrestateProvider) fetchPrestate(hash common.Hash, dest string) error {
 	if err := os.MkdirAll(m.dataDir, 0755); err != nil {
 		return fmt.Errorf("error creating prestate dir: %w", err)
 	}
-	prestateUrl := m.baseUrl.JoinPath(hash.Hex() + ".json")
+	err := m.download(m.baseUrl.JoinPath(hash.Hex()+".json"), dest)
+	if !errors.Is(err, ErrPrestateUnavailable) {
+		return err
+	}
+	// Fall back to the download URL from the registry, for prestates not published at the base URL
+	if release, regErr := m.registry.ByHash(hash); regErr == nil && release.URL != "" {
+		releaseUrl, urlErr := release.DownloadURL(nil)
+		if urlErr != nil {
+			return errors.Join(err, urlErr)
+		}
+		if releaseErr := m.download(releaseUrl, dest); releaseErr != nil {
+			return errors.Join(err, releaseErr)
+		}
+		return nil
+	}
+	return err
+}
+
+func (m *MultiPrestateProvider) download(prestateUrl *url.URL, dest string) error {
 	resp, err := http.Get(prestateUrl.String())
 	if err != nil {
 		return fmt.Errorf("failed to fetch prestate from %v: %w", prestateUrl, err)
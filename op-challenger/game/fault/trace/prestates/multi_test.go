@@ -1,6 +1,7 @@
 package prestates
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	registry "github.com/ethereum-optimism/optimism/op-program/prestates"
 	"github.com/ethereum-optimism/optimism/op-service/ioutil"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
@@ -84,6 +86,36 @@ func TestMissingPrestate(t *testing.T) {
 	require.ErrorIs(t, err, os.ErrNotExist)
 }
 
+func TestPrestateFromRegistry(t *testing.T) {
+	dir := t.TempDir()
+	hash := common.Hash{0xaa}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/releases/1.0.0.json" {
+			w.WriteHeader(404)
+			return
+		}
+		_, _ = w.Write([]byte("release prestate"))
+	}))
+	defer server.Close()
+	releases, err := registry.Parse([]byte(fmt.Sprintf(`[{"version": "1.0.0", "hash": "%v", "type": "cannon", "url": "%v/releases/1.0.0.json"}]`, hash, server.URL)))
+	require.NoError(t, err)
+	provider := NewMultiPrestateProvider(parseURL(t, server.URL), dir)
+	provider.registry = releases
+
+	path, err := provider.PrestatePath(hash)
+	require.NoError(t, err)
+	in, err := ioutil.OpenDecompressed(path)
+	require.NoError(t, err)
+	defer in.Close()
+	content, err := io.ReadAll(in)
+	require.NoError(t, err)
+	require.Equal(t, "release prestate", string(content))
+
+	_, err = provider.PrestatePath(common.Hash{0xbb})
+	require.ErrorIs(t, err, ErrPrestateUnavailable)
+	require.ErrorContains(t, err, "unknown release")
+}
+
 func parseURL(t *testing.T, str string) *url.URL {
 	parsed, err := url.Parse(str)
 	require.NoError(t, err)
@@ -50,4 +50,26 @@ func (p *TranslatingProvider) GetL2BlockNumberChallenge(ctx context.Context) (*t
 	return p.provider.GetL2BlockNumberChallenge(ctx)
 }
 
+// PruneProofs translates the claimed positions, ignoring those above the root of the provider,
+// and passes them on to the provider, if it keeps proofs.
+func (p *TranslatingProvider) PruneProofs(claimed []types.Position) error {
+	pruner, ok := p.provider.(types.ProofPruner)
+	if !ok {
+		return nil
+	}
+	relative := make([]types.Position, 0, len(claimed))
+	for _, pos := range claimed {
+		if pos.Depth() < p.rootDepth {
+			continue
+		}
+		relativePos, err := pos.RelativeToAncestorAtDepth(p.rootDepth)
+		if err != nil {
+			return err
+		}
+		relative = append(relative, relativePos)
+	}
+	return pruner.PruneProofs(relative)
+}
+
 var _ types.TraceProvider = (*TranslatingProvider)(nil)
+var _ types.ProofPruner = (*TranslatingProvider)(nil)
@@ -58,3 +58,37 @@ func TestTranslate_AbsolutePreStateCommitment(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, origValue, translatedValue)
 }
+
+func TestTranslate_PruneProofs(t *testing.T) {
+	orig := &stubPruningProvider{TraceProvider: alphabet.NewTraceProvider(big.NewInt(0), 4)}
+	translated := Translate(orig, 3)
+	claimed := []types.Position{
+		types.NewPositionFromGIndex(big.NewInt(4)),  // Above the translated root
+		types.NewPositionFromGIndex(big.NewInt(8)),  // Translated root
+		types.NewPositionFromGIndex(big.NewInt(19)), // GIndex 3 relative to the translated root
+	}
+	require.NoError(t, translated.(types.ProofPruner).PruneProofs(claimed))
+	require.Equal(t, [][]uint64{{1, 3}}, orig.prunedGIndices())
+}
+
+type stubPruningProvider struct {
+	types.TraceProvider
+	pruned [][]types.Position
+}
+
+func (s *stubPruningProvider) PruneProofs(claimed []types.Position) error {
+	s.pruned = append(s.pruned, claimed)
+	return nil
+}
+
+func (s *stubPruningProvider) prunedGIndices() [][]uint64 {
+	var gIndices [][]uint64
+	for _, claimed := range s.pruned {
+		var indices []uint64
+		for _, pos := range claimed {
+			indices = append(indices, pos.ToGIndex().Uint64())
+		}
+		gIndices = append(gIndices, indices)
+	}
+	return gIndices
+}
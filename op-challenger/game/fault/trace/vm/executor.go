@@ -2,7 +2,9 @@ package vm
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"math"
 	"os"
 	"path/filepath"
@@ -13,17 +15,21 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 const (
-	debugFilename = "debug-info.json"
+	debugFilename      = "debug-info.json"
+	exitReportFilename = "exit-report.json"
 )
 
 type Metricer interface {
 	RecordVmExecutionTime(vmType string, t time.Duration)
 	RecordVmMemoryUsed(vmType string, memoryUsed uint64)
+	RecordVmPageGrowthRate(vmType string, rate float64)
+	RecordVmFailure(vmType string, reason string)
 }
 
 type Config struct {
@@ -33,15 +39,24 @@ type Config struct {
 	SnapshotFreq uint   // Frequency of snapshots to create when executing (in VM instructions)
 	InfoFreq     uint   // Frequency of progress log messages (in VM instructions)
 	DebugInfo    bool
+	// ExitReport enables the exit report of each execution, which is logged to explain why the execution stopped
+	ExitReport bool
 
 	// Host Configuration
-	L1               string
-	L1Beacon         string
-	L2               string
-	Server           string // Path to the executable that provides the pre-image oracle server
-	Network          string
-	RollupConfigPath string
-	L2GenesisPath    string
+	L1       string
+	L1Beacon string
+	// L1BeaconFallbacks are Beacon API endpoints, such as blob archivers, used when blobs have expired from L1Beacon
+	L1BeaconFallbacks []string
+	L2                string
+	Server            string // Path to the executable that provides the pre-image oracle server
+	Network           string
+	RollupConfigPath  string
+	L2GenesisPath     string
+
+	// Limiter bounds the concurrent executions of all executors sharing it. Not limited if nil.
+	Limiter *ExecutionLimiter
+	// Sandbox limits the resources of each execution
+	Sandbox SandboxConfig
 }
 
 type OracleServerExecutor interface {
@@ -68,7 +83,7 @@ func NewExecutor(logger log.Logger, m Metricer, cfg Config, oracleServer OracleS
 		inputs:           inputs,
 		absolutePreState: prestate,
 		selectSnapshot:   FindStartingSnapshot,
-		cmdExecutor:      RunCmd,
+		cmdExecutor:      SandboxedCmdExecutor(cfg.Sandbox),
 	}
 }
 
@@ -106,6 +121,14 @@ func (e *Executor) DoGenerateProof(ctx context.Context, dir string, begin uint64
 	if e.cfg.DebugInfo {
 		args = append(args, "--debug-info", filepath.Join(dataDir, debugFilename))
 	}
+	exitReportPath := filepath.Join(dataDir, exitReportFilename)
+	if e.cfg.ExitReport {
+		// don't log the report of a previous execution if this one fails to write its own
+		if err := os.Remove(exitReportPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("could not remove previous exit report: %w", err)
+		}
+		args = append(args, "--exit-report", exitReportPath)
+	}
 	args = append(args, extraVmArgs...)
 	args = append(args, "--")
 	oracleArgs, err := e.oracleServer.OracleCommand(e.cfg, dataDir, e.inputs)
@@ -123,24 +146,71 @@ func (e *Executor) DoGenerateProof(ctx context.Context, dir string, begin uint64
 	if err := os.MkdirAll(proofDir, 0755); err != nil {
 		return fmt.Errorf("could not create proofs directory %v: %w", proofDir, err)
 	}
+	if err := e.cfg.Limiter.Acquire(ctx); err != nil {
+		return fmt.Errorf("waiting to execute vm: %w", err)
+	}
+	defer e.cfg.Limiter.Release()
 	e.logger.Info("Generating trace", "proof", end, "cmd", e.cfg.VmBin, "args", strings.Join(args, ", "))
 	execStart := time.Now()
 	err = e.cmdExecutor(ctx, e.logger.New("proof", end), e.cfg.VmBin, args...)
 	execTime := time.Since(execStart)
 	memoryUsed := "unknown"
 	e.metrics.RecordVmExecutionTime(e.cfg.VmType.String(), execTime)
-	if e.cfg.DebugInfo && err == nil {
+	if err != nil {
+		reason := vmFailureReason(err)
+		e.metrics.RecordVmFailure(e.cfg.VmType.String(), reason)
+		e.logger.Error("VM execution failed", "time", execTime, "reason", reason, "err", err)
+		if e.cfg.ExitReport {
+			e.logExitReport(log.LevelError, exitReportPath)
+		}
+		return err
+	}
+	if e.cfg.DebugInfo {
 		if info, err := jsonutil.LoadJSON[debugInfo](filepath.Join(dataDir, debugFilename)); err != nil {
 			e.logger.Warn("Failed to load debug metrics", "err", err)
 		} else {
 			e.metrics.RecordVmMemoryUsed(e.cfg.VmType.String(), uint64(info.MemoryUsed))
+			e.metrics.RecordVmPageGrowthRate(e.cfg.VmType.String(), info.PageGrowthRate)
 			memoryUsed = fmt.Sprintf("%d", uint64(info.MemoryUsed))
 		}
 	}
 	e.logger.Info("VM execution complete", "time", execTime, "memory", memoryUsed)
-	return err
+	if e.cfg.ExitReport {
+		e.logExitReport(log.LevelInfo, exitReportPath)
+	}
+	return nil
+}
+
+// logExitReport logs the exit report of an execution, explaining why it stopped.
+func (e *Executor) logExitReport(lvl slog.Level, path string) {
+	report, err := jsonutil.LoadJSON[exitReport](path)
+	if err != nil {
+		e.logger.Warn("Failed to load VM exit report", "err", err)
+		return
+	}
+	syscalls := make([]uint32, 0, len(report.Syscalls))
+	for _, syscall := range report.Syscalls {
+		syscalls = append(syscalls, syscall.Num)
+	}
+	e.logger.Log(lvl, "VM exit report", "class", report.Class, "exitCode", report.ExitCode, "step", report.Step,
+		"pc", report.PC, "symbol", report.Symbol, "preimageKey", report.PreimageKey, "syscalls", syscalls, "err", report.Error)
+}
+
+// exitReport is the exit report written by the vm.
+type exitReport struct {
+	Class       string      `json:"class"`
+	ExitCode    uint8       `json:"exitCode"`
+	Step        uint64      `json:"step"`
+	PC          string      `json:"pc"`
+	Symbol      string      `json:"symbol"`
+	Error       string      `json:"error"`
+	PreimageKey common.Hash `json:"preimageKey"`
+	Syscalls    []struct {
+		Num uint32 `json:"num"`
+	} `json:"syscalls"`
 }
 
 type debugInfo struct {
-	MemoryUsed hexutil.Uint64 `json:"memory_used"`
+	MemoryUsed     hexutil.Uint64 `json:"memory_used"`
+	PageGrowthRate float64        `json:"page_growth_rate"`
 }
@@ -2,9 +2,13 @@ package vm
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math"
 	"math/big"
+	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -130,9 +134,69 @@ func TestGenerateProof(t *testing.T) {
 	})
 }
 
+func TestGenerateProofFailure(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{VmType: "test", VmBin: "./bin/testvm", Server: "./bin/testserver", SnapshotFreq: 500, InfoFreq: 900}
+	inputs := utils.LocalGameInputs{L2BlockNumber: big.NewInt(3333)}
+	for _, test := range []struct {
+		err    error
+		reason string
+	}{
+		{err: fmt.Errorf("%w: signal: killed", ErrVmOutOfMemory), reason: VmFailureOutOfMemory},
+		{err: fmt.Errorf("%w of 1h0m0s: signal: killed", ErrVmTimeout), reason: VmFailureTimeout},
+		{err: errors.New("exit status 1"), reason: VmFailureError},
+	} {
+		t.Run(test.reason, func(t *testing.T) {
+			m := &stubVmMetrics{}
+			executor := NewExecutor(testlog.Logger(t, log.LevelCrit), m, cfg, NewOpProgramServerExecutor(), "pre.json", inputs)
+			executor.cmdExecutor = func(ctx context.Context, l log.Logger, b string, a ...string) error {
+				return test.err
+			}
+			err := executor.GenerateProof(context.Background(), dir, 100)
+			require.ErrorIs(t, err, test.err)
+			require.Equal(t, 1, m.executionTimeRecordCount)
+			require.Equal(t, []string{test.reason}, m.failureReasons)
+		})
+	}
+}
+
+func TestGenerateProofExitReport(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{VmType: "test", VmBin: "./bin/testvm", Server: "./bin/testserver", SnapshotFreq: 500, InfoFreq: 900, ExitReport: true}
+	inputs := utils.LocalGameInputs{L2BlockNumber: big.NewInt(3333)}
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	executor := NewExecutor(logger, &stubVmMetrics{}, cfg, NewOpProgramServerExecutor(), "pre.json", inputs)
+	execErr := errors.New("exit status 1")
+	executor.cmdExecutor = func(ctx context.Context, l log.Logger, b string, a ...string) error {
+		i := slices.Index(a, "--exit-report")
+		require.NotEqual(t, -1, i)
+		require.Equal(t, filepath.Join(dir, PreimagesDir, exitReportFilename), a[i+1])
+		require.NoFileExists(t, a[i+1], "should remove the report of the previous execution")
+		report := `{"class":"oracle_error","exitCode":0,"step":42,"pc":"00001000","error":"pre-image server exited","syscalls":[{"num":4003},{"num":4004}]}`
+		require.NoError(t, os.WriteFile(a[i+1], []byte(report), 0o644))
+		return execErr
+	}
+	require.ErrorIs(t, executor.GenerateProof(context.Background(), dir, 100), execErr)
+	// the report of the execution is replaced by the next one
+	require.ErrorIs(t, executor.GenerateProof(context.Background(), dir, 100), execErr)
+
+	msg := logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter("VM exit report"))
+	require.NotNil(t, msg)
+	require.Equal(t, "oracle_error", msg.AttrValue("class"))
+	require.Equal(t, uint64(42), msg.AttrValue("step"))
+	require.Equal(t, "00001000", msg.AttrValue("pc"))
+	require.Equal(t, []uint32{4003, 4004}, msg.AttrValue("syscalls"))
+	require.Equal(t, "pre-image server exited", msg.AttrValue("err"))
+}
+
 type stubVmMetrics struct {
 	metrics.NoopMetricsImpl
 	executionTimeRecordCount int
+	failureReasons           []string
+}
+
+func (c *stubVmMetrics) RecordVmFailure(_ string, reason string) {
+	c.failureReasons = append(c.failureReasons, reason)
 }
 
 func (c *stubVmMetrics) RecordVmExecutionTime(_ string, _ time.Duration) {
@@ -0,0 +1,51 @@
+package vm
+
+import (
+	"context"
+	"time"
+)
+
+type LimiterMetrics interface {
+	RecordVmExecutionQueueTime(t time.Duration)
+}
+
+// ExecutionLimiter bounds the number of VM executions that run concurrently, across all games.
+// A nil ExecutionLimiter does not limit executions.
+type ExecutionLimiter struct {
+	metrics LimiterMetrics
+	slots   chan struct{}
+}
+
+// NewExecutionLimiter creates a limiter allowing max concurrent executions.
+// Returns nil, so executions are not limited, if max is 0.
+func NewExecutionLimiter(m LimiterMetrics, max uint) *ExecutionLimiter {
+	if max == 0 {
+		return nil
+	}
+	return &ExecutionLimiter{
+		metrics: m,
+		slots:   make(chan struct{}, max),
+	}
+}
+
+// Acquire waits until an execution may start. Release must be called once the execution completes.
+func (l *ExecutionLimiter) Acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	start := time.Now()
+	select {
+	case l.slots <- struct{}{}:
+		l.metrics.RecordVmExecutionQueueTime(time.Since(start))
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *ExecutionLimiter) Release() {
+	if l == nil {
+		return
+	}
+	<-l.slots
+}
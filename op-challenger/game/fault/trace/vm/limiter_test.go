@@ -0,0 +1,52 @@
+package vm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionLimiter(t *testing.T) {
+	t.Run("NilDoesNotLimit", func(t *testing.T) {
+		limiter := NewExecutionLimiter(&stubLimiterMetrics{}, 0)
+		require.Nil(t, limiter)
+		for i := 0; i < 10; i++ {
+			require.NoError(t, limiter.Acquire(context.Background()))
+		}
+		limiter.Release()
+	})
+
+	t.Run("Limits", func(t *testing.T) {
+		m := &stubLimiterMetrics{}
+		limiter := NewExecutionLimiter(m, 2)
+		require.NoError(t, limiter.Acquire(context.Background()))
+		require.NoError(t, limiter.Acquire(context.Background()))
+		require.Equal(t, 2, m.queueTimeRecordCount)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		require.ErrorIs(t, limiter.Acquire(ctx), context.DeadlineExceeded)
+
+		acquired := make(chan error, 1)
+		go func() {
+			acquired <- limiter.Acquire(context.Background())
+		}()
+		limiter.Release()
+		select {
+		case err := <-acquired:
+			require.NoError(t, err)
+		case <-time.After(10 * time.Second):
+			require.FailNow(t, "Did not acquire after release")
+		}
+	})
+}
+
+type stubLimiterMetrics struct {
+	queueTimeRecordCount int
+}
+
+func (s *stubLimiterMetrics) RecordVmExecutionQueueTime(_ time.Duration) {
+	s.queueTimeRecordCount++
+}
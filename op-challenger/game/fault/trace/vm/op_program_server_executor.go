@@ -26,6 +26,9 @@ func (s *OpProgramServerExecutor) OracleCommand(cfg Config, dataDir string, inpu
 		"--l2.claim", inputs.L2Claim.Hex(),
 		"--l2.blocknumber", inputs.L2BlockNumber.Text(10),
 	}
+	for _, fallback := range cfg.L1BeaconFallbacks {
+		args = append(args, "--l1.beacon-fallbacks", fallback)
+	}
 	if cfg.Network != "" {
 		args = append(args, "--network", cfg.Network)
 	}
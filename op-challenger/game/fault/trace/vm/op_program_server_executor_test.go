@@ -81,6 +81,21 @@ func TestOpProgramFillHostCommand(t *testing.T) {
 		require.True(t, slices.Contains(args, "--l2.genesis"))
 	})
 
+	t.Run("WithL1BeaconFallbacks", func(t *testing.T) {
+		cfg := cfg
+		cfg.L1BeaconFallbacks = []string{"http://archiver:9000", "http://backup:9000"}
+		vmConfig := NewOpProgramServerExecutor()
+
+		args, err := vmConfig.OracleCommand(cfg, dir, inputs)
+		require.NoError(t, err)
+
+		validateStandard(t, args)
+		idx := slices.Index(args, "--l1.beacon-fallbacks")
+		require.GreaterOrEqual(t, idx, 0)
+		require.Equal(t, "http://archiver:9000", args[idx+1])
+		require.Equal(t, []string{"--l1.beacon-fallbacks", "http://backup:9000"}, args[idx+2:idx+4])
+	})
+
 	t.Run("WithAllExtras", func(t *testing.T) {
 		cfg.Network = "op-test"
 		cfg.RollupConfigPath = "rollup.config"
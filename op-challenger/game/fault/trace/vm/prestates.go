@@ -30,7 +30,11 @@ func PreimageDir(dir string) string {
 }
 
 func RunCmd(ctx context.Context, l log.Logger, binary string, args ...string) error {
-	cmd := exec.CommandContext(ctx, binary, args...)
+	return runLogged(l, exec.CommandContext(ctx, binary, args...))
+}
+
+// runLogged runs the command, logging its output.
+func runLogged(l log.Logger, cmd *exec.Cmd) error {
 	stdOut := log2.NewWriter(l, log.LevelInfo)
 	defer stdOut.Close()
 	// Keep stdErr at info level because FPVM uses stderr for progress messages
@@ -0,0 +1,201 @@
+package vm
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+// TraceRange is an inclusive range of trace indices.
+type TraceRange struct {
+	Start uint64
+	End   uint64
+}
+
+func (r TraceRange) Contains(i uint64) bool {
+	return r.Start <= i && i <= r.End
+}
+
+// ProofRequirements are the proofs an execution game may require, given the positions claimed in it.
+type ProofRequirements struct {
+	// Proofs are the sorted trace indices of the proofs the game requires with its current claims:
+	// the values of the claims, of the positions that counter them, and the pre-states of steps countering leaf claims.
+	Proofs []uint64
+	// Ranges are the sorted, disjoint trace index ranges of all positions that can ever be claimed in the game:
+	// the subtrees of the positions that counter the current claims. New claims can only be made within these.
+	Ranges []TraceRange
+}
+
+// RequiredProofs computes the proofs a game of maxDepth may require, given the positions claimed in it.
+// Proofs at trace indices outside of the requirements can never be required by the game.
+func RequiredProofs(maxDepth types.Depth, claimed []types.Position) ProofRequirements {
+	var req ProofRequirements
+	addProof := func(pos types.Position) {
+		if idx := pos.TraceIndex(maxDepth); idx.IsUint64() {
+			req.Proofs = append(req.Proofs, idx.Uint64())
+		}
+	}
+	addSubtree := func(pos types.Position) {
+		addProof(pos)
+		start := new(big.Int).Lsh(pos.IndexAtDepth(), uint(maxDepth-pos.Depth()))
+		end := pos.TraceIndex(maxDepth)
+		if start.IsUint64() && end.IsUint64() {
+			req.Ranges = append(req.Ranges, TraceRange{Start: start.Uint64(), End: end.Uint64()})
+		}
+	}
+	for _, pos := range claimed {
+		if pos.Depth() > maxDepth {
+			continue
+		}
+		addProof(pos)
+		if pos.Depth() == maxDepth {
+			// Attacking a leaf claim steps from its pre-state, defending it steps from the pre-state of the next index.
+			addProof(pos.MoveRight())
+			continue
+		}
+		addSubtree(pos.Attack())
+		if !pos.IsRootPosition() {
+			addSubtree(pos.Defend())
+		}
+	}
+	slices.Sort(req.Proofs)
+	req.Proofs = slices.Compact(req.Proofs)
+	req.Ranges = mergeRanges(req.Ranges)
+	return req
+}
+
+func mergeRanges(ranges []TraceRange) []TraceRange {
+	slices.SortFunc(ranges, func(a, b TraceRange) int {
+		return cmp.Compare(a.Start, b.Start)
+	})
+	var merged []TraceRange
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && (merged[n-1].End == ^uint64(0) || r.Start <= merged[n-1].End+1) {
+			merged[n-1].End = max(merged[n-1].End, r.End)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// PruneProofs removes the proofs and snapshots in the game data dir that the game can never require.
+// Proofs that are pruned but turn out to be required after all are generated again, from the remaining snapshots.
+// If lastStep is known (non-zero), proofs beyond the end of the trace are served by the proof at lastStep.
+func PruneProofs(logger log.Logger, dir string, req ProofRequirements, lastStep uint64) error {
+	keepProofs := make(map[uint64]bool, len(req.Proofs))
+	for _, i := range req.Proofs {
+		if lastStep != 0 && i > lastStep {
+			i = lastStep
+		}
+		keepProofs[i] = true
+	}
+	if lastStep != 0 {
+		// The proof of the last step is generated without executing the VM, and can't be generated from snapshots.
+		keepProofs[lastStep] = true
+	}
+	proofs, err := listIndexedFiles(filepath.Join(dir, utils.ProofsDir))
+	if err != nil {
+		return err
+	}
+	var prunedProofs int
+	for _, i := range proofs {
+		if keepProofs[i] {
+			continue
+		}
+		if err := os.Remove(indexedFile(filepath.Join(dir, utils.ProofsDir), i)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to prune proof %v: %w", i, err)
+		}
+		prunedProofs++
+	}
+
+	snapshots, err := listIndexedFiles(filepath.Join(dir, SnapsDir))
+	if err != nil {
+		return err
+	}
+	keepSnapshots := requiredSnapshots(snapshots, req)
+	var prunedSnapshots int
+	for _, i := range snapshots {
+		if keepSnapshots[i] {
+			continue
+		}
+		if err := os.Remove(indexedFile(filepath.Join(dir, SnapsDir), i)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to prune snapshot %v: %w", i, err)
+		}
+		prunedSnapshots++
+	}
+	if prunedProofs > 0 || prunedSnapshots > 0 {
+		logger.Debug("Pruned proofs", "proofs", prunedProofs, "snapshots", prunedSnapshots,
+			"remainingProofs", len(proofs)-prunedProofs, "remainingSnapshots", len(snapshots)-prunedSnapshots)
+	}
+	return nil
+}
+
+// requiredSnapshots returns the snapshots that a required proof may be generated from.
+// A proof is generated from the latest snapshot before its trace index, see FindStartingSnapshot.
+func requiredSnapshots(snapshots []uint64, req ProofRequirements) map[uint64]bool {
+	keep := make(map[uint64]bool)
+	// latestBefore returns the latest snapshot before i, if any
+	latestBefore := func(i uint64) (uint64, bool) {
+		idx, _ := slices.BinarySearch(snapshots, i)
+		if idx == 0 {
+			return 0, false
+		}
+		return snapshots[idx-1], true
+	}
+	for _, i := range req.Proofs {
+		if snap, ok := latestBefore(i); ok {
+			keep[snap] = true
+		}
+	}
+	for _, r := range req.Ranges {
+		if snap, ok := latestBefore(r.Start); ok {
+			keep[snap] = true
+		}
+		for _, snap := range snapshots {
+			if r.Start <= snap && snap < r.End {
+				keep[snap] = true
+			}
+		}
+	}
+	return keep
+}
+
+func indexedFile(dir string, i uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%d.json.gz", i))
+}
+
+// listIndexedFiles returns the sorted indices of the proof or snapshot files in dir.
+func listIndexedFiles(dir string) ([]uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list %v: %w", dir, err)
+	}
+	var indices []uint64
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json.gz")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		i, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		indices = append(indices, i)
+	}
+	slices.Sort(indices)
+	return indices, nil
+}
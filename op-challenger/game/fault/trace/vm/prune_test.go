@@ -0,0 +1,110 @@
+package vm
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestRequiredProofs(t *testing.T) {
+	depth := types.Depth(3)
+	pos := func(depth types.Depth, idx int64) types.Position {
+		return types.NewPosition(depth, big.NewInt(idx))
+	}
+
+	t.Run("RootOnly", func(t *testing.T) {
+		req := RequiredProofs(depth, []types.Position{types.RootPosition})
+		require.Equal(t, []uint64{3, 7}, req.Proofs)
+		require.Equal(t, []TraceRange{{Start: 0, End: 3}}, req.Ranges, "root can only be attacked")
+	})
+
+	t.Run("NonLeafClaims", func(t *testing.T) {
+		req := RequiredProofs(depth, []types.Position{types.RootPosition, pos(1, 0)})
+		require.Equal(t, []uint64{1, 3, 5, 7}, req.Proofs)
+		require.Equal(t, []TraceRange{{Start: 0, End: 5}}, req.Ranges, "adjacent ranges should be merged")
+	})
+
+	t.Run("DisjointRanges", func(t *testing.T) {
+		req := RequiredProofs(depth, []types.Position{pos(2, 3)})
+		require.Equal(t, []uint64{6, 7}, req.Proofs)
+		require.Equal(t, []TraceRange{{Start: 6, End: 6}}, req.Ranges)
+
+		req = RequiredProofs(depth, []types.Position{pos(2, 0), pos(2, 3)})
+		require.Equal(t, []uint64{0, 1, 2, 6, 7}, req.Proofs)
+		require.Equal(t, []TraceRange{{Start: 0, End: 0}, {Start: 2, End: 2}, {Start: 6, End: 6}}, req.Ranges)
+	})
+
+	t.Run("LeafClaims", func(t *testing.T) {
+		req := RequiredProofs(depth, []types.Position{pos(3, 5)})
+		require.Equal(t, []uint64{5, 6}, req.Proofs, "should require the pre-state of both steps")
+		require.Empty(t, req.Ranges)
+	})
+
+	t.Run("IgnoreBeyondMaxDepth", func(t *testing.T) {
+		req := RequiredProofs(depth, []types.Position{pos(4, 5)})
+		require.Empty(t, req.Proofs)
+		require.Empty(t, req.Ranges)
+	})
+}
+
+func TestPruneProofs(t *testing.T) {
+	setup := func(t *testing.T, proofs []uint64, snapshots []uint64) string {
+		dir := t.TempDir()
+		for subdir, indices := range map[string][]uint64{utils.ProofsDir: proofs, SnapsDir: snapshots} {
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, subdir), 0o755))
+			for _, i := range indices {
+				require.NoError(t, os.WriteFile(indexedFile(filepath.Join(dir, subdir), i), nil, 0o644))
+			}
+		}
+		return dir
+	}
+	requireFiles := func(t *testing.T, dir string, expected []uint64) {
+		actual, err := listIndexedFiles(dir)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+	logger := testlog.Logger(t, log.LevelInfo)
+
+	t.Run("PruneUnrequired", func(t *testing.T) {
+		dir := setup(t, []uint64{0, 1, 2, 3, 4, 5, 6, 7}, []uint64{2, 4, 6})
+		req := RequiredProofs(3, []types.Position{types.RootPosition})
+		require.NoError(t, PruneProofs(logger, dir, req, 0))
+		requireFiles(t, filepath.Join(dir, utils.ProofsDir), []uint64{3, 7})
+		requireFiles(t, filepath.Join(dir, SnapsDir), []uint64{2, 6})
+	})
+
+	t.Run("KeepSnapshotsInRanges", func(t *testing.T) {
+		dir := setup(t, nil, []uint64{10, 20, 30, 40, 50, 60})
+		req := ProofRequirements{Proofs: []uint64{55}, Ranges: []TraceRange{{Start: 25, End: 40}}}
+		require.NoError(t, PruneProofs(logger, dir, req, 0))
+		requireFiles(t, filepath.Join(dir, SnapsDir), []uint64{20, 30, 50})
+	})
+
+	t.Run("KeepLastStep", func(t *testing.T) {
+		dir := setup(t, []uint64{3, 4, 5, 7}, nil)
+		req := RequiredProofs(3, []types.Position{types.RootPosition})
+		require.NoError(t, PruneProofs(logger, dir, req, 5))
+		requireFiles(t, filepath.Join(dir, utils.ProofsDir), []uint64{3, 5})
+	})
+
+	t.Run("IgnoreOtherFiles", func(t *testing.T) {
+		dir := setup(t, []uint64{1}, nil)
+		other := filepath.Join(dir, utils.ProofsDir, "other.json")
+		require.NoError(t, os.WriteFile(other, nil, 0o644))
+		require.NoError(t, PruneProofs(logger, dir, ProofRequirements{}, 0))
+		requireFiles(t, filepath.Join(dir, utils.ProofsDir), nil)
+		require.FileExists(t, other)
+	})
+
+	t.Run("MissingDir", func(t *testing.T) {
+		require.NoError(t, PruneProofs(logger, filepath.Join(t.TempDir(), "missing"), ProofRequirements{}, 0))
+	})
+}
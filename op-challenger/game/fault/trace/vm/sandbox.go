@@ -0,0 +1,150 @@
+package vm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrVmOutOfMemory = errors.New("vm exceeded its memory limit")
+	ErrVmTimeout     = errors.New("vm exceeded its time limit")
+
+	ErrNegativeVmCPULimit       = errors.New("vm cpu limit must not be negative")
+	ErrNegativeVmTimeout        = errors.New("vm timeout must not be negative")
+	ErrVmCgroupsUnsupported     = errors.New("vm cgroups are only supported on linux")
+	ErrVmCPULimitRequiresLimits = errors.New("vm cpu limit requires a cgroup dir or a timeout")
+)
+
+// Reasons a VM execution failed, as recorded with Metricer.RecordVmFailure.
+const (
+	VmFailureOutOfMemory = "oom"
+	VmFailureTimeout     = "timeout"
+	VmFailureError       = "error"
+)
+
+// cpuPeriod is the cgroup cpu.max period the CPU limit is a fraction of, in microseconds.
+const cpuPeriod = 100_000
+
+// SandboxConfig limits the resources of VM processes, so a pathological game can't take down the whole host.
+// On Linux, limits are enforced with a cgroup v2 per execution if CgroupDir is set.
+// Otherwise they are enforced with rlimits: the memory limit applies to the address space of the VM process,
+// and the CPU limit is turned into a CPU time limit of CPULimit * Timeout.
+type SandboxConfig struct {
+	MemoryLimit uint64        // Maximum memory of a VM execution in bytes (0 == no limit)
+	CPULimit    float64       // Maximum number of CPUs a VM execution may use (0 == no limit)
+	Timeout     time.Duration // Maximum duration of a VM execution (0 == no limit)
+	// CgroupDir is a cgroup v2 directory delegated to the challenger, with the memory and cpu controllers
+	// enabled for its children. A cgroup is created in it for each VM execution. Linux only.
+	CgroupDir string
+}
+
+func (c SandboxConfig) Check() error {
+	if c.CPULimit < 0 {
+		return ErrNegativeVmCPULimit
+	}
+	if c.Timeout < 0 {
+		return ErrNegativeVmTimeout
+	}
+	if c.CgroupDir != "" && runtime.GOOS != "linux" {
+		return ErrVmCgroupsUnsupported
+	}
+	if c.CPULimit > 0 && c.CgroupDir == "" && c.Timeout == 0 {
+		return ErrVmCPULimitRequiresLimits
+	}
+	return nil
+}
+
+func (c SandboxConfig) enabled() bool {
+	return c.MemoryLimit > 0 || c.CPULimit > 0 || c.Timeout > 0 || c.CgroupDir != ""
+}
+
+// SandboxedCmdExecutor returns a CmdExecutor that runs commands within the limits of the config.
+// Executions that exceed the limits fail with ErrVmOutOfMemory or ErrVmTimeout.
+func SandboxedCmdExecutor(cfg SandboxConfig) CmdExecutor {
+	if !cfg.enabled() {
+		return RunCmd
+	}
+	return func(ctx context.Context, l log.Logger, binary string, args ...string) error {
+		if cfg.Timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeoutCause(ctx, cfg.Timeout, ErrVmTimeout)
+			defer cancel()
+		}
+		var err error
+		if cfg.CgroupDir != "" {
+			err = runInCgroup(ctx, l, cfg, binary, args)
+		} else {
+			err = runWithRlimits(ctx, l, cfg, binary, args)
+		}
+		if err != nil && !errors.Is(err, ErrVmOutOfMemory) && errors.Is(context.Cause(ctx), ErrVmTimeout) {
+			return fmt.Errorf("%w of %v: %w", ErrVmTimeout, cfg.Timeout, err)
+		}
+		return err
+	}
+}
+
+// runWithRlimits runs the command through the shell, to apply the rlimits to the command only.
+func runWithRlimits(ctx context.Context, l log.Logger, cfg SandboxConfig, binary string, args []string) error {
+	script := ""
+	var limits []string
+	if cfg.MemoryLimit > 0 {
+		script += `ulimit -v "$1" && `
+		limits = append(limits, strconv.FormatUint(cfg.MemoryLimit/1024, 10))
+	} else {
+		limits = append(limits, "")
+	}
+	if cfg.CPULimit > 0 && cfg.Timeout > 0 {
+		script += `ulimit -t "$2" && `
+		limits = append(limits, strconv.FormatUint(uint64(cfg.CPULimit*cfg.Timeout.Seconds())+1, 10))
+	} else {
+		limits = append(limits, "")
+	}
+	script += `shift 2 && exec "$@"`
+	shellArgs := append([]string{"-c", script, "sh"}, limits...)
+	shellArgs = append(shellArgs, binary)
+	shellArgs = append(shellArgs, args...)
+	err := runLogged(l, exec.CommandContext(ctx, "/bin/sh", shellArgs...))
+	if err != nil && ctx.Err() == nil {
+		switch terminatedBy(err) {
+		case syscall.SIGKILL:
+			// Not killed by the context, so most likely by the kernel OOM killer.
+			return fmt.Errorf("%w: %w", ErrVmOutOfMemory, err)
+		case syscall.SIGXCPU:
+			return fmt.Errorf("%w: cpu time limit: %w", ErrVmTimeout, err)
+		}
+	}
+	return err
+}
+
+// terminatedBy returns the signal that terminated the process of the command error, or -1 if not signaled.
+func terminatedBy(err error) syscall.Signal {
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return -1
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok || !status.Signaled() {
+		return -1
+	}
+	return status.Signal()
+}
+
+// vmFailureReason classifies the error of a VM execution for metrics.
+func vmFailureReason(err error) string {
+	switch {
+	case errors.Is(err, ErrVmOutOfMemory):
+		return VmFailureOutOfMemory
+	case errors.Is(err, ErrVmTimeout):
+		return VmFailureTimeout
+	default:
+		return VmFailureError
+	}
+}
@@ -0,0 +1,96 @@
+package vm
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// runInCgroup runs the command in a new cgroup in the cgroup dir of the config, which is removed afterwards.
+// Child processes of the command, like the pre-image server, are in the same cgroup, and share its limits.
+func runInCgroup(ctx context.Context, l log.Logger, cfg SandboxConfig, binary string, args []string) error {
+	dir, err := os.MkdirTemp(cfg.CgroupDir, "vm-")
+	if err != nil {
+		return fmt.Errorf("failed to create cgroup: %w", err)
+	}
+	defer removeCgroup(l, dir)
+	if cfg.MemoryLimit > 0 {
+		if err := writeCgroupFile(dir, "memory.max", strconv.FormatUint(cfg.MemoryLimit, 10)); err != nil {
+			return err
+		}
+		// Don't let the VM swap instead of hitting the limit. The file is missing if swap accounting is disabled.
+		if err := writeCgroupFile(dir, "memory.swap.max", "0"); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if cfg.CPULimit > 0 {
+		quota := max(uint64(cfg.CPULimit*cpuPeriod), 1000)
+		if err := writeCgroupFile(dir, "cpu.max", fmt.Sprintf("%d %d", quota, cpuPeriod)); err != nil {
+			return err
+		}
+	}
+	cgroup, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("failed to open cgroup: %w", err)
+	}
+	defer cgroup.Close()
+
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{UseCgroupFD: true, CgroupFD: int(cgroup.Fd())}
+	err = runLogged(l, cmd)
+	if err != nil {
+		if kills, readErr := cgroupOOMKills(dir); readErr != nil {
+			l.Warn("Failed to read cgroup memory events", "cgroup", dir, "err", readErr)
+		} else if kills > 0 {
+			return fmt.Errorf("%w: %w", ErrVmOutOfMemory, err)
+		}
+	}
+	return err
+}
+
+func writeCgroupFile(dir string, name string, value string) error {
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0); err != nil {
+		return fmt.Errorf("failed to set cgroup %v: %w", name, err)
+	}
+	return nil
+}
+
+// cgroupOOMKills returns the number of processes of the cgroup that were killed for exceeding the memory limit.
+func cgroupOOMKills(dir string) (uint64, error) {
+	f, err := os.Open(filepath.Join(dir, "memory.events"))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "oom_kill "); ok {
+			return strconv.ParseUint(value, 10, 64)
+		}
+	}
+	return 0, scanner.Err()
+}
+
+// removeCgroup kills any processes left in the cgroup, e.g. a pre-image server that is still shutting down,
+// and removes it. A cgroup can only be removed once all of its processes exited.
+func removeCgroup(l log.Logger, dir string) {
+	_ = os.WriteFile(filepath.Join(dir, "cgroup.kill"), []byte("1"), 0)
+	var err error
+	for i := 0; i < 50; i++ {
+		if err = os.Remove(dir); err == nil || errors.Is(err, os.ErrNotExist) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	l.Warn("Failed to remove cgroup", "cgroup", dir, "err", err)
+}
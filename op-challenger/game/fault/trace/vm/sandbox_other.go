@@ -0,0 +1,13 @@
+//go:build !linux
+
+package vm
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func runInCgroup(_ context.Context, _ log.Logger, _ SandboxConfig, _ string, _ []string) error {
+	return ErrVmCgroupsUnsupported
+}
@@ -0,0 +1,65 @@
+package vm
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandboxConfigCheck(t *testing.T) {
+	require.NoError(t, SandboxConfig{}.Check())
+	require.NoError(t, SandboxConfig{MemoryLimit: 1024, CPULimit: 2, Timeout: time.Hour}.Check())
+	require.ErrorIs(t, SandboxConfig{CPULimit: -1}.Check(), ErrNegativeVmCPULimit)
+	require.ErrorIs(t, SandboxConfig{Timeout: -time.Second}.Check(), ErrNegativeVmTimeout)
+	require.ErrorIs(t, SandboxConfig{CPULimit: 2}.Check(), ErrVmCPULimitRequiresLimits)
+	if runtime.GOOS == "linux" {
+		require.NoError(t, SandboxConfig{CPULimit: 2, CgroupDir: "/sys/fs/cgroup/challenger"}.Check())
+	} else {
+		require.ErrorIs(t, SandboxConfig{CgroupDir: "/sys/fs/cgroup/challenger"}.Check(), ErrVmCgroupsUnsupported)
+	}
+}
+
+func TestSandboxedCmdExecutor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("rlimits are not supported on windows")
+	}
+	logger := testlog.Logger(t, log.LevelCrit)
+
+	t.Run("Timeout", func(t *testing.T) {
+		run := SandboxedCmdExecutor(SandboxConfig{Timeout: 50 * time.Millisecond})
+		err := run(context.Background(), logger, "sleep", "10")
+		require.ErrorIs(t, err, ErrVmTimeout)
+		require.Equal(t, VmFailureTimeout, vmFailureReason(err))
+	})
+
+	t.Run("ParentContextCanceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		run := SandboxedCmdExecutor(SandboxConfig{Timeout: time.Hour})
+		err := run(ctx, logger, "sleep", "10")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrVmTimeout)
+	})
+
+	t.Run("Rlimits", func(t *testing.T) {
+		run := SandboxedCmdExecutor(SandboxConfig{MemoryLimit: 512 * 1024 * 1024, CPULimit: 2, Timeout: 10 * time.Second})
+		require.NoError(t, run(context.Background(), logger, "sh", "-c", `test "$(ulimit -v)" = 524288 && test "$(ulimit -t)" = 21`))
+		require.Error(t, run(context.Background(), logger, "sh", "-c", `test "$(ulimit -v)" = unlimited`))
+	})
+
+	t.Run("ArgsPassedThrough", func(t *testing.T) {
+		run := SandboxedCmdExecutor(SandboxConfig{Timeout: 10 * time.Second})
+		require.NoError(t, run(context.Background(), logger, "sh", "-c", `test "$1 $2" = "a b c"`, "sh", "a", "b c"))
+	})
+}
+
+func TestVmFailureReason(t *testing.T) {
+	require.Equal(t, VmFailureOutOfMemory, vmFailureReason(ErrVmOutOfMemory))
+	require.Equal(t, VmFailureTimeout, vmFailureReason(ErrVmTimeout))
+	require.Equal(t, VmFailureError, vmFailureReason(context.Canceled))
+}
@@ -204,6 +204,13 @@ type TraceAccessor interface {
 	GetL2BlockNumberChallenge(ctx context.Context, game Game) (*InvalidL2BlockNumberChallenge, error)
 }
 
+// ProofPruner is implemented by trace providers that keep proofs on disk,
+// to remove the proofs that can no longer be required by the game.
+type ProofPruner interface {
+	// PruneProofs removes the proofs that can never be required, given the positions claimed in the game of the provider.
+	PruneProofs(claimed []Position) error
+}
+
 // PrestateProvider defines an interface to request the absolute prestate.
 type PrestateProvider interface {
 	// AbsolutePreStateCommitment is the commitment of the pre-image value of the trace that transitions to the trace value at index 0
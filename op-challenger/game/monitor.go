@@ -41,6 +41,10 @@ type claimer interface {
 	Schedule(blockNumber uint64, games []types.GameMetadata) error
 }
 
+type txGovernor interface {
+	OnNewL1Block(blockNumber uint64)
+}
+
 type gameMonitor struct {
 	logger       log.Logger
 	clock        RWClock
@@ -49,6 +53,7 @@ type gameMonitor struct {
 	preimages    preimageScheduler
 	gameWindow   time.Duration
 	claimer      claimer
+	governor     txGovernor
 	allowedGames []common.Address
 	l1HeadsSub   ethereum.Subscription
 	l1Source     *headSource
@@ -75,6 +80,7 @@ func newGameMonitor(
 	preimages preimageScheduler,
 	gameWindow time.Duration,
 	claimer claimer,
+	governor txGovernor,
 	allowedGames []common.Address,
 	l1Source MinimalSubscriber,
 ) *gameMonitor {
@@ -86,6 +92,7 @@ func newGameMonitor(
 		source:       source,
 		gameWindow:   gameWindow,
 		claimer:      claimer,
+		governor:     governor,
 		allowedGames: allowedGames,
 		l1Source:     &headSource{inner: l1Source},
 	}
@@ -130,6 +137,7 @@ func (m *gameMonitor) progressGames(ctx context.Context, blockHash common.Hash,
 
 func (m *gameMonitor) onNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 	m.clock.SetTime(sig.Time)
+	m.governor.OnNewL1Block(sig.Number)
 	if err := m.progressGames(ctx, sig.Hash, sig.Number); err != nil {
 		m.logger.Error("Failed to progress games", "err", err)
 	}
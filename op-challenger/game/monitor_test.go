@@ -66,6 +66,7 @@ func TestMonitorGames(t *testing.T) {
 		require.Len(t, sched.Scheduled(), 1)
 		require.Equal(t, []common.Address{addr1, addr2}, sched.Scheduled()[0])
 		require.GreaterOrEqual(t, preimages.ScheduleCount(), 1, "Should schedule preimage checks")
+		require.Equal(t, uint64(1), monitor.governor.(*stubTxGovernor).LastBlock(), "Should reset tx governor limits")
 	})
 
 	t.Run("Resubscribes on error", func(t *testing.T) {
@@ -167,12 +168,30 @@ func setupMonitorTest(
 		preimages,
 		time.Duration(0),
 		stubClaimer,
+		&stubTxGovernor{},
 		allowedGames,
 		mockHeadSource,
 	)
 	return monitor, source, sched, mockHeadSource, preimages, stubClaimer
 }
 
+type stubTxGovernor struct {
+	sync.Mutex
+	lastBlock uint64
+}
+
+func (s *stubTxGovernor) OnNewL1Block(blockNumber uint64) {
+	s.Lock()
+	defer s.Unlock()
+	s.lastBlock = blockNumber
+}
+
+func (s *stubTxGovernor) LastBlock() uint64 {
+	s.Lock()
+	defer s.Unlock()
+	return s.lastBlock
+}
+
 type mockNewHeadSource struct {
 	sync.Mutex
 	sub *mockSubscription
@@ -0,0 +1,70 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+type RollupConfigHashProvider interface {
+	RollupConfigHash(ctx context.Context) (common.Hash, error)
+}
+
+// checkRollupConfigs logs an error for each VM configured with a different rollup config than the rollup node.
+// The VM traces would then not match the output roots proposed from the rollup node,
+// so the challenger would dispute valid claims, or fail to dispute invalid claims.
+// A mismatch may also be caused by version skew between the challenger and the rollup node,
+// so it is not treated as fatal.
+func checkRollupConfigs(ctx context.Context, logger log.Logger, node RollupConfigHashProvider, vmCfgs ...vm.Config) {
+	if len(vmCfgs) == 0 {
+		return
+	}
+	nodeHash, err := node.RollupConfigHash(ctx)
+	if err != nil {
+		logger.Warn("Unable to verify the rollup config of the VMs, rollup node did not report its rollup config hash", "err", err)
+		return
+	}
+	for _, vmCfg := range vmCfgs {
+		hash, err := vmRollupConfigHash(vmCfg)
+		if errors.Is(err, errNoRollupConfig) {
+			continue
+		} else if err != nil {
+			logger.Warn("Unable to verify the rollup config of the VM", "vm", vmCfg.VmType, "err", err)
+			continue
+		}
+		if hash != nodeHash {
+			logger.Error("VM rollup config does not match the rollup config of the rollup node",
+				"vm", vmCfg.VmType, "network", vmCfg.Network, "rollupConfig", vmCfg.RollupConfigPath, "hash", hash, "node", nodeHash)
+		} else {
+			logger.Debug("VM rollup config matches the rollup node", "vm", vmCfg.VmType, "hash", hash)
+		}
+	}
+}
+
+var errNoRollupConfig = errors.New("no rollup config")
+
+// vmRollupConfigHash returns the hash of the rollup config the VM runs with, preferring the network like the VM does.
+func vmRollupConfigHash(vmCfg vm.Config) (common.Hash, error) {
+	var cfg *rollup.Config
+	var err error
+	switch {
+	case vmCfg.Network != "":
+		cfg, err = chaincfg.GetRollupConfig(vmCfg.Network)
+	case vmCfg.RollupConfigPath != "":
+		cfg, err = jsonutil.LoadJSON[rollup.Config](vmCfg.RollupConfigPath)
+	default:
+		return common.Hash{}, errNoRollupConfig
+	}
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to load rollup config: %w", err)
+	}
+	return cfg.Hash()
+}
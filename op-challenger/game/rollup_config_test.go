@@ -0,0 +1,73 @@
+package game
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubRollupConfigHashProvider struct {
+	hash common.Hash
+	err  error
+}
+
+func (s *stubRollupConfigHashProvider) RollupConfigHash(_ context.Context) (common.Hash, error) {
+	return s.hash, s.err
+}
+
+func TestCheckRollupConfigs(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &rollup.Config{L2ChainID: big.NewInt(901)}
+	cfgPath := filepath.Join(dir, "rollup.json")
+	require.NoError(t, jsonutil.WriteJSON(cfgPath, cfg, 0o644))
+	hash, err := cfg.Hash()
+	require.NoError(t, err)
+	vmCfg := vm.Config{VmType: types.TraceTypeCannon, RollupConfigPath: cfgPath}
+
+	t.Run("Matches", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelDebug)
+		checkRollupConfigs(context.Background(), logger, &stubRollupConfigHashProvider{hash: hash}, vmCfg)
+		require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("VM rollup config matches the rollup node")))
+		require.Nil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn)))
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelDebug)
+		checkRollupConfigs(context.Background(), logger, &stubRollupConfigHashProvider{hash: common.Hash{0xaa}}, vmCfg)
+		msg := logs.FindLog(testlog.NewLevelFilter(log.LevelError), testlog.NewMessageFilter("VM rollup config does not match the rollup config of the rollup node"))
+		require.NotNil(t, msg)
+		require.Equal(t, hash, msg.AttrValue("hash"))
+		require.Equal(t, common.Hash{0xaa}, msg.AttrValue("node"))
+	})
+
+	t.Run("NodeDoesNotReportHash", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelDebug)
+		checkRollupConfigs(context.Background(), logger, &stubRollupConfigHashProvider{err: errors.New("method not found")}, vmCfg)
+		require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageContainsFilter("did not report its rollup config hash")))
+	})
+
+	t.Run("InvalidLocalConfig", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelDebug)
+		invalid := vm.Config{VmType: types.TraceTypeAsterisc, RollupConfigPath: filepath.Join(dir, "missing.json")}
+		checkRollupConfigs(context.Background(), logger, &stubRollupConfigHashProvider{hash: hash}, invalid)
+		require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn), testlog.NewMessageFilter("Unable to verify the rollup config of the VM")))
+	})
+
+	t.Run("NoRollupConfig", func(t *testing.T) {
+		logger, logs := testlog.CaptureLogger(t, log.LevelDebug)
+		checkRollupConfigs(context.Background(), logger, &stubRollupConfigHashProvider{hash: hash}, vm.Config{VmType: types.TraceTypeCannon})
+		require.Nil(t, logs.FindLog(testlog.NewLevelFilter(log.LevelWarn)))
+	})
+}
@@ -21,6 +21,7 @@ type CoordinatorMetricer interface {
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
+	RecordGameDataDiskUsage(active, inactive uint64)
 }
 
 type gameState struct {
@@ -105,6 +106,10 @@ func (c *coordinator) schedule(ctx context.Context, games []types.GameMetadata,
 	c.lastScheduledBlockNum = blockNumber
 	c.m.RecordActedL1Block(lowestProcessedBlockNum)
 
+	// Expire the data of games that are no longer required, even if no game updates complete
+	c.deleteResolvedGameFiles()
+	c.recordDiskUsage()
+
 	// Finally, enqueue the jobs
 	for _, j := range jobs {
 		if err := c.enqueueJob(ctx, j); err != nil {
@@ -192,6 +197,23 @@ func (c *coordinator) deleteResolvedGameFiles() {
 	}
 }
 
+func (c *coordinator) recordDiskUsage() {
+	usage, err := c.disk.Usage()
+	if err != nil {
+		// Still record the usage of the games that could be measured
+		c.logger.Warn("Unable to measure game data disk usage", "err", err)
+	}
+	var active, inactive uint64
+	for _, game := range usage {
+		if game.InactiveSince.IsZero() {
+			active += game.Bytes
+		} else {
+			inactive += game.Bytes
+		}
+	}
+	c.m.RecordGameDataDiskUsage(active, inactive)
+}
+
 func newCoordinator(logger log.Logger, m CoordinatorMetricer, jobQueue chan<- job, resultQueue <-chan job, createPlayer PlayerCreator, disk DiskManager, allowInvalidPrestate bool) *coordinator {
 	return &coordinator{
 		logger:               logger,
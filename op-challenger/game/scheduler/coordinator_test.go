@@ -2,9 +2,11 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler/test"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
@@ -209,6 +211,36 @@ func TestDeleteDataForResolvedGames(t *testing.T) {
 	require.False(t, disk.gameDirExists[gameAddr3], "game 3 data should be deleted")
 }
 
+func TestDeleteDataForResolvedGamesWhenScheduling(t *testing.T) {
+	c, workQueue, _, games, disk, _ := setupCoordinatorTest(t, 10)
+	gameAddr1 := common.Address{0xaa}
+	gameAddr2 := common.Address{0xbb}
+	games.createCompleted = gameAddr2
+	ctx := context.Background()
+
+	// Data of games that are no longer required expires even if no game updates complete
+	disk.DirForGame(gameAddr2)
+	require.NoError(t, c.schedule(ctx, asGames(gameAddr1, gameAddr2), 0))
+	require.Len(t, workQueue, 1, "should only schedule the game in progress")
+	require.True(t, disk.gameDirExists[gameAddr1], "game 1 data should be preserved (in flight)")
+	require.False(t, disk.gameDirExists[gameAddr2], "game 2 data should be deleted (resolved)")
+}
+
+func TestSchedule_RecordDiskUsage(t *testing.T) {
+	c, _, _, _, disk, _ := setupCoordinatorTest(t, 10)
+	m := &stubSchedulerMetrics{}
+	c.m = m
+	disk.usage = []GameDiskUsage{
+		{Game: common.Address{0xaa}, Bytes: 100},
+		{Game: common.Address{0xbb}, Bytes: 20},
+		{Game: common.Address{0xcc}, Bytes: 3, InactiveSince: time.Unix(1000, 0)},
+	}
+	disk.usageErr = errors.New("boom")
+	require.NoError(t, c.schedule(context.Background(), nil, 0))
+	require.Equal(t, uint64(120), m.activeDiskUsage)
+	require.Equal(t, uint64(3), m.inactiveDiskUsage)
+}
+
 func TestSchedule_RecordActedL1Block(t *testing.T) {
 	c, workQueue, _, _, _, _ := setupCoordinatorTest(t, 10)
 	gameAddr1 := common.Address{0xaa}
@@ -438,7 +470,9 @@ func (c *createdGames) CreateGame(fdg types.GameMetadata, dir string) (GamePlaye
 }
 
 type stubSchedulerMetrics struct {
-	actedL1Blocks uint64
+	actedL1Blocks     uint64
+	activeDiskUsage   uint64
+	inactiveDiskUsage uint64
 }
 
 func (s *stubSchedulerMetrics) RecordActedL1Block(n uint64) {
@@ -449,9 +483,16 @@ func (s *stubSchedulerMetrics) RecordGamesStatus(_, _, _ int) {}
 func (s *stubSchedulerMetrics) RecordGameUpdateScheduled()    {}
 func (s *stubSchedulerMetrics) RecordGameUpdateCompleted()    {}
 
+func (s *stubSchedulerMetrics) RecordGameDataDiskUsage(active, inactive uint64) {
+	s.activeDiskUsage = active
+	s.inactiveDiskUsage = inactive
+}
+
 type stubDiskManager struct {
 	gameDirExists map[common.Address]bool
 	deletedDirs   []common.Address
+	usage         []GameDiskUsage
+	usageErr      error
 }
 
 func (s *stubDiskManager) DirForGame(addr common.Address) string {
@@ -470,6 +511,10 @@ func (s *stubDiskManager) RemoveAllExcept(addrs []common.Address) error {
 	return nil
 }
 
+func (s *stubDiskManager) Usage() ([]GameDiskUsage, error) {
+	return s.usage, s.usageErr
+}
+
 func asGames(addrs ...common.Address) []types.GameMetadata {
 	var games []types.GameMetadata
 	for _, addr := range addrs {
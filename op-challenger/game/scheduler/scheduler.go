@@ -16,6 +16,7 @@ type SchedulerMetricer interface {
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
+	RecordGameDataDiskUsage(active, inactive uint64)
 	IncActiveExecutors()
 	DecActiveExecutors()
 	IncIdleExecutors()
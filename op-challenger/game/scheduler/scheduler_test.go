@@ -31,8 +31,8 @@ func TestSchedulerProcessesGames(t *testing.T) {
 
 	require.NoError(t, s.Schedule(games, 0))
 
-	// All jobs should be executed and completed, the last step being to clean up disk resources
-	for i := 0; i < len(games); i++ {
+	// Disk resources are cleaned up when scheduling, and when each job is completed
+	for i := 0; i < len(games)+1; i++ {
 		kept := <-removeExceptCalls
 		require.Len(t, kept, len(games), "should keep all games")
 		for _, game := range games {
@@ -71,3 +71,7 @@ func (t *trackingDiskManager) RemoveAllExcept(addrs []common.Address) error {
 	t.removeExceptCalls <- addrs
 	return nil
 }
+
+func (t *trackingDiskManager) Usage() ([]GameDiskUsage, error) {
+	return nil, nil
+}
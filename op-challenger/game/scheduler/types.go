@@ -2,6 +2,7 @@ package scheduler
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 
@@ -17,6 +18,15 @@ type GamePlayer interface {
 type DiskManager interface {
 	DirForGame(addr common.Address) string
 	RemoveAllExcept(addrs []common.Address) error
+	Usage() ([]GameDiskUsage, error)
+}
+
+// GameDiskUsage is the disk space used by the data of a game.
+type GameDiskUsage struct {
+	Game  common.Address
+	Bytes uint64
+	// InactiveSince is the time the data was first no longer required, or zero if the game is still active.
+	InactiveSince time.Time
 }
 
 type job struct {
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"sync/atomic"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/keccak"
@@ -16,13 +18,21 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/factoryscan"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/claims"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/coordination"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs/outputdb"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/vm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-challenger/version"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
@@ -33,33 +43,36 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
+const (
+	// outputDBDir is the directory within the datadir of a chain to store the output cache in.
+	outputDBDir = "output-cache"
+	// gameScanCursorFile is the file within the datadir of a chain to store the progress of the game factory log scan in.
+	gameScanCursorFile = "game-scan-cursor.json"
+)
+
 type Service struct {
 	logger  log.Logger
 	metrics metrics.Metricer
-	monitor *gameMonitor
-	sched   *scheduler.Scheduler
-
-	faultGamesCloser fault.CloseFunc
-
-	preimages *keccak.LargePreimageScheduler
 
 	txMgr    *txmgr.SimpleTxManager
 	txSender *sender.TxSender
+	governor *sender.Governor
 
 	systemClock clock.Clock
-	l1Clock     *clock.SimpleClock
 
 	claimants []common.Address
-	claimer   *claims.BondClaimScheduler
-
-	factoryContract *contracts.DisputeGameFactoryContract
-	registry        *registry.GameTypeRegistry
-	oracles         *registry.OracleRegistry
-	rollupClient    *sources.RollupClient
 
 	l1Client   *ethclient.Client
 	pollClient client.RPC
 
+	// vmLimiter is shared by all chains, so the limit applies to the VM executions of all games
+	vmLimiter *vm.ExecutionLimiter
+
+	// coordination gossips the claims countered by the challenger, for the games of all chains. Nil if disabled.
+	coordination *coordination.Gossip
+
+	chains []*chain
+
 	pprofService *oppprof.Service
 	metricsSrv   *httputil.HTTPServer
 
@@ -68,11 +81,38 @@ type Service struct {
 	stopped atomic.Bool
 }
 
+// chain is a chain monitored by the service.
+// Each chain has its own metrics, scheduler and monitor, while the tx manager and L1 clients are shared.
+type chain struct {
+	name    string
+	logger  log.Logger
+	metrics metrics.Metricer
+	l1Clock *clock.SimpleClock
+
+	monitor *gameMonitor
+	sched   *scheduler.Scheduler
+
+	faultGamesCloser fault.CloseFunc
+
+	preimages *keccak.LargePreimageScheduler
+
+	claimer *claims.BondClaimScheduler
+
+	factoryContract *contracts.DisputeGameFactoryContract
+	gameSource      gameSource
+	registry        *registry.GameTypeRegistry
+	oracles         *registry.OracleRegistry
+	rollupClient    *sources.RollupClient
+
+	// gameRollupClient is the rollup client used by games, which serves outputs from outputDB if it is enabled
+	gameRollupClient fault.RollupClient
+	outputDB         *outputdb.DB
+}
+
 // NewService creates a new Service.
 func NewService(ctx context.Context, logger log.Logger, cfg *config.Config, m metrics.Metricer) (*Service, error) {
 	s := &Service{
 		systemClock: clock.SystemClock,
-		l1Clock:     clock.NewSimpleClock(),
 		logger:      logger,
 		metrics:     m,
 	}
@@ -93,9 +133,6 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := s.initL1Client(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init l1 client: %w", err)
 	}
-	if err := s.initRollupClient(ctx, cfg); err != nil {
-		return fmt.Errorf("failed to init rollup client: %w", err)
-	}
 	if err := s.initPollClient(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init poll client: %w", err)
 	}
@@ -105,26 +142,68 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 	if err := s.initMetricsServer(&cfg.MetricsConfig); err != nil {
 		return fmt.Errorf("failed to init metrics server: %w", err)
 	}
-	if err := s.initFactoryContract(cfg); err != nil {
+	s.vmLimiter = vm.NewExecutionLimiter(s.metrics, cfg.MaxConcurrentVmExecutions)
+	if err := s.initCoordination(cfg); err != nil {
+		return fmt.Errorf("failed to init coordination: %w", err)
+	}
+	if len(cfg.Chains) == 0 {
+		if err := s.initChain(ctx, "", s.logger, s.metrics, cfg); err != nil {
+			return err
+		}
+	}
+	for _, chainCfg := range cfg.Chains {
+		c, err := cfg.ForChain(chainCfg)
+		if err != nil {
+			return fmt.Errorf("invalid config for chain %v: %w", chainCfg.Name, err)
+		}
+		if err := s.initChain(ctx, chainCfg.Name, s.logger.New("chain", chainCfg.Name), s.metrics.ChainMetrics(chainCfg.Name), &c); err != nil {
+			return fmt.Errorf("failed to init chain %v: %w", chainCfg.Name, err)
+		}
+	}
+
+	if cfg.TraceTypeEnabled(types.TraceTypeCannon) || cfg.TraceTypeEnabled(types.TraceTypePermissioned) {
+		appinfo.SetCannonStateVersion(cfg.Cannon.VmType.String())
+	}
+	s.metrics.RecordInfo(version.SimpleWithMeta)
+	s.metrics.RecordUp()
+	return nil
+}
+
+func (s *Service) initChain(ctx context.Context, name string, logger log.Logger, m metrics.Metricer, cfg *config.Config) error {
+	c := &chain{
+		name:    name,
+		logger:  logger,
+		metrics: m,
+		l1Clock: clock.NewSimpleClock(),
+	}
+	// Track the chain before initialising it, so its components that did start are closed on failure
+	s.chains = append(s.chains, c)
+	if err := s.initRollupClient(ctx, c, cfg); err != nil {
+		return fmt.Errorf("failed to init rollup client: %w", err)
+	}
+	if err := s.initFactoryContract(ctx, c, cfg); err != nil {
 		return fmt.Errorf("failed to create factory contract bindings: %w", err)
 	}
-	if err := s.registerGameTypes(ctx, cfg); err != nil {
+	if err := s.initGameSource(c, cfg); err != nil {
+		return fmt.Errorf("failed to init game source: %w", err)
+	}
+	if err := s.registerGameTypes(ctx, c, cfg); err != nil {
 		return fmt.Errorf("failed to register game types: %w", err)
 	}
-	if err := s.initBondClaims(); err != nil {
+	if err := s.initBondClaims(c); err != nil {
 		return fmt.Errorf("failed to init bond claiming: %w", err)
 	}
-	if err := s.initScheduler(cfg); err != nil {
+	if err := s.initScheduler(c, cfg); err != nil {
 		return fmt.Errorf("failed to init scheduler: %w", err)
 	}
-	if err := s.initLargePreimages(); err != nil {
+	if err := s.initLargePreimages(c); err != nil {
 		return fmt.Errorf("failed to init large preimage scheduler: %w", err)
 	}
 
-	s.initMonitor(cfg)
+	s.initMonitor(c, cfg)
 
-	s.metrics.RecordInfo(version.SimpleWithMeta)
-	s.metrics.RecordUp()
+	c.metrics.RecordInfo(version.SimpleWithMeta)
+	c.metrics.RecordUp()
 	return nil
 }
 
@@ -140,6 +219,11 @@ func (s *Service) initTxManager(ctx context.Context, cfg *config.Config) error {
 	}
 	s.txMgr = txMgr
 	s.txSender = sender.NewTxSender(ctx, s.logger, txMgr, cfg.MaxPendingTx)
+	s.governor = sender.NewGovernor(ctx, s.logger, s.metrics, s.systemClock, s.txSender, sender.GovernorConfig{
+		MaxTxsPerBlock:     cfg.MaxTxsPerBlock,
+		MaxGameTxsPerBlock: cfg.MaxGameTxsPerBlock,
+		DailyGasBudget:     cfg.DailyGasBudget,
+	})
 	return nil
 }
 
@@ -183,11 +267,11 @@ func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
 		return nil
 	}
 	s.logger.Debug("starting metrics server", "addr", cfg.ListenAddr, "port", cfg.ListenPort)
-	m, ok := s.metrics.(opmetrics.RegistryMetricer)
+	m, ok := s.metrics.(opmetrics.GathererMetricer)
 	if !ok {
 		return fmt.Errorf("metrics were enabled, but metricer %T does not expose registry for metrics-server", s.metrics)
 	}
-	metricsSrv, err := opmetrics.StartServer(m.Registry(), cfg.ListenAddr, cfg.ListenPort)
+	metricsSrv, err := opmetrics.StartGathererServer(m.Registry(), m.Gatherer(), cfg.ListenAddr, cfg.ListenPort)
 	if err != nil {
 		return fmt.Errorf("failed to start metrics server: %w", err)
 	}
@@ -197,71 +281,160 @@ func (s *Service) initMetricsServer(cfg *opmetrics.CLIConfig) error {
 	return nil
 }
 
-func (s *Service) initFactoryContract(cfg *config.Config) error {
-	factoryContract := contracts.NewDisputeGameFactoryContract(s.metrics, cfg.GameFactoryAddress,
+func (s *Service) initFactoryContract(ctx context.Context, c *chain, cfg *config.Config) error {
+	if err := addrcheck.Check(ctx, c.logger, s.l1Client, cfg.AddrCheckConfig.Apply(addrcheck.ExpectContract("DisputeGameFactory", cfg.GameFactoryAddress))...); err != nil {
+		return err
+	}
+	c.factoryContract = contracts.NewDisputeGameFactoryContract(c.metrics, cfg.GameFactoryAddress,
 		batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize))
-	s.factoryContract = factoryContract
 	return nil
 }
 
-func (s *Service) initBondClaims() error {
-	claimer := claims.NewBondClaimer(s.logger, s.metrics, s.registry.CreateBondContract, s.txSender, s.claimants...)
-	s.claimer = claims.NewBondClaimScheduler(s.logger, s.metrics, claimer)
+func (s *Service) initGameSource(c *chain, cfg *config.Config) error {
+	if cfg.GameFactoryLogRange == 0 {
+		c.gameSource = c.factoryContract
+		return nil
+	}
+	if err := os.MkdirAll(cfg.Datadir, 0755); err != nil {
+		return fmt.Errorf("failed to create datadir: %w", err)
+	}
+	c.gameSource = factoryscan.NewScanner(c.logger, cfg.GameFactoryAddress, c.factoryContract, s.l1Client,
+		filepath.Join(cfg.Datadir, gameScanCursorFile), cfg.GameFactoryLogRange)
+	return nil
+}
+
+func (s *Service) initBondClaims(c *chain) error {
+	claimer := claims.NewBondClaimer(c.logger, c.metrics, c.registry.CreateBondContract, s.governor, s.claimants...)
+	c.claimer = claims.NewBondClaimScheduler(c.logger, c.metrics, claimer)
 	return nil
 }
 
-func (s *Service) initRollupClient(ctx context.Context, cfg *config.Config) error {
+func (s *Service) initRollupClient(ctx context.Context, c *chain, cfg *config.Config) error {
 	if cfg.RollupRpc == "" {
 		return nil
 	}
-	rollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, s.logger, cfg.RollupRpc)
+	rollupClient, err := dial.DialRollupClientWithTimeout(ctx, dial.DefaultDialTimeout, c.logger, cfg.RollupRpc)
 	if err != nil {
 		return err
 	}
-	s.rollupClient = rollupClient
+	c.rollupClient = rollupClient
+	c.gameRollupClient = rollupClient
+	checkRollupConfigs(ctx, c.logger, rollupClient, enabledVMs(cfg)...)
+	if !cfg.OutputCache {
+		return nil
+	}
+	rollupCfg, err := rollupClient.RollupConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load rollup config for output cache: %w", err)
+	}
+	outputDB, err := outputdb.Open(c.logger, filepath.Join(cfg.Datadir, outputDBDir), rollupCfg.Genesis.L2.Hash)
+	if err != nil {
+		return fmt.Errorf("failed to open output cache: %w", err)
+	}
+	c.outputDB = outputDB
+	c.gameRollupClient = outputdb.NewCachingRollupClient(c.logger, c.metrics, rollupClient, outputDB)
 	return nil
 }
 
-func (s *Service) registerGameTypes(ctx context.Context, cfg *config.Config) error {
+// enabledVMs returns the configs of the VMs used by the enabled trace types.
+func enabledVMs(cfg *config.Config) []vm.Config {
+	var vms []vm.Config
+	if cfg.TraceTypeEnabled(types.TraceTypeCannon) || cfg.TraceTypeEnabled(types.TraceTypePermissioned) {
+		vms = append(vms, cfg.Cannon)
+	}
+	if cfg.TraceTypeEnabled(types.TraceTypeAsterisc) {
+		vms = append(vms, cfg.Asterisc)
+	}
+	if cfg.TraceTypeEnabled(types.TraceTypeAsteriscKona) {
+		vms = append(vms, cfg.AsteriscKona)
+	}
+	return vms
+}
+
+// resolutionBatching configures batching of claim resolutions via Multicall3,
+// falling back to resolving claims individually if Multicall3 is not deployed.
+func (s *Service) resolutionBatching(ctx context.Context, cfg *config.Config) responder.ResolutionBatching {
+	if cfg.ResolutionBatchGasLimit == 0 {
+		return responder.ResolutionBatching{}
+	}
+	if err := addrcheck.Check(ctx, s.logger, s.l1Client, cfg.AddrCheckConfig.Apply(addrcheck.ExpectContract("Multicall3", cfg.Multicall3Address))...); err != nil {
+		s.logger.Warn("Multicall3 not available, resolving claims individually", "err", err)
+		return responder.ResolutionBatching{}
+	}
+	return responder.ResolutionBatching{
+		Batcher:   contracts.NewMulticall3Contract(cfg.Multicall3Address),
+		Estimator: s.l1Client,
+		From:      s.txSender.From(),
+		MaxGas:    cfg.ResolutionBatchGasLimit,
+	}
+}
+
+func (s *Service) initCoordination(cfg *config.Config) error {
+	if !cfg.Coordination.Enabled() {
+		return nil
+	}
+	gossip, err := coordination.StartGossip(s.logger, cfg.Coordination, s.systemClock)
+	if err != nil {
+		return err
+	}
+	s.coordination = gossip
+	return nil
+}
+
+func (s *Service) registerGameTypes(ctx context.Context, c *chain, cfg *config.Config) error {
 	gameTypeRegistry := registry.NewGameTypeRegistry()
 	oracles := registry.NewOracleRegistry()
 	caller := batching.NewMultiCaller(s.l1Client.Client(), batching.DefaultBatchSize)
-	closer, err := fault.RegisterGameTypes(ctx, s.systemClock, s.l1Clock, s.logger, s.metrics, cfg, gameTypeRegistry, oracles, s.rollupClient, s.txSender, s.factoryContract, caller, s.l1Client, cfg.SelectiveClaimResolution, s.claimants)
+	var coordinator coordination.Coordinator = coordination.NoopCoordinator{}
+	if s.coordination != nil {
+		gossipCoordinator, err := s.coordination.Join(cfg.GameFactoryAddress)
+		if err != nil {
+			return fmt.Errorf("failed to join coordination gossip: %w", err)
+		}
+		coordinator = gossipCoordinator
+	}
+	closer, err := fault.RegisterGameTypes(ctx, s.systemClock, c.l1Clock, c.logger, c.metrics, cfg, gameTypeRegistry, oracles, c.gameRollupClient, s.gameTxSender, c.factoryContract, caller, s.l1Client, cfg.SelectiveClaimResolution, s.claimants, s.resolutionBatching(ctx, cfg), s.vmLimiter, coordinator)
 	if err != nil {
 		return err
 	}
-	s.faultGamesCloser = closer
-	s.registry = gameTypeRegistry
-	s.oracles = oracles
+	c.faultGamesCloser = closer
+	c.registry = gameTypeRegistry
+	c.oracles = oracles
 	return nil
 }
 
-func (s *Service) initScheduler(cfg *config.Config) error {
-	disk := newDiskManager(cfg.Datadir)
-	s.sched = scheduler.NewScheduler(s.logger, s.metrics, disk, cfg.MaxConcurrency, s.registry.CreatePlayer, cfg.AllowInvalidPrestate)
+func (s *Service) gameTxSender(game common.Address) fault.TxSender {
+	return s.governor.ForGame(game)
+}
+
+func (s *Service) initScheduler(c *chain, cfg *config.Config) error {
+	disk := NewDiskManager(cfg.Datadir, cfg.GameDataRetention, s.systemClock)
+	c.sched = scheduler.NewScheduler(c.logger, c.metrics, disk, cfg.MaxConcurrency, c.registry.CreatePlayer, cfg.AllowInvalidPrestate)
 	return nil
 }
 
-func (s *Service) initLargePreimages() error {
-	fetcher := fetcher.NewPreimageFetcher(s.logger, s.l1Client)
-	verifier := keccak.NewPreimageVerifier(s.logger, fetcher)
-	challenger := keccak.NewPreimageChallenger(s.logger, s.metrics, verifier, s.txSender)
-	s.preimages = keccak.NewLargePreimageScheduler(s.logger, s.metrics, s.l1Clock, s.oracles, challenger)
+func (s *Service) initLargePreimages(c *chain) error {
+	fetcher := fetcher.NewPreimageFetcher(c.logger, s.l1Client)
+	verifier := keccak.NewPreimageVerifier(c.logger, fetcher)
+	challenger := keccak.NewPreimageChallenger(c.logger, c.metrics, verifier, s.governor)
+	c.preimages = keccak.NewLargePreimageScheduler(c.logger, c.metrics, c.l1Clock, c.oracles, challenger)
 	return nil
 }
 
-func (s *Service) initMonitor(cfg *config.Config) {
-	s.monitor = newGameMonitor(s.logger, s.l1Clock, s.factoryContract, s.sched, s.preimages, cfg.GameWindow, s.claimer, cfg.GameAllowlist, s.pollClient)
+func (s *Service) initMonitor(c *chain, cfg *config.Config) {
+	c.monitor = newGameMonitor(c.logger, c.l1Clock, c.gameSource, c.sched, c.preimages, cfg.GameWindow, c.claimer, s.governor, cfg.GameAllowlist, s.pollClient)
 }
 
 func (s *Service) Start(ctx context.Context) error {
-	s.logger.Info("starting scheduler")
-	s.sched.Start(ctx)
-	s.claimer.Start(ctx)
-	s.preimages.Start(ctx)
-	s.logger.Info("starting monitoring")
-	s.monitor.StartMonitoring()
-	s.logger.Info("challenger game service start completed")
+	for _, c := range s.chains {
+		c.logger.Info("starting scheduler")
+		c.sched.Start(ctx)
+		c.claimer.Start(ctx)
+		c.preimages.Start(ctx)
+		c.logger.Info("starting monitoring")
+		c.monitor.StartMonitoring()
+	}
+	s.logger.Info("challenger game service start completed", "chains", len(s.chains))
 	return nil
 }
 
@@ -273,22 +446,19 @@ func (s *Service) Stop(ctx context.Context) error {
 	s.logger.Info("stopping challenger game service")
 
 	var result error
-	if s.sched != nil {
-		if err := s.sched.Close(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close scheduler: %w", err))
+	for _, c := range s.chains {
+		if err := c.stop(); err != nil {
+			if c.name != "" {
+				err = fmt.Errorf("chain %v: %w", c.name, err)
+			}
+			result = errors.Join(result, err)
 		}
 	}
-	if s.monitor != nil {
-		s.monitor.StopMonitoring()
-	}
-	if s.claimer != nil {
-		if err := s.claimer.Close(); err != nil {
-			result = errors.Join(result, fmt.Errorf("failed to close claimer: %w", err))
+	if s.coordination != nil {
+		if err := s.coordination.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close coordination gossip: %w", err))
 		}
 	}
-	if s.faultGamesCloser != nil {
-		s.faultGamesCloser()
-	}
 	if s.pprofService != nil {
 		if err := s.pprofService.Stop(ctx); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close pprof server: %w", err))
@@ -304,9 +474,6 @@ func (s *Service) Stop(ctx context.Context) error {
 		s.txMgr.Close()
 	}
 
-	if s.rollupClient != nil {
-		s.rollupClient.Close()
-	}
 	if s.pollClient != nil {
 		s.pollClient.Close()
 	}
@@ -322,3 +489,32 @@ func (s *Service) Stop(ctx context.Context) error {
 	s.logger.Info("stopped challenger game service", "err", result)
 	return result
 }
+
+func (c *chain) stop() error {
+	var result error
+	if c.sched != nil {
+		if err := c.sched.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close scheduler: %w", err))
+		}
+	}
+	if c.monitor != nil {
+		c.monitor.StopMonitoring()
+	}
+	if c.claimer != nil {
+		if err := c.claimer.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close claimer: %w", err))
+		}
+	}
+	if c.faultGamesCloser != nil {
+		c.faultGamesCloser()
+	}
+	if c.rollupClient != nil {
+		c.rollupClient.Close()
+	}
+	if c.outputDB != nil {
+		if err := c.outputDB.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close output cache: %w", err))
+		}
+	}
+	return result
+}
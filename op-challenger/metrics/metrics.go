@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
@@ -10,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 
 	contractMetrics "github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts/metrics"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -40,6 +42,9 @@ type Metricer interface {
 	RecordGameL2Challenge()
 	RecordVmExecutionTime(vmType string, t time.Duration)
 	RecordVmMemoryUsed(vmType string, memoryUsed uint64)
+	RecordVmPageGrowthRate(vmType string, rate float64)
+	RecordVmExecutionQueueTime(t time.Duration)
+	RecordVmFailure(vmType string, reason string)
 	RecordClaimResolutionTime(t float64)
 	RecordGameActTime(t float64)
 
@@ -54,22 +59,37 @@ type Metricer interface {
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
 
+	RecordGameDataDiskUsage(active, inactive uint64)
+
 	RecordLargePreimageCount(count int)
 
+	RecordTxThrottled(limit string)
+	RecordDailyGasSpend(eth float64)
+
 	IncActiveExecutors()
 	DecActiveExecutors()
 	IncIdleExecutors()
 	DecIdleExecutors()
+
+	// ChainMetrics returns the Metricer for a chain monitored alongside others by the same process.
+	// Its metrics are labelled with the chain name.
+	ChainMetrics(chain string) Metricer
 }
 
 // Metrics implementation must implement RegistryMetricer to allow the metrics server to work.
 var _ opmetrics.RegistryMetricer = (*Metrics)(nil)
 
+// Metrics implementation must implement GathererMetricer to serve the metrics of all chains.
+var _ opmetrics.GathererMetricer = (*Metrics)(nil)
+
 type Metrics struct {
 	ns       string
 	registry *prometheus.Registry
 	factory  opmetrics.Factory
 
+	chainsLock sync.Mutex
+	chains     []*prometheus.Registry
+
 	txmetrics.TxMetrics
 	*opmetrics.CacheMetrics
 	*contractMetrics.ContractMetrics
@@ -96,9 +116,17 @@ type Metrics struct {
 	gameActTime         prometheus.Histogram
 	vmExecutionTime     *prometheus.HistogramVec
 	vmMemoryUsed        *prometheus.HistogramVec
+	vmPageGrowthRate    *prometheus.GaugeVec
+	vmQueueTime         prometheus.Histogram
+	vmFailures          *prometheus.CounterVec
+
+	txThrottled   *prometheus.CounterVec
+	dailyGasSpend prometheus.Gauge
 
 	trackedGames  prometheus.GaugeVec
 	inflightGames prometheus.Gauge
+
+	gameDataDiskUsage prometheus.GaugeVec
 }
 
 func (m *Metrics) Registry() *prometheus.Registry {
@@ -109,8 +137,10 @@ var _ Metricer = (*Metrics)(nil)
 
 func NewMetrics() *Metrics {
 	registry := opmetrics.NewRegistry()
-	factory := opmetrics.With(registry)
+	return newMetrics(registry, opmetrics.With(registry))
+}
 
+func newMetrics(registry *prometheus.Registry, factory opmetrics.Factory) *Metrics {
 	return &Metrics{
 		ns:       Namespace,
 		registry: registry,
@@ -185,6 +215,34 @@ func NewMetrics() *Metrics {
 			// 100MiB increments from 0 to 1.5GiB
 			Buckets: prometheus.LinearBuckets(0, 1024*1024*100, 15),
 		}, []string{"vm"}),
+		vmPageGrowthRate: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "vm_page_growth_rate",
+			Help:      "Memory pages allocated per million steps during the last execution of the fault proof VM",
+		}, []string{"vm"}),
+		vmQueueTime: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Name:      "vm_queue_time",
+			Help:      "Time (in seconds) spent waiting for a slot to execute the fault proof VM",
+			Buckets: append(
+				[]float64{0.1, 1.0, 10.0},
+				prometheus.ExponentialBuckets(30.0, 2.0, 14)...),
+		}),
+		vmFailures: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "vm_failures_total",
+			Help:      "Number of failed fault proof VM executions, by reason: oom, timeout or error",
+		}, []string{"vm", "reason"}),
+		txThrottled: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "tx_throttled",
+			Help:      "Number of times sending a transaction was delayed or rejected by the governor, by limit",
+		}, []string{"limit"}),
+		dailyGasSpend: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "daily_gas_spend",
+			Help:      "ETH spent on transaction fees in the last 24 hours",
+		}),
 		bondClaimFailures: factory.NewCounter(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "claim_failures",
@@ -227,11 +285,42 @@ func NewMetrics() *Metrics {
 			Name:      "inflight_games",
 			Help:      "Number of games being tracked by the challenger",
 		}),
+		gameDataDiskUsage: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "game_data_disk_usage_bytes",
+			Help:      "Disk space used by the data of games, by whether the game is active or retained after it is no longer required",
+		}, []string{
+			"status",
+		}),
 	}
 }
 
+// ChainMetrics creates the metrics of a chain, served together with the metrics of m.
+// The metrics are kept in a separate registry, so each chain has its own copy labelled with the chain name.
+func (m *Metrics) ChainMetrics(chain string) Metricer {
+	registry := prometheus.NewRegistry()
+	factory := opmetrics.WithRegisterer(prometheus.WrapRegistererWith(prometheus.Labels{"chain": chain}, registry))
+	m.chainsLock.Lock()
+	defer m.chainsLock.Unlock()
+	m.chains = append(m.chains, registry)
+	return newMetrics(registry, factory)
+}
+
+// Gatherer gathers the metrics of m and of all chains created with ChainMetrics, including chains created later.
+func (m *Metrics) Gatherer() prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		m.chainsLock.Lock()
+		gatherers := prometheus.Gatherers{m.registry}
+		for _, registry := range m.chains {
+			gatherers = append(gatherers, registry)
+		}
+		m.chainsLock.Unlock()
+		return gatherers.Gather()
+	})
+}
+
 func (m *Metrics) Start(host string, port int) (*httputil.HTTPServer, error) {
-	return opmetrics.StartServer(m.registry, host, port)
+	return opmetrics.StartGathererServer(m.registry, m.Gatherer(), host, port)
 }
 
 func (m *Metrics) StartBalanceMetrics(
@@ -298,6 +387,26 @@ func (m *Metrics) RecordVmMemoryUsed(vmType string, memoryUsed uint64) {
 	m.vmMemoryUsed.WithLabelValues(vmType).Observe(float64(memoryUsed))
 }
 
+func (m *Metrics) RecordVmPageGrowthRate(vmType string, rate float64) {
+	m.vmPageGrowthRate.WithLabelValues(vmType).Set(rate)
+}
+
+func (m *Metrics) RecordVmExecutionQueueTime(dur time.Duration) {
+	m.vmQueueTime.Observe(dur.Seconds())
+}
+
+func (m *Metrics) RecordVmFailure(vmType string, reason string) {
+	m.vmFailures.WithLabelValues(vmType, reason).Inc()
+}
+
+func (m *Metrics) RecordTxThrottled(limit string) {
+	m.txThrottled.WithLabelValues(limit).Inc()
+}
+
+func (m *Metrics) RecordDailyGasSpend(eth float64) {
+	m.dailyGasSpend.Set(eth)
+}
+
 func (m *Metrics) RecordClaimResolutionTime(t float64) {
 	m.claimResolutionTime.Observe(t)
 }
@@ -339,3 +448,8 @@ func (m *Metrics) RecordGameUpdateScheduled() {
 func (m *Metrics) RecordGameUpdateCompleted() {
 	m.inflightGames.Sub(1)
 }
+
+func (m *Metrics) RecordGameDataDiskUsage(active, inactive uint64) {
+	m.gameDataDiskUsage.WithLabelValues("active").Set(float64(active))
+	m.gameDataDiskUsage.WithLabelValues("inactive").Set(float64(inactive))
+}
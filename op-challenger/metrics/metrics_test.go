@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainMetrics(t *testing.T) {
+	m := NewMetrics()
+	m.RecordGameMove()
+	m.ChainMetrics("a").RecordGameMove()
+	b := m.ChainMetrics("b")
+	b.RecordGameMove()
+	b.RecordGameMove()
+
+	families, err := m.Gatherer().Gather()
+	require.NoError(t, err)
+	moves := make(map[string]float64)
+	for _, family := range families {
+		if family.GetName() != Namespace+"_moves" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			chain := ""
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "chain" {
+					chain = label.GetValue()
+				}
+			}
+			moves[chain] = metric.GetCounter().GetValue()
+		}
+	}
+	require.Equal(t, map[string]float64{"": 1, "a": 1, "b": 2}, moves)
+}
@@ -23,6 +23,10 @@ func (i *NoopMetricsImpl) StartBalanceMetrics(l log.Logger, client *ethclient.Cl
 
 var NoopMetrics Metricer = new(NoopMetricsImpl)
 
+func (i *NoopMetricsImpl) ChainMetrics(_ string) Metricer {
+	return i
+}
+
 func (*NoopMetricsImpl) RecordInfo(version string) {}
 func (*NoopMetricsImpl) RecordUp()                 {}
 
@@ -36,11 +40,17 @@ func (*NoopMetricsImpl) RecordPreimageChallenged()      {}
 func (*NoopMetricsImpl) RecordPreimageChallengeFailed() {}
 func (*NoopMetricsImpl) RecordLargePreimageCount(_ int) {}
 
+func (*NoopMetricsImpl) RecordTxThrottled(_ string)    {}
+func (*NoopMetricsImpl) RecordDailyGasSpend(_ float64) {}
+
 func (*NoopMetricsImpl) RecordBondClaimFailed()   {}
 func (*NoopMetricsImpl) RecordBondClaimed(uint64) {}
 
 func (*NoopMetricsImpl) RecordVmExecutionTime(_ string, _ time.Duration) {}
 func (*NoopMetricsImpl) RecordVmMemoryUsed(_ string, _ uint64)           {}
+func (*NoopMetricsImpl) RecordVmPageGrowthRate(_ string, _ float64)      {}
+func (*NoopMetricsImpl) RecordVmExecutionQueueTime(_ time.Duration)      {}
+func (*NoopMetricsImpl) RecordVmFailure(_ string, _ string)              {}
 func (*NoopMetricsImpl) RecordClaimResolutionTime(t float64)             {}
 func (*NoopMetricsImpl) RecordGameActTime(t float64)                     {}
 
@@ -49,6 +59,8 @@ func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon
 func (*NoopMetricsImpl) RecordGameUpdateScheduled() {}
 func (*NoopMetricsImpl) RecordGameUpdateCompleted() {}
 
+func (*NoopMetricsImpl) RecordGameDataDiskUsage(_, _ uint64) {}
+
 func (*NoopMetricsImpl) IncActiveExecutors() {}
 func (*NoopMetricsImpl) DecActiveExecutors() {}
 func (*NoopMetricsImpl) IncIdleExecutors()   {}
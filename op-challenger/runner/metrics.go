@@ -21,6 +21,8 @@ type Metrics struct {
 	vmLastExecutionTime *prometheus.GaugeVec
 	vmMemoryUsed        *prometheus.HistogramVec
 	vmLastMemoryUsed    *prometheus.GaugeVec
+	vmPageGrowthRate    *prometheus.GaugeVec
+	vmFailures          *prometheus.CounterVec
 	successTotal        *prometheus.CounterVec
 	failuresTotal       *prometheus.CounterVec
 	invalidTotal        *prometheus.CounterVec
@@ -67,6 +69,16 @@ func NewMetrics() *Metrics {
 			Name:      "vm_last_memory_used",
 			Help:      "Memory used (in bytes) for the last execution of the fault proof VM",
 		}, []string{"vm"}),
+		vmPageGrowthRate: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "vm_page_growth_rate",
+			Help:      "Memory pages allocated per million steps during the last execution of the fault proof VM",
+		}, []string{"vm"}),
+		vmFailures: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "vm_failures_total",
+			Help:      "Number of failed fault proof VM executions, by reason: oom, timeout or error",
+		}, []string{"vm", "reason"}),
 		successTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "success_total",
@@ -100,6 +112,14 @@ func (m *Metrics) RecordVmMemoryUsed(vmType string, memoryUsed uint64) {
 	m.vmLastMemoryUsed.WithLabelValues(vmType).Set(float64(memoryUsed))
 }
 
+func (m *Metrics) RecordVmPageGrowthRate(vmType string, rate float64) {
+	m.vmPageGrowthRate.WithLabelValues(vmType).Set(rate)
+}
+
+func (m *Metrics) RecordVmFailure(vmType string, reason string) {
+	m.vmFailures.WithLabelValues(vmType, reason).Inc()
+}
+
 func (m *Metrics) RecordSuccess(vmType types.TraceType) {
 	m.successTotal.WithLabelValues(vmType.String()).Inc()
 }
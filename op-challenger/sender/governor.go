@@ -0,0 +1,243 @@
+package sender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrGasBudgetExhausted = errors.New("daily gas budget exhausted")
+
+// gasBudgetWindow is the rolling window the gas budget applies to.
+const gasBudgetWindow = 24 * time.Hour
+
+// Limits reported when a transaction is throttled by the governor.
+const (
+	LimitBlockTxs     = "block_txs"
+	LimitGameBlockTxs = "game_block_txs"
+	LimitGasBudget    = "gas_budget"
+)
+
+type GovernorMetrics interface {
+	RecordTxThrottled(limit string)
+	RecordDailyGasSpend(eth float64)
+}
+
+type GovernorConfig struct {
+	MaxTxsPerBlock     uint64   // Maximum number of transactions sent per L1 block (0 == no limit)
+	MaxGameTxsPerBlock uint64   // Maximum number of transactions sent per L1 block for a single game (0 == no limit)
+	DailyGasBudget     *big.Int // Maximum wei spent on transaction fees in any 24 hour window (nil == no limit)
+}
+
+type gasSpend struct {
+	time time.Time
+	wei  *big.Int
+}
+
+// Governor limits the transactions sent by the challenger, so a burst of games can't exhaust its funds.
+// Transactions over the per block limits are queued until the next L1 block.
+// Once the daily gas budget is spent, transactions fail with ErrGasBudgetExhausted until older spending
+// leaves the 24 hour window. Transactions already queued are sent, so the budget may be overspent by those.
+type Governor struct {
+	ctx     context.Context
+	log     log.Logger
+	metrics GovernorMetrics
+	clock   clock.Clock
+	sender  *TxSender
+	cfg     GovernorConfig
+
+	mu       sync.Mutex
+	blockNum uint64
+	blockTxs uint64
+	gameTxs  map[common.Address]uint64
+	// newBlock is closed when the next L1 block arrives, to wake up the throttled transactions
+	newBlock chan struct{}
+	spends   []gasSpend
+	spent    *big.Int
+}
+
+func NewGovernor(ctx context.Context, logger log.Logger, m GovernorMetrics, cl clock.Clock, sender *TxSender, cfg GovernorConfig) *Governor {
+	return &Governor{
+		ctx:      ctx,
+		log:      logger,
+		metrics:  m,
+		clock:    cl,
+		sender:   sender,
+		cfg:      cfg,
+		gameTxs:  make(map[common.Address]uint64),
+		newBlock: make(chan struct{}),
+		spent:    new(big.Int),
+	}
+}
+
+// OnNewL1Block resets the per block transaction limits.
+func (g *Governor) OnNewL1Block(blockNum uint64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if blockNum == g.blockNum {
+		return
+	}
+	g.blockNum = blockNum
+	g.blockTxs = 0
+	clear(g.gameTxs)
+	close(g.newBlock)
+	g.newBlock = make(chan struct{})
+}
+
+func (g *Governor) From() common.Address {
+	return g.sender.From()
+}
+
+// SendAndWaitDetailed sends transactions that do not belong to a game, subject to the global limits.
+func (g *Governor) SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidate) []error {
+	return g.sendAndWait(common.Address{}, txPurpose, txs)
+}
+
+func (g *Governor) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
+	return errors.Join(g.SendAndWaitDetailed(txPurpose, txs...)...)
+}
+
+// ForGame returns a sender for the transactions of a game, subject to both the global and per game limits.
+func (g *Governor) ForGame(game common.Address) *GameTxSender {
+	return &GameTxSender{governor: g, game: game}
+}
+
+func (g *Governor) sendAndWait(game common.Address, txPurpose string, txs []txmgr.TxCandidate) []error {
+	errs := make([]error, len(txs))
+	if err := g.checkGasBudget(); err != nil {
+		g.log.Warn("Not sending transactions, gas budget exhausted", "purpose", txPurpose, "game", game, "err", err)
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+	receiptsCh := make(chan txmgr.TxReceipt[int], len(txs))
+	sent := 0
+	for i, tx := range txs {
+		if err := g.acquire(game); err != nil {
+			for j := i; j < len(txs); j++ {
+				errs[j] = err
+			}
+			break
+		}
+		g.sender.queue.Send(i, tx, receiptsCh)
+		sent++
+	}
+	for received := 0; received < sent; received++ {
+		rcpt := <-receiptsCh
+		if rcpt.Receipt != nil {
+			g.recordGasSpend(rcpt.Receipt)
+		}
+		errs[rcpt.ID] = g.sender.receiptErr(txPurpose, rcpt)
+	}
+	return errs
+}
+
+// acquire reserves a transaction in the current block, waiting for the next block if the limits are reached.
+func (g *Governor) acquire(game common.Address) error {
+	for {
+		g.mu.Lock()
+		limit := g.blockLimitReached(game)
+		if limit == "" {
+			g.blockTxs++
+			if game != (common.Address{}) {
+				g.gameTxs[game]++
+			}
+			g.mu.Unlock()
+			return nil
+		}
+		newBlock := g.newBlock
+		g.mu.Unlock()
+
+		g.metrics.RecordTxThrottled(limit)
+		g.log.Debug("Delaying transaction until the next block", "limit", limit, "game", game)
+		select {
+		case <-newBlock:
+		case <-g.ctx.Done():
+			return g.ctx.Err()
+		}
+	}
+}
+
+// blockLimitReached returns the per block limit that prevents sending a transaction for the game, if any.
+// Must be called with the lock held.
+func (g *Governor) blockLimitReached(game common.Address) string {
+	if g.cfg.MaxTxsPerBlock != 0 && g.blockTxs >= g.cfg.MaxTxsPerBlock {
+		return LimitBlockTxs
+	}
+	if game != (common.Address{}) && g.cfg.MaxGameTxsPerBlock != 0 && g.gameTxs[game] >= g.cfg.MaxGameTxsPerBlock {
+		return LimitGameBlockTxs
+	}
+	return ""
+}
+
+func (g *Governor) checkGasBudget() error {
+	if g.cfg.DailyGasBudget == nil || g.cfg.DailyGasBudget.Sign() == 0 {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.pruneSpends()
+	if g.spent.Cmp(g.cfg.DailyGasBudget) < 0 {
+		return nil
+	}
+	g.metrics.RecordTxThrottled(LimitGasBudget)
+	return fmt.Errorf("%w: spent %v of %v wei in the last %v", ErrGasBudgetExhausted, g.spent, g.cfg.DailyGasBudget, gasBudgetWindow)
+}
+
+func (g *Governor) recordGasSpend(rcpt *types.Receipt) {
+	wei := new(big.Int).SetUint64(rcpt.GasUsed)
+	if rcpt.EffectiveGasPrice != nil {
+		wei.Mul(wei, rcpt.EffectiveGasPrice)
+	} else {
+		wei.SetUint64(0)
+	}
+	if rcpt.BlobGasPrice != nil {
+		wei.Add(wei, new(big.Int).Mul(new(big.Int).SetUint64(rcpt.BlobGasUsed), rcpt.BlobGasPrice))
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.spends = append(g.spends, gasSpend{time: g.clock.Now(), wei: wei})
+	g.spent.Add(g.spent, wei)
+	g.pruneSpends()
+}
+
+// pruneSpends removes the spending that left the budget window.
+// Must be called with the lock held.
+func (g *Governor) pruneSpends() {
+	cutoff := g.clock.Now().Add(-gasBudgetWindow)
+	i := 0
+	for ; i < len(g.spends) && !g.spends[i].time.After(cutoff); i++ {
+		g.spent.Sub(g.spent, g.spends[i].wei)
+	}
+	g.spends = g.spends[i:]
+	g.metrics.RecordDailyGasSpend(eth.WeiToEther(g.spent))
+}
+
+// GameTxSender sends the transactions of a single game through the Governor.
+type GameTxSender struct {
+	governor *Governor
+	game     common.Address
+}
+
+func (s *GameTxSender) From() common.Address {
+	return s.governor.From()
+}
+
+func (s *GameTxSender) SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidate) []error {
+	return s.governor.sendAndWait(s.game, txPurpose, txs)
+}
+
+func (s *GameTxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
+	return errors.Join(s.SendAndWaitDetailed(txPurpose, txs...)...)
+}
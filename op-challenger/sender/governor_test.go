@@ -0,0 +1,160 @@
+package sender
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGovernorBlockLimits(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	txMgr := &stubTxMgr{sending: make(map[byte]chan *types.Receipt)}
+	m := &stubGovernorMetrics{}
+	governor := newTestGovernor(t, ctx, txMgr, m, clock.SystemClock, GovernorConfig{
+		MaxTxsPerBlock:     2,
+		MaxGameTxsPerBlock: 1,
+	})
+	gameA := governor.ForGame(common.Address{0xaa})
+	gameB := governor.ForGame(common.Address{0xbb})
+
+	tx := func(i byte) txmgr.TxCandidate {
+		return txmgr.TxCandidate{TxData: []byte{i}}
+	}
+	sendAsync := func(sender simpleSender, tx txmgr.TxCandidate) chan error {
+		ch := make(chan error, 1)
+		go func() {
+			ch <- sender.SendAndWaitSimple("testing", tx)
+		}()
+		return ch
+	}
+
+	result1 := sendAsync(gameA, tx(1))
+	require.Eventually(t, func() bool { return txMgr.sentCount() == 1 }, 10*time.Second, time.Millisecond)
+
+	// Delayed by the per game limit
+	result2 := sendAsync(gameA, tx(2))
+	require.Eventually(t, func() bool { return m.throttled(LimitGameBlockTxs) >= 1 }, 10*time.Second, time.Millisecond)
+
+	// Other games and non-game transactions are not affected by the per game limit
+	result3 := sendAsync(governor, tx(3))
+	require.Eventually(t, func() bool { return txMgr.sentCount() == 2 }, 10*time.Second, time.Millisecond)
+
+	// Delayed by the global limit
+	result4 := sendAsync(gameB, tx(4))
+	require.Eventually(t, func() bool { return m.throttled(LimitBlockTxs) >= 1 }, 10*time.Second, time.Millisecond)
+	require.Equal(t, 2, txMgr.sentCount())
+
+	// Same block does not reset the limits
+	governor.OnNewL1Block(0)
+	require.Never(t, func() bool { return txMgr.sentCount() > 2 }, 100*time.Millisecond, time.Millisecond)
+
+	governor.OnNewL1Block(1)
+	require.Eventually(t, func() bool { return txMgr.sentCount() == 4 }, 10*time.Second, time.Millisecond)
+
+	for i, result := range []chan error{result1, result2, result3, result4} {
+		txMgr.txSuccess(tx(byte(i + 1)))
+		require.NoError(t, <-result)
+	}
+}
+
+func TestGovernorStopsWaitingWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	txMgr := &stubTxMgr{
+		sending:    make(map[byte]chan *types.Receipt),
+		syncStatus: map[byte]uint64{1: types.ReceiptStatusSuccessful},
+	}
+	m := &stubGovernorMetrics{}
+	governor := newTestGovernor(t, ctx, txMgr, m, clock.SystemClock, GovernorConfig{MaxTxsPerBlock: 1})
+
+	result := make(chan []error, 1)
+	go func() {
+		result <- governor.SendAndWaitDetailed("testing", txmgr.TxCandidate{TxData: []byte{1}}, txmgr.TxCandidate{TxData: []byte{2}})
+	}()
+	require.Eventually(t, func() bool { return m.throttled(LimitBlockTxs) >= 1 }, 10*time.Second, time.Millisecond)
+	cancel()
+	errs := <-result
+	require.NoError(t, errs[0])
+	require.ErrorIs(t, errs[1], context.Canceled)
+}
+
+func TestGovernorGasBudget(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	txMgr := &stubTxMgr{
+		sending:    make(map[byte]chan *types.Receipt),
+		syncStatus: map[byte]uint64{1: types.ReceiptStatusSuccessful},
+	}
+	m := &stubGovernorMetrics{}
+	cl := clock.NewDeterministicClock(time.Unix(10_000_000, 0))
+	governor := newTestGovernor(t, ctx, txMgr, m, cl, GovernorConfig{DailyGasBudget: big.NewInt(1000)})
+
+	governor.recordGasSpend(&types.Receipt{GasUsed: 10, EffectiveGasPrice: big.NewInt(50)})
+	cl.AdvanceTime(time.Hour)
+	governor.recordGasSpend(&types.Receipt{GasUsed: 10, EffectiveGasPrice: big.NewInt(40), BlobGasUsed: 5, BlobGasPrice: big.NewInt(20)})
+	require.Equal(t, big.NewInt(1000), governor.spent)
+
+	err := governor.ForGame(common.Address{0xaa}).SendAndWaitSimple("testing", txmgr.TxCandidate{TxData: []byte{1}})
+	require.ErrorIs(t, err, ErrGasBudgetExhausted)
+	require.Zero(t, txMgr.sentCount())
+	require.Equal(t, 1, m.throttled(LimitGasBudget))
+
+	// Once the first spend leaves the window, there's budget left again
+	cl.AdvanceTime(gasBudgetWindow - time.Hour + time.Second)
+	require.NoError(t, governor.ForGame(common.Address{0xaa}).SendAndWaitSimple("testing", txmgr.TxCandidate{TxData: []byte{1}}))
+	require.Equal(t, big.NewInt(500), governor.spent)
+	require.InDelta(t, 500e-18, m.dailyGasSpend(), 1e-30)
+}
+
+func newTestGovernor(t *testing.T, ctx context.Context, txMgr *stubTxMgr, m GovernorMetrics, cl clock.Clock, cfg GovernorConfig) *Governor {
+	logger := testlog.Logger(t, log.LevelInfo)
+	return NewGovernor(ctx, logger, m, cl, NewTxSender(ctx, logger, txMgr, 0), cfg)
+}
+
+type simpleSender interface {
+	SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error
+}
+
+type stubGovernorMetrics struct {
+	m        sync.Mutex
+	limits   map[string]int
+	gasSpend float64
+}
+
+func (s *stubGovernorMetrics) RecordTxThrottled(limit string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.limits == nil {
+		s.limits = make(map[string]int)
+	}
+	s.limits[limit]++
+}
+
+func (s *stubGovernorMetrics) RecordDailyGasSpend(eth float64) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.gasSpend = eth
+}
+
+func (s *stubGovernorMetrics) throttled(limit string) int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.limits[limit]
+}
+
+func (s *stubGovernorMetrics) dailyGasSpend() float64 {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.gasSpend
+}
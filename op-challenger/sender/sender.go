@@ -43,19 +43,24 @@ func (s *TxSender) SendAndWaitDetailed(txPurpose string, txs ...txmgr.TxCandidat
 	for completed < len(txs) {
 		rcpt := <-receiptsCh
 		completed++
-		if rcpt.Err != nil {
-			errs[rcpt.ID] = rcpt.Err
-		} else if rcpt.Receipt != nil {
-			if rcpt.Receipt.Status != types.ReceiptStatusSuccessful {
-				errs[rcpt.ID] = fmt.Errorf("%w purpose: %v hash: %v", ErrTransactionReverted, txPurpose, rcpt.Receipt.TxHash)
-			} else {
-				s.log.Debug("Transaction successfully published", "tx_hash", rcpt.Receipt.TxHash, "purpose", txPurpose)
-			}
-		}
+		errs[rcpt.ID] = s.receiptErr(txPurpose, rcpt)
 	}
 	return errs
 }
 
+// receiptErr returns the error of a sent transaction, if it failed to publish or reverted.
+func (s *TxSender) receiptErr(txPurpose string, rcpt txmgr.TxReceipt[int]) error {
+	if rcpt.Err != nil {
+		return rcpt.Err
+	} else if rcpt.Receipt != nil {
+		if rcpt.Receipt.Status != types.ReceiptStatusSuccessful {
+			return fmt.Errorf("%w purpose: %v hash: %v", ErrTransactionReverted, txPurpose, rcpt.Receipt.TxHash)
+		}
+		s.log.Debug("Transaction successfully published", "tx_hash", rcpt.Receipt.TxHash, "purpose", txPurpose)
+	}
+	return nil
+}
+
 func (s *TxSender) SendAndWaitSimple(txPurpose string, txs ...txmgr.TxCandidate) error {
 	errs := s.SendAndWaitDetailed(txPurpose, txs...)
 	return errors.Join(errs...)
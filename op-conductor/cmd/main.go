@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-conductor/conductor"
 	"github.com/ethereum-optimism/optimism/op-conductor/flags"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/opio"
@@ -24,6 +25,7 @@ var (
 
 func main() {
 	oplog.SetupDefaults()
+	appinfo.SetBuild(Version, GitCommit, GitDate)
 
 	app := cli.NewApp()
 	app.Flags = cliapp.ProtectFlags(flags.Flags)
@@ -51,6 +51,9 @@ type Config struct {
 	// RPCEnableProxy is true if the sequencer RPC proxy should be enabled.
 	RPCEnableProxy bool
 
+	// RPCEnableBatcherLease is true if the batcher failover lease should be served.
+	RPCEnableBatcherLease bool
+
 	LogConfig     oplog.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
@@ -122,12 +125,13 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*Config, error) {
 			SafeInterval:   ctx.Uint64(flags.HealthCheckSafeInterval.Name),
 			MinPeerCount:   ctx.Uint64(flags.HealthCheckMinPeerCount.Name),
 		},
-		RollupCfg:      *rollupCfg,
-		RPCEnableProxy: ctx.Bool(flags.RPCEnableProxy.Name),
-		LogConfig:      oplog.ReadCLIConfig(ctx),
-		MetricsConfig:  opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:    oppprof.ReadCLIConfig(ctx),
-		RPC:            oprpc.ReadCLIConfig(ctx),
+		RollupCfg:             *rollupCfg,
+		RPCEnableProxy:        ctx.Bool(flags.RPCEnableProxy.Name),
+		RPCEnableBatcherLease: ctx.Bool(flags.RPCEnableBatcherLease.Name),
+		LogConfig:             oplog.ReadCLIConfig(ctx),
+		MetricsConfig:         opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:           oppprof.ReadCLIConfig(ctx),
+		RPC:                   oprpc.ReadCLIConfig(ctx),
 	}, nil
 }
 
@@ -22,12 +22,16 @@ import (
 	conductorrpc "github.com/ethereum-optimism/optimism/op-conductor/rpc"
 	opp2p "github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
+	"github.com/ethereum-optimism/optimism/op-service/lease"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 )
@@ -102,6 +106,11 @@ func NewOpConductor(
 
 func (c *OpConductor) init(ctx context.Context) error {
 	c.log.Info("initializing OpConductor", "version", c.version)
+	rollupConfigHash, err := c.cfg.RollupCfg.Hash()
+	if err != nil {
+		return err
+	}
+	appinfo.SetRollupConfigHash(rollupConfigHash)
 	if err := c.initSequencerControl(ctx); err != nil {
 		return errors.Wrap(err, "failed to initialize sequencer control")
 	}
@@ -193,11 +202,16 @@ func (c *OpConductor) initHealthMonitor(ctx context.Context) error {
 }
 
 func (oc *OpConductor) initRPCServer(ctx context.Context) error {
+	adminJWTSecret, err := oc.cfg.RPC.AdminJWTSecret()
+	if err != nil {
+		return err
+	}
 	server := oprpc.NewServer(
 		oc.cfg.RPC.ListenAddr,
 		oc.cfg.RPC.ListenPort,
 		oc.version,
 		oprpc.WithLogger(oc.log),
+		oprpc.WithAdminJWTSecret(adminJWTSecret),
 	)
 	api := conductorrpc.NewAPIBackend(oc.log, oc)
 	server.AddAPI(rpc.API{
@@ -205,6 +219,10 @@ func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 		Version:   oc.version,
 		Service:   api,
 	})
+	if oc.cfg.RPC.EnableAdmin && adminJWTSecret != nil {
+		server.AddAPI(oppprof.GetRuntimeAPI(oppprof.NewRuntimeAPI(oc.log, oc.cfg.PprofConfig.ProfileDir)))
+		oc.log.Info("Authenticated admin runtime RPC enabled")
+	}
 
 	if oc.cfg.RPCEnableProxy {
 		execClient, err := dial.DialEthClientWithTimeout(ctx, 1*time.Minute, oc.log, oc.cfg.ExecutionRPC)
@@ -234,6 +252,11 @@ func (oc *OpConductor) initRPCServer(ctx context.Context) error {
 		})
 	}
 
+	if oc.cfg.RPCEnableBatcherLease {
+		server.AddAPI(lease.GetAPI(conductorrpc.NewLeaderLease(oc.log, oc, clock.SystemClock)))
+		oc.log.Info("Batcher failover lease RPC enabled")
+	}
+
 	oc.rpcServer = server
 	return nil
 }
@@ -93,6 +93,13 @@ var (
 		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RPC_ENABLE_PROXY"),
 		Value:   true,
 	}
+	RPCEnableBatcherLease = &cli.BoolFlag{
+		Name: "rpc.enable-batcher-lease",
+		Usage: "Serve the batcher failover lease (lease_acquire and lease_release) on the leader, " +
+			"for batchers running with --failover.lease-rpc pointing to the conductors",
+		EnvVars: opservice.PrefixEnvVar(EnvVarPrefix, "RPC_ENABLE_BATCHER_LEASE"),
+		Value:   false,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -110,6 +117,7 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	Paused,
 	RPCEnableProxy,
+	RPCEnableBatcherLease,
 	RaftBootstrap,
 	HealthCheckSafeEnabled,
 	HealthCheckSafeInterval,
@@ -0,0 +1,86 @@
+package rpc
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/lease"
+)
+
+// leaderChecker is the part of the conductor the leader lease needs.
+type leaderChecker interface {
+	Leader(ctx context.Context) bool
+}
+
+// LeaderLease is the batcher failover lease, served by the leader conductor to the batchers of the sequencer cluster.
+// The lease is kept in memory by the leader, and is refused by the other conductors.
+// When leadership changes, the new leader doesn't know the holder of the lease at the previous leader,
+// so it only grants the lease once the lease granted by the previous leader has expired.
+type LeaderLease struct {
+	log   log.Logger
+	con   leaderChecker
+	clock clock.Clock
+
+	mu    sync.Mutex
+	lease *lease.MemoryLease
+	// leaderSince is the time the conductor was first seen as leader, zero if it's not the leader.
+	leaderSince time.Time
+}
+
+var _ lease.Lease = (*LeaderLease)(nil)
+
+func NewLeaderLease(log log.Logger, con leaderChecker, clock clock.Clock) *LeaderLease {
+	return &LeaderLease{
+		log:   log,
+		con:   con,
+		clock: clock,
+		lease: lease.NewMemoryLease(clock),
+	}
+}
+
+// checkLeader returns whether the conductor is the leader, and resets the lease if it's not.
+func (l *LeaderLease) checkLeader(ctx context.Context) bool {
+	if l.con.Leader(ctx) {
+		if l.leaderSince.IsZero() {
+			l.leaderSince = l.clock.Now()
+		}
+		return true
+	}
+	if !l.leaderSince.IsZero() {
+		l.log.Info("Lost leadership, dropping batcher lease", "holder", l.lease.Holder())
+		l.lease = lease.NewMemoryLease(l.clock)
+		l.leaderSince = time.Time{}
+	}
+	return false
+}
+
+func (l *LeaderLease) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.checkLeader(ctx) {
+		return false, ErrNotLeader
+	}
+	// A lease granted by the previous leader is at most ttl old
+	if l.clock.Now().Before(l.leaderSince.Add(ttl)) {
+		return false, nil
+	}
+	prev := l.lease.Holder()
+	held, err := l.lease.Acquire(ctx, holder, ttl)
+	if held && prev != holder {
+		l.log.Info("Granted batcher lease", "holder", holder, "previous", prev)
+	}
+	return held, err
+}
+
+func (l *LeaderLease) Release(ctx context.Context, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.checkLeader(ctx) {
+		return ErrNotLeader
+	}
+	return l.lease.Release(ctx, holder)
+}
@@ -0,0 +1,87 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubLeader struct {
+	leader bool
+}
+
+func (s *stubLeader) Leader(context.Context) bool {
+	return s.leader
+}
+
+func TestLeaderLease(t *testing.T) {
+	ctx := context.Background()
+	ttl := 10 * time.Second
+
+	setup := func() (*LeaderLease, *stubLeader, *clock.DeterministicClock) {
+		con := &stubLeader{leader: true}
+		clk := clock.NewDeterministicClock(time.Unix(1000, 0))
+		return NewLeaderLease(testlog.Logger(t, log.LevelInfo), con, clk), con, clk
+	}
+
+	t.Run("NotLeader", func(t *testing.T) {
+		l, con, _ := setup()
+		con.leader = false
+		held, err := l.Acquire(ctx, "a", ttl)
+		require.ErrorIs(t, err, ErrNotLeader)
+		require.False(t, held)
+		require.ErrorIs(t, l.Release(ctx, "a"), ErrNotLeader)
+	})
+
+	t.Run("GracePeriod", func(t *testing.T) {
+		l, _, clk := setup()
+		// the lease granted by the previous leader may still be valid
+		held, err := l.Acquire(ctx, "a", ttl)
+		require.NoError(t, err)
+		require.False(t, held)
+
+		clk.AdvanceTime(ttl)
+		held, err = l.Acquire(ctx, "a", ttl)
+		require.NoError(t, err)
+		require.True(t, held)
+
+		held, err = l.Acquire(ctx, "b", ttl)
+		require.NoError(t, err)
+		require.False(t, held, "lease is held by a")
+
+		require.NoError(t, l.Release(ctx, "a"))
+		held, err = l.Acquire(ctx, "b", ttl)
+		require.NoError(t, err)
+		require.True(t, held)
+	})
+
+	t.Run("LostLeadership", func(t *testing.T) {
+		l, con, clk := setup()
+		_, err := l.Acquire(ctx, "a", ttl)
+		require.NoError(t, err)
+		clk.AdvanceTime(ttl)
+		held, err := l.Acquire(ctx, "a", ttl)
+		require.NoError(t, err)
+		require.True(t, held)
+
+		con.leader = false
+		_, err = l.Acquire(ctx, "a", ttl)
+		require.ErrorIs(t, err, ErrNotLeader)
+
+		// regaining leadership starts a new grace period, and forgets the previous holder
+		con.leader = true
+		held, err = l.Acquire(ctx, "b", ttl)
+		require.NoError(t, err)
+		require.False(t, held)
+		clk.AdvanceTime(ttl)
+		held, err = l.Acquire(ctx, "b", ttl)
+		require.NoError(t, err)
+		require.True(t, held)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -67,3 +68,14 @@ func (api *NodeProxyBackend) RollupConfig(ctx context.Context) (*rollup.Config,
 	}
 	return config, nil
 }
+
+func (api *NodeProxyBackend) RollupConfigHash(ctx context.Context) (common.Hash, error) {
+	hash, err := api.client.RollupConfigHash(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if !api.con.Leader(ctx) {
+		return common.Hash{}, ErrNotLeader
+	}
+	return hash, nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-devnet/devnet"
+	"github.com/ethereum-optimism/optimism/op-devnet/flags"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+)
+
+var (
+	Version   = "v0.0.1"
+	GitCommit = ""
+	GitDate   = ""
+)
+
+func main() {
+	ctx := opio.WithInterruptBlocker(context.Background())
+	err := run(ctx, os.Args)
+	if err != nil {
+		log.Crit("Application failed", "message", err)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	oplog.SetupDefaults()
+
+	app := cli.NewApp()
+	app.Flags = cliapp.ProtectFlags(flags.Flags)
+	app.Version = opservice.FormatVersion(Version, GitCommit, GitDate, "")
+	app.Name = "op-devnet"
+	app.Usage = "op-devnet runs a local OP Stack devnet"
+	app.Description = "The op-devnet deploys the L1 contracts, generates the L2 genesis and runs an L1 chain together with " +
+		"op-geth, op-node, op-batcher, op-proposer and op-challenger, until interrupted."
+	app.Action = cliapp.LifecycleCmd(devnet.Main())
+	return app.RunContext(ctx, args)
+}
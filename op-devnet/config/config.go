@@ -0,0 +1,133 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var (
+	ErrMissingMonorepoDir = errors.New("must specify monorepo dir")
+	ErrMissingDevnetDir   = errors.New("must specify devnet dir")
+	ErrMissingBinary      = errors.New("missing binary path")
+	ErrDuplicatePort      = errors.New("duplicate port")
+	ErrInvalidTimeout     = errors.New("startup timeout must be positive")
+)
+
+// Ports are the host ports the devnet services listen on.
+// The defaults match the ports of the docker-compose devnet, so tooling written against either works with both.
+type Ports struct {
+	L1RPC     int
+	L1WS      int
+	L1Beacon  int
+	L2RPC     int
+	L2WS      int
+	L2Auth    int
+	RollupRPC int
+	Batcher   int
+	Proposer  int
+}
+
+func DefaultPorts() Ports {
+	return Ports{
+		L1RPC:     8545,
+		L1WS:      8546,
+		L1Beacon:  5052,
+		L2RPC:     9545,
+		L2WS:      9546,
+		L2Auth:    8551,
+		RollupRPC: 7545,
+		Batcher:   6545,
+		Proposer:  6546,
+	}
+}
+
+func (p Ports) all() []int {
+	return []int{p.L1RPC, p.L1WS, p.L1Beacon, p.L2RPC, p.L2WS, p.L2Auth, p.RollupRPC, p.Batcher, p.Proposer}
+}
+
+// Binaries are the paths of the executables launched by the devnet.
+type Binaries struct {
+	OpGeth       string
+	OpNode       string
+	OpBatcher    string
+	OpProposer   string
+	OpChallenger string
+}
+
+type Config struct {
+	LogConfig oplog.CLIConfig
+
+	// MonorepoDir is the root of the monorepo, used to locate the contracts and default binaries.
+	MonorepoDir string
+	// DevnetDir is where genesis files, allocs, chain data and service logs are written.
+	DevnetDir string
+
+	Binaries Binaries
+	Ports    Ports
+
+	// L2OO deploys and uses the L2OutputOracle instead of fault proofs. The challenger is not started in this mode.
+	L2OO bool
+	// DisableChallenger skips launching the op-challenger.
+	DisableChallenger bool
+	// RegenerateAllocs forces the L1 and L2 allocs to be regenerated with forge, even if they already exist.
+	RegenerateAllocs bool
+
+	// StartupTimeout is how long to wait for each service to become healthy.
+	StartupTimeout time.Duration
+}
+
+func (c *Config) Check() error {
+	var result error
+	if c.MonorepoDir == "" {
+		result = errors.Join(result, ErrMissingMonorepoDir)
+	}
+	if c.DevnetDir == "" {
+		result = errors.Join(result, ErrMissingDevnetDir)
+	}
+	for _, bin := range []struct{ name, path string }{
+		{"op-geth", c.Binaries.OpGeth},
+		{"op-node", c.Binaries.OpNode},
+		{"op-batcher", c.Binaries.OpBatcher},
+		{"op-proposer", c.Binaries.OpProposer},
+		{"op-challenger", c.Binaries.OpChallenger},
+	} {
+		if bin.path == "" {
+			result = errors.Join(result, fmt.Errorf("%w: %s", ErrMissingBinary, bin.name))
+		}
+	}
+	seen := make(map[int]bool)
+	for _, port := range c.Ports.all() {
+		if seen[port] {
+			result = errors.Join(result, ErrDuplicatePort)
+			break
+		}
+		seen[port] = true
+	}
+	if c.StartupTimeout <= 0 {
+		result = errors.Join(result, ErrInvalidTimeout)
+	}
+	return result
+}
+
+// NewConfig creates a new config using default values whenever possible.
+// Binaries default to the build output of each service in the monorepo, except op-geth which is looked up in PATH.
+func NewConfig(monorepoDir string) *Config {
+	return &Config{
+		LogConfig:   oplog.DefaultCLIConfig(),
+		MonorepoDir: monorepoDir,
+		DevnetDir:   filepath.Join(monorepoDir, ".devnet"),
+		Binaries: Binaries{
+			OpGeth:       "geth",
+			OpNode:       filepath.Join(monorepoDir, "op-node", "bin", "op-node"),
+			OpBatcher:    filepath.Join(monorepoDir, "op-batcher", "bin", "op-batcher"),
+			OpProposer:   filepath.Join(monorepoDir, "op-proposer", "bin", "op-proposer"),
+			OpChallenger: filepath.Join(monorepoDir, "op-challenger", "bin", "op-challenger"),
+		},
+		Ports:          DefaultPorts(),
+		StartupTimeout: 2 * time.Minute,
+	}
+}
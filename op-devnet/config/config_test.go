@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultConfigIsValid(t *testing.T) {
+	cfg := validConfig()
+	require.NoError(t, cfg.Check())
+}
+
+func TestRequireMonorepoDir(t *testing.T) {
+	cfg := validConfig()
+	cfg.MonorepoDir = ""
+	require.ErrorIs(t, cfg.Check(), ErrMissingMonorepoDir)
+}
+
+func TestRequireDevnetDir(t *testing.T) {
+	cfg := validConfig()
+	cfg.DevnetDir = ""
+	require.ErrorIs(t, cfg.Check(), ErrMissingDevnetDir)
+}
+
+func TestRequireBinaries(t *testing.T) {
+	cfg := validConfig()
+	cfg.Binaries.OpChallenger = ""
+	err := cfg.Check()
+	require.ErrorIs(t, err, ErrMissingBinary)
+	require.ErrorContains(t, err, "op-challenger")
+}
+
+func TestRejectDuplicatePorts(t *testing.T) {
+	cfg := validConfig()
+	cfg.Ports.Proposer = cfg.Ports.Batcher
+	require.ErrorIs(t, cfg.Check(), ErrDuplicatePort)
+}
+
+func TestRequireStartupTimeout(t *testing.T) {
+	cfg := validConfig()
+	cfg.StartupTimeout = 0
+	require.ErrorIs(t, cfg.Check(), ErrInvalidTimeout)
+}
+
+func validConfig() *Config {
+	// Should be valid using only the required arguments passed in via the constructor.
+	return NewConfig("./devnet_config_testdir")
+}
@@ -0,0 +1,380 @@
+package devnet
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+
+	"github.com/ethereum-optimism/optimism/op-devnet/config"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/devgeth"
+	"github.com/ethereum-optimism/optimism/op-service/fakebeacon"
+)
+
+const (
+	// devMnemonic is the mnemonic of the prefunded devnet accounts, shared with the docker-compose devnet.
+	devMnemonic    = "test test test test test test test test test test test junk"
+	proposerHDPath = "m/44'/60'/0'/0/1"
+	batcherHDPath  = "m/44'/60'/0'/0/2"
+	challengerPath = "m/44'/60'/0'/0/4"
+
+	// l1FinalizedDistance is the distance from the L1 head that L1 blocks are finalized at. Short, for a faster devnet.
+	l1FinalizedDistance = 8
+	// fastGameType is the game type played by the challenger and created by the proposer with fault proofs enabled.
+	fastGameType = 254
+	// challengerGracePeriod is how long the challenger must keep running after starting to be considered healthy.
+	challengerGracePeriod = 2 * time.Second
+)
+
+// Devnet runs a local L1 and L2 chain: an in-process L1 geth node with a fake beacon API, and op-geth, op-node,
+// op-batcher, op-proposer and op-challenger child processes.
+// It implements cliapp.Lifecycle, so it can be run as a command or embedded in tests.
+type Devnet struct {
+	log   log.Logger
+	cfg   *config.Config
+	paths paths
+
+	genesis *Genesis
+
+	beacon *fakebeacon.FakeBeacon
+	l1Node *node.Node
+	procs  []*process
+
+	stopped atomic.Bool
+}
+
+var _ cliapp.Lifecycle = (*Devnet)(nil)
+
+func New(logger log.Logger, cfg *config.Config) (*Devnet, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return &Devnet{
+		log:   logger,
+		cfg:   cfg,
+		paths: newPaths(cfg),
+	}, nil
+}
+
+// Start generates the genesis and starts each service in order, waiting for it to be healthy before starting the next.
+// Stop should be called to clean up after a failed start.
+func (d *Devnet) Start(ctx context.Context) error {
+	gen, err := GenerateGenesis(ctx, d.log, d.cfg)
+	if err != nil {
+		return fmt.Errorf("failed to generate genesis: %w", err)
+	}
+	d.genesis = gen
+	if err := d.resetChainData(); err != nil {
+		return err
+	}
+	if err := d.writeJWTSecret(); err != nil {
+		return err
+	}
+	if err := d.startL1(ctx); err != nil {
+		return fmt.Errorf("failed to start L1: %w", err)
+	}
+	if err := d.startL2(ctx); err != nil {
+		return fmt.Errorf("failed to start op-geth: %w", err)
+	}
+	if err := d.startOpNode(ctx); err != nil {
+		return fmt.Errorf("failed to start op-node: %w", err)
+	}
+	if err := d.startBatcher(ctx); err != nil {
+		return fmt.Errorf("failed to start op-batcher: %w", err)
+	}
+	if err := d.startProposer(ctx); err != nil {
+		return fmt.Errorf("failed to start op-proposer: %w", err)
+	}
+	if !d.cfg.L2OO && !d.cfg.DisableChallenger {
+		if err := d.startChallenger(ctx); err != nil {
+			return fmt.Errorf("failed to start op-challenger: %w", err)
+		}
+	}
+	d.log.Info("Devnet ready",
+		"l1", d.L1RPC(), "l2", d.L2RPC(), "rollup", d.RollupRPC(), "beacon", d.L1BeaconRPC(),
+		"batchInbox", gen.Rollup.BatchInboxAddress)
+	return nil
+}
+
+// Stop tears down all services in the reverse order of starting them.
+func (d *Devnet) Stop(ctx context.Context) error {
+	var result error
+	for i := len(d.procs) - 1; i >= 0; i-- {
+		result = errors.Join(result, d.procs[i].Stop(ctx))
+	}
+	d.procs = nil
+	if d.l1Node != nil {
+		result = errors.Join(result, d.l1Node.Close())
+		d.l1Node = nil
+	}
+	if d.beacon != nil {
+		result = errors.Join(result, d.beacon.Close())
+		d.beacon = nil
+	}
+	d.stopped.Store(true)
+	return result
+}
+
+func (d *Devnet) Stopped() bool {
+	return d.stopped.Load()
+}
+
+// Healthy returns an error if any of the started child processes exited.
+func (d *Devnet) Healthy() error {
+	var result error
+	for _, p := range d.procs {
+		result = errors.Join(result, p.Exited())
+	}
+	return result
+}
+
+// Genesis returns the generated devnet genesis. It is nil until the devnet is started.
+func (d *Devnet) Genesis() *Genesis {
+	return d.genesis
+}
+
+func (d *Devnet) L1RPC() string {
+	return "http://127.0.0.1:" + strconv.Itoa(d.cfg.Ports.L1RPC)
+}
+
+func (d *Devnet) L1WS() string {
+	return "ws://127.0.0.1:" + strconv.Itoa(d.cfg.Ports.L1WS)
+}
+
+func (d *Devnet) L1BeaconRPC() string {
+	return "http://127.0.0.1:" + strconv.Itoa(d.cfg.Ports.L1Beacon)
+}
+
+func (d *Devnet) L2RPC() string {
+	return "http://127.0.0.1:" + strconv.Itoa(d.cfg.Ports.L2RPC)
+}
+
+func (d *Devnet) L2Auth() string {
+	return "http://127.0.0.1:" + strconv.Itoa(d.cfg.Ports.L2Auth)
+}
+
+func (d *Devnet) RollupRPC() string {
+	return "http://127.0.0.1:" + strconv.Itoa(d.cfg.Ports.RollupRPC)
+}
+
+func (d *Devnet) BatcherRPC() string {
+	return "http://127.0.0.1:" + strconv.Itoa(d.cfg.Ports.Batcher)
+}
+
+func (d *Devnet) ProposerRPC() string {
+	return "http://127.0.0.1:" + strconv.Itoa(d.cfg.Ports.Proposer)
+}
+
+// resetChainData removes the data of a previous run, which is incompatible with the newly generated genesis.
+func (d *Devnet) resetChainData() error {
+	for _, dir := range []string{d.paths.l2DataDir, d.paths.challengerDir, d.paths.blobsDir} {
+		if err := os.RemoveAll(dir); err != nil {
+			return fmt.Errorf("failed to remove %v: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+func (d *Devnet) writeJWTSecret() error {
+	if exists(d.paths.jwtSecret) {
+		return nil
+	}
+	var secret [32]byte
+	if _, err := rand.Read(secret[:]); err != nil {
+		return fmt.Errorf("failed to generate jwt secret: %w", err)
+	}
+	if err := os.WriteFile(d.paths.jwtSecret, []byte(hexutil.Encode(secret[:])), 0o600); err != nil {
+		return fmt.Errorf("failed to write jwt secret: %w", err)
+	}
+	return nil
+}
+
+func (d *Devnet) startL1(ctx context.Context) error {
+	deployConfig := d.genesis.DeployConfig
+	d.beacon = fakebeacon.NewBeacon(d.log.New("role", "l1-cl"), filepath.Join(d.paths.blobsDir, "l1-cl"),
+		d.genesis.L1.Timestamp, deployConfig.L1BlockTime)
+	if err := d.beacon.Start("127.0.0.1:" + strconv.Itoa(d.cfg.Ports.L1Beacon)); err != nil {
+		return fmt.Errorf("failed to start beacon API: %w", err)
+	}
+	ports := func(_ *ethconfig.Config, nodeCfg *node.Config) error {
+		nodeCfg.HTTPPort = d.cfg.Ports.L1RPC
+		nodeCfg.WSPort = d.cfg.Ports.L1WS
+		return nil
+	}
+	l1Node, _, err := devgeth.InitL1(deployConfig.L1ChainID, deployConfig.L1BlockTime, l1FinalizedDistance, d.genesis.L1,
+		clock.SystemClock, filepath.Join(d.paths.blobsDir, "l1-el"), d.beacon, ports)
+	if err != nil {
+		return err
+	}
+	d.l1Node = l1Node
+	if err := l1Node.Start(); err != nil {
+		return err
+	}
+	return waitHealthy(ctx, d.log, "l1", nil, d.cfg.StartupTimeout, rpcHealthCheck(d.L1RPC(), "eth_chainId"))
+}
+
+func (d *Devnet) startL2(ctx context.Context) error {
+	d.log.Info("Initializing op-geth", "datadir", d.paths.l2DataDir)
+	if err := d.runToCompletion(ctx, "op-geth-init", d.cfg.Binaries.OpGeth,
+		"init", "--datadir="+d.paths.l2DataDir, "--state.scheme=hash", d.paths.genesisL2); err != nil {
+		return err
+	}
+	// Archive mode is required, otherwise old trie nodes are pruned within minutes of starting the devnet.
+	proc, err := d.startProcess("op-geth", d.cfg.Binaries.OpGeth,
+		"--datadir="+d.paths.l2DataDir,
+		"--http", "--http.addr=127.0.0.1", "--http.port="+strconv.Itoa(d.cfg.Ports.L2RPC),
+		"--http.api=web3,debug,eth,txpool,net,engine",
+		"--ws", "--ws.addr=127.0.0.1", "--ws.port="+strconv.Itoa(d.cfg.Ports.L2WS),
+		"--ws.api=debug,eth,txpool,net,engine",
+		"--authrpc.addr=127.0.0.1", "--authrpc.port="+strconv.Itoa(d.cfg.Ports.L2Auth),
+		"--authrpc.jwtsecret="+d.paths.jwtSecret,
+		"--syncmode=full", "--gcmode=archive", "--state.scheme=hash",
+		"--nodiscover", "--maxpeers=0",
+		"--networkid="+strconv.FormatUint(d.genesis.DeployConfig.L2ChainID, 10),
+		"--rpc.allow-unprotected-txs")
+	if err != nil {
+		return err
+	}
+	return waitHealthy(ctx, d.log, "op-geth", proc, d.cfg.StartupTimeout, rpcHealthCheck(d.L2RPC(), "eth_chainId"))
+}
+
+func (d *Devnet) startOpNode(ctx context.Context) error {
+	proc, err := d.startProcess("op-node", d.cfg.Binaries.OpNode,
+		"--l1="+d.L1WS(),
+		"--l1.beacon="+d.L1BeaconRPC(),
+		"--l1.epoch-poll-interval=12s",
+		"--l1.http-poll-interval=6s",
+		"--l2="+d.L2Auth(),
+		"--l2.jwt-secret="+d.paths.jwtSecret,
+		"--rollup.config="+d.paths.rollupConfig,
+		"--sequencer.enabled",
+		"--sequencer.l1-confs=0",
+		"--verifier.l1-confs=0",
+		"--p2p.disable",
+		"--rpc.addr=127.0.0.1",
+		"--rpc.port="+strconv.Itoa(d.cfg.Ports.RollupRPC),
+		"--rpc.enable-admin")
+	if err != nil {
+		return err
+	}
+	return waitHealthy(ctx, d.log, "op-node", proc, d.cfg.StartupTimeout, rpcHealthCheck(d.RollupRPC(), "optimism_syncStatus"))
+}
+
+func (d *Devnet) startBatcher(ctx context.Context) error {
+	proc, err := d.startProcess("op-batcher", d.cfg.Binaries.OpBatcher,
+		"--l1-eth-rpc="+d.L1RPC(),
+		"--l2-eth-rpc="+d.L2RPC(),
+		"--rollup-rpc="+d.RollupRPC(),
+		"--max-channel-duration=2",
+		"--sub-safety-margin=4",
+		"--poll-interval=1s",
+		"--num-confirmations=1",
+		"--mnemonic="+devMnemonic,
+		"--sequencer-hd-path="+batcherHDPath,
+		"--batch-type=1",
+		"--data-availability-type=blobs",
+		"--rpc.addr=127.0.0.1",
+		"--rpc.port="+strconv.Itoa(d.cfg.Ports.Batcher),
+		"--rpc.enable-admin")
+	if err != nil {
+		return err
+	}
+	return waitHealthy(ctx, d.log, "op-batcher", proc, d.cfg.StartupTimeout, httpHealthCheck(d.BatcherRPC()+"/healthz"))
+}
+
+func (d *Devnet) startProposer(ctx context.Context) error {
+	args := []string{
+		"--l1-eth-rpc=" + d.L1RPC(),
+		"--rollup-rpc=" + d.RollupRPC(),
+		"--poll-interval=1s",
+		"--num-confirmations=1",
+		"--mnemonic=" + devMnemonic,
+		"--l2-output-hd-path=" + proposerHDPath,
+		"--allow-non-finalized",
+		"--rpc.addr=127.0.0.1",
+		"--rpc.port=" + strconv.Itoa(d.cfg.Ports.Proposer),
+		"--rpc.enable-admin",
+	}
+	// The proposer refuses to start if both the L2OutputOracle and dispute game factory are set.
+	if d.cfg.L2OO {
+		args = append(args, "--l2oo-address="+d.genesis.L1Deployments.L2OutputOracleProxy.Hex())
+	} else {
+		args = append(args,
+			"--game-factory-address="+d.genesis.L1Deployments.DisputeGameFactoryProxy.Hex(),
+			"--game-type="+strconv.Itoa(fastGameType),
+			"--proposal-interval=12s")
+	}
+	proc, err := d.startProcess("op-proposer", d.cfg.Binaries.OpProposer, args...)
+	if err != nil {
+		return err
+	}
+	return waitHealthy(ctx, d.log, "op-proposer", proc, d.cfg.StartupTimeout, httpHealthCheck(d.ProposerRPC()+"/healthz"))
+}
+
+func (d *Devnet) startChallenger(ctx context.Context) error {
+	proc, err := d.startProcess("op-challenger", d.cfg.Binaries.OpChallenger,
+		"--l1-eth-rpc="+d.L1RPC(),
+		"--l1-beacon="+d.L1BeaconRPC(),
+		"--l2-eth-rpc="+d.L2RPC(),
+		"--rollup-rpc="+d.RollupRPC(),
+		"--game-factory-address="+d.genesis.L1Deployments.DisputeGameFactoryProxy.Hex(),
+		"--trace-type=fast",
+		"--datadir="+d.paths.challengerDir,
+		"--mnemonic="+devMnemonic,
+		"--hd-path="+challengerPath,
+		"--num-confirmations=1",
+		// The devnet can't set the absolute prestate output root because the contracts are deployed in L1 genesis
+		// before the L2 genesis is known.
+		"--unsafe-allow-invalid-prestate")
+	if err != nil {
+		return err
+	}
+	// The challenger has no RPC server, so it is considered healthy if it is still running after a grace period.
+	select {
+	case <-proc.done:
+		return proc.Exited()
+	case <-time.After(challengerGracePeriod):
+		d.log.Info("Service healthy", "service", "op-challenger")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Devnet) startProcess(name string, bin string, args ...string) (*process, error) {
+	proc, err := startProcess(d.log, d.paths.logsDir, name, bin, args...)
+	if err != nil {
+		return nil, err
+	}
+	d.procs = append(d.procs, proc)
+	return proc, nil
+}
+
+// runToCompletion runs a one-off command, such as a database init, and waits for it to exit successfully.
+func (d *Devnet) runToCompletion(ctx context.Context, name string, bin string, args ...string) error {
+	proc, err := startProcess(d.log, d.paths.logsDir, name, bin, args...)
+	if err != nil {
+		return err
+	}
+	select {
+	case <-proc.done:
+		if proc.err != nil {
+			return fmt.Errorf("%v failed: %w", name, proc.err)
+		}
+		return nil
+	case <-ctx.Done():
+		return errors.Join(ctx.Err(), proc.Stop(ctx))
+	}
+}
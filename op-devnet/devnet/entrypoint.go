@@ -0,0 +1,38 @@
+package devnet
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+
+	"github.com/ethereum-optimism/optimism/op-devnet/flags"
+)
+
+// Main is the entrypoint into the devnet.
+// This method returns a cliapp.LifecycleAction, to create an op-service CLI-lifecycle-managed devnet with.
+func Main() cliapp.LifecycleAction {
+	return func(cliCtx *cli.Context, closeApp context.CancelCauseFunc) (cliapp.Lifecycle, error) {
+		if err := flags.CheckRequired(cliCtx); err != nil {
+			return nil, err
+		}
+		cfg, err := flags.ConfigFromCLI(cliCtx)
+		if err != nil {
+			return nil, err
+		}
+		if err := cfg.Check(); err != nil {
+			return nil, fmt.Errorf("invalid CLI flags: %w", err)
+		}
+
+		l := oplog.NewLogger(oplog.AppOut(cliCtx), cfg.LogConfig)
+		oplog.SetGlobalLogHandler(l.Handler())
+		opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.Flags, l)
+
+		l.Info("Initializing devnet", "dir", cfg.DevnetDir)
+		return New(l, cfg)
+	}
+}
@@ -0,0 +1,256 @@
+package devnet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-chain-ops/foundry"
+	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
+	"github.com/ethereum-optimism/optimism/op-devnet/config"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+// deployerSender is the account the L1 contracts are deployed from. Its private key is known,
+// because the sender ends up being the owner of the ProxyAdmin safe.
+const deployerSender = "0x90F79bf6EB2c4f870365E785982E1f101E93b906"
+
+// l2AllocsModes are the L2 allocs generated by the L2Genesis script, one per supported fork.
+var l2AllocsModes = []genesis.L2AllocsMode{
+	genesis.L2AllocsDelta,
+	genesis.L2AllocsEcotone,
+	genesis.L2AllocsFjord,
+	genesis.L2AllocsGranite,
+}
+
+// paths are the locations of the files used to generate the devnet genesis.
+type paths struct {
+	contractsDir         string
+	deployConfigTemplate string
+	deployConfig         string
+	forgeDeployments     string
+	forgeL1Dump          string
+
+	allocsL1      string
+	addresses     string
+	genesisL1     string
+	genesisL2     string
+	rollupConfig  string
+	jwtSecret     string
+	l2DataDir     string
+	challengerDir string
+	blobsDir      string
+	logsDir       string
+}
+
+func newPaths(cfg *config.Config) paths {
+	contractsDir := filepath.Join(cfg.MonorepoDir, "packages", "contracts-bedrock")
+	return paths{
+		contractsDir:         contractsDir,
+		deployConfigTemplate: filepath.Join(contractsDir, "deploy-config", "devnetL1-template.json"),
+		deployConfig:         filepath.Join(contractsDir, "deploy-config", "devnetL1.json"),
+		forgeDeployments:     filepath.Join(contractsDir, "deployments", "devnetL1", ".deploy"),
+		forgeL1Dump:          filepath.Join(contractsDir, "state-dump-900.json"),
+
+		allocsL1:      filepath.Join(cfg.DevnetDir, "allocs-l1.json"),
+		addresses:     filepath.Join(cfg.DevnetDir, "addresses.json"),
+		genesisL1:     filepath.Join(cfg.DevnetDir, "genesis-l1.json"),
+		genesisL2:     filepath.Join(cfg.DevnetDir, "genesis-l2.json"),
+		rollupConfig:  filepath.Join(cfg.DevnetDir, "rollup.json"),
+		jwtSecret:     filepath.Join(cfg.DevnetDir, "jwt-secret.txt"),
+		l2DataDir:     filepath.Join(cfg.DevnetDir, "l2-geth"),
+		challengerDir: filepath.Join(cfg.DevnetDir, "challenger"),
+		blobsDir:      filepath.Join(cfg.DevnetDir, "blobs"),
+		logsDir:       filepath.Join(cfg.DevnetDir, "logs"),
+	}
+}
+
+func (p paths) allocsL2(mode genesis.L2AllocsMode) string {
+	return filepath.Join(filepath.Dir(p.allocsL1), "allocs-l2-"+string(mode)+".json")
+}
+
+// Genesis is the generated genesis of the devnet chains.
+type Genesis struct {
+	DeployConfig  *genesis.DeployConfig
+	L1Deployments *genesis.L1Deployments
+	L1            *core.Genesis
+	L2            *core.Genesis
+	Rollup        *rollup.Config
+}
+
+// GenerateGenesis deploys the L1 contracts into the L1 allocs and generates the L1 and L2 genesis and the rollup config.
+// The forge allocs are reused when they already exist, unless cfg.RegenerateAllocs is set or the L2OutputOracle is used.
+// All outputs are written to cfg.DevnetDir.
+func GenerateGenesis(ctx context.Context, logger log.Logger, cfg *config.Config) (*Genesis, error) {
+	p := newPaths(cfg)
+	if err := os.MkdirAll(cfg.DevnetDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create devnet dir: %w", err)
+	}
+
+	if err := writeDeployConfig(p, cfg.L2OO); err != nil {
+		return nil, err
+	}
+	regenerate := cfg.RegenerateAllocs || cfg.L2OO
+	if regenerate || !exists(p.allocsL1) || !exists(p.addresses) {
+		if err := generateL1Allocs(ctx, logger, p); err != nil {
+			return nil, err
+		}
+		// The L2 allocs depend on the L1 deployment addresses.
+		regenerate = true
+	} else {
+		logger.Info("Re-using existing L1 allocs", "path", p.allocsL1)
+	}
+	if regenerate || !exists(p.allocsL2(l2AllocsModes[len(l2AllocsModes)-1])) {
+		if err := generateL2Allocs(ctx, logger, p); err != nil {
+			return nil, err
+		}
+	} else {
+		logger.Info("Re-using existing L2 allocs")
+	}
+
+	deployConfig, err := genesis.NewDeployConfig(p.deployConfig)
+	if err != nil {
+		return nil, err
+	}
+	deployments, err := genesis.NewL1Deployments(p.addresses)
+	if err != nil {
+		return nil, err
+	}
+	l1Allocs, err := foundry.LoadForgeAllocs(p.allocsL1)
+	if err != nil {
+		return nil, err
+	}
+	deployConfig.L1GenesisBlockTimestamp = hexutil.Uint64(time.Now().Unix())
+	deployConfig.SetDeployments(deployments)
+	if err := deployConfig.Check(logger); err != nil {
+		return nil, fmt.Errorf("invalid deploy config: %w", err)
+	}
+
+	l1Genesis, err := genesis.BuildL1DeveloperGenesis(deployConfig, l1Allocs, deployments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build L1 genesis: %w", err)
+	}
+	l1Block := l1Genesis.ToBlock()
+
+	allocsMode := deployConfig.AllocMode(l1Block.Time())
+	logger.Info("Generating L2 genesis", "l2_allocs_mode", string(allocsMode))
+	l2Allocs, err := foundry.LoadForgeAllocs(p.allocsL2(allocsMode))
+	if err != nil {
+		return nil, err
+	}
+	l2Genesis, err := genesis.BuildL2Genesis(deployConfig, l2Allocs, l1Block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build L2 genesis: %w", err)
+	}
+	l2Block := l2Genesis.ToBlock()
+	rollupConfig, err := deployConfig.RollupConfig(l1Block, l2Block.Hash(), l2Block.NumberU64())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rollup config: %w", err)
+	}
+	rollupConfig.ProtocolVersionsAddress = deployments.ProtocolVersionsProxy
+	if err := rollupConfig.Check(); err != nil {
+		return nil, fmt.Errorf("invalid rollup config: %w", err)
+	}
+
+	if err := jsonutil.WriteJSON(p.genesisL1, l1Genesis, 0o666); err != nil {
+		return nil, err
+	}
+	if err := jsonutil.WriteJSON(p.genesisL2, l2Genesis, 0o666); err != nil {
+		return nil, err
+	}
+	if err := jsonutil.WriteJSON(p.rollupConfig, rollupConfig, 0o666); err != nil {
+		return nil, err
+	}
+	return &Genesis{
+		DeployConfig:  deployConfig,
+		L1Deployments: deployments,
+		L1:            l1Genesis,
+		L2:            l2Genesis,
+		Rollup:        rollupConfig,
+	}, nil
+}
+
+// writeDeployConfig writes the devnet deploy config from the template, applying the devnet variant settings.
+func writeDeployConfig(p paths, l2oo bool) error {
+	data, err := os.ReadFile(p.deployConfigTemplate)
+	if err != nil {
+		return fmt.Errorf("failed to read deploy config template: %w", err)
+	}
+	// Use a generic map so that the config is written back without adding default values.
+	var deployConfig map[string]any
+	if err := json.Unmarshal(data, &deployConfig); err != nil {
+		return fmt.Errorf("failed to parse deploy config template: %w", err)
+	}
+	if l2oo {
+		deployConfig["useFaultProofs"] = false
+	}
+	return jsonutil.WriteJSON(p.deployConfig, deployConfig, 0o666)
+}
+
+func generateL1Allocs(ctx context.Context, logger log.Logger, p paths) error {
+	logger.Info("Generating L1 genesis allocs")
+	err := runForge(ctx, logger, p.contractsDir, []string{"DEPLOYMENT_OUTFILE=" + p.forgeDeployments, "DEPLOY_CONFIG_PATH=" + p.deployConfig},
+		"script", "scripts/deploy/Deploy.s.sol:Deploy", "--sig", "runWithStateDump()", "--sender", deployerSender)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(p.forgeL1Dump, p.allocsL1); err != nil {
+		return fmt.Errorf("failed to move L1 allocs: %w", err)
+	}
+	return copyFile(p.forgeDeployments, p.addresses)
+}
+
+func generateL2Allocs(ctx context.Context, logger log.Logger, p paths) error {
+	logger.Info("Generating L2 genesis allocs", "deployments", p.forgeDeployments)
+	err := runForge(ctx, logger, p.contractsDir, []string{"CONTRACT_ADDRESSES_PATH=" + p.forgeDeployments, "DEPLOY_CONFIG_PATH=" + p.deployConfig},
+		"script", "scripts/L2Genesis.s.sol:L2Genesis", "--sig", "runWithAllUpgrades()")
+	if err != nil {
+		return err
+	}
+	for _, mode := range l2AllocsModes {
+		src := filepath.Join(p.contractsDir, "state-dump-901-"+string(mode)+".json")
+		if err := os.Rename(src, p.allocsL2(mode)); err != nil {
+			return fmt.Errorf("failed to move %v L2 allocs: %w", mode, err)
+		}
+		logger.Info("Generated L2 allocs", "path", p.allocsL2(mode))
+	}
+	return nil
+}
+
+func runForge(ctx context.Context, logger log.Logger, dir string, env []string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "forge", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		logger.Error("Forge failed", "args", args, "output", string(out))
+		return fmt.Errorf("forge %v failed: %w", args[1], err)
+	}
+	return nil
+}
+
+func copyFile(src, dst string) error {
+	data, err := os.ReadFile(src)
+	if err != nil {
+		return fmt.Errorf("failed to read %v: %w", src, err)
+	}
+	if err := os.WriteFile(dst, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %v: %w", dst, err)
+	}
+	return nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, os.ErrNotExist)
+}
@@ -0,0 +1,76 @@
+package devnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const healthPollInterval = 500 * time.Millisecond
+
+// healthCheck returns an error while the service is not healthy.
+type healthCheck func(ctx context.Context) error
+
+// rpcHealthCheck checks that the JSON-RPC server at url responds successfully to the given method, called without params.
+func rpcHealthCheck(url string, method string) healthCheck {
+	return func(ctx context.Context) error {
+		client, err := rpc.DialContext(ctx, url)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		var result json.RawMessage
+		return client.CallContext(ctx, &result, method)
+	}
+}
+
+// httpHealthCheck checks that a GET request to url returns a 200 status.
+// op-service RPC servers serve this on /healthz.
+func httpHealthCheck(url string) healthCheck {
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("unexpected status %v", resp.Status)
+		}
+		return nil
+	}
+}
+
+// waitHealthy polls check until it passes, the timeout expires or, if proc is not nil, the process exits.
+func waitHealthy(ctx context.Context, logger log.Logger, name string, proc *process, timeout time.Duration, check healthCheck) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(healthPollInterval)
+	defer ticker.Stop()
+	var lastErr error
+	for {
+		if proc != nil {
+			if err := proc.Exited(); err != nil {
+				return err
+			}
+		}
+		if lastErr = check(ctx); lastErr == nil {
+			logger.Info("Service healthy", "service", name)
+			return nil
+		}
+		logger.Debug("Waiting for service", "service", name, "err", lastErr)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%v not healthy: %w (last error: %w)", name, ctx.Err(), lastErr)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestHTTPHealthCheck(t *testing.T) {
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	check := httpHealthCheck(srv.URL)
+	require.Error(t, check(context.Background()))
+	healthy.Store(true)
+	require.NoError(t, check(context.Background()))
+}
+
+func TestWaitHealthy(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelDebug)
+
+	t.Run("EventuallyHealthy", func(t *testing.T) {
+		calls := 0
+		err := waitHealthy(context.Background(), logger, "test", nil, time.Minute, func(ctx context.Context) error {
+			calls++
+			if calls < 3 {
+				return errors.New("not yet")
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		notReady := errors.New("not ready")
+		err := waitHealthy(context.Background(), logger, "test", nil, 10*time.Millisecond, func(ctx context.Context) error {
+			return notReady
+		})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorIs(t, err, notReady)
+	})
+
+	t.Run("ProcessExited", func(t *testing.T) {
+		proc, err := startProcess(logger, t.TempDir(), "false", "false")
+		require.NoError(t, err)
+		<-proc.done
+		err = waitHealthy(context.Background(), logger, "test", proc, time.Minute, func(ctx context.Context) error {
+			return errors.New("never healthy")
+		})
+		require.ErrorContains(t, err, "false exited")
+	})
+}
+
+func TestStopProcess(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	proc, err := startProcess(logger, t.TempDir(), "sleep", "sleep", "60")
+	require.NoError(t, err)
+	require.NoError(t, proc.Exited())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, proc.Stop(ctx))
+	require.Error(t, proc.Exited())
+}
@@ -0,0 +1,100 @@
+package devnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// process is a service binary run as a child process, with its output written to a log file.
+type process struct {
+	name    string
+	log     log.Logger
+	cmd     *exec.Cmd
+	logFile *os.File
+
+	done chan struct{}
+	err  error
+}
+
+func startProcess(logger log.Logger, logsDir string, name string, bin string, args ...string) (*process, error) {
+	if err := os.MkdirAll(logsDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create logs dir: %w", err)
+	}
+	logPath := filepath.Join(logsDir, name+".log")
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %v log file: %w", name, err)
+	}
+	cmd := exec.Command(bin, args...)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	if err := cmd.Start(); err != nil {
+		_ = logFile.Close()
+		return nil, fmt.Errorf("failed to start %v: %w", name, err)
+	}
+	p := &process{
+		name:    name,
+		log:     logger.New("process", name),
+		cmd:     cmd,
+		logFile: logFile,
+		done:    make(chan struct{}),
+	}
+	p.log.Info("Started process", "pid", cmd.Process.Pid, "logs", logPath)
+	go p.wait()
+	return p, nil
+}
+
+func (p *process) wait() {
+	p.err = p.cmd.Wait()
+	_ = p.logFile.Close()
+	close(p.done)
+	if p.err != nil {
+		p.log.Warn("Process exited", "err", p.err)
+	} else {
+		p.log.Info("Process exited")
+	}
+}
+
+// Exited returns a non-nil error if the process is no longer running.
+func (p *process) Exited() error {
+	select {
+	case <-p.done:
+		if p.err != nil {
+			return fmt.Errorf("%v exited: %w", p.name, p.err)
+		}
+		return fmt.Errorf("%v exited", p.name)
+	default:
+		return nil
+	}
+}
+
+// Stop interrupts the process and waits for it to exit, killing it if ctx is done first.
+func (p *process) Stop(ctx context.Context) error {
+	select {
+	case <-p.done:
+		return nil
+	default:
+	}
+	p.log.Info("Stopping process")
+	if err := p.cmd.Process.Signal(syscall.SIGINT); err != nil && !errors.Is(err, os.ErrProcessDone) {
+		return fmt.Errorf("failed to interrupt %v: %w", p.name, err)
+	}
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.log.Warn("Process did not stop in time, killing it")
+		if err := p.cmd.Process.Kill(); err != nil && !errors.Is(err, os.ErrProcessDone) {
+			return fmt.Errorf("failed to kill %v: %w", p.name, err)
+		}
+		<-p.done
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,150 @@
+package flags
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-devnet/config"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const EnvVarPrefix = "OP_DEVNET"
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(EnvVarPrefix, name)
+}
+
+var (
+	MonorepoDirFlag = &cli.PathFlag{
+		Name:    "monorepo-dir",
+		Usage:   "Path to the monorepo root. Defaults to the monorepo containing the working directory.",
+		EnvVars: prefixEnvVars("MONOREPO_DIR"),
+	}
+	DevnetDirFlag = &cli.PathFlag{
+		Name:    "devnet-dir",
+		Usage:   "Directory to write genesis files, chain data and service logs to. Defaults to <monorepo-dir>/.devnet",
+		EnvVars: prefixEnvVars("DEVNET_DIR"),
+	}
+	OpGethBinFlag = &cli.StringFlag{
+		Name:    "op-geth-bin",
+		Usage:   "Path to the op-geth binary",
+		EnvVars: prefixEnvVars("OP_GETH_BIN"),
+		Value:   "geth",
+	}
+	OpNodeBinFlag = &cli.PathFlag{
+		Name:    "op-node-bin",
+		Usage:   "Path to the op-node binary. Defaults to <monorepo-dir>/op-node/bin/op-node",
+		EnvVars: prefixEnvVars("OP_NODE_BIN"),
+	}
+	OpBatcherBinFlag = &cli.PathFlag{
+		Name:    "op-batcher-bin",
+		Usage:   "Path to the op-batcher binary. Defaults to <monorepo-dir>/op-batcher/bin/op-batcher",
+		EnvVars: prefixEnvVars("OP_BATCHER_BIN"),
+	}
+	OpProposerBinFlag = &cli.PathFlag{
+		Name:    "op-proposer-bin",
+		Usage:   "Path to the op-proposer binary. Defaults to <monorepo-dir>/op-proposer/bin/op-proposer",
+		EnvVars: prefixEnvVars("OP_PROPOSER_BIN"),
+	}
+	OpChallengerBinFlag = &cli.PathFlag{
+		Name:    "op-challenger-bin",
+		Usage:   "Path to the op-challenger binary. Defaults to <monorepo-dir>/op-challenger/bin/op-challenger",
+		EnvVars: prefixEnvVars("OP_CHALLENGER_BIN"),
+	}
+	L2OOFlag = &cli.BoolFlag{
+		Name:    "l2oo",
+		Usage:   "Use the L2OutputOracle instead of fault proofs. The op-challenger is not started.",
+		EnvVars: prefixEnvVars("L2OO"),
+	}
+	DisableChallengerFlag = &cli.BoolFlag{
+		Name:    "disable-challenger",
+		Usage:   "Do not start the op-challenger",
+		EnvVars: prefixEnvVars("DISABLE_CHALLENGER"),
+	}
+	RegenerateAllocsFlag = &cli.BoolFlag{
+		Name:    "regenerate-allocs",
+		Usage:   "Regenerate the L1 and L2 allocs with forge, even if they already exist",
+		EnvVars: prefixEnvVars("REGENERATE_ALLOCS"),
+	}
+	StartupTimeoutFlag = &cli.DurationFlag{
+		Name:    "startup-timeout",
+		Usage:   "Maximum time to wait for each service to become healthy",
+		EnvVars: prefixEnvVars("STARTUP_TIMEOUT"),
+		Value:   config.NewConfig("").StartupTimeout,
+	}
+)
+
+var requiredFlags = []cli.Flag{}
+
+var optionalFlags = []cli.Flag{
+	MonorepoDirFlag,
+	DevnetDirFlag,
+	OpGethBinFlag,
+	OpNodeBinFlag,
+	OpBatcherBinFlag,
+	OpProposerBinFlag,
+	OpChallengerBinFlag,
+	L2OOFlag,
+	DisableChallengerFlag,
+	RegenerateAllocsFlag,
+	StartupTimeoutFlag,
+}
+
+func init() {
+	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+
+	Flags = append(Flags, requiredFlags...)
+	Flags = append(Flags, optionalFlags...)
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func CheckRequired(ctx *cli.Context) error {
+	for _, f := range requiredFlags {
+		if !ctx.IsSet(f.Names()[0]) {
+			return fmt.Errorf("flag %s is required", f.Names()[0])
+		}
+	}
+	return nil
+}
+
+func ConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
+	monorepoDir := ctx.Path(MonorepoDirFlag.Name)
+	if monorepoDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+		monorepoDir, err = opservice.FindMonorepoRoot(cwd)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find monorepo root, specify --%s: %w", MonorepoDirFlag.Name, err)
+		}
+	}
+	cfg := config.NewConfig(monorepoDir)
+	cfg.LogConfig = oplog.ReadCLIConfig(ctx)
+	if ctx.IsSet(DevnetDirFlag.Name) {
+		cfg.DevnetDir = ctx.Path(DevnetDirFlag.Name)
+	}
+	cfg.Binaries.OpGeth = ctx.String(OpGethBinFlag.Name)
+	if ctx.IsSet(OpNodeBinFlag.Name) {
+		cfg.Binaries.OpNode = ctx.Path(OpNodeBinFlag.Name)
+	}
+	if ctx.IsSet(OpBatcherBinFlag.Name) {
+		cfg.Binaries.OpBatcher = ctx.Path(OpBatcherBinFlag.Name)
+	}
+	if ctx.IsSet(OpProposerBinFlag.Name) {
+		cfg.Binaries.OpProposer = ctx.Path(OpProposerBinFlag.Name)
+	}
+	if ctx.IsSet(OpChallengerBinFlag.Name) {
+		cfg.Binaries.OpChallenger = ctx.Path(OpChallengerBinFlag.Name)
+	}
+	cfg.L2OO = ctx.Bool(L2OOFlag.Name)
+	cfg.DisableChallenger = ctx.Bool(DisableChallengerFlag.Name)
+	cfg.RegenerateAllocs = ctx.Bool(RegenerateAllocsFlag.Name)
+	cfg.StartupTimeout = ctx.Duration(StartupTimeoutFlag.Name)
+	return cfg, nil
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	challengerFlags "github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/flags"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/extract"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	BacktestCSVFlag = &cli.PathFlag{
+		Name:      "csv",
+		Usage:     "Path to write the result of every resolved game to, as CSV.",
+		TakesFile: true,
+	}
+	BacktestL1BlockFlag = &cli.Uint64Flag{
+		Name:  "l1-block",
+		Usage: "L1 block number to load the games at. Defaults to the latest block.",
+	}
+)
+
+var ErrIncorrectResolutions = errors.New("games resolved contrary to the canonical chain")
+
+func Backtest(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	l1Rpc := ctx.String(flags.L1EthRpcFlag.Name)
+	if l1Rpc == "" {
+		return fmt.Errorf("missing %v", flags.L1EthRpcFlag.Name)
+	}
+	rollupRpc := ctx.String(flags.RollupRpcFlag.Name)
+	if rollupRpc == "" {
+		return fmt.Errorf("missing %v", flags.RollupRpcFlag.Name)
+	}
+	factoryAddr, err := challengerFlags.FactoryAddress(ctx)
+	if err != nil {
+		return err
+	}
+	var ignoredGames []common.Address
+	for _, addrStr := range ctx.StringSlice(flags.IgnoredGamesFlag.Name) {
+		game, err := opservice.ParseAddress(addrStr)
+		if err != nil {
+			return fmt.Errorf("invalid ignored game address: %w", err)
+		}
+		ignoredGames = append(ignoredGames, game)
+	}
+	maxConcurrency := ctx.Uint(flags.MaxConcurrencyFlag.Name)
+	if maxConcurrency == 0 {
+		return fmt.Errorf("%v must not be 0", flags.MaxConcurrencyFlag.Name)
+	}
+
+	l1Client, err := dial.DialEthClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, l1Rpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer l1Client.Close()
+	rollupClient, err := dial.DialRollupClientWithTimeout(ctx.Context, dial.DefaultDialTimeout, logger, rollupRpc)
+	if err != nil {
+		return fmt.Errorf("failed to dial rollup client: %w", err)
+	}
+	defer rollupClient.Close()
+
+	var l1Block *big.Int
+	if ctx.IsSet(BacktestL1BlockFlag.Name) {
+		l1Block = new(big.Int).SetUint64(ctx.Uint64(BacktestL1BlockFlag.Name))
+	}
+	head, err := l1Client.HeaderByNumber(ctx.Context, l1Block)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve L1 block: %w", err)
+	}
+
+	caller := batching.NewMultiCaller(l1Client.Client(), batching.DefaultBatchSize)
+	factory := contracts.NewDisputeGameFactoryContract(metrics.NoopMetrics, factoryAddr, caller)
+	creator := extract.NewGameCallerCreator(metrics.NoopMetrics, caller)
+	extractor := extract.NewExtractor(
+		logger,
+		creator.CreateContract,
+		factory.GetGamesAtOrAfter,
+		ignoredGames,
+		maxConcurrency,
+		extract.NewL1HeadBlockNumEnricher(l1Client),
+		extract.NewAgreementEnricher(logger, metrics.NoopMetrics, rollupClient),
+	)
+
+	// The game window is relative to the L1 block, so older blocks can be backtested with the same window
+	headClock := clock.NewDeterministicClock(time.Unix(int64(head.Time), 0))
+	minTimestamp := clock.MinCheckedTimestamp(headClock, ctx.Duration(flags.GameWindowFlag.Name))
+	logger.Info("Backtesting resolved games", "l1Block", head.Number, "l1Hash", head.Hash(),
+		"since", time.Unix(int64(minTimestamp), 0).UTC())
+	summary, err := mon.NewBacktest(logger, extractor.Extract).Run(ctx.Context, head.Hash(), minTimestamp)
+	if err != nil {
+		return err
+	}
+
+	if path := ctx.Path(BacktestCSVFlag.Name); path != "" {
+		if err := writeBacktestCSV(path, summary.Results); err != nil {
+			return err
+		}
+	}
+	logger.Info("Backtest complete", "resolved", len(summary.Results), "incorrect", summary.Incorrect,
+		"inProgress", summary.InProgress, "ignored", summary.Ignored, "failed", summary.Failed)
+	if summary.Incorrect > 0 {
+		return fmt.Errorf("%w: %d of %d", ErrIncorrectResolutions, summary.Incorrect, len(summary.Results))
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("failed to load %d games", summary.Failed)
+	}
+	return nil
+}
+
+func writeBacktestCSV(path string, results []mon.BacktestResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create csv file: %w", err)
+	}
+	defer f.Close()
+	if err := mon.WriteBacktestCSV(f, results); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func backtestFlags() []cli.Flag {
+	cliFlags := []cli.Flag{
+		flags.L1EthRpcFlag,
+		flags.RollupRpcFlag,
+		flags.GameFactoryAddressFlag,
+		flags.NetworkFlag,
+		flags.GameWindowFlag,
+		flags.IgnoredGamesFlag,
+		flags.MaxConcurrencyFlag,
+		BacktestCSVFlag,
+		BacktestL1BlockFlag,
+	}
+	cliFlags = append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
+	return cliFlags
+}
+
+var BacktestCommand = &cli.Command{
+	Name:  "backtest",
+	Usage: "Checks that resolved games resolved in favour of the canonical chain",
+	Description: "Loads the games resolved within the game window, and compares the outcome of each game " +
+		"to the output root reported by a trusted rollup node. " +
+		"Exits with an error if any game resolved contrary to the canonical chain.",
+	Action: Backtest,
+	Flags:  backtestFlags(),
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/flags"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/version"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/opio"
@@ -38,6 +39,7 @@ type ConfiguredLifecycle func(ctx context.Context, log log.Logger, config *confi
 
 func run(ctx context.Context, args []string, action ConfiguredLifecycle) error {
 	oplog.SetupDefaults()
+	appinfo.SetBuild(version.SimpleWithMeta, GitCommit, GitDate)
 
 	app := cli.NewApp()
 	app.Version = VersionWithMeta
@@ -45,6 +47,9 @@ func run(ctx context.Context, args []string, action ConfiguredLifecycle) error {
 	app.Name = "op-dispute-mon"
 	app.Usage = "Monitor dispute games"
 	app.Description = "Monitors output proposals and dispute games."
+	app.Commands = []*cli.Command{
+		BacktestCommand,
+	}
 	app.Action = cliapp.LifecycleCmd(func(ctx *cli.Context, close context.CancelCauseFunc) (cliapp.Lifecycle, error) {
 		logger, err := setupLogging(ctx)
 		if err != nil {
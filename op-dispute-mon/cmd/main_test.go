@@ -234,6 +234,54 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestGameFactoryLogRange(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultGameFactoryLogRange, cfg.GameFactoryLogRange)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--game-factory-log-range", "50"))
+		require.Equal(t, uint64(50), cfg.GameFactoryLogRange)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--game-factory-log-range", "0"))
+		require.Zero(t, cfg.GameFactoryLogRange)
+	})
+}
+
+func TestGameScanCursorFile(t *testing.T) {
+	t.Run("NotRequired", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.GameScanCursorFile)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--game-scan-cursor-file", "/data/cursor.json"))
+		require.Equal(t, "/data/cursor.json", cfg.GameScanCursorFile)
+	})
+}
+
+func TestClaimantLeaderboardSize(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultClaimantLeaderboardSize, cfg.ClaimantLeaderboardSize)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--claimant-leaderboard-size", "3"))
+		require.Equal(t, uint(3), cfg.ClaimantLeaderboardSize)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(
+			t,
+			"invalid value \"abc\" for flag -claimant-leaderboard-size",
+			addRequiredArgs("--claimant-leaderboard-size", "abc"))
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := dryRunWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 
@@ -30,6 +31,13 @@ const (
 
 	//DefaultMaxConcurrency is the default number of threads to use when fetching game data
 	DefaultMaxConcurrency = uint(5)
+
+	// DefaultClaimantLeaderboardSize is the default number of claimants with the most claims disagreeing with
+	// the canonical chain to report metrics for.
+	DefaultClaimantLeaderboardSize = uint(10)
+
+	// DefaultGameFactoryLogRange is the default maximum number of L1 blocks to scan for new games in a single request.
+	DefaultGameFactoryLogRange = uint64(1000)
 )
 
 // Config is a well typed config that is parsed from the CLI params.
@@ -45,8 +53,14 @@ type Config struct {
 	IgnoredGames    []common.Address // Games to exclude from monitoring
 	MaxConcurrency  uint             // Maximum number of threads to use when fetching game data
 
-	MetricsConfig opmetrics.CLIConfig
-	PprofConfig   oppprof.CLIConfig
+	GameFactoryLogRange uint64 // Maximum number of L1 blocks to scan for new games per request (0 == load games by index instead)
+	GameScanCursorFile  string // File to persist the progress of the game scan to, so restarts resume from it (empty == not persisted)
+
+	ClaimantLeaderboardSize uint // Number of claimants with the most disagreeing claims to report metrics for
+
+	MetricsConfig   opmetrics.CLIConfig
+	PprofConfig     oppprof.CLIConfig
+	AddrCheckConfig addrcheck.CLIConfig
 }
 
 func NewConfig(gameFactoryAddress common.Address, l1EthRpc string, rollupRpc string) Config {
@@ -59,6 +73,10 @@ func NewConfig(gameFactoryAddress common.Address, l1EthRpc string, rollupRpc str
 		GameWindow:      DefaultGameWindow,
 		MaxConcurrency:  DefaultMaxConcurrency,
 
+		GameFactoryLogRange: DefaultGameFactoryLogRange,
+
+		ClaimantLeaderboardSize: DefaultClaimantLeaderboardSize,
+
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
 	}
@@ -83,5 +101,8 @@ func (c Config) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return fmt.Errorf("pprof config: %w", err)
 	}
+	if err := c.AddrCheckConfig.Check(); err != nil {
+		return fmt.Errorf("address check config: %w", err)
+	}
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/config"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -16,11 +17,11 @@ import (
 )
 
 const (
-	envVarPrefix = "OP_DISPUTE_MON"
+	EnvVarPrefix = "OP_DISPUTE_MON"
 )
 
 func prefixEnvVars(name string) []string {
-	return opservice.PrefixEnvVar(envVarPrefix, name)
+	return opservice.PrefixEnvVar(EnvVarPrefix, name)
 }
 
 var (
@@ -41,7 +42,7 @@ var (
 		Usage:   "Address of the fault game factory contract.",
 		EnvVars: prefixEnvVars("GAME_FACTORY_ADDRESS"),
 	}
-	NetworkFlag      = flags.CLINetworkFlag(envVarPrefix, "")
+	NetworkFlag      = flags.CLINetworkFlag(EnvVarPrefix, "")
 	HonestActorsFlag = &cli.StringSliceFlag{
 		Name:    "honest-actors",
 		Usage:   "List of honest actors that are monitored for any claims that are resolved against them.",
@@ -71,6 +72,25 @@ var (
 		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
 		Value:   config.DefaultMaxConcurrency,
 	}
+	GameFactoryLogRangeFlag = &cli.Uint64Flag{
+		Name: "game-factory-log-range",
+		Usage: "Maximum number of L1 blocks to scan for new games in a single eth_getLogs request. " +
+			"Set to 0 to load the games in the game window by index on every update instead.",
+		EnvVars: prefixEnvVars("GAME_FACTORY_LOG_RANGE"),
+		Value:   config.DefaultGameFactoryLogRange,
+	}
+	GameScanCursorFileFlag = &cli.PathFlag{
+		Name:      "game-scan-cursor-file",
+		Usage:     "File to store the progress of the scan for new games in, so restarts resume from it instead of reloading all games in the game window.",
+		EnvVars:   prefixEnvVars("GAME_SCAN_CURSOR_FILE"),
+		TakesFile: true,
+	}
+	ClaimantLeaderboardSizeFlag = &cli.UintFlag{
+		Name:    "claimant-leaderboard-size",
+		Usage:   "Number of claimants with the most claims disagreeing with the canonical chain to report metrics for, in addition to the honest actors",
+		EnvVars: prefixEnvVars("CLAIMANT_LEADERBOARD_SIZE"),
+		Value:   config.DefaultClaimantLeaderboardSize,
+	}
 )
 
 // requiredFlags are checked by [CheckRequired]
@@ -88,12 +108,16 @@ var optionalFlags = []cli.Flag{
 	GameWindowFlag,
 	IgnoredGamesFlag,
 	MaxConcurrencyFlag,
+	GameFactoryLogRangeFlag,
+	GameScanCursorFileFlag,
+	ClaimantLeaderboardSizeFlag,
 }
 
 func init() {
-	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, addrcheck.CLIFlags(EnvVarPrefix, "")...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -161,7 +185,13 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		IgnoredGames:    ignoredGames,
 		MaxConcurrency:  maxConcurrency,
 
-		MetricsConfig: metricsConfig,
-		PprofConfig:   pprofConfig,
+		GameFactoryLogRange: ctx.Uint64(GameFactoryLogRangeFlag.Name),
+		GameScanCursorFile:  ctx.Path(GameScanCursorFileFlag.Name),
+
+		ClaimantLeaderboardSize: ctx.Uint(ClaimantLeaderboardSizeFlag.Name),
+
+		MetricsConfig:   metricsConfig,
+		PprofConfig:     pprofConfig,
+		AddrCheckConfig: addrcheck.ReadCLIConfig(ctx),
 	}, nil
 }
@@ -149,6 +149,15 @@ type HonestActorData struct {
 	WonBonds          *big.Int
 }
 
+// ClaimantData is the claims posted by a claimant in the monitored games,
+// broken down by whether they agree with the canonical chain.
+type ClaimantData struct {
+	AgreeCount    int
+	DisagreeCount int
+	AgreeBonds    *big.Int
+	DisagreeBonds *big.Int
+}
+
 type Metricer interface {
 	RecordInfo(version string)
 	RecordUp()
@@ -183,6 +192,10 @@ type Metricer interface {
 
 	RecordL2Challenges(agreement bool, count int)
 
+	RecordClaimants(claimants map[common.Address]*ClaimantData)
+
+	RecordCounterResponseTimes(avg, max time.Duration, uncountered int)
+
 	caching.Metrics
 	contractMetrics.ContractMetricer
 }
@@ -224,6 +237,11 @@ type Metrics struct {
 	failedGames                prometheus.Gauge
 	l2Challenges               prometheus.GaugeVec
 
+	claimantClaims      prometheus.GaugeVec
+	claimantBonds       prometheus.GaugeVec
+	counterResponseTime prometheus.GaugeVec
+	uncounteredClaims   prometheus.Gauge
+
 	requiredCollateral  prometheus.GaugeVec
 	availableCollateral prometheus.GaugeVec
 }
@@ -386,6 +404,34 @@ func NewMetrics() *Metrics {
 			// An l2 block number challenge with an agreement means the challenge was invalid.
 			"root_agreement",
 		}),
+		claimantClaims: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "claimant_claims",
+			Help:      "Number of claims posted by a claimant, broken down by whether they agree with the canonical chain. Only reported for the claimants with the most disagreeing claims and honest actors",
+		}, []string{
+			"claimant",
+			"agreement",
+		}),
+		claimantBonds: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "claimant_bonds",
+			Help:      "Sum of bonds (ETH) posted by a claimant, broken down by whether the claims agree with the canonical chain. Only reported for the claimants with the most disagreeing claims and honest actors",
+		}, []string{
+			"claimant",
+			"agreement",
+		}),
+		counterResponseTime: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "counter_response_time_seconds",
+			Help:      "Time taken to counter claims disagreeing with the canonical chain with an agreeing claim",
+		}, []string{
+			"stat",
+		}),
+		uncounteredClaims: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "uncountered_claims",
+			Help:      "Number of unresolved claims disagreeing with the canonical chain that have not been countered by an agreeing claim",
+		}),
 	}
 }
 
@@ -549,6 +595,24 @@ const (
 	agree      = true
 )
 
+func (m *Metrics) RecordClaimants(claimants map[common.Address]*ClaimantData) {
+	// The leaderboard changes over time, so remove claimants that dropped out of it
+	m.claimantClaims.Reset()
+	m.claimantBonds.Reset()
+	for addr, data := range claimants {
+		m.claimantClaims.WithLabelValues(addr.Hex(), "agree").Set(float64(data.AgreeCount))
+		m.claimantClaims.WithLabelValues(addr.Hex(), "disagree").Set(float64(data.DisagreeCount))
+		m.claimantBonds.WithLabelValues(addr.Hex(), "agree").Set(weiToEther(data.AgreeBonds))
+		m.claimantBonds.WithLabelValues(addr.Hex(), "disagree").Set(weiToEther(data.DisagreeBonds))
+	}
+}
+
+func (m *Metrics) RecordCounterResponseTimes(avg, max time.Duration, uncountered int) {
+	m.counterResponseTime.WithLabelValues("avg").Set(avg.Seconds())
+	m.counterResponseTime.WithLabelValues("max").Set(max.Seconds())
+	m.uncounteredClaims.Set(float64(uncountered))
+}
+
 func labelValuesFor(status GameAgreementStatus) []string {
 	asStrings := func(status string, inProgress, correct, agree bool) []string {
 		inProgressStr := "in_progress"
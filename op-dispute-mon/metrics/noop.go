@@ -49,3 +49,7 @@ func (*NoopMetricsImpl) RecordFailedGames(_ int) {}
 func (*NoopMetricsImpl) RecordBondCollateral(_ common.Address, _, _ *big.Int) {}
 
 func (*NoopMetricsImpl) RecordL2Challenges(_ bool, _ int) {}
+
+func (*NoopMetricsImpl) RecordClaimants(_ map[common.Address]*ClaimantData) {}
+
+func (*NoopMetricsImpl) RecordCounterResponseTimes(_, _ time.Duration, _ int) {}
@@ -0,0 +1,108 @@
+package mon
+
+import (
+	"cmp"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// BacktestResult is the outcome of a resolved game, checked against the trusted rollup node.
+type BacktestResult struct {
+	Game *types.EnrichedGameData
+	// Correct is true if the game resolved in favour of the canonical chain.
+	Correct bool
+}
+
+type BacktestSummary struct {
+	Results   []BacktestResult
+	Incorrect int
+	// InProgress is the number of games that are not resolved yet, and were not checked.
+	InProgress int
+	Ignored    int
+	Failed     int
+}
+
+type Backtest struct {
+	logger  log.Logger
+	extract Extract
+}
+
+func NewBacktest(logger log.Logger, extract Extract) *Backtest {
+	return &Backtest{
+		logger:  logger,
+		extract: extract,
+	}
+}
+
+// Run checks the outcome of all games resolved as of the given L1 block, that were created at or after minTimestamp.
+// A game resolved correctly if the defender won and the rollup node agrees with the root claim,
+// or if the challenger won and the rollup node disagrees with it.
+// The results are ordered by game creation time.
+func (b *Backtest) Run(ctx context.Context, blockHash common.Hash, minTimestamp uint64) (*BacktestSummary, error) {
+	games, ignored, failed, err := b.extract(ctx, blockHash, minTimestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load games: %w", err)
+	}
+	summary := &BacktestSummary{Ignored: ignored, Failed: failed}
+	for _, game := range games {
+		if game.Status == gameTypes.GameStatusInProgress {
+			summary.InProgress++
+			continue
+		}
+		result := BacktestResult{
+			Game:    game,
+			Correct: (game.Status == gameTypes.GameStatusDefenderWon) == game.AgreeWithClaim,
+		}
+		if !result.Correct {
+			summary.Incorrect++
+			b.logger.Error("Game resolved contrary to the canonical chain",
+				"game", game.Proxy, "status", game.Status, "l2BlockNum", game.L2BlockNumber,
+				"rootClaim", game.RootClaim, "expectedRootClaim", game.ExpectedRootClaim)
+		}
+		summary.Results = append(summary.Results, result)
+	}
+	slices.SortFunc(summary.Results, func(a, b BacktestResult) int {
+		if c := cmp.Compare(a.Game.Timestamp, b.Game.Timestamp); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Game.Index, b.Game.Index)
+	})
+	return summary, nil
+}
+
+var backtestCSVHeader = []string{"game", "gameType", "created", "l2BlockNum", "rootClaim", "expectedRootClaim", "status", "correct"}
+
+// WriteBacktestCSV writes the backtest results as CSV, with one row per game.
+func WriteBacktestCSV(out io.Writer, results []BacktestResult) error {
+	w := csv.NewWriter(out)
+	if err := w.Write(backtestCSVHeader); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+	for _, result := range results {
+		game := result.Game
+		if err := w.Write([]string{
+			game.Proxy.Hex(),
+			strconv.FormatUint(uint64(game.GameType), 10),
+			time.Unix(int64(game.Timestamp), 0).UTC().Format(time.RFC3339),
+			strconv.FormatUint(game.L2BlockNumber, 10),
+			game.RootClaim.Hex(),
+			game.ExpectedRootClaim.Hex(),
+			game.Status.String(),
+			strconv.FormatBool(result.Correct),
+		}); err != nil {
+			return fmt.Errorf("failed to write game %v: %w", game.Proxy, err)
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
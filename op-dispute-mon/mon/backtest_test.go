@@ -0,0 +1,102 @@
+package mon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	monTypes "github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBacktest_Run(t *testing.T) {
+	blockHash := common.Hash{0xaa}
+	games := []*monTypes.EnrichedGameData{
+		backtestGame(common.Address{0x01}, 30, gameTypes.GameStatusDefenderWon, true),
+		backtestGame(common.Address{0x02}, 10, gameTypes.GameStatusDefenderWon, false),
+		backtestGame(common.Address{0x03}, 20, gameTypes.GameStatusChallengerWon, false),
+		backtestGame(common.Address{0x04}, 15, gameTypes.GameStatusChallengerWon, true),
+		backtestGame(common.Address{0x05}, 5, gameTypes.GameStatusInProgress, false),
+	}
+	extractor := &stubBacktestExtractor{games: games, ignored: 2, failed: 1}
+	logger, logs := testlog.CaptureLogger(t, log.LevelInfo)
+	summary, err := NewBacktest(logger, extractor.Extract).Run(context.Background(), blockHash, 4)
+	require.NoError(t, err)
+	require.Equal(t, blockHash, extractor.blockHash)
+	require.Equal(t, uint64(4), extractor.minTimestamp)
+
+	require.Equal(t, 2, summary.Incorrect)
+	require.Equal(t, 1, summary.InProgress)
+	require.Equal(t, 2, summary.Ignored)
+	require.Equal(t, 1, summary.Failed)
+	require.Equal(t, []BacktestResult{
+		{Game: games[1], Correct: false},
+		{Game: games[3], Correct: false},
+		{Game: games[2], Correct: true},
+		{Game: games[0], Correct: true},
+	}, summary.Results)
+
+	levelFilter := testlog.NewLevelFilter(log.LevelError)
+	msgFilter := testlog.NewMessageFilter("Game resolved contrary to the canonical chain")
+	require.Len(t, logs.FindLogs(levelFilter, msgFilter), 2)
+}
+
+func TestBacktest_ExtractError(t *testing.T) {
+	extractor := &stubBacktestExtractor{err: errors.New("boom")}
+	_, err := NewBacktest(testlog.Logger(t, log.LevelInfo), extractor.Extract).Run(context.Background(), common.Hash{}, 0)
+	require.ErrorIs(t, err, extractor.err)
+}
+
+func TestWriteBacktestCSV(t *testing.T) {
+	game := backtestGame(common.Address{0x01}, 1700000000, gameTypes.GameStatusChallengerWon, true)
+	game.GameType = 1
+	game.L2BlockNumber = 42
+	game.RootClaim = common.Hash{0xbb}
+	game.ExpectedRootClaim = common.Hash{0xcc}
+
+	var out bytes.Buffer
+	require.NoError(t, WriteBacktestCSV(&out, []BacktestResult{{Game: game, Correct: false}}))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, strings.Join(backtestCSVHeader, ","), lines[0])
+	require.Equal(t, strings.Join([]string{
+		game.Proxy.Hex(),
+		"1",
+		"2023-11-14T22:13:20Z",
+		"42",
+		game.RootClaim.Hex(),
+		game.ExpectedRootClaim.Hex(),
+		"Challenger Won",
+		"false",
+	}, ","), lines[1])
+}
+
+func backtestGame(proxy common.Address, timestamp uint64, status gameTypes.GameStatus, agree bool) *monTypes.EnrichedGameData {
+	return &monTypes.EnrichedGameData{
+		GameMetadata:   gameTypes.GameMetadata{Proxy: proxy, Timestamp: timestamp},
+		Status:         status,
+		AgreeWithClaim: agree,
+	}
+}
+
+type stubBacktestExtractor struct {
+	games   []*monTypes.EnrichedGameData
+	ignored int
+	failed  int
+	err     error
+
+	blockHash    common.Hash
+	minTimestamp uint64
+}
+
+func (s *stubBacktestExtractor) Extract(_ context.Context, blockHash common.Hash, minTimestamp uint64) ([]*monTypes.EnrichedGameData, int, int, error) {
+	s.blockHash = blockHash
+	s.minTimestamp = minTimestamp
+	return s.games, s.ignored, s.failed, s.err
+}
@@ -0,0 +1,157 @@
+package mon
+
+import (
+	"bytes"
+	"math/big"
+	"slices"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+type ClaimantMetrics interface {
+	RecordClaimants(claimants map[common.Address]*metrics.ClaimantData)
+	RecordCounterResponseTimes(avg, max time.Duration, uncountered int)
+}
+
+// ClaimantMonitor classifies every claim of the monitored games as agreeing or disagreeing with the canonical chain,
+// and attributes the claims to their claimants.
+// The claimants with the most disagreeing claims are reported as a leaderboard, along with the honest actors,
+// to identify persistent attackers. The time taken to counter disagreeing claims with agreeing claims is reported
+// to measure how quickly honest challengers respond.
+type ClaimantMonitor struct {
+	logger          log.Logger
+	honestActors    types.HonestActors
+	leaderboardSize int
+	metrics         ClaimantMetrics
+}
+
+func NewClaimantMonitor(logger log.Logger, honestActors types.HonestActors, leaderboardSize uint, metrics ClaimantMetrics) *ClaimantMonitor {
+	return &ClaimantMonitor{
+		logger:          logger,
+		honestActors:    honestActors,
+		leaderboardSize: int(leaderboardSize),
+		metrics:         metrics,
+	}
+}
+
+func (c *ClaimantMonitor) CheckClaimants(games []*types.EnrichedGameData) {
+	claimants := make(map[common.Address]*metrics.ClaimantData)
+	var totalResponseTime, maxResponseTime time.Duration
+	responses := 0
+	uncountered := 0
+	for _, game := range games {
+		children := make(map[int][]*types.EnrichedClaim)
+		for i := range game.Claims {
+			claim := &game.Claims[i]
+			if !claim.IsRoot() {
+				children[claim.ParentContractIndex] = append(children[claim.ParentContractIndex], claim)
+			}
+		}
+		for i := range game.Claims {
+			claim := &game.Claims[i]
+			data, ok := claimants[claim.Claimant]
+			if !ok {
+				data = newClaimantData()
+				claimants[claim.Claimant] = data
+			}
+			if agreesWithCanonicalChain(game, &claim.Claim) {
+				data.AgreeCount++
+				data.AgreeBonds.Add(data.AgreeBonds, claim.Bond)
+				continue
+			}
+			data.DisagreeCount++
+			data.DisagreeBonds.Add(data.DisagreeBonds, claim.Bond)
+			if c.honestActors.Contains(claim.Claimant) {
+				c.logger.Error("Honest actor posted claim disagreeing with the canonical chain",
+					"game", game.Proxy, "honestActor", claim.Claimant, "claimContractIndex", claim.ContractIndex)
+			}
+			responseTime, ok := counterResponseTime(game, claim, children[claim.ContractIndex])
+			if !ok {
+				if !claim.Resolved {
+					uncountered++
+				}
+				continue
+			}
+			responses++
+			totalResponseTime += responseTime
+			maxResponseTime = max(maxResponseTime, responseTime)
+		}
+	}
+	var avgResponseTime time.Duration
+	if responses > 0 {
+		avgResponseTime = totalResponseTime / time.Duration(responses)
+	}
+	c.metrics.RecordClaimants(c.leaderboard(claimants))
+	c.metrics.RecordCounterResponseTimes(avgResponseTime, maxResponseTime, uncountered)
+}
+
+// leaderboard returns the claimants with the most claims disagreeing with the canonical chain, up to the
+// leaderboard size, along with all honest actors.
+func (c *ClaimantMonitor) leaderboard(claimants map[common.Address]*metrics.ClaimantData) map[common.Address]*metrics.ClaimantData {
+	var disagreeing []common.Address
+	for addr, data := range claimants {
+		if data.DisagreeCount > 0 {
+			disagreeing = append(disagreeing, addr)
+		}
+	}
+	slices.SortFunc(disagreeing, func(a, b common.Address) int {
+		if diff := claimants[b].DisagreeCount - claimants[a].DisagreeCount; diff != 0 {
+			return diff
+		}
+		if diff := claimants[b].DisagreeBonds.Cmp(claimants[a].DisagreeBonds); diff != 0 {
+			return diff
+		}
+		return bytes.Compare(a[:], b[:])
+	})
+	board := make(map[common.Address]*metrics.ClaimantData)
+	for _, addr := range disagreeing[:min(len(disagreeing), c.leaderboardSize)] {
+		board[addr] = claimants[addr]
+	}
+	for actor := range c.honestActors {
+		if data, ok := claimants[actor]; ok {
+			board[actor] = data
+		} else {
+			board[actor] = newClaimantData()
+		}
+	}
+	return board
+}
+
+// agreesWithCanonicalChain returns true if the claim supports the side of the game that agrees with the canonical
+// chain. Claims at even depths support the root claim, while claims at odd depths dispute it.
+// The values of the claims below the root aren't checked individually, so a claim on the agreeing side with an
+// incorrect value is still classified as agreeing.
+func agreesWithCanonicalChain(game *types.EnrichedGameData, claim *faultTypes.Claim) bool {
+	supportsRoot := claim.Position.Depth()%2 == 0
+	return supportsRoot == game.AgreeWithClaim
+}
+
+// counterResponseTime returns the time from the disagreeing claim being posted until it was first countered
+// by a claim agreeing with the canonical chain, or false if it hasn't been countered by an agreeing claim.
+func counterResponseTime(game *types.EnrichedGameData, claim *types.EnrichedClaim, children []*types.EnrichedClaim) (time.Duration, bool) {
+	var firstCounter *types.EnrichedClaim
+	for _, child := range children {
+		if !agreesWithCanonicalChain(game, &child.Claim) {
+			continue
+		}
+		if firstCounter == nil || child.Clock.Timestamp.Before(firstCounter.Clock.Timestamp) {
+			firstCounter = child
+		}
+	}
+	if firstCounter == nil {
+		return 0, false
+	}
+	return firstCounter.Clock.Timestamp.Sub(claim.Clock.Timestamp), true
+}
+
+func newClaimantData() *metrics.ClaimantData {
+	return &metrics.ClaimantData{
+		AgreeBonds:    big.NewInt(0),
+		DisagreeBonds: big.NewInt(0),
+	}
+}
@@ -0,0 +1,152 @@
+package mon
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/metrics"
+	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/types"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	proposer   = common.Address{0x01}
+	honest     = common.Address{0x02}
+	attacker   = common.Address{0x03}
+	attacker2  = common.Address{0x04}
+	claimStart = time.Unix(1000, 0)
+)
+
+func TestClaimantMonitor_ClassifyClaims(t *testing.T) {
+	monitor, cMetrics, _ := newTestClaimantMonitor(t, 10)
+	// Invalid proposal, countered by the honest actor, with the attacker defending the root
+	invalidGame := &types.EnrichedGameData{
+		GameMetadata:   gameTypes.GameMetadata{Proxy: common.Address{0xaa}},
+		AgreeWithClaim: false,
+		Claims: []types.EnrichedClaim{
+			claimAt(0, 1, -1, attacker, 10, 0),
+			claimAt(1, 2, 0, honest, 20, 5*time.Minute),
+			claimAt(2, 4, 1, attacker, 30, 7*time.Minute),
+		},
+	}
+	// Valid proposal attacked by the attacker and defended by the honest actor
+	validGame := &types.EnrichedGameData{
+		GameMetadata:   gameTypes.GameMetadata{Proxy: common.Address{0xbb}},
+		AgreeWithClaim: true,
+		Claims: []types.EnrichedClaim{
+			claimAt(0, 1, -1, proposer, 10, 0),
+			claimAt(1, 2, 0, attacker2, 20, time.Minute),
+			claimAt(2, 4, 1, honest, 30, 4*time.Minute),
+			claimAt(3, 3, 0, attacker, 40, 10*time.Minute),
+		},
+	}
+	monitor.CheckClaimants([]*types.EnrichedGameData{invalidGame, validGame})
+
+	require.Equal(t, &metrics.ClaimantData{DisagreeCount: 3, AgreeBonds: big.NewInt(0), DisagreeBonds: big.NewInt(80)}, cMetrics.claimants[attacker])
+	require.Equal(t, &metrics.ClaimantData{DisagreeCount: 1, AgreeBonds: big.NewInt(0), DisagreeBonds: big.NewInt(20)}, cMetrics.claimants[attacker2])
+	require.Equal(t, &metrics.ClaimantData{AgreeCount: 2, AgreeBonds: big.NewInt(50), DisagreeBonds: big.NewInt(0)}, cMetrics.claimants[honest])
+	require.NotContains(t, cMetrics.claimants, proposer, "should only report claimants with disagreeing claims and honest actors")
+
+	// Root of the invalid game countered after 5 minutes, the attack on the valid game after 3 minutes.
+	require.Equal(t, 4*time.Minute, cMetrics.avgResponse)
+	require.Equal(t, 5*time.Minute, cMetrics.maxResponse)
+	// The attacker's claims at index 2 in the invalid game and index 3 in the valid game aren't countered yet.
+	require.Equal(t, 2, cMetrics.uncountered)
+}
+
+func TestClaimantMonitor_Leaderboard(t *testing.T) {
+	monitor, cMetrics, _ := newTestClaimantMonitor(t, 1)
+	game := &types.EnrichedGameData{
+		AgreeWithClaim: true,
+		Claims: []types.EnrichedClaim{
+			claimAt(0, 1, -1, proposer, 10, 0),
+			claimAt(1, 2, 0, attacker2, 20, time.Minute),
+			claimAt(2, 3, 0, attacker, 20, time.Minute),
+			claimAt(3, 4, 1, honest, 30, 2*time.Minute),
+			claimAt(4, 8, 3, attacker, 20, 3*time.Minute),
+		},
+	}
+	monitor.CheckClaimants([]*types.EnrichedGameData{game})
+
+	require.Len(t, cMetrics.claimants, 2, "should only report the top attacker and honest actor")
+	require.Equal(t, 2, cMetrics.claimants[attacker].DisagreeCount)
+	require.Equal(t, 1, cMetrics.claimants[honest].AgreeCount)
+}
+
+func TestClaimantMonitor_HonestActorsAlwaysReported(t *testing.T) {
+	monitor, cMetrics, _ := newTestClaimantMonitor(t, 10)
+	monitor.CheckClaimants(nil)
+	require.Equal(t, map[common.Address]*metrics.ClaimantData{
+		honest: {AgreeBonds: big.NewInt(0), DisagreeBonds: big.NewInt(0)},
+	}, cMetrics.claimants)
+	require.Zero(t, cMetrics.avgResponse)
+	require.Zero(t, cMetrics.maxResponse)
+	require.Zero(t, cMetrics.uncountered)
+}
+
+func TestClaimantMonitor_HonestActorDisagrees(t *testing.T) {
+	monitor, cMetrics, logs := newTestClaimantMonitor(t, 10)
+	game := &types.EnrichedGameData{
+		GameMetadata:   gameTypes.GameMetadata{Proxy: common.Address{0xaa}},
+		AgreeWithClaim: true,
+		Claims: []types.EnrichedClaim{
+			claimAt(0, 1, -1, proposer, 10, 0),
+			claimAt(1, 2, 0, honest, 20, time.Minute),
+		},
+	}
+	monitor.CheckClaimants([]*types.EnrichedGameData{game})
+	require.Equal(t, 1, cMetrics.claimants[honest].DisagreeCount)
+
+	l := logs.FindLog(
+		testlog.NewLevelFilter(log.LevelError),
+		testlog.NewMessageFilter("Honest actor posted claim disagreeing with the canonical chain"))
+	require.NotNil(t, l)
+	require.Equal(t, common.Address{0xaa}, l.AttrValue("game"))
+	require.Equal(t, honest, l.AttrValue("honestActor"))
+	require.Equal(t, int64(1), l.AttrValue("claimContractIndex"))
+}
+
+func claimAt(idx int, gindex int64, parentIdx int, claimant common.Address, bond int64, postedAfter time.Duration) types.EnrichedClaim {
+	return types.EnrichedClaim{
+		Claim: faultTypes.Claim{
+			ClaimData: faultTypes.ClaimData{
+				Position: faultTypes.NewPositionFromGIndex(big.NewInt(gindex)),
+				Bond:     big.NewInt(bond),
+			},
+			Claimant:            claimant,
+			Clock:               faultTypes.Clock{Timestamp: claimStart.Add(postedAfter)},
+			ContractIndex:       idx,
+			ParentContractIndex: parentIdx,
+		},
+	}
+}
+
+func newTestClaimantMonitor(t *testing.T, leaderboardSize uint) (*ClaimantMonitor, *stubClaimantMetrics, *testlog.CapturingHandler) {
+	logger, handler := testlog.CaptureLogger(t, log.LvlInfo)
+	cMetrics := &stubClaimantMetrics{}
+	honestActors := types.NewHonestActors([]common.Address{honest})
+	return NewClaimantMonitor(logger, honestActors, leaderboardSize, cMetrics), cMetrics, handler
+}
+
+type stubClaimantMetrics struct {
+	claimants   map[common.Address]*metrics.ClaimantData
+	avgResponse time.Duration
+	maxResponse time.Duration
+	uncountered int
+}
+
+func (s *stubClaimantMetrics) RecordClaimants(claimants map[common.Address]*metrics.ClaimantData) {
+	s.claimants = claimants
+}
+
+func (s *stubClaimantMetrics) RecordCounterResponseTimes(avg, max time.Duration, uncountered int) {
+	s.avgResponse = avg
+	s.maxResponse = max
+	s.uncountered = uncountered
+}
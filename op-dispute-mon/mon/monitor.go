@@ -41,6 +41,7 @@ type gameMonitor struct {
 	bonds            Bonds
 	resolutions      Resolutions
 	claims           Monitor
+	claimants        Monitor
 	withdrawals      Monitor
 	l2Challenges     Monitor
 	extract          Extract
@@ -59,6 +60,7 @@ func newGameMonitor(
 	bonds Bonds,
 	resolutions Resolutions,
 	claims Monitor,
+	claimants Monitor,
 	withdrawals Monitor,
 	l2Challenges Monitor,
 	extract Extract,
@@ -77,6 +79,7 @@ func newGameMonitor(
 		bonds:            bonds,
 		resolutions:      resolutions,
 		claims:           claims,
+		claimants:        claimants,
 		withdrawals:      withdrawals,
 		l2Challenges:     l2Challenges,
 		extract:          extract,
@@ -105,6 +108,7 @@ func (m *gameMonitor) monitorGames() error {
 	m.forecast(enrichedGames, ignored, failed)
 	m.bonds(enrichedGames)
 	m.claims(enrichedGames)
+	m.claimants(enrichedGames)
 	m.withdrawals(enrichedGames)
 	m.l2Challenges(enrichedGames)
 	timeTaken := m.clock.Since(start)
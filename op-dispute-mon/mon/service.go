@@ -18,7 +18,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/mon/extract"
 	"github.com/ethereum-optimism/optimism/op-dispute-mon/version"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/factoryscan"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/contracts"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
@@ -44,6 +46,7 @@ type Service struct {
 	game         *extract.GameCallerCreator
 	resolutions  *ResolutionMonitor
 	claims       *ClaimMonitor
+	claimants    *ClaimantMonitor
 	withdrawals  *WithdrawalMonitor
 	rollupClient *sources.RollupClient
 
@@ -109,6 +112,7 @@ func (s *Service) initFromConfig(ctx context.Context, cfg *config.Config) error
 
 func (s *Service) initClaimMonitor(cfg *config.Config) {
 	s.claims = NewClaimMonitor(s.logger, s.cl, s.honestActors, s.metrics)
+	s.claimants = NewClaimantMonitor(s.logger, s.honestActors, cfg.ClaimantLeaderboardSize, s.metrics)
 }
 
 func (s *Service) initResolutionMonitor() {
@@ -124,10 +128,15 @@ func (s *Service) initGameCallerCreator() {
 }
 
 func (s *Service) initExtractor(cfg *config.Config) {
+	getGames := s.factoryContract.GetGamesAtOrAfter
+	if cfg.GameFactoryLogRange != 0 {
+		scanner := factoryscan.NewScanner(s.logger, cfg.GameFactoryAddress, s.factoryContract, s.l1Client, cfg.GameScanCursorFile, cfg.GameFactoryLogRange)
+		getGames = scanner.GetGamesAtOrAfter
+	}
 	s.extractor = extract.NewExtractor(
 		s.logger,
 		s.game.CreateContract,
-		s.factoryContract.GetGamesAtOrAfter,
+		getGames,
 		cfg.IgnoredGames,
 		cfg.MaxConcurrency,
 		extract.NewClaimEnricher(),
@@ -163,6 +172,9 @@ func (s *Service) initL1Client(ctx context.Context, cfg *config.Config) error {
 		return fmt.Errorf("failed to dial L1: %w", err)
 	}
 	s.l1Client = l1Client
+	if err := addrcheck.Check(ctx, s.logger, l1Client, cfg.AddrCheckConfig.Apply(addrcheck.ExpectContract("DisputeGameFactory", cfg.GameFactoryAddress))...); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -228,6 +240,7 @@ func (s *Service) initMonitor(ctx context.Context, cfg *config.Config) {
 		s.bonds.CheckBonds,
 		s.resolutions.CheckResolutions,
 		s.claims.CheckClaims,
+		s.claimants.CheckClaimants,
 		s.withdrawals.CheckWithdrawals,
 		l2ChallengesMonitor.CheckL2Challenges,
 		s.extractor.Extract,
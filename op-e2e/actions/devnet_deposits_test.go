@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// TestDevnetDeposits rehearses an upgrade deployment with a devnet deposit,
+// and checks the verifier derives the same block from L1.
+func TestDevnetDeposits(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	log := testlog.Logger(t, log.LvlDebug)
+
+	deployer := common.Address{0x42, 0x10}
+	// init code of a contract with the runtime code 0x2a
+	initCode := []byte{0x60, 0x2a, 0x60, 0x00, 0x53, 0x60, 0x01, 0x60, 0x00, 0xf3}
+	depositTime := sd.RollupCfg.Genesis.L2Time + 2*sd.RollupCfg.BlockTime
+	dep := rollup.DevnetDeposit{
+		Time:   depositTime,
+		Intent: "Devnet: rehearsal deployment",
+		From:   deployer,
+		Gas:    100_000,
+		Data:   initCode,
+	}
+	sd.RollupCfg.UnsafeDevnetDeposits = []rollup.DevnetDeposit{dep}
+
+	_, _, miner, sequencer, seqEngine, verifier, _, batcher := setupReorgTestActors(t, dp, sd, log)
+	ethCl := seqEngine.EthClient()
+
+	sequencer.ActBuildL2ToTime(t, depositTime)
+	block, err := ethCl.BlockByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	require.Equal(t, depositTime, block.Time())
+
+	txs := block.Transactions()
+	require.Len(t, txs, 2, "l1 info tx + devnet deposit")
+	require.Equal(t, (&derive.UpgradeDepositSource{Intent: dep.Intent}).SourceHash(), txs[1].SourceHash())
+	receipt, err := ethCl.TransactionReceipt(context.Background(), txs[1].Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	code, err := ethCl.CodeAt(context.Background(), crypto.CreateAddress(deployer, 0), nil)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x2a}, code)
+
+	sequencer.ActL2StartBlock(t)
+	sequencer.ActL2EndBlock(t)
+	block, err = ethCl.BlockByNumber(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions(), 1, "devnet deposit is only inserted once")
+
+	batcher.ActSubmitAll(t)
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTx(sd.RollupCfg.Genesis.SystemConfig.BatcherAddr)(t)
+	miner.ActL1EndBlock(t)
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, sequencer.L2Unsafe(), verifier.L2Safe(), "verifier derives the devnet deposit")
+}
@@ -0,0 +1,174 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// L1BeaconCfg configures the slot timing and blob sidecar availability of a L1Beacon.
+type L1BeaconCfg struct {
+	// SecondsPerSlot is the duration of a beacon slot.
+	SecondsPerSlot uint64
+	// AvailabilityDelay is the number of slots after the slot of a block before its blob sidecars are served,
+	// e.g. to model a beacon node that lags behind the execution layer.
+	AvailabilityDelay uint64
+	// RetentionSlots is the number of slots after the slot of a block that its blob sidecars are served for,
+	// before they are pruned. Zero disables pruning.
+	RetentionSlots uint64
+}
+
+// DefaultL1BeaconCfg serves blob sidecars immediately,
+// and keeps them for MIN_EPOCHS_FOR_BLOB_SIDECARS_REQUESTS epochs of 32 slots, like mainnet.
+func DefaultL1BeaconCfg() *L1BeaconCfg {
+	return &L1BeaconCfg{
+		SecondsPerSlot:    12,
+		AvailabilityDelay: 0,
+		RetentionSlots:    4096 * 32,
+	}
+}
+
+// L1Beacon is a fake beacon chain that serves the blob sidecars of the blocks built by a L1Miner.
+// Time on the beacon chain follows the blocks of the miner, and can be advanced further to model missed slots.
+// Blob sidecars are only served within their availability window, so blob fetching can be tested deterministically,
+// including the behavior when sidecars are not available yet or have expired.
+type L1Beacon struct {
+	log log.Logger
+	cfg L1BeaconCfg
+
+	genesisTime uint64
+	// currentSlot is the latest slot the beacon chain has progressed to
+	currentSlot uint64
+	slots       map[uint64][]*eth.BlobSidecar
+}
+
+var (
+	_ derive.L1BlobsFetcher   = (*L1Beacon)(nil)
+	_ prefetcher.L1BlobSource = (*L1Beacon)(nil)
+)
+
+// NewL1Beacon creates a L1Beacon that imports the blob sidecars of all blocks built by the miner from now on.
+func NewL1Beacon(t Testing, log log.Logger, miner *L1Miner, cfg *L1BeaconCfg) *L1Beacon {
+	if cfg.SecondsPerSlot == 0 {
+		t.Fatalf("invalid seconds per slot: %d", cfg.SecondsPerSlot)
+	}
+	genesis := miner.l1Chain.Genesis()
+	b := &L1Beacon{
+		log:         log,
+		cfg:         *cfg,
+		genesisTime: genesis.Time(),
+		slots:       make(map[uint64][]*eth.BlobSidecar),
+	}
+	b.currentSlot = b.SlotAt(miner.l1Chain.CurrentHeader().Time)
+	miner.beacon = b
+	return b
+}
+
+// SlotAt returns the slot that covers the given timestamp.
+func (b *L1Beacon) SlotAt(timestamp uint64) uint64 {
+	if timestamp < b.genesisTime {
+		return 0
+	}
+	return (timestamp - b.genesisTime) / b.cfg.SecondsPerSlot
+}
+
+// CurrentSlot returns the latest slot the beacon chain has progressed to.
+func (b *L1Beacon) CurrentSlot() uint64 {
+	return b.currentSlot
+}
+
+// ActAdvanceSlots returns an action that progresses the beacon chain by n slots, without any new blocks.
+func (b *L1Beacon) ActAdvanceSlots(n uint64) Action {
+	return func(t Testing) {
+		b.advanceTo(b.currentSlot + n)
+	}
+}
+
+// importBlock stores the blob sidecars of a newly built block, and progresses the beacon chain to its slot.
+func (b *L1Beacon) importBlock(t Testing, block *types.Block, blobSidecars []*types.BlobTxSidecar) {
+	slot := b.SlotAt(block.Time())
+	if slot < b.currentSlot {
+		t.Fatalf("block %s at slot %d is older than current beacon slot %d", block.Hash(), slot, b.currentSlot)
+	}
+	var sidecars []*eth.BlobSidecar
+	for _, sidecar := range blobSidecars {
+		for i := range sidecar.Blobs {
+			sidecars = append(sidecars, &eth.BlobSidecar{
+				Blob:          eth.Blob(sidecar.Blobs[i]),
+				Index:         eth.Uint64String(len(sidecars)),
+				KZGCommitment: eth.Bytes48(sidecar.Commitments[i]),
+				KZGProof:      eth.Bytes48(sidecar.Proofs[i]),
+			})
+		}
+	}
+	// Blocks reorged out at the same slot are replaced, like the beacon chain would.
+	b.slots[slot] = sidecars
+	b.advanceTo(slot)
+}
+
+func (b *L1Beacon) advanceTo(slot uint64) {
+	b.currentSlot = max(b.currentSlot, slot)
+	if b.cfg.RetentionSlots == 0 {
+		return
+	}
+	for s := range b.slots {
+		if b.currentSlot >= s+b.cfg.RetentionSlots {
+			b.log.Debug("Pruning blob sidecars", "slot", s, "current", b.currentSlot)
+			delete(b.slots, s)
+		}
+	}
+}
+
+// GetBlobSidecars returns the blob sidecars with the given indices of the block at the slot of ref.Time.
+// Like a real beacon node, only the time of ref is used to identify the block.
+// Sidecars that are not available yet or have been pruned are reported with a wrapped ethereum.NotFound error.
+func (b *L1Beacon) GetBlobSidecars(_ context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	if len(hashes) == 0 {
+		return []*eth.BlobSidecar{}, nil
+	}
+	slot := b.SlotAt(ref.Time)
+	if b.currentSlot < slot+b.cfg.AvailabilityDelay {
+		return nil, fmt.Errorf("blob sidecars of slot %d not available until slot %d, current slot %d: %w",
+			slot, slot+b.cfg.AvailabilityDelay, b.currentSlot, ethereum.NotFound)
+	}
+	if b.cfg.RetentionSlots != 0 && b.currentSlot >= slot+b.cfg.RetentionSlots {
+		return nil, fmt.Errorf("blob sidecars of slot %d were pruned at slot %d: %w", slot, slot+b.cfg.RetentionSlots, ethereum.NotFound)
+	}
+	sidecars, ok := b.slots[slot]
+	if !ok {
+		return nil, fmt.Errorf("no blob sidecars known for slot %d: %w", slot, ethereum.NotFound)
+	}
+	out := make([]*eth.BlobSidecar, 0, len(hashes))
+	for _, h := range hashes {
+		if h.Index >= uint64(len(sidecars)) {
+			return nil, fmt.Errorf("blob %d of slot %d is not known: %w", h.Index, slot, ethereum.NotFound)
+		}
+		out = append(out, sidecars[h.Index])
+	}
+	return out, nil
+}
+
+// GetBlobs returns the blobs with the given indexed hashes of the block at the slot of ref.Time.
+// The versioned hash of each blob is checked against the commitment in its sidecar.
+func (b *L1Beacon) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	sidecars, err := b.GetBlobSidecars(ctx, ref, hashes)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*eth.Blob, len(hashes))
+	for i, h := range hashes {
+		if hash := eth.KZGToVersionedHash(kzg4844.Commitment(sidecars[i].KZGCommitment)); hash != h.Hash {
+			return nil, fmt.Errorf("expected hash %s for blob at index %d but got %s", h.Hash, h.Index, hash)
+		}
+		out[i] = &sidecars[i].Blob
+	}
+	return out, nil
+}
@@ -0,0 +1,146 @@
+package actions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	batcherFlags "github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// setupL1BeaconTest submits a blob batch to L1, and returns the L1 block that includes it.
+func setupL1BeaconTest(t Testing, log log.Logger, cfg *L1BeaconCfg) (*e2eutils.SetupData, *L1Miner, *L1Beacon, *L2Sequencer, eth.L1BlockRef, []eth.IndexedBlobHash) {
+	dp := e2eutils.MakeDeployParams(t, defaultRollupTestParams)
+	genesisActivation := hexutil.Uint64(0)
+	dp.DeployConfig.L1CancunTimeOffset = &genesisActivation
+	dp.DeployConfig.L2GenesisCanyonTimeOffset = &genesisActivation
+	dp.DeployConfig.L2GenesisDeltaTimeOffset = &genesisActivation
+	dp.DeployConfig.L2GenesisEcotoneTimeOffset = &genesisActivation
+
+	sd := e2eutils.Setup(t, dp, defaultAlloc)
+	miner, seqEngine, sequencer := setupSequencerTest(t, sd, log)
+	beacon := NewL1Beacon(t, log.New("role", "l1-beacon"), miner, cfg)
+	miner.ActL1SetFeeRecipient(common.Address{'A'})
+	sequencer.ActL2PipelineFull(t)
+	batcher := setupBatcher(t, log, sd, dp, miner, sequencer, seqEngine, batcherFlags.BlobsType)
+
+	miner.ActEmptyBlock(t)
+	miner.ActL1SafeNext(t)
+	miner.ActL1FinalizeNext(t)
+	sequencer.ActL1HeadSignal(t)
+	sequencer.ActBuildToL1Head(t)
+
+	batcher.ActSubmitAll(t)
+	batchTx := batcher.LastSubmitted
+	require.Equal(t, uint8(types.BlobTxType), batchTx.Type(), "batch tx must be blob-tx")
+	miner.ActL1StartBlock(12)(t)
+	miner.ActL1IncludeTxByHash(batchTx.Hash())(t)
+	miner.ActL1EndBlock(t)
+
+	head := miner.l1Chain.CurrentBlock()
+	ref := eth.L1BlockRef{Hash: head.Hash(), Number: head.Number.Uint64(), ParentHash: head.ParentHash, Time: head.Time}
+	var hashes []eth.IndexedBlobHash
+	for i, h := range batchTx.BlobHashes() {
+		hashes = append(hashes, eth.IndexedBlobHash{Index: uint64(i), Hash: h})
+	}
+	return sd, miner, beacon, sequencer, ref, hashes
+}
+
+func TestL1Beacon_ServesSidecars(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	log := testlog.Logger(t, log.LevelDebug)
+	_, _, beacon, _, ref, hashes := setupL1BeaconTest(t, log, DefaultL1BeaconCfg())
+
+	require.Equal(t, beacon.SlotAt(ref.Time), beacon.CurrentSlot(), "beacon follows the miner")
+	sidecars, err := beacon.GetBlobSidecars(t.Ctx(), ref, hashes)
+	require.NoError(t, err)
+	require.Len(t, sidecars, len(hashes))
+	for i, sidecar := range sidecars {
+		require.Equal(t, hashes[i].Index, uint64(sidecar.Index))
+		require.Equal(t, hashes[i].Hash, eth.KZGToVersionedHash(kzg4844.Commitment(sidecar.KZGCommitment)))
+		require.NoError(t, eth.VerifyBlobProof(&sidecar.Blob, kzg4844.Commitment(sidecar.KZGCommitment), kzg4844.Proof(sidecar.KZGProof)))
+	}
+
+	blobs, err := beacon.GetBlobs(t.Ctx(), ref, hashes)
+	require.NoError(t, err)
+	require.Equal(t, &sidecars[0].Blob, blobs[0])
+
+	_, err = beacon.GetBlobs(t.Ctx(), ref, []eth.IndexedBlobHash{{Index: 0, Hash: common.Hash{0xaa}}})
+	require.ErrorContains(t, err, "expected hash")
+	_, err = beacon.GetBlobs(t.Ctx(), ref, []eth.IndexedBlobHash{{Index: uint64(len(hashes)), Hash: hashes[0].Hash}})
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
+
+func TestL1Beacon_AvailabilityDelay(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	log := testlog.Logger(t, log.LevelDebug)
+	cfg := DefaultL1BeaconCfg()
+	cfg.AvailabilityDelay = 2
+	sd, miner, beacon, sequencer, ref, hashes := setupL1BeaconTest(t, log, cfg)
+	_, verifier := setupVerifier(t, sd, log, miner.L1Client(t, sd.RollupCfg), beacon, &sync.Config{})
+
+	_, err := beacon.GetBlobs(t.Ctx(), ref, hashes)
+	require.ErrorIs(t, err, ethereum.NotFound)
+	require.ErrorContains(t, err, "not available")
+
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2EventsUntil(t, isMissingBlobsReset, 1000, false)
+	require.Less(t, verifier.L2Safe().Number, sequencer.L2Unsafe().Number, "cannot derive without the blobs")
+
+	beacon.ActAdvanceSlots(1)(t)
+	_, err = beacon.GetBlobs(t.Ctx(), ref, hashes)
+	require.ErrorIs(t, err, ethereum.NotFound)
+
+	beacon.ActAdvanceSlots(1)(t)
+	_, err = beacon.GetBlobs(t.Ctx(), ref, hashes)
+	require.NoError(t, err)
+
+	verifier.ActL2PipelineFull(t)
+	require.Equal(t, verifier.L2Safe(), sequencer.L2Unsafe(), "verifier syncs once the blobs are available")
+}
+
+func TestL1Beacon_Pruning(gt *testing.T) {
+	t := NewDefaultTesting(gt)
+	log := testlog.Logger(t, log.LevelDebug)
+	cfg := DefaultL1BeaconCfg()
+	cfg.RetentionSlots = 3
+	sd, miner, beacon, sequencer, ref, hashes := setupL1BeaconTest(t, log, cfg)
+
+	// Empty blocks progress the beacon chain, but the blobs are still within the retention window.
+	miner.ActEmptyBlock(t)
+	miner.ActEmptyBlock(t)
+	_, err := beacon.GetBlobs(t.Ctx(), ref, hashes)
+	require.NoError(t, err)
+
+	// Missed slots progress the beacon chain too.
+	beacon.ActAdvanceSlots(1)(t)
+	_, err = beacon.GetBlobs(t.Ctx(), ref, hashes)
+	require.ErrorIs(t, err, ethereum.NotFound)
+	require.ErrorContains(t, err, "pruned")
+
+	// A verifier that syncs after the blobs expired cannot derive the batch.
+	_, verifier := setupVerifier(t, sd, log, miner.L1Client(t, sd.RollupCfg), beacon, &sync.Config{})
+	verifier.ActL1HeadSignal(t)
+	verifier.ActL2EventsUntil(t, isMissingBlobsReset, 1000, false)
+	require.Less(t, verifier.L2Safe().Number, sequencer.L2Unsafe().Number, "cannot derive from expired blobs")
+}
+
+// isMissingBlobsReset matches the pipeline reset caused by blobs that the beacon chain does not serve.
+// The pipeline retries immediately in action tests, so it is stepped until the first reset, rather than to completion.
+func isMissingBlobsReset(ev event.Event) bool {
+	x, ok := ev.(rollup.ResetEvent)
+	return ok && errors.Is(x.Err, ethereum.NotFound)
+}
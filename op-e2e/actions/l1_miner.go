@@ -26,6 +26,8 @@ type L1Miner struct {
 	L1Replica
 
 	blobStore *e2eutils.BlobsStore
+	// optional fake beacon chain that serves the blob sidecars of the built blocks
+	beacon *L1Beacon
 
 	// L1 block building preferences
 	prefCoinbase common.Address
@@ -243,6 +245,9 @@ func (s *L1Miner) ActL1EndBlock(t Testing) {
 			s.blobStore.StoreBlob(block.Hash(), h, blob)
 		}
 	}
+	if s.beacon != nil {
+		s.beacon.importBlock(t, block, s.l1BuildingBlobSidecars)
+	}
 	_, err = s.l1Chain.InsertChain(types.Blocks{block})
 	if err != nil {
 		t.Fatalf("failed to insert block into l1 chain")
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/time/rate"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	gnode "github.com/ethereum/go-ethereum/node"
@@ -77,6 +78,7 @@ type L2API interface {
 	// GetProof returns a proof of the account, it may return a nil result without error if the address was not found.
 	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
 	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
 }
 
 type safeDB interface {
@@ -130,7 +132,7 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, blobsSrc deri
 
 	testActionEmitter := sys.Register("test-action", nil, opts)
 
-	syncStatusTracker := status.NewStatusTracker(log, metrics)
+	syncStatusTracker := status.NewStatusTracker(log, metrics, cfg)
 	sys.Register("status", syncStatusTracker, opts)
 
 	sys.Register("sync", &driver.SyncDeriver{
@@ -222,10 +224,31 @@ func (s *l2VerifierBackend) SequencerActive(ctx context.Context) (bool, error) {
 	return false, nil
 }
 
+func (s *l2VerifierBackend) SetSequencerOriginPolicy(ctx context.Context, name string) error {
+	return errors.New("changing the L2Verifier sequencer origin policy is not supported")
+}
+
+func (s *l2VerifierBackend) SequencerOriginPolicy(ctx context.Context) (string, error) {
+	return "", errors.New("the L2Verifier has no sequencer origin policy")
+}
+
 func (s *l2VerifierBackend) OverrideLeader(ctx context.Context) error {
 	return nil
 }
 
+func (s *l2VerifierBackend) SetRecoverMode(ctx context.Context, mode bool) error {
+	return errors.New("the L2Verifier has no sequencer recover mode")
+}
+
+func (s *l2VerifierBackend) RecoverMode(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+func (s *l2VerifierBackend) SimulateDerivation(ctx context.Context, data [][]byte) (*eth.DerivationSimulation, error) {
+	status := s.verifier.SyncStatus()
+	return derive.SimulateDerivation(ctx, s.verifier.rollupCfg, s.verifier.log, s.verifier.l1, s.verifier.eng, status.SafeL2, status.HeadL1, data)
+}
+
 func (s *l2VerifierBackend) OnUnsafeL2Payload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
 	return nil
 }
@@ -0,0 +1,96 @@
+package op_e2e
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	bss "github.com/ethereum-optimism/optimism/op-batcher/batcher"
+	batcherFlags "github.com/ethereum-optimism/optimism/op-batcher/flags"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// TestBatcherRecoversFromDAFailures injects data availability failures into the batcher's L1 transaction
+// submission, and checks that the batcher still gets the L2 chain safe within the sequencing window,
+// so the verifier derives the same chain the sequencer built.
+func TestBatcherRecoversFromDAFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		daType   batcherFlags.DataAvailabilityType
+		failures bss.FailureConfig
+	}{
+		{
+			name:     "BlobReverts",
+			daType:   batcherFlags.BlobsType,
+			failures: bss.FailureConfig{BlobTxReverts: 3},
+		},
+		{
+			name:     "MempoolStalls",
+			daType:   batcherFlags.CalldataType,
+			failures: bss.FailureConfig{MempoolStalls: 2, MempoolStallDuration: 6 * time.Second},
+		},
+		{
+			name:     "NonceGaps",
+			daType:   batcherFlags.CalldataType,
+			failures: bss.FailureConfig{NonceGaps: 2, NonceGapTimeout: 6 * time.Second},
+		},
+		{
+			name:   "Combined",
+			daType: batcherFlags.BlobsType,
+			failures: bss.FailureConfig{
+				BlobTxReverts:        2,
+				MempoolStalls:        1,
+				MempoolStallDuration: 4 * time.Second,
+				NonceGaps:            1,
+				NonceGapTimeout:      4 * time.Second,
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			testBatcherRecoversFromDAFailures(t, test.daType, test.failures)
+		})
+	}
+}
+
+func testBatcherRecoversFromDAFailures(t *testing.T, daType batcherFlags.DataAvailabilityType, failures bss.FailureConfig) {
+	InitParallel(t)
+
+	cfg := EcotoneSystemConfig(t, &genesisTime)
+	cfg.DataAvailabilityType = daType
+	cfg.BatcherFailures = &failures
+	// A short sequencing window, so the verifier would derive a different chain if the batcher failed to recover
+	cfg.DeployConfig.SequencerWindowSize = 20
+
+	sys, err := cfg.Start(t)
+	require.NoError(t, err, "Error starting up system")
+	defer sys.Close()
+
+	l2Seq := sys.Clients["sequencer"]
+	l2Verif := sys.Clients["verifier"]
+
+	receipt := SendL2Tx(t, cfg, l2Seq, cfg.Secrets.Alice, func(opts *TxOpts) {
+		opts.Value = big.NewInt(1_000_000_000)
+		opts.ToAddr = &common.Address{0xff, 0xff}
+	})
+
+	window := time.Duration(cfg.DeployConfig.SequencerWindowSize*cfg.DeployConfig.L1BlockTime) * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), window)
+	defer cancel()
+	_, err = wait.AndGet(ctx, time.Second, func() (*eth.SyncStatus, error) {
+		return sys.RollupClient(RoleVerif).SyncStatus(ctx)
+	}, func(status *eth.SyncStatus) bool {
+		return status.SafeL2.Number >= receipt.BlockNumber.Uint64()
+	})
+	require.NoError(t, err, "batcher did not recover within the sequencing window")
+
+	verifBlock, err := l2Verif.BlockByNumber(context.Background(), receipt.BlockNumber)
+	require.NoError(t, err)
+	require.Equal(t, receipt.BlockHash, verifBlock.Hash(), "verifier must derive the sequencer's block")
+}
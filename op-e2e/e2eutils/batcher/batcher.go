@@ -9,11 +9,14 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	gethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,3 +57,39 @@ func (h *Helper) SendLargeInvalidBatch(ctx context.Context) {
 	_, err = wait.ForReceiptOK(ctx, h.l1Client, tx.Hash())
 	require.NoError(h.t, err, "Tx should be ok")
 }
+
+// SendInvalidBlobBatchWithLargeCalldata sends a blob tx from the correct batcher key to the batcher input, with an
+// invalid batch in its blob and very large calldata. Derivation ignores the calldata of blob txs, but the calldata is
+// part of the tx, so the node of the L1 transactions trie that holds the tx is a large preimage.
+// The blob itself is loaded by field element, which never makes a large preimage. The sent tx is returned.
+func (h *Helper) SendInvalidBlobBatchWithLargeCalldata(ctx context.Context) *gethTypes.Transaction {
+	nonce, err := h.l1Client.PendingNonceAt(ctx, crypto.PubkeyToAddress(h.privKey.PublicKey))
+	require.NoError(h.t, err, "Should get next batcher nonce")
+
+	rng := rand.New(rand.NewSource(9849248))
+	var blob eth.Blob
+	require.NoError(h.t, blob.FromData(testutils.RandomData(rng, 1000)), "Should encode blob")
+	sidecar, blobHashes, err := txmgr.MakeSidecar([]*eth.Blob{&blob})
+	require.NoError(h.t, err, "Should create blob sidecar")
+
+	maxTxDataSize := 131072 // As per the Ethereum spec.
+	data := testutils.RandomData(rng, maxTxDataSize-200)
+	tx := gethTypes.MustSignNewTx(h.privKey, h.rollupCfg.L1Signer(), &gethTypes.BlobTx{
+		ChainID:    uint256.MustFromBig(h.rollupCfg.L1ChainID),
+		Nonce:      nonce,
+		GasTipCap:  uint256.NewInt(1 * params.GWei),
+		GasFeeCap:  uint256.NewInt(10 * params.GWei),
+		Gas:        5_000_000,
+		To:         h.rollupCfg.BatchInboxAddress,
+		Value:      uint256.NewInt(0),
+		Data:       data,
+		BlobFeeCap: uint256.NewInt(10 * params.GWei),
+		BlobHashes: blobHashes,
+		Sidecar:    sidecar,
+	})
+	err = h.l1Client.SendTransaction(ctx, tx)
+	require.NoError(h.t, err, "Should send large blob batch transaction")
+	_, err = wait.ForReceiptOK(ctx, h.l1Client, tx.Hash())
+	require.NoError(h.t, err, "Tx should be ok")
+	return tx
+}
@@ -1,8 +1,10 @@
 package faultproofs
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/utils"
@@ -256,6 +258,54 @@ func TestOutputCannonStepWithLargePreimage(t *testing.T) {
 	// So we don't waste time resolving the game - that's tested elsewhere.
 }
 
+func TestOutputCannonStepWithLargeBlobTxPreimage(t *testing.T) {
+	op_e2e.InitParallel(t, op_e2e.UsesCannon)
+
+	ctx := context.Background()
+	sys, _ := StartFaultDisputeSystem(t, WithBatcherStopped(), WithBlobBatches())
+	t.Cleanup(sys.Close)
+
+	// Manually send a blob tx from the correct batcher key to the batcher input, with an invalid batch in its blob
+	// and very large calldata. This forces op-program to load the blob, and the blob tx as a large preimage.
+	blobTx := sys.BatcherHelper().SendInvalidBlobBatchWithLargeCalldata(ctx)
+
+	require.NoError(t, sys.BatchSubmitter.Start(ctx))
+
+	safeHead, err := wait.ForNextSafeBlock(ctx, sys.NodeClient("sequencer"))
+	require.NoError(t, err, "Batcher should resume submitting valid batches")
+
+	l2BlockNumber := safeHead.NumberU64()
+	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys)
+	// Dispute any block - it will have to read the L1 batches to see if the block is reached
+	game := disputeGameFactory.StartOutputCannonGame(ctx, "sequencer", l2BlockNumber, common.Hash{0x01, 0xaa})
+	require.NotNil(t, game)
+	outputRootClaim := game.DisputeBlock(ctx, l2BlockNumber)
+	game.LogGameData(ctx)
+
+	game.StartChallenger(ctx, "Challenger", challenger.WithPrivKey(sys.Cfg.Secrets.Alice))
+
+	// Wait for the honest challenger to dispute the outputRootClaim.
+	// This creates a root of an execution game that we challenge by
+	// coercing a step at a preimage trace index.
+	outputRootClaim = outputRootClaim.WaitForCounterClaim(ctx)
+
+	game.LogGameData(ctx)
+	// Now the honest challenger is positioned as the defender of the execution game. We then move to challenge it
+	// to induce the large preimage load, which the challenger uploads through the large preimage proposal flow.
+	sender := sys.Cfg.Secrets.Addresses().Alice
+	largePreimageLoadCheck := game.CreateStepLargePreimageLoadCheck(ctx, sender)
+	preimageLoadCheck := func(provider types.TraceProvider, targetTraceIndex uint64) error {
+		// The large preimage is the node of the L1 transactions trie that holds the blob tx, not blob data
+		_, _, preimageData, err := provider.GetStepData(ctx, types.NewPosition(game.ExecDepth(ctx), new(big.Int).SetUint64(targetTraceIndex)))
+		require.NoError(t, err)
+		require.True(t, bytes.Contains(preimageData.GetPreimageWithoutSize(), blobTx.Data()), "large preimage should hold the blob tx calldata")
+		return largePreimageLoadCheck(provider, targetTraceIndex)
+	}
+	game.ChallengeToPreimageLoad(ctx, outputRootClaim, sys.Cfg.Secrets.Alice, utils.PreimageLargerThan(preimage.MinPreimageSize), preimageLoadCheck, false)
+	// The above method already verified the image was uploaded and step called successfully
+	// So we don't waste time resolving the game - that's tested elsewhere.
+}
+
 func TestOutputCannonStepWithPreimage(t *testing.T) {
 	op_e2e.InitParallel(t, op_e2e.UsesCannon)
 	testPreimageStep := func(t *testing.T, preimageType utils.PreimageOpt, preloadPreimage bool) {
@@ -53,7 +53,8 @@ func StartFaultDisputeSystem(t *testing.T, opts ...faultDisputeConfigOpts) (*op_
 	cfg.Nodes["sequencer"].SafeDBPath = t.TempDir()
 	cfg.DeployConfig.SequencerWindowSize = 4
 	cfg.DeployConfig.FinalizationPeriodSeconds = 2
-	cfg.SupportL1TimeTravel = true
+	// Game clocks are expired by time travel. The L2 chain travels too, so it keeps up with the L1 chain.
+	cfg.SupportL2TimeTravel = true
 	// Disable proposer creating fast games automatically - required games are manually created
 	cfg.DisableProposer = true
 	for _, opt := range opts {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/geth"
+	"github.com/ethereum-optimism/optimism/op-service/devgeth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -13,7 +14,7 @@ import (
 func TestTxGossip(t *testing.T) {
 	InitParallel(t)
 	cfg := DefaultSystemConfig(t)
-	gethOpts := []geth.GethOption{
+	gethOpts := []devgeth.GethOption{
 		geth.WithP2P(),
 	}
 	cfg.GethOptions["sequencer"] = append(cfg.GethOptions["sequencer"], gethOpts...)
@@ -46,8 +46,8 @@ import (
 	"github.com/ethereum-optimism/optimism/op-chain-ops/genesis"
 	"github.com/ethereum-optimism/optimism/op-e2e/config"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
-	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/fakebeacon"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/geth"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	rollupNode "github.com/ethereum-optimism/optimism/op-node/node"
@@ -61,8 +61,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/devgeth"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/fakebeacon"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/predeploys"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -169,7 +171,7 @@ func DefaultSystemConfig(t testing.TB) SystemConfig {
 			"batcher":  testlog.Logger(t, log.LevelInfo).New("role", "batcher"),
 			"proposer": testlog.Logger(t, log.LevelInfo).New("role", "proposer"),
 		},
-		GethOptions:            map[string][]geth.GethOption{},
+		GethOptions:            map[string][]devgeth.GethOption{},
 		P2PTopology:            nil, // no P2P connectivity by default
 		NonFinalizedProposals:  false,
 		ExternalL2Shim:         config.ExternalL2Shim,
@@ -255,7 +257,7 @@ type SystemConfig struct {
 	Premine        map[common.Address]*big.Int
 	Nodes          map[string]*rollupNode.Config // Per node config. Don't use populate rollup.Config
 	Loggers        map[string]log.Logger
-	GethOptions    map[string][]geth.GethOption
+	GethOptions    map[string][]devgeth.GethOption
 	ProposerLogger log.Logger
 	BatcherLogger  log.Logger
 
@@ -292,6 +294,9 @@ type SystemConfig struct {
 	// whether to actually use BatcherMaxL1TxSizeBytes for blobs, insteaf of max blob size
 	BatcherUseMaxTxSizeForBlobs bool
 
+	// Failures to inject into the batcher's L1 transaction submission, if any
+	BatcherFailures *bss.FailureConfig
+
 	// Singular (0) or span batches (1)
 	BatcherBatchType uint
 
@@ -301,6 +306,10 @@ type SystemConfig struct {
 	// SupportL1TimeTravel determines if the L1 node supports quickly skipping forward in time
 	SupportL1TimeTravel bool
 
+	// SupportL2TimeTravel determines if the rollup nodes schedule L2 blocks with the same clock as the L1 node,
+	// so that time travel also advances L2 block timestamps. Implies SupportL1TimeTravel.
+	SupportL2TimeTravel bool
+
 	// MaxPendingTransactions determines how many transactions the batcher will try to send
 	// concurrently. 0 means unlimited.
 	MaxPendingTransactions uint64
@@ -359,11 +368,13 @@ type System struct {
 
 	L1BeaconAPIAddr string
 
-	// TimeTravelClock is nil unless SystemConfig.SupportL1TimeTravel was set to true
+	// TimeTravelClock is nil unless SystemConfig.SupportL1TimeTravel or SystemConfig.SupportL2TimeTravel was set to true
 	// It provides access to the clock instance used by the L1 node. Calling TimeTravelClock.AdvanceBy
 	// allows tests to quickly time travel L1 into the future.
 	// Note that this time travel may occur in a single block, creating a very large difference in the Time
 	// on sequential blocks.
+	// With SystemConfig.SupportL2TimeTravel the sequencer uses the same clock, and catches up with the new time
+	// by building L2 blocks as fast as possible, rather than in a single block.
 	TimeTravelClock *clock.AdvancingClock
 
 	t      *testing.T
@@ -383,6 +394,20 @@ func (sys *System) AdvanceTime(d time.Duration) {
 	}
 }
 
+// AdvanceTimeAndWait advances the time like AdvanceTime, and waits for the L1 chain to catch up with it.
+// Time travel of less than a few minutes builds the L1 blocks in between, so the L1 chain keeps its block time.
+// Without time travel support, it waits for the time to pass instead.
+func (sys *System) AdvanceTimeAndWait(ctx context.Context, d time.Duration) {
+	if sys.TimeTravelClock == nil {
+		time.Sleep(d)
+		return
+	}
+	l1Head, err := sys.Clients["l1"].HeaderByNumber(ctx, nil)
+	require.NoError(sys.t, err)
+	sys.TimeTravelClock.AdvanceTime(d)
+	require.NoError(sys.t, wait.ForBlockWithTimestamp(ctx, sys.Clients["l1"], l1Head.Time+uint64(d.Seconds())))
+}
+
 func (sys *System) L1BeaconEndpoint() string {
 	return sys.L1BeaconAPIAddr
 }
@@ -525,7 +550,7 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	t.Cleanup(sys.Close)
 
 	c := clock.SystemClock
-	if cfg.SupportL1TimeTravel {
+	if cfg.SupportL1TimeTravel || cfg.SupportL2TimeTravel {
 		sys.TimeTravelClock = clock.NewAdvancingClock(100 * time.Millisecond)
 		c = sys.TimeTravelClock
 	}
@@ -610,6 +635,7 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 			EcotoneTime:             cfg.DeployConfig.EcotoneTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
 			FjordTime:               cfg.DeployConfig.FjordTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
 			GraniteTime:             cfg.DeployConfig.GraniteTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
+			HoloceneTime:            cfg.DeployConfig.HoloceneTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
 			InteropTime:             cfg.DeployConfig.InteropTime(uint64(cfg.DeployConfig.L1GenesisBlockTimestamp)),
 			ProtocolVersionsAddress: cfg.L1Deployments.ProtocolVersionsProxy,
 		}
@@ -632,7 +658,7 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	sys.L1BeaconAPIAddr = beaconApiAddr
 
 	// Initialize nodes
-	l1Node, l1Backend, err := geth.InitL1(cfg.DeployConfig.L1ChainID,
+	l1Node, l1Backend, err := devgeth.InitL1(cfg.DeployConfig.L1ChainID,
 		cfg.DeployConfig.L1BlockTime, cfg.L1FinalizedDistance, l1Genesis, c,
 		path.Join(cfg.BlobsPath, "l1_el"), bcn, cfg.GethOptions[RoleL1]...)
 	if err != nil {
@@ -650,7 +676,7 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	for name := range cfg.Nodes {
 		var ethClient EthInstance
 		if cfg.ExternalL2Shim == "" {
-			node, backend, err := geth.InitL2(name, big.NewInt(int64(cfg.DeployConfig.L2ChainID)), l2Genesis, cfg.JWTFilePath, cfg.GethOptions[name]...)
+			node, backend, err := devgeth.InitL2(name, big.NewInt(int64(cfg.DeployConfig.L2ChainID)), l2Genesis, cfg.JWTFilePath, cfg.GethOptions[name]...)
 			if err != nil {
 				return nil, err
 			}
@@ -773,6 +799,9 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 		nodeConfig := cfg.Nodes[name]
 		c := *nodeConfig // copy
 		c.Rollup = makeRollupConfig()
+		if cfg.SupportL2TimeTravel {
+			c.Driver.Clock = sys.TimeTravelClock
+		}
 		if err := c.LoadPersisted(cfg.Loggers[name]); err != nil {
 			return nil, err
 		}
@@ -907,6 +936,7 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 		MaxChannelDuration:       1,
 		MaxL1TxSize:              batcherMaxL1TxSizeBytes,
 		TestUseMaxTxSizeForBlobs: cfg.BatcherUseMaxTxSizeForBlobs,
+		TestFailures:             cfg.BatcherFailures,
 		TargetNumFrames:          int(batcherTargetNumFrames),
 		ApproxComprRatio:         0.4,
 		SubSafetyMargin:          4,
@@ -42,6 +42,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/devgeth"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum-optimism/optimism/op-service/predeploys"
@@ -290,6 +291,31 @@ func TestSystemE2E(t *testing.T) {
 	defer sys.Close()
 }
 
+// TestL2TimeTravel checks that advancing the system clock also advances the L2 block timestamps,
+// without having to wait for the time to pass.
+func TestL2TimeTravel(t *testing.T) {
+	InitParallel(t)
+
+	cfg := DefaultSystemConfig(t)
+	cfg.SupportL2TimeTravel = true
+
+	sys, err := cfg.Start(t)
+	require.Nil(t, err, "Error starting up system")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	l1Head, err := sys.Clients["l1"].HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	l2Head, err := sys.Clients["sequencer"].HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+
+	travel := 10 * time.Minute
+	sys.AdvanceTime(travel)
+
+	require.NoError(t, wait.ForBlockWithTimestamp(ctx, sys.Clients["l1"], l1Head.Time+uint64(travel.Seconds())))
+	require.NoError(t, wait.ForBlockWithTimestamp(ctx, sys.Clients["sequencer"], l2Head.Time+uint64(travel.Seconds())))
+}
+
 func runE2ESystemTest(t *testing.T, sys *System) {
 	log := testlog.Logger(t, log.LevelInfo)
 	log.Info("genesis", "l2", sys.RollupConfig.Genesis.L2, "l1", sys.RollupConfig.Genesis.L1, "l2_time", sys.RollupConfig.Genesis.L2Time)
@@ -413,14 +439,14 @@ func TestPendingGasLimit(t *testing.T) {
 
 	// configure the L2 gas limit to be high, and the pending gas limits to be lower for resource saving.
 	cfg.DeployConfig.L2GenesisBlockGasLimit = 30_000_000
-	cfg.GethOptions["sequencer"] = append(cfg.GethOptions["sequencer"], []geth.GethOption{
+	cfg.GethOptions["sequencer"] = append(cfg.GethOptions["sequencer"], []devgeth.GethOption{
 		func(ethCfg *ethconfig.Config, nodeCfg *node.Config) error {
 			ethCfg.Miner.GasCeil = 10_000_000
 			ethCfg.Miner.RollupComputePendingBlock = true
 			return nil
 		},
 	}...)
-	cfg.GethOptions["verifier"] = append(cfg.GethOptions["verifier"], []geth.GethOption{
+	cfg.GethOptions["verifier"] = append(cfg.GethOptions["verifier"], []devgeth.GethOption{
 		func(ethCfg *ethconfig.Config, nodeCfg *node.Config) error {
 			ethCfg.Miner.GasCeil = 9_000_000
 			ethCfg.Miner.RollupComputePendingBlock = true
@@ -490,6 +516,8 @@ func TestMissingBatchE2E(t *testing.T) {
 	cfg := DefaultSystemConfig(t)
 	// small sequence window size so the test does not take as long
 	cfg.DeployConfig.SequencerWindowSize = 4
+	// the sequencing window is expired by time travel, rather than by waiting for it
+	cfg.SupportL2TimeTravel = true
 
 	// Specifically set batch submitter balance to stop batches from being included
 	cfg.Premine[cfg.Secrets.Addresses().Batcher] = big.NewInt(0)
@@ -513,13 +541,16 @@ func TestMissingBatchE2E(t *testing.T) {
 		opts.Value = big.NewInt(1_000_000_000)
 	})
 
+	// Expire the sequencing window of the block, so the verifier derives it without the batch
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	sys.AdvanceTimeAndWait(ctx, time.Duration((sys.RollupConfig.SeqWindowSize+4)*cfg.DeployConfig.L1BlockTime)*time.Second)
+
 	// Wait until the block it was first included in shows up in the safe chain on the verifier
-	_, err = geth.WaitForBlock(receipt.BlockNumber, l2Verif, time.Duration((sys.RollupConfig.SeqWindowSize+4)*cfg.DeployConfig.L1BlockTime)*time.Second)
+	_, err = geth.WaitForBlock(receipt.BlockNumber, l2Verif, 30*time.Second)
 	require.Nil(t, err, "Waiting for block on verifier")
 
 	// Assert that the transaction is not found on the verifier
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
 	_, err = l2Verif.TransactionReceipt(ctx, receipt.TxHash)
 	require.Equal(t, ethereum.NotFound, err, "Found transaction in verifier when it should not have been included")
 
@@ -797,7 +828,7 @@ func TestSystemP2PAltSync(t *testing.T) {
 		},
 	}
 	configureL1(syncNodeCfg, sys.EthInstances["l1"], sys.L1BeaconEndpoint())
-	syncerL2Engine, _, err := geth.InitL2("syncer", big.NewInt(int64(cfg.DeployConfig.L2ChainID)), sys.L2GenesisCfg, cfg.JWTFilePath)
+	syncerL2Engine, _, err := devgeth.InitL2("syncer", big.NewInt(int64(cfg.DeployConfig.L2ChainID)), sys.L2GenesisCfg, cfg.JWTFilePath)
 	require.NoError(t, err)
 	require.NoError(t, syncerL2Engine.Start())
 
@@ -1301,6 +1332,8 @@ func StopStartBatcher(t *testing.T, cfgMod func(*SystemConfig)) {
 	InitParallel(t)
 
 	cfg := DefaultSystemConfig(t)
+	// the batches are given time to be submitted and derived by time travel, rather than by waiting
+	cfg.SupportL2TimeTravel = true
 	cfgMod(&cfg)
 	sys, err := cfg.Start(t)
 	require.NoError(t, err, "Error starting up system")
@@ -1346,7 +1379,7 @@ func StopStartBatcher(t *testing.T, cfgMod func(*SystemConfig)) {
 	require.NoError(t, err)
 
 	// wait for any old safe blocks being submitted / derived
-	time.Sleep(safeBlockInclusionDuration)
+	sys.AdvanceTimeAndWait(context.Background(), safeBlockInclusionDuration)
 
 	// get the initial sync status
 	seqStatus, err = rollupClient.SyncStatus(context.Background())
@@ -1354,7 +1387,7 @@ func StopStartBatcher(t *testing.T, cfgMod func(*SystemConfig)) {
 
 	// send another tx
 	sendTx()
-	time.Sleep(safeBlockInclusionDuration)
+	sys.AdvanceTimeAndWait(context.Background(), safeBlockInclusionDuration)
 
 	// ensure that the safe chain does not advance while the batcher is stopped
 	newSeqStatus, err = rollupClient.SyncStatus(context.Background())
@@ -1364,7 +1397,7 @@ func StopStartBatcher(t *testing.T, cfgMod func(*SystemConfig)) {
 	// start the batch submission
 	err = driver.StartBatchSubmitting()
 	require.NoError(t, err)
-	time.Sleep(safeBlockInclusionDuration)
+	sys.AdvanceTimeAndWait(context.Background(), safeBlockInclusionDuration)
 
 	// send a third tx
 	receipt = sendTx()
@@ -1576,7 +1609,7 @@ func TestRequiredProtocolVersionChangeAndHalt(t *testing.T) {
 	// configure halt in verifier op-node
 	cfg.Nodes["verifier"].RollupHalt = "major"
 	// configure halt in verifier op-geth node
-	cfg.GethOptions["verifier"] = append(cfg.GethOptions["verifier"], []geth.GethOption{
+	cfg.GethOptions["verifier"] = append(cfg.GethOptions["verifier"], []devgeth.GethOption{
 		func(ethCfg *ethconfig.Config, nodeCfg *node.Config) error {
 			ethCfg.RollupHaltOnIncompatibleProtocolVersion = "major"
 			return nil
@@ -6,6 +6,7 @@ import (
 	heartbeat "github.com/ethereum-optimism/optimism/op-heartbeat"
 	"github.com/ethereum-optimism/optimism/op-heartbeat/flags"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
@@ -19,6 +20,7 @@ var (
 
 func main() {
 	oplog.SetupDefaults()
+	appinfo.SetBuild(Version, GitCommit, GitDate)
 
 	app := cli.NewApp()
 	app.Flags = flags.Flags
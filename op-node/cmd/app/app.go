@@ -0,0 +1,88 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/checkconfig"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/genesis"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/networks"
+	"github.com/ethereum-optimism/optimism/op-node/cmd/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/metrics/doc"
+)
+
+// New creates the op-node app, running the rollup node with the given version.
+func New(version string) *cli.App {
+	app := cli.NewApp()
+	app.Version = version
+	app.Flags = cliapp.ProtectFlags(flags.Flags)
+	app.Name = "op-node"
+	app.Usage = "Optimism Rollup Node"
+	app.Description = "The Optimism Rollup Node derives L2 block inputs from L1 data and drives an external L2 Execution Engine to build a L2 chain."
+	app.Action = cliapp.LifecycleCmd(RollupNodeMain(version))
+	app.Commands = []*cli.Command{
+		{
+			Name:        "p2p",
+			Subcommands: p2p.Subcommands,
+		},
+		{
+			Name:        "genesis",
+			Subcommands: genesis.Subcommands,
+		},
+		{
+			Name:        "doc",
+			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
+		},
+		{
+			Name:        "networks",
+			Subcommands: networks.Subcommands,
+		},
+		checkconfig.Command,
+	}
+	return app
+}
+
+// RollupNodeMain returns the lifecycle action that creates the rollup node with the given version.
+func RollupNodeMain(version string) cliapp.LifecycleAction {
+	return func(ctx *cli.Context, closeApp context.CancelCauseFunc) (cliapp.Lifecycle, error) {
+		logCfg := oplog.ReadCLIConfig(ctx)
+		log := oplog.NewLogger(oplog.AppOut(ctx), logCfg)
+		oplog.SetGlobalLogHandler(log.Handler())
+		opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.Flags, log)
+		opservice.WarnOnDeprecatedFlags(ctx, flags.DeprecatedFlags, log)
+		m := metrics.NewMetrics("default")
+
+		cfg, err := opnode.NewConfig(ctx, log)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the rollup node config: %w", err)
+		}
+		cfg.Cancel = closeApp
+
+		// Only pretty-print the banner if it is a terminal log. Other log it as key-value pairs.
+		if logCfg.Format == "terminal" {
+			log.Info("rollup config:\n" + cfg.Rollup.Description(chaincfg.L2ChainIDToNetworkDisplayName))
+		} else {
+			cfg.Rollup.LogDescription(log, chaincfg.L2ChainIDToNetworkDisplayName)
+		}
+		for _, dep := range cfg.Rollup.UnsafeDevnetDeposits {
+			log.Warn("Inserting unsafe devnet deposit, this rollup config is only for devnets", "intent", dep.Intent, "time", dep.Time)
+		}
+
+		n, err := node.New(ctx.Context, cfg, log, version, m)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the rollup node: %w", err)
+		}
+
+		return n, nil
+	}
+}
@@ -0,0 +1,185 @@
+package checkconfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var (
+	ErrIncompatible  = errors.New("configs are not compatible")
+	ErrInvalidConfig = errors.New("invalid config")
+)
+
+var (
+	L2GenesisFlag = &cli.PathFlag{
+		Name:  "l2.genesis",
+		Usage: "Optional L2 genesis or chain config file to check against the rollup config. Loaded from the superchain registry if a network is selected.",
+	}
+	OtherFlag = &cli.PathFlag{
+		Name:  "other",
+		Usage: "Rollup config file to compare against",
+	}
+	OtherNetworkFlag = &cli.StringFlag{
+		Name:  "other.network",
+		Usage: "Predefined network to compare against",
+	}
+	OtherL2GenesisFlag = &cli.PathFlag{
+		Name:  "other.l2.genesis",
+		Usage: "Optional L2 genesis or chain config file of the other chain. Loaded from the superchain registry if a network is selected.",
+	}
+)
+
+var Command = &cli.Command{
+	Name:  "check-config",
+	Usage: "Diffs two rollup configs, and validates their hardfork ordering",
+	Description: "Reports all differences between the rollup config (--rollup.config or --network) and the other config " +
+		"(--other or --other.network), and classifies each as consensus-breaking or cosmetic. " +
+		"If L2 chain configs are available, these are diffed and checked against the hardforks of the rollup config as well. " +
+		"Exits with an error if the configs are invalid or any difference is consensus-breaking.",
+	Flags: []cli.Flag{
+		opflags.CLINetworkFlag(flags.EnvVarPrefix, ""),
+		opflags.CLIRollupConfigFlag(flags.EnvVarPrefix, ""),
+		L2GenesisFlag,
+		OtherFlag,
+		OtherNetworkFlag,
+		OtherL2GenesisFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+		a, err := loadChain(logger, ctx.String(opflags.NetworkFlagName), ctx.String(opflags.RollupConfigFlagName), ctx.Path(L2GenesisFlag.Name))
+		if err != nil {
+			return err
+		}
+		b, err := loadChain(logger, ctx.String(OtherNetworkFlag.Name), ctx.Path(OtherFlag.Name), ctx.Path(OtherL2GenesisFlag.Name))
+		if err != nil {
+			return fmt.Errorf("other: %w", err)
+		}
+		return Check(ctx.App.Writer, a, b)
+	},
+}
+
+// Chain is the configuration of a chain to check.
+type Chain struct {
+	Rollup *rollup.Config
+	// L2 is the execution-engine chain config, nil if not available.
+	L2 *params.ChainConfig
+}
+
+// Check writes the validation results of both chains, and the differences between them, to out.
+// Returns ErrInvalidConfig if either chain is invalid, or ErrIncompatible if there is a consensus-breaking difference.
+func Check(out io.Writer, a, b Chain) error {
+	invalid := false
+	for _, c := range []struct {
+		name  string
+		chain Chain
+	}{{"config", a}, {"other", b}} {
+		errs := validate(c.chain)
+		for _, err := range errs {
+			_, _ = fmt.Fprintf(out, "%s: %v\n", c.name, err)
+		}
+		invalid = invalid || len(errs) > 0
+	}
+
+	diffs := a.Rollup.Diff(b.Rollup)
+	if a.L2 != nil && b.L2 != nil {
+		l2Diffs, err := rollup.DiffChainConfigs(a.L2, b.L2)
+		if err != nil {
+			return err
+		}
+		for _, d := range l2Diffs {
+			d.Field = "l2." + d.Field
+			diffs = append(diffs, d)
+		}
+	}
+	for _, d := range diffs {
+		_, _ = fmt.Fprintln(out, d)
+	}
+	breaking := len(rollup.ConsensusBreaking(diffs))
+	_, _ = fmt.Fprintf(out, "%d differences, %d consensus-breaking\n", len(diffs), breaking)
+
+	if invalid {
+		return ErrInvalidConfig
+	}
+	if breaking > 0 {
+		return fmt.Errorf("%w: %d consensus-breaking differences", ErrIncompatible, breaking)
+	}
+	return nil
+}
+
+func validate(c Chain) []error {
+	var errs []error
+	// Check stops at the first error, so report the fork ordering separately.
+	forkErr := c.Rollup.CheckForkOrder()
+	if forkErr != nil {
+		errs = append(errs, fmt.Errorf("invalid hardfork order: %w", forkErr))
+	}
+	if err := c.Rollup.Check(); err != nil && (forkErr == nil || err.Error() != forkErr.Error()) {
+		errs = append(errs, fmt.Errorf("invalid rollup config: %w", err))
+	}
+	if c.L2 != nil {
+		if err := c.L2.CheckConfigForkOrder(); err != nil {
+			errs = append(errs, fmt.Errorf("invalid L2 chain config hardfork order: %w", err))
+		}
+		if err := c.Rollup.CheckChainConfig(c.L2); err != nil {
+			errs = append(errs, fmt.Errorf("L2 chain config does not match rollup config: %w", err))
+		}
+	}
+	return errs
+}
+
+func loadChain(logger log.Logger, network string, rollupConfigPath string, l2GenesisPath string) (Chain, error) {
+	if network == "" && rollupConfigPath == "" {
+		return Chain{}, errors.New("must specify a network or rollup config")
+	}
+	rollupCfg, err := opnode.NewRollupConfig(logger, network, rollupConfigPath)
+	if err != nil {
+		return Chain{}, err
+	}
+	chain := Chain{Rollup: rollupCfg}
+	if l2GenesisPath != "" {
+		chain.L2, err = loadChainConfig(l2GenesisPath)
+		if err != nil {
+			return Chain{}, err
+		}
+	} else if network != "" {
+		chain.L2, err = params.LoadOPStackChainConfig(rollupCfg.L2ChainID.Uint64())
+		if err != nil {
+			return Chain{}, fmt.Errorf("failed to load L2 chain config of network %v: %w", network, err)
+		}
+	}
+	return chain, nil
+}
+
+// loadChainConfig loads the chain config from either a genesis file, or a chain config file.
+func loadChainConfig(path string) (*params.ChainConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read L2 genesis: %w", err)
+	}
+	// Only the config of a genesis file is needed, so don't decode it as a core.Genesis with its required fields.
+	var genesis struct {
+		Config *params.ChainConfig `json:"config"`
+	}
+	if err := json.Unmarshal(data, &genesis); err == nil && genesis.Config != nil {
+		return genesis.Config, nil
+	}
+	var chainCfg params.ChainConfig
+	if err := json.Unmarshal(data, &chainCfg); err != nil {
+		return nil, fmt.Errorf("failed to decode L2 chain config: %w", err)
+	}
+	return &chainCfg, nil
+}
@@ -0,0 +1,80 @@
+package checkconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func loadNetwork(t *testing.T, network string) Chain {
+	chain, err := loadChain(testlog.Logger(t, log.LevelInfo), network, "", "")
+	require.NoError(t, err)
+	require.NotNil(t, chain.L2)
+	return chain
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("same network", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, Check(&out, loadNetwork(t, "op-sepolia"), loadNetwork(t, "op-sepolia")))
+		require.Equal(t, "0 differences, 0 consensus-breaking\n", out.String())
+	})
+
+	t.Run("different networks", func(t *testing.T) {
+		var out bytes.Buffer
+		err := Check(&out, loadNetwork(t, "op-sepolia"), loadNetwork(t, "op-mainnet"))
+		require.ErrorIs(t, err, ErrIncompatible)
+		require.Contains(t, out.String(), "l2_chain_id (consensus-breaking): 11155420 != 10")
+		require.Contains(t, out.String(), "l2.chainId (consensus-breaking): 11155420 != 10")
+	})
+
+	t.Run("invalid fork order", func(t *testing.T) {
+		a, b := loadNetwork(t, "op-sepolia"), loadNetwork(t, "op-sepolia")
+		cfg := *b.Rollup
+		granite := *cfg.FjordTime - 1
+		cfg.GraniteTime = &granite
+		b.Rollup = &cfg
+		var out bytes.Buffer
+		require.ErrorIs(t, Check(&out, a, b), ErrInvalidConfig)
+		require.Contains(t, out.String(), "other: invalid hardfork order")
+		require.Contains(t, out.String(), "other: L2 chain config does not match rollup config")
+		require.NotContains(t, out.String(), "other: invalid rollup config")
+	})
+}
+
+func TestLoadChainConfig(t *testing.T) {
+	dir := t.TempDir()
+	chainCfg := params.OptimismTestConfig
+	write := func(name string, v any) string {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, data, 0o644))
+		return path
+	}
+
+	loaded, err := loadChainConfig(write("genesis.json", &core.Genesis{Config: chainCfg, Alloc: types.GenesisAlloc{}, Difficulty: common.Big0}))
+	require.NoError(t, err)
+	require.Equal(t, chainCfg.ChainID, loaded.ChainID)
+	require.Equal(t, chainCfg.CanyonTime, loaded.CanyonTime)
+
+	loaded, err = loadChainConfig(write("config.json", chainCfg))
+	require.NoError(t, err)
+	require.Equal(t, chainCfg.ChainID, loaded.ChainID)
+	require.Equal(t, chainCfg.CanyonTime, loaded.CanyonTime)
+
+	_, err = loadChainConfig(filepath.Join(dir, "missing.json"))
+	require.Error(t, err)
+}
@@ -0,0 +1,154 @@
+package p2p
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p/loadtest"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var (
+	defaultLoadTest = loadtest.DefaultConfig()
+
+	LoadTestNodesFlag = &cli.IntFlag{
+		Name:  "nodes",
+		Usage: "Number of gossip nodes, including the publishing node",
+		Value: defaultLoadTest.Nodes,
+	}
+	LoadTestDegreeFlag = &cli.IntFlag{
+		Name:  "degree",
+		Usage: "Minimum number of peers each node connects to",
+		Value: defaultLoadTest.Degree,
+	}
+	LoadTestRateFlag = &cli.Float64Flag{
+		Name:  "rate",
+		Usage: "Number of payloads published per second",
+		Value: defaultLoadTest.Rate,
+	}
+	LoadTestDurationFlag = &cli.DurationFlag{
+		Name:  "duration",
+		Usage: "Time to publish payloads for",
+		Value: defaultLoadTest.Duration,
+	}
+	LoadTestWarmupFlag = &cli.DurationFlag{
+		Name:  "warmup",
+		Usage: "Time for the gossip meshes to form before publishing",
+		Value: defaultLoadTest.Warmup,
+	}
+	LoadTestDrainFlag = &cli.DurationFlag{
+		Name:  "drain",
+		Usage: "Time to wait for the last payloads to propagate after publishing",
+		Value: defaultLoadTest.Drain,
+	}
+	LoadTestPayloadSizeFlag = &cli.IntFlag{
+		Name:  "payload-size",
+		Usage: "Size in bytes of the transaction data of each payload",
+		Value: defaultLoadTest.PayloadSize,
+	}
+	LoadTestLatencyFlag = &cli.DurationFlag{
+		Name:  "latency",
+		Usage: "Latency of each link between two nodes",
+		Value: defaultLoadTest.Latency,
+	}
+	LoadTestBandwidthFlag = &cli.Float64Flag{
+		Name:  "bandwidth",
+		Usage: "Bandwidth in bytes per second of each link between two nodes, unlimited if 0",
+		Value: defaultLoadTest.Bandwidth,
+	}
+	LoadTestMeshDFlag = &cli.IntFlag{
+		Name:  "mesh.d",
+		Usage: "Target number of peers in the gossip mesh",
+		Value: defaultLoadTest.MeshD,
+	}
+	LoadTestMeshDLoFlag = &cli.IntFlag{
+		Name:  "mesh.dlo",
+		Usage: "Low watermark of peers in the gossip mesh",
+		Value: defaultLoadTest.MeshDLo,
+	}
+	LoadTestMeshDHiFlag = &cli.IntFlag{
+		Name:  "mesh.dhi",
+		Usage: "High watermark of peers in the gossip mesh",
+		Value: defaultLoadTest.MeshDHi,
+	}
+	LoadTestMeshDLazyFlag = &cli.IntFlag{
+		Name:  "mesh.dlazy",
+		Usage: "Number of peers to emit gossip to",
+		Value: defaultLoadTest.MeshDLazy,
+	}
+	LoadTestFloodPublishFlag = &cli.BoolFlag{
+		Name:  "flood-publish",
+		Usage: "Publish payloads to all peers of the topic, not only to the mesh peers",
+		Value: defaultLoadTest.FloodPublish,
+	}
+	LoadTestScoringFlag = &cli.StringFlag{
+		Name:  "scoring",
+		Usage: "Peer scoring level: light or none",
+		Value: defaultLoadTest.Scoring,
+	}
+	LoadTestSeedFlag = &cli.Int64Flag{
+		Name:  "seed",
+		Usage: "Seed of the random topology and payload data",
+		Value: defaultLoadTest.Seed,
+	}
+)
+
+// LoadTestCommand measures the propagation latency and loss of unsafe blocks, over an in-memory network of gossip nodes.
+var LoadTestCommand = &cli.Command{
+	Name:  "loadtest",
+	Usage: "Measures the propagation of unsafe block gossip through a network of in-memory nodes",
+	Description: "Connects gossip-only nodes over an in-memory network with the given latency and bandwidth, " +
+		"publishes signed synthetic payloads from one node at the given rate, and reports the loss and the latency " +
+		"percentiles of the deliveries to the other nodes as JSON, to compare gossip topology and peer scoring parameters.",
+	Flags: []cli.Flag{
+		LoadTestNodesFlag,
+		LoadTestDegreeFlag,
+		LoadTestRateFlag,
+		LoadTestDurationFlag,
+		LoadTestWarmupFlag,
+		LoadTestDrainFlag,
+		LoadTestPayloadSizeFlag,
+		LoadTestLatencyFlag,
+		LoadTestBandwidthFlag,
+		LoadTestMeshDFlag,
+		LoadTestMeshDLoFlag,
+		LoadTestMeshDHiFlag,
+		LoadTestMeshDLazyFlag,
+		LoadTestFloodPublishFlag,
+		LoadTestScoringFlag,
+		LoadTestSeedFlag,
+	},
+	Action: func(ctx *cli.Context) error {
+		logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+		res, err := loadtest.Run(ctx.Context, logger, ReadLoadTestConfig(ctx))
+		if err != nil {
+			return fmt.Errorf("load test failed: %w", err)
+		}
+		enc := json.NewEncoder(ctx.App.Writer)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	},
+}
+
+func ReadLoadTestConfig(ctx *cli.Context) loadtest.Config {
+	return loadtest.Config{
+		Nodes:        ctx.Int(LoadTestNodesFlag.Name),
+		Degree:       ctx.Int(LoadTestDegreeFlag.Name),
+		Rate:         ctx.Float64(LoadTestRateFlag.Name),
+		Duration:     ctx.Duration(LoadTestDurationFlag.Name),
+		Warmup:       ctx.Duration(LoadTestWarmupFlag.Name),
+		Drain:        ctx.Duration(LoadTestDrainFlag.Name),
+		PayloadSize:  ctx.Int(LoadTestPayloadSizeFlag.Name),
+		Latency:      ctx.Duration(LoadTestLatencyFlag.Name),
+		Bandwidth:    ctx.Float64(LoadTestBandwidthFlag.Name),
+		MeshD:        ctx.Int(LoadTestMeshDFlag.Name),
+		MeshDLo:      ctx.Int(LoadTestMeshDLoFlag.Name),
+		MeshDHi:      ctx.Int(LoadTestMeshDHiFlag.Name),
+		MeshDLazy:    ctx.Int(LoadTestMeshDLazyFlag.Name),
+		FloodPublish: ctx.Bool(LoadTestFloodPublishFlag.Name),
+		Scoring:      ctx.String(LoadTestScoringFlag.Name),
+		Seed:         ctx.Int64(LoadTestSeedFlag.Name),
+	}
+}
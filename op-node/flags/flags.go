@@ -8,7 +8,9 @@ import (
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -104,6 +106,13 @@ var (
 		EnvVars:  prefixEnvVars("L1_BEACON_FETCH_ALL_SIDECARS"),
 		Category: L1RPCCategory,
 	}
+	SupervisorAddr = &cli.StringFlag{
+		Name: "interop.supervisor",
+		Usage: "RPC address of the op-supervisor to report the local heads to, and to track the cross-safe and finalized heads with. " +
+			"Enables interop mode, in which the supervisor is the source of L2 finality.",
+		EnvVars:  prefixEnvVars("INTEROP_SUPERVISOR"),
+		Category: RollupCategory,
+	}
 	SyncModeFlag = &cli.GenericFlag{
 		Name:    "syncmode",
 		Usage:   fmt.Sprintf("Blockchain sync mode (options: %s)", openum.EnumString(sync.ModeStrings)),
@@ -134,6 +143,14 @@ var (
 		EnvVars:  prefixEnvVars("RPC_ENABLE_ADMIN"),
 		Category: OperationsCategory,
 	}
+	RPCAdminJWTSecret = &cli.StringFlag{
+		Name: "rpc.admin-jwt-secret",
+		Usage: "Path to a JWT secret file to authenticate privileged admin RPC calls with, such as capturing traces, " +
+			"heap dumps and GC tuning. These calls are disabled if not set.",
+		EnvVars:   prefixEnvVars("RPC_ADMIN_JWT_SECRET"),
+		TakesFile: true,
+		Category:  OperationsCategory,
+	}
 	RPCAdminPersistence = &cli.StringFlag{
 		Name:     "rpc.admin-state",
 		Usage:    "File path used to persist state changes made via the admin API so they persist across restarts. Disabled if not set.",
@@ -164,6 +181,33 @@ var (
 		Hidden:   true,
 		Category: L1RPCCategory,
 	}
+	L1CacheFile = &cli.StringFlag{
+		Name: "l1.cache-file",
+		Usage: "File path used to persist the L1 header, transaction and receipt cache across restarts, " +
+			"to avoid refetching the L1 blocks during the pipeline reset. Compressed if the path ends in .gz. Disabled if not set.",
+		EnvVars:  prefixEnvVars("L1_CACHE_FILE"),
+		Category: L1RPCCategory,
+	}
+	L1CacheDepth = &cli.Uint64Flag{
+		Name:     "l1.cache-depth",
+		Usage:    "Maximum number of L1 blocks, below the highest cached block, to persist to the l1.cache-file.",
+		EnvVars:  prefixEnvVars("L1_CACHE_DEPTH"),
+		Value:    1000,
+		Category: L1RPCCategory,
+	}
+	L1CacheSaveInterval = &cli.DurationFlag{
+		Name:     "l1.cache-save-interval",
+		Usage:    "Interval to persist the L1 cache to the l1.cache-file at, besides on shutdown, so a crash does not lose it. 0 to only persist on shutdown.",
+		EnvVars:  prefixEnvVars("L1_CACHE_SAVE_INTERVAL"),
+		Value:    5 * time.Minute,
+		Category: L1RPCCategory,
+	}
+	L1SkipAddressCheck = &cli.BoolFlag{
+		Name:     "l1.skip-address-check",
+		Usage:    "Skip validating the L1 contract addresses of the rollup config against the code on L1 at startup, e.g. when the L1 node is still syncing.",
+		EnvVars:  prefixEnvVars("L1_SKIP_ADDRESS_CHECK"),
+		Category: L1RPCCategory,
+	}
 	L1RPCMaxConcurrency = &cli.IntFlag{
 		Name:     "l1.max-concurrency",
 		Usage:    "Maximum number of concurrent RPC requests to make to the L1 RPC provider.",
@@ -180,7 +224,7 @@ var (
 	}
 	L1RPCMaxBatchSize = &cli.IntFlag{
 		Name:     "l1.rpc-max-batch-size",
-		Usage:    "Maximum number of RPC requests to bundle, e.g. during L1 blocks receipt fetching. The L1 RPC rate limit counts this as N items, but allows it to burst at once.",
+		Usage:    "Maximum number of RPC requests to bundle, e.g. during L1 blocks receipt fetching. The L1 RPC rate limit counts this as N items, but allows it to burst at once. Batches are split further if the L1 RPC provider reports them as too large.",
 		EnvVars:  prefixEnvVars("L1_RPC_MAX_BATCH_SIZE"),
 		Value:    20,
 		Category: L1RPCCategory,
@@ -203,6 +247,14 @@ var (
 		}(),
 		Category: RollupCategory,
 	}
+	L2EngineReconcile = &cli.BoolFlag{
+		Name: "l2.engine-reconcile",
+		Usage: "Retry engine API calls while the execution engine is unavailable, and replay the recent payloads and " +
+			"forkchoice state to the engine if it restarts without its most recent blocks",
+		EnvVars:  prefixEnvVars("L2_ENGINE_RECONCILE"),
+		Value:    false,
+		Category: RollupCategory,
+	}
 	VerifierL1Confs = &cli.Uint64Flag{
 		Name:     "verifier.l1-confs",
 		Usage:    "Number of L1 blocks to keep distance from the L1 head before deriving L2 data from. Reorgs are supported, but may be slow to perform.",
@@ -229,6 +281,28 @@ var (
 		Value:    0,
 		Category: SequencerCategory,
 	}
+	SequencerDepositsOnlyThresholdFlag = &cli.DurationFlag{
+		Name: "sequencer.deposits-only-threshold",
+		Usage: "Minimum time left before the timestamp of a new block to include tx-pool transactions. " +
+			"Blocks that start building later, e.g. due to a slow execution engine, are built with deposits only to stay on schedule. Disabled if 0.",
+		EnvVars:  prefixEnvVars("SEQUENCER_DEPOSITS_ONLY_THRESHOLD"),
+		Value:    0,
+		Category: SequencerCategory,
+	}
+	SequencerBuilderAddrFlag = &cli.StringFlag{
+		Name: "sequencer.builder",
+		Usage: "Engine API address of an external block builder to build sequenced blocks with, alongside the local engine (experimental). " +
+			"The payload of the builder is adopted if it is valid, otherwise the locally built payload is used.",
+		EnvVars:  prefixEnvVars("SEQUENCER_BUILDER"),
+		Category: SequencerCategory,
+	}
+	SequencerBuilderJWTSecretFlag = &cli.StringFlag{
+		Name:      "sequencer.builder.jwt-secret",
+		Usage:     "Path to the JWT secret key of the external block builder. Keys are 32 bytes, hex encoded in a file.",
+		EnvVars:   prefixEnvVars("SEQUENCER_BUILDER_JWT_SECRET"),
+		Category:  SequencerCategory,
+		TakesFile: true,
+	}
 	SequencerL1Confs = &cli.Uint64Flag{
 		Name:     "sequencer.l1-confs",
 		Usage:    "Number of L1 blocks to keep distance from the L1 head as a sequencer for picking an L1 origin.",
@@ -236,6 +310,43 @@ var (
 		Value:    4,
 		Category: SequencerCategory,
 	}
+	SequencerOriginPolicyFlag = &cli.StringFlag{
+		Name: "sequencer.origin-policy",
+		Usage: fmt.Sprintf("Policy for applying the sequencer L1 confirmations when picking an L1 origin. Options: %s. "+
+			"The aggressive policy drops the confirmations once past half of the max sequencer drift, to avoid deposit-only blocks.",
+			openum.EnumString(sequencing.OriginPolicies)),
+		EnvVars:  prefixEnvVars("SEQUENCER_ORIGIN_POLICY"),
+		Value:    sequencing.ConservativeOriginPolicy,
+		Category: SequencerCategory,
+	}
+	SequencerConditionalTxsFlag = &cli.BoolFlag{
+		Name: "sequencer.conditional-txs",
+		Usage: "Accept transactions with inclusion conditions through the eth_sendRawTransactionConditional RPC. " +
+			"The conditions are checked against the parent state when building each block.",
+		EnvVars:  prefixEnvVars("SEQUENCER_CONDITIONAL_TXS"),
+		Category: SequencerCategory,
+	}
+	SequencerConditionalTxsMaxPendingFlag = &cli.IntFlag{
+		Name:     "sequencer.conditional-txs.max-pending",
+		Usage:    "Maximum number of conditional transactions waiting for inclusion.",
+		EnvVars:  prefixEnvVars("SEQUENCER_CONDITIONAL_TXS_MAX_PENDING"),
+		Value:    1000,
+		Category: SequencerCategory,
+	}
+	SequencerConditionalTxsMaxCostFlag = &cli.IntFlag{
+		Name:     "sequencer.conditional-txs.max-cost",
+		Usage:    "Maximum number of known account storage roots and slots a single transaction conditional may check.",
+		EnvVars:  prefixEnvVars("SEQUENCER_CONDITIONAL_TXS_MAX_COST"),
+		Value:    1000,
+		Category: SequencerCategory,
+	}
+	SequencerConditionalTxsMaxPerBlockFlag = &cli.IntFlag{
+		Name:     "sequencer.conditional-txs.max-per-block",
+		Usage:    "Maximum number of conditional transactions included in a single block.",
+		EnvVars:  prefixEnvVars("SEQUENCER_CONDITIONAL_TXS_MAX_PER_BLOCK"),
+		Value:    16,
+		Category: SequencerCategory,
+	}
 	L1EpochPollIntervalFlag = &cli.DurationFlag{
 		Name:     "l1.epoch-poll-interval",
 		Usage:    "Poll interval for retrieving new L1 epoch updates such as safe and finalized block changes. Disabled if 0 or negative.",
@@ -308,6 +419,12 @@ var (
 		EnvVars:  prefixEnvVars("ROLLUP_LOAD_PROTOCOL_VERSIONS"),
 		Category: RollupCategory,
 	}
+	RollupAllowUnsafeDevnetDeposits = &cli.BoolFlag{
+		Name:     "rollup.allow-unsafe-devnet-deposits",
+		Usage:    "UNSAFE: allow a rollup config with devnet deposits, which insert synthetic system deposits into L2 blocks to rehearse upgrades. Only for devnets.",
+		EnvVars:  prefixEnvVars("ROLLUP_ALLOW_UNSAFE_DEVNET_DEPOSITS"),
+		Category: RollupCategory,
+	}
 	SafeDBPath = &cli.StringFlag{
 		Name:     "safedb.path",
 		Usage:    "File path used to persist safe head update data. Disabled if not set.",
@@ -397,10 +514,19 @@ var optionalFlags = []cli.Flag{
 	SequencerEnabledFlag,
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
+	SequencerDepositsOnlyThresholdFlag,
 	SequencerL1Confs,
+	SequencerOriginPolicyFlag,
+	SequencerConditionalTxsFlag,
+	SequencerConditionalTxsMaxPendingFlag,
+	SequencerConditionalTxsMaxCostFlag,
+	SequencerConditionalTxsMaxPerBlockFlag,
+	SequencerBuilderAddrFlag,
+	SequencerBuilderJWTSecretFlag,
 	L1EpochPollIntervalFlag,
 	RuntimeConfigReloadIntervalFlag,
 	RPCEnableAdmin,
+	RPCAdminJWTSecret,
 	RPCAdminPersistence,
 	MetricsEnabledFlag,
 	MetricsAddrFlag,
@@ -411,12 +537,19 @@ var optionalFlags = []cli.Flag{
 	HeartbeatURLFlag,
 	RollupHalt,
 	RollupLoadProtocolVersions,
+	RollupAllowUnsafeDevnetDeposits,
 	L1RethDBPath,
+	L1CacheFile,
+	L1CacheDepth,
+	L1CacheSaveInterval,
+	L1SkipAddressCheck,
 	ConductorEnabledFlag,
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
 	SafeDBPath,
 	L2EngineKind,
+	L2EngineReconcile,
+	SupervisorAddr,
 }
 
 var DeprecatedFlags = []cli.Flag{
@@ -439,6 +572,7 @@ func init() {
 	optionalFlags = append(optionalFlags, DeprecatedFlags...)
 	optionalFlags = append(optionalFlags, opflags.CLIFlags(EnvVarPrefix, RollupCategory)...)
 	optionalFlags = append(optionalFlags, altda.CLIFlags(EnvVarPrefix, AltDACategory)...)
+	optionalFlags = append(optionalFlags, addrcheck.CLIFlags(EnvVarPrefix, L1RPCCategory)...)
 	Flags = append(requiredFlags, optionalFlags...)
 }
 
@@ -12,6 +12,7 @@ import (
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
 
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 
@@ -60,6 +61,10 @@ type Metricer interface {
 	RecordBandwidth(ctx context.Context, bwc *libp2pmetrics.BandwidthCounter)
 	RecordSequencerBuildingDiffTime(duration time.Duration)
 	RecordSequencerSealingTime(duration time.Duration)
+	RecordSequencerBlockPhase(phase string, duration time.Duration)
+	RecordSequencerDepositsOnlyBlock()
+	RecordSequencerRecoverMode(mode bool)
+	RecordEngineReconciliation(replayedPayloads int)
 	Document() []metrics.DocumentedMetric
 	RecordChannelInputBytes(num int)
 	RecordHeadChannelOpened()
@@ -74,6 +79,9 @@ type Metricer interface {
 	RecordIPUnban()
 	RecordDial(allow bool)
 	RecordAccept(allow bool)
+	RecordStaticPeerConnected(peerID string, connected bool)
+	RecordStaticPeerDial(peerID string, success bool)
+	RemoveStaticPeer(peerID string)
 	ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion)
 }
 
@@ -126,6 +134,13 @@ type Metrics struct {
 	SequencerSealingDurationSeconds prometheus.Histogram
 	SequencerSealingTotal           prometheus.Counter
 
+	SequencerBlockPhaseDurationSeconds *prometheus.HistogramVec
+	SequencerDepositsOnlyBlocks        prometheus.Counter
+	SequencerRecoverMode               prometheus.Gauge
+
+	EngineReconciliations    prometheus.Counter
+	EngineReconciledPayloads prometheus.Counter
+
 	UnsafePayloadsBufferLen     prometheus.Gauge
 	UnsafePayloadsBufferMemSize prometheus.Gauge
 
@@ -152,6 +167,8 @@ type Metrics struct {
 	Dials             *prometheus.CounterVec
 	Accepts           *prometheus.CounterVec
 	PeerScores        *prometheus.HistogramVec
+	StaticPeerUp      *prometheus.GaugeVec
+	StaticPeerDials   *prometheus.CounterVec
 
 	ChannelInputBytes prometheus.Counter
 
@@ -177,6 +194,7 @@ func NewMetrics(procName string) *Metrics {
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	registry.MustRegister(collectors.NewGoCollector())
+	registry.MustRegister(appinfo.NewCollector())
 	factory := metrics.With(registry)
 
 	return &Metrics{
@@ -325,6 +343,18 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "accepts",
 			Help:      "Count of incoming dial attempts to accept, with label to filter to allowed attempts",
 		}, []string{"allow"}),
+		StaticPeerUp: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "static_peer_up",
+			Help:      "1 if the static peer is connected, 0 otherwise",
+		}, []string{"peer"}),
+		StaticPeerDials: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "static_peer_dials",
+			Help:      "Count of (re)connect attempts to static peers, with label to filter to successful attempts",
+		}, []string{"peer", "success"}),
 
 		headChannelOpenedEvent: metrics.NewEvent(factory, ns, "", "head_channel", "New channel at the front of the channel bank"),
 		channelTimedOutEvent:   metrics.NewEvent(factory, ns, "", "channel_timeout", "Channel has timed out"),
@@ -406,6 +436,33 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "sequencer_sealing_total",
 			Help:      "Number of sequencer block sealing jobs",
 		}),
+		SequencerBlockPhaseDurationSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "sequencer_block_phase_seconds",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of the time the sequencer spent in each phase of producing a block",
+		}, []string{"phase"}),
+		SequencerDepositsOnlyBlocks: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "sequencer_deposits_only_blocks_total",
+			Help:      "Number of blocks the sequencer built without tx-pool transactions, as block building started too late",
+		}),
+		SequencerRecoverMode: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_recover_mode",
+			Help:      "1 if the sequencer is in recover mode and builds blocks with deposits only, 0 otherwise",
+		}),
+
+		EngineReconciliations: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "engine_reconciliations_total",
+			Help:      "Number of times the forkchoice state was replayed to the execution engine, after its head regressed",
+		}),
+		EngineReconciledPayloads: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "engine_reconciled_payloads_total",
+			Help:      "Number of payloads replayed to the execution engine, after it lost them",
+		}),
 
 		ProtocolVersionDelta: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: ns,
@@ -590,6 +647,32 @@ func (m *Metrics) RecordSequencerSealingTime(duration time.Duration) {
 	m.SequencerSealingDurationSeconds.Observe(float64(duration) / float64(time.Second))
 }
 
+// RecordSequencerBlockPhase tracks the time the sequencer spent in a phase of producing a block:
+// preparing the attributes, starting the block building job, sealing, publishing or inserting the block.
+func (m *Metrics) RecordSequencerBlockPhase(phase string, duration time.Duration) {
+	m.SequencerBlockPhaseDurationSeconds.WithLabelValues(phase).Observe(float64(duration) / float64(time.Second))
+}
+
+// RecordSequencerDepositsOnlyBlock tracks blocks built without tx-pool transactions to stay on schedule.
+func (m *Metrics) RecordSequencerDepositsOnlyBlock() {
+	m.SequencerDepositsOnlyBlocks.Inc()
+}
+
+// RecordSequencerRecoverMode tracks whether the sequencer builds blocks with deposits only for incident response.
+func (m *Metrics) RecordSequencerRecoverMode(mode bool) {
+	if mode {
+		m.SequencerRecoverMode.Set(1)
+	} else {
+		m.SequencerRecoverMode.Set(0)
+	}
+}
+
+// RecordEngineReconciliation tracks the replays of lost payloads and forkchoice state to the execution engine.
+func (m *Metrics) RecordEngineReconciliation(replayedPayloads int) {
+	m.EngineReconciliations.Inc()
+	m.EngineReconciledPayloads.Add(float64(replayedPayloads))
+}
+
 // StartServer starts the metrics server on the given hostname and port.
 func (m *Metrics) StartServer(hostname string, port int) (*ophttp.HTTPServer, error) {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
@@ -663,6 +746,27 @@ func (m *Metrics) RecordAccept(allow bool) {
 		m.Accepts.WithLabelValues("false").Inc()
 	}
 }
+func (m *Metrics) RecordStaticPeerConnected(peerID string, connected bool) {
+	if connected {
+		m.StaticPeerUp.WithLabelValues(peerID).Set(1)
+	} else {
+		m.StaticPeerUp.WithLabelValues(peerID).Set(0)
+	}
+}
+
+func (m *Metrics) RecordStaticPeerDial(peerID string, success bool) {
+	if success {
+		m.StaticPeerDials.WithLabelValues(peerID, "true").Inc()
+	} else {
+		m.StaticPeerDials.WithLabelValues(peerID, "false").Inc()
+	}
+}
+
+func (m *Metrics) RemoveStaticPeer(peerID string) {
+	m.StaticPeerUp.DeleteLabelValues(peerID)
+	m.StaticPeerDials.DeletePartialMatch(prometheus.Labels{"peer": peerID})
+}
+
 func (m *Metrics) ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion) {
 	m.ProtocolVersionDelta.WithLabelValues("local_recommended").Set(float64(local.Compare(recommended)))
 	m.ProtocolVersionDelta.WithLabelValues("local_required").Set(float64(local.Compare(required)))
@@ -764,6 +868,18 @@ func (n *noopMetricer) RecordSequencerBuildingDiffTime(duration time.Duration) {
 func (n *noopMetricer) RecordSequencerSealingTime(duration time.Duration) {
 }
 
+func (n *noopMetricer) RecordSequencerBlockPhase(phase string, duration time.Duration) {
+}
+
+func (n *noopMetricer) RecordSequencerDepositsOnlyBlock() {
+}
+
+func (n *noopMetricer) RecordSequencerRecoverMode(mode bool) {
+}
+
+func (n *noopMetricer) RecordEngineReconciliation(replayedPayloads int) {
+}
+
 func (n *noopMetricer) Document() []metrics.DocumentedMetric {
 	return nil
 }
@@ -800,5 +916,14 @@ func (n *noopMetricer) RecordDial(allow bool) {
 
 func (n *noopMetricer) RecordAccept(allow bool) {
 }
+func (n *noopMetricer) RecordStaticPeerConnected(peerID string, connected bool) {
+}
+
+func (n *noopMetricer) RecordStaticPeerDial(peerID string, success bool) {
+}
+
+func (n *noopMetricer) RemoveStaticPeer(peerID string) {
+}
+
 func (n *noopMetricer) ReportProtocolVersions(local, engine, recommended, required params.ProtocolVersion) {
 }
@@ -32,8 +32,13 @@ type driverClient interface {
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
 	StopSequencer(context.Context) (common.Hash, error)
 	SequencerActive(context.Context) (bool, error)
+	SetSequencerOriginPolicy(ctx context.Context, name string) error
+	SequencerOriginPolicy(ctx context.Context) (string, error)
 	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 	OverrideLeader(ctx context.Context) error
+	SetRecoverMode(ctx context.Context, mode bool) error
+	RecoverMode(ctx context.Context) (bool, error)
+	SimulateDerivation(ctx context.Context, data [][]byte) (*eth.DerivationSimulation, error)
 }
 
 type SafeDBReader interface {
@@ -76,6 +81,19 @@ func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
 	return n.dr.SequencerActive(ctx)
 }
 
+// SetSequencerOriginPolicy changes the policy the sequencer selects new L1 origins with, see sequencing.OriginPolicies.
+func (n *adminAPI) SetSequencerOriginPolicy(ctx context.Context, name string) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_setSequencerOriginPolicy")
+	defer recordDur()
+	return n.dr.SetSequencerOriginPolicy(ctx, name)
+}
+
+func (n *adminAPI) SequencerOriginPolicy(ctx context.Context) (string, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_sequencerOriginPolicy")
+	defer recordDur()
+	return n.dr.SequencerOriginPolicy(ctx)
+}
+
 // PostUnsafePayload is a special API that allows posting an unsafe payload to the L2 derivation pipeline.
 // It should only be used by op-conductor for sequencer failover scenarios.
 func (n *adminAPI) PostUnsafePayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
@@ -98,6 +116,20 @@ func (n *adminAPI) OverrideLeader(ctx context.Context) error {
 	return n.dr.OverrideLeader(ctx)
 }
 
+// SetRecoverMode enables or disables recover mode, in which the sequencer builds blocks with deposits only.
+// This keeps the chain live during incident response, e.g. when the tx-pool or block builder of the engine misbehaves.
+func (n *adminAPI) SetRecoverMode(ctx context.Context, mode bool) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_setRecoverMode")
+	defer recordDur()
+	return n.dr.SetRecoverMode(ctx, mode)
+}
+
+func (n *adminAPI) RecoverMode(ctx context.Context) (bool, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_recoverMode")
+	defer recordDur()
+	return n.dr.RecoverMode(ctx)
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
@@ -162,14 +194,57 @@ func (n *nodeAPI) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return n.dr.SyncStatus(ctx)
 }
 
+// SimulateDerivation answers whether the given batcher transaction data would derive into valid blocks,
+// if it was included in L1 now, and which blocks. This lets a batcher detect misconfiguration before submitting data.
+func (n *nodeAPI) SimulateDerivation(ctx context.Context, data []hexutil.Bytes) (*eth.DerivationSimulation, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_simulateDerivation")
+	defer recordDur()
+	txData := make([][]byte, len(data))
+	for i, d := range data {
+		txData[i] = d
+	}
+	return n.dr.SimulateDerivation(ctx, txData)
+}
+
 func (n *nodeAPI) RollupConfig(_ context.Context) (*rollup.Config, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_rollupConfig")
 	defer recordDur()
 	return n.config, nil
 }
 
+// RollupConfigHash returns the hash of the rollup config the node runs with, as a commitment to the
+// config returned by optimism_rollupConfig. It matches the hash included in the sync status.
+func (n *nodeAPI) RollupConfigHash(_ context.Context) (common.Hash, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_rollupConfigHash")
+	defer recordDur()
+	return n.config.Hash()
+}
+
 func (n *nodeAPI) Version(ctx context.Context) (string, error) {
 	recordDur := n.m.RecordRPCServerRequest("optimism_version")
 	defer recordDur()
 	return version.Version + "-" + version.Meta, nil
 }
+
+type conditionalTxPool interface {
+	Add(ctx context.Context, data hexutil.Bytes, cond *eth.TransactionConditional) (common.Hash, error)
+}
+
+// conditionalTxAPI accepts transactions the sequencer only includes in blocks their conditions hold for.
+type conditionalTxAPI struct {
+	pool conditionalTxPool
+	m    metrics.RPCMetricer
+}
+
+func NewConditionalTxAPI(pool conditionalTxPool, m metrics.RPCMetricer) *conditionalTxAPI {
+	return &conditionalTxAPI{
+		pool: pool,
+		m:    m,
+	}
+}
+
+func (n *conditionalTxAPI) SendRawTransactionConditional(ctx context.Context, data hexutil.Bytes, cond eth.TransactionConditional) (common.Hash, error) {
+	recordDur := n.m.RecordRPCServerRequest("eth_sendRawTransactionConditional")
+	defer recordDur()
+	return n.pool.Add(ctx, data, &cond)
+}
@@ -39,6 +39,18 @@ type L1BeaconEndpointSetup interface {
 	Check() error
 }
 
+type SupervisorEndpointSetup interface {
+	// Setup a RPC client to the op-supervisor, to report local heads to and track cross-safety with.
+	Setup(ctx context.Context, log log.Logger) (client.RPC, error)
+	Check() error
+}
+
+type L2BuilderEndpointSetup interface {
+	// Setup a RPC client to the external block builder, to build sequenced blocks with.
+	Setup(ctx context.Context, log log.Logger) (client.RPC, error)
+	Check() error
+}
+
 type L2EndpointConfig struct {
 	// L2EngineAddr is the address of the L2 Engine JSON-RPC endpoint to use. The engine and eth
 	// namespaces must be enabled by the endpoint.
@@ -141,6 +153,8 @@ func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 	opts := []client.RPCOption{
 		client.WithHttpPollInterval(cfg.HttpPollInterval),
 		client.WithDialBackoff(10),
+		// Rate-limited providers may reject batches of the configured size, so smaller batches are used if needed
+		client.WithBatchSplitting(cfg.BatchSize, cfg.MaxConcurrency),
 	}
 	if cfg.RateLimit != 0 {
 		opts = append(opts, client.WithRateLimit(cfg.RateLimit, cfg.BatchSize))
@@ -230,3 +244,48 @@ func parseHTTPHeader(headerStr string) (http.Header, error) {
 	h.Add(s[0], s[1])
 	return h, nil
 }
+
+type L2BuilderEndpointConfig struct {
+	// L2BuilderAddr is the address of the engine API endpoint of the external block builder.
+	L2BuilderAddr string
+
+	// L2BuilderJWTSecret is the JWT secret for engine API authentication with the builder.
+	L2BuilderJWTSecret [32]byte
+}
+
+var _ L2BuilderEndpointSetup = (*L2BuilderEndpointConfig)(nil)
+
+func (cfg *L2BuilderEndpointConfig) Check() error {
+	if cfg.L2BuilderAddr == "" {
+		return errors.New("empty L2 builder address")
+	}
+	return nil
+}
+
+func (cfg *L2BuilderEndpointConfig) Setup(ctx context.Context, log log.Logger) (client.RPC, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.L2BuilderJWTSecret))
+	return client.NewRPC(ctx, log, cfg.L2BuilderAddr, client.WithGethRPCOptions(auth), client.WithDialBackoff(10))
+}
+
+type SupervisorEndpointConfig struct {
+	SupervisorAddr string
+}
+
+var _ SupervisorEndpointSetup = (*SupervisorEndpointConfig)(nil)
+
+func (cfg *SupervisorEndpointConfig) Check() error {
+	if cfg.SupervisorAddr == "" {
+		return errors.New("empty supervisor address")
+	}
+	return nil
+}
+
+func (cfg *SupervisorEndpointConfig) Setup(ctx context.Context, log log.Logger) (client.RPC, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	return client.NewRPC(ctx, log, cfg.SupervisorAddr, client.WithDialBackoff(10))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -23,6 +24,13 @@ type Config struct {
 
 	Beacon L1BeaconEndpointSetup
 
+	// Supervisor is the op-supervisor endpoint to use in interop mode. Interop mode is disabled if nil.
+	Supervisor SupervisorEndpointSetup
+
+	// L2Builder is the external block builder endpoint the sequencer builds blocks with,
+	// alongside the local engine. Experimental, disabled if nil.
+	L2Builder L2BuilderEndpointSetup
+
 	Driver driver.Config
 
 	Rollup rollup.Config
@@ -69,6 +77,27 @@ type Config struct {
 	// [OPTIONAL] The reth DB path to read receipts from
 	RethDBPath string
 
+	// [OPTIONAL] The file to persist the L1 cache to, periodically and on shutdown, and restore it from, on startup.
+	// Disabled when set to empty string.
+	L1CacheFile string
+	// The maximum depth of L1 blocks, below the highest cached block, to persist.
+	L1CacheDepth uint64
+	// The interval to persist the L1 cache at while running. The cache is only persisted on shutdown if 0.
+	L1CacheSaveInterval time.Duration
+
+	// L2EngineReconcile enables retrying engine API calls, and replaying the recent payloads and forkchoice state
+	// to the execution engine if it restarts without its most recent blocks.
+	L2EngineReconcile bool
+
+	// SkipL1AddressCheck disables validating the configured L1 addresses against the L1 chain at startup.
+	SkipL1AddressCheck bool
+	// L1AddressCheck optionally restricts the L1 contracts to expected code hashes.
+	L1AddressCheck addrcheck.CLIConfig
+
+	// AllowUnsafeDevnetDeposits allows running with a rollup config that has devnet deposits.
+	// Devnet deposits are only meant to rehearse upgrades on devnets.
+	AllowUnsafeDevnetDeposits bool
+
 	// Conductor is used to determine this node is the leader sequencer.
 	ConductorEnabled    bool
 	ConductorRpc        string
@@ -82,6 +111,8 @@ type RPCConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	// AdminJWTSecret authenticates the privileged admin runtime API, which is disabled if nil.
+	AdminJWTSecret []byte
 }
 
 func (cfg *RPCConfig) HttpEndpoint() string {
@@ -138,6 +169,9 @@ func (cfg *Config) Check() error {
 	if err := cfg.L2.Check(); err != nil {
 		return fmt.Errorf("l2 endpoint config error: %w", err)
 	}
+	if err := cfg.L1AddressCheck.Check(); err != nil {
+		return fmt.Errorf("l1 address check config error: %w", err)
+	}
 	if cfg.Rollup.EcotoneTime != nil {
 		if cfg.Beacon == nil {
 			return fmt.Errorf("the Ecotone upgrade is scheduled but no L1 Beacon API endpoint is configured")
@@ -146,9 +180,25 @@ func (cfg *Config) Check() error {
 			return fmt.Errorf("misconfigured L1 Beacon API endpoint: %w", err)
 		}
 	}
+	if cfg.Supervisor != nil {
+		if err := cfg.Supervisor.Check(); err != nil {
+			return fmt.Errorf("misconfigured supervisor endpoint: %w", err)
+		}
+	}
+	if cfg.L2Builder != nil {
+		if !cfg.Driver.SequencerEnabled {
+			return errors.New("an external block builder is configured, but the sequencer is not enabled")
+		}
+		if err := cfg.L2Builder.Check(); err != nil {
+			return fmt.Errorf("misconfigured L2 builder endpoint: %w", err)
+		}
+	}
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
+	if len(cfg.Rollup.UnsafeDevnetDeposits) > 0 && !cfg.AllowUnsafeDevnetDeposits {
+		return fmt.Errorf("rollup config error: %w", rollup.ErrUnsafeDevnetDepositsNotAllowed)
+	}
 	if err := cfg.Metrics.Check(); err != nil {
 		return fmt.Errorf("metrics config error: %w", err)
 	}
@@ -160,6 +210,12 @@ func (cfg *Config) Check() error {
 			return fmt.Errorf("p2p config error: %w", err)
 		}
 	}
+	if cfg.RPC.AdminJWTSecret != nil && !cfg.RPC.EnableAdmin {
+		return fmt.Errorf("admin JWT secret requires the admin API to be enabled")
+	}
+	if err := cfg.Driver.Check(); err != nil {
+		return fmt.Errorf("driver config error: %w", err)
+	}
 	if !(cfg.RollupHalt == "" || cfg.RollupHalt == "major" || cfg.RollupHalt == "minor" || cfg.RollupHalt == "patch") {
 		return fmt.Errorf("invalid rollup halting option: %q", cfg.RollupHalt)
 	}
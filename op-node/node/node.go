@@ -23,8 +23,12 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/conductor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-node/version"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
@@ -61,6 +65,11 @@ type OpNode struct {
 
 	safeDB closableSafeDB
 
+	l1CacheFile  string // file to persist the L1 cache to, disabled if empty
+	l1CacheDepth uint64
+	// Indicates when the periodic L1 cache persistence is done, and the cache can be persisted a final time
+	l1CacheSaverDone chan struct{}
+
 	rollupHalt string // when to halt the rollup, disabled if empty
 
 	pprofService *oppprof.Service
@@ -68,6 +77,10 @@ type OpNode struct {
 
 	beacon *sources.L1BeaconClient
 
+	supervisor *sources.SupervisorClient // nil if interop mode is disabled
+
+	conditionalTxs *sequencing.ConditionalTxPool // nil if conditional transactions are disabled
+
 	// some resources cannot be stopped directly, like the p2p gossipsub router (not our design),
 	// and depend on this ctx to be closed.
 	resourcesCtx   context.Context
@@ -118,6 +131,11 @@ func New(ctx context.Context, cfg *Config, log log.Logger, appVersion string, m
 
 func (n *OpNode) init(ctx context.Context, cfg *Config) error {
 	n.log.Info("Initializing rollup node", "version", n.appVersion)
+	rollupConfigHash, err := cfg.Rollup.Hash()
+	if err != nil {
+		return err
+	}
+	appinfo.SetRollupConfigHash(rollupConfigHash)
 	if err := n.initTracer(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init the trace: %w", err)
 	}
@@ -182,6 +200,24 @@ func (n *OpNode) initL1(ctx context.Context, cfg *Config) error {
 	if err := cfg.Rollup.ValidateL1Config(ctx, n.l1Source); err != nil {
 		return fmt.Errorf("failed to validate the L1 config: %w", err)
 	}
+	if !cfg.SkipL1AddressCheck {
+		if err := addrcheck.Check(ctx, n.log, addrcheck.NewRPCCodeFetcher(l1Node), cfg.L1AddressCheck.Apply(cfg.Rollup.L1AddressExpectations()...)...); err != nil {
+			return fmt.Errorf("failed to validate the L1 addresses: %w", err)
+		}
+	}
+
+	if cfg.L1CacheFile != "" {
+		n.l1CacheFile = cfg.L1CacheFile
+		n.l1CacheDepth = cfg.L1CacheDepth
+		// The cache is an optimization only, failing to restore it is not fatal.
+		if _, err := n.l1Source.LoadCache(ctx, cfg.L1CacheFile); err != nil {
+			n.log.Warn("Failed to restore persisted L1 cache", "file", cfg.L1CacheFile, "err", err)
+		}
+		if cfg.L1CacheSaveInterval > 0 {
+			n.l1CacheSaverDone = make(chan struct{})
+			go n.saveL1CachePeriodically(n.resourcesCtx, cfg.L1CacheSaveInterval) // this keeps running after initialization
+		}
+	}
 
 	// Keep subscribed to the L1 heads, which keeps the L1 maintainer pointing to the best headers to sync
 	n.l1HeadsSub = event.ResubscribeErr(time.Second*10, func(ctx context.Context, err error) (event.Subscription, error) {
@@ -301,6 +337,24 @@ func (n *OpNode) initRuntimeConfig(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+// saveL1CachePeriodically persists the L1 cache at the given interval, so it survives a crash,
+// until the ctx is done. The cache is persisted a final time on Stop.
+func (n *OpNode) saveL1CachePeriodically(ctx context.Context, interval time.Duration) {
+	defer close(n.l1CacheSaverDone)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := n.l1Source.SaveCache(n.l1CacheFile, n.l1CacheDepth); err != nil {
+				n.log.Warn("Failed to persist L1 cache", "file", n.l1CacheFile, "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
 func (n *OpNode) initL1BeaconAPI(ctx context.Context, cfg *Config) error {
 	// If Ecotone upgrade is not scheduled yet, then there is no need for a Beacon API.
 	if cfg.Rollup.EcotoneTime == nil {
@@ -401,7 +455,36 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 	} else {
 		n.safeDB = safedb.Disabled
 	}
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n.beacon, n, n, n.log, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, altDA)
+	var supervisor interop.InteropBackend
+	if cfg.Supervisor != nil {
+		rpcClient, err := cfg.Supervisor.Setup(ctx, n.log)
+		if err != nil {
+			return fmt.Errorf("failed to setup supervisor RPC client: %w", err)
+		}
+		n.supervisor = sources.NewSupervisorClient(client.NewInstrumentedRPC(rpcClient, &n.metrics.RPCClientMetrics))
+		supervisor = n.supervisor
+		n.log.Info("Interop mode enabled, tracking cross-safety with the supervisor")
+	}
+	driverCfg := cfg.Driver
+	if cfg.Driver.SequencerEnabled && cfg.Driver.SequencerConditionalTxs.Enabled {
+		n.conditionalTxs = sequencing.NewConditionalTxPool(n.log, &cfg.Rollup, cfg.Driver.SequencerConditionalTxs, n.l2Source)
+		driverCfg.ConditionalTxs = n.conditionalTxs
+		n.log.Info("Conditional transactions enabled")
+	}
+	if cfg.L2Builder != nil {
+		rpcClient, err := cfg.L2Builder.Setup(ctx, n.log)
+		if err != nil {
+			return fmt.Errorf("failed to setup L2 builder RPC client: %w", err)
+		}
+		driverCfg.BlockBuilder = sources.NewEngineAPIClient(client.NewInstrumentedRPC(rpcClient, &n.metrics.RPCClientMetrics), n.log, &cfg.Rollup)
+		n.log.Warn("External block builder enabled, this is experimental")
+	}
+	var l2Engine driver.L2Chain = n.l2Source
+	if cfg.L2EngineReconcile {
+		n.log.Info("Engine reconciliation enabled")
+		l2Engine = sources.NewReconcilingEngineClient(n.l2Source, n.log, n.metrics, sources.EngineReconcilerDefaultConfig())
+	}
+	n.l2Driver = driver.NewDriver(&driverCfg, &cfg.Rollup, l2Engine, n.l1Source, n.beacon, n, n, n.log, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, altDA, supervisor)
 	return nil
 }
 
@@ -413,9 +496,16 @@ func (n *OpNode) initRPCServer(cfg *Config) error {
 	if n.p2pNode != nil {
 		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
 	}
+	if n.conditionalTxs != nil {
+		server.EnableConditionalTxAPI(NewConditionalTxAPI(n.conditionalTxs, n.metrics))
+	}
 	if cfg.RPC.EnableAdmin {
 		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
 		n.log.Info("Admin RPC enabled")
+		if cfg.RPC.AdminJWTSecret != nil {
+			server.EnableRuntimeAPI(oppprof.NewRuntimeAPI(n.log, cfg.Pprof.ProfileDir))
+			n.log.Info("Authenticated admin runtime RPC enabled")
+		}
 	}
 	n.log.Info("Starting JSON-RPC server")
 	if err := server.Start(); err != nil {
@@ -686,8 +776,21 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		n.l2Source.Close()
 	}
 
+	if n.supervisor != nil {
+		n.supervisor.Close()
+	}
+
 	// close L1 data source
 	if n.l1Source != nil {
+		// Wait for the periodic persistence to be done, to not write the cache file concurrently
+		if n.l1CacheSaverDone != nil {
+			<-n.l1CacheSaverDone
+		}
+		if n.l1CacheFile != "" {
+			if err := n.l1Source.SaveCache(n.l1CacheFile, n.l1CacheDepth); err != nil {
+				n.log.Warn("Failed to persist L1 cache", "file", n.l1CacheFile, "err", err)
+			}
+		}
 		n.l1Source.Close()
 	}
 
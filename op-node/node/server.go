@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	ophttp "github.com/ethereum-optimism/optimism/op-service/httputil"
+	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
@@ -19,11 +21,12 @@ import (
 )
 
 type rpcServer struct {
-	endpoint   string
-	apis       []rpc.API
-	httpServer *ophttp.HTTPServer
-	appVersion string
-	log        log.Logger
+	endpoint       string
+	apis           []rpc.API
+	adminJWTSecret []byte
+	httpServer     *ophttp.HTTPServer
+	appVersion     string
+	log            log.Logger
 	sources.L2Client
 }
 
@@ -37,9 +40,10 @@ func newRPCServer(rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthCli
 			Namespace:     "optimism",
 			Service:       api,
 			Authenticated: false,
-		}},
-		appVersion: appVersion,
-		log:        log,
+		}, appinfo.GetAPI()},
+		adminJWTSecret: rpcCfg.AdminJWTSecret,
+		appVersion:     appVersion,
+		log:            log,
 	}
 	return r, nil
 }
@@ -53,6 +57,21 @@ func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
 	})
 }
 
+// EnableConditionalTxAPI serves eth_sendRawTransactionConditional.
+func (s *rpcServer) EnableConditionalTxAPI(api *conditionalTxAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "eth",
+		Version:       "",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
+// EnableRuntimeAPI serves the runtime API to clients authenticated with the admin JWT secret.
+func (s *rpcServer) EnableRuntimeAPI(api *oppprof.RuntimeAPI) {
+	s.apis = append(s.apis, oppprof.GetRuntimeAPI(api))
+}
+
 func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	s.apis = append(s.apis, rpc.API{
 		Namespace:     p2p.NamespaceRPC,
@@ -63,16 +82,14 @@ func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 }
 
 func (s *rpcServer) Start() error {
-	srv := rpc.NewServer()
-	if err := node.RegisterApis(s.apis, nil, srv); err != nil {
-		return err
-	}
-
 	// The CORS and VHosts arguments below must be set in order for
 	// other services to connect to the opnode. VHosts in particular
 	// defaults to localhost, which will prevent containers from
 	// calling into the opnode without an "invalid host" error.
-	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
+	nodeHandler, err := oprpc.NewAuthHTTPHandler(s.apis, []string{"*"}, []string{"*"}, s.adminJWTSecret, nil)
+	if err != nil {
+		return err
+	}
 
 	mux := http.NewServeMux()
 	mux.Handle("/", nodeHandler)
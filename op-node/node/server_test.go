@@ -3,6 +3,7 @@ package node
 import (
 	"context"
 	"encoding/json"
+	"math/big"
 	"math/rand"
 	"testing"
 
@@ -154,6 +155,44 @@ func TestVersion(t *testing.T) {
 	assert.Equal(t, version.Version+"-"+version.Meta, out)
 }
 
+func TestRollupConfigHash(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		L2ChainID: big.NewInt(901),
+	}
+	expected, err := rollupCfg.Hash()
+	require.NoError(t, err)
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out common.Hash
+	err = client.CallContext(context.Background(), &out, "optimism_rollupConfigHash")
+	require.NoError(t, err)
+	require.Equal(t, expected, out)
+
+	// The hash commits to the config served by optimism_rollupConfig
+	var served *rollup.Config
+	err = client.CallContext(context.Background(), &served, "optimism_rollupConfig")
+	require.NoError(t, err)
+	servedHash, err := served.Hash()
+	require.NoError(t, err)
+	require.Equal(t, expected, servedHash)
+}
+
 func randomSyncStatus(rng *rand.Rand) *eth.SyncStatus {
 	return &eth.SyncStatus{
 		CurrentL1:          testutils.RandomBlockRef(rng),
@@ -165,6 +204,7 @@ func randomSyncStatus(rng *rand.Rand) *eth.SyncStatus {
 		SafeL2:             testutils.RandomL2BlockRef(rng),
 		FinalizedL2:        testutils.RandomL2BlockRef(rng),
 		PendingSafeL2:      testutils.RandomL2BlockRef(rng),
+		RollupConfigHash:   testutils.RandomHash(rng),
 	}
 }
 
@@ -200,6 +240,43 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestSimulateDerivation(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	data := []hexutil.Bytes{{0x00, 0x01}, {0x00, 0x02}}
+	expected := &eth.DerivationSimulation{
+		SafeHead: eth.L2BlockRef{Hash: common.Hash{0xaa}, Number: 10},
+		L1Head:   eth.L1BlockRef{Hash: common.Hash{0xbb}, Number: 20},
+		Channels: []eth.SimulatedChannel{{ID: "01", Frames: 2, Ready: true, Batches: []eth.SimulatedBatch{{Type: "singular", Timestamp: 22, Validity: "accept"}}}},
+		Blocks:   []eth.SimulatedBlock{{Number: 11, Timestamp: 22, Transactions: 3}},
+	}
+	drClient.On("SimulateDerivation", [][]byte{{0x00, 0x01}, {0x00, 0x02}}).Return(expected)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.DerivationSimulation
+	err = client.CallContext(context.Background(), &out, "optimism_simulateDerivation", data)
+	require.NoError(t, err)
+	require.Equal(t, expected, out)
+}
+
 func TestSafeHeadAtL1Block(t *testing.T) {
 	log := testlog.Logger(t, log.LevelError)
 	l2Client := &testutils.MockL2Client{}
@@ -279,6 +356,14 @@ func (c *mockDriverClient) SequencerActive(ctx context.Context) (bool, error) {
 	return c.Mock.MethodCalled("SequencerActive").Get(0).(bool), nil
 }
 
+func (c *mockDriverClient) SetSequencerOriginPolicy(ctx context.Context, name string) error {
+	return c.Mock.MethodCalled("SetSequencerOriginPolicy", name).Get(0).(error)
+}
+
+func (c *mockDriverClient) SequencerOriginPolicy(ctx context.Context) (string, error) {
+	return c.Mock.MethodCalled("SequencerOriginPolicy").Get(0).(string), nil
+}
+
 func (c *mockDriverClient) OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
 	return c.Mock.MethodCalled("OnUnsafeL2Payload").Get(0).(error)
 }
@@ -287,6 +372,18 @@ func (c *mockDriverClient) OverrideLeader(ctx context.Context) error {
 	return c.Mock.MethodCalled("OverrideLeader").Get(0).(error)
 }
 
+func (c *mockDriverClient) SetRecoverMode(ctx context.Context, mode bool) error {
+	return c.Mock.MethodCalled("SetRecoverMode", mode).Get(0).(error)
+}
+
+func (c *mockDriverClient) RecoverMode(ctx context.Context) (bool, error) {
+	return c.Mock.MethodCalled("RecoverMode").Get(0).(bool), nil
+}
+
+func (c *mockDriverClient) SimulateDerivation(ctx context.Context, data [][]byte) (*eth.DerivationSimulation, error) {
+	return c.Mock.MethodCalled("SimulateDerivation", data).Get(0).(*eth.DerivationSimulation), nil
+}
+
 type mockSafeDBReader struct {
 	mock.Mock
 }
@@ -299,3 +396,46 @@ func (m *mockSafeDBReader) SafeHeadAtL1(ctx context.Context, l1BlockNum uint64)
 func (m *mockSafeDBReader) ExpectSafeHeadAtL1(l1BlockNum uint64, l1 eth.BlockID, safeHead eth.BlockID, err error) {
 	m.Mock.On("SafeHeadAtL1", l1BlockNum).Return(l1, safeHead, &err)
 }
+
+type stubConditionalTxPool struct {
+	data hexutil.Bytes
+	cond *eth.TransactionConditional
+}
+
+func (s *stubConditionalTxPool) Add(ctx context.Context, data hexutil.Bytes, cond *eth.TransactionConditional) (common.Hash, error) {
+	s.data = data
+	s.cond = cond
+	return common.Hash{0xaa}, nil
+}
+
+func TestSendRawTransactionConditional(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, &mockDriverClient{}, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	pool := &stubConditionalTxPool{}
+	server.EnableConditionalTxAPI(NewConditionalTxAPI(pool, metrics.NoopMetrics))
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	root := common.Hash{0x01}
+	maxBlock := hexutil.Uint64(10)
+	cond := eth.TransactionConditional{
+		KnownAccounts:  map[common.Address]eth.KnownAccount{{0x02}: {StorageRoot: &root}},
+		BlockNumberMax: &maxBlock,
+	}
+	var out common.Hash
+	err = client.CallContext(context.Background(), &out, "eth_sendRawTransactionConditional", hexutil.Bytes{0x02, 0x03}, cond)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{0xaa}, out)
+	require.Equal(t, hexutil.Bytes{0x02, 0x03}, pool.data)
+	require.Equal(t, &cond, pool.cond)
+}
@@ -43,6 +43,7 @@ var DefaultBootnodes = []*enode.Node{
 type HostMetrics interface {
 	gating.UnbanMetrics
 	gating.ConnectionGaterMetrics
+	StaticPeerMetrics
 }
 
 // SetupP2P provides a host and discovery service for usage in the rollup node.
@@ -2,6 +2,7 @@ package p2p
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"sync"
@@ -50,6 +51,9 @@ type ExtraHostFeatures interface {
 	ConnectionManager() connmgr.ConnManager
 	IsStatic(peerID peer.ID) bool
 	SyncOnlyReqToStatic() bool
+	AddStaticPeer(addr ma.Multiaddr) (peer.ID, error)
+	RemoveStaticPeer(id peer.ID) error
+	StaticPeers() []StaticPeerInfo
 }
 
 type extraHost struct {
@@ -57,9 +61,13 @@ type extraHost struct {
 	gater   gating.BlockingConnectionGater
 	connMgr connmgr.ConnManager
 	log     log.Logger
+	clock   clock.Clock
+	metrics StaticPeerMetrics
 
-	staticPeers   []*peer.AddrInfo
-	staticPeerIDs map[peer.ID]struct{}
+	staticPeersLock sync.Mutex
+	staticPeers     map[peer.ID]*staticPeer
+	// staticDials tracks the dials to static peers, which are cancelled on Close
+	staticDials sync.WaitGroup
 
 	pinging *PingService
 
@@ -77,7 +85,9 @@ func (e *extraHost) ConnectionManager() connmgr.ConnManager {
 }
 
 func (e *extraHost) IsStatic(peerID peer.ID) bool {
-	_, exists := e.staticPeerIDs[peerID]
+	e.staticPeersLock.Lock()
+	defer e.staticPeersLock.Unlock()
+	_, exists := e.staticPeers[peerID]
 	return exists
 }
 
@@ -86,75 +96,17 @@ func (e *extraHost) SyncOnlyReqToStatic() bool {
 }
 
 func (e *extraHost) Close() error {
+	// Closed under the lock, so no static peer dials are started after
+	e.staticPeersLock.Lock()
 	close(e.quitC)
+	e.staticPeersLock.Unlock()
+	e.staticDials.Wait()
 	if e.pinging != nil {
 		e.pinging.Close()
 	}
 	return e.Host.Close()
 }
 
-func (e *extraHost) initStaticPeers() {
-	for _, addr := range e.staticPeers {
-		e.Peerstore().AddAddrs(addr.ID, addr.Addrs, time.Hour*24*7)
-		// We protect the peer, so the connection manager doesn't decide to prune it.
-		// We tag it with "static" so other protects/unprotects with different tags don't affect this protection.
-		e.connMgr.Protect(addr.ID, staticPeerTag)
-		// Try to dial the node in the background
-		go func(addr *peer.AddrInfo) {
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
-			defer cancel()
-			if err := e.dialStaticPeer(ctx, addr); err != nil {
-				e.log.Warn("error dialing static peer", "peer", addr.ID, "err", err)
-			}
-		}(addr)
-	}
-}
-
-func (e *extraHost) dialStaticPeer(ctx context.Context, addr *peer.AddrInfo) error {
-	e.log.Info("dialing static peer", "peer", addr.ID, "addrs", addr.Addrs)
-	if _, err := e.Network().DialPeer(ctx, addr.ID); err != nil {
-		return err
-	}
-	return nil
-}
-
-func (e *extraHost) monitorStaticPeers() {
-	tick := time.NewTicker(time.Minute)
-	defer tick.Stop()
-
-	for {
-		select {
-		case <-tick.C:
-			ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
-			var wg sync.WaitGroup
-
-			e.log.Debug("polling static peers", "peers", len(e.staticPeers))
-			for _, addr := range e.staticPeers {
-				connectedness := e.Network().Connectedness(addr.ID)
-				e.log.Trace("static peer connectedness", "peer", addr.ID, "connectedness", connectedness)
-
-				if connectedness == network.Connected {
-					continue
-				}
-
-				wg.Add(1)
-				go func(addr *peer.AddrInfo) {
-					e.log.Warn("static peer disconnected, reconnecting", "peer", addr.ID)
-					if err := e.dialStaticPeer(ctx, addr); err != nil {
-						e.log.Warn("error reconnecting to static peer", "peer", addr.ID, "err", err)
-					}
-					wg.Done()
-				}(addr)
-			}
-
-			wg.Wait()
-			cancel()
-		case <-e.quitC:
-			return
-		}
-	}
-}
-
 var _ ExtraHostFeatures = (*extraHost)(nil)
 
 func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics HostMetrics) (host.Host, error) {
@@ -254,31 +206,26 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 		return nil, err
 	}
 
-	staticPeers := make([]*peer.AddrInfo, 0, len(conf.StaticPeers))
-	staticPeerIDs := make(map[peer.ID]struct{})
-	for _, peerAddr := range conf.StaticPeers {
-		addr, err := peer.AddrInfoFromP2pAddr(peerAddr)
-		if err != nil {
-			return nil, fmt.Errorf("bad peer address: %w", err)
-		}
-		if addr.ID == h.ID() {
-			log.Info("Static-peer list contains address of local peer, ignoring the address.", "peer_id", addr.ID, "addrs", addr.Addrs)
-			continue
-		}
-		staticPeers = append(staticPeers, addr)
-		staticPeerIDs[addr.ID] = struct{}{}
-	}
-
 	out := &extraHost{
 		Host:                h,
 		connMgr:             connMngr,
 		log:                 log,
-		staticPeers:         staticPeers,
-		staticPeerIDs:       staticPeerIDs,
+		clock:               clock.SystemClock,
+		metrics:             metrics,
+		staticPeers:         make(map[peer.ID]*staticPeer),
 		quitC:               make(chan struct{}),
 		syncOnlyReqToStatic: conf.SyncOnlyReqToStatic,
 	}
 
+	for _, peerAddr := range conf.StaticPeers {
+		if _, err := out.AddStaticPeer(peerAddr); errors.Is(err, ErrStaticPeerSelf) {
+			log.Info("Static-peer list contains address of local peer, ignoring the address.", "addr", peerAddr)
+		} else if err != nil {
+			_ = h.Close()
+			return nil, err
+		}
+	}
+
 	if conf.EnablePingService {
 		out.pinging = NewPingService(
 			log,
@@ -289,10 +236,8 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 		)
 	}
 
-	out.initStaticPeers()
-	if len(conf.StaticPeers) > 0 {
-		go out.monitorStaticPeers()
-	}
+	// Static peers can be added at runtime, so always monitor them.
+	go out.monitorStaticPeers()
 
 	out.gater = connGtr
 	return out, nil
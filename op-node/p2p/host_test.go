@@ -3,6 +3,7 @@ package p2p
 import (
 	"context"
 	"crypto/rand"
+	"fmt"
 	"math/big"
 	"net"
 	"slices"
@@ -72,6 +73,137 @@ func TestP2PSimple(t *testing.T) {
 	require.Equal(t, hostB.Network().Connectedness(hostA.ID()), network.Connected)
 }
 
+func TestStaticPeers(t *testing.T) {
+	confA := TestingConfig(t)
+	confB := TestingConfig(t)
+	hostA, err := confA.Host(testlog.Logger(t, log.LevelError).New("host", "A"), nil, metrics.NoopMetrics)
+	require.NoError(t, err, "failed to launch host A")
+	defer hostA.Close()
+	hostB, err := confB.Host(testlog.Logger(t, log.LevelError).New("host", "B"), nil, metrics.NoopMetrics)
+	require.NoError(t, err, "failed to launch host B")
+	defer hostB.Close()
+	extraB := hostB.(*extraHost)
+
+	addrsA, err := peer.AddrInfoToP2pAddrs(&peer.AddrInfo{ID: hostA.ID(), Addrs: hostA.Addrs()})
+	require.NoError(t, err)
+	addrsB, err := peer.AddrInfoToP2pAddrs(&peer.AddrInfo{ID: hostB.ID(), Addrs: hostB.Addrs()})
+	require.NoError(t, err)
+
+	_, err = extraB.AddStaticPeer(addrsB[0])
+	require.ErrorIs(t, err, ErrStaticPeerSelf)
+
+	id, err := extraB.AddStaticPeer(addrsA[0])
+	require.NoError(t, err)
+	require.Equal(t, hostA.ID(), id)
+	require.True(t, extraB.IsStatic(hostA.ID()))
+	require.True(t, extraB.ConnectionManager().IsProtected(hostA.ID(), staticPeerTag))
+
+	connected := func() bool {
+		extraB.checkStaticPeers()
+		peers := extraB.StaticPeers()
+		return len(peers) == 1 && peers[0].Connected
+	}
+	require.Eventually(t, connected, 10*time.Second, 10*time.Millisecond, "static peer must be dialed when added")
+
+	// When the static peer drops the connection, it is reconnected.
+	require.NoError(t, hostA.Network().ClosePeer(hostB.ID()))
+	require.Eventually(t, func() bool {
+		extraB.checkStaticPeers()
+		return !extraB.StaticPeers()[0].Connected
+	}, 10*time.Second, 10*time.Millisecond, "disconnect must be detected")
+	require.Eventually(t, connected, 10*time.Second, 10*time.Millisecond, "static peer must be reconnected")
+	info := extraB.StaticPeers()[0]
+	require.Equal(t, hostA.ID(), info.PeerID)
+	require.NotZero(t, info.LastConnected)
+	require.Zero(t, info.FailedDials)
+
+	require.NoError(t, extraB.RemoveStaticPeer(hostA.ID()))
+	require.False(t, extraB.IsStatic(hostA.ID()))
+	require.False(t, extraB.ConnectionManager().IsProtected(hostA.ID(), staticPeerTag))
+	require.Empty(t, extraB.StaticPeers())
+	require.ErrorIs(t, extraB.RemoveStaticPeer(hostA.ID()), ErrStaticPeerUnknown)
+}
+
+func TestStaticPeerBackoff(t *testing.T) {
+	conf := TestingConfig(t)
+	h, err := conf.Host(testlog.Logger(t, log.LevelError), nil, metrics.NoopMetrics)
+	require.NoError(t, err)
+	defer h.Close()
+	extra := h.(*extraHost)
+
+	// A static peer that isn't listening, so dials fail.
+	pOther, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	idOther, err := peer.IDFromPublicKey(pOther.GetPublic())
+	require.NoError(t, err)
+	addr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/1/p2p/" + idOther.String())
+	require.NoError(t, err)
+	_, err = extra.AddStaticPeer(addr)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		peers := extra.StaticPeers()
+		return peers[0].FailedDials == 1
+	}, 10*time.Second, 10*time.Millisecond)
+	info := extra.StaticPeers()[0]
+	require.False(t, info.Connected)
+	require.NotZero(t, info.NextDial)
+
+	// The peer is not redialed before the backoff expires.
+	extra.checkStaticPeers()
+	extra.staticPeersLock.Lock()
+	require.False(t, extra.staticPeers[idOther].dialing)
+	extra.staticPeersLock.Unlock()
+}
+
+func TestStaticPeerDialCancelledOnClose(t *testing.T) {
+	conf := TestingConfig(t)
+	conf.TimeoutNegotiation = time.Minute
+	conf.TimeoutDial = time.Minute
+	h, err := conf.Host(testlog.Logger(t, log.LevelError), nil, metrics.NoopMetrics)
+	require.NoError(t, err)
+	extra := h.(*extraHost)
+
+	// A static peer that accepts connections, but never completes the handshake, so the dial hangs.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+	pOther, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	idOther, err := peer.IDFromPublicKey(pOther.GetPublic())
+	require.NoError(t, err)
+	addr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/127.0.0.1/tcp/%d/p2p/%s", listener.Addr().(*net.TCPAddr).Port, idOther))
+	require.NoError(t, err)
+	_, err = extra.AddStaticPeer(addr)
+	require.NoError(t, err)
+	select {
+	case conn := <-accepted:
+		defer conn.Close()
+	case <-time.After(10 * time.Second):
+		t.Fatal("static peer was not dialed")
+	}
+
+	closed := make(chan error)
+	go func() { closed <- h.Close() }()
+	select {
+	case err := <-closed:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("close must cancel the static peer dial")
+	}
+	extra.checkStaticPeers()
+	extra.staticPeersLock.Lock()
+	require.Equal(t, 1, extra.staticPeers[idOther].failedDials, "no dials after close")
+	extra.staticPeersLock.Unlock()
+}
+
 type mockGossipIn struct {
 	OnUnsafeL2PayloadFn func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error
 }
@@ -202,6 +334,11 @@ func TestP2PFull(t *testing.T) {
 	require.Error(t, p2pClientA.UnprotectPeer(ctx, ""))
 	require.Error(t, p2pClientA.ConnectPeer(ctx, ""))
 	require.Error(t, p2pClientA.DisconnectPeer(ctx, ""))
+	require.Error(t, p2pClientA.AddStaticPeer(ctx, ""))
+	require.Error(t, p2pClientA.RemoveStaticPeer(ctx, ""))
+	staticPeers, err := p2pClientA.ListStaticPeers(ctx)
+	require.NoError(t, err)
+	require.Empty(t, staticPeers)
 
 	require.NoError(t, p2pClientA.BlockAddr(ctx, net.IP{123, 123, 123, 123}))
 	blockedIPs, err := p2pClientA.ListBlockedAddrs(ctx)
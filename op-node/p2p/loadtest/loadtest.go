@@ -0,0 +1,390 @@
+// Package loadtest measures the propagation of unsafe blocks through a network of gossip-only nodes,
+// to evaluate changes to the gossip topology and peer scoring parameters.
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Config configures a load test.
+type Config struct {
+	// Nodes is the number of gossip nodes, including the publishing node
+	Nodes int
+	// Degree is the minimum number of peers each node connects to
+	Degree int
+	// Rate is the number of payloads published per second
+	Rate float64
+	// Duration is the time to publish payloads for
+	Duration time.Duration
+	// Warmup is the time for the gossip meshes to form before publishing
+	Warmup time.Duration
+	// Drain is the time to wait for the last payloads to propagate after publishing
+	Drain time.Duration
+	// PayloadSize is the size in bytes of the transaction data of each payload
+	PayloadSize int
+
+	// Latency is the latency of each link between two nodes
+	Latency time.Duration
+	// Bandwidth is the bandwidth in bytes per second of each link between two nodes, unlimited if 0
+	Bandwidth float64
+
+	MeshD     int
+	MeshDLo   int
+	MeshDHi   int
+	MeshDLazy int
+	// FloodPublish publishes payloads to all peers of the topic, not only to the mesh peers
+	FloodPublish bool
+	// Scoring is the peer scoring level: "light" or "none"
+	Scoring string
+
+	// Seed seeds the random topology and payload data
+	Seed int64
+}
+
+// DefaultConfig returns the configuration of a small load test, with the gossip parameters of op-node.
+func DefaultConfig() Config {
+	return Config{
+		Nodes:       20,
+		Degree:      4,
+		Rate:        1,
+		Duration:    30 * time.Second,
+		Warmup:      5 * time.Second,
+		Drain:       5 * time.Second,
+		PayloadSize: 10_000,
+		Latency:     50 * time.Millisecond,
+		MeshD:       p2p.DefaultMeshD,
+		MeshDLo:     p2p.DefaultMeshDlo,
+		MeshDHi:     p2p.DefaultMeshDhi,
+		MeshDLazy:   p2p.DefaultMeshDlazy,
+		Scoring:     "light",
+	}
+}
+
+func (c *Config) Check() error {
+	if c.Nodes < 2 {
+		return errors.New("load test requires at least 2 nodes")
+	}
+	if c.Degree < 1 || c.Degree >= c.Nodes {
+		return fmt.Errorf("degree %d must be at least 1 and less than the number of nodes %d", c.Degree, c.Nodes)
+	}
+	if c.Rate <= 0 {
+		return fmt.Errorf("rate must be positive: %v", c.Rate)
+	}
+	if c.Duration <= 0 {
+		return fmt.Errorf("duration must be positive: %v", c.Duration)
+	}
+	if c.PayloadSize < 0 {
+		return fmt.Errorf("payload size must not be negative: %d", c.PayloadSize)
+	}
+	if c.MeshDLo > c.MeshD || c.MeshD > c.MeshDHi {
+		return fmt.Errorf("mesh parameters must satisfy d-lo (%d) <= d (%d) <= d-hi (%d)", c.MeshDLo, c.MeshD, c.MeshDHi)
+	}
+	if c.Scoring != "light" && c.Scoring != "none" {
+		return fmt.Errorf("unknown peer scoring level: %q", c.Scoring)
+	}
+	return nil
+}
+
+// Result is the outcome of a load test.
+type Result struct {
+	Nodes     int `json:"nodes"`
+	Links     int `json:"links"`
+	Published int `json:"published"`
+	// Expected is the number of payload deliveries to the nodes other than the publisher
+	Expected int `json:"expected"`
+	Received int `json:"received"`
+	// Loss is the fraction of expected deliveries that were not received
+	Loss float64 `json:"loss"`
+
+	LatencyP50 time.Duration `json:"latencyP50"`
+	LatencyP90 time.Duration `json:"latencyP90"`
+	LatencyP99 time.Duration `json:"latencyP99"`
+	LatencyMax time.Duration `json:"latencyMax"`
+}
+
+// tracker records the publication and receipt times of payloads.
+type tracker struct {
+	mu        sync.Mutex
+	published map[common.Hash]time.Time
+	latencies []time.Duration
+}
+
+func (t *tracker) publish(hash common.Hash, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.published[hash] = at
+}
+
+func (t *tracker) receive(hash common.Hash, at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if published, ok := t.published[hash]; ok {
+		t.latencies = append(t.latencies, at.Sub(published))
+	}
+}
+
+// receiver is the gossip input of a node that doesn't publish.
+type receiver struct {
+	tracker *tracker
+}
+
+func (r *receiver) OnUnsafeL2Payload(_ context.Context, _ peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
+	r.tracker.receive(envelope.ExecutionPayload.BlockHash, time.Now())
+	return nil
+}
+
+// publisherInput is the gossip input of the publishing node, which ignores its own payloads.
+type publisherInput struct{}
+
+func (publisherInput) OnUnsafeL2Payload(_ context.Context, _ peer.ID, _ *eth.ExecutionPayloadEnvelope) error {
+	return nil
+}
+
+type runtimeConfig struct {
+	sequencer common.Address
+}
+
+func (c *runtimeConfig) P2PSequencerAddress() common.Address {
+	return c.sequencer
+}
+
+type gossipConfig struct {
+	p2p.Config
+	scoring *p2p.ScoringParams
+}
+
+func (c *gossipConfig) PeerScoringParams() *p2p.ScoringParams {
+	return c.scoring
+}
+
+// noopScorer doesn't apply any application score, so only the gossip parameters affect the peer scores.
+type noopScorer struct{}
+
+func (noopScorer) SnapshotHook() pubsub.ExtendedPeerScoreInspectFn {
+	return func(map[peer.ID]*pubsub.PeerScoreSnapshot) {}
+}
+
+func (noopScorer) ApplicationScore(peer.ID) float64 {
+	return 0
+}
+
+type noopGossipMetrics struct{}
+
+func (noopGossipMetrics) RecordGossipEvent(int32) {}
+
+// Run runs the load test: it connects the nodes in a random topology, over an in-memory network,
+// and publishes signed payloads from the first node, measuring how long the payloads take to reach the other nodes.
+func Run(ctx context.Context, logger log.Logger, cfg Config) (*Result, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	rollupCfg := &rollup.Config{L2ChainID: big.NewInt(901), BlockTime: 2}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate sequencer key: %w", err)
+	}
+	runCfg := &runtimeConfig{sequencer: crypto.PubkeyToAddress(key.PublicKey)}
+	scoring, err := p2p.GetScoringParams(cfg.Scoring, rollupCfg)
+	if err != nil {
+		return nil, err
+	}
+	gossipCfg := &gossipConfig{
+		Config: p2p.Config{
+			MeshD:        cfg.MeshD,
+			MeshDLo:      cfg.MeshDLo,
+			MeshDHi:      cfg.MeshDHi,
+			MeshDLazy:    cfg.MeshDLazy,
+			FloodPublish: cfg.FloodPublish,
+		},
+		scoring: scoring,
+	}
+
+	mnet := mocknet.New()
+	defer mnet.Close()
+	mnet.SetLinkDefaults(mocknet.LinkOptions{Latency: cfg.Latency, Bandwidth: cfg.Bandwidth})
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	tr := &tracker{published: make(map[common.Hash]time.Time)}
+	hosts := make([]host.Host, cfg.Nodes)
+	var out p2p.GossipOut
+	for i := range hosts {
+		h, err := mnet.GenPeer()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create node %d: %w", i, err)
+		}
+		hosts[i] = h
+		nodeLog := logger.New("node", i)
+		ps, err := p2p.NewGossipSub(runCtx, h, rollupCfg, gossipCfg, noopScorer{}, noopGossipMetrics{}, nodeLog)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gossip of node %d: %w", i, err)
+		}
+		var in p2p.GossipIn = &receiver{tracker: tr}
+		if i == 0 {
+			in = publisherInput{}
+		}
+		gossip, err := p2p.JoinGossip(h.ID(), ps, nodeLog, rollupCfg, runCfg, in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to join gossip of node %d: %w", i, err)
+		}
+		defer gossip.Close()
+		if i == 0 {
+			out = gossip
+		}
+	}
+
+	links := topology(rng, cfg.Nodes, cfg.Degree)
+	for _, link := range links {
+		a, b := hosts[link[0]].ID(), hosts[link[1]].ID()
+		if _, err := mnet.LinkPeers(a, b); err != nil {
+			return nil, fmt.Errorf("failed to link nodes %d and %d: %w", link[0], link[1], err)
+		}
+		if _, err := mnet.ConnectPeers(a, b); err != nil {
+			return nil, fmt.Errorf("failed to connect nodes %d and %d: %w", link[0], link[1], err)
+		}
+	}
+	logger.Info("Connected nodes", "nodes", cfg.Nodes, "links", len(links))
+
+	if err := sleep(ctx, cfg.Warmup); err != nil {
+		return nil, err
+	}
+
+	signer := p2p.NewLocalSigner(key)
+	published, err := publish(ctx, logger, cfg, rng, out, signer, tr)
+	if err != nil {
+		return nil, err
+	}
+	if err := sleep(ctx, cfg.Drain); err != nil {
+		return nil, err
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	return summarize(cfg.Nodes, len(links), published, tr.latencies), nil
+}
+
+// publish publishes payloads at the configured rate, and returns the number of published payloads.
+func publish(ctx context.Context, logger log.Logger, cfg Config, rng *rand.Rand, out p2p.GossipOut, signer p2p.Signer, tr *tracker) (int, error) {
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / cfg.Rate))
+	defer ticker.Stop()
+	end := time.After(cfg.Duration)
+	published := 0
+	var parent common.Hash
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-end:
+			return published, nil
+		case <-ticker.C:
+			envelope := syntheticPayload(rng, uint64(published+1), parent, cfg.PayloadSize)
+			hash := envelope.ExecutionPayload.BlockHash
+			tr.publish(hash, time.Now())
+			if err := out.PublishL2Payload(ctx, envelope, signer); err != nil {
+				return 0, fmt.Errorf("failed to publish payload %d: %w", published, err)
+			}
+			logger.Debug("Published payload", "number", published+1, "hash", hash)
+			parent = hash
+			published++
+		}
+	}
+}
+
+// syntheticPayload creates a payload with random transaction data, that passes the gossip validation.
+func syntheticPayload(rng *rand.Rand, number uint64, parent common.Hash, size int) *eth.ExecutionPayloadEnvelope {
+	tx := make(eth.Data, size)
+	rng.Read(tx)
+	payload := &eth.ExecutionPayload{
+		ParentHash:   parent,
+		BlockNumber:  eth.Uint64Quantity(number),
+		Timestamp:    hexutil.Uint64(time.Now().Unix()),
+		GasLimit:     30_000_000,
+		Transactions: []eth.Data{tx},
+	}
+	envelope := &eth.ExecutionPayloadEnvelope{ExecutionPayload: payload}
+	payload.BlockHash, _ = envelope.CheckBlockHash()
+	return envelope
+}
+
+// topology returns the links of a random connected topology, in which each node has at least the given degree.
+func topology(rng *rand.Rand, nodes int, degree int) [][2]int {
+	linked := make(map[[2]int]bool)
+	peers := make([]int, nodes)
+	var links [][2]int
+	link := func(a, b int) {
+		key := [2]int{min(a, b), max(a, b)}
+		if a == b || linked[key] {
+			return
+		}
+		linked[key] = true
+		peers[a]++
+		peers[b]++
+		links = append(links, key)
+	}
+	// a ring keeps all nodes connected
+	for i := 0; i < nodes; i++ {
+		link(i, (i+1)%nodes)
+	}
+	for i := 0; i < nodes; i++ {
+		for peers[i] < degree {
+			link(i, rng.Intn(nodes))
+		}
+	}
+	return links
+}
+
+func summarize(nodes int, links int, published int, latencies []time.Duration) *Result {
+	res := &Result{
+		Nodes:     nodes,
+		Links:     links,
+		Published: published,
+		Expected:  published * (nodes - 1),
+		Received:  len(latencies),
+	}
+	if res.Expected > 0 {
+		res.Loss = float64(res.Expected-res.Received) / float64(res.Expected)
+	}
+	if len(latencies) == 0 {
+		return res
+	}
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+	percentile := func(p float64) time.Duration {
+		return sorted[int(p*float64(len(sorted)-1))]
+	}
+	res.LatencyP50 = percentile(0.5)
+	res.LatencyP90 = percentile(0.9)
+	res.LatencyP99 = percentile(0.99)
+	res.LatencyMax = sorted[len(sorted)-1]
+	return res
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package loadtest
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestRun(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Nodes = 5
+	cfg.Degree = 2
+	cfg.Rate = 10
+	cfg.Duration = time.Second
+	cfg.Warmup = time.Second
+	cfg.Drain = time.Second
+	cfg.PayloadSize = 1000
+	cfg.Latency = 10 * time.Millisecond
+
+	res, err := Run(context.Background(), testlog.Logger(t, log.LevelInfo), cfg)
+	require.NoError(t, err)
+	require.Equal(t, 5, res.Nodes)
+	require.Greater(t, res.Published, 0)
+	require.Equal(t, res.Published*4, res.Expected)
+	require.Equal(t, res.Expected, res.Received)
+	require.Zero(t, res.Loss)
+	require.GreaterOrEqual(t, res.LatencyP50, cfg.Latency, "payloads cross at least one link")
+	require.LessOrEqual(t, res.LatencyP50, res.LatencyP99)
+	require.LessOrEqual(t, res.LatencyP99, res.LatencyMax)
+}
+
+func TestTopology(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	links := topology(rng, 10, 3)
+	degrees := make([]int, 10)
+	seen := make(map[[2]int]bool)
+	for _, link := range links {
+		require.Less(t, link[0], link[1])
+		require.False(t, seen[link], "duplicate link %v", link)
+		seen[link] = true
+		degrees[link[0]]++
+		degrees[link[1]]++
+	}
+	for i, degree := range degrees {
+		require.GreaterOrEqualf(t, degree, 3, "node %d", i)
+	}
+	require.True(t, seen[[2]int{0, 9}], "ring connects the last node to the first")
+}
+
+func TestConfigCheck(t *testing.T) {
+	cfg := DefaultConfig()
+	require.NoError(t, cfg.Check())
+	cfg.Degree = cfg.Nodes
+	require.ErrorContains(t, cfg.Check(), "degree")
+	cfg = DefaultConfig()
+	cfg.Scoring = "heavy"
+	require.ErrorContains(t, cfg.Check(), "scoring")
+}
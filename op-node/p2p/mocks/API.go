@@ -28,6 +28,49 @@ func (_m *API) EXPECT() *API_Expecter {
 	return &API_Expecter{mock: &_m.Mock}
 }
 
+// AddStaticPeer provides a mock function with given fields: ctx, addr
+func (_m *API) AddStaticPeer(ctx context.Context, addr string) error {
+	ret := _m.Called(ctx, addr)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, addr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// API_AddStaticPeer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddStaticPeer'
+type API_AddStaticPeer_Call struct {
+	*mock.Call
+}
+
+// AddStaticPeer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - addr string
+func (_e *API_Expecter) AddStaticPeer(ctx interface{}, addr interface{}) *API_AddStaticPeer_Call {
+	return &API_AddStaticPeer_Call{Call: _e.mock.On("AddStaticPeer", ctx, addr)}
+}
+
+func (_c *API_AddStaticPeer_Call) Run(run func(ctx context.Context, addr string)) *API_AddStaticPeer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *API_AddStaticPeer_Call) Return(_a0 error) *API_AddStaticPeer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *API_AddStaticPeer_Call) RunAndReturn(run func(context.Context, string) error) *API_AddStaticPeer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BlockAddr provides a mock function with given fields: ctx, ip
 func (_m *API) BlockAddr(ctx context.Context, ip net.IP) error {
 	ret := _m.Called(ctx, ip)
@@ -459,6 +502,60 @@ func (_c *API_ListBlockedSubnets_Call) RunAndReturn(run func(context.Context) ([
 	return _c
 }
 
+// ListStaticPeers provides a mock function with given fields: ctx
+func (_m *API) ListStaticPeers(ctx context.Context) ([]p2p.StaticPeerInfo, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []p2p.StaticPeerInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]p2p.StaticPeerInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []p2p.StaticPeerInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]p2p.StaticPeerInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// API_ListStaticPeers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStaticPeers'
+type API_ListStaticPeers_Call struct {
+	*mock.Call
+}
+
+// ListStaticPeers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *API_Expecter) ListStaticPeers(ctx interface{}) *API_ListStaticPeers_Call {
+	return &API_ListStaticPeers_Call{Call: _e.mock.On("ListStaticPeers", ctx)}
+}
+
+func (_c *API_ListStaticPeers_Call) Run(run func(ctx context.Context)) *API_ListStaticPeers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *API_ListStaticPeers_Call) Return(_a0 []p2p.StaticPeerInfo, _a1 error) *API_ListStaticPeers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *API_ListStaticPeers_Call) RunAndReturn(run func(context.Context) ([]p2p.StaticPeerInfo, error)) *API_ListStaticPeers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PeerStats provides a mock function with given fields: ctx
 func (_m *API) PeerStats(ctx context.Context) (*p2p.PeerStats, error) {
 	ret := _m.Called(ctx)
@@ -611,6 +708,49 @@ func (_c *API_ProtectPeer_Call) RunAndReturn(run func(context.Context, peer.ID)
 	return _c
 }
 
+// RemoveStaticPeer provides a mock function with given fields: ctx, id
+func (_m *API) RemoveStaticPeer(ctx context.Context, id peer.ID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, peer.ID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// API_RemoveStaticPeer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveStaticPeer'
+type API_RemoveStaticPeer_Call struct {
+	*mock.Call
+}
+
+// RemoveStaticPeer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id peer.ID
+func (_e *API_Expecter) RemoveStaticPeer(ctx interface{}, id interface{}) *API_RemoveStaticPeer_Call {
+	return &API_RemoveStaticPeer_Call{Call: _e.mock.On("RemoveStaticPeer", ctx, id)}
+}
+
+func (_c *API_RemoveStaticPeer_Call) Run(run func(ctx context.Context, id peer.ID)) *API_RemoveStaticPeer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(peer.ID))
+	})
+	return _c
+}
+
+func (_c *API_RemoveStaticPeer_Call) Return(_a0 error) *API_RemoveStaticPeer_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *API_RemoveStaticPeer_Call) RunAndReturn(run func(context.Context, peer.ID) error) *API_RemoveStaticPeer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Self provides a mock function with given fields: ctx
 func (_m *API) Self(ctx context.Context) (*p2p.PeerInfo, error) {
 	ret := _m.Called(ctx)
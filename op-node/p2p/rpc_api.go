@@ -60,4 +60,7 @@ type API interface {
 	UnprotectPeer(ctx context.Context, p peer.ID) error
 	ConnectPeer(ctx context.Context, addr string) error
 	DisconnectPeer(ctx context.Context, id peer.ID) error
+	AddStaticPeer(ctx context.Context, addr string) error
+	RemoveStaticPeer(ctx context.Context, id peer.ID) error
+	ListStaticPeers(ctx context.Context) ([]StaticPeerInfo, error)
 }
@@ -107,3 +107,17 @@ func (c *Client) ConnectPeer(ctx context.Context, addr string) error {
 func (c *Client) DisconnectPeer(ctx context.Context, id peer.ID) error {
 	return c.c.CallContext(ctx, nil, prefixRPC("disconnectPeer"), id)
 }
+
+func (c *Client) AddStaticPeer(ctx context.Context, addr string) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("addStaticPeer"), addr)
+}
+
+func (c *Client) RemoveStaticPeer(ctx context.Context, id peer.ID) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("removeStaticPeer"), id)
+}
+
+func (c *Client) ListStaticPeers(ctx context.Context) ([]StaticPeerInfo, error) {
+	var out []StaticPeerInfo
+	err := c.c.CallContext(ctx, &out, prefixRPC("listStaticPeers"))
+	return out, err
+}
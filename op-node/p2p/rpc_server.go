@@ -19,6 +19,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
 
 	gcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
@@ -37,6 +38,7 @@ var (
 	ErrNoConnectionManager = errors.New("no connection manager")
 	ErrNoConnectionGater   = errors.New("no connection gater")
 	ErrInvalidRequest      = errors.New("invalid request")
+	ErrNoStaticPeers       = errors.New("static peers not supported by host")
 )
 
 type Node interface {
@@ -428,3 +430,51 @@ func (s *APIBackend) DisconnectPeer(_ context.Context, id peer.ID) error {
 	}
 	return nil
 }
+
+func (s *APIBackend) staticPeerHost() (ExtraHostFeatures, error) {
+	if extra, ok := s.node.Host().(ExtraHostFeatures); ok {
+		return extra, nil
+	}
+	return nil, ErrNoStaticPeers
+}
+
+// AddStaticPeer adds a static peer, which is kept connected with automatic reconnects until it is removed.
+func (s *APIBackend) AddStaticPeer(_ context.Context, addr string) error {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_addStaticPeer")
+	defer recordDur()
+	maddr, err := ma.NewMultiaddr(addr)
+	if err != nil {
+		return fmt.Errorf("bad peer address: %w", err)
+	}
+	extra, err := s.staticPeerHost()
+	if err != nil {
+		return err
+	}
+	_, err = extra.AddStaticPeer(maddr)
+	return err
+}
+
+// RemoveStaticPeer stops keeping the static peer connected. It does not disconnect the peer.
+func (s *APIBackend) RemoveStaticPeer(_ context.Context, id peer.ID) error {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_removeStaticPeer")
+	if err := id.Validate(); err != nil {
+		s.log.Warn("invalid peer ID", "method", "RemoveStaticPeer", "peer", id, "err", err)
+		return ErrInvalidRequest
+	}
+	defer recordDur()
+	extra, err := s.staticPeerHost()
+	if err != nil {
+		return err
+	}
+	return extra.RemoveStaticPeer(id)
+}
+
+func (s *APIBackend) ListStaticPeers(_ context.Context) ([]StaticPeerInfo, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_listStaticPeers")
+	defer recordDur()
+	extra, err := s.staticPeerHost()
+	if err != nil {
+		return nil, err
+	}
+	return extra.StaticPeers(), nil
+}
@@ -0,0 +1,228 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+const (
+	// staticPeerPollInterval is how often the connectedness of static peers is checked.
+	staticPeerPollInterval = 5 * time.Second
+	// staticPeerDialTimeout limits the duration of a single dial attempt to a static peer.
+	staticPeerDialTimeout = 30 * time.Second
+	// staticPeerAddrTTL is how long static peer addresses are kept in the peerstore.
+	staticPeerAddrTTL = time.Hour * 24 * 7
+)
+
+var (
+	ErrStaticPeerSelf    = errors.New("cannot add local peer as static peer")
+	ErrStaticPeerUnknown = errors.New("unknown static peer")
+)
+
+// staticPeerBackoff is the reconnect policy for static peers: exponential backoff after consecutive failed dials,
+// capped to a minute, with some jitter so a restarted peer isn't redialed by the whole mesh at once.
+var staticPeerBackoff = &retry.ExponentialStrategy{
+	Min:       0,
+	Max:       time.Minute,
+	MaxJitter: time.Second,
+}
+
+type StaticPeerMetrics interface {
+	RecordStaticPeerConnected(peerID string, connected bool)
+	RecordStaticPeerDial(peerID string, success bool)
+	RemoveStaticPeer(peerID string)
+}
+
+// StaticPeerInfo describes the state of a static peer connection.
+type StaticPeerInfo struct {
+	PeerID peer.ID  `json:"peerID"`
+	Addrs  []string `json:"addrs"`
+
+	Connected bool `json:"connected"`
+	// LastConnected is the unix timestamp the peer was last seen connected, 0 if never.
+	LastConnected uint64 `json:"lastConnected"`
+	// FailedDials is the number of consecutive failed dials.
+	FailedDials int `json:"failedDials"`
+	// NextDial is the unix timestamp of the next reconnect attempt, 0 if connected.
+	NextDial uint64 `json:"nextDial"`
+}
+
+type staticPeer struct {
+	addr *peer.AddrInfo
+
+	connected     bool
+	lastConnected time.Time
+	failedDials   int
+	nextDial      time.Time
+	dialing       bool
+}
+
+func (s *staticPeer) info() StaticPeerInfo {
+	addrs := make([]string, len(s.addr.Addrs))
+	for i, addr := range s.addr.Addrs {
+		addrs[i] = addr.String()
+	}
+	out := StaticPeerInfo{
+		PeerID:      s.addr.ID,
+		Addrs:       addrs,
+		Connected:   s.connected,
+		FailedDials: s.failedDials,
+	}
+	if !s.lastConnected.IsZero() {
+		out.LastConnected = uint64(s.lastConnected.Unix())
+	}
+	if !s.connected && !s.nextDial.IsZero() {
+		out.NextDial = uint64(s.nextDial.Unix())
+	}
+	return out
+}
+
+// AddStaticPeer adds a static peer, protects it from pruning and starts dialing it.
+// Adding an existing static peer updates its addresses.
+func (e *extraHost) AddStaticPeer(addr ma.Multiaddr) (peer.ID, error) {
+	info, err := peer.AddrInfoFromP2pAddr(addr)
+	if err != nil {
+		return "", fmt.Errorf("bad peer address: %w", err)
+	}
+	if info.ID == e.ID() {
+		return "", ErrStaticPeerSelf
+	}
+	e.staticPeersLock.Lock()
+	if existing, ok := e.staticPeers[info.ID]; ok {
+		existing.addr.Addrs = append(existing.addr.Addrs, info.Addrs...)
+		existing.addr.Addrs = ma.Unique(existing.addr.Addrs)
+		existing.nextDial = time.Time{}
+		e.staticPeersLock.Unlock()
+		e.Peerstore().AddAddrs(info.ID, info.Addrs, staticPeerAddrTTL)
+		return info.ID, nil
+	}
+	e.staticPeers[info.ID] = &staticPeer{addr: info}
+	e.staticPeersLock.Unlock()
+
+	e.Peerstore().AddAddrs(info.ID, info.Addrs, staticPeerAddrTTL)
+	// We protect the peer, so the connection manager doesn't decide to prune it.
+	// We tag it with "static" so other protects/unprotects with different tags don't affect this protection.
+	e.connMgr.Protect(info.ID, staticPeerTag)
+	e.log.Info("added static peer", "peer", info.ID, "addrs", info.Addrs)
+	e.metrics.RecordStaticPeerConnected(info.ID.String(), e.Network().Connectedness(info.ID) == network.Connected)
+	e.checkStaticPeers()
+	return info.ID, nil
+}
+
+// RemoveStaticPeer stops supervising the connection to the static peer and unprotects it.
+// The peer is not disconnected, it may stay connected as a regular peer.
+func (e *extraHost) RemoveStaticPeer(id peer.ID) error {
+	e.staticPeersLock.Lock()
+	_, ok := e.staticPeers[id]
+	delete(e.staticPeers, id)
+	e.staticPeersLock.Unlock()
+	if !ok {
+		return ErrStaticPeerUnknown
+	}
+	e.connMgr.Unprotect(id, staticPeerTag)
+	e.metrics.RemoveStaticPeer(id.String())
+	e.log.Info("removed static peer", "peer", id)
+	return nil
+}
+
+// StaticPeers returns the state of all static peers.
+func (e *extraHost) StaticPeers() []StaticPeerInfo {
+	e.staticPeersLock.Lock()
+	defer e.staticPeersLock.Unlock()
+	out := make([]StaticPeerInfo, 0, len(e.staticPeers))
+	for _, p := range e.staticPeers {
+		out = append(out, p.info())
+	}
+	return out
+}
+
+// checkStaticPeers updates the liveness of all static peers, and redials the disconnected peers that are due.
+func (e *extraHost) checkStaticPeers() {
+	now := e.clock.Now()
+	e.staticPeersLock.Lock()
+	defer e.staticPeersLock.Unlock()
+	select {
+	case <-e.quitC: // closed, stop dialing
+		return
+	default:
+	}
+	e.log.Trace("polling static peers", "peers", len(e.staticPeers))
+	for id, p := range e.staticPeers {
+		connected := e.Network().Connectedness(id) == network.Connected
+		if connected != p.connected {
+			e.metrics.RecordStaticPeerConnected(id.String(), connected)
+			if !connected {
+				e.log.Warn("static peer disconnected", "peer", id)
+			}
+		}
+		p.connected = connected
+		if connected {
+			p.lastConnected = now
+			p.failedDials = 0
+			p.nextDial = time.Time{}
+			continue
+		}
+		if p.dialing || now.Before(p.nextDial) {
+			continue
+		}
+		p.dialing = true
+		e.staticDials.Add(1)
+		go e.dialStaticPeer(peer.AddrInfo{ID: id, Addrs: slices.Clone(p.addr.Addrs)})
+	}
+}
+
+func (e *extraHost) dialStaticPeer(addr peer.AddrInfo) {
+	defer e.staticDials.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), staticPeerDialTimeout)
+	defer cancel()
+	// The dial is cancelled when the host is closed
+	go func() {
+		select {
+		case <-e.quitC:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	e.log.Info("dialing static peer", "peer", addr.ID, "addrs", addr.Addrs)
+	_, err := e.Network().DialPeer(ctx, addr.ID)
+	e.metrics.RecordStaticPeerDial(addr.ID.String(), err == nil)
+
+	e.staticPeersLock.Lock()
+	defer e.staticPeersLock.Unlock()
+	p, ok := e.staticPeers[addr.ID]
+	if !ok { // removed while dialing
+		return
+	}
+	p.dialing = false
+	if err != nil {
+		p.nextDial = e.clock.Now().Add(staticPeerBackoff.Duration(p.failedDials))
+		p.failedDials++
+		e.log.Warn("error dialing static peer", "peer", addr.ID, "err", err, "failedDials", p.failedDials, "nextDial", p.nextDial)
+		return
+	}
+	p.failedDials = 0
+	p.nextDial = time.Time{}
+}
+
+func (e *extraHost) monitorStaticPeers() {
+	tick := e.clock.NewTicker(staticPeerPollInterval)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-tick.Ch():
+			e.checkStaticPeers()
+		case <-e.quitC:
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package rollup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrMissingBatcherKeyAddress = errors.New("batcher key address cannot be empty")
+	ErrInvalidBatcherKeyWindow  = errors.New("batcher key must be deactivated after it is activated")
+)
+
+// BatcherKey is a batcher address that batches are accepted from during a window of L1 time.
+// Scheduling batcher keys allows a chain to rotate its batcher key by protocol rule,
+// with an overlap in which both the old and the new key are accepted, so the batcher can switch keys without downtime.
+type BatcherKey struct {
+	Address common.Address `json:"address"`
+	// ActivationTime is the L1 block time from which batches from Address are accepted.
+	ActivationTime uint64 `json:"activation_time"`
+	// DeactivationTime is the L1 block time from which batches from Address are no longer accepted.
+	// The key is never deactivated if nil.
+	DeactivationTime *uint64 `json:"deactivation_time,omitempty"`
+}
+
+// IsActive returns true if batches from the key are accepted in a L1 block with the given time.
+func (k BatcherKey) IsActive(l1Time uint64) bool {
+	return l1Time >= k.ActivationTime && (k.DeactivationTime == nil || l1Time < *k.DeactivationTime)
+}
+
+func (k BatcherKey) String() string {
+	return fmt.Sprintf("%s@[%d, %s)", k.Address, k.ActivationTime, fmtDiffValue(k.DeactivationTime))
+}
+
+// BatcherAddresses returns the addresses that batches are accepted from in a L1 block with the given time.
+// While any of the scheduled batcher keys is active, only the active batcher keys are accepted,
+// and the batcher address of the system config is not.
+// Otherwise batches are only accepted from the batcher address of the system config.
+func (cfg *Config) BatcherAddresses(l1Time uint64, sysCfgBatcher common.Address) []common.Address {
+	var out []common.Address
+	for _, key := range cfg.BatcherKeys {
+		if key.IsActive(l1Time) {
+			out = append(out, key.Address)
+		}
+	}
+	if len(out) == 0 {
+		return []common.Address{sysCfgBatcher}
+	}
+	return out
+}
+
+func validateBatcherKeys(keys []BatcherKey) error {
+	for i, key := range keys {
+		if key.Address == (common.Address{}) {
+			return fmt.Errorf("batcher key %d: %w", i, ErrMissingBatcherKeyAddress)
+		}
+		if key.DeactivationTime != nil && *key.DeactivationTime <= key.ActivationTime {
+			return fmt.Errorf("batcher key %d (%v): %w", i, key, ErrInvalidBatcherKeyWindow)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package rollup
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatcherAddresses(t *testing.T) {
+	u64 := func(v uint64) *uint64 { return &v }
+	sysCfgBatcher := common.Address{0x01}
+	oldKey := common.Address{0x02}
+	newKey := common.Address{0x03}
+	cfg := &Config{BatcherKeys: []BatcherKey{
+		{Address: oldKey, ActivationTime: 100, DeactivationTime: u64(200)},
+		{Address: newKey, ActivationTime: 150},
+	}}
+
+	require.Equal(t, []common.Address{sysCfgBatcher}, cfg.BatcherAddresses(99, sysCfgBatcher), "system config batcher before any key is active")
+	require.Equal(t, []common.Address{oldKey}, cfg.BatcherAddresses(100, sysCfgBatcher))
+	require.Equal(t, []common.Address{oldKey, newKey}, cfg.BatcherAddresses(150, sysCfgBatcher), "overlap")
+	require.Equal(t, []common.Address{oldKey, newKey}, cfg.BatcherAddresses(199, sysCfgBatcher), "overlap")
+	require.Equal(t, []common.Address{newKey}, cfg.BatcherAddresses(200, sysCfgBatcher), "old key deactivated")
+
+	require.Equal(t, []common.Address{sysCfgBatcher}, (&Config{}).BatcherAddresses(1000, sysCfgBatcher), "no scheduled keys")
+}
+
+func TestValidateBatcherKeys(t *testing.T) {
+	u64 := func(v uint64) *uint64 { return &v }
+	require.NoError(t, validateBatcherKeys(nil))
+	require.NoError(t, validateBatcherKeys([]BatcherKey{{Address: common.Address{0x01}, ActivationTime: 10, DeactivationTime: u64(11)}}))
+	require.ErrorIs(t, validateBatcherKeys([]BatcherKey{{ActivationTime: 10}}), ErrMissingBatcherKeyAddress)
+	require.ErrorIs(t, validateBatcherKeys([]BatcherKey{{Address: common.Address{0x01}, ActivationTime: 10, DeactivationTime: u64(10)}}), ErrInvalidBatcherKeyWindow)
+}
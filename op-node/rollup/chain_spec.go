@@ -110,6 +110,12 @@ func (s *ChainSpec) MaxSequencerDrift(t uint64) uint64 {
 	return s.config.MaxSequencerDrift
 }
 
+// ChannelIDChainTag returns the tag that the channel IDs of the chain start with,
+// and whether channels are chain-tagged at all, see Config.ChainTaggedChannels.
+func (s *ChainSpec) ChannelIDChainTag() ([ChannelIDChainTagLength]byte, bool) {
+	return s.config.ChannelIDChainTag(), s.config.ChainTaggedChannels()
+}
+
 func (s *ChainSpec) CheckForkActivation(log log.Logger, block eth.L2BlockRef) {
 	if s.currentFork == Interop {
 		return
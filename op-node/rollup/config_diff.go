@@ -0,0 +1,219 @@
+package rollup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ConfigDiff is a difference of a single field between two configs.
+type ConfigDiff struct {
+	// Field is the JSON path of the field, e.g. "genesis.l2_time".
+	Field string
+	// A and B are the formatted values of the field in the two configs.
+	A, B string
+	// ConsensusBreaking is true if nodes running the two configs may not agree on the same L2 chain.
+	ConsensusBreaking bool
+}
+
+func (d ConfigDiff) String() string {
+	kind := "cosmetic"
+	if d.ConsensusBreaking {
+		kind = "consensus-breaking"
+	}
+	return fmt.Sprintf("%s (%s): %s != %s", d.Field, kind, d.A, d.B)
+}
+
+// ConsensusBreaking filters the consensus-breaking differences.
+func ConsensusBreaking(diffs []ConfigDiff) []ConfigDiff {
+	var out []ConfigDiff
+	for _, d := range diffs {
+		if d.ConsensusBreaking {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// Diff returns the differences between the rollup config and other.
+//
+// All fields of the rollup config take part in derivation, and a difference is consensus-breaking, except:
+//   - fork times that differ, but are both at or before the L2 genesis time, as the fork is then active from genesis in either config.
+//   - the max sequencer drift, when Fjord is active from genesis in both configs, as it is a constant since Fjord.
+//   - the protocol versions address, which is only used to signal upgrades.
+func (cfg *Config) Diff(other *Config) []ConfigDiff {
+	var diffs []ConfigDiff
+	add := func(field string, a, b any, consensus bool) {
+		as, bs := fmtDiffValue(a), fmtDiffValue(b)
+		if as != bs {
+			diffs = append(diffs, ConfigDiff{Field: field, A: as, B: bs, ConsensusBreaking: consensus})
+		}
+	}
+
+	add("genesis.l1", cfg.Genesis.L1, other.Genesis.L1, true)
+	add("genesis.l2", cfg.Genesis.L2, other.Genesis.L2, true)
+	add("genesis.l2_time", cfg.Genesis.L2Time, other.Genesis.L2Time, true)
+	add("genesis.system_config.batcherAddr", cfg.Genesis.SystemConfig.BatcherAddr, other.Genesis.SystemConfig.BatcherAddr, true)
+	add("genesis.system_config.overhead", cfg.Genesis.SystemConfig.Overhead, other.Genesis.SystemConfig.Overhead, true)
+	add("genesis.system_config.scalar", cfg.Genesis.SystemConfig.Scalar, other.Genesis.SystemConfig.Scalar, true)
+	add("genesis.system_config.gasLimit", cfg.Genesis.SystemConfig.GasLimit, other.Genesis.SystemConfig.GasLimit, true)
+	add("block_time", cfg.BlockTime, other.BlockTime, true)
+	fjordAtGenesis := cfg.IsFjord(cfg.Genesis.L2Time) && other.IsFjord(other.Genesis.L2Time)
+	add("max_sequencer_drift", cfg.MaxSequencerDrift, other.MaxSequencerDrift, !fjordAtGenesis)
+	add("seq_window_size", cfg.SeqWindowSize, other.SeqWindowSize, true)
+	add("channel_timeout", cfg.ChannelTimeoutBedrock, other.ChannelTimeoutBedrock, true)
+	add("l1_chain_id", cfg.L1ChainID, other.L1ChainID, true)
+	add("l2_chain_id", cfg.L2ChainID, other.L2ChainID, true)
+
+	forks := []struct {
+		name string
+		a, b *uint64
+	}{
+		{"regolith_time", cfg.RegolithTime, other.RegolithTime},
+		{"canyon_time", cfg.CanyonTime, other.CanyonTime},
+		{"delta_time", cfg.DeltaTime, other.DeltaTime},
+		{"ecotone_time", cfg.EcotoneTime, other.EcotoneTime},
+		{"fjord_time", cfg.FjordTime, other.FjordTime},
+		{"granite_time", cfg.GraniteTime, other.GraniteTime},
+		{"holocene_time", cfg.HoloceneTime, other.HoloceneTime},
+		{"interop_time", cfg.InteropTime, other.InteropTime},
+	}
+	for _, f := range forks {
+		atGenesis := f.a != nil && *f.a <= cfg.Genesis.L2Time && f.b != nil && *f.b <= other.Genesis.L2Time
+		add(f.name, f.a, f.b, !atGenesis)
+	}
+
+	add("batch_inbox_address", cfg.BatchInboxAddress, other.BatchInboxAddress, true)
+	add("deposit_contract_address", cfg.DepositContractAddress, other.DepositContractAddress, true)
+	add("l1_system_config_address", cfg.L1SystemConfigAddress, other.L1SystemConfigAddress, true)
+	add("protocol_versions_address", cfg.ProtocolVersionsAddress, other.ProtocolVersionsAddress, false)
+
+	var altDAA, altDAB AltDAConfig
+	if cfg.AltDAConfig != nil {
+		altDAA = *cfg.AltDAConfig
+	}
+	if other.AltDAConfig != nil {
+		altDAB = *other.AltDAConfig
+	}
+	add("alt_da.enabled", cfg.AltDAConfig != nil, other.AltDAConfig != nil, true)
+	add("alt_da.da_challenge_contract_address", altDAA.DAChallengeAddress, altDAB.DAChallengeAddress, true)
+	add("alt_da.da_commitment_type", altDAA.CommitmentType, altDAB.CommitmentType, true)
+	add("alt_da.da_challenge_window", altDAA.DAChallengeWindow, altDAB.DAChallengeWindow, true)
+	add("alt_da.da_resolve_window", altDAA.DAResolveWindow, altDAB.DAResolveWindow, true)
+	add("batcher_keys", cfg.BatcherKeys, other.BatcherKeys, true)
+	add("shared_batch_inbox_addresses", cfg.SharedBatchInboxAddresses, other.SharedBatchInboxAddresses, true)
+	add("unsafe_devnet_deposits", cfg.UnsafeDevnetDeposits, other.UnsafeDevnetDeposits, true)
+	return diffs
+}
+
+// CheckChainConfig checks the fork activation times of the L2 execution-engine chain config
+// match the forks of the rollup config.
+func (cfg *Config) CheckChainConfig(chainCfg *params.ChainConfig) error {
+	if chainCfg.ChainID == nil || cfg.L2ChainID == nil || chainCfg.ChainID.Cmp(cfg.L2ChainID) != 0 {
+		return fmt.Errorf("chain config chain ID %v does not match rollup L2 chain ID %v", chainCfg.ChainID, cfg.L2ChainID)
+	}
+	forks := []struct {
+		name      string
+		rollup    *uint64
+		chain     *uint64
+		chainName string
+	}{
+		{"regolith", cfg.RegolithTime, chainCfg.RegolithTime, "regolithTime"},
+		{"canyon", cfg.CanyonTime, chainCfg.CanyonTime, "canyonTime"},
+		{"canyon", cfg.CanyonTime, chainCfg.ShanghaiTime, "shanghaiTime"},
+		{"ecotone", cfg.EcotoneTime, chainCfg.EcotoneTime, "ecotoneTime"},
+		{"ecotone", cfg.EcotoneTime, chainCfg.CancunTime, "cancunTime"},
+		{"fjord", cfg.FjordTime, chainCfg.FjordTime, "fjordTime"},
+		{"granite", cfg.GraniteTime, chainCfg.GraniteTime, "graniteTime"},
+		{"holocene", cfg.HoloceneTime, chainCfg.HoloceneTime, "holoceneTime"},
+		{"interop", cfg.InteropTime, chainCfg.InteropTime, "interopTime"},
+	}
+	for _, f := range forks {
+		if a, b := fmtDiffValue(f.rollup), fmtDiffValue(f.chain); a != b {
+			return fmt.Errorf("rollup %s time %s does not match chain config %s %s", f.name, a, f.chainName, b)
+		}
+	}
+	return nil
+}
+
+// DiffChainConfigs returns the differences between two L2 execution-engine chain configs.
+// The chain config only holds execution rules, so any difference is consensus-breaking.
+func DiffChainConfigs(a, b *params.ChainConfig) ([]ConfigDiff, error) {
+	fieldsA, err := jsonFields(a)
+	if err != nil {
+		return nil, err
+	}
+	fieldsB, err := jsonFields(b)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]struct{})
+	for name := range fieldsA {
+		names[name] = struct{}{}
+	}
+	for name := range fieldsB {
+		names[name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var diffs []ConfigDiff
+	for _, name := range sorted {
+		va, vb := fieldsA[name], fieldsB[name]
+		if bytes.Equal(va, vb) {
+			continue
+		}
+		diffs = append(diffs, ConfigDiff{Field: name, A: fmtJSONField(va), B: fmtJSONField(vb), ConsensusBreaking: true})
+	}
+	return diffs, nil
+}
+
+func jsonFields(v any) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode config fields: %w", err)
+	}
+	for name, value := range fields {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, value); err != nil {
+			return nil, fmt.Errorf("failed to compact config field %q: %w", name, err)
+		}
+		fields[name] = compact.Bytes()
+	}
+	return fields, nil
+}
+
+func fmtJSONField(v json.RawMessage) string {
+	if v == nil {
+		return "(not configured)"
+	}
+	return string(v)
+}
+
+func fmtDiffValue(v any) string {
+	switch x := v.(type) {
+	case *uint64:
+		if x == nil {
+			return "(not configured)"
+		}
+		return fmt.Sprintf("%d", *x)
+	case *big.Int:
+		if x == nil {
+			return "(not configured)"
+		}
+		return x.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,120 @@
+package rollup
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestConfigDiff(t *testing.T) {
+	u64 := func(v uint64) *uint64 { return &v }
+
+	t.Run("identical", func(t *testing.T) {
+		a, b := diffConfigs()
+		require.Empty(t, a.Diff(b))
+	})
+
+	t.Run("consensus-breaking", func(t *testing.T) {
+		a, b := diffConfigs()
+		b.BlockTime = 1
+		b.L2ChainID = big.NewInt(902)
+		b.EcotoneTime = u64(a.Genesis.L2Time + 100)
+		b.AltDAConfig = &AltDAConfig{CommitmentType: "GenericCommitment"}
+		diffs := a.Diff(b)
+		require.Equal(t, []ConfigDiff{
+			{Field: "block_time", A: "2", B: "1", ConsensusBreaking: true},
+			{Field: "l2_chain_id", A: "901", B: "902", ConsensusBreaking: true},
+			{Field: "ecotone_time", A: "(not configured)", B: fmtDiffValue(b.EcotoneTime), ConsensusBreaking: true},
+			{Field: "alt_da.enabled", A: "false", B: "true", ConsensusBreaking: true},
+			{Field: "alt_da.da_commitment_type", A: "", B: "GenericCommitment", ConsensusBreaking: true},
+		}, diffs)
+		require.Equal(t, diffs, ConsensusBreaking(diffs))
+	})
+
+	t.Run("cosmetic", func(t *testing.T) {
+		a, b := diffConfigs()
+		b.ProtocolVersionsAddress = common.Address{0xaa}
+		// Both active from genesis
+		a.RegolithTime = u64(0)
+		b.RegolithTime = u64(b.Genesis.L2Time)
+		a.CanyonTime, b.CanyonTime = u64(0), u64(0)
+		a.DeltaTime, b.DeltaTime = u64(0), u64(0)
+		a.EcotoneTime, b.EcotoneTime = u64(0), u64(0)
+		a.FjordTime, b.FjordTime = u64(0), u64(0)
+		// Constant since Fjord
+		b.MaxSequencerDrift = 1800
+		diffs := a.Diff(b)
+		require.Len(t, diffs, 3)
+		require.Empty(t, ConsensusBreaking(diffs))
+	})
+
+	t.Run("fork at genesis in one config only", func(t *testing.T) {
+		a, b := diffConfigs()
+		a.RegolithTime = u64(0)
+		b.RegolithTime = u64(b.Genesis.L2Time + 1)
+		b.MaxSequencerDrift = 1800
+		require.Len(t, ConsensusBreaking(a.Diff(b)), 2)
+	})
+}
+
+// diffConfigs returns two identical configs.
+func diffConfigs() (*Config, *Config) {
+	a, b := randConfig(), randConfig()
+	b.Genesis.L2Time = a.Genesis.L2Time
+	return a, b
+}
+
+func TestCheckForkOrder(t *testing.T) {
+	u64 := func(v uint64) *uint64 { return &v }
+	cfg := randConfig()
+	require.NoError(t, cfg.CheckForkOrder())
+	cfg.RegolithTime = u64(10)
+	cfg.CanyonTime = u64(20)
+	require.NoError(t, cfg.CheckForkOrder())
+	cfg.DeltaTime = u64(15)
+	require.ErrorContains(t, cfg.CheckForkOrder(), "prior fork canyon")
+	require.ErrorContains(t, cfg.Check(), "prior fork canyon")
+}
+
+func TestCheckChainConfig(t *testing.T) {
+	u64 := func(v uint64) *uint64 { return &v }
+	cfg := randConfig()
+	cfg.RegolithTime = u64(0)
+	cfg.CanyonTime = u64(10)
+	chainCfg := &params.ChainConfig{
+		ChainID:      big.NewInt(901),
+		RegolithTime: u64(0),
+		CanyonTime:   u64(10),
+		ShanghaiTime: u64(10),
+	}
+	require.NoError(t, cfg.CheckChainConfig(chainCfg))
+
+	chainCfg.ShanghaiTime = nil
+	require.ErrorContains(t, cfg.CheckChainConfig(chainCfg), "shanghaiTime")
+
+	chainCfg.ShanghaiTime = u64(10)
+	chainCfg.ChainID = big.NewInt(902)
+	require.ErrorContains(t, cfg.CheckChainConfig(chainCfg), "chain ID")
+}
+
+func TestDiffChainConfigs(t *testing.T) {
+	u64 := func(v uint64) *uint64 { return &v }
+	a := &params.ChainConfig{ChainID: big.NewInt(901), CanyonTime: u64(10)}
+	b := &params.ChainConfig{ChainID: big.NewInt(901), CanyonTime: u64(10)}
+	diffs, err := DiffChainConfigs(a, b)
+	require.NoError(t, err)
+	require.Empty(t, diffs)
+
+	b.CanyonTime = u64(20)
+	b.EcotoneTime = u64(30)
+	diffs, err = DiffChainConfigs(a, b)
+	require.NoError(t, err)
+	require.Equal(t, []ConfigDiff{
+		{Field: "canyonTime", A: "10", B: "20", ConsensusBreaking: true},
+		{Field: "ecotoneTime", A: "(not configured)", B: "30", ConsensusBreaking: true},
+	}, diffs)
+}
@@ -116,6 +116,14 @@ func (ba *FetchingAttributesBuilder) PreparePayloadAttributes(ctx context.Contex
 		upgradeTxs = append(upgradeTxs, fjord...)
 	}
 
+	if devnetDeposits := ba.rollupCfg.DevnetDeposits(nextL2Time); len(devnetDeposits) > 0 {
+		devnetTxs, err := DevnetDepositTransactions(devnetDeposits)
+		if err != nil {
+			return nil, NewCriticalError(fmt.Errorf("failed to build devnet deposit txs: %w", err))
+		}
+		upgradeTxs = append(upgradeTxs, devnetTxs...)
+	}
+
 	l1InfoTx, err := L1InfoDepositBytes(ba.rollupCfg, sysConfig, seqNumber, l1Info, nextL2Time)
 	if err != nil {
 		return nil, NewCriticalError(fmt.Errorf("failed to create l1InfoTx: %w", err))
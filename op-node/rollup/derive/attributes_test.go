@@ -195,6 +195,46 @@ func TestPreparePayloadAttributes(t *testing.T) {
 		require.Equal(t, l1InfoTx, []byte(attrs.Transactions[0]))
 		require.True(t, attrs.NoTxPool)
 	})
+	t.Run("devnet deposits", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1234))
+		l1Fetcher := &testutils.MockL1Source{}
+		defer l1Fetcher.AssertExpectations(t)
+		l2Parent := testutils.RandomL2BlockRef(rng)
+		l1CfgFetcher := &testutils.MockL2Client{}
+		l1CfgFetcher.ExpectSystemConfigByL2Hash(l2Parent.Hash, testSysCfg, nil)
+		defer l1CfgFetcher.AssertExpectations(t)
+		l1Info := testutils.RandomBlockInfo(rng)
+		l1Info.InfoHash = l2Parent.L1Origin.Hash
+		l1Info.InfoNum = l2Parent.L1Origin.Number
+
+		nextL2Time := l2Parent.Time + cfg.BlockTime
+		to := common.Address{0xdd}
+		devnetCfg := *cfg
+		devnetCfg.UnsafeDevnetDeposits = []rollup.DevnetDeposit{
+			{Time: nextL2Time - 1, Intent: "Devnet: call", From: common.Address{0xee}, To: &to, Gas: 100_000, Data: []byte{1, 2, 3}},
+			{Time: nextL2Time, Intent: "Devnet: deploy", From: common.Address{0xee}, Gas: 200_000, Data: []byte{4, 5, 6}},
+			{Time: nextL2Time - cfg.BlockTime, Intent: "Devnet: previous block"},
+			{Time: nextL2Time + 1, Intent: "Devnet: next block"},
+		}
+
+		epoch := l1Info.ID()
+		l1Fetcher.ExpectInfoByHash(epoch.Hash, l1Info, nil)
+		attrBuilder := NewFetchingAttributesBuilder(&devnetCfg, l1Fetcher, l1CfgFetcher)
+		attrs, err := attrBuilder.PreparePayloadAttributes(context.Background(), l2Parent, epoch)
+		require.NoError(t, err)
+		require.Equal(t, 3, len(attrs.Transactions), "Expected l1 info tx + the devnet deposits of the block")
+
+		var tx types.Transaction
+		require.NoError(t, tx.UnmarshalBinary(attrs.Transactions[1]))
+		require.Equal(t, (&UpgradeDepositSource{Intent: "Devnet: call"}).SourceHash(), tx.SourceHash())
+		require.Equal(t, &to, tx.To())
+		require.Equal(t, uint64(100_000), tx.Gas())
+		require.Equal(t, []byte{1, 2, 3}, tx.Data())
+		require.False(t, tx.IsSystemTx())
+		require.NoError(t, tx.UnmarshalBinary(attrs.Transactions[2]))
+		require.Equal(t, (&UpgradeDepositSource{Intent: "Devnet: deploy"}).SourceHash(), tx.SourceHash())
+		require.Nil(t, tx.To())
+	})
 	// Test that the payload attributes builder changes the deposit format based on L2-time-based regolith activation
 	t.Run("regolith", func(t *testing.T) {
 		testCases := []struct {
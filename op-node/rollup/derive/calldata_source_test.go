@@ -1,7 +1,10 @@
 package derive
 
 import (
+	"context"
 	"crypto/ecdsa"
+	"errors"
+	"io"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -60,8 +63,11 @@ func TestDataFromEVMTransactions(t *testing.T) {
 		BatchInboxAddress: crypto.PubkeyToAddress(inboxPriv.PublicKey),
 	}
 	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	rotatedPriv := testutils.RandomKey()
+	rotatedAddr := crypto.PubkeyToAddress(rotatedPriv.PublicKey)
 
 	altInbox := testutils.RandomAddress(rand.New(rand.NewSource(1234)))
+	sharedInbox := testutils.RandomAddress(rand.New(rand.NewSource(5678)))
 	altAuthor := testutils.RandomKey()
 
 	testCases := []calldataTest{
@@ -105,6 +111,22 @@ func TestDataFromEVMTransactions(t *testing.T) {
 				{to: &altInbox, dataLen: 2020, value: 12, author: batcherPriv, good: false},
 			},
 		},
+		{
+			name: "rotated author",
+			txs: []testTx{
+				{to: &cfg.BatchInboxAddress, dataLen: 1234, author: rotatedPriv, good: true},
+				{to: &cfg.BatchInboxAddress, dataLen: 2000, author: batcherPriv, good: true},
+				{to: &cfg.BatchInboxAddress, dataLen: 3333, author: altAuthor, good: false},
+			},
+		},
+		{
+			name: "shared inbox",
+			txs: []testTx{
+				{to: &sharedInbox, dataLen: 1234, author: batcherPriv, good: true},
+				{to: &sharedInbox, dataLen: 2000, author: altAuthor, good: false},
+				{to: &cfg.BatchInboxAddress, dataLen: 3333, author: batcherPriv, good: true},
+			},
+		},
 		// TODO: test with different batcher key, i.e. when it's changed from initial config value by L1 contract
 	}
 
@@ -121,8 +143,48 @@ func TestDataFromEVMTransactions(t *testing.T) {
 			}
 		}
 
-		out := DataFromEVMTransactions(DataSourceConfig{cfg.L1Signer(), cfg.BatchInboxAddress, false}, batcherAddr, txs, testlog.Logger(t, log.LevelCrit))
+		out := DataFromEVMTransactions(DataSourceConfig{l1Signer: cfg.L1Signer(), batchInboxAddress: cfg.BatchInboxAddress, sharedBatchInboxAddresses: []common.Address{sharedInbox}}, []common.Address{batcherAddr, rotatedAddr}, txs, testlog.Logger(t, log.LevelCrit))
 		require.ElementsMatch(t, expectedData, out)
 	}
 
 }
+
+func TestDataSourceFactory_BatcherKeys(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	sysCfgBatcherPriv := testutils.RandomKey()
+	sysCfgBatcher := crypto.PubkeyToAddress(sysCfgBatcherPriv.PublicKey)
+	rotatedPriv := testutils.RandomKey()
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+		BatcherKeys: []rollup.BatcherKey{
+			{Address: crypto.PubkeyToAddress(rotatedPriv.PublicKey), ActivationTime: 100},
+		},
+	}
+	signer := cfg.L1Signer()
+	sysCfgTx := (&testTx{to: &cfg.BatchInboxAddress, dataLen: 100, author: sysCfgBatcherPriv}).Create(t, signer, rng)
+	rotatedTx := (&testTx{to: &cfg.BatchInboxAddress, dataLen: 200, author: rotatedPriv}).Create(t, signer, rng)
+
+	l1F := &testutils.MockL1Source{}
+	factory := NewDataSourceFactory(testlog.Logger(t, log.LevelCrit), cfg, l1F, nil, nil)
+	readAll := func(ref eth.L1BlockRef) []eth.Data {
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), types.Transactions{sysCfgTx, rotatedTx}, nil)
+		src, err := factory.OpenData(context.Background(), ref, sysCfgBatcher)
+		require.NoError(t, err)
+		var out []eth.Data
+		for {
+			data, err := src.Next(context.Background())
+			if errors.Is(err, io.EOF) {
+				return out
+			}
+			require.NoError(t, err)
+			out = append(out, data)
+		}
+	}
+
+	before := readAll(eth.L1BlockRef{Hash: common.Hash{0x01}, Time: 99})
+	require.Equal(t, []eth.Data{sysCfgTx.Data()}, before, "only the system config batcher before the rotation")
+	after := readAll(eth.L1BlockRef{Hash: common.Hash{0x02}, Time: 100})
+	require.Equal(t, []eth.Data{rotatedTx.Data()}, after, "only the scheduled batcher key after the rotation")
+	l1F.AssertExpectations(t)
+}
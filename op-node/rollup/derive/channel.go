@@ -3,6 +3,7 @@ package derive
 import (
 	"bufio"
 	"bytes"
+	"compress/flate"
 	"compress/zlib"
 	"fmt"
 	"io"
@@ -158,7 +159,8 @@ func (ch *Channel) Reader() io.Reader {
 // The L1Inclusion block is also provided at creation time.
 // Warning: the batch reader can read every batch-type.
 // The caller of the batch-reader should filter the results.
-func BatchReader(r io.Reader, maxRLPBytesPerChannel uint64, isFjord bool) (func() (*BatchData, error), error) {
+// Channels compressed with a preset dictionary are only accepted since Holocene, and may only contain span batches.
+func BatchReader(r io.Reader, maxRLPBytesPerChannel uint64, isFjord bool, isHolocene bool) (func() (*BatchData, error), error) {
 	// use buffered reader so can peek the first byte
 	bufReader := bufio.NewReader(r)
 	compressionType, err := bufReader.Peek(1)
@@ -189,6 +191,25 @@ func BatchReader(r io.Reader, maxRLPBytesPerChannel uint64, isFjord bool) (func(
 		}
 		zr = brotli.NewReader(bufReader)
 		comprAlgo = Brotli
+	} else if compressionType[0] == ChannelVersionZlibDict {
+		// If before Holocene, we cannot accept dictionary compressed batch
+		if !isHolocene {
+			return nil, fmt.Errorf("cannot accept dictionary compressed batch before Holocene")
+		}
+		// discard the first byte, and read the dictionary version
+		if _, err := bufReader.Discard(1); err != nil {
+			return nil, err
+		}
+		dictVersion, err := bufReader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		dict, err := CompressionDict(dictVersion)
+		if err != nil {
+			return nil, err
+		}
+		zr = flate.NewReaderDict(bufReader, dict)
+		comprAlgo = ZlibDict
 	} else {
 		return nil, fmt.Errorf("cannot distinguish the compression algo used given type byte %v", compressionType[0])
 	}
@@ -201,6 +222,9 @@ func BatchReader(r io.Reader, maxRLPBytesPerChannel uint64, isFjord bool) (func(
 		if err := rlpReader.Decode(&batchData); err != nil {
 			return nil, err
 		}
+		if comprAlgo == ZlibDict && batchData.GetBatchType() != SpanBatchType {
+			return nil, fmt.Errorf("cannot accept batch of type %d in dictionary compressed channel", batchData.GetBatchType())
+		}
 		return &batchData, nil
 	}, nil
 }
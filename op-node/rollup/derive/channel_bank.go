@@ -84,6 +84,12 @@ func (cb *ChannelBank) IngestFrame(f Frame) {
 	log := cb.log.New("origin", origin, "channel", f.ID, "length", len(f.Data), "frame_number", f.FrameNumber, "is_last", f.IsLast)
 	log.Debug("channel bank got new data")
 
+	// Frames of other chains that share a batch inbox with this chain are not for us
+	if tag, ok := cb.spec.ChannelIDChainTag(); ok && !f.ID.HasChainTag(tag) {
+		log.Debug("ignoring frame of channel of other chain")
+		return
+	}
+
 	currentCh, ok := cb.channels[f.ID]
 	if !ok {
 		// Only record a head channel if it can immediately be active.
@@ -3,6 +3,7 @@ package derive
 import (
 	"context"
 	"io"
+	"math/big"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
@@ -306,3 +308,47 @@ func TestChannelBankDuplicates(t *testing.T) {
 	require.Nil(t, out)
 	require.Equal(t, io.EOF, err)
 }
+
+// TestChannelBankChainTagged ensures that the channel bank ignores the frames of channels of other chains,
+// when the chain shares a batch inbox.
+func TestChannelBankChainTagged(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	a := testutils.RandomBlockRef(rng)
+
+	cfg := &rollup.Config{
+		ChannelTimeoutBedrock:     10,
+		L2ChainID:                 big.NewInt(0x0102),
+		SharedBatchInboxAddresses: []common.Address{{0xaa}},
+	}
+	tag := cfg.ChannelIDChainTag()
+	ours := ChannelID{}
+	copy(ours[:], tag[:])
+	ours[15] = 1
+	other := ChannelID{0, 0, 0, 0, 0, 0, 0x01, 0x03}
+	other[15] = 1
+
+	input := &fakeChannelBankInput{origin: a}
+	input.AddFrame(Frame{ID: other, FrameNumber: 0, Data: []byte("theirs"), IsLast: true}, nil)
+	input.AddFrame(Frame{ID: ours, FrameNumber: 0, Data: []byte("ours"), IsLast: true}, nil)
+	input.AddFrame(Frame{}, io.EOF)
+
+	cb := NewChannelBank(testlog.Logger(t, log.LevelCrit), cfg, input, nil, metrics.NoopMetrics)
+
+	// The frame of the other chain is ignored
+	out, err := cb.NextData(context.Background())
+	require.ErrorIs(t, err, NotEnoughData)
+	require.Nil(t, out)
+	require.Empty(t, cb.channels)
+
+	out, err = cb.NextData(context.Background())
+	require.ErrorIs(t, err, NotEnoughData)
+	require.Nil(t, out)
+
+	out, err = cb.NextData(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "ours", string(out))
+
+	out, err = cb.NextData(context.Background())
+	require.Nil(t, out)
+	require.Equal(t, io.EOF, err)
+}
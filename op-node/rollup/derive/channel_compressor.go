@@ -2,6 +2,7 @@ package derive
 
 import (
 	"bytes"
+	"compress/flate"
 	"compress/zlib"
 	"fmt"
 	"io"
@@ -11,6 +12,10 @@ import (
 
 const (
 	ChannelVersionBrotli byte = 0x01
+	// ChannelVersionZlibDict is followed by the version of the preset dictionary, and a raw deflate stream compressed
+	// with that dictionary. The zlib header, dictionary ID and checksum are left out, as the dictionary version
+	// already identifies the dictionary.
+	ChannelVersionZlibDict byte = 0x02
 )
 
 type ChannelCompressor interface {
@@ -66,6 +71,16 @@ func (bc *BrotliCompressor) Reset() {
 	bc.CompressorWriter.Reset(bc.compressed)
 }
 
+type ZlibDictCompressor struct {
+	BaseChannelCompressor
+}
+
+func (zc *ZlibDictCompressor) Reset() {
+	zc.compressed.Reset()
+	zc.compressed.Write([]byte{ChannelVersionZlibDict, LatestCompressionDict})
+	zc.CompressorWriter.Reset(zc.compressed)
+}
+
 func NewChannelCompressor(algo CompressionAlgo) (ChannelCompressor, error) {
 	compressed := &bytes.Buffer{}
 	if algo == Zlib {
@@ -79,6 +94,22 @@ func NewChannelCompressor(algo CompressionAlgo) (ChannelCompressor, error) {
 				compressed:       compressed,
 			},
 		}, nil
+	} else if algo == ZlibDict {
+		dict, err := CompressionDict(LatestCompressionDict)
+		if err != nil {
+			return nil, err
+		}
+		compressed.Write([]byte{ChannelVersionZlibDict, LatestCompressionDict})
+		writer, err := flate.NewWriterDict(compressed, flate.BestCompression, dict)
+		if err != nil {
+			return nil, err
+		}
+		return &ZlibDictCompressor{
+			BaseChannelCompressor{
+				CompressorWriter: writer,
+				compressed:       compressed,
+			},
+		}, nil
 	} else if algo.IsBrotli() {
 		compressed.WriteByte(ChannelVersionBrotli)
 		writer := brotli.NewWriterLevel(compressed, GetBrotliLevel(algo))
@@ -36,6 +36,11 @@ func TestChannelCompressor_NewReset(t *testing.T) {
 			algo:              Brotli10,
 			expectedResetSize: 1,
 		},
+		{
+			name:              "zlib-dict",
+			algo:              ZlibDict,
+			expectedResetSize: 2,
+		},
 		{
 			name:              "zstd",
 			algo:              CompressionAlgo("zstd"),
@@ -2,7 +2,6 @@ package derive
 
 import (
 	"bytes"
-	"crypto/rand"
 	"errors"
 	"fmt"
 	"io"
@@ -83,17 +82,17 @@ func (co *SingularChannelOut) ID() ChannelID {
 }
 
 func NewSingularChannelOut(compress Compressor, chainSpec *rollup.ChainSpec) (*SingularChannelOut, error) {
+	id, err := newChannelID(chainSpec)
+	if err != nil {
+		return nil, err
+	}
 	c := &SingularChannelOut{
-		id:        ChannelID{},
+		id:        id,
 		frame:     0,
 		rlpLength: 0,
 		compress:  compress,
 		chainSpec: chainSpec,
 	}
-	_, err := rand.Read(c.id[:])
-	if err != nil {
-		return nil, err
-	}
 
 	return c, nil
 }
@@ -103,8 +102,12 @@ func (co *SingularChannelOut) Reset() error {
 	co.rlpLength = 0
 	co.compress.Reset()
 	co.closed = false
-	_, err := rand.Read(co.id[:])
-	return err
+	id, err := newChannelID(co.chainSpec)
+	if err != nil {
+		return err
+	}
+	co.id = id
+	return nil
 }
 
 // AddBlock adds a block to the channel. It returns the RLP encoded byte size
@@ -266,6 +266,18 @@ func TestSpanChannelOut(t *testing.T) {
 	}
 }
 
+// channelVersionLen returns the length of the channel version prefix that the compressor writes before any data
+func channelVersionLen(algo CompressionAlgo) int {
+	switch {
+	case algo == ZlibDict:
+		return 2 // channel version and dictionary version
+	case algo.IsBrotli():
+		return 1 // brotli channel version
+	default:
+		return 0
+	}
+}
+
 func funcName(fn any) string {
 	return runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
 }
@@ -296,11 +308,7 @@ func SpanChannelOutCompressionUndo(t *testing.T, algo CompressionAlgo) {
 	err := cout.AddSingularBatch(singularBatches[0], 0)
 	require.NoError(t, err)
 	// confirm that the first compression was skipped
-	if algo == Zlib {
-		require.Equal(t, 0, cout.compressor.Len())
-	} else {
-		require.Equal(t, 1, cout.compressor.Len()) // 1 because of brotli channel version
-	}
+	require.Equal(t, channelVersionLen(algo), cout.compressor.Len())
 	// record the RLP length to confirm it doesn't change when adding a rejected batch
 	rlp1 := cout.activeRLP().Len()
 
@@ -322,12 +330,7 @@ func SpanChannelOutClose(t *testing.T, algo CompressionAlgo) {
 	err := cout.AddSingularBatch(singularBatches[0], 0)
 	require.NoError(t, err)
 	// confirm no compression has happened yet
-
-	if algo == Zlib {
-		require.Equal(t, 0, cout.compressor.Len())
-	} else {
-		require.Equal(t, 1, cout.compressor.Len()) // 1 because of brotli channel version
-	}
+	require.Equal(t, channelVersionLen(algo), cout.compressor.Len())
 
 	// confirm the RLP length is less than the target
 	rlpLen := cout.activeRLP().Len()
@@ -450,7 +453,7 @@ func testSpanChannelOut_MaxBlocksPerSpanBatch(t *testing.T, tt maxBlocksTest) {
 	require.False(t, ch.IsReady())
 	require.NoError(t, ch.AddFrame(frame, l1Origin))
 	require.True(t, ch.IsReady())
-	br, err := BatchReader(ch.Reader(), spec.MaxRLPBytesPerChannel(0), true)
+	br, err := BatchReader(ch.Reader(), spec.MaxRLPBytesPerChannel(0), true, true)
 	require.NoError(t, err)
 
 	sbs := make([]*SingularBatch, 0, tt.numBatches-1)
@@ -487,3 +490,23 @@ func testSpanChannelOut_MaxBlocksPerSpanBatch(t *testing.T, tt maxBlocksTest) {
 		require.Equalf(t, batch0, batch, "iteration %d", i)
 	}
 }
+
+func TestChannelOutChainTaggedID(t *testing.T) {
+	for _, tcase := range channelTypes {
+		t.Run(tcase.Name, func(t *testing.T) {
+			rcfg := &rollup.Config{L2ChainID: big.NewInt(901)}
+			tag := [rollup.ChannelIDChainTagLength]byte{0, 0, 0, 0, 0, 0, 0x03, 0x85}
+
+			cout := tcase.ChannelOut(t, rcfg)
+			require.False(t, cout.ID().HasChainTag(tag), "untagged without shared batch inboxes")
+
+			rcfg.SharedBatchInboxAddresses = []common.Address{{0xaa}}
+			cout = tcase.ChannelOut(t, rcfg)
+			require.True(t, cout.ID().HasChainTag(tag))
+			first := cout.ID()
+			require.NoError(t, cout.Reset())
+			require.True(t, cout.ID().HasChainTag(tag))
+			require.NotEqual(t, first, cout.ID(), "channel IDs are still random after the tag")
+		})
+	}
+}
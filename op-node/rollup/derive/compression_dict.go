@@ -0,0 +1,115 @@
+package derive
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+)
+
+const (
+	// CompressionDictV1 is the version of the first preset compression dictionary, activated with Holocene.
+	CompressionDictV1 byte = 0x01
+
+	// LatestCompressionDict is the version of the dictionary used for new channels.
+	LatestCompressionDict = CompressionDictV1
+)
+
+// compressionDicts are the preset dictionaries that channels with ChannelVersionZlibDict can be
+// compressed with, by dictionary version. The dictionaries are part of the derivation rules:
+// they must never change, new dictionaries are added with a new version instead.
+var compressionDicts = map[byte][]byte{
+	CompressionDictV1: compressionDictV1(),
+}
+
+// CompressionDict returns the preset dictionary of the given version.
+func CompressionDict(version byte) ([]byte, error) {
+	dict, ok := compressionDicts[version]
+	if !ok {
+		return nil, fmt.Errorf("unknown compression dictionary version %d", version)
+	}
+	return dict, nil
+}
+
+// compressionDictV1Addresses are the addresses of predeploys and preinstalls that are commonly called on
+// OP Stack chains, or passed as call arguments, e.g. in approvals.
+var compressionDictV1Addresses = []common.Address{
+	predeploys.SafeSingletonFactoryAddr,
+	predeploys.DeterministicDeploymentProxyAddr,
+	predeploys.Create2DeployerAddr,
+	predeploys.MultiSendCallOnly_v130Addr,
+	predeploys.L2ToL1MessagePasserAddr,
+	predeploys.L2CrossDomainMessengerAddr,
+	predeploys.L2StandardBridgeAddr,
+	predeploys.EntryPoint_v060Addr,
+	predeploys.EntryPoint_v070Addr,
+	predeploys.MultiCall3Addr,
+	predeploys.Permit2Addr,
+	predeploys.GovernanceTokenAddr,
+	predeploys.WETHAddr,
+}
+
+// compressionDictV1Selectors are the function selectors of contract calls that are common on OP Stack chains:
+// token transfers and approvals, DEX swaps, WETH, NFT mints and transfers, and ERC-4337 bundles.
+var compressionDictV1Selectors = []string{
+	"1fad948c", // handleOps (ERC-4337 v0.6)
+	"765e827f", // handleOps (ERC-4337 v0.7)
+	"b61d27f6", // execute(address,uint256,bytes)
+	"42842e0e", // safeTransferFrom(address,address,uint256)
+	"40c10f19", // mint(address,uint256)
+	"b6b55f25", // deposit(uint256)
+	"2e1a7d4d", // withdraw(uint256)
+	"d0e30db0", // deposit()
+	"18cbafe5", // swapExactTokensForETH
+	"7ff36ab5", // swapExactETHForTokens
+	"38ed1739", // swapExactTokensForTokens
+	"04e45aaf", // exactInputSingle (SwapRouter02)
+	"414bf389", // exactInputSingle
+	"5ae401dc", // multicall(uint256,bytes[])
+	"ac9650d8", // multicall(bytes[])
+	"3593564c", // execute(bytes,bytes[],uint256)
+	"23b872dd", // transferFrom(address,address,uint256)
+	"095ea7b3", // approve(address,uint256)
+	"a9059cbb", // transfer(address,uint256)
+}
+
+// compressionDictV1 builds the preset dictionary of version 1. It is made of byte patterns that are common in the
+// transaction data of span batches, ordered from least to most common, as matches at the end of the dictionary
+// are cheaper to encode. The transaction data of a span batch is the concatenation of the transaction type and
+// the RLP encoding of the remaining fields of each transaction, ending with the calldata and access list.
+func compressionDictV1() []byte {
+	var dict bytes.Buffer
+	word := func(v byte) []byte {
+		w := make([]byte, 32)
+		w[31] = v
+		return w
+	}
+	// ABI encoded offsets of dynamic arguments and unlimited approvals
+	for _, v := range []byte{0xa0, 0x80, 0x60, 0x40, 0x20} {
+		dict.Write(word(v))
+	}
+	dict.Write(bytes.Repeat([]byte{0xff}, 32))
+	// Addresses, as call destinations and as ABI encoded arguments
+	for _, addr := range compressionDictV1Addresses {
+		dict.Write(make([]byte, 12))
+		dict.Write(addr.Bytes())
+	}
+	// Calls with address arguments, each preceded by the RLP string header of calldata of two or three words
+	for _, sel := range compressionDictV1Selectors {
+		dict.Write([]byte{0xb8, 0x64})
+		dict.Write(common.FromHex(sel))
+		dict.Write(make([]byte, 12))
+	}
+	dict.Write([]byte{0xb8, 0x44})
+	dict.Write(common.FromHex(compressionDictV1Selectors[len(compressionDictV1Selectors)-1]))
+	dict.Write(make([]byte, 12))
+	// An empty access list, followed by the start of the next dynamic fee transaction, with or without value
+	dict.Write([]byte{0xc0, types.DynamicFeeTxType, 0xf8})
+	dict.Write([]byte{0xc0, types.DynamicFeeTxType, 0xf8, 0x6d, 0x80})
+	// Zero padding of ABI encoded words
+	dict.Write(make([]byte, 32))
+	return dict.Bytes()
+}
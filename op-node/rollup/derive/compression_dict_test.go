@@ -0,0 +1,107 @@
+package derive
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/predeploys"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestCompressionDict(t *testing.T) {
+	dict, err := CompressionDict(CompressionDictV1)
+	require.NoError(t, err)
+	// The dictionary is part of the derivation rules, and must never change
+	require.Equal(t, common.HexToHash("0x6c8825ea75b130ea7c38e22588b063b08be7511f0722f17a5828d4ccc7c54ce4"), crypto.Keccak256Hash(dict))
+	require.Equal(t, dict, compressionDictV1(), "dictionary must be deterministic")
+
+	_, err = CompressionDict(0)
+	require.ErrorContains(t, err, "unknown compression dictionary")
+}
+
+// erc20TransferBatches returns consecutive batches of ERC-20 transfers, from a few senders to many recipients,
+// as is common on chains with homogeneous transaction patterns.
+func erc20TransferBatches(t *testing.T, rng *rand.Rand, numBatches int, txsPerBatch int) []*SingularBatch {
+	chainID := rollupCfg.L2ChainID
+	signer := types.LatestSignerForChainID(chainID)
+	tokens := []common.Address{predeploys.WETHAddr, testutils.RandomAddress(rng)}
+	senders := make([]struct {
+		key   *ecdsa.PrivateKey
+		nonce uint64
+	}, 4)
+	for i := range senders {
+		senders[i].key = testutils.InsecureRandomKey(rng)
+	}
+	batches := make([]*SingularBatch, 0, numBatches)
+	for i := 0; i < numBatches; i++ {
+		batch := &SingularBatch{
+			ParentHash: testutils.RandomHash(rng),
+			EpochNum:   rollup.Epoch(i / 6),
+			EpochHash:  testutils.RandomHash(rng),
+			Timestamp:  rollupCfg.Genesis.L2Time + 420_000 + rollupCfg.BlockTime*uint64(i),
+		}
+		for j := 0; j < txsPerBatch; j++ {
+			sender := &senders[rng.Intn(len(senders))]
+			data := make([]byte, 0, 68)
+			data = append(data, 0xa9, 0x05, 0x9c, 0xbb)
+			data = append(data, common.LeftPadBytes(testutils.RandomAddress(rng).Bytes(), 32)...)
+			data = append(data, common.LeftPadBytes(big.NewInt(rng.Int63n(1e18)).Bytes(), 32)...)
+			token := tokens[rng.Intn(len(tokens))]
+			tx, err := types.SignNewTx(sender.key, signer, &types.DynamicFeeTx{
+				ChainID:   chainID,
+				Nonce:     sender.nonce,
+				GasTipCap: big.NewInt(1_000_000),
+				GasFeeCap: big.NewInt(1_000_000 + rng.Int63n(1_000_000)),
+				Gas:       60_000 + uint64(rng.Intn(10_000)),
+				To:        &token,
+				Data:      data,
+			})
+			require.NoError(t, err)
+			sender.nonce++
+			enc, err := tx.MarshalBinary()
+			require.NoError(t, err)
+			batch.Transactions = append(batch.Transactions, hexutil.Bytes(enc))
+		}
+		batches = append(batches, batch)
+	}
+	return batches
+}
+
+func compressedSpanChannelSize(t *testing.T, algo CompressionAlgo, batches []*SingularBatch) int {
+	cout, err := NewSpanChannelOut(rollupCfg.Genesis.L2Time, rollupCfg.L2ChainID, 10_000_000, algo, rollup.NewChainSpec(&rollupCfg))
+	require.NoError(t, err)
+	for i, batch := range batches {
+		require.NoError(t, cout.AddSingularBatch(batch, uint64(i)))
+	}
+	require.NoError(t, cout.Close())
+	return cout.compressor.Len()
+}
+
+// TestCompressionDict_HomogeneousTxs measures the size of span batch channels of ERC-20 transfers, with and
+// without the preset dictionary. The dictionary only helps with the first occurrence of each pattern in a channel,
+// so the gains are largest for small channels. For large channels, deflate finds the same patterns in the channel
+// itself, and the gain shrinks to the few bytes saved by leaving out the zlib framing.
+func TestCompressionDict_HomogeneousTxs(t *testing.T) {
+	for _, tc := range []struct {
+		batches, txsPerBatch int
+	}{
+		{1, 1}, {1, 5}, {1, 10}, {10, 10}, {100, 10},
+	} {
+		rng := rand.New(rand.NewSource(1234))
+		batches := erc20TransferBatches(t, rng, tc.batches, tc.txsPerBatch)
+		zlibSize := compressedSpanChannelSize(t, Zlib, batches)
+		dictSize := compressedSpanChannelSize(t, ZlibDict, batches)
+		t.Logf("blocks: %d, txs: %d, zlib: %d bytes, zlib-dict: %d bytes, gain: %.2f%%",
+			tc.batches, tc.batches*tc.txsPerBatch, zlibSize, dictSize, 100*float64(zlibSize-dictSize)/float64(zlibSize))
+		require.Less(t, dictSize, zlibSize)
+	}
+}
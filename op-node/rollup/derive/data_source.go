@@ -3,6 +3,7 @@ package derive
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -45,6 +46,8 @@ type DataSourceFactory struct {
 	blobsFetcher L1BlobsFetcher
 	altDAFetcher AltDAInputFetcher
 	ecotoneTime  *uint64
+	// batcherAddresses resolves the batcher addresses that are accepted at a L1 time
+	batcherAddresses func(l1Time uint64, sysCfgBatcher common.Address) []common.Address
 }
 
 func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1Fetcher, blobsFetcher L1BlobsFetcher, altDAFetcher AltDAInputFetcher) *DataSourceFactory {
@@ -52,6 +55,8 @@ func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1Fetcher,
 		l1Signer:          cfg.L1Signer(),
 		batchInboxAddress: cfg.BatchInboxAddress,
 		altDAEnabled:      cfg.AltDAEnabled(),
+
+		sharedBatchInboxAddresses: cfg.SharedBatchInboxAddresses,
 	}
 	return &DataSourceFactory{
 		log:          log,
@@ -60,6 +65,8 @@ func NewDataSourceFactory(log log.Logger, cfg *rollup.Config, fetcher L1Fetcher,
 		blobsFetcher: blobsFetcher,
 		altDAFetcher: altDAFetcher,
 		ecotoneTime:  cfg.EcotoneTime,
+
+		batcherAddresses: cfg.BatcherAddresses,
 	}
 }
 
@@ -68,13 +75,14 @@ func (ds *DataSourceFactory) OpenData(ctx context.Context, ref eth.L1BlockRef, b
 	// Creates a data iterator from blob or calldata source so we can forward it to the altDA source
 	// if enabled as it still requires an L1 data source for fetching input commmitments.
 	var src DataIter
+	batcherAddrs := ds.batcherAddresses(ref.Time, batcherAddr)
 	if ds.ecotoneTime != nil && ref.Time >= *ds.ecotoneTime {
 		if ds.blobsFetcher == nil {
 			return nil, fmt.Errorf("ecotone upgrade active but beacon endpoint not configured")
 		}
-		src = NewBlobDataSource(ctx, ds.log, ds.dsCfg, ds.fetcher, ds.blobsFetcher, ref, batcherAddr)
+		src = NewBlobDataSource(ctx, ds.log, ds.dsCfg, ds.fetcher, ds.blobsFetcher, ref, batcherAddrs)
 	} else {
-		src = NewCalldataSource(ctx, ds.log, ds.dsCfg, ds.fetcher, ref, batcherAddr)
+		src = NewCalldataSource(ctx, ds.log, ds.dsCfg, ds.fetcher, ref, batcherAddrs)
 	}
 	if ds.dsCfg.altDAEnabled {
 		// altDA([calldata | blobdata](l1Ref)) -> data
@@ -88,23 +96,31 @@ type DataSourceConfig struct {
 	l1Signer          types.Signer
 	batchInboxAddress common.Address
 	altDAEnabled      bool
+
+	// sharedBatchInboxAddresses are the inboxes shared with other chains, that batch data is read from too
+	sharedBatchInboxAddresses []common.Address
+}
+
+// isBatchInbox returns true if batch data is read from transactions to the address.
+func (c *DataSourceConfig) isBatchInbox(addr common.Address) bool {
+	return addr == c.batchInboxAddress || slices.Contains(c.sharedBatchInboxAddresses, addr)
 }
 
 // isValidBatchTx returns true if:
-//  1. the transaction has a To() address that matches the batch inbox address, and
-//  2. the transaction has a valid signature from the batcher address
-func isValidBatchTx(tx *types.Transaction, l1Signer types.Signer, batchInboxAddr, batcherAddr common.Address) bool {
+//  1. the transaction has a To() address that matches the batch inbox address or one of the shared batch inboxes, and
+//  2. the transaction has a valid signature from one of the batcher addresses
+func isValidBatchTx(tx *types.Transaction, dsCfg *DataSourceConfig, batcherAddrs []common.Address) bool {
 	to := tx.To()
-	if to == nil || *to != batchInboxAddr {
+	if to == nil || !dsCfg.isBatchInbox(*to) {
 		return false
 	}
-	seqDataSubmitter, err := l1Signer.Sender(tx) // optimization: only derive sender if To is correct
+	seqDataSubmitter, err := dsCfg.l1Signer.Sender(tx) // optimization: only derive sender if To is correct
 	if err != nil {
 		log.Warn("tx in inbox with invalid signature", "hash", tx.Hash(), "err", err)
 		return false
 	}
 	// some random L1 user might have sent a transaction to our batch inbox, ignore them
-	if seqDataSubmitter != batcherAddr {
+	if !slices.Contains(batcherAddrs, seqDataSubmitter) {
 		log.Warn("tx in inbox with unauthorized submitter", "addr", seqDataSubmitter, "hash", tx.Hash(), "err", err)
 		return false
 	}
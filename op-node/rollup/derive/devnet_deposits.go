@@ -0,0 +1,35 @@
+package derive
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+// DevnetDepositTransactions returns the deposit transactions of the devnet deposits,
+// with source hashes in the upgrade deposit domain, like the network upgrade transactions.
+func DevnetDepositTransactions(deposits []rollup.DevnetDeposit) ([]hexutil.Bytes, error) {
+	txs := make([]hexutil.Bytes, 0, len(deposits))
+	for _, dep := range deposits {
+		source := UpgradeDepositSource{Intent: dep.Intent}
+		tx, err := types.NewTx(&types.DepositTx{
+			SourceHash:          source.SourceHash(),
+			From:                dep.From,
+			To:                  dep.To,
+			Mint:                big.NewInt(0),
+			Value:               big.NewInt(0),
+			Gas:                 dep.Gas,
+			IsSystemTransaction: false,
+			Data:                dep.Data,
+		}).MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode devnet deposit %v: %w", dep, err)
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
@@ -1,11 +1,13 @@
 package derive
 
 import (
+	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 )
 
 // count the tagging info as 200 in terms of buffer size.
@@ -37,6 +39,25 @@ const ChannelIDLength = 16
 // ChannelID is an opaque identifier for a channel. It is 128 bits to be globally unique.
 type ChannelID [ChannelIDLength]byte
 
+// newChannelID returns a random channel ID, that starts with the chain tag of the chain if its channels are chain-tagged.
+func newChannelID(spec *rollup.ChainSpec) (ChannelID, error) {
+	var id ChannelID
+	if _, err := rand.Read(id[:]); err != nil {
+		return ChannelID{}, err
+	}
+	if spec != nil {
+		if tag, ok := spec.ChannelIDChainTag(); ok {
+			copy(id[:], tag[:])
+		}
+	}
+	return id, nil
+}
+
+// HasChainTag returns true if the channel ID starts with the chain tag.
+func (id ChannelID) HasChainTag(tag [rollup.ChannelIDChainTagLength]byte) bool {
+	return [rollup.ChannelIDChainTagLength]byte(id[:rollup.ChannelIDChainTagLength]) == tag
+}
+
 func (id ChannelID) String() string {
 	return fmt.Sprintf("%x", id[:])
 }
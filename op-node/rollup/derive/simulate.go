@@ -0,0 +1,214 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SimulationL1Fetcher is the L1 source of a derivation simulation.
+type SimulationL1Fetcher interface {
+	L1BlockRefByNumber(context.Context, uint64) (eth.L1BlockRef, error)
+}
+
+// SimulateDerivation simulates the derivation of batcher transaction data, as if it was included in l1Head,
+// on top of the given L2 safe head. Each entry of data is the data of one batcher transaction.
+//
+// The batches of the channels are checked with the rules of the batch queue, in the order they were submitted.
+// Accepted batches advance the simulated safe head to the matching local unsafe block.
+// If there is no such block, the hash of the derived block is unknown, and later batches are left undecided.
+// Batches are not buffered: batches that can't be applied yet are reported as future batches.
+func SimulateDerivation(ctx context.Context, cfg *rollup.Config, log log.Logger, l1 SimulationL1Fetcher, l2 SafeBlockFetcher,
+	l2SafeHead eth.L2BlockRef, l1Head eth.L1BlockRef, data [][]byte,
+) (*eth.DerivationSimulation, error) {
+	spec := rollup.NewChainSpec(cfg)
+	var order []ChannelID
+	channels := make(map[ChannelID]*Channel)
+	results := make(map[ChannelID]*eth.SimulatedChannel)
+	for i, txData := range data {
+		frames, err := ParseFrames(txData)
+		if err != nil {
+			return nil, fmt.Errorf("invalid batcher transaction data %d: %w", i, err)
+		}
+		for _, frame := range frames {
+			ch, ok := channels[frame.ID]
+			if !ok {
+				ch = NewChannel(frame.ID, l1Head)
+				channels[frame.ID] = ch
+				results[frame.ID] = &eth.SimulatedChannel{ID: frame.ID.String()}
+				order = append(order, frame.ID)
+			}
+			result := results[frame.ID]
+			result.Frames++
+			if tag, ok := spec.ChannelIDChainTag(); ok && !frame.ID.HasChainTag(tag) {
+				result.Error = "channel ID is not tagged with the chain ID"
+				continue
+			}
+			if err := ch.AddFrame(frame, l1Head); err != nil && result.Error == "" {
+				result.Error = fmt.Sprintf("invalid frame %d: %v", frame.FrameNumber, err)
+			}
+		}
+	}
+
+	l1Blocks, err := simulationL1Blocks(ctx, cfg, l1, l2SafeHead, l1Head)
+	if err != nil {
+		return nil, err
+	}
+	sim := &eth.DerivationSimulation{
+		SafeHead: l2SafeHead,
+		L1Head:   l1Head,
+		Channels: make([]eth.SimulatedChannel, 0, len(order)),
+		Blocks:   []eth.SimulatedBlock{},
+	}
+	safeHead := l2SafeHead
+	safeHeadKnown := true
+	for _, id := range order {
+		ch, result := channels[id], results[id]
+		result.Ready = ch.IsReady()
+		if !result.Ready || result.Error != "" {
+			sim.Channels = append(sim.Channels, *result)
+			continue
+		}
+		batches, err := readSimulatedBatches(cfg, spec, ch, l1Head)
+		if err != nil {
+			result.Error = err.Error()
+		}
+		for _, batch := range batches {
+			simBatch := eth.SimulatedBatch{Timestamp: batch.GetTimestamp(), Validity: "undecided"}
+			if batch.GetBatchType() == SpanBatchType {
+				simBatch.Type = "span"
+			} else {
+				simBatch.Type = "singular"
+			}
+			if !safeHeadKnown {
+				result.Batches = append(result.Batches, simBatch)
+				continue
+			}
+			validity := CheckBatch(ctx, cfg, log, l1Blocks, safeHead, &BatchWithL1InclusionBlock{Batch: batch, L1InclusionBlock: l1Head}, l2)
+			simBatch.Validity = batchValidityName(validity)
+			if validity == BatchAccept {
+				var blocks []*SingularBatch
+				if singular, ok := batch.AsSingularBatch(); ok {
+					blocks = []*SingularBatch{singular}
+				} else if span, ok := batch.AsSpanBatch(); ok {
+					blocks, err = span.GetSingularBatches(l1Blocks, safeHead)
+					if err != nil {
+						return nil, fmt.Errorf("failed to derive blocks of span batch: %w", err)
+					}
+				}
+				for _, block := range blocks {
+					simBlock := eth.SimulatedBlock{
+						Number:       safeHead.Number + 1,
+						Timestamp:    block.Timestamp,
+						L1Origin:     eth.BlockID{Hash: block.EpochHash, Number: uint64(block.EpochNum)},
+						Transactions: len(block.Transactions),
+					}
+					if unsafe, err := l2.L2BlockRefByNumber(ctx, simBlock.Number); err == nil &&
+						unsafe.ParentHash == safeHead.Hash && unsafe.Time == simBlock.Timestamp && unsafe.L1Origin == simBlock.L1Origin {
+						simBlock.Unsafe = &unsafe
+						safeHead = unsafe
+						l1Blocks = advanceL1Blocks(l1Blocks, safeHead.L1Origin)
+					} else {
+						safeHeadKnown = false
+					}
+					simBatch.Blocks = append(simBatch.Blocks, simBlock)
+					sim.Blocks = append(sim.Blocks, simBlock)
+					if !safeHeadKnown {
+						break
+					}
+				}
+			}
+			result.Batches = append(result.Batches, simBatch)
+		}
+		sim.Channels = append(sim.Channels, *result)
+	}
+	return sim, nil
+}
+
+// simulationL1Blocks fetches the L1 blocks from the origin of the safe head up to the L1 head,
+// limited to the sequencing window: batches can't have a later L1 origin.
+func simulationL1Blocks(ctx context.Context, cfg *rollup.Config, l1 SimulationL1Fetcher, l2SafeHead eth.L2BlockRef, l1Head eth.L1BlockRef) ([]eth.L1BlockRef, error) {
+	start := l2SafeHead.L1Origin.Number
+	if l1Head.Number < start {
+		return nil, fmt.Errorf("L1 head %s is behind the L1 origin %s of the safe head", l1Head, l2SafeHead.L1Origin)
+	}
+	end := min(l1Head.Number, start+cfg.SeqWindowSize)
+	l1Blocks := make([]eth.L1BlockRef, 0, end-start+1)
+	for num := start; num <= end; num++ {
+		ref, err := l1.L1BlockRefByNumber(ctx, num)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch L1 block %d: %w", num, err)
+		}
+		l1Blocks = append(l1Blocks, ref)
+	}
+	if l1Blocks[0].ID() != l2SafeHead.L1Origin {
+		return nil, fmt.Errorf("L1 origin %s of the safe head is not canonical, found %s", l2SafeHead.L1Origin, l1Blocks[0])
+	}
+	return l1Blocks, nil
+}
+
+// advanceL1Blocks drops the L1 blocks before the L1 origin of the new safe head, like the batch queue does.
+func advanceL1Blocks(l1Blocks []eth.L1BlockRef, origin eth.BlockID) []eth.L1BlockRef {
+	for i, l1Block := range l1Blocks {
+		if l1Block.ID() == origin {
+			return l1Blocks[i:]
+		}
+	}
+	return l1Blocks
+}
+
+// readSimulatedBatches reads the batches of a ready channel, like the channel-in reader does.
+// It returns the batches read before the first error, if any.
+func readSimulatedBatches(cfg *rollup.Config, spec *rollup.ChainSpec, ch *Channel, l1Head eth.L1BlockRef) ([]Batch, error) {
+	nextBatch, err := BatchReader(ch.Reader(), spec.MaxRLPBytesPerChannel(l1Head.Time), cfg.IsFjord(l1Head.Time), cfg.IsHolocene(l1Head.Time))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read channel: %w", err)
+	}
+	var batches []Batch
+	for {
+		batchData, err := nextBatch()
+		if errors.Is(err, io.EOF) {
+			return batches, nil
+		} else if err != nil {
+			return batches, fmt.Errorf("failed to read batch %d: %w", len(batches), err)
+		}
+		switch batchData.GetBatchType() {
+		case SingularBatchType:
+			batch, err := GetSingularBatch(batchData)
+			if err != nil {
+				return batches, fmt.Errorf("invalid singular batch %d: %w", len(batches), err)
+			}
+			batches = append(batches, batch)
+		case SpanBatchType:
+			if !cfg.IsDelta(l1Head.Time) {
+				return batches, fmt.Errorf("span batch %d before Delta activation", len(batches))
+			}
+			batch, err := DeriveSpanBatch(batchData, cfg.BlockTime, cfg.Genesis.L2Time, cfg.L2ChainID)
+			if err != nil {
+				return batches, fmt.Errorf("invalid span batch %d: %w", len(batches), err)
+			}
+			batches = append(batches, batch)
+		default:
+			return batches, fmt.Errorf("unrecognized type %d of batch %d", batchData.GetBatchType(), len(batches))
+		}
+	}
+}
+
+func batchValidityName(validity BatchValidity) string {
+	switch validity {
+	case BatchDrop:
+		return "drop"
+	case BatchAccept:
+		return "accept"
+	case BatchFuture:
+		return "future"
+	default:
+		return "undecided"
+	}
+}
@@ -0,0 +1,176 @@
+package derive
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubSimulationChain struct {
+	l1 []eth.L1BlockRef
+	l2 map[uint64]eth.L2BlockRef
+}
+
+func (s *stubSimulationChain) L1BlockRefByNumber(_ context.Context, num uint64) (eth.L1BlockRef, error) {
+	if num >= uint64(len(s.l1)) {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return s.l1[num], nil
+}
+
+func (s *stubSimulationChain) L2BlockRefByNumber(_ context.Context, num uint64) (eth.L2BlockRef, error) {
+	ref, ok := s.l2[num]
+	if !ok {
+		return eth.L2BlockRef{}, ethereum.NotFound
+	}
+	return ref, nil
+}
+
+func (s *stubSimulationChain) PayloadByNumber(_ context.Context, _ uint64) (*eth.ExecutionPayloadEnvelope, error) {
+	return nil, ethereum.NotFound
+}
+
+// simulationTxData encodes the batches into a single-frame channel with the given ID.
+func simulationTxData(t *testing.T, id ChannelID, batches ...*SingularBatch) []byte {
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	for _, batch := range batches {
+		require.NoError(t, rlp.Encode(w, NewBatchData(batch)))
+	}
+	require.NoError(t, w.Close())
+	frame := Frame{ID: id, Data: compressed.Bytes(), IsLast: true}
+	txData := bytes.NewBuffer([]byte{DerivationVersion0})
+	require.NoError(t, frame.MarshalBinary(txData))
+	return txData.Bytes()
+}
+
+func TestSimulateDerivation(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelCrit)
+	cfg := &rollup.Config{
+		Genesis:           rollup.Genesis{L2Time: 10},
+		BlockTime:         2,
+		MaxSequencerDrift: 600,
+		SeqWindowSize:     10,
+		L2ChainID:         big.NewInt(1234),
+	}
+	l1 := L1Chain([]uint64{10, 20, 30, 40})
+	safeHead := eth.L2BlockRef{
+		Hash:     mockHash(20, 2),
+		Number:   5,
+		Time:     20,
+		L1Origin: l1[1].ID(),
+	}
+	unsafe := eth.L2BlockRef{
+		Hash:       mockHash(22, 2),
+		Number:     6,
+		ParentHash: safeHead.Hash,
+		Time:       22,
+		L1Origin:   l1[1].ID(),
+	}
+	chain := &stubSimulationChain{l1: l1, l2: map[uint64]eth.L2BlockRef{6: unsafe}}
+	batch1 := b(cfg.L2ChainID, 22, l1[1])
+	batch2 := b(cfg.L2ChainID, 24, l1[1])
+	batch2.ParentHash = unsafe.Hash
+	batch3 := b(cfg.L2ChainID, 26, l1[1])
+
+	t.Run("DeriveBlocks", func(t *testing.T) {
+		data := [][]byte{
+			simulationTxData(t, ChannelID{0x01}, batch1, batch2),
+			simulationTxData(t, ChannelID{0x02}, batch3),
+		}
+		sim, err := SimulateDerivation(context.Background(), cfg, logger, chain, chain, safeHead, l1[3], data)
+		require.NoError(t, err)
+		require.Equal(t, safeHead, sim.SafeHead)
+		require.Equal(t, l1[3], sim.L1Head)
+		require.Len(t, sim.Channels, 2)
+
+		ch := sim.Channels[0]
+		require.Equal(t, ChannelID{0x01}.String(), ch.ID)
+		require.True(t, ch.Ready)
+		require.Empty(t, ch.Error)
+		require.Len(t, ch.Batches, 2)
+		require.Equal(t, "singular", ch.Batches[0].Type)
+		require.Equal(t, "accept", ch.Batches[0].Validity)
+		require.Equal(t, "accept", ch.Batches[1].Validity)
+
+		require.Len(t, sim.Blocks, 2)
+		require.Equal(t, uint64(6), sim.Blocks[0].Number)
+		require.Equal(t, uint64(22), sim.Blocks[0].Timestamp)
+		require.Equal(t, l1[1].ID(), sim.Blocks[0].L1Origin)
+		require.Equal(t, 1, sim.Blocks[0].Transactions)
+		require.Equal(t, &unsafe, sim.Blocks[0].Unsafe)
+		require.Equal(t, uint64(7), sim.Blocks[1].Number)
+		require.Nil(t, sim.Blocks[1].Unsafe, "no matching unsafe block")
+
+		require.Equal(t, []eth.SimulatedBatch{{Type: "singular", Timestamp: 26, Validity: "undecided"}}, sim.Channels[1].Batches,
+			"should not check batches after a derived block with an unknown hash")
+	})
+
+	t.Run("DropInvalidBatch", func(t *testing.T) {
+		data := [][]byte{simulationTxData(t, ChannelID{0x01}, batch2)}
+		sim, err := SimulateDerivation(context.Background(), cfg, logger, chain, chain, safeHead, l1[3], data)
+		require.NoError(t, err)
+		require.Equal(t, "future", sim.Channels[0].Batches[0].Validity, "batch is not the next batch")
+		require.Empty(t, sim.Blocks)
+
+		parentMismatch := b(cfg.L2ChainID, 22, l1[1])
+		parentMismatch.ParentHash = mockHash(18, 2)
+		data = [][]byte{simulationTxData(t, ChannelID{0x01}, parentMismatch)}
+		sim, err = SimulateDerivation(context.Background(), cfg, logger, chain, chain, safeHead, l1[3], data)
+		require.NoError(t, err)
+		require.Equal(t, "drop", sim.Channels[0].Batches[0].Validity)
+		require.Empty(t, sim.Blocks)
+	})
+
+	t.Run("IncompleteChannel", func(t *testing.T) {
+		frame := Frame{ID: ChannelID{0x03}, FrameNumber: 1, Data: []byte{0x01}, IsLast: true}
+		txData := bytes.NewBuffer([]byte{DerivationVersion0})
+		require.NoError(t, frame.MarshalBinary(txData))
+		sim, err := SimulateDerivation(context.Background(), cfg, logger, chain, chain, safeHead, l1[3], [][]byte{txData.Bytes()})
+		require.NoError(t, err)
+		require.Equal(t, []eth.SimulatedChannel{{ID: ChannelID{0x03}.String(), Frames: 1}}, sim.Channels)
+	})
+
+	t.Run("ChannelOfOtherChain", func(t *testing.T) {
+		cfg := *cfg
+		cfg.SharedBatchInboxAddresses = []common.Address{{0xaa}}
+		var tagged ChannelID
+		tag := cfg.ChannelIDChainTag()
+		copy(tagged[:], tag[:])
+		tagged[15] = 0x01
+		data := [][]byte{
+			simulationTxData(t, ChannelID{0x01}, batch1),
+			simulationTxData(t, tagged, batch1),
+		}
+		sim, err := SimulateDerivation(context.Background(), &cfg, logger, chain, chain, safeHead, l1[3], data)
+		require.NoError(t, err)
+		require.Equal(t, "channel ID is not tagged with the chain ID", sim.Channels[0].Error)
+		require.Empty(t, sim.Channels[0].Batches)
+		require.Empty(t, sim.Channels[1].Error)
+		require.Equal(t, "accept", sim.Channels[1].Batches[0].Validity)
+	})
+
+	t.Run("InvalidData", func(t *testing.T) {
+		_, err := SimulateDerivation(context.Background(), cfg, logger, chain, chain, safeHead, l1[3], [][]byte{{0x01}})
+		require.ErrorContains(t, err, "invalid batcher transaction data 0")
+	})
+
+	t.Run("UnknownSafeHeadOrigin", func(t *testing.T) {
+		reorged := safeHead
+		reorged.L1Origin = eth.BlockID{Hash: mockHash(99, 1), Number: 1}
+		_, err := SimulateDerivation(context.Background(), cfg, logger, chain, chain, reorged, l1[3], nil)
+		require.ErrorContains(t, err, "not canonical")
+	})
+}
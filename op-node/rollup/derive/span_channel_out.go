@@ -2,7 +2,6 @@ package derive
 
 import (
 	"bytes"
-	"crypto/rand"
 	"fmt"
 	"io"
 	"math/big"
@@ -53,8 +52,12 @@ func (co *SpanChannelOut) ID() ChannelID {
 }
 
 func (co *SpanChannelOut) setRandomID() error {
-	_, err := rand.Read(co.id[:])
-	return err
+	id, err := newChannelID(co.chainSpec)
+	if err != nil {
+		return err
+	}
+	co.id = id
+	return nil
 }
 
 type SpanChannelOutOption func(co *SpanChannelOut)
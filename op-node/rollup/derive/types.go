@@ -14,6 +14,8 @@ const (
 	Brotli9  CompressionAlgo = "brotli-9"
 	Brotli10 CompressionAlgo = "brotli-10"
 	Brotli11 CompressionAlgo = "brotli-11"
+	// ZlibDict is deflate with the latest preset dictionary, for span batches since Holocene
+	ZlibDict CompressionAlgo = "zlib-dict"
 )
 
 var CompressionAlgos = []CompressionAlgo{
@@ -22,6 +24,7 @@ var CompressionAlgos = []CompressionAlgo{
 	Brotli9,
 	Brotli10,
 	Brotli11,
+	ZlibDict,
 }
 
 var brotliRegexp = regexp.MustCompile(`^brotli(|-(9|10|11))$`)
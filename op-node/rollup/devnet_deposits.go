@@ -0,0 +1,70 @@
+package rollup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	ErrMissingDevnetDepositIntent     = errors.New("devnet deposit intent cannot be empty")
+	ErrDuplicateDevnetDepositIntent   = errors.New("devnet deposit intent must be unique")
+	ErrMissingDevnetDepositGas        = errors.New("devnet deposit gas cannot be 0")
+	ErrDevnetDepositBeforeGenesis     = errors.New("devnet deposit must be after the L2 genesis time")
+	ErrUnsafeDevnetDepositsNotAllowed = errors.New("rollup config has unsafe devnet deposits, which must be explicitly allowed")
+)
+
+// DevnetDeposit is a synthetic system deposit that derivation inserts into the first L2 block at or after Time,
+// after the user deposits and network upgrade transactions of the block.
+// It is executed like the network upgrade transactions, with a source hash derived from the Intent,
+// so devnets can rehearse an upgrade, e.g. the deployment of a new predeploy implementation,
+// without modifying the L1 contracts or the op-node.
+// Devnet deposits change derivation, and are only accepted by nodes that explicitly allow them: never use them on a live network.
+type DevnetDeposit struct {
+	// Time is the L2 block time from which the deposit is included, in the first block at or after it.
+	Time uint64 `json:"time"`
+	// Intent describes the deposit, and must be unique.
+	Intent string         `json:"intent"`
+	From   common.Address `json:"from"`
+	// To is the address the deposit calls, or nil to create a contract.
+	To   *common.Address `json:"to,omitempty"`
+	Gas  uint64          `json:"gas"`
+	Data hexutil.Bytes   `json:"data,omitempty"`
+}
+
+func (d DevnetDeposit) String() string {
+	return fmt.Sprintf("%q@%d", d.Intent, d.Time)
+}
+
+// DevnetDeposits returns the devnet deposits to insert into the L2 block with the given time.
+func (cfg *Config) DevnetDeposits(l2BlockTime uint64) []DevnetDeposit {
+	var out []DevnetDeposit
+	for _, dep := range cfg.UnsafeDevnetDeposits {
+		if l2BlockTime >= dep.Time && (l2BlockTime < cfg.BlockTime || l2BlockTime-cfg.BlockTime < dep.Time) {
+			out = append(out, dep)
+		}
+	}
+	return out
+}
+
+func validateDevnetDeposits(cfg *Config) error {
+	intents := make(map[string]struct{}, len(cfg.UnsafeDevnetDeposits))
+	for i, dep := range cfg.UnsafeDevnetDeposits {
+		if dep.Intent == "" {
+			return fmt.Errorf("devnet deposit %d: %w", i, ErrMissingDevnetDepositIntent)
+		}
+		if _, ok := intents[dep.Intent]; ok {
+			return fmt.Errorf("devnet deposit %d (%v): %w", i, dep, ErrDuplicateDevnetDepositIntent)
+		}
+		intents[dep.Intent] = struct{}{}
+		if dep.Gas == 0 {
+			return fmt.Errorf("devnet deposit %d (%v): %w", i, dep, ErrMissingDevnetDepositGas)
+		}
+		if dep.Time <= cfg.Genesis.L2Time {
+			return fmt.Errorf("devnet deposit %d (%v): %w", i, dep, ErrDevnetDepositBeforeGenesis)
+		}
+	}
+	return nil
+}
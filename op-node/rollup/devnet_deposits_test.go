@@ -0,0 +1,47 @@
+package rollup
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevnetDeposits(t *testing.T) {
+	cfg := &Config{BlockTime: 2, UnsafeDevnetDeposits: []DevnetDeposit{
+		{Time: 100, Intent: "a"},
+		{Time: 101, Intent: "b"},
+	}}
+	intents := func(l2BlockTime uint64) (out []string) {
+		for _, dep := range cfg.DevnetDeposits(l2BlockTime) {
+			out = append(out, dep.Intent)
+		}
+		return out
+	}
+	require.Empty(t, intents(98))
+	require.Equal(t, []string{"a"}, intents(100))
+	require.Equal(t, []string{"b"}, intents(102), "included in the first block after its time")
+	require.Empty(t, intents(104))
+	require.Equal(t, []string{"a", "b"}, intents(101), "odd block times")
+	require.Empty(t, (&Config{BlockTime: 2}).DevnetDeposits(100))
+}
+
+func TestValidateDevnetDeposits(t *testing.T) {
+	check := func(deps ...DevnetDeposit) error {
+		return validateDevnetDeposits(&Config{Genesis: Genesis{L2Time: 10}, UnsafeDevnetDeposits: deps})
+	}
+	valid := DevnetDeposit{Time: 11, Intent: "a", From: common.Address{0x01}, Gas: 1000}
+	require.NoError(t, check())
+	require.NoError(t, check(valid))
+
+	noIntent := valid
+	noIntent.Intent = ""
+	require.ErrorIs(t, check(noIntent), ErrMissingDevnetDepositIntent)
+	require.ErrorIs(t, check(valid, valid), ErrDuplicateDevnetDepositIntent)
+	noGas := valid
+	noGas.Gas = 0
+	require.ErrorIs(t, check(noGas), ErrMissingDevnetDepositGas)
+	atGenesis := valid
+	atGenesis.Time = 10
+	require.ErrorIs(t, check(atGenesis), ErrDevnetDepositBeforeGenesis)
+}
@@ -1,5 +1,14 @@
 package driver
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	VerifierConfDepth uint64 `json:"verifier_conf_depth"`
@@ -11,6 +20,10 @@ type Config struct {
 	// and thus fail to produce a block with anything more than deposits.
 	SequencerConfDepth uint64 `json:"sequencer_conf_depth"`
 
+	// SequencerOriginPolicy is the name of the policy that applies SequencerConfDepth when selecting the next L1 origin.
+	// See sequencing.OriginPolicies. Defaults to the conservative policy if empty.
+	SequencerOriginPolicy string `json:"sequencer_origin_policy"`
+
 	// SequencerEnabled is true when the driver should sequence new blocks.
 	SequencerEnabled bool `json:"sequencer_enabled"`
 
@@ -20,4 +33,46 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// SequencerDepositsOnlyThreshold is the minimum time left before the timestamp of a new L2 block
+	// to build it with tx-pool transactions. Blocks that start building later, e.g. because the engine was slow,
+	// are built with deposits only, to keep block production on schedule. Disabled if 0.
+	SequencerDepositsOnlyThreshold time.Duration `json:"sequencer_deposits_only_threshold"`
+
+	// SequencerConditionalTxs configures the acceptance of conditional transactions by the sequencer.
+	SequencerConditionalTxs sequencing.ConditionalTxConfig `json:"sequencer_conditional_txs"`
+
+	// ConditionalTxs provides the conditional transactions the sequencer includes in new blocks.
+	// Optional, set by the node when SequencerConditionalTxs is enabled.
+	ConditionalTxs sequencing.ConditionalTxSource `json:"-"`
+
+	// BlockBuilder is the external block builder the sequencer builds blocks with, alongside the local engine.
+	// Optional and experimental, set by the node when an L2 builder endpoint is configured.
+	BlockBuilder engine.BlockBuilder `json:"-"`
+
+	// Clock is the clock the sequencer schedules block building with.
+	// Optional, defaults to the system clock. Tests may use a controllable clock
+	// to advance L2 block timestamps deterministically.
+	Clock clock.Clock `json:"-"`
+}
+
+// OriginPolicy returns the name of the sequencer origin selection policy, applying the default.
+func (cfg *Config) OriginPolicy() string {
+	if cfg.SequencerOriginPolicy == "" {
+		return sequencing.ConservativeOriginPolicy
+	}
+	return cfg.SequencerOriginPolicy
+}
+
+func (cfg *Config) Check() error {
+	if _, err := sequencing.NewOriginSelectionPolicy(cfg.OriginPolicy(), cfg.SequencerConfDepth); err != nil {
+		return fmt.Errorf("invalid sequencer origin policy: %w", err)
+	}
+	if cfg.SequencerDepositsOnlyThreshold < 0 {
+		return fmt.Errorf("sequencer deposits-only threshold must not be negative: %v", cfg.SequencerDepositsOnlyThreshold)
+	}
+	if err := cfg.SequencerConditionalTxs.Check(); err != nil {
+		return fmt.Errorf("invalid sequencer conditional transactions config: %w", err)
+	}
+	return nil
 }
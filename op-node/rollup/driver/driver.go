@@ -3,6 +3,7 @@ package driver
 import (
 	"context"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -17,9 +18,11 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -64,6 +67,7 @@ type L1Chain interface {
 
 type L2Chain interface {
 	engine.Engine
+	SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error)
 	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
 	L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error)
 	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
@@ -165,9 +169,15 @@ func NewDriver(
 	syncCfg *sync.Config,
 	sequencerConductor conductor.SequencerConductor,
 	altDA AltDAIface,
+	supervisor interop.InteropBackend,
 ) *Driver {
 	driverCtx, driverCancel := context.WithCancel(context.Background())
 
+	clk := driverCfg.Clock
+	if clk == nil {
+		clk = clock.SystemClock
+	}
+
 	var executor event.Executor
 	var drain func() error
 	// This instantiation will be one of more options: soon there will be a parallel events executor
@@ -181,7 +191,7 @@ func NewDriver(
 
 	opts := event.DefaultRegisterOpts()
 
-	statusTracker := status.NewStatusTracker(log, metrics)
+	statusTracker := status.NewStatusTracker(log, metrics, cfg)
 	sys.Register("status", statusTracker, opts)
 
 	l1Tracker := status.NewL1Tracker(l1)
@@ -200,12 +210,17 @@ func NewDriver(
 	sys.Register("cl-sync", clSync, opts)
 
 	var finalizer Finalizer
-	if cfg.AltDAEnabled() {
+	if supervisor != nil {
+		// In interop mode finality is determined by the supervisor, across all chains of the dependency set
+		sys.Register("interop", interop.NewInteropDeriver(log, cfg, driverCtx, supervisor, l2), opts)
+	} else if cfg.AltDAEnabled() {
 		finalizer = finality.NewAltDAFinalizer(driverCtx, log, cfg, l1, altDA)
 	} else {
 		finalizer = finality.NewFinalizer(driverCtx, log, cfg, l1)
 	}
-	sys.Register("finalizer", finalizer, opts)
+	if finalizer != nil {
+		sys.Register("finalizer", finalizer, opts)
+	}
 
 	sys.Register("attributes-handler",
 		attributes.NewAttributesHandler(log, cfg, driverCtx, l2), opts)
@@ -230,20 +245,31 @@ func NewDriver(
 	}
 	sys.Register("sync", syncDeriver, opts)
 
-	sys.Register("engine", engine.NewEngDeriver(log, driverCtx, cfg, metrics, ec), opts)
+	engDeriv := engine.NewEngDeriver(log, driverCtx, cfg, metrics, ec)
+	if driverCfg.BlockBuilder != nil {
+		engDeriv.SetBlockBuilder(driverCfg.BlockBuilder)
+	}
+	sys.Register("engine", engDeriv, opts)
 
 	schedDeriv := NewStepSchedulingDeriver(log)
 	sys.Register("step-scheduler", schedDeriv, opts)
 
 	var sequencer sequencing.SequencerIface
+	var findL1Origin *sequencing.L1OriginSelector
 	if driverCfg.SequencerEnabled {
 		asyncGossiper := async.NewAsyncGossiper(driverCtx, network, log, metrics)
 		attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
-		sequencerConfDepth := confdepth.NewConfDepth(driverCfg.SequencerConfDepth, statusTracker.L1Head, l1)
-		findL1Origin := sequencing.NewL1OriginSelector(log, cfg, sequencerConfDepth)
-		sequencer = sequencing.NewSequencer(driverCtx, log, cfg, attrBuilder, findL1Origin,
-			sequencerStateListener, sequencerConductor, asyncGossiper, metrics)
-		sys.Register("sequencer", sequencer, opts)
+		policy, err := sequencing.NewOriginSelectionPolicy(driverCfg.OriginPolicy(), driverCfg.SequencerConfDepth)
+		if err != nil {
+			log.Error("Invalid sequencer origin policy, falling back to conservative policy", "err", err)
+			policy = sequencing.NewConservativePolicy(driverCfg.SequencerConfDepth)
+		}
+		findL1Origin = sequencing.NewL1OriginSelector(log, cfg, l1, statusTracker.L1Head, policy)
+		seq := sequencing.NewSequencer(driverCtx, log, cfg, attrBuilder, findL1Origin,
+			sequencerStateListener, sequencerConductor, asyncGossiper, driverCfg.ConditionalTxs, metrics, clk)
+		seq.SetDepositsOnlyThreshold(driverCfg.SequencerDepositsOnlyThreshold)
+		sys.Register("sequencer", seq, opts)
+		sequencer = seq
 	} else {
 		sequencer = sequencing.DisabledSequencer{}
 	}
@@ -263,6 +289,8 @@ func NewDriver(
 		driverCancel:     driverCancel,
 		log:              log,
 		sequencer:        sequencer,
+		originSelector:   findL1Origin,
+		clock:            clk,
 		network:          network,
 		metrics:          metrics,
 		l1HeadSig:        make(chan eth.L1BlockRef, 10),
@@ -270,6 +298,7 @@ func NewDriver(
 		l1FinalizedSig:   make(chan eth.L1BlockRef, 10),
 		unsafeL2Payloads: make(chan *eth.ExecutionPayloadEnvelope, 10),
 		altSync:          altSync,
+		interopEnabled:   supervisor != nil,
 	}
 
 	return driver
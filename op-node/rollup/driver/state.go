@@ -16,15 +16,20 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
 // Deprecated: use eth.SyncStatus instead.
 type SyncStatus = eth.SyncStatus
 
+// elSyncPollInterval is the interval at which the sync progress of the execution engine is checked, while EL syncing.
+const elSyncPollInterval = 10 * time.Second
+
 type Driver struct {
 	eventSys event.System
 
@@ -61,12 +66,20 @@ type Driver struct {
 	// Interface to signal the L2 block range to sync.
 	altSync AltSync
 
+	// interopEnabled is true if the node reports to and syncs with an op-supervisor.
+	interopEnabled bool
+
 	// L2 Signals:
 
 	unsafeL2Payloads chan *eth.ExecutionPayloadEnvelope
 
 	sequencer sequencing.SequencerIface
-	network   Network // may be nil, network for is optional
+	// originSelector is nil if the sequencer is disabled
+	originSelector *sequencing.L1OriginSelector
+	network        Network // may be nil, network for is optional
+
+	// clock is used to schedule sequencer actions
+	clock clock.Clock
 
 	metrics Metrics
 	log     log.Logger
@@ -163,7 +176,7 @@ func (s *Driver) eventLoop() {
 	// L1 chain that we need to handle.
 	reqStep()
 
-	sequencerTimer := time.NewTimer(0)
+	var sequencerTimer clock.Timer
 	var sequencerCh <-chan time.Time
 	var prevTime time.Time
 	// planSequencerAction updates the sequencerTimer with the next action, if any.
@@ -183,13 +196,13 @@ func (s *Driver) eventLoop() {
 			return
 		}
 		prevTime = nextAction
-		sequencerCh = sequencerTimer.C
-		if len(sequencerCh) > 0 { // empty if not already drained before resetting
-			<-sequencerCh
+		if sequencerTimer != nil {
+			sequencerTimer.Stop()
 		}
-		delta := time.Until(nextAction)
+		delta := nextAction.Sub(s.clock.Now())
 		s.log.Info("Scheduled sequencer action", "delta", delta)
-		sequencerTimer.Reset(delta)
+		sequencerTimer = s.clock.NewTimer(delta)
+		sequencerCh = sequencerTimer.Ch()
 	}
 
 	// Create a ticker to check if there is a gap in the engine queue. Whenever
@@ -199,6 +212,22 @@ func (s *Driver) eventLoop() {
 	defer altSyncTicker.Stop()
 	lastUnsafeL2 := s.Engine.UnsafeL2Head()
 
+	// Check for cross-safety updates of the supervisor, if interop is enabled
+	var interopCh <-chan time.Time
+	if s.interopEnabled {
+		interopTicker := time.NewTicker(interop.PollInterval)
+		defer interopTicker.Stop()
+		interopCh = interopTicker.C
+	}
+
+	// Report the progress of the execution engine, while relying on it to sync
+	var elSyncCh <-chan time.Time
+	if s.SyncCfg.SyncMode == sync.ELSync {
+		elSyncTicker := time.NewTicker(elSyncPollInterval)
+		defer elSyncTicker.Stop()
+		elSyncCh = elSyncTicker.C
+	}
+
 	for {
 		if s.driverCtx.Err() != nil { // don't try to schedule/handle more work when we are closing.
 			return
@@ -235,6 +264,18 @@ func (s *Driver) eventLoop() {
 			if err != nil {
 				s.log.Warn("failed to check for unsafe L2 blocks to sync", "err", err)
 			}
+		case <-interopCh:
+			s.emitter.Emit(interop.CrossUpdateRequestEvent{})
+		case <-elSyncCh:
+			if !s.Engine.IsEngineSyncing() {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(s.driverCtx, time.Second*2)
+			err := s.checkELSyncProgress(ctx)
+			cancel()
+			if err != nil {
+				s.log.Warn("failed to check EL sync progress", "err", err)
+			}
 		case envelope := <-s.unsafeL2Payloads:
 			// If we are doing CL sync or done with engine syncing, fallback to the unsafe payload queue & CL P2P sync.
 			if s.SyncCfg.SyncMode == sync.CLSync || !s.Engine.IsEngineSyncing() {
@@ -497,10 +538,41 @@ func (s *Driver) SequencerActive(ctx context.Context) (bool, error) {
 	return s.sequencer.Active(), nil
 }
 
+// SetSequencerOriginPolicy changes the policy the sequencer selects new L1 origins with.
+func (s *Driver) SetSequencerOriginPolicy(ctx context.Context, name string) error {
+	if s.originSelector == nil {
+		return sequencing.ErrSequencerNotEnabled
+	}
+	policy, err := sequencing.NewOriginSelectionPolicy(name, s.driverConfig.SequencerConfDepth)
+	if err != nil {
+		return err
+	}
+	s.originSelector.SetPolicy(policy)
+	return nil
+}
+
+// SequencerOriginPolicy returns the name of the policy the sequencer selects new L1 origins with.
+func (s *Driver) SequencerOriginPolicy(ctx context.Context) (string, error) {
+	if s.originSelector == nil {
+		return "", sequencing.ErrSequencerNotEnabled
+	}
+	return s.originSelector.Policy().Name(), nil
+}
+
 func (s *Driver) OverrideLeader(ctx context.Context) error {
 	return s.sequencer.OverrideLeader(ctx)
 }
 
+// SetRecoverMode enables or disables sequencing of deposits-only blocks, to keep the chain live during incidents.
+func (s *Driver) SetRecoverMode(ctx context.Context, mode bool) error {
+	return s.sequencer.SetRecoverMode(ctx, mode)
+}
+
+// RecoverMode returns whether the sequencer only includes deposits in new blocks.
+func (s *Driver) RecoverMode(ctx context.Context) (bool, error) {
+	return s.sequencer.RecoverMode(), nil
+}
+
 // SyncStatus blocks the driver event loop and captures the syncing status.
 func (s *Driver) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
 	return s.statusTracker.SyncStatus(), nil
@@ -527,6 +599,16 @@ func (s *Driver) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2Bloc
 	}
 }
 
+// SimulateDerivation simulates the derivation of the given batcher transaction data on top of the current safe head,
+// as if it was included in the current L1 head. See derive.SimulateDerivation.
+func (s *Driver) SimulateDerivation(ctx context.Context, data [][]byte) (*eth.DerivationSimulation, error) {
+	status := s.statusTracker.SyncStatus()
+	if status.SafeL2 == (eth.L2BlockRef{}) || status.HeadL1 == (eth.L1BlockRef{}) {
+		return nil, errors.New("safe head and L1 head are not known yet")
+	}
+	return derive.SimulateDerivation(ctx, s.Config, s.log, s.L1, s.L2, status.SafeL2, status.HeadL1, data)
+}
+
 // checkForGapInUnsafeQueue checks if there is a gap in the unsafe queue and attempts to retrieve the missing payloads from an alt-sync method.
 // WARNING: This is only an outgoing signal, the blocks are not guaranteed to be retrieved.
 // Results are received through OnUnsafeL2Payload.
@@ -543,3 +625,27 @@ func (s *Driver) checkForGapInUnsafeQueue(ctx context.Context) error {
 	}
 	return nil
 }
+
+// checkELSyncProgress fetches the sync progress of the execution engine, and reports it in the sync status.
+func (s *Driver) checkELSyncProgress(ctx context.Context) error {
+	progress, err := s.L2.SyncProgress(ctx)
+	if err != nil {
+		return err
+	}
+	status := eth.ELSyncStatus{Syncing: true}
+	if progress != nil {
+		status.StartingBlock = progress.StartingBlock
+		status.CurrentBlock = progress.CurrentBlock
+		status.HighestBlock = progress.HighestBlock
+		status.SyncedAccounts = progress.SyncedAccounts
+		status.SyncedStorage = progress.SyncedStorage
+		status.SyncedBytecodes = progress.SyncedBytecodes
+		status.HealedTrienodes = progress.HealedTrienodes
+		status.HealingTrienodes = progress.HealingTrienodes
+	}
+	s.log.Info("EL sync in progress", "current", status.CurrentBlock, "highest", status.HighestBlock,
+		"synced_accounts", status.SyncedAccounts, "synced_storage", status.SyncedStorage,
+		"healing_trienodes", status.HealingTrienodes, "unsafe_l2", s.Engine.UnsafeL2Head())
+	s.emitter.Emit(engine.ELSyncProgressEvent{Progress: status})
+	return nil
+}
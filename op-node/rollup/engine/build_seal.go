@@ -88,6 +88,14 @@ func (eq *EngDeriver) onBuildSeal(ev BuildSealEvent) {
 		return
 	}
 
+	if eq.builderJob != nil {
+		if built := eq.sealBuilderJob(ev.Info.ID, envelope); built != nil {
+			eq.log.Info("Adopted payload from external builder", "blockhash", built.ExecutionPayload.BlockHash,
+				"txs", len(built.ExecutionPayload.Transactions), "local_txs", len(envelope.ExecutionPayload.Transactions))
+			envelope = built
+		}
+	}
+
 	ref, err := derive.PayloadToBlockRef(eq.cfg, envelope.ExecutionPayload)
 	if err != nil {
 		eq.emitter.Emit(PayloadSealInvalidEvent{
@@ -59,6 +59,7 @@ func (eq *EngDeriver) onBuildStart(ev BuildStartEvent) {
 		}
 	}
 	eq.emitter.Emit(fcEvent)
+	eq.startBuilderJob(fc, ev.Attributes, id)
 
 	eq.emitter.Emit(BuildStartedEvent{
 		Info:         eth.PayloadInfo{ID: id, Timestamp: uint64(ev.Attributes.Attributes.Timestamp)},
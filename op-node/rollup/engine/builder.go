@@ -0,0 +1,184 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// builderSealBudget is the fraction of the block time that sealing waits for the payload of the builder,
+// and for the local engine to validate it, before falling back to the local payload.
+const builderSealBudget = 4
+
+// BlockBuilder is an external block builder endpoint. It speaks the subset of the engine API
+// that builds blocks, so it can be another execution engine that follows the same chain.
+// The sequencer starts each block with tx-pool transactions on both the local engine and the builder,
+// and adopts the payload of the builder if it is valid, falling back to the local payload otherwise.
+type BlockBuilder interface {
+	ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+	GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error)
+}
+
+// builderJob is a block building job of the external builder, that runs alongside the local job.
+type builderJob struct {
+	// localID is the ID of the local job building the same block
+	localID eth.PayloadID
+	attrs   *eth.PayloadAttributes
+
+	// started is closed when the builder has started the job, or failed to, see err.
+	// info and err are only set before it is closed.
+	started chan struct{}
+	info    eth.PayloadInfo
+	err     error
+}
+
+// startBuilderJob starts building the block on the external builder, if there is one and the block is sequenced.
+// The job is started in the background, to not delay the local job: failing to start it in time for sealing
+// only disables the builder for this block.
+func (eq *EngDeriver) startBuilderJob(fc eth.ForkchoiceState, attrs *derive.AttributesWithParent, localID eth.PayloadID) {
+	eq.builderJob = nil
+	if eq.builder == nil || attrs.DerivedFrom != (eth.L1BlockRef{}) || attrs.Attributes.NoTxPool {
+		return
+	}
+	job := &builderJob{
+		localID: localID,
+		attrs:   attrs.Attributes,
+		started: make(chan struct{}),
+	}
+	eq.builderJob = job
+	go func() {
+		defer close(job.started)
+		ctx, cancel := context.WithTimeout(eq.ctx, eq.blockTime())
+		defer cancel()
+		fcRes, err := eq.builder.ForkchoiceUpdate(ctx, &fc, attrs.Attributes)
+		if err == nil && fcRes.PayloadStatus.Status != eth.ExecutionValid {
+			err = eth.ForkchoiceUpdateErr(fcRes.PayloadStatus)
+		} else if err == nil && fcRes.PayloadID == nil {
+			err = errors.New("nil id in forkchoice result when expecting a valid ID")
+		}
+		if err != nil {
+			job.err = err
+			return
+		}
+		job.info = eth.PayloadInfo{ID: *fcRes.PayloadID, Timestamp: uint64(attrs.Attributes.Timestamp)}
+	}()
+}
+
+func (eq *EngDeriver) blockTime() time.Duration {
+	return time.Duration(eq.cfg.BlockTime) * time.Second
+}
+
+// sealBuilderJob returns the payload of the external builder job that runs alongside the local job,
+// if the payload is valid. It returns nil to fall back to the local payload otherwise.
+func (eq *EngDeriver) sealBuilderJob(localID eth.PayloadID, local *eth.ExecutionPayloadEnvelope) *eth.ExecutionPayloadEnvelope {
+	job := eq.builderJob
+	eq.builderJob = nil
+	if job == nil || job.localID != localID {
+		return nil
+	}
+	select {
+	case <-job.started:
+	default:
+		eq.log.Warn("External builder did not start the block building job in time, using local payload", "localID", localID)
+		return nil
+	}
+	if job.err != nil {
+		eq.log.Warn("Failed to start block building job on external builder, using local payload", "err", job.err)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(eq.ctx, eq.blockTime()/builderSealBudget)
+	defer cancel()
+	envelope, err := eq.builder.GetPayload(ctx, job.info)
+	if err != nil {
+		eq.log.Warn("Failed to get payload from external builder, using local payload",
+			"payloadID", job.info.ID, "err", err)
+		return nil
+	}
+	if err := eq.validateBuilderPayload(ctx, job.attrs, local, envelope); err != nil {
+		eq.log.Warn("Invalid payload from external builder, using local payload",
+			"payloadID", job.info.ID, "blockhash", envelope.ExecutionPayload.BlockHash, "err", err)
+		return nil
+	}
+	return envelope
+}
+
+// validateBuilderPayload checks that the payload of the builder builds the same block as the local payload,
+// with the same block properties and forced transactions of the attributes, and that the local engine accepts it.
+func (eq *EngDeriver) validateBuilderPayload(ctx context.Context, attrs *eth.PayloadAttributes, local, built *eth.ExecutionPayloadEnvelope) error {
+	if err := checkBuilderPayload(attrs, local, built); err != nil {
+		return err
+	}
+	status, err := eq.ec.engine.NewPayload(ctx, built.ExecutionPayload, built.ParentBeaconBlockRoot)
+	if err != nil {
+		return fmt.Errorf("failed to execute payload: %w", err)
+	}
+	if status.Status != eth.ExecutionValid {
+		return eth.NewPayloadErr(built.ExecutionPayload, status)
+	}
+	return nil
+}
+
+// checkBuilderPayload checks the payload of the builder against the local payload for the same attributes,
+// without executing it.
+func checkBuilderPayload(attrs *eth.PayloadAttributes, local, built *eth.ExecutionPayloadEnvelope) error {
+	if built == nil || built.ExecutionPayload == nil {
+		return errors.New("empty payload")
+	}
+	want, got := local.ExecutionPayload, built.ExecutionPayload
+	if got.ParentHash != want.ParentHash {
+		return fmt.Errorf("payload builds on %s, expected %s", got.ParentHash, want.ParentHash)
+	}
+	if got.BlockNumber != want.BlockNumber {
+		return fmt.Errorf("payload has block number %d, expected %d", got.BlockNumber, want.BlockNumber)
+	}
+	if got.Timestamp != want.Timestamp {
+		return fmt.Errorf("payload has timestamp %d, expected %d", got.Timestamp, want.Timestamp)
+	}
+	if got.FeeRecipient != want.FeeRecipient {
+		return fmt.Errorf("payload has fee recipient %s, expected %s", got.FeeRecipient, want.FeeRecipient)
+	}
+	if got.PrevRandao != want.PrevRandao {
+		return fmt.Errorf("payload has prev-randao %s, expected %s", got.PrevRandao, want.PrevRandao)
+	}
+	if got.GasLimit != want.GasLimit {
+		return fmt.Errorf("payload has gas limit %d, expected %d", got.GasLimit, want.GasLimit)
+	}
+	if !bytes.Equal(got.ExtraData, want.ExtraData) {
+		return fmt.Errorf("payload has extra data %x, expected %x", got.ExtraData, want.ExtraData)
+	}
+	if (built.ParentBeaconBlockRoot == nil) != (local.ParentBeaconBlockRoot == nil) ||
+		(built.ParentBeaconBlockRoot != nil && *built.ParentBeaconBlockRoot != *local.ParentBeaconBlockRoot) {
+		return errors.New("payload has a different parent beacon block root")
+	}
+	if (got.Withdrawals == nil) != (want.Withdrawals == nil) ||
+		(got.Withdrawals != nil && len(*got.Withdrawals) != len(*want.Withdrawals)) {
+		return errors.New("payload has different withdrawals")
+	}
+	if err := sanityCheckPayload(got); err != nil {
+		return err
+	}
+	// the forced transactions of the attributes come first, unchanged
+	if len(got.Transactions) < len(attrs.Transactions) {
+		return fmt.Errorf("payload has %d transactions, expected at least the %d forced transactions",
+			len(got.Transactions), len(attrs.Transactions))
+	}
+	for i, tx := range attrs.Transactions {
+		if !bytes.Equal(got.Transactions[i], tx) {
+			return fmt.Errorf("payload transaction %d is not the forced transaction", i)
+		}
+	}
+	// derivation only accepts the deposits of the attributes, a block with other deposits would be reorged out
+	for i := len(attrs.Transactions); i < len(got.Transactions); i++ {
+		if len(got.Transactions[i]) > 0 && got.Transactions[i][0] == types.DepositTxType {
+			return fmt.Errorf("payload transaction %d is a deposit that is not forced", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestCheckBuilderPayload(t *testing.T) {
+	deposit := eth.Data{types.DepositTxType, 0x01}
+	userTx := eth.Data{types.DynamicFeeTxType, 0x02}
+	attrs := &eth.PayloadAttributes{Transactions: []eth.Data{deposit}}
+	beaconRoot := common.Hash{0xbe}
+	newEnvelope := func(txs ...eth.Data) *eth.ExecutionPayloadEnvelope {
+		return &eth.ExecutionPayloadEnvelope{
+			ParentBeaconBlockRoot: &beaconRoot,
+			ExecutionPayload: &eth.ExecutionPayload{
+				ParentHash:   common.Hash{0x01},
+				BlockNumber:  10,
+				Timestamp:    1000,
+				FeeRecipient: common.Address{0x02},
+				GasLimit:     30_000_000,
+				Withdrawals:  &types.Withdrawals{},
+				Transactions: txs,
+			},
+		}
+	}
+	local := newEnvelope(deposit)
+
+	require.NoError(t, checkBuilderPayload(attrs, local, newEnvelope(deposit)))
+	require.NoError(t, checkBuilderPayload(attrs, local, newEnvelope(deposit, userTx)))
+
+	for name, modify := range map[string]func(e *eth.ExecutionPayloadEnvelope){
+		"parent":      func(e *eth.ExecutionPayloadEnvelope) { e.ExecutionPayload.ParentHash = common.Hash{0xff} },
+		"number":      func(e *eth.ExecutionPayloadEnvelope) { e.ExecutionPayload.BlockNumber++ },
+		"timestamp":   func(e *eth.ExecutionPayloadEnvelope) { e.ExecutionPayload.Timestamp++ },
+		"recipient":   func(e *eth.ExecutionPayloadEnvelope) { e.ExecutionPayload.FeeRecipient = common.Address{0xff} },
+		"gas limit":   func(e *eth.ExecutionPayloadEnvelope) { e.ExecutionPayload.GasLimit++ },
+		"extra data":  func(e *eth.ExecutionPayloadEnvelope) { e.ExecutionPayload.ExtraData = eth.BytesMax32{0x01} },
+		"beacon root": func(e *eth.ExecutionPayloadEnvelope) { e.ParentBeaconBlockRoot = nil },
+		"withdrawals": func(e *eth.ExecutionPayloadEnvelope) { e.ExecutionPayload.Withdrawals = nil },
+		"no forced txs": func(e *eth.ExecutionPayloadEnvelope) {
+			e.ExecutionPayload.Transactions = []eth.Data{userTx}
+		},
+		"other forced tx": func(e *eth.ExecutionPayloadEnvelope) {
+			e.ExecutionPayload.Transactions = []eth.Data{{types.DepositTxType, 0x03}, userTx}
+		},
+		"unforced deposit": func(e *eth.ExecutionPayloadEnvelope) {
+			e.ExecutionPayload.Transactions = []eth.Data{deposit, {types.DepositTxType, 0x04}, userTx}
+		},
+		"deposit after tx": func(e *eth.ExecutionPayloadEnvelope) {
+			e.ExecutionPayload.Transactions = []eth.Data{deposit, userTx, deposit}
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			built := newEnvelope(deposit, userTx)
+			modify(built)
+			require.Error(t, checkBuilderPayload(attrs, local, built))
+		})
+	}
+	require.Error(t, checkBuilderPayload(attrs, local, &eth.ExecutionPayloadEnvelope{}))
+}
+
+type stubBlockBuilder struct {
+	// release unblocks ForkchoiceUpdate
+	release chan struct{}
+}
+
+func (s *stubBlockBuilder) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}, nil
+}
+
+func (s *stubBlockBuilder) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestBuilderJobNotStarted(t *testing.T) {
+	builder := &stubBlockBuilder{release: make(chan struct{})}
+	eq := NewEngDeriver(testlog.Logger(t, log.LevelDebug), context.Background(), &rollup.Config{BlockTime: 2}, nil, nil)
+	eq.SetBlockBuilder(builder)
+	attrs := &derive.AttributesWithParent{Attributes: &eth.PayloadAttributes{}}
+	localID := eth.PayloadID{0x01}
+
+	// the builder blocks starting the job: starting and sealing don't wait for it
+	eq.startBuilderJob(eth.ForkchoiceState{}, attrs, localID)
+	job := eq.builderJob
+	require.NotNil(t, job)
+	require.Nil(t, eq.sealBuilderJob(localID, &eth.ExecutionPayloadEnvelope{}))
+	require.Nil(t, eq.builderJob)
+
+	close(builder.release)
+	<-job.started
+	require.ErrorContains(t, job.err, "nil id in forkchoice result")
+}
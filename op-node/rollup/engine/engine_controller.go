@@ -33,7 +33,11 @@ const (
 	syncStatusFinishedEL                // EL sync is done & we should be performing consolidation
 )
 
-var ErrNoFCUNeeded = errors.New("no FCU call was needed")
+var (
+	ErrNoFCUNeeded = errors.New("no FCU call was needed")
+	// ErrELSyncing is returned when a forkchoice update is deferred until the execution engine finished EL sync.
+	ErrELSyncing = errors.New("forkchoice update deferred while EL syncing")
+)
 
 type ExecEngine interface {
 	GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error)
@@ -231,6 +235,13 @@ func (e *EngineController) TryUpdateEngine(ctx context.Context) error {
 	if !e.needFCUCall {
 		return ErrNoFCUNeeded
 	}
+	if e.syncStatus == syncStatusStartedEL {
+		// The engine syncs towards the unsafe payloads it is given, and does not have the safe and finalized
+		// blocks of the rollup node yet. The pending changes are applied once the EL sync is finished.
+		e.log.Debug("Deferring forkchoice update while EL syncing",
+			"unsafe", e.unsafeHead, "safe", e.safeHead, "finalized", e.finalizedHead)
+		return ErrELSyncing
+	}
 	if e.IsEngineSyncing() {
 		e.log.Warn("Attempting to update forkchoice state while EL syncing")
 	}
@@ -279,9 +290,11 @@ func (e *EngineController) InsertUnsafePayload(ctx context.Context, envelope *et
 			e.syncStatus = syncStatusStartedEL
 			e.log.Info("Starting EL sync")
 			e.elStart = e.clock.Now()
+			e.emitter.Emit(ELSyncStartedEvent{})
 		} else if err == nil {
 			e.syncStatus = syncStatusFinishedEL
 			e.log.Info("Skipping EL sync and going straight to CL sync because there is a finalized block", "id", b.ID())
+			e.emitter.Emit(ELSyncFinishedEvent{Finalized: b})
 			return nil
 		} else {
 			return derive.NewTemporaryError(fmt.Errorf("failed to fetch finalized head: %w", err))
@@ -338,8 +351,10 @@ func (e *EngineController) InsertUnsafePayload(ctx context.Context, envelope *et
 	e.needFCUCall = false
 
 	if e.syncStatus == syncStatusFinishedELButNotFinalized {
-		e.log.Info("Finished EL sync", "sync_duration", e.clock.Since(e.elStart), "finalized_block", ref.ID().String())
+		duration := e.clock.Since(e.elStart)
+		e.log.Info("Finished EL sync", "sync_duration", duration, "finalized_block", ref.ID().String())
 		e.syncStatus = syncStatusFinishedEL
+		e.emitter.Emit(ELSyncFinishedEvent{Finalized: ref, Duration: duration})
 	}
 
 	if fcRes.PayloadStatus.Status == eth.ExecutionValid {
@@ -133,6 +133,37 @@ func (ev PromoteFinalizedEvent) String() string {
 	return "promote-finalized"
 }
 
+// ELSyncStartedEvent signals that the rollup node relies on the execution engine to sync the chain,
+// and holds off derivation until the engine is done.
+type ELSyncStartedEvent struct {
+}
+
+func (ev ELSyncStartedEvent) String() string {
+	return "el-sync-started"
+}
+
+// ELSyncProgressEvent reports the progress of the execution engine while it is EL syncing.
+type ELSyncProgressEvent struct {
+	Progress eth.ELSyncStatus
+}
+
+func (ev ELSyncProgressEvent) String() string {
+	return "el-sync-progress"
+}
+
+// ELSyncFinishedEvent signals that the execution engine is done syncing,
+// and that the rollup node switches to consolidation and derivation from L1.
+type ELSyncFinishedEvent struct {
+	// Finalized is the block marked as finalized at the end of the EL sync,
+	// or the existing finalized block of the engine, if EL sync was skipped.
+	Finalized eth.L2BlockRef
+	Duration  time.Duration
+}
+
+func (ev ELSyncFinishedEvent) String() string {
+	return "el-sync-finished"
+}
+
 type EngDeriver struct {
 	metrics Metrics
 
@@ -141,6 +172,10 @@ type EngDeriver struct {
 	ec      *EngineController
 	ctx     context.Context
 	emitter event.Emitter
+
+	// builder is the optional external block builder, see SetBlockBuilder
+	builder    BlockBuilder
+	builderJob *builderJob
 }
 
 var _ event.Deriver = (*EngDeriver)(nil)
@@ -156,6 +191,12 @@ func NewEngDeriver(log log.Logger, ctx context.Context, cfg *rollup.Config,
 	}
 }
 
+// SetBlockBuilder sets an external block builder that sequenced blocks are built with alongside the local engine.
+// Experimental: the payload of the builder is adopted only if it is valid, otherwise the local payload is used.
+func (d *EngDeriver) SetBlockBuilder(builder BlockBuilder) {
+	d.builder = builder
+}
+
 func (d *EngDeriver) AttachEmitter(em event.Emitter) {
 	d.emitter = em
 }
@@ -186,7 +227,7 @@ func (d *EngDeriver) OnEvent(ev event.Event) bool {
 	case TryUpdateEngineEvent:
 		// If we don't need to call FCU, keep going b/c this was a no-op. If we needed to
 		// perform a network call, then we should yield even if we did not encounter an error.
-		if err := d.ec.TryUpdateEngine(d.ctx); err != nil && !errors.Is(err, ErrNoFCUNeeded) {
+		if err := d.ec.TryUpdateEngine(d.ctx); err != nil && !errors.Is(err, ErrNoFCUNeeded) && !errors.Is(err, ErrELSyncing) {
 			if errors.Is(err, derive.ErrReset) {
 				d.emitter.Emit(rollup.ResetEvent{Err: err})
 			} else if errors.Is(err, derive.ErrTemporary) {
@@ -0,0 +1,227 @@
+// Package interop connects the node to the op-supervisor: it reports the local heads of the chain,
+// and tracks the cross-safe and finalized heads that the supervisor determines across all chains of the dependency set.
+package interop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// PollInterval is how often the supervisor is checked for cross-safe and finalized updates.
+const PollInterval = 2 * time.Second
+
+const rpcTimeout = 10 * time.Second
+
+var ErrInvalidatedBlock = errors.New("block was invalidated by the supervisor")
+
+type InteropBackend interface {
+	UpdateLocalUnsafe(ctx context.Context, chainID types.ChainID, head eth.L2BlockRef) error
+	UpdateLocalSafe(ctx context.Context, chainID types.ChainID, derivedFrom eth.L1BlockRef, lastDerived eth.L2BlockRef) error
+	CrossSafe(ctx context.Context, chainID types.ChainID) (eth.BlockID, error)
+	Finalized(ctx context.Context, chainID types.ChainID) (eth.BlockID, error)
+	CheckBlock(ctx context.Context, chainID types.ChainID, blockHash common.Hash, blockNumber uint64) (types.SafetyLevel, error)
+}
+
+type L2Source interface {
+	L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error)
+}
+
+// CrossUpdateRequestEvent requests the InteropDeriver to sync with the supervisor:
+// to retry reporting any local heads that failed to be reported, and to check for cross-safety updates.
+type CrossUpdateRequestEvent struct{}
+
+func (ev CrossUpdateRequestEvent) String() string {
+	return "cross-update-request"
+}
+
+// CrossSafeUpdateEvent signals that the supervisor marked a new block as cross-safe.
+type CrossSafeUpdateEvent struct {
+	CrossSafe eth.L2BlockRef
+}
+
+func (ev CrossSafeUpdateEvent) String() string {
+	return "cross-safe-update"
+}
+
+// InteropDeriver reports the local-unsafe and local-safe heads to the supervisor, and consumes
+// the cross-safe and finalized heads of the supervisor. The supervisor is the source of finality in interop mode.
+// If the supervisor invalidates a local-safe block, the chain is rewound to the cross-safe head and re-derived.
+type InteropDeriver struct {
+	log     log.Logger
+	cfg     *rollup.Config
+	ctx     context.Context
+	chainID types.ChainID
+
+	backend InteropBackend
+	l2      L2Source
+
+	// Local heads that still have to be reported to the supervisor.
+	// Failed reports are retried on the next CrossUpdateRequestEvent.
+	pendingUnsafe        *eth.L2BlockRef
+	pendingSafe          *engine.SafeDerivedEvent
+	unsafe, localSafe    eth.L2BlockRef
+	crossSafe, finalized eth.L2BlockRef
+
+	emitter event.Emitter
+}
+
+func NewInteropDeriver(log log.Logger, cfg *rollup.Config, ctx context.Context, backend InteropBackend, l2 L2Source) *InteropDeriver {
+	return &InteropDeriver{
+		log:     log,
+		cfg:     cfg,
+		ctx:     ctx,
+		chainID: types.ChainIDFromBig(cfg.L2ChainID),
+		backend: backend,
+		l2:      l2,
+	}
+}
+
+func (d *InteropDeriver) AttachEmitter(em event.Emitter) {
+	d.emitter = em
+}
+
+func (d *InteropDeriver) OnEvent(ev event.Event) bool {
+	switch x := ev.(type) {
+	case engine.ForkchoiceUpdateEvent:
+		if x.FinalizedL2Head.Number > d.finalized.Number {
+			d.finalized = x.FinalizedL2Head
+		}
+		if x.UnsafeL2Head != d.unsafe {
+			d.unsafe = x.UnsafeL2Head
+			d.pendingUnsafe = &x.UnsafeL2Head
+			d.reportUnsafe()
+		}
+	case engine.SafeDerivedEvent:
+		d.localSafe = x.Safe
+		d.pendingSafe = &x
+		d.reportSafe()
+	case engine.EngineResetConfirmedEvent:
+		d.unsafe = x.Unsafe
+		d.localSafe = x.Safe
+		d.pendingUnsafe = &x.Unsafe
+		d.reportUnsafe()
+	case CrossUpdateRequestEvent:
+		d.reportUnsafe()
+		d.reportSafe()
+		d.checkCrossSafe()
+		d.checkFinalized()
+	default:
+		return false
+	}
+	return true
+}
+
+func (d *InteropDeriver) reportUnsafe() {
+	if d.pendingUnsafe == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(d.ctx, rpcTimeout)
+	defer cancel()
+	if err := d.backend.UpdateLocalUnsafe(ctx, d.chainID, *d.pendingUnsafe); err != nil {
+		d.log.Warn("Failed to report local-unsafe head to supervisor, will retry", "head", *d.pendingUnsafe, "err", err)
+		return
+	}
+	d.pendingUnsafe = nil
+}
+
+func (d *InteropDeriver) reportSafe() {
+	if d.pendingSafe == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(d.ctx, rpcTimeout)
+	defer cancel()
+	if err := d.backend.UpdateLocalSafe(ctx, d.chainID, d.pendingSafe.DerivedFrom, d.pendingSafe.Safe); err != nil {
+		d.log.Warn("Failed to report local-safe head to supervisor, will retry",
+			"head", d.pendingSafe.Safe, "derivedFrom", d.pendingSafe.DerivedFrom, "err", err)
+		return
+	}
+	d.pendingSafe = nil
+}
+
+func (d *InteropDeriver) checkCrossSafe() {
+	ctx, cancel := context.WithTimeout(d.ctx, rpcTimeout)
+	defer cancel()
+	// Only interop blocks that have been reported as local-safe can be invalidated by the supervisor
+	if d.localSafe != (eth.L2BlockRef{}) && d.cfg.IsInterop(d.localSafe.Time) &&
+		d.localSafe.Number > d.crossSafe.Number && d.pendingSafe == nil {
+		level, err := d.backend.CheckBlock(ctx, d.chainID, d.localSafe.Hash, d.localSafe.Number)
+		if err != nil {
+			d.log.Warn("Failed to check local-safe block with supervisor", "block", d.localSafe, "err", err)
+			return
+		}
+		if level == types.Invalid {
+			d.onInvalidated(ctx, d.localSafe)
+			return
+		}
+	}
+	id, err := d.backend.CrossSafe(ctx, d.chainID)
+	if err != nil {
+		d.log.Warn("Failed to get cross-safe head from supervisor", "err", err)
+		return
+	}
+	if id == d.crossSafe.ID() || id == (eth.BlockID{}) {
+		return
+	}
+	ref, err := d.l2.L2BlockRefByHash(ctx, id.Hash)
+	if err != nil {
+		d.log.Warn("Failed to retrieve cross-safe block", "block", id, "err", err)
+		return
+	}
+	d.log.Info("New cross-safe block", "block", ref)
+	d.crossSafe = ref
+	d.emitter.Emit(CrossSafeUpdateEvent{CrossSafe: ref})
+}
+
+func (d *InteropDeriver) checkFinalized() {
+	ctx, cancel := context.WithTimeout(d.ctx, rpcTimeout)
+	defer cancel()
+	id, err := d.backend.Finalized(ctx, d.chainID)
+	if err != nil {
+		d.log.Warn("Failed to get finalized head from supervisor", "err", err)
+		return
+	}
+	// The engine only finalizes blocks that it considers safe
+	if id.Number <= d.finalized.Number || id.Number > d.localSafe.Number || id == (eth.BlockID{}) {
+		return
+	}
+	ref, err := d.l2.L2BlockRefByHash(ctx, id.Hash)
+	if err != nil {
+		d.log.Warn("Failed to retrieve finalized block", "block", id, "err", err)
+		return
+	}
+	d.finalized = ref
+	d.emitter.Emit(engine.PromoteFinalizedEvent{Ref: ref})
+}
+
+// onInvalidated rewinds the chain to the parent of the invalidated block, and the safe head to the cross-safe head,
+// so the invalidated block is dropped, and the chain re-derived from the cross-safe head.
+func (d *InteropDeriver) onInvalidated(ctx context.Context, invalidated eth.L2BlockRef) {
+	d.log.Warn("Local-safe block was invalidated by the supervisor, re-deriving from the cross-safe head",
+		"invalidated", invalidated, "crossSafe", d.crossSafe)
+	parent, err := d.l2.L2BlockRefByHash(ctx, invalidated.ParentHash)
+	if err != nil {
+		d.log.Warn("Failed to retrieve parent of invalidated block, will retry", "block", invalidated, "err", err)
+		return
+	}
+	safe := d.crossSafe
+	if safe == (eth.L2BlockRef{}) || safe.Number > parent.Number {
+		safe = parent
+	}
+	finalized := d.finalized
+	if finalized.Number > safe.Number {
+		finalized = safe
+	}
+	d.emitter.Emit(engine.ForceEngineResetEvent{Unsafe: parent, Safe: safe, Finalized: finalized})
+	d.emitter.Emit(rollup.ResetEvent{Err: fmt.Errorf("%w: %v", ErrInvalidatedBlock, invalidated)})
+}
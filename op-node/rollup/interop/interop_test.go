@@ -0,0 +1,190 @@
+package interop
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand" // nosemgrep
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+type fakeBackend struct {
+	err error
+
+	localUnsafe []eth.L2BlockRef
+	localSafe   []eth.L2BlockRef
+	derivedFrom []eth.L1BlockRef
+
+	crossSafe eth.BlockID
+	finalized eth.BlockID
+	levels    map[common.Hash]types.SafetyLevel
+}
+
+func (f *fakeBackend) UpdateLocalUnsafe(ctx context.Context, chainID types.ChainID, head eth.L2BlockRef) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.localUnsafe = append(f.localUnsafe, head)
+	return nil
+}
+
+func (f *fakeBackend) UpdateLocalSafe(ctx context.Context, chainID types.ChainID, derivedFrom eth.L1BlockRef, lastDerived eth.L2BlockRef) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.localSafe = append(f.localSafe, lastDerived)
+	f.derivedFrom = append(f.derivedFrom, derivedFrom)
+	return nil
+}
+
+func (f *fakeBackend) CrossSafe(ctx context.Context, chainID types.ChainID) (eth.BlockID, error) {
+	return f.crossSafe, f.err
+}
+
+func (f *fakeBackend) Finalized(ctx context.Context, chainID types.ChainID) (eth.BlockID, error) {
+	return f.finalized, f.err
+}
+
+func (f *fakeBackend) CheckBlock(ctx context.Context, chainID types.ChainID, blockHash common.Hash, blockNumber uint64) (types.SafetyLevel, error) {
+	if f.err != nil {
+		return types.Unsafe, f.err
+	}
+	if lvl, ok := f.levels[blockHash]; ok {
+		return lvl, nil
+	}
+	return types.Unsafe, nil
+}
+
+type fakeL2 map[common.Hash]eth.L2BlockRef
+
+func (f fakeL2) L2BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L2BlockRef, error) {
+	ref, ok := f[hash]
+	if !ok {
+		return eth.L2BlockRef{}, errors.New("not found")
+	}
+	return ref, nil
+}
+
+// testChain creates a chain of n L2 blocks, and a fake L2 source serving them.
+func testChain(rng *rand.Rand, n int) ([]eth.L2BlockRef, fakeL2) {
+	l2 := make(fakeL2)
+	blocks := make([]eth.L2BlockRef, n)
+	parent := common.Hash{}
+	for i := range blocks {
+		blocks[i] = eth.L2BlockRef{
+			Hash:       testutils.RandomHash(rng),
+			Number:     uint64(100 + i),
+			ParentHash: parent,
+			Time:       uint64(1000 + i*2),
+		}
+		parent = blocks[i].Hash
+		l2[blocks[i].Hash] = blocks[i]
+	}
+	return blocks, l2
+}
+
+func newTestDeriver(t *testing.T, backend InteropBackend, l2 L2Source) (*InteropDeriver, *testutils.MockEmitter) {
+	interopTime := uint64(0)
+	cfg := &rollup.Config{L2ChainID: big.NewInt(901), InteropTime: &interopTime}
+	d := NewInteropDeriver(testlog.Logger(t, log.LevelInfo), cfg, context.Background(), backend, l2)
+	emitter := &testutils.MockEmitter{}
+	d.AttachEmitter(emitter)
+	return d, emitter
+}
+
+func TestReportLocalHeads(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	blocks, l2 := testChain(rng, 5)
+	l1 := testutils.RandomBlockRef(rng)
+	backend := &fakeBackend{}
+	d, emitter := newTestDeriver(t, backend, l2)
+
+	d.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: blocks[3], SafeL2Head: blocks[1]})
+	require.Equal(t, []eth.L2BlockRef{blocks[3]}, backend.localUnsafe)
+	// Unchanged unsafe heads are not reported again
+	d.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: blocks[3], SafeL2Head: blocks[2]})
+	require.Len(t, backend.localUnsafe, 1)
+
+	d.OnEvent(engine.SafeDerivedEvent{Safe: blocks[2], DerivedFrom: l1})
+	require.Equal(t, []eth.L2BlockRef{blocks[2]}, backend.localSafe)
+	require.Equal(t, []eth.L1BlockRef{l1}, backend.derivedFrom)
+
+	// Failed reports are retried on the next update request
+	backend.err = errors.New("supervisor unavailable")
+	d.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: blocks[4], SafeL2Head: blocks[2]})
+	d.OnEvent(engine.SafeDerivedEvent{Safe: blocks[3], DerivedFrom: l1})
+	require.Len(t, backend.localUnsafe, 1)
+	require.Len(t, backend.localSafe, 1)
+
+	backend.err = nil
+	d.OnEvent(CrossUpdateRequestEvent{})
+	require.Equal(t, []eth.L2BlockRef{blocks[3], blocks[4]}, backend.localUnsafe)
+	require.Equal(t, []eth.L2BlockRef{blocks[2], blocks[3]}, backend.localSafe)
+	emitter.AssertExpectations(t)
+}
+
+func TestCrossSafeAndFinalizedUpdates(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	blocks, l2 := testChain(rng, 5)
+	l1 := testutils.RandomBlockRef(rng)
+	backend := &fakeBackend{}
+	d, emitter := newTestDeriver(t, backend, l2)
+	d.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: blocks[4], SafeL2Head: blocks[3], FinalizedL2Head: blocks[0]})
+	d.OnEvent(engine.SafeDerivedEvent{Safe: blocks[3], DerivedFrom: l1})
+
+	// No updates while the supervisor doesn't know about the chain yet
+	d.OnEvent(CrossUpdateRequestEvent{})
+
+	backend.crossSafe = blocks[2].ID()
+	backend.finalized = blocks[1].ID()
+	emitter.ExpectOnce(CrossSafeUpdateEvent{CrossSafe: blocks[2]})
+	emitter.ExpectOnce(engine.PromoteFinalizedEvent{Ref: blocks[1]})
+	d.OnEvent(CrossUpdateRequestEvent{})
+	emitter.AssertExpectations(t)
+
+	// Unchanged heads are not emitted again
+	d.OnEvent(CrossUpdateRequestEvent{})
+	emitter.AssertExpectations(t)
+
+	// The supervisor being unavailable doesn't affect the tracked heads
+	backend.err = errors.New("supervisor unavailable")
+	d.OnEvent(CrossUpdateRequestEvent{})
+	emitter.AssertExpectations(t)
+	backend.err = nil
+
+	backend.crossSafe = blocks[3].ID()
+	emitter.ExpectOnce(CrossSafeUpdateEvent{CrossSafe: blocks[3]})
+	d.OnEvent(CrossUpdateRequestEvent{})
+	emitter.AssertExpectations(t)
+}
+
+func TestInvalidatedBlock(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	blocks, l2 := testChain(rng, 5)
+	l1 := testutils.RandomBlockRef(rng)
+	backend := &fakeBackend{crossSafe: blocks[1].ID()}
+	d, emitter := newTestDeriver(t, backend, l2)
+	d.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: blocks[4], SafeL2Head: blocks[3], FinalizedL2Head: blocks[0]})
+	emitter.ExpectOnce(CrossSafeUpdateEvent{CrossSafe: blocks[1]})
+	d.OnEvent(CrossUpdateRequestEvent{})
+	emitter.AssertExpectations(t)
+
+	d.OnEvent(engine.SafeDerivedEvent{Safe: blocks[3], DerivedFrom: l1})
+	backend.levels = map[common.Hash]types.SafetyLevel{blocks[3].Hash: types.Invalid}
+	emitter.ExpectOnce(engine.ForceEngineResetEvent{Unsafe: blocks[2], Safe: blocks[1], Finalized: blocks[0]})
+	emitter.ExpectOnceType("rollup.ResetEvent")
+	d.OnEvent(CrossUpdateRequestEvent{})
+	emitter.AssertExpectations(t)
+}
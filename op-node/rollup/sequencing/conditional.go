@@ -0,0 +1,296 @@
+package sequencing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var (
+	ErrConditionalTxsDisabled  = errors.New("conditional transactions are not enabled")
+	ErrConditionalTxPoolFull   = errors.New("conditional transaction pool is full")
+	ErrConditionalTxKnown      = errors.New("conditional transaction already known")
+	ErrConditionalCostTooHigh  = errors.New("conditional cost too high")
+	ErrConditionalNonceTooLow  = errors.New("nonce too low")
+	ErrConditionalDepositTx    = errors.New("deposit transactions cannot be conditional")
+	ErrConditionalInvalidRange = errors.New("invalid conditional range")
+)
+
+// ConditionalTxConfig configures the acceptance of eth_sendRawTransactionConditional transactions by the sequencer.
+type ConditionalTxConfig struct {
+	// Enabled is true when the sequencer accepts conditional transactions.
+	Enabled bool `json:"enabled"`
+	// MaxPending is the maximum number of conditional transactions waiting for inclusion.
+	MaxPending int `json:"max_pending"`
+	// MaxCost is the maximum number of known account storage roots and slots a single conditional may check.
+	MaxCost int `json:"max_cost"`
+	// MaxPerBlock is the maximum number of conditional transactions included in a single block.
+	MaxPerBlock int `json:"max_per_block"`
+}
+
+func (c *ConditionalTxConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.MaxPending <= 0 {
+		return errors.New("max pending conditional transactions must be positive")
+	}
+	if c.MaxCost < 0 {
+		return errors.New("max conditional cost must not be negative")
+	}
+	if c.MaxPerBlock <= 0 {
+		return errors.New("max conditional transactions per block must be positive")
+	}
+	return nil
+}
+
+// ConditionalTxSource provides the conditional transactions to include in the next block,
+// after the deposits and before any transactions from the execution engine tx pool.
+type ConditionalTxSource interface {
+	// Select returns the transactions to include in the block with the given timestamp on top of parent.
+	Select(ctx context.Context, parent eth.L2BlockRef, timestamp uint64) []eth.Data
+	// Drop removes the transactions, e.g. when the engine failed to build a block with them.
+	Drop(txs []eth.Data)
+}
+
+// ConditionalState provides the L2 state conditional transactions are checked against.
+type ConditionalState interface {
+	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
+	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
+}
+
+type conditionalTx struct {
+	hash   common.Hash
+	data   eth.Data
+	tx     *types.Transaction
+	sender common.Address
+	cond   *eth.TransactionConditional
+}
+
+// ConditionalTxPool holds transactions submitted with eth_sendRawTransactionConditional
+// until the sequencer builds a block their conditions hold for.
+//
+// The conditions are checked when building the block, against the state of its parent.
+// Transactions that pass are force-included by the sequencer, directly after the deposits of the block,
+// so their known accounts can only have been changed by these deposits.
+// Of the conditional transactions that check the same account, only the first is included in a block,
+// so that one cannot invalidate the condition of another. The rest wait for the next block.
+//
+// Transactions are dropped once their conditions can no longer hold: when the block or timestamp range has passed,
+// a known account no longer matches, or the sender nonce has moved past the transaction.
+// They remain in the pool after inclusion until the sender nonce has moved past them,
+// so they are included again if the block is reorged out.
+type ConditionalTxPool struct {
+	log       log.Logger
+	cfg       ConditionalTxConfig
+	rollupCfg *rollup.Config
+	signer    types.Signer
+	state     ConditionalState
+
+	mu  sync.Mutex
+	txs []*conditionalTx
+}
+
+var _ ConditionalTxSource = (*ConditionalTxPool)(nil)
+
+func NewConditionalTxPool(log log.Logger, rollupCfg *rollup.Config, cfg ConditionalTxConfig, state ConditionalState) *ConditionalTxPool {
+	return &ConditionalTxPool{
+		log:       log,
+		cfg:       cfg,
+		rollupCfg: rollupCfg,
+		signer:    types.LatestSignerForChainID(rollupCfg.L2ChainID),
+		state:     state,
+	}
+}
+
+// Add checks the conditional against the unsafe head and adds the transaction to the pool.
+// Transactions with a conditional that isn't valid yet are accepted, and wait for a block it holds for.
+func (p *ConditionalTxPool) Add(ctx context.Context, data hexutil.Bytes, cond *eth.TransactionConditional) (common.Hash, error) {
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(data); err != nil {
+		return common.Hash{}, fmt.Errorf("invalid transaction: %w", err)
+	}
+	if tx.IsDepositTx() {
+		return common.Hash{}, ErrConditionalDepositTx
+	}
+	sender, err := types.Sender(p.signer, &tx)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid sender: %w", err)
+	}
+	if err := cond.Validate(); err != nil {
+		return common.Hash{}, fmt.Errorf("%w: %w", ErrConditionalInvalidRange, err)
+	}
+	if cost := cond.Cost(); cost > p.cfg.MaxCost {
+		return common.Hash{}, fmt.Errorf("%w: %d, max %d", ErrConditionalCostTooHigh, cost, p.cfg.MaxCost)
+	}
+	entry := &conditionalTx{
+		hash:   tx.Hash(),
+		data:   eth.Data(data),
+		tx:     &tx,
+		sender: sender,
+		cond:   cond,
+	}
+
+	head, err := p.state.L2BlockRefByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to retrieve unsafe head: %w", err)
+	}
+	if err := cond.CheckBlock(head.Number+1, head.Time+p.rollupCfg.BlockTime); errors.Is(err, eth.ErrConditionalExpired) {
+		return common.Hash{}, err
+	}
+	if err := p.checkKnownAccounts(ctx, entry, head.Hash); err != nil {
+		return common.Hash{}, err
+	}
+	account, err := p.state.GetProof(ctx, sender, nil, head.Hash.String())
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to retrieve sender account: %w", err)
+	}
+	if tx.Nonce() < uint64(account.Nonce) {
+		return common.Hash{}, fmt.Errorf("%w: next nonce %d, tx nonce %d", ErrConditionalNonceTooLow, account.Nonce, tx.Nonce())
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, existing := range p.txs {
+		if existing.hash == entry.hash {
+			return common.Hash{}, ErrConditionalTxKnown
+		}
+	}
+	if len(p.txs) >= p.cfg.MaxPending {
+		return common.Hash{}, ErrConditionalTxPoolFull
+	}
+	p.txs = append(p.txs, entry)
+	p.log.Debug("Added conditional transaction", "hash", entry.hash, "sender", sender, "nonce", tx.Nonce())
+	return entry.hash, nil
+}
+
+// Pending returns the number of conditional transactions in the pool.
+func (p *ConditionalTxPool) Pending() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.txs)
+}
+
+func (p *ConditionalTxPool) Select(ctx context.Context, parent eth.L2BlockRef, timestamp uint64) []eth.Data {
+	p.mu.Lock()
+	candidates := make([]*conditionalTx, len(p.txs))
+	copy(candidates, p.txs)
+	p.mu.Unlock()
+
+	type senderState struct {
+		nonce   uint64
+		balance *big.Int
+	}
+	senders := make(map[common.Address]*senderState)
+	checked := make(map[common.Address]struct{})
+	drop := make(map[common.Hash]struct{})
+	var selected []eth.Data
+	for _, c := range candidates {
+		if len(selected) >= p.cfg.MaxPerBlock {
+			break
+		}
+		lgr := p.log.New("hash", c.hash, "sender", c.sender, "nonce", c.tx.Nonce())
+		if err := c.cond.CheckBlock(parent.Number+1, timestamp); err != nil {
+			if errors.Is(err, eth.ErrConditionalExpired) {
+				lgr.Debug("Dropping expired conditional transaction", "err", err)
+				drop[c.hash] = struct{}{}
+			}
+			continue
+		}
+		if overlaps(c.cond, checked) {
+			continue
+		}
+		if err := p.checkKnownAccounts(ctx, c, parent.Hash); err != nil {
+			if errors.Is(err, eth.ErrKnownAccountMismatch) {
+				lgr.Debug("Dropping conditional transaction with mismatched known account", "err", err)
+				drop[c.hash] = struct{}{}
+			} else {
+				lgr.Warn("Failed to check conditional transaction", "err", err)
+			}
+			continue
+		}
+		sender, ok := senders[c.sender]
+		if !ok {
+			account, err := p.state.GetProof(ctx, c.sender, nil, parent.Hash.String())
+			if err != nil {
+				lgr.Warn("Failed to retrieve conditional transaction sender", "err", err)
+				continue
+			}
+			sender = &senderState{nonce: uint64(account.Nonce), balance: account.Balance.ToInt()}
+			senders[c.sender] = sender
+		}
+		if c.tx.Nonce() < sender.nonce {
+			lgr.Debug("Dropping conditional transaction with used nonce", "next_nonce", sender.nonce)
+			drop[c.hash] = struct{}{}
+			continue
+		}
+		if c.tx.Nonce() > sender.nonce || sender.balance.Cmp(c.tx.Cost()) < 0 {
+			continue
+		}
+		sender.nonce++
+		sender.balance = new(big.Int).Sub(sender.balance, c.tx.Cost())
+		for addr := range c.cond.KnownAccounts {
+			checked[addr] = struct{}{}
+		}
+		selected = append(selected, c.data)
+	}
+	p.remove(drop)
+	return selected
+}
+
+func (p *ConditionalTxPool) Drop(txs []eth.Data) {
+	drop := make(map[common.Hash]struct{}, len(txs))
+	for _, data := range txs {
+		drop[crypto.Keccak256Hash(data)] = struct{}{}
+	}
+	p.remove(drop)
+}
+
+func (p *ConditionalTxPool) remove(drop map[common.Hash]struct{}) {
+	if len(drop) == 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	remaining := p.txs[:0]
+	for _, c := range p.txs {
+		if _, ok := drop[c.hash]; !ok {
+			remaining = append(remaining, c)
+		}
+	}
+	clear(p.txs[len(remaining):])
+	p.txs = remaining
+}
+
+func (p *ConditionalTxPool) checkKnownAccounts(ctx context.Context, c *conditionalTx, blockHash common.Hash) error {
+	for addr, known := range c.cond.KnownAccounts {
+		account, err := p.state.GetProof(ctx, addr, known.Slots(), blockHash.String())
+		if err != nil {
+			return fmt.Errorf("failed to retrieve known account %v: %w", addr, err)
+		}
+		if err := known.Check(account); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func overlaps(cond *eth.TransactionConditional, checked map[common.Address]struct{}) bool {
+	for addr := range cond.KnownAccounts {
+		if _, ok := checked[addr]; ok {
+			return true
+		}
+	}
+	return false
+}
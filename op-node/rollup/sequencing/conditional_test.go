@@ -0,0 +1,290 @@
+package sequencing
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubAccount struct {
+	nonce       uint64
+	balance     *big.Int
+	storageRoot common.Hash
+	storage     map[common.Hash]common.Hash
+}
+
+type stubConditionalState struct {
+	head     eth.L2BlockRef
+	accounts map[common.Address]*stubAccount
+}
+
+func (s *stubConditionalState) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	return s.head, nil
+}
+
+func (s *stubConditionalState) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	account, ok := s.accounts[address]
+	if !ok {
+		account = &stubAccount{balance: new(big.Int)}
+	}
+	result := &eth.AccountResult{
+		Address:     address,
+		Balance:     (*hexutil.Big)(account.balance),
+		Nonce:       hexutil.Uint64(account.nonce),
+		StorageHash: account.storageRoot,
+	}
+	for _, slot := range storage {
+		result.StorageProof = append(result.StorageProof, eth.StorageProofEntry{
+			Key:   slot,
+			Value: hexutil.Big(*account.storage[slot].Big()),
+		})
+	}
+	return result, nil
+}
+
+type conditionalTestSetup struct {
+	pool   *ConditionalTxPool
+	state  *stubConditionalState
+	sender common.Address
+	sign   func(tx types.TxData) hexutil.Bytes
+}
+
+func setupConditionalTxPool(t *testing.T, cfg ConditionalTxConfig) *conditionalTestSetup {
+	rollupCfg := &rollup.Config{L2ChainID: big.NewInt(901), BlockTime: 2}
+	privKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(privKey.PublicKey)
+	state := &stubConditionalState{
+		head: eth.L2BlockRef{Hash: common.Hash{0x01}, Number: 100, Time: 1000},
+		accounts: map[common.Address]*stubAccount{
+			sender: {nonce: 5, balance: big.NewInt(params.Ether)},
+			entryPoint: {
+				storageRoot: common.Hash{0xaa},
+				storage:     map[common.Hash]common.Hash{{0x01}: {0x02}},
+			},
+		},
+	}
+	signer := types.LatestSignerForChainID(rollupCfg.L2ChainID)
+	return &conditionalTestSetup{
+		pool:   NewConditionalTxPool(testlog.Logger(t, log.LevelDebug), rollupCfg, cfg, state),
+		state:  state,
+		sender: sender,
+		sign: func(txData types.TxData) hexutil.Bytes {
+			tx := types.MustSignNewTx(privKey, signer, txData)
+			data, err := tx.MarshalBinary()
+			require.NoError(t, err)
+			return data
+		},
+	}
+}
+
+var entryPoint = common.Address{0xee}
+
+func (s *conditionalTestSetup) tx(nonce uint64) hexutil.Bytes {
+	return s.sign(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(901),
+		Nonce:     nonce,
+		To:        &entryPoint,
+		Gas:       100_000,
+		GasFeeCap: big.NewInt(1),
+	})
+}
+
+var defaultConditionalTxConfig = ConditionalTxConfig{
+	Enabled:     true,
+	MaxPending:  10,
+	MaxCost:     10,
+	MaxPerBlock: 10,
+}
+
+func TestConditionalTxPoolAdd(t *testing.T) {
+	ctx := context.Background()
+	root := common.Hash{0xaa}
+
+	t.Run("Valid", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		data := s.tx(5)
+		hash, err := s.pool.Add(ctx, data, &eth.TransactionConditional{
+			KnownAccounts: map[common.Address]eth.KnownAccount{entryPoint: {StorageRoot: &root}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, crypto.Keccak256Hash(data), hash)
+		require.Equal(t, 1, s.pool.Pending())
+
+		_, err = s.pool.Add(ctx, data, &eth.TransactionConditional{})
+		require.ErrorIs(t, err, ErrConditionalTxKnown)
+	})
+
+	t.Run("NotYetValid", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		_, err := s.pool.Add(ctx, s.tx(5), &eth.TransactionConditional{BlockNumberMin: u64(200)})
+		require.NoError(t, err)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		_, err := s.pool.Add(ctx, s.tx(5), &eth.TransactionConditional{TimestampMax: u64(1001)})
+		require.ErrorIs(t, err, eth.ErrConditionalExpired)
+	})
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		_, err := s.pool.Add(ctx, s.tx(5), &eth.TransactionConditional{BlockNumberMin: u64(2), BlockNumberMax: u64(1)})
+		require.ErrorIs(t, err, ErrConditionalInvalidRange)
+	})
+
+	t.Run("KnownAccountMismatch", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		_, err := s.pool.Add(ctx, s.tx(5), &eth.TransactionConditional{
+			KnownAccounts: map[common.Address]eth.KnownAccount{
+				entryPoint: {StorageSlots: map[common.Hash]common.Hash{{0x01}: {0x03}}},
+			},
+		})
+		require.ErrorIs(t, err, eth.ErrKnownAccountMismatch)
+	})
+
+	t.Run("CostTooHigh", func(t *testing.T) {
+		cfg := defaultConditionalTxConfig
+		cfg.MaxCost = 1
+		s := setupConditionalTxPool(t, cfg)
+		_, err := s.pool.Add(ctx, s.tx(5), &eth.TransactionConditional{
+			KnownAccounts: map[common.Address]eth.KnownAccount{
+				entryPoint: {StorageSlots: map[common.Hash]common.Hash{{0x01}: {0x02}, {0x02}: {}}},
+			},
+		})
+		require.ErrorIs(t, err, ErrConditionalCostTooHigh)
+	})
+
+	t.Run("NonceTooLow", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		_, err := s.pool.Add(ctx, s.tx(4), &eth.TransactionConditional{})
+		require.ErrorIs(t, err, ErrConditionalNonceTooLow)
+	})
+
+	t.Run("PoolFull", func(t *testing.T) {
+		cfg := defaultConditionalTxConfig
+		cfg.MaxPending = 1
+		s := setupConditionalTxPool(t, cfg)
+		_, err := s.pool.Add(ctx, s.tx(5), &eth.TransactionConditional{})
+		require.NoError(t, err)
+		_, err = s.pool.Add(ctx, s.tx(6), &eth.TransactionConditional{})
+		require.ErrorIs(t, err, ErrConditionalTxPoolFull)
+	})
+
+	t.Run("InvalidTx", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		_, err := s.pool.Add(ctx, hexutil.Bytes{0x02, 0x01}, &eth.TransactionConditional{})
+		require.ErrorContains(t, err, "invalid transaction")
+	})
+}
+
+func TestConditionalTxPoolSelect(t *testing.T) {
+	ctx := context.Background()
+	root := common.Hash{0xaa}
+
+	t.Run("IncludeInNonceOrder", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		tx6 := s.tx(6)
+		tx5 := s.tx(5)
+		for _, data := range []hexutil.Bytes{tx6, tx5} {
+			_, err := s.pool.Add(ctx, data, &eth.TransactionConditional{})
+			require.NoError(t, err)
+		}
+		// tx6 is skipped as it has a nonce gap, until tx5 is included
+		selected := s.pool.Select(ctx, s.state.head, s.state.head.Time+2)
+		require.Equal(t, []eth.Data{eth.Data(tx5)}, selected)
+		require.Equal(t, 2, s.pool.Pending(), "included txs stay until their nonce is used")
+
+		s.state.accounts[s.sender].nonce = 6
+		selected = s.pool.Select(ctx, s.state.head, s.state.head.Time+2)
+		require.Equal(t, []eth.Data{eth.Data(tx6)}, selected)
+		require.Equal(t, 1, s.pool.Pending(), "tx with used nonce is dropped")
+	})
+
+	t.Run("BlockRange", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		data := s.tx(5)
+		_, err := s.pool.Add(ctx, data, &eth.TransactionConditional{BlockNumberMin: u64(102), BlockNumberMax: u64(102)})
+		require.NoError(t, err)
+
+		require.Empty(t, s.pool.Select(ctx, s.state.head, 1002), "not yet valid for block 101")
+		require.Equal(t, 1, s.pool.Pending())
+
+		parent := eth.L2BlockRef{Hash: common.Hash{0x02}, Number: 101, Time: 1002}
+		require.Equal(t, []eth.Data{eth.Data(data)}, s.pool.Select(ctx, parent, 1004))
+
+		parent = eth.L2BlockRef{Hash: common.Hash{0x03}, Number: 102, Time: 1004}
+		require.Empty(t, s.pool.Select(ctx, parent, 1006))
+		require.Zero(t, s.pool.Pending(), "expired tx is dropped")
+	})
+
+	t.Run("KnownAccountChanged", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		_, err := s.pool.Add(ctx, s.tx(5), &eth.TransactionConditional{
+			KnownAccounts: map[common.Address]eth.KnownAccount{entryPoint: {StorageRoot: &root}},
+		})
+		require.NoError(t, err)
+		s.state.accounts[entryPoint].storageRoot = common.Hash{0xbb}
+		require.Empty(t, s.pool.Select(ctx, s.state.head, s.state.head.Time+2))
+		require.Zero(t, s.pool.Pending())
+	})
+
+	t.Run("OverlappingKnownAccounts", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		cond := &eth.TransactionConditional{
+			KnownAccounts: map[common.Address]eth.KnownAccount{entryPoint: {StorageRoot: &root}},
+		}
+		tx5, tx6 := s.tx(5), s.tx(6)
+		for _, data := range []hexutil.Bytes{tx5, tx6} {
+			_, err := s.pool.Add(ctx, data, cond)
+			require.NoError(t, err)
+		}
+		require.Equal(t, []eth.Data{eth.Data(tx5)}, s.pool.Select(ctx, s.state.head, s.state.head.Time+2),
+			"second tx checking the same account waits for the next block")
+	})
+
+	t.Run("InsufficientBalance", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		_, err := s.pool.Add(ctx, s.tx(5), &eth.TransactionConditional{})
+		require.NoError(t, err)
+		s.state.accounts[s.sender].balance = big.NewInt(1)
+		require.Empty(t, s.pool.Select(ctx, s.state.head, s.state.head.Time+2))
+		require.Equal(t, 1, s.pool.Pending(), "kept until the balance is sufficient")
+	})
+
+	t.Run("MaxPerBlock", func(t *testing.T) {
+		cfg := defaultConditionalTxConfig
+		cfg.MaxPerBlock = 1
+		s := setupConditionalTxPool(t, cfg)
+		for _, data := range []hexutil.Bytes{s.tx(5), s.tx(6)} {
+			_, err := s.pool.Add(ctx, data, &eth.TransactionConditional{})
+			require.NoError(t, err)
+		}
+		require.Len(t, s.pool.Select(ctx, s.state.head, s.state.head.Time+2), 1)
+	})
+
+	t.Run("Drop", func(t *testing.T) {
+		s := setupConditionalTxPool(t, defaultConditionalTxConfig)
+		data := s.tx(5)
+		_, err := s.pool.Add(ctx, data, &eth.TransactionConditional{})
+		require.NoError(t, err)
+		s.pool.Drop([]eth.Data{eth.Data(data)})
+		require.Zero(t, s.pool.Pending())
+	})
+}
+
+func u64(v uint64) *hexutil.Uint64 {
+	return (*hexutil.Uint64)(&v)
+}
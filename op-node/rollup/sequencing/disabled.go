@@ -48,4 +48,12 @@ func (ds DisabledSequencer) OverrideLeader(ctx context.Context) error {
 	return ErrSequencerNotEnabled
 }
 
+func (ds DisabledSequencer) SetRecoverMode(ctx context.Context, mode bool) error {
+	return ErrSequencerNotEnabled
+}
+
+func (ds DisabledSequencer) RecoverMode() bool {
+	return false
+}
+
 func (ds DisabledSequencer) Close() {}
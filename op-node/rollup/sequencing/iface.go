@@ -19,5 +19,8 @@ type SequencerIface interface {
 	Stop(ctx context.Context) (hash common.Hash, err error)
 	SetMaxSafeLag(ctx context.Context, v uint64) error
 	OverrideLeader(ctx context.Context) error
+	// SetRecoverMode enables or disables building blocks with deposits only, for incident response.
+	SetRecoverMode(ctx context.Context, mode bool) error
+	RecoverMode() bool
 	Close()
 }
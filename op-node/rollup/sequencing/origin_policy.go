@@ -0,0 +1,78 @@
+package sequencing
+
+import (
+	"fmt"
+)
+
+const (
+	ConservativeOriginPolicy = "conservative"
+	AggressiveOriginPolicy   = "aggressive"
+)
+
+// OriginPolicies lists the names of the supported L1 origin selection policies.
+var OriginPolicies = []string{ConservativeOriginPolicy, AggressiveOriginPolicy}
+
+// OriginSelectionPolicy decides how many L1 confirmations the sequencer requires
+// before adopting the next L1 block as L1 origin, trading off latency against reorg safety.
+type OriginSelectionPolicy interface {
+	// Name identifies the policy.
+	Name() string
+	// ConfDepth returns the distance to keep from the L1 head when adopting the next L1 origin.
+	// seqDrift is the time between the current L1 origin and the next L2 block,
+	// maxSeqDrift is the max sequencer drift at the current L1 origin.
+	ConfDepth(seqDrift uint64, maxSeqDrift uint64) uint64
+}
+
+// NewOriginSelectionPolicy creates the policy with the given name, configured with the sequencer conf depth.
+func NewOriginSelectionPolicy(name string, confDepth uint64) (OriginSelectionPolicy, error) {
+	switch name {
+	case ConservativeOriginPolicy:
+		return NewConservativePolicy(confDepth), nil
+	case AggressiveOriginPolicy:
+		return NewAggressivePolicy(confDepth), nil
+	default:
+		return nil, fmt.Errorf("unknown origin selection policy %q, expected one of %v", name, OriginPolicies)
+	}
+}
+
+// ConservativePolicy always requires the configured confirmations.
+// It's more important to maintain safety with an empty block than to maintain liveness with poor conf depth,
+// so if the confirmations don't come in time, the sequencer falls back to deposit-only blocks.
+type ConservativePolicy struct {
+	depth uint64
+}
+
+func NewConservativePolicy(confDepth uint64) *ConservativePolicy {
+	return &ConservativePolicy{depth: confDepth}
+}
+
+func (p *ConservativePolicy) Name() string {
+	return ConservativeOriginPolicy
+}
+
+func (p *ConservativePolicy) ConfDepth(seqDrift uint64, maxSeqDrift uint64) uint64 {
+	return p.depth
+}
+
+// AggressivePolicy requires the configured confirmations only while the next L2 block
+// is within half of the max sequencer drift of its current L1 origin.
+// Beyond that it adopts the next L1 block without confirmations,
+// preferring to keep including transactions over protecting against L1 reorgs.
+type AggressivePolicy struct {
+	depth uint64
+}
+
+func NewAggressivePolicy(confDepth uint64) *AggressivePolicy {
+	return &AggressivePolicy{depth: confDepth}
+}
+
+func (p *AggressivePolicy) Name() string {
+	return AggressiveOriginPolicy
+}
+
+func (p *AggressivePolicy) ConfDepth(seqDrift uint64, maxSeqDrift uint64) uint64 {
+	if seqDrift >= maxSeqDrift/2 {
+		return 0
+	}
+	return p.depth
+}
@@ -0,0 +1,31 @@
+package sequencing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOriginSelectionPolicy(t *testing.T) {
+	t.Run("conservative", func(t *testing.T) {
+		p, err := NewOriginSelectionPolicy(ConservativeOriginPolicy, 4)
+		require.NoError(t, err)
+		require.Equal(t, ConservativeOriginPolicy, p.Name())
+		require.Equal(t, uint64(4), p.ConfDepth(0, 600))
+		require.Equal(t, uint64(4), p.ConfDepth(600, 600))
+		require.Equal(t, uint64(4), p.ConfDepth(700, 600))
+	})
+	t.Run("aggressive", func(t *testing.T) {
+		p, err := NewOriginSelectionPolicy(AggressiveOriginPolicy, 4)
+		require.NoError(t, err)
+		require.Equal(t, AggressiveOriginPolicy, p.Name())
+		require.Equal(t, uint64(4), p.ConfDepth(0, 600))
+		require.Equal(t, uint64(4), p.ConfDepth(299, 600))
+		require.Equal(t, uint64(0), p.ConfDepth(300, 600))
+		require.Equal(t, uint64(0), p.ConfDepth(700, 600))
+	})
+	t.Run("unknown", func(t *testing.T) {
+		_, err := NewOriginSelectionPolicy("yolo", 4)
+		require.ErrorContains(t, err, "unknown origin selection policy")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -24,16 +25,45 @@ type L1OriginSelector struct {
 	cfg  *rollup.Config
 	spec *rollup.ChainSpec
 
-	l1 L1Blocks
+	l1     L1Blocks
+	l1Head func() eth.L1BlockRef
+
+	policy atomic.Pointer[OriginSelectionPolicy]
 }
 
-func NewL1OriginSelector(log log.Logger, cfg *rollup.Config, l1 L1Blocks) *L1OriginSelector {
-	return &L1OriginSelector{
-		log:  log,
-		cfg:  cfg,
-		spec: rollup.NewChainSpec(cfg),
-		l1:   l1,
+// NewL1OriginSelector creates an origin selector that only adopts L1 blocks
+// with the confirmations required by the policy, relative to l1Head.
+func NewL1OriginSelector(log log.Logger, cfg *rollup.Config, l1 L1Blocks, l1Head func() eth.L1BlockRef, policy OriginSelectionPolicy) *L1OriginSelector {
+	los := &L1OriginSelector{
+		log:    log,
+		cfg:    cfg,
+		spec:   rollup.NewChainSpec(cfg),
+		l1:     l1,
+		l1Head: l1Head,
 	}
+	los.policy.Store(&policy)
+	return los
+}
+
+// SetPolicy changes the origin selection policy, taking effect with the next L2 block.
+func (los *L1OriginSelector) SetPolicy(policy OriginSelectionPolicy) {
+	prev := los.policy.Swap(&policy)
+	los.log.Info("Changed L1 origin selection policy", "prev", (*prev).Name(), "policy", policy.Name())
+}
+
+// Policy returns the current origin selection policy.
+func (los *L1OriginSelector) Policy() OriginSelectionPolicy {
+	return *los.policy.Load()
+}
+
+// confirmed checks if the L1 block with the given number has at least depth confirmations.
+// The conf depth is not applied if the L1 head is unknown, as it is during startup.
+func (los *L1OriginSelector) confirmed(num uint64, depth uint64) bool {
+	l1Head := los.l1Head()
+	if l1Head == (eth.L1BlockRef{}) {
+		return true
+	}
+	return depth == 0 || num+depth <= l1Head.Number
 }
 
 // FindL1Origin determines what the next L1 Origin should be.
@@ -50,6 +80,7 @@ func (los *L1OriginSelector) FindL1Origin(ctx context.Context, l2Head eth.L2Bloc
 		"l2_head", l2Head, "l2_head_time", l2Head.Time, "max_seq_drift", msd)
 
 	seqDrift := l2Head.Time + los.cfg.BlockTime - currentOrigin.Time
+	confDepth := los.Policy().ConfDepth(seqDrift, msd)
 
 	// If we are past the sequencer depth, we may want to advance the origin, but need to still
 	// check the time of the next origin.
@@ -70,14 +101,19 @@ func (los *L1OriginSelector) FindL1Origin(ctx context.Context, l2Head eth.L2Bloc
 
 	// Attempt to find the next L1 origin block, where the next origin is the immediate child of
 	// the current origin block.
-	// The L1 source can be shimmed to hide new L1 blocks and enforce a sequencer confirmation distance.
-	nextOrigin, err := los.l1.L1BlockRefByNumber(fetchCtx, currentOrigin.Number+1)
+	// Blocks without the confirmations required by the policy are treated as not found yet.
+	var nextOrigin eth.L1BlockRef
+	if los.confirmed(currentOrigin.Number+1, confDepth) {
+		nextOrigin, err = los.l1.L1BlockRefByNumber(fetchCtx, currentOrigin.Number+1)
+	} else {
+		err = ethereum.NotFound
+	}
 	if err != nil {
 		if pastSeqDrift {
 			return eth.L1BlockRef{}, fmt.Errorf("cannot build next L2 block past current L1 origin %s by more than sequencer time drift, and failed to find next L1 origin: %w", currentOrigin, err)
 		}
 		if errors.Is(err, ethereum.NotFound) {
-			log.Debug("No next L1 block found, repeating current origin")
+			log.Debug("No next L1 block found, repeating current origin", "conf_depth", confDepth)
 		} else {
 			log.Error("Failed to get next origin. Falling back to current origin", "err", err)
 		}
@@ -5,7 +5,6 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
-	"github.com/ethereum-optimism/optimism/op-node/rollup/confdepth"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
@@ -49,7 +48,7 @@ func TestOriginSelectorAdvances(t *testing.T) {
 	l1.ExpectL1BlockRefByHash(a.Hash, a, nil)
 	l1.ExpectL1BlockRefByNumber(b.Number, b, nil)
 
-	s := NewL1OriginSelector(log, cfg, l1)
+	s := NewL1OriginSelector(log, cfg, l1, noL1Head, NewConservativePolicy(0))
 	next, err := s.FindL1Origin(context.Background(), l2Head)
 	require.Nil(t, err)
 	require.Equal(t, b, next)
@@ -90,7 +89,7 @@ func TestOriginSelectorRespectsOriginTiming(t *testing.T) {
 	l1.ExpectL1BlockRefByHash(a.Hash, a, nil)
 	l1.ExpectL1BlockRefByNumber(b.Number, b, nil)
 
-	s := NewL1OriginSelector(log, cfg, l1)
+	s := NewL1OriginSelector(log, cfg, l1, noL1Head, NewConservativePolicy(0))
 	next, err := s.FindL1Origin(context.Background(), l2Head)
 	require.Nil(t, err)
 	require.Equal(t, a, next)
@@ -128,8 +127,7 @@ func TestOriginSelectorRespectsConfDepth(t *testing.T) {
 	}
 
 	l1.ExpectL1BlockRefByHash(a.Hash, a, nil)
-	confDepthL1 := confdepth.NewConfDepth(10, func() eth.L1BlockRef { return b }, l1)
-	s := NewL1OriginSelector(log, cfg, confDepthL1)
+	s := NewL1OriginSelector(log, cfg, l1, func() eth.L1BlockRef { return b }, NewConservativePolicy(10))
 
 	next, err := s.FindL1Origin(context.Background(), l2Head)
 	require.Nil(t, err)
@@ -171,13 +169,58 @@ func TestOriginSelectorStrictConfDepth(t *testing.T) {
 	}
 
 	l1.ExpectL1BlockRefByHash(a.Hash, a, nil)
-	confDepthL1 := confdepth.NewConfDepth(10, func() eth.L1BlockRef { return b }, l1)
-	s := NewL1OriginSelector(log, cfg, confDepthL1)
+	s := NewL1OriginSelector(log, cfg, l1, func() eth.L1BlockRef { return b }, NewConservativePolicy(10))
 
 	_, err := s.FindL1Origin(context.Background(), l2Head)
 	require.ErrorContains(t, err, "sequencer time drift")
 }
 
+// TestOriginSelectorAggressiveConfDepth has the same setup as TestOriginSelectorStrictConfDepth,
+// but switches to the aggressive policy at runtime. The next L2 block is past half of the sequencer drift,
+// so the aggressive policy gives up on the conf depth and adopts `b` to stay live.
+func TestOriginSelectorAggressiveConfDepth(t *testing.T) {
+	log := testlog.Logger(t, log.LevelCrit)
+	cfg := &rollup.Config{
+		MaxSequencerDrift: 8,
+		BlockTime:         2,
+	}
+	l1 := &testutils.MockL1Source{}
+	defer l1.AssertExpectations(t)
+	a := eth.L1BlockRef{
+		Hash:   common.Hash{'a'},
+		Number: 10,
+		Time:   20,
+	}
+	b := eth.L1BlockRef{
+		Hash:       common.Hash{'b'},
+		Number:     11,
+		Time:       25,
+		ParentHash: a.Hash,
+	}
+	l2Head := eth.L2BlockRef{
+		L1Origin: a.ID(),
+		Time:     27,
+	}
+
+	l1.ExpectL1BlockRefByHash(a.Hash, a, nil)
+	s := NewL1OriginSelector(log, cfg, l1, func() eth.L1BlockRef { return b }, NewConservativePolicy(10))
+	_, err := s.FindL1Origin(context.Background(), l2Head)
+	require.ErrorContains(t, err, "sequencer time drift")
+
+	s.SetPolicy(NewAggressivePolicy(10))
+	require.Equal(t, AggressiveOriginPolicy, s.Policy().Name())
+
+	l1.ExpectL1BlockRefByHash(a.Hash, a, nil)
+	l1.ExpectL1BlockRefByNumber(b.Number, b, nil)
+	next, err := s.FindL1Origin(context.Background(), l2Head)
+	require.NoError(t, err)
+	require.Equal(t, b, next)
+}
+
+func noL1Head() eth.L1BlockRef {
+	return eth.L1BlockRef{}
+}
+
 func u64ptr(n uint64) *uint64 {
 	return &n
 }
@@ -207,7 +250,7 @@ func TestOriginSelector_FjordSeqDrift(t *testing.T) {
 
 	l1.ExpectL1BlockRefByHash(a.Hash, a, nil)
 	l1.ExpectL1BlockRefByNumber(a.Number+1, eth.L1BlockRef{}, ethereum.NotFound)
-	s := NewL1OriginSelector(log, cfg, l1)
+	s := NewL1OriginSelector(log, cfg, l1, noL1Head, NewConservativePolicy(0))
 
 	l1O, err := s.FindL1Origin(context.Background(), l2Head)
 	require.NoError(t, err, "with Fjord activated, have increased max seq drift")
@@ -247,7 +290,7 @@ func TestOriginSelectorSeqDriftRespectsNextOriginTime(t *testing.T) {
 	l1.ExpectL1BlockRefByHash(a.Hash, a, nil)
 	l1.ExpectL1BlockRefByNumber(b.Number, b, nil)
 
-	s := NewL1OriginSelector(log, cfg, l1)
+	s := NewL1OriginSelector(log, cfg, l1, noL1Head, NewConservativePolicy(0))
 	next, err := s.FindL1Origin(context.Background(), l2Head)
 	require.Nil(t, err)
 	require.Equal(t, a, next)
@@ -305,8 +348,7 @@ func TestOriginSelectorHandlesLateL1Blocks(t *testing.T) {
 	l1.ExpectL1BlockRefByNumber(b.Number, b, nil)
 
 	l1Head := b
-	confDepthL1 := confdepth.NewConfDepth(2, func() eth.L1BlockRef { return l1Head }, l1)
-	s := NewL1OriginSelector(log, cfg, confDepthL1)
+	s := NewL1OriginSelector(log, cfg, l1, func() eth.L1BlockRef { return l1Head }, NewConservativePolicy(2))
 
 	_, err := s.FindL1Origin(context.Background(), l2Head)
 	require.ErrorContains(t, err, "sequencer time drift")
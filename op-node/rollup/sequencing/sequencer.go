@@ -10,6 +10,7 @@ import (
 	"github.com/protolambda/ctxlock"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -17,6 +18,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -36,7 +38,24 @@ type Metrics interface {
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
 	RecordSequencingError()
-}
+	RecordSequencerBlockPhase(phase string, duration time.Duration)
+	RecordSequencerDepositsOnlyBlock()
+	RecordSequencerRecoverMode(mode bool)
+}
+
+// Phases of producing a block, as recorded with Metrics.RecordSequencerBlockPhase.
+const (
+	// PhaseAttributes is the selection of the L1 origin and preparation of the payload attributes.
+	PhaseAttributes = "attributes"
+	// PhaseBuildStart is the start of the block building job by the engine.
+	PhaseBuildStart = "build_start"
+	// PhaseSeal is the sealing of the block, i.e. retrieving the payload from the engine.
+	PhaseSeal = "seal"
+	// PhasePublish is the commit of the block to the conductor and start of gossiping it.
+	PhasePublish = "publish"
+	// PhaseInsert is the insertion of the block into the canonical chain of the engine.
+	PhaseInsert = "insert"
+)
 
 type SequencerStateListener interface {
 	SequencerStarted() error
@@ -72,6 +91,14 @@ type BuildingState struct {
 	Ref eth.L2BlockRef
 }
 
+// phaseTiming tracks when the phases of producing the latest block were started,
+// to record how long each phase took. Zero if the phase is not in progress.
+type phaseTiming struct {
+	buildStart time.Time
+	seal       time.Time
+	insert     time.Time
+}
+
 // Sequencer implements the sequencing interface of the driver: it starts and completes block building jobs.
 type Sequencer struct {
 	l ctxlock.Lock
@@ -85,6 +112,10 @@ type Sequencer struct {
 
 	maxSafeLag atomic.Uint64
 
+	// recoverMode makes the sequencer build blocks with deposits only, without any tx-pool or conditional transactions.
+	// This is an atomic value, so it can be toggled during incident response without waiting for the sequencer lock.
+	recoverMode atomic.Bool
+
 	// active identifies whether the sequencer is running.
 	// This is an atomic value, so it can be read without locking the whole sequencer.
 	active atomic.Bool
@@ -102,6 +133,9 @@ type Sequencer struct {
 	attrBuilder      derive.AttributesBuilder
 	l1OriginSelector L1OriginSelectorIface
 
+	// conditionalTxs provides conditional transactions to include in new blocks. Optional.
+	conditionalTxs ConditionalTxSource
+
 	metrics Metrics
 
 	// timeNow enables sequencer testing to mock the time
@@ -114,6 +148,13 @@ type Sequencer struct {
 	latest     BuildingState
 	latestHead eth.L2BlockRef
 
+	timing phaseTiming
+
+	// depositsOnlyThreshold is the minimum time left before the timestamp of a new block to build it with tx-pool
+	// transactions. Blocks that start building later are built with deposits only, so a slow engine doesn't
+	// make the sequencer fall further behind schedule. Disabled if 0.
+	depositsOnlyThreshold time.Duration
+
 	// toBlockRef converts a payload to a block-ref, and is only configurable for test-purposes
 	toBlockRef func(rollupCfg *rollup.Config, payload *eth.ExecutionPayload) (eth.L2BlockRef, error)
 }
@@ -126,7 +167,9 @@ func NewSequencer(driverCtx context.Context, log log.Logger, rollupCfg *rollup.C
 	listener SequencerStateListener,
 	conductor conductor.SequencerConductor,
 	asyncGossip AsyncGossiper,
-	metrics Metrics) *Sequencer {
+	conditionalTxs ConditionalTxSource,
+	metrics Metrics,
+	clk clock.Clock) *Sequencer {
 	return &Sequencer{
 		ctx:              driverCtx,
 		log:              log,
@@ -136,9 +179,10 @@ func NewSequencer(driverCtx context.Context, log log.Logger, rollupCfg *rollup.C
 		conductor:        conductor,
 		asyncGossip:      asyncGossip,
 		attrBuilder:      attributesBuilder,
+		conditionalTxs:   conditionalTxs,
 		l1OriginSelector: l1OriginSelector,
 		metrics:          metrics,
-		timeNow:          time.Now,
+		timeNow:          clk.Now,
 		toBlockRef:       derive.PayloadToBlockRef,
 	}
 }
@@ -216,6 +260,7 @@ func (d *Sequencer) onBuildStarted(x engine.BuildStartedEvent) {
 		"payloadID", x.Info.ID, "parent", x.Parent, "parent_time", x.Parent.Time)
 	d.latest.Info = x.Info
 	d.latest.Started = x.BuildStarted
+	d.recordPhase(PhaseBuildStart, &d.timing.buildStart)
 
 	d.nextActionOK = d.active.Load()
 
@@ -249,6 +294,20 @@ func (d *Sequencer) onInvalidPayloadAttributes(x engine.InvalidPayloadAttributes
 		"attributes_parent", x.Attributes.Parent,
 		"timestamp", x.Attributes.Attributes.Timestamp, "err", x.Err)
 
+	if d.conditionalTxs != nil {
+		// The attributes only contain deposits and conditional transactions,
+		// and the deposits can't be the problem, so retry without the conditional transactions.
+		var txs []eth.Data
+		for _, tx := range x.Attributes.Attributes.Transactions {
+			if len(tx) > 0 && tx[0] != types.DepositTxType {
+				txs = append(txs, tx)
+			}
+		}
+		if len(txs) > 0 {
+			d.log.Warn("Dropping conditional transactions of invalid payload attributes", "count", len(txs))
+			d.conditionalTxs.Drop(txs)
+		}
+	}
 	d.handleInvalid()
 }
 
@@ -262,6 +321,9 @@ func (d *Sequencer) onBuildSealed(x engine.BuildSealedEvent) {
 		"txs", len(x.Envelope.ExecutionPayload.Transactions),
 		"time", uint64(x.Envelope.ExecutionPayload.Timestamp))
 
+	d.recordPhase(PhaseSeal, &d.timing.seal)
+	publishStart := d.timeNow()
+
 	// generous timeout, the conductor is important
 	ctx, cancel := context.WithTimeout(d.ctx, time.Second*30)
 	defer cancel()
@@ -275,6 +337,8 @@ func (d *Sequencer) onBuildSealed(x engine.BuildSealedEvent) {
 	// asyncGossip.Clear() will be called later if an non-temporary error is found,
 	// or if the payload is successfully inserted
 	d.asyncGossip.Gossip(x.Envelope)
+	d.metrics.RecordSequencerBlockPhase(PhasePublish, d.timeNow().Sub(publishStart))
+	d.timing.insert = d.timeNow()
 	// Now after having gossiped the block, try to put it in our own canonical chain
 	d.emitter.Emit(engine.PayloadProcessEvent{
 		IsLastInSpan: x.IsLastInSpan,
@@ -322,6 +386,7 @@ func (d *Sequencer) onPayloadSuccess(x engine.PayloadSuccessEvent) {
 		return
 	}
 	d.latest = BuildingState{}
+	d.recordPhase(PhaseInsert, &d.timing.insert)
 	d.log.Info("Sequencer inserted block",
 		"block", x.Ref, "parent", x.Envelope.ExecutionPayload.ParentID())
 	// The payload was already published upon sealing.
@@ -359,6 +424,7 @@ func (d *Sequencer) onSequencerAction(x SequencerActionEvent) {
 		if d.latest.Info != (eth.PayloadInfo{}) {
 			// We should not repeat the seal request.
 			d.nextActionOK = false
+			d.timing.seal = d.timeNow()
 			// No known payload for block building job,
 			// we have to retrieve it first.
 			d.emitter.Emit(engine.BuildSealEvent{
@@ -472,6 +538,8 @@ func (d *Sequencer) startBuildingBlock() {
 		return
 	}
 
+	attributesStart := d.timeNow()
+
 	// Figure out which L1 origin block we're going to be building on top of.
 	l1Origin, err := d.l1OriginSelector.FindL1Origin(ctx, l2Head)
 	if err != nil {
@@ -532,6 +600,35 @@ func (d *Sequencer) startBuildingBlock() {
 		d.log.Info("Sequencing Granite upgrade block")
 	}
 
+	// In recover mode the chain is kept live with deposits-only blocks, e.g. while the tx-pool or block builder misbehaves.
+	// The gas limit is part of the system config and must be kept, but the blocks only spend the gas of the deposits.
+	if d.recoverMode.Load() {
+		attrs.NoTxPool = true
+		d.log.Warn("Sequencer is in recover mode, building deposits-only block", "num", l2Head.Number+1, "time", uint64(attrs.Timestamp))
+	}
+
+	// If block building starts too late to fill the block and still publish it on time,
+	// e.g. because the engine was slow to produce the previous block, then build it with deposits only.
+	if d.depositsOnlyThreshold > 0 && !attrs.NoTxPool {
+		remaining := time.Unix(int64(attrs.Timestamp), 0).Sub(d.timeNow())
+		if remaining < d.depositsOnlyThreshold {
+			d.log.Warn("Block building started late, building deposits-only block to stay on schedule",
+				"num", l2Head.Number+1, "time", uint64(attrs.Timestamp), "remaining", remaining, "threshold", d.depositsOnlyThreshold)
+			attrs.NoTxPool = true
+			d.metrics.RecordSequencerDepositsOnlyBlock()
+		}
+	}
+
+	// Conditional transactions are checked against the parent state, and force-included after the deposits.
+	// If the engine fails to build the block with them, they are dropped upon the invalid attributes.
+	if !attrs.NoTxPool && d.conditionalTxs != nil {
+		if txs := d.conditionalTxs.Select(fetchCtx, l2Head, uint64(attrs.Timestamp)); len(txs) > 0 {
+			d.log.Info("Including conditional transactions", "num", l2Head.Number+1, "count", len(txs))
+			attrs.Transactions = append(attrs.Transactions, txs...)
+		}
+	}
+
+	d.metrics.RecordSequencerBlockPhase(PhaseAttributes, d.timeNow().Sub(attributesStart))
 	d.log.Debug("prepared attributes for new block",
 		"num", l2Head.Number+1, "time", uint64(attrs.Timestamp),
 		"origin", l1Origin, "origin_time", l1Origin.Time, "noTxPool", attrs.NoTxPool)
@@ -550,12 +647,32 @@ func (d *Sequencer) startBuildingBlock() {
 	// Reset building state, and remember what we are building on.
 	// If we get a forkchoice update that conflicts, we will have to abort building.
 	d.latest = BuildingState{Onto: l2Head}
+	d.timing = phaseTiming{buildStart: d.timeNow()}
 
 	d.emitter.Emit(engine.BuildStartEvent{
 		Attributes: withParent,
 	})
 }
 
+// recordPhase records the duration of the phase that started at the given time, if it is in progress,
+// and marks it completed.
+func (d *Sequencer) recordPhase(phase string, started *time.Time) {
+	if started.IsZero() {
+		return
+	}
+	d.metrics.RecordSequencerBlockPhase(phase, d.timeNow().Sub(*started))
+	*started = time.Time{}
+}
+
+// SetDepositsOnlyThreshold configures the minimum time left before the timestamp of a new block
+// to build it with tx-pool transactions. Blocks that start building later are built with deposits only.
+// Disabled if 0.
+func (d *Sequencer) SetDepositsOnlyThreshold(threshold time.Duration) {
+	d.l.Lock()
+	defer d.l.Unlock()
+	d.depositsOnlyThreshold = threshold
+}
+
 func (d *Sequencer) NextAction() (t time.Time, ok bool) {
 	d.l.Lock()
 	defer d.l.Unlock()
@@ -655,6 +772,20 @@ func (d *Sequencer) SetMaxSafeLag(ctx context.Context, v uint64) error {
 	return nil
 }
 
+// SetRecoverMode enables or disables recover mode: while enabled, new blocks are built with deposits only.
+func (d *Sequencer) SetRecoverMode(ctx context.Context, mode bool) error {
+	if prev := d.recoverMode.Swap(mode); prev != mode {
+		d.log.Warn("Sequencer recover mode changed", "recover_mode", mode)
+	}
+	d.metrics.RecordSequencerRecoverMode(mode)
+	return nil
+}
+
+// RecoverMode returns whether the sequencer builds blocks with deposits only.
+func (d *Sequencer) RecoverMode() bool {
+	return d.recoverMode.Load()
+}
+
 func (d *Sequencer) OverrideLeader(ctx context.Context) error {
 	return d.conductor.OverrideLeader(ctx)
 }
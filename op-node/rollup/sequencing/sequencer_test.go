@@ -3,6 +3,7 @@ package sequencing
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"math/rand" // nosemgrep
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
@@ -151,7 +153,46 @@ func (f *FakeAsyncGossip) Start() {
 
 var _ AsyncGossiper = (*FakeAsyncGossip)(nil)
 
+type FakeConditionalTxs struct {
+	txs     []eth.Data
+	parent  eth.L2BlockRef
+	dropped []eth.Data
+}
+
+func (f *FakeConditionalTxs) Select(ctx context.Context, parent eth.L2BlockRef, timestamp uint64) []eth.Data {
+	f.parent = parent
+	return f.txs
+}
+
+func (f *FakeConditionalTxs) Drop(txs []eth.Data) {
+	f.dropped = append(f.dropped, txs...)
+}
+
+var _ ConditionalTxSource = (*FakeConditionalTxs)(nil)
+
 // TestSequencer_StartStop runs through start/stop state back and forth to test state changes.
+type FakeMetrics struct {
+	metrics.Metricer
+	phases       map[string]time.Duration
+	depositsOnly int
+	recoverMode  bool
+}
+
+func (m *FakeMetrics) RecordSequencerBlockPhase(phase string, duration time.Duration) {
+	if m.phases == nil {
+		m.phases = make(map[string]time.Duration)
+	}
+	m.phases[phase] = duration
+}
+
+func (m *FakeMetrics) RecordSequencerDepositsOnlyBlock() {
+	m.depositsOnly++
+}
+
+func (m *FakeMetrics) RecordSequencerRecoverMode(mode bool) {
+	m.recoverMode = mode
+}
+
 func TestSequencer_StartStop(t *testing.T) {
 	logger := testlog.Logger(t, log.LevelError)
 	seq, deps := createSequencer(logger)
@@ -349,6 +390,13 @@ func TestSequencerBuild(t *testing.T) {
 	})
 	require.Nil(t, deps.asyncGossip.payload, "async gossip should have cleared,"+
 		" after previous publishing and now having persisted the block ourselves")
+	require.Equal(t, map[string]time.Duration{
+		PhaseAttributes: 0,
+		PhaseBuildStart: time.Millisecond * 150,
+		PhaseSeal:       0,
+		PhasePublish:    0,
+		PhaseInsert:     0,
+	}, deps.metrics.phases, "all block building phases are timed")
 	_, ok = seq.NextAction()
 	require.False(t, ok, "published and processed, but not canonical yet. Cannot proceed until then.")
 
@@ -374,6 +422,8 @@ type sequencerTestDeps struct {
 	seqState         *BasicSequencerStateListener
 	conductor        *FakeConductor
 	asyncGossip      *FakeAsyncGossip
+	conditionalTxs   *FakeConditionalTxs
+	metrics          *FakeMetrics
 }
 
 func createSequencer(log log.Logger) (*Sequencer, *sequencerTestDeps) {
@@ -407,13 +457,15 @@ func createSequencer(log log.Logger) (*Sequencer, *sequencerTestDeps) {
 				panic("override this")
 			},
 		},
-		seqState:    &BasicSequencerStateListener{},
-		conductor:   &FakeConductor{},
-		asyncGossip: &FakeAsyncGossip{},
+		seqState:       &BasicSequencerStateListener{},
+		conductor:      &FakeConductor{},
+		asyncGossip:    &FakeAsyncGossip{},
+		conditionalTxs: &FakeConditionalTxs{},
+		metrics:        &FakeMetrics{Metricer: metrics.NoopMetrics},
 	}
 	seq := NewSequencer(context.Background(), log, cfg, deps.attribBuilder,
 		deps.l1OriginSelector, deps.seqState, deps.conductor,
-		deps.asyncGossip, metrics.NoopMetrics)
+		deps.asyncGossip, deps.conditionalTxs, deps.metrics, clock.SystemClock)
 	// We create mock payloads, with the epoch-id as tx[0], rather than proper L1Block-info deposit tx.
 	seq.toBlockRef = func(rollupCfg *rollup.Config, payload *eth.ExecutionPayload) (eth.L2BlockRef, error) {
 		return eth.L2BlockRef{
@@ -427,3 +479,158 @@ func createSequencer(log log.Logger) (*Sequencer, *sequencerTestDeps) {
 	}
 	return seq, deps
 }
+
+func TestSequencerConditionalTxs(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	seq, deps := createSequencer(logger)
+	testClock := clock.NewSimpleClock()
+	seq.timeNow = testClock.Now
+	testClock.SetTime(30000)
+	emitter := &testutils.MockEmitter{}
+	seq.AttachEmitter(emitter)
+
+	emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+	require.NoError(t, seq.Init(context.Background(), true))
+	emitter.AssertExpectations(t)
+
+	head := eth.L2BlockRef{
+		Hash:     common.Hash{0x22},
+		Number:   100,
+		L1Origin: eth.BlockID{Hash: common.Hash{0x11, 0xa}, Number: 1000},
+		Time:     uint64(testClock.Now().Unix()),
+	}
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+	deps.l1OriginSelector.l1OriginFn = func(l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		return eth.L1BlockRef{Hash: common.Hash{0x11, 0xa}, Number: 1000, Time: 29998}, nil
+	}
+	conditionalTx := eth.Data{types.DynamicFeeTxType, 0xaa}
+	deps.conditionalTxs.txs = []eth.Data{conditionalTx}
+
+	var sentAttributes *derive.AttributesWithParent
+	emitter.ExpectOnceRun(func(ev event.Event) {
+		x, ok := ev.(engine.BuildStartEvent)
+		require.True(t, ok)
+		sentAttributes = x.Attributes
+	})
+	seq.OnEvent(SequencerActionEvent{})
+	emitter.AssertExpectations(t)
+	require.Equal(t, head, deps.conditionalTxs.parent)
+	txs := sentAttributes.Attributes.Transactions
+	require.Len(t, txs, 2, "conditional tx is included after the deposits")
+	require.Equal(t, conditionalTx, txs[1])
+	require.False(t, sentAttributes.Attributes.NoTxPool)
+
+	// If the engine can't build with the conditional transactions, they are dropped
+	seq.OnEvent(engine.InvalidPayloadAttributesEvent{Attributes: sentAttributes, Err: errors.New("failed to force-include tx")})
+	require.Contains(t, deps.conditionalTxs.dropped, conditionalTx)
+	_, ok := seq.NextAction()
+	require.True(t, ok, "retries building")
+}
+
+func TestSequencerDepositsOnlyWhenLate(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	seq, deps := createSequencer(logger)
+	testClock := clock.NewSimpleClock()
+	seq.timeNow = testClock.Now
+	testClock.SetTime(30000)
+	emitter := &testutils.MockEmitter{}
+	seq.AttachEmitter(emitter)
+	seq.SetDepositsOnlyThreshold(time.Second)
+
+	emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+	require.NoError(t, seq.Init(context.Background(), true))
+	emitter.AssertExpectations(t)
+
+	head := eth.L2BlockRef{
+		Hash:     common.Hash{0x22},
+		Number:   100,
+		L1Origin: eth.BlockID{Hash: common.Hash{0x11, 0xa}, Number: 1000},
+		Time:     uint64(testClock.Now().Unix()),
+	}
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+	deps.l1OriginSelector.l1OriginFn = func(l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		return eth.L1BlockRef{Hash: common.Hash{0x11, 0xa}, Number: 1000, Time: 29998}, nil
+	}
+	deps.conditionalTxs.txs = []eth.Data{{types.DynamicFeeTxType, 0xaa}}
+
+	startBuilding := func() *derive.AttributesWithParent {
+		var sentAttributes *derive.AttributesWithParent
+		emitter.ExpectOnceRun(func(ev event.Event) {
+			x, ok := ev.(engine.BuildStartEvent)
+			require.True(t, ok)
+			sentAttributes = x.Attributes
+		})
+		seq.OnEvent(SequencerActionEvent{})
+		emitter.AssertExpectations(t)
+		return sentAttributes
+	}
+
+	// With 2 seconds left until the block timestamp, the block includes tx-pool transactions
+	attrs := startBuilding()
+	require.False(t, attrs.Attributes.NoTxPool)
+	require.Zero(t, deps.metrics.depositsOnly)
+
+	// Starting with less time left than the threshold, e.g. after a slow engine, only deposits are included
+	seq.OnEvent(engine.InvalidPayloadAttributesEvent{Attributes: attrs, Err: errors.New("engine too slow")})
+	testClock.Set(time.Unix(int64(head.Time), 0).Add(time.Millisecond * 1500))
+	attrs = startBuilding()
+	require.True(t, attrs.Attributes.NoTxPool, "late block is built with deposits only")
+	require.Len(t, attrs.Attributes.Transactions, 1, "no conditional transactions in deposits-only block")
+	require.Equal(t, 1, deps.metrics.depositsOnly)
+}
+
+func TestSequencerRecoverMode(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	seq, deps := createSequencer(logger)
+	testClock := clock.NewSimpleClock()
+	seq.timeNow = testClock.Now
+	testClock.SetTime(30000)
+	emitter := &testutils.MockEmitter{}
+	seq.AttachEmitter(emitter)
+
+	emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+	require.NoError(t, seq.Init(context.Background(), true))
+	emitter.AssertExpectations(t)
+
+	head := eth.L2BlockRef{
+		Hash:     common.Hash{0x22},
+		Number:   100,
+		L1Origin: eth.BlockID{Hash: common.Hash{0x11, 0xa}, Number: 1000},
+		Time:     uint64(testClock.Now().Unix()),
+	}
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+	deps.l1OriginSelector.l1OriginFn = func(l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		return eth.L1BlockRef{Hash: common.Hash{0x11, 0xa}, Number: 1000, Time: 29998}, nil
+	}
+	deps.conditionalTxs.txs = []eth.Data{{types.DynamicFeeTxType, 0xaa}}
+
+	startBuilding := func() *derive.AttributesWithParent {
+		var sentAttributes *derive.AttributesWithParent
+		emitter.ExpectOnceRun(func(ev event.Event) {
+			x, ok := ev.(engine.BuildStartEvent)
+			require.True(t, ok)
+			sentAttributes = x.Attributes
+		})
+		seq.OnEvent(SequencerActionEvent{})
+		emitter.AssertExpectations(t)
+		return sentAttributes
+	}
+
+	require.False(t, seq.RecoverMode())
+	require.NoError(t, seq.SetRecoverMode(context.Background(), true))
+	require.True(t, seq.RecoverMode())
+	require.True(t, deps.metrics.recoverMode)
+
+	attrs := startBuilding()
+	require.True(t, attrs.Attributes.NoTxPool, "recover mode builds deposits-only blocks")
+	require.Len(t, attrs.Attributes.Transactions, 1, "no conditional transactions in recover mode")
+	require.Zero(t, deps.metrics.depositsOnly, "recover mode blocks are not counted as late blocks")
+
+	// After leaving recover mode, blocks include tx-pool and conditional transactions again
+	require.NoError(t, seq.SetRecoverMode(context.Background(), false))
+	require.False(t, deps.metrics.recoverMode)
+	seq.OnEvent(engine.InvalidPayloadAttributesEvent{Attributes: attrs, Err: errors.New("retry")})
+	attrs = startBuilding()
+	require.False(t, attrs.Attributes.NoTxPool)
+	require.Len(t, attrs.Attributes.Transactions, 2)
+}
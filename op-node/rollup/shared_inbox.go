@@ -0,0 +1,57 @@
+package rollup
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	ErrSharedBatchInboxIsBatchInbox   = errors.New("shared batch inbox address must differ from the batch inbox address")
+	ErrDuplicateSharedBatchInbox      = errors.New("duplicate shared batch inbox address")
+	ErrChainIDTooLargeForSharedInbox  = errors.New("L2 chain ID must fit in 64 bits to tag channels for shared batch inboxes")
+	ErrMissingSharedBatchInboxAddress = errors.New("shared batch inbox address cannot be empty")
+)
+
+// ChannelIDChainTagLength is the number of leading bytes of a channel ID that identify the chain,
+// when the chain reads batch data from shared batch inboxes.
+const ChannelIDChainTagLength = 8
+
+// ChainTaggedChannels returns true if the channels of the chain are tagged with the chain ID,
+// so the channel bank can filter out the frames of other chains that share a batch inbox.
+// Channels are tagged as soon as any shared batch inbox is configured, also on the batch inbox of the chain itself,
+// since a batcher may post the frames of several chains to either inbox.
+func (cfg *Config) ChainTaggedChannels() bool {
+	return len(cfg.SharedBatchInboxAddresses) > 0
+}
+
+// ChannelIDChainTag returns the leading bytes of the channel IDs of the chain when channels are chain-tagged:
+// the L2 chain ID as big-endian uint64.
+func (cfg *Config) ChannelIDChainTag() [ChannelIDChainTagLength]byte {
+	var tag [ChannelIDChainTagLength]byte
+	if cfg.L2ChainID != nil && cfg.L2ChainID.IsUint64() {
+		binary.BigEndian.PutUint64(tag[:], cfg.L2ChainID.Uint64())
+	}
+	return tag
+}
+
+func validateSharedBatchInboxes(cfg *Config) error {
+	for i, addr := range cfg.SharedBatchInboxAddresses {
+		if addr == (common.Address{}) {
+			return fmt.Errorf("shared batch inbox %d: %w", i, ErrMissingSharedBatchInboxAddress)
+		}
+		if addr == cfg.BatchInboxAddress {
+			return fmt.Errorf("shared batch inbox %d (%s): %w", i, addr, ErrSharedBatchInboxIsBatchInbox)
+		}
+		if slices.Contains(cfg.SharedBatchInboxAddresses[:i], addr) {
+			return fmt.Errorf("shared batch inbox %d (%s): %w", i, addr, ErrDuplicateSharedBatchInbox)
+		}
+	}
+	if cfg.ChainTaggedChannels() && (cfg.L2ChainID == nil || !cfg.L2ChainID.IsUint64()) {
+		return ErrChainIDTooLargeForSharedInbox
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package rollup
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChainTaggedChannels(t *testing.T) {
+	cfg := &Config{BatchInboxAddress: common.Address{0x01}}
+	require.False(t, cfg.ChainTaggedChannels())
+
+	cfg.SharedBatchInboxAddresses = []common.Address{{0x02}}
+	require.True(t, cfg.ChainTaggedChannels())
+}
+
+func TestChannelIDChainTag(t *testing.T) {
+	cfg := &Config{L2ChainID: big.NewInt(0x0a0b0c)}
+	require.Equal(t, [ChannelIDChainTagLength]byte{0, 0, 0, 0, 0, 0x0a, 0x0b, 0x0c}, cfg.ChannelIDChainTag())
+}
+
+func TestValidateSharedBatchInboxes(t *testing.T) {
+	inbox := common.Address{0x01}
+	shared := common.Address{0x02}
+	tooLarge := new(big.Int).Lsh(big.NewInt(1), 64)
+	tests := []struct {
+		name    string
+		shared  []common.Address
+		chainID *big.Int
+		err     error
+	}{
+		{name: "none", chainID: tooLarge},
+		{name: "valid", shared: []common.Address{shared, {0x03}}, chainID: big.NewInt(10)},
+		{name: "empty address", shared: []common.Address{{}}, chainID: big.NewInt(10), err: ErrMissingSharedBatchInboxAddress},
+		{name: "batch inbox", shared: []common.Address{inbox}, chainID: big.NewInt(10), err: ErrSharedBatchInboxIsBatchInbox},
+		{name: "duplicate", shared: []common.Address{shared, shared}, chainID: big.NewInt(10), err: ErrDuplicateSharedBatchInbox},
+		{name: "chain ID too large", shared: []common.Address{shared}, chainID: tooLarge, err: ErrChainIDTooLargeForSharedInbox},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{BatchInboxAddress: inbox, SharedBatchInboxAddresses: tc.shared, L2ChainID: tc.chainID}
+			err := validateSharedBatchInboxes(cfg)
+			if tc.err == nil {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
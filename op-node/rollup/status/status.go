@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -47,13 +48,19 @@ type StatusTracker struct {
 	mu sync.RWMutex
 }
 
-func NewStatusTracker(log log.Logger, metrics Metrics) *StatusTracker {
+func NewStatusTracker(log log.Logger, metrics Metrics, cfg *rollup.Config) *StatusTracker {
 	st := &StatusTracker{
 		log:     log,
 		metrics: metrics,
 	}
 	st.data = eth.SyncStatus{}
-	st.published.Store(&eth.SyncStatus{})
+	if hash, err := cfg.Hash(); err != nil {
+		log.Error("Failed to compute rollup config hash", "err", err)
+	} else {
+		st.data.RollupConfigHash = hash
+	}
+	published := st.data
+	st.published.Store(&published)
 	return st
 }
 
@@ -105,6 +112,15 @@ func (st *StatusTracker) OnEvent(ev event.Event) bool {
 		st.data.UnsafeL2 = eth.L2BlockRef{}
 		st.data.SafeL2 = eth.L2BlockRef{}
 		st.data.CurrentL1 = eth.L1BlockRef{}
+	case interop.CrossSafeUpdateEvent:
+		st.data.CrossSafeL2 = x.CrossSafe
+	case engine.ELSyncStartedEvent:
+		st.data.ELSync.Syncing = true
+	case engine.ELSyncProgressEvent:
+		st.data.ELSync = x.Progress
+	case engine.ELSyncFinishedEvent:
+		st.log.Info("Switching from EL sync to derivation", "finalized_l2", x.Finalized, "el_sync_duration", x.Duration)
+		st.data.ELSync = eth.ELSyncStatus{}
 	case engine.EngineResetConfirmedEvent:
 		st.data.UnsafeL2 = x.Unsafe
 		st.data.SafeL2 = x.Safe
@@ -0,0 +1,32 @@
+package status
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestSyncStatusIncludesRollupConfigHash(t *testing.T) {
+	cfg := &rollup.Config{L2ChainID: big.NewInt(901)}
+	expected, err := cfg.Hash()
+	require.NoError(t, err)
+
+	st := NewStatusTracker(testlog.Logger(t, log.LevelError), metrics.NoopMetrics, cfg)
+	require.Equal(t, expected, st.SyncStatus().RollupConfigHash)
+
+	// The hash is kept when the status changes
+	origin := eth.L1BlockRef{Number: 10, Hash: [32]byte{0xaa}}
+	require.True(t, st.OnEvent(derive.DeriverL1StatusEvent{Origin: origin}))
+	require.Equal(t, origin, st.SyncStatus().CurrentL1)
+	require.Equal(t, expected, st.SyncStatus().RollupConfigHash)
+	require.True(t, st.OnEvent(rollup.ResetEvent{}))
+	require.Equal(t, expected, st.SyncStatus().RollupConfigHash)
+}
@@ -2,6 +2,7 @@ package rollup
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -9,10 +10,12 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -137,6 +140,20 @@ type Config struct {
 
 	// AltDAConfig. We are in the process of migrating to the AltDAConfig from these legacy top level values
 	AltDAConfig *AltDAConfig `json:"alt_da,omitempty"`
+
+	// BatcherKeys schedules the batcher addresses that batches are accepted from, overriding the system config batcher while active.
+	// Optional, batches are only accepted from the system config batcher if empty.
+	BatcherKeys []BatcherKey `json:"batcher_keys,omitempty"`
+
+	// SharedBatchInboxAddresses are L1 addresses that batch data is read from, in addition to the BatchInboxAddress,
+	// e.g. a blob inbox shared by the chains of an interop set to share the cost of data availability.
+	// If set, the channels of the chain must be tagged with the chain ID, see ChainTaggedChannels.
+	// Optional, and changes derivation: only set at genesis or in coordination with all nodes of the chain.
+	SharedBatchInboxAddresses []common.Address `json:"shared_batch_inbox_addresses,omitempty"`
+
+	// UnsafeDevnetDeposits are synthetic system deposits inserted into L2 blocks by derivation, to rehearse upgrades on devnets.
+	// Optional, and changes derivation: nodes refuse to run with them, unless explicitly allowed. See DevnetDeposit.
+	UnsafeDevnetDeposits []DevnetDeposit `json:"unsafe_devnet_deposits,omitempty"`
 }
 
 // ValidateL1Config checks L1 config variables for errors.
@@ -154,6 +171,21 @@ func (cfg *Config) ValidateL1Config(ctx context.Context, client L1Client) error
 	return nil
 }
 
+// L1AddressExpectations returns the configured L1 addresses and the on-chain code they are expected to hold,
+// to validate them with addrcheck.Check. They are named after the contracts, to pin their code hashes by name.
+func (cfg *Config) L1AddressExpectations() []addrcheck.Expectation {
+	exps := []addrcheck.Expectation{
+		addrcheck.ExpectContract("OptimismPortal", cfg.DepositContractAddress),
+		addrcheck.ExpectContract("SystemConfig", cfg.L1SystemConfigAddress),
+		addrcheck.ExpectNoCode("BatchInbox", cfg.BatchInboxAddress),
+		{Name: "ProtocolVersions", Address: cfg.ProtocolVersionsAddress, Kind: addrcheck.Contract, Optional: true},
+	}
+	if cfg.AltDAConfig != nil {
+		exps = append(exps, addrcheck.Expectation{Name: "DataAvailabilityChallenge", Address: cfg.AltDAConfig.DAChallengeAddress, Kind: addrcheck.Contract, Optional: true})
+	}
+	return exps
+}
+
 // ValidateL2Config checks L2 config variables for errors.
 func (cfg *Config) ValidateL2Config(ctx context.Context, client L2Client, skipL2GenesisBlockHash bool) error {
 	// Validate the L2 Client Chain ID
@@ -307,7 +339,21 @@ func (cfg *Config) Check() error {
 	if err := validateAltDAConfig(cfg); err != nil {
 		return err
 	}
+	if err := validateBatcherKeys(cfg.BatcherKeys); err != nil {
+		return err
+	}
+	if err := validateSharedBatchInboxes(cfg); err != nil {
+		return err
+	}
+	if err := validateDevnetDeposits(cfg); err != nil {
+		return err
+	}
+
+	return cfg.CheckForkOrder()
+}
 
+// CheckForkOrder checks that the configured network upgrades activate in order.
+func (cfg *Config) CheckForkOrder() error {
 	if err := checkFork(cfg.RegolithTime, cfg.CanyonTime, Regolith, Canyon); err != nil {
 		return err
 	}
@@ -363,6 +409,16 @@ func checkFork(a, b *uint64, aName, bName ForkName) error {
 	return nil
 }
 
+// Hash returns the keccak256 hash of the JSON encoding of the rollup config.
+// Nodes running with the same rollup config have the same hash, so it can be used to detect config skew.
+func (c *Config) Hash() (common.Hash, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to encode rollup config: %w", err)
+	}
+	return crypto.Keccak256Hash(data), nil
+}
+
 func (c *Config) L1Signer() types.Signer {
 	return types.NewCancunSigner(c.L1ChainID)
 }
@@ -612,6 +668,9 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 		"holocene_time", fmtForkTimeOrUnset(c.HoloceneTime),
 		"interop_time", fmtForkTimeOrUnset(c.InteropTime),
 		"alt_da", c.AltDAConfig != nil,
+		"batcher_keys", len(c.BatcherKeys),
+		"shared_batch_inboxes", len(c.SharedBatchInboxAddresses),
+		"unsafe_devnet_deposits", len(c.UnsafeDevnetDeposits),
 	)
 }
 
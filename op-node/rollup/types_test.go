@@ -60,6 +60,26 @@ func TestConfigJSON(t *testing.T) {
 	assert.Equal(t, &roundTripped, config)
 }
 
+func TestConfigHash(t *testing.T) {
+	config := randConfig()
+	hash, err := config.Hash()
+	require.NoError(t, err)
+
+	// The hash commits to the config, not to the instance
+	data, err := json.Marshal(config)
+	require.NoError(t, err)
+	var roundTripped Config
+	require.NoError(t, json.Unmarshal(data, &roundTripped))
+	roundTrippedHash, err := roundTripped.Hash()
+	require.NoError(t, err)
+	require.Equal(t, hash, roundTrippedHash)
+
+	roundTripped.BlockTime++
+	modifiedHash, err := roundTripped.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash, modifiedHash)
+}
+
 type mockL1Client struct {
 	chainID *big.Int
 	Hash    common.Hash
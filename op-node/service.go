@@ -11,7 +11,9 @@ import (
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -24,6 +26,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
 )
@@ -65,26 +68,42 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		return nil, fmt.Errorf("failed to load l2 endpoints info: %w", err)
 	}
 
+	l2Builder, err := NewL2BuilderEndpointConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load l2 builder endpoint info: %w", err)
+	}
+
 	syncConfig, err := NewSyncConfig(ctx, log)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the sync config: %w", err)
 	}
 
+	var adminJWTSecret []byte
+	if path := ctx.String(flags.RPCAdminJWTSecret.Name); path != "" {
+		adminJWTSecret, err = oprpc.ReadJWTSecret(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	haltOption := ctx.String(flags.RollupHalt.Name)
 	if haltOption == "none" {
 		haltOption = ""
 	}
 
 	cfg := &node.Config{
-		L1:     l1Endpoint,
-		L2:     l2Endpoint,
-		Rollup: *rollupConfig,
-		Driver: *driverConfig,
-		Beacon: NewBeaconEndpointConfig(ctx),
+		L1:         l1Endpoint,
+		L2:         l2Endpoint,
+		Rollup:     *rollupConfig,
+		Driver:     *driverConfig,
+		Beacon:     NewBeaconEndpointConfig(ctx),
+		Supervisor: NewSupervisorEndpointConfig(ctx),
+		L2Builder:  l2Builder,
 		RPC: node.RPCConfig{
-			ListenAddr:  ctx.String(flags.RPCListenAddr.Name),
-			ListenPort:  ctx.Int(flags.RPCListenPort.Name),
-			EnableAdmin: ctx.Bool(flags.RPCEnableAdmin.Name),
+			ListenAddr:     ctx.String(flags.RPCListenAddr.Name),
+			ListenPort:     ctx.Int(flags.RPCListenPort.Name),
+			EnableAdmin:    ctx.Bool(flags.RPCEnableAdmin.Name),
+			AdminJWTSecret: adminJWTSecret,
 		},
 		Metrics: node.MetricsConfig{
 			Enabled:    ctx.Bool(flags.MetricsEnabledFlag.Name),
@@ -101,11 +120,19 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 			Moniker: ctx.String(flags.HeartbeatMonikerFlag.Name),
 			URL:     ctx.String(flags.HeartbeatURLFlag.Name),
 		},
-		ConfigPersistence: configPersistence,
-		SafeDBPath:        ctx.String(flags.SafeDBPath.Name),
-		Sync:              *syncConfig,
-		RollupHalt:        haltOption,
-		RethDBPath:        ctx.String(flags.L1RethDBPath.Name),
+		ConfigPersistence:   configPersistence,
+		SafeDBPath:          ctx.String(flags.SafeDBPath.Name),
+		Sync:                *syncConfig,
+		RollupHalt:          haltOption,
+		RethDBPath:          ctx.String(flags.L1RethDBPath.Name),
+		L1CacheFile:         ctx.String(flags.L1CacheFile.Name),
+		L1CacheDepth:        ctx.Uint64(flags.L1CacheDepth.Name),
+		L1CacheSaveInterval: ctx.Duration(flags.L1CacheSaveInterval.Name),
+		SkipL1AddressCheck:  ctx.Bool(flags.L1SkipAddressCheck.Name),
+		L1AddressCheck:      addrcheck.ReadCLIConfig(ctx),
+		L2EngineReconcile:   ctx.Bool(flags.L2EngineReconcile.Name),
+
+		AllowUnsafeDevnetDeposits: ctx.Bool(flags.RollupAllowUnsafeDevnetDeposits.Name),
 
 		ConductorEnabled:    ctx.Bool(flags.ConductorEnabledFlag.Name),
 		ConductorRpc:        ctx.String(flags.ConductorRpcFlag.Name),
@@ -139,6 +166,34 @@ func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
 	}
 }
 
+// NewSupervisorEndpointConfig returns the supervisor endpoint, or nil if interop mode is not enabled.
+func NewSupervisorEndpointConfig(ctx *cli.Context) node.SupervisorEndpointSetup {
+	if !ctx.IsSet(flags.SupervisorAddr.Name) {
+		return nil
+	}
+	return &node.SupervisorEndpointConfig{
+		SupervisorAddr: ctx.String(flags.SupervisorAddr.Name),
+	}
+}
+
+// NewL2BuilderEndpointConfig returns the external block builder endpoint, or nil if none is configured.
+func NewL2BuilderEndpointConfig(ctx *cli.Context) (node.L2BuilderEndpointSetup, error) {
+	if !ctx.IsSet(flags.SequencerBuilderAddrFlag.Name) {
+		return nil, nil
+	}
+	fileName := strings.TrimSpace(ctx.String(flags.SequencerBuilderJWTSecretFlag.Name))
+	if fileName == "" {
+		return nil, fmt.Errorf("file-name of builder jwt secret is empty")
+	}
+	jwtSecret, err := oprpc.ReadJWTSecret(fileName)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &node.L2BuilderEndpointConfig{L2BuilderAddr: ctx.String(flags.SequencerBuilderAddrFlag.Name)}
+	copy(cfg.L2BuilderJWTSecret[:], jwtSecret)
+	return cfg, nil
+}
+
 func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 	return &node.L1EndpointConfig{
 		L1NodeAddr:       ctx.String(flags.L1NodeAddr.Name),
@@ -191,11 +246,19 @@ func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 
 func NewDriverConfig(ctx *cli.Context) *driver.Config {
 	return &driver.Config{
-		VerifierConfDepth:   ctx.Uint64(flags.VerifierL1Confs.Name),
-		SequencerConfDepth:  ctx.Uint64(flags.SequencerL1Confs.Name),
-		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
-		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
-		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		VerifierConfDepth:              ctx.Uint64(flags.VerifierL1Confs.Name),
+		SequencerConfDepth:             ctx.Uint64(flags.SequencerL1Confs.Name),
+		SequencerOriginPolicy:          ctx.String(flags.SequencerOriginPolicyFlag.Name),
+		SequencerEnabled:               ctx.Bool(flags.SequencerEnabledFlag.Name),
+		SequencerStopped:               ctx.Bool(flags.SequencerStoppedFlag.Name),
+		SequencerMaxSafeLag:            ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		SequencerDepositsOnlyThreshold: ctx.Duration(flags.SequencerDepositsOnlyThresholdFlag.Name),
+		SequencerConditionalTxs: sequencing.ConditionalTxConfig{
+			Enabled:     ctx.Bool(flags.SequencerConditionalTxsFlag.Name),
+			MaxPending:  ctx.Int(flags.SequencerConditionalTxsMaxPendingFlag.Name),
+			MaxCost:     ctx.Int(flags.SequencerConditionalTxsMaxCostFlag.Name),
+			MaxPerBlock: ctx.Int(flags.SequencerConditionalTxsMaxPerBlockFlag.Name),
+		},
 	}
 }
 
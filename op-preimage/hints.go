@@ -6,10 +6,43 @@ import (
 	"io"
 )
 
+// HintProtocolV2Handshake is the reserved hint a HintWriter sends to negotiate version 2 of the hint protocol.
+// Readers that support version 2 respond with the hintProtocolV2 byte instead of handling it as a hint.
+// Older readers handle it as a hint, and acknowledge it with HintAck, so the writer keeps using version 1.
+// Writers never forward it as a hint.
+const HintProtocolV2Handshake = "hint-protocol-v2"
+
+const (
+	// HintAck is the response to a processed hint. In version 1 of the protocol it is the only response.
+	HintAck byte = 0
+	// HintFailed is the response to a hint that failed to be processed, so the pre-images it is for may be missing.
+	// It is followed by a big-endian uint32 length prefixed error message. Only sent in version 2 of the protocol.
+	HintFailed byte = 1
+
+	hintProtocolV2 byte = 2
+
+	// maxHintErrorLen is the maximum length of the error message sent in response to a failed hint.
+	maxHintErrorLen = 4096
+)
+
+// HintError is the error a HintWriter panics with when the reader reports a hint failed to be processed.
+type HintError struct {
+	Hint    string
+	Message string
+}
+
+func (e *HintError) Error() string {
+	return fmt.Sprintf("pre-image hint %q failed, pre-images may be missing: %s", e.Hint, e.Message)
+}
+
 // HintWriter writes hints to an io.Writer (e.g. a special file descriptor, or a debug log),
 // for a pre-image oracle service to prepare specific pre-images.
 type HintWriter struct {
 	rw io.ReadWriter
+	// negotiateV2 is true if version 2 of the protocol is to be negotiated before the first hint is written.
+	negotiateV2 bool
+	// v2 is true if the reader agreed to use version 2 of the protocol.
+	v2 bool
 }
 
 var _ Hinter = (*HintWriter)(nil)
@@ -18,8 +51,35 @@ func NewHintWriter(rw io.ReadWriter) *HintWriter {
 	return &HintWriter{rw: rw}
 }
 
+// NewHintWriterV2 creates a HintWriter that negotiates version 2 of the protocol before writing the first hint,
+// so it can tell processed hints apart from failed hints, and panics with a *HintError on the latter.
+// Readers that don't support version 2, or don't respond (e.g. onchain), are spoken to with version 1.
+func NewHintWriterV2(rw io.ReadWriter) *HintWriter {
+	return &HintWriter{rw: rw, negotiateV2: true}
+}
+
 func (hw *HintWriter) Hint(v Hint) {
 	hint := v.Hint()
+	if hint == HintProtocolV2Handshake {
+		// The handshake of a relayed writer is not a hint, and would change the protocol of this writer.
+		return
+	}
+	if hw.negotiateV2 {
+		hw.negotiateV2 = false
+		hw.write(HintProtocolV2Handshake)
+		response := []byte{0}
+		if _, err := hw.rw.Read(response); err != nil {
+			panic(fmt.Errorf("failed to read pre-image hint protocol version: %w", err))
+		}
+		hw.v2 = response[0] == hintProtocolV2
+	}
+	hw.write(hint)
+	if err := hw.readResponse(hint); err != nil {
+		panic(err)
+	}
+}
+
+func (hw *HintWriter) write(hint string) {
 	var hintBytes []byte
 	hintBytes = binary.BigEndian.AppendUint32(hintBytes, uint32(len(hint)))
 	hintBytes = append(hintBytes, []byte(hint)...)
@@ -27,9 +87,34 @@ func (hw *HintWriter) Hint(v Hint) {
 	if err != nil {
 		panic(fmt.Errorf("failed to write pre-image hint: %w", err))
 	}
-	_, err = hw.rw.Read([]byte{0})
-	if err != nil {
-		panic(fmt.Errorf("failed to read pre-image hint ack: %w", err))
+}
+
+func (hw *HintWriter) readResponse(hint string) error {
+	status := []byte{0}
+	if _, err := hw.rw.Read(status); err != nil {
+		return fmt.Errorf("failed to read pre-image hint ack: %w", err)
+	}
+	if !hw.v2 {
+		return nil
+	}
+	switch status[0] {
+	case HintAck:
+		return nil
+	case HintFailed:
+		var length uint32
+		if err := binary.Read(hw.rw, binary.BigEndian, &length); err != nil {
+			return fmt.Errorf("failed to read pre-image hint error length prefix: %w", err)
+		}
+		if length > maxHintErrorLen {
+			return fmt.Errorf("pre-image hint error too long: %d bytes", length)
+		}
+		msg := make([]byte, length)
+		if _, err := io.ReadFull(hw.rw, msg); err != nil {
+			return fmt.Errorf("failed to read pre-image hint error (length %d): %w", length, err)
+		}
+		return &HintError{Hint: hint, Message: string(msg)}
+	default:
+		return fmt.Errorf("unexpected pre-image hint response status %d", status[0])
 	}
 }
 
@@ -37,6 +122,8 @@ func (hw *HintWriter) Hint(v Hint) {
 // Onchain the written hints are no-op.
 type HintReader struct {
 	rw io.ReadWriter
+	// v2 is true once the writer negotiated version 2 of the protocol.
+	v2 bool
 }
 
 func NewHintReader(rw io.ReadWriter) *HintReader {
@@ -59,13 +146,35 @@ func (hr *HintReader) NextHint(router HintHandler) error {
 			return fmt.Errorf("failed to read hint payload (length %d): %w", length, err)
 		}
 	}
+	if string(payload) == HintProtocolV2Handshake {
+		hr.v2 = true
+		if _, err := hr.rw.Write([]byte{hintProtocolV2}); err != nil {
+			return fmt.Errorf("failed to write hint protocol version: %w", err)
+		}
+		return nil
+	}
 	if err := router(string(payload)); err != nil {
 		// write back on error to unblock the HintWriter
-		_, _ = hr.rw.Write([]byte{0})
+		_, _ = hr.rw.Write(hr.errorResponse(err))
 		return fmt.Errorf("failed to handle hint: %w", err)
 	}
-	if _, err := hr.rw.Write([]byte{0}); err != nil {
+	if _, err := hr.rw.Write([]byte{HintAck}); err != nil {
 		return fmt.Errorf("failed to write trailing no-op byte to unblock hint writer: %w", err)
 	}
 	return nil
 }
+
+// errorResponse returns the response to a failed hint.
+// Writers of version 1 of the protocol are sent an ack, as they can't tell failed hints apart.
+func (hr *HintReader) errorResponse(err error) []byte {
+	if !hr.v2 {
+		return []byte{HintAck}
+	}
+	msg := err.Error()
+	if len(msg) > maxHintErrorLen {
+		msg = msg[:maxHintErrorLen]
+	}
+	out := []byte{HintFailed}
+	out = binary.BigEndian.AppendUint32(out, uint32(len(msg)))
+	return append(out, msg...)
+}
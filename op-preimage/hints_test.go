@@ -3,6 +3,7 @@ package preimage
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/binary"
 	"errors"
 	"io"
 	"sync"
@@ -119,6 +120,91 @@ func TestHints(t *testing.T) {
 	})
 }
 
+func TestHintsV2(t *testing.T) {
+	t.Run("ack and error", func(t *testing.T) {
+		a, b := bidirectionalPipe()
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		var panicked any
+		go func() {
+			defer wg.Done()
+			defer func() { panicked = recover() }()
+			hw := NewHintWriterV2(a)
+			hw.Hint(rawHint("one"))
+			hw.Hint(rawHint("two"))
+		}()
+		var got []string
+		go func() {
+			defer wg.Done()
+			hr := NewHintReader(b)
+			// The handshake is not passed to the router
+			for i := 0; i < 2; i++ {
+				require.NoError(t, hr.NextHint(func(hint string) error {
+					got = append(got, hint)
+					return nil
+				}))
+			}
+			err := hr.NextHint(func(hint string) error { return errors.New("no data for two") })
+			require.ErrorContains(t, err, "no data for two")
+		}()
+		if waitTimeout(&wg) {
+			t.Fatal("hint read/write stuck")
+		}
+		require.Equal(t, []string{"one"}, got)
+		var hintErr *HintError
+		require.ErrorAs(t, panicked.(error), &hintErr)
+		require.Equal(t, "two", hintErr.Hint)
+		require.Equal(t, "no data for two", hintErr.Message)
+	})
+
+	t.Run("v1 reader", func(t *testing.T) {
+		a, b := bidirectionalPipe()
+		var wg sync.WaitGroup
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			hw := NewHintWriterV2(a)
+			hw.Hint(rawHint("one"))
+			hw.Hint(rawHint("two"))
+		}()
+		var got []string
+		go func() {
+			defer wg.Done()
+			// A reader of version 1 of the protocol handles the handshake as a hint, and only acks hints
+			for i := 0; i < 3; i++ {
+				var length uint32
+				require.NoError(t, binary.Read(b, binary.BigEndian, &length))
+				payload := make([]byte, length)
+				_, err := io.ReadFull(b, payload)
+				require.NoError(t, err)
+				got = append(got, string(payload))
+				_, err = b.Write([]byte{HintAck})
+				require.NoError(t, err)
+			}
+		}()
+		if waitTimeout(&wg) {
+			t.Fatal("hint read/write stuck")
+		}
+		require.Equal(t, []string{HintProtocolV2Handshake, "one", "two"}, got)
+	})
+
+	t.Run("v1 writer is acked on error", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewHintWriter(&buf).write("one")
+		hr := NewHintReader(&buf)
+		require.Error(t, hr.NextHint(func(hint string) error { return errors.New("fail") }))
+		require.Equal(t, []byte{HintAck}, buf.Bytes())
+	})
+
+	t.Run("handshake is not forwarded", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewHintWriter(&buf).Hint(rawHint(HintProtocolV2Handshake))
+		require.Zero(t, buf.Len())
+	})
+}
+
 // waitTimeout returns true iff wg.Wait timed out
 func waitTimeout(wg *sync.WaitGroup) bool {
 	done := make(chan struct{})
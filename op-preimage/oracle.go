@@ -7,16 +7,31 @@ import (
 	"io"
 )
 
+// DefaultReadWindow is the size of the read window of a buffered OracleClient.
+const DefaultReadWindow = 4096
+
 // OracleClient implements the Oracle by writing the pre-image key to the given stream,
 // and reading back a length-prefixed value.
 type OracleClient struct {
 	rw io.ReadWriter
+	// window, if not nil, buffers the reads of the responses
+	window []byte
 }
 
 func NewOracleClient(rw io.ReadWriter) *OracleClient {
 	return &OracleClient{rw: rw}
 }
 
+// NewBufferedOracleClient creates an OracleClient that reads each response through a window of windowSize bytes.
+// The length prefix and the start of the value are read with a single window read, and values that fit the window
+// are served from it, instead of reading the length prefix and the value separately.
+// Reads never go beyond the current response, since the server only writes the next response after the next request.
+func NewBufferedOracleClient(rw io.ReadWriter, windowSize int) *OracleClient {
+	// Heap allocations of this size are word aligned. Reads into the window are thus aligned:
+	// the VM serves at most a word per read syscall, and fewer bytes if the destination is not aligned.
+	return &OracleClient{rw: rw, window: make([]byte, max(windowSize, 8))}
+}
+
 var _ Oracle = (*OracleClient)(nil)
 
 func (o *OracleClient) Get(key Key) []byte {
@@ -24,6 +39,13 @@ func (o *OracleClient) Get(key Key) []byte {
 	if _, err := o.rw.Write(h[:]); err != nil {
 		panic(fmt.Errorf("failed to write key %s (%T) to pre-image oracle: %w", key, key, err))
 	}
+	if o.window != nil {
+		payload, err := o.readBuffered()
+		if err != nil {
+			panic(fmt.Errorf("failed to read pre-image of key %s (%T) from pre-image oracle: %w", key, key, err))
+		}
+		return payload
+	}
 
 	var length uint64
 	if err := binary.Read(o.rw, binary.BigEndian, &length); err != nil {
@@ -37,6 +59,29 @@ func (o *OracleClient) Get(key Key) []byte {
 	return payload
 }
 
+func (o *OracleClient) readBuffered() ([]byte, error) {
+	n, err := io.ReadAtLeast(o.rw, o.window, 8)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pre-image length: %w", err)
+	}
+	length := binary.BigEndian.Uint64(o.window[:8])
+	if uint64(n-8) > length {
+		return nil, fmt.Errorf("read %d bytes beyond pre-image of length %d", uint64(n-8)-length, length)
+	}
+	payload := make([]byte, length)
+	copied := copy(payload, o.window[8:n])
+	for copied < len(payload) {
+		// Large values are read through the window too, so every read starts at an aligned address.
+		chunk := min(len(o.window), len(payload)-copied)
+		m, err := io.ReadAtLeast(o.rw, o.window[:chunk], 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read pre-image payload (length %d, read %d): %w", length, copied, err)
+		}
+		copied += copy(payload[copied:], o.window[:m])
+	}
+	return payload, nil
+}
+
 // OracleServer serves the pre-image requests of the OracleClient, implementing the same protocol as the onchain VM.
 type OracleServer struct {
 	rw io.ReadWriter
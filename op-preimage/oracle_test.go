@@ -3,6 +3,7 @@ package preimage
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"sync"
@@ -23,9 +24,20 @@ func bidirectionalPipe() (a, b io.ReadWriter) {
 }
 
 func TestOracle(t *testing.T) {
+	t.Run("direct", func(t *testing.T) {
+		testOracle(t, NewOracleClient)
+	})
+	t.Run("buffered", func(t *testing.T) {
+		testOracle(t, func(rw io.ReadWriter) *OracleClient {
+			return NewBufferedOracleClient(rw, 64)
+		})
+	})
+}
+
+func testOracle(t *testing.T, newClient func(rw io.ReadWriter) *OracleClient) {
 	testPreimage := func(preimages ...[]byte) {
 		a, b := bidirectionalPipe()
-		cl := NewOracleClient(a)
+		cl := newClient(a)
 		srv := NewOracleServer(b)
 
 		preimageByHash := make(map[[32]byte][]byte)
@@ -81,3 +93,46 @@ func TestOracle(t *testing.T) {
 		testPreimage(dat)
 	})
 }
+
+// countingReadWriter serves a fixed response, at most maxRead bytes per read.
+type countingReadWriter struct {
+	io.Writer
+	r       *bytes.Reader
+	maxRead int
+	reads   int
+}
+
+func (c *countingReadWriter) Read(p []byte) (int, error) {
+	c.reads++
+	return c.r.Read(p[:min(len(p), c.maxRead)])
+}
+
+func TestBufferedOracleReads(t *testing.T) {
+	response := func(value []byte) *bytes.Reader {
+		var buf bytes.Buffer
+		require.NoError(t, binary.Write(&buf, binary.BigEndian, uint64(len(value))))
+		buf.Write(value)
+		return bytes.NewReader(buf.Bytes())
+	}
+	key := Keccak256Key{0x01}
+	for _, size := range []int{0, 1, 100, DefaultReadWindow - 8, DefaultReadWindow, 3*DefaultReadWindow + 5} {
+		value := make([]byte, size)
+		_, _ = rand.Read(value)
+		t.Run(fmt.Sprintf("size-%d", size), func(t *testing.T) {
+			direct := &countingReadWriter{Writer: io.Discard, r: response(value), maxRead: DefaultReadWindow}
+			require.Equal(t, value, NewOracleClient(direct).Get(key))
+			buffered := &countingReadWriter{Writer: io.Discard, r: response(value), maxRead: DefaultReadWindow}
+			require.Equal(t, value, NewBufferedOracleClient(buffered, DefaultReadWindow).Get(key))
+			require.LessOrEqual(t, buffered.reads, direct.reads)
+			if size <= DefaultReadWindow-8 {
+				require.Equal(t, 1, buffered.reads, "length and value are read with a single read")
+			}
+		})
+	}
+	t.Run("word-sized reads", func(t *testing.T) {
+		value := make([]byte, 1001)
+		_, _ = rand.Read(value)
+		rw := &countingReadWriter{Writer: io.Discard, r: response(value), maxRead: 4}
+		require.Equal(t, value, NewBufferedOracleClient(rw, DefaultReadWindow).Get(key))
+	})
+}
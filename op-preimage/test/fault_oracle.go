@@ -0,0 +1,180 @@
+package test
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// FaultKind is the way a FaultOracle misbehaves when serving a pre-image.
+type FaultKind int
+
+const (
+	// FaultTruncate serves the pre-image without its last byte
+	FaultTruncate FaultKind = iota + 1
+	// FaultCorrupt serves the pre-image with the bits of its first byte flipped
+	FaultCorrupt
+	// FaultDelay serves the correct pre-image, after a delay
+	FaultDelay
+	// FaultWrongKey serves the pre-image of another key
+	FaultWrongKey
+)
+
+func (k FaultKind) String() string {
+	switch k {
+	case FaultTruncate:
+		return "truncate"
+	case FaultCorrupt:
+		return "corrupt"
+	case FaultDelay:
+		return "delay"
+	case FaultWrongKey:
+		return "wrong-key"
+	default:
+		return "unknown"
+	}
+}
+
+// Fault describes how to serve a pre-image.
+type Fault struct {
+	Kind FaultKind
+	// Delay is the time to wait before serving the pre-image, for FaultDelay
+	Delay time.Duration
+	// Substitute is the key of the pre-image that is served instead, for FaultWrongKey
+	Substitute [32]byte
+}
+
+func Truncate() Fault {
+	return Fault{Kind: FaultTruncate}
+}
+
+func Corrupt() Fault {
+	return Fault{Kind: FaultCorrupt}
+}
+
+func Delay(d time.Duration) Fault {
+	return Fault{Kind: FaultDelay, Delay: d}
+}
+
+func WrongKey(substitute [32]byte) Fault {
+	return Fault{Kind: FaultWrongKey, Substitute: substitute}
+}
+
+// InjectedFault is a fault that was applied to a pre-image request.
+type InjectedFault struct {
+	// Request is the index of the request, counting from 0
+	Request int
+	Key     [32]byte
+	Kind    FaultKind
+}
+
+// FaultOracle wraps a pre-image source, and serves faulty pre-images for the keys or requests that faults are injected for.
+// It implements both the client preimage.Oracle, and the Hint and GetPreimage methods of the VM pre-image oracle.
+type FaultOracle struct {
+	source func(key [32]byte) []byte
+	hint   func(v []byte)
+
+	mu        sync.Mutex
+	requests  int
+	byKey     map[[32]byte]Fault
+	byRequest map[int]Fault
+	injected  []InjectedFault
+}
+
+var _ preimage.Oracle = (*FaultOracle)(nil)
+
+// NewFaultOracle creates a FaultOracle that serves the pre-images of source.
+// Hints are passed to hint, or ignored if hint is nil.
+func NewFaultOracle(source func(key [32]byte) []byte, hint func(v []byte)) *FaultOracle {
+	if hint == nil {
+		hint = func(v []byte) {}
+	}
+	return &FaultOracle{
+		source:    source,
+		hint:      hint,
+		byKey:     make(map[[32]byte]Fault),
+		byRequest: make(map[int]Fault),
+	}
+}
+
+// InjectKey applies the fault to every request for the key.
+func (o *FaultOracle) InjectKey(key [32]byte, fault Fault) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.byKey[key] = fault
+}
+
+// InjectRequest applies the fault to the n-th request, counting from 0.
+// A fault for the request takes precedence over a fault for the requested key.
+func (o *FaultOracle) InjectRequest(n int, fault Fault) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.byRequest[n] = fault
+}
+
+// Injected returns the faults applied so far, in order of the requests.
+func (o *FaultOracle) Injected() []InjectedFault {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return slices.Clone(o.injected)
+}
+
+// Requests returns the number of pre-images requested so far.
+func (o *FaultOracle) Requests() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.requests
+}
+
+func (o *FaultOracle) Get(key preimage.Key) []byte {
+	return o.GetPreimage(key.PreimageKey())
+}
+
+func (o *FaultOracle) Hint(v []byte) {
+	o.hint(v)
+}
+
+func (o *FaultOracle) GetPreimage(key [32]byte) []byte {
+	fault, ok := o.next(key)
+	if !ok {
+		return o.source(key)
+	}
+	switch fault.Kind {
+	case FaultTruncate:
+		data := o.source(key)
+		return slices.Clone(data[:max(len(data)-1, 0)])
+	case FaultCorrupt:
+		data := slices.Clone(o.source(key))
+		if len(data) == 0 {
+			// Nothing to corrupt, so serve an extra byte instead
+			return []byte{0xff}
+		}
+		data[0] ^= 0xff
+		return data
+	case FaultDelay:
+		time.Sleep(fault.Delay)
+		return o.source(key)
+	case FaultWrongKey:
+		return o.source(fault.Substitute)
+	default:
+		panic("unknown fault kind")
+	}
+}
+
+// next counts the request for the key, and returns the fault to apply to it, if any.
+func (o *FaultOracle) next(key [32]byte) (Fault, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	n := o.requests
+	o.requests++
+	fault, ok := o.byRequest[n]
+	if !ok {
+		fault, ok = o.byKey[key]
+	}
+	if ok {
+		o.injected = append(o.injected, InjectedFault{Request: n, Key: key, Kind: fault.Kind})
+	}
+	return fault, ok
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+func TestFaultOracle(t *testing.T) {
+	dataA := []byte("hello world")
+	dataB := []byte("goodbye world")
+	keyA := preimage.Keccak256Key(preimage.Keccak256(dataA)).PreimageKey()
+	keyB := preimage.Keccak256Key(preimage.Keccak256(dataB)).PreimageKey()
+	source := func(key [32]byte) []byte {
+		switch key {
+		case keyA:
+			return dataA
+		case keyB:
+			return dataB
+		}
+		t.Fatalf("unknown key %x", key)
+		return nil
+	}
+
+	tests := []struct {
+		fault    Fault
+		expected []byte
+	}{
+		{Truncate(), []byte("hello worl")},
+		{Corrupt(), append([]byte{'h' ^ 0xff}, dataA[1:]...)},
+		{Delay(10 * time.Millisecond), dataA},
+		{WrongKey(keyB), dataB},
+	}
+	for _, test := range tests {
+		t.Run(test.fault.Kind.String(), func(t *testing.T) {
+			o := NewFaultOracle(source, nil)
+			o.InjectKey(keyA, test.fault)
+			start := time.Now()
+			data := o.Get(preimage.Keccak256Key(keyA))
+			require.Equal(t, test.expected, data)
+			require.GreaterOrEqual(t, time.Since(start), test.fault.Delay)
+			require.Equal(t, []byte("hello world"), dataA, "source data must not be modified")
+			require.Equal(t, []InjectedFault{{Request: 0, Key: keyA, Kind: test.fault.Kind}}, o.Injected())
+
+			// The verifying pre-image getter of the host rejects all data faults
+			getter := preimage.WithVerification(func(key [32]byte) ([]byte, error) {
+				return o.GetPreimage(key), nil
+			})
+			_, err := getter(keyA)
+			if test.fault.Kind == FaultDelay {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, preimage.ErrIncorrectData)
+			}
+
+			// Other keys are served as is
+			require.Equal(t, dataB, o.GetPreimage(keyB))
+			require.Len(t, o.Injected(), 2)
+			require.Equal(t, 3, o.Requests())
+		})
+	}
+}
+
+func TestFaultOracle_InjectRequest(t *testing.T) {
+	data := []byte("hello world")
+	key := preimage.Keccak256Key(preimage.Keccak256(data)).PreimageKey()
+	var hints []string
+	o := NewFaultOracle(func([32]byte) []byte { return data }, func(v []byte) {
+		hints = append(hints, string(v))
+	})
+	o.InjectKey(key, Corrupt())
+	o.InjectRequest(1, Truncate())
+
+	o.Hint([]byte("fetch"))
+	require.Equal(t, []string{"fetch"}, hints)
+	require.Len(t, o.GetPreimage(key), len(data))
+	require.Len(t, o.GetPreimage(key), len(data)-1, "request fault takes precedence")
+	require.Len(t, o.GetPreimage(key), len(data))
+	require.Equal(t, []InjectedFault{
+		{Request: 0, Key: key, Kind: FaultCorrupt},
+		{Request: 1, Key: key, Kind: FaultTruncate},
+		{Request: 2, Key: key, Kind: FaultCorrupt},
+	}, o.Injected())
+}
@@ -19,30 +19,38 @@ func WithVerification(source PreimageGetter) PreimageGetter {
 		if err != nil {
 			return nil, err
 		}
+		if err := Verify(key, data); err != nil {
+			return nil, err
+		}
+		return data, nil
+	}
+}
 
-		switch KeyType(key[0]) {
-		case LocalKeyType:
-			return data, nil
-		case Keccak256KeyType:
-			hash := Keccak256(data)
-			if !slices.Equal(hash[1:], key[1:]) {
-				return nil, fmt.Errorf("%w for key %v, hash: %v data: %x", ErrIncorrectData, key, hash, data)
-			}
-			return data, nil
-		case Sha256KeyType:
-			hash := sha256.Sum256(data)
-			if !slices.Equal(hash[1:], key[1:]) {
-				return nil, fmt.Errorf("%w for key %v, hash: %v data: %x", ErrIncorrectData, key, hash, data)
-			}
-			return data, nil
-		case BlobKeyType:
-			// Can't verify an individual field element without having a kzg proof
-			return data, nil
-		case PrecompileKeyType:
-			// Can't verify precompile result without knowing the input preimage
-			return data, nil
-		default:
-			return nil, fmt.Errorf("%w: %v", ErrUnsupportedKeyType, key[0])
+// Verify checks that the data is a valid pre-image for the key.
+// Local, blob and precompile pre-images can't be verified from the key alone, and are always accepted.
+func Verify(key [32]byte, data []byte) error {
+	switch KeyType(key[0]) {
+	case LocalKeyType:
+		return nil
+	case Keccak256KeyType:
+		hash := Keccak256(data)
+		if !slices.Equal(hash[1:], key[1:]) {
+			return fmt.Errorf("%w for key %v, hash: %v data: %x", ErrIncorrectData, key, hash, data)
+		}
+		return nil
+	case Sha256KeyType:
+		hash := sha256.Sum256(data)
+		if !slices.Equal(hash[1:], key[1:]) {
+			return fmt.Errorf("%w for key %v, hash: %v data: %x", ErrIncorrectData, key, hash, data)
 		}
+		return nil
+	case BlobKeyType:
+		// Can't verify an individual field element without having a kzg proof
+		return nil
+	case PrecompileKeyType:
+		// Can't verify precompile result without knowing the input preimage
+		return nil
+	default:
+		return fmt.Errorf("%w: %v", ErrUnsupportedKeyType, key[0])
 	}
 }
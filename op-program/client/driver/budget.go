@@ -0,0 +1,73 @@
+package driver
+
+import (
+	"errors"
+	"runtime"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+)
+
+var ErrStepBudgetExceeded = errors.New("derivation step budget exceeded")
+
+// StepBudget bounds the derivation by the number of processed events.
+// Steps do not depend on wall-clock time, so the same budget results in the same behavior
+// when the program runs natively and when it runs in the VM.
+type StepBudget struct {
+	// MaxSteps is the maximum number of events to process. Unlimited if 0.
+	MaxSteps uint64
+	// YieldInterval is the number of steps between explicit yields to other goroutines. Never yields if 0.
+	YieldInterval uint64
+}
+
+// DefaultStepBudget does not limit the number of steps, and yields at a fixed interval,
+// so other goroutines get to run during long derivations.
+// Yielding does not make garbage collection deterministic: collections are triggered by allocations.
+var DefaultStepBudget = StepBudget{YieldInterval: 1000}
+
+// StepStats counts the steps of the derivation. The counts only depend on the inputs of the program,
+// so they are comparable across native and VM runs.
+type StepStats struct {
+	// Steps is the total number of processed events.
+	Steps uint64
+	// PipelineSteps is the number of derivation pipeline steps.
+	PipelineSteps uint64
+	// DerivedAttributes is the number of derived payload attributes.
+	DerivedAttributes uint64
+	// BlocksProcessed is the number of blocks the engine started building from derived attributes.
+	BlocksProcessed uint64
+	// Yields is the number of explicit yields.
+	Yields uint64
+	// MaxQueued is the highest number of queued events.
+	MaxQueued uint64
+}
+
+func (s *StepStats) record(ev event.Event, queued int) {
+	s.Steps++
+	s.MaxQueued = max(s.MaxQueued, uint64(queued))
+	switch ev.(type) {
+	case derive.PipelineStepEvent:
+		s.PipelineSteps++
+	case derive.DerivedAttributesEvent:
+		s.DerivedAttributes++
+	case engine.BuildStartEvent:
+		s.BlocksProcessed++
+	}
+}
+
+// LogValues returns the stats as key-value pairs for logging.
+func (s StepStats) LogValues() []any {
+	return []any{
+		"steps", s.Steps,
+		"pipelineSteps", s.PipelineSteps,
+		"derivedAttributes", s.DerivedAttributes,
+		"blocksProcessed", s.BlocksProcessed,
+		"yields", s.Yields,
+		"maxQueued", s.MaxQueued,
+	}
+}
+
+func defaultYield() {
+	runtime.Gosched()
+}
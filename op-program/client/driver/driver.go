@@ -3,6 +3,7 @@ package driver
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/log"
 
@@ -27,6 +28,10 @@ type Driver struct {
 
 	end     EndCondition
 	deriver event.Deriver
+
+	budget StepBudget
+	yield  func()
+	stats  StepStats
 }
 
 func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher,
@@ -34,6 +39,8 @@ func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher,
 
 	d := &Driver{
 		logger: logger,
+		budget: DefaultStepBudget,
+		yield:  defaultYield,
 	}
 
 	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, l1BlobsSource, altda.Disabled, l2Source, metrics.NoopMetrics)
@@ -73,6 +80,16 @@ func (d *Driver) Emit(ev event.Event) {
 	d.events = append(d.events, ev)
 }
 
+// SetStepBudget replaces the step budget of the driver. It must be set before running the driver.
+func (d *Driver) SetStepBudget(budget StepBudget) {
+	d.budget = budget
+}
+
+// Stats returns the step counters of the derivation so far.
+func (d *Driver) Stats() StepStats {
+	return d.stats
+}
+
 var ExhaustErr = errors.New("exhausted events before completing program")
 
 func (d *Driver) RunComplete() error {
@@ -86,9 +103,17 @@ func (d *Driver) RunComplete() error {
 		if len(d.events) > 10000 { // sanity check, in case of bugs. Better than going OOM.
 			return errors.New("way too many events queued up, something is wrong")
 		}
+		if d.budget.MaxSteps > 0 && d.stats.Steps >= d.budget.MaxSteps {
+			return fmt.Errorf("%w: processed %d steps", ErrStepBudgetExceeded, d.stats.Steps)
+		}
 		ev := d.events[0]
+		d.stats.record(ev, len(d.events))
 		d.events = d.events[1:]
 		d.deriver.OnEvent(ev)
+		if d.budget.YieldInterval > 0 && d.stats.Steps%d.budget.YieldInterval == 0 && d.yield != nil {
+			d.stats.Yields++
+			d.yield()
+		}
 	}
 	return d.end.Result()
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 )
@@ -108,4 +109,35 @@ func TestDriver(t *testing.T) {
 		// add 1 for initial event that RunComplete fires
 		require.Equal(t, 1+3*2, count, "must have queued up 2 events 3 times")
 	})
+	t.Run("step budget", func(t *testing.T) {
+		d := newTestDriver(t, func(d *Driver, end *fakeEnd, ev event.Event) {
+			d.Emit(TestEvent{})
+		})
+		yields := 0
+		d.yield = func() { yields++ }
+		d.SetStepBudget(StepBudget{MaxSteps: 10, YieldInterval: 3})
+		require.ErrorIs(t, d.RunComplete(), ErrStepBudgetExceeded)
+		stats := d.Stats()
+		require.Equal(t, uint64(10), stats.Steps)
+		require.Equal(t, uint64(3), stats.Yields)
+		require.Equal(t, 3, yields)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		count := 0
+		d := newTestDriver(t, func(d *Driver, end *fakeEnd, ev event.Event) {
+			if count >= 4 {
+				end.closing = true
+				return
+			}
+			count += 1
+			d.Emit(derive.PipelineStepEvent{})
+			d.Emit(TestEvent{})
+		})
+		require.NoError(t, d.RunComplete())
+		stats := d.Stats()
+		require.Equal(t, uint64(5), stats.Steps)
+		require.Equal(t, uint64(2), stats.PipelineSteps)
+		require.Equal(t, uint64(5), stats.MaxQueued)
+	})
 }
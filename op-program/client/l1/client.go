@@ -23,18 +23,22 @@ type OracleL1Client struct {
 	oracle               Oracle
 	head                 eth.L1BlockRef
 	hashByNum            map[uint64]common.Hash
-	earliestIndexedBlock eth.L1BlockRef
+	earliestIndexedBlock eth.BlockInfo
 }
 
 func NewOracleL1Client(logger log.Logger, oracle Oracle, l1Head common.Hash) *OracleL1Client {
-	head := eth.InfoToL1BlockRef(oracle.HeaderByBlockHash(l1Head))
+	headInfo := oracle.HeaderByBlockHash(l1Head)
+	if headInfo.Hash() != l1Head {
+		panic(fmt.Errorf("%w: L1 head %s loaded as block %s", ErrInvalidHeaderChain, l1Head, headInfo.Hash()))
+	}
+	head := eth.InfoToL1BlockRef(headInfo)
 	logger.Info("L1 head loaded", "hash", head.Hash, "number", head.Number)
 	return &OracleL1Client{
 		logger:               logger,
 		oracle:               oracle,
 		head:                 head,
 		hashByNum:            map[uint64]common.Hash{head.Number: head.Hash},
-		earliestIndexedBlock: head,
+		earliestIndexedBlock: headInfo,
 	}
 }
 
@@ -55,13 +59,18 @@ func (o *OracleL1Client) L1BlockRefByNumber(ctx context.Context, number uint64)
 		return o.L1BlockRefByHash(ctx, hash)
 	}
 	block := o.earliestIndexedBlock
-	o.logger.Info("Extending block by number lookup", "from", block.Number, "to", number)
-	for block.Number > number {
-		block = eth.InfoToL1BlockRef(o.oracle.HeaderByBlockHash(block.ParentHash))
-		o.hashByNum[block.Number] = block.Hash
+	o.logger.Info("Extending block by number lookup", "from", block.NumberU64(), "to", number)
+	for block.NumberU64() > number {
+		parent := o.oracle.HeaderByBlockHash(block.ParentHash())
+		// The oracle is not trusted to serve a consistent chain, so verify each ancestor before indexing it
+		if err := VerifyParent(parent, block); err != nil {
+			panic(err)
+		}
+		block = parent
+		o.hashByNum[block.NumberU64()] = block.Hash()
 		o.earliestIndexedBlock = block
 	}
-	return block, nil
+	return eth.InfoToL1BlockRef(block), nil
 }
 
 func (o *OracleL1Client) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
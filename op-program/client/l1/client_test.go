@@ -2,6 +2,7 @@ package l1
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"testing"
 
@@ -159,6 +160,28 @@ func TestL1BlockRefByNumber(t *testing.T) {
 			require.Equal(t, eth.InfoToL1BlockRef(block), ref)
 		}
 	})
+	t.Run("InconsistentParent", func(t *testing.T) {
+		client, oracle := newClient(t)
+		// The oracle serves a header that is not the parent of the head
+		parent := blockNum(head.NumberU64() - 2)
+		oracle.Blocks[head.ParentHash()] = parent
+
+		require.PanicsWithError(t,
+			fmt.Sprintf("%v: block %s has parent %s, not %s", ErrInvalidHeaderChain, head.Hash(), head.ParentHash(), parent.Hash()),
+			func() {
+				_, _ = client.L1BlockRefByNumber(context.Background(), head.NumberU64()-1)
+			})
+	})
+}
+
+func TestNewOracleL1ClientRejectsInconsistentHead(t *testing.T) {
+	stub := test.NewStubOracle(t)
+	stub.Blocks[head.Hash()] = blockNum(head.NumberU64() - 1)
+	require.PanicsWithError(t,
+		fmt.Sprintf("%v: L1 head %s loaded as block %s", ErrInvalidHeaderChain, head.Hash(), blockNum(head.NumberU64()-1).Hash()),
+		func() {
+			NewOracleL1Client(testlog.Logger(t, log.LevelDebug), stub, head.Hash())
+		})
 }
 
 func newClient(t *testing.T) (*OracleL1Client, *test.StubOracle) {
@@ -168,18 +191,25 @@ func newClient(t *testing.T) (*OracleL1Client, *test.StubOracle) {
 	return client, stub
 }
 
-func blockNum(num uint64) eth.BlockInfo {
-	parentNum := num - 1
-	return &testutils.MockBlockInfo{
-		InfoHash:        common.BytesToHash(big.NewInt(int64(num)).Bytes()),
-		InfoParentHash:  common.BytesToHash(big.NewInt(int64(parentNum)).Bytes()),
-		InfoCoinbase:    common.Address{},
-		InfoRoot:        common.Hash{},
-		InfoNum:         num,
-		InfoTime:        num * 2,
-		InfoMixDigest:   [32]byte{},
-		InfoBaseFee:     nil,
-		InfoReceiptRoot: common.Hash{},
-		InfoGasUsed:     0,
+// chain is a valid header chain, so blocks pass the verification of the walk back by number.
+var chain = func() []*types.Header {
+	headers := make([]*types.Header, 0, 1001)
+	parentHash := common.Hash{}
+	for num := uint64(0); num <= 1000; num++ {
+		header := &types.Header{
+			ParentHash:  parentHash,
+			UncleHash:   types.EmptyUncleHash,
+			Number:      new(big.Int).SetUint64(num),
+			Time:        num * 2,
+			Difficulty:  common.Big0,
+			ReceiptHash: types.EmptyReceiptsHash,
+		}
+		headers = append(headers, header)
+		parentHash = header.Hash()
 	}
+	return headers
+}()
+
+func blockNum(num uint64) eth.BlockInfo {
+	return eth.HeaderBlockInfo(chain[num])
 }
@@ -55,6 +55,9 @@ func (p *PreimageOracle) headerByBlockHash(blockHash common.Hash) *types.Header
 	if err := rlp.DecodeBytes(headerRlp, &header); err != nil {
 		panic(fmt.Errorf("invalid block header %s: %w", blockHash, err))
 	}
+	if header.Hash() != blockHash {
+		panic(fmt.Errorf("%w: block header %s has hash %s", ErrInvalidHeaderChain, blockHash, header.Hash()))
+	}
 	return &header
 }
 
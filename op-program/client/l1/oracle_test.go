@@ -103,3 +103,21 @@ func TestPreimageOracleBlockByHash(t *testing.T) {
 		})
 	}
 }
+
+func TestPreimageOracleRejectsMismatchedHeader(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	block, _ := testutils.RandomBlock(rng, 1)
+	hdrBytes, err := rlp.EncodeToBytes(block.Header())
+	require.NoError(t, err)
+	po := NewPreimageOracle(
+		preimage.OracleFn(func(key preimage.Key) []byte {
+			// Serves the same header, regardless of the requested hash
+			return hdrBytes
+		}),
+		preimage.HinterFn(func(v preimage.Hint) {}))
+
+	require.Equal(t, block.Hash(), po.HeaderByBlockHash(block.Hash()).Hash())
+	require.PanicsWithError(t,
+		fmt.Sprintf("%v: block header %s has hash %s", ErrInvalidHeaderChain, common.Hash{0xaa}, block.Hash()),
+		func() { po.HeaderByBlockHash(common.Hash{0xaa}) })
+}
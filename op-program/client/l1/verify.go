@@ -0,0 +1,141 @@
+package l1
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrInvalidHeaderChain = errors.New("invalid L1 header chain")
+
+var (
+	// expDiffPeriod is the number of blocks per doubling of the ethash difficulty bomb.
+	expDiffPeriod = big.NewInt(100000)
+	// maxCliqueDifficulty is the difficulty of in-turn clique blocks. Out-of-turn blocks have a difficulty of 1.
+	maxCliqueDifficulty = big.NewInt(2)
+)
+
+// VerifyParent verifies that parent is the parent of child, as served by a potentially malicious oracle.
+// Both headers are decoded from their RLP and must match their claimed hash.
+//
+// The L1 chain config is not available to the client, so pre-merge difficulty is only checked to be within
+// the bounds of the Frontier, Homestead and Byzantium retarget rules, assuming the largest possible difficulty bomb.
+// That is exact enough to reject arbitrary difficulties on pre-merge dev chains.
+func VerifyParent(parent eth.BlockInfo, child eth.BlockInfo) error {
+	parentHeader, err := decodeHeader(parent)
+	if err != nil {
+		return err
+	}
+	childHeader, err := decodeHeader(child)
+	if err != nil {
+		return err
+	}
+	return verifyParentHeader(parentHeader, childHeader)
+}
+
+// decodeHeader decodes the header of the block info and verifies it matches the hash of the block info.
+func decodeHeader(info eth.BlockInfo) (*types.Header, error) {
+	headerRLP, err := info.HeaderRLP()
+	if err != nil {
+		return nil, fmt.Errorf("%w: header RLP of block %s unavailable: %w", ErrInvalidHeaderChain, info.Hash(), err)
+	}
+	var header types.Header
+	if err := rlp.DecodeBytes(headerRLP, &header); err != nil {
+		return nil, fmt.Errorf("%w: invalid header RLP of block %s: %w", ErrInvalidHeaderChain, info.Hash(), err)
+	}
+	if header.Hash() != info.Hash() {
+		return nil, fmt.Errorf("%w: header hash %s does not match block hash %s", ErrInvalidHeaderChain, header.Hash(), info.Hash())
+	}
+	return &header, nil
+}
+
+func verifyParentHeader(parent *types.Header, child *types.Header) error {
+	if child.ParentHash != parent.Hash() {
+		return fmt.Errorf("%w: block %s has parent %s, not %s", ErrInvalidHeaderChain, child.Hash(), child.ParentHash, parent.Hash())
+	}
+	if child.Number == nil || parent.Number == nil || child.Number.Cmp(new(big.Int).Add(parent.Number, common.Big1)) != 0 {
+		return fmt.Errorf("%w: block number %v does not follow parent block number %v", ErrInvalidHeaderChain, child.Number, parent.Number)
+	}
+	if child.Time <= parent.Time {
+		return fmt.Errorf("%w: block %v timestamp %d is not after parent timestamp %d", ErrInvalidHeaderChain, child.Number, child.Time, parent.Time)
+	}
+	if err := verifyDifficulty(parent, child); err != nil {
+		return fmt.Errorf("%w: block %v: %w", ErrInvalidHeaderChain, child.Number, err)
+	}
+	return nil
+}
+
+// verifyDifficulty verifies the difficulty of the child is a valid retarget of the difficulty of the parent.
+func verifyDifficulty(parent *types.Header, child *types.Header) error {
+	parentDiff, childDiff := difficulty(parent), difficulty(child)
+	switch {
+	case childDiff.Sign() == 0:
+		// Post-merge, or the merge transition block
+		return nil
+	case parentDiff.Sign() == 0:
+		return fmt.Errorf("pre-merge difficulty %v after post-merge parent", childDiff)
+	case parentDiff.Cmp(maxCliqueDifficulty) <= 0:
+		// Clique chains only alternate between the in-turn and out-of-turn difficulty
+		if childDiff.Cmp(maxCliqueDifficulty) > 0 {
+			return fmt.Errorf("difficulty %v after clique parent difficulty %v", childDiff, parentDiff)
+		}
+		return nil
+	}
+	lower, upper := ethashDifficultyBounds(parent, child)
+	if childDiff.Cmp(lower) < 0 || childDiff.Cmp(upper) > 0 {
+		return fmt.Errorf("difficulty %v outside of retarget bounds [%v, %v]", childDiff, lower, upper)
+	}
+	return nil
+}
+
+// ethashDifficultyBounds returns the minimum and maximum ethash difficulty of the child,
+// across the Frontier, Homestead and Byzantium difficulty adjustments.
+func ethashDifficultyBounds(parent *types.Header, child *types.Header) (*big.Int, *big.Int) {
+	elapsed := int64(child.Time - parent.Time)
+	// Frontier adjusts by a single step, depending on the block time
+	frontier := int64(-1)
+	if elapsed < params.DurationLimit.Int64() {
+		frontier = 1
+	}
+	homestead := max(1-elapsed/10, -99)
+	byzantiumBase := int64(1)
+	if parent.UncleHash != types.EmptyUncleHash {
+		byzantiumBase = 2
+	}
+	byzantium := max(byzantiumBase-elapsed/9, -99)
+
+	step := new(big.Int).Div(difficulty(parent), params.DifficultyBoundDivisor)
+	lower := adjustDifficulty(difficulty(parent), step, min(frontier, homestead, byzantium))
+	upper := adjustDifficulty(difficulty(parent), step, max(frontier, homestead, byzantium))
+
+	// The bomb is largest without any bomb delay. Delays only reduce the bomb, so can't be checked without the chain config.
+	periodCount := new(big.Int).Div(child.Number, expDiffPeriod)
+	if periodCount.Cmp(common.Big1) > 0 {
+		bomb := new(big.Int).Exp(common.Big2, periodCount.Sub(periodCount, common.Big2), nil)
+		upper.Add(upper, bomb)
+	}
+	return lower, upper
+}
+
+func adjustDifficulty(parentDiff *big.Int, step *big.Int, factor int64) *big.Int {
+	diff := new(big.Int).Mul(step, big.NewInt(factor))
+	diff.Add(diff, parentDiff)
+	if diff.Cmp(params.MinimumDifficulty) < 0 {
+		diff.Set(params.MinimumDifficulty)
+	}
+	return diff
+}
+
+func difficulty(header *types.Header) *big.Int {
+	if header.Difficulty == nil {
+		return common.Big0
+	}
+	return header.Difficulty
+}
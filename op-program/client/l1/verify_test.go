@@ -0,0 +1,192 @@
+package l1
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func newHeader(parent *types.Header, elapsed uint64, difficulty *big.Int) *types.Header {
+	return &types.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  types.EmptyUncleHash,
+		Number:     new(big.Int).Add(parent.Number, common.Big1),
+		Time:       parent.Time + elapsed,
+		Difficulty: difficulty,
+	}
+}
+
+func TestVerifyParent(t *testing.T) {
+	genesis := &types.Header{
+		UncleHash:  types.EmptyUncleHash,
+		Number:     big.NewInt(5_000_000),
+		Time:       1_000_000,
+		Difficulty: common.Big0,
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		child := newHeader(genesis, 12, common.Big0)
+		require.NoError(t, VerifyParent(eth.HeaderBlockInfo(genesis), eth.HeaderBlockInfo(child)))
+	})
+
+	tests := []struct {
+		name   string
+		modify func(child *types.Header)
+		err    string
+	}{
+		{
+			name:   "WrongParentHash",
+			modify: func(child *types.Header) { child.ParentHash = common.Hash{0xaa} },
+			err:    "has parent",
+		},
+		{
+			name:   "SkippedNumber",
+			modify: func(child *types.Header) { child.Number = big.NewInt(5_000_002) },
+			err:    "block number 5000002 does not follow parent block number 5000000",
+		},
+		{
+			name:   "SameNumber",
+			modify: func(child *types.Header) { child.Number = big.NewInt(5_000_000) },
+			err:    "block number 5000000 does not follow parent block number 5000000",
+		},
+		{
+			name:   "SameTimestamp",
+			modify: func(child *types.Header) { child.Time = genesis.Time },
+			err:    "timestamp 1000000 is not after parent timestamp 1000000",
+		},
+		{
+			name:   "EarlierTimestamp",
+			modify: func(child *types.Header) { child.Time = genesis.Time - 1 },
+			err:    "timestamp 999999 is not after parent timestamp 1000000",
+		},
+		{
+			name:   "DifficultyAfterMerge",
+			modify: func(child *types.Header) { child.Difficulty = big.NewInt(131072) },
+			err:    "pre-merge difficulty 131072 after post-merge parent",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			child := newHeader(genesis, 12, common.Big0)
+			test.modify(child)
+			err := VerifyParent(eth.HeaderBlockInfo(genesis), eth.HeaderBlockInfo(child))
+			require.ErrorIs(t, err, ErrInvalidHeaderChain)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+
+	t.Run("MismatchedHash", func(t *testing.T) {
+		child := newHeader(genesis, 12, common.Big0)
+		headerRLP, err := eth.HeaderBlockInfo(child).HeaderRLP()
+		require.NoError(t, err)
+		info := &testutils.MockBlockInfo{InfoHash: common.Hash{0xbb}, InfoHeaderRLP: headerRLP}
+		err = VerifyParent(eth.HeaderBlockInfo(genesis), info)
+		require.ErrorIs(t, err, ErrInvalidHeaderChain)
+		require.ErrorContains(t, err, fmt.Sprintf("header hash %s does not match block hash %s", child.Hash(), common.Hash{0xbb}))
+	})
+
+	t.Run("MissingHeaderRLP", func(t *testing.T) {
+		err := VerifyParent(eth.HeaderBlockInfo(genesis), &testutils.MockBlockInfo{InfoHash: common.Hash{0xbb}})
+		require.ErrorIs(t, err, ErrInvalidHeaderChain)
+		require.ErrorContains(t, err, "header RLP")
+	})
+}
+
+func TestVerifyDifficulty(t *testing.T) {
+	parent := &types.Header{
+		UncleHash:  types.EmptyUncleHash,
+		Number:     big.NewInt(1000),
+		Time:       1_000_000,
+		Difficulty: big.NewInt(2048 * 1000),
+	}
+
+	tests := []struct {
+		name       string
+		parentDiff *big.Int
+		elapsed    uint64
+		difficulty *big.Int
+		valid      bool
+	}{
+		{name: "MergeTransition", elapsed: 12, difficulty: common.Big0, valid: true},
+		{name: "CliqueInTurn", parentDiff: common.Big1, elapsed: 5, difficulty: common.Big2, valid: true},
+		{name: "CliqueOutOfTurn", parentDiff: common.Big2, elapsed: 5, difficulty: common.Big1, valid: true},
+		{name: "CliqueExceeded", parentDiff: common.Big2, elapsed: 5, difficulty: big.NewInt(3), valid: false},
+		// Fast blocks increase the difficulty by at most one step without uncles
+		{name: "MaxIncrease", elapsed: 1, difficulty: big.NewInt(2048*1000 + 1000), valid: true},
+		{name: "ExcessiveIncrease", elapsed: 1, difficulty: big.NewInt(2048*1000 + 1001), valid: false},
+		// Frontier decreases the difficulty after 13 seconds, where Byzantium doesn't adjust the difficulty yet
+		{name: "FrontierDecrease", elapsed: 13, difficulty: big.NewInt(2048*1000 - 1000), valid: true},
+		{name: "ExcessiveDecrease", elapsed: 13, difficulty: big.NewInt(2048*1000 - 1001), valid: false},
+		// Slow blocks decrease the difficulty by at most 99 steps
+		{name: "MaxDecrease", elapsed: 10_000, difficulty: big.NewInt(2048*1000 - 99*1000), valid: true},
+		{name: "BelowMaxDecrease", elapsed: 10_000, difficulty: big.NewInt(2048*1000 - 99*1000 - 1), valid: false},
+		// The difficulty never decreases below the minimum difficulty
+		{name: "MinimumDifficulty", parentDiff: big.NewInt(131072), elapsed: 10_000, difficulty: big.NewInt(131072), valid: true},
+		{name: "BelowMinimumDifficulty", parentDiff: big.NewInt(131072), elapsed: 10_000, difficulty: big.NewInt(131071), valid: false},
+		{name: "SlowBlocksIncrease", elapsed: 10_000, difficulty: big.NewInt(2048 * 1001), valid: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			parent := types.CopyHeader(parent)
+			if test.parentDiff != nil {
+				parent.Difficulty = test.parentDiff
+			}
+			child := newHeader(parent, test.elapsed, test.difficulty)
+			err := VerifyParent(eth.HeaderBlockInfo(parent), eth.HeaderBlockInfo(child))
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrInvalidHeaderChain)
+				require.ErrorContains(t, err, "difficulty")
+			}
+		})
+	}
+}
+
+// TestVerifyDifficultyMatchesEthash checks that the difficulty computed by ethash is accepted for each fork,
+// including the difficulty bomb and blocks with uncles.
+func TestVerifyDifficultyMatchesEthash(t *testing.T) {
+	configs := map[string]*params.ChainConfig{
+		"Frontier":       {ChainID: common.Big1},
+		"Homestead":      {ChainID: common.Big1, HomesteadBlock: common.Big0},
+		"Byzantium":      {ChainID: common.Big1, HomesteadBlock: common.Big0, ByzantiumBlock: common.Big0},
+		"MuirGlacier":    {ChainID: common.Big1, HomesteadBlock: common.Big0, ByzantiumBlock: common.Big0, ConstantinopleBlock: common.Big0, MuirGlacierBlock: common.Big0},
+		"GrayGlacier":    {ChainID: common.Big1, HomesteadBlock: common.Big0, ByzantiumBlock: common.Big0, ConstantinopleBlock: common.Big0, MuirGlacierBlock: common.Big0, LondonBlock: common.Big0, ArrowGlacierBlock: common.Big0, GrayGlacierBlock: common.Big0},
+		"NoBombDelayDev": {ChainID: big.NewInt(1337), HomesteadBlock: common.Big0, ByzantiumBlock: common.Big0},
+	}
+	for name, config := range configs {
+		config := config
+		t.Run(name, func(t *testing.T) {
+			for _, number := range []int64{1, 500_000, 5_000_000, 15_000_000} {
+				for _, uncles := range []bool{false, true} {
+					for _, elapsed := range []uint64{1, 9, 12, 13, 20, 100, 10_000} {
+						parent := &types.Header{
+							UncleHash:  types.EmptyUncleHash,
+							Number:     big.NewInt(number),
+							Time:       1_000_000,
+							Difficulty: big.NewInt(10_000_000_000),
+						}
+						if uncles {
+							parent.UncleHash = common.Hash{0xcc}
+						}
+						child := newHeader(parent, elapsed, ethash.CalcDifficulty(config, parent.Time+elapsed, parent))
+						require.NoError(t, verifyParentHeader(parent, child),
+							"number %d, uncles %v, elapsed %d", number, uncles, elapsed)
+					}
+				}
+			}
+		})
+	}
+}
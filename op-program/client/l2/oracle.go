@@ -1,6 +1,7 @@
 package l2
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -12,6 +13,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
+// ErrInvalidPreimage is the error the oracle panics with when the data served for a hash doesn't match the hash.
+var ErrInvalidPreimage = errors.New("invalid pre-image")
+
 // StateOracle defines the high-level API used to retrieve L2 state data pre-images
 // The returned data is always the preimage of the requested hash.
 type StateOracle interface {
@@ -59,6 +63,9 @@ func (p *PreimageOracle) headerByBlockHash(blockHash common.Hash) *types.Header
 	if err := rlp.DecodeBytes(headerRlp, &header); err != nil {
 		panic(fmt.Errorf("invalid block header %s: %w", blockHash, err))
 	}
+	if header.Hash() != blockHash {
+		panic(fmt.Errorf("%w: block header %s has hash %s", ErrInvalidPreimage, blockHash, header.Hash()))
+	}
 	return &header
 }
 
@@ -100,5 +107,8 @@ func (p *PreimageOracle) OutputByRoot(l2OutputRoot common.Hash) eth.Output {
 	if err != nil {
 		panic(fmt.Errorf("invalid L2 output data for root %s: %w", l2OutputRoot, err))
 	}
+	if root := common.Hash(eth.OutputRoot(output)); root != l2OutputRoot {
+		panic(fmt.Errorf("%w: L2 output %s has root %s", ErrInvalidPreimage, l2OutputRoot, root))
+	}
 	return output
 }
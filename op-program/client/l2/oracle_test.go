@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -14,6 +15,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	preimagetest "github.com/ethereum-optimism/optimism/op-preimage/test"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
@@ -140,3 +142,70 @@ func TestPreimageOracleOutputByRoot(t *testing.T) {
 		})
 	}
 }
+
+func TestPreimageOracleFaults(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	block, _ := testutils.RandomBlock(rng, 1)
+	hdrBytes, err := rlp.EncodeToBytes(block.Header())
+	require.NoError(t, err)
+	output := testutils.RandomOutputV0(rng)
+	outputRoot := common.Hash(eth.OutputRoot(output))
+	headerKey := preimage.Keccak256Key(block.Hash()).PreimageKey()
+	outputKey := preimage.Keccak256Key(outputRoot).PreimageKey()
+	preimages := map[[32]byte][]byte{
+		headerKey: hdrBytes,
+		outputKey: output.Marshal(),
+	}
+	loadHeader := func(po *PreimageOracle) { po.headerByBlockHash(block.Hash()) }
+	loadOutput := func(po *PreimageOracle) { po.OutputByRoot(outputRoot) }
+
+	tests := []struct {
+		name  string
+		key   [32]byte
+		fault preimagetest.Fault
+		load  func(po *PreimageOracle)
+	}{
+		{"TruncatedHeader", headerKey, preimagetest.Truncate(), loadHeader},
+		{"CorruptedHeader", headerKey, preimagetest.Corrupt(), loadHeader},
+		{"WrongKeyHeader", headerKey, preimagetest.WrongKey(outputKey), loadHeader},
+		{"TruncatedOutput", outputKey, preimagetest.Truncate(), loadOutput},
+		{"CorruptedOutput", outputKey, preimagetest.Corrupt(), loadOutput},
+		{"WrongKeyOutput", outputKey, preimagetest.WrongKey(headerKey), loadOutput},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Faults are detected the same way every time, instead of producing diverging data
+			var errs []string
+			for i := 0; i < 2; i++ {
+				faults := preimagetest.NewFaultOracle(func(key [32]byte) []byte { return preimages[key] }, nil)
+				faults.InjectKey(test.key, test.fault)
+				po := NewPreimageOracle(faults, preimage.HinterFn(func(v preimage.Hint) {}))
+				errs = append(errs, requirePanicErr(t, func() { test.load(po) }).Error())
+				require.Len(t, faults.Injected(), 1)
+			}
+			require.Equal(t, errs[0], errs[1])
+		})
+	}
+
+	t.Run("Delayed", func(t *testing.T) {
+		faults := preimagetest.NewFaultOracle(func(key [32]byte) []byte { return preimages[key] }, nil)
+		faults.InjectKey(headerKey, preimagetest.Delay(10*time.Millisecond))
+		faults.InjectKey(outputKey, preimagetest.Delay(10*time.Millisecond))
+		po := NewPreimageOracle(faults, preimage.HinterFn(func(v preimage.Hint) {}))
+		require.Equal(t, block.Hash(), po.headerByBlockHash(block.Hash()).Hash())
+		require.Equal(t, output, po.OutputByRoot(outputRoot))
+		require.Len(t, faults.Injected(), 2)
+	})
+}
+
+func requirePanicErr(t *testing.T, fn func()) (err error) {
+	defer func() {
+		r := recover()
+		require.NotNil(t, r, "expected a panic")
+		var ok bool
+		err, ok = r.(error)
+		require.True(t, ok, "expected to panic with an error, got %v", r)
+	}()
+	fn()
+	return nil
+}
@@ -0,0 +1,128 @@
+package l2
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l2/engineapi"
+)
+
+// HeaderByHash retrieves the header of an ancestor block, for the BLOCKHASH opcode.
+// Returns nil if the header is unknown.
+type HeaderByHash func(hash common.Hash) *types.Header
+
+// ExecuteStateless executes the block on top of its parent, retrieving the parent state from the oracle only,
+// and checks that the execution results in the same block.
+// Returns an error wrapping ErrMissingWitnessData if the oracle is a WitnessOracle that lacks required state.
+func ExecuteStateless(chainCfg *params.ChainConfig, oracle StateOracle, parent *types.Header, block *types.Block, headers HeaderByHash) (err error) {
+	if block.ParentHash() != parent.Hash() {
+		return fmt.Errorf("block %v does not build on parent %v", block.Hash(), parent.Hash())
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if rErr, ok := r.(error); ok && errors.Is(rErr, ErrMissingWitnessData) {
+				err = rErr
+				return
+			}
+			panic(r)
+		}
+	}()
+	chain := &statelessChain{
+		chainCfg: chainCfg,
+		engine:   beacon.New(nil),
+		db:       NewOracleBackedDB(oracle),
+		parent:   parent,
+		headers:  headers,
+	}
+	processor, err := engineapi.NewBlockProcessorFromHeader(chain, block.Header())
+	if err != nil {
+		return err
+	}
+	for i, tx := range block.Transactions() {
+		if err := processor.AddTx(tx); err != nil {
+			return fmt.Errorf("invalid transaction (%d): %w", i, err)
+		}
+	}
+	expected, err := processor.Assemble()
+	if err != nil {
+		return fmt.Errorf("invalid block: %w", err)
+	}
+	if expected.Hash() != block.Hash() {
+		return fmt.Errorf("block hash mismatch, expected: %v (state root %v), actual: %v (state root %v)",
+			expected.Hash(), expected.Root(), block.Hash(), block.Root())
+	}
+	return nil
+}
+
+// statelessChain provides the data to execute a single block on top of its parent.
+type statelessChain struct {
+	chainCfg *params.ChainConfig
+	engine   consensus.Engine
+	db       *OracleKeyValueStore
+	vmCfg    vm.Config
+	parent   *types.Header
+	headers  HeaderByHash
+}
+
+var _ engineapi.BlockDataProvider = (*statelessChain)(nil)
+
+func (s *statelessChain) StateAt(root common.Hash) (*state.StateDB, error) {
+	return state.New(root, state.NewDatabase(rawdb.NewDatabase(s.db)), nil)
+}
+
+func (s *statelessChain) Engine() consensus.Engine {
+	return s.engine
+}
+
+func (s *statelessChain) GetVMConfig() *vm.Config {
+	return &s.vmCfg
+}
+
+func (s *statelessChain) Config() *params.ChainConfig {
+	return s.chainCfg
+}
+
+func (s *statelessChain) CurrentHeader() *types.Header {
+	return s.parent
+}
+
+func (s *statelessChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	header := s.GetHeaderByHash(hash)
+	if header == nil || header.Number.Uint64() != number {
+		return nil
+	}
+	return header
+}
+
+func (s *statelessChain) GetHeaderByNumber(number uint64) *types.Header {
+	header := s.parent
+	for header != nil && header.Number.Uint64() > number {
+		header = s.GetHeaderByHash(header.ParentHash)
+	}
+	if header == nil || header.Number.Uint64() != number {
+		return nil
+	}
+	return header
+}
+
+func (s *statelessChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	if hash == s.parent.Hash() {
+		return s.parent
+	}
+	return s.headers(hash)
+}
+
+func (s *statelessChain) GetTd(hash common.Hash, number uint64) *big.Int {
+	// Difficulty is always 0 post-merge and bedrock starts post-merge so total difficulty also always 0
+	return common.Big0
+}
@@ -0,0 +1,61 @@
+package l2
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteStateless(t *testing.T) {
+	_, chain := setupOracleBackedChain(t, 1)
+	parent := chain.CurrentHeader()
+	block := createBlock(t, chain)
+	headers := func(hash common.Hash) *types.Header {
+		return chain.GetHeaderByHash(hash)
+	}
+
+	// Record the witness while executing with the full oracle
+	recorder := NewWitnessRecorder(chain.oracle)
+	require.NoError(t, ExecuteStateless(chain.Config(), recorder, parent, block, headers))
+	witness := recorder.Witness()
+	require.NotEmpty(t, witness.State)
+
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, ExecuteStateless(chain.Config(), NewWitnessOracle(witness), parent, block, headers))
+	})
+
+	t.Run("MissingState", func(t *testing.T) {
+		for hash := range witness.State {
+			incomplete := recorder.Witness()
+			delete(incomplete.State, hash)
+			err := ExecuteStateless(chain.Config(), NewWitnessOracle(incomplete), parent, block, headers)
+			require.ErrorIs(t, err, ErrMissingWitnessData)
+		}
+	})
+
+	t.Run("IgnoreMismatchedKeys", func(t *testing.T) {
+		incorrect := recorder.Witness()
+		for hash, node := range incorrect.State {
+			delete(incorrect.State, hash)
+			incorrect.State[common.Hash{0xaa}] = node
+			break
+		}
+		require.NoError(t, ExecuteStateless(chain.Config(), NewWitnessOracle(incorrect), parent, block, headers))
+	})
+
+	t.Run("StateRootMismatch", func(t *testing.T) {
+		header := block.Header()
+		header.Root = common.Hash{0xbb}
+		invalid := block.WithSeal(header)
+		err := ExecuteStateless(chain.Config(), NewWitnessOracle(witness), parent, invalid, headers)
+		require.ErrorContains(t, err, "block hash mismatch")
+	})
+
+	t.Run("WrongParent", func(t *testing.T) {
+		grandparent := chain.GetHeaderByHash(parent.ParentHash)
+		err := ExecuteStateless(chain.Config(), NewWitnessOracle(witness), grandparent, block, headers)
+		require.ErrorContains(t, err, "does not build on parent")
+	})
+}
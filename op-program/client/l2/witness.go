@@ -0,0 +1,112 @@
+package l2
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrMissingWitnessData = errors.New("missing from execution witness")
+
+// WitnessOracle implements StateOracle using only the state in an execution witness.
+// Requesting state that isn't in the witness panics with ErrMissingWitnessData, as the oracle would.
+type WitnessOracle struct {
+	nodes map[common.Hash][]byte
+	codes map[common.Hash][]byte
+}
+
+var _ StateOracle = (*WitnessOracle)(nil)
+
+// NewWitnessOracle creates a WitnessOracle for the witness.
+// The witness data is indexed by its hash, so keys that don't match their data are ignored.
+func NewWitnessOracle(witness *eth.ExecutionWitness) *WitnessOracle {
+	return &WitnessOracle{
+		nodes: indexByHash(witness.State),
+		codes: indexByHash(witness.Codes),
+	}
+}
+
+func indexByHash(data map[common.Hash]hexutil.Bytes) map[common.Hash][]byte {
+	indexed := make(map[common.Hash][]byte, len(data))
+	for _, value := range data {
+		indexed[crypto.Keccak256Hash(value)] = value
+	}
+	return indexed
+}
+
+func (w *WitnessOracle) NodeByHash(nodeHash common.Hash) []byte {
+	node, ok := w.nodes[nodeHash]
+	if !ok {
+		panic(fmt.Errorf("state node %s %w", nodeHash, ErrMissingWitnessData))
+	}
+	return node
+}
+
+func (w *WitnessOracle) CodeByHash(codeHash common.Hash) []byte {
+	code, ok := w.codes[codeHash]
+	if !ok {
+		panic(fmt.Errorf("code %s %w", codeHash, ErrMissingWitnessData))
+	}
+	return code
+}
+
+// WitnessRecorder implements StateOracle by retrieving state from another StateOracle,
+// and records the state retrieved to build an execution witness.
+type WitnessRecorder struct {
+	oracle StateOracle
+
+	mu    sync.Mutex
+	nodes map[common.Hash]hexutil.Bytes
+	codes map[common.Hash]hexutil.Bytes
+}
+
+var _ StateOracle = (*WitnessRecorder)(nil)
+
+func NewWitnessRecorder(oracle StateOracle) *WitnessRecorder {
+	return &WitnessRecorder{
+		oracle: oracle,
+		nodes:  make(map[common.Hash]hexutil.Bytes),
+		codes:  make(map[common.Hash]hexutil.Bytes),
+	}
+}
+
+func (w *WitnessRecorder) NodeByHash(nodeHash common.Hash) []byte {
+	node := w.oracle.NodeByHash(nodeHash)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.nodes[nodeHash] = node
+	return node
+}
+
+func (w *WitnessRecorder) CodeByHash(codeHash common.Hash) []byte {
+	code := w.oracle.CodeByHash(codeHash)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.codes[codeHash] = code
+	return code
+}
+
+// Witness returns the execution witness of the state retrieved so far.
+// Keys are not recorded, as they are not required to execute blocks.
+func (w *WitnessRecorder) Witness() *eth.ExecutionWitness {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	witness := &eth.ExecutionWitness{
+		Keys:  make(map[common.Hash]hexutil.Bytes),
+		Codes: make(map[common.Hash]hexutil.Bytes, len(w.codes)),
+		State: make(map[common.Hash]hexutil.Bytes, len(w.nodes)),
+	}
+	for hash, code := range w.codes {
+		witness.Codes[hash] = code
+	}
+	for hash, node := range w.nodes {
+		witness.State[hash] = node
+	}
+	return witness
+}
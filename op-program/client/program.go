@@ -25,21 +25,41 @@ func Main(logger log.Logger) {
 	log.Info("Starting fault proof program client")
 	preimageOracle := CreatePreimageChannel()
 	preimageHinter := CreateHinterChannel()
-	if err := RunProgram(logger, preimageOracle, preimageHinter); errors.Is(err, claim.ErrClaimNotValid) {
-		log.Error("Claim is invalid", "err", err)
+	// The budget can't be configured for the program in the VM: the boot info only holds the inputs of the
+	// dispute game, so the default budget is used.
+	result, err := RunProgram(logger, preimageOracle, preimageHinter, cldr.DefaultStepBudget)
+	if errors.Is(err, claim.ErrClaimNotValid) {
+		log.Error("Claim is invalid", append(result.LogValues(), "err", err)...)
 		os.Exit(1)
 	} else if err != nil {
 		log.Error("Program failed", "err", err)
 		os.Exit(2)
 	} else {
-		log.Info("Claim successfully verified")
+		log.Info("Claim successfully verified", result.LogValues()...)
 		os.Exit(0)
 	}
 }
 
+// Result is the outcome of a program run: the claim, and the step counters of the derivation that checked it.
+type Result struct {
+	L2Claim            common.Hash
+	L2ClaimBlockNumber uint64
+	Stats              cldr.StepStats
+}
+
+// LogValues returns the result as key-value pairs for logging.
+func (r *Result) LogValues() []any {
+	if r == nil {
+		return nil
+	}
+	return append([]any{"claim", r.L2Claim, "blockNumber", r.L2ClaimBlockNumber}, r.Stats.LogValues()...)
+}
+
 // RunProgram executes the Program, while attached to an IO based pre-image oracle, to be served by a host.
-func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter io.ReadWriter) error {
-	pClient := preimage.NewOracleClient(preimageOracle)
+// The derivation is bounded by the given step budget.
+// The result is returned whenever the derivation ran, including when the claim is invalid.
+func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter io.ReadWriter, budget cldr.StepBudget) (*Result, error) {
+	pClient := preimage.NewBufferedOracleClient(preimageOracle, preimage.DefaultReadWindow)
 	hClient := preimage.NewHintWriter(preimageHinter)
 	l1PreimageOracle := l1.NewCachingOracle(l1.NewPreimageOracle(pClient, hClient))
 	l2PreimageOracle := l2.NewCachingOracle(l2.NewPreimageOracle(pClient, hClient))
@@ -56,25 +76,29 @@ func RunProgram(logger log.Logger, preimageOracle io.ReadWriter, preimageHinter
 		bootInfo.L2ClaimBlockNumber,
 		l1PreimageOracle,
 		l2PreimageOracle,
+		budget,
 	)
 }
 
 // runDerivation executes the L2 state transition, given a minimal interface to retrieve data.
-func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle) error {
+func runDerivation(logger log.Logger, cfg *rollup.Config, l2Cfg *params.ChainConfig, l1Head common.Hash, l2OutputRoot common.Hash, l2Claim common.Hash, l2ClaimBlockNum uint64, l1Oracle l1.Oracle, l2Oracle l2.Oracle, budget cldr.StepBudget) (*Result, error) {
 	l1Source := l1.NewOracleL1Client(logger, l1Oracle, l1Head)
 	l1BlobsSource := l1.NewBlobFetcher(logger, l1Oracle)
 	engineBackend, err := l2.NewOracleBackedL2Chain(logger, l2Oracle, l1Oracle /* kzg oracle */, l2Cfg, l2OutputRoot)
 	if err != nil {
-		return fmt.Errorf("failed to create oracle-backed L2 chain: %w", err)
+		return nil, fmt.Errorf("failed to create oracle-backed L2 chain: %w", err)
 	}
 	l2Source := l2.NewOracleEngine(cfg, logger, engineBackend)
 
 	logger.Info("Starting derivation")
 	d := cldr.NewDriver(logger, cfg, l1Source, l1BlobsSource, l2Source, l2ClaimBlockNum)
-	if err := d.RunComplete(); err != nil {
-		return fmt.Errorf("failed to run program to completion: %w", err)
+	d.SetStepBudget(budget)
+	err = d.RunComplete()
+	result := &Result{L2Claim: l2Claim, L2ClaimBlockNumber: l2ClaimBlockNum, Stats: d.Stats()}
+	if err != nil {
+		return result, fmt.Errorf("failed to run program to completion: %w", err)
 	}
-	return claim.ValidateClaim(logger, l2ClaimBlockNum, eth.Bytes32(l2Claim), l2Source)
+	return result, claim.ValidateClaim(logger, l2ClaimBlockNum, eth.Bytes32(l2Claim), l2Source)
 }
 
 func CreateHinterChannel() preimage.FileChannel {
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-program/host"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+)
+
+type ConfigAction func(log log.Logger, config *config.Config) error
+
+type StatelessAction func(log log.Logger, config *config.StatelessConfig) error
+
+// VerifyStateless is the default StatelessAction, verifying the blocks with the host.
+func VerifyStateless(logger log.Logger, cfg *config.StatelessConfig) error {
+	return host.VerifyStateless(context.Background(), logger, cfg)
+}
+
+// New creates the op-program app with the given version.
+// The app parses its args to create a config.Config instance, sets up logging then calls the supplied ConfigAction.
+// The verify-stateless command instead creates a config.StatelessConfig and calls the supplied StatelessAction.
+func New(version string, action ConfigAction, statelessAction StatelessAction) *cli.App {
+	app := cli.NewApp()
+	app.Version = version
+	app.Flags = flags.Flags
+	app.Name = "op-program"
+	app.Usage = "Optimism Fault Proof Program"
+	app.Description = "The Optimism Fault Proof Program fault proof program that runs through the rollup state-transition to verify an L2 output from L1 inputs."
+	app.Action = func(ctx *cli.Context) error {
+		logger, err := setupLogging(ctx)
+		if err != nil {
+			return err
+		}
+		logger.Info("Starting fault proof program", "version", version)
+
+		cfg, err := config.NewConfigFromCLI(logger, ctx)
+		if err != nil {
+			return err
+		}
+		return action(logger, cfg)
+	}
+	app.Commands = []*cli.Command{
+		{
+			Name:        "verify-stateless",
+			Usage:       "Verify L2 blocks using only their execution witness",
+			Description: "Executes each L2 block in the range statelessly, with only the state in its execution witness, and checks the result matches the block.",
+			Flags:       flags.StatelessFlags,
+			Action: func(ctx *cli.Context) error {
+				logger, err := setupLogging(ctx)
+				if err != nil {
+					return err
+				}
+				cfg, err := config.NewStatelessConfigFromCLI(ctx)
+				if err != nil {
+					return err
+				}
+				return statelessAction(logger, cfg)
+			},
+		},
+	}
+	return app
+}
+
+func setupLogging(ctx *cli.Context) (log.Logger, error) {
+	logCfg := oplog.ReadCLIConfig(ctx)
+	logger := oplog.NewLogger(oplog.AppOut(ctx), logCfg)
+	oplog.SetGlobalLogHandler(logger.Handler())
+	return logger, nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/replay"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+)
+
+const envVarPrefix = "OP_PROGRAM_LOADTEST"
+
+var (
+	TraceFlag = &cli.PathFlag{
+		Name:     "trace",
+		Usage:    "Path of a trace recorded with op-program --record-trace",
+		EnvVars:  opservice.PrefixEnvVar(envVarPrefix, "TRACE"),
+		Required: true,
+	}
+	ConcurrencyFlag = &cli.IntFlag{
+		Name:    "concurrency",
+		Usage:   "Number of hosts to replay the trace against at the same time",
+		EnvVars: opservice.PrefixEnvVar(envVarPrefix, "CONCURRENCY"),
+		Value:   1,
+	}
+	IterationsFlag = &cli.IntFlag{
+		Name:    "iterations",
+		Usage:   "Number of times to replay the trace against each host",
+		EnvVars: opservice.PrefixEnvVar(envVarPrefix, "ITERATIONS"),
+		Value:   1,
+	}
+)
+
+func main() {
+	oplog.SetupDefaults()
+	app := cli.NewApp()
+	app.Name = "op-program-loadtest"
+	app.Usage = "Load test op-program hosts with a recorded trace"
+	app.Description = "Replays a trace of hints and pre-image requests, recorded with op-program --record-trace, " +
+		"against op-program hosts in server mode and reports the latency percentiles per hint and pre-image key type. " +
+		"The host command and its arguments follow the flags after --, " +
+		"e.g. op-program-loadtest --trace trace.jsonl --concurrency 4 -- op-program --server --l1 ... --l2 ..."
+	app.ArgsUsage = "-- <host command> [host args...]"
+	app.Flags = append([]cli.Flag{TraceFlag, ConcurrencyFlag, IterationsFlag}, oplog.CLIFlags(envVarPrefix)...)
+	app.Action = run
+	ctx := opio.CancelOnInterrupt(context.Background())
+	if err := app.RunContext(ctx, os.Args); err != nil {
+		log.Crit("Application failed", "err", err)
+	}
+}
+
+func run(ctx *cli.Context) error {
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	if ctx.NArg() == 0 {
+		return errors.New("no host command specified")
+	}
+	f, err := os.Open(ctx.Path(TraceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to open trace: %w", err)
+	}
+	events, err := replay.ReadTrace(f)
+	_ = f.Close()
+	if err != nil {
+		return err
+	}
+	cfg := replay.Config{
+		Concurrency: ctx.Int(ConcurrencyFlag.Name),
+		Iterations:  ctx.Int(IterationsFlag.Name),
+	}
+	logger.Info("Starting load test", "events", len(events), "concurrency", cfg.Concurrency, "iterations", cfg.Iterations)
+	dial := replay.NewProcessDialer(logger, ctx.Args().First(), ctx.Args().Tail()...)
+	report, err := replay.Run(ctx.Context, logger, events, cfg, dial)
+	if err != nil {
+		return err
+	}
+	return printReport(report)
+}
+
+func printReport(report *replay.Report) error {
+	fmt.Printf("Replayed %d requests in %v\n\n", report.Requests, report.Duration)
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "KIND\tCOUNT\tP50\tP90\tP99\tMAX")
+	for _, s := range report.Stats {
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%v\t%v\t%v\t%v\n", s.Kind, s.Count, s.P50, s.P90, s.P99, s.Max)
+	}
+	return w.Flush()
+}
@@ -4,13 +4,11 @@ import (
 	"os"
 
 	"github.com/ethereum-optimism/optimism/op-program/host"
-	"github.com/ethereum-optimism/optimism/op-program/host/config"
-	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	"github.com/ethereum-optimism/optimism/op-program/host/cmd/app"
 	"github.com/ethereum-optimism/optimism/op-program/host/version"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum/go-ethereum/log"
-	"github.com/urfave/cli/v2"
 )
 
 var (
@@ -23,47 +21,19 @@ var VersionWithMeta = opservice.FormatVersion(version.Version, GitCommit, GitDat
 
 func main() {
 	args := os.Args
-	if err := run(args, host.Main); err != nil {
+	if err := run(args, host.Main, app.VerifyStateless); err != nil {
 		log.Crit("Application failed", "err", err)
 	}
 }
 
-type ConfigAction func(log log.Logger, config *config.Config) error
-
 // run parses the supplied args to create a config.Config instance, sets up logging
 // then calls the supplied ConfigAction.
+// The verify-stateless command instead creates a config.StatelessConfig and calls the supplied StatelessAction.
 // This allows testing the translation from CLI arguments to Config
-func run(args []string, action ConfigAction) error {
+func run(args []string, action app.ConfigAction, statelessAction app.StatelessAction) error {
 	// Set up logger with a default INFO level in case we fail to parse flags,
 	// otherwise the final critical log won't show what the parsing error was.
 	oplog.SetupDefaults()
 
-	app := cli.NewApp()
-	app.Version = VersionWithMeta
-	app.Flags = flags.Flags
-	app.Name = "op-program"
-	app.Usage = "Optimism Fault Proof Program"
-	app.Description = "The Optimism Fault Proof Program fault proof program that runs through the rollup state-transition to verify an L2 output from L1 inputs."
-	app.Action = func(ctx *cli.Context) error {
-		logger, err := setupLogging(ctx)
-		if err != nil {
-			return err
-		}
-		logger.Info("Starting fault proof program", "version", VersionWithMeta)
-
-		cfg, err := config.NewConfigFromCLI(logger, ctx)
-		if err != nil {
-			return err
-		}
-		return action(logger, cfg)
-	}
-
-	return app.Run(args)
-}
-
-func setupLogging(ctx *cli.Context) (log.Logger, error) {
-	logCfg := oplog.ReadCLIConfig(ctx)
-	logger := oplog.NewLogger(oplog.AppOut(ctx), logCfg)
-	oplog.SetGlobalLogHandler(logger.Handler())
-	return logger, nil
+	return app.New(VersionWithMeta, action, statelessAction).Run(args)
 }
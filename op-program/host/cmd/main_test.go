@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"strconv"
 	"testing"
@@ -195,6 +196,21 @@ func TestL1(t *testing.T) {
 	require.Equal(t, expected, cfg.L1URL)
 }
 
+func TestL1BeaconFallbacks(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.L1BeaconFallbackURLs)
+	})
+	t.Run("Multiple", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l1.beacon-fallbacks", "http://a.example.com,http://b.example.com"))
+		require.Equal(t, []string{"http://a.example.com", "http://b.example.com"}, cfg.L1BeaconFallbackURLs)
+	})
+	t.Run("ArchiverAlias", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--l1.beacon-archiver", "http://archiver.example.com"))
+		require.Equal(t, []string{"http://archiver.example.com"}, cfg.L1BeaconFallbackURLs)
+	})
+}
+
 func TestL1TrustRPC(t *testing.T) {
 	t.Run("DefaultFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
@@ -285,6 +301,39 @@ func TestExec(t *testing.T) {
 	})
 }
 
+func TestRecordTrace(t *testing.T) {
+	t.Run("DefaultEmpty", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, "", cfg.RecordTracePath)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--record-trace", "/tmp/trace.jsonl"))
+		require.Equal(t, "/tmp/trace.jsonl", cfg.RecordTracePath)
+	})
+}
+
+func TestHintConcurrency(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.EqualValues(t, 1, cfg.HintConcurrency)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--hint-concurrency", "8"))
+		require.EqualValues(t, 8, cfg.HintConcurrency)
+	})
+}
+
+func TestMaxDerivationSteps(t *testing.T) {
+	t.Run("DefaultUnlimited", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Zero(t, cfg.MaxDerivationSteps)
+	})
+	t.Run("Set", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--max-derivation-steps", "5000"))
+		require.EqualValues(t, 5000, cfg.MaxDerivationSteps)
+	})
+}
+
 func TestServerMode(t *testing.T) {
 	t.Run("DefaultFalse", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs())
@@ -307,6 +356,56 @@ func TestServerMode(t *testing.T) {
 	})
 }
 
+func TestVerifyStateless(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		cfg := statelessConfigForArgs(t, "--network", "sepolia", "--l2", "http://localhost:8545", "--from", "10")
+		require.Equal(t, &config.StatelessConfig{
+			L2URL:         "http://localhost:8545",
+			L2ChainConfig: chainconfig.OPSepoliaChainConfig,
+			From:          10,
+			To:            10,
+			WitnessSource: config.WitnessSourceRPC,
+		}, cfg)
+		require.NoError(t, cfg.Check())
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		cfg := statelessConfigForArgs(t, "--network", "sepolia", "--l2", "http://localhost:8545", "--from", "10", "--to", "20")
+		require.EqualValues(t, 10, cfg.From)
+		require.EqualValues(t, 20, cfg.To)
+	})
+
+	t.Run("Genesis", func(t *testing.T) {
+		genesisFile := writeValidGenesis(t)
+		cfg := statelessConfigForArgs(t, "--l2.genesis", genesisFile, "--l2", "http://localhost:8545", "--from", "10")
+		require.Equal(t, l2GenesisConfig, cfg.L2ChainConfig)
+	})
+
+	t.Run("WitnessSource", func(t *testing.T) {
+		cfg := statelessConfigForArgs(t, "--network", "sepolia", "--l2", "http://localhost:8545", "--from", "10", "--witness-source", "oracle")
+		require.Equal(t, config.WitnessSourceOracle, cfg.WitnessSource)
+		require.NoError(t, cfg.Check())
+
+		cfg = statelessConfigForArgs(t, "--network", "sepolia", "--l2", "http://localhost:8545", "--from", "10", "--witness-source", "foo")
+		require.ErrorIs(t, cfg.Check(), config.ErrInvalidWitnessSource)
+	})
+
+	t.Run("RequireL2", func(t *testing.T) {
+		_, err := runStatelessWithArgs([]string{"--network", "sepolia", "--from", "10"})
+		require.ErrorContains(t, err, "flag l2 is required")
+	})
+
+	t.Run("RequireFrom", func(t *testing.T) {
+		_, err := runStatelessWithArgs([]string{"--network", "sepolia", "--l2", "http://localhost:8545"})
+		require.ErrorContains(t, err, "flag from is required")
+	})
+
+	t.Run("RequireNetworkOrGenesis", func(t *testing.T) {
+		_, err := runStatelessWithArgs([]string{"--l2", "http://localhost:8545", "--from", "10"})
+		require.ErrorContains(t, err, "flag network or l2.genesis is required")
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := runWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
@@ -326,10 +425,30 @@ func runWithArgs(cliArgs []string) (log.Logger, *config.Config, error) {
 		logger = log
 		cfg = config
 		return nil
+	}, func(log log.Logger, config *config.StatelessConfig) error {
+		return errors.New("unexpected verify-stateless command")
 	})
 	return logger, cfg, err
 }
 
+func statelessConfigForArgs(t *testing.T, cliArgs ...string) *config.StatelessConfig {
+	cfg, err := runStatelessWithArgs(cliArgs)
+	require.NoError(t, err)
+	return cfg
+}
+
+func runStatelessWithArgs(cliArgs []string) (*config.StatelessConfig, error) {
+	var cfg *config.StatelessConfig
+	fullArgs := append([]string{"op-program", "verify-stateless"}, cliArgs...)
+	err := run(fullArgs, func(log log.Logger, config *config.Config) error {
+		return errors.New("unexpected fault proof program run")
+	}, func(log log.Logger, config *config.StatelessConfig) error {
+		cfg = config
+		return nil
+	})
+	return cfg, err
+}
+
 func addRequiredArgs(args ...string) []string {
 	req := requiredArgs()
 	combined := toArgList(req)
@@ -20,16 +20,17 @@ import (
 )
 
 var (
-	ErrMissingRollupConfig = errors.New("missing rollup config")
-	ErrMissingL2Genesis    = errors.New("missing l2 genesis")
-	ErrInvalidL1Head       = errors.New("invalid l1 head")
-	ErrInvalidL2Head       = errors.New("invalid l2 head")
-	ErrInvalidL2OutputRoot = errors.New("invalid l2 output root")
-	ErrL1AndL2Inconsistent = errors.New("l1 and l2 options must be specified together or both omitted")
-	ErrInvalidL2Claim      = errors.New("invalid l2 claim")
-	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
-	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
-	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrMissingRollupConfig    = errors.New("missing rollup config")
+	ErrMissingL2Genesis       = errors.New("missing l2 genesis")
+	ErrInvalidL1Head          = errors.New("invalid l1 head")
+	ErrInvalidL2Head          = errors.New("invalid l2 head")
+	ErrInvalidL2OutputRoot    = errors.New("invalid l2 output root")
+	ErrL1AndL2Inconsistent    = errors.New("l1 and l2 options must be specified together or both omitted")
+	ErrInvalidL2Claim         = errors.New("invalid l2 claim")
+	ErrInvalidL2ClaimBlock    = errors.New("invalid l2 claim block number")
+	ErrDataDirRequired        = errors.New("datadir must be specified when in non-fetching mode")
+	ErrNoExecInServerMode     = errors.New("exec command must not be set when in server mode")
+	ErrInvalidHintConcurrency = errors.New("hint concurrency must be at least 1")
 )
 
 type Config struct {
@@ -42,8 +43,18 @@ type Config struct {
 	L1Head      common.Hash
 	L1URL       string
 	L1BeaconURL string
-	L1TrustRPC  bool
-	L1RPCKind   sources.RPCProviderKind
+	// L1BeaconFallbackURLs are Beacon API endpoints, such as blob archivers, used to fetch blob sidecars
+	// that are no longer available from L1BeaconURL.
+	L1BeaconFallbackURLs []string
+	L1TrustRPC           bool
+	L1RPCKind            sources.RPCProviderKind
+	// HintConcurrency is the maximum number of hints processed at once. If above 1, hints are processed as they
+	// are received, so failed hints are reported to the client, and their follow-up hints in the background.
+	// Otherwise hints are only processed when the pre-images they prepare are requested.
+	HintConcurrency uint
+	// MaxDerivationSteps bounds the derivation of a client program run in the host process. Unlimited if 0.
+	// A client program run by ExecCmd or in the VM always uses the default step budget.
+	MaxDerivationSteps uint64
 
 	// L2Head is the l2 block hash contained in the L2 Output referenced by the L2OutputRoot
 	L2Head common.Hash
@@ -65,6 +76,10 @@ type Config struct {
 	// No client program is run.
 	ServerMode bool
 
+	// RecordTracePath is the file to record the hints and pre-image requests received by the pre-image server to.
+	// No trace is recorded if empty.
+	RecordTracePath string
+
 	// IsCustomChainConfig indicates that the program uses a custom chain configuration
 	IsCustomChainConfig bool
 }
@@ -100,6 +115,9 @@ func (c *Config) Check() error {
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
+	if c.HintConcurrency == 0 {
+		return ErrInvalidHintConcurrency
+	}
 	return nil
 }
 
@@ -129,6 +147,7 @@ func NewConfig(
 		L2Claim:             l2Claim,
 		L2ClaimBlockNumber:  l2ClaimBlockNum,
 		L1RPCKind:           sources.RPCKindStandard,
+		HintConcurrency:     1,
 		IsCustomChainConfig: isCustomConfig,
 	}
 }
@@ -162,45 +181,55 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if l1Head == (common.Hash{}) {
 		return nil, ErrInvalidL1Head
 	}
+	l2ChainConfig, isCustomConfig, err := L2ChainConfigFromCLI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Config{
+		Rollup:               rollupCfg,
+		DataDir:              ctx.String(flags.DataDir.Name),
+		L2URL:                ctx.String(flags.L2NodeAddr.Name),
+		L2ChainConfig:        l2ChainConfig,
+		L2Head:               l2Head,
+		L2OutputRoot:         l2OutputRoot,
+		L2Claim:              l2Claim,
+		L2ClaimBlockNumber:   l2ClaimBlockNum,
+		L1Head:               l1Head,
+		L1URL:                ctx.String(flags.L1NodeAddr.Name),
+		L1BeaconURL:          ctx.String(flags.L1BeaconAddr.Name),
+		L1BeaconFallbackURLs: ctx.StringSlice(flags.L1BeaconFallbackAddrs.Name),
+		L1TrustRPC:           ctx.Bool(flags.L1TrustRPC.Name),
+		L1RPCKind:            sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
+		HintConcurrency:      ctx.Uint(flags.HintConcurrency.Name),
+		MaxDerivationSteps:   ctx.Uint64(flags.MaxDerivationSteps.Name),
+		ExecCmd:              ctx.String(flags.Exec.Name),
+		ServerMode:           ctx.Bool(flags.Server.Name),
+		RecordTracePath:      ctx.String(flags.RecordTrace.Name),
+		IsCustomChainConfig:  isCustomConfig,
+	}, nil
+}
+
+// L2ChainConfigFromCLI loads the op-geth chain config from the l2.genesis file if set, or the selected network.
+// Reports whether the chain config is custom, i.e. loaded from a genesis file.
+func L2ChainConfigFromCLI(ctx *cli.Context) (*params.ChainConfig, bool, error) {
 	l2GenesisPath := ctx.String(flags.L2GenesisPath.Name)
-	var l2ChainConfig *params.ChainConfig
-	var isCustomConfig bool
-	if l2GenesisPath == "" {
-		networkName := ctx.String(flags.Network.Name)
-		ch := chaincfg.ChainByName(networkName)
-		if ch == nil {
-			return nil, fmt.Errorf("flag %s is required for network %s", flags.L2GenesisPath.Name, networkName)
-		}
-		cfg, err := params.LoadOPStackChainConfig(ch.ChainID)
+	if l2GenesisPath != "" {
+		l2ChainConfig, err := loadChainConfigFromGenesis(l2GenesisPath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load chain config for chain %d: %w", ch.ChainID, err)
+			return nil, false, fmt.Errorf("invalid genesis: %w", err)
 		}
-		l2ChainConfig = cfg
-	} else {
-		l2ChainConfig, err = loadChainConfigFromGenesis(l2GenesisPath)
-		isCustomConfig = true
+		return l2ChainConfig, true, nil
 	}
+	networkName := ctx.String(flags.Network.Name)
+	ch := chaincfg.ChainByName(networkName)
+	if ch == nil {
+		return nil, false, fmt.Errorf("flag %s is required for network %s", flags.L2GenesisPath.Name, networkName)
+	}
+	l2ChainConfig, err := params.LoadOPStackChainConfig(ch.ChainID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid genesis: %w", err)
+		return nil, false, fmt.Errorf("invalid genesis: failed to load chain config for chain %d: %w", ch.ChainID, err)
 	}
-	return &Config{
-		Rollup:              rollupCfg,
-		DataDir:             ctx.String(flags.DataDir.Name),
-		L2URL:               ctx.String(flags.L2NodeAddr.Name),
-		L2ChainConfig:       l2ChainConfig,
-		L2Head:              l2Head,
-		L2OutputRoot:        l2OutputRoot,
-		L2Claim:             l2Claim,
-		L2ClaimBlockNumber:  l2ClaimBlockNum,
-		L1Head:              l1Head,
-		L1URL:               ctx.String(flags.L1NodeAddr.Name),
-		L1BeaconURL:         ctx.String(flags.L1BeaconAddr.Name),
-		L1TrustRPC:          ctx.Bool(flags.L1TrustRPC.Name),
-		L1RPCKind:           sources.RPCProviderKind(ctx.String(flags.L1RPCProviderKind.Name)),
-		ExecCmd:             ctx.String(flags.Exec.Name),
-		ServerMode:          ctx.Bool(flags.Server.Name),
-		IsCustomChainConfig: isCustomConfig,
-	}, nil
+	return l2ChainConfig, false, nil
 }
 
 func loadChainConfigFromGenesis(path string) (*params.ChainConfig, error) {
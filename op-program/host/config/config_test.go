@@ -160,6 +160,13 @@ func TestRejectExecAndServerMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoExecInServerMode)
 }
 
+func TestRejectZeroHintConcurrency(t *testing.T) {
+	cfg := validConfig()
+	cfg.HintConcurrency = 0
+	err := cfg.Check()
+	require.ErrorIs(t, err, ErrInvalidHintConcurrency)
+}
+
 func TestIsCustomChainConfig(t *testing.T) {
 	t.Run("nonCustom", func(t *testing.T) {
 		cfg := validConfig()
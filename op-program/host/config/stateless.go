@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	ErrMissingL2URL         = errors.New("missing l2 rpc url")
+	ErrInvalidBlockRange    = errors.New("invalid block range")
+	ErrInvalidWitnessSource = errors.New("invalid witness source")
+)
+
+type WitnessSource string
+
+const (
+	// WitnessSourceRPC retrieves the execution witness with debug_executionWitness
+	WitnessSourceRPC WitnessSource = "rpc"
+	// WitnessSourceOracle builds the execution witness by executing the block with state retrieved via the
+	// debug_dbGet based pre-image oracle, for nodes that don't support debug_executionWitness.
+	WitnessSourceOracle WitnessSource = "oracle"
+)
+
+var WitnessSources = []WitnessSource{WitnessSourceRPC, WitnessSourceOracle}
+
+func (s WitnessSource) valid() bool {
+	for _, source := range WitnessSources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// StatelessConfig is the configuration for verifying L2 blocks statelessly, using only their execution witness.
+type StatelessConfig struct {
+	L2URL string
+	// L2ChainConfig is the op-geth chain config for the L2 execution engine
+	L2ChainConfig *params.ChainConfig
+	// From is the first L2 block number to verify
+	From uint64
+	// To is the last L2 block number to verify (inclusive)
+	To uint64
+	// WitnessSource is where the execution witness for each block is retrieved from
+	WitnessSource WitnessSource
+}
+
+func (c *StatelessConfig) Check() error {
+	if c.L2URL == "" {
+		return ErrMissingL2URL
+	}
+	if c.L2ChainConfig == nil {
+		return ErrMissingL2Genesis
+	}
+	if c.From == 0 || c.To < c.From {
+		return fmt.Errorf("%w: %d to %d", ErrInvalidBlockRange, c.From, c.To)
+	}
+	if !c.WitnessSource.valid() {
+		return fmt.Errorf("%w: %q", ErrInvalidWitnessSource, c.WitnessSource)
+	}
+	return nil
+}
+
+func NewStatelessConfigFromCLI(ctx *cli.Context) (*StatelessConfig, error) {
+	if err := flags.CheckStatelessRequired(ctx); err != nil {
+		return nil, err
+	}
+	l2ChainConfig, _, err := L2ChainConfigFromCLI(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from := ctx.Uint64(flags.StatelessFrom.Name)
+	to := from
+	if ctx.IsSet(flags.StatelessTo.Name) {
+		to = ctx.Uint64(flags.StatelessTo.Name)
+	}
+	return &StatelessConfig{
+		L2URL:         ctx.String(flags.L2NodeAddr.Name),
+		L2ChainConfig: l2ChainConfig,
+		From:          from,
+		To:            to,
+		WitnessSource: WitnessSource(ctx.String(flags.StatelessWitnessSource.Name)),
+	}, nil
+}
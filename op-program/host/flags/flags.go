@@ -80,6 +80,12 @@ var (
 		Usage:   "Address of L1 Beacon API endpoint to use",
 		EnvVars: prefixEnvVars("L1_BEACON_API"),
 	}
+	L1BeaconFallbackAddrs = &cli.StringSliceFlag{
+		Name:    "l1.beacon-fallbacks",
+		Aliases: []string{"l1.beacon-archiver"},
+		Usage:   "Addresses of L1 Beacon-API compatible HTTP fallback endpoints. Used to fetch blob sidecars not available at the l1.beacon (e.g. expired blobs).",
+		EnvVars: prefixEnvVars("L1_BEACON_FALLBACKS"),
+	}
 	L1TrustRPC = &cli.BoolFlag{
 		Name:    "l1.trustrpc",
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
@@ -95,6 +101,18 @@ var (
 			return &out
 		}(),
 	}
+	HintConcurrency = &cli.UintFlag{
+		Name: "hint-concurrency",
+		Usage: "Maximum number of hints to process at once. If above 1, hints are processed as they are received, so failed hints are reported to the client, " +
+			"and the transactions and receipts of L1 blocks are fetched in the background along with their headers.",
+		EnvVars: prefixEnvVars("HINT_CONCURRENCY"),
+		Value:   1,
+	}
+	MaxDerivationSteps = &cli.Uint64Flag{
+		Name:    "max-derivation-steps",
+		Usage:   "Maximum number of derivation steps of a client program run in the host process. Unlimited if 0. Has no effect with --exec.",
+		EnvVars: prefixEnvVars("MAX_DERIVATION_STEPS"),
+	}
 	Exec = &cli.StringFlag{
 		Name:    "exec",
 		Usage:   "Run the specified client program as a separate process detached from the host. Default is to run the client program in the host process.",
@@ -105,6 +123,28 @@ var (
 		Usage:   "Run in pre-image server mode without executing any client program.",
 		EnvVars: prefixEnvVars("SERVER"),
 	}
+	RecordTrace = &cli.StringFlag{
+		Name:    "record-trace",
+		Usage:   "Path to write a trace of the hints and pre-image requests received by the pre-image server to, for replaying with op-program-loadtest.",
+		EnvVars: prefixEnvVars("RECORD_TRACE"),
+	}
+	StatelessFrom = &cli.Uint64Flag{
+		Name:    "from",
+		Usage:   "Number of the first L2 block to verify statelessly",
+		EnvVars: prefixEnvVars("STATELESS_FROM"),
+	}
+	StatelessTo = &cli.Uint64Flag{
+		Name:    "to",
+		Usage:   "Number of the last L2 block to verify statelessly. Defaults to the from block",
+		EnvVars: prefixEnvVars("STATELESS_TO"),
+	}
+	StatelessWitnessSource = &cli.StringFlag{
+		Name: "witness-source",
+		Usage: "Where to retrieve the execution witness of each block from. " +
+			"'rpc' uses debug_executionWitness, 'oracle' builds the witness from state retrieved with debug_dbGet",
+		EnvVars: prefixEnvVars("STATELESS_WITNESS_SOURCE"),
+		Value:   "rpc",
+	}
 )
 
 // Flags contains the list of configuration options available to the binary.
@@ -126,10 +166,24 @@ var programFlags = []cli.Flag{
 	L2GenesisPath,
 	L1NodeAddr,
 	L1BeaconAddr,
+	L1BeaconFallbackAddrs,
 	L1TrustRPC,
 	L1RPCProviderKind,
+	HintConcurrency,
+	MaxDerivationSteps,
 	Exec,
 	Server,
+	RecordTrace,
+}
+
+// StatelessFlags contains the configuration options available to the verify-stateless command.
+var StatelessFlags = []cli.Flag{
+	Network,
+	L2GenesisPath,
+	L2NodeAddr,
+	StatelessFrom,
+	StatelessTo,
+	StatelessWitnessSource,
 }
 
 func init() {
@@ -157,3 +211,15 @@ func CheckRequired(ctx *cli.Context) error {
 	}
 	return nil
 }
+
+func CheckStatelessRequired(ctx *cli.Context) error {
+	if ctx.String(Network.Name) == "" && ctx.String(L2GenesisPath.Name) == "" {
+		return fmt.Errorf("flag %s or %s is required", Network.Name, L2GenesisPath.Name)
+	}
+	for _, flag := range []cli.Flag{L2NodeAddr, StatelessFrom} {
+		if !ctx.IsSet(flag.Names()[0]) {
+			return fmt.Errorf("flag %s is required", flag.Names()[0])
+		}
+	}
+	return nil
+}
@@ -12,10 +12,12 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	cl "github.com/ethereum-optimism/optimism/op-program/client"
+	cldr "github.com/ethereum-optimism/optimism/op-program/client/driver"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
+	"github.com/ethereum-optimism/optimism/op-program/host/replay"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -111,7 +113,13 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 		logger.Debug("Client program completed successfully")
 		return nil
 	} else {
-		return cl.RunProgram(logger, pClientRW, hClientRW)
+		budget := cldr.DefaultStepBudget
+		budget.MaxSteps = cfg.MaxDerivationSteps
+		result, err := cl.RunProgram(logger, pClientRW, hClientRW, budget)
+		if result != nil {
+			logger.Info("Client program completed", result.LogValues()...)
+		}
+		return err
 	}
 }
 
@@ -122,6 +130,7 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel preimage.FileChannel, hintChannel preimage.FileChannel) error {
 	var serverDone chan error
 	var hinterDone chan error
+	var closeTrace func()
 	defer func() {
 		preimageChannel.Close()
 		hintChannel.Close()
@@ -133,6 +142,9 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 			// Wait for hinter to complete
 			<-hinterDone
 		}
+		if closeTrace != nil {
+			closeTrace()
+		}
 	}()
 	logger.Info("Starting preimage server")
 	var kv kvstore.KV
@@ -152,6 +164,9 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		hinter      preimage.HintHandler
 	)
 	if cfg.FetchingEnabled() {
+		// Stops processing hints in the background once the server stops
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
 		prefetch, err := makePrefetcher(ctx, logger, kv, cfg)
 		if err != nil {
 			return fmt.Errorf("failed to create prefetcher: %w", err)
@@ -170,6 +185,22 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 	localPreimageSource := kvstore.NewLocalPreimageSource(cfg)
 	splitter := kvstore.NewPreimageSourceSplitter(localPreimageSource.Get, getPreimage)
 	preimageGetter := preimage.WithVerification(splitter.Get)
+	if cfg.RecordTracePath != "" {
+		logger.Info("Recording hints and pre-image requests", "path", cfg.RecordTracePath)
+		f, err := os.Create(cfg.RecordTracePath)
+		if err != nil {
+			return fmt.Errorf("failed to create trace file: %w", err)
+		}
+		recorder := replay.NewRecorder(f)
+		// Closed after the handlers completed, so no requests are recorded after closing the file.
+		closeTrace = func() {
+			if err := errors.Join(recorder.Err(), f.Close()); err != nil {
+				logger.Error("Failed to record trace", "err", err)
+			}
+		}
+		hinter = recorder.Hinter(hinter)
+		preimageGetter = recorder.Getter(preimageGetter)
+	}
 
 	serverDone = launchOracleServer(logger, preimageChannel, preimageGetter)
 	hinterDone = routeHints(logger, hintChannel, hinter)
@@ -201,13 +232,22 @@ func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *
 		return nil, fmt.Errorf("failed to create L1 client: %w", err)
 	}
 	l1Beacon := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(cfg.L1BeaconURL, logger))
-	l1BlobFetcher := sources.NewL1BeaconClient(l1Beacon, sources.L1BeaconClientConfig{FetchAllSidecars: false})
+	var l1BeaconFallbacks []sources.BlobSideCarsFetcher
+	for _, addr := range cfg.L1BeaconFallbackURLs {
+		l1BeaconFallbacks = append(l1BeaconFallbacks, sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(addr, logger)))
+	}
+	l1BlobFetcher := sources.NewL1BeaconClient(l1Beacon, sources.L1BeaconClientConfig{FetchAllSidecars: false}, l1BeaconFallbacks...)
 	l2Cl, err := NewL2Client(l2RPC, logger, nil, &L2ClientConfig{L2ClientConfig: l2ClCfg, L2Head: cfg.L2Head})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create L2 client: %w", err)
 	}
 	l2DebugCl := &L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
-	return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2DebugCl, kv), nil
+	prefetch := prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2DebugCl, kv)
+	if cfg.HintConcurrency > 1 {
+		logger.Info("Processing hints concurrently", "concurrency", cfg.HintConcurrency)
+		prefetch.EnableConcurrentHints(ctx, int(cfg.HintConcurrency))
+	}
+	return prefetch, nil
 }
 
 func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHandler) chan error {
@@ -216,7 +256,17 @@ func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHa
 	go func() {
 		defer close(chErr)
 		for {
-			if err := hintReader.NextHint(hinter); err != nil {
+			// A failed hint is reported to the client, which may still continue, so keep serving hints
+			var hintErr error
+			handler := func(hint string) error {
+				hintErr = hinter(hint)
+				return hintErr
+			}
+			if err := hintReader.NextHint(handler); err != nil {
+				if hintErr != nil {
+					logger.Warn("Failed to process pre-image hint", "err", hintErr)
+					continue
+				}
 				if err == io.EOF || errors.Is(err, fs.ErrClosed) {
 					logger.Debug("closing pre-image hint handler")
 					return
@@ -2,7 +2,9 @@ package host
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
+	"math/rand"
 	"testing"
 	"time"
 
@@ -13,9 +15,13 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,6 +60,54 @@ func TestServerMode(t *testing.T) {
 	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
 }
 
+func TestRouteHints_FailedHint(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := testlog.Logger(t, log.LevelDebug)
+	l1Source := new(testutils.MockL1Source)
+	blobSource := new(testutils.MockBlobsFetcher)
+	prefetch := prefetcher.NewPrefetcher(logger, l1Source, blobSource, nil, kvstore.NewMemKV())
+	prefetch.EnableConcurrentHints(ctx, 4)
+
+	hintServer, hintClient, err := preimage.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	hinterDone := routeHints(logger, hintServer, prefetch.Hint)
+	defer func() {
+		require.NoError(t, hintServer.Close())
+		require.NoError(t, waitFor(hinterDone))
+	}()
+	defer hintClient.Close()
+	// Cannon negotiates version 2 of the hint protocol with the host
+	hClient := preimage.NewHintWriterV2(hintClient)
+
+	// The beacon node doesn't have the blob, so the hint fails before it is acknowledged
+	blobSource.Mock.On("GetBlobSidecars", mock.Anything, mock.Anything).Once().Return([]*eth.BlobSidecar{}, nil)
+	blobHint := make([]byte, 48)
+	copy(blobHint, common.Hash{0xbb}.Bytes())
+	binary.BigEndian.PutUint64(blobHint[40:], 1234)
+	hint := l1.BlobHint(blobHint).Hint()
+	func() {
+		defer func() {
+			r := recover()
+			hintErr, ok := r.(*preimage.HintError)
+			require.Truef(t, ok, "expected *preimage.HintError, got %v", r)
+			require.Equal(t, hint, hintErr.Hint)
+			require.Contains(t, hintErr.Message, "failed to fetch blob sidecars")
+		}()
+		hClient.Hint(l1.BlobHint(blobHint))
+	}()
+
+	// The host keeps serving hints after a failed hint
+	block, _ := testutils.RandomBlock(rand.New(rand.NewSource(123)), 1)
+	l1Source.ExpectInfoByHash(block.Hash(), eth.BlockToInfo(block), nil)
+	l1Source.ExpectInfoAndTxsByHash(block.Hash(), eth.BlockToInfo(block), block.Transactions(), nil)
+	l1Source.ExpectFetchReceipts(block.Hash(), eth.BlockToInfo(block), nil, nil)
+	require.NotPanics(t, func() {
+		hClient.Hint(l1.BlockHeaderHint(block.Hash()))
+	})
+	blobSource.AssertExpectations(t)
+}
+
 func waitFor(ch chan error) error {
 	timeout := time.After(30 * time.Second)
 	select {
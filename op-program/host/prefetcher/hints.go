@@ -0,0 +1,142 @@
+package prefetcher
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+)
+
+// hintTaskCacheSize is the number of recently processed hints that are not processed again.
+const hintTaskCacheSize = 10_000
+
+// hintTask is the processing of a hint in the background.
+type hintTask struct {
+	done chan struct{}
+	err  error
+}
+
+func (t *hintTask) isDone() bool {
+	select {
+	case <-t.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// concurrentHints processes hints in the background, with up to a maximum number of hints processed at once.
+// The processing of hints is independent: each hint carries the hashes of the data to fetch.
+// Pre-image requests however depend on the hints received before them, so they wait for those hints to be
+// processed before a pre-image is considered missing.
+type concurrentHints struct {
+	ctx     context.Context
+	sem     chan struct{}
+	process func(ctx context.Context, hint string) error
+
+	mu sync.Mutex
+	// pending are the tasks of the hints that are not known to be processed, in the order they were received
+	pending []*hintTask
+	// tasks are the tasks of recent hints, so hints that are received again are not processed twice
+	tasks *lru.Cache[string, *hintTask]
+}
+
+func newConcurrentHints(ctx context.Context, concurrency int, process func(ctx context.Context, hint string) error) *concurrentHints {
+	// Only errors for a non-positive size
+	tasks, _ := lru.New[string, *hintTask](hintTaskCacheSize)
+	return &concurrentHints{
+		ctx:     ctx,
+		sem:     make(chan struct{}, concurrency),
+		process: process,
+		tasks:   tasks,
+	}
+}
+
+// Schedule processes the hint in the background, unless it is already being processed or was processed recently.
+// It returns the task that processes the hint.
+func (c *concurrentHints) Schedule(hint string) *hintTask {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.prune()
+	if task, ok := c.tasks.Get(hint); ok {
+		return task
+	}
+	task := &hintTask{done: make(chan struct{})}
+	c.tasks.Add(hint, task)
+	c.pending = append(c.pending, task)
+	go c.run(hint, task)
+	return task
+}
+
+// WaitFor waits until the task is done, and returns the error of processing its hint.
+func (c *concurrentHints) WaitFor(task *hintTask) error {
+	select {
+	case <-task.done:
+		return task.err
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
+
+func (c *concurrentHints) run(hint string, task *hintTask) {
+	defer close(task.done)
+	select {
+	case c.sem <- struct{}{}:
+	case <-c.ctx.Done():
+		task.err = c.ctx.Err()
+	}
+	if task.err == nil {
+		task.err = c.process(c.ctx, hint)
+		<-c.sem
+	}
+	if task.err != nil {
+		// Allow the hint to be processed again
+		c.mu.Lock()
+		if t, ok := c.tasks.Peek(hint); ok && t == task {
+			c.tasks.Remove(hint)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// Wait waits until all hints scheduled so far are processed, successfully or not.
+func (c *concurrentHints) Wait(ctx context.Context) error {
+	c.mu.Lock()
+	pending := c.pending
+	c.mu.Unlock()
+	for _, task := range pending {
+		select {
+		case <-task.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	c.mu.Lock()
+	c.prune()
+	c.mu.Unlock()
+	return nil
+}
+
+// prune drops the processed tasks at the start of the pending tasks. Must be called with the lock held.
+func (c *concurrentHints) prune() {
+	i := 0
+	for i < len(c.pending) && c.pending[i].isDone() {
+		i++
+	}
+	c.pending = c.pending[i:]
+}
+
+// followUpHints returns the hints that are typically received after the given hint, and that can be processed
+// concurrently with it. The transactions and receipts of L1 blocks are requested after their headers,
+// when the L1 blocks are derived from.
+func followUpHints(hint string) []string {
+	hintType, hintBytes, err := parseHint(hint)
+	if err != nil || hintType != l1.HintL1BlockHeader || len(hintBytes) != 32 {
+		return nil
+	}
+	hash := common.Hash(hintBytes)
+	return []string{l1.TransactionsHint(hash).Hint(), l1.ReceiptsHint(hash).Hint()}
+}
@@ -0,0 +1,117 @@
+package prefetcher
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func TestConcurrentHints(t *testing.T) {
+	t.Run("FetchReceiptsWithHeader", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(123))
+		block, receipts := testutils.RandomBlock(rng, 10)
+		hash := block.Hash()
+
+		prefetcher, l1Cl, _, _, _ := createPrefetcher(t)
+		prefetcher.EnableConcurrentHints(context.Background(), 4)
+		// Each is fetched once, even though the client hints the transactions and receipts again
+		l1Cl.ExpectInfoByHash(hash, eth.BlockToInfo(block), nil)
+		l1Cl.ExpectInfoAndTxsByHash(hash, eth.BlockToInfo(block), block.Transactions(), nil)
+		l1Cl.ExpectFetchReceipts(hash, eth.BlockToInfo(block), receipts, nil)
+		defer l1Cl.AssertExpectations(t)
+
+		oracle := l1.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		header := oracle.HeaderByBlockHash(hash)
+		require.EqualValues(t, hash, header.Hash())
+		require.NoError(t, prefetcher.hints.Wait(context.Background()))
+		l1Cl.AssertExpectations(t)
+
+		_, actualReceipts := oracle.ReceiptsByBlockHash(hash)
+		assertReceiptsEqual(t, receipts, actualReceipts)
+	})
+
+	t.Run("WaitForEarlierHints", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(123))
+		node1 := testutils.RandomData(rng, 30)
+		hash1 := crypto.Keccak256Hash(node1)
+		node2 := testutils.RandomData(rng, 30)
+		hash2 := crypto.Keccak256Hash(node2)
+
+		prefetcher, _, _, l2Cl, _ := createPrefetcher(t)
+		prefetcher.EnableConcurrentHints(context.Background(), 4)
+		release := make(chan time.Time)
+		l2Cl.MockDebugClient.Mock.On("NodeByHash", hash1).Once().WaitUntil(release).Return(node1, nil)
+		l2Cl.ExpectNodeByHash(hash2, node2, nil)
+		defer l2Cl.MockDebugClient.AssertExpectations(t)
+
+		// The first hint is processed in the background, like follow-up hints
+		prefetcher.hints.Schedule(l2.StateNodeHint(hash1).Hint())
+		require.NoError(t, prefetcher.Hint(l2.StateNodeHint(hash2).Hint()))
+
+		// The pre-image of the first hint is requested after the second hint was received
+		result := make(chan []byte, 1)
+		go func() {
+			pre, err := prefetcher.GetPreimage(context.Background(), preimage.Keccak256Key(hash1).PreimageKey())
+			require.NoError(t, err)
+			result <- pre
+		}()
+		select {
+		case <-result:
+			t.Fatal("pre-image returned before the hint was processed")
+		case <-time.After(50 * time.Millisecond):
+		}
+		close(release)
+		require.Equal(t, node1, <-result)
+	})
+
+	t.Run("LimitConcurrency", func(t *testing.T) {
+		var active, maxActive atomic.Int32
+		release := make(chan struct{})
+		hints := newConcurrentHints(context.Background(), 2, func(ctx context.Context, hint string) error {
+			n := active.Add(1)
+			for m := maxActive.Load(); n > m && !maxActive.CompareAndSwap(m, n); m = maxActive.Load() {
+			}
+			<-release
+			active.Add(-1)
+			return nil
+		})
+		rng := rand.New(rand.NewSource(123))
+		for i := 0; i < 5; i++ {
+			hints.Schedule(l2.CodeHint(testutils.RandomHash(rng)).Hint())
+		}
+		require.Eventually(t, func() bool { return active.Load() == 2 }, 10*time.Second, 10*time.Millisecond)
+		close(release)
+		require.NoError(t, hints.Wait(context.Background()))
+		require.EqualValues(t, 2, maxActive.Load())
+	})
+
+	t.Run("RetryFailedHint", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(123))
+		node := testutils.RandomData(rng, 30)
+		hash := crypto.Keccak256Hash(node)
+
+		prefetcher, _, _, _, _ := createPrefetcher(t)
+		calls := 0
+		prefetcher.hints = newConcurrentHints(context.Background(), 2, func(ctx context.Context, hint string) error {
+			calls++
+			return errors.New("boom")
+		})
+		hint := l2.StateNodeHint(hash).Hint()
+		// The failure is reported before the hint is acknowledged
+		require.ErrorContains(t, prefetcher.Hint(hint), "boom")
+		require.ErrorContains(t, prefetcher.Hint(hint), "boom")
+		require.Equal(t, 2, calls, "failed hints should be processed again")
+	})
+}
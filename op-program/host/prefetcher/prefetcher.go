@@ -59,6 +59,8 @@ type Prefetcher struct {
 	l2Fetcher     L2Source
 	lastHint      string
 	kvStore       kvstore.KV
+	// hints processes hints in the background, if concurrent hint processing is enabled
+	hints *concurrentHints
 }
 
 func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
@@ -71,15 +73,42 @@ func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSo
 	}
 }
 
+// EnableConcurrentHints makes the prefetcher process hints in the background as they are received, with up to
+// concurrency hints processed at once, instead of when a pre-image is requested. The transactions and receipts
+// of L1 blocks are fetched along with their headers. Hints are processed with ctx, which must be cancelled
+// when the prefetcher is no longer used. Must be called before any hint is received.
+func (p *Prefetcher) EnableConcurrentHints(ctx context.Context, concurrency int) {
+	p.hints = newConcurrentHints(ctx, concurrency, p.prefetch)
+}
+
+// Hint prepares the pre-images of the hint. If concurrent hint processing is enabled, the hint is processed before
+// Hint returns, so a failure is reported to the client, while its follow-up hints are processed in the background.
+// Otherwise the hint is only processed when a pre-image it prepares is requested, and Hint never fails.
 func (p *Prefetcher) Hint(hint string) error {
 	p.logger.Trace("Received hint", "hint", hint)
 	p.lastHint = hint
+	if p.hints != nil {
+		task := p.hints.Schedule(hint)
+		for _, followUp := range followUpHints(hint) {
+			p.hints.Schedule(followUp)
+		}
+		if err := p.hints.WaitFor(task); err != nil {
+			return fmt.Errorf("failed to process hint %q: %w", hint, err)
+		}
+	}
 	return nil
 }
 
 func (p *Prefetcher) GetPreimage(ctx context.Context, key common.Hash) ([]byte, error) {
 	p.logger.Trace("Pre-image requested", "key", key)
 	pre, err := p.kvStore.Get(key)
+	if errors.Is(err, kvstore.ErrNotFound) && p.hints != nil {
+		// The pre-image may be fetched by a hint that is still being processed
+		if err := p.hints.Wait(ctx); err != nil {
+			return nil, err
+		}
+		pre, err = p.kvStore.Get(key)
+	}
 	// Use a loop to keep retrying the prefetch as long as the key is not found
 	// This handles the case where the prefetch downloads a preimage, but it is then deleted unexpectedly
 	// before we get to read it.
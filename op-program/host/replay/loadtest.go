@@ -0,0 +1,273 @@
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+const pollTimeout = 500 * time.Millisecond
+
+var ErrInvalidConfig = errors.New("invalid load test config")
+
+// Conn is a connection to a single op-program host, as used by one fault proof program.
+type Conn interface {
+	Hint(hint string) error
+	GetPreimage(key common.Hash) ([]byte, error)
+	Close() error
+}
+
+// Dialer opens a new connection to a host. Every worker of a load test uses its own connection.
+type Dialer func(ctx context.Context) (Conn, error)
+
+type Config struct {
+	// Concurrency is the number of workers that replay the trace at the same time,
+	// each simulating a separate fault proof program, e.g. of a different game.
+	Concurrency int
+	// Iterations is the number of times each worker replays the trace.
+	Iterations int
+}
+
+func (c Config) Check() error {
+	if c.Concurrency < 1 {
+		return fmt.Errorf("%w: concurrency must be at least 1", ErrInvalidConfig)
+	}
+	if c.Iterations < 1 {
+		return fmt.Errorf("%w: iterations must be at least 1", ErrInvalidConfig)
+	}
+	return nil
+}
+
+// LatencyStats summarizes the latencies of one kind of request.
+type LatencyStats struct {
+	Kind  string
+	Count int
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+type Report struct {
+	Duration time.Duration
+	Requests int
+	// Stats are the latencies per hint type and pre-image key type, sorted by kind.
+	Stats []LatencyStats
+}
+
+// Run replays the trace events on every worker and reports the latencies of the host responses.
+// The load test is aborted on the first failed request.
+func Run(ctx context.Context, logger log.Logger, events []Event, cfg Config, dial Dialer) (*Report, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	var (
+		mu        sync.Mutex
+		latencies = make(map[string][]time.Duration)
+	)
+	start := time.Now()
+	g, ctx := errgroup.WithContext(ctx)
+	for i := 0; i < cfg.Concurrency; i++ {
+		worker := i
+		g.Go(func() error {
+			local, err := replay(ctx, logger.New("worker", worker), events, cfg.Iterations, dial)
+			if err != nil {
+				return fmt.Errorf("worker %d: %w", worker, err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for kind, l := range local {
+				latencies[kind] = append(latencies[kind], l...)
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	report := &Report{Duration: time.Since(start)}
+	for kind, l := range latencies {
+		report.Requests += len(l)
+		report.Stats = append(report.Stats, summarize(kind, l))
+	}
+	sort.Slice(report.Stats, func(i, j int) bool {
+		return report.Stats[i].Kind < report.Stats[j].Kind
+	})
+	return report, nil
+}
+
+func replay(ctx context.Context, logger log.Logger, events []Event, iterations int, dial Dialer) (map[string][]time.Duration, error) {
+	conn, err := dial(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to host: %w", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			logger.Warn("Failed to close host connection", "err", err)
+		}
+	}()
+	latencies := make(map[string][]time.Duration)
+	for i := 0; i < iterations; i++ {
+		for j, ev := range events {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			start := time.Now()
+			if ev.Key != nil {
+				_, err = conn.GetPreimage(*ev.Key)
+			} else {
+				err = conn.Hint(ev.Hint)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to replay event %d (%v): %w", j, ev.Kind(), err)
+			}
+			latencies[ev.Kind()] = append(latencies[ev.Kind()], time.Since(start))
+		}
+		logger.Debug("Replayed trace", "iteration", i)
+	}
+	return latencies, nil
+}
+
+func summarize(kind string, latencies []time.Duration) LatencyStats {
+	slices.Sort(latencies)
+	// Nearest-rank percentile of the sorted latencies
+	percentile := func(p int) time.Duration {
+		rank := (p*len(latencies) + 99) / 100
+		return latencies[max(rank-1, 0)]
+	}
+	return LatencyStats{
+		Kind:  kind,
+		Count: len(latencies),
+		P50:   percentile(50),
+		P90:   percentile(90),
+		P99:   percentile(99),
+		Max:   latencies[len(latencies)-1],
+	}
+}
+
+// channelConn is a Conn over the hint and pre-image channels of a host.
+type channelConn struct {
+	hints     *preimage.HintWriter
+	preimages *preimage.OracleClient
+	closers   []func() error
+}
+
+// NewChannelConn creates a Conn that communicates with a host over the client end of its hint and pre-image channels.
+func NewChannelConn(hintRW preimage.FileChannel, preimageRW preimage.FileChannel) Conn {
+	return newChannelConn(hintRW, preimageRW, hintRW.Close, preimageRW.Close)
+}
+
+func newChannelConn(hintRW io.ReadWriter, preimageRW io.ReadWriter, closers ...func() error) *channelConn {
+	return &channelConn{
+		hints:     preimage.NewHintWriter(hintRW),
+		preimages: preimage.NewOracleClient(preimageRW),
+		closers:   closers,
+	}
+}
+
+// The oracle clients panic on I/O errors, as the fault proof program can't recover from them.
+// The load test reports them as errors instead.
+func recoverErr(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("host request failed: %v", r)
+	}
+}
+
+func (c *channelConn) Hint(hint string) (err error) {
+	defer recoverErr(&err)
+	c.hints.Hint(rawHint(hint))
+	return nil
+}
+
+func (c *channelConn) GetPreimage(key common.Hash) (data []byte, err error) {
+	defer recoverErr(&err)
+	return c.preimages.Get(rawKey(key)), nil
+}
+
+func (c *channelConn) Close() error {
+	var result error
+	for _, closer := range c.closers {
+		result = errors.Join(result, closer())
+	}
+	return result
+}
+
+type rawKey [32]byte
+
+func (k rawKey) PreimageKey() [32]byte {
+	return k
+}
+
+type rawHint string
+
+func (h rawHint) Hint() string {
+	return string(h)
+}
+
+// NewProcessDialer creates a Dialer that starts a new host process in server mode for every connection,
+// e.g. "op-program --server --l1 ... --l2 ... --datadir ...". The host is stopped when the connection is closed.
+func NewProcessDialer(logger log.Logger, name string, args ...string) Dialer {
+	return func(ctx context.Context) (Conn, error) {
+		pClientRW, pHostRW, err := preimage.CreateBidirectionalChannel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pre-image channel: %w", err)
+		}
+		hClientRW, hHostRW, err := preimage.CreateBidirectionalChannel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create hint channel: %w", err)
+		}
+		cmd := exec.Command(name, args...) // nosemgrep
+		cmd.Stdout = os.Stderr
+		cmd.Stderr = os.Stderr
+		cmd.ExtraFiles = []*os.File{
+			hHostRW.Reader(),
+			hHostRW.Writer(),
+			pHostRW.Reader(),
+			pHostRW.Writer(),
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("failed to start host: %w", err)
+		}
+		// The host ends are owned by the host process now.
+		_ = hHostRW.Close()
+		_ = pHostRW.Close()
+
+		// Abort blocked requests when the host exits.
+		ioCtx, cancelIO := context.WithCancelCause(ctx)
+		exited := make(chan error, 1)
+		go func() {
+			err := cmd.Wait()
+			cancelIO(fmt.Errorf("host exited: %w", err))
+			exited <- err
+		}()
+		return newChannelConn(
+			preimage.NewFilePoller(ioCtx, hClientRW, pollTimeout),
+			preimage.NewFilePoller(ioCtx, pClientRW, pollTimeout),
+			hClientRW.Close,
+			pClientRW.Close,
+			func() error {
+				// The host exits once its channels are closed.
+				select {
+				case err := <-exited:
+					return err
+				case <-time.After(10 * time.Second):
+					logger.Warn("Host did not exit, killing it")
+					_ = cmd.Process.Kill()
+					return <-exited
+				}
+			}), nil
+	}
+}
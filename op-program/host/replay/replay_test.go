@@ -0,0 +1,186 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func keccakKey(data []byte) common.Hash {
+	return preimage.Keccak256Key(crypto.Keccak256Hash(data)).PreimageKey()
+}
+
+func TestRecordAndReadTrace(t *testing.T) {
+	data := []byte("hello")
+	var buf bytes.Buffer
+	recorder := NewRecorder(&buf)
+	var hints []string
+	hinter := recorder.Hinter(func(hint string) error {
+		hints = append(hints, hint)
+		return nil
+	})
+	getter := recorder.Getter(func(key [32]byte) ([]byte, error) {
+		return data, nil
+	})
+	require.NoError(t, hinter("l1-block-header 0x1234"))
+	result, err := getter(keccakKey(data))
+	require.NoError(t, err)
+	require.Equal(t, data, result)
+	require.NoError(t, hinter("l2-state-node 0x5678"))
+	require.NoError(t, recorder.Err())
+	require.Equal(t, []string{"l1-block-header 0x1234", "l2-state-node 0x5678"}, hints)
+
+	events, err := ReadTrace(&buf)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Equal(t, "hint l1-block-header", events[0].Kind())
+	require.Equal(t, "preimage keccak256", events[1].Kind())
+	require.Equal(t, keccakKey(data), *events[1].Key)
+	require.Equal(t, "hint l2-state-node", events[2].Kind())
+}
+
+func TestReadInvalidTrace(t *testing.T) {
+	_, err := ReadTrace(strings.NewReader(`{"hint":"a"}` + "\n" + `{}`))
+	require.ErrorIs(t, err, ErrInvalidEvent)
+	require.ErrorContains(t, err, "line 2")
+
+	_, err = ReadTrace(strings.NewReader(`{"hint":"a","key":"0x0200000000000000000000000000000000000000000000000000000000000000"}`))
+	require.ErrorIs(t, err, ErrInvalidEvent)
+
+	_, err = ReadTrace(strings.NewReader(`not json`))
+	require.ErrorContains(t, err, "line 1")
+}
+
+// newTestDialer serves every connection with an in-process pre-image server and hint router.
+func newTestDialer(t *testing.T, preimages map[common.Hash][]byte, hintErr error) (Dialer, *int) {
+	var mu sync.Mutex
+	var hintCount int
+	dial := func(ctx context.Context) (Conn, error) {
+		pClientRW, pHostRW, err := preimage.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		hClientRW, hHostRW, err := preimage.CreateBidirectionalChannel()
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = pHostRW.Close()
+			_ = hHostRW.Close()
+		})
+		server := preimage.NewOracleServer(pHostRW)
+		go func() {
+			for {
+				err := server.NextPreimageRequest(func(key [32]byte) ([]byte, error) {
+					data, ok := preimages[key]
+					if !ok {
+						return nil, errors.New("not found")
+					}
+					return data, nil
+				})
+				if err != nil {
+					_ = pHostRW.Close()
+					return
+				}
+			}
+		}()
+		hints := preimage.NewHintReader(hHostRW)
+		go func() {
+			for {
+				err := hints.NextHint(func(hint string) error {
+					mu.Lock()
+					defer mu.Unlock()
+					hintCount++
+					return hintErr
+				})
+				if errors.Is(err, io.EOF) || errors.Is(err, fs.ErrClosed) {
+					return
+				}
+			}
+		}()
+		return NewChannelConn(hClientRW, pClientRW), nil
+	}
+	return dial, &hintCount
+}
+
+func TestRun(t *testing.T) {
+	a, b := []byte("a"), []byte("b")
+	preimages := map[common.Hash][]byte{keccakKey(a): a, keccakKey(b): b}
+	keyA, keyB := keccakKey(a), keccakKey(b)
+	events := []Event{
+		{Hint: "l1-block-header 0x01"},
+		{Key: &keyA},
+		{Hint: "l1-block-header 0x02"},
+		{Key: &keyB},
+		{Hint: "l2-code 0x03"},
+	}
+	dial, hintCount := newTestDialer(t, preimages, nil)
+	report, err := Run(context.Background(), testlog.Logger(t, log.LevelInfo), events, Config{Concurrency: 3, Iterations: 2}, dial)
+	require.NoError(t, err)
+	require.Equal(t, 30, report.Requests)
+	require.Equal(t, 18, *hintCount)
+
+	require.Len(t, report.Stats, 3)
+	expected := []struct {
+		kind  string
+		count int
+	}{
+		{"hint l1-block-header", 12},
+		{"hint l2-code", 6},
+		{"preimage keccak256", 12},
+	}
+	for i, exp := range expected {
+		stats := report.Stats[i]
+		require.Equal(t, exp.kind, stats.Kind)
+		require.Equal(t, exp.count, stats.Count)
+		require.LessOrEqual(t, stats.P50, stats.P90)
+		require.LessOrEqual(t, stats.P90, stats.P99)
+		require.LessOrEqual(t, stats.P99, stats.Max)
+		require.Positive(t, stats.Max)
+	}
+}
+
+func TestRunFailedRequest(t *testing.T) {
+	missing := keccakKey([]byte("missing"))
+	dial, _ := newTestDialer(t, nil, nil)
+	_, err := Run(context.Background(), testlog.Logger(t, log.LevelInfo), []Event{{Key: &missing}}, Config{Concurrency: 1, Iterations: 1}, dial)
+	require.ErrorContains(t, err, "failed to replay event 0 (preimage keccak256)")
+}
+
+func TestRunInvalidConfig(t *testing.T) {
+	dial, _ := newTestDialer(t, nil, nil)
+	_, err := Run(context.Background(), testlog.Logger(t, log.LevelInfo), nil, Config{Concurrency: 0, Iterations: 1}, dial)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+	_, err = Run(context.Background(), testlog.Logger(t, log.LevelInfo), nil, Config{Concurrency: 1, Iterations: 0}, dial)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestSummarize(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	stats := summarize("hint test", latencies)
+	require.Equal(t, LatencyStats{
+		Kind:  "hint test",
+		Count: 100,
+		P50:   50 * time.Millisecond,
+		P90:   90 * time.Millisecond,
+		P99:   99 * time.Millisecond,
+		Max:   100 * time.Millisecond,
+	}, stats)
+
+	single := summarize("hint test", []time.Duration{time.Second})
+	require.Equal(t, time.Second, single.P50)
+	require.Equal(t, time.Second, single.P99)
+}
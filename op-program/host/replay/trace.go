@@ -0,0 +1,126 @@
+// Package replay records the hints and pre-image requests received by the pre-image server,
+// and replays them against op-program hosts to measure how they perform under load.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// Event is a single hint or pre-image request of a trace. Exactly one of Hint and Key is set.
+type Event struct {
+	Hint string       `json:"hint,omitempty"`
+	Key  *common.Hash `json:"key,omitempty"`
+}
+
+// Kind returns the name the latency of the event is reported under:
+// the hint type for hints, and the key type for pre-image requests.
+func (e Event) Kind() string {
+	if e.Key != nil {
+		return "preimage " + keyTypeName(preimage.KeyType(e.Key[0]))
+	}
+	hintType, _, _ := strings.Cut(e.Hint, " ")
+	return "hint " + hintType
+}
+
+func keyTypeName(t preimage.KeyType) string {
+	switch t {
+	case preimage.LocalKeyType:
+		return "local"
+	case preimage.Keccak256KeyType:
+		return "keccak256"
+	case preimage.GlobalGenericKeyType:
+		return "global-generic"
+	case preimage.Sha256KeyType:
+		return "sha256"
+	case preimage.BlobKeyType:
+		return "blob"
+	case preimage.PrecompileKeyType:
+		return "precompile"
+	default:
+		return fmt.Sprintf("unknown-%d", t)
+	}
+}
+
+// Recorder writes the hints and pre-image requests that pass through it to a trace, one JSON event per line.
+// It is safe for concurrent use, so the hint router and the pre-image server can share it.
+type Recorder struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+	err error
+}
+
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Hinter records every hint before passing it to the wrapped handler.
+func (r *Recorder) Hinter(handler preimage.HintHandler) preimage.HintHandler {
+	return func(hint string) error {
+		r.record(Event{Hint: hint})
+		return handler(hint)
+	}
+}
+
+// Getter records every pre-image request before passing it to the wrapped getter.
+func (r *Recorder) Getter(getter preimage.PreimageGetter) preimage.PreimageGetter {
+	return func(key [32]byte) ([]byte, error) {
+		k := common.Hash(key)
+		r.record(Event{Key: &k})
+		return getter(key)
+	}
+}
+
+// Err returns the first error that occurred while writing the trace.
+// Recording stops after the first error, but the wrapped handlers keep serving requests.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) record(ev Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	if err := r.enc.Encode(ev); err != nil {
+		r.err = fmt.Errorf("failed to record trace event: %w", err)
+	}
+}
+
+var ErrInvalidEvent = errors.New("invalid trace event")
+
+// ReadTrace reads all events of a trace written by a Recorder.
+func ReadTrace(r io.Reader) ([]Event, error) {
+	var events []Event
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var ev Event
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			return nil, fmt.Errorf("failed to decode trace event on line %d: %w", line, err)
+		}
+		if (ev.Key == nil) == (ev.Hint == "") {
+			return nil, fmt.Errorf("%w on line %d: exactly one of hint and key must be set", ErrInvalidEvent, line)
+		}
+		events = append(events, ev)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read trace: %w", err)
+	}
+	return events, nil
+}
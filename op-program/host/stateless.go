@@ -0,0 +1,234 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// StatelessSource provides the L2 blocks to verify and the state required to build their execution witness.
+type StatelessSource interface {
+	InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error)
+	InfoAndTxsByNumber(ctx context.Context, number uint64) (eth.BlockInfo, types.Transactions, error)
+	ExecutionWitness(ctx context.Context, blockNum uint64) (*eth.ExecutionWitness, error)
+	NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
+	CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+type statelessL2Source struct {
+	*sources.EthClient
+	*sources.DebugClient
+}
+
+// VerifyStateless is the entry-point for the verify-stateless command.
+// It executes each L2 block in the configured range using only its execution witness.
+func VerifyStateless(ctx context.Context, logger log.Logger, cfg *config.StatelessConfig) error {
+	if err := cfg.Check(); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	l2RPC, err := client.NewRPC(ctx, logger, cfg.L2URL, client.WithDialBackoff(10))
+	if err != nil {
+		return fmt.Errorf("failed to setup L2 RPC: %w", err)
+	}
+	defer l2RPC.Close()
+	ethCl, err := sources.NewEthClient(l2RPC, logger, nil, &sources.EthClientConfig{
+		MaxRequestsPerBatch:   20,
+		MaxConcurrentRequests: 10,
+		ReceiptsCacheSize:     10,
+		TransactionsCacheSize: 10,
+		HeadersCacheSize:      100,
+		PayloadsCacheSize:     10,
+		TrustRPC:              false,
+		MustBePostMerge:       true,
+		RPCProviderKind:       sources.RPCKindStandard,
+		MethodResetDuration:   time.Minute,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create L2 client: %w", err)
+	}
+	source := &statelessL2Source{EthClient: ethCl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
+	verifier := NewStatelessVerifier(logger, cfg.L2ChainConfig, source, cfg.WitnessSource)
+	if err := verifier.VerifyRange(ctx, cfg.From, cfg.To); err != nil {
+		return err
+	}
+	logger.Info("Blocks successfully verified statelessly", "from", cfg.From, "to", cfg.To)
+	return nil
+}
+
+// StatelessVerifier checks that L2 blocks can be executed using only their execution witness,
+// and that the execution results in the same block.
+type StatelessVerifier struct {
+	logger        log.Logger
+	chainCfg      *params.ChainConfig
+	source        StatelessSource
+	witnessSource config.WitnessSource
+}
+
+func NewStatelessVerifier(logger log.Logger, chainCfg *params.ChainConfig, source StatelessSource, witnessSource config.WitnessSource) *StatelessVerifier {
+	return &StatelessVerifier{
+		logger:        logger,
+		chainCfg:      chainCfg,
+		source:        source,
+		witnessSource: witnessSource,
+	}
+}
+
+// VerifyRange verifies the blocks from and to (inclusive), stopping at the first block that fails verification.
+func (v *StatelessVerifier) VerifyRange(ctx context.Context, from uint64, to uint64) error {
+	for num := from; num <= to; num++ {
+		if err := v.VerifyBlock(ctx, num); err != nil {
+			return fmt.Errorf("block %d: %w", num, err)
+		}
+	}
+	return nil
+}
+
+// VerifyBlock retrieves the execution witness of the block and executes the block statelessly.
+func (v *StatelessVerifier) VerifyBlock(ctx context.Context, num uint64) error {
+	info, txs, err := v.source.InfoAndTxsByNumber(ctx, num)
+	if err != nil {
+		return fmt.Errorf("failed to fetch block: %w", err)
+	}
+	block, err := blockFromInfo(info, txs)
+	if err != nil {
+		return err
+	}
+	parent, err := v.headerByHash(ctx, block.ParentHash())
+	if err != nil {
+		return fmt.Errorf("failed to fetch parent: %w", err)
+	}
+	headers := func(hash common.Hash) *types.Header {
+		header, err := v.headerByHash(ctx, hash)
+		if err != nil {
+			v.logger.Warn("Failed to fetch ancestor header", "hash", hash, "err", err)
+			return nil
+		}
+		return header
+	}
+
+	witness, err := v.executionWitness(ctx, parent, block, headers)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve execution witness: %w", err)
+	}
+	if err := l2.ExecuteStateless(v.chainCfg, l2.NewWitnessOracle(witness), parent, block, headers); err != nil {
+		return err
+	}
+	v.logger.Info("Verified block statelessly", "block", eth.ToBlockID(block), "txs", len(txs),
+		"nodes", len(witness.State), "codes", len(witness.Codes))
+	return nil
+}
+
+func (v *StatelessVerifier) executionWitness(ctx context.Context, parent *types.Header, block *types.Block, headers l2.HeaderByHash) (*eth.ExecutionWitness, error) {
+	switch v.witnessSource {
+	case config.WitnessSourceRPC:
+		return v.source.ExecutionWitness(ctx, block.NumberU64())
+	case config.WitnessSourceOracle:
+		return v.recordWitness(ctx, parent, block, headers)
+	default:
+		return nil, fmt.Errorf("%w: %q", config.ErrInvalidWitnessSource, v.witnessSource)
+	}
+}
+
+// recordWitness builds the execution witness by executing the block with state retrieved from the source.
+func (v *StatelessVerifier) recordWitness(ctx context.Context, parent *types.Header, block *types.Block, headers l2.HeaderByHash) (witness *eth.ExecutionWitness, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var rErr stateFetchError
+			if e, ok := r.(error); ok && errors.As(e, &rErr) {
+				err = rErr
+				return
+			}
+			panic(r)
+		}
+	}()
+	recorder := l2.NewWitnessRecorder(&sourceStateOracle{ctx: ctx, source: v.source})
+	if err := l2.ExecuteStateless(v.chainCfg, recorder, parent, block, headers); err != nil {
+		return nil, err
+	}
+	return recorder.Witness(), nil
+}
+
+func (v *StatelessVerifier) headerByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	info, err := v.source.InfoByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return headerFromInfo(info)
+}
+
+func headerFromInfo(info eth.BlockInfo) (*types.Header, error) {
+	data, err := info.HeaderRLP()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode header %v: %w", info.Hash(), err)
+	}
+	var header types.Header
+	if err := rlp.DecodeBytes(data, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode header %v: %w", info.Hash(), err)
+	}
+	if header.Hash() != info.Hash() {
+		return nil, fmt.Errorf("header hash %v does not match block hash %v", header.Hash(), info.Hash())
+	}
+	return &header, nil
+}
+
+func blockFromInfo(info eth.BlockInfo, txs types.Transactions) (*types.Block, error) {
+	header, err := headerFromInfo(info)
+	if err != nil {
+		return nil, err
+	}
+	if txHash := types.DeriveSha(txs, trie.NewStackTrie(nil)); txHash != header.TxHash {
+		return nil, fmt.Errorf("transactions root %v does not match header %v", txHash, header.TxHash)
+	}
+	return types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs}), nil
+}
+
+// stateFetchError is used to panic out of block execution when state can't be retrieved from the source.
+type stateFetchError struct {
+	err error
+}
+
+func (e stateFetchError) Error() string {
+	return e.err.Error()
+}
+
+func (e stateFetchError) Unwrap() error {
+	return e.err
+}
+
+// sourceStateOracle adapts a StatelessSource to the l2.StateOracle interface.
+type sourceStateOracle struct {
+	ctx    context.Context
+	source StatelessSource
+}
+
+var _ l2.StateOracle = (*sourceStateOracle)(nil)
+
+func (o *sourceStateOracle) NodeByHash(nodeHash common.Hash) []byte {
+	node, err := o.source.NodeByHash(o.ctx, nodeHash)
+	if err != nil {
+		panic(stateFetchError{fmt.Errorf("failed to fetch state node %v: %w", nodeHash, err)})
+	}
+	return node
+}
+
+func (o *sourceStateOracle) CodeByHash(codeHash common.Hash) []byte {
+	code, err := o.source.CodeByHash(o.ctx, codeHash)
+	if err != nil {
+		panic(stateFetchError{fmt.Errorf("failed to fetch code %v: %w", codeHash, err)})
+	}
+	return code
+}
@@ -0,0 +1,182 @@
+package host
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestStatelessVerifier(t *testing.T) {
+	t.Run("Oracle", func(t *testing.T) {
+		source := newStubStatelessSource(t)
+		verifier := NewStatelessVerifier(testlog.Logger(t, log.LevelInfo), source.chainCfg, source, config.WitnessSourceOracle)
+		require.NoError(t, verifier.VerifyRange(context.Background(), 1, source.head()))
+	})
+
+	t.Run("RPC", func(t *testing.T) {
+		source := newStubStatelessSource(t)
+		source.recordWitnesses(t)
+		verifier := NewStatelessVerifier(testlog.Logger(t, log.LevelInfo), source.chainCfg, source, config.WitnessSourceRPC)
+		require.NoError(t, verifier.VerifyRange(context.Background(), 1, source.head()))
+	})
+
+	t.Run("IncompleteWitness", func(t *testing.T) {
+		source := newStubStatelessSource(t)
+		source.recordWitnesses(t)
+		witness := source.witnesses[2]
+		for hash := range witness.State {
+			delete(witness.State, hash)
+			break
+		}
+		verifier := NewStatelessVerifier(testlog.Logger(t, log.LevelInfo), source.chainCfg, source, config.WitnessSourceRPC)
+		err := verifier.VerifyRange(context.Background(), 1, source.head())
+		require.ErrorIs(t, err, l2.ErrMissingWitnessData)
+		require.ErrorContains(t, err, "block 2")
+	})
+
+	t.Run("StateUnavailable", func(t *testing.T) {
+		source := newStubStatelessSource(t)
+		source.db = rawdb.NewMemoryDatabase()
+		verifier := NewStatelessVerifier(testlog.Logger(t, log.LevelInfo), source.chainCfg, source, config.WitnessSourceOracle)
+		err := verifier.VerifyBlock(context.Background(), 1)
+		require.ErrorIs(t, err, errNotFound)
+	})
+
+	t.Run("InconsistentTransactions", func(t *testing.T) {
+		source := newStubStatelessSource(t)
+		source.txs[2] = source.txs[1]
+		verifier := NewStatelessVerifier(testlog.Logger(t, log.LevelInfo), source.chainCfg, source, config.WitnessSourceOracle)
+		err := verifier.VerifyBlock(context.Background(), 2)
+		require.ErrorContains(t, err, "transactions root")
+	})
+}
+
+var errNotFound = errors.New("not found")
+
+type stubStatelessSource struct {
+	chainCfg  *params.ChainConfig
+	db        ethdb.KeyValueReader
+	blocks    []*types.Block
+	txs       map[uint64]types.Transactions
+	witnesses map[uint64]*eth.ExecutionWitness
+}
+
+func newStubStatelessSource(t *testing.T) *stubStatelessSource {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	// Stores 1 in slot 0, to require code and storage in the witness
+	contract := common.Address{0xcc}
+	genesis := &core.Genesis{
+		Config:   params.MergedTestChainConfig,
+		GasLimit: 30_000_000,
+		BaseFee:  big.NewInt(params.InitialBaseFee),
+		Alloc: types.GenesisAlloc{
+			sender:   {Balance: big.NewInt(params.Ether)},
+			contract: {Code: common.FromHex("0x600160005500")},
+		},
+	}
+	signer := types.LatestSigner(genesis.Config)
+	db, blocks, _ := core.GenerateChainWithGenesis(genesis, beacon.New(ethash.NewFaker()), 3, func(i int, gen *core.BlockGen) {
+		tx := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   genesis.Config.ChainID,
+			Nonce:     gen.TxNonce(sender),
+			To:        &contract,
+			Gas:       100_000,
+			GasFeeCap: gen.BaseFee(),
+			Value:     big.NewInt(1),
+		})
+		gen.AddTx(tx)
+	})
+	source := &stubStatelessSource{
+		chainCfg:  genesis.Config,
+		db:        db,
+		blocks:    append([]*types.Block{genesis.ToBlock()}, blocks...),
+		txs:       make(map[uint64]types.Transactions),
+		witnesses: make(map[uint64]*eth.ExecutionWitness),
+	}
+	for _, block := range source.blocks {
+		source.txs[block.NumberU64()] = block.Transactions()
+	}
+	return source
+}
+
+func (s *stubStatelessSource) head() uint64 {
+	return uint64(len(s.blocks) - 1)
+}
+
+// recordWitnesses records the execution witness of every block, to serve as the debug_executionWitness result.
+func (s *stubStatelessSource) recordWitnesses(t *testing.T) {
+	for _, block := range s.blocks[1:] {
+		recorder := l2.NewWitnessRecorder(&sourceStateOracle{ctx: context.Background(), source: s})
+		parent := s.blocks[block.NumberU64()-1].Header()
+		require.NoError(t, l2.ExecuteStateless(s.chainCfg, recorder, parent, block, s.headerByHash))
+		s.witnesses[block.NumberU64()] = recorder.Witness()
+	}
+}
+
+func (s *stubStatelessSource) headerByHash(hash common.Hash) *types.Header {
+	for _, block := range s.blocks {
+		if block.Hash() == hash {
+			return block.Header()
+		}
+	}
+	return nil
+}
+
+func (s *stubStatelessSource) InfoByHash(_ context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	header := s.headerByHash(hash)
+	if header == nil {
+		return nil, errNotFound
+	}
+	return eth.HeaderBlockInfo(header), nil
+}
+
+func (s *stubStatelessSource) InfoAndTxsByNumber(_ context.Context, number uint64) (eth.BlockInfo, types.Transactions, error) {
+	if number >= uint64(len(s.blocks)) {
+		return nil, nil, errNotFound
+	}
+	return eth.BlockToInfo(s.blocks[number]), s.txs[number], nil
+}
+
+func (s *stubStatelessSource) ExecutionWitness(_ context.Context, blockNum uint64) (*eth.ExecutionWitness, error) {
+	witness, ok := s.witnesses[blockNum]
+	if !ok {
+		return nil, errNotFound
+	}
+	return witness, nil
+}
+
+func (s *stubStatelessSource) NodeByHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	node, err := s.db.Get(hash.Bytes())
+	if err != nil {
+		return nil, errNotFound
+	}
+	return node, nil
+}
+
+func (s *stubStatelessSource) CodeByHash(_ context.Context, hash common.Hash) ([]byte, error) {
+	code := rawdb.ReadCode(s.db, hash)
+	if len(code) == 0 {
+		return nil, errNotFound
+	}
+	return code, nil
+}
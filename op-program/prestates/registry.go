@@ -0,0 +1,137 @@
+// Package prestates is a registry of the absolute prestates of op-program releases,
+// to identify the prestate hashes of dispute games and find where to download them.
+package prestates
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+//go:embed releases.json
+var releasesJSON []byte
+
+var (
+	ErrUnknownPrestate = errors.New("unknown prestate")
+	ErrInvalidRegistry = errors.New("invalid prestate registry")
+)
+
+// Release is the absolute prestate of an op-program release.
+type Release struct {
+	// Version is the op-program release version, without the op-program/v tag prefix.
+	Version string `json:"version"`
+	// Hash is the absolute prestate hash, as used by dispute games.
+	Hash common.Hash `json:"hash"`
+	// Type is the VM the prestate is built for, e.g. cannon or cannon-mt.
+	Type string `json:"type"`
+	// GovernanceApproved is true if the prestate was approved for use on mainnet chains.
+	GovernanceApproved bool `json:"governanceApproved,omitempty"`
+	// URL is the download URL of the prestate. Optional, the prestate is expected at a prestates base URL otherwise.
+	URL string `json:"url,omitempty"`
+}
+
+// DownloadURL returns the URL to download the prestate from.
+// This is the URL of the release if set, or the location of the prestate at baseURL,
+// using the <hash>.json layout of the op-challenger prestates URL.
+// Returns nil if there is neither.
+func (r Release) DownloadURL(baseURL *url.URL) (*url.URL, error) {
+	if r.URL != "" {
+		return url.Parse(r.URL)
+	}
+	if baseURL == nil {
+		return nil, nil
+	}
+	return baseURL.JoinPath(r.Hash.Hex() + ".json"), nil
+}
+
+func (r Release) String() string {
+	approved := ""
+	if r.GovernanceApproved {
+		approved = ", governance approved"
+	}
+	return fmt.Sprintf("op-program v%s (%s%s)", r.Version, r.Type, approved)
+}
+
+// Registry maps prestate hashes to releases.
+type Registry struct {
+	releases []Release
+	byHash   map[common.Hash]Release
+}
+
+// Default returns the registry of releases embedded in this build.
+func Default() *Registry {
+	r, err := Parse(releasesJSON)
+	if err != nil {
+		panic(fmt.Errorf("embedded prestate registry: %w", err))
+	}
+	return r
+}
+
+// Load loads a registry from a JSON file, e.g. a newer version of the embedded releases.json.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prestate registry: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses a JSON list of releases.
+func Parse(data []byte) (*Registry, error) {
+	var releases []Release
+	if err := json.Unmarshal(data, &releases); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRegistry, err)
+	}
+	r := &Registry{
+		releases: releases,
+		byHash:   make(map[common.Hash]Release, len(releases)),
+	}
+	for _, release := range releases {
+		if release.Version == "" {
+			return nil, fmt.Errorf("%w: prestate %v has no version", ErrInvalidRegistry, release.Hash)
+		}
+		if release.Hash == (common.Hash{}) {
+			return nil, fmt.Errorf("%w: release %v has no prestate hash", ErrInvalidRegistry, release.Version)
+		}
+		if existing, ok := r.byHash[release.Hash]; ok {
+			return nil, fmt.Errorf("%w: prestate %v listed for both %v and %v", ErrInvalidRegistry, release.Hash, existing.Version, release.Version)
+		}
+		if release.URL != "" {
+			if _, err := url.Parse(release.URL); err != nil {
+				return nil, fmt.Errorf("%w: release %v has invalid URL: %w", ErrInvalidRegistry, release.Version, err)
+			}
+		}
+		r.byHash[release.Hash] = release
+	}
+	return r, nil
+}
+
+// Releases returns all releases, in the order of the registry.
+func (r *Registry) Releases() []Release {
+	return append([]Release(nil), r.releases...)
+}
+
+// ByHash returns the release of the prestate hash.
+func (r *Registry) ByHash(hash common.Hash) (Release, error) {
+	release, ok := r.byHash[hash]
+	if !ok {
+		return Release{}, fmt.Errorf("%w: %v", ErrUnknownPrestate, hash)
+	}
+	return release, nil
+}
+
+// ByVersion returns the prestates of the release version, one per VM type.
+func (r *Registry) ByVersion(version string) []Release {
+	var out []Release
+	for _, release := range r.releases {
+		if release.Version == version {
+			out = append(out, release)
+		}
+	}
+	return out
+}
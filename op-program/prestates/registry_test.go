@@ -0,0 +1,69 @@
+package prestates
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultRegistry(t *testing.T) {
+	// The embedded registry must always parse
+	require.NotNil(t, Default())
+}
+
+func TestRegistry(t *testing.T) {
+	registry, err := Parse([]byte(`[
+		{"version": "1.1.0", "hash": "0x0300000000000000000000000000000000000000000000000000000000000001", "type": "cannon", "governanceApproved": true},
+		{"version": "1.2.0", "hash": "0x0300000000000000000000000000000000000000000000000000000000000002", "type": "cannon"},
+		{"version": "1.2.0", "hash": "0x0300000000000000000000000000000000000000000000000000000000000003", "type": "cannon-mt", "url": "https://example.com/mt.json"}
+	]`))
+	require.NoError(t, err)
+	require.Len(t, registry.Releases(), 3)
+
+	release, err := registry.ByHash(common.HexToHash("0x0300000000000000000000000000000000000000000000000000000000000001"))
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", release.Version)
+	require.Equal(t, "op-program v1.1.0 (cannon, governance approved)", release.String())
+
+	_, err = registry.ByHash(common.Hash{0xaa})
+	require.ErrorIs(t, err, ErrUnknownPrestate)
+
+	versions := registry.ByVersion("1.2.0")
+	require.Len(t, versions, 2)
+	require.Equal(t, "cannon", versions[0].Type)
+	require.Equal(t, "cannon-mt", versions[1].Type)
+	require.Empty(t, registry.ByVersion("0.1.0"))
+
+	base, err := url.Parse("https://prestates.example.com/cannon")
+	require.NoError(t, err)
+	u, err := versions[0].DownloadURL(base)
+	require.NoError(t, err)
+	require.Equal(t, "https://prestates.example.com/cannon/0x0300000000000000000000000000000000000000000000000000000000000002.json", u.String())
+	u, err = versions[1].DownloadURL(base)
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/mt.json", u.String())
+	u, err = versions[0].DownloadURL(nil)
+	require.NoError(t, err)
+	require.Nil(t, u)
+}
+
+func TestInvalidRegistry(t *testing.T) {
+	tests := map[string]string{
+		"not json":        `{`,
+		"missing version": `[{"hash": "0x0300000000000000000000000000000000000000000000000000000000000001"}]`,
+		"missing hash":    `[{"version": "1.0.0"}]`,
+		"duplicate hash": `[
+			{"version": "1.0.0", "hash": "0x0300000000000000000000000000000000000000000000000000000000000001"},
+			{"version": "1.0.1", "hash": "0x0300000000000000000000000000000000000000000000000000000000000001"}
+		]`,
+		"invalid url": `[{"version": "1.0.0", "hash": "0x0300000000000000000000000000000000000000000000000000000000000001", "url": "://"}]`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			require.ErrorIs(t, err, ErrInvalidRegistry)
+		})
+	}
+}
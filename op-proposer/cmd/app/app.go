@@ -0,0 +1,29 @@
+package app
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/flags"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-proposer/proposer"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/metrics/doc"
+)
+
+// New creates the op-proposer app, running the proposer with the given version.
+func New(version string) *cli.App {
+	app := cli.NewApp()
+	app.Flags = cliapp.ProtectFlags(flags.Flags)
+	app.Version = version
+	app.Name = "op-proposer"
+	app.Usage = "L2 Output Submitter"
+	app.Description = "Service for generating and proposing L2 Outputs"
+	app.Action = cliapp.LifecycleCmd(proposer.Main(version))
+	app.Commands = []*cli.Command{
+		{
+			Name:        "doc",
+			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
+		},
+	}
+	return app
+}
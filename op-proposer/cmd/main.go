@@ -3,15 +3,10 @@ package main
 import (
 	"os"
 
+	"github.com/ethereum-optimism/optimism/op-proposer/cmd/app"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
-	"github.com/urfave/cli/v2"
-
-	"github.com/ethereum-optimism/optimism/op-proposer/flags"
-	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
-	"github.com/ethereum-optimism/optimism/op-proposer/proposer"
-	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
-	"github.com/ethereum-optimism/optimism/op-service/metrics/doc"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -23,22 +18,12 @@ var (
 
 func main() {
 	oplog.SetupDefaults()
+	appinfo.SetBuild(Version, GitCommit, GitDate)
 
-	app := cli.NewApp()
-	app.Flags = cliapp.ProtectFlags(flags.Flags)
-	app.Version = opservice.FormatVersion(Version, GitCommit, GitDate, "")
-	app.Name = "op-proposer"
-	app.Usage = "L2 Output Submitter"
-	app.Description = "Service for generating and proposing L2 Outputs"
-	app.Action = cliapp.LifecycleCmd(proposer.Main(Version))
-	app.Commands = []*cli.Command{
-		{
-			Name:        "doc",
-			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
-		},
-	}
+	proposerApp := app.New(Version)
+	proposerApp.Version = opservice.FormatVersion(Version, GitCommit, GitDate, "")
 
-	err := app.Run(os.Args)
+	err := proposerApp.Run(os.Args)
 	if err != nil {
 		log.Crit("Application failed", "message", err)
 	}
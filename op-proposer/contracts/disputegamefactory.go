@@ -21,9 +21,14 @@ const (
 	methodCreateGame  = "create"
 	methodVersion     = "version"
 
-	methodClaim = "claimData"
+	methodClaim         = "claimData"
+	methodL2BlockNumber = "l2BlockNumber"
+	methodStatus        = "status"
 )
 
+// gameStatusInProgress is the status of games that are not resolved yet.
+const gameStatusInProgress = 0
+
 type gameMetadata struct {
 	GameType  uint32
 	Timestamp time.Time
@@ -31,6 +36,15 @@ type gameMetadata struct {
 	Proposer  common.Address
 }
 
+// Proposal is a game created by a proposer.
+type Proposal struct {
+	Timestamp     time.Time
+	Address       common.Address
+	L2BlockNumber uint64
+	// InProgress is true if the game is not resolved yet.
+	InProgress bool
+}
+
 type DisputeGameFactory struct {
 	caller         *batching.MultiCaller
 	contract       *batching.BoundContract
@@ -63,29 +77,60 @@ func (f *DisputeGameFactory) Version(ctx context.Context) (string, error) {
 // given cut off time. If one is found, returns true and the time the game was created at.
 // If no matching proposal is found, returns false, time.Time{}, nil
 func (f *DisputeGameFactory) HasProposedSince(ctx context.Context, proposer common.Address, cutoff time.Time, gameType uint32) (bool, time.Time, error) {
+	game, found, err := f.findProposalSince(ctx, proposer, cutoff, gameType)
+	if err != nil || !found {
+		return false, time.Time{}, err
+	}
+	return true, game.Timestamp, nil
+}
+
+// LatestProposalSince attempts to find the latest game with the specified game type created by the specified proposer
+// after the given cut off time. If one is found, returns true and the proposal.
+// If no matching proposal is found, returns false, Proposal{}, nil
+func (f *DisputeGameFactory) LatestProposalSince(ctx context.Context, proposer common.Address, cutoff time.Time, gameType uint32) (bool, Proposal, error) {
+	game, found, err := f.findProposalSince(ctx, proposer, cutoff, gameType)
+	if err != nil || !found {
+		return false, Proposal{}, err
+	}
+	gameContract := batching.NewBoundContract(f.gameABI, game.Address)
+	cCtx, cancel := context.WithTimeout(ctx, f.networkTimeout)
+	defer cancel()
+	results, err := f.caller.Call(cCtx, rpcblock.Latest, gameContract.Call(methodL2BlockNumber), gameContract.Call(methodStatus))
+	if err != nil {
+		return false, Proposal{}, fmt.Errorf("failed to load proposal of game %v: %w", game.Address, err)
+	}
+	return true, Proposal{
+		Timestamp:     game.Timestamp,
+		Address:       game.Address,
+		L2BlockNumber: results[0].GetBigInt(0).Uint64(),
+		InProgress:    results[1].GetUint8(0) == gameStatusInProgress,
+	}, nil
+}
+
+func (f *DisputeGameFactory) findProposalSince(ctx context.Context, proposer common.Address, cutoff time.Time, gameType uint32) (gameMetadata, bool, error) {
 	gameCount, err := f.gameCount(ctx)
 	if err != nil {
-		return false, time.Time{}, fmt.Errorf("failed to get dispute game count: %w", err)
+		return gameMetadata{}, false, fmt.Errorf("failed to get dispute game count: %w", err)
 	}
 	if gameCount == 0 {
-		return false, time.Time{}, nil
+		return gameMetadata{}, false, nil
 	}
 	for idx := gameCount - 1; ; idx-- {
 		game, err := f.gameAtIndex(ctx, idx)
 		if err != nil {
-			return false, time.Time{}, fmt.Errorf("failed to get dispute game %d: %w", idx, err)
+			return gameMetadata{}, false, fmt.Errorf("failed to get dispute game %d: %w", idx, err)
 		}
 		if game.Timestamp.Before(cutoff) {
 			// Reached a game that is before the expected cutoff, so we haven't found a suitable proposal
-			return false, time.Time{}, nil
+			return gameMetadata{}, false, nil
 		}
 		if game.GameType == gameType && game.Proposer == proposer {
 			// Found a matching proposal
-			return true, game.Timestamp, nil
+			return game, true, nil
 		}
 		if idx == 0 { // Need to check here rather than in the for condition to avoid underflow
 			// Checked every game and didn't find a match
-			return false, time.Time{}, nil
+			return gameMetadata{}, false, nil
 		}
 	}
 }
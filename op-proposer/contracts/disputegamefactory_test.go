@@ -179,6 +179,66 @@ func TestHasProposedSince(t *testing.T) {
 	})
 }
 
+func TestLatestProposalSince(t *testing.T) {
+	cutOffTime := time.Unix(1000, 0)
+
+	t.Run("NoMatchingProposal", func(t *testing.T) {
+		stubRpc, factory := setupDisputeGameFactoryTest(t)
+		withClaims(stubRpc, gameMetadata{
+			GameType:  0,
+			Timestamp: time.Unix(999, 0),
+			Address:   common.Address{0x11},
+			Proposer:  proposerAddr,
+		})
+
+		proposed, proposal, err := factory.LatestProposalSince(context.Background(), proposerAddr, cutOffTime, 0)
+		require.NoError(t, err)
+		require.False(t, proposed)
+		require.Equal(t, Proposal{}, proposal)
+	})
+
+	t.Run("MatchingProposal", func(t *testing.T) {
+		stubRpc, factory := setupDisputeGameFactoryTest(t)
+		game := gameMetadata{
+			GameType:  0,
+			Timestamp: time.Unix(1100, 0),
+			Address:   common.Address{0x11},
+			Proposer:  proposerAddr,
+		}
+		withClaims(stubRpc, game)
+		stubRpc.SetResponse(game.Address, methodL2BlockNumber, rpcblock.Latest, nil, []interface{}{big.NewInt(456)})
+		stubRpc.SetResponse(game.Address, methodStatus, rpcblock.Latest, nil, []interface{}{uint8(gameStatusInProgress)})
+
+		proposed, proposal, err := factory.LatestProposalSince(context.Background(), proposerAddr, cutOffTime, 0)
+		require.NoError(t, err)
+		require.True(t, proposed)
+		require.Equal(t, Proposal{
+			Timestamp:     game.Timestamp,
+			Address:       game.Address,
+			L2BlockNumber: 456,
+			InProgress:    true,
+		}, proposal)
+	})
+
+	t.Run("ResolvedProposal", func(t *testing.T) {
+		stubRpc, factory := setupDisputeGameFactoryTest(t)
+		game := gameMetadata{
+			GameType:  0,
+			Timestamp: time.Unix(1100, 0),
+			Address:   common.Address{0x11},
+			Proposer:  proposerAddr,
+		}
+		withClaims(stubRpc, game)
+		stubRpc.SetResponse(game.Address, methodL2BlockNumber, rpcblock.Latest, nil, []interface{}{big.NewInt(456)})
+		stubRpc.SetResponse(game.Address, methodStatus, rpcblock.Latest, nil, []interface{}{uint8(2)}) // Defender wins
+
+		proposed, proposal, err := factory.LatestProposalSince(context.Background(), proposerAddr, cutOffTime, 0)
+		require.NoError(t, err)
+		require.True(t, proposed)
+		require.False(t, proposal.InProgress)
+	})
+}
+
 func TestProposalTx(t *testing.T) {
 	stubRpc, factory := setupDisputeGameFactoryTest(t)
 	traceType := uint32(123)
@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -60,6 +61,19 @@ var (
 		Usage:   "Interval between submitting L2 output proposals when the dispute game factory address is set",
 		EnvVars: prefixEnvVars("PROPOSAL_INTERVAL"),
 	}
+	ProposalStrategyFlag = &cli.StringFlag{
+		Name: "proposal-strategy",
+		Usage: "Strategy deciding when to propose to the dispute game factory, in addition to once per proposal interval. " +
+			"'interval' proposes once per proposal interval, 'blocks' every --proposal-blocks L2 blocks, " +
+			"and 'active-game' when the latest proposal is no longer in progress.",
+		Value:   "interval",
+		EnvVars: prefixEnvVars("PROPOSAL_STRATEGY"),
+	}
+	ProposalBlocksFlag = &cli.Uint64Flag{
+		Name:    "proposal-blocks",
+		Usage:   "Number of L2 blocks between proposals of the 'blocks' proposal strategy",
+		EnvVars: prefixEnvVars("PROPOSAL_BLOCKS"),
+	}
 	DisputeGameTypeFlag = &cli.UintFlag{
 		Name:    "game-type",
 		Usage:   "Dispute game type to create via the configured DisputeGameFactory",
@@ -79,6 +93,25 @@ var (
 		Value:   false,
 		EnvVars: prefixEnvVars("WAIT_NODE_SYNC"),
 	}
+	SimulateFlag = &cli.BoolFlag{
+		Name: "simulate",
+		Usage: "Estimate and report the cost (bond + gas) of each proposal without submitting it. " +
+			"Intended to evaluate proposal costs before enabling the proposer.",
+		Value:   false,
+		EnvVars: prefixEnvVars("SIMULATE"),
+	}
+	MaxL1BaseFeeFlag = &cli.Float64Flag{
+		Name:    "max-l1-base-fee",
+		Usage:   "Delay proposals while the L1 base fee (in GWei) exceeds this ceiling. 0 disables the ceiling.",
+		Value:   0,
+		EnvVars: prefixEnvVars("MAX_L1_BASE_FEE"),
+	}
+	MaxL1BlobBaseFeeFlag = &cli.Float64Flag{
+		Name:    "max-l1-blob-base-fee",
+		Usage:   "Delay proposals while the L1 blob base fee (in GWei) exceeds this ceiling. 0 disables the ceiling.",
+		Value:   0,
+		EnvVars: prefixEnvVars("MAX_L1_BLOB_BASE_FEE"),
+	}
 	// Legacy Flags
 	L2OutputHDPathFlag = txmgr.L2OutputHDPathFlag
 )
@@ -95,9 +128,14 @@ var optionalFlags = []cli.Flag{
 	L2OutputHDPathFlag,
 	DisputeGameFactoryAddressFlag,
 	ProposalIntervalFlag,
+	ProposalStrategyFlag,
+	ProposalBlocksFlag,
 	DisputeGameTypeFlag,
 	ActiveSequencerCheckDurationFlag,
 	WaitNodeSyncFlag,
+	SimulateFlag,
+	MaxL1BaseFeeFlag,
+	MaxL1BlobBaseFeeFlag,
 }
 
 func init() {
@@ -106,6 +144,7 @@ func init() {
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, addrcheck.CLIFlags(EnvVarPrefix, "")...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -2,6 +2,8 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -34,6 +36,10 @@ type Metricer interface {
 	StartBalanceMetrics(l log.Logger, client *ethclient.Client, account common.Address) io.Closer
 
 	RecordL2BlocksProposed(l2ref eth.L2BlockRef)
+
+	RecordProposalCost(bond *big.Int, gasCost *big.Int)
+	RecordProposalDelayed()
+	RecordProposalLag(blocks uint64, age time.Duration)
 }
 
 type Metrics struct {
@@ -47,6 +53,14 @@ type Metrics struct {
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
+
+	proposalBond     prometheus.Gauge
+	proposalGasCost  prometheus.Gauge
+	proposalCost     prometheus.Counter
+	proposalsDelayed prometheus.Counter
+
+	proposalLagBlocks  prometheus.Gauge
+	proposalLagSeconds prometheus.Gauge
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -81,6 +95,36 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-proposer has finished starting up",
 		}),
+		proposalBond: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "proposal_bond",
+			Help:      "Bond in ETH required by the last estimated proposal",
+		}),
+		proposalGasCost: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "proposal_gas_cost",
+			Help:      "Gas cost in ETH of the last estimated proposal",
+		}),
+		proposalCost: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "proposal_cost_total",
+			Help:      "Cumulative estimated cost in ETH (bond + gas) of all simulated proposals",
+		}),
+		proposalsDelayed: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "proposals_delayed_total",
+			Help:      "Number of times proposing was delayed because L1 fees exceeded the configured ceiling, counted once until fees drop below it",
+		}),
+		proposalLagBlocks: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "proposal_lag_blocks",
+			Help:      "Number of proposable L2 blocks since the latest proposal",
+		}),
+		proposalLagSeconds: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "proposal_lag_seconds",
+			Help:      "Seconds since the latest proposal was made",
+		}),
 	}
 }
 
@@ -113,6 +157,25 @@ func (m *Metrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(BlockProposed, l2ref)
 }
 
+// RecordProposalCost records the estimated cost of a simulated proposal
+func (m *Metrics) RecordProposalCost(bond *big.Int, gasCost *big.Int) {
+	m.proposalBond.Set(eth.WeiToEther(bond))
+	m.proposalGasCost.Set(eth.WeiToEther(gasCost))
+	m.proposalCost.Add(eth.WeiToEther(new(big.Int).Add(bond, gasCost)))
+}
+
+// RecordProposalDelayed should be called when proposing starts being delayed due to high L1 fees,
+// not again on every retry while the fees stay high.
+func (m *Metrics) RecordProposalDelayed() {
+	m.proposalsDelayed.Inc()
+}
+
+// RecordProposalLag records the distance between the latest proposal and the current proposable L2 block
+func (m *Metrics) RecordProposalLag(blocks uint64, age time.Duration) {
+	m.proposalLagBlocks.Set(float64(blocks))
+	m.proposalLagSeconds.Set(age.Seconds())
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -2,6 +2,8 @@ package metrics
 
 import (
 	"io"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -25,6 +27,10 @@ func (*noopMetrics) RecordUp()                 {}
 
 func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {}
 
+func (*noopMetrics) RecordProposalCost(*big.Int, *big.Int)   {}
+func (*noopMetrics) RecordProposalDelayed()                  {}
+func (*noopMetrics) RecordProposalLag(uint64, time.Duration) {}
+
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
 }
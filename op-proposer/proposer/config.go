@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-proposer/flags"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -47,6 +48,8 @@ type CLIConfig struct {
 
 	PprofConfig oppprof.CLIConfig
 
+	AddrCheckConfig addrcheck.CLIConfig
+
 	// DGFAddress is the DisputeGameFactory contract address.
 	DGFAddress string
 
@@ -61,6 +64,22 @@ type CLIConfig struct {
 
 	// Whether to wait for the sequencer to sync to a recent block at startup.
 	WaitNodeSync bool
+
+	// Simulate enables the simulation mode, in which proposal costs are estimated and reported,
+	// but no proposals are submitted.
+	Simulate bool
+
+	// MaxL1BaseFeeGwei is the L1 base fee ceiling above which proposals are delayed. 0 disables it.
+	MaxL1BaseFeeGwei float64
+
+	// MaxL1BlobBaseFeeGwei is the L1 blob base fee ceiling above which proposals are delayed. 0 disables it.
+	MaxL1BlobBaseFeeGwei float64
+
+	// ProposalStrategy is the name of the strategy that decides when to propose within the ProposalInterval.
+	ProposalStrategy string
+
+	// ProposalBlocks is the number of L2 blocks between proposals of the blocks proposal strategy.
+	ProposalBlocks uint64
 }
 
 func (c *CLIConfig) Check() error {
@@ -76,6 +95,9 @@ func (c *CLIConfig) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.AddrCheckConfig.Check(); err != nil {
+		return err
+	}
 
 	if c.DGFAddress == "" && c.L2OOAddress == "" {
 		return errors.New("neither the `DisputeGameFactory` nor `L2OutputOracle` address was provided")
@@ -89,6 +111,18 @@ func (c *CLIConfig) Check() error {
 	if c.ProposalInterval != 0 && c.DGFAddress == "" {
 		return errors.New("the `ProposalInterval` was provided but the `DisputeGameFactory` address was not set")
 	}
+	if c.ProposalStrategy != "" && c.ProposalStrategy != IntervalStrategyName && c.DGFAddress == "" {
+		return errors.New("the `ProposalStrategy` was provided but the `DisputeGameFactory` address was not set")
+	}
+	if _, err := NewProposalStrategy(c.ProposalStrategy, c.ProposalBlocks); err != nil {
+		return err
+	}
+	if c.MaxL1BaseFeeGwei < 0 {
+		return errors.New("the `MaxL1BaseFee` must not be negative")
+	}
+	if c.MaxL1BlobBaseFeeGwei < 0 {
+		return errors.New("the `MaxL1BlobBaseFee` must not be negative")
+	}
 
 	return nil
 }
@@ -108,10 +142,16 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		LogConfig:                    oplog.ReadCLIConfig(ctx),
 		MetricsConfig:                opmetrics.ReadCLIConfig(ctx),
 		PprofConfig:                  oppprof.ReadCLIConfig(ctx),
+		AddrCheckConfig:              addrcheck.ReadCLIConfig(ctx),
 		DGFAddress:                   ctx.String(flags.DisputeGameFactoryAddressFlag.Name),
 		ProposalInterval:             ctx.Duration(flags.ProposalIntervalFlag.Name),
 		DisputeGameType:              uint32(ctx.Uint(flags.DisputeGameTypeFlag.Name)),
 		ActiveSequencerCheckDuration: ctx.Duration(flags.ActiveSequencerCheckDurationFlag.Name),
 		WaitNodeSync:                 ctx.Bool(flags.WaitNodeSyncFlag.Name),
+		Simulate:                     ctx.Bool(flags.SimulateFlag.Name),
+		MaxL1BaseFeeGwei:             ctx.Float64(flags.MaxL1BaseFeeFlag.Name),
+		MaxL1BlobBaseFeeGwei:         ctx.Float64(flags.MaxL1BlobBaseFeeFlag.Name),
+		ProposalStrategy:             ctx.String(flags.ProposalStrategyFlag.Name),
+		ProposalBlocks:               ctx.Uint64(flags.ProposalBlocksFlag.Name),
 	}
 }
@@ -0,0 +1,184 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// L1Fees are the L1 fee conditions a proposal would be submitted under.
+type L1Fees struct {
+	BaseFee *big.Int
+	TipCap  *big.Int
+	// BlobBaseFee is nil if the L1 chain has not activated 4844 yet.
+	BlobBaseFee *big.Int
+}
+
+// ProposalCost is the estimated full cost of submitting a single proposal.
+type ProposalCost struct {
+	// Bond is the value sent with the proposal, i.e. the DisputeGameFactory init bond. Zero for the L2OutputOracle.
+	Bond *big.Int
+	// Gas is the estimated gas used by the proposal transaction.
+	Gas uint64
+	// GasPrice is the effective gas price the cost estimate is based on (base fee + tip).
+	GasPrice *big.Int
+	// GasCost is Gas * GasPrice.
+	GasCost *big.Int
+}
+
+// Total returns the total cost of the proposal: the bond plus the gas cost.
+func (c *ProposalCost) Total() *big.Int {
+	return new(big.Int).Add(c.Bond, c.GasCost)
+}
+
+// ProposalPolicy decides whether a proposal should be delayed under the current L1 fee conditions.
+type ProposalPolicy interface {
+	// ShouldDelay returns true, and a human-readable reason, if the proposal should be delayed.
+	ShouldDelay(fees L1Fees) (bool, string)
+}
+
+// FeeCeilingPolicy delays proposals while the L1 base fee or blob base fee exceed a ceiling.
+// A nil ceiling is not enforced.
+type FeeCeilingPolicy struct {
+	MaxBaseFee     *big.Int
+	MaxBlobBaseFee *big.Int
+}
+
+// NewFeeCeilingPolicy creates a FeeCeilingPolicy from the ceilings specified in GWei.
+// A ceiling of 0 is not enforced.
+func NewFeeCeilingPolicy(maxBaseFeeGwei float64, maxBlobBaseFeeGwei float64) (*FeeCeilingPolicy, error) {
+	var policy FeeCeilingPolicy
+	if maxBaseFeeGwei != 0 {
+		maxBaseFee, err := eth.GweiToWei(maxBaseFeeGwei)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max L1 base fee: %w", err)
+		}
+		policy.MaxBaseFee = maxBaseFee
+	}
+	if maxBlobBaseFeeGwei != 0 {
+		maxBlobBaseFee, err := eth.GweiToWei(maxBlobBaseFeeGwei)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max L1 blob base fee: %w", err)
+		}
+		policy.MaxBlobBaseFee = maxBlobBaseFee
+	}
+	return &policy, nil
+}
+
+func (p *FeeCeilingPolicy) ShouldDelay(fees L1Fees) (bool, string) {
+	if p.MaxBaseFee != nil && fees.BaseFee != nil && fees.BaseFee.Cmp(p.MaxBaseFee) > 0 {
+		return true, fmt.Sprintf("L1 base fee %v exceeds ceiling %v", fees.BaseFee, p.MaxBaseFee)
+	}
+	if p.MaxBlobBaseFee != nil && fees.BlobBaseFee != nil && fees.BlobBaseFee.Cmp(p.MaxBlobBaseFee) > 0 {
+		return true, fmt.Sprintf("L1 blob base fee %v exceeds ceiling %v", fees.BlobBaseFee, p.MaxBlobBaseFee)
+	}
+	return false, ""
+}
+
+// FetchL1Fees retrieves the current L1 fee conditions from the L1 head block.
+func (l *L2OutputSubmitter) FetchL1Fees(ctx context.Context) (L1Fees, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	head, err := l.L1Client.HeaderByNumber(cCtx, nil)
+	if err != nil {
+		return L1Fees{}, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	if head.BaseFee == nil {
+		return L1Fees{}, errors.New("pre-london L1 blocks without base fee are not supported")
+	}
+	tip, err := l.L1Client.SuggestGasTipCap(cCtx)
+	if err != nil {
+		return L1Fees{}, fmt.Errorf("failed to fetch suggested gas tip cap: %w", err)
+	}
+	fees := L1Fees{
+		BaseFee: head.BaseFee,
+		TipCap:  tip,
+	}
+	if head.ExcessBlobGas != nil {
+		fees.BlobBaseFee = eip4844.CalcBlobFee(*head.ExcessBlobGas)
+	}
+	return fees, nil
+}
+
+// EstimateProposalCost estimates the full cost (bond + gas) of proposing the given output under the given L1 fees.
+func (l *L2OutputSubmitter) EstimateProposalCost(ctx context.Context, output *eth.OutputResponse, fees L1Fees) (*ProposalCost, error) {
+	candidate, err := l.proposalTxCandidate(ctx, output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create proposal tx candidate: %w", err)
+	}
+	bond := candidate.Value
+	if bond == nil {
+		bond = new(big.Int)
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.Cfg.NetworkTimeout)
+	defer cancel()
+	gas, err := l.L1Client.EstimateGas(cCtx, ethereum.CallMsg{
+		From:  l.Txmgr.From(),
+		To:    candidate.To,
+		Value: bond,
+		Data:  candidate.TxData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate proposal gas: %w", err)
+	}
+	gasPrice := new(big.Int).Add(fees.BaseFee, fees.TipCap)
+	return &ProposalCost{
+		Bond:     bond,
+		Gas:      gas,
+		GasPrice: gasPrice,
+		GasCost:  new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gas)),
+	}, nil
+}
+
+// proposalTxCandidate creates the tx candidate that proposes the given output
+// to either the DisputeGameFactory or the L2OutputOracle.
+func (l *L2OutputSubmitter) proposalTxCandidate(ctx context.Context, output *eth.OutputResponse) (txmgr.TxCandidate, error) {
+	if l.Cfg.DisputeGameFactoryAddr != nil {
+		return l.ProposeL2OutputDGFTxCandidate(ctx, output)
+	}
+	if l.Cfg.Simulate && l.l2ooNextBlock != 0 && output.BlockRef.Number > l.l2ooNextBlock {
+		// The L2OutputOracle only accepts proposals of its next checkpoint, which simulated proposals run ahead of.
+		// The gas used doesn't depend on the block number, so the cost is estimated as a proposal of the next checkpoint.
+		onchain := *output
+		onchain.BlockRef.Number = l.l2ooNextBlock
+		output = &onchain
+	}
+	data, err := l.ProposeL2OutputTxData(output)
+	if err != nil {
+		return txmgr.TxCandidate{}, err
+	}
+	return txmgr.TxCandidate{
+		TxData:   data,
+		To:       l.Cfg.L2OutputOracleAddr,
+		GasLimit: 0,
+	}, nil
+}
+
+// simulateProposal estimates and reports the cost of proposing the given output, without submitting it.
+func (l *L2OutputSubmitter) simulateProposal(ctx context.Context, output *eth.OutputResponse, fees L1Fees) error {
+	cost, err := l.EstimateProposalCost(ctx, output, fees)
+	if err != nil {
+		return err
+	}
+	l.Log.Info("Simulated proposal",
+		"block", output.BlockRef,
+		"bond", cost.Bond,
+		"gas", cost.Gas,
+		"gasPrice", cost.GasPrice,
+		"gasCost", cost.GasCost,
+		"total", cost.Total(),
+		"baseFee", fees.BaseFee,
+		"blobBaseFee", fees.BlobBaseFee)
+	l.Metr.RecordProposalCost(cost.Bond, cost.GasCost)
+	l.lastSimulated = time.Now()
+	l.lastSimulatedBlock = output.BlockRef.Number
+	return nil
+}
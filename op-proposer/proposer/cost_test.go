@@ -0,0 +1,214 @@
+package proposer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/bindings"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	txmgrmocks "github.com/ethereum-optimism/optimism/op-service/txmgr/mocks"
+)
+
+type stubL1Client struct {
+	head *types.Header
+	tip  *big.Int
+	gas  uint64
+
+	estimated ethereum.CallMsg
+}
+
+func (s *stubL1Client) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
+	return s.head, nil
+}
+
+func (s *stubL1Client) CodeAt(_ context.Context, _ common.Address, _ *big.Int) ([]byte, error) {
+	panic("not implemented")
+}
+
+func (s *stubL1Client) CallContract(_ context.Context, _ ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	panic("not implemented")
+}
+
+func (s *stubL1Client) SuggestGasTipCap(_ context.Context) (*big.Int, error) {
+	return s.tip, nil
+}
+
+func (s *stubL1Client) EstimateGas(_ context.Context, call ethereum.CallMsg) (uint64, error) {
+	s.estimated = call
+	return s.gas, nil
+}
+
+func TestFeeCeilingPolicy(t *testing.T) {
+	policy, err := NewFeeCeilingPolicy(10, 0)
+	require.NoError(t, err)
+	require.Nil(t, policy.MaxBlobBaseFee)
+
+	delay, _ := policy.ShouldDelay(L1Fees{BaseFee: big.NewInt(10_000_000_000), BlobBaseFee: big.NewInt(1_000_000_000_000)})
+	require.False(t, delay, "should not delay at the ceiling or for unbounded blob base fee")
+
+	delay, reason := policy.ShouldDelay(L1Fees{BaseFee: big.NewInt(10_000_000_001)})
+	require.True(t, delay)
+	require.Contains(t, reason, "L1 base fee")
+
+	policy, err = NewFeeCeilingPolicy(0, 1)
+	require.NoError(t, err)
+	require.Nil(t, policy.MaxBaseFee)
+
+	delay, _ = policy.ShouldDelay(L1Fees{BaseFee: big.NewInt(100_000_000_000)})
+	require.False(t, delay, "should not delay pre-4844")
+
+	delay, reason = policy.ShouldDelay(L1Fees{BaseFee: big.NewInt(1), BlobBaseFee: big.NewInt(1_000_000_001)})
+	require.True(t, delay)
+	require.Contains(t, reason, "L1 blob base fee")
+}
+
+func setupCostTest(t *testing.T, l1 L1Client) (*L2OutputSubmitter, *txmgrmocks.TxManager, *testlog.CapturingHandler) {
+	l2OutputOracleAddr := common.HexToAddress("0x3F8A862E63E759a77DA22d384027D21BF096bA9E")
+	parsed, err := bindings.L2OutputOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	txmgr := txmgrmocks.NewTxManager(t)
+	lgr, logs := testlog.CaptureLogger(t, log.LevelDebug)
+	return &L2OutputSubmitter{
+		DriverSetup: DriverSetup{
+			Log:  lgr,
+			Metr: metrics.NoopMetrics,
+			Cfg: ProposerConfig{
+				NetworkTimeout:     time.Second,
+				L2OutputOracleAddr: &l2OutputOracleAddr,
+			},
+			Txmgr:    txmgr,
+			L1Client: l1,
+		},
+		done:    make(chan struct{}),
+		l2ooABI: parsed,
+	}, txmgr, logs
+}
+
+func TestSimulateProposal(t *testing.T) {
+	l1 := &stubL1Client{
+		head: &types.Header{BaseFee: big.NewInt(100)},
+		tip:  big.NewInt(2),
+		gas:  50_000,
+	}
+	ps, txmgr, logs := setupCostTest(t, l1)
+	ps.Cfg.Simulate = true
+	txmgr.On("From").Return(common.Address{0xab})
+
+	output := &eth.OutputResponse{
+		Version:  supportedL2OutputVersion,
+		BlockRef: eth.L2BlockRef{Number: 42},
+		Status:   &eth.SyncStatus{},
+	}
+	fees, err := ps.FetchL1Fees(context.Background())
+	require.NoError(t, err)
+	require.Nil(t, fees.BlobBaseFee)
+
+	cost, err := ps.EstimateProposalCost(context.Background(), output, fees)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(0), cost.Bond)
+	require.Equal(t, uint64(50_000), cost.Gas)
+	require.Equal(t, big.NewInt(102), cost.GasPrice)
+	require.Equal(t, big.NewInt(5_100_000), cost.Total())
+	require.Equal(t, ps.Cfg.L2OutputOracleAddr, l1.estimated.To)
+
+	ps.proposeOutput(context.Background(), output)
+	require.NotNil(t, logs.FindLog(testlog.NewMessageFilter("Simulated proposal")))
+	require.Equal(t, uint64(42), ps.lastSimulatedBlock)
+	txmgr.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestDelayProposalOnHighFees(t *testing.T) {
+	ps, txmgr, logs := setupCostTest(t, &stubL1Client{
+		head: &types.Header{BaseFee: big.NewInt(2_000_000_000)},
+		tip:  big.NewInt(1),
+	})
+	policy, err := NewFeeCeilingPolicy(1, 0)
+	require.NoError(t, err)
+	ps.Policy = policy
+	m := &delayCountingMetrics{Metricer: metrics.NoopMetrics}
+	ps.Metr = m
+
+	output := &eth.OutputResponse{
+		Version:  supportedL2OutputVersion,
+		BlockRef: eth.L2BlockRef{Number: 42},
+		Status:   &eth.SyncStatus{},
+	}
+	// Retrying on every tick while the fees stay high counts as a single delay
+	for i := 0; i < 3; i++ {
+		ps.proposeOutput(context.Background(), output)
+	}
+	require.Len(t, logs.FindLogs(testlog.NewMessageFilter("Delaying proposal")), 1)
+	require.Equal(t, 1, m.delayed)
+	txmgr.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+type delayCountingMetrics struct {
+	metrics.Metricer
+	delayed int
+}
+
+func (m *delayCountingMetrics) RecordProposalDelayed() {
+	m.delayed++
+}
+
+func TestSimulateProposal_L2OOAdvancesCheckpoint(t *testing.T) {
+	l1 := &stubL1Client{
+		head: &types.Header{BaseFee: big.NewInt(100)},
+		tip:  big.NewInt(2),
+		gas:  50_000,
+	}
+	ps, txmgr, logs := setupCostTest(t, l1)
+	ps.Cfg.Simulate = true
+	txmgr.On("From").Return(common.Address{0xab})
+	ep := newEndpointProvider()
+	ps.RollupProvider = ep
+	l2oo := new(MockL2OOContract)
+	ps.l2ooContract = l2oo
+	l2oo.On("NextBlockNumber", mock.Anything).Return(big.NewInt(42), nil)
+	l2oo.On("SubmissionInterval", mock.Anything).Return(big.NewInt(10), nil)
+	ep.rollupClient.On("SyncStatus").Return(&eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 60}}, nil)
+	outputAt := func(n uint64) *eth.OutputResponse {
+		return &eth.OutputResponse{
+			Version:  supportedL2OutputVersion,
+			BlockRef: eth.L2BlockRef{Number: n},
+			Status:   &eth.SyncStatus{FinalizedL2: eth.L2BlockRef{Number: 60}},
+		}
+	}
+	ep.rollupClient.ExpectOutputAtBlock(42, outputAt(42), nil)
+	ep.rollupClient.ExpectOutputAtBlock(52, outputAt(52), nil)
+
+	// The on-chain checkpoint never advances, as simulated proposals are not submitted
+	for _, expected := range []uint64{42, 52} {
+		output, shouldPropose, err := ps.FetchL2OOOutput(context.Background())
+		require.NoError(t, err)
+		require.True(t, shouldPropose)
+		require.Equal(t, expected, output.BlockRef.Number)
+
+		ps.proposeOutput(context.Background(), output)
+		require.Equal(t, expected, ps.lastSimulatedBlock)
+		// The gas is estimated for a proposal the contract accepts, i.e. of its next checkpoint
+		args, err := ps.l2ooABI.Methods["proposeL2Output"].Inputs.Unpack(l1.estimated.Data[4:])
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(42), args[1])
+	}
+	require.Len(t, logs.FindLogs(testlog.NewMessageFilter("Simulated proposal")), 2)
+
+	// The checkpoint after the last simulated proposal is not finalized yet
+	output, shouldPropose, err := ps.FetchL2OOOutput(context.Background())
+	require.NoError(t, err)
+	require.False(t, shouldPropose)
+	require.Nil(t, output)
+	ep.rollupClient.AssertExpectations(t)
+	txmgr.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
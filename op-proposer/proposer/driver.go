@@ -37,16 +37,21 @@ type L1Client interface {
 	// CallContract executes an Ethereum contract call with the specified data as the
 	// input.
 	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+
+	// SuggestGasTipCap and EstimateGas are used to estimate proposal costs.
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
 }
 
 type L2OOContract interface {
 	Version(*bind.CallOpts) (string, error)
 	NextBlockNumber(*bind.CallOpts) (*big.Int, error)
+	SubmissionInterval(*bind.CallOpts) (*big.Int, error)
 }
 
 type DGFContract interface {
 	Version(ctx context.Context) (string, error)
-	HasProposedSince(ctx context.Context, proposer common.Address, cutoff time.Time, gameType uint32) (bool, time.Time, error)
+	LatestProposalSince(ctx context.Context, proposer common.Address, cutoff time.Time, gameType uint32) (bool, contracts.Proposal, error)
 	ProposalTx(ctx context.Context, gameType uint32, outputRoot common.Hash, l2BlockNum uint64) (txmgr.TxCandidate, error)
 }
 
@@ -65,6 +70,13 @@ type DriverSetup struct {
 
 	// RollupProvider's RollupClient() is used to retrieve output roots from
 	RollupProvider dial.RollupProvider
+
+	// Policy is optional, and if set, is consulted before each proposal to decide whether it should be delayed.
+	Policy ProposalPolicy
+
+	// Strategy decides when to propose to the DisputeGameFactory, within the proposal interval.
+	// Optional, defaults to proposing once per proposal interval.
+	Strategy ProposalStrategy
 }
 
 // L2OutputSubmitter is responsible for proposing outputs
@@ -84,6 +96,17 @@ type L2OutputSubmitter struct {
 	l2ooABI      *abi.ABI
 
 	dgfContract DGFContract
+
+	// lastSimulated and lastSimulatedBlock track the last simulated proposal,
+	// as simulated proposals do not show up on-chain.
+	lastSimulated      time.Time
+	lastSimulatedBlock uint64
+	// l2ooNextBlock is the next checkpoint block of the L2OutputOracle when it was last queried.
+	// Simulated proposals run ahead of it, as they never advance it on-chain.
+	l2ooNextBlock uint64
+	// delayed is whether the Policy delayed the last attempted proposal.
+	// Only the loop goroutine accesses it.
+	delayed bool
 }
 
 // NewL2OutputSubmitter creates a new L2 Output Submitter
@@ -233,6 +256,16 @@ func (l *L2OutputSubmitter) FetchL2OOOutput(ctx context.Context) (*eth.OutputRes
 		return nil, false, fmt.Errorf("querying next block number: %w", err)
 	}
 	nextCheckpointBlock := nextCheckpointBlockBig.Uint64()
+	l.l2ooNextBlock = nextCheckpointBlock
+	if l.Cfg.Simulate && l.lastSimulatedBlock != 0 && nextCheckpointBlock <= l.lastSimulatedBlock {
+		// Simulated proposals are not submitted, so the next checkpoint is advanced locally from the last simulated one
+		interval, err := l.l2ooContract.SubmissionInterval(callOpts)
+		if err != nil {
+			return nil, false, fmt.Errorf("querying submission interval: %w", err)
+		}
+		nextCheckpointBlock = l.lastSimulatedBlock + interval.Uint64()
+		l.Log.Debug("Advancing simulated checkpoint", "onchainNextBlockNumber", l.l2ooNextBlock, "nextBlockNumber", nextCheckpointBlock)
+	}
 	// Fetch the current L2 heads
 	currentBlockNumber, err := l.FetchCurrentBlockNumber(ctx)
 	if err != nil {
@@ -269,16 +302,15 @@ func (l *L2OutputSubmitter) FetchL2OOOutput(ctx context.Context) (*eth.OutputRes
 // context will be derived from it.
 func (l *L2OutputSubmitter) FetchDGFOutput(ctx context.Context) (*eth.OutputResponse, bool, error) {
 	cutoff := time.Now().Add(-l.Cfg.ProposalInterval)
-	proposedRecently, proposalTime, err := l.dgfContract.HasProposedSince(ctx, l.Txmgr.From(), cutoff, l.Cfg.DisputeGameType)
+	proposedRecently, latest, err := l.dgfContract.LatestProposalSince(ctx, l.Txmgr.From(), cutoff, l.Cfg.DisputeGameType)
 	if err != nil {
 		return nil, false, fmt.Errorf("could not check for recent proposal: %w", err)
 	}
-
-	if proposedRecently {
-		l.Log.Debug("Duration since last game not past proposal interval", "duration", time.Since(proposalTime))
-		return nil, false, nil
+	if !proposedRecently && l.Cfg.Simulate && l.lastSimulated.After(cutoff) {
+		// Simulated proposals are treated as in progress, as they never resolve.
+		proposedRecently = true
+		latest = contracts.Proposal{Timestamp: l.lastSimulated, L2BlockNumber: l.lastSimulatedBlock, InProgress: true}
 	}
-	l.Log.Info("No proposals found for at least proposal interval, submitting proposal now", "proposalInterval", l.Cfg.ProposalInterval)
 
 	// Fetch the current L2 heads
 	currentBlockNumber, err := l.FetchCurrentBlockNumber(ctx)
@@ -286,10 +318,31 @@ func (l *L2OutputSubmitter) FetchDGFOutput(ctx context.Context) (*eth.OutputResp
 		return nil, false, fmt.Errorf("could not fetch current block number: %w", err)
 	}
 
+	if proposedRecently {
+		var lagBlocks uint64
+		if currentBlockNumber > latest.L2BlockNumber {
+			lagBlocks = currentBlockNumber - latest.L2BlockNumber
+		}
+		l.Metr.RecordProposalLag(lagBlocks, time.Since(latest.Timestamp))
+		propose, reason := l.strategy().ShouldPropose(latest, currentBlockNumber)
+		if !propose {
+			l.Log.Debug("Not proposing yet", "reason", reason, "duration", time.Since(latest.Timestamp),
+				"latest", latest.L2BlockNumber, "current", currentBlockNumber)
+			return nil, false, nil
+		}
+		l.Log.Info("Submitting proposal now", "reason", reason, "latest", latest.L2BlockNumber, "current", currentBlockNumber)
+	} else {
+		l.Log.Info("No proposals found for at least proposal interval, submitting proposal now", "proposalInterval", l.Cfg.ProposalInterval)
+	}
+
 	if currentBlockNumber == 0 {
 		l.Log.Info("Skipping proposal for genesis block")
 		return nil, false, nil
 	}
+	if proposedRecently && currentBlockNumber <= latest.L2BlockNumber {
+		l.Log.Info("Skipping proposal, no new block since latest proposal", "latest", latest.L2BlockNumber, "current", currentBlockNumber)
+		return nil, false, nil
+	}
 
 	output, err := l.FetchOutput(ctx, currentBlockNumber)
 	if err != nil {
@@ -299,6 +352,13 @@ func (l *L2OutputSubmitter) FetchDGFOutput(ctx context.Context) (*eth.OutputResp
 	return output, true, nil
 }
 
+func (l *L2OutputSubmitter) strategy() ProposalStrategy {
+	if l.Strategy == nil {
+		return IntervalStrategy{}
+	}
+	return l.Strategy
+}
+
 // FetchCurrentBlockNumber gets the current block number from the [L2OutputSubmitter]'s [RollupClient]. If the `AllowNonFinalized` configuration
 // option is set, it will return the safe head block number, and if not, it will return the finalized head block number.
 func (l *L2OutputSubmitter) FetchCurrentBlockNumber(ctx context.Context) (uint64, error) {
@@ -395,29 +455,13 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 	}
 
 	l.Log.Info("Proposing output root", "output", output.OutputRoot, "block", output.BlockRef)
-	var receipt *types.Receipt
-	if l.Cfg.DisputeGameFactoryAddr != nil {
-		candidate, err := l.ProposeL2OutputDGFTxCandidate(ctx, output)
-		if err != nil {
-			return err
-		}
-		receipt, err = l.Txmgr.Send(ctx, candidate)
-		if err != nil {
-			return err
-		}
-	} else {
-		data, err := l.ProposeL2OutputTxData(output)
-		if err != nil {
-			return err
-		}
-		receipt, err = l.Txmgr.Send(ctx, txmgr.TxCandidate{
-			TxData:   data,
-			To:       l.Cfg.L2OutputOracleAddr,
-			GasLimit: 0,
-		})
-		if err != nil {
-			return err
-		}
+	candidate, err := l.proposalTxCandidate(ctx, output)
+	if err != nil {
+		return err
+	}
+	receipt, err := l.Txmgr.Send(ctx, candidate)
+	if err != nil {
+		return err
 	}
 
 	if receipt.Status == types.ReceiptStatusFailed {
@@ -497,6 +541,38 @@ func (l *L2OutputSubmitter) proposeOutput(ctx context.Context, output *eth.Outpu
 	cCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
 	defer cancel()
 
+	if l.Cfg.Simulate || l.Policy != nil {
+		fees, err := l.FetchL1Fees(cCtx)
+		if err != nil {
+			l.Log.Error("Failed to fetch L1 fees", "err", err)
+			return
+		}
+		if l.Policy != nil {
+			delay, reason := l.Policy.ShouldDelay(fees)
+			if delay {
+				// The proposal is retried on every tick while the ceiling holds: only count it delayed once
+				if !l.delayed {
+					l.Log.Warn("Delaying proposal", "reason", reason, "block", output.BlockRef)
+					l.Metr.RecordProposalDelayed()
+				} else {
+					l.Log.Debug("Still delaying proposal", "reason", reason, "block", output.BlockRef)
+				}
+				l.delayed = true
+				return
+			}
+			if l.delayed {
+				l.Log.Info("Resuming delayed proposal", "block", output.BlockRef)
+				l.delayed = false
+			}
+		}
+		if l.Cfg.Simulate {
+			if err := l.simulateProposal(cCtx, output, fees); err != nil {
+				l.Log.Error("Failed to simulate proposal", "err", err, "block", output.BlockRef)
+			}
+			return
+		}
+	}
+
 	if err := l.sendTransaction(cCtx, output); err != nil {
 		l.Log.Error("Failed to send proposal transaction",
 			"err", err,
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
 	"github.com/ethereum-optimism/optimism/op-proposer/proposer/rpc"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/addrcheck"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/httputil"
@@ -47,6 +49,18 @@ type ProposerConfig struct {
 	AllowNonFinalized bool
 
 	WaitNodeSync bool
+
+	// Simulate estimates and reports proposal costs instead of submitting proposals.
+	Simulate bool
+
+	// MaxL1BaseFee and MaxL1BlobBaseFee, if non-nil, delay proposals while the L1 fees exceed them.
+	MaxL1BaseFee     *big.Int
+	MaxL1BlobBaseFee *big.Int
+
+	// ProposalStrategy is the name of the strategy that decides when to propose within the ProposalInterval.
+	// ProposalBlocks is the number of L2 blocks between proposals of the blocks strategy.
+	ProposalStrategy string
+	ProposalBlocks   uint64
 }
 
 type ProposerService struct {
@@ -93,6 +107,10 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	ps.NetworkTimeout = cfg.TxMgrConfig.NetworkTimeout
 	ps.AllowNonFinalized = cfg.AllowNonFinalized
 	ps.WaitNodeSync = cfg.WaitNodeSync
+	ps.Simulate = cfg.Simulate
+	if err := ps.initFeeCeilings(cfg); err != nil {
+		return err
+	}
 
 	ps.initL2ooAddress(cfg)
 	ps.initDGF(cfg)
@@ -100,6 +118,9 @@ func (ps *ProposerService) initFromCLIConfig(ctx context.Context, version string
 	if err := ps.initRPCClients(ctx, cfg); err != nil {
 		return err
 	}
+	if err := ps.checkAddresses(ctx, cfg); err != nil {
+		return err
+	}
 	if err := ps.initTxManager(cfg); err != nil {
 		return fmt.Errorf("failed to init Tx manager: %w", err)
 	}
@@ -222,9 +243,45 @@ func (ps *ProposerService) initDGF(cfg *CLIConfig) {
 	ps.DisputeGameFactoryAddr = &dgfAddress
 	ps.ProposalInterval = cfg.ProposalInterval
 	ps.DisputeGameType = cfg.DisputeGameType
+	ps.ProposalStrategy = cfg.ProposalStrategy
+	ps.ProposalBlocks = cfg.ProposalBlocks
+}
+
+// checkAddresses validates that the configured output oracle or dispute game factory is deployed on L1,
+// with one of the expected code hashes if configured.
+func (ps *ProposerService) checkAddresses(ctx context.Context, cfg *CLIConfig) error {
+	var exps []addrcheck.Expectation
+	if ps.L2OutputOracleAddr != nil {
+		exps = append(exps, addrcheck.ExpectContract("L2OutputOracle", *ps.L2OutputOracleAddr))
+	}
+	if ps.DisputeGameFactoryAddr != nil {
+		exps = append(exps, addrcheck.ExpectContract("DisputeGameFactory", *ps.DisputeGameFactoryAddr))
+	}
+	return addrcheck.Check(ctx, ps.Log, ps.L1Client, cfg.AddrCheckConfig.Apply(exps...)...)
+}
+
+func (ps *ProposerService) initFeeCeilings(cfg *CLIConfig) error {
+	if cfg.MaxL1BaseFeeGwei == 0 && cfg.MaxL1BlobBaseFeeGwei == 0 {
+		return nil
+	}
+	policy, err := NewFeeCeilingPolicy(cfg.MaxL1BaseFeeGwei, cfg.MaxL1BlobBaseFeeGwei)
+	if err != nil {
+		return err
+	}
+	ps.MaxL1BaseFee = policy.MaxBaseFee
+	ps.MaxL1BlobBaseFee = policy.MaxBlobBaseFee
+	return nil
 }
 
 func (ps *ProposerService) initDriver() error {
+	var policy ProposalPolicy
+	if ps.MaxL1BaseFee != nil || ps.MaxL1BlobBaseFee != nil {
+		policy = &FeeCeilingPolicy{MaxBaseFee: ps.MaxL1BaseFee, MaxBlobBaseFee: ps.MaxL1BlobBaseFee}
+	}
+	strategy, err := NewProposalStrategy(ps.ProposalStrategy, ps.ProposalBlocks)
+	if err != nil {
+		return fmt.Errorf("failed to create proposal strategy: %w", err)
+	}
 	driver, err := NewL2OutputSubmitter(DriverSetup{
 		Log:            ps.Log,
 		Metr:           ps.Metrics,
@@ -233,6 +290,8 @@ func (ps *ProposerService) initDriver() error {
 		L1Client:       ps.L1Client,
 		Multicaller:    batching.NewMultiCaller(ps.L1Client.Client(), batching.DefaultBatchSize),
 		RollupProvider: ps.RollupProvider,
+		Policy:         policy,
+		Strategy:       strategy,
 	})
 	if err != nil {
 		return err
@@ -242,17 +301,26 @@ func (ps *ProposerService) initDriver() error {
 }
 
 func (ps *ProposerService) initRPCServer(cfg *CLIConfig) error {
+	adminJWTSecret, err := cfg.RPCConfig.AdminJWTSecret()
+	if err != nil {
+		return err
+	}
 	server := oprpc.NewServer(
 		cfg.RPCConfig.ListenAddr,
 		cfg.RPCConfig.ListenPort,
 		ps.Version,
 		oprpc.WithLogger(ps.Log),
+		oprpc.WithAdminJWTSecret(adminJWTSecret),
 	)
 	if cfg.RPCConfig.EnableAdmin {
 		adminAPI := rpc.NewAdminAPI(ps.driver, ps.Metrics, ps.Log)
 		server.AddAPI(rpc.GetAdminAPI(adminAPI))
 		server.AddAPI(ps.TxManager.API())
 		ps.Log.Info("Admin RPC enabled")
+		if adminJWTSecret != nil {
+			server.AddAPI(oppprof.GetRuntimeAPI(oppprof.NewRuntimeAPI(ps.Log, cfg.PprofConfig.ProfileDir)))
+			ps.Log.Info("Authenticated admin runtime RPC enabled")
+		}
 	}
 	ps.Log.Info("Starting JSON-RPC server")
 	if err := server.Start(); err != nil {
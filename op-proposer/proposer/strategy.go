@@ -0,0 +1,74 @@
+package proposer
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/contracts"
+)
+
+// Names of the supported proposal strategies.
+const (
+	IntervalStrategyName   = "interval"
+	BlocksStrategyName     = "blocks"
+	ActiveGameStrategyName = "active-game"
+)
+
+var ProposalStrategies = []string{IntervalStrategyName, BlocksStrategyName, ActiveGameStrategyName}
+
+// ProposalStrategy decides when a new output is proposed to the DisputeGameFactory.
+// A proposal is always made when the proposer has not proposed within the proposal interval,
+// so the strategies can only make proposals more frequent.
+type ProposalStrategy interface {
+	// ShouldPropose returns true if the output of the given L2 block should be proposed,
+	// given the latest proposal made within the proposal interval. Returns a human-readable reason either way.
+	ShouldPropose(latest contracts.Proposal, l2Block uint64) (bool, string)
+}
+
+// NewProposalStrategy creates the proposal strategy with the given name.
+// blocks is the number of L2 blocks between proposals of the blocks strategy, and is ignored by other strategies.
+func NewProposalStrategy(name string, blocks uint64) (ProposalStrategy, error) {
+	switch name {
+	case IntervalStrategyName, "":
+		return IntervalStrategy{}, nil
+	case BlocksStrategyName:
+		if blocks == 0 {
+			return nil, errors.New("the blocks proposal strategy requires a non-zero number of blocks")
+		}
+		return BlocksStrategy{Blocks: blocks}, nil
+	case ActiveGameStrategyName:
+		return ActiveGameStrategy{}, nil
+	default:
+		return nil, fmt.Errorf("unknown proposal strategy %q, expected one of %v", name, ProposalStrategies)
+	}
+}
+
+// IntervalStrategy proposes once per proposal interval.
+type IntervalStrategy struct{}
+
+func (IntervalStrategy) ShouldPropose(_ contracts.Proposal, _ uint64) (bool, string) {
+	return false, "proposed within proposal interval"
+}
+
+// BlocksStrategy proposes when the L2 block is at least Blocks past the latest proposal.
+type BlocksStrategy struct {
+	Blocks uint64
+}
+
+func (s BlocksStrategy) ShouldPropose(latest contracts.Proposal, l2Block uint64) (bool, string) {
+	if l2Block >= latest.L2BlockNumber+s.Blocks {
+		return true, fmt.Sprintf("%d blocks since latest proposal", l2Block-latest.L2BlockNumber)
+	}
+	return false, fmt.Sprintf("less than %d blocks since latest proposal", s.Blocks)
+}
+
+// ActiveGameStrategy proposes when the latest proposal is no longer in progress,
+// so a game covering a recent L2 block is always active.
+type ActiveGameStrategy struct{}
+
+func (ActiveGameStrategy) ShouldPropose(latest contracts.Proposal, _ uint64) (bool, string) {
+	if latest.InProgress {
+		return false, "latest proposal is still in progress"
+	}
+	return true, "latest proposal is resolved"
+}
@@ -0,0 +1,55 @@
+package proposer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-proposer/contracts"
+)
+
+func TestNewProposalStrategy(t *testing.T) {
+	for _, name := range ProposalStrategies {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewProposalStrategy(name, 10)
+			require.NoError(t, err)
+		})
+	}
+
+	strategy, err := NewProposalStrategy("", 0)
+	require.NoError(t, err)
+	require.Equal(t, IntervalStrategy{}, strategy, "defaults to interval strategy")
+
+	_, err = NewProposalStrategy(BlocksStrategyName, 0)
+	require.ErrorContains(t, err, "non-zero number of blocks")
+
+	_, err = NewProposalStrategy("unknown", 10)
+	require.ErrorContains(t, err, "unknown proposal strategy")
+}
+
+func TestProposalStrategies(t *testing.T) {
+	inProgress := contracts.Proposal{L2BlockNumber: 100, InProgress: true}
+	resolved := contracts.Proposal{L2BlockNumber: 100, InProgress: false}
+
+	tests := []struct {
+		name     string
+		strategy ProposalStrategy
+		latest   contracts.Proposal
+		l2Block  uint64
+		expected bool
+	}{
+		{name: "Interval", strategy: IntervalStrategy{}, latest: resolved, l2Block: 1000, expected: false},
+		{name: "BlocksNotReached", strategy: BlocksStrategy{Blocks: 50}, latest: inProgress, l2Block: 149, expected: false},
+		{name: "BlocksReached", strategy: BlocksStrategy{Blocks: 50}, latest: inProgress, l2Block: 150, expected: true},
+		{name: "BlocksBehindLatest", strategy: BlocksStrategy{Blocks: 50}, latest: inProgress, l2Block: 90, expected: false},
+		{name: "ActiveGameInProgress", strategy: ActiveGameStrategy{}, latest: inProgress, l2Block: 1000, expected: false},
+		{name: "ActiveGameResolved", strategy: ActiveGameStrategy{}, latest: resolved, l2Block: 101, expected: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			propose, reason := test.strategy.ShouldPropose(test.latest, test.l2Block)
+			require.Equal(t, test.expected, propose)
+			require.NotEmpty(t, reason)
+		})
+	}
+}
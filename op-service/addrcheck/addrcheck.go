@@ -0,0 +1,135 @@
+// Package addrcheck validates configured L1 addresses against the chain at startup,
+// so misconfigured addresses fail fast instead of causing reverts or silent misbehavior later.
+package addrcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+var (
+	ErrZeroAddress      = errors.New("address is not set")
+	ErrNoCode           = errors.New("no contract code at address")
+	ErrUnexpectedCode   = errors.New("unexpected contract code at address")
+	ErrUnknownCodeHash  = errors.New("code hash does not match any expected code hash")
+	ErrZeroBytes32Value = errors.New("value is not set")
+)
+
+// Kind is the kind of account expected at an address.
+type Kind int
+
+const (
+	// Contract addresses must have code, e.g. the OptimismPortal or DisputeGameFactory.
+	Contract Kind = iota
+	// NoCode addresses must not have code, e.g. the batch inbox.
+	NoCode
+)
+
+// Expectation describes a configured address and what it is expected to hold on chain.
+type Expectation struct {
+	// Name is the name of the config option or contract, used in errors.
+	Name    string
+	Address common.Address
+	Kind    Kind
+	// Optional marks addresses that are allowed to be unset, in which case they are not checked.
+	Optional bool
+	// CodeHashes optionally restricts contracts to the given code hashes.
+	CodeHashes []common.Hash
+}
+
+// ExpectContract creates the expectation of a contract at the address.
+func ExpectContract(name string, addr common.Address) Expectation {
+	return Expectation{Name: name, Address: addr, Kind: Contract}
+}
+
+// ExpectNoCode creates the expectation of an address without code.
+func ExpectNoCode(name string, addr common.Address) Expectation {
+	return Expectation{Name: name, Address: addr, Kind: NoCode}
+}
+
+// CodeFetcher retrieves the code at an address. It is implemented by ethclient.Client,
+// a nil block number retrieves the code at the latest block.
+type CodeFetcher interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// Check validates all expectations and returns an error that describes every failed expectation.
+// The code hashes of the contracts are logged, so they can be pinned as expected code hashes.
+func Check(ctx context.Context, logger log.Logger, fetcher CodeFetcher, expectations ...Expectation) error {
+	var result error
+	for _, exp := range expectations {
+		if err := check(ctx, logger, fetcher, exp); err != nil {
+			result = errors.Join(result, fmt.Errorf("invalid %s address %s: %w", exp.Name, exp.Address, err))
+		}
+	}
+	return result
+}
+
+func check(ctx context.Context, logger log.Logger, fetcher CodeFetcher, exp Expectation) error {
+	if exp.Address == (common.Address{}) {
+		if exp.Optional {
+			return nil
+		}
+		return ErrZeroAddress
+	}
+	code, err := fetcher.CodeAt(ctx, exp.Address, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch code: %w", err)
+	}
+	switch exp.Kind {
+	case NoCode:
+		if len(code) > 0 {
+			return ErrUnexpectedCode
+		}
+	case Contract:
+		if len(code) == 0 {
+			return fmt.Errorf("%w, check that it is configured for the right L1 chain and that the L1 node is synced", ErrNoCode)
+		}
+		codeHash := crypto.Keccak256Hash(code)
+		if len(exp.CodeHashes) > 0 && !slices.Contains(exp.CodeHashes, codeHash) {
+			return fmt.Errorf("%w: got %s, expected one of %v", ErrUnknownCodeHash, codeHash, exp.CodeHashes)
+		}
+		logger.Info("Validated contract address", "name", exp.Name, "address", exp.Address, "codeHash", codeHash)
+	}
+	return nil
+}
+
+// CheckBytes32 validates that a configured bytes32 value, such as an absolute prestate, is set.
+func CheckBytes32(name string, value common.Hash) error {
+	if value == (common.Hash{}) {
+		return fmt.Errorf("invalid %s: %w", name, ErrZeroBytes32Value)
+	}
+	return nil
+}
+
+// rpcCodeFetcher adapts a client.RPC to a CodeFetcher.
+type rpcCodeFetcher struct {
+	rpc client.RPC
+}
+
+// NewRPCCodeFetcher creates a CodeFetcher that fetches the latest code with eth_getCode.
+func NewRPCCodeFetcher(rpc client.RPC) CodeFetcher {
+	return &rpcCodeFetcher{rpc: rpc}
+}
+
+func (r *rpcCodeFetcher) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	block := "latest"
+	if blockNumber != nil {
+		block = hexutil.EncodeBig(blockNumber)
+	}
+	var code hexutil.Bytes
+	if err := r.rpc.CallContext(ctx, &code, "eth_getCode", account, block); err != nil {
+		return nil, err
+	}
+	return code, nil
+}
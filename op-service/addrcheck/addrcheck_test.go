@@ -0,0 +1,71 @@
+package addrcheck
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubCodeFetcher map[common.Address][]byte
+
+func (s stubCodeFetcher) CodeAt(_ context.Context, account common.Address, _ *big.Int) ([]byte, error) {
+	if account == (common.Address{0xee}) {
+		return nil, errors.New("boom")
+	}
+	return s[account], nil
+}
+
+func TestCheck(t *testing.T) {
+	contract := common.Address{0xaa}
+	code := []byte{0x60, 0x80}
+	eoa := common.Address{0xbb}
+	fetcher := stubCodeFetcher{contract: code}
+	logger := testlog.Logger(t, log.LevelInfo)
+	ctx := context.Background()
+
+	t.Run("valid", func(t *testing.T) {
+		err := Check(ctx, logger, fetcher,
+			ExpectContract("Portal", contract),
+			ExpectNoCode("BatchInbox", eoa),
+			Expectation{Name: "DisputeGameFactory", Address: contract, Kind: Contract, CodeHashes: []common.Hash{crypto.Keccak256Hash(code)}},
+			Expectation{Name: "ProtocolVersions", Kind: Contract, Optional: true})
+		require.NoError(t, err)
+	})
+	t.Run("zero", func(t *testing.T) {
+		err := Check(ctx, logger, fetcher, ExpectContract("Portal", common.Address{}))
+		require.ErrorIs(t, err, ErrZeroAddress)
+		require.ErrorContains(t, err, "invalid Portal address")
+	})
+	t.Run("missing code", func(t *testing.T) {
+		require.ErrorIs(t, Check(ctx, logger, fetcher, ExpectContract("Portal", eoa)), ErrNoCode)
+	})
+	t.Run("unexpected code", func(t *testing.T) {
+		require.ErrorIs(t, Check(ctx, logger, fetcher, ExpectNoCode("BatchInbox", contract)), ErrUnexpectedCode)
+	})
+	t.Run("unknown code hash", func(t *testing.T) {
+		exp := ExpectContract("DisputeGameFactory", contract)
+		exp.CodeHashes = []common.Hash{{0x01}}
+		require.ErrorIs(t, Check(ctx, logger, fetcher, exp), ErrUnknownCodeHash)
+	})
+	t.Run("fetch error", func(t *testing.T) {
+		require.ErrorContains(t, Check(ctx, logger, fetcher, ExpectContract("Portal", common.Address{0xee})), "boom")
+	})
+	t.Run("reports all errors", func(t *testing.T) {
+		err := Check(ctx, logger, fetcher, ExpectContract("Portal", eoa), ExpectNoCode("BatchInbox", contract))
+		require.ErrorIs(t, err, ErrNoCode)
+		require.ErrorIs(t, err, ErrUnexpectedCode)
+	})
+}
+
+func TestCheckBytes32(t *testing.T) {
+	require.NoError(t, CheckBytes32("AbsolutePrestate", common.Hash{0x01}))
+	require.ErrorIs(t, CheckBytes32("AbsolutePrestate", common.Hash{}), ErrZeroBytes32Value)
+}
@@ -0,0 +1,77 @@
+package addrcheck
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const CodeHashesFlagName = "l1.code-hashes"
+
+// CLIFlags creates the flag to pin the code hashes of the L1 contracts validated at startup.
+func CLIFlags(envPrefix string, category string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringSliceFlag{
+			Name: CodeHashesFlagName,
+			Usage: "Expected code hashes of the L1 contracts validated at startup, as <contract>=<code hash> entries, " +
+				"e.g. DisputeGameFactory=0x... Repeat a contract to accept any of multiple code hashes. " +
+				"The code hashes of the validated contracts are logged at startup.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "L1_CODE_HASHES"),
+			Category: category,
+		},
+	}
+}
+
+type CLIConfig struct {
+	// CodeHashes are the <contract>=<code hash> entries of the expected code hashes.
+	CodeHashes []string
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{CodeHashes: ctx.StringSlice(CodeHashesFlagName)}
+}
+
+func (c CLIConfig) Check() error {
+	_, err := c.codeHashes()
+	return err
+}
+
+// Apply restricts the contract expectations to the configured code hashes of their name, if any.
+// The config must be valid, see Check.
+func (c CLIConfig) Apply(exps ...Expectation) []Expectation {
+	hashes, err := c.codeHashes()
+	if err != nil {
+		panic(fmt.Errorf("invalid address check config: %w", err))
+	}
+	out := make([]Expectation, len(exps))
+	for i, exp := range exps {
+		if exp.Kind == Contract {
+			exp.CodeHashes = append(exp.CodeHashes, hashes[exp.Name]...)
+		}
+		out[i] = exp
+	}
+	return out
+}
+
+func (c CLIConfig) codeHashes() (map[string][]common.Hash, error) {
+	hashes := make(map[string][]common.Hash)
+	for _, entry := range c.CodeHashes {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid %s entry %q, expected <contract>=<code hash>", CodeHashesFlagName, entry)
+		}
+		var hash common.Hash
+		if err := hash.UnmarshalText([]byte(value)); err != nil {
+			return nil, fmt.Errorf("invalid %s code hash %q: %w", name, value, err)
+		}
+		if err := CheckBytes32(name+" code hash", hash); err != nil {
+			return nil, err
+		}
+		hashes[name] = append(hashes[name], hash)
+	}
+	return hashes, nil
+}
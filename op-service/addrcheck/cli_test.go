@@ -0,0 +1,30 @@
+package addrcheck
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCLIConfig(t *testing.T) {
+	hashA, hashB := common.Hash{0x0a}, common.Hash{0x0b}
+	cfg := CLIConfig{CodeHashes: []string{
+		"DisputeGameFactory=" + hashA.Hex(),
+		"DisputeGameFactory=" + hashB.Hex(),
+		"BatchInbox=" + hashA.Hex(),
+	}}
+	require.NoError(t, cfg.Check())
+	exps := cfg.Apply(
+		ExpectContract("DisputeGameFactory", common.Address{0xaa}),
+		ExpectContract("Multicall3", common.Address{0xbb}),
+		ExpectNoCode("BatchInbox", common.Address{0xcc}))
+	require.Equal(t, []common.Hash{hashA, hashB}, exps[0].CodeHashes)
+	require.Empty(t, exps[1].CodeHashes)
+	require.Empty(t, exps[2].CodeHashes, "code hashes only apply to contracts")
+
+	require.NoError(t, CLIConfig{}.Check())
+	require.ErrorContains(t, CLIConfig{CodeHashes: []string{hashA.Hex()}}.Check(), "expected <contract>=<code hash>")
+	require.ErrorContains(t, CLIConfig{CodeHashes: []string{"DisputeGameFactory=0x1234"}}.Check(), "invalid DisputeGameFactory code hash")
+	require.ErrorIs(t, CLIConfig{CodeHashes: []string{"DisputeGameFactory=" + common.Hash{}.Hex()}}.Check(), ErrZeroBytes32Value)
+}
@@ -0,0 +1,120 @@
+// Package appinfo tracks the build and configuration of the running service, and exposes it
+// in the same way across all services: as the app_info metric and the version_info RPC method.
+// Fleet dashboards use it to detect version and config skew between instances.
+package appinfo
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RPCNamespace is the namespace of the RPC API serving the app info.
+const RPCNamespace = "version"
+
+// Info describes the build and configuration of the running service.
+type Info struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	// GitDate is the unix timestamp of the commit the service was built from.
+	GitDate string `json:"gitDate"`
+	// CannonStateVersion is the cannon state version supported by the service, if it runs cannon.
+	CannonStateVersion string `json:"cannonStateVersion,omitempty"`
+	// RollupConfigHash is the hash of the rollup config the service runs with, if it uses one.
+	RollupConfigHash *common.Hash `json:"rollupConfigHash,omitempty"`
+}
+
+var (
+	lock    sync.RWMutex
+	current Info
+)
+
+// SetBuild records the build of the running service. Services call it from main, with the values set at link time.
+// The git commit and date default to the VCS info embedded by the go toolchain, if not set at link time.
+func SetBuild(version string, gitCommit string, gitDate string) {
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range buildInfo.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && gitCommit == "":
+				gitCommit = setting.Value
+			case setting.Key == "vcs.time" && gitDate == "":
+				gitDate = setting.Value
+			}
+		}
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	current.Version = version
+	current.GitCommit = gitCommit
+	current.GitDate = gitDate
+}
+
+// SetCannonStateVersion records the cannon state version supported by the running service.
+func SetCannonStateVersion(version string) {
+	lock.Lock()
+	defer lock.Unlock()
+	current.CannonStateVersion = version
+}
+
+// SetRollupConfigHash records the hash of the rollup config the running service runs with.
+func SetRollupConfigHash(hash common.Hash) {
+	lock.Lock()
+	defer lock.Unlock()
+	current.RollupConfigHash = &hash
+}
+
+// Current returns the info of the running service.
+func Current() Info {
+	lock.RLock()
+	defer lock.RUnlock()
+	return current
+}
+
+// collector collects the app_info pseudo-metric, labelled with the info at the time of collection.
+// The metric is intentionally not namespaced, so it can be queried in the same way for all services.
+type collector struct {
+	desc *prometheus.Desc
+}
+
+// NewCollector creates a collector of the app_info metric.
+func NewCollector() prometheus.Collector {
+	return &collector{
+		desc: prometheus.NewDesc("app_info",
+			"Pseudo-metric tracking the build and config of the service",
+			[]string{"version", "git_commit", "git_date", "cannon_state_version", "rollup_config_hash"}, nil),
+	}
+}
+
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	info := Current()
+	rollupConfigHash := ""
+	if info.RollupConfigHash != nil {
+		rollupConfigHash = info.RollupConfigHash.Hex()
+	}
+	ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, 1,
+		info.Version, info.GitCommit, info.GitDate, info.CannonStateVersion, rollupConfigHash)
+}
+
+// API serves the app info over RPC.
+type API struct{}
+
+// Info returns the info of the running service.
+func (API) Info(_ context.Context) (Info, error) {
+	return Current(), nil
+}
+
+// GetAPI returns the RPC API serving the app info, as version_info.
+func GetAPI() rpc.API {
+	return rpc.API{
+		Namespace: RPCNamespace,
+		Service:   API{},
+	}
+}
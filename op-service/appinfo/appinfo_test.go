@@ -0,0 +1,48 @@
+package appinfo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppInfo(t *testing.T) {
+	t.Cleanup(func() {
+		current = Info{}
+	})
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(NewCollector())
+
+	SetBuild("v1.2.3", "abcdef", "1700000000")
+	require.Equal(t, Info{Version: "v1.2.3", GitCommit: "abcdef", GitDate: "1700000000"}, Current())
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP app_info Pseudo-metric tracking the build and config of the service
+# TYPE app_info gauge
+app_info{cannon_state_version="",git_commit="abcdef",git_date="1700000000",rollup_config_hash="",version="v1.2.3"} 1
+`)))
+
+	// The metric reflects config recorded after registration
+	hash := common.Hash{0xaa}
+	SetRollupConfigHash(hash)
+	SetCannonStateVersion("cannon")
+	require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP app_info Pseudo-metric tracking the build and config of the service
+# TYPE app_info gauge
+app_info{cannon_state_version="cannon",git_commit="abcdef",git_date="1700000000",rollup_config_hash="`+hash.Hex()+`",version="v1.2.3"} 1
+`)))
+
+	info, err := API{}.Info(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, Info{
+		Version:            "v1.2.3",
+		GitCommit:          "abcdef",
+		GitDate:            "1700000000",
+		CannonStateVersion: "cannon",
+		RollupConfigHash:   &hash,
+	}, info)
+}
@@ -0,0 +1,173 @@
+// Package cache provides a size-bounded LRU cache with optional expiry of entries,
+// de-duplication of concurrent fetches of the same key, and hit/miss metrics.
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+type Metrics interface {
+	CacheAdd(label string, cacheSize int, evicted bool)
+	CacheGet(label string, hit bool)
+}
+
+// Config configures a Cache.
+type Config struct {
+	// Size is the maximum number of entries in the cache.
+	Size int
+	// TTL is the duration after which an entry expires. Entries don't expire if 0.
+	TTL time.Duration
+}
+
+func (c Config) Check() error {
+	if c.Size <= 0 {
+		return fmt.Errorf("invalid cache size: %d", c.Size)
+	}
+	if c.TTL < 0 {
+		return fmt.Errorf("invalid cache TTL: %v", c.TTL)
+	}
+	return nil
+}
+
+type entry[V any] struct {
+	value   V
+	expires time.Time // zero if the entry does not expire
+}
+
+// fetch is an in-progress fetch of a key, that concurrent fetches of the same key wait for.
+type fetch[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// Cache is a LRU cache that tracks cache metrics.
+type Cache[K comparable, V any] struct {
+	m     Metrics
+	label string
+	ttl   time.Duration
+	now   func() time.Time
+	inner *lru.Cache[K, entry[V]]
+
+	fetchingLock sync.Mutex
+	fetching     map[K]*fetch[V]
+}
+
+// New creates a cache with the given metrics, labeling the cache adds/gets.
+// Metrics are optional: no metrics will be tracked if m == nil.
+// The cache holds at least one entry, if the configured size is not positive.
+func New[K comparable, V any](m Metrics, label string, cfg Config) *Cache[K, V] {
+	// no errors if the size is positive
+	inner, _ := lru.New[K, entry[V]](max(cfg.Size, 1))
+	return &Cache[K, V]{
+		m:        m,
+		label:    label,
+		ttl:      max(cfg.TTL, 0),
+		now:      time.Now,
+		inner:    inner,
+		fetching: make(map[K]*fetch[V]),
+	}
+}
+
+// get returns the value of the key, removing it if it expired.
+func (c *Cache[K, V]) get(key K, peek func(K) (entry[V], bool)) (value V, ok bool) {
+	e, ok := peek(key)
+	if ok && !e.expires.IsZero() && !c.now().Before(e.expires) {
+		c.inner.Remove(key)
+		return value, false
+	}
+	return e.value, ok
+}
+
+// Get returns the value of the key, and marks the key as recently used.
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	value, ok = c.get(key, c.inner.Get)
+	if c.m != nil {
+		c.m.CacheGet(c.label, ok)
+	}
+	return value, ok
+}
+
+// Peek returns the value of the key, without updating the recent-ness of the key or tracking cache metrics.
+func (c *Cache[K, V]) Peek(key K) (value V, ok bool) {
+	return c.get(key, c.inner.Peek)
+}
+
+// Add adds the value of the key, replacing any existing value, and returns true if an entry was evicted to make room.
+func (c *Cache[K, V]) Add(key K, value V) (evicted bool) {
+	e := entry[V]{value: value}
+	if c.ttl > 0 {
+		e.expires = c.now().Add(c.ttl)
+	}
+	evicted = c.inner.Add(key, e)
+	if c.m != nil {
+		c.m.CacheAdd(c.label, c.inner.Len(), evicted)
+	}
+	return evicted
+}
+
+// Remove removes the key from the cache, and returns true if it was present.
+func (c *Cache[K, V]) Remove(key K) bool {
+	return c.inner.Remove(key)
+}
+
+// Keys returns the keys in the cache, from oldest to newest.
+// Expired entries that have not been accessed since expiring are included.
+func (c *Cache[K, V]) Keys() []K {
+	return c.inner.Keys()
+}
+
+// Len returns the number of entries in the cache, including expired entries that have not been accessed since.
+func (c *Cache[K, V]) Len() int {
+	return c.inner.Len()
+}
+
+// Purge removes all entries from the cache.
+func (c *Cache[K, V]) Purge() {
+	c.inner.Purge()
+}
+
+// GetOrFetch returns the cached value of the key, or fetches and caches it if it is not cached.
+// Concurrent calls for the same key share a single fetch, run with the context of the first call.
+// Errors are returned to all waiting calls, and are not cached.
+func (c *Cache[K, V]) GetOrFetch(ctx context.Context, key K, fn func(ctx context.Context) (V, error)) (V, error) {
+	if value, ok := c.Get(key); ok {
+		return value, nil
+	}
+
+	c.fetchingLock.Lock()
+	if f, ok := c.fetching[key]; ok {
+		c.fetchingLock.Unlock()
+		select {
+		case <-f.done:
+			return f.value, f.err
+		case <-ctx.Done():
+			var value V
+			return value, ctx.Err()
+		}
+	}
+	// Another call may have completed the fetch since the cache was checked
+	if value, ok := c.Peek(key); ok {
+		c.fetchingLock.Unlock()
+		return value, nil
+	}
+	f := &fetch[V]{done: make(chan struct{})}
+	c.fetching[key] = f
+	c.fetchingLock.Unlock()
+
+	f.value, f.err = fn(ctx)
+	if f.err == nil {
+		c.Add(key, f.value)
+	}
+
+	c.fetchingLock.Lock()
+	delete(c.fetching, key)
+	c.fetchingLock.Unlock()
+	close(f.done)
+	return f.value, f.err
+}
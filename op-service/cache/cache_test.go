@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testMetrics struct {
+	hits, misses, evictions int
+	size                    int
+}
+
+func (m *testMetrics) CacheAdd(label string, cacheSize int, evicted bool) {
+	m.size = cacheSize
+	if evicted {
+		m.evictions++
+	}
+}
+
+func (m *testMetrics) CacheGet(label string, hit bool) {
+	if hit {
+		m.hits++
+	} else {
+		m.misses++
+	}
+}
+
+func TestCacheLRU(t *testing.T) {
+	m := new(testMetrics)
+	c := New[int, string](m, "test", Config{Size: 2})
+	require.False(t, c.Add(1, "a"))
+	require.False(t, c.Add(2, "b"))
+	v, ok := c.Get(1)
+	require.True(t, ok)
+	require.Equal(t, "a", v)
+
+	// 2 is the least recently used
+	require.True(t, c.Add(3, "c"))
+	_, ok = c.Get(2)
+	require.False(t, ok)
+	require.Equal(t, []int{1, 3}, c.Keys())
+
+	require.Equal(t, &testMetrics{hits: 1, misses: 1, evictions: 1, size: 2}, m)
+
+	// Peek is not tracked
+	_, ok = c.Peek(3)
+	require.True(t, ok)
+	require.Equal(t, 2, m.hits+m.misses)
+
+	require.True(t, c.Remove(3))
+	require.Equal(t, 1, c.Len())
+	c.Purge()
+	require.Zero(t, c.Len())
+}
+
+func TestCacheTTL(t *testing.T) {
+	now := time.Unix(1000, 0)
+	c := New[int, string](nil, "test", Config{Size: 10, TTL: time.Minute})
+	c.now = func() time.Time { return now }
+
+	c.Add(1, "a")
+	now = now.Add(30 * time.Second)
+	c.Add(2, "b")
+	_, ok := c.Get(1)
+	require.True(t, ok)
+
+	now = now.Add(30 * time.Second)
+	_, ok = c.Get(1)
+	require.False(t, ok, "expired")
+	_, ok = c.Peek(2)
+	require.True(t, ok)
+	require.Equal(t, []int{2}, c.Keys(), "expired entry is removed")
+
+	// Re-adding resets the expiry
+	now = now.Add(29 * time.Second)
+	c.Add(2, "b")
+	now = now.Add(59 * time.Second)
+	_, ok = c.Get(2)
+	require.True(t, ok)
+}
+
+func TestCacheGetOrFetch(t *testing.T) {
+	c := New[int, string](nil, "test", Config{Size: 10})
+	ctx := context.Background()
+
+	t.Run("Fetch", func(t *testing.T) {
+		v, err := c.GetOrFetch(ctx, 1, func(ctx context.Context) (string, error) { return "a", nil })
+		require.NoError(t, err)
+		require.Equal(t, "a", v)
+		v, err = c.GetOrFetch(ctx, 1, func(ctx context.Context) (string, error) {
+			t.Fatal("must not fetch cached key")
+			return "", nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "a", v)
+	})
+
+	t.Run("ErrorNotCached", func(t *testing.T) {
+		fetchErr := errors.New("boom")
+		_, err := c.GetOrFetch(ctx, 2, func(ctx context.Context) (string, error) { return "", fetchErr })
+		require.ErrorIs(t, err, fetchErr)
+		_, ok := c.Peek(2)
+		require.False(t, ok)
+	})
+
+	t.Run("Dedup", func(t *testing.T) {
+		var fetches atomic.Int32
+		release := make(chan struct{})
+		fetch := func(ctx context.Context) (string, error) {
+			fetches.Add(1)
+			<-release
+			return "c", nil
+		}
+		var wg sync.WaitGroup
+		results := make([]string, 5)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				v, err := c.GetOrFetch(ctx, 3, fetch)
+				require.NoError(t, err)
+				results[i] = v
+			}(i)
+		}
+		require.Eventually(t, func() bool { return fetches.Load() == 1 }, time.Second, time.Millisecond)
+		close(release)
+		wg.Wait()
+		require.EqualValues(t, 1, fetches.Load())
+		require.Equal(t, []string{"c", "c", "c", "c", "c"}, results)
+	})
+
+	t.Run("WaitCancelled", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		started := make(chan struct{})
+		go func() {
+			_, _ = c.GetOrFetch(ctx, 4, func(ctx context.Context) (string, error) {
+				close(started)
+				<-release
+				return "d", nil
+			})
+		}()
+		<-started
+		cctx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := c.GetOrFetch(cctx, 4, func(ctx context.Context) (string, error) {
+			t.Fatal("must wait for in-progress fetch")
+			return "", nil
+		})
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestConfigCheck(t *testing.T) {
+	require.NoError(t, Config{Size: 1}.Check())
+	require.NoError(t, Config{Size: 1, TTL: time.Second}.Check())
+	require.Error(t, Config{}.Check())
+	require.Error(t, Config{Size: 1, TTL: -time.Second}.Check())
+}
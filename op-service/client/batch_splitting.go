@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"golang.org/x/sync/errgroup"
+)
+
+// responseTooLargeErrCode is the error code geth responds with once a batch response exceeds its size limit.
+const responseTooLargeErrCode = -32003
+
+// tooLargeErrMsgs are the error messages providers respond with when a batch, or its response, is too large.
+var tooLargeErrMsgs = []string{
+	"response too large",
+	"batch too large",
+	"batch size too large",
+	"batch limit exceeded",
+	"response size exceeded",
+}
+
+// BatchSplittingClient is a wrapper around a pure RPC that splits batch requests to stay within the limits of the provider.
+// Batches are split to the max batch size up front, and bisected when the provider reports that a batch,
+// or its response, is too large. The reduced batch size is kept for later batch requests.
+// The split batches are sent concurrently, with a limit on the concurrent batches across all batch requests.
+type BatchSplittingClient struct {
+	c   RPC
+	log log.Logger
+	// maxBatchSize is the current max number of requests per batch. 0 if unlimited.
+	maxBatchSize atomic.Int64
+	slots        chan struct{}
+}
+
+// NewBatchSplittingClient creates a client that sends batches of at most maxBatchSize requests (0 for no initial limit),
+// with at most maxConcurrency batches in flight.
+func NewBatchSplittingClient(lgr log.Logger, c RPC, maxBatchSize int, maxConcurrency int) *BatchSplittingClient {
+	b := &BatchSplittingClient{
+		c:     c,
+		log:   lgr,
+		slots: make(chan struct{}, maxConcurrency),
+	}
+	b.maxBatchSize.Store(int64(maxBatchSize))
+	return b
+}
+
+func (b *BatchSplittingClient) Close() {
+	b.c.Close()
+}
+
+func (b *BatchSplittingClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	return b.c.CallContext(ctx, result, method, args...)
+}
+
+func (b *BatchSplittingClient) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	size := int(b.maxBatchSize.Load())
+	if size == 0 || len(batch) <= size {
+		return b.sendBatch(ctx, batch)
+	}
+	g, gCtx := errgroup.WithContext(ctx)
+	for start := 0; start < len(batch); start += size {
+		chunk := batch[start:min(start+size, len(batch))]
+		g.Go(func() error {
+			return b.sendBatch(gCtx, chunk)
+		})
+	}
+	return g.Wait()
+}
+
+func (b *BatchSplittingClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return b.c.EthSubscribe(ctx, channel, args...)
+}
+
+// MaxBatchSize returns the current max number of requests per batch, 0 if unlimited.
+func (b *BatchSplittingClient) MaxBatchSize() int {
+	return int(b.maxBatchSize.Load())
+}
+
+// sendBatch sends a single batch, and bisects it if it is too large for the provider.
+func (b *BatchSplittingClient) sendBatch(ctx context.Context, batch []rpc.BatchElem) error {
+	select {
+	case b.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	err := b.c.BatchCallContext(ctx, batch)
+	<-b.slots
+	if len(batch) == 1 || !batchTooLarge(err, batch) {
+		return err
+	}
+	b.reduceMaxBatchSize(len(batch) / 2)
+	for i := range batch {
+		batch[i].Error = nil
+	}
+	return b.BatchCallContext(ctx, batch)
+}
+
+// reduceMaxBatchSize lowers the max batch size to size, unless it is already lower.
+func (b *BatchSplittingClient) reduceMaxBatchSize(size int) {
+	for {
+		current := b.maxBatchSize.Load()
+		if current != 0 && current <= int64(size) {
+			return
+		}
+		if b.maxBatchSize.CompareAndSwap(current, int64(size)) {
+			b.log.Warn("Batch too large for RPC provider, reducing max batch size", "from", current, "to", size)
+			return
+		}
+	}
+}
+
+// batchTooLarge checks if the batch failed, or any of its requests failed, because the batch was too large.
+func batchTooLarge(err error, batch []rpc.BatchElem) bool {
+	if err != nil {
+		return isTooLargeErr(err)
+	}
+	for _, elem := range batch {
+		if elem.Error != nil && isTooLargeErr(elem.Error) {
+			return true
+		}
+	}
+	return false
+}
+
+func isTooLargeErr(err error) bool {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == responseTooLargeErrCode {
+		return true
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusRequestEntityTooLarge {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, tooLarge := range tooLargeErrMsgs {
+		if strings.Contains(msg, tooLarge) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestBatchSplittingClient(t *testing.T) {
+	t.Run("SplitsToMaxBatchSize", func(t *testing.T) {
+		stub := &stubBatchRPC{}
+		client := NewBatchSplittingClient(testlog.Logger(t, log.LevelInfo), stub, 3, 1)
+		batch := makeBatch(8)
+		require.NoError(t, client.BatchCallContext(context.Background(), batch))
+		requireResults(t, batch)
+		require.ElementsMatch(t, []int{3, 3, 2}, stub.batchSizes())
+	})
+
+	t.Run("UnlimitedBatchSize", func(t *testing.T) {
+		stub := &stubBatchRPC{}
+		client := NewBatchSplittingClient(testlog.Logger(t, log.LevelInfo), stub, 0, 1)
+		batch := makeBatch(8)
+		require.NoError(t, client.BatchCallContext(context.Background(), batch))
+		requireResults(t, batch)
+		require.Equal(t, []int{8}, stub.batchSizes())
+	})
+
+	tooLargeErrs := map[string]func(batch []rpc.BatchElem) error{
+		"BatchError": func(batch []rpc.BatchElem) error {
+			return errors.New("batch too large")
+		},
+		"HTTPError": func(batch []rpc.BatchElem) error {
+			return rpc.HTTPError{StatusCode: http.StatusRequestEntityTooLarge, Status: "413 Request Entity Too Large"}
+		},
+		"ResponseTooLarge": func(batch []rpc.BatchElem) error {
+			// geth returns the results that fit, and fails the remaining requests
+			for i := range batch {
+				if i < 2 {
+					*batch[i].Result.(*string) = fmt.Sprint(batch[i].Args[0])
+				} else {
+					batch[i].Error = &stubRPCError{code: responseTooLargeErrCode, msg: "response too large"}
+				}
+			}
+			return nil
+		},
+	}
+	for name, tooLarge := range tooLargeErrs {
+		tooLarge := tooLarge
+		t.Run("Bisects"+name, func(t *testing.T) {
+			stub := &stubBatchRPC{limit: 3, tooLarge: tooLarge}
+			client := NewBatchSplittingClient(testlog.Logger(t, log.LevelInfo), stub, 10, 2)
+			batch := makeBatch(10)
+			require.NoError(t, client.BatchCallContext(context.Background(), batch))
+			requireResults(t, batch)
+			require.Equal(t, 2, client.MaxBatchSize())
+
+			// Later batches use the reduced batch size straight away
+			stub.reset()
+			batch = makeBatch(4)
+			require.NoError(t, client.BatchCallContext(context.Background(), batch))
+			requireResults(t, batch)
+			require.Equal(t, []int{2, 2}, stub.batchSizes())
+		})
+	}
+
+	t.Run("SingleRequestTooLarge", func(t *testing.T) {
+		expectedErr := errors.New("response too large")
+		stub := &stubBatchRPC{limit: 0, tooLarge: func(batch []rpc.BatchElem) error { return expectedErr }}
+		client := NewBatchSplittingClient(testlog.Logger(t, log.LevelInfo), stub, 4, 1)
+		require.ErrorIs(t, client.BatchCallContext(context.Background(), makeBatch(4)), expectedErr)
+		require.Equal(t, 1, client.MaxBatchSize())
+	})
+
+	t.Run("OtherErrorsNotRetried", func(t *testing.T) {
+		expectedErr := errors.New("boom")
+		stub := &stubBatchRPC{limit: 0, tooLarge: func(batch []rpc.BatchElem) error { return expectedErr }}
+		client := NewBatchSplittingClient(testlog.Logger(t, log.LevelInfo), stub, 4, 1)
+		require.ErrorIs(t, client.BatchCallContext(context.Background(), makeBatch(4)), expectedErr)
+		require.Equal(t, []int{4}, stub.batchSizes())
+		require.Equal(t, 4, client.MaxBatchSize())
+	})
+
+	t.Run("LimitsConcurrency", func(t *testing.T) {
+		stub := &stubBatchRPC{delay: 10 * time.Millisecond}
+		client := NewBatchSplittingClient(testlog.Logger(t, log.LevelInfo), stub, 1, 3)
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				batch := makeBatch(5)
+				require.NoError(t, client.BatchCallContext(context.Background(), batch))
+				requireResults(t, batch)
+			}()
+		}
+		wg.Wait()
+		require.Len(t, stub.batchSizes(), 15)
+		require.Equal(t, 3, stub.maxInFlight)
+	})
+}
+
+func makeBatch(n int) []rpc.BatchElem {
+	batch := make([]rpc.BatchElem, n)
+	for i := range batch {
+		var result string
+		batch[i] = rpc.BatchElem{Method: "test_echo", Args: []any{i}, Result: &result}
+	}
+	return batch
+}
+
+func requireResults(t *testing.T, batch []rpc.BatchElem) {
+	for i, elem := range batch {
+		require.NoError(t, elem.Error)
+		require.Equal(t, fmt.Sprint(i), *elem.Result.(*string))
+	}
+}
+
+type stubRPCError struct {
+	code int
+	msg  string
+}
+
+func (e *stubRPCError) Error() string  { return e.msg }
+func (e *stubRPCError) ErrorCode() int { return e.code }
+
+// stubBatchRPC echoes the args of each request, and fails batches larger than the limit (if set).
+type stubBatchRPC struct {
+	limit    int
+	tooLarge func(batch []rpc.BatchElem) error
+	delay    time.Duration
+
+	mu          sync.Mutex
+	sizes       []int
+	inFlight    int
+	maxInFlight int
+}
+
+func (s *stubBatchRPC) Close() {}
+
+func (s *stubBatchRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	return errors.New("not implemented")
+}
+
+func (s *stubBatchRPC) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	s.mu.Lock()
+	s.sizes = append(s.sizes, len(batch))
+	s.inFlight++
+	s.maxInFlight = max(s.maxInFlight, s.inFlight)
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.inFlight--
+		s.mu.Unlock()
+	}()
+	time.Sleep(s.delay)
+
+	if s.tooLarge != nil && len(batch) > s.limit {
+		return s.tooLarge(batch)
+	}
+	for _, elem := range batch {
+		*elem.Result.(*string) = fmt.Sprint(elem.Args[0])
+	}
+	return nil
+}
+
+func (s *stubBatchRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *stubBatchRPC) batchSizes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int(nil), s.sizes...)
+}
+
+func (s *stubBatchRPC) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sizes = nil
+}
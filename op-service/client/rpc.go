@@ -35,6 +35,8 @@ type rpcConfig struct {
 	backoffAttempts  int
 	limit            float64
 	burst            int
+	maxBatchSize     int
+	batchConcurrency int
 }
 
 type RPCOption func(cfg *rpcConfig) error
@@ -74,6 +76,23 @@ func WithRateLimit(rateLimit float64, burst int) RPCOption {
 	}
 }
 
+// WithBatchSplitting configures the RPC to split batch requests into batches of at most maxBatchSize requests,
+// and to reduce the batch size further if the provider reports batches as too large.
+// At most maxConcurrency batches are sent concurrently. See NewBatchSplittingClient for more details.
+func WithBatchSplitting(maxBatchSize int, maxConcurrency int) RPCOption {
+	return func(cfg *rpcConfig) error {
+		if maxBatchSize < 0 {
+			return fmt.Errorf("invalid max batch size %d", maxBatchSize)
+		}
+		if maxConcurrency < 1 {
+			return fmt.Errorf("max batch concurrency must be at least 1, was %d", maxConcurrency)
+		}
+		cfg.maxBatchSize = maxBatchSize
+		cfg.batchConcurrency = maxConcurrency
+		return nil
+	}
+}
+
 // NewRPC returns the correct client.RPC instance for a given RPC url.
 func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption) (RPC, error) {
 	var cfg rpcConfig
@@ -98,6 +117,10 @@ func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption)
 		wrapped = NewRateLimitingClient(wrapped, rate.Limit(cfg.limit), cfg.burst)
 	}
 
+	if cfg.batchConcurrency != 0 {
+		wrapped = NewBatchSplittingClient(lgr, wrapped, cfg.maxBatchSize, cfg.batchConcurrency)
+	}
+
 	return NewRPCWithClient(ctx, lgr, addr, wrapped, cfg.httpPollInterval)
 }
 
@@ -0,0 +1,60 @@
+// Package da defines a generic interface to data availability layers,
+// so that alternative DA integrations can be plugged into the batcher and derivation
+// without forking either.
+package da
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when the DA layer could not find the data for a commitment.
+var ErrNotFound = errors.New("not found")
+
+// ErrInvalidInput is returned when the input is not valid for posting to the DA layer.
+var ErrInvalidInput = errors.New("invalid input")
+
+// ErrVerificationUnsupported is returned by clients that cannot verify commitments locally.
+var ErrVerificationUnsupported = errors.New("commitment verification not supported")
+
+// ErrInvalidCommitment is returned when a commitment is not encoded as a known commitment type.
+var ErrInvalidCommitment = errors.New("invalid commitment")
+
+// Commitment types, the first byte of an encoded commitment.
+const (
+	// Keccak256CommitmentType is followed by the 32-byte keccak256 hash of the data.
+	Keccak256CommitmentType byte = 0
+	// GenericCommitmentType is followed by a non-empty byte string that only the DA layer interprets.
+	GenericCommitmentType byte = 1
+)
+
+// CheckCommitment returns ErrInvalidCommitment if the commitment is not encoded as a known commitment type.
+func CheckCommitment(commitment []byte) error {
+	if len(commitment) < 2 {
+		return ErrInvalidCommitment
+	}
+	switch commitment[0] {
+	case Keccak256CommitmentType:
+		if len(commitment) != 1+32 {
+			return ErrInvalidCommitment
+		}
+	case GenericCommitmentType:
+	default:
+		return ErrInvalidCommitment
+	}
+	return nil
+}
+
+// Client is the interface to a data availability layer.
+// Commitments are encoded as their commitment type byte followed by the commitment, see CheckCommitment.
+// The batcher posts the commitments returned by Put to L1, and derivation passes them back to Get,
+// so a commitment of another encoding can't be derived from.
+type Client interface {
+	// Put stores the data in the DA layer and returns the encoded commitment to it.
+	Put(ctx context.Context, data []byte) ([]byte, error)
+	// Get retrieves the data for the given commitment.
+	// ErrNotFound is returned if the DA layer does not have the data.
+	Get(ctx context.Context, commitment []byte) ([]byte, error)
+	// VerifyCommitment checks that the data matches the given commitment.
+	VerifyCommitment(commitment []byte, data []byte) error
+}
@@ -0,0 +1,88 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// VerifyFn checks that the data matches the given commitment.
+type VerifyFn func(commitment []byte, data []byte) error
+
+// HTTPClient is a reference Client for DA servers that speak the alt-DA HTTP protocol:
+// data is stored with a POST to /put/, which responds with the encoded commitment, and is
+// retrieved with a GET from /get/0x<encoded commitment>.
+// DA layers that are integrated through such a server only need to provide a VerifyFn
+// to support commitment verification.
+type HTTPClient struct {
+	url    string
+	client *http.Client
+	verify VerifyFn
+}
+
+var _ Client = (*HTTPClient)(nil)
+
+// NewHTTPClient creates a new HTTPClient for the DA server at the given URL.
+// The verify function is optional; if nil, VerifyCommitment returns ErrVerificationUnsupported.
+func NewHTTPClient(url string, verify VerifyFn) *HTTPClient {
+	return &HTTPClient{
+		url:    url,
+		client: http.DefaultClient,
+		verify: verify,
+	}
+}
+
+func (c *HTTPClient) Put(ctx context.Context, data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidInput
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/put/", c.url), bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to store data: %v", resp.StatusCode)
+	}
+	commitment, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := CheckCommitment(commitment); err != nil {
+		return nil, fmt.Errorf("DA server returned commitment %x: %w", commitment, err)
+	}
+	return commitment, nil
+}
+
+func (c *HTTPClient) Get(ctx context.Context, commitment []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/get/0x%x", c.url, commitment), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get data: %v", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (c *HTTPClient) VerifyCommitment(commitment []byte, data []byte) error {
+	if c.verify == nil {
+		return ErrVerificationUnsupported
+	}
+	return c.verify(commitment, data)
+}
@@ -0,0 +1,104 @@
+package da
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	store := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/put/":
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			comm := keccakCommitment(data)
+			store[string(comm)] = data
+			_, _ = w.Write(comm)
+		case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/get/0x"):
+			comm, err := hex.DecodeString(strings.TrimPrefix(r.URL.Path, "/get/0x"))
+			require.NoError(t, err)
+			data, ok := store[string(comm)]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func keccakCommitment(data []byte) []byte {
+	return append([]byte{Keccak256CommitmentType}, crypto.Keccak256(data)...)
+}
+
+func verifyKeccak(commitment []byte, data []byte) error {
+	if !bytes.Equal(commitment, keccakCommitment(data)) {
+		return errors.New("commitment mismatch")
+	}
+	return nil
+}
+
+func TestHTTPClient(t *testing.T) {
+	srv := newTestServer(t)
+	ctx := context.Background()
+	client := NewHTTPClient(srv.URL, verifyKeccak)
+
+	_, err := client.Put(ctx, nil)
+	require.ErrorIs(t, err, ErrInvalidInput)
+
+	input := []byte("hello world")
+	comm, err := client.Put(ctx, input)
+	require.NoError(t, err)
+	require.Equal(t, keccakCommitment(input), comm)
+
+	data, err := client.Get(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, input, data)
+	require.NoError(t, client.VerifyCommitment(comm, data))
+	require.Error(t, client.VerifyCommitment(comm, []byte("tampered")))
+
+	_, err = client.Get(ctx, keccakCommitment([]byte("unknown")))
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestHTTPClientWithoutVerifier(t *testing.T) {
+	client := NewHTTPClient("http://localhost", nil)
+	require.ErrorIs(t, client.VerifyCommitment([]byte{1}, []byte{2}), ErrVerificationUnsupported)
+}
+
+func TestHTTPClientInvalidCommitment(t *testing.T) {
+	// A server that responds with the bare hash, without the commitment type
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		_, _ = w.Write(crypto.Keccak256(data))
+	}))
+	t.Cleanup(srv.Close)
+	_, err := NewHTTPClient(srv.URL, nil).Put(context.Background(), []byte("hello world"))
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+}
+
+func TestCheckCommitment(t *testing.T) {
+	require.NoError(t, CheckCommitment(keccakCommitment([]byte("hello world"))))
+	require.NoError(t, CheckCommitment([]byte{GenericCommitmentType, 0xaa}))
+	require.ErrorIs(t, CheckCommitment(nil), ErrInvalidCommitment)
+	require.ErrorIs(t, CheckCommitment([]byte{GenericCommitmentType}), ErrInvalidCommitment)
+	require.ErrorIs(t, CheckCommitment(crypto.Keccak256([]byte("hello world"))), ErrInvalidCommitment, "missing commitment type")
+	require.ErrorIs(t, CheckCommitment([]byte{Keccak256CommitmentType, 0xaa}), ErrInvalidCommitment)
+	require.ErrorIs(t, CheckCommitment([]byte{0x02, 0xaa}), ErrInvalidCommitment)
+}
@@ -1,4 +1,4 @@
-package geth
+package devgeth
 
 import (
 	"encoding/binary"
@@ -18,14 +18,13 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	opeth "github.com/ethereum-optimism/optimism/op-service/eth"
-	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
 
 type Beacon interface {
 	StoreBlobsBundle(slot uint64, bundle *engine.BlobsBundleV1) error
 }
 
-// fakePoS is a testing-only utility to attach to Geth,
+// fakePoS is a devnet and testing utility to attach to Geth,
 // to build a fake proof-of-stake L1 chain with fixed block time and basic lagging safe/finalized blocks.
 type fakePoS struct {
 	clock     clock.Clock
@@ -89,10 +88,12 @@ func (f *fakePoS) Start() error {
 				// create some random withdrawals
 				withdrawals := make([]*types.Withdrawal, withdrawalsRNG.Intn(4))
 				for i := 0; i < len(withdrawals); i++ {
+					var addr common.Address
+					withdrawalsRNG.Read(addr[:])
 					withdrawals[i] = &types.Withdrawal{
 						Index:     f.withdrawalsIndex + uint64(i),
 						Validator: withdrawalsRNG.Uint64() % 100_000_000, // 100 million fake validators
-						Address:   testutils.RandomAddress(withdrawalsRNG),
+						Address:   addr,
 						// in gwei, consensus-layer quirk. withdraw non-zero value up to 50 ETH
 						Amount: uint64(withdrawalsRNG.Intn(50_000_000_000) + 1),
 					}
@@ -1,4 +1,6 @@
-package geth
+// Package devgeth runs in-process geth nodes for devnets and tests: an L1 node with blocks sequenced by a
+// fake proof-of-stake consensus layer, and L2 nodes driven through the engine API.
+package devgeth
 
 import (
 	"fmt"
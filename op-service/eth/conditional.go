@@ -0,0 +1,141 @@
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+var (
+	// ErrConditionalNotYetValid is returned when the block number or timestamp is before the range of a conditional.
+	ErrConditionalNotYetValid = errors.New("conditional not yet valid")
+	// ErrConditionalExpired is returned when the block number or timestamp is after the range of a conditional.
+	ErrConditionalExpired = errors.New("conditional expired")
+	// ErrKnownAccountMismatch is returned when the state of an account does not match the known account of a conditional.
+	ErrKnownAccountMismatch = errors.New("known account mismatch")
+)
+
+// KnownAccount is the expected state of an account in a TransactionConditional:
+// either the root of its storage trie, or the values of a set of its storage slots.
+type KnownAccount struct {
+	StorageRoot  *common.Hash
+	StorageSlots map[common.Hash]common.Hash
+}
+
+// UnmarshalJSON decodes either a storage root hash, or an object of storage slot values.
+func (a *KnownAccount) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var root common.Hash
+		if err := json.Unmarshal(data, &root); err != nil {
+			return err
+		}
+		*a = KnownAccount{StorageRoot: &root}
+		return nil
+	}
+	var slots map[common.Hash]common.Hash
+	if err := json.Unmarshal(data, &slots); err != nil {
+		return err
+	}
+	*a = KnownAccount{StorageSlots: slots}
+	return nil
+}
+
+func (a KnownAccount) MarshalJSON() ([]byte, error) {
+	if a.StorageRoot != nil {
+		return json.Marshal(a.StorageRoot)
+	}
+	return json.Marshal(a.StorageSlots)
+}
+
+// Slots returns the storage slots to retrieve to check the known account.
+func (a KnownAccount) Slots() []common.Hash {
+	slots := make([]common.Hash, 0, len(a.StorageSlots))
+	for slot := range a.StorageSlots {
+		slots = append(slots, slot)
+	}
+	return slots
+}
+
+// Check checks the account state matches the known account.
+// The account must include storage proof entries for the Slots of the known account.
+func (a KnownAccount) Check(account *AccountResult) error {
+	if a.StorageRoot != nil {
+		if account.StorageHash != *a.StorageRoot {
+			return fmt.Errorf("%w: %v storage root %v, expected %v",
+				ErrKnownAccountMismatch, account.Address, account.StorageHash, *a.StorageRoot)
+		}
+		return nil
+	}
+	values := make(map[common.Hash]common.Hash, len(account.StorageProof))
+	for _, entry := range account.StorageProof {
+		values[entry.Key] = common.BigToHash(entry.Value.ToInt())
+	}
+	for slot, expected := range a.StorageSlots {
+		actual, ok := values[slot]
+		if !ok {
+			return fmt.Errorf("%w: %v storage slot %v not retrieved", ErrKnownAccountMismatch, account.Address, slot)
+		}
+		if actual != expected {
+			return fmt.Errorf("%w: %v storage slot %v is %v, expected %v",
+				ErrKnownAccountMismatch, account.Address, slot, actual, expected)
+		}
+	}
+	return nil
+}
+
+// TransactionConditional is the set of conditions a transaction submitted with
+// eth_sendRawTransactionConditional requires to hold for it to be included in a block.
+// The block number and timestamp ranges are inclusive, and each bound is optional.
+type TransactionConditional struct {
+	KnownAccounts  map[common.Address]KnownAccount `json:"knownAccounts"`
+	BlockNumberMin *hexutil.Uint64                 `json:"blockNumberMin,omitempty"`
+	BlockNumberMax *hexutil.Uint64                 `json:"blockNumberMax,omitempty"`
+	TimestampMin   *hexutil.Uint64                 `json:"timestampMin,omitempty"`
+	TimestampMax   *hexutil.Uint64                 `json:"timestampMax,omitempty"`
+}
+
+// Cost is the number of storage roots and slots that are checked to validate the known accounts.
+func (c *TransactionConditional) Cost() int {
+	cost := 0
+	for _, account := range c.KnownAccounts {
+		if account.StorageRoot != nil {
+			cost += 1
+		} else {
+			cost += len(account.StorageSlots)
+		}
+	}
+	return cost
+}
+
+// Validate checks the ranges of the conditional are not empty.
+func (c *TransactionConditional) Validate() error {
+	if c.BlockNumberMin != nil && c.BlockNumberMax != nil && *c.BlockNumberMin > *c.BlockNumberMax {
+		return fmt.Errorf("block number min %d is greater than max %d", *c.BlockNumberMin, *c.BlockNumberMax)
+	}
+	if c.TimestampMin != nil && c.TimestampMax != nil && *c.TimestampMin > *c.TimestampMax {
+		return fmt.Errorf("timestamp min %d is greater than max %d", *c.TimestampMin, *c.TimestampMax)
+	}
+	return nil
+}
+
+// CheckBlock checks the block number and timestamp are within the ranges of the conditional.
+// Returns ErrConditionalExpired if the conditional can no longer hold for any later block,
+// or ErrConditionalNotYetValid if it may still hold for a later block.
+func (c *TransactionConditional) CheckBlock(number uint64, timestamp uint64) error {
+	if c.BlockNumberMax != nil && number > uint64(*c.BlockNumberMax) {
+		return fmt.Errorf("%w: block number %d after max %d", ErrConditionalExpired, number, *c.BlockNumberMax)
+	}
+	if c.TimestampMax != nil && timestamp > uint64(*c.TimestampMax) {
+		return fmt.Errorf("%w: timestamp %d after max %d", ErrConditionalExpired, timestamp, *c.TimestampMax)
+	}
+	if c.BlockNumberMin != nil && number < uint64(*c.BlockNumberMin) {
+		return fmt.Errorf("%w: block number %d before min %d", ErrConditionalNotYetValid, number, *c.BlockNumberMin)
+	}
+	if c.TimestampMin != nil && timestamp < uint64(*c.TimestampMin) {
+		return fmt.Errorf("%w: timestamp %d before min %d", ErrConditionalNotYetValid, timestamp, *c.TimestampMin)
+	}
+	return nil
+}
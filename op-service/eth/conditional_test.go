@@ -0,0 +1,102 @@
+package eth
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionConditionalJSON(t *testing.T) {
+	input := `{
+		"knownAccounts": {
+			"0x1111111111111111111111111111111111111111": "0x2222222222222222222222222222222222222222222222222222222222222222",
+			"0x3333333333333333333333333333333333333333": {
+				"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000004"
+			}
+		},
+		"blockNumberMin": "0x10",
+		"timestampMax": "0x20"
+	}`
+	var cond TransactionConditional
+	require.NoError(t, json.Unmarshal([]byte(input), &cond))
+
+	root := common.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222")
+	expected := TransactionConditional{
+		KnownAccounts: map[common.Address]KnownAccount{
+			common.HexToAddress("0x1111111111111111111111111111111111111111"): {StorageRoot: &root},
+			common.HexToAddress("0x3333333333333333333333333333333333333333"): {StorageSlots: map[common.Hash]common.Hash{
+				common.BigToHash(big.NewInt(1)): common.BigToHash(big.NewInt(4)),
+			}},
+		},
+		BlockNumberMin: uint64Ptr(0x10),
+		TimestampMax:   uint64Ptr(0x20),
+	}
+	require.Equal(t, expected, cond)
+	require.Equal(t, 2, cond.Cost())
+
+	out, err := json.Marshal(cond)
+	require.NoError(t, err)
+	var roundTrip TransactionConditional
+	require.NoError(t, json.Unmarshal(out, &roundTrip))
+	require.Equal(t, expected, roundTrip)
+}
+
+func TestTransactionConditionalValidate(t *testing.T) {
+	require.NoError(t, (&TransactionConditional{}).Validate())
+	require.NoError(t, (&TransactionConditional{BlockNumberMin: uint64Ptr(5), BlockNumberMax: uint64Ptr(5)}).Validate())
+	require.ErrorContains(t, (&TransactionConditional{BlockNumberMin: uint64Ptr(6), BlockNumberMax: uint64Ptr(5)}).Validate(), "block number")
+	require.ErrorContains(t, (&TransactionConditional{TimestampMin: uint64Ptr(6), TimestampMax: uint64Ptr(5)}).Validate(), "timestamp")
+}
+
+func TestTransactionConditionalCheckBlock(t *testing.T) {
+	cond := &TransactionConditional{
+		BlockNumberMin: uint64Ptr(10),
+		BlockNumberMax: uint64Ptr(20),
+		TimestampMin:   uint64Ptr(100),
+		TimestampMax:   uint64Ptr(200),
+	}
+	require.NoError(t, cond.CheckBlock(10, 100))
+	require.NoError(t, cond.CheckBlock(20, 200))
+	require.ErrorIs(t, cond.CheckBlock(9, 150), ErrConditionalNotYetValid)
+	require.ErrorIs(t, cond.CheckBlock(15, 99), ErrConditionalNotYetValid)
+	require.ErrorIs(t, cond.CheckBlock(21, 150), ErrConditionalExpired)
+	require.ErrorIs(t, cond.CheckBlock(15, 201), ErrConditionalExpired)
+	// Expiry takes precedence, as the conditional can't become valid later
+	require.ErrorIs(t, cond.CheckBlock(9, 201), ErrConditionalExpired)
+
+	require.NoError(t, (&TransactionConditional{}).CheckBlock(0, 0))
+}
+
+func TestKnownAccountCheck(t *testing.T) {
+	addr := common.Address{0xaa}
+	root := common.Hash{0x01}
+	slot := common.Hash{0x02}
+	account := &AccountResult{
+		Address:     addr,
+		StorageHash: root,
+		StorageProof: []StorageProofEntry{
+			{Key: slot, Value: hexutil.Big(*big.NewInt(7))},
+		},
+	}
+
+	require.NoError(t, KnownAccount{StorageRoot: &root}.Check(account))
+	require.ErrorIs(t, KnownAccount{StorageRoot: &common.Hash{0x03}}.Check(account), ErrKnownAccountMismatch)
+
+	slots := KnownAccount{StorageSlots: map[common.Hash]common.Hash{slot: common.BigToHash(big.NewInt(7))}}
+	require.Equal(t, []common.Hash{slot}, slots.Slots())
+	require.NoError(t, slots.Check(account))
+
+	wrongValue := KnownAccount{StorageSlots: map[common.Hash]common.Hash{slot: common.BigToHash(big.NewInt(8))}}
+	require.ErrorIs(t, wrongValue.Check(account), ErrKnownAccountMismatch)
+
+	missingSlot := KnownAccount{StorageSlots: map[common.Hash]common.Hash{{0x04}: {}}}
+	require.ErrorIs(t, missingSlot.Check(account), ErrKnownAccountMismatch)
+}
+
+func uint64Ptr(v uint64) *hexutil.Uint64 {
+	return (*hexutil.Uint64)(&v)
+}
@@ -0,0 +1,47 @@
+package eth
+
+// DerivationSimulation is the result of simulating the derivation of candidate batcher data
+// on top of the current safe head of a node.
+type DerivationSimulation struct {
+	// SafeHead is the L2 safe head the batches were checked against.
+	SafeHead L2BlockRef `json:"safeHead"`
+	// L1Head is the L1 block the batcher data is assumed to be included in.
+	L1Head L1BlockRef `json:"l1Head"`
+	// Channels are the channels of the submitted frames, in the order they were first seen.
+	Channels []SimulatedChannel `json:"channels"`
+	// Blocks are the L2 blocks that would be derived from the batcher data, in order.
+	Blocks []SimulatedBlock `json:"blocks"`
+}
+
+// SimulatedChannel is a channel of the batcher data of a derivation simulation.
+type SimulatedChannel struct {
+	ID     string `json:"id"`
+	Frames int    `json:"frames"`
+	// Ready is true if all frames of the channel were submitted, and the channel can be read.
+	Ready   bool             `json:"ready"`
+	Batches []SimulatedBatch `json:"batches"`
+	// Error describes why (part of) the channel can't be read, if it can't.
+	Error string `json:"error,omitempty"`
+}
+
+// SimulatedBatch is a batch read from a channel of a derivation simulation.
+type SimulatedBatch struct {
+	// Type is "singular" or "span".
+	Type      string `json:"type"`
+	Timestamp uint64 `json:"timestamp"`
+	// Validity is the outcome of the batch checks: "accept", "drop", "future" or "undecided".
+	Validity string `json:"validity"`
+	// Blocks are the L2 blocks derived from the batch, if it is accepted.
+	Blocks []SimulatedBlock `json:"blocks,omitempty"`
+}
+
+// SimulatedBlock is an L2 block derived in a derivation simulation.
+type SimulatedBlock struct {
+	Number       uint64  `json:"number"`
+	Timestamp    uint64  `json:"timestamp"`
+	L1Origin     BlockID `json:"l1Origin"`
+	Transactions int     `json:"transactions"`
+	// Unsafe is the local unsafe block the derived block builds on the chain of, if there is one.
+	// The batches that follow are checked against it, and are left undecided if there is none.
+	Unsafe *L2BlockRef `json:"unsafe,omitempty"`
+}
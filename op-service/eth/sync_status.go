@@ -1,5 +1,7 @@
 package eth
 
+import "github.com/ethereum/go-ethereum/common"
+
 // SyncStatus is a snapshot of the driver.
 // Values may be zeroed if not yet initialized.
 type SyncStatus struct {
@@ -29,6 +31,33 @@ type SyncStatus struct {
 	// FinalizedL2 points to the L2 block that was derived fully from
 	// finalized L1 information, thus irreversible.
 	FinalizedL2 L2BlockRef `json:"finalized_l2"`
+	// CrossSafeL2 points to the L2 block that is safe, including all its cross-chain dependencies.
+	// This is only set in interop mode, where the SafeL2 block is only locally safe until the supervisor
+	// verified its executing messages.
+	CrossSafeL2 L2BlockRef `json:"cross_safe_l2"`
 	// PendingSafeL2 points to the L2 block processed from the batch, but not consolidated to the safe block yet.
 	PendingSafeL2 L2BlockRef `json:"pending_safe_l2"`
+	// ELSync is the progress of the execution engine, while the rollup node relies on it to sync the chain.
+	// This is zeroed if the node is not EL syncing.
+	ELSync ELSyncStatus `json:"el_sync"`
+	// RollupConfigHash is the hash of the rollup config the node runs with, to detect mismatched configs.
+	// This is zeroed if the node does not report it.
+	RollupConfigHash common.Hash `json:"rollup_config_hash"`
+}
+
+// ELSyncStatus is the progress of an execution engine that is syncing by itself (e.g. with snap sync),
+// as reported by the engine. Derivation is held off until the EL sync is finished.
+type ELSyncStatus struct {
+	// Syncing is true while the rollup node waits for the execution engine to sync.
+	Syncing bool `json:"syncing"`
+	// StartingBlock, CurrentBlock and HighestBlock are the L2 block numbers of the sync of the engine.
+	StartingBlock uint64 `json:"starting_block"`
+	CurrentBlock  uint64 `json:"current_block"`
+	HighestBlock  uint64 `json:"highest_block"`
+	// Snap sync progress of the state
+	SyncedAccounts   uint64 `json:"synced_accounts"`
+	SyncedStorage    uint64 `json:"synced_storage"`
+	SyncedBytecodes  uint64 `json:"synced_bytecodes"`
+	HealedTrienodes  uint64 `json:"healed_trienodes"`
+	HealingTrienodes uint64 `json:"healing_trienodes"`
 }
@@ -0,0 +1,17 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ExecutionWitness is the state accessed when executing a block: enough to execute the block statelessly.
+// It is the result of debug_executionWitness.
+type ExecutionWitness struct {
+	// Keys are the preimages of the hashed account addresses and storage slots that were accessed.
+	Keys map[common.Hash]hexutil.Bytes `json:"keys"`
+	// Codes are the contract codes that were executed, by code hash.
+	Codes map[common.Hash]hexutil.Bytes `json:"codes"`
+	// State are the account and storage trie nodes that were accessed, by node hash.
+	State map[common.Hash]hexutil.Bytes `json:"state"`
+}
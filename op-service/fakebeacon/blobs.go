@@ -21,7 +21,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
-// FakeBeacon presents a beacon-node in testing, without leading any chain-building.
+// FakeBeacon presents a beacon-node in devnets and testing, without leading any chain-building.
 // This merely serves a fake beacon API, and holds on to blocks,
 // to complement the actual block-building to happen in testing (e.g. through the fake consensus geth module).
 type FakeBeacon struct {
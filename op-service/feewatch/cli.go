@@ -0,0 +1,95 @@
+package feewatch
+
+import (
+	"errors"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const (
+	EnabledFlagName       = "fee-watch.enabled"
+	WindowFlagName        = "fee-watch.window"
+	AlertAfterFlagName    = "fee-watch.alert-after"
+	MinMarginFlagName     = "fee-watch.min-margin"
+	CheckIntervalFlagName = "fee-watch.check-interval"
+)
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    EnabledFlagName,
+			Usage:   "Watch the fee scalars in the SystemConfig contract and the realized fee margin, and alert when the chain is submitting data at a loss",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "FEE_WATCH_ENABLED"),
+		},
+		&cli.DurationFlag{
+			Name:    WindowFlagName,
+			Usage:   "Period over which the realized fee margin is computed",
+			Value:   time.Hour,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "FEE_WATCH_WINDOW"),
+		},
+		&cli.DurationFlag{
+			Name:    AlertAfterFlagName,
+			Usage:   "Period the fee margin must stay below the minimum margin before alerting",
+			Value:   30 * time.Minute,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "FEE_WATCH_ALERT_AFTER"),
+		},
+		&cli.Float64Flag{
+			Name:    MinMarginFlagName,
+			Usage:   "Minimum fee margin, as the fee revenue relative to the L1 cost minus 1. E.g. 0.1 alerts when the revenue is less than 110% of the cost",
+			Value:   0,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "FEE_WATCH_MIN_MARGIN"),
+		},
+		&cli.DurationFlag{
+			Name:    CheckIntervalFlagName,
+			Usage:   "Interval of the fee scalars and margin checks",
+			Value:   time.Minute,
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "FEE_WATCH_CHECK_INTERVAL"),
+		},
+	}
+}
+
+type CLIConfig struct {
+	Enabled       bool
+	Window        time.Duration
+	AlertAfter    time.Duration
+	MinMargin     float64
+	CheckInterval time.Duration
+}
+
+func (c CLIConfig) Check() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Window <= 0 {
+		return errors.New("fee watch window must be positive")
+	}
+	if c.AlertAfter < 0 {
+		return errors.New("fee watch alert-after must not be negative")
+	}
+	if c.CheckInterval <= 0 {
+		return errors.New("fee watch check interval must be positive")
+	}
+	return nil
+}
+
+func (c CLIConfig) Config() Config {
+	return Config{
+		Window:        c.Window,
+		AlertAfter:    c.AlertAfter,
+		MinMargin:     c.MinMargin,
+		CheckInterval: c.CheckInterval,
+	}
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		Enabled:       ctx.Bool(EnabledFlagName),
+		Window:        ctx.Duration(WindowFlagName),
+		AlertAfter:    ctx.Duration(AlertAfterFlagName),
+		MinMargin:     ctx.Float64(MinMarginFlagName),
+		CheckInterval: ctx.Duration(CheckIntervalFlagName),
+	}
+}
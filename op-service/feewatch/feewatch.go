@@ -0,0 +1,171 @@
+// Package feewatch watches the fee scalars of the chain and the realized fee margin of its data submission,
+// and alerts when the chain is submitting data at a loss for a sustained period.
+package feewatch
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// FeeScalarsSource provides the current fee scalars of the chain, e.g. the SystemConfigContract.
+type FeeScalarsSource interface {
+	FeeScalars(ctx context.Context) (FeeScalars, error)
+}
+
+type Config struct {
+	// Window is the period over which the realized fee margin is computed.
+	Window time.Duration
+	// AlertAfter is the period the margin must stay below MinMargin before alerting.
+	AlertAfter time.Duration
+	// MinMargin is the minimum relative fee margin, e.g. 0.1 for a fee revenue 10% over the L1 cost.
+	MinMargin float64
+	// CheckInterval is the interval of the fee scalars and margin checks.
+	CheckInterval time.Duration
+}
+
+// observation is the L1 cost and the L1 data fee revenue of submitted data, e.g. a channel.
+type observation struct {
+	time    time.Time
+	cost    *big.Int
+	revenue *big.Int
+}
+
+// Watcher computes the realized fee margin of the observed data submissions over a sliding window,
+// and alerts, as an error log and metric, when it stays below the minimum margin for a sustained period.
+type Watcher struct {
+	log     log.Logger
+	metrics FeeMetricer
+	clock   clock.Clock
+	scalars FeeScalarsSource
+	cfg     Config
+
+	mu           sync.Mutex
+	observations []observation
+
+	// lastScalars are the fee scalars at the last check. nil if not fetched yet.
+	lastScalars *FeeScalars
+	// lossSince is the time the margin dropped below the minimum margin. Zero if it is not below the minimum.
+	lossSince time.Time
+	alerting  bool
+
+	loop *clock.LoopFn
+}
+
+func NewWatcher(log log.Logger, metrics FeeMetricer, cl clock.Clock, scalars FeeScalarsSource, cfg Config) *Watcher {
+	return &Watcher{
+		log:     log,
+		metrics: metrics,
+		clock:   cl,
+		scalars: scalars,
+		cfg:     cfg,
+	}
+}
+
+// Start checks the fee scalars and margin every check interval, until the watcher is closed.
+func (w *Watcher) Start() {
+	w.loop = clock.NewLoopFn(w.clock, w.Check, nil, w.cfg.CheckInterval)
+}
+
+func (w *Watcher) Close() error {
+	if w.loop == nil {
+		return nil
+	}
+	return w.loop.Close()
+}
+
+// Observe adds the L1 cost and the L1 data fee revenue in wei of submitted data.
+func (w *Watcher) Observe(cost, revenue *big.Int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.observations = append(w.observations, observation{
+		time:    w.clock.Now(),
+		cost:    new(big.Int).Set(cost),
+		revenue: new(big.Int).Set(revenue),
+	})
+}
+
+// Check fetches the fee scalars and computes the realized fee margin over the window,
+// raising or clearing the loss alert.
+func (w *Watcher) Check(ctx context.Context) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	scalars, err := w.scalars.FeeScalars(ctx)
+	if err != nil {
+		w.log.Warn("Failed to fetch fee scalars", "err", err)
+	} else {
+		if w.lastScalars != nil && *w.lastScalars != scalars {
+			w.log.Info("Fee scalars changed",
+				"base_fee_scalar", scalars.BaseFeeScalar, "prev_base_fee_scalar", w.lastScalars.BaseFeeScalar,
+				"blob_base_fee_scalar", scalars.BlobBaseFeeScalar, "prev_blob_base_fee_scalar", w.lastScalars.BlobBaseFeeScalar)
+		}
+		w.lastScalars = &scalars
+		w.metrics.RecordFeeScalars(scalars)
+	}
+
+	now := w.clock.Now()
+	cost, revenue := w.totals(now)
+	if cost.Sign() == 0 {
+		// Nothing was submitted within the window, so there is no margin to judge
+		w.resetLoss()
+		return
+	}
+	margin := relativeMargin(cost, revenue)
+	w.metrics.RecordFeeMargin(margin)
+
+	if margin >= w.cfg.MinMargin {
+		w.resetLoss()
+		return
+	}
+	if w.lossSince.IsZero() {
+		w.lossSince = now
+	}
+	if since := now.Sub(w.lossSince); since >= w.cfg.AlertAfter {
+		logCtx := []any{"margin", margin, "min_margin", w.cfg.MinMargin, "since", w.lossSince,
+			"l1_cost", cost, "fee_revenue", revenue}
+		if w.lastScalars != nil {
+			logCtx = append(logCtx, "base_fee_scalar", w.lastScalars.BaseFeeScalar, "blob_base_fee_scalar", w.lastScalars.BlobBaseFeeScalar)
+		}
+		w.log.Error("Chain is submitting data at a loss, fee scalars need adjustment", logCtx...)
+		w.alerting = true
+		w.metrics.RecordFeeLossAlert(true)
+	}
+}
+
+// totals prunes the observations outside the window, and returns the total cost and revenue of the others.
+func (w *Watcher) totals(now time.Time) (cost, revenue *big.Int) {
+	start := now.Add(-w.cfg.Window)
+	i := 0
+	for i < len(w.observations) && w.observations[i].time.Before(start) {
+		i++
+	}
+	w.observations = w.observations[i:]
+
+	cost, revenue = new(big.Int), new(big.Int)
+	for _, o := range w.observations {
+		cost.Add(cost, o.cost)
+		revenue.Add(revenue, o.revenue)
+	}
+	return cost, revenue
+}
+
+func (w *Watcher) resetLoss() {
+	w.lossSince = time.Time{}
+	if w.alerting {
+		w.log.Info("Chain is no longer submitting data at a loss")
+		w.alerting = false
+		w.metrics.RecordFeeLossAlert(false)
+	}
+}
+
+// relativeMargin returns the fee revenue relative to the L1 cost, minus 1. The cost must not be zero.
+func relativeMargin(cost, revenue *big.Int) float64 {
+	ratio, _ := new(big.Float).Quo(new(big.Float).SetInt(revenue), new(big.Float).SetInt(cost)).Float64()
+	return ratio - 1
+}
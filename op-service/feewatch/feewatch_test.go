@@ -0,0 +1,157 @@
+package feewatch
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type stubScalars struct {
+	scalars FeeScalars
+	err     error
+}
+
+func (s *stubScalars) FeeScalars(context.Context) (FeeScalars, error) {
+	return s.scalars, s.err
+}
+
+type recordingMetrics struct {
+	scalars  FeeScalars
+	margin   float64
+	alerting bool
+}
+
+func (m *recordingMetrics) RecordFeeScalars(scalars FeeScalars) { m.scalars = scalars }
+func (m *recordingMetrics) RecordFeeMargin(margin float64)      { m.margin = margin }
+func (m *recordingMetrics) RecordFeeLossAlert(active bool)      { m.alerting = active }
+
+var testConfig = Config{
+	Window:        time.Hour,
+	AlertAfter:    10 * time.Minute,
+	MinMargin:     0.1,
+	CheckInterval: time.Minute,
+}
+
+func setupWatcher(t *testing.T) (*Watcher, *clock.DeterministicClock, *recordingMetrics, *stubScalars, *testlog.CapturingHandler) {
+	lgr, logs := testlog.CaptureLogger(t, slog.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	m := &recordingMetrics{}
+	scalars := &stubScalars{scalars: FeeScalars{BaseFeeScalar: 1368, BlobBaseFeeScalar: 810949}}
+	return NewWatcher(lgr, m, cl, scalars, testConfig), cl, m, scalars, logs
+}
+
+func TestWatcher(t *testing.T) {
+	ctx := context.Background()
+	lossAlert := testlog.NewMessageContainsFilter("submitting data at a loss")
+
+	t.Run("Profitable", func(t *testing.T) {
+		w, cl, m, _, logs := setupWatcher(t)
+		w.Observe(big.NewInt(100), big.NewInt(150))
+		w.Check(ctx)
+		require.InDelta(t, 0.5, m.margin, 1e-9)
+		require.Equal(t, FeeScalars{BaseFeeScalar: 1368, BlobBaseFeeScalar: 810949}, m.scalars)
+
+		cl.AdvanceTime(time.Hour)
+		w.Check(ctx)
+		require.False(t, m.alerting)
+		require.Nil(t, logs.FindLog(lossAlert))
+	})
+
+	t.Run("SustainedLoss", func(t *testing.T) {
+		w, cl, m, _, logs := setupWatcher(t)
+		w.Observe(big.NewInt(100), big.NewInt(105))
+		w.Check(ctx)
+		require.InDelta(t, 0.05, m.margin, 1e-9)
+		require.False(t, m.alerting, "loss is not sustained yet")
+
+		cl.AdvanceTime(9 * time.Minute)
+		w.Check(ctx)
+		require.False(t, m.alerting, "loss is not sustained yet")
+
+		cl.AdvanceTime(time.Minute)
+		w.Check(ctx)
+		require.True(t, m.alerting)
+		require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(slog.LevelError), lossAlert))
+
+		// Recovers once the margin is back above the minimum
+		w.Observe(big.NewInt(100), big.NewInt(200))
+		w.Check(ctx)
+		require.InDelta(t, 0.525, m.margin, 1e-9)
+		require.False(t, m.alerting)
+		require.NotNil(t, logs.FindLog(testlog.NewMessageContainsFilter("no longer submitting data at a loss")))
+	})
+
+	t.Run("TemporaryLoss", func(t *testing.T) {
+		w, cl, m, _, logs := setupWatcher(t)
+		w.Observe(big.NewInt(100), big.NewInt(50))
+		w.Check(ctx)
+		cl.AdvanceTime(5 * time.Minute)
+		w.Observe(big.NewInt(100), big.NewInt(200))
+		w.Check(ctx)
+		require.InDelta(t, 0.25, m.margin, 1e-9)
+
+		// The loss period restarts when the margin drops again
+		w.Observe(big.NewInt(200), big.NewInt(0))
+		w.Check(ctx)
+		cl.AdvanceTime(9 * time.Minute)
+		w.Check(ctx)
+		require.False(t, m.alerting)
+		require.Nil(t, logs.FindLog(lossAlert))
+	})
+
+	t.Run("ObservationsExpire", func(t *testing.T) {
+		w, cl, m, _, _ := setupWatcher(t)
+		w.Observe(big.NewInt(100), big.NewInt(0))
+		cl.AdvanceTime(30 * time.Minute)
+		w.Observe(big.NewInt(100), big.NewInt(300))
+		w.Check(ctx)
+		require.InDelta(t, 0.5, m.margin, 1e-9)
+
+		cl.AdvanceTime(31 * time.Minute)
+		w.Check(ctx)
+		require.InDelta(t, 2, m.margin, 1e-9, "first observation is outside the window")
+	})
+
+	t.Run("NoSubmissions", func(t *testing.T) {
+		w, cl, m, _, _ := setupWatcher(t)
+		w.Observe(big.NewInt(100), big.NewInt(0))
+		w.Check(ctx)
+		cl.AdvanceTime(10 * time.Minute)
+		w.Check(ctx)
+		require.True(t, m.alerting)
+
+		cl.AdvanceTime(time.Hour)
+		w.Check(ctx)
+		require.False(t, m.alerting, "no loss without submissions in the window")
+	})
+
+	t.Run("ScalarsChanged", func(t *testing.T) {
+		w, _, m, scalars, logs := setupWatcher(t)
+		w.Check(ctx)
+		require.Nil(t, logs.FindLog(testlog.NewMessageContainsFilter("Fee scalars changed")))
+
+		scalars.scalars.BlobBaseFeeScalar = 1000000
+		w.Check(ctx)
+		require.Equal(t, uint32(1000000), m.scalars.BlobBaseFeeScalar)
+		require.NotNil(t, logs.FindLog(testlog.NewMessageContainsFilter("Fee scalars changed")))
+	})
+
+	t.Run("ScalarsUnavailable", func(t *testing.T) {
+		w, cl, m, scalars, logs := setupWatcher(t)
+		scalars.err = errors.New("connection refused")
+		w.Observe(big.NewInt(100), big.NewInt(0))
+		w.Check(ctx)
+		cl.AdvanceTime(10 * time.Minute)
+		w.Check(ctx)
+		require.NotNil(t, logs.FindLog(testlog.NewLevelFilter(slog.LevelWarn), testlog.NewMessageContainsFilter("Failed to fetch fee scalars")))
+		require.True(t, m.alerting, "margin is still watched")
+	})
+}
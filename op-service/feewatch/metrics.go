@@ -0,0 +1,75 @@
+package feewatch
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+type FeeMetricer interface {
+	RecordFeeScalars(scalars FeeScalars)
+	RecordFeeMargin(margin float64)
+	RecordFeeLossAlert(active bool)
+}
+
+// FeeMetrics are the fee watcher metrics, to embed in the metrics of the service running the watcher.
+type FeeMetrics struct {
+	baseFeeScalar     prometheus.Gauge
+	blobBaseFeeScalar prometheus.Gauge
+	margin            prometheus.Gauge
+	lossAlert         prometheus.Gauge
+}
+
+var _ FeeMetricer = (*FeeMetrics)(nil)
+
+func MakeFeeMetrics(ns string, factory metrics.Factory) FeeMetrics {
+	return FeeMetrics{
+		baseFeeScalar: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "feewatch",
+			Name:      "base_fee_scalar",
+			Help:      "L1 base fee scalar configured in the SystemConfig contract",
+		}),
+		blobBaseFeeScalar: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "feewatch",
+			Name:      "blob_base_fee_scalar",
+			Help:      "L1 blob base fee scalar configured in the SystemConfig contract",
+		}),
+		margin: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "feewatch",
+			Name:      "fee_margin",
+			Help:      "Realized fee margin over the watch window: L1 data fee revenue relative to the L1 cost of the batches, minus 1",
+		}),
+		lossAlert: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "feewatch",
+			Name:      "loss_alert",
+			Help:      "1 if the chain has been submitting data below the minimum fee margin for a sustained period, 0 otherwise",
+		}),
+	}
+}
+
+func (m *FeeMetrics) RecordFeeScalars(scalars FeeScalars) {
+	m.baseFeeScalar.Set(float64(scalars.BaseFeeScalar))
+	m.blobBaseFeeScalar.Set(float64(scalars.BlobBaseFeeScalar))
+}
+
+func (m *FeeMetrics) RecordFeeMargin(margin float64) {
+	m.margin.Set(margin)
+}
+
+func (m *FeeMetrics) RecordFeeLossAlert(active bool) {
+	if active {
+		m.lossAlert.Set(1)
+	} else {
+		m.lossAlert.Set(0)
+	}
+}
+
+type NoopFeeMetrics struct{}
+
+func (*NoopFeeMetrics) RecordFeeScalars(FeeScalars) {}
+func (*NoopFeeMetrics) RecordFeeMargin(float64)     {}
+func (*NoopFeeMetrics) RecordFeeLossAlert(bool)     {}
@@ -0,0 +1,49 @@
+package feewatch
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+const (
+	methodBasefeeScalar     = "basefeeScalar"
+	methodBlobbasefeeScalar = "blobbasefeeScalar"
+)
+
+// FeeScalars are the Ecotone L1 fee scalars of the chain.
+type FeeScalars struct {
+	BaseFeeScalar     uint32
+	BlobBaseFeeScalar uint32
+}
+
+// SystemConfigContract reads the fee scalars from the SystemConfig contract on L1.
+type SystemConfigContract struct {
+	caller   *batching.MultiCaller
+	contract *batching.BoundContract
+}
+
+func NewSystemConfigContract(caller *batching.MultiCaller, addr common.Address) *SystemConfigContract {
+	return &SystemConfigContract{
+		caller:   caller,
+		contract: batching.NewBoundContract(snapshots.LoadSystemConfigABI(), addr),
+	}
+}
+
+func (c *SystemConfigContract) FeeScalars(ctx context.Context) (FeeScalars, error) {
+	results, err := c.caller.Call(ctx, rpcblock.Latest,
+		c.contract.Call(methodBasefeeScalar),
+		c.contract.Call(methodBlobbasefeeScalar))
+	if err != nil {
+		return FeeScalars{}, fmt.Errorf("failed to fetch fee scalars: %w", err)
+	}
+	return FeeScalars{
+		BaseFeeScalar:     results[0].GetUint32(0),
+		BlobBaseFeeScalar: results[1].GetUint32(0),
+	}, nil
+}
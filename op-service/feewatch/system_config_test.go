@@ -0,0 +1,27 @@
+package feewatch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	batchingTest "github.com/ethereum-optimism/optimism/op-service/sources/batching/test"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+func TestSystemConfigContract_FeeScalars(t *testing.T) {
+	addr := common.Address{0xaa}
+	stubRpc := batchingTest.NewAbiBasedRpc(t, addr, snapshots.LoadSystemConfigABI())
+	caller := batching.NewMultiCaller(stubRpc, batching.DefaultBatchSize)
+	sysCfg := NewSystemConfigContract(caller, addr)
+	stubRpc.SetResponse(addr, methodBasefeeScalar, rpcblock.Latest, nil, []interface{}{uint32(1368)})
+	stubRpc.SetResponse(addr, methodBlobbasefeeScalar, rpcblock.Latest, nil, []interface{}{uint32(810949)})
+
+	scalars, err := sysCfg.FeeScalars(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, FeeScalars{BaseFeeScalar: 1368, BlobBaseFeeScalar: 810949}, scalars)
+}
@@ -0,0 +1,115 @@
+// Package lease implements a lease shared between the instances of a service,
+// to ensure only a single instance is active at a time.
+package lease
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// Namespace is the RPC namespace the lease is served in.
+const Namespace = "lease"
+
+// Lease is a lock shared between the instances of a service, e.g. batchers, to ensure only a single instance is active at a time.
+type Lease interface {
+	// Acquire acquires the lease for holder, or renews it if holder already holds it, for the given ttl.
+	// It returns false if the lease is held by another holder.
+	Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error)
+	// Release releases the lease if it is held by holder.
+	Release(ctx context.Context, holder string) error
+}
+
+// MemoryLease is a Lease kept in memory. It can be shared with other processes by serving it with GetAPI.
+type MemoryLease struct {
+	mu     sync.Mutex
+	clock  clock.Clock
+	holder string
+	expiry time.Time
+}
+
+func NewMemoryLease(clock clock.Clock) *MemoryLease {
+	return &MemoryLease{clock: clock}
+}
+
+func (m *MemoryLease) Acquire(_ context.Context, holder string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock.Now()
+	if m.holder != "" && m.holder != holder && now.Before(m.expiry) {
+		return false, nil
+	}
+	m.holder = holder
+	m.expiry = now.Add(ttl)
+	return true, nil
+}
+
+func (m *MemoryLease) Release(_ context.Context, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.holder == holder {
+		m.holder = ""
+		m.expiry = time.Time{}
+	}
+	return nil
+}
+
+// Holder returns the current holder of the lease, or an empty string if the lease is not held.
+func (m *MemoryLease) Holder() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.holder == "" || !m.clock.Now().Before(m.expiry) {
+		return ""
+	}
+	return m.holder
+}
+
+type leaseAPI struct {
+	lease Lease
+}
+
+// Acquire serves lease_acquire. The ttl is specified in milliseconds.
+func (a *leaseAPI) Acquire(ctx context.Context, holder string, ttlMs uint64) (bool, error) {
+	return a.lease.Acquire(ctx, holder, time.Duration(ttlMs)*time.Millisecond)
+}
+
+// Release serves lease_release.
+func (a *leaseAPI) Release(ctx context.Context, holder string) error {
+	return a.lease.Release(ctx, holder)
+}
+
+// GetAPI serves the lease to other processes in the lease RPC namespace, for use with RPCLease.
+func GetAPI(lease Lease) gethrpc.API {
+	return gethrpc.API{
+		Namespace: Namespace,
+		Service:   &leaseAPI{lease: lease},
+	}
+}
+
+// RPCLease is a Lease that is held through a lock RPC, such as the lease API served by another process.
+type RPCLease struct {
+	rpc client.RPC
+}
+
+func NewRPCLease(rpc client.RPC) *RPCLease {
+	return &RPCLease{rpc: rpc}
+}
+
+func (r *RPCLease) Acquire(ctx context.Context, holder string, ttl time.Duration) (bool, error) {
+	var result bool
+	err := r.rpc.CallContext(ctx, &result, "lease_acquire", holder, uint64(ttl.Milliseconds()))
+	return result, err
+}
+
+func (r *RPCLease) Release(ctx context.Context, holder string) error {
+	return r.rpc.CallContext(ctx, nil, "lease_release", holder)
+}
+
+func (r *RPCLease) Close() {
+	r.rpc.Close()
+}
@@ -0,0 +1,43 @@
+package lease
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+func TestRPCLease(t *testing.T) {
+	ctx := context.Background()
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	lease := NewMemoryLease(cl)
+
+	server := gethrpc.NewServer()
+	api := GetAPI(lease)
+	require.NoError(t, server.RegisterName(api.Namespace, api.Service))
+	t.Cleanup(server.Stop)
+	rpcLease := NewRPCLease(client.NewBaseRPCClient(gethrpc.DialInProc(server)))
+	t.Cleanup(rpcLease.Close)
+
+	held, err := rpcLease.Acquire(ctx, "a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, held)
+	held, err = rpcLease.Acquire(ctx, "b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, held)
+
+	cl.AdvanceTime(time.Minute)
+	held, err = rpcLease.Acquire(ctx, "b", time.Minute)
+	require.NoError(t, err)
+	require.True(t, held)
+
+	require.NoError(t, rpcLease.Release(ctx, "a"), "releasing a lease that is not held is a no-op")
+	require.Equal(t, "b", lease.Holder())
+	require.NoError(t, rpcLease.Release(ctx, "b"))
+	require.Equal(t, "", lease.Holder())
+}
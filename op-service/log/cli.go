@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	"golang.org/x/term"
@@ -21,8 +22,14 @@ const (
 	FormatFlagName = "log.format"
 	ColorFlagName  = "log.color"
 	PidFlagName    = "log.pid"
+
+	SampleIntervalFlagName = "log.sample.interval"
+	SampleBurstFlagName    = "log.sample.burst"
 )
 
+// DefaultSampleBurst is the default number of records of the same message logged per sample interval.
+const DefaultSampleBurst = 10
+
 func CLIFlags(envPrefix string) []cli.Flag {
 	return CLIFlagsWithCategory(envPrefix, "")
 }
@@ -58,6 +65,20 @@ func CLIFlagsWithCategory(envPrefix string, category string) []cli.Flag {
 			EnvVars:  opservice.PrefixEnvVar(envPrefix, "LOG_PID"),
 			Category: category,
 		},
+		&cli.DurationFlag{
+			Name: SampleIntervalFlagName,
+			Usage: "Sample frequent info, debug and trace log messages: log at most log.sample.burst records of the same message per interval. " +
+				"Warnings and errors are never sampled. Disabled if 0.",
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "LOG_SAMPLE_INTERVAL"),
+			Category: category,
+		},
+		&cli.IntFlag{
+			Name:     SampleBurstFlagName,
+			Usage:    "Number of records of the same log message that are logged per log.sample.interval",
+			Value:    DefaultSampleBurst,
+			EnvVars:  opservice.PrefixEnvVar(envPrefix, "LOG_SAMPLE_BURST"),
+			Category: category,
+		},
 	}
 }
 
@@ -195,6 +216,9 @@ type CLIConfig struct {
 	Color  bool
 	Format FormatType
 	Pid    bool
+	// SampleInterval is the interval of log sampling, see SamplingConfig. Zero disables sampling.
+	SampleInterval time.Duration
+	SampleBurst    int
 }
 
 // AppOut returns an io.Writer to write app output to, like logs.
@@ -206,9 +230,17 @@ func AppOut(ctx *cli.Context) io.Writer {
 	return ctx.App.Writer
 }
 
-// NewLogHandler creates a new configured handler, compatible as LvlSetter for log-level changes during runtime.
+// NewLogHandler creates a new configured handler, compatible as LvlSetter for log-level changes during runtime,
+// and as ModuleLvlSetter for log-level changes of individual modules.
 func NewLogHandler(wr io.Writer, cfg CLIConfig) slog.Handler {
 	handler := FormatHandler(cfg.Format, cfg.Color)(wr)
+	if cfg.SampleInterval > 0 {
+		handler = NewSamplingHandler(SamplingConfig{
+			Interval: cfg.SampleInterval,
+			Burst:    cfg.SampleBurst,
+			MaxLevel: log.LevelInfo,
+		}, handler)
+	}
 	return NewDynamicLogHandler(cfg.Level, handler)
 }
 
@@ -236,9 +268,10 @@ func SetGlobalLogHandler(h slog.Handler) {
 // Color defaults to true if terminal is detected.
 func DefaultCLIConfig() CLIConfig {
 	return CLIConfig{
-		Level:  log.LevelInfo,
-		Format: FormatText,
-		Color:  term.IsTerminal(int(os.Stdout.Fd())),
+		Level:       log.LevelInfo,
+		Format:      FormatText,
+		Color:       term.IsTerminal(int(os.Stdout.Fd())),
+		SampleBurst: DefaultSampleBurst,
 	}
 }
 
@@ -250,5 +283,7 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		cfg.Color = ctx.Bool(ColorFlagName)
 	}
 	cfg.Pid = ctx.Bool(PidFlagName)
+	cfg.SampleInterval = ctx.Duration(SampleIntervalFlagName)
+	cfg.SampleBurst = ctx.Int(SampleBurstFlagName)
 	return cfg
 }
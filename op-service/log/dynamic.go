@@ -3,22 +3,69 @@ package log
 import (
 	"context"
 	"log/slog"
+	"maps"
+	"sync"
+	"sync/atomic"
 )
 
+// ModuleKey is the log attribute that identifies the module a logger belongs to,
+// e.g. logger.With(ModuleKey, "derivation"), for module log levels to apply to it.
+const ModuleKey = "module"
+
 type LvlSetter interface {
 	SetLogLevel(lvl slog.Level)
 }
 
+// ModuleLvlSetter changes the log level of individual modules, overriding the log level of the handler.
+type ModuleLvlSetter interface {
+	SetModuleLogLevel(module string, lvl slog.Level)
+	ResetModuleLogLevel(module string)
+	ModuleLogLevels() map[string]slog.Level
+}
+
+// moduleLevels holds the log level overrides per module, shared with derived dynamic handlers.
+// The map is replaced on every change, so reads on the logging path do not need a lock.
+type moduleLevels struct {
+	mu     sync.Mutex // serializes updates
+	levels atomic.Pointer[map[string]slog.Level]
+}
+
+func (m *moduleLevels) get(module string) (slog.Level, bool) {
+	levels := m.levels.Load()
+	if levels == nil {
+		return 0, false
+	}
+	lvl, ok := (*levels)[module]
+	return lvl, ok
+}
+
+func (m *moduleLevels) update(fn func(levels map[string]slog.Level)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	levels := make(map[string]slog.Level)
+	if prev := m.levels.Load(); prev != nil {
+		maps.Copy(levels, *prev)
+	}
+	fn(levels)
+	m.levels.Store(&levels)
+}
+
 // DynamicLogHandler allow runtime-configuration of the log handler.
 type DynamicLogHandler struct {
-	h      slog.Handler
-	minLvl *slog.Level // shared with derived dynamic handlers
+	h       slog.Handler
+	minLvl  *slog.Level   // shared with derived dynamic handlers
+	modules *moduleLevels // shared with derived dynamic handlers
+	// module is the value of the ModuleKey attribute of the logger, if any
+	module string
+	// inGroup is true when attributes are added to a group, and thus cannot identify the module
+	inGroup bool
 }
 
 func NewDynamicLogHandler(lvl slog.Level, h slog.Handler) *DynamicLogHandler {
 	return &DynamicLogHandler{
-		h:      h,
-		minLvl: &lvl,
+		h:       h,
+		minLvl:  &lvl,
+		modules: new(moduleLevels),
 	}
 }
 
@@ -26,27 +73,73 @@ func (d *DynamicLogHandler) SetLogLevel(lvl slog.Level) {
 	*d.minLvl = lvl
 }
 
+// SetModuleLogLevel overrides the log level of the loggers of the module, both more and less verbose than the handler level.
+func (d *DynamicLogHandler) SetModuleLogLevel(module string, lvl slog.Level) {
+	d.modules.update(func(levels map[string]slog.Level) {
+		levels[module] = lvl
+	})
+}
+
+// ResetModuleLogLevel removes the log level override of the module.
+func (d *DynamicLogHandler) ResetModuleLogLevel(module string) {
+	d.modules.update(func(levels map[string]slog.Level) {
+		delete(levels, module)
+	})
+}
+
+// ModuleLogLevels returns a copy of the log level overrides per module.
+func (d *DynamicLogHandler) ModuleLogLevels() map[string]slog.Level {
+	out := make(map[string]slog.Level)
+	if levels := d.modules.levels.Load(); levels != nil {
+		maps.Copy(out, *levels)
+	}
+	return out
+}
+
+func (d *DynamicLogHandler) level() slog.Level {
+	if d.module != "" {
+		if lvl, ok := d.modules.get(d.module); ok {
+			return lvl
+		}
+	}
+	return *d.minLvl
+}
+
 func (d *DynamicLogHandler) Handle(ctx context.Context, r slog.Record) error {
-	if r.Level < *d.minLvl { // higher log level values are more critical
+	if r.Level < d.level() { // higher log level values are more critical
 		return nil
 	}
 	return d.h.Handle(ctx, r) // process the log
 }
 
 func (d *DynamicLogHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
-	return (lvl >= *d.minLvl) && d.h.Enabled(ctx, lvl)
+	return (lvl >= d.level()) && d.h.Enabled(ctx, lvl)
 }
 
 func (d *DynamicLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	module := d.module
+	if !d.inGroup {
+		for _, attr := range attrs {
+			if attr.Key == ModuleKey {
+				module = attr.Value.String()
+			}
+		}
+	}
 	return &DynamicLogHandler{
-		h:      d.h.WithAttrs(attrs),
-		minLvl: d.minLvl,
+		h:       d.h.WithAttrs(attrs),
+		minLvl:  d.minLvl,
+		modules: d.modules,
+		module:  module,
+		inGroup: d.inGroup,
 	}
 }
 
 func (d *DynamicLogHandler) WithGroup(name string) slog.Handler {
 	return &DynamicLogHandler{
-		h:      d.h.WithGroup(name),
-		minLvl: d.minLvl,
+		h:       d.h.WithGroup(name),
+		minLvl:  d.minLvl,
+		modules: d.modules,
+		module:  d.module,
+		inGroup: true,
 	}
 }
@@ -67,6 +67,37 @@ func TestDynamicLogHandler_WithAttrs(t *testing.T) {
 	require.Equal(t, h.records[3].Message, "error1")
 }
 
+func TestDynamicLogHandler_ModuleLogLevel(t *testing.T) {
+	h := new(testRecorder)
+	d := NewDynamicLogHandler(log.LevelInfo, h)
+	logger := log.NewLogger(d)
+	derivation := logger.With(ModuleKey, "derivation")
+	p2p := logger.New(ModuleKey, "p2p")
+	grouped := log.NewLogger(logger.Handler().WithGroup("sub")).With(ModuleKey, "p2p")
+
+	d.SetModuleLogLevel("derivation", log.LevelDebug)
+	d.SetModuleLogLevel("p2p", log.LevelError)
+	require.Equal(t, map[string]slog.Level{"derivation": log.LevelDebug, "p2p": log.LevelError}, d.ModuleLogLevels())
+
+	logger.Debug("debug0")                  // n
+	derivation.Debug("debug1")              // y
+	derivation.With("a", 1).Debug("debug2") // y: derived loggers keep the module
+	p2p.Warn("warn0")                       // n
+	p2p.Error("error0")                     // y
+	grouped.Info("info0")                   // y, the module attribute is in a group
+
+	d.ResetModuleLogLevel("derivation")
+	derivation.Debug("debug3") // n
+	derivation.Info("info1")   // y
+
+	// module overrides are independent of the global level
+	d.SetLogLevel(log.LevelTrace)
+	p2p.Debug("debug4")    // n
+	logger.Debug("debug5") // y
+
+	require.Equal(t, []string{"debug1", "debug2", "error0", "info0", "info1", "debug5"}, h.messages())
+}
+
 type testRecorder struct {
 	records []slog.Record
 }
@@ -80,5 +111,13 @@ func (r *testRecorder) Handle(_ context.Context, rec slog.Record) error {
 	return nil
 }
 
+func (r *testRecorder) messages() []string {
+	out := make([]string, len(r.records))
+	for i, rec := range r.records {
+		out[i] = rec.Message
+	}
+	return out
+}
+
 func (r *testRecorder) WithAttrs([]slog.Attr) slog.Handler { return r }
 func (r *testRecorder) WithGroup(string) slog.Handler      { return r }
@@ -0,0 +1,139 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// SampledKey is the log attribute that reports how many records of the same message were dropped by sampling,
+// since the previous record of the message that was logged.
+const SampledKey = "sampled_dropped"
+
+// maxSampledMessages bounds the number of messages that sampling keeps track of.
+// Log messages are expected to be constant strings, this only guards against unbounded growth.
+const maxSampledMessages = 10_000
+
+// SamplingConfig configures rate-based sampling of log records.
+type SamplingConfig struct {
+	// Interval is the period in which at most Burst records of the same message are logged. Zero disables sampling.
+	Interval time.Duration
+	// Burst is the number of records of the same message that are logged per Interval.
+	Burst int
+	// MaxLevel is the most critical level that is sampled. More critical records are always logged.
+	MaxLevel slog.Level
+}
+
+type sampleWindow struct {
+	start   time.Time
+	count   int
+	dropped int
+}
+
+// samplingState is shared with derived sampling handlers,
+// so a message is sampled the same, regardless of the attributes of the logger it is logged with.
+type samplingState struct {
+	mu      sync.Mutex
+	windows map[slog.Level]map[string]*sampleWindow
+	size    int
+}
+
+// SamplingHandler drops records of frequent messages, e.g. logs for every block or step,
+// logging at most a burst of records of each message per interval.
+// The first record of a message that is logged after records were dropped reports the number of dropped records.
+type SamplingHandler struct {
+	h     slog.Handler
+	cfg   SamplingConfig
+	now   func() time.Time
+	state *samplingState
+}
+
+func NewSamplingHandler(cfg SamplingConfig, h slog.Handler) *SamplingHandler {
+	return newSamplingHandler(cfg, h, time.Now)
+}
+
+func newSamplingHandler(cfg SamplingConfig, h slog.Handler, now func() time.Time) *SamplingHandler {
+	return &SamplingHandler{
+		h:   h,
+		cfg: cfg,
+		now: now,
+		state: &samplingState{
+			windows: make(map[slog.Level]map[string]*sampleWindow),
+		},
+	}
+}
+
+// sample returns whether the record should be logged, and the number of records of the message that were dropped before it.
+func (s *SamplingHandler) sample(r slog.Record) (bool, int) {
+	if s.cfg.Interval <= 0 || r.Level > s.cfg.MaxLevel {
+		return true, 0
+	}
+	now := s.now()
+	st := s.state
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	byMsg, ok := st.windows[r.Level]
+	if !ok {
+		byMsg = make(map[string]*sampleWindow)
+		st.windows[r.Level] = byMsg
+	}
+	w, ok := byMsg[r.Message]
+	if !ok {
+		if st.size >= maxSampledMessages {
+			// Start over, rather than growing without bound
+			st.windows = map[slog.Level]map[string]*sampleWindow{r.Level: byMsg}
+			clear(byMsg)
+			st.size = 0
+		}
+		w = &sampleWindow{start: now}
+		byMsg[r.Message] = w
+		st.size++
+	}
+	if now.Sub(w.start) >= s.cfg.Interval {
+		w.start = now
+		w.count = 0
+	}
+	if w.count >= s.cfg.Burst {
+		w.dropped++
+		return false, 0
+	}
+	w.count++
+	dropped := w.dropped
+	w.dropped = 0
+	return true, dropped
+}
+
+func (s *SamplingHandler) Handle(ctx context.Context, r slog.Record) error {
+	ok, dropped := s.sample(r)
+	if !ok {
+		return nil
+	}
+	if dropped > 0 {
+		r = r.Clone()
+		r.AddAttrs(slog.Int(SampledKey, dropped))
+	}
+	return s.h.Handle(ctx, r)
+}
+
+func (s *SamplingHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	return s.h.Enabled(ctx, lvl)
+}
+
+func (s *SamplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &SamplingHandler{
+		h:     s.h.WithAttrs(attrs),
+		cfg:   s.cfg,
+		now:   s.now,
+		state: s.state,
+	}
+}
+
+func (s *SamplingHandler) WithGroup(name string) slog.Handler {
+	return &SamplingHandler{
+		h:     s.h.WithGroup(name),
+		cfg:   s.cfg,
+		now:   s.now,
+		state: s.state,
+	}
+}
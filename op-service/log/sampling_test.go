@@ -0,0 +1,78 @@
+package log
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+func TestSamplingHandler(t *testing.T) {
+	h := new(testRecorder)
+	now := time.Unix(1000, 0)
+	s := newSamplingHandler(SamplingConfig{Interval: time.Second, Burst: 2, MaxLevel: log.LevelInfo}, h, func() time.Time { return now })
+	logger := log.NewLogger(s)
+	derived := logger.With("a", 1)
+
+	for i := 0; i < 5; i++ {
+		logger.Info("block")
+	}
+	derived.Info("block") // sampled together with the other logger
+	logger.Info("step")
+	logger.Debug("block") // sampled separately per level
+	logger.Warn("block")  // never sampled
+	logger.Warn("block")
+	logger.Warn("block")
+	require.Equal(t, []string{"block", "block", "step", "block", "block", "block", "block"}, h.messages())
+	for _, rec := range h.records {
+		require.Zero(t, sampledDropped(rec))
+	}
+
+	// the next interval reports the dropped records
+	h.records = nil
+	now = now.Add(time.Second)
+	logger.Info("block")
+	logger.Info("block")
+	logger.Info("block")
+	require.Equal(t, []string{"block", "block"}, h.messages())
+	require.Equal(t, int64(4), sampledDropped(h.records[0]))
+	require.Zero(t, sampledDropped(h.records[1]))
+}
+
+func TestSamplingHandler_Disabled(t *testing.T) {
+	h := new(testRecorder)
+	logger := log.NewLogger(NewSamplingHandler(SamplingConfig{}, h))
+	for i := 0; i < 100; i++ {
+		logger.Info("block")
+	}
+	require.Len(t, h.records, 100)
+}
+
+func TestNewLogHandler_Sampling(t *testing.T) {
+	cfg := DefaultCLIConfig()
+	cfg.SampleInterval = time.Hour
+	cfg.SampleBurst = 1
+	h := NewLogHandler(new(discardWriter), cfg)
+	d, ok := h.(*DynamicLogHandler)
+	require.True(t, ok)
+	_, ok = d.h.(*SamplingHandler)
+	require.True(t, ok)
+}
+
+type discardWriter struct{}
+
+func (discardWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+func sampledDropped(rec slog.Record) int64 {
+	var dropped int64
+	rec.Attrs(func(attr slog.Attr) bool {
+		if attr.Key == SampledKey {
+			dropped = attr.Value.Int64()
+		}
+		return true
+	})
+	return dropped
+}
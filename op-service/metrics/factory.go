@@ -30,8 +30,14 @@ type documentor struct {
 }
 
 func With(registry *prometheus.Registry) Factory {
+	return WithRegisterer(registry)
+}
+
+// WithRegisterer creates a Factory registering metrics with r,
+// e.g. a registerer wrapped to add constant labels to all metrics.
+func WithRegisterer(r prometheus.Registerer) Factory {
 	return &documentor{
-		factory: promauto.With(registry),
+		factory: promauto.With(r),
 	}
 }
 
@@ -3,15 +3,25 @@ package metrics
 import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
+
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 )
 
 func NewRegistry() *prometheus.Registry {
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	registry.MustRegister(collectors.NewGoCollector())
+	registry.MustRegister(appinfo.NewCollector())
 	return registry
 }
 
 type RegistryMetricer interface {
 	Registry() *prometheus.Registry
 }
+
+// GathererMetricer is implemented by metrics served from more than their own registry,
+// e.g. together with the registries of sub-components.
+type GathererMetricer interface {
+	RegistryMetricer
+	Gatherer() prometheus.Gatherer
+}
@@ -10,9 +10,15 @@ import (
 )
 
 func StartServer(r *prometheus.Registry, hostname string, port int) (*httputil.HTTPServer, error) {
+	return StartGathererServer(r, r, hostname, port)
+}
+
+// StartGathererServer serves the metrics gathered by g, e.g. the metrics of multiple registries.
+// The metrics of the server itself are registered with r.
+func StartGathererServer(r prometheus.Registerer, g prometheus.Gatherer, hostname string, port int) (*httputil.HTTPServer, error) {
 	addr := net.JoinHostPort(hostname, strconv.Itoa(port))
 	h := promhttp.InstrumentMetricHandler(
-		r, promhttp.HandlerFor(r, promhttp.HandlerOpts{}),
+		r, promhttp.HandlerFor(g, promhttp.HandlerOpts{}),
 	)
 	return httputil.StartHTTPServer(addr, h)
 }
@@ -0,0 +1,119 @@
+package oppprof
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+	"runtime/trace"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// MaxTraceDuration limits how long an execution trace can be captured for, to bound the trace size.
+const MaxTraceDuration = time.Minute
+
+var ErrInvalidTraceDuration = errors.New("invalid trace duration")
+
+// RuntimeAPI captures profiling data to files and tunes the Go runtime of a running service,
+// so performance incidents can be diagnosed without restarting it.
+// It must only be served to authenticated clients, see GetRuntimeAPI.
+type RuntimeAPI struct {
+	log log.Logger
+	// dir is where traces and heap profiles are written to
+	dir string
+}
+
+// NewRuntimeAPI creates a RuntimeAPI that writes to dir, or to the system temporary directory if dir is empty.
+func NewRuntimeAPI(log log.Logger, dir string) *RuntimeAPI {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	return &RuntimeAPI{log: log, dir: dir}
+}
+
+// GetRuntimeAPI serves the runtime API as authenticated part of the admin namespace.
+func GetRuntimeAPI(api *RuntimeAPI) rpc.API {
+	return rpc.API{
+		Namespace:     "admin",
+		Service:       api,
+		Authenticated: true,
+	}
+}
+
+// CaptureTrace captures an execution trace for the given number of seconds, and returns the path of the trace file.
+func (a *RuntimeAPI) CaptureTrace(ctx context.Context, seconds uint64) (string, error) {
+	dur := time.Duration(seconds) * time.Second
+	if dur == 0 || dur > MaxTraceDuration {
+		return "", fmt.Errorf("%w: must be between 1s and %v", ErrInvalidTraceDuration, MaxTraceDuration)
+	}
+	path, f, err := a.create("trace", "out")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if err := trace.Start(f); err != nil {
+		return "", fmt.Errorf("failed to start trace: %w", err)
+	}
+	a.log.Info("Capturing execution trace", "duration", dur, "path", path)
+	select {
+	case <-time.After(dur):
+	case <-ctx.Done():
+	}
+	trace.Stop()
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("trace capture interrupted: %w", err)
+	}
+	return path, nil
+}
+
+// DumpHeap runs a garbage collection and writes a heap profile, and returns the path of the profile.
+func (a *RuntimeAPI) DumpHeap(ctx context.Context) (string, error) {
+	path, f, err := a.create("heap", "prof")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	runtime.GC()
+	if err := pprof.Lookup("heap").WriteTo(f, 0); err != nil {
+		return "", fmt.Errorf("failed to write heap profile: %w", err)
+	}
+	a.log.Info("Wrote heap profile", "path", path)
+	return path, nil
+}
+
+// SetGCPercent sets the garbage collection target percentage, and returns the previous setting.
+// A negative percentage disables the garbage collector, unless a memory limit is set.
+func (a *RuntimeAPI) SetGCPercent(ctx context.Context, percent int) (int, error) {
+	prev := debug.SetGCPercent(percent)
+	a.log.Info("Changed GC percent", "percent", percent, "prev", prev)
+	return prev, nil
+}
+
+// SetMemoryLimit sets the soft memory limit of the runtime in bytes, and returns the previous limit.
+// A negative limit only returns the current limit.
+func (a *RuntimeAPI) SetMemoryLimit(ctx context.Context, limit int64) (int64, error) {
+	prev := debug.SetMemoryLimit(limit)
+	if limit >= 0 {
+		a.log.Info("Changed memory limit", "limit", limit, "prev", prev)
+	}
+	return prev, nil
+}
+
+func (a *RuntimeAPI) create(kind string, ext string) (string, *os.File, error) {
+	if err := os.MkdirAll(a.dir, 0o755); err != nil {
+		return "", nil, fmt.Errorf("failed to create dir: %w", err)
+	}
+	path := filepath.Join(a.dir, fmt.Sprintf("%s-%d.%s", kind, time.Now().UnixNano(), ext))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create %v file: %w", kind, err)
+	}
+	return path, f, nil
+}
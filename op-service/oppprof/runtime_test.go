@@ -0,0 +1,62 @@
+package oppprof
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestRuntimeAPI(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	api := NewRuntimeAPI(testlog.Logger(t, log.LevelInfo), dir)
+
+	t.Run("trace", func(t *testing.T) {
+		_, err := api.CaptureTrace(ctx, 0)
+		require.ErrorIs(t, err, ErrInvalidTraceDuration)
+		_, err = api.CaptureTrace(ctx, 61)
+		require.ErrorIs(t, err, ErrInvalidTraceDuration)
+
+		path, err := api.CaptureTrace(ctx, 1)
+		require.NoError(t, err)
+		require.FileExists(t, path)
+		require.Equal(t, dir, filepath.Dir(path))
+	})
+
+	t.Run("heap", func(t *testing.T) {
+		path, err := api.DumpHeap(ctx)
+		require.NoError(t, err)
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.NotZero(t, info.Size())
+	})
+
+	t.Run("gc percent", func(t *testing.T) {
+		orig := debug.SetGCPercent(100)
+		defer debug.SetGCPercent(orig)
+		prev, err := api.SetGCPercent(ctx, 50)
+		require.NoError(t, err)
+		require.Equal(t, 100, prev)
+		prev, err = api.SetGCPercent(ctx, 100)
+		require.NoError(t, err)
+		require.Equal(t, 50, prev)
+	})
+
+	t.Run("memory limit", func(t *testing.T) {
+		orig := debug.SetMemoryLimit(-1)
+		defer debug.SetMemoryLimit(orig)
+		prev, err := api.SetMemoryLimit(ctx, 1<<40)
+		require.NoError(t, err)
+		require.Equal(t, orig, prev)
+		current, err := api.SetMemoryLimit(ctx, -1)
+		require.NoError(t, err)
+		require.Equal(t, int64(1<<40), current)
+	})
+}
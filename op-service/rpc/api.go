@@ -2,6 +2,7 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -48,3 +49,61 @@ func (n *CommonAdminAPI) SetLogLevel(ctx context.Context, lvlStr string) error {
 	lvlSetter.SetLogLevel(lvl)
 	return nil
 }
+
+// SetModuleLogLevel overrides the log level of a single module, e.g. to debug one subsystem in production.
+func (n *CommonAdminAPI) SetModuleLogLevel(ctx context.Context, module string, lvlStr string) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_setModuleLogLevel")
+	defer recordDur()
+
+	if module == "" {
+		return errors.New("empty module")
+	}
+	lvl, err := oplog.LevelFromString(lvlStr)
+	if err != nil {
+		return err
+	}
+	lvlSetter, err := n.moduleLvlSetter()
+	if err != nil {
+		return err
+	}
+	lvlSetter.SetModuleLogLevel(module, lvl)
+	return nil
+}
+
+// ResetModuleLogLevel removes the log level override of a module, so it logs at the global log level again.
+func (n *CommonAdminAPI) ResetModuleLogLevel(ctx context.Context, module string) error {
+	recordDur := n.M.RecordRPCServerRequest("admin_resetModuleLogLevel")
+	defer recordDur()
+
+	lvlSetter, err := n.moduleLvlSetter()
+	if err != nil {
+		return err
+	}
+	lvlSetter.ResetModuleLogLevel(module)
+	return nil
+}
+
+// ModuleLogLevels returns the log level overrides per module.
+func (n *CommonAdminAPI) ModuleLogLevels(ctx context.Context) (map[string]string, error) {
+	recordDur := n.M.RecordRPCServerRequest("admin_moduleLogLevels")
+	defer recordDur()
+
+	lvlSetter, err := n.moduleLvlSetter()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string)
+	for module, lvl := range lvlSetter.ModuleLogLevels() {
+		out[module] = log.LevelString(lvl)
+	}
+	return out, nil
+}
+
+func (n *CommonAdminAPI) moduleLvlSetter() (oplog.ModuleLvlSetter, error) {
+	h := n.log.Handler()
+	lvlSetter, ok := h.(oplog.ModuleLvlSetter)
+	if !ok {
+		return nil, fmt.Errorf("log handler type %T cannot change module log levels", h)
+	}
+	return lvlSetter, nil
+}
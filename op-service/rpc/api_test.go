@@ -0,0 +1,37 @@
+package rpc
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+func TestCommonAdminAPI_ModuleLogLevel(t *testing.T) {
+	h := oplog.NewDynamicLogHandler(log.LevelInfo, log.DiscardHandler())
+	api := NewCommonAdminAPI(&metrics.NoopRPCMetrics{}, log.NewLogger(h))
+	ctx := context.Background()
+
+	require.NoError(t, api.SetModuleLogLevel(ctx, "derivation", "DEBUG"))
+	require.NoError(t, api.SetModuleLogLevel(ctx, "p2p", "warn"))
+	levels, err := api.ModuleLogLevels(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"derivation": "debug", "p2p": "warn"}, levels)
+	require.Equal(t, map[string]slog.Level{"derivation": log.LevelDebug, "p2p": log.LevelWarn}, h.ModuleLogLevels())
+
+	require.NoError(t, api.ResetModuleLogLevel(ctx, "p2p"))
+	levels, err = api.ModuleLogLevels(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"derivation": "debug"}, levels)
+
+	require.ErrorContains(t, api.SetModuleLogLevel(ctx, "", "debug"), "empty module")
+	require.ErrorContains(t, api.SetModuleLogLevel(ctx, "p2p", "loud"), "unknown level")
+
+	api = NewCommonAdminAPI(&metrics.NoopRPCMetrics{}, log.NewLogger(log.DiscardHandler()))
+	require.ErrorContains(t, api.SetModuleLogLevel(ctx, "p2p", "debug"), "cannot change module log levels")
+}
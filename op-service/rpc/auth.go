@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// PublicAPIs returns the APIs that do not require authentication.
+func PublicAPIs(apis []rpc.API) []rpc.API {
+	var out []rpc.API
+	for _, api := range apis {
+		if !api.Authenticated {
+			out = append(out, api)
+		}
+	}
+	return out
+}
+
+// NewAuthHandler routes requests with an Authorization header to authed, and all other requests to public.
+// The authed handler is expected to verify the authorization, e.g. with a JWT handler stack,
+// and to serve the authenticated APIs in addition to the public ones.
+func NewAuthHandler(public http.Handler, authed http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			authed.ServeHTTP(w, r)
+			return
+		}
+		public.ServeHTTP(w, r)
+	})
+}
+
+// NewAuthHTTPHandler serves the APIs over HTTP, where the authenticated APIs are only served to requests
+// carrying a JWT signed with adminJWTSecret. If adminJWTSecret is nil, authentication is not enforced,
+// and all APIs are served to all requests. The middleware, if not nil, wraps the RPC servers.
+func NewAuthHTTPHandler(apis []rpc.API, corsHosts []string, vHosts []string, adminJWTSecret []byte, middleware func(http.Handler) http.Handler) (http.Handler, error) {
+	newHandler := func(apis []rpc.API, secret []byte) (http.Handler, error) {
+		srv := rpc.NewServer()
+		if err := node.RegisterApis(apis, nil, srv); err != nil {
+			return nil, fmt.Errorf("error registering APIs: %w", err)
+		}
+		var hdlr http.Handler = srv
+		if middleware != nil {
+			hdlr = middleware(hdlr)
+		}
+		return node.NewHTTPHandlerStack(hdlr, corsHosts, vHosts, secret), nil
+	}
+	if adminJWTSecret == nil {
+		return newHandler(apis, nil)
+	}
+	public, err := newHandler(PublicAPIs(apis), nil)
+	if err != nil {
+		return nil, err
+	}
+	authed, err := newHandler(apis, adminJWTSecret)
+	if err != nil {
+		return nil, err
+	}
+	return NewAuthHandler(public, authed), nil
+}
+
+// ReadJWTSecret reads a hex-encoded 32 byte JWT secret from the file at path.
+func ReadJWTSecret(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT secret: %w", err)
+	}
+	secret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("invalid JWT secret in path %s, not 32 hex-formatted bytes", path)
+	}
+	return secret, nil
+}
@@ -12,6 +12,7 @@ const (
 	ListenAddrFlagName  = "rpc.addr"
 	PortFlagName        = "rpc.port"
 	EnableAdminFlagName = "rpc.enable-admin"
+	AdminJWTSecretName  = "rpc.admin-jwt-secret"
 )
 
 var ErrInvalidPort = errors.New("invalid RPC port")
@@ -35,6 +36,13 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Usage:   "Enable the admin API",
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_ENABLE_ADMIN"),
 		},
+		&cli.StringFlag{
+			Name: AdminJWTSecretName,
+			Usage: "Path to a JWT secret file to authenticate privileged admin RPC calls with, such as capturing traces, " +
+				"heap dumps and GC tuning. These calls are disabled if not set.",
+			EnvVars:   opservice.PrefixEnvVar(envPrefix, "RPC_ADMIN_JWT_SECRET"),
+			TakesFile: true,
+		},
 	}
 }
 
@@ -42,6 +50,8 @@ type CLIConfig struct {
 	ListenAddr  string
 	ListenPort  int
 	EnableAdmin bool
+	// AdminJWTSecretPath is the path of the JWT secret for the authenticated admin APIs, disabled if empty.
+	AdminJWTSecretPath string
 }
 
 func DefaultCLIConfig() CLIConfig {
@@ -56,14 +66,26 @@ func (c CLIConfig) Check() error {
 	if c.ListenPort < 0 || c.ListenPort > math.MaxUint16 {
 		return ErrInvalidPort
 	}
+	if c.AdminJWTSecretPath != "" && !c.EnableAdmin {
+		return errors.New("admin JWT secret requires the admin API to be enabled")
+	}
 
 	return nil
 }
 
 func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
-		ListenAddr:  ctx.String(ListenAddrFlagName),
-		ListenPort:  ctx.Int(PortFlagName),
-		EnableAdmin: ctx.Bool(EnableAdminFlagName),
+		ListenAddr:         ctx.String(ListenAddrFlagName),
+		ListenPort:         ctx.Int(PortFlagName),
+		EnableAdmin:        ctx.Bool(EnableAdminFlagName),
+		AdminJWTSecretPath: ctx.String(AdminJWTSecretName),
+	}
+}
+
+// AdminJWTSecret reads the admin JWT secret, nil if not configured.
+func (c CLIConfig) AdminJWTSecret() ([]byte, error) {
+	if c.AdminJWTSecretPath == "" {
+		return nil, nil
 	}
+	return ReadJWTSecret(c.AdminJWTSecretPath)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	optls "github.com/ethereum-optimism/optimism/op-service/tls"
@@ -29,6 +30,7 @@ type Server struct {
 	corsHosts      []string
 	vHosts         []string
 	jwtSecret      []byte
+	adminJWTSecret []byte
 	rpcPath        string
 	healthzPath    string
 	httpRecorder   opmetrics.HTTPRecorder
@@ -78,6 +80,14 @@ func WithJWTSecret(secret []byte) ServerOption {
 	}
 }
 
+// WithAdminJWTSecret restricts the authenticated APIs, such as the oppprof runtime API,
+// to requests carrying a JWT signed with the secret. Other requests are served the public APIs only.
+func WithAdminJWTSecret(secret []byte) ServerOption {
+	return func(b *Server) {
+		b.adminJWTSecret = secret
+	}
+}
+
 func WithRPCPath(path string) ServerOption {
 	return func(b *Server) {
 		b.rpcPath = path
@@ -146,6 +156,7 @@ func NewServer(host string, port int, appVersion string, opts ...ServerOption) *
 			appVersion: appVersion,
 		},
 	})
+	bs.AddAPI(appinfo.GetAPI())
 	return bs
 }
 
@@ -158,17 +169,28 @@ func (b *Server) AddAPI(api rpc.API) {
 }
 
 func (b *Server) Start() error {
-	srv := rpc.NewServer()
-	if err := node.RegisterApis(b.apis, nil, srv); err != nil {
-		return fmt.Errorf("error registering APIs: %w", err)
-	}
-
 	// rpc middleware
-	var nodeHdlr http.Handler = srv
-	for _, middleware := range b.middlewares {
-		nodeHdlr = middleware(nodeHdlr)
+	middleware := func(hdlr http.Handler) http.Handler {
+		for _, middleware := range b.middlewares {
+			hdlr = middleware(hdlr)
+		}
+		return hdlr
+	}
+	var nodeHdlr http.Handler
+	if b.jwtSecret != nil {
+		// All requests are authenticated, so all APIs are served.
+		srv := rpc.NewServer()
+		if err := node.RegisterApis(b.apis, nil, srv); err != nil {
+			return fmt.Errorf("error registering APIs: %w", err)
+		}
+		nodeHdlr = node.NewHTTPHandlerStack(middleware(srv), b.corsHosts, b.vHosts, b.jwtSecret)
+	} else {
+		hdlr, err := NewAuthHTTPHandler(b.apis, b.corsHosts, b.vHosts, b.adminJWTSecret, middleware)
+		if err != nil {
+			return err
+		}
+		nodeHdlr = hdlr
 	}
-	nodeHdlr = node.NewHTTPHandlerStack(nodeHdlr, b.corsHosts, b.vHosts, b.jwtSecret)
 
 	mux := http.NewServeMux()
 	mux.Handle(b.rpcPath, nodeHdlr)
@@ -1,6 +1,7 @@
 package rpc
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net"
@@ -8,8 +9,11 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 )
 
 type testAPI struct{}
@@ -54,6 +58,12 @@ func TestBaseServer(t *testing.T) {
 		require.Equal(t, appVersion, res)
 	})
 
+	t.Run("supports version_info", func(t *testing.T) {
+		var res appinfo.Info
+		require.NoError(t, rpcClient.Call(&res, "version_info"))
+		require.Equal(t, appinfo.Current(), res)
+	})
+
 	t.Run("supports additional RPC APIs", func(t *testing.T) {
 		var res int
 		require.NoError(t, rpcClient.Call(&res, "test_frobnicate", 2))
@@ -69,3 +79,48 @@ func TestBaseServer(t *testing.T) {
 		require.Greater(t, port, 0)
 	})
 }
+
+func TestAuthenticatedAPIs(t *testing.T) {
+	var secret [32]byte
+	secret[0] = 0xaa
+	server := NewServer(
+		"127.0.0.1",
+		0,
+		"test",
+		WithAPIs([]rpc.API{
+			{
+				Namespace: "test",
+				Service:   new(testAPI),
+			},
+			{
+				Namespace:     "secret",
+				Service:       new(testAPI),
+				Authenticated: true,
+			},
+		}),
+		WithAdminJWTSecret(secret[:]),
+	)
+	require.NoError(t, server.Start())
+	defer func() {
+		_ = server.Stop()
+	}()
+	endpoint := fmt.Sprintf("http://%s", server.endpoint)
+
+	var res int
+	publicClient, err := rpc.Dial(endpoint)
+	require.NoError(t, err)
+	require.NoError(t, publicClient.Call(&res, "test_frobnicate", 2))
+	require.Error(t, publicClient.Call(&res, "secret_frobnicate", 2), "authenticated API must not be served without auth")
+
+	var wrongSecret [32]byte
+	wrongClient, err := rpc.DialOptions(context.Background(), endpoint, rpc.WithHTTPAuth(node.NewJWTAuth(wrongSecret)))
+	require.NoError(t, err)
+	require.Error(t, wrongClient.Call(&res, "secret_frobnicate", 2), "invalid JWT must be rejected")
+
+	authClient, err := rpc.DialOptions(context.Background(), endpoint, rpc.WithHTTPAuth(node.NewJWTAuth(secret)))
+	require.NoError(t, err)
+	require.NoError(t, authClient.Call(&res, "secret_frobnicate", 3))
+	require.Equal(t, 6, res)
+	require.NoError(t, authClient.Call(&res, "test_frobnicate", 4), "public APIs are served to authenticated clients")
+	require.Equal(t, 8, res)
+}
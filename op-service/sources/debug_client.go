@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/batching"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -40,6 +41,15 @@ func (o *DebugClient) CodeByHash(ctx context.Context, hash common.Hash) ([]byte,
 	return code, nil
 }
 
+// ExecutionWitness retrieves the witness to execute the block with the given number statelessly.
+func (o *DebugClient) ExecutionWitness(ctx context.Context, blockNum uint64) (*eth.ExecutionWitness, error) {
+	var witness eth.ExecutionWitness
+	if err := o.callContext(ctx, &witness, "debug_executionWitness", hexutil.EncodeUint64(blockNum)); err != nil {
+		return nil, fmt.Errorf("failed to retrieve execution witness for block %d: %w", blockNum, err)
+	}
+	return &witness, nil
+}
+
 func (o *DebugClient) dbGet(ctx context.Context, key []byte) ([]byte, error) {
 	var node hexutil.Bytes
 	err := o.callContext(ctx, &node, "debug_dbGet", hexutil.Encode(key))
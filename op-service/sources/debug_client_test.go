@@ -0,0 +1,41 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestDebugClient_ExecutionWitness(t *testing.T) {
+	response := `{
+		"keys": {"0x0100000000000000000000000000000000000000000000000000000000000000": "0xaa"},
+		"codes": {"0x0200000000000000000000000000000000000000000000000000000000000000": "0x6000"},
+		"state": {"0x0300000000000000000000000000000000000000000000000000000000000000": "0xc0"}
+	}`
+	client := NewDebugClient(func(ctx context.Context, result any, method string, args ...any) error {
+		require.Equal(t, "debug_executionWitness", method)
+		require.Equal(t, []any{"0x2a"}, args)
+		return json.Unmarshal([]byte(response), result)
+	})
+	witness, err := client.ExecutionWitness(context.Background(), 42)
+	require.NoError(t, err)
+	require.Equal(t, &eth.ExecutionWitness{
+		Keys:  map[common.Hash]hexutil.Bytes{{0x01}: {0xaa}},
+		Codes: map[common.Hash]hexutil.Bytes{{0x02}: {0x60, 0x00}},
+		State: map[common.Hash]hexutil.Bytes{{0x03}: {0xc0}},
+	}, witness)
+
+	expectedErr := errors.New("method not found")
+	client = NewDebugClient(func(ctx context.Context, result any, method string, args ...any) error {
+		return expectedErr
+	})
+	_, err = client.ExecutionWitness(context.Background(), 42)
+	require.ErrorIs(t, err, expectedErr)
+}
@@ -0,0 +1,260 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+)
+
+// ReconcilableEngine is the engine API the EngineReconciler wraps.
+type ReconcilableEngine interface {
+	GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error)
+	ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+	NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error)
+	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
+}
+
+type EngineReconcileMetrics interface {
+	RecordEngineReconciliation(replayedPayloads int)
+}
+
+type EngineReconcilerConfig struct {
+	// MaxAttempts is the number of attempts of an engine API call that fails with a temporary error.
+	MaxAttempts int
+	// Strategy is the backoff between the attempts.
+	Strategy retry.Strategy
+	// MaxReplayPayloads is the number of recent payloads kept to replay to the engine when it loses them.
+	MaxReplayPayloads int
+}
+
+func EngineReconcilerDefaultConfig() *EngineReconcilerConfig {
+	return &EngineReconcilerConfig{
+		MaxAttempts:       5,
+		Strategy:          retry.Exponential(),
+		MaxReplayPayloads: 128,
+	}
+}
+
+type replayPayload struct {
+	payload               *eth.ExecutionPayload
+	parentBeaconBlockRoot *common.Hash
+}
+
+// EngineReconciler wraps the engine API to recover from restarts of the execution engine.
+//
+// Engine API calls that fail with a temporary error, like a refused connection while the engine restarts,
+// are retried. An engine that crashed may restart without its most recent blocks, i.e. with its head
+// behind the last forkchoice state it accepted. The reconciler detects this regression before retrying
+// a call, and when the engine is syncing towards a block it accepted before, and then replays the lost
+// payloads and the last forkchoice state, so the node can continue without a restart.
+type EngineReconciler struct {
+	engine  ReconcilableEngine
+	log     log.Logger
+	metrics EngineReconcileMetrics
+	cfg     EngineReconcilerConfig
+
+	mu sync.Mutex
+	// lastFC is the last forkchoice state the engine accepted as valid
+	lastFC *eth.ForkchoiceState
+	// payloads are the recent payloads the engine accepted as valid, oldest first
+	payloads []replayPayload
+}
+
+func NewEngineReconciler(engine ReconcilableEngine, log log.Logger, metrics EngineReconcileMetrics, cfg *EngineReconcilerConfig) *EngineReconciler {
+	return &EngineReconciler{
+		engine:  engine,
+		log:     log,
+		metrics: metrics,
+		cfg:     *cfg,
+	}
+}
+
+func (r *EngineReconciler) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	return retryEngineCall(ctx, r, func() (*eth.ExecutionPayloadEnvelope, error) {
+		return r.engine.GetPayload(ctx, payloadInfo)
+	})
+}
+
+func (r *EngineReconciler) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	result, err := retryEngineCall(ctx, r, func() (*eth.ForkchoiceUpdatedResult, error) {
+		return r.engine.ForkchoiceUpdate(ctx, fc, attr)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result.PayloadStatus.Status == eth.ExecutionSyncing && r.accepted(fc.HeadBlockHash) {
+		// The engine lost the head block it accepted before
+		if err := r.reconcile(ctx); err != nil {
+			r.log.Warn("Failed to reconcile engine forkchoice state", "err", err)
+		} else if result, err = r.engine.ForkchoiceUpdate(ctx, fc, attr); err != nil {
+			return nil, err
+		}
+	}
+	if result.PayloadStatus.Status == eth.ExecutionValid {
+		r.mu.Lock()
+		fcCopy := *fc
+		r.lastFC = &fcCopy
+		r.mu.Unlock()
+	}
+	return result, nil
+}
+
+func (r *EngineReconciler) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	status, err := retryEngineCall(ctx, r, func() (*eth.PayloadStatusV1, error) {
+		return r.engine.NewPayload(ctx, payload, parentBeaconBlockRoot)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if (status.Status == eth.ExecutionSyncing || status.Status == eth.ExecutionAccepted) && r.accepted(payload.ParentHash) {
+		// The engine lost the parent block it accepted before
+		if err := r.reconcile(ctx); err != nil {
+			r.log.Warn("Failed to reconcile engine forkchoice state", "err", err)
+		} else if status, err = r.engine.NewPayload(ctx, payload, parentBeaconBlockRoot); err != nil {
+			return nil, err
+		}
+	}
+	if status.Status == eth.ExecutionValid {
+		r.mu.Lock()
+		r.payloads = append(r.payloads, replayPayload{payload: payload, parentBeaconBlockRoot: parentBeaconBlockRoot})
+		if len(r.payloads) > r.cfg.MaxReplayPayloads {
+			r.payloads = slices.Delete(r.payloads, 0, len(r.payloads)-r.cfg.MaxReplayPayloads)
+		}
+		r.mu.Unlock()
+	}
+	return status, nil
+}
+
+// accepted returns whether the engine accepted the block as valid before.
+func (r *EngineReconciler) accepted(hash common.Hash) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lastFC != nil && r.lastFC.HeadBlockHash == hash {
+		return true
+	}
+	return slices.ContainsFunc(r.payloads, func(p replayPayload) bool {
+		return p.payload.BlockHash == hash
+	})
+}
+
+// reconcile replays the lost payloads and the last forkchoice state to the engine,
+// if the unsafe head of the engine regressed from the last forkchoice state it accepted.
+func (r *EngineReconciler) reconcile(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lastFC == nil {
+		return nil
+	}
+	head, err := r.engine.L2BlockRefByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return fmt.Errorf("failed to fetch engine unsafe head: %w", err)
+	}
+	if head.Hash == r.lastFC.HeadBlockHash {
+		return nil
+	}
+
+	// Collect the lost payloads, from the forkchoice head back to the head of the engine
+	byHash := make(map[common.Hash]replayPayload, len(r.payloads))
+	for _, p := range r.payloads {
+		byHash[p.payload.BlockHash] = p
+	}
+	var lost []replayPayload
+	for hash := r.lastFC.HeadBlockHash; hash != head.Hash; {
+		p, ok := byHash[hash]
+		if !ok {
+			return fmt.Errorf("engine head %s regressed beyond the %d recent payloads", head, len(r.payloads))
+		}
+		lost = append(lost, p)
+		hash = p.payload.ParentHash
+	}
+	slices.Reverse(lost)
+
+	r.log.Warn("Engine head regressed, replaying lost payloads", "head", head, "forkchoice_head", r.lastFC.HeadBlockHash, "payloads", len(lost))
+	for _, p := range lost {
+		status, err := r.engine.NewPayload(ctx, p.payload, p.parentBeaconBlockRoot)
+		if err != nil {
+			return fmt.Errorf("failed to replay payload %s: %w", p.payload.ID(), err)
+		}
+		if status.Status != eth.ExecutionValid {
+			return fmt.Errorf("replayed payload %s has status %s", p.payload.ID(), status.Status)
+		}
+	}
+	result, err := r.engine.ForkchoiceUpdate(ctx, r.lastFC, nil)
+	if err != nil {
+		return fmt.Errorf("failed to replay forkchoice state: %w", err)
+	}
+	if result.PayloadStatus.Status != eth.ExecutionValid {
+		return fmt.Errorf("replayed forkchoice state has status %s", result.PayloadStatus.Status)
+	}
+	r.metrics.RecordEngineReconciliation(len(lost))
+	r.log.Info("Reconciled engine forkchoice state", "head", r.lastFC.HeadBlockHash, "payloads", len(lost))
+	return nil
+}
+
+// retryEngineCall retries the engine API call while it fails with a temporary error,
+// and reconciles the engine before each retry, since it may have restarted in the meantime.
+func retryEngineCall[T any](ctx context.Context, r *EngineReconciler, call func() (T, error)) (T, error) {
+	var result T
+	var err error
+	for attempt := 0; attempt < r.cfg.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(r.cfg.Strategy.Duration(attempt - 1)):
+			case <-ctx.Done():
+				return result, ctx.Err()
+			}
+			if err := r.reconcile(ctx); err != nil {
+				r.log.Warn("Failed to reconcile engine forkchoice state", "err", err)
+			}
+		}
+		result, err = call()
+		if err == nil || !isTemporaryEngineErr(ctx, err) {
+			return result, err
+		}
+		r.log.Warn("Engine API call failed, retrying", "attempt", attempt+1, "err", err)
+	}
+	return result, err
+}
+
+// isTemporaryEngineErr returns whether the engine API call may succeed when retried:
+// errors returned by the engine itself, e.g. eth.InputError, are not temporary.
+func isTemporaryEngineErr(ctx context.Context, err error) bool {
+	var rpcErr rpc.Error
+	return ctx.Err() == nil && !errors.As(err, &rpcErr)
+}
+
+// ReconcilingEngineClient is an EngineClient that recovers from restarts of the execution engine,
+// see EngineReconciler.
+type ReconcilingEngineClient struct {
+	*EngineClient
+	reconciler *EngineReconciler
+}
+
+func NewReconcilingEngineClient(engine *EngineClient, log log.Logger, metrics EngineReconcileMetrics, cfg *EngineReconcilerConfig) *ReconcilingEngineClient {
+	return &ReconcilingEngineClient{
+		EngineClient: engine,
+		reconciler:   NewEngineReconciler(engine, log, metrics, cfg),
+	}
+}
+
+func (c *ReconcilingEngineClient) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	return c.reconciler.GetPayload(ctx, payloadInfo)
+}
+
+func (c *ReconcilingEngineClient) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	return c.reconciler.ForkchoiceUpdate(ctx, fc, attr)
+}
+
+func (c *ReconcilingEngineClient) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	return c.reconciler.NewPayload(ctx, payload, parentBeaconBlockRoot)
+}
@@ -0,0 +1,197 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/retry"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+// fakeRestartingEngine is an engine that can be restarted with a lower head, losing its most recent blocks.
+type fakeRestartingEngine struct {
+	blocks map[common.Hash]*eth.ExecutionPayload
+	head   common.Hash
+	// down is the number of calls that fail, as if the engine is unavailable
+	down int
+}
+
+func newFakeRestartingEngine(genesis *eth.ExecutionPayload) *fakeRestartingEngine {
+	return &fakeRestartingEngine{
+		blocks: map[common.Hash]*eth.ExecutionPayload{genesis.BlockHash: genesis},
+		head:   genesis.BlockHash,
+	}
+}
+
+func (f *fakeRestartingEngine) call() error {
+	if f.down > 0 {
+		f.down--
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (f *fakeRestartingEngine) restart(head *eth.ExecutionPayload) {
+	for hash, block := range f.blocks {
+		if block.BlockNumber > head.BlockNumber {
+			delete(f.blocks, hash)
+		}
+	}
+	f.head = head.BlockHash
+}
+
+func (f *fakeRestartingEngine) GetPayload(ctx context.Context, payloadInfo eth.PayloadInfo) (*eth.ExecutionPayloadEnvelope, error) {
+	if err := f.call(); err != nil {
+		return nil, err
+	}
+	return &eth.ExecutionPayloadEnvelope{ExecutionPayload: f.blocks[f.head]}, nil
+}
+
+func (f *fakeRestartingEngine) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	if err := f.call(); err != nil {
+		return nil, err
+	}
+	if _, ok := f.blocks[state.HeadBlockHash]; !ok {
+		return &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionSyncing}}, nil
+	}
+	f.head = state.HeadBlockHash
+	return &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}, nil
+}
+
+func (f *fakeRestartingEngine) NewPayload(ctx context.Context, payload *eth.ExecutionPayload, parentBeaconBlockRoot *common.Hash) (*eth.PayloadStatusV1, error) {
+	if err := f.call(); err != nil {
+		return nil, err
+	}
+	if _, ok := f.blocks[payload.ParentHash]; !ok {
+		return &eth.PayloadStatusV1{Status: eth.ExecutionSyncing}, nil
+	}
+	f.blocks[payload.BlockHash] = payload
+	return &eth.PayloadStatusV1{Status: eth.ExecutionValid}, nil
+}
+
+func (f *fakeRestartingEngine) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	if err := f.call(); err != nil {
+		return eth.L2BlockRef{}, err
+	}
+	head := f.blocks[f.head]
+	return eth.L2BlockRef{Hash: head.BlockHash, Number: uint64(head.BlockNumber), ParentHash: head.ParentHash}, nil
+}
+
+type fakeReconcileMetrics struct {
+	reconciliations []int
+}
+
+func (m *fakeReconcileMetrics) RecordEngineReconciliation(replayedPayloads int) {
+	m.reconciliations = append(m.reconciliations, replayedPayloads)
+}
+
+type fakeEngineRPCErr struct{}
+
+func (fakeEngineRPCErr) Error() string  { return "invalid forkchoice state" }
+func (fakeEngineRPCErr) ErrorCode() int { return int(eth.InvalidForkchoiceState) }
+
+func testChain(n int) []*eth.ExecutionPayload {
+	chain := make([]*eth.ExecutionPayload, n)
+	for i := range chain {
+		chain[i] = &eth.ExecutionPayload{BlockHash: common.Hash{byte(i + 1)}, BlockNumber: eth.Uint64Quantity(i)}
+		if i > 0 {
+			chain[i].ParentHash = chain[i-1].BlockHash
+		}
+	}
+	return chain
+}
+
+func setupReconciler(t *testing.T, maxReplayPayloads int) (*EngineReconciler, *fakeRestartingEngine, *fakeReconcileMetrics, []*eth.ExecutionPayload) {
+	chain := testChain(8)
+	engine := newFakeRestartingEngine(chain[0])
+	m := &fakeReconcileMetrics{}
+	r := NewEngineReconciler(engine, testlog.Logger(t, log.LevelInfo), m, &EngineReconcilerConfig{
+		MaxAttempts:       3,
+		Strategy:          retry.Fixed(0),
+		MaxReplayPayloads: maxReplayPayloads,
+	})
+	// Process the blocks up to 5
+	for _, block := range chain[1:6] {
+		status, err := r.NewPayload(context.Background(), block, nil)
+		require.NoError(t, err)
+		require.Equal(t, eth.ExecutionValid, status.Status)
+		result, err := r.ForkchoiceUpdate(context.Background(), &eth.ForkchoiceState{HeadBlockHash: block.BlockHash}, nil)
+		require.NoError(t, err)
+		require.Equal(t, eth.ExecutionValid, result.PayloadStatus.Status)
+	}
+	return r, engine, m, chain
+}
+
+func TestEngineReconciler(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RetryTemporaryErrors", func(t *testing.T) {
+		r, engine, m, chain := setupReconciler(t, 10)
+		engine.down = 2
+		status, err := r.NewPayload(ctx, chain[6], nil)
+		require.NoError(t, err)
+		require.Equal(t, eth.ExecutionValid, status.Status)
+		require.Empty(t, m.reconciliations, "engine did not lose blocks")
+
+		engine.down = 10
+		_, err = r.NewPayload(ctx, chain[7], nil)
+		require.ErrorContains(t, err, "connection refused")
+	})
+
+	t.Run("NoRetryOfEngineErrors", func(t *testing.T) {
+		chain := testChain(2)
+		calls := 0
+		r := NewEngineReconciler(&erroringEngine{fakeRestartingEngine: newFakeRestartingEngine(chain[0]), calls: &calls},
+			testlog.Logger(t, log.LevelInfo), &fakeReconcileMetrics{}, &EngineReconcilerConfig{MaxAttempts: 3, Strategy: retry.Fixed(0)})
+		_, err := r.ForkchoiceUpdate(ctx, &eth.ForkchoiceState{HeadBlockHash: chain[1].BlockHash}, nil)
+		require.ErrorIs(t, err, eth.InputError{})
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("ReplayLostPayloads", func(t *testing.T) {
+		r, engine, m, chain := setupReconciler(t, 10)
+		engine.restart(chain[2])
+		// The engine reports it's syncing, since it lost the parent
+		status, err := r.NewPayload(ctx, chain[6], nil)
+		require.NoError(t, err)
+		require.Equal(t, eth.ExecutionValid, status.Status)
+		require.Equal(t, []int{3}, m.reconciliations)
+		require.Equal(t, chain[5].BlockHash, engine.head)
+	})
+
+	t.Run("ReplayAfterUnavailable", func(t *testing.T) {
+		r, engine, m, chain := setupReconciler(t, 10)
+		engine.restart(chain[3])
+		engine.down = 1
+		result, err := r.ForkchoiceUpdate(ctx, &eth.ForkchoiceState{HeadBlockHash: chain[5].BlockHash}, nil)
+		require.NoError(t, err)
+		require.Equal(t, eth.ExecutionValid, result.PayloadStatus.Status)
+		require.Equal(t, []int{2}, m.reconciliations)
+	})
+
+	t.Run("RegressedBeyondRecentPayloads", func(t *testing.T) {
+		r, engine, m, chain := setupReconciler(t, 2)
+		engine.restart(chain[1])
+		status, err := r.NewPayload(ctx, chain[6], nil)
+		require.NoError(t, err)
+		require.Equal(t, eth.ExecutionSyncing, status.Status)
+		require.Empty(t, m.reconciliations)
+	})
+}
+
+// erroringEngine rejects all forkchoice updates with an engine error.
+type erroringEngine struct {
+	*fakeRestartingEngine
+	calls *int
+}
+
+func (e *erroringEngine) ForkchoiceUpdate(ctx context.Context, state *eth.ForkchoiceState, attr *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	*e.calls++
+	return nil, eth.InputError{Inner: fakeEngineRPCErr{}, Code: eth.InvalidForkchoiceState}
+}
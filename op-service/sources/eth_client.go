@@ -11,6 +11,7 @@ package sources
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"time"
@@ -21,6 +22,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/ethereum-optimism/optimism/op-service/cache"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
@@ -117,15 +119,15 @@ type EthClient struct {
 
 	// cache transactions in bundles per block hash
 	// common.Hash -> types.Transactions
-	transactionsCache *caching.LRUCache[common.Hash, types.Transactions]
+	transactionsCache *cache.Cache[common.Hash, types.Transactions]
 
 	// cache block headers of blocks by hash
 	// common.Hash -> *HeaderInfo
-	headersCache *caching.LRUCache[common.Hash, eth.BlockInfo]
+	headersCache *cache.Cache[common.Hash, eth.BlockInfo]
 
 	// cache payloads by hash
 	// common.Hash -> *eth.ExecutionPayload
-	payloadsCache *caching.LRUCache[common.Hash, *eth.ExecutionPayloadEnvelope]
+	payloadsCache *cache.Cache[common.Hash, *eth.ExecutionPayloadEnvelope]
 }
 
 // NewEthClient returns an [EthClient], wrapping an RPC with bindings to fetch ethereum data with added error logging,
@@ -146,9 +148,9 @@ func NewEthClient(client client.RPC, log log.Logger, metrics caching.Metrics, co
 		trustRPC:          config.TrustRPC,
 		mustBePostMerge:   config.MustBePostMerge,
 		log:               log,
-		transactionsCache: caching.NewLRUCache[common.Hash, types.Transactions](metrics, "txs", config.TransactionsCacheSize),
-		headersCache:      caching.NewLRUCache[common.Hash, eth.BlockInfo](metrics, "headers", config.HeadersCacheSize),
-		payloadsCache:     caching.NewLRUCache[common.Hash, *eth.ExecutionPayloadEnvelope](metrics, "payloads", config.PayloadsCacheSize),
+		transactionsCache: cache.New[common.Hash, types.Transactions](metrics, "txs", cache.Config{Size: config.TransactionsCacheSize}),
+		headersCache:      cache.New[common.Hash, eth.BlockInfo](metrics, "headers", cache.Config{Size: config.HeadersCacheSize}),
+		payloadsCache:     cache.New[common.Hash, *eth.ExecutionPayloadEnvelope](metrics, "payloads", cache.Config{Size: config.PayloadsCacheSize}),
 	}, nil
 }
 
@@ -190,6 +192,16 @@ func (n numberID) CheckID(id eth.BlockID) error {
 }
 
 func (s *EthClient) headerCall(ctx context.Context, method string, id rpcBlockID) (eth.BlockInfo, error) {
+	info, err := s.fetchHeader(ctx, method, id)
+	if err != nil {
+		return nil, err
+	}
+	s.headersCache.Add(info.Hash(), info)
+	return info, nil
+}
+
+// fetchHeader fetches a block header, without caching it.
+func (s *EthClient) fetchHeader(ctx context.Context, method string, id rpcBlockID) (eth.BlockInfo, error) {
 	var header *RPCHeader
 	err := s.client.CallContext(ctx, &header, method, id.Arg(), false) // headers are just blocks without txs
 	if err != nil {
@@ -205,7 +217,6 @@ func (s *EthClient) headerCall(ctx context.Context, method string, id rpcBlockID
 	if err := id.CheckID(eth.ToBlockID(info)); err != nil {
 		return nil, fmt.Errorf("fetched block header does not match requested ID: %w", err)
 	}
-	s.headersCache.Add(info.Hash(), info)
 	return info, nil
 }
 
@@ -231,6 +242,16 @@ func (s *EthClient) blockCall(ctx context.Context, method string, id rpcBlockID)
 }
 
 func (s *EthClient) payloadCall(ctx context.Context, method string, id rpcBlockID) (*eth.ExecutionPayloadEnvelope, error) {
+	envelope, err := s.fetchPayload(ctx, method, id)
+	if err != nil {
+		return nil, err
+	}
+	s.payloadsCache.Add(envelope.ExecutionPayload.BlockHash, envelope)
+	return envelope, nil
+}
+
+// fetchPayload fetches a block as execution payload, without caching it.
+func (s *EthClient) fetchPayload(ctx context.Context, method string, id rpcBlockID) (*eth.ExecutionPayloadEnvelope, error) {
 	var block *RPCBlock
 	err := s.client.CallContext(ctx, &block, method, id.Arg(), true)
 	if err != nil {
@@ -246,7 +267,6 @@ func (s *EthClient) payloadCall(ctx context.Context, method string, id rpcBlockI
 	if err := id.CheckID(envelope.ExecutionPayload.ID()); err != nil {
 		return nil, fmt.Errorf("fetched payload does not match requested ID: %w", err)
 	}
-	s.payloadsCache.Add(envelope.ExecutionPayload.BlockHash, envelope)
 	return envelope, nil
 }
 
@@ -260,11 +280,55 @@ func (s *EthClient) ChainID(ctx context.Context) (*big.Int, error) {
 	return (*big.Int)(&id), nil
 }
 
-func (s *EthClient) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
-	if header, ok := s.headersCache.Get(hash); ok {
-		return header, nil
+// rpcSyncProgress is the eth_syncing result of a node that is syncing.
+type rpcSyncProgress struct {
+	StartingBlock    hexutil.Uint64 `json:"startingBlock"`
+	CurrentBlock     hexutil.Uint64 `json:"currentBlock"`
+	HighestBlock     hexutil.Uint64 `json:"highestBlock"`
+	SyncedAccounts   hexutil.Uint64 `json:"syncedAccounts"`
+	SyncedStorage    hexutil.Uint64 `json:"syncedStorage"`
+	SyncedBytecodes  hexutil.Uint64 `json:"syncedBytecodes"`
+	HealedTrienodes  hexutil.Uint64 `json:"healedTrienodes"`
+	HealedBytecodes  hexutil.Uint64 `json:"healedBytecodes"`
+	HealingTrienodes hexutil.Uint64 `json:"healingTrienodes"`
+	HealingBytecode  hexutil.Uint64 `json:"healingBytecode"`
+}
+
+// SyncProgress fetches the sync progress of the node with eth_syncing.
+// It returns nil if the node is not syncing.
+func (s *EthClient) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
+	var raw json.RawMessage
+	if err := s.client.CallContext(ctx, &raw, "eth_syncing"); err != nil {
+		return nil, err
 	}
-	return s.headerCall(ctx, "eth_getBlockByHash", hashID(hash))
+	// The node returns false instead of a progress object when it is not syncing
+	var syncing bool
+	if err := json.Unmarshal(raw, &syncing); err == nil {
+		return nil, nil
+	}
+	var p rpcSyncProgress
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil, fmt.Errorf("failed to decode sync progress: %w", err)
+	}
+	return &ethereum.SyncProgress{
+		StartingBlock:    uint64(p.StartingBlock),
+		CurrentBlock:     uint64(p.CurrentBlock),
+		HighestBlock:     uint64(p.HighestBlock),
+		SyncedAccounts:   uint64(p.SyncedAccounts),
+		SyncedStorage:    uint64(p.SyncedStorage),
+		SyncedBytecodes:  uint64(p.SyncedBytecodes),
+		HealedTrienodes:  uint64(p.HealedTrienodes),
+		HealedBytecodes:  uint64(p.HealedBytecodes),
+		HealingTrienodes: uint64(p.HealingTrienodes),
+		HealingBytecode:  uint64(p.HealingBytecode),
+	}, nil
+}
+
+func (s *EthClient) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	// concurrent requests for the same block share a single fetch
+	return s.headersCache.GetOrFetch(ctx, hash, func(ctx context.Context) (eth.BlockInfo, error) {
+		return s.fetchHeader(ctx, "eth_getBlockByHash", hashID(hash))
+	})
 }
 
 func (s *EthClient) InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error) {
@@ -297,10 +361,10 @@ func (s *EthClient) InfoAndTxsByLabel(ctx context.Context, label eth.BlockLabel)
 }
 
 func (s *EthClient) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayloadEnvelope, error) {
-	if payload, ok := s.payloadsCache.Get(hash); ok {
-		return payload, nil
-	}
-	return s.payloadCall(ctx, "eth_getBlockByHash", hashID(hash))
+	// concurrent requests for the same block share a single fetch
+	return s.payloadsCache.GetOrFetch(ctx, hash, func(ctx context.Context) (*eth.ExecutionPayloadEnvelope, error) {
+		return s.fetchPayload(ctx, "eth_getBlockByHash", hashID(hash))
+	})
 }
 
 func (s *EthClient) PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayloadEnvelope, error) {
@@ -3,6 +3,7 @@ package sources
 import (
 	"context"
 	crand "crypto/rand"
+	"encoding/json"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -17,6 +18,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/cache"
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
@@ -182,9 +184,9 @@ func TestEthClient_WrongInfoByHash(t *testing.T) {
 
 func newEthClientWithCaches(metrics caching.Metrics, cacheSize int) *EthClient {
 	return &EthClient{
-		transactionsCache: caching.NewLRUCache[common.Hash, types.Transactions](metrics, "txs", cacheSize),
-		headersCache:      caching.NewLRUCache[common.Hash, eth.BlockInfo](metrics, "headers", cacheSize),
-		payloadsCache:     caching.NewLRUCache[common.Hash, *eth.ExecutionPayloadEnvelope](metrics, "payloads", cacheSize),
+		transactionsCache: cache.New[common.Hash, types.Transactions](metrics, "txs", cache.Config{Size: cacheSize}),
+		headersCache:      cache.New[common.Hash, eth.BlockInfo](metrics, "headers", cache.Config{Size: cacheSize}),
+		payloadsCache:     cache.New[common.Hash, *eth.ExecutionPayloadEnvelope](metrics, "payloads", cache.Config{Size: cacheSize}),
 	}
 }
 
@@ -226,3 +228,36 @@ func TestReceiptValidation(t *testing.T) {
 	_, _, err := ethcl.FetchReceipts(ctx, block.Hash)
 	require.ErrorContains(err, "unexpected nil block number")
 }
+
+func TestEthClient_SyncProgress(t *testing.T) {
+	ctx := context.Background()
+	newClient := func(t *testing.T, result string) *EthClient {
+		m := new(mockRPC)
+		m.On("CallContext", ctx, new(json.RawMessage), "eth_syncing", []any(nil)).Run(func(args mock.Arguments) {
+			*args[1].(*json.RawMessage) = json.RawMessage(result)
+		}).Return([]error{nil})
+		t.Cleanup(func() {
+			m.Mock.AssertExpectations(t)
+		})
+		s, err := NewEthClient(m, nil, nil, testEthClientConfig)
+		require.NoError(t, err)
+		return s
+	}
+
+	t.Run("NotSyncing", func(t *testing.T) {
+		progress, err := newClient(t, "false").SyncProgress(ctx)
+		require.NoError(t, err)
+		require.Nil(t, progress)
+	})
+
+	t.Run("Syncing", func(t *testing.T) {
+		progress, err := newClient(t, `{"startingBlock":"0x0","currentBlock":"0x10","highestBlock":"0x100","syncedAccounts":"0x5","healingTrienodes":"0x2"}`).SyncProgress(ctx)
+		require.NoError(t, err)
+		require.Equal(t, &ethereum.SyncProgress{
+			CurrentBlock:     0x10,
+			HighestBlock:     0x100,
+			SyncedAccounts:   5,
+			HealingTrienodes: 2,
+		}, progress)
+	})
+}
@@ -0,0 +1,253 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+)
+
+const l1CacheVersion = 1
+
+// persistedL1Block is a single cached L1 block, as persisted to disk.
+// Transactions and receipts are optional.
+type persistedL1Block struct {
+	Header       hexutil.Bytes  `json:"header"`
+	Transactions hexutil.Bytes  `json:"transactions,omitempty"`
+	Receipts     types.Receipts `json:"receipts,omitempty"`
+}
+
+type persistedL1Cache struct {
+	Version uint64             `json:"version"`
+	Blocks  []persistedL1Block `json:"blocks"`
+}
+
+// loadedL1Block is a persisted L1 block that passed the integrity checks.
+type loadedL1Block struct {
+	info     eth.BlockInfo
+	txs      types.Transactions
+	receipts types.Receipts
+}
+
+// receiptsCache is implemented by receipt providers that cache receipts, and allows persisting them.
+type receiptsCache interface {
+	CachedReceipts(blockHash common.Hash) (types.Receipts, bool)
+	AddCachedReceipts(blockHash common.Hash, receipts types.Receipts)
+}
+
+// SaveCache persists the cached L1 headers, transactions and receipts to the given file,
+// so they do not have to be fetched from the RPC again after a restart.
+// Only blocks within maxDepth of the highest cached block are persisted.
+// If the path ends in .gz the file is compressed.
+func (s *L1Client) SaveCache(path string, maxDepth uint64) error {
+	recCache, _ := s.recProvider.(receiptsCache)
+	var infos []eth.BlockInfo
+	var highest uint64
+	for _, hash := range s.headersCache.Keys() {
+		info, ok := s.headersCache.Peek(hash)
+		if !ok {
+			continue
+		}
+		infos = append(infos, info)
+		highest = max(highest, info.NumberU64())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].NumberU64() < infos[j].NumberU64()
+	})
+	out := persistedL1Cache{Version: l1CacheVersion}
+	for _, info := range infos {
+		if info.NumberU64()+maxDepth < highest {
+			continue
+		}
+		headerRLP, err := info.HeaderRLP()
+		if err != nil {
+			return fmt.Errorf("failed to encode header %s: %w", info.Hash(), err)
+		}
+		block := persistedL1Block{Header: headerRLP}
+		if txs, ok := s.transactionsCache.Peek(info.Hash()); ok {
+			txsRLP, err := rlp.EncodeToBytes(txs)
+			if err != nil {
+				return fmt.Errorf("failed to encode transactions of block %s: %w", info.Hash(), err)
+			}
+			block.Transactions = txsRLP
+			if recCache != nil {
+				if receipts, ok := recCache.CachedReceipts(info.Hash()); ok {
+					block.Receipts = receipts
+				}
+			}
+		}
+		out.Blocks = append(out.Blocks, block)
+	}
+	if err := jsonutil.WriteJSON(path, out, 0o644); err != nil {
+		return fmt.Errorf("failed to write L1 cache: %w", err)
+	}
+	s.log.Info("Persisted L1 cache", "path", path, "blocks", len(out.Blocks), "highest", highest)
+	return nil
+}
+
+// LoadCache restores the L1 headers, transactions and receipts persisted with SaveCache.
+// Each block is checked against its header (tx root, receipts root), and blocks that are no longer
+// canonical, because of an L1 reorg while the node was offline, are discarded.
+// A missing file is not an error. Returns the number of restored blocks.
+func (s *L1Client) LoadCache(ctx context.Context, path string) (int, error) {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	persisted, err := jsonutil.LoadJSON[persistedL1Cache](path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read L1 cache: %w", err)
+	}
+	if persisted.Version != l1CacheVersion {
+		return 0, fmt.Errorf("unsupported L1 cache version %d", persisted.Version)
+	}
+	blocks := make(map[common.Hash]*loadedL1Block, len(persisted.Blocks))
+	for i, b := range persisted.Blocks {
+		block, err := decodePersistedL1Block(b)
+		if err != nil {
+			s.log.Warn("Discarding invalid persisted L1 block", "index", i, "err", err)
+			continue
+		}
+		blocks[block.info.Hash()] = block
+	}
+	tip, err := s.canonicalTip(ctx, blocks)
+	if err != nil {
+		return 0, err
+	}
+	recCache, _ := s.recProvider.(receiptsCache)
+	// Only restore the blocks that are ancestors of the canonical tip.
+	restored := 0
+	for block, ok := blocks[tip]; ok; block, ok = blocks[block.info.ParentHash()] {
+		hash := block.info.Hash()
+		s.headersCache.Add(hash, block.info)
+		s.l1BlockRefsCache.Add(hash, eth.InfoToL1BlockRef(block.info))
+		if block.txs != nil {
+			s.transactionsCache.Add(hash, block.txs)
+		}
+		if block.receipts != nil && recCache != nil {
+			recCache.AddCachedReceipts(hash, block.receipts)
+		}
+		restored++
+	}
+	if dropped := len(blocks) - restored; dropped > 0 {
+		s.log.Info("Discarded persisted L1 blocks that are not canonical", "count", dropped)
+	}
+	s.log.Info("Restored L1 cache", "path", path, "blocks", restored)
+	return restored, nil
+}
+
+// canonicalTip returns the hash of the highest persisted block that is still canonical on L1,
+// or the zero hash if there is none.
+// Canonicality is monotonic along a chain: the ancestors of a canonical block are canonical, and the descendants
+// of a non-canonical block are not. So only the head of each persisted chain is checked against L1,
+// and the chain is binary searched if the head was reorged out, bounding the RPC calls to O(log n) per chain.
+func (s *L1Client) canonicalTip(ctx context.Context, blocks map[common.Hash]*loadedL1Block) (common.Hash, error) {
+	// The heads of the persisted chains are the blocks that are not the parent of another persisted block.
+	parents := make(map[common.Hash]struct{}, len(blocks))
+	for _, b := range blocks {
+		parents[b.info.ParentHash()] = struct{}{}
+	}
+	var heads []*loadedL1Block
+	for hash, b := range blocks {
+		if _, ok := parents[hash]; !ok {
+			heads = append(heads, b)
+		}
+	}
+	sort.Slice(heads, func(i, j int) bool {
+		return heads[i].info.NumberU64() > heads[j].info.NumberU64()
+	})
+
+	canonical := make(map[uint64]common.Hash)
+	isCanonical := func(b *loadedL1Block) (bool, error) {
+		num := b.info.NumberU64()
+		hash, ok := canonical[num]
+		if !ok {
+			info, err := s.InfoByNumber(ctx, num)
+			if err != nil {
+				return false, fmt.Errorf("failed to check canonical L1 block %d: %w", num, err)
+			}
+			hash = info.Hash()
+			canonical[num] = hash
+		}
+		return hash == b.info.Hash(), nil
+	}
+
+	var tip *loadedL1Block
+	for _, head := range heads {
+		// A lower chain can't have a higher canonical block
+		if tip != nil && head.info.NumberU64() <= tip.info.NumberU64() {
+			break
+		}
+		if ok, err := isCanonical(head); err != nil {
+			return common.Hash{}, err
+		} else if ok {
+			tip = head
+			continue
+		}
+		// The chain, from its lowest persisted block, without the non-canonical head
+		var chain []*loadedL1Block
+		for b, ok := blocks[head.info.ParentHash()]; ok; b, ok = blocks[b.info.ParentHash()] {
+			chain = append(chain, b)
+		}
+		slices.Reverse(chain)
+		var searchErr error
+		i := sort.Search(len(chain), func(i int) bool {
+			if searchErr != nil {
+				return true
+			}
+			ok, err := isCanonical(chain[i])
+			if err != nil {
+				searchErr = err
+				return true
+			}
+			return !ok
+		})
+		if searchErr != nil {
+			return common.Hash{}, searchErr
+		}
+		if i > 0 && (tip == nil || chain[i-1].info.NumberU64() > tip.info.NumberU64()) {
+			tip = chain[i-1]
+		}
+	}
+	if tip == nil {
+		return common.Hash{}, nil
+	}
+	return tip.info.Hash(), nil
+}
+
+func decodePersistedL1Block(b persistedL1Block) (*loadedL1Block, error) {
+	var header types.Header
+	if err := rlp.DecodeBytes(b.Header, &header); err != nil {
+		return nil, fmt.Errorf("failed to decode header: %w", err)
+	}
+	info := eth.HeaderBlockInfo(&header)
+	block := &loadedL1Block{info: info}
+	if b.Transactions == nil {
+		return block, nil
+	}
+	var txs types.Transactions
+	if err := rlp.DecodeBytes(b.Transactions, &txs); err != nil {
+		return nil, fmt.Errorf("failed to decode transactions of block %s: %w", info.Hash(), err)
+	}
+	if computed := types.DeriveSha(txs, trie.NewStackTrie(nil)); computed != header.TxHash {
+		return nil, fmt.Errorf("transactions of block %s do not match tx root %s, computed %s", info.Hash(), header.TxHash, computed)
+	}
+	block.txs = txs
+	if b.Receipts != nil {
+		if err := validateReceipts(eth.ToBlockID(info), header.ReceiptHash, eth.TransactionsToHashes(txs), b.Receipts); err != nil {
+			return nil, fmt.Errorf("invalid receipts of block %s: %w", info.Hash(), err)
+		}
+		block.receipts = b.Receipts
+	}
+	return block, nil
+}
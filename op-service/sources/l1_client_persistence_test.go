@@ -0,0 +1,194 @@
+package sources
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type testL1Block struct {
+	header   *types.Header
+	txs      types.Transactions
+	receipts types.Receipts
+}
+
+// makeTestL1Chain creates a chain of L1 blocks with a single transaction and receipt each.
+// The extra data differentiates otherwise identical chains.
+func makeTestL1Chain(parent common.Hash, start uint64, count int, extra []byte) []testL1Block {
+	var blocks []testL1Block
+	for i := 0; i < count; i++ {
+		num := start + uint64(i)
+		tx := types.NewTx(&types.LegacyTx{Nonce: num, Gas: 21000, GasPrice: big.NewInt(1), To: &common.Address{0x42}})
+		receipt := &types.Receipt{
+			Type:              types.LegacyTxType,
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 21000,
+			GasUsed:           21000,
+			Logs:              []*types.Log{},
+			TxHash:            tx.Hash(),
+			BlockNumber:       new(big.Int).SetUint64(num),
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		txs := types.Transactions{tx}
+		receipts := types.Receipts{receipt}
+		header := &types.Header{
+			ParentHash:  parent,
+			Number:      new(big.Int).SetUint64(num),
+			Difficulty:  big.NewInt(0),
+			TxHash:      types.DeriveSha(txs, trie.NewStackTrie(nil)),
+			ReceiptHash: types.DeriveSha(receipts, trie.NewStackTrie(nil)),
+			BaseFee:     big.NewInt(7),
+			Time:        num * 12,
+			Extra:       extra,
+		}
+		receipt.BlockHash = header.Hash()
+		blocks = append(blocks, testL1Block{header: header, txs: txs, receipts: receipts})
+		parent = header.Hash()
+	}
+	return blocks
+}
+
+func newTestPersistenceL1Client(t *testing.T, m *mockRPC) *L1Client {
+	s, err := NewL1Client(m, testlog.Logger(t, log.LevelDebug), nil, L1ClientSimpleConfig(false, RPCKindStandard, 100))
+	require.NoError(t, err)
+	return s
+}
+
+func addToCache(s *L1Client, b testL1Block) {
+	info := eth.HeaderBlockInfo(b.header)
+	s.headersCache.Add(info.Hash(), info)
+	s.transactionsCache.Add(info.Hash(), b.txs)
+	s.recProvider.(receiptsCache).AddCachedReceipts(info.Hash(), b.receipts)
+}
+
+func expectCanonical(m *mockRPC, hdr *types.Header) *mock.Call {
+	rhdr := &RPCHeader{
+		ParentHash:  hdr.ParentHash,
+		UncleHash:   hdr.UncleHash,
+		Root:        hdr.Root,
+		TxHash:      hdr.TxHash,
+		ReceiptHash: hdr.ReceiptHash,
+		Difficulty:  *(*hexutil.Big)(hdr.Difficulty),
+		Number:      hexutil.Uint64(hdr.Number.Uint64()),
+		Time:        hexutil.Uint64(hdr.Time),
+		Extra:       hdr.Extra,
+		BaseFee:     (*hexutil.Big)(hdr.BaseFee),
+		Hash:        hdr.Hash(),
+	}
+	return m.On("CallContext", mock.Anything, new(*RPCHeader),
+		"eth_getBlockByNumber", []any{hexutil.EncodeUint64(hdr.Number.Uint64()), false}).Run(func(args mock.Arguments) {
+		*args[1].(**RPCHeader) = rhdr
+	}).Return([]error{nil}).Once()
+}
+
+func TestL1ClientCachePersistence(t *testing.T) {
+	ctx := context.Background()
+	chain := makeTestL1Chain(common.Hash{0xaa}, 100, 5, nil)
+	path := filepath.Join(t.TempDir(), "l1cache.json.gz")
+
+	src := newTestPersistenceL1Client(t, new(mockRPC))
+	for _, b := range chain {
+		addToCache(src, b)
+	}
+	// Only the blocks within depth 3 of the highest block are persisted
+	require.NoError(t, src.SaveCache(path, 3))
+
+	t.Run("Restore", func(t *testing.T) {
+		m := new(mockRPC)
+		expectCanonical(m, chain[4].header)
+		dst := newTestPersistenceL1Client(t, m)
+		restored, err := dst.LoadCache(ctx, path)
+		require.NoError(t, err)
+		require.Equal(t, 4, restored)
+		m.AssertExpectations(t)
+
+		// Served from the cache, without RPC calls
+		for _, b := range chain[1:] {
+			info, receipts, err := dst.FetchReceipts(ctx, b.header.Hash())
+			require.NoError(t, err)
+			require.Equal(t, b.header.Hash(), info.Hash())
+			require.Len(t, receipts, 1)
+			require.Equal(t, b.receipts[0].TxHash, receipts[0].TxHash)
+			ref, err := dst.L1BlockRefByHash(ctx, b.header.Hash())
+			require.NoError(t, err)
+			require.Equal(t, b.header.Number.Uint64(), ref.Number)
+		}
+		_, ok := dst.headersCache.Peek(chain[0].header.Hash())
+		require.False(t, ok, "block beyond the depth should not be persisted")
+	})
+
+	t.Run("Reorg", func(t *testing.T) {
+		m := new(mockRPC)
+		// blocks 103 and 104 were reorged out while offline
+		reorged := makeTestL1Chain(chain[2].header.Hash(), 103, 2, []byte("reorg"))
+		expectCanonical(m, reorged[1].header)
+		expectCanonical(m, reorged[0].header)
+		expectCanonical(m, chain[2].header)
+		dst := newTestPersistenceL1Client(t, m)
+		restored, err := dst.LoadCache(ctx, path)
+		require.NoError(t, err)
+		require.Equal(t, 2, restored)
+		m.AssertExpectations(t)
+		_, ok := dst.headersCache.Peek(chain[3].header.Hash())
+		require.False(t, ok, "reorged block should not be restored")
+		_, ok = dst.headersCache.Peek(chain[2].header.Hash())
+		require.True(t, ok)
+	})
+
+	t.Run("BoundedChecks", func(t *testing.T) {
+		long := makeTestL1Chain(common.Hash{0xbb}, 200, 64, nil)
+		longPath := filepath.Join(t.TempDir(), "l1cache.json")
+		src := newTestPersistenceL1Client(t, new(mockRPC))
+		for _, b := range long {
+			addToCache(src, b)
+		}
+		require.NoError(t, src.SaveCache(longPath, 100))
+
+		m := new(mockRPC)
+		// all blocks after 209 were reorged out while offline
+		for _, b := range makeTestL1Chain(long[9].header.Hash(), 210, 54, []byte("reorg")) {
+			expectCanonical(m, b.header).Maybe()
+		}
+		for _, b := range long[:10] {
+			expectCanonical(m, b.header).Maybe()
+		}
+		dst := newTestPersistenceL1Client(t, m)
+		restored, err := dst.LoadCache(ctx, longPath)
+		require.NoError(t, err)
+		require.Equal(t, 10, restored)
+		// the head, then a binary search of the rest of the chain
+		require.LessOrEqual(t, len(m.Calls), 1+7)
+	})
+
+	t.Run("Corrupted", func(t *testing.T) {
+		persisted := persistedL1Block{}
+		headerRLP, err := eth.HeaderBlockInfo(chain[1].header).HeaderRLP()
+		require.NoError(t, err)
+		persisted.Header = headerRLP
+		// transactions of another block don't match the tx root
+		persisted.Transactions, err = rlp.EncodeToBytes(chain[2].txs)
+		require.NoError(t, err)
+		_, err = decodePersistedL1Block(persisted)
+		require.ErrorContains(t, err, "do not match tx root")
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		dst := newTestPersistenceL1Client(t, new(mockRPC))
+		restored, err := dst.LoadCache(ctx, filepath.Join(t.TempDir(), "missing.json"))
+		require.NoError(t, err)
+		require.Zero(t, restored)
+	})
+}
@@ -2,8 +2,8 @@ package sources
 
 import (
 	"context"
-	"sync"
 
+	"github.com/ethereum-optimism/optimism/op-service/cache"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 	"github.com/ethereum/go-ethereum/common"
@@ -15,18 +15,13 @@ import (
 // ReceiptsProvider. It also avoids duplicate in-flight requests per block hash.
 type CachingReceiptsProvider struct {
 	inner ReceiptsProvider
-	cache *caching.LRUCache[common.Hash, types.Receipts]
-
-	// lock fetching process for each block hash to avoid duplicate requests
-	fetching   map[common.Hash]*sync.Mutex
-	fetchingMu sync.Mutex // only protects map
+	cache *cache.Cache[common.Hash, types.Receipts]
 }
 
 func NewCachingReceiptsProvider(inner ReceiptsProvider, m caching.Metrics, cacheSize int) *CachingReceiptsProvider {
 	return &CachingReceiptsProvider{
-		inner:    inner,
-		cache:    caching.NewLRUCache[common.Hash, types.Receipts](m, "receipts", cacheSize),
-		fetching: make(map[common.Hash]*sync.Mutex),
+		inner: inner,
+		cache: cache.New[common.Hash, types.Receipts](m, "receipts", cache.Config{Size: cacheSize}),
 	}
 }
 
@@ -34,51 +29,24 @@ func NewCachingRPCReceiptsProvider(client rpcClient, log log.Logger, config RPCR
 	return NewCachingReceiptsProvider(NewRPCReceiptsFetcher(client, log, config), m, cacheSize)
 }
 
-func (p *CachingReceiptsProvider) getOrCreateFetchingLock(blockHash common.Hash) *sync.Mutex {
-	p.fetchingMu.Lock()
-	defer p.fetchingMu.Unlock()
-	if mu, ok := p.fetching[blockHash]; ok {
-		return mu
-	}
-	mu := new(sync.Mutex)
-	p.fetching[blockHash] = mu
-	return mu
-}
-
-func (p *CachingReceiptsProvider) deleteFetchingLock(blockHash common.Hash) {
-	p.fetchingMu.Lock()
-	defer p.fetchingMu.Unlock()
-	delete(p.fetching, blockHash)
-}
-
 // FetchReceipts fetches receipts for the given block and transaction hashes
 // it expects that the inner FetchReceipts implementation handles validation
 func (p *CachingReceiptsProvider) FetchReceipts(ctx context.Context, blockInfo eth.BlockInfo, txHashes []common.Hash) (types.Receipts, error) {
-	block := eth.ToBlockID(blockInfo)
-	if r, ok := p.cache.Get(block.Hash); ok {
-		return r, nil
-	}
-
-	mu := p.getOrCreateFetchingLock(block.Hash)
-	mu.Lock()
-	defer mu.Unlock()
-	// Other routine might have fetched in the meantime
-	if r, ok := p.cache.Get(block.Hash); ok {
-		// we might have created a new lock above while the old
-		// fetching job completed.
-		p.deleteFetchingLock(block.Hash)
-		return r, nil
-	}
+	return p.cache.GetOrFetch(ctx, blockInfo.Hash(), func(ctx context.Context) (types.Receipts, error) {
+		return p.inner.FetchReceipts(ctx, blockInfo, txHashes)
+	})
+}
 
-	r, err := p.inner.FetchReceipts(ctx, blockInfo, txHashes)
-	if err != nil {
-		return nil, err
-	}
+// CachedReceipts returns the cached receipts of the given block, if any.
+// It does not fetch the receipts if they are not cached.
+func (p *CachingReceiptsProvider) CachedReceipts(blockHash common.Hash) (types.Receipts, bool) {
+	return p.cache.Peek(blockHash)
+}
 
-	p.cache.Add(block.Hash, r)
-	// result now in cache, can delete fetching lock
-	p.deleteFetchingLock(block.Hash)
-	return r, nil
+// AddCachedReceipts adds the receipts of the given block to the cache.
+// The receipts are expected to be validated already.
+func (p *CachingReceiptsProvider) AddCachedReceipts(blockHash common.Hash, receipts types.Receipts) {
+	p.cache.Add(blockHash, receipts)
 }
 
 func (p *CachingReceiptsProvider) isInnerNil() bool {
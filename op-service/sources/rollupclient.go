@@ -44,12 +44,24 @@ func (r *RollupClient) RollupConfig(ctx context.Context) (*rollup.Config, error)
 	return output, err
 }
 
+func (r *RollupClient) RollupConfigHash(ctx context.Context) (common.Hash, error) {
+	var output common.Hash
+	err := r.rpc.CallContext(ctx, &output, "optimism_rollupConfigHash")
+	return output, err
+}
+
 func (r *RollupClient) Version(ctx context.Context) (string, error) {
 	var output string
 	err := r.rpc.CallContext(ctx, &output, "optimism_version")
 	return output, err
 }
 
+func (r *RollupClient) SimulateDerivation(ctx context.Context, data []hexutil.Bytes) (*eth.DerivationSimulation, error) {
+	var output *eth.DerivationSimulation
+	err := r.rpc.CallContext(ctx, &output, "optimism_simulateDerivation", data)
+	return output, err
+}
+
 func (r *RollupClient) StartSequencer(ctx context.Context, unsafeHead common.Hash) error {
 	return r.rpc.CallContext(ctx, nil, "admin_startSequencer", unsafeHead)
 }
@@ -66,6 +78,16 @@ func (r *RollupClient) SequencerActive(ctx context.Context) (bool, error) {
 	return result, err
 }
 
+func (r *RollupClient) SetSequencerOriginPolicy(ctx context.Context, name string) error {
+	return r.rpc.CallContext(ctx, nil, "admin_setSequencerOriginPolicy", name)
+}
+
+func (r *RollupClient) SequencerOriginPolicy(ctx context.Context) (string, error) {
+	var result string
+	err := r.rpc.CallContext(ctx, &result, "admin_sequencerOriginPolicy")
+	return result, err
+}
+
 func (r *RollupClient) PostUnsafePayload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error {
 	return r.rpc.CallContext(ctx, nil, "admin_postUnsafePayload", payload)
 }
@@ -74,10 +96,28 @@ func (r *RollupClient) OverrideLeader(ctx context.Context) error {
 	return r.rpc.CallContext(ctx, nil, "admin_overrideLeader")
 }
 
+func (r *RollupClient) SetRecoverMode(ctx context.Context, mode bool) error {
+	return r.rpc.CallContext(ctx, nil, "admin_setRecoverMode", mode)
+}
+
+func (r *RollupClient) RecoverMode(ctx context.Context) (bool, error) {
+	var result bool
+	err := r.rpc.CallContext(ctx, &result, "admin_recoverMode")
+	return result, err
+}
+
 func (r *RollupClient) SetLogLevel(ctx context.Context, lvl slog.Level) error {
 	return r.rpc.CallContext(ctx, nil, "admin_setLogLevel", lvl.String())
 }
 
+func (r *RollupClient) SetModuleLogLevel(ctx context.Context, module string, lvl slog.Level) error {
+	return r.rpc.CallContext(ctx, nil, "admin_setModuleLogLevel", module, lvl.String())
+}
+
+func (r *RollupClient) ResetModuleLogLevel(ctx context.Context, module string) error {
+	return r.rpc.CallContext(ctx, nil, "admin_resetModuleLogLevel", module)
+}
+
 func (r *RollupClient) Close() {
 	r.rpc.Close()
 }
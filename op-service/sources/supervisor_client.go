@@ -0,0 +1,76 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// SupervisorClient is the client of the op-supervisor API, as used by op-node in interop mode
+// to report the local heads of its chain, and to track the cross-safety of its chain.
+type SupervisorClient struct {
+	rpc client.RPC
+}
+
+func NewSupervisorClient(rpc client.RPC) *SupervisorClient {
+	return &SupervisorClient{rpc: rpc}
+}
+
+// UpdateLocalUnsafe reports the local-unsafe head of the chain, i.e. the tip of the chain before cross-chain validation.
+func (cl *SupervisorClient) UpdateLocalUnsafe(ctx context.Context, chainID types.ChainID, head eth.L2BlockRef) error {
+	err := cl.rpc.CallContext(ctx, nil, "supervisor_updateLocalUnsafe", (*hexutil.U256)(&chainID), head)
+	if err != nil {
+		return fmt.Errorf("failed to update local-unsafe head of chain %v to %v: %w", chainID, head, err)
+	}
+	return nil
+}
+
+// UpdateLocalSafe reports the local-safe head of the chain, and the L1 block it was derived from.
+func (cl *SupervisorClient) UpdateLocalSafe(ctx context.Context, chainID types.ChainID, derivedFrom eth.L1BlockRef, lastDerived eth.L2BlockRef) error {
+	err := cl.rpc.CallContext(ctx, nil, "supervisor_updateLocalSafe", (*hexutil.U256)(&chainID), derivedFrom, lastDerived)
+	if err != nil {
+		return fmt.Errorf("failed to update local-safe head of chain %v to %v (derived from %v): %w", chainID, lastDerived, derivedFrom, err)
+	}
+	return nil
+}
+
+// CrossSafe returns the latest block of the chain that is safe, including all its cross-chain dependencies.
+func (cl *SupervisorClient) CrossSafe(ctx context.Context, chainID types.ChainID) (eth.BlockID, error) {
+	var result eth.BlockID
+	err := cl.rpc.CallContext(ctx, &result, "supervisor_crossSafe", (*hexutil.U256)(&chainID))
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to get cross-safe head of chain %v: %w", chainID, err)
+	}
+	return result, nil
+}
+
+// Finalized returns the latest block of the chain that is finalized, including all its cross-chain dependencies.
+func (cl *SupervisorClient) Finalized(ctx context.Context, chainID types.ChainID) (eth.BlockID, error) {
+	var result eth.BlockID
+	err := cl.rpc.CallContext(ctx, &result, "supervisor_finalized", (*hexutil.U256)(&chainID))
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to get finalized head of chain %v: %w", chainID, err)
+	}
+	return result, nil
+}
+
+// CheckBlock returns the safety level of the block. The safety level is types.Invalid
+// if the block was found to execute messages that were never initiated.
+func (cl *SupervisorClient) CheckBlock(ctx context.Context, chainID types.ChainID, blockHash common.Hash, blockNumber uint64) (types.SafetyLevel, error) {
+	var result types.SafetyLevel
+	err := cl.rpc.CallContext(ctx, &result, "supervisor_checkBlock", (*hexutil.U256)(&chainID), blockHash, hexutil.Uint64(blockNumber))
+	if err != nil {
+		return types.Unsafe, fmt.Errorf("failed to check block %v:%d of chain %v: %w", blockHash, blockNumber, chainID, err)
+	}
+	return result, nil
+}
+
+func (cl *SupervisorClient) Close() {
+	cl.rpc.Close()
+}
@@ -0,0 +1,65 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+func TestSupervisorClient(t *testing.T) {
+	ctx := context.Background()
+	chainID := types.ChainIDFromUInt64(901)
+	rpcChainID := (*hexutil.U256)(&chainID)
+	head := eth.L2BlockRef{Hash: common.Hash{0xaa}, Number: 10}
+	l1 := eth.L1BlockRef{Hash: common.Hash{0xbb}, Number: 5}
+
+	t.Run("UpdateLocalUnsafe", func(t *testing.T) {
+		m := new(mockRPC)
+		m.On("CallContext", ctx, nil, "supervisor_updateLocalUnsafe", []any{rpcChainID, head}).Return([]error{nil})
+		require.NoError(t, NewSupervisorClient(m).UpdateLocalUnsafe(ctx, chainID, head))
+		m.AssertExpectations(t)
+	})
+
+	t.Run("UpdateLocalSafe", func(t *testing.T) {
+		m := new(mockRPC)
+		m.On("CallContext", ctx, nil, "supervisor_updateLocalSafe", []any{rpcChainID, l1, head}).Return([]error{nil})
+		require.NoError(t, NewSupervisorClient(m).UpdateLocalSafe(ctx, chainID, l1, head))
+		m.AssertExpectations(t)
+	})
+
+	t.Run("CrossSafe", func(t *testing.T) {
+		m := new(mockRPC)
+		m.On("CallContext", ctx, new(eth.BlockID), "supervisor_crossSafe", []any{rpcChainID}).Run(func(args mock.Arguments) {
+			*args[1].(*eth.BlockID) = head.ID()
+		}).Return([]error{nil})
+		result, err := NewSupervisorClient(m).CrossSafe(ctx, chainID)
+		require.NoError(t, err)
+		require.Equal(t, head.ID(), result)
+	})
+
+	t.Run("Finalized", func(t *testing.T) {
+		m := new(mockRPC)
+		m.On("CallContext", ctx, new(eth.BlockID), "supervisor_finalized", []any{rpcChainID}).Return([]error{errors.New("boom")})
+		_, err := NewSupervisorClient(m).Finalized(ctx, chainID)
+		require.ErrorContains(t, err, "boom")
+	})
+
+	t.Run("CheckBlock", func(t *testing.T) {
+		m := new(mockRPC)
+		m.On("CallContext", ctx, new(types.SafetyLevel), "supervisor_checkBlock",
+			[]any{rpcChainID, head.Hash, hexutil.Uint64(head.Number)}).Run(func(args mock.Arguments) {
+			*args[1].(*types.SafetyLevel) = types.Invalid
+		}).Return([]error{nil})
+		result, err := NewSupervisorClient(m).CheckBlock(ctx, chainID, head.Hash, head.Number)
+		require.NoError(t, err)
+		require.Equal(t, types.Invalid, result)
+	})
+}
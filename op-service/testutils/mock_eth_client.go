@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -142,6 +143,15 @@ func (m *MockEthClient) ExpectBlockByNumber(number *big.Int, block *types.Block,
 	m.Mock.On("BlockByNumber", number).Once().Return(block, err)
 }
 
+func (m *MockEthClient) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	out := m.Mock.Called(blockNrOrHash)
+	return out.Get(0).([]*types.Receipt), out.Error(1)
+}
+
+func (m *MockEthClient) ExpectBlockReceipts(blockNrOrHash rpc.BlockNumberOrHash, receipts []*types.Receipt, err error) {
+	m.Mock.On("BlockReceipts", blockNrOrHash).Once().Return(receipts, err)
+}
+
 func (m *MockEthClient) ExpectClose() {
 	m.Mock.On("Close").Once()
 }
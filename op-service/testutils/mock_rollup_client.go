@@ -40,6 +40,15 @@ func (m *MockRollupClient) ExpectRollupConfig(config *rollup.Config, err error)
 	m.Mock.On("RollupConfig").Once().Return(config, err)
 }
 
+func (m *MockRollupClient) RollupConfigHash(ctx context.Context) (common.Hash, error) {
+	out := m.Mock.Called()
+	return out.Get(0).(common.Hash), out.Error(1)
+}
+
+func (m *MockRollupClient) ExpectRollupConfigHash(hash common.Hash, err error) {
+	m.Mock.On("RollupConfigHash").Once().Return(hash, err)
+}
+
 func (m *MockRollupClient) StartSequencer(ctx context.Context, unsafeHead common.Hash) error {
 	out := m.Mock.Called(unsafeHead)
 	return out.Error(0)
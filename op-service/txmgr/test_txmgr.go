@@ -92,3 +92,21 @@ func (m *TestTxManager) makeStuckTx(ctx context.Context, candidate TxCandidate)
 
 	return m.cfg.Signer(ctx, m.cfg.From, types.NewTx(txMessage))
 }
+
+// SkipNonce makes the next transaction skip a nonce, leaving a gap that keeps it from being included
+// until the nonce is reset after a failed send. It should be used ONLY for testing.
+func (m *TestTxManager) SkipNonce(ctx context.Context) error {
+	m.nonceLock.Lock()
+	defer m.nonceLock.Unlock()
+	if m.nonce == nil {
+		nonce, err := m.backend.NonceAt(ctx, m.cfg.From, nil)
+		if err != nil {
+			return err
+		}
+		// The tracked nonce is the last one used, so the next transaction uses nonce+1
+		m.nonce = &nonce
+	} else {
+		*m.nonce++
+	}
+	return nil
+}
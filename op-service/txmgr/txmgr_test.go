@@ -1290,6 +1290,28 @@ func TestNonceReset(t *testing.T) {
 	require.Equal(t, []uint64{1, 1, 2, 3, 1, 2, 3, 1}, nonces)
 }
 
+func TestSkipNonce(t *testing.T) {
+	h := newTestHarness(t)
+	ttm := &TestTxManager{SimpleTxManager: h.mgr}
+	ctx := context.Background()
+
+	require.NoError(t, ttm.SkipNonce(ctx))
+	tx, err := h.mgr.craftTx(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, uint64(startingNonce+1), tx.Nonce())
+
+	require.NoError(t, ttm.SkipNonce(ctx))
+	tx, err = h.mgr.craftTx(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, uint64(startingNonce+3), tx.Nonce())
+
+	// Resetting the nonce closes the gap
+	h.mgr.resetNonce()
+	tx, err = h.mgr.craftTx(ctx, h.createTxCandidate())
+	require.NoError(t, err)
+	require.Equal(t, uint64(startingNonce), tx.Nonce())
+}
+
 func TestMinFees(t *testing.T) {
 	for _, tt := range []struct {
 		desc             string
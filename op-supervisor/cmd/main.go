@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
 	"github.com/ethereum-optimism/optimism/op-service/cliapp"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	"github.com/ethereum-optimism/optimism/op-service/metrics/doc"
@@ -35,6 +36,7 @@ func main() {
 
 func run(ctx context.Context, args []string, fn supervisor.MainFn) error {
 	oplog.SetupDefaults()
+	appinfo.SetBuild(Version, GitCommit, GitDate)
 
 	app := cli.NewApp()
 	app.Flags = cliapp.ProtectFlags(flags.Flags)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-supervisor/config"
 	"github.com/stretchr/testify/require"
@@ -67,6 +68,35 @@ func TestDatadir(t *testing.T) {
 	})
 }
 
+func TestL1RPC(t *testing.T) {
+	t.Run("Optional", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Empty(t, cfg.L1RPC)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		url := "http://example.com:8545"
+		cfg := configForArgs(t, addRequiredArgs("--l1-rpc", url))
+		require.Equal(t, url, cfg.L1RPC)
+	})
+}
+
+func TestMessageExpiryWindow(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, config.DefaultMessageExpiryWindow, cfg.MessageExpiryWindow)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs("--message-expiry-window", "24h"))
+		require.Equal(t, 24*time.Hour, cfg.MessageExpiryWindow)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, config.ErrInvalidMessageExpiryWindow.Error(), addRequiredArgs("--message-expiry-window", "0s"))
+	})
+}
+
 func TestMockRun(t *testing.T) {
 	t.Run("Valid", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs("--mock-run"))
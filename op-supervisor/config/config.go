@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"time"
 
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -12,8 +13,13 @@ import (
 var (
 	ErrMissingL2RPC   = errors.New("must specify at least one L2 RPC")
 	ErrMissingDatadir = errors.New("must specify datadir")
+
+	ErrInvalidMessageExpiryWindow = errors.New("message expiry window must be at least one second")
 )
 
+// DefaultMessageExpiryWindow is the expiry window of initiating messages of the interop specification.
+const DefaultMessageExpiryWindow = 180 * 24 * time.Hour
+
 type Config struct {
 	Version string
 
@@ -27,6 +33,12 @@ type Config struct {
 
 	L2RPCs  []string
 	Datadir string
+
+	// L1RPC is the optional L1 RPC, to finalize the blocks derived from finalized L1 blocks
+	L1RPC string
+
+	// MessageExpiryWindow is the time during which an initiating message can be executed
+	MessageExpiryWindow time.Duration
 }
 
 func (c *Config) Check() error {
@@ -40,6 +52,9 @@ func (c *Config) Check() error {
 	if c.Datadir == "" {
 		result = errors.Join(result, ErrMissingDatadir)
 	}
+	if c.MessageExpiryWindow < time.Second {
+		result = errors.Join(result, ErrInvalidMessageExpiryWindow)
+	}
 	return result
 }
 
@@ -54,5 +69,7 @@ func NewConfig(l2RPCs []string, datadir string) *Config {
 		MockRun:       false,
 		L2RPCs:        l2RPCs,
 		Datadir:       datadir,
+
+		MessageExpiryWindow: DefaultMessageExpiryWindow,
 	}
 }
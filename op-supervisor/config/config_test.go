@@ -26,6 +26,12 @@ func TestRequireDatadir(t *testing.T) {
 	require.ErrorIs(t, cfg.Check(), ErrMissingDatadir)
 }
 
+func TestRequireMessageExpiryWindow(t *testing.T) {
+	cfg := validConfig()
+	cfg.MessageExpiryWindow = 0
+	require.ErrorIs(t, cfg.Check(), ErrInvalidMessageExpiryWindow)
+}
+
 func TestValidateMetricsConfig(t *testing.T) {
 	cfg := validConfig()
 	cfg.MetricsConfig.Enabled = true
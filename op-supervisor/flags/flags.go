@@ -30,6 +30,17 @@ var (
 		Usage:   "Directory to store data generated as part of responding to games",
 		EnvVars: prefixEnvVars("DATADIR"),
 	}
+	L1RPCFlag = &cli.StringFlag{
+		Name:    "l1-rpc",
+		Usage:   "L1 RPC source, to finalize the L2 blocks derived from finalized L1 blocks.",
+		EnvVars: prefixEnvVars("L1_RPC"),
+	}
+	MessageExpiryWindowFlag = &cli.DurationFlag{
+		Name:    "message-expiry-window",
+		Usage:   "Time during which an initiating message can be executed. Executing messages of expired messages are rejected.",
+		EnvVars: prefixEnvVars("MESSAGE_EXPIRY_WINDOW"),
+		Value:   config.DefaultMessageExpiryWindow,
+	}
 	MockRunFlag = &cli.BoolFlag{
 		Name:    "mock-run",
 		Usage:   "Mock run, no actual backend used, just presenting the service",
@@ -44,6 +55,8 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	L1RPCFlag,
+	MessageExpiryWindowFlag,
 	MockRunFlag,
 }
 
@@ -79,5 +92,8 @@ func ConfigFromCLI(ctx *cli.Context, version string) *config.Config {
 		MockRun:       ctx.Bool(MockRunFlag.Name),
 		L2RPCs:        ctx.StringSlice(L2RPCsFlag.Name),
 		Datadir:       ctx.Path(DataDirFlag.Name),
+		L1RPC:         ctx.String(L1RPCFlag.Name),
+
+		MessageExpiryWindow: ctx.Duration(MessageExpiryWindowFlag.Name),
 	}
 }
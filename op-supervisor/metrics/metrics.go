@@ -21,6 +21,8 @@ type Metricer interface {
 	RecordDBEntryCount(chainID types.ChainID, count int64)
 	RecordDBSearchEntriesRead(chainID types.ChainID, count int64)
 
+	RecordRejectedMessage(chainID types.ChainID, reason string)
+
 	Document() []opmetrics.DocumentedMetric
 }
 
@@ -38,6 +40,8 @@ type Metrics struct {
 	DBEntryCountVec        *prometheus.GaugeVec
 	DBSearchEntriesReadVec *prometheus.HistogramVec
 
+	RejectedMessagesVec *prometheus.CounterVec
+
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
 }
@@ -118,6 +122,15 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"chain",
 		}),
+
+		RejectedMessagesVec: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "rejected_messages_total",
+			Help:      "Number of executing messages rejected by chain ID and reason",
+		}, []string{
+			"chain",
+			"reason",
+		}),
 	}
 }
 
@@ -167,6 +180,10 @@ func (m *Metrics) RecordDBSearchEntriesRead(chainID types.ChainID, count int64)
 	m.DBSearchEntriesReadVec.WithLabelValues(chainIDLabel(chainID)).Observe(float64(count))
 }
 
+func (m *Metrics) RecordRejectedMessage(chainID types.ChainID, reason string) {
+	m.RejectedMessagesVec.WithLabelValues(chainIDLabel(chainID), reason).Inc()
+}
+
 func chainIDLabel(chainID types.ChainID) string {
 	return chainID.String()
 }
@@ -5,30 +5,43 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"path/filepath"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-supervisor/config"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/heads"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/source"
+	backendTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/types"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/frontend"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// finalityPollInterval is the interval at which the finalized L1 block is polled,
+// to finalize the local-safe blocks derived from it.
+const finalityPollInterval = 30 * time.Second
+
 type SupervisorBackend struct {
 	started atomic.Bool
 	logger  log.Logger
 
 	chainMonitors []*source.ChainMonitor
 	db            *db.ChainsDB
+
+	// l1 is the optional source of L1 finality
+	l1          eth.L1BlockRefsSource
+	finalitySub ethereum.Subscription
 }
 
 var _ frontend.Backend = (*SupervisorBackend)(nil)
@@ -65,6 +78,8 @@ func NewSupervisorBackend(ctx context.Context, logger log.Logger, m Metrics, cfg
 		chainClients[chainID] = rpcClient
 	}
 	chainsDB := db.NewChainsDB(logDBs, headTracker)
+	chainsDB.SetMessageExpiryWindow(uint64(cfg.MessageExpiryWindow / time.Second))
+	chainsDB.SetMetrics(m)
 	if err := chainsDB.Resume(); err != nil {
 		return nil, fmt.Errorf("failed to resume chains db: %w", err)
 	}
@@ -78,10 +93,21 @@ func NewSupervisorBackend(ctx context.Context, logger log.Logger, m Metrics, cfg
 		}
 		chainMonitors = append(chainMonitors, monitor)
 	}
+	var l1 eth.L1BlockRefsSource
+	if cfg.L1RPC != "" {
+		l1Client, err := createL1Client(ctx, logger, cfg.L1RPC)
+		if err != nil {
+			return nil, err
+		}
+		l1 = l1Client
+	} else {
+		logger.Warn("No L1 RPC configured, blocks will not be finalized")
+	}
 	return &SupervisorBackend{
 		logger:        logger,
 		chainMonitors: chainMonitors,
 		db:            chainsDB,
+		l1:            l1,
 	}, nil
 }
 
@@ -97,6 +123,19 @@ func createRpcClient(ctx context.Context, logger log.Logger, rpc string) (client
 	return client.NewBaseRPCClient(ethClient.Client()), types.ChainIDFromBig(chainID), nil
 }
 
+func createL1Client(ctx context.Context, logger log.Logger, rpc string) (*sources.L1Client, error) {
+	ethClient, err := dial.DialEthClientWithTimeout(ctx, 10*time.Second, logger, rpc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to L1 rpc %v: %w", rpc, err)
+	}
+	l1Client, err := sources.NewL1Client(client.NewBaseRPCClient(ethClient.Client()), logger, nil,
+		sources.L1ClientSimpleConfig(false, sources.RPCKindStandard, 100))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create L1 client: %w", err)
+	}
+	return l1Client, nil
+}
+
 func (su *SupervisorBackend) Start(ctx context.Context) error {
 	if !su.started.CompareAndSwap(false, true) {
 		return errors.New("already started")
@@ -109,13 +148,26 @@ func (su *SupervisorBackend) Start(ctx context.Context) error {
 	}
 	// start db maintenance loop
 	su.db.StartCrossHeadMaintenance(ctx)
+	if su.l1 != nil {
+		su.finalitySub = eth.PollBlockChanges(su.logger, su.l1, su.onFinalizedL1, eth.Finalized,
+			finalityPollInterval, time.Second*10)
+	}
 	return nil
 }
 
+func (su *SupervisorBackend) onFinalizedL1(ctx context.Context, finalized eth.L1BlockRef) {
+	if err := su.db.UpdateFinalizedL1(finalized.ID()); err != nil {
+		su.logger.Error("Failed to update finalized blocks", "l1", finalized, "err", err)
+	}
+}
+
 func (su *SupervisorBackend) Stop(ctx context.Context) error {
 	if !su.started.CompareAndSwap(true, false) {
 		return errors.New("already stopped")
 	}
+	if su.finalitySub != nil {
+		su.finalitySub.Unsubscribe()
+	}
 	var errs error
 	for _, monitor := range su.chainMonitors {
 		if err := monitor.Stop(); err != nil {
@@ -134,11 +186,29 @@ func (su *SupervisorBackend) Close() error {
 }
 
 func (su *SupervisorBackend) CheckMessage(identifier types.Identifier, payloadHash common.Hash) (types.SafetyLevel, error) {
-	// TODO(protocol-quest#288): hook up to logdb lookup
-	return types.CrossUnsafe, nil
+	if identifier.LogIndex > math.MaxUint32 {
+		return types.Invalid, nil
+	}
+	logHash := backendTypes.PayloadHashToLogHash(payloadHash, identifier.Origin)
+	return su.db.CheckMessage(identifier.ChainID, identifier.BlockNumber, uint32(identifier.LogIndex), logHash)
 }
 
 func (su *SupervisorBackend) CheckBlock(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) (types.SafetyLevel, error) {
-	// TODO(protocol-quest#288): hook up to logdb lookup
-	return types.CrossUnsafe, nil
+	return su.db.CheckBlock(types.ChainID(*chainID), eth.BlockID{Hash: blockHash, Number: uint64(blockNumber)})
+}
+
+func (su *SupervisorBackend) CrossSafe(chainID *hexutil.U256) (eth.BlockID, error) {
+	return su.db.CrossSafe(types.ChainID(*chainID))
+}
+
+func (su *SupervisorBackend) Finalized(chainID *hexutil.U256) (eth.BlockID, error) {
+	return su.db.Finalized(types.ChainID(*chainID))
+}
+
+func (su *SupervisorBackend) UpdateLocalUnsafe(chainID *hexutil.U256, head eth.L2BlockRef) error {
+	return su.db.UpdateLocalUnsafe(types.ChainID(*chainID), head.ID())
+}
+
+func (su *SupervisorBackend) UpdateLocalSafe(chainID *hexutil.U256, derivedFrom eth.L1BlockRef, lastDerived eth.L2BlockRef) error {
+	return su.db.UpdateLocalSafe(types.ChainID(*chainID), derivedFrom.ID(), lastDerived.ID())
 }
@@ -12,6 +12,8 @@ type Metrics interface {
 
 	RecordDBEntryCount(chainID types.ChainID, count int64)
 	RecordDBSearchEntriesRead(chainID types.ChainID, count int64)
+
+	RecordRejectedMessage(chainID types.ChainID, reason string)
 }
 
 // chainMetrics is an adapter between the metrics API expected by clients that assume there's only a single chain
@@ -0,0 +1,338 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/heads"
+	backendTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/types"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+var (
+	// ErrFuture is returned when a block is referenced that has not been indexed yet.
+	ErrFuture = errors.New("block not indexed yet")
+	// ErrNotFound is returned when no block is known at the requested safety level.
+	ErrNotFound = errors.New("not found")
+)
+
+// maxSafeBlocks bounds the number of local-safe blocks tracked per chain,
+// for when the local-safe blocks are not pruned by L1 finality.
+const maxSafeBlocks = 10_000
+
+// trackedBlock is a block that was reported as local head,
+// along with the index of the last log entry at or before the block.
+type trackedBlock struct {
+	id          eth.BlockID
+	derivedFrom eth.BlockID
+	lastLog     entrydb.EntryIdx
+}
+
+// chainBlocks tracks the local heads reported for a chain.
+// The heads of the ChainsDB are log entry indices, which can not identify a block by themselves:
+// blocks without logs have no entries, and block hashes are only recorded at search checkpoints.
+// The reported blocks are used to translate the entry-based cross-heads back into blocks.
+type chainBlocks struct {
+	mu sync.RWMutex
+	// sealed is the number of the last block of which all logs were recorded
+	sealed uint64
+	unsafe trackedBlock
+	// safe holds the reported local-safe blocks in ascending order
+	safe      []trackedBlock
+	finalized trackedBlock
+	// messages tracks the executed messages, to reject expired and duplicate executing messages
+	messages *chainMessages
+}
+
+// lastSafeWithin returns the last local-safe block, at or before maxNum, of which all logs are at or before entryIdx.
+func (c *chainBlocks) lastSafeWithin(entryIdx entrydb.EntryIdx, maxNum uint64) (trackedBlock, bool) {
+	for i := len(c.safe) - 1; i >= 0; i-- {
+		b := c.safe[i]
+		if b.id.Number <= maxNum && b.lastLog <= entryIdx {
+			return b, true
+		}
+	}
+	return trackedBlock{}, false
+}
+
+// pruneSafe drops the local-safe blocks before the given block number.
+func (c *chainBlocks) pruneSafe(number uint64) {
+	i := 0
+	for i < len(c.safe) && c.safe[i].id.Number < number {
+		i++
+	}
+	c.safe = c.safe[i:]
+}
+
+func (db *ChainsDB) chain(chain types.ChainID) (LogStorage, *chainBlocks, error) {
+	logDB, ok := db.logDBs[chain]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %v", ErrUnknownChain, chain)
+	}
+	return logDB, db.blocks[chain], nil
+}
+
+// SealBlock marks the given block as fully indexed: all its logs, if any, have been recorded.
+func (db *ChainsDB) SealBlock(chain types.ChainID, block eth.BlockID) error {
+	_, blocks, err := db.chain(chain)
+	if err != nil {
+		return err
+	}
+	blocks.mu.Lock()
+	defer blocks.mu.Unlock()
+	if block.Number > blocks.sealed {
+		blocks.sealed = block.Number
+	}
+	return nil
+}
+
+// lastLogOf returns the index of the last log entry at or before the given indexed block.
+func lastLogOf(logDB LogStorage, blocks *chainBlocks, block eth.BlockID) (entrydb.EntryIdx, error) {
+	if block.Number > blocks.sealed {
+		return 0, fmt.Errorf("%w: block %v, indexed up to %v", ErrFuture, block, blocks.sealed)
+	}
+	idx, err := logDB.LastLogAtOrBefore(block.Number)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find last log of block %v: %w", block, err)
+	}
+	return idx, nil
+}
+
+// UpdateLocalUnsafe updates the local-unsafe head of the chain to the given block.
+// If the head moved backwards, the cross-unsafe head is moved back with it.
+func (db *ChainsDB) UpdateLocalUnsafe(chain types.ChainID, head eth.BlockID) error {
+	logDB, blocks, err := db.chain(chain)
+	if err != nil {
+		return err
+	}
+	blocks.mu.Lock()
+	defer blocks.mu.Unlock()
+	idx, err := lastLogOf(logDB, blocks, head)
+	if err != nil {
+		return err
+	}
+	err = db.heads.Apply(heads.OperationFn(func(h *heads.Heads) error {
+		chainHeads := h.Get(chain)
+		chainHeads.Unsafe = idx
+		chainHeads.CrossUnsafe = min(chainHeads.CrossUnsafe, idx)
+		h.Put(chain, chainHeads)
+		return nil
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to update local-unsafe head of chain %v: %w", chain, err)
+	}
+	blocks.unsafe = trackedBlock{id: head, lastLog: idx}
+	return nil
+}
+
+// UpdateLocalSafe updates the local-safe head of the chain to the given block, derived from the given L1 block.
+// If the head moved backwards, the cross-safe head is moved back with it.
+func (db *ChainsDB) UpdateLocalSafe(chain types.ChainID, derivedFrom eth.BlockID, lastDerived eth.BlockID) error {
+	logDB, blocks, err := db.chain(chain)
+	if err != nil {
+		return err
+	}
+	blocks.mu.Lock()
+	defer blocks.mu.Unlock()
+	if blocks.finalized != (trackedBlock{}) && lastDerived.Number < blocks.finalized.id.Number {
+		return fmt.Errorf("local-safe block %v is before finalized block %v", lastDerived, blocks.finalized.id)
+	}
+	idx, err := lastLogOf(logDB, blocks, lastDerived)
+	if err != nil {
+		return err
+	}
+	err = db.heads.Apply(heads.OperationFn(func(h *heads.Heads) error {
+		chainHeads := h.Get(chain)
+		chainHeads.LocalSafe = idx
+		chainHeads.CrossSafe = min(chainHeads.CrossSafe, idx)
+		h.Put(chain, chainHeads)
+		return nil
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to update local-safe head of chain %v: %w", chain, err)
+	}
+	// drop any previous blocks that were replaced by the new local-safe block
+	n := len(blocks.safe)
+	for n > 0 && blocks.safe[n-1].id.Number >= lastDerived.Number {
+		n--
+	}
+	blocks.safe = append(blocks.safe[:n], trackedBlock{id: lastDerived, derivedFrom: derivedFrom, lastLog: idx})
+	if len(blocks.safe) > maxSafeBlocks {
+		blocks.safe = blocks.safe[len(blocks.safe)-maxSafeBlocks:]
+	}
+	return nil
+}
+
+// UpdateFinalizedL1 updates the local-finalized head of each chain,
+// to the last local-safe block that was derived from the given finalized L1 block or before.
+func (db *ChainsDB) UpdateFinalizedL1(finalized eth.BlockID) error {
+	for chain, blocks := range db.blocks {
+		if err := db.updateLocalFinalized(chain, blocks, finalized); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (db *ChainsDB) updateLocalFinalized(chain types.ChainID, blocks *chainBlocks, finalizedL1 eth.BlockID) error {
+	blocks.mu.Lock()
+	defer blocks.mu.Unlock()
+	var finalized trackedBlock
+	for _, b := range blocks.safe {
+		if b.derivedFrom.Number > finalizedL1.Number {
+			break
+		}
+		finalized = b
+	}
+	if finalized == (trackedBlock{}) || finalized.id.Number <= blocks.finalized.id.Number {
+		return nil
+	}
+	err := db.heads.Apply(heads.OperationFn(func(h *heads.Heads) error {
+		chainHeads := h.Get(chain)
+		chainHeads.LocalFinalized = finalized.lastLog
+		h.Put(chain, chainHeads)
+		return nil
+	}))
+	if err != nil {
+		return fmt.Errorf("failed to update local-finalized head of chain %v: %w", chain, err)
+	}
+	blocks.finalized = finalized
+	// The blocks before the cross-finalized block are no longer needed
+	crossFinalized := db.heads.Current().Get(chain).CrossFinalized
+	if b, ok := blocks.lastSafeWithin(crossFinalized, finalized.id.Number); ok {
+		blocks.pruneSafe(b.id.Number)
+	}
+	return nil
+}
+
+// CrossSafe returns the last local-safe block of the chain of which all executing messages are cross-safe.
+func (db *ChainsDB) CrossSafe(chain types.ChainID) (eth.BlockID, error) {
+	_, blocks, err := db.chain(chain)
+	if err != nil {
+		return eth.BlockID{}, err
+	}
+	blocks.mu.RLock()
+	defer blocks.mu.RUnlock()
+	crossSafe := db.heads.Current().Get(chain).CrossSafe
+	b, ok := blocks.lastSafeWithin(crossSafe, ^uint64(0))
+	if !ok {
+		return eth.BlockID{}, fmt.Errorf("%w: no cross-safe block of chain %v", ErrNotFound, chain)
+	}
+	return b.id, nil
+}
+
+// Finalized returns the last local-finalized block of the chain of which all executing messages are finalized.
+func (db *ChainsDB) Finalized(chain types.ChainID) (eth.BlockID, error) {
+	_, blocks, err := db.chain(chain)
+	if err != nil {
+		return eth.BlockID{}, err
+	}
+	blocks.mu.RLock()
+	defer blocks.mu.RUnlock()
+	if blocks.finalized == (trackedBlock{}) {
+		return eth.BlockID{}, fmt.Errorf("%w: no finalized block of chain %v", ErrNotFound, chain)
+	}
+	crossFinalized := db.heads.Current().Get(chain).CrossFinalized
+	b, ok := blocks.lastSafeWithin(crossFinalized, blocks.finalized.id.Number)
+	if !ok {
+		return eth.BlockID{}, fmt.Errorf("%w: no cross-finalized block of chain %v", ErrNotFound, chain)
+	}
+	return b.id, nil
+}
+
+// CheckBlock returns the safety level of the given block.
+// A block is invalid if it executes a message that was not initiated on the referenced chain,
+// once that chain has indexed the block the message refers to,
+// or if it executes a message that is expired, from the future, or already executed on the chain.
+func (db *ChainsDB) CheckBlock(chain types.ChainID, block eth.BlockID) (types.SafetyLevel, error) {
+	logDB, blocks, err := db.chain(chain)
+	if err != nil {
+		return types.Unsafe, err
+	}
+	blocks.mu.RLock()
+	idx, err := lastLogOf(logDB, blocks, block)
+	rejected := blocks.messages.isRejected(block.Number)
+	blocks.mu.RUnlock()
+	if errors.Is(err, ErrFuture) {
+		return types.Unsafe, nil
+	} else if err != nil {
+		return types.Unsafe, err
+	}
+	if rejected {
+		return types.Invalid, nil
+	}
+	msgs, err := logDB.ExecutingMessages(block.Number)
+	if err != nil {
+		return types.Unsafe, fmt.Errorf("failed to read executing messages of block %v: %w", block, err)
+	}
+	for _, msg := range msgs {
+		valid, err := db.checkInitiated(msg)
+		if err != nil {
+			return types.Unsafe, err
+		}
+		if !valid {
+			return types.Invalid, nil
+		}
+	}
+	return db.safetyOf(chain, block.Number, idx), nil
+}
+
+// CheckMessage returns the safety level of the log with the given hash, at the given block number and log index.
+// A message is invalid if the chain indexed the block, but the log is not there.
+func (db *ChainsDB) CheckMessage(chain types.ChainID, blockNum uint64, logIdx uint32, logHash backendTypes.TruncatedHash) (types.SafetyLevel, error) {
+	logDB, blocks, err := db.chain(chain)
+	if err != nil {
+		return types.Unsafe, err
+	}
+	blocks.mu.RLock()
+	sealed := blocks.sealed
+	blocks.mu.RUnlock()
+	if blockNum > sealed {
+		return types.Unsafe, nil
+	}
+	exists, idx, err := logDB.Contains(blockNum, logIdx, logHash)
+	if err != nil {
+		return types.Unsafe, fmt.Errorf("failed to check log %v of block %v: %w", logIdx, blockNum, err)
+	}
+	if !exists {
+		return types.Invalid, nil
+	}
+	return db.safetyOf(chain, blockNum, idx), nil
+}
+
+// checkInitiated returns false if the initiating message of the executing message is known to not exist.
+func (db *ChainsDB) checkInitiated(msg backendTypes.ExecutingMessage) (bool, error) {
+	initChain := types.ChainIDFromUInt64(uint64(msg.Chain))
+	if _, ok := db.logDBs[initChain]; !ok {
+		// messages can only be initiated by chains in the dependency set
+		return false, nil
+	}
+	level, err := db.CheckMessage(initChain, msg.BlockNum, msg.LogIdx, msg.Hash)
+	if err != nil {
+		return false, fmt.Errorf("failed to check initiating message of chain %v: %w", initChain, err)
+	}
+	return level != types.Invalid, nil
+}
+
+// safetyOf returns the safety level of the log entry, or of the block without logs at or after it.
+func (db *ChainsDB) safetyOf(chain types.ChainID, blockNum uint64, entryIdx entrydb.EntryIdx) types.SafetyLevel {
+	blocks := db.blocks[chain]
+	blocks.mu.RLock()
+	defer blocks.mu.RUnlock()
+	h := db.heads.Current().Get(chain)
+	if blocks.finalized != (trackedBlock{}) {
+		if b, ok := blocks.lastSafeWithin(h.CrossFinalized, blocks.finalized.id.Number); ok && blockNum <= b.id.Number {
+			return types.Finalized
+		}
+	}
+	if b, ok := blocks.lastSafeWithin(h.CrossSafe, ^uint64(0)); ok && blockNum <= b.id.Number {
+		return types.Safe
+	}
+	if blockNum <= blocks.unsafe.id.Number && entryIdx <= h.CrossUnsafe {
+		return types.CrossUnsafe
+	}
+	return types.Unsafe
+}
@@ -0,0 +1,209 @@
+package db
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/heads"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
+	backendTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/types"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+var (
+	chainA = types.ChainIDFromUInt64(1)
+	chainB = types.ChainIDFromUInt64(2)
+
+	initHash = backendTypes.TruncatedHash{0xaa}
+
+	blockA1 = eth.BlockID{Hash: common.Hash{0xa1}, Number: 1}
+	blockA2 = eth.BlockID{Hash: common.Hash{0xa2}, Number: 2}
+	blockB1 = eth.BlockID{Hash: common.Hash{0xb1}, Number: 1}
+	blockB2 = eth.BlockID{Hash: common.Hash{0xb2}, Number: 2}
+
+	l1Block1 = eth.BlockID{Hash: common.Hash{0x11}, Number: 10}
+	l1Block2 = eth.BlockID{Hash: common.Hash{0x12}, Number: 11}
+)
+
+// setupChainsDB creates a ChainsDB with two chains:
+// block A1 initiates a message, A2 has no logs,
+// block B1 executes the message of A1, and B2 executes a message that was never initiated.
+func setupChainsDB(t *testing.T) *ChainsDB {
+	db := newTestChainsDB(t, t.TempDir())
+
+	require.NoError(t, db.AddLog(chainA, initHash, blockA1, 100, 0, nil))
+	require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb1}, blockB1, 102, 0, &backendTypes.ExecutingMessage{
+		Chain:     1,
+		BlockNum:  blockA1.Number,
+		LogIdx:    0,
+		Timestamp: 100,
+		Hash:      initHash,
+	}))
+	require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb2}, blockB2, 104, 0, &backendTypes.ExecutingMessage{
+		Chain:     1,
+		BlockNum:  blockA1.Number,
+		LogIdx:    5,
+		Timestamp: 100,
+		Hash:      initHash,
+	}))
+	return db
+}
+
+// newTestChainsDB creates a ChainsDB with chains A and B, stored in the given directory.
+func newTestChainsDB(t *testing.T, dir string) *ChainsDB {
+	logger := testlog.Logger(t, log.LvlInfo)
+	logDBs := make(map[types.ChainID]LogStorage)
+	for _, chain := range []types.ChainID{chainA, chainB} {
+		logDB, err := logs.NewFromFile(logger, &stubMetrics{}, filepath.Join(dir, chain.String()+".db"))
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_ = logDB.Close()
+		})
+		logDBs[chain] = logDB
+	}
+	headTracker, err := heads.NewHeadTracker(filepath.Join(dir, "heads.json"))
+	require.NoError(t, err)
+	return NewChainsDB(logDBs, headTracker)
+}
+
+func sealAll(t *testing.T, db *ChainsDB) {
+	require.NoError(t, db.SealBlock(chainA, blockA1))
+	require.NoError(t, db.SealBlock(chainA, blockA2))
+	require.NoError(t, db.SealBlock(chainB, blockB1))
+	require.NoError(t, db.SealBlock(chainB, blockB2))
+}
+
+func TestChainsDB_LocalHeadsRequireIndexedBlocks(t *testing.T) {
+	db := setupChainsDB(t)
+	require.ErrorIs(t, db.UpdateLocalUnsafe(chainA, blockA1), ErrFuture)
+	require.ErrorIs(t, db.UpdateLocalSafe(chainA, l1Block1, blockA1), ErrFuture)
+	require.ErrorIs(t, db.UpdateLocalUnsafe(types.ChainIDFromUInt64(3), blockA1), ErrUnknownChain)
+
+	level, err := db.CheckBlock(chainB, blockB1)
+	require.NoError(t, err)
+	require.Equal(t, types.Unsafe, level)
+
+	require.NoError(t, db.SealBlock(chainA, blockA1))
+	require.NoError(t, db.UpdateLocalUnsafe(chainA, blockA1))
+	require.ErrorIs(t, db.UpdateLocalUnsafe(chainA, blockA2), ErrFuture)
+}
+
+func TestChainsDB_CrossSafe(t *testing.T) {
+	db := setupChainsDB(t)
+	sealAll(t, db)
+
+	// B1 can not be cross-safe while the initiating message is not locally safe yet
+	require.NoError(t, db.UpdateLocalSafe(chainB, l1Block1, blockB1))
+	require.NoError(t, db.UpdateCrossSafeHeads())
+	_, err := db.CrossSafe(chainB)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, db.UpdateLocalSafe(chainA, l1Block1, blockA2))
+	require.NoError(t, db.UpdateCrossSafeHeads())
+	crossSafe, err := db.CrossSafe(chainA)
+	require.NoError(t, err)
+	require.Equal(t, blockA2, crossSafe)
+	crossSafe, err = db.CrossSafe(chainB)
+	require.NoError(t, err)
+	require.Equal(t, blockB1, crossSafe)
+
+	level, err := db.CheckBlock(chainB, blockB1)
+	require.NoError(t, err)
+	require.Equal(t, types.Safe, level)
+	level, err = db.CheckMessage(chainA, blockA1.Number, 0, initHash)
+	require.NoError(t, err)
+	require.Equal(t, types.Safe, level)
+
+	// B2 stays behind, as its executing message can never be satisfied
+	require.NoError(t, db.UpdateLocalSafe(chainB, l1Block2, blockB2))
+	require.NoError(t, db.UpdateCrossSafeHeads())
+	crossSafe, err = db.CrossSafe(chainB)
+	require.NoError(t, err)
+	require.Equal(t, blockB1, crossSafe)
+}
+
+func TestChainsDB_CheckInvalid(t *testing.T) {
+	db := setupChainsDB(t)
+	sealAll(t, db)
+
+	level, err := db.CheckBlock(chainB, blockB2)
+	require.NoError(t, err)
+	require.Equal(t, types.Invalid, level)
+
+	level, err = db.CheckMessage(chainA, blockA1.Number, 0, backendTypes.TruncatedHash{0xbb})
+	require.NoError(t, err)
+	require.Equal(t, types.Invalid, level)
+
+	// Messages can not be checked before the block is indexed
+	level, err = db.CheckMessage(chainA, 3, 0, initHash)
+	require.NoError(t, err)
+	require.Equal(t, types.Unsafe, level)
+}
+
+func TestChainsDB_CrossUnsafe(t *testing.T) {
+	db := setupChainsDB(t)
+	sealAll(t, db)
+
+	require.NoError(t, db.UpdateLocalUnsafe(chainA, blockA2))
+	require.NoError(t, db.UpdateLocalUnsafe(chainB, blockB1))
+	require.NoError(t, db.UpdateCrossHeads(NewSafetyChecker(Unsafe, *db)))
+
+	level, err := db.CheckBlock(chainB, blockB1)
+	require.NoError(t, err)
+	require.Equal(t, types.CrossUnsafe, level)
+	level, err = db.CheckBlock(chainA, blockA2)
+	require.NoError(t, err)
+	require.Equal(t, types.CrossUnsafe, level)
+}
+
+func TestChainsDB_Finalized(t *testing.T) {
+	db := setupChainsDB(t)
+	sealAll(t, db)
+	finalizedChecker := NewSafetyChecker(Finalized, *db)
+
+	_, err := db.Finalized(chainA)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, db.UpdateLocalSafe(chainA, l1Block1, blockA1))
+	require.NoError(t, db.UpdateLocalSafe(chainA, l1Block2, blockA2))
+	require.NoError(t, db.UpdateLocalSafe(chainB, l1Block1, blockB1))
+	require.NoError(t, db.UpdateCrossSafeHeads())
+
+	// Only the blocks derived from the finalized L1 block are finalized
+	require.NoError(t, db.UpdateFinalizedL1(l1Block1))
+	require.NoError(t, db.UpdateCrossHeads(finalizedChecker))
+	finalized, err := db.Finalized(chainA)
+	require.NoError(t, err)
+	require.Equal(t, blockA1, finalized)
+	finalized, err = db.Finalized(chainB)
+	require.NoError(t, err)
+	require.Equal(t, blockB1, finalized)
+
+	level, err := db.CheckBlock(chainB, blockB1)
+	require.NoError(t, err)
+	require.Equal(t, types.Finalized, level)
+	level, err = db.CheckBlock(chainA, blockA2)
+	require.NoError(t, err)
+	require.Equal(t, types.Safe, level)
+
+	require.NoError(t, db.UpdateFinalizedL1(l1Block2))
+	require.NoError(t, db.UpdateCrossHeads(finalizedChecker))
+	finalized, err = db.Finalized(chainA)
+	require.NoError(t, err)
+	require.Equal(t, blockA2, finalized)
+
+	// The local-safe head can not move back before the finalized block
+	require.Error(t, db.UpdateLocalSafe(chainA, l1Block1, blockA1))
+}
+
+type stubMetrics struct{}
+
+func (s *stubMetrics) RecordDBEntryCount(count int64) {}
+
+func (s *stubMetrics) RecordDBSearchEntriesRead(count int64) {}
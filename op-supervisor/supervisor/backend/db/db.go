@@ -25,6 +25,8 @@ type LogStorage interface {
 	AddLog(logHash backendTypes.TruncatedHash, block eth.BlockID, timestamp uint64, logIdx uint32, execMsg *backendTypes.ExecutingMessage) error
 	Rewind(newHeadBlockNum uint64) error
 	LatestBlockNum() uint64
+	LastLogAtOrBefore(blockNum uint64) (entrydb.EntryIdx, error)
+	ExecutingMessages(blockNum uint64) ([]backendTypes.ExecutingMessage, error)
 	ClosestBlockInfo(blockNum uint64) (uint64, backendTypes.TruncatedHash, error)
 	Contains(blockNum uint64, logIdx uint32, loghash backendTypes.TruncatedHash) (bool, entrydb.EntryIdx, error)
 	LastCheckpointBehind(entrydb.EntryIdx) (logs.Iterator, error)
@@ -41,15 +43,39 @@ type HeadsStorage interface {
 type ChainsDB struct {
 	logDBs map[types.ChainID]LogStorage
 	heads  HeadsStorage
+	blocks map[types.ChainID]*chainBlocks
+
+	// messageExpiryWindow is the time, in seconds, during which an initiating message can be executed
+	messageExpiryWindow uint64
+	metrics             Metrics
 }
 
 func NewChainsDB(logDBs map[types.ChainID]LogStorage, heads HeadsStorage) *ChainsDB {
+	blocks := make(map[types.ChainID]*chainBlocks)
+	for chain := range logDBs {
+		blocks[chain] = &chainBlocks{messages: newChainMessages()}
+	}
 	return &ChainsDB{
-		logDBs: logDBs,
-		heads:  heads,
+		logDBs:              logDBs,
+		heads:               heads,
+		blocks:              blocks,
+		messageExpiryWindow: DefaultMessageExpiryWindow,
+		metrics:             noopMetrics{},
 	}
 }
 
+// SetMessageExpiryWindow sets the time, in seconds, during which an initiating message can be executed.
+// It must be called before any logs are added.
+func (db *ChainsDB) SetMessageExpiryWindow(window uint64) {
+	db.messageExpiryWindow = window
+}
+
+// SetMetrics sets the metrics to record rejected executing messages with.
+// It must be called before any logs are added.
+func (db *ChainsDB) SetMetrics(m Metrics) {
+	db.metrics = m
+}
+
 // Resume prepares the chains db to resume recording events after a restart.
 // It rewinds the database to the last block that is guaranteed to have been fully recorded to the database
 // to ensure it can resume recording from the first log of the next block.
@@ -58,6 +84,13 @@ func (db *ChainsDB) Resume() error {
 		if err := Resume(logStore); err != nil {
 			return fmt.Errorf("failed to resume chain %v: %w", chain, err)
 		}
+		if err := db.indexExecutions(chain, logStore); err != nil {
+			return fmt.Errorf("failed to index executed messages of chain %v: %w", chain, err)
+		}
+		// the blocks up to the resumed block were fully recorded before the restart
+		if err := db.SealBlock(chain, eth.BlockID{Number: logStore.LatestBlockNum()}); err != nil {
+			return fmt.Errorf("failed to seal resumed block of chain %v: %w", chain, err)
+		}
 	}
 	return nil
 }
@@ -107,6 +140,26 @@ func (db *ChainsDB) UpdateCrossHeadsForChain(chainID types.ChainID, checker Safe
 	xHead := checker.CrossHeadForChain(chainID)
 	// advance as far as the local head
 	localHead := checker.LocalHeadForChain(chainID)
+	// but never into a block with a rejected executing message
+	blocks := db.blocks[chainID]
+	blocks.mu.RLock()
+	rejected, ok := blocks.messages.firstRejected()
+	blocks.mu.RUnlock()
+	if ok {
+		lastValid := entrydb.EntryIdx(-1)
+		if rejected > 0 {
+			idx, err := db.logDBs[chainID].LastLogAtOrBefore(rejected - 1)
+			if err != nil {
+				return fmt.Errorf("failed to find last log before rejected block %v of chain %v: %w", rejected, chainID, err)
+			}
+			lastValid = idx
+		}
+		localHead = min(localHead, lastValid)
+	}
+	if localHead <= xHead {
+		// nothing to promote
+		return nil
+	}
 	// get an iterator for the last checkpoint behind the x-head
 	i, err := db.logDBs[chainID].LastCheckpointBehind(xHead)
 	if err != nil {
@@ -114,18 +167,21 @@ func (db *ChainsDB) UpdateCrossHeadsForChain(chainID types.ChainID, checker Safe
 	}
 	// advance the logDB through all executing messages we can
 	// this loop will break:
-	// - when we reach the local head
+	// - when we reach the local head, or the end of the logs, after which the x-head is the local head
 	// - when we reach a message that is not safe
 	// - if an error occurs
 	for {
 		exec, err := db.logDBs[chainID].NextExecutingMessage(i)
 		if err == io.EOF {
+			// there are no more executing messages up to the local head
+			xHead = localHead
 			break
 		} else if err != nil {
 			return fmt.Errorf("failed to read next executing message for chain %v: %w", chainID, err)
 		}
 		// if we are now beyond the local head, stop
 		if i.Index() > localHead {
+			xHead = localHead
 			break
 		}
 		// use the checker to determine if this message is safe
@@ -177,7 +233,16 @@ func (db *ChainsDB) AddLog(chain types.ChainID, logHash backendTypes.TruncatedHa
 	if !ok {
 		return fmt.Errorf("%w: %v", ErrUnknownChain, chain)
 	}
-	return logDB.AddLog(logHash, block, timestamp, logIdx, execMsg)
+	if err := logDB.AddLog(logHash, block, timestamp, logIdx, execMsg); err != nil {
+		return err
+	}
+	if execMsg != nil {
+		// the log is recorded regardless: a rejected executing message invalidates the block, not the log
+		if reason := db.checkExecution(chain, block.Number, timestamp, logIdx, *execMsg); reason != "" {
+			log.Warn("Rejected executing message", "chain", chain, "block", block, "logIdx", logIdx, "reason", reason)
+		}
+	}
+	return nil
 }
 
 func (db *ChainsDB) Rewind(chain types.ChainID, headBlockNum uint64) error {
@@ -185,7 +250,15 @@ func (db *ChainsDB) Rewind(chain types.ChainID, headBlockNum uint64) error {
 	if !ok {
 		return fmt.Errorf("%w: %v", ErrUnknownChain, chain)
 	}
-	return logDB.Rewind(headBlockNum)
+	if err := logDB.Rewind(headBlockNum); err != nil {
+		return err
+	}
+	blocks := db.blocks[chain]
+	blocks.mu.Lock()
+	defer blocks.mu.Unlock()
+	blocks.sealed = min(blocks.sealed, headBlockNum)
+	blocks.messages.rewind(headBlockNum)
+	return nil
 }
 
 func (db *ChainsDB) Close() error {
@@ -117,10 +117,10 @@ func TestChainsDB_UpdateCrossHeadsEOF(t *testing.T) {
 	// Update cross-heads is expected to:
 	// 1. get a last checkpoint iterator from the logDB (stubbed to be at 15)
 	// 2. after processing 10 messages as safe, fail to find any executing messages (EOF)
-	// 3. update to the last successful safety check (25) without returning an error
+	// 3. update to the local head (40) without returning an error, as there are no more messages to check
 	err := db.UpdateCrossHeads(checker)
 	require.NoError(t, err)
-	require.Equal(t, entrydb.EntryIdx(25), checker.updated)
+	require.Equal(t, entrydb.EntryIdx(40), checker.updated)
 }
 
 func TestChainsDB_UpdateCrossHeadsError(t *testing.T) {
@@ -322,6 +322,14 @@ func (s *stubLogDB) Rewind(newHeadBlockNum uint64) error {
 	return nil
 }
 
+func (s *stubLogDB) LastLogAtOrBefore(blockNum uint64) (entrydb.EntryIdx, error) {
+	panic("not implemented")
+}
+
+func (s *stubLogDB) ExecutingMessages(blockNum uint64) ([]backendTypes.ExecutingMessage, error) {
+	panic("not implemented")
+}
+
 func (s *stubLogDB) LatestBlockNum() uint64 {
 	return s.headBlockNum
 }
@@ -78,6 +78,14 @@ func (s *stubLogStore) AddLog(logHash types.TruncatedHash, block eth.BlockID, ti
 	panic("not supported")
 }
 
+func (s *stubLogStore) LastLogAtOrBefore(blockNum uint64) (entrydb.EntryIdx, error) {
+	panic("not supported")
+}
+
+func (s *stubLogStore) ExecutingMessages(blockNum uint64) ([]types.ExecutingMessage, error) {
+	panic("not supported")
+}
+
 func (s *stubLogStore) LatestBlockNum() uint64 {
 	panic("not supported")
 }
@@ -254,6 +254,89 @@ func (db *DB) Executes(blockNum uint64, logIdx uint32) (types.ExecutingMessage,
 	return execMsg, nil
 }
 
+// LastLogAtOrBefore returns the entry index of the last log recorded at or before the given block number.
+// Returns -1 if no logs were recorded up to and including the block.
+// The entry index can be compared against the entry-based heads, to tell if all logs of the block are within a head.
+func (db *DB) LastLogAtOrBefore(blockNum uint64) (entrydb.EntryIdx, error) {
+	db.rwLock.RLock()
+	defer db.rwLock.RUnlock()
+	if db.lastEntryIdx() < 0 {
+		return -1, nil
+	}
+	entryIdx, err := db.searchCheckpoint(blockNum, math.MaxUint32)
+	if errors.Is(err, io.EOF) {
+		// No checkpoint at or before the block, so there are no logs at or before the block either
+		return -1, nil
+	} else if err != nil {
+		return 0, err
+	}
+	i, err := db.newIterator(entryIdx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer func() {
+		db.m.RecordDBSearchEntriesRead(i.entriesRead)
+	}()
+	last := entrydb.EntryIdx(-1)
+	for {
+		evtBlockNum, _, _, err := i.NextLog()
+		if errors.Is(err, io.EOF) {
+			return last, nil
+		} else if err != nil {
+			return 0, fmt.Errorf("failed to read next log: %w", err)
+		}
+		if evtBlockNum > blockNum {
+			return last, nil
+		}
+		last = i.Index()
+	}
+}
+
+// ExecutingMessages returns the executing messages registered by the logs of the given block, in log order.
+// Returns an empty slice if the block has no executing messages, or if the block is not recorded.
+func (db *DB) ExecutingMessages(blockNum uint64) ([]types.ExecutingMessage, error) {
+	db.rwLock.RLock()
+	defer db.rwLock.RUnlock()
+	var msgs []types.ExecutingMessage
+	if db.lastEntryIdx() < 0 {
+		return msgs, nil
+	}
+	entryIdx, err := db.searchCheckpoint(blockNum, 0)
+	if errors.Is(err, io.EOF) {
+		return msgs, nil
+	} else if err != nil {
+		return nil, err
+	}
+	i, err := db.newIterator(entryIdx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer func() {
+		db.m.RecordDBSearchEntriesRead(i.entriesRead)
+	}()
+	for {
+		evtBlockNum, _, _, err := i.NextLog()
+		if errors.Is(err, io.EOF) {
+			return msgs, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read next log: %w", err)
+		}
+		if evtBlockNum > blockNum {
+			return msgs, nil
+		}
+		if evtBlockNum < blockNum {
+			continue
+		}
+		exec, err := i.ExecMessage()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read executing message: %w", err)
+		}
+		if exec != (types.ExecutingMessage{}) {
+			msgs = append(msgs, exec)
+		}
+	}
+}
+
 func (db *DB) findLogInfo(blockNum uint64, logIdx uint32) (types.TruncatedHash, Iterator, error) {
 	entryIdx, err := db.searchCheckpoint(blockNum, logIdx)
 	if errors.Is(err, io.EOF) {
@@ -537,6 +537,117 @@ func TestGetBlockInfo(t *testing.T) {
 	})
 }
 
+func TestLastLogAtOrBefore(t *testing.T) {
+	execMsg := types.ExecutingMessage{
+		Chain:     33,
+		BlockNum:  22,
+		LogIdx:    99,
+		Timestamp: 948294,
+		Hash:      createTruncatedHash(332299),
+	}
+
+	t.Run("ReturnsNoneWhenEmpty", func(t *testing.T) {
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				requireLastLog(t, db, 10, -1)
+			})
+	})
+
+	t.Run("ReturnsLastInitiatingEvent", func(t *testing.T) {
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				require.NoError(t, db.AddLog(createTruncatedHash(1), eth.BlockID{Hash: createHash(50), Number: 50}, 500, 0, nil))
+				require.NoError(t, db.AddLog(createTruncatedHash(3), eth.BlockID{Hash: createHash(50), Number: 50}, 500, 1, &execMsg))
+				require.NoError(t, db.AddLog(createTruncatedHash(2), eth.BlockID{Hash: createHash(50), Number: 50}, 500, 2, nil))
+				require.NoError(t, db.AddLog(createTruncatedHash(1), eth.BlockID{Hash: createHash(52), Number: 52}, 502, 0, &execMsg))
+			},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				requireLastLog(t, db, 49, -1)
+				// 0 and 1 are the checkpoint and canonical hash, 4 and 5 the executing link and check
+				requireLastLog(t, db, 50, 6)
+				// Blocks without logs extend as far as the previous block with logs
+				requireLastLog(t, db, 51, 6)
+				requireLastLog(t, db, 52, 7)
+				requireLastLog(t, db, 100, 7)
+			})
+	})
+
+	t.Run("SearchAcrossCheckpoints", func(t *testing.T) {
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				for i := 1; i < searchCheckpointFrequency+3; i++ {
+					block := eth.BlockID{Hash: createHash(i), Number: uint64(i)}
+					err := db.AddLog(createTruncatedHash(i), block, uint64(i)*2, 0, nil)
+					require.NoError(t, err)
+				}
+			},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				requireLastLog(t, db, 1, 2)
+				requireLastLog(t, db, searchCheckpointFrequency-2, searchCheckpointFrequency-1)
+				// The second checkpoint and canonical hash come before the log of the next block
+				requireLastLog(t, db, searchCheckpointFrequency-1, searchCheckpointFrequency+2)
+				requireLastLog(t, db, searchCheckpointFrequency, searchCheckpointFrequency+3)
+			})
+	})
+}
+
+func requireLastLog(t *testing.T, db *DB, blockNum uint64, expected entrydb.EntryIdx) {
+	idx, err := db.LastLogAtOrBefore(blockNum)
+	require.NoError(t, err)
+	require.Equal(t, expected, idx)
+}
+
+func TestExecutingMessages(t *testing.T) {
+	execMsg1 := types.ExecutingMessage{
+		Chain:     33,
+		BlockNum:  22,
+		LogIdx:    99,
+		Timestamp: 948294,
+		Hash:      createTruncatedHash(332299),
+	}
+	execMsg2 := types.ExecutingMessage{
+		Chain:     44,
+		BlockNum:  55,
+		LogIdx:    66,
+		Timestamp: 77777,
+		Hash:      createTruncatedHash(445566),
+	}
+	execMsg3 := types.ExecutingMessage{
+		Chain:     77,
+		BlockNum:  88,
+		LogIdx:    89,
+		Timestamp: 6578567,
+		Hash:      createTruncatedHash(778889),
+	}
+	runDBTest(t,
+		func(t *testing.T, db *DB, m *stubMetrics) {
+			require.NoError(t, db.AddLog(createTruncatedHash(1), eth.BlockID{Hash: createHash(50), Number: 50}, 500, 0, nil))
+			require.NoError(t, db.AddLog(createTruncatedHash(3), eth.BlockID{Hash: createHash(50), Number: 50}, 500, 1, &execMsg1))
+			require.NoError(t, db.AddLog(createTruncatedHash(2), eth.BlockID{Hash: createHash(50), Number: 50}, 500, 2, nil))
+			require.NoError(t, db.AddLog(createTruncatedHash(1), eth.BlockID{Hash: createHash(52), Number: 52}, 500, 0, &execMsg2))
+			require.NoError(t, db.AddLog(createTruncatedHash(3), eth.BlockID{Hash: createHash(52), Number: 52}, 500, 1, &execMsg3))
+			require.NoError(t, db.AddLog(createTruncatedHash(4), eth.BlockID{Hash: createHash(53), Number: 53}, 500, 0, nil))
+		},
+		func(t *testing.T, db *DB, m *stubMetrics) {
+			requireExecutingMessages(t, db, 49)
+			requireExecutingMessages(t, db, 50, execMsg1)
+			requireExecutingMessages(t, db, 51)
+			requireExecutingMessages(t, db, 52, execMsg2, execMsg3)
+			requireExecutingMessages(t, db, 53)
+			requireExecutingMessages(t, db, 54)
+		})
+}
+
+func requireExecutingMessages(t *testing.T, db *DB, blockNum uint64, expected ...types.ExecutingMessage) {
+	msgs, err := db.ExecutingMessages(blockNum)
+	require.NoError(t, err)
+	require.Len(t, msgs, len(expected))
+	for i, msg := range expected {
+		require.Equal(t, msg, msgs[i])
+	}
+}
+
 func requireClosestBlockInfo(t *testing.T, db *DB, searchFor uint64, expectedBlockNum uint64, expectedHash common.Hash) {
 	blockNum, hash, err := db.ClosestBlockInfo(searchFor)
 	require.NoError(t, err)
@@ -0,0 +1,193 @@
+package db
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
+	backendTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/types"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+// DefaultMessageExpiryWindow is the time, in seconds, after which an initiating message can no longer be executed.
+const DefaultMessageExpiryWindow = 180 * 24 * 60 * 60
+
+// Reasons for which an executing message is rejected.
+const (
+	// RejectFuture is the reason for messages that execute a message with a later timestamp than the executing block
+	RejectFuture = "future"
+	// RejectExpired is the reason for messages that execute a message after the expiry window
+	RejectExpired = "expired"
+	// RejectDuplicate is the reason for messages that execute a message that was already executed on the chain
+	RejectDuplicate = "duplicate"
+)
+
+// Metrics records the executing messages rejected by the ChainsDB.
+type Metrics interface {
+	RecordRejectedMessage(chainID types.ChainID, reason string)
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) RecordRejectedMessage(_ types.ChainID, _ string) {}
+
+// execution is the log that executed a message.
+type execution struct {
+	blockNum uint64
+	logIdx   uint32
+}
+
+// executionQueue orders the executed messages by the timestamp of their initiating message,
+// to prune the messages that expired.
+type executionQueue []backendTypes.ExecutingMessage
+
+func (q executionQueue) Len() int           { return len(q) }
+func (q executionQueue) Less(i, j int) bool { return q[i].Timestamp < q[j].Timestamp }
+func (q executionQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *executionQueue) Push(x any)        { *q = append(*q, x.(backendTypes.ExecutingMessage)) }
+func (q *executionQueue) Pop() any {
+	old := *q
+	n := len(old)
+	x := old[n-1]
+	*q = old[:n-1]
+	return x
+}
+
+// chainMessages tracks the messages executed by a chain, and the blocks that executed a message in a rejected way.
+// Executed messages are only tracked until they expire: executing them again is rejected as expired by then.
+type chainMessages struct {
+	executions map[backendTypes.ExecutingMessage]execution
+	queue      executionQueue
+	// rejected holds the numbers of the blocks with rejected executing messages, in ascending order
+	rejected []uint64
+}
+
+func newChainMessages() *chainMessages {
+	return &chainMessages{executions: make(map[backendTypes.ExecutingMessage]execution)}
+}
+
+// add records the execution of the message, and returns the previous execution if the message was already executed.
+func (m *chainMessages) add(msg backendTypes.ExecutingMessage, exec execution) (execution, bool) {
+	if prev, ok := m.executions[msg]; ok && prev != exec {
+		return prev, true
+	} else if ok {
+		// the same log was recorded again
+		return execution{}, false
+	}
+	m.executions[msg] = exec
+	heap.Push(&m.queue, msg)
+	return execution{}, false
+}
+
+// prune drops the executed messages that expired before the given timestamp.
+func (m *chainMessages) prune(timestamp uint64, window uint64) {
+	for len(m.queue) > 0 && m.queue[0].Timestamp+window < timestamp {
+		delete(m.executions, heap.Pop(&m.queue).(backendTypes.ExecutingMessage))
+	}
+}
+
+// reject records that the block has a rejected executing message.
+func (m *chainMessages) reject(blockNum uint64) {
+	if n := len(m.rejected); n > 0 && m.rejected[n-1] >= blockNum {
+		return
+	}
+	m.rejected = append(m.rejected, blockNum)
+}
+
+// isRejected returns true if the block has a rejected executing message.
+func (m *chainMessages) isRejected(blockNum uint64) bool {
+	i := sort.Search(len(m.rejected), func(i int) bool { return m.rejected[i] >= blockNum })
+	return i < len(m.rejected) && m.rejected[i] == blockNum
+}
+
+// firstRejected returns the number of the first block with a rejected executing message.
+func (m *chainMessages) firstRejected() (uint64, bool) {
+	if len(m.rejected) == 0 {
+		return 0, false
+	}
+	return m.rejected[0], true
+}
+
+// rewind drops the executions and rejections of the blocks after the new head.
+func (m *chainMessages) rewind(headBlockNum uint64) {
+	for msg, exec := range m.executions {
+		if exec.blockNum > headBlockNum {
+			delete(m.executions, msg)
+		}
+	}
+	m.queue = m.queue[:0]
+	for msg := range m.executions {
+		m.queue = append(m.queue, msg)
+	}
+	heap.Init(&m.queue)
+	i := sort.Search(len(m.rejected), func(i int) bool { return m.rejected[i] > headBlockNum })
+	m.rejected = m.rejected[:i]
+}
+
+// checkExecution checks the executing message, of the log at the given index of the block with the given timestamp.
+// It returns the reason the message is rejected for, or an empty string if the message is not rejected.
+// Messages that execute an initiating message that does not exist are not rejected here: see CheckBlock.
+func (db *ChainsDB) checkExecution(chain types.ChainID, blockNum uint64, timestamp uint64, logIdx uint32, msg backendTypes.ExecutingMessage) string {
+	blocks := db.blocks[chain]
+	blocks.mu.Lock()
+	defer blocks.mu.Unlock()
+	messages := blocks.messages
+	reason := ""
+	if msg.Timestamp > timestamp {
+		reason = RejectFuture
+	} else if timestamp-msg.Timestamp > db.messageExpiryWindow {
+		reason = RejectExpired
+	} else if _, ok := messages.add(msg, execution{blockNum: blockNum, logIdx: logIdx}); ok {
+		reason = RejectDuplicate
+	}
+	messages.prune(timestamp, db.messageExpiryWindow)
+	if reason != "" {
+		messages.reject(blockNum)
+		db.metrics.RecordRejectedMessage(chain, reason)
+	}
+	return reason
+}
+
+// indexExecutions rebuilds the index of the executed messages of a chain from its log db.
+// Executing messages are only checked against the expiry window when they are added:
+// the timestamps of the executing blocks are not stored, so the blocks indexed before a restart are not checked again.
+func (db *ChainsDB) indexExecutions(chain types.ChainID, logDB LogStorage) error {
+	last, err := logDB.LastLogAtOrBefore(logDB.LatestBlockNum())
+	if err != nil {
+		return fmt.Errorf("failed to find last log: %w", err)
+	}
+	if last < 0 {
+		return nil
+	}
+	iter, err := logDB.LastCheckpointBehind(entrydb.EntryIdx(0))
+	if err != nil {
+		return fmt.Errorf("failed to read first checkpoint: %w", err)
+	}
+	messages := newChainMessages()
+	for {
+		blockNum, logIdx, _, err := iter.NextLog()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to read log: %w", err)
+		}
+		msg, err := iter.ExecMessage()
+		if err != nil {
+			return fmt.Errorf("failed to read executing message of log %v of block %v: %w", logIdx, blockNum, err)
+		}
+		if msg == (backendTypes.ExecutingMessage{}) {
+			continue
+		}
+		if _, ok := messages.add(msg, execution{blockNum: blockNum, logIdx: logIdx}); ok {
+			messages.reject(blockNum)
+		}
+	}
+	blocks := db.blocks[chain]
+	blocks.mu.Lock()
+	defer blocks.mu.Unlock()
+	blocks.messages = messages
+	return nil
+}
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	backendTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/types"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+func blockB(num uint64) eth.BlockID {
+	return eth.BlockID{Hash: common.Hash{0xb0, byte(num)}, Number: num}
+}
+
+func execA1(logIdx uint32, timestamp uint64) *backendTypes.ExecutingMessage {
+	return &backendTypes.ExecutingMessage{
+		Chain:     1,
+		BlockNum:  blockA1.Number,
+		LogIdx:    logIdx,
+		Timestamp: timestamp,
+		Hash:      initHash,
+	}
+}
+
+func TestChainsDB_RejectMessages(t *testing.T) {
+	db := newTestChainsDB(t, t.TempDir())
+	m := &stubRejectionMetrics{}
+	db.SetMetrics(m)
+	db.SetMessageExpiryWindow(1000)
+
+	for logIdx := uint32(0); logIdx < 3; logIdx++ {
+		require.NoError(t, db.AddLog(chainA, initHash, blockA1, 100, logIdx, nil))
+	}
+	require.NoError(t, db.SealBlock(chainA, blockA1))
+	require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb1}, blockB(1), 102, 0, execA1(0, 100)))
+	require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb2}, blockB(2), 104, 0, execA1(0, 100)))
+	require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb3}, blockB(3), 106, 0, execA1(0, 200)))
+	require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb4}, blockB(4), 1100, 0, execA1(1, 100)))
+	require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb5}, blockB(5), 1101, 0, execA1(2, 100)))
+	require.NoError(t, db.SealBlock(chainB, blockB(5)))
+
+	level, err := db.CheckBlock(chainB, blockB(1))
+	require.NoError(t, err)
+	require.Equal(t, types.Unsafe, level)
+	for num, reason := range map[uint64]string{2: RejectDuplicate, 3: RejectFuture, 5: RejectExpired} {
+		level, err := db.CheckBlock(chainB, blockB(num))
+		require.NoError(t, err)
+		require.Equalf(t, types.Invalid, level, "block %v should be rejected as %v", num, reason)
+	}
+	level, err = db.CheckBlock(chainB, blockB(4))
+	require.NoError(t, err)
+	require.Equal(t, types.Unsafe, level, "message executed at the end of the expiry window")
+	require.Equal(t, map[string]int{RejectDuplicate: 1, RejectFuture: 1, RejectExpired: 1}, m.rejected)
+
+	// The cross-unsafe head can not advance into a block with a rejected message
+	require.NoError(t, db.UpdateLocalUnsafe(chainA, blockA1))
+	require.NoError(t, db.UpdateLocalUnsafe(chainB, blockB(5)))
+	require.NoError(t, db.UpdateCrossHeads(NewSafetyChecker(Unsafe, *db)))
+	level, err = db.CheckBlock(chainB, blockB(1))
+	require.NoError(t, err)
+	require.Equal(t, types.CrossUnsafe, level)
+	lastValid, err := db.logDBs[chainB].LastLogAtOrBefore(1)
+	require.NoError(t, err)
+	require.Equal(t, lastValid, db.heads.Current().Get(chainB).CrossUnsafe)
+}
+
+func TestChainsDB_RewindMessages(t *testing.T) {
+	db := newTestChainsDB(t, t.TempDir())
+	require.NoError(t, db.AddLog(chainA, initHash, blockA1, 100, 0, nil))
+	require.NoError(t, db.SealBlock(chainA, blockA1))
+	require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb1}, blockB(1), 102, 0, execA1(0, 100)))
+	require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb2}, blockB(2), 104, 0, execA1(0, 100)))
+	require.NoError(t, db.SealBlock(chainB, blockB(2)))
+	level, err := db.CheckBlock(chainB, blockB(2))
+	require.NoError(t, err)
+	require.Equal(t, types.Invalid, level)
+
+	// The replaced block is no longer rejected
+	require.NoError(t, db.Rewind(chainB, 1))
+	messages := db.blocks[chainB].messages
+	require.False(t, messages.isRejected(2))
+	require.Len(t, messages.executions, 1)
+
+	// The message executed by a replaced block can be executed again
+	require.NoError(t, db.Rewind(chainB, 0))
+	require.Empty(t, messages.executions)
+	require.Empty(t, messages.queue)
+}
+
+func TestChainsDB_ResumeMessages(t *testing.T) {
+	dir := t.TempDir()
+	db := newTestChainsDB(t, dir)
+	require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb1}, blockB(1), 102, 0, execA1(0, 100)))
+	// add enough logs to pass the second search checkpoint, for the resumed db to keep the first block
+	for num := uint64(2); num < 512; num++ {
+		require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb2}, blockB(num), 100+2*num, 0, nil))
+	}
+	require.NoError(t, db.Close())
+
+	db = newTestChainsDB(t, dir)
+	require.NoError(t, db.Resume())
+	next := db.LatestBlockNum(chainB) + 1
+	require.Greater(t, next, uint64(2))
+	require.NoError(t, db.AddLog(chainB, backendTypes.TruncatedHash{0xb3}, blockB(next), 100+2*next, 0, execA1(0, 100)))
+	require.NoError(t, db.SealBlock(chainB, blockB(next)))
+	level, err := db.CheckBlock(chainB, blockB(next))
+	require.NoError(t, err)
+	require.Equal(t, types.Invalid, level, "message was executed before the restart")
+}
+
+type stubRejectionMetrics struct {
+	rejected map[string]int
+}
+
+func (s *stubRejectionMetrics) RecordRejectedMessage(_ types.ChainID, reason string) {
+	if s.rejected == nil {
+		s.rejected = make(map[string]int)
+	}
+	s.rejected[reason]++
+}
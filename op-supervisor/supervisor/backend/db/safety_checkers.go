@@ -119,7 +119,11 @@ func check(
 	// exist at the blockNum and logIdx
 	// have a hash that matches the provided hash (implicit in the Contains call), and
 	// be less than or equal to the local head for the chain
-	exists, index, err := chainsDB.logDBs[chain].Contains(blockNum, logIdx, logHash)
+	logDB, ok := chainsDB.logDBs[chain]
+	if !ok {
+		return false
+	}
+	exists, index, err := logDB.Contains(blockNum, logIdx, logHash)
 	if err != nil {
 		return false
 	}
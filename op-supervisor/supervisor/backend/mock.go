@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/frontend"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 )
@@ -47,6 +48,22 @@ func (m *MockBackend) CheckBlock(chainID *hexutil.U256, blockHash common.Hash, b
 	return types.CrossUnsafe, nil
 }
 
+func (m *MockBackend) CrossSafe(chainID *hexutil.U256) (eth.BlockID, error) {
+	return eth.BlockID{}, nil
+}
+
+func (m *MockBackend) Finalized(chainID *hexutil.U256) (eth.BlockID, error) {
+	return eth.BlockID{}, nil
+}
+
+func (m *MockBackend) UpdateLocalUnsafe(chainID *hexutil.U256, head eth.L2BlockRef) error {
+	return nil
+}
+
+func (m *MockBackend) UpdateLocalSafe(chainID *hexutil.U256, derivedFrom eth.L1BlockRef, lastDerived eth.L2BlockRef) error {
+	return nil
+}
+
 func (m *MockBackend) Close() error {
 	return nil
 }
@@ -15,7 +15,6 @@ import (
 	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
 	"github.com/ethereum/go-ethereum/common"
 	ethTypes "github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
@@ -75,7 +74,7 @@ func (i *CrossL2Inbox) DecodeExecutingMessageLog(l *ethTypes.Log) (backendTypes.
 	if err != nil {
 		return backendTypes.ExecutingMessage{}, fmt.Errorf("failed to convert chain ID %v to uint32: %w", identifier.ChainId, err)
 	}
-	hash := payloadHashToLogHash(msgHash, identifier.Origin)
+	hash := backendTypes.PayloadHashToLogHash(msgHash, identifier.Origin)
 	return backendTypes.ExecutingMessage{
 		Chain:     chainID,
 		Hash:      hash,
@@ -118,17 +117,3 @@ func identifierFromBytes(identifierBytes io.Reader) (contractIdentifier, error)
 		ChainId:     chainID,
 	}, nil
 }
-
-// payloadHashToLogHash converts the payload hash to the log hash
-// it is the concatenation of the log's address and the hash of the log's payload,
-// which is then hashed again. This is the hash that is stored in the log storage.
-// The logHash can then be used to traverse from the executing message
-// to the log the referenced initiating message.
-// TODO: this function is duplicated between contracts and backend/source/log_processor.go
-// to avoid a circular dependency. It should be reorganized to avoid this duplication.
-func payloadHashToLogHash(payloadHash common.Hash, addr common.Address) backendTypes.TruncatedHash {
-	msg := make([]byte, 0, 2*common.HashLength)
-	msg = append(msg, addr.Bytes()...)
-	msg = append(msg, payloadHash.Bytes()...)
-	return backendTypes.TruncateHash(crypto.Keccak256Hash(msg))
-}
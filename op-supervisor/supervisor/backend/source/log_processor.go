@@ -16,6 +16,7 @@ import (
 
 type LogStorage interface {
 	AddLog(chain supTypes.ChainID, logHash backendTypes.TruncatedHash, block eth.BlockID, timestamp uint64, logIdx uint32, execMsg *backendTypes.ExecutingMessage) error
+	SealBlock(chain supTypes.ChainID, block eth.BlockID) error
 }
 
 type EventDecoder interface {
@@ -37,7 +38,8 @@ func newLogProcessor(chain supTypes.ChainID, logStore LogStorage) *logProcessor
 }
 
 // ProcessLogs processes logs from a block and stores them in the log storage
-// for any logs that are related to executing messages, they are decoded and stored
+// for any logs that are related to executing messages, they are decoded and stored.
+// Once all logs are stored, the block is sealed to mark it as fully indexed, even if it had no logs.
 func (p *logProcessor) ProcessLogs(_ context.Context, block eth.L1BlockRef, rcpts ethTypes.Receipts) error {
 	for _, rcpt := range rcpts {
 		for _, l := range rcpt.Logs {
@@ -60,6 +62,9 @@ func (p *logProcessor) ProcessLogs(_ context.Context, block eth.L1BlockRef, rcpt
 			}
 		}
 	}
+	if err := p.logStore.SealBlock(p.chain, block.ID()); err != nil {
+		return fmt.Errorf("failed to seal block %v: %w", block.ID(), err)
+	}
 	return nil
 }
 
@@ -70,7 +75,7 @@ func (p *logProcessor) ProcessLogs(_ context.Context, block eth.L1BlockRef, rcpt
 // and because they represent paired data.
 func logToLogHash(l *ethTypes.Log) backendTypes.TruncatedHash {
 	payloadHash := crypto.Keccak256(logToMessagePayload(l))
-	return payloadHashToLogHash(common.Hash(payloadHash), l.Address)
+	return backendTypes.PayloadHashToLogHash(common.Hash(payloadHash), l.Address)
 }
 
 // logToMessagePayload is the data that is hashed to get the logHash
@@ -84,15 +89,3 @@ func logToMessagePayload(l *ethTypes.Log) []byte {
 	msg = append(msg, l.Data...)
 	return msg
 }
-
-// payloadHashToLogHash converts the payload hash to the log hash
-// it is the concatenation of the log's address and the hash of the log's payload,
-// which is then hashed. This is the hash that is stored in the log storage.
-// The logHash can then be used to traverse from the executing message
-// to the log the referenced initiating message.
-func payloadHashToLogHash(payloadHash common.Hash, addr common.Address) backendTypes.TruncatedHash {
-	msg := make([]byte, 0, 2*common.HashLength)
-	msg = append(msg, addr.Bytes()...)
-	msg = append(msg, payloadHash.Bytes()...)
-	return backendTypes.TruncateHash(crypto.Keccak256Hash(msg))
-}
@@ -26,6 +26,7 @@ func TestLogProcessor(t *testing.T) {
 		err := processor.ProcessLogs(ctx, block1, ethTypes.Receipts{})
 		require.NoError(t, err)
 		require.Empty(t, store.logs)
+		require.Equal(t, block1.ID(), store.sealed)
 	})
 
 	t.Run("OutputLogs", func(t *testing.T) {
@@ -183,7 +184,16 @@ func TestToLogHash(t *testing.T) {
 }
 
 type stubLogStorage struct {
-	logs []storedLog
+	logs   []storedLog
+	sealed eth.BlockID
+}
+
+func (s *stubLogStorage) SealBlock(chainID supTypes.ChainID, block eth.BlockID) error {
+	if logProcessorChainID != chainID {
+		return fmt.Errorf("chain id mismatch, expected %v but got %v", logProcessorChainID, chainID)
+	}
+	s.sealed = block
+	return nil
 }
 
 func (s *stubLogStorage) AddLog(chainID supTypes.ChainID, logHash backendTypes.TruncatedHash, block eth.BlockID, timestamp uint64, logIdx uint32, execMsg *backendTypes.ExecutingMessage) error {
@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 )
 
@@ -17,11 +18,19 @@ type AdminBackend interface {
 type QueryBackend interface {
 	CheckMessage(identifier types.Identifier, payloadHash common.Hash) (types.SafetyLevel, error)
 	CheckBlock(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) (types.SafetyLevel, error)
+	CrossSafe(chainID *hexutil.U256) (eth.BlockID, error)
+	Finalized(chainID *hexutil.U256) (eth.BlockID, error)
+}
+
+type UpdatesBackend interface {
+	UpdateLocalUnsafe(chainID *hexutil.U256, head eth.L2BlockRef) error
+	UpdateLocalSafe(chainID *hexutil.U256, derivedFrom eth.L1BlockRef, lastDerived eth.L2BlockRef) error
 }
 
 type Backend interface {
 	AdminBackend
 	QueryBackend
+	UpdatesBackend
 }
 
 type QueryFrontend struct {
@@ -39,6 +48,31 @@ func (q *QueryFrontend) CheckBlock(chainID *hexutil.U256, blockHash common.Hash,
 	return q.Supervisor.CheckBlock(chainID, blockHash, blockNumber)
 }
 
+// CrossSafe returns the last block of the chain that is safe, including all its cross-chain dependencies.
+func (q *QueryFrontend) CrossSafe(chainID *hexutil.U256) (eth.BlockID, error) {
+	return q.Supervisor.CrossSafe(chainID)
+}
+
+// Finalized returns the last block of the chain that is finalized, including all its cross-chain dependencies.
+func (q *QueryFrontend) Finalized(chainID *hexutil.U256) (eth.BlockID, error) {
+	return q.Supervisor.Finalized(chainID)
+}
+
+type UpdatesFrontend struct {
+	Supervisor UpdatesBackend
+}
+
+// UpdateLocalUnsafe updates the local-unsafe head of a chain, as reported by the node of the chain.
+func (u *UpdatesFrontend) UpdateLocalUnsafe(chainID *hexutil.U256, head eth.L2BlockRef) error {
+	return u.Supervisor.UpdateLocalUnsafe(chainID, head)
+}
+
+// UpdateLocalSafe updates the local-safe head of a chain, and the L1 block it was derived from,
+// as reported by the node of the chain.
+func (u *UpdatesFrontend) UpdateLocalSafe(chainID *hexutil.U256, derivedFrom eth.L1BlockRef, lastDerived eth.L2BlockRef) error {
+	return u.Supervisor.UpdateLocalSafe(chainID, derivedFrom, lastDerived)
+}
+
 type AdminFrontend struct {
 	Supervisor Backend
 }
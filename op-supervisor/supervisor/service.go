@@ -129,11 +129,16 @@ func (su *SupervisorService) initMetricsServer(cfg *config.Config) error {
 }
 
 func (su *SupervisorService) initRPCServer(cfg *config.Config) error {
+	adminJWTSecret, err := cfg.RPC.AdminJWTSecret()
+	if err != nil {
+		return err
+	}
 	server := oprpc.NewServer(
 		cfg.RPC.ListenAddr,
 		cfg.RPC.ListenPort,
 		cfg.Version,
 		oprpc.WithLogger(su.log),
+		oprpc.WithAdminJWTSecret(adminJWTSecret),
 		//oprpc.WithHTTPRecorder(su.metrics), // TODO(protocol-quest#286) hook up metrics to RPC server
 	)
 	if cfg.RPC.EnableAdmin {
@@ -143,12 +148,21 @@ func (su *SupervisorService) initRPCServer(cfg *config.Config) error {
 			Service:       &frontend.AdminFrontend{Supervisor: su.backend},
 			Authenticated: true, // TODO(protocol-quest#286): enforce auth on this or not?
 		})
+		if adminJWTSecret != nil {
+			server.AddAPI(oppprof.GetRuntimeAPI(oppprof.NewRuntimeAPI(su.log, cfg.PprofConfig.ProfileDir)))
+			su.log.Info("Authenticated admin runtime RPC enabled")
+		}
 	}
 	server.AddAPI(rpc.API{
 		Namespace:     "supervisor",
 		Service:       &frontend.QueryFrontend{Supervisor: su.backend},
 		Authenticated: false,
 	})
+	server.AddAPI(rpc.API{
+		Namespace:     "supervisor",
+		Service:       &frontend.UpdatesFrontend{Supervisor: su.backend},
+		Authenticated: false,
+	})
 	su.rpcServer = server
 	return nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/oppprof"
@@ -67,6 +68,16 @@ func TestSupervisorService(t *testing.T) {
 		cancel()
 		require.NoError(t, err)
 		require.Equal(t, types.CrossUnsafe, dest, "expecting mock to return cross-unsafe")
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+		err = cl.CallContext(ctx, nil, "supervisor_updateLocalUnsafe",
+			(*hexutil.U256)(uint256.NewInt(1)), eth.L2BlockRef{Hash: common.Hash{0xab}, Number: 123})
+		cancel()
+		require.NoError(t, err)
+		ctx, cancel = context.WithTimeout(context.Background(), time.Second*5)
+		var crossSafe eth.BlockID
+		err = cl.CallContext(ctx, &crossSafe, "supervisor_crossSafe", (*hexutil.U256)(uint256.NewInt(1)))
+		cancel()
+		require.NoError(t, err)
 		cl.Close()
 	}
 	require.NoError(t, supervisor.Stop(context.Background()), "stop service")
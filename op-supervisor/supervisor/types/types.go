@@ -60,7 +60,7 @@ func (lvl SafetyLevel) String() string {
 
 func (lvl SafetyLevel) Valid() bool {
 	switch lvl {
-	case Finalized, Safe, CrossUnsafe, Unsafe:
+	case Finalized, Safe, CrossUnsafe, Unsafe, Invalid:
 		return true
 	default:
 		return false
@@ -88,6 +88,9 @@ const (
 	Safe        SafetyLevel = "safe"
 	CrossUnsafe SafetyLevel = "cross-unsafe"
 	Unsafe      SafetyLevel = "unsafe"
+	// Invalid is the safety level of a block that executes a message that was never initiated,
+	// or of a message that does not match the initiating message.
+	Invalid SafetyLevel = "invalid"
 )
 
 type ChainID uint256.Int
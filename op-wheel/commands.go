@@ -256,6 +256,7 @@ func rollupFromGethConfig(cfg *params.ChainConfig) *rollup.Config {
 		CanyonTime:   cfg.CanyonTime,
 		EcotoneTime:  cfg.EcotoneTime,
 		GraniteTime:  cfg.GraniteTime,
+		HoloceneTime: cfg.HoloceneTime,
 		InteropTime:  cfg.InteropTime,
 	}
 }
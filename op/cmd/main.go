@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	cannon "github.com/ethereum-optimism/optimism/cannon/cmd"
+	batcher "github.com/ethereum-optimism/optimism/op-batcher/cmd/app"
+	opchallenger "github.com/ethereum-optimism/optimism/op-challenger"
+	challenger "github.com/ethereum-optimism/optimism/op-challenger/cmd/app"
+	challengerconfig "github.com/ethereum-optimism/optimism/op-challenger/config"
+	challengermetrics "github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	node "github.com/ethereum-optimism/optimism/op-node/cmd/app"
+	"github.com/ethereum-optimism/optimism/op-program/host"
+	program "github.com/ethereum-optimism/optimism/op-program/host/cmd/app"
+	proposer "github.com/ethereum-optimism/optimism/op-proposer/cmd/app"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/appinfo"
+	"github.com/ethereum-optimism/optimism/op-service/cliapp"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+)
+
+var (
+	Version   = "v0.0.0"
+	GitCommit = ""
+	GitDate   = ""
+)
+
+func main() {
+	// Set up logger with a default INFO level in case we fail to parse flags,
+	// otherwise the final critical log won't show what the parsing error was.
+	oplog.SetupDefaults()
+	appinfo.SetBuild(Version, GitCommit, GitDate)
+
+	ctx := opio.WithInterruptBlocker(context.Background())
+	err := newApp(opservice.FormatVersion(Version, GitCommit, GitDate, "")).RunContext(ctx, os.Args)
+	if err != nil {
+		log.Crit("Application failed", "message", err)
+	}
+}
+
+// newApp creates the op app, with a subcommand for each of the bundled services.
+// All services run with the same version, as they are built together.
+func newApp(version string) *cli.App {
+	app := cli.NewApp()
+	app.Version = version
+	app.Name = "op"
+	app.Usage = "OP Stack multitool"
+	app.Description = "Runs the OP Stack services and tools from a single binary, with a subcommand for each."
+	app.Commands = []*cli.Command{
+		subcommand("node", node.New(version)),
+		subcommand("batcher", batcher.New(version)),
+		subcommand("proposer", proposer.New(version)),
+		subcommand("challenger", challenger.New(version, runChallenger)),
+		subcommand("program", program.New(version, host.Main, program.VerifyStateless)),
+		subcommand("cannon", cannon.NewApp()),
+	}
+	return app
+}
+
+// subcommand turns the app of a service into a subcommand with the given name.
+// The flags, including their env vars, are the same as for the standalone binary of the service.
+func subcommand(name string, app *cli.App) *cli.Command {
+	return &cli.Command{
+		Name:        name,
+		Usage:       app.Usage,
+		Description: app.Description,
+		Flags:       app.Flags,
+		Action:      app.Action,
+		Subcommands: app.Commands,
+	}
+}
+
+func runChallenger(ctx context.Context, l log.Logger, config *challengerconfig.Config) (cliapp.Lifecycle, error) {
+	return opchallenger.Main(ctx, l, config, challengermetrics.NewMetrics())
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubcommands(t *testing.T) {
+	app := newApp("v1.2.3")
+	var names []string
+	for _, cmd := range app.Commands {
+		names = append(names, cmd.Name)
+		require.NotEmpty(t, cmd.Usage, "subcommand %v must have usage", cmd.Name)
+	}
+	require.Equal(t, []string{"node", "batcher", "proposer", "challenger", "program", "cannon"}, names)
+}
+
+func TestSubcommandFlagsMatchServices(t *testing.T) {
+	app := newApp("v1.2.3")
+	for _, cmd := range app.Commands {
+		if cmd.Name == "cannon" {
+			// cannon only has subcommands
+			require.NotEmpty(t, cmd.Subcommands)
+			continue
+		}
+		require.NotNil(t, cmd.Action, "subcommand %v must run the service", cmd.Name)
+		require.NotEmpty(t, cmd.Flags, "subcommand %v must have the service flags", cmd.Name)
+	}
+}
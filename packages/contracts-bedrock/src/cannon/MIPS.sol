@@ -8,6 +8,7 @@ import { MIPSInstructions as ins } from "src/cannon/libraries/MIPSInstructions.s
 import { MIPSSyscalls as sys } from "src/cannon/libraries/MIPSSyscalls.sol";
 import { MIPSState as st } from "src/cannon/libraries/MIPSState.sol";
 import { MIPSMemory } from "src/cannon/libraries/MIPSMemory.sol";
+import { MIPSFPU as fpu } from "src/cannon/libraries/MIPSFPU.sol";
 
 /// @title MIPS
 /// @notice The MIPS contract emulates a single MIPS instruction.
@@ -26,8 +27,9 @@ import { MIPSMemory } from "src/cannon/libraries/MIPSMemory.sol";
 ///      MIPS linux kernel errors used by Go runtime
 contract MIPS is ISemver {
     /// @notice Stores the VM state.
-    ///         Total state size: 32 + 32 + 6 * 4 + 1 + 1 + 8 + 32 * 4 = 226 bytes
+    ///         Total state size: 32 + 32 + 6 * 4 + 1 + 1 + 8 + 32 * 4 + 4 + 32 * 4 = 358 bytes
     ///         If nextPC != pc + 4, then the VM is executing a branch/jump delay slot.
+    ///         A double occupies an even/odd pair of the floating-point registers, with the low word in the even one.
     struct State {
         bytes32 memRoot;
         bytes32 preimageKey;
@@ -41,17 +43,21 @@ contract MIPS is ISemver {
         bool exited;
         uint64 step;
         uint32[32] registers;
+        uint32 fcsr;
+        uint32[32] fpr;
     }
 
-    /// @notice The semantic version of the MIPS contract.
-    /// @custom:semver 1.1.0-rc.1
-    string public constant version = "1.1.0-rc.1";
+    /// @notice The semantic version of the MIPS contract. The contract steps version 2 of the singlethreaded VM
+    ///         state: version 1 adds the file descriptors of the Go runtime network poller, version 2 the
+    ///         floating-point coprocessor and its registers in the witness.
+    /// @custom:semver 1.2.0-rc.2
+    string public constant version = "1.2.0-rc.2";
 
     /// @notice The preimage oracle contract.
     IPreimageOracle internal immutable ORACLE;
 
     // The offset of the start of proof calldata (_proof.offset) in the step() function
-    uint256 internal constant STEP_PROOF_OFFSET = 420;
+    uint256 internal constant STEP_PROOF_OFFSET = 548;
 
     /// @param _oracle The address of the preimage oracle contract.
     constructor(IPreimageOracle _oracle) {
@@ -96,7 +102,8 @@ contract MIPS is ISemver {
             let exited := mload(from)
             from, to := copyMem(from, to, 1) // exited
             from, to := copyMem(from, to, 8) // step
-            from := add(from, 32) // offset to registers
+            let fcsr := add(from, 32) // offset to fcsr
+            from := mload(from) // offset to registers
 
             // Verify that the value of exited is valid (0 or 1)
             if gt(exited, 1) {
@@ -109,6 +116,11 @@ contract MIPS is ISemver {
             // Copy registers
             for { let i := 0 } lt(i, 32) { i := add(i, 1) } { from, to := copyMem(from, to, 4) }
 
+            // Copy the FPU state
+            from, to := copyMem(fcsr, to, 4) // fcsr
+            from := mload(from) // offset to fpr
+            for { let i := 0 } lt(i, 32) { i := add(i, 1) } { from, to := copyMem(from, to, 4) }
+
             // Clean up end of memory
             mstore(to, 0)
 
@@ -153,7 +165,9 @@ contract MIPS is ISemver {
             uint32 v0 = 0;
             uint32 v1 = 0;
 
-            if (syscall_no == sys.SYS_MMAP) {
+            if (sys.isNetpollSyscall(syscall_no, a0)) {
+                (v0, v1) = sys.handleNetpollSyscall(syscall_no, a0, a1);
+            } else if (syscall_no == sys.SYS_MMAP) {
                 (v0, v1, state.heap) = sys.handleSysMmap(a0, a1, state.heap);
             } else if (syscall_no == sys.SYS_BRK) {
                 // brk: Returns a fixed address for the program break at 0x40000000
@@ -217,7 +231,7 @@ contract MIPS is ISemver {
                     // expected state mem offset check
                     revert(0, 0)
                 }
-                if iszero(eq(mload(0x40), shl(5, 48))) {
+                if iszero(eq(mload(0x40), shl(5, 82))) {
                     // expected memory check
                     revert(0, 0)
                 }
@@ -226,7 +240,7 @@ contract MIPS is ISemver {
                     revert(0, 0)
                 }
                 if iszero(eq(_proof.offset, STEP_PROOF_OFFSET)) {
-                    // 132+32+256=420 expected proof offset
+                    // 132+32+384=548 expected proof offset
                     revert(0, 0)
                 }
 
@@ -263,13 +277,19 @@ contract MIPS is ISemver {
                 }
 
                 // Compiler should have done this already
-                if iszero(eq(mload(m), add(m, 32))) {
+                if iszero(eq(mload(m), add(m, 96))) {
                     // expected registers offset check
                     revert(0, 0)
                 }
 
                 // Unpack register calldata into memory
-                m := add(m, 32)
+                let fcsr := add(m, 32)
+                m := mload(m)
+                for { let i := 0 } lt(i, 32) { i := add(i, 1) } { c, m := putField(c, m, 4) }
+
+                // Unpack the FPU state calldata into memory
+                c, m := putField(c, fcsr, 4) // fcsr
+                m := mload(m)
                 for { let i := 0 } lt(i, 32) { i := add(i, 1) } { c, m := putField(c, m, 4) }
             }
 
@@ -291,6 +311,11 @@ contract MIPS is ISemver {
                 return handleSyscall(_localContext);
             }
 
+            // Handle the floating-point instructions separately
+            if (fpu.isFpuInstruction(opcode)) {
+                return handleFpu(insn, opcode);
+            }
+
             // Exec the rest of the step logic
             st.CpuScalars memory cpu = getCpuScalars(state);
             (state.memRoot) = ins.execMipsCoreStepLogic({
@@ -308,6 +333,35 @@ contract MIPS is ISemver {
         }
     }
 
+    /// @notice Handles a floating-point instruction.
+    /// @param _insn The current 32-bit instruction at the pc.
+    /// @param _opcode The opcode value parsed from _insn.
+    /// @return out_ The hashed MIPS state.
+    function handleFpu(uint32 _insn, uint32 _opcode) internal returns (bytes32 out_) {
+        unchecked {
+            // Load state from memory
+            State memory state;
+            assembly {
+                state := 0x80
+            }
+
+            st.CpuScalars memory cpu = getCpuScalars(state);
+            (state.memRoot, state.fcsr) = fpu.execMipsFpuStepLogic({
+                _cpu: cpu,
+                _registers: state.registers,
+                _fpr: state.fpr,
+                _fcsr: state.fcsr,
+                _memRoot: state.memRoot,
+                _memProofOffset: MIPSMemory.memoryProofOffset(STEP_PROOF_OFFSET, 1),
+                _insn: _insn,
+                _opcode: _opcode
+            });
+            setStateCpuScalars(state, cpu);
+
+            out_ = outputState();
+        }
+    }
+
     function getCpuScalars(State memory _state) internal pure returns (st.CpuScalars memory) {
         return st.CpuScalars({ pc: _state.pc, nextPC: _state.nextPC, lo: _state.lo, hi: _state.hi });
     }
@@ -7,6 +7,7 @@ import { MIPSMemory } from "src/cannon/libraries/MIPSMemory.sol";
 import { MIPSSyscalls as sys } from "src/cannon/libraries/MIPSSyscalls.sol";
 import { MIPSState as st } from "src/cannon/libraries/MIPSState.sol";
 import { MIPSInstructions as ins } from "src/cannon/libraries/MIPSInstructions.sol";
+import { MIPSFPU as fpu } from "src/cannon/libraries/MIPSFPU.sol";
 import { VMStatuses } from "src/dispute/lib/Types.sol";
 
 /// @title MIPS2
@@ -14,7 +15,7 @@ import { VMStatuses } from "src/dispute/lib/Types.sol";
 ///         It differs from MIPS.sol in that it supports multi-threading.
 contract MIPS2 is ISemver {
     /// @notice The thread context.
-    ///         Total state size: 4 + 1 + 1 + 4 + 4 + 8 + 4 + 4 + 4 + 4 + 32 * 4 = 166 bytes
+    ///         Total state size: 4 + 1 + 1 + 4 + 4 + 8 + 4 + 4 + 4 + 4 + 32 * 4 + 4 + 32 * 4 = 298 bytes
     struct ThreadState {
         // metadata
         uint32 threadID;
@@ -29,16 +30,21 @@ contract MIPS2 is ISemver {
         uint32 lo;
         uint32 hi;
         uint32[32] registers;
+        uint32 fcsr;
+        uint32[32] fpr;
     }
 
     /// @notice Stores the VM state.
-    ///         Total state size: 32 + 32 + 4 + 4 + 1 + 1 + 8 + 8 + 4 + 1 + 32 + 32 + 4 = 163 bytes
+    ///         Total state size: 32 + 32 + 4 + 4 + 1 + 4 + 4 + 1 + 1 + 8 + 8 + 4 + 1 + 32 + 32 + 4 = 172 bytes
     ///         If nextPC != pc + 4, then the VM is executing a branch/jump delay slot.
     struct State {
         bytes32 memRoot;
         bytes32 preimageKey;
         uint32 preimageOffset;
         uint32 heap;
+        bool llReservationActive;
+        uint32 llAddress;
+        uint32 llOwnerThread;
         uint8 exitCode;
         bool exited;
         uint64 step;
@@ -50,9 +56,11 @@ contract MIPS2 is ISemver {
         uint32 nextThreadID;
     }
 
-    /// @notice The semantic version of the MIPS2 contract.
-    /// @custom:semver 1.0.0-beta.4
-    string public constant version = "1.0.0-beta.4";
+    /// @notice The semantic version of the MIPS2 contract. The contract steps version 3 of the multithreaded VM
+    ///         state: version 1 adds the ll/sc memory reservation, version 2 the file descriptors of the Go runtime
+    ///         network poller, version 3 the floating-point coprocessor and its registers in the serialized threads.
+    /// @custom:semver 1.0.0-beta.8
+    string public constant version = "1.0.0-beta.8";
 
     /// @notice The preimage oracle contract.
     IPreimageOracle internal immutable ORACLE;
@@ -61,7 +69,7 @@ contract MIPS2 is ISemver {
     uint256 internal constant THREAD_PROOF_OFFSET = 356;
 
     // The offset of the start of proof calldata (_memProof.offset) in the step() function
-    uint256 internal constant MEM_PROOF_OFFSET = THREAD_PROOF_OFFSET + 166 + 32;
+    uint256 internal constant MEM_PROOF_OFFSET = THREAD_PROOF_OFFSET + 298 + 32;
 
     // The empty thread root - keccak256(bytes32(0) ++ bytes32(0))
     bytes32 internal constant EMPTY_THREAD_ROOT = hex"ad3228b676f7d3cd4284a5443f17f1962b36e491b30a40b2405849e597ba5fb5";
@@ -70,7 +78,11 @@ contract MIPS2 is ISemver {
     uint256 internal constant STATE_MEM_OFFSET = 0x80;
 
     // ThreadState memory offset allocated during step
-    uint256 internal constant TC_MEM_OFFSET = 0x220;
+    uint256 internal constant TC_MEM_OFFSET = 0x280;
+
+    // The opcodes of the load-linked and store-conditional instructions
+    uint32 internal constant OP_LOAD_LINKED = 0x30;
+    uint32 internal constant OP_STORE_CONDITIONAL = 0x38;
 
     /// @param _oracle The address of the preimage oracle contract.
     constructor(IPreimageOracle _oracle) {
@@ -101,8 +113,8 @@ contract MIPS2 is ISemver {
                     // expected thread mem offset check
                     revert(0, 0)
                 }
-                if iszero(eq(mload(0x40), shl(5, 60))) {
-                    // 4 + 13 state slots + 43 thread slots = 60 expected memory check
+                if iszero(eq(mload(0x40), shl(5, 97))) {
+                    // 4 + 16 state slots + 77 thread slots = 97 expected memory check
                     revert(0, 0)
                 }
                 if iszero(eq(_stateData.offset, 132)) {
@@ -129,6 +141,9 @@ contract MIPS2 is ISemver {
                 c, m := putField(c, m, 32) // preimageKey
                 c, m := putField(c, m, 4) // preimageOffset
                 c, m := putField(c, m, 4) // heap
+                c, m := putField(c, m, 1) // llReservationActive
+                c, m := putField(c, m, 4) // llAddress
+                c, m := putField(c, m, 4) // llOwnerThread
                 c, m := putField(c, m, 1) // exitCode
                 c, m := putField(c, m, 1) // exited
                 c, m := putField(c, m, 8) // step
@@ -219,6 +234,21 @@ contract MIPS2 is ISemver {
                 return handleSyscall(_localContext);
             }
 
+            // Handle the read-modify-write ops separately
+            if (opcode == OP_LOAD_LINKED || opcode == OP_STORE_CONDITIONAL) {
+                return handleRMWOps(state, thread, insn, opcode);
+            }
+
+            // Any other store to the reserved address breaks the reservation
+            if (isStore(opcode)) {
+                handleStore(state, thread, insn, opcode);
+            }
+
+            // Handle the floating-point instructions separately
+            if (fpu.isFpuInstruction(opcode)) {
+                return handleFpu(insn, opcode);
+            }
+
             // Exec the rest of the step logic
             st.CpuScalars memory cpu = getCpuScalars(thread);
             (state.memRoot) = ins.execMipsCoreStepLogic({
@@ -252,7 +282,9 @@ contract MIPS2 is ISemver {
             uint32 v0 = 0;
             uint32 v1 = 0;
 
-            if (syscall_no == sys.SYS_MMAP) {
+            if (sys.isNetpollSyscall(syscall_no, a0)) {
+                (v0, v1) = sys.handleNetpollSyscall(syscall_no, a0, a1);
+            } else if (syscall_no == sys.SYS_MMAP) {
                 (v0, v1, state.heap) = sys.handleSysMmap(a0, a1, state.heap);
             } else if (syscall_no == sys.SYS_BRK) {
                 // brk: Returns a fixed address for the program break at 0x40000000
@@ -279,6 +311,11 @@ contract MIPS2 is ISemver {
                 for (uint256 i; i < 32; i++) {
                     newThread.registers[i] = thread.registers[i];
                 }
+                // the child inherits the floating-point context of the parent
+                newThread.fcsr = thread.fcsr;
+                for (uint256 i; i < 32; i++) {
+                    newThread.fpr[i] = thread.fpr[i];
+                }
                 newThread.registers[29] = a1; // set stack pointer
                 // the child will perceive a 0 value as returned value instead, and no error
                 newThread.registers[2] = 0;
@@ -311,6 +348,10 @@ contract MIPS2 is ISemver {
                     memRoot: state.memRoot
                 });
                 (v0, v1, state.preimageOffset, state.memRoot) = sys.handleSysRead(args);
+                // A pre-image read writes to memory, which breaks any reservation of the same address
+                if (a0 == sys.FD_PREIMAGE_READ) {
+                    handleMemoryUpdate(state, a1 & 0xFFffFFfc);
+                }
             } else if (syscall_no == sys.SYS_WRITE) {
                 (v0, v1, state.preimageKey, state.preimageOffset) = sys.handleSysWrite({
                     _a0: a0,
@@ -409,14 +450,6 @@ contract MIPS2 is ISemver {
                 // ignored
             } else if (syscall_no == sys.SYS_IOCTL) {
                 // ignored
-            } else if (syscall_no == sys.SYS_EPOLLCREATE1) {
-                // ignored
-            } else if (syscall_no == sys.SYS_PIPE2) {
-                // ignored
-            } else if (syscall_no == sys.SYS_EPOLLCTL) {
-                // ignored
-            } else if (syscall_no == sys.SYS_EPOLLPWAIT) {
-                // ignored
             } else if (syscall_no == sys.SYS_GETRANDOM) {
                 // ignored
             } else if (syscall_no == sys.SYS_UNAME) {
@@ -458,6 +491,136 @@ contract MIPS2 is ISemver {
         }
     }
 
+    /// @notice Handles the load-linked and store-conditional instructions.
+    ///         The store-conditional only succeeds if the reservation of the load-linked is still held by the
+    ///         thread, i.e. if no other thread has stored to, or load-linked, any address since.
+    function handleRMWOps(
+        State memory _state,
+        ThreadState memory _thread,
+        uint32 _insn,
+        uint32 _opcode
+    )
+        internal
+        returns (bytes32)
+    {
+        unchecked {
+            uint32 rtReg = (_insn >> 16) & 0x1F;
+            uint32 addr = effectiveAddress(_thread.registers, _insn);
+            uint256 memProofOffset = MIPSMemory.memoryProofOffset(MEM_PROOF_OFFSET, 1);
+            uint32 mem = MIPSMemory.readMem(_state.memRoot, addr, memProofOffset);
+
+            uint32 retVal = 0;
+            if (_opcode == OP_LOAD_LINKED) {
+                retVal = mem;
+                _state.llReservationActive = true;
+                _state.llAddress = addr;
+                _state.llOwnerThread = _thread.threadID;
+            } else if (
+                _state.llReservationActive && _state.llOwnerThread == _thread.threadID && _state.llAddress == addr
+            ) {
+                // The reservation is intact: complete the atomic update, and return 1 for success
+                clearLLMemoryReservation(_state);
+                _state.memRoot = MIPSMemory.writeMem(addr, memProofOffset, _thread.registers[rtReg]);
+                retVal = 1;
+            }
+            // else the atomic update failed, and 0 is returned
+
+            st.CpuScalars memory cpu = getCpuScalars(_thread);
+            ins.handleRd(cpu, _thread.registers, rtReg, retVal, true);
+            setStateCpuScalars(_thread, cpu);
+            updateCurrentThreadRoot();
+            return outputState();
+        }
+    }
+
+    /// @notice Handles a floating-point instruction.
+    /// @param _insn The current 32-bit instruction at the pc.
+    /// @param _opcode The opcode value parsed from _insn.
+    /// @return out_ The hashed MIPS state.
+    function handleFpu(uint32 _insn, uint32 _opcode) internal returns (bytes32 out_) {
+        unchecked {
+            // Load state from memory
+            State memory state;
+            ThreadState memory thread;
+            assembly {
+                state := STATE_MEM_OFFSET
+                thread := TC_MEM_OFFSET
+            }
+
+            st.CpuScalars memory cpu = getCpuScalars(thread);
+            (state.memRoot, thread.fcsr) = fpu.execMipsFpuStepLogic({
+                _cpu: cpu,
+                _registers: thread.registers,
+                _fpr: thread.fpr,
+                _fcsr: thread.fcsr,
+                _memRoot: state.memRoot,
+                _memProofOffset: MIPSMemory.memoryProofOffset(MEM_PROOF_OFFSET, 1),
+                _insn: _insn,
+                _opcode: _opcode
+            });
+            setStateCpuScalars(thread, cpu);
+            updateCurrentThreadRoot();
+            out_ = outputState();
+        }
+    }
+
+    /// @notice Breaks the ll/sc reservation if the store instruction writes to the reserved address.
+    function handleStore(
+        State memory _state,
+        ThreadState memory _thread,
+        uint32 _insn,
+        uint32 _opcode
+    )
+        internal
+        pure
+    {
+        unchecked {
+            uint32 addr = effectiveAddress(_thread.registers, _insn);
+            if (_opcode == fpu.OP_STORE_DOUBLE_COP1) {
+                // sdc1 writes both words of the 8-byte aligned double word
+                addr &= 0xFFFFFFF8;
+                handleMemoryUpdate(_state, addr);
+                handleMemoryUpdate(_state, addr + 4);
+            } else {
+                handleMemoryUpdate(_state, addr);
+            }
+        }
+    }
+
+    /// @notice Breaks the ll/sc reservation if the memory at the reserved address is updated.
+    function handleMemoryUpdate(State memory _state, uint32 _memAddr) internal pure {
+        if (_memAddr == _state.llAddress) {
+            clearLLMemoryReservation(_state);
+        }
+    }
+
+    /// @notice Clears the ll/sc reservation.
+    function clearLLMemoryReservation(State memory _state) internal pure {
+        _state.llReservationActive = false;
+        _state.llAddress = 0;
+        _state.llOwnerThread = 0;
+    }
+
+    /// @notice Returns whether the opcode of a load or store instruction writes to memory, like IsStore of the Go VM.
+    ///         The stores are listed explicitly, since the store opcode range also holds loads and cache.
+    function isStore(uint32 _opcode) internal pure returns (bool) {
+        // sb, sh, swl, sw, swr
+        if (_opcode == 0x28 || _opcode == 0x29 || _opcode == 0x2A || _opcode == 0x2B || _opcode == 0x2E) {
+            return true;
+        }
+        // sc, swc1, sdc1
+        return _opcode == OP_STORE_CONDITIONAL || _opcode == fpu.OP_STORE_WORD_COP1
+            || _opcode == fpu.OP_STORE_DOUBLE_COP1;
+    }
+
+    /// @notice Computes the word-aligned address accessed by a load or store instruction.
+    function effectiveAddress(uint32[32] memory _registers, uint32 _insn) internal pure returns (uint32) {
+        unchecked {
+            uint32 base = _registers[(_insn >> 21) & 0x1F];
+            return (base + ins.signExtend(_insn & 0xFFFF, 16)) & 0xFFFFFFFC;
+        }
+    }
+
     /// @notice Computes the hash of the MIPS state.
     /// @return out_ The hashed MIPS state.
     function outputState() internal returns (bytes32 out_) {
@@ -481,6 +644,9 @@ contract MIPS2 is ISemver {
             from, to := copyMem(from, to, 32) // preimageKey
             from, to := copyMem(from, to, 4) // preimageOffset
             from, to := copyMem(from, to, 4) // heap
+            from, to := copyMem(from, to, 1) // llReservationActive
+            from, to := copyMem(from, to, 4) // llAddress
+            from, to := copyMem(from, to, 4) // llOwnerThread
             let exitCode := mload(from)
             from, to := copyMem(from, to, 1) // exitCode
             let exited := mload(from)
@@ -655,9 +821,14 @@ contract MIPS2 is ISemver {
             from, to := copyMem(from, to, 4) // nextPC
             from, to := copyMem(from, to, 4) // lo
             from, to := copyMem(from, to, 4) // hi
+            let fcsr := add(from, 32) // offset to fcsr
             from := mload(from) // offset to registers
             // Copy registers
             for { let i := 0 } lt(i, 32) { i := add(i, 1) } { from, to := copyMem(from, to, 4) }
+            // Copy the FPU state
+            from, to := copyMem(fcsr, to, 4) // fcsr
+            from := mload(from) // offset to fpr
+            for { let i := 0 } lt(i, 32) { i := add(i, 1) } { from, to := copyMem(from, to, 4) }
 
             // Clean up end of memory
             mstore(to, 0)
@@ -692,7 +863,7 @@ contract MIPS2 is ISemver {
             s := calldatasize()
         }
         // verify we have enough calldata
-        require(s >= (THREAD_PROOF_OFFSET + 166), "insufficient calldata for thread witness");
+        require(s >= (THREAD_PROOF_OFFSET + 298), "insufficient calldata for thread witness");
 
         unchecked {
             assembly {
@@ -716,9 +887,14 @@ contract MIPS2 is ISemver {
                 c, m := putField(c, m, 4) // nextPC
                 c, m := putField(c, m, 4) // lo
                 c, m := putField(c, m, 4) // hi
+                let fcsr := add(m, 32) // offset to fcsr
                 m := mload(m) // offset to registers
                 // Unpack register calldata into memory
                 for { let i := 0 } lt(i, 32) { i := add(i, 1) } { c, m := putField(c, m, 4) }
+                // Unpack the FPU state calldata into memory
+                c, m := putField(c, fcsr, 4) // fcsr
+                m := mload(m) // offset to fpr
+                for { let i := 0 } lt(i, 32) { i := add(i, 1) } { c, m := putField(c, m, 4) }
             }
         }
     }
@@ -728,9 +904,9 @@ contract MIPS2 is ISemver {
         uint256 s = 0;
         assembly {
             s := calldatasize()
-            innerThreadRoot_ := calldataload(add(THREAD_PROOF_OFFSET, 166))
+            innerThreadRoot_ := calldataload(add(THREAD_PROOF_OFFSET, 298))
         }
         // verify we have enough calldata
-        require(s >= (THREAD_PROOF_OFFSET + 198), "insufficient calldata for thread witness"); // 166 + 32
+        require(s >= (THREAD_PROOF_OFFSET + 330), "insufficient calldata for thread witness"); // 298 + 32
     }
 }
@@ -0,0 +1,705 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.15;
+
+import { MIPSMemory } from "src/cannon/libraries/MIPSMemory.sol";
+import { MIPSState as st } from "src/cannon/libraries/MIPSState.sol";
+import { MIPSInstructions as ins } from "src/cannon/libraries/MIPSInstructions.sol";
+
+/// @title MIPSFPU
+/// @notice Emulates the floating-point coprocessor (COP1) instructions.
+///         Arithmetic is IEEE 754 with round-to-nearest-even, implemented in software on the bit patterns of
+///         singles and doubles. NaN results are canonicalized, and the FCSR flag and cause bits are not updated.
+///         Doubles occupy even/odd register pairs, with the low word in the even register.
+library MIPSFPU {
+    uint32 internal constant OP_COP1 = 0x11;
+    uint32 internal constant OP_LOAD_WORD_COP1 = 0x31;
+    uint32 internal constant OP_LOAD_DOUBLE_COP1 = 0x35;
+    uint32 internal constant OP_STORE_WORD_COP1 = 0x39;
+    uint32 internal constant OP_STORE_DOUBLE_COP1 = 0x3D;
+
+    // The fmt field of COP1 arithmetic instructions
+    uint32 internal constant FMT_S = 16;
+    uint32 internal constant FMT_D = 17;
+    uint32 internal constant FMT_W = 20;
+    uint32 internal constant FMT_L = 21;
+
+    /// @notice The read-only floating-point implementation register: S, D, W and L formats are implemented.
+    uint32 internal constant FIR = 0x00330000;
+
+    /// @notice The rounding mode and exception enable bits of the FCSR.
+    ///         Only round-to-nearest with all exceptions disabled is emulated.
+    uint32 internal constant FCSR_UNSUPPORTED_MASK = 0x00000F83;
+
+    // The default quiet NaNs of the legacy MIPS NaN encoding
+    uint256 internal constant CANONICAL_NAN_S = 0x7FBFFFFF;
+    uint256 internal constant CANONICAL_NAN_D = 0x7FF7FFFFFFFFFFFF;
+
+    // The results of invalid conversions to fixed point (NaN, infinity, out of range)
+    uint256 internal constant INVALID_W = 0x7FFFFFFF;
+    uint256 internal constant INVALID_L = 0x7FFFFFFFFFFFFFFF;
+
+    /// @notice Returns whether the opcode is a COP1 instruction, or a load or store of a floating-point register.
+    function isFpuInstruction(uint32 _opcode) internal pure returns (bool) {
+        return _opcode == OP_COP1 || _opcode == OP_LOAD_WORD_COP1 || _opcode == OP_LOAD_DOUBLE_COP1
+            || _opcode == OP_STORE_WORD_COP1 || _opcode == OP_STORE_DOUBLE_COP1;
+    }
+
+    /// @notice Execute a floating-point instruction.
+    /// @param _cpu The CPU scalar fields.
+    /// @param _registers The CPU registers.
+    /// @param _fpr The floating-point registers.
+    /// @param _fcsr The floating-point control and status register.
+    /// @param _memRoot The current merkle root of the memory.
+    /// @param _memProofOffset The offset in calldata specify where the memory merkle proof is located.
+    /// @param _insn The current 32-bit instruction at the pc.
+    /// @param _opcode The opcode value parsed from insn_.
+    /// @return newMemRoot_ The updated merkle root of memory after any modifications, may be unchanged.
+    /// @return fcsr_ The updated floating-point control and status register.
+    function execMipsFpuStepLogic(
+        st.CpuScalars memory _cpu,
+        uint32[32] memory _registers,
+        uint32[32] memory _fpr,
+        uint32 _fcsr,
+        bytes32 _memRoot,
+        uint256 _memProofOffset,
+        uint32 _insn,
+        uint32 _opcode
+    )
+        internal
+        pure
+        returns (bytes32 newMemRoot_, uint32 fcsr_)
+    {
+        unchecked {
+            newMemRoot_ = _memRoot;
+            fcsr_ = _fcsr;
+
+            if (_opcode != OP_COP1) {
+                newMemRoot_ = handleLoadStore(_cpu, _registers, _fpr, _memRoot, _memProofOffset, _insn, _opcode);
+                return (newMemRoot_, fcsr_);
+            }
+
+            fcsr_ = execCop1(_cpu, _registers, _fpr, _fcsr, _insn);
+        }
+    }
+
+    /// @notice Executes a COP1 instruction: a move, branch, arithmetic, conversion or comparison.
+    /// @return fcsr_ The updated floating-point control and status register.
+    function execCop1(
+        st.CpuScalars memory _cpu,
+        uint32[32] memory _registers,
+        uint32[32] memory _fpr,
+        uint32 _fcsr,
+        uint32 _insn
+    )
+        internal
+        pure
+        returns (uint32 fcsr_)
+    {
+        unchecked {
+            fcsr_ = _fcsr;
+            uint32 rs = (_insn >> 21) & 0x1F;
+            uint32 rt = (_insn >> 16) & 0x1F;
+            uint32 fs = (_insn >> 11) & 0x1F;
+
+            // mfc1
+            if (rs == 0) {
+                ins.handleRd(_cpu, _registers, rt, _fpr[fs], true);
+            }
+            // cfc1
+            else if (rs == 2 && (fs == 0 || fs == 31)) {
+                ins.handleRd(_cpu, _registers, rt, fs == 0 ? FIR : _fcsr, true);
+            }
+            // mtc1
+            else if (rs == 4) {
+                _fpr[fs] = _registers[rt];
+                ins.handleRd(_cpu, _registers, 0, 0, false);
+            }
+            // ctc1, the FIR is read-only
+            else if (rs == 6 && (fs == 0 || fs == 31)) {
+                if (fs == 31) {
+                    if (_registers[rt] & FCSR_UNSUPPORTED_MASK != 0) {
+                        revert("MIPS: unsupported fcsr value");
+                    }
+                    fcsr_ = _registers[rt];
+                }
+                ins.handleRd(_cpu, _registers, 0, 0, false);
+            }
+            // bc1f, bc1t, bc1fl, bc1tl
+            else if (rs == 8) {
+                handleBranch(_cpu, _fcsr, _insn);
+            }
+            // arithmetic, conversions and comparisons
+            else if (rs == FMT_S || rs == FMT_D || rs == FMT_W || rs == FMT_L) {
+                fcsr_ = execArithmetic(_fpr, _fcsr, _insn, rs);
+                ins.handleRd(_cpu, _registers, 0, 0, false);
+            } else {
+                revert("invalid instruction");
+            }
+        }
+    }
+
+    /// @notice Handles the loads and stores of floating-point registers.
+    function handleLoadStore(
+        st.CpuScalars memory _cpu,
+        uint32[32] memory _registers,
+        uint32[32] memory _fpr,
+        bytes32 _memRoot,
+        uint256 _memProofOffset,
+        uint32 _insn,
+        uint32 _opcode
+    )
+        internal
+        pure
+        returns (bytes32 newMemRoot_)
+    {
+        unchecked {
+            newMemRoot_ = _memRoot;
+            uint32 ft = (_insn >> 16) & 0x1F;
+            uint32 vaddr = _registers[(_insn >> 21) & 0x1F] + ins.signExtend(_insn & 0xFFFF, 16);
+            // doubles are 8-byte aligned, and thus always within a single memory proof leaf
+            uint32 daddr = vaddr & 0xFFFFFFF8;
+
+            // lwc1
+            if (_opcode == OP_LOAD_WORD_COP1) {
+                _fpr[ft] = MIPSMemory.readMem(_memRoot, vaddr & 0xFFFFFFFC, _memProofOffset);
+            }
+            // ldc1
+            else if (_opcode == OP_LOAD_DOUBLE_COP1) {
+                uint256 val = uint256(MIPSMemory.readMem(_memRoot, daddr, _memProofOffset)) << 32;
+                val |= MIPSMemory.readMem(_memRoot, daddr + 4, _memProofOffset);
+                writeFloat(_fpr, ft, val, true);
+            }
+            // swc1
+            else if (_opcode == OP_STORE_WORD_COP1) {
+                // the memory proof is validated by the read
+                MIPSMemory.readMem(_memRoot, vaddr & 0xFFFFFFFC, _memProofOffset);
+                newMemRoot_ = MIPSMemory.writeMem(vaddr & 0xFFFFFFFC, _memProofOffset, _fpr[ft]);
+            }
+            // sdc1
+            else {
+                uint64 val = uint64(readFloat(_fpr, ft, true));
+                MIPSMemory.readMem(_memRoot, daddr, _memProofOffset);
+                newMemRoot_ = MIPSMemory.writeMem64(daddr, _memProofOffset, val);
+            }
+
+            ins.handleRd(_cpu, _registers, 0, 0, false);
+        }
+    }
+
+    /// @notice Handles a branch on a floating-point condition code, updating the PC.
+    ///         The delay slot of a branch likely is nullified if the branch is not taken.
+    function handleBranch(st.CpuScalars memory _cpu, uint32 _fcsr, uint32 _insn) internal pure {
+        unchecked {
+            if (_cpu.nextPC != _cpu.pc + 4) {
+                revert("branch in delay slot");
+            }
+
+            bool shouldBranch = conditionCode(_fcsr, (_insn >> 18) & 7) == (((_insn >> 16) & 1) == 1);
+            uint32 prevPC = _cpu.pc;
+
+            if (shouldBranch) {
+                // Execute the delay slot first
+                _cpu.pc = _cpu.nextPC;
+                _cpu.nextPC = prevPC + 4 + (ins.signExtend(_insn & 0xFFFF, 16) << 2);
+            } else if (((_insn >> 17) & 1) == 1) {
+                _cpu.pc = _cpu.nextPC + 4;
+                _cpu.nextPC = _cpu.nextPC + 8;
+            } else {
+                _cpu.pc = _cpu.nextPC;
+                _cpu.nextPC = _cpu.nextPC + 4;
+            }
+        }
+    }
+
+    /// @notice Executes an arithmetic, conversion or comparison instruction of the format _fmt.
+    /// @return fcsr_ The updated FCSR, which holds the condition codes set by comparisons.
+    function execArithmetic(
+        uint32[32] memory _fpr,
+        uint32 _fcsr,
+        uint32 _insn,
+        uint32 _fmt
+    )
+        internal
+        pure
+        returns (uint32 fcsr_)
+    {
+        unchecked {
+            fcsr_ = _fcsr;
+            uint32 fun = _insn & 0x3F;
+            bool d = _fmt == FMT_D;
+
+            // c.cond
+            if (fun >= 0x30 && (_fmt == FMT_S || d)) {
+                bool cond =
+                    compare(readFloat(_fpr, (_insn >> 11) & 0x1F, d), readFloat(_fpr, (_insn >> 16) & 0x1F, d), d, fun);
+                return setConditionCode(_fcsr, (_insn >> 8) & 7, cond);
+            }
+
+            (uint256 val, bool dstD) = arithmeticResult(_fpr, _insn, _fmt);
+            writeFloat(_fpr, (_insn >> 6) & 0x1F, val, dstD);
+        }
+    }
+
+    /// @notice Computes the result of an arithmetic or conversion instruction of the format _fmt.
+    /// @return val_ The result.
+    /// @return d_ Whether the result is a double or long, rather than a single or word.
+    function arithmeticResult(
+        uint32[32] memory _fpr,
+        uint32 _insn,
+        uint32 _fmt
+    )
+        internal
+        pure
+        returns (uint256 val_, bool d_)
+    {
+        unchecked {
+            uint32 fun = _insn & 0x3F;
+            uint32 fs = (_insn >> 11) & 0x1F;
+            bool d = _fmt == FMT_D;
+
+            if (_fmt == FMT_S || d) {
+                // add, sub, mul, div, sqrt
+                if (fun <= 0x4) {
+                    return (arithmetic(fun, readFloat(_fpr, fs, d), readFloat(_fpr, (_insn >> 16) & 0x1F, d), d), d);
+                }
+                // abs, mov, neg
+                if (fun <= 0x7) {
+                    return (signOp(fun, readFloat(_fpr, fs, d), d), d);
+                }
+                // round.l, trunc.l, ceil.l, floor.l, round.w, trunc.w, ceil.w, floor.w
+                if (fun <= 0xF) {
+                    return (toInt(readFloat(_fpr, fs, d), d, fun & 3, fun < 0xC), fun < 0xC);
+                }
+                // cvt.w and cvt.l, with the round-to-nearest rounding mode
+                if (fun == 0x24 || fun == 0x25) {
+                    return (toInt(readFloat(_fpr, fs, d), d, 0, fun == 0x25), fun == 0x25);
+                }
+            }
+            // cvt.s
+            if (fun == 0x20 && _fmt != FMT_S) {
+                return (convert(_fpr, fs, _fmt, false), false);
+            }
+            // cvt.d
+            if (fun == 0x21 && _fmt != FMT_D) {
+                return (convert(_fpr, fs, _fmt, true), true);
+            }
+            revert("invalid instruction");
+        }
+    }
+
+    /// @notice Reads a single or word, or a double or long from an even/odd register pair.
+    function readFloat(uint32[32] memory _fpr, uint32 _r, bool _d) internal pure returns (uint256) {
+        unchecked {
+            if (!_d) {
+                return _fpr[_r];
+            }
+            if (_r & 1 != 0) {
+                revert("invalid instruction");
+            }
+            return uint256(_fpr[_r + 1]) << 32 | _fpr[_r];
+        }
+    }
+
+    /// @notice Writes a single or word, or a double or long to an even/odd register pair.
+    function writeFloat(uint32[32] memory _fpr, uint32 _r, uint256 _val, bool _d) internal pure {
+        unchecked {
+            if (!_d) {
+                _fpr[_r] = uint32(_val);
+                return;
+            }
+            if (_r & 1 != 0) {
+                revert("invalid instruction");
+            }
+            _fpr[_r] = uint32(_val);
+            _fpr[_r + 1] = uint32(_val >> 32);
+        }
+    }
+
+    /// @notice Executes abs, mov or neg, which only operate on the sign bit.
+    function signOp(uint32 _fun, uint256 _a, bool _d) internal pure returns (uint256) {
+        if (_fun == 0x5) {
+            return _a & ~signBit(_d);
+        } else if (_fun == 0x7) {
+            return _a ^ signBit(_d);
+        }
+        return _a;
+    }
+
+    /// @notice Converts the value in _fs of the format _fmt to a single or double.
+    function convert(uint32[32] memory _fpr, uint32 _fs, uint32 _fmt, bool _toD) internal pure returns (uint256) {
+        unchecked {
+            if (_fmt == FMT_W) {
+                return fromInt(int256(int32(_fpr[_fs])), _toD);
+            }
+            if (_fmt == FMT_L) {
+                return fromInt(int256(int64(uint64(readFloat(_fpr, _fs, true)))), _toD);
+            }
+            bool d = _fmt == FMT_D;
+            uint256 a = readFloat(_fpr, _fs, d);
+            if (isNaN(a, d)) {
+                return canonicalNaN(_toD);
+            }
+            uint256 sign = a & signBit(d) == 0 ? 0 : signBit(_toD);
+            if (isInf(a, d)) {
+                return sign | infinity(_toD);
+            }
+            if (isZero(a, d)) {
+                return sign;
+            }
+            (, uint256 m, int256 e) = unpack(a, d);
+            return roundPack(sign != 0, m, e, _toD);
+        }
+    }
+
+    /// @notice Executes add, sub, mul, div or sqrt on singles or doubles.
+    function arithmetic(uint32 _fun, uint256 _a, uint256 _b, bool _d) internal pure returns (uint256) {
+        if (_fun == 0x0) {
+            return add(_a, _b, _d);
+        } else if (_fun == 0x1) {
+            return add(_a, _b ^ signBit(_d), _d);
+        } else if (_fun == 0x2) {
+            return mul(_a, _b, _d);
+        } else if (_fun == 0x3) {
+            return div(_a, _b, _d);
+        }
+        return sqrt(_a, _d);
+    }
+
+    function add(uint256 _a, uint256 _b, bool _d) internal pure returns (uint256) {
+        unchecked {
+            if (isNaN(_a, _d) || isNaN(_b, _d)) {
+                return canonicalNaN(_d);
+            }
+            if (isInf(_a, _d)) {
+                // inf - inf
+                return isInf(_b, _d) && _a != _b ? canonicalNaN(_d) : _a;
+            }
+            if (isInf(_b, _d)) {
+                return _b;
+            }
+            // the sum of two zeros is -0 only if both are -0
+            if (isZero(_a, _d) && isZero(_b, _d)) {
+                return _a & _b;
+            }
+
+            // order the operands by magnitude, which orders them by exponent as well
+            if ((_a & ~signBit(_d)) < (_b & ~signBit(_d))) {
+                (_a, _b) = (_b, _a);
+            }
+            if (isZero(_b, _d)) {
+                return _a;
+            }
+
+            (bool sa, uint256 ma, int256 ea) = unpack(_a, _d);
+            (bool sb, uint256 mb, int256 eb) = unpack(_b, _d);
+            if (ea - eb > 128) {
+                // _b is far below the rounding position of _a, and only contributes as a sticky bit
+                ma <<= 128;
+                mb = 1;
+                eb = ea - 128;
+            } else {
+                ma <<= uint256(ea - eb);
+            }
+
+            if (sa == sb) {
+                return roundPack(sa, ma + mb, eb, _d);
+            }
+            // an exact cancellation is +0 in the round-to-nearest rounding mode
+            if (ma == mb) {
+                return 0;
+            }
+            return roundPack(sa, ma - mb, eb, _d);
+        }
+    }
+
+    function mul(uint256 _a, uint256 _b, bool _d) internal pure returns (uint256) {
+        unchecked {
+            if (isNaN(_a, _d) || isNaN(_b, _d)) {
+                return canonicalNaN(_d);
+            }
+            uint256 sign = (_a ^ _b) & signBit(_d);
+            if (isInf(_a, _d) || isInf(_b, _d)) {
+                // 0 * inf
+                if (isZero(_a, _d) || isZero(_b, _d)) {
+                    return canonicalNaN(_d);
+                }
+                return sign | infinity(_d);
+            }
+            if (isZero(_a, _d) || isZero(_b, _d)) {
+                return sign;
+            }
+
+            (, uint256 ma, int256 ea) = unpack(_a, _d);
+            (, uint256 mb, int256 eb) = unpack(_b, _d);
+            return roundPack(sign != 0, ma * mb, ea + eb, _d);
+        }
+    }
+
+    function div(uint256 _a, uint256 _b, bool _d) internal pure returns (uint256) {
+        unchecked {
+            if (isNaN(_a, _d) || isNaN(_b, _d)) {
+                return canonicalNaN(_d);
+            }
+            uint256 sign = (_a ^ _b) & signBit(_d);
+            if (isInf(_a, _d)) {
+                // inf / inf
+                return isInf(_b, _d) ? canonicalNaN(_d) : sign | infinity(_d);
+            }
+            if (isInf(_b, _d)) {
+                return sign;
+            }
+            if (isZero(_b, _d)) {
+                // 0 / 0
+                return isZero(_a, _d) ? canonicalNaN(_d) : sign | infinity(_d);
+            }
+            if (isZero(_a, _d)) {
+                return sign;
+            }
+
+            (, uint256 ma, int256 ea) = unpack(_a, _d);
+            (, uint256 mb, int256 eb) = unpack(_b, _d);
+            // scale the dividend so that the quotient has at least precision + 2 bits,
+            // and add a sticky bit below it if the division is inexact
+            uint256 k = _d ? 109 : 51;
+            uint256 q = (ma << k) / mb;
+            uint256 sticky = (ma << k) % mb == 0 ? 0 : 1;
+            return roundPack(sign != 0, q << 1 | sticky, ea - eb - int256(k) - 1, _d);
+        }
+    }
+
+    function sqrt(uint256 _a, bool _d) internal pure returns (uint256) {
+        unchecked {
+            if (isNaN(_a, _d)) {
+                return canonicalNaN(_d);
+            }
+            // sqrt(-0) = -0
+            if (isZero(_a, _d)) {
+                return _a;
+            }
+            if (_a & signBit(_d) != 0) {
+                return canonicalNaN(_d);
+            }
+            if (isInf(_a, _d)) {
+                return _a;
+            }
+
+            (, uint256 m, int256 e) = unpack(_a, _d);
+            // scale the significand so that its root has at least precision + 2 bits, and the exponent is even
+            uint256 k = (_d ? 110 : 52) + uint256(e & 1);
+            m <<= k;
+            e -= int256(k);
+            uint256 r = isqrt(m);
+            uint256 sticky = r * r == m ? 0 : 1;
+            return roundPack(false, r << 1 | sticky, e / 2 - 1, _d);
+        }
+    }
+
+    /// @notice Converts a signed integer to a single or double.
+    function fromInt(int256 _val, bool _d) internal pure returns (uint256) {
+        unchecked {
+            if (_val == 0) {
+                return 0;
+            }
+            return roundPack(_val < 0, uint256(_val < 0 ? -_val : _val), 0, _d);
+        }
+    }
+
+    /// @notice Converts a single or double to a 32-bit (word) or 64-bit (long) integer.
+    /// @param _mode The rounding mode: nearest-even, towards zero, up or down.
+    function toInt(uint256 _a, bool _d, uint32 _mode, bool _toL) internal pure returns (uint256) {
+        unchecked {
+            uint256 invalid = _toL ? INVALID_L : INVALID_W;
+            if (isNaN(_a, _d) || isInf(_a, _d)) {
+                return invalid;
+            }
+            if (isZero(_a, _d)) {
+                return 0;
+            }
+
+            (bool sign, uint256 m, int256 e) = unpack(_a, _d);
+            uint256 r;
+            if (e > 64) {
+                return invalid;
+            } else if (e >= 0) {
+                r = m << uint256(e);
+            } else {
+                // m is less than 2^53, so any shift of at least 128 truncates it the same way
+                r = roundShift(sign, m, e < -128 ? 128 : uint256(-e), _mode);
+            }
+
+            uint256 limit = _toL ? 1 << 63 : 1 << 31;
+            if (sign ? r > limit : r >= limit) {
+                return invalid;
+            }
+            // two's complement of the magnitude
+            uint256 mask = _toL ? 0xFFFFFFFFFFFFFFFF : 0xFFFFFFFF;
+            return sign ? (mask + 1 - r) & mask : r;
+        }
+    }
+
+    /// @notice Compares two singles or doubles with the condition _cond: bit 0 is unordered, bit 1 equal
+    ///         and bit 2 less than.
+    function compare(uint256 _a, uint256 _b, bool _d, uint32 _cond) internal pure returns (bool) {
+        unchecked {
+            if (isNaN(_a, _d) || isNaN(_b, _d)) {
+                return _cond & 1 != 0;
+            }
+            int256 x = orderedValue(_a, _d);
+            int256 y = orderedValue(_b, _d);
+            return (_cond & 2 != 0 && x == y) || (_cond & 4 != 0 && x < y);
+        }
+    }
+
+    /// @notice Maps a non-NaN single or double to an integer of the same order. Both zeros map to 0.
+    function orderedValue(uint256 _a, bool _d) internal pure returns (int256) {
+        unchecked {
+            int256 magnitude = int256(_a & ~signBit(_d));
+            return _a & signBit(_d) == 0 ? magnitude : -magnitude;
+        }
+    }
+
+    /// @notice Splits a finite single or double into its sign, and a significand and exponent such that
+    ///         the magnitude is m_ * 2^e_.
+    function unpack(uint256 _a, bool _d) internal pure returns (bool sign_, uint256 m_, int256 e_) {
+        unchecked {
+            uint256 fb = fractionBits(_d);
+            int256 bias = _d ? int256(1023) : int256(127);
+            uint256 exp = (_a >> fb) & (_d ? 0x7FF : 0xFF);
+            sign_ = _a & signBit(_d) != 0;
+            m_ = _a & ((1 << fb) - 1);
+            if (exp == 0) {
+                // subnormal
+                e_ = 1 - bias - int256(fb);
+            } else {
+                m_ |= 1 << fb;
+                e_ = int256(exp) - bias - int256(fb);
+            }
+        }
+    }
+
+    /// @notice Rounds the non-zero magnitude _m * 2^_e to the nearest single or double, ties to even.
+    ///         Magnitudes beyond the largest finite value round to infinity.
+    function roundPack(bool _sign, uint256 _m, int256 _e, bool _d) internal pure returns (uint256 out_) {
+        unchecked {
+            int256 fb = int256(fractionBits(_d));
+            int256 bias = _d ? int256(1023) : int256(127);
+            // the exponent of the most significant bit, which is limited to the minimum normal exponent
+            int256 top = _e + int256(bitLength(_m)) - 1;
+            if (top < 1 - bias) {
+                top = 1 - bias;
+            }
+            // the exponent of the least significant bit of the result
+            int256 lsb = top - fb;
+
+            uint256 r;
+            if (_e >= lsb) {
+                r = _m << uint256(_e - lsb);
+            } else {
+                // _m is less than 2^254, so any shift of at least 255 rounds it the same way
+                r = roundShift(false, _m, lsb - _e > 255 ? 255 : uint256(lsb - _e), 0);
+            }
+
+            // the leading bit of a normal significand adds to the exponent field,
+            // and a carry out of the significand while rounding increments it
+            out_ = (uint256(top + bias - 1) << uint256(fb)) + r;
+            if (out_ > infinity(_d)) {
+                out_ = infinity(_d);
+            }
+            if (_sign) {
+                out_ |= signBit(_d);
+            }
+        }
+    }
+
+    /// @notice Shifts the magnitude _m right by _s bits, rounding the discarded bits with the rounding mode:
+    ///         nearest-even, towards zero, up or down.
+    function roundShift(bool _sign, uint256 _m, uint256 _s, uint32 _mode) internal pure returns (uint256 r_) {
+        unchecked {
+            r_ = _m >> _s;
+            uint256 rem = _m - (r_ << _s);
+            if (rem == 0) {
+                return r_;
+            }
+            uint256 half = 1 << (_s - 1);
+            if (_mode == 0) {
+                if (rem > half || (rem == half && r_ & 1 == 1)) {
+                    r_ += 1;
+                }
+            } else if ((_mode == 2 && !_sign) || (_mode == 3 && _sign)) {
+                r_ += 1;
+            }
+        }
+    }
+
+    /// @notice Computes the integer square root, rounded down.
+    function isqrt(uint256 _x) internal pure returns (uint256 r_) {
+        unchecked {
+            // start from a power of two at least the root, and descend with Newton's method
+            r_ = 1 << ((bitLength(_x) + 1) / 2);
+            while (true) {
+                uint256 y = (r_ + _x / r_) >> 1;
+                if (y >= r_) {
+                    return r_;
+                }
+                r_ = y;
+            }
+        }
+    }
+
+    /// @notice Returns the number of bits needed to represent _x.
+    function bitLength(uint256 _x) internal pure returns (uint256 n_) {
+        unchecked {
+            for (uint256 i = 128; i > 0; i >>= 1) {
+                if (_x >> i != 0) {
+                    _x >>= i;
+                    n_ += i;
+                }
+            }
+            if (_x != 0) {
+                n_ += 1;
+            }
+        }
+    }
+
+    function fractionBits(bool _d) internal pure returns (uint256) {
+        return _d ? 52 : 23;
+    }
+
+    function signBit(bool _d) internal pure returns (uint256) {
+        return _d ? 1 << 63 : 1 << 31;
+    }
+
+    function infinity(bool _d) internal pure returns (uint256) {
+        return _d ? 0x7FF0000000000000 : 0x7F800000;
+    }
+
+    function canonicalNaN(bool _d) internal pure returns (uint256) {
+        return _d ? CANONICAL_NAN_D : CANONICAL_NAN_S;
+    }
+
+    function isNaN(uint256 _a, bool _d) internal pure returns (bool) {
+        return (_a & ~signBit(_d)) > infinity(_d);
+    }
+
+    function isInf(uint256 _a, bool _d) internal pure returns (bool) {
+        return (_a & ~signBit(_d)) == infinity(_d);
+    }
+
+    function isZero(uint256 _a, bool _d) internal pure returns (bool) {
+        return (_a & ~signBit(_d)) == 0;
+    }
+
+    /// @notice Returns the FCSR condition code bit _cc: FCC0 is bit 23, FCC1-7 are bits 25-31.
+    function conditionCode(uint32 _fcsr, uint32 _cc) internal pure returns (bool) {
+        return (_fcsr >> conditionCodeBit(_cc)) & 1 == 1;
+    }
+
+    function setConditionCode(uint32 _fcsr, uint32 _cc, bool _val) internal pure returns (uint32) {
+        uint32 bit = uint32(1) << conditionCodeBit(_cc);
+        return _val ? _fcsr | bit : _fcsr & ~bit;
+    }
+
+    function conditionCodeBit(uint32 _cc) internal pure returns (uint32) {
+        return _cc == 0 ? 23 : 24 + _cc;
+    }
+}
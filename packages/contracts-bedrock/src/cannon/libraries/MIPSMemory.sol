@@ -97,6 +97,53 @@ library MIPSMemory {
         }
     }
 
+    /// @notice Writes a 64-bit value to memory.
+    ///         This function first overwrites the part of the leaf.
+    ///         Then it recomputes the memory merkle root.
+    /// @param _addr The address to write to, which must be 8-byte aligned.
+    /// @param _proofOffset The offset of the memory proof in calldata.
+    /// @param _val The value to write.
+    /// @return newMemRoot_ The new memory root after modification
+    function writeMem64(uint32 _addr, uint256 _proofOffset, uint64 _val) internal pure returns (bytes32 newMemRoot_) {
+        unchecked {
+            validateMemoryProofAvailability(_proofOffset);
+            assembly {
+                // Validate the address alignement.
+                if and(_addr, 7) { revert(0, 0) }
+
+                // Load the leaf value.
+                let leaf := calldataload(_proofOffset)
+                let shamt := shl(3, sub(sub(32, 8), and(_addr, 31)))
+
+                // Mask out 8 bytes, and OR in the value
+                leaf := or(and(leaf, not(shl(shamt, 0xFFffFFffFFffFFff))), shl(shamt, _val))
+                _proofOffset := add(_proofOffset, 32)
+
+                // Convenience function to hash two nodes together in scratch space.
+                function hashPair(a, b) -> h {
+                    mstore(0, a)
+                    mstore(32, b)
+                    h := keccak256(0, 64)
+                }
+
+                // Start with the leaf node.
+                // Work back up by combining with siblings, to reconstruct the root.
+                let path := shr(5, _addr)
+                let node := leaf
+                for { let i := 0 } lt(i, 27) { i := add(i, 1) } {
+                    let sibling := calldataload(_proofOffset)
+                    _proofOffset := add(_proofOffset, 32)
+                    switch and(shr(i, path), 1)
+                    case 0 { node := hashPair(node, sibling) }
+                    case 1 { node := hashPair(sibling, node) }
+                }
+
+                newMemRoot_ := node
+            }
+            return newMemRoot_;
+        }
+    }
+
     /// @notice Computes the offset of a memory proof in the calldata.
     /// @param _proofDataOffset The offset of the set of all memory proof data within calldata (proof.offset)
     ///     Equal to the offset of the first memory proof (at _proofIndex 0).
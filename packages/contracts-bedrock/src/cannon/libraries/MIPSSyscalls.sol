@@ -61,6 +61,7 @@ library MIPSSyscalls {
     uint32 internal constant SYS_PIPE2 = 4328;
     uint32 internal constant SYS_EPOLLCTL = 4249;
     uint32 internal constant SYS_EPOLLPWAIT = 4313;
+    uint32 internal constant SYS_EVENTFD2 = 4325;
     uint32 internal constant SYS_GETRANDOM = 4353;
     uint32 internal constant SYS_UNAME = 4122;
     uint32 internal constant SYS_STAT64 = 4213;
@@ -84,12 +85,18 @@ library MIPSSyscalls {
     uint32 internal constant FD_HINT_WRITE = 4;
     uint32 internal constant FD_PREIMAGE_READ = 5;
     uint32 internal constant FD_PREIMAGE_WRITE = 6;
+    // The eventfd and the epoll instance of the Go runtime network poller
+    uint32 internal constant FD_EVENTFD = 100;
+    uint32 internal constant FD_EPOLL = 101;
+
+    uint32 internal constant EFD_NONBLOCK = 0x80;
 
     uint32 internal constant SYS_ERROR_SIGNAL = 0xFF_FF_FF_FF;
     uint32 internal constant EBADF = 0x9;
     uint32 internal constant EINVAL = 0x16;
     uint32 internal constant EAGAIN = 0xb;
     uint32 internal constant ETIMEDOUT = 0x91;
+    uint32 internal constant ENOSYS = 0x59;
 
     uint32 internal constant FUTEX_WAIT_PRIVATE = 128;
     uint32 internal constant FUTEX_WAKE_PRIVATE = 129;
@@ -359,6 +366,60 @@ library MIPSSyscalls {
         }
     }
 
+    /// @notice Returns whether the syscall is one of the syscalls of the Go runtime network poller, handled by
+    ///         handleNetpollSyscall: the epoll and eventfd syscalls, pipe2, and reads and writes of the eventfd.
+    /// @param _syscallNum The syscall number.
+    /// @param _a0 The first syscall argument, the file descriptor of reads and writes.
+    /// @return isNetpoll_ Whether handleNetpollSyscall handles the syscall.
+    function isNetpollSyscall(uint32 _syscallNum, uint32 _a0) internal pure returns (bool isNetpoll_) {
+        isNetpoll_ = _syscallNum == SYS_EPOLLCREATE1 || _syscallNum == SYS_EPOLLCTL || _syscallNum == SYS_EPOLLPWAIT
+            || _syscallNum == SYS_EVENTFD2 || _syscallNum == SYS_PIPE2
+            || ((_syscallNum == SYS_READ || _syscallNum == SYS_WRITE) && _a0 == FD_EVENTFD);
+    }
+
+    /// @notice Handles the syscalls of the Go runtime network poller. The epoll instance and the eventfd are dedicated
+    ///         file descriptors without any I/O: polling reports no events, and reads and writes of the eventfd fail
+    ///         with EAGAIN, as they would block. pipe2 is not supported.
+    /// @param _syscallNum The syscall number, see isNetpollSyscall.
+    /// @param _a0 The first syscall argument.
+    /// @param _a1 The second syscall argument.
+    /// @return v0_ The syscall result, or -1 on error.
+    /// @return v1_ An error number, or 0 if there is no error.
+    function handleNetpollSyscall(
+        uint32 _syscallNum,
+        uint32 _a0,
+        uint32 _a1
+    )
+        internal
+        pure
+        returns (uint32 v0_, uint32 v1_)
+    {
+        if (_syscallNum == SYS_EPOLLCREATE1) {
+            v0_ = FD_EPOLL;
+        } else if (_syscallNum == SYS_EPOLLCTL || _syscallNum == SYS_EPOLLPWAIT) {
+            // args: _a0 = epoll fd. No events are ever reported.
+            if (_a0 != FD_EPOLL) {
+                v0_ = SYS_ERROR_SIGNAL;
+                v1_ = EBADF;
+            }
+        } else if (_syscallNum == SYS_EVENTFD2) {
+            // args: _a0 = initial value, _a1 = flags. Only non-blocking eventfds are supported.
+            if ((_a1 & EFD_NONBLOCK) == 0) {
+                v0_ = SYS_ERROR_SIGNAL;
+                v1_ = EINVAL;
+            } else {
+                v0_ = FD_EVENTFD;
+            }
+        } else if (_syscallNum == SYS_PIPE2) {
+            v0_ = SYS_ERROR_SIGNAL;
+            v1_ = ENOSYS;
+        } else {
+            // read or write of the eventfd
+            v0_ = SYS_ERROR_SIGNAL;
+            v1_ = EAGAIN;
+        }
+    }
+
     function handleSyscallUpdates(
         st.CpuScalars memory _cpu,
         uint32[32] memory _registers,